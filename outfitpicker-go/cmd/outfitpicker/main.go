@@ -0,0 +1,12 @@
+// Command outfitpicker picks outfits from a wardrobe directory in rotation.
+package main
+
+import (
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/cli"
+)
+
+func main() {
+	os.Exit(cli.New().Run(os.Args[1:]))
+}
@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// BackupResult reports a new backup and the old backups removed to make room
+// for it.
+type BackupResult struct {
+	Backup  entities.Backup   `json:"backup"`
+	Removed []entities.Backup `json:"removed,omitempty"`
+}
+
+// RestoreResult reports a restored backup and the backup of the state it
+// replaced.
+type RestoreResult struct {
+	Restored entities.Backup `json:"restored"`
+	Previous entities.Backup `json:"previous"`
+}
+
+// BackupUseCase snapshots and restores the config and cache files.
+type BackupUseCase struct {
+	services Services
+}
+
+// NewBackupUseCase creates a new backup use case.
+func NewBackupUseCase(services Services) *BackupUseCase {
+	return &BackupUseCase{services: services}
+}
+
+// Create backs up the config and cache, then removes the oldest backups
+// beyond entities.MaxBackups.
+func (u *BackupUseCase) Create() (*BackupResult, error) {
+	paths, err := u.paths()
+	if err != nil {
+		return nil, err
+	}
+	backup, err := u.services.Backups.Create(u.services.now(), paths)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := u.rotate()
+	if err != nil {
+		return nil, err
+	}
+	return &BackupResult{Backup: backup, Removed: removed}, nil
+}
+
+// List returns the backups, oldest first.
+func (u *BackupUseCase) List() ([]entities.Backup, error) {
+	return u.services.Backups.List()
+}
+
+// Restore replaces the config and cache with the backup's copies. The
+// current files are backed up first so a restore can itself be undone.
+func (u *BackupUseCase) Restore(id string) (*RestoreResult, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	paths, err := u.paths()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.find(id); err != nil {
+		return nil, err
+	}
+
+	previous, err := u.services.Backups.Create(u.services.now(), paths)
+	if err != nil {
+		return nil, err
+	}
+	restored, err := u.services.Backups.Restore(id, paths)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.rotate(); err != nil {
+		return nil, err
+	}
+	return &RestoreResult{Restored: restored, Previous: previous}, nil
+}
+
+// find returns the backup with the given ID.
+func (u *BackupUseCase) find(id string) (entities.Backup, error) {
+	backups, err := u.services.Backups.List()
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	for _, backup := range backups {
+		if backup.ID == id {
+			return backup, nil
+		}
+	}
+	return entities.Backup{}, errors.NewInvalidInputError(fmt.Sprintf("no backup %q; run backup list to see them", id))
+}
+
+// rotate removes the oldest backups beyond entities.MaxBackups and returns
+// them.
+func (u *BackupUseCase) rotate() ([]entities.Backup, error) {
+	backups, err := u.services.Backups.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) <= entities.MaxBackups {
+		return nil, nil
+	}
+	removed := backups[:len(backups)-entities.MaxBackups]
+	for _, backup := range removed {
+		if err := u.services.Backups.Delete(backup.ID); err != nil {
+			return nil, err
+		}
+	}
+	return removed, nil
+}
+
+// paths returns the files a backup holds.
+func (u *BackupUseCase) paths() ([]string, error) {
+	configPath, err := u.services.Config.Path()
+	if err != nil {
+		return nil, err
+	}
+	cachePath, err := u.services.Cache.Path()
+	if err != nil {
+		return nil, err
+	}
+	return []string{configPath, cachePath}, nil
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestBackupUseCase_CreateRotates(t *testing.T) {
+	env := newTestEnv(t, nil)
+	useCase := NewBackupUseCase(env.services)
+
+	for range entities.MaxBackups {
+		if _, err := useCase.Create(); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	oldest := env.backups.Backups[0]
+	result, err := useCase.Create()
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !slices.Equal(result.Backup.Files, []string{"config.json", "cache.json"}) {
+		t.Errorf("backup files = %v", result.Backup.Files)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].ID != oldest.ID || len(env.backups.Backups) != entities.MaxBackups {
+		t.Errorf("Create() removed %+v, %d backups left; want the oldest removed", result.Removed, len(env.backups.Backups))
+	}
+}
+
+func TestBackupUseCase_Restore(t *testing.T) {
+	env := newTestEnv(t, nil)
+	useCase := NewBackupUseCase(env.services)
+	created, err := useCase.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.services.Now = func() time.Time { return testNow.Add(time.Hour) }
+	result, err := NewBackupUseCase(env.services).Restore(created.Backup.ID)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if result.Restored.ID != created.Backup.ID || !slices.Equal(env.backups.Restored, []string{created.Backup.ID}) {
+		t.Errorf("Restore() = %+v, restored %v", result, env.backups.Restored)
+	}
+	if result.Previous.ID == created.Backup.ID || len(env.backups.Backups) != 2 {
+		t.Errorf("previous state backup = %+v, want a new backup", result.Previous)
+	}
+}
+
+func TestBackupUseCase_RestoreErrors(t *testing.T) {
+	env := newTestEnv(t, nil)
+	useCase := NewBackupUseCase(env.services)
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Restore("20240101-000000"); !errors.As(err, &invalid) {
+		t.Errorf("Restore() of an unknown backup error = %v, want InvalidInputError", err)
+	}
+	if len(env.backups.Backups) != 0 {
+		t.Error("a failed restore backed up the current state")
+	}
+	env.maintenance.State.Enabled = true
+	if _, err := useCase.Restore("20240101-000000"); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Restore() in maintenance mode error = %v, want ErrMaintenanceMode", err)
+	}
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// CategoryExclusionUseCase leaves categories out of picks and listings,
+// for good or for a while.
+type CategoryExclusionUseCase struct {
+	services Services
+}
+
+// NewCategoryExclusionUseCase creates a new category exclusion use case.
+func NewCategoryExclusionUseCase(services Services) *CategoryExclusionUseCase {
+	return &CategoryExclusionUseCase{services: services}
+}
+
+// Exclude leaves the named category out until it is included again or,
+// when period is positive, until period has passed. A temporary exclusion
+// replaces an earlier one; a category excluded for good stays so. It
+// returns when the exclusion ends, zero when it does not.
+func (u *CategoryExclusionUseCase) Exclude(categoryName string, period time.Duration) (time.Time, error) {
+	if err := logic.ValidateCategoryName(categoryName); err != nil {
+		return time.Time{}, err
+	}
+	var until time.Time
+	if period > 0 {
+		until = u.services.now().Add(period)
+	}
+	err := u.update(func(config *entities.Config) error {
+		if config.ExcludedCategories[categoryName] {
+			if until.IsZero() {
+				return nil
+			}
+			return errors.NewInvalidInputError(fmt.Sprintf("%s is already excluded until it is included again", categoryName))
+		}
+		if until.IsZero() {
+			delete(config.ExcludedUntil, categoryName)
+			if config.ExcludedCategories == nil {
+				config.ExcludedCategories = make(map[string]bool)
+			}
+			config.ExcludedCategories[categoryName] = true
+			return nil
+		}
+		if config.ExcludedUntil == nil {
+			config.ExcludedUntil = make(map[string]time.Time)
+		}
+		config.ExcludedUntil[categoryName] = until
+		return nil
+	})
+	return until, err
+}
+
+// Include ends the exclusion of the named category, temporary or not.
+// Categories that no longer exist can be included.
+func (u *CategoryExclusionUseCase) Include(categoryName string) error {
+	return u.update(func(config *entities.Config) error {
+		_, temporary := config.ExcludedUntil[categoryName]
+		if !config.ExcludedCategories[categoryName] && !temporary {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s is not excluded", categoryName))
+		}
+		delete(config.ExcludedCategories, categoryName)
+		delete(config.ExcludedUntil, categoryName)
+		if len(config.ExcludedUntil) == 0 {
+			config.ExcludedUntil = nil
+		}
+		return nil
+	})
+}
+
+// List returns the categories excluded now, by name. It only reads, so it
+// also works in maintenance mode.
+func (u *CategoryExclusionUseCase) List() ([]entities.CategoryExclusion, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	now := u.services.now()
+	var exclusions []entities.CategoryExclusion
+	excluded := config.ExcludedAt(now)
+	for _, category := range slices.Sorted(maps.Keys(excluded)) {
+		if !excluded[category] {
+			continue
+		}
+		exclusion := entities.CategoryExclusion{Category: category}
+		if !config.ExcludedCategories[category] {
+			exclusion.Until = config.ExcludedUntil[category]
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	return exclusions, nil
+}
+
+// update applies change to the configuration and saves it, reapplying
+// change if another writer saved first. Temporary exclusions that have
+// ended are dropped on the way.
+func (u *CategoryExclusionUseCase) update(change func(config *entities.Config) error) error {
+	return retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		config.DropExpiredExclusions(u.services.now())
+		if err := change(config); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestCategoryExclusionUseCase_TemporaryExclusionEnds(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "office": {"suit.avatar"}})
+	until, err := NewCategoryExclusionUseCase(env.services).Exclude("office", 14*24*time.Hour)
+	if err != nil || !until.Equal(testNow.AddDate(0, 0, 14)) {
+		t.Fatalf("Exclude() = %v, %v; want two weeks from now", until, err)
+	}
+
+	if state := categoryState(t, env.services, "office"); state != entities.CategoryStateUserExcluded {
+		t.Errorf("office is %s while excluded, want %s", state, entities.CategoryStateUserExcluded)
+	}
+	exclusions, err := NewCategoryExclusionUseCase(env.services).List()
+	if want := (entities.CategoryExclusion{Category: "office", Until: until}); err != nil || len(exclusions) != 1 || exclusions[0] != want {
+		t.Errorf("List() = %+v, %v; want %+v", exclusions, err, want)
+	}
+
+	env.services.Now = func() time.Time { return until }
+	if state := categoryState(t, env.services, "office"); state != entities.CategoryStateHasOutfits {
+		t.Errorf("office is %s after the exclusion ended, want %s", state, entities.CategoryStateHasOutfits)
+	}
+	if exclusions, _ := NewCategoryExclusionUseCase(env.services).List(); len(exclusions) != 0 {
+		t.Errorf("List() after the exclusion ended = %+v, want none", exclusions)
+	}
+}
+
+func TestCategoryExclusionUseCase_ExcludeAndInclude(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "formal": {"suit.avatar"}})
+	exclusions := NewCategoryExclusionUseCase(env.services)
+
+	if until, err := exclusions.Exclude("formal", 0); err != nil || !until.IsZero() {
+		t.Fatalf("Exclude() for good = %v, %v", until, err)
+	}
+	if !env.config.Config.ExcludedCategories["formal"] {
+		t.Errorf("ExcludedCategories = %v, want formal", env.config.Config.ExcludedCategories)
+	}
+	if _, err := exclusions.Exclude("formal", time.Hour); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Exclude() for a while of a category excluded for good error = %v, want InvalidInputError", err)
+	}
+
+	if err := exclusions.Include("formal"); err != nil {
+		t.Fatalf("Include() error = %v", err)
+	}
+	if err := exclusions.Include("formal"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Include() of an included category error = %v, want InvalidInputError", err)
+	}
+	if _, err := exclusions.Exclude("", 0); err == nil {
+		t.Error("Exclude() accepted an empty category name")
+	}
+}
+
+func categoryState(t *testing.T, services Services, category string) entities.CategoryState {
+	t.Helper()
+	infos, err := NewGetCategoriesUseCase(services).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, info := range infos {
+		if info.Category.Name == category {
+			return info.State
+		}
+	}
+	t.Fatalf("no category %s", category)
+	return ""
+}
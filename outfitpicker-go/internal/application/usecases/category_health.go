@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// CategoryHealthUseCase scores how well each category is being used.
+type CategoryHealthUseCase struct {
+	services Services
+}
+
+// NewCategoryHealthUseCase creates a new category health use case.
+func NewCategoryHealthUseCase(services Services) *CategoryHealthUseCase {
+	return &CategoryHealthUseCase{services: services}
+}
+
+// Execute scores every category that has outfits and is not excluded,
+// keyed by category name, using the configured thresholds.
+func (u *CategoryHealthUseCase) Execute() (map[string]logic.CategoryHealth, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	return u.services.categoryHealth(config, infos)
+}
+
+// categoryHealth scores the categories among infos that have outfits.
+func (s Services) categoryHealth(config *entities.Config, infos []entities.CategoryInfo) (map[string]logic.CategoryHealth, error) {
+	cache, err := s.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	log, err := s.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	thresholds := config.Health.WithDefaults()
+	recentSince := now.AddDate(0, 0, -thresholds.TargetRotationDays)
+	health := make(map[string]logic.CategoryHealth)
+	for _, info := range infos {
+		if info.State != entities.CategoryStateHasOutfits {
+			continue
+		}
+		activity := categoryActivity(info.Category.Name, log, cache.Categories[info.Category.Name], recentSince)
+		activity.Outfits = info.OutfitCount
+		health[info.Category.Name] = logic.CategoryHealthScore(activity, thresholds, now)
+	}
+	return health, nil
+}
+
+// categoryActivity derives a category's use from its wear events, falling
+// back to the rotation state for wears recorded before the wear log existed.
+func categoryActivity(category string, log entities.WearLog, state entities.CategoryCache, recentSince time.Time) logic.CategoryActivity {
+	activity := logic.CategoryActivity{WornInRotation: len(state.WornOutfits)}
+	var rotationStarted time.Time
+	for _, event := range log.Events {
+		if event.Category != category {
+			continue
+		}
+		if event.WornAt.After(activity.LastWorn) {
+			activity.LastWorn = event.WornAt
+		}
+		if !event.WornAt.Before(recentSince) {
+			activity.RecentWears++
+		}
+		switch {
+		case event.CompletedRotation:
+			rotationStarted = time.Time{}
+		case rotationStarted.IsZero():
+			rotationStarted = event.WornAt
+		}
+	}
+	if activity.WornInRotation > 0 {
+		activity.RotationStarted = rotationStarted
+		if activity.LastWorn.IsZero() {
+			activity.LastWorn = state.LastUpdated
+		}
+	}
+	return activity
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func TestCategoryHealthUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"},
+		"work":   {"suit.avatar", "blazer.avatar"},
+		"formal": {"tux.avatar"},
+		"beach":  nil,
+	})
+	env.config.Config.ExcludedCategories = map[string]bool{"formal": true}
+	useCase := NewWearOutfitUseCase(env.services)
+	for _, file := range []string{"tee.avatar", "jeans.avatar"} {
+		if err := useCase.Execute(env.outfit("casual", file)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	health, err := NewCategoryHealthUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(health) != 2 {
+		t.Fatalf("scored %d categories, want casual and work: %+v", len(health), health)
+	}
+	if casual := health["casual"]; casual.Status != logic.HealthHealthy || casual.Components.Laundry >= 0.5 {
+		t.Errorf("casual health = %+v", casual)
+	}
+	if work := health["work"]; work.Status != logic.HealthWarning || work.Reasons[0] != "never worn" {
+		t.Errorf("work health = %+v", work)
+	}
+}
+
+func TestCategoryHealthUseCase_UsesConfiguredThresholds(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"work": {"suit.avatar", "blazer.avatar"}})
+	env.config.Config.Health = entities.HealthThresholds{HealthyScore: 50, CriticalScore: 45}
+
+	health, err := NewCategoryHealthUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := health["work"]; got.Score != 45 || got.Status != logic.HealthWarning {
+		t.Errorf("work health = %+v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package usecases
+
+import "github.com/dh85/outfitpicker/internal/domain/entities"
+
+// CategoryOrderUseCase reads and changes the order categories are listed in.
+type CategoryOrderUseCase struct {
+	services Services
+}
+
+// NewCategoryOrderUseCase creates a new category order use case.
+func NewCategoryOrderUseCase(services Services) *CategoryOrderUseCase {
+	return &CategoryOrderUseCase{services: services}
+}
+
+// Current returns the configured category order.
+func (u *CategoryOrderUseCase) Current() (entities.CategoryOrder, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.CategoryOrder{}, err
+	}
+	return config.Order, nil
+}
+
+// Set replaces the category order and saves the configuration, retrying if
+// another writer saved first.
+func (u *CategoryOrderUseCase) Set(order entities.CategoryOrder) error {
+	return retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetOrder(order); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+}
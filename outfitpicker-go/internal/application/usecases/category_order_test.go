@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestCategoryOrderUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar"},
+		"work":   {"suit.avatar", "blazer.avatar"},
+		"beach":  {"shorts.avatar"},
+	})
+	env.cache.Cache = entities.NewOutfitCache().
+		Updating("work", entities.NewCategoryCache(2).Adding("suit.avatar")).
+		Updating("casual", entities.NewCategoryCache(2))
+	useCase := NewCategoryOrderUseCase(env.services)
+	categories := NewGetCategoriesUseCase(env.services)
+	names := func() []string {
+		t.Helper()
+		infos, err := categories.Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, info := range infos {
+			names = append(names, info.Category.Name)
+		}
+		return names
+	}
+
+	if got := names(); !slices.Equal(got, []string{"beach", "casual", "work"}) {
+		t.Errorf("default order = %v, want by name", got)
+	}
+
+	if err := useCase.Set(entities.CategoryOrder{Sort: entities.CategorySortManual, Manual: []string{"work", "beach"}}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if got := names(); !slices.Equal(got, []string{"work", "beach", "casual"}) {
+		t.Errorf("manual order = %v", got)
+	}
+
+	if err := useCase.Set(entities.CategoryOrder{Sort: entities.CategorySortProgress}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	progress, err := categories.Progress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 3 || progress[0].Category.Name != "work" || progress[1].Category.Name != "casual" {
+		t.Errorf("Progress() in progress order = %+v, want work first and the untracked beach last", progress)
+	}
+	if current, _ := useCase.Current(); current.Sort != entities.CategorySortProgress {
+		t.Errorf("Current() = %+v", current)
+	}
+
+	if err := useCase.Set(entities.CategoryOrder{Sort: "size"}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Set() with an unknown sort error = %v, want ErrInvalidConfiguration", err)
+	}
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// MaxChallengeDays caps how long a capsule challenge can last.
+const MaxChallengeDays = 365
+
+// ChallengeUseCase starts, reports on and ends capsule challenges.
+type ChallengeUseCase struct {
+	services Services
+}
+
+// NewChallengeUseCase creates a new challenge use case.
+func NewChallengeUseCase(services Services) *ChallengeUseCase {
+	return &ChallengeUseCase{services: services}
+}
+
+// Start begins a challenge of days days worn from the outfits given as
+// category/file, replacing the last challenge once it is over. Every
+// outfit must be in the wardrobe, and only one challenge runs at a time.
+func (u *ChallengeUseCase) Start(outfits []string, days int) (logic.ChallengeReport, error) {
+	if days < 1 || days > MaxChallengeDays {
+		return logic.ChallengeReport{}, errors.NewInvalidInputError(fmt.Sprintf("a challenge lasts 1 to %d days, got %d", MaxChallengeDays, days))
+	}
+	if len(outfits) == 0 {
+		return logic.ChallengeReport{}, errors.NewInvalidInputError("a capsule needs at least one outfit")
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return logic.ChallengeReport{}, err
+	}
+	capsule, err := u.capsule(outfits)
+	if err != nil {
+		return logic.ChallengeReport{}, err
+	}
+
+	now := u.services.now()
+	var started entities.Challenge
+	err = retryOnConflict(func() error {
+		current, err := u.services.Challenge.Load()
+		if err != nil {
+			return err
+		}
+		if current.ActiveAt(now) {
+			return errors.NewInvalidInputError(fmt.Sprintf("a capsule challenge is already running until %s; end it first", current.EndsAt.Format("2006-01-02")))
+		}
+		started = entities.Challenge{
+			Capsule:   capsule,
+			StartedAt: now,
+			EndsAt:    now.AddDate(0, 0, days),
+			Revision:  current.Revision,
+		}
+		return u.services.Challenge.Save(started)
+	})
+	if err != nil {
+		return logic.ChallengeReport{}, err
+	}
+	return logic.SummarizeChallenge(started, now), nil
+}
+
+// Report sums up the running challenge, or the last one once it is over.
+// It only reads, so it also works in maintenance mode.
+func (u *ChallengeUseCase) Report() (logic.ChallengeReport, error) {
+	challenge, err := u.services.Challenge.Load()
+	if err != nil {
+		return logic.ChallengeReport{}, err
+	}
+	if challenge.IsZero() {
+		return logic.ChallengeReport{}, errors.NewInvalidInputError("no capsule challenge has been started")
+	}
+	return logic.SummarizeChallenge(challenge, u.services.now()), nil
+}
+
+// End stops the running challenge before its last day and returns its
+// completion report.
+func (u *ChallengeUseCase) End() (logic.ChallengeReport, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return logic.ChallengeReport{}, err
+	}
+	now := u.services.now()
+	var ended entities.Challenge
+	err := retryOnConflict(func() error {
+		challenge, err := u.services.Challenge.Load()
+		if err != nil {
+			return err
+		}
+		if !challenge.ActiveAt(now) {
+			return errors.NewInvalidInputError("no capsule challenge is running")
+		}
+		ended = challenge.Ending(now)
+		return u.services.Challenge.Save(ended)
+	})
+	if err != nil {
+		return logic.ChallengeReport{}, err
+	}
+	return logic.SummarizeChallenge(ended, now), nil
+}
+
+// capsule resolves outfits given as category/file to their locations,
+// dropping duplicates.
+func (u *ChallengeUseCase) capsule(outfits []string) ([]entities.OutfitLocation, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	scanned := make(map[string][]entities.FileEntry)
+	var capsule []entities.OutfitLocation
+	for _, outfit := range outfits {
+		categoryName, fileName, ok := strings.Cut(outfit, "/")
+		if !ok || categoryName == "" || fileName == "" {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("%q is not an outfit; give it as category/file", outfit))
+		}
+		files, ok := scanned[categoryName]
+		if !ok {
+			if err := logic.ValidateCategoryName(categoryName); err != nil {
+				return nil, err
+			}
+			category, err := u.services.categoryReference(config, categoryName)
+			if err != nil {
+				return nil, err
+			}
+			if files, err = u.services.outfitsIn(config, category); err != nil {
+				return nil, err
+			}
+			scanned[categoryName] = files
+		}
+		if !containsFile(files, fileName) {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("%s is not an outfit in the wardrobe", outfit))
+		}
+		location := entities.OutfitLocation{Category: categoryName, FileName: fileName}
+		if !slices.Contains(capsule, location) {
+			capsule = append(capsule, location)
+		}
+	}
+	return capsule, nil
+}
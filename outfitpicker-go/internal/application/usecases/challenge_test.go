@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestChallengeUseCase_StartReportEnd(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"shirt.avatar"}})
+	challenges := NewChallengeUseCase(env.services)
+
+	report, err := challenges.Start([]string{"casual/tee.avatar", "work/shirt.avatar", "casual/tee.avatar"}, 30)
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if report.Days != 30 || report.Day != 1 || len(report.Capsule) != 2 || report.Finished {
+		t.Errorf("Start() = %+v, want day 1 of 30 with two outfits", report)
+	}
+	if !env.challenge.Challenge.EndsAt.Equal(testNow.AddDate(0, 0, 30)) {
+		t.Errorf("EndsAt = %v, want 30 days on", env.challenge.Challenge.EndsAt)
+	}
+	if _, err := challenges.Start([]string{"casual/jeans.avatar"}, 7); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Start() while running error = %v, want InvalidInputError", err)
+	}
+
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	if report, err = challenges.Report(); err != nil || report.Wears != 1 || report.WornOutfits != 1 {
+		t.Errorf("Report() = %+v, %v; want one wear", report, err)
+	}
+
+	report, err = challenges.End()
+	if err != nil || !report.Finished || !report.EndedEarly {
+		t.Fatalf("End() = %+v, %v; want ended early", report, err)
+	}
+	if _, err := challenges.End(); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("End() without a challenge error = %v, want InvalidInputError", err)
+	}
+	if _, err := challenges.Start([]string{"casual/jeans.avatar"}, 7); err != nil {
+		t.Errorf("Start() after the last challenge ended error = %v", err)
+	}
+}
+
+func TestChallengeUseCase_StartRejects(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name    string
+		outfits []string
+		days    int
+	}{
+		{"no days", []string{"casual/tee.avatar"}, 0},
+		{"too many days", []string{"casual/tee.avatar"}, MaxChallengeDays + 1},
+		{"empty capsule", nil, 7},
+		{"not category/file", []string{"tee.avatar"}, 7},
+		{"missing outfit", []string{"casual/jeans.avatar"}, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewChallengeUseCase(env.services).Start(tt.outfits, tt.days); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+				t.Errorf("Start() error = %v, want InvalidInputError", err)
+			}
+		})
+	}
+	if _, err := NewChallengeUseCase(env.services).Report(); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Report() without a challenge error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestChallenge_PicksOnlyFromCapsule(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "shorts.avatar"}, "work": {"shirt.avatar"}})
+	env.challenge.Challenge = entities.Challenge{
+		Capsule:   []entities.OutfitLocation{{Category: "casual", FileName: "tee.avatar"}},
+		StartedAt: testNow,
+		EndsAt:    testNow.AddDate(0, 0, 7),
+	}
+	// tee.avatar is already worn in the rotation, yet stays the only pick.
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("tee.avatar"))
+
+	for range 5 {
+		proposal, err := NewPickOutfitUseCase(env.services).Propose("casual")
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if proposal.Outfit.FileName != "tee.avatar" {
+			t.Fatalf("Propose() = %s, want the capsule's tee.avatar", proposal.Outfit.FileName)
+		}
+	}
+	if _, err := NewPickOutfitUseCase(env.services).Propose("work"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Propose() outside the capsule error = %v, want InvalidInputError", err)
+	}
+	pick, err := NewPickAnyOutfitUseCase(env.services).Execute()
+	if err != nil || pick.Outfit.FileName != "tee.avatar" {
+		t.Errorf("PickAny = %+v, %v; want tee.avatar", pick, err)
+	}
+
+	env.challenge.Challenge = env.challenge.Challenge.Ending(testNow)
+	if _, err := NewPickOutfitUseCase(env.services).Propose("work"); err != nil {
+		t.Errorf("Propose() after the challenge error = %v", err)
+	}
+}
+
+func TestChallenge_CountsWearsApartFromRotation(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "shorts.avatar"}})
+	env.challenge.Challenge = entities.Challenge{
+		Capsule:   []entities.OutfitLocation{{Category: "casual", FileName: "tee.avatar"}},
+		StartedAt: testNow,
+		EndsAt:    testNow.AddDate(0, 0, 7),
+	}
+	wear := NewWearOutfitUseCase(env.services)
+	for _, fileName := range []string{"tee.avatar", "tee.avatar", "jeans.avatar"} {
+		if err := wear.Execute(env.outfit("casual", fileName)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if wears := env.challenge.Challenge.Wears; len(wears) != 2 {
+		t.Errorf("challenge wears = %v, want tee.avatar twice", wears)
+	}
+	if events := env.wearLog.Log.Events; len(events) != 2 {
+		t.Errorf("wear log = %v, want the repeat wear kept out of it", events)
+	}
+
+	if _, err := NewUndoWearUseCase(env.services).UndoLast(); err != nil {
+		t.Fatal(err)
+	}
+	if env.challenge.Saves != 2 {
+		t.Errorf("challenge saves after undoing a wear outside the capsule = %d, want 2", env.challenge.Saves)
+	}
+	if _, err := NewUndoWearUseCase(env.services).UndoLast(); err != nil {
+		t.Fatal(err)
+	}
+	if wears := env.challenge.Challenge.Wears; len(wears) != 1 {
+		t.Errorf("challenge wears after undo = %v, want one", wears)
+	}
+}
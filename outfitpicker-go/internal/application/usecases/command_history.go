@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// CommandHistoryUseCase keeps the recent runs of the program, so they can
+// be listed and repeated.
+type CommandHistoryUseCase struct {
+	services Services
+}
+
+// NewCommandHistoryUseCase creates a new command history use case.
+func NewCommandHistoryUseCase(services Services) *CommandHistoryUseCase {
+	return &CommandHistoryUseCase{services: services}
+}
+
+// Record adds a run of command with args that exited with exitCode. The
+// history is not wardrobe state, so runs are also recorded in maintenance
+// mode.
+func (u *CommandHistoryUseCase) Record(command string, args []string, exitCode int) error {
+	record := entities.CommandRecord{
+		Command:  command,
+		Args:     slices.Clone(args),
+		RanAt:    u.services.now(),
+		ExitCode: exitCode,
+	}
+	return retryOnConflict(func() error {
+		history, err := u.services.Commands.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Commands.Save(history.Appending(record))
+	})
+}
+
+// List returns up to limit of the most recent runs, newest first; a limit
+// of zero or less returns them all.
+func (u *CommandHistoryUseCase) List(limit int) ([]entities.CommandRecord, error) {
+	history, err := u.services.Commands.Load()
+	if err != nil {
+		return nil, err
+	}
+	records := slices.Clone(history.Commands)
+	slices.Reverse(records)
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+// Last returns the most recent run of command, or an InvalidInputError
+// when it has not been run yet.
+func (u *CommandHistoryUseCase) Last(command string) (entities.CommandRecord, error) {
+	history, err := u.services.Commands.Load()
+	if err != nil {
+		return entities.CommandRecord{}, err
+	}
+	record, ok := history.Last(command)
+	if !ok {
+		return entities.CommandRecord{}, errors.NewInvalidInputError(fmt.Sprintf("no %s command to repeat yet", command))
+	}
+	return record, nil
+}
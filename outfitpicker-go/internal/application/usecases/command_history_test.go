@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestCommandHistoryUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	env.maintenance.State.Enabled = true
+	history := NewCommandHistoryUseCase(env.services)
+
+	if _, err := history.Last("pick"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Last() of an empty history error = %v, want InvalidInputError", err)
+	}
+
+	runs := []struct {
+		command string
+		args    []string
+		code    int
+	}{
+		{"pick", []string{"pick", "casual"}, 0},
+		{"pick", []string{"--json", "pick", "casual", "--weather"}, 0},
+		{"list", []string{"list"}, 30},
+	}
+	for _, run := range runs {
+		if err := history.Record(run.command, run.args, run.code); err != nil {
+			t.Fatalf("Record(%v) error = %v", run.args, err)
+		}
+	}
+
+	last, err := history.Last("pick")
+	if err != nil || !slices.Equal(last.Args, runs[1].args) || !last.RanAt.Equal(testNow) {
+		t.Errorf("Last(pick) = %+v, %v; want the pick with --weather", last, err)
+	}
+
+	records, err := history.List(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Command != "list" || records[0].ExitCode != 30 || records[1].Command != "pick" {
+		t.Errorf("List(2) = %+v, want the list then the last pick", records)
+	}
+	if records, _ := history.List(0); len(records) != len(runs) {
+		t.Errorf("List(0) returned %d records, want %d", len(records), len(runs))
+	}
+}
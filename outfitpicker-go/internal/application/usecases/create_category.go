@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// CategoryCreateRequest describes a new category and what to seed it with.
+type CategoryCreateRequest struct {
+	Name string
+	// From names an existing category whose outfits are copied into the
+	// new one.
+	From string
+	// Pack is the path of an outfit pack, or another zip or tar archive,
+	// whose outfits are imported into the new one.
+	Pack string
+}
+
+// CategoryCreateResult reports a new category.
+type CategoryCreateResult struct {
+	Category string `json:"category"`
+	Path     string `json:"path"`
+	// Seeded lists the outfits copied or imported into the category.
+	Seeded []string `json:"seeded,omitempty"`
+	// Skipped lists the entries of the pack that were not outfits.
+	Skipped []entities.SkippedEntry `json:"skipped,omitempty"`
+}
+
+// CreateCategoryUseCase adds a category directory to the wardrobe, so a
+// category can be made without leaving the command line.
+type CreateCategoryUseCase struct {
+	services Services
+}
+
+// NewCreateCategoryUseCase creates a new create category use case.
+func NewCreateCategoryUseCase(services Services) *CreateCategoryUseCase {
+	return &CreateCategoryUseCase{services: services}
+}
+
+// Execute creates the category's directory in the primary root and records
+// it as known, seeding it from another category or a pack when the request
+// names one. A name already taken by a category of any root, in any case,
+// is refused.
+func (u *CreateCategoryUseCase) Execute(request CategoryCreateRequest) (*CategoryCreateResult, error) {
+	if err := logic.ValidateNewCategoryName(request.Name); err != nil {
+		return nil, err
+	}
+	if request.From != "" && request.Pack != "" {
+		return nil, errors.NewInvalidInputError("seed a category from another category or from a pack, not both")
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var source *entities.CategoryReference
+	for _, info := range infos {
+		if strings.EqualFold(info.Category.Name, request.Name) {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("category %q already exists", info.Category.Name))
+		}
+		if info.Category.Name == request.From {
+			source = &info.Category
+		}
+	}
+	var files []entities.FileEntry
+	if request.From != "" {
+		if source == nil {
+			return nil, errors.ErrCategoryNotFound
+		}
+		if files, err = u.services.outfitsIn(config, *source); err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("%s has no outfits to copy", request.From))
+		}
+	}
+
+	category := entities.NewCategoryReference(request.Name, filepath.Join(config.PrimaryRoot(), request.Name))
+	if err := u.services.Creator.Create(category.Path); err != nil {
+		return nil, err
+	}
+	result := &CategoryCreateResult{Category: category.Name, Path: category.Path}
+	for _, file := range files {
+		if err := u.services.Creator.Copy(filepath.Join(source.Path, file.FileName), category.Path); err != nil {
+			return nil, err
+		}
+		result.Seeded = append(result.Seeded, file.FileName)
+	}
+	if request.Pack != "" {
+		imported, err := u.services.Importer.Import(request.Pack, category.Path, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, outfit := range imported.Imported {
+			result.Seeded = append(result.Seeded, outfit.FileName)
+		}
+		result.Skipped = imported.Skipped
+	}
+
+	err = retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		knowCategory(config, category.Name)
+		for _, fileName := range result.Seeded {
+			knowFile(config, category.Name, fileName)
+		}
+		return u.services.Config.Save(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = u.services.Cache.UpdateCategory(category.Name, func(entities.CategoryCache, bool) (entities.CategoryCache, error) {
+		return entities.NewCategoryCache(len(result.Seeded)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// knowCategory records category as known, with no known files yet.
+func knowCategory(config *entities.Config, category string) {
+	if config.KnownCategories == nil {
+		config.KnownCategories = make(map[string]bool)
+	}
+	if config.KnownCategoryFiles == nil {
+		config.KnownCategoryFiles = make(map[string]map[string]bool)
+	}
+	config.KnownCategories[category] = true
+	if config.KnownCategoryFiles[category] == nil {
+		config.KnownCategoryFiles[category] = make(map[string]bool)
+	}
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestCreateCategoryUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	result, err := NewCreateCategoryUseCase(env.services).Execute(CategoryCreateRequest{Name: "date night"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Path != filepath.Join(env.root, "date night") || len(result.Seeded) != 0 {
+		t.Errorf("Execute() = %+v", result)
+	}
+	if info, err := os.Stat(result.Path); err != nil || !info.IsDir() {
+		t.Errorf("category directory was not created: %v", err)
+	}
+	if !env.config.Config.KnownCategories["date night"] {
+		t.Error("the category was not recorded as known")
+	}
+	if _, ok := env.cache.Cache.Categories["date night"]; !ok {
+		t.Error("the category was not added to the cache")
+	}
+}
+
+func TestCreateCategoryUseCase_Seeds(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	useCase := NewCreateCategoryUseCase(env.services)
+
+	copied, err := useCase.Execute(CategoryCreateRequest{Name: "weekend", From: "casual"})
+	if err != nil {
+		t.Fatalf("Execute() from a category error = %v", err)
+	}
+	slices.Sort(copied.Seeded)
+	if !slices.Equal(copied.Seeded, []string{"jeans.avatar", "tee.avatar"}) || env.cache.Cache.Categories["weekend"].TotalOutfits != 2 {
+		t.Errorf("Execute() from a category = %+v", copied)
+	}
+	if !env.config.Config.KnownCategoryFiles["weekend"]["tee.avatar"] {
+		t.Error("copied outfits were not recorded as known")
+	}
+
+	imported, err := useCase.Execute(CategoryCreateRequest{Name: "gym", Pack: writeTestZip(t, "gym/shorts.avatar", "gym/notes.txt")})
+	if err != nil {
+		t.Fatalf("Execute() from a pack error = %v", err)
+	}
+	if !slices.Equal(imported.Seeded, []string{"shorts.avatar"}) || len(imported.Skipped) != 1 {
+		t.Errorf("Execute() from a pack = %+v", imported)
+	}
+}
+
+func TestCreateCategoryUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "empty": nil})
+	useCase := NewCreateCategoryUseCase(env.services)
+	tests := []struct {
+		name    string
+		request CategoryCreateRequest
+		want    error
+	}{
+		{"existing name", CategoryCreateRequest{Name: "Casual"}, nil},
+		{"nested name", CategoryCreateRequest{Name: "work/suits"}, nil},
+		{"both seeds", CategoryCreateRequest{Name: "new", From: "casual", Pack: "pack.zip"}, nil},
+		{"empty source", CategoryCreateRequest{Name: "new", From: "empty"}, nil},
+		{"unknown source", CategoryCreateRequest{Name: "new", From: "nope"}, domainerrors.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Execute(tt.request)
+			var invalid *domainerrors.InvalidInputError
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.want)
+			} else if tt.want == nil && !errors.As(err, &invalid) {
+				t.Errorf("Execute() error = %v, want an InvalidInputError", err)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "new")); !os.IsNotExist(err) {
+		t.Error("a refused category was created")
+	}
+}
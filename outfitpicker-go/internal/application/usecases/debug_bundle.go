@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/buildinfo"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// DebugBundleRequest describes what to include in a debug bundle.
+type DebugBundleRequest struct {
+	Build buildinfo.Info
+	// LogFiles are JSON log files copied into the bundle with their records
+	// anonymized.
+	LogFiles []string
+}
+
+// DebugBundleManifest is written to manifest.json at the root of the bundle.
+type DebugBundleManifest struct {
+	CreatedAt time.Time      `json:"createdAt"`
+	Build     buildinfo.Info `json:"build"`
+	Files     []string       `json:"files"`
+	// Notes explains anything that could not be collected.
+	Notes []string `json:"notes,omitempty"`
+}
+
+type maintenanceSummary struct {
+	Enabled   bool      `json:"enabled"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// DebugBundleUseCase collects anonymized state for attaching to bug reports.
+type DebugBundleUseCase struct {
+	services Services
+}
+
+// NewDebugBundleUseCase creates a new debug bundle use case.
+func NewDebugBundleUseCase(services Services) *DebugBundleUseCase {
+	return &DebugBundleUseCase{services: services}
+}
+
+// Write writes a zip archive to w containing the redacted configuration, the
+// anonymized cache, history, wear log and requested logs and version
+// information. Names are hashed with a random salt that is not included in
+// the bundle.
+func (u *DebugBundleUseCase) Write(w io.Writer, request DebugBundleRequest) (*DebugBundleManifest, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	anonymizer := logic.NewAnonymizer(salt)
+	manifest := &DebugBundleManifest{CreatedAt: u.services.now(), Build: request.Build}
+	archive := zip.NewWriter(w)
+
+	add := func(name string, v any) error {
+		manifest.Files = append(manifest.Files, name)
+		return writeZipJSON(archive, name, v)
+	}
+	note := func(format string, args ...any) {
+		manifest.Notes = append(manifest.Notes, fmt.Sprintf(format, args...))
+	}
+
+	if config, err := u.services.Config.Load(); err != nil {
+		note("config.json: %v", err)
+	} else if err := add("config.json", anonymizer.Config(*config)); err != nil {
+		return nil, err
+	}
+
+	if cache, err := u.services.Cache.Load(); err != nil {
+		note("cache.json: %v", err)
+	} else if err := add("cache.json", anonymizer.Cache(cache)); err != nil {
+		return nil, err
+	}
+
+	if history, err := u.services.History.Load(); err != nil {
+		note("history.json: %v", err)
+	} else if err := add("history.json", anonymizer.History(history)); err != nil {
+		return nil, err
+	}
+
+	if log, err := u.services.WearLog.Load(); err != nil {
+		note("wearlog.json: %v", err)
+	} else if err := add("wearlog.json", anonymizer.WearLog(log)); err != nil {
+		return nil, err
+	}
+
+	if state, err := u.services.Maintenance.Load(); err != nil {
+		note("maintenance.json: %v", err)
+	} else if err := add("maintenance.json", maintenanceSummary{Enabled: state.Enabled, StartedAt: state.StartedAt}); err != nil {
+		return nil, err
+	}
+
+	for _, path := range request.LogFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			note("%s: %v", filepath.Base(path), err)
+			continue
+		}
+		name := "logs/" + filepath.Base(path)
+		manifest.Files = append(manifest.Files, name)
+		if err := writeZipFile(archive, name, anonymizeLog(data, anonymizer)); err != nil {
+			return nil, err
+		}
+	}
+	if len(request.LogFiles) == 0 {
+		note("no log files were available")
+	}
+
+	if err := writeZipJSON(archive, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// anonymizeLog runs each record of a JSON log file through anonymizer.
+// Lines that are not JSON records are replaced whole, as nothing tells which
+// part of them names the wardrobe.
+func anonymizeLog(data []byte, anonymizer *logic.Anonymizer) []byte {
+	var out bytes.Buffer
+	for line := range bytes.Lines(data) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal(line, &record); err != nil {
+			out.WriteString(logic.RedactedValue + "\n")
+			continue
+		}
+		encoded, err := json.Marshal(anonymizer.LogRecord(record))
+		if err != nil {
+			out.WriteString(logic.RedactedValue + "\n")
+			continue
+		}
+		out.Write(encoded)
+		out.WriteByte('\n')
+	}
+	return out.Bytes()
+}
+
+func writeZipJSON(archive *zip.Writer, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeZipFile(archive, name, data)
+}
+
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/buildinfo"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestDebugBundleUseCase_Write(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"red-dress.avatar"}})
+	env.config.Config.ExcludedCategories["secret-project"] = true
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(1).Adding("red-dress.avatar"))
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{{Category: "casual", FileName: "red-dress.avatar", SelectedAt: testNow}}}
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{{Category: "casual", FileName: "red-dress.avatar", WornAt: testNow, Note: "secret-project launch"}}}
+
+	logFile := filepath.Join(t.TempDir(), "outfitpicker.log")
+	if err := os.WriteFile(logFile, []byte(`{"time":"2024-05-01T09:00:00Z","level":"INFO","msg":"picked outfit","category":"casual","outfit":"red-dress.avatar","available":1}`+"\n"+
+		`{"level":"DEBUG","msg":"listed outfits","path":"`+env.root+`/casual","args":["secret-project"]}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := NewDebugBundleUseCase(env.services).Write(&buf, DebugBundleRequest{
+		Build:    buildinfo.Info{Version: "v1.0.0"},
+		LogFiles: []string{logFile},
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	files := readZip(t, buf.Bytes())
+	for _, name := range []string{"manifest.json", "config.json", "cache.json", "history.json", "wearlog.json", "maintenance.json", "logs/outfitpicker.log"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("bundle missing %s", name)
+		}
+	}
+	for name, content := range files {
+		for _, secret := range []string{env.root, "casual", "red-dress", "secret-project"} {
+			if strings.Contains(content, secret) {
+				t.Errorf("%s leaks %q:\n%s", name, secret, content)
+			}
+		}
+	}
+
+	if log := files["logs/outfitpicker.log"]; !strings.Contains(log, `"msg":"picked outfit"`) || !strings.Contains(log, `"available":1`) {
+		t.Errorf("logs/outfitpicker.log = %s, want the messages and counts kept", log)
+	}
+
+	var history entities.SelectionHistory
+	if err := json.Unmarshal([]byte(files["history.json"]), &history); err != nil || len(history.Records) != 1 {
+		t.Errorf("history.json = %s (%v), want the one pick", files["history.json"], err)
+	}
+	var wearLog entities.WearLog
+	if err := json.Unmarshal([]byte(files["wearlog.json"]), &wearLog); err != nil || len(wearLog.Events) != 1 {
+		t.Errorf("wearlog.json = %s (%v), want the one wear", files["wearlog.json"], err)
+	}
+
+	var written DebugBundleManifest
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &written); err != nil {
+		t.Fatalf("manifest.json: %v", err)
+	}
+	if written.Build.Version != "v1.0.0" || len(written.Files) != len(manifest.Files) {
+		t.Errorf("manifest = %+v", written)
+	}
+}
+
+func TestDebugBundleUseCase_MissingStateIsNoted(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Config = nil
+	env.cache.LoadErr = domainerrors.ErrCache
+
+	var buf bytes.Buffer
+	manifest, err := NewDebugBundleUseCase(env.services).Write(&buf, DebugBundleRequest{})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	notes := strings.Join(manifest.Notes, "\n")
+	for _, want := range []string{"config.json: configuration not found", "cache.json: cache error", "no log files"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("notes missing %q: %v", want, manifest.Notes)
+		}
+	}
+	if _, ok := readZip(t, buf.Bytes())["config.json"]; ok {
+		t.Error("bundle contains config.json despite missing configuration")
+	}
+}
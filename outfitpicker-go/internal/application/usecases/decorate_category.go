@@ -0,0 +1,35 @@
+package usecases
+
+import "github.com/dh85/outfitpicker/internal/domain/entities"
+
+// DecorateCategoryUseCase changes how a category is displayed.
+type DecorateCategoryUseCase struct {
+	services Services
+}
+
+// NewDecorateCategoryUseCase creates a new decorate category use case.
+func NewDecorateCategoryUseCase(services Services) *DecorateCategoryUseCase {
+	return &DecorateCategoryUseCase{services: services}
+}
+
+// Execute replaces the category's decoration with change(current) and saves
+// the configuration, reapplying change if another writer saved first. It
+// returns the saved configuration.
+func (u *DecorateCategoryUseCase) Execute(category string, change func(current entities.CategoryDecoration) entities.CategoryDecoration) (*entities.Config, error) {
+	var saved *entities.Config
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetDecoration(category, change(config.Decoration(category))); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config
+		return nil
+	})
+	return saved, err
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestDecorateCategoryUseCase_RetriesOnConflict(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Conflicts = maxConflictAttempts - 1
+
+	config, err := NewDecorateCategoryUseCase(env.services).Execute("casual", func(current entities.CategoryDecoration) entities.CategoryDecoration {
+		current.Color = "blue"
+		return current
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if config.Decoration("casual").Color != "blue" || env.config.Config.Decoration("casual").Color != "blue" {
+		t.Errorf("decoration not saved: %+v", env.config.Config.CategoryDecorations)
+	}
+}
+
+func TestDecorateCategoryUseCase_GivesUpAfterRepeatedConflicts(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Conflicts = maxConflictAttempts
+
+	_, err := NewDecorateCategoryUseCase(env.services).Execute("casual", func(current entities.CategoryDecoration) entities.CategoryDecoration {
+		current.Color = "blue"
+		return current
+	})
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Execute() error = %v, want ConflictError", err)
+	}
+}
+
+func TestDecorateCategoryUseCase_InvalidDecoration(t *testing.T) {
+	env := newTestEnv(t, nil)
+
+	_, err := NewDecorateCategoryUseCase(env.services).Execute("casual", func(entities.CategoryDecoration) entities.CategoryDecoration {
+		return entities.CategoryDecoration{Color: "chartreuse"}
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute() error = %v, want ErrInvalidConfiguration", err)
+	}
+	if env.config.Saves != 0 {
+		t.Errorf("Saves = %d, want 0", env.config.Saves)
+	}
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// DiskRepair is a repair of the rotation state from the wardrobe on disk,
+// planned but not yet applied.
+type DiskRepair struct {
+	// Categories lists what the repair changes in each category that
+	// differs from the disk, sorted by category.
+	Categories []entities.CategoryRepair `json:"categories"`
+
+	snapshot entities.WardrobeSnapshot
+}
+
+// IsEmpty reports whether the state already matches the disk.
+func (r *DiskRepair) IsEmpty() bool {
+	return len(r.Categories) == 0
+}
+
+// DiskRepairUseCase repairs the rotation state in bulk by treating the
+// wardrobe on disk as authoritative: outfit counts are rebuilt, worn entries
+// of missing files dropped and the known files of each category rewritten.
+type DiskRepairUseCase struct {
+	services Services
+}
+
+// NewDiskRepairUseCase creates a new disk repair use case.
+func NewDiskRepairUseCase(services Services) *DiskRepairUseCase {
+	return &DiskRepairUseCase{services: services}
+}
+
+// Plan scans every category, excluded ones included, and returns what a
+// repair would change without changing anything.
+func (u *DiskRepairUseCase) Plan() (*DiskRepair, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := make(entities.WardrobeSnapshot, len(infos))
+	for _, info := range infos {
+		files, err := u.services.outfitsIn(config, info.Category)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[info.Category.Name] = fileNames(files)
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &DiskRepair{
+		Categories: logic.PlanDiskRepair(cache, config.KnownCategoryFiles, snapshot),
+		snapshot:   snapshot,
+	}, nil
+}
+
+// Apply brings the cache and the known files in line with the disk as it
+// was when the repair was planned.
+func (u *DiskRepairUseCase) Apply(repair *DiskRepair) error {
+	if err := u.services.ensureWritable(); err != nil {
+		return err
+	}
+	if repair.IsEmpty() {
+		return nil
+	}
+	err := retryOnConflict(func() error {
+		cache, err := u.services.Cache.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Cache.Save(logic.ReconcileCache(cache, repair.snapshot))
+	})
+	if err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Config.Save(withKnownFiles(config, repair.snapshot))
+	})
+}
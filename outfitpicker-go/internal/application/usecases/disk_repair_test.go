@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestDiskRepairUseCase_PlanAndApply(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("tee.avatar").Adding("gone.avatar"))
+	env.config.Config.KnownCategoryFiles = map[string]map[string]bool{"casual": {"tee.avatar": true, "gone.avatar": true}}
+	useCase := NewDiskRepairUseCase(env.services)
+
+	repair, err := useCase.Plan()
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(repair.Categories) != 1 {
+		t.Fatalf("Plan() = %+v, want casual repaired", repair.Categories)
+	}
+	if got := repair.Categories[0]; got.TotalBefore != 3 || got.TotalAfter != 2 || len(got.DroppedWorn) != 1 || len(got.AddedKnown) != 1 || len(got.RemovedKnown) != 1 {
+		t.Errorf("Plan() = %+v", got)
+	}
+	if env.cache.Cache.Categories["casual"].TotalOutfits != 3 {
+		t.Error("Plan() changed the cache")
+	}
+
+	env.maintenance.State.Enabled = true
+	if err := useCase.Apply(repair); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Apply() in maintenance mode error = %v, want ErrMaintenanceMode", err)
+	}
+	env.maintenance.State.Enabled = false
+	if err := useCase.Apply(repair); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	casual := env.cache.Cache.Categories["casual"]
+	if casual.TotalOutfits != 2 || len(casual.WornOutfits) != 1 || !casual.WornOutfits["tee.avatar"] {
+		t.Errorf("cache after Apply() = %+v", casual)
+	}
+	if known := env.config.Config.KnownCategoryFiles["casual"]; len(known) != 2 || !known["jeans.avatar"] {
+		t.Errorf("known files after Apply() = %v", known)
+	}
+	if again, err := useCase.Plan(); err != nil || !again.IsEmpty() {
+		t.Errorf("Plan() after Apply() = %+v, %v, want nothing to repair", again, err)
+	}
+}
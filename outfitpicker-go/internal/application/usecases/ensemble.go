@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// maxEnsembleCandidates is how many outfits of each category an ensemble
+// pick considers, in the order its rotation would pick them.
+const maxEnsembleCandidates = 8
+
+// EnsemblePiece is the outfit picked for one slot of an ensemble.
+type EnsemblePiece struct {
+	Slot   string                   `json:"slot"`
+	Outfit entities.OutfitReference `json:"outfit"`
+}
+
+// EnsemblePick is one outfit picked for each slot, worn together.
+type EnsemblePick struct {
+	Pieces []EnsemblePiece `json:"pieces"`
+
+	proposals []*PickProposal
+}
+
+// Changes returns the changes committing the pick makes, slot by slot.
+func (p *EnsemblePick) Changes() []StateChange {
+	var changes []StateChange
+	for _, proposal := range p.proposals {
+		changes = append(changes, proposal.Changes()...)
+	}
+	return changes
+}
+
+// EnsembleUseCase picks an outfit for every slot of an ensemble, such as a
+// top, a bottom and shoes, that go together, and reads and changes which
+// categories fill which slot and what goes together.
+type EnsembleUseCase struct {
+	services Services
+}
+
+// NewEnsembleUseCase creates a new ensemble use case.
+func NewEnsembleUseCase(services Services) *EnsembleUseCase {
+	return &EnsembleUseCase{services: services}
+}
+
+// Slots returns the categories filling each slot, in the configured
+// category order, from the configuration and the categories' category.json.
+func (u *EnsembleUseCase) Slots() (map[string][]string, entities.EnsembleSettings, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, entities.EnsembleSettings{}, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, entities.EnsembleSettings{}, err
+	}
+	if err := u.services.sortCategories(config, infos); err != nil {
+		return nil, entities.EnsembleSettings{}, err
+	}
+	slots := make(map[string][]string)
+	for _, info := range infos {
+		if slot := config.Ensemble.SlotOf(info); slot != "" {
+			slots[slot] = append(slots[slot], info.Category.Name)
+		}
+	}
+	return slots, config.Ensemble, nil
+}
+
+// Update replaces the ensemble settings with change(current) and saves the
+// configuration, reapplying change if another writer saved first. It
+// returns the saved settings.
+func (u *EnsembleUseCase) Update(change func(current entities.EnsembleSettings) entities.EnsembleSettings) (entities.EnsembleSettings, error) {
+	var saved entities.EnsembleSettings
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetEnsemble(change(config.Ensemble)); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config.Ensemble
+		return nil
+	})
+	return saved, err
+}
+
+// Execute picks an ensemble and records the pick of each of its outfits.
+func (u *EnsembleUseCase) Execute(opts ...PickOption) (*EnsemblePick, error) {
+	pick, err := u.Propose(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Commit(pick); err != nil {
+		return nil, err
+	}
+	return pick, nil
+}
+
+// Commit records each outfit of an ensemble picked by Propose.
+func (u *EnsembleUseCase) Commit(pick *EnsemblePick) error {
+	picker := NewPickOutfitUseCase(u.services)
+	for _, proposal := range pick.proposals {
+		if err := picker.Commit(proposal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Propose picks an ensemble without saving anything: one outfit for each
+// slot, in slot name order, from the categories filling it. Each category
+// offers the outfits its rotation and the options would pick, and the
+// composition engine chooses the first set that breaks none of the
+// configured compatibility rules. An outfit's colors come from its
+// metadata, else its color file, else its file name. Categories that cannot be picked from
+// now are passed over like they are by picks across all categories.
+func (u *EnsembleUseCase) Propose(opts ...PickOption) (*EnsemblePick, error) {
+	var options pickOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := config.Ensemble.WithColorRules()
+	if err != nil {
+		return nil, err
+	}
+	slotCategories := make(map[string][]string)
+	paths := make(map[string]string)
+	for _, info := range infos {
+		slot := config.Ensemble.SlotOf(info)
+		if slot != "" && info.State == entities.CategoryStateHasOutfits && config.Permissions.CanPick(info.Category.Name) {
+			slotCategories[slot] = append(slotCategories[slot], info.Category.Name)
+			paths[info.Category.Name] = info.Category.Path
+		}
+	}
+	if len(slotCategories) == 0 {
+		return nil, domainerrors.NewInvalidInputError("no category with outfits fills an ensemble slot; assign one with: ensemble slot <category> <slot>")
+	}
+	index, err := u.services.taggedMetadata(config)
+	if err != nil {
+		return nil, err
+	}
+
+	source := randomSource(options.seed)
+	slots := slices.Sorted(maps.Keys(slotCategories))
+	candidates := make(map[string][]logic.Piece, len(slots))
+	// proposals are keyed by the pieces they were offered as.
+	proposals := make(map[string]*PickProposal)
+	for _, slot := range slots {
+		categories := slotCategories[slot]
+		source.Shuffle(len(categories), func(i, j int) { categories[i], categories[j] = categories[j], categories[i] })
+		for _, category := range categories {
+			offered, err := u.offer(category, opts)
+			if err != nil {
+				return nil, err
+			}
+			colorFiles, err := u.services.Scanner.ReadColorFiles(u.services.ctx(), paths[category])
+			if err != nil {
+				return nil, err
+			}
+			for _, proposal := range offered {
+				fileName := proposal.Outfit.FileName
+				metadata := index.Effective(category, fileName)
+				piece := logic.Piece{
+					Slot:     slot,
+					Category: category,
+					FileName: fileName,
+					Tags:     index.TagsOf(category, fileName),
+					Colors:   logic.OutfitColors(metadata.Colors, colorFiles[fileName], fileName),
+				}
+				candidates[slot] = append(candidates[slot], piece)
+				proposals[piece.String()] = proposal
+			}
+		}
+	}
+
+	pieces, err := logic.Compose(slots, candidates, settings)
+	if err != nil {
+		return nil, err
+	}
+	pick := &EnsemblePick{}
+	for _, piece := range pieces {
+		proposal := proposals[piece.String()]
+		pick.Pieces = append(pick.Pieces, EnsemblePiece{Slot: piece.Slot, Outfit: proposal.Outfit})
+		pick.proposals = append(pick.proposals, proposal)
+	}
+	return pick, nil
+}
+
+// offer returns up to maxEnsembleCandidates proposals from the category,
+// in the order its rotation picks them. A category nothing can be picked
+// from now offers none.
+func (u *EnsembleUseCase) offer(category string, opts []PickOption) ([]*PickProposal, error) {
+	picker := NewPickOutfitUseCase(u.services)
+	var offered []*PickProposal
+	var without []string
+	for len(offered) < maxEnsembleCandidates {
+		proposal, err := picker.Propose(category, append(slices.Clone(opts), WithoutOutfits(without...))...)
+		var invalid *domainerrors.InvalidInputError
+		var limited *domainerrors.RateLimitedError
+		if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) || errors.As(err, &invalid) || errors.As(err, &limited) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		offered = append(offered, proposal)
+		without = append(without, proposal.Outfit.FileName)
+	}
+	return offered, nil
+}
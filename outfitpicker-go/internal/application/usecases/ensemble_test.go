@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func newEnsembleEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, map[string][]string{
+		"shirts":   {"tee.avatar", "blouse.avatar"},
+		"trousers": {"chinos.avatar", "joggers.avatar"},
+		"casual":   {"hoodie.avatar"},
+	})
+	env.config.Config.Ensemble = entities.EnsembleSettings{}.
+		SettingSlot("shirts", "top").
+		SettingSlot("trousers", "bottom")
+	env.metadata.Index = env.metadata.Index.
+		Setting("shirts", "tee.avatar", entities.OutfitMetadata{Tags: []string{"sporty"}, Colors: []string{"red"}}).
+		Setting("shirts", "blouse.avatar", entities.OutfitMetadata{Tags: []string{"formal"}, Colors: []string{"white"}}).
+		Setting("trousers", "chinos.avatar", entities.OutfitMetadata{Tags: []string{"formal"}, Colors: []string{"navy"}}).
+		Setting("trousers", "joggers.avatar", entities.OutfitMetadata{Tags: []string{"sporty"}, Colors: []string{"grey"}})
+	return env
+}
+
+func TestEnsembleUseCase_Execute(t *testing.T) {
+	env := newEnsembleEnv(t)
+
+	pick, err := NewEnsembleUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(pick.Pieces) != 2 || pick.Pieces[0].Slot != "bottom" || pick.Pieces[0].Outfit.Category.Name != "trousers" ||
+		pick.Pieces[1].Slot != "top" || pick.Pieces[1].Outfit.Category.Name != "shirts" {
+		t.Errorf("Execute() = %+v, want a bottom from trousers and a top from shirts", pick.Pieces)
+	}
+	if len(env.history.History.Records) != 2 {
+		t.Errorf("history = %+v, want both pieces picked", env.history.History.Records)
+	}
+}
+
+func TestEnsembleUseCase_CompatibilityRules(t *testing.T) {
+	env := newEnsembleEnv(t)
+	useCase := NewEnsembleUseCase(env.services)
+	if _, err := useCase.Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		current.TagConflicts = entities.AddingRule(current.TagConflicts, "formal", "sporty")
+		current.ColorClashes = entities.AddingRule(current.ColorClashes, "red", "grey")
+		return current
+	}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	for seed := range uint64(10) {
+		pick, err := useCase.Propose(WithPickSeed(seed))
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if got := pick.Pieces[0].Outfit.FileName + " " + pick.Pieces[1].Outfit.FileName; got != "chinos.avatar blouse.avatar" {
+			t.Errorf("Propose() = %s, want the only pair that goes together", got)
+		}
+		if len(pick.Changes()) == 0 {
+			t.Error("Changes() is empty")
+		}
+	}
+	if len(env.history.History.Records) != 0 {
+		t.Errorf("Propose() saved picks: %+v", env.history.History.Records)
+	}
+
+	if _, err := useCase.Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		current.TagConflicts = entities.AddingRule(current.TagConflicts, "formal", "formal")
+		return current
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Propose(); !errors.As(err, &invalid) {
+		t.Errorf("Propose() with no compatible pair error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestEnsembleUseCase_ColorFilesAndRules(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"shirts":   {"tee [Red].avatar", "blouse.avatar"},
+		"trousers": {"chinos.avatar", "joggers [grey].avatar"},
+	})
+	if err := os.WriteFile(filepath.Join(env.root, "shirts", "blouse.avatar.colors"), []byte("white\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	env.metadata.Index = env.metadata.Index.Setting("trousers", "chinos.avatar", entities.OutfitMetadata{Colors: []string{"navy"}})
+	env.config.Config.Ensemble = entities.EnsembleSettings{
+		ColorRules: []string{"warm = red, orange", "avoid warm with navy, grey", "avoid white with grey"},
+	}.SettingSlot("shirts", "top").SettingSlot("trousers", "bottom")
+
+	for seed := range uint64(10) {
+		pick, err := NewEnsembleUseCase(env.services).Propose(WithPickSeed(seed))
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if got := pick.Pieces[0].Outfit.FileName + " " + pick.Pieces[1].Outfit.FileName; got != "chinos.avatar blouse.avatar" {
+			t.Errorf("Propose() = %s, want the only pair the color rules allow", got)
+		}
+	}
+}
+
+func TestEnsembleUseCase_Slots(t *testing.T) {
+	env := newEnsembleEnv(t)
+	useCase := NewEnsembleUseCase(env.services)
+
+	slots, _, err := useCase.Slots()
+	if err != nil || len(slots) != 2 || slots["top"][0] != "shirts" {
+		t.Errorf("Slots() = %v, %v", slots, err)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		return current.SettingSlot("casual", "Top")
+	}); !errors.As(err, &invalid) {
+		t.Errorf("Update() with a bad slot error = %v, want InvalidInputError", err)
+	}
+
+	env.config.Config.Ensemble = entities.EnsembleSettings{}
+	if _, err := useCase.Propose(); !errors.As(err, &invalid) {
+		t.Errorf("Propose() without slots error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ExportPackUseCase bundles a category into an outfit pack that others can
+// add to their wardrobe with the archive import.
+type ExportPackUseCase struct {
+	services Services
+}
+
+// NewExportPackUseCase creates a new export pack use case.
+func NewExportPackUseCase(services Services) *ExportPackUseCase {
+	return &ExportPackUseCase{services: services}
+}
+
+// Write writes a zip archive to w holding the category's outfit files under
+// a directory named after the category, and a manifest listing them with
+// their shareable metadata.
+func (u *ExportPackUseCase) Write(w io.Writer, categoryName string) (*entities.PackManifest, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	category, err := u.services.categoryReference(config, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := u.services.outfitsIn(config, category)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("%s has no outfits to export", categoryName))
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &entities.PackManifest{
+		Format:    entities.PackFormat,
+		Category:  categoryName,
+		CreatedAt: u.services.now(),
+		Labels:    maps.Clone(config.Names(categoryName).Labels),
+		Outfits:   make([]entities.PackOutfit, 0, len(files)),
+	}
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		if err := copyIntoZip(archive, categoryName+"/"+file.FileName, filepath.Join(category.Path, file.FileName)); err != nil {
+			return nil, err
+		}
+		outfit := entities.PackOutfit{FileName: file.FileName}
+		if metadata, ok := index.Get(categoryName, file.FileName); ok {
+			if shareable := metadata.Shareable(); !shareable.IsEmpty() {
+				outfit.Metadata = &shareable
+			}
+		}
+		manifest.Outfits = append(manifest.Outfits, outfit)
+	}
+
+	if err := writeZipJSON(archive, entities.PackManifestName, manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// copyIntoZip adds the file at path to the archive under name.
+func copyIntoZip(archive *zip.Writer, name, path string) error {
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(target, source)
+	return err
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestExportPackUseCase_Write(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	if err := os.WriteFile(filepath.Join(env.root, "casual", "tee.avatar"), []byte("tee"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	env.config.Config.CategoryNames = map[string]entities.CategoryNames{
+		"casual": {Labels: map[string]string{"de": "Freizeit"}, Aliases: []string{"weekend"}},
+	}
+	env.metadata.Index = env.metadata.Index.
+		Setting("casual", "tee.avatar", entities.OutfitMetadata{Tags: []string{"summer"}, Price: 25}).
+		Setting("casual", "jeans.avatar", entities.OutfitMetadata{Price: 80})
+
+	var buf bytes.Buffer
+	manifest, err := NewExportPackUseCase(env.services).Write(&buf, "casual")
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(manifest.Outfits) != 2 || manifest.Format != entities.PackFormat || !manifest.CreatedAt.Equal(testNow) {
+		t.Errorf("manifest = %+v", manifest)
+	}
+
+	files := readZip(t, buf.Bytes())
+	if files["casual/tee.avatar"] != "tee" {
+		t.Errorf("casual/tee.avatar = %q, want the outfit's content", files["casual/tee.avatar"])
+	}
+	if _, ok := files["casual/jeans.avatar"]; !ok {
+		t.Error("pack missing casual/jeans.avatar")
+	}
+	written := files[entities.PackManifestName]
+	for _, private := range []string{env.root, "80", "weekend", "price"} {
+		if strings.Contains(written, private) {
+			t.Errorf("manifest leaks %q:\n%s", private, written)
+		}
+	}
+	var decoded entities.PackManifest
+	if err := json.Unmarshal([]byte(written), &decoded); err != nil {
+		t.Fatalf("%s: %v", entities.PackManifestName, err)
+	}
+	if decoded.Labels["de"] != "Freizeit" {
+		t.Errorf("labels = %v", decoded.Labels)
+	}
+	for _, outfit := range decoded.Outfits {
+		switch outfit.FileName {
+		case "tee.avatar":
+			if outfit.Metadata == nil || outfit.Metadata.Tags[0] != "summer" {
+				t.Errorf("tee.avatar metadata = %+v", outfit.Metadata)
+			}
+		case "jeans.avatar":
+			if outfit.Metadata != nil {
+				t.Errorf("jeans.avatar metadata = %+v, want none once the price is dropped", outfit.Metadata)
+			}
+		}
+	}
+}
+
+func TestExportPackUseCase_RoundTrip(t *testing.T) {
+	source := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	pack := filepath.Join(t.TempDir(), "casual.zip")
+	f, err := os.Create(pack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewExportPackUseCase(source.services).Write(f, "casual"); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	f.Close()
+
+	target := newTestEnv(t, map[string][]string{"work": {"blazer.avatar"}})
+	result, err := NewImportArchiveUseCase(target.services).Execute(pack, "casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.Imported) != 2 || len(result.Skipped) != 0 {
+		t.Errorf("import = %+v, want both outfits and the manifest passed over", result.ArchiveImport)
+	}
+}
+
+func TestExportPackUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "empty": nil})
+	useCase := NewExportPackUseCase(env.services)
+
+	if _, err := useCase.Write(&bytes.Buffer{}, "missing"); !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("Write() of a missing category error = %v, want %v", err, domainerrors.ErrCategoryNotFound)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Write(&bytes.Buffer{}, "empty"); !errors.As(err, &invalid) {
+		t.Errorf("Write() of an empty category error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// FavoritesUseCase marks and unmarks outfits as favorites.
+type FavoritesUseCase struct {
+	services Services
+}
+
+// NewFavoritesUseCase creates a new favorites use case.
+func NewFavoritesUseCase(services Services) *FavoritesUseCase {
+	return &FavoritesUseCase{services: services}
+}
+
+// List returns every favorite.
+func (u *FavoritesUseCase) List() (entities.Favorites, error) {
+	return u.services.Favorites.Load()
+}
+
+// Add marks an outfit as a favorite. Adding a favorite again changes
+// nothing.
+func (u *FavoritesUseCase) Add(outfit entities.OutfitReference) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	if err := u.services.ensureOutfitExists(outfit); err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		favorites, err := u.services.Favorites.Load()
+		if err != nil {
+			return err
+		}
+		if favorites.Contains(outfit.Category.Name, outfit.FileName) {
+			return nil
+		}
+		return u.services.Favorites.Save(favorites.Adding(outfit.Category.Name, outfit.FileName))
+	})
+}
+
+// Remove unmarks a favorite. The outfit need not exist on disk any more, so
+// favorites of deleted outfits can be cleaned up.
+func (u *FavoritesUseCase) Remove(outfit entities.OutfitReference) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		favorites, err := u.services.Favorites.Load()
+		if err != nil {
+			return err
+		}
+		if !favorites.Contains(outfit.Category.Name, outfit.FileName) {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s/%s is not a favorite", outfit.Category.Name, outfit.FileName))
+		}
+		return u.services.Favorites.Save(favorites.Removing(outfit.Category.Name, outfit.FileName))
+	})
+}
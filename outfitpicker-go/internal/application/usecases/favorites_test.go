@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestFavoritesUseCase_AddAndRemove(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	useCase := NewFavoritesUseCase(env.services)
+
+	for range 2 {
+		if err := useCase.Add(env.outfit("casual", "tee.avatar")); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if env.favorites.Saves != 1 {
+		t.Errorf("saves = %d, want 1 for a repeated add", env.favorites.Saves)
+	}
+	favorites, err := useCase.List()
+	if err != nil || !slices.Equal(favorites.Category("casual"), []string{"tee.avatar"}) {
+		t.Errorf("List() = %v, %v", favorites.Outfits, err)
+	}
+
+	if err := useCase.Remove(env.outfit("casual", "tee.avatar")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Remove(env.outfit("casual", "tee.avatar")); !errors.As(err, &invalid) {
+		t.Errorf("Remove() of a non-favorite error = %v, want InvalidInputError", err)
+	}
+	if err := useCase.Add(env.outfit("casual", "nope.avatar")); !errors.As(err, &invalid) {
+		t.Errorf("Add() of a missing outfit error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickOutfitUseCase_FavoritesOnly(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.favorites.Favorites = entities.NewFavorites().Adding("casual", "b.avatar")
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 20 {
+		outfit, err := useCase.Execute("casual", WithFavoritesOnly())
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "b.avatar" {
+			t.Fatalf("Execute() = %v, want the favorite b.avatar", outfit.FileName)
+		}
+	}
+
+	if _, err := useCase.Propose("casual", WithFavoritesOnly(), WithoutOutfits("b.avatar")); !errors.Is(err, domainerrors.ErrNoOutfitsAvailable) {
+		t.Errorf("Propose() without every favorite error = %v, want ErrNoOutfitsAvailable", err)
+	}
+
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("b.avatar"))
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Execute("casual", WithFavoritesOnly()); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with every favorite worn error = %v, want InvalidInputError", err)
+	}
+
+	env.favorites.Favorites = entities.NewFavorites()
+	if _, err := useCase.Execute("casual", WithFavoritesOnly()); !errors.As(err, &invalid) {
+		t.Errorf("Execute() without favorites error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// OutfitFeedback summarizes the feedback recorded for one outfit.
+type OutfitFeedback struct {
+	Outfit entities.OutfitReference `json:"outfit"`
+	// Events lists the outfit's wear events, oldest first.
+	Events []entities.WearEvent `json:"events"`
+	Score  int                  `json:"score"`
+	// LastCompliment is when the outfit was last worn to a compliment.
+	LastCompliment *time.Time `json:"lastCompliment,omitempty"`
+}
+
+// FeedbackUseCase records and reports feedback on worn outfits.
+type FeedbackUseCase struct {
+	services Services
+}
+
+// NewFeedbackUseCase creates a new feedback use case.
+func NewFeedbackUseCase(services Services) *FeedbackUseCase {
+	return &FeedbackUseCase{services: services}
+}
+
+// Add attaches feedback, and an optional note, to the outfit's most recent
+// wear event.
+func (u *FeedbackUseCase) Add(outfit entities.OutfitReference, kind entities.FeedbackKind, note string) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return err
+		}
+		updated, ok := log.AddingFeedback(outfit.Category.Name, outfit.FileName, kind, note)
+		if !ok {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s/%s has not been worn yet", outfit.Category.Name, outfit.FileName))
+		}
+		return u.services.WearLog.Save(updated)
+	})
+}
+
+// Show returns the wear events and net feedback score of an outfit.
+func (u *FeedbackUseCase) Show(outfit entities.OutfitReference) (*OutfitFeedback, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return nil, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+	events := log.EventsFor(outfit.Category.Name, outfit.FileName)
+	score := 0
+	for _, event := range events {
+		score += event.Score()
+	}
+	feedback := &OutfitFeedback{Outfit: outfit, Events: events, Score: score}
+	if at, ok := log.LastCompliment(outfit.Category.Name, outfit.FileName); ok {
+		feedback.LastCompliment = &at
+	}
+	return feedback, nil
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestWearOutfitUseCase_RecordsWearEvent(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+
+	events := env.wearLog.Log.Events
+	if len(events) != 1 || events[0].FileName != "tee.avatar" || !events[0].WornAt.Equal(testNow) {
+		t.Errorf("wear log = %+v", events)
+	}
+}
+
+func TestFeedbackUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	outfit := env.outfit("casual", "tee.avatar")
+	useCase := NewFeedbackUseCase(env.services)
+
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Add(outfit, entities.FeedbackCompliment, ""); !errors.As(err, &invalid) {
+		t.Errorf("Add() before wearing error = %v, want InvalidInputError", err)
+	}
+
+	if err := NewWearOutfitUseCase(env.services).Execute(outfit); err != nil {
+		t.Fatal(err)
+	}
+	if err := useCase.Add(outfit, entities.FeedbackCompliment, "office party"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := useCase.Add(outfit, entities.FeedbackComfortable, ""); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	feedback, err := useCase.Show(outfit)
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if feedback.Score != 2 || len(feedback.Events) != 1 || feedback.Events[0].Note != "office party" {
+		t.Errorf("Show() = %+v", feedback)
+	}
+	if feedback.LastCompliment == nil || !feedback.LastCompliment.Equal(testNow) {
+		t.Errorf("LastCompliment = %v, want %v", feedback.LastCompliment, testNow)
+	}
+}
+
+func TestPickOutfitUseCase_WeightedBoostsPositiveFeedback(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"liked.avatar", "plain.avatar"}})
+	env.config.Config.Selection = entities.SelectionPreferences{Strategy: entities.StrategyWeighted, FeedbackBoost: 10}
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "liked.avatar", Feedback: []entities.FeedbackKind{entities.FeedbackCompliment, entities.FeedbackComfortable}},
+	}}
+
+	const picks = 200
+	liked := 0
+	useCase := NewPickOutfitUseCase(env.services)
+	for range picks {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outfit.FileName == "liked.avatar" {
+			liked++
+		}
+	}
+	// liked.avatar has weight 21 against 1.
+	if liked < picks*8/10 {
+		t.Errorf("liked.avatar picked %d of %d times, want a strong preference", liked, picks)
+	}
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// GetCategoriesUseCase lists the categories under the configured root.
+type GetCategoriesUseCase struct {
+	services Services
+}
+
+// NewGetCategoriesUseCase creates a new get categories use case.
+func NewGetCategoriesUseCase(services Services) *GetCategoriesUseCase {
+	return &GetCategoriesUseCase{services: services}
+}
+
+// Execute returns every category with its state, in the configured
+// category order.
+func (u *GetCategoriesUseCase) Execute() ([]entities.CategoryInfo, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return u.orderedCategories(config)
+}
+
+// Pickable returns the categories the profile may pick from, in the
+// configured category order.
+func (u *GetCategoriesUseCase) Pickable() ([]entities.CategoryInfo, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.orderedCategories(config)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(infos, func(info entities.CategoryInfo) bool {
+		return !config.Permissions.CanPick(info.Category.Name)
+	}), nil
+}
+
+// orderedCategories scans the categories and puts them in the configured
+// order.
+func (u *GetCategoriesUseCase) orderedCategories(config *entities.Config) ([]entities.CategoryInfo, error) {
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.services.sortCategories(config, infos); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// InSeason returns the categories worn in season, in the configured
+// category order, and the season itself, resolving entities.SeasonAuto to
+// the current one.
+func (u *GetCategoriesUseCase) InSeason(season string) ([]entities.CategoryInfo, string, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, "", err
+	}
+	season, err = logic.ResolveSeason(season, config.Seasons, u.services.now())
+	if err != nil {
+		return nil, "", err
+	}
+	infos, err := u.orderedCategories(config)
+	if err != nil {
+		return nil, "", err
+	}
+	var inSeason []entities.CategoryInfo
+	for _, info := range infos {
+		if logic.CategoryInSeason(config.Seasons, info.Category.Name, season) {
+			inSeason = append(inSeason, info)
+		}
+	}
+	return inSeason, season, nil
+}
+
+// Progress returns the rotation progress of every category with outfits, in
+// the configured category order.
+func (u *GetCategoriesUseCase) Progress() ([]entities.RotationProgress, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.orderedCategories(config)
+	if err != nil {
+		return nil, err
+	}
+	var progress []entities.RotationProgress
+	for _, info := range infos {
+		if info.State != entities.CategoryStateHasOutfits {
+			continue
+		}
+		worn := len(cache.Categories[info.Category.Name].WornOutfits)
+		progress = append(progress, entities.NewRotationProgress(info.Category, worn, info.OutfitCount))
+	}
+	return progress, nil
+}
+
+// OutfitNames returns the file names of the outfits in the named category.
+func (u *GetCategoriesUseCase) OutfitNames(category string) ([]string, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	reference, err := u.services.categoryReference(config, category)
+	if err != nil {
+		return nil, err
+	}
+	files, err := u.services.outfitsIn(config, reference)
+	if err != nil {
+		return nil, err
+	}
+	return fileNames(files), nil
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestGetCategoriesUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config.ExcludedCategories["formal"] = true
+
+	infos, err := NewGetCategoriesUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("Execute() returned %d categories, want 2", len(infos))
+	}
+	if infos[0].Category.Name != "casual" || infos[0].State != entities.CategoryStateHasOutfits {
+		t.Errorf("infos[0] = %+v", infos[0])
+	}
+	if infos[1].State != entities.CategoryStateUserExcluded {
+		t.Errorf("infos[1] = %+v, want excluded", infos[1])
+	}
+}
+
+func TestGetCategoriesUseCase_Progress(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "empty": {}, "formal": {"suit.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar"))
+
+	progress, err := NewGetCategoriesUseCase(env.services).Progress()
+	if err != nil {
+		t.Fatalf("Progress() error = %v", err)
+	}
+	if len(progress) != 2 {
+		t.Fatalf("Progress() = %+v, want casual and formal", progress)
+	}
+	if progress[0].Category.Name != "casual" || progress[0].WornCount != 1 || progress[0].TotalOutfitCount != 2 {
+		t.Errorf("progress[0] = %+v", progress[0])
+	}
+	if progress[1].Category.Name != "formal" || progress[1].WornCount != 0 {
+		t.Errorf("progress[1] = %+v", progress[1])
+	}
+}
+
+func TestGetCategoriesUseCase_OutfitNames(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	useCase := NewGetCategoriesUseCase(env.services)
+
+	names, err := useCase.OutfitNames("casual")
+	if err != nil || !slices.Equal(names, []string{"a.avatar", "b.avatar"}) {
+		t.Errorf("OutfitNames() = %v, %v", names, err)
+	}
+	if _, err := useCase.OutfitNames("pyjamas"); !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("OutfitNames(missing) error = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestGetCategoriesUseCase_NoConfig(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Config = nil
+
+	if _, err := NewGetCategoriesUseCase(env.services).Execute(); !errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		t.Errorf("Execute() error = %v, want ErrConfigurationNotFound", err)
+	}
+}
@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// HistoryExport is everything recorded about the outfits: every pick and
+// wear, oldest first, and the metadata of each outfit.
+type HistoryExport struct {
+	ExportedAt time.Time
+	Picks      []entities.SelectionRecord
+	Wears      []entities.WearEvent
+	Metadata   entities.MetadataIndex
+}
+
+// HistoryUseCase answers questions about what was picked and worn when.
+type HistoryUseCase struct {
+	services Services
+}
+
+// NewHistoryUseCase creates a new history use case.
+func NewHistoryUseCase(services Services) *HistoryUseCase {
+	return &HistoryUseCase{services: services}
+}
+
+// List returns one page of picks matching query, newest first.
+func (u *HistoryUseCase) List(query entities.HistoryQuery) (entities.Page[entities.SelectionRecord], error) {
+	query, err := query.Normalized()
+	if err != nil {
+		return entities.Page[entities.SelectionRecord]{}, err
+	}
+	return u.services.History.Query(query)
+}
+
+// Worn returns one page of wear events matching query, newest first.
+func (u *HistoryUseCase) Worn(query entities.HistoryQuery) (entities.Page[entities.WearEvent], error) {
+	query, err := query.Normalized()
+	if err != nil {
+		return entities.Page[entities.WearEvent]{}, err
+	}
+	return u.services.WearLog.Query(query)
+}
+
+// Events returns up to limit of the latest picks and rotation completions,
+// newest first. A zero limit means entities.DefaultHistoryLimit.
+func (u *HistoryUseCase) Events(limit int) ([]entities.WardrobeEvent, error) {
+	query, err := entities.HistoryQuery{Limit: limit}.Normalized()
+	if err != nil {
+		return nil, err
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return nil, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+	return logic.RecentWardrobeEvents(history, log, query.Limit), nil
+}
+
+// Export returns the whole history and the outfit metadata, as loaded, for
+// an export to write out record by record.
+func (u *HistoryUseCase) Export() (HistoryExport, error) {
+	history, err := u.services.History.Load()
+	if err != nil {
+		return HistoryExport{}, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return HistoryExport{}, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return HistoryExport{}, err
+	}
+	return HistoryExport{ExportedAt: u.services.now(), Picks: history.Records, Wears: log.Events, Metadata: index}, nil
+}
+
+// Clear removes every recorded pick and returns how many there were. Wear
+// events are kept, as feedback is attached to them.
+func (u *HistoryUseCase) Clear() (int, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return 0, err
+	}
+	removed := 0
+	err := retryOnConflict(func() error {
+		history, err := u.services.History.Load()
+		if err != nil {
+			return err
+		}
+		removed = len(history.Records)
+		if removed == 0 {
+			return nil
+		}
+		return u.services.History.Save(history.Cleared())
+	})
+	return removed, err
+}
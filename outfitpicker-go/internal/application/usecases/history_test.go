@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestHistoryUseCase_RecordsPicks(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "work": {"shirt.avatar"}})
+	picks := NewPickOutfitUseCase(env.services)
+	for _, category := range []string{"casual", "work", "casual"} {
+		if _, err := picks.Execute(category); err != nil {
+			t.Fatalf("Execute(%s) error = %v", category, err)
+		}
+	}
+
+	page, err := NewHistoryUseCase(env.services).List(entities.HistoryQuery{Category: "casual"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if page.Total != 2 || page.Limit != entities.DefaultHistoryLimit {
+		t.Errorf("List() = %+v", page)
+	}
+	for _, record := range page.Items {
+		if record.Category != "casual" || !record.SelectedAt.Equal(testNow) {
+			t.Errorf("record = %+v", record)
+		}
+	}
+
+	page, err = NewHistoryUseCase(env.services).List(entities.HistoryQuery{Since: testNow.Add(time.Minute)})
+	if err != nil || page.Total != 0 {
+		t.Errorf("List(since later) = %+v, %v", page, err)
+	}
+}
+
+func TestHistoryUseCase_Worn(t *testing.T) {
+	env := newTestEnv(t, nil)
+	for i, name := range []string{"a.avatar", "b.avatar", "c.avatar"} {
+		env.wearLog.Log = env.wearLog.Log.Appending(entities.WearEvent{Category: "casual", FileName: name, WornAt: testNow.AddDate(0, 0, i)})
+	}
+
+	page, err := NewHistoryUseCase(env.services).Worn(entities.HistoryQuery{Limit: 2, Page: 2})
+	if err != nil {
+		t.Fatalf("Worn() error = %v", err)
+	}
+	if page.Total != 3 || len(page.Items) != 1 || page.Items[0].FileName != "a.avatar" {
+		t.Errorf("Worn() = %+v", page)
+	}
+}
+
+func TestHistoryUseCase_Events(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.history.History = env.history.History.Appending(entities.SelectionRecord{Category: "casual", FileName: "a.avatar", SelectedAt: testNow})
+	env.wearLog.Log = env.wearLog.Log.
+		Appending(entities.WearEvent{Category: "casual", FileName: "b.avatar", WornAt: testNow.Add(-time.Hour)}).
+		Appending(entities.WearEvent{Category: "casual", FileName: "a.avatar", WornAt: testNow.Add(time.Hour), CompletedRotation: true})
+
+	events, err := NewHistoryUseCase(env.services).Events(0)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 2 || events[0].Kind != entities.WardrobeEventRotationCompleted || events[1].Kind != entities.WardrobeEventPick {
+		t.Errorf("Events() = %+v, want the completed rotation, then the pick", events)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := NewHistoryUseCase(env.services).Events(entities.MaxHistoryLimit + 1); !errors.As(err, &invalid) {
+		t.Errorf("Events() over the limit error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestHistoryUseCase_Export(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.history.History = env.history.History.Appending(entities.SelectionRecord{Category: "casual", FileName: "a.avatar", SelectedAt: testNow})
+	env.wearLog.Log = env.wearLog.Log.Appending(entities.WearEvent{Category: "casual", FileName: "a.avatar", WornAt: testNow})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "a.avatar", entities.OutfitMetadata{Tags: []string{"relaxed"}})
+
+	export, err := NewHistoryUseCase(env.services).Export()
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !export.ExportedAt.Equal(testNow) || len(export.Picks) != 1 || len(export.Wears) != 1 || len(export.Metadata.Outfits["casual"]) != 1 {
+		t.Errorf("Export() = %+v, want the pick, the wear and the metadata", export)
+	}
+}
+
+func TestHistoryUseCase_Clear(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.history.History = env.history.History.Appending(entities.SelectionRecord{Category: "casual", FileName: "a.avatar", SelectedAt: testNow})
+	env.wearLog.Log = env.wearLog.Log.Appending(entities.WearEvent{Category: "casual", FileName: "a.avatar", WornAt: testNow})
+
+	removed, err := NewHistoryUseCase(env.services).Clear()
+	if err != nil || removed != 1 {
+		t.Fatalf("Clear() = %d, %v; want 1", removed, err)
+	}
+	if len(env.history.History.Records) != 0 || len(env.wearLog.Log.Events) != 1 {
+		t.Errorf("after Clear() history = %+v, wear log = %+v", env.history.History, env.wearLog.Log)
+	}
+
+	if removed, err := NewHistoryUseCase(env.services).Clear(); err != nil || removed != 0 || env.history.Saves != 1 {
+		t.Errorf("second Clear() = %d, %v with %d saves; want a no-op", removed, err, env.history.Saves)
+	}
+}
+
+func TestHistoryUseCase_InvalidQuery(t *testing.T) {
+	env := newTestEnv(t, nil)
+
+	_, err := NewHistoryUseCase(env.services).List(entities.HistoryQuery{Page: -1})
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Errorf("List() error = %v, want InvalidInputError", err)
+	}
+}
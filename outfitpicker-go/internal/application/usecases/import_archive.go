@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// ArchiveImportResult reports an archive imported into a category.
+type ArchiveImportResult struct {
+	Category string `json:"category"`
+	entities.ArchiveImport
+	// Outfits is the category's outfit count after the import.
+	Outfits int `json:"outfits"`
+}
+
+// ImportArchiveUseCase adds the outfits of a zip or tar archive, such as an
+// outfit pack, to a category.
+type ImportArchiveUseCase struct {
+	services Services
+}
+
+// NewImportArchiveUseCase creates a new import archive use case.
+func NewImportArchiveUseCase(services Services) *ImportArchiveUseCase {
+	return &ImportArchiveUseCase{services: services}
+}
+
+// Execute extracts the archive's outfits into the named category, creating
+// it if needed. Imported outfits are recorded as known and the category's
+// outfit count in the cache is updated.
+func (u *ImportArchiveUseCase) Execute(archivePath, categoryName string) (*ArchiveImportResult, error) {
+	if err := logic.ValidateCategoryName(categoryName); err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(categoryName, `/\`) || strings.HasPrefix(categoryName, ".") {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("category %q must be a single, non-hidden directory name", categoryName))
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := u.services.categoryReference(config, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	entries := 0
+	u.services.report(entities.OperationImport, entities.ProgressStart, 0, 0, filepath.Base(archivePath))
+	imported, err := u.services.Importer.Import(archivePath, category.Path, func(entry string) {
+		entries++
+		u.services.report(entities.OperationImport, entities.ProgressStep, entries, 0, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.services.report(entities.OperationImport, entities.ProgressDone, entries, entries, "")
+	files, err := u.services.outfitsIn(config, category)
+	if err != nil {
+		return nil, err
+	}
+	result := &ArchiveImportResult{Category: categoryName, ArchiveImport: imported, Outfits: len(files)}
+	if len(imported.Imported) == 0 {
+		return result, nil
+	}
+
+	err = retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		for _, outfit := range imported.Imported {
+			knowFile(config, categoryName, outfit.FileName)
+		}
+		return u.services.Config.Save(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = u.services.Cache.UpdateCategory(categoryName, func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+		if !exists {
+			return entities.NewCategoryCache(len(files)), nil
+		}
+		current.TotalOutfits = len(files)
+		return current, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func writeTestZip(t *testing.T, names ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pack.zip")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	writer := zip.NewWriter(out)
+	for _, name := range names {
+		if _, err := writer.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportArchiveUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(1).Adding("tee.avatar"))
+	archive := writeTestZip(t, "pack/tee.avatar", "pack/jeans.avatar", "pack/notes.txt")
+
+	result, err := NewImportArchiveUseCase(env.services).Execute(archive, "casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(result.Imported) != 2 || len(result.Skipped) != 1 || result.Outfits != 3 {
+		t.Errorf("Execute() = %+v", result)
+	}
+
+	known := env.config.Config.KnownCategoryFiles["casual"]
+	if !known["jeans.avatar"] || !known["tee-2.avatar"] {
+		t.Errorf("known files = %v", known)
+	}
+	categoryCache := env.cache.Cache.Categories["casual"]
+	if categoryCache.TotalOutfits != 3 || !categoryCache.WornOutfits["tee.avatar"] {
+		t.Errorf("cache = %+v, want 3 outfits with tee.avatar still worn", categoryCache)
+	}
+}
+
+func TestImportArchiveUseCase_ReportsProgress(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	var events []entities.ProgressEvent
+	env.services.Progress = func(event entities.ProgressEvent) { events = append(events, event) }
+	archive := writeTestZip(t, "jeans.avatar", "notes.txt")
+
+	if _, err := NewImportArchiveUseCase(env.services).Execute(archive, "casual"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := []entities.ProgressEvent{
+		{Operation: entities.OperationImport, Phase: entities.ProgressStart, Item: "pack.zip"},
+		{Operation: entities.OperationImport, Phase: entities.ProgressStep, Current: 1, Item: "jeans.avatar"},
+		{Operation: entities.OperationImport, Phase: entities.ProgressStep, Current: 2, Item: "notes.txt"},
+		{Operation: entities.OperationImport, Phase: entities.ProgressDone, Current: 2, Total: 2},
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
+
+func TestImportArchiveUseCase_NewCategory(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeTestZip(t, "shorts.avatar")
+
+	result, err := NewImportArchiveUseCase(env.services).Execute(archive, "gym")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Outfits != 1 || !env.config.Config.KnownCategories["gym"] {
+		t.Errorf("Execute() = %+v, known categories = %v", result, env.config.Config.KnownCategories)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "gym", "shorts.avatar")); err != nil {
+		t.Errorf("imported outfit missing: %v", err)
+	}
+}
+
+func TestImportArchiveUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeTestZip(t, "shorts.avatar")
+
+	var invalid *domainerrors.InvalidInputError
+	for _, name := range []string{"../escape", "nested/category", ".hidden"} {
+		if _, err := NewImportArchiveUseCase(env.services).Execute(archive, name); !errors.As(err, &invalid) {
+			t.Errorf("Execute(%q) error = %v, want InvalidInputError", name, err)
+		}
+	}
+
+	env.maintenance.State = entities.MaintenanceState{Enabled: true}
+	if _, err := NewImportArchiveUseCase(env.services).Execute(archive, "casual"); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Execute() in maintenance error = %v, want ErrMaintenanceMode", err)
+	}
+}
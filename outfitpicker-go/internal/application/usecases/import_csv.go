@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// CSVImportSkip is a spreadsheet row that matched no outfit.
+type CSVImportSkip struct {
+	Line     int    `json:"line"`
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Reason   string `json:"reason"`
+}
+
+// CSVImportProposal is a metadata import worked out but not yet saved.
+type CSVImportProposal struct {
+	// Rows are the rows that matched an outfit, with their categories'
+	// names resolved.
+	Rows    []entities.MetadataImportRow `json:"rows"`
+	Skipped []CSVImportSkip              `json:"skipped,omitempty"`
+}
+
+// Tagged counts the rows giving an outfit tags.
+func (p *CSVImportProposal) Tagged() int {
+	return p.count(func(row entities.MetadataImportRow) bool { return len(row.Tags) > 0 })
+}
+
+// Weighted counts the rows giving an outfit a weight.
+func (p *CSVImportProposal) Weighted() int {
+	return p.count(func(row entities.MetadataImportRow) bool { return row.Weight != nil })
+}
+
+// Rated counts the rows giving an outfit a rating.
+func (p *CSVImportProposal) Rated() int {
+	return p.count(func(row entities.MetadataImportRow) bool { return row.Rating != 0 })
+}
+
+func (p *CSVImportProposal) count(matches func(entities.MetadataImportRow) bool) int {
+	n := 0
+	for _, row := range p.Rows {
+		if matches(row) {
+			n++
+		}
+	}
+	return n
+}
+
+// Changes returns the changes committing the import makes, in the order
+// Commit makes them.
+func (p *CSVImportProposal) Changes() []StateChange {
+	var changes []StateChange
+	var metadata []string
+	if n := p.Tagged(); n > 0 {
+		metadata = append(metadata, "add tags to "+outfitCount(n))
+	}
+	if n := p.Rated(); n > 0 {
+		metadata = append(metadata, "rate "+outfitCount(n))
+	}
+	if len(metadata) > 0 {
+		changes = append(changes, StateChange{Store: "metadata", Change: strings.Join(metadata, ", ")})
+	}
+	if n := p.Weighted(); n > 0 {
+		changes = append(changes, StateChange{Store: "weights", Change: "weigh " + outfitCount(n)})
+	}
+	return changes
+}
+
+func outfitCount(n int) string {
+	return countOf(n, "outfit")
+}
+
+// countOf returns n with noun, made plural unless n is 1.
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// ImportCSVUseCase gives outfits the tags, weights and ratings listed in a
+// spreadsheet export, for users moving from tracking them by hand.
+type ImportCSVUseCase struct {
+	services Services
+}
+
+// NewImportCSVUseCase creates a new CSV import use case.
+func NewImportCSVUseCase(services Services) *ImportCSVUseCase {
+	return &ImportCSVUseCase{services: services}
+}
+
+// Propose matches the rows, as logic.ParseMetadataCSV reads them, to
+// outfits without saving anything. A category may be named by label or
+// alias. Rows naming a category or outfit that does not exist are skipped.
+// Commit saves the import.
+func (u *ImportCSVUseCase) Propose(rows []entities.MetadataImportRow) (*CSVImportProposal, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(infos))
+	references := make(map[string]entities.CategoryReference, len(infos))
+	for i, info := range infos {
+		names[i] = info.Category.Name
+		references[info.Category.Name] = info.Category
+	}
+
+	proposal := &CSVImportProposal{}
+	outfits := make(map[string][]entities.FileEntry)
+	for _, row := range rows {
+		name, err := logic.MatchCategory(row.Category, names, config.CategoryNames, config.Language)
+		if err != nil {
+			proposal.Skipped = append(proposal.Skipped, CSVImportSkip{Line: row.Line, Category: row.Category, FileName: row.FileName, Reason: "category not found"})
+			continue
+		}
+		files, listed := outfits[name]
+		if !listed {
+			if files, err = u.services.outfitsIn(config, references[name]); err != nil {
+				return nil, err
+			}
+			outfits[name] = files
+		}
+		if !containsFile(files, row.FileName) {
+			proposal.Skipped = append(proposal.Skipped, CSVImportSkip{Line: row.Line, Category: name, FileName: row.FileName, Reason: "outfit not found"})
+			continue
+		}
+		row.Category = name
+		proposal.Rows = append(proposal.Rows, row)
+	}
+	return proposal, nil
+}
+
+// Commit saves an import worked out by Propose: tags are added to each
+// outfit's own, and weights and ratings replace what it has. A later row
+// for the same outfit wins.
+func (u *ImportCSVUseCase) Commit(proposal *CSVImportProposal) error {
+	if err := u.services.ensureWritable(); err != nil {
+		return err
+	}
+	if proposal.Tagged() > 0 || proposal.Rated() > 0 {
+		err := retryOnConflict(func() error {
+			index, err := u.services.Metadata.Load()
+			if err != nil {
+				return err
+			}
+			for _, row := range proposal.Rows {
+				metadata, _ := index.Get(row.Category, row.FileName)
+				metadata = metadata.AddingTags(row.Tags)
+				if row.Rating != 0 {
+					metadata.Rating = row.Rating
+				}
+				index = index.Setting(row.Category, row.FileName, metadata)
+			}
+			return u.services.Metadata.Save(index)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if proposal.Weighted() == 0 {
+		return nil
+	}
+	return retryOnConflict(func() error {
+		weights, err := u.services.Weights.Load()
+		if err != nil {
+			return err
+		}
+		for _, row := range proposal.Rows {
+			if row.Weight != nil {
+				weights = weights.Setting(row.Category, row.FileName, *row.Weight)
+			}
+		}
+		return u.services.Weights.Save(weights)
+	})
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func TestImportCSVUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"blazer.avatar"}})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "tee.avatar", entities.OutfitMetadata{Tags: []string{"summer"}, Price: 20})
+	rows, err := logic.ParseMetadataCSV(strings.NewReader("category,outfit,tags,weight,rating\n"+
+		"casual,tee.avatar,beach,3,5\n"+
+		"work,blazer.avatar,office,,\n"+
+		"casual,missing.avatar,beach,,\n"+
+		"gym,kit.avatar,,2,\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useCase := NewImportCSVUseCase(env.services)
+
+	proposal, err := useCase.Propose(rows)
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	if len(proposal.Rows) != 2 || len(proposal.Skipped) != 2 || proposal.Skipped[0].Line != 4 || proposal.Skipped[1].Reason != "category not found" {
+		t.Fatalf("Propose() = %+v, want two rows matched and two skipped", proposal)
+	}
+	if changes := proposal.Changes(); len(changes) != 2 || changes[0].Change != "add tags to 2 outfits, rate 1 outfit" || changes[1].Store != "weights" {
+		t.Errorf("Changes() = %+v", changes)
+	}
+	if env.metadata.Saves != 0 || env.weights.Saves != 0 {
+		t.Fatal("Propose() saved something")
+	}
+
+	if err := useCase.Commit(proposal); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	tee, _ := env.metadata.Index.Get("casual", "tee.avatar")
+	if !slices.Equal(tee.Tags, []string{"summer", "beach"}) || tee.Rating != 5 || tee.Price != 20 {
+		t.Errorf("tee.avatar metadata = %+v, want the tag added, the rating set and the price kept", tee)
+	}
+	if !env.metadata.Index.HasTag("work", "blazer.avatar", "office") {
+		t.Error("blazer.avatar was not tagged")
+	}
+	if got := env.weights.Weights.Weight("casual", "tee.avatar"); got != 3 {
+		t.Errorf("tee.avatar weight = %v, want 3", got)
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// IntegrityUseCase turns state file signing on and off, checks the
+// signatures and accepts changes made outside the app.
+type IntegrityUseCase struct {
+	services Services
+}
+
+// NewIntegrityUseCase creates a new integrity use case.
+func NewIntegrityUseCase(services Services) *IntegrityUseCase {
+	return &IntegrityUseCase{services: services}
+}
+
+// Status reports whether signing is on and, if so, which state files fail
+// their integrity check.
+func (u *IntegrityUseCase) Status() (entities.IntegrityStatus, error) {
+	enabled, err := u.services.Integrity.Enabled()
+	if err != nil || !enabled {
+		return entities.IntegrityStatus{}, err
+	}
+	failed, err := u.services.Integrity.Verify()
+	if err != nil {
+		return entities.IntegrityStatus{}, err
+	}
+	return entities.IntegrityStatus{Enabled: true, Failed: failed}, nil
+}
+
+// Enable turns signing on and signs the current state files. It reports
+// false when signing was already on, leaving the signatures alone so a
+// changed file is not accepted by accident.
+func (u *IntegrityUseCase) Enable() (bool, error) {
+	enabled, err := u.services.Integrity.Enabled()
+	if err != nil || enabled {
+		return false, err
+	}
+	return true, u.services.Integrity.Enable()
+}
+
+// Disable turns signing off and removes the signatures. It reports false
+// when signing was already off.
+func (u *IntegrityUseCase) Disable() (bool, error) {
+	enabled, err := u.services.Integrity.Enabled()
+	if err != nil || !enabled {
+		return false, err
+	}
+	return true, u.services.Integrity.Disable()
+}
+
+// Accept signs the current contents of the state files, trusting whatever
+// changed them, and returns the files that failed their check before.
+func (u *IntegrityUseCase) Accept() ([]string, error) {
+	enabled, err := u.services.Integrity.Enabled()
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, domainerrors.NewInvalidInputError("state file signing is off")
+	}
+	failed, err := u.services.Integrity.Verify()
+	if err != nil {
+		return nil, err
+	}
+	return failed, u.services.Integrity.Sign()
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestIntegrityUseCase_EnableAcceptDisable(t *testing.T) {
+	env := newTestEnv(t, nil)
+	useCase := NewIntegrityUseCase(env.services)
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Accept(); !errors.As(err, &invalid) {
+		t.Errorf("Accept() while off error = %v, want InvalidInputError", err)
+	}
+	if changed, err := useCase.Enable(); err != nil || !changed || !env.integrity.On {
+		t.Fatalf("Enable() = %v, %v", changed, err)
+	}
+
+	env.integrity.Failed = []string{"cache.json"}
+	if changed, err := useCase.Enable(); err != nil || changed || env.integrity.Signs != 1 {
+		t.Errorf("Enable() again = %v, %v after %d signs; want no change", changed, err, env.integrity.Signs)
+	}
+	status, err := useCase.Status()
+	if err != nil || !status.Enabled || !slices.Equal(status.Failed, []string{"cache.json"}) {
+		t.Errorf("Status() = %+v, %v", status, err)
+	}
+	accepted, err := useCase.Accept()
+	if err != nil || !slices.Equal(accepted, []string{"cache.json"}) {
+		t.Errorf("Accept() = %v, %v", accepted, err)
+	}
+	if status, _ := useCase.Status(); len(status.Failed) != 0 {
+		t.Errorf("Status() after Accept = %+v", status)
+	}
+
+	if changed, err := useCase.Disable(); err != nil || !changed || env.integrity.On {
+		t.Errorf("Disable() = %v, %v", changed, err)
+	}
+	if changed, err := useCase.Disable(); err != nil || changed {
+		t.Errorf("Disable() again = %v, %v; want no change", changed, err)
+	}
+}
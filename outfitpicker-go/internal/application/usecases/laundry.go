@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// LaundryUseCase tracks outfits through the laundry: worn outfits go into
+// the wash and come back clean. While tracking is on only clean outfits
+// are picked.
+type LaundryUseCase struct {
+	services Services
+}
+
+// NewLaundryUseCase creates a new laundry use case.
+func NewLaundryUseCase(services Services) *LaundryUseCase {
+	return &LaundryUseCase{services: services}
+}
+
+// Status returns the laundry states, and whether tracking is on.
+func (u *LaundryUseCase) Status() (entities.Laundry, error) {
+	return u.services.Laundry.Load()
+}
+
+// Enable turns laundry tracking on. Outfits worn in the current rotations
+// start out worn and every other outfit clean. Enabling it again changes
+// nothing.
+func (u *LaundryUseCase) Enable() error {
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		laundry, err := u.services.Laundry.Load()
+		if err != nil || laundry.Enabled {
+			return err
+		}
+		laundry = laundry.Clearing()
+		laundry.Enabled = true
+		for category, categoryCache := range cache.Categories {
+			for fileName, worn := range categoryCache.WornOutfits {
+				if worn {
+					laundry = laundry.Wearing(category, fileName)
+				}
+			}
+		}
+		return u.services.Laundry.Save(laundry)
+	})
+}
+
+// Disable turns laundry tracking off and forgets every outfit's state.
+func (u *LaundryUseCase) Disable() error {
+	return retryOnConflict(func() error {
+		laundry, err := u.services.Laundry.Load()
+		if err != nil || !laundry.Enabled {
+			return err
+		}
+		laundry = laundry.Clearing()
+		laundry.Enabled = false
+		return u.services.Laundry.Save(laundry)
+	})
+}
+
+// Wash puts worn outfits into the laundry: the given ones, or every worn
+// outfit when none are given. It returns the outfits put in.
+func (u *LaundryUseCase) Wash(outfits []entities.OutfitReference) ([]entities.LaundryOutfit, error) {
+	return u.transition(outfits, entities.LaundryWorn, entities.LaundryInWash, "no worn outfits are waiting to be washed")
+}
+
+// Done takes outfits out of the laundry clean: the given ones, or every
+// outfit in the laundry when none are given. It returns the outfits taken
+// out.
+func (u *LaundryUseCase) Done(outfits []entities.OutfitReference) ([]entities.LaundryOutfit, error) {
+	return u.transition(outfits, entities.LaundryInWash, entities.LaundryClean, "no outfits are in the laundry")
+}
+
+// transition moves outfits to the state to, or every outfit in the state
+// from when none are given. Nothing changes unless every outfit can move.
+func (u *LaundryUseCase) transition(outfits []entities.OutfitReference, from, to entities.LaundryState, noneMessage string) ([]entities.LaundryOutfit, error) {
+	for _, outfit := range outfits {
+		if err := logic.ValidateOutfit(outfit); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	var moved []entities.LaundryOutfit
+	err := retryOnConflict(func() error {
+		laundry, err := u.services.Laundry.Load()
+		if err != nil {
+			return err
+		}
+		if !laundry.Enabled {
+			return errors.NewInvalidInputError("laundry tracking is off; turn it on with: laundry enable")
+		}
+		targets := laundry.InState(from)
+		if len(outfits) > 0 {
+			targets = make([]entities.LaundryOutfit, len(outfits))
+			for i, outfit := range outfits {
+				targets[i] = entities.LaundryOutfit{Category: outfit.Category.Name, FileName: outfit.FileName}
+			}
+		}
+		if len(targets) == 0 {
+			return errors.NewInvalidInputError(noneMessage)
+		}
+		moved = make([]entities.LaundryOutfit, len(targets))
+		for i, target := range targets {
+			if laundry, err = laundry.Transition(target.Category, target.FileName, to); err != nil {
+				return err
+			}
+			moved[i] = entities.LaundryOutfit{Category: target.Category, FileName: target.FileName, State: to}
+		}
+		return u.services.Laundry.Save(laundry)
+	})
+	return moved, err
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// LaundryReport groups the outfits worn in the current rotations into loads
+// that can be washed together.
+type LaundryReport struct {
+	Loads []logic.WashLoad `json:"loads"`
+	// Unsorted lists worn outfits without material or care metadata.
+	Unsorted []entities.OutfitReference `json:"unsorted"`
+}
+
+// OutfitCount returns the number of outfits in the report.
+func (r LaundryReport) OutfitCount() int {
+	total := len(r.Unsorted)
+	for _, load := range r.Loads {
+		total += len(load.Outfits)
+	}
+	return total
+}
+
+// LaundryReportUseCase builds the laundry report.
+type LaundryReportUseCase struct {
+	services Services
+}
+
+// NewLaundryReportUseCase creates a new laundry report use case.
+func NewLaundryReportUseCase(services Services) *LaundryReportUseCase {
+	return &LaundryReportUseCase{services: services}
+}
+
+// Execute reports every worn outfit that is still in a non-excluded
+// category, grouped by wash program. While laundry is tracked, worn means
+// waiting to be washed rather than worn in the current rotation.
+func (u *LaundryReportUseCase) Execute() (*LaundryReport, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	laundry, err := u.services.Laundry.Load()
+	if err != nil {
+		return nil, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	references := make(map[string]entities.CategoryReference, len(infos))
+	for _, info := range infos {
+		references[info.Category.Name] = info.Category
+	}
+
+	waiting := func(category, file string) bool {
+		if laundry.Enabled {
+			return laundry.State(category, file) == entities.LaundryWorn
+		}
+		return cache.Categories[category].WornOutfits[file]
+	}
+	var items []logic.LaundryItem
+	for category, files := range snapshot {
+		for _, file := range files {
+			if !waiting(category, file) {
+				continue
+			}
+			metadata, _ := index.Get(category, file)
+			items = append(items, logic.LaundryItem{
+				Outfit:   entities.NewOutfitReference(file, references[category]),
+				Metadata: metadata,
+			})
+		}
+	}
+
+	loads, unsorted := logic.GroupWashLoads(items)
+	return &LaundryReport{Loads: loads, Unsorted: unsorted}, nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestLaundryUseCase_Cycle(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("tee.avatar"))
+	useCase := NewLaundryUseCase(env.services)
+
+	if err := useCase.Enable(); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if got := env.laundry.Laundry.State("casual", "tee.avatar"); !env.laundry.Laundry.Enabled || got != entities.LaundryWorn {
+		t.Fatalf("after Enable() tee.avatar is %q, want worn as it was worn in the rotation", got)
+	}
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "jeans.avatar")); err != nil && !errors.As(err, new(*domainerrors.RotationCompletedError)) {
+		t.Fatalf("wear error = %v", err)
+	}
+	if got := env.laundry.Laundry.State("casual", "jeans.avatar"); got != entities.LaundryWorn {
+		t.Errorf("after a wear jeans.avatar is %q, want worn", got)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := NewPickOutfitUseCase(env.services).Execute("casual"); !errors.As(err, &invalid) {
+		t.Errorf("pick with nothing clean error = %v, want InvalidInputError", err)
+	}
+
+	washed, err := useCase.Wash(nil)
+	if err != nil || len(washed) != 2 {
+		t.Fatalf("Wash() = %+v, %v; want both worn outfits", washed, err)
+	}
+	if _, err := useCase.Done([]entities.OutfitReference{env.outfit("casual", "jeans.avatar")}); err != nil {
+		t.Fatalf("Done() error = %v", err)
+	}
+	for range 5 {
+		outfit, err := NewPickOutfitUseCase(env.services).Execute("casual")
+		if err != nil || outfit.FileName != "jeans.avatar" {
+			t.Fatalf("pick = %v, %v; want the only clean outfit", outfit, err)
+		}
+	}
+	if _, err := useCase.Wash([]entities.OutfitReference{env.outfit("casual", "jeans.avatar")}); !errors.As(err, &invalid) {
+		t.Errorf("Wash() of a clean outfit error = %v, want InvalidInputError", err)
+	}
+
+	if err := useCase.Disable(); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if _, err := useCase.Done(nil); !errors.As(err, &invalid) {
+		t.Errorf("Done() with tracking off error = %v, want InvalidInputError", err)
+	}
+	if len(env.laundry.Laundry.Outfits) != 0 {
+		t.Errorf("Disable() kept states %v", env.laundry.Laundry.Outfits)
+	}
+}
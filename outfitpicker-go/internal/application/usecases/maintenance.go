@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// MaintenanceReport summarizes what changed while the wardrobe was locked.
+type MaintenanceReport struct {
+	StartedAt time.Time
+	EndedAt   time.Time
+	Changes   logic.WardrobeChanges
+}
+
+// rebuildSteps counts the stores Disable rebuilds: the cache and the known
+// files.
+const rebuildSteps = 2
+
+// MaintenanceUseCase locks the wardrobe for reorganization and reconciles the
+// rotation state once the user is done.
+type MaintenanceUseCase struct {
+	services Services
+}
+
+// NewMaintenanceUseCase creates a new maintenance use case.
+func NewMaintenanceUseCase(services Services) *MaintenanceUseCase {
+	return &MaintenanceUseCase{services: services}
+}
+
+// Status returns the current maintenance state.
+func (u *MaintenanceUseCase) Status() (entities.MaintenanceState, error) {
+	return u.services.Maintenance.Load()
+}
+
+// Enable locks the wardrobe, recording a snapshot of it to diff against when
+// maintenance ends. Enabling an already locked wardrobe keeps the original
+// snapshot.
+func (u *MaintenanceUseCase) Enable() (entities.MaintenanceState, error) {
+	state, err := u.services.Maintenance.Load()
+	if err != nil {
+		return entities.MaintenanceState{}, err
+	}
+	if state.Enabled {
+		return state, nil
+	}
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.MaintenanceState{}, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return entities.MaintenanceState{}, err
+	}
+
+	state = entities.NewMaintenanceState(snapshot, u.services.now())
+	if err := u.services.Maintenance.Save(state); err != nil {
+		return entities.MaintenanceState{}, err
+	}
+	return state, nil
+}
+
+// Disable unlocks the wardrobe after reconciling the cache and known
+// categories with what is now on disk.
+func (u *MaintenanceUseCase) Disable() (*MaintenanceReport, error) {
+	state, err := u.services.Maintenance.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !state.Enabled {
+		return nil, errors.NewInvalidInputError("maintenance mode is not enabled")
+	}
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+
+	u.services.report(entities.OperationRebuild, entities.ProgressStart, 0, rebuildSteps, "")
+	err = retryOnConflict(func() error {
+		cache, err := u.services.Cache.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Cache.Save(logic.ReconcileCache(cache, snapshot))
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.services.report(entities.OperationRebuild, entities.ProgressStep, 1, rebuildSteps, "cache")
+	err = retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Config.Save(withKnownFiles(config, snapshot))
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.services.report(entities.OperationRebuild, entities.ProgressStep, 2, rebuildSteps, "known files")
+	u.services.report(entities.OperationRebuild, entities.ProgressDone, rebuildSteps, rebuildSteps, "")
+	if err := u.services.Maintenance.Save(entities.MaintenanceState{}); err != nil {
+		return nil, err
+	}
+
+	return &MaintenanceReport{
+		StartedAt: state.StartedAt,
+		EndedAt:   u.services.now(),
+		Changes:   logic.DiffSnapshots(state.Snapshot, snapshot),
+	}, nil
+}
+
+// withKnownFiles returns a copy of config whose known categories and files
+// match snapshot.
+func withKnownFiles(config *entities.Config, snapshot entities.WardrobeSnapshot) *entities.Config {
+	updated := *config
+	updated.KnownCategories = make(map[string]bool, len(snapshot))
+	updated.KnownCategoryFiles = make(map[string]map[string]bool, len(snapshot))
+	for category, files := range snapshot {
+		updated.KnownCategories[category] = true
+		known := make(map[string]bool, len(files))
+		for _, file := range files {
+			known[file] = true
+		}
+		updated.KnownCategoryFiles[category] = known
+	}
+	return &updated
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestMaintenanceUseCase_EnableRecordsSnapshot(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config.ExcludedCategories["formal"] = true
+
+	state, err := NewMaintenanceUseCase(env.services).Enable()
+	if err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !state.Enabled || !state.StartedAt.Equal(testNow) {
+		t.Errorf("Enable() = %+v, want enabled at %v", state, testNow)
+	}
+	if !slices.Equal(state.Snapshot["casual"], []string{"a.avatar", "b.avatar"}) {
+		t.Errorf("Snapshot[casual] = %v", state.Snapshot["casual"])
+	}
+	if _, ok := state.Snapshot["formal"]; ok {
+		t.Error("excluded category included in snapshot")
+	}
+}
+
+func TestMaintenanceUseCase_EnableIsIdempotent(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	useCase := NewMaintenanceUseCase(env.services)
+
+	if _, err := useCase.Enable(); err != nil {
+		t.Fatal(err)
+	}
+	writeOutfit(t, env.root, "casual", "b.avatar")
+	state, err := useCase.Enable()
+	if err != nil {
+		t.Fatalf("second Enable() error = %v", err)
+	}
+	if len(state.Snapshot["casual"]) != 1 {
+		t.Errorf("second Enable() replaced the snapshot: %v", state.Snapshot)
+	}
+}
+
+func TestMaintenanceUseCase_DisableReconciles(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "formal": {"suit.avatar"}})
+	env.cache.Cache = env.cache.Cache.
+		Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar")).
+		Updating("formal", entities.NewCategoryCache(1))
+	useCase := NewMaintenanceUseCase(env.services)
+
+	if _, err := useCase.Enable(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Remove(filepath.Join(env.root, "casual", "a.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	writeOutfit(t, env.root, "casual", "c.avatar")
+	writeOutfit(t, env.root, "casual", "d.avatar")
+	if err := os.RemoveAll(filepath.Join(env.root, "formal")); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := useCase.Disable()
+	if err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+
+	if !slices.Equal(report.Changes.RemovedCategories, []string{"formal"}) {
+		t.Errorf("RemovedCategories = %v", report.Changes.RemovedCategories)
+	}
+	if !slices.Equal(report.Changes.AddedOutfits["casual"], []string{"c.avatar", "d.avatar"}) {
+		t.Errorf("AddedOutfits[casual] = %v", report.Changes.AddedOutfits["casual"])
+	}
+	if !slices.Equal(report.Changes.RemovedOutfits["casual"], []string{"a.avatar"}) {
+		t.Errorf("RemovedOutfits[casual] = %v", report.Changes.RemovedOutfits["casual"])
+	}
+
+	casual := env.cache.Cache.Categories["casual"]
+	if casual.TotalOutfits != 3 || len(casual.WornOutfits) != 0 {
+		t.Errorf("casual cache = %+v, want 3 outfits and none worn", casual)
+	}
+	if _, ok := env.cache.Cache.Categories["formal"]; ok {
+		t.Error("removed category still cached")
+	}
+	if !env.config.Config.KnownCategoryFiles["casual"]["d.avatar"] || env.config.Config.KnownCategories["formal"] {
+		t.Errorf("known files not updated: %+v", env.config.Config.KnownCategoryFiles)
+	}
+	if env.maintenance.State.Enabled {
+		t.Error("maintenance still enabled after Disable()")
+	}
+}
+
+func TestMaintenanceUseCase_DisableWhenNotEnabled(t *testing.T) {
+	env := newTestEnv(t, nil)
+
+	_, err := NewMaintenanceUseCase(env.services).Disable()
+
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Errorf("Disable() error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestMaintenanceUseCase_DisableReportsProgress(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "formal": {"suit.avatar"}})
+	var events []entities.ProgressEvent
+	env.services.Progress = func(event entities.ProgressEvent) { events = append(events, event) }
+	useCase := NewMaintenanceUseCase(env.services)
+	if _, err := useCase.Enable(); err != nil {
+		t.Fatal(err)
+	}
+	events = nil
+
+	if _, err := useCase.Disable(); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	want := []entities.ProgressEvent{
+		{Operation: entities.OperationScan, Phase: entities.ProgressStart, Total: 2},
+		{Operation: entities.OperationScan, Phase: entities.ProgressStep, Current: 1, Total: 2, Item: "casual"},
+		{Operation: entities.OperationScan, Phase: entities.ProgressStep, Current: 2, Total: 2, Item: "formal"},
+		{Operation: entities.OperationScan, Phase: entities.ProgressDone, Current: 2, Total: 2},
+		{Operation: entities.OperationRebuild, Phase: entities.ProgressStart, Total: 2},
+		{Operation: entities.OperationRebuild, Phase: entities.ProgressStep, Current: 1, Total: 2, Item: "cache"},
+		{Operation: entities.OperationRebuild, Phase: entities.ProgressStep, Current: 2, Total: 2, Item: "known files"},
+		{Operation: entities.OperationRebuild, Phase: entities.ProgressDone, Current: 2, Total: 2},
+	}
+	if !slices.Equal(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
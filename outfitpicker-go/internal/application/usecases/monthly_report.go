@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxNeglectedOutfits bounds how many neglected outfits a monthly report
+// lists; NeglectedTotal still counts all of them.
+const MaxNeglectedOutfits = 10
+
+// MonthlyReport summarizes one calendar month of picks and wears.
+type MonthlyReport struct {
+	// Month is midnight on the first day of the month.
+	Month              time.Time            `json:"month"`
+	Picks              int                  `json:"picks"`
+	PicksByCategory    []CategoryCount      `json:"picksByCategory"`
+	Wears              int                  `json:"wears"`
+	CompletedRotations []CompletedRotation  `json:"completedRotations"`
+	Neglected          []NeglectedOutfit    `json:"neglected"`
+	NeglectedTotal     int                  `json:"neglectedTotal"`
+	SpendPerWear       []OutfitSpendPerWear `json:"spendPerWear"`
+}
+
+// CategoryCount is a number of events in one category.
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// CompletedRotation records a wear that finished a category's rotation.
+type CompletedRotation struct {
+	Category    string    `json:"category"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// NeglectedOutfit is an outfit that was not worn during the month.
+type NeglectedOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	// LastWorn is nil when the outfit has never been worn.
+	LastWorn *time.Time `json:"lastWorn,omitempty"`
+}
+
+// OutfitSpendPerWear divides an outfit's price by every wear up to the end
+// of the month.
+type OutfitSpendPerWear struct {
+	Category      string  `json:"category"`
+	FileName      string  `json:"fileName"`
+	Price         float64 `json:"price"`
+	Wears         int     `json:"wears"`
+	PerWear       float64 `json:"perWear"`
+	WornThisMonth int     `json:"wornThisMonth"`
+}
+
+// End returns midnight on the first day of the following month.
+func (r MonthlyReport) End() time.Time {
+	return r.Month.AddDate(0, 1, 0)
+}
+
+// MonthlyReportUseCase builds monthly reports and emails them.
+type MonthlyReportUseCase struct {
+	services Services
+}
+
+// NewMonthlyReportUseCase creates a new monthly report use case.
+func NewMonthlyReportUseCase(services Services) *MonthlyReportUseCase {
+	return &MonthlyReportUseCase{services: services}
+}
+
+// Generate reports on the calendar month containing month, or on the
+// previous calendar month when month is zero.
+func (u *MonthlyReportUseCase) Generate(month time.Time) (*MonthlyReport, error) {
+	if month.IsZero() {
+		month = u.services.now().AddDate(0, -1, 0)
+	}
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	report := &MonthlyReport{Month: start}
+	end := report.End()
+	inMonth := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return nil, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+
+	picks := make(map[string]int)
+	for _, record := range history.Records {
+		if inMonth(record.SelectedAt) {
+			report.Picks++
+			picks[record.Category]++
+		}
+	}
+	for category, count := range picks {
+		report.PicksByCategory = append(report.PicksByCategory, CategoryCount{Category: category, Count: count})
+	}
+	slices.SortFunc(report.PicksByCategory, func(a, b CategoryCount) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.Category, b.Category))
+	})
+
+	type outfitKey struct{ category, fileName string }
+	lastWorn := make(map[outfitKey]time.Time)
+	totalWears := make(map[outfitKey]int)
+	monthWears := make(map[outfitKey]int)
+	for _, event := range log.Events {
+		if !event.WornAt.Before(end) {
+			continue
+		}
+		key := outfitKey{event.Category, event.FileName}
+		totalWears[key]++
+		if event.WornAt.After(lastWorn[key]) {
+			lastWorn[key] = event.WornAt
+		}
+		if !inMonth(event.WornAt) {
+			continue
+		}
+		report.Wears++
+		monthWears[key]++
+		if event.CompletedRotation {
+			report.CompletedRotations = append(report.CompletedRotations, CompletedRotation{Category: event.Category, CompletedAt: event.WornAt})
+		}
+	}
+
+	for category, files := range snapshot {
+		for _, file := range files {
+			key := outfitKey{category, file}
+			if monthWears[key] == 0 {
+				neglected := NeglectedOutfit{Category: category, FileName: file}
+				if worn, ok := lastWorn[key]; ok {
+					neglected.LastWorn = &worn
+				}
+				report.Neglected = append(report.Neglected, neglected)
+				continue
+			}
+			metadata, _ := index.Get(category, file)
+			if metadata.Price > 0 {
+				report.SpendPerWear = append(report.SpendPerWear, OutfitSpendPerWear{
+					Category:      category,
+					FileName:      file,
+					Price:         metadata.Price,
+					Wears:         totalWears[key],
+					PerWear:       metadata.Price / float64(totalWears[key]),
+					WornThisMonth: monthWears[key],
+				})
+			}
+		}
+	}
+
+	// Never-worn outfits come first, then the longest unworn.
+	slices.SortFunc(report.Neglected, func(a, b NeglectedOutfit) int {
+		switch {
+		case a.LastWorn == nil && b.LastWorn != nil:
+			return -1
+		case a.LastWorn != nil && b.LastWorn == nil:
+			return 1
+		case a.LastWorn != nil && !a.LastWorn.Equal(*b.LastWorn):
+			return a.LastWorn.Compare(*b.LastWorn)
+		}
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.FileName, b.FileName))
+	})
+	report.NeglectedTotal = len(report.Neglected)
+	if len(report.Neglected) > MaxNeglectedOutfits {
+		report.Neglected = report.Neglected[:MaxNeglectedOutfits]
+	}
+	slices.SortFunc(report.SpendPerWear, func(a, b OutfitSpendPerWear) int {
+		return cmp.Or(cmp.Compare(b.PerWear, a.PerWear), cmp.Compare(a.Category, b.Category), cmp.Compare(a.FileName, b.FileName))
+	})
+	return report, nil
+}
+
+// Settings returns the configured report settings.
+func (u *MonthlyReportUseCase) Settings() (entities.ReportSettings, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.ReportSettings{}, err
+	}
+	return config.Reports, nil
+}
+
+// Send emails message through the configured SMTP server.
+func (u *MonthlyReportUseCase) Send(message entities.MailMessage) error {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return err
+	}
+	if config.Reports.SMTP == nil {
+		return errors.NewInvalidInputError("no SMTP server is configured; run 'outfitpicker report configure' first")
+	}
+	return u.services.Mailer.Send(*config.Reports.SMTP, message)
+}
+
+// Configure replaces the report settings with change(current) and saves the
+// configuration, reapplying change if another writer saved first.
+func (u *MonthlyReportUseCase) Configure(change func(current entities.ReportSettings) entities.ReportSettings) (entities.ReportSettings, error) {
+	var saved entities.ReportSettings
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetReports(change(config.Reports)); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config.Reports
+		return nil
+	})
+	return saved, err
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func may(day int) time.Time {
+	return time.Date(2024, 5, day, 8, 0, 0, 0, time.UTC)
+}
+
+func TestMonthlyReportUseCase_Generate(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"},
+		"work":   {"suit.avatar", "blazer.avatar"},
+	})
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: may(2)},
+		{Category: "casual", FileName: "jeans.avatar", SelectedAt: may(3)},
+		{Category: "work", FileName: "suit.avatar", SelectedAt: may(4)},
+		{Category: "work", FileName: "suit.avatar", SelectedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}}
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "work", FileName: "suit.avatar", WornAt: time.Date(2024, 4, 10, 8, 0, 0, 0, time.UTC)},
+		{Category: "casual", FileName: "tee.avatar", WornAt: time.Date(2024, 4, 20, 8, 0, 0, 0, time.UTC)},
+		{Category: "work", FileName: "suit.avatar", WornAt: may(4)},
+		{Category: "work", FileName: "blazer.avatar", WornAt: may(5), CompletedRotation: true},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)},
+	}}
+	env.metadata.Index = env.metadata.Index.
+		Setting("work", "suit.avatar", entities.OutfitMetadata{Price: 300}).
+		Setting("work", "blazer.avatar", entities.OutfitMetadata{Price: 120}).
+		Setting("casual", "hoodie.avatar", entities.OutfitMetadata{Price: 50})
+
+	report, err := NewMonthlyReportUseCase(env.services).Generate(time.Time{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !report.Month.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Month = %v, want May 2024", report.Month)
+	}
+	if report.Picks != 3 || len(report.PicksByCategory) != 2 || report.PicksByCategory[0] != (CategoryCount{"casual", 2}) {
+		t.Errorf("picks = %d %+v", report.Picks, report.PicksByCategory)
+	}
+	if report.Wears != 2 {
+		t.Errorf("Wears = %d, want 2", report.Wears)
+	}
+	if len(report.CompletedRotations) != 1 || report.CompletedRotations[0].Category != "work" {
+		t.Errorf("CompletedRotations = %+v", report.CompletedRotations)
+	}
+
+	var neglected []string
+	for _, outfit := range report.Neglected {
+		neglected = append(neglected, outfit.FileName)
+	}
+	if want := []string{"hoodie.avatar", "jeans.avatar", "tee.avatar"}; !slices.Equal(neglected, want) {
+		t.Errorf("Neglected = %v, want %v", neglected, want)
+	}
+	if report.Neglected[2].LastWorn == nil || report.NeglectedTotal != 3 {
+		t.Errorf("tee last worn = %v, total = %d", report.Neglected[2].LastWorn, report.NeglectedTotal)
+	}
+
+	want := []OutfitSpendPerWear{
+		{Category: "work", FileName: "suit.avatar", Price: 300, Wears: 2, PerWear: 150, WornThisMonth: 1},
+		{Category: "work", FileName: "blazer.avatar", Price: 120, Wears: 1, PerWear: 120, WornThisMonth: 1},
+	}
+	if len(report.SpendPerWear) != len(want) || report.SpendPerWear[0] != want[0] || report.SpendPerWear[1] != want[1] {
+		t.Errorf("SpendPerWear = %+v, want %+v", report.SpendPerWear, want)
+	}
+}
+
+func TestMonthlyReportUseCase_CapsNeglected(t *testing.T) {
+	files := make([]string, MaxNeglectedOutfits+5)
+	for i := range files {
+		files[i] = string(rune('a'+i)) + ".avatar"
+	}
+	env := newTestEnv(t, map[string][]string{"casual": files})
+
+	report, err := NewMonthlyReportUseCase(env.services).Generate(may(1))
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(report.Neglected) != MaxNeglectedOutfits || report.NeglectedTotal != len(files) {
+		t.Errorf("neglected = %d of %d", len(report.Neglected), report.NeglectedTotal)
+	}
+}
+
+func TestMonthlyReportUseCase_Send(t *testing.T) {
+	env := newValidatedTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewMonthlyReportUseCase(env.services)
+	message := entities.MailMessage{Subject: "May", Body: "report"}
+
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Send(message); !errors.As(err, &invalid) {
+		t.Errorf("Send() without SMTP error = %v, want InvalidInputError", err)
+	}
+
+	server := entities.SMTPSettings{Host: "smtp.example.com", Port: 587, From: "me@example.com", To: []string{"me@example.com"}}
+	if _, err := useCase.Configure(func(current entities.ReportSettings) entities.ReportSettings {
+		current.SMTP = &server
+		return current
+	}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if err := useCase.Send(message); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if len(env.mailer.Sent) != 1 || env.mailer.Server.Host != "smtp.example.com" {
+		t.Errorf("mailer = %+v", env.mailer)
+	}
+
+	if _, err := NewSetupUseCase(env.services).Execute(SetupRequest{Language: "de"}); err != nil {
+		t.Fatal(err)
+	}
+	if env.config.Config.Reports.SMTP == nil {
+		t.Error("setup dropped the report settings")
+	}
+}
+
+func TestMonthlyReportUseCase_ConfigureRejectsInvalidSettings(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	_, err := NewMonthlyReportUseCase(env.services).Configure(func(current entities.ReportSettings) entities.ReportSettings {
+		current.Format = "pdf"
+		return current
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Configure() error = %v, want ErrInvalidConfiguration", err)
+	}
+	if env.config.Config.Reports.Format != "" {
+		t.Errorf("format saved despite error: %q", env.config.Config.Reports.Format)
+	}
+}
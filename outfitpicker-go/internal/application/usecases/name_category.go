@@ -0,0 +1,35 @@
+package usecases
+
+import "github.com/dh85/outfitpicker/internal/domain/entities"
+
+// NameCategoryUseCase changes the labels and aliases a category answers to.
+type NameCategoryUseCase struct {
+	services Services
+}
+
+// NewNameCategoryUseCase creates a new name category use case.
+func NewNameCategoryUseCase(services Services) *NameCategoryUseCase {
+	return &NameCategoryUseCase{services: services}
+}
+
+// Execute replaces the category's names with change(current) and saves the
+// configuration, reapplying change if another writer saved first. It returns
+// the saved configuration.
+func (u *NameCategoryUseCase) Execute(category string, change func(current entities.CategoryNames) entities.CategoryNames) (*entities.Config, error) {
+	var saved *entities.Config
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetNames(category, change(config.Names(category))); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config
+		return nil
+	})
+	return saved, err
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// OccasionsUseCase reads and changes the configured occasions and turns
+// them into picks.
+type OccasionsUseCase struct {
+	services Services
+}
+
+// NewOccasionsUseCase creates a new occasions use case.
+func NewOccasionsUseCase(services Services) *OccasionsUseCase {
+	return &OccasionsUseCase{services: services}
+}
+
+// List returns the configured occasions by name.
+func (u *OccasionsUseCase) List() (map[string]entities.Occasion, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return config.Occasions, nil
+}
+
+// Set adds or replaces the named occasion and saves the configuration.
+func (u *OccasionsUseCase) Set(name string, occasion entities.Occasion) error {
+	if occasion.IsEmpty() {
+		return errors.NewInvalidInputError(fmt.Sprintf("occasion %q needs categories, tags or both", name))
+	}
+	return u.update(name, occasion)
+}
+
+// Remove removes the named occasion and saves the configuration.
+func (u *OccasionsUseCase) Remove(name string) error {
+	return u.update(name, entities.Occasion{})
+}
+
+func (u *OccasionsUseCase) update(name string, occasion entities.Occasion) error {
+	return retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if _, ok := config.Occasions[name]; !ok && occasion.IsEmpty() {
+			return unknownOccasion(name)
+		}
+		if err := config.SetOccasion(name, occasion); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+}
+
+// Target returns where picks for the named occasion choose from and the
+// pick options limiting them to the occasion's tags.
+func (u *OccasionsUseCase) Target(name string) (entities.SelectionTarget, []PickOption, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	occasion, ok := config.Occasions[name]
+	if !ok {
+		return nil, nil, unknownOccasion(name)
+	}
+	categories := make([]entities.CategoryReference, 0, len(occasion.Categories))
+	for _, category := range occasion.Categories {
+		reference, err := u.services.categoryReference(config, category)
+		if err != nil {
+			return nil, nil, err
+		}
+		categories = append(categories, reference)
+	}
+	var opts []PickOption
+	if len(occasion.Tags) > 0 {
+		opts = append(opts, WithAnyTag(occasion.Tags...))
+	}
+	return occasion.Target(categories), opts, nil
+}
+
+func unknownOccasion(name string) error {
+	return errors.NewInvalidInputError(fmt.Sprintf("no occasion named %q; add one with: occasion set %s --categories ... --tags ...", name, name))
+}
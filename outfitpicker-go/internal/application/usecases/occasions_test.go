@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func newOccasionEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar"},
+		"formal": {"suit.avatar", "tux.avatar"},
+		"work":   {"blazer.avatar", "hoodie.avatar"},
+	})
+	env.metadata.Index = env.metadata.Index.
+		Setting("formal", "suit.avatar", entities.OutfitMetadata{Tags: []string{"smart"}}).
+		Setting("work", "blazer.avatar", entities.OutfitMetadata{Tags: []string{"smart"}}).
+		Setting("casual", "tee.avatar", entities.OutfitMetadata{Tags: []string{"relaxed"}})
+	return env
+}
+
+func TestOccasionsUseCase_SetAndRemove(t *testing.T) {
+	env := newOccasionEnv(t)
+	useCase := NewOccasionsUseCase(env.services)
+
+	if err := useCase.Set("interview", entities.Occasion{Categories: []string{"work", "formal"}, Tags: []string{"smart"}}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	occasions, err := useCase.List()
+	if err != nil || len(occasions["interview"].Categories) != 2 {
+		t.Fatalf("List() = %+v, %v, want interview", occasions, err)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Set("interview", entities.Occasion{}); !errors.As(err, &invalid) {
+		t.Errorf("Set() of an empty occasion error = %v, want InvalidInputError", err)
+	}
+	if err := useCase.Set("Date Night", entities.Occasion{Tags: []string{"dressy"}}); !errors.As(err, &invalid) {
+		t.Errorf("Set() of a bad name error = %v, want InvalidInputError", err)
+	}
+	if err := useCase.Remove("interview"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := useCase.Remove("interview"); !errors.As(err, &invalid) {
+		t.Errorf("Remove() of an unknown occasion error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestOccasionsUseCase_Target(t *testing.T) {
+	env := newOccasionEnv(t)
+	env.config.Config.Occasions = map[string]entities.Occasion{
+		"interview": {Categories: []string{"work", "formal"}, Tags: []string{"smart"}},
+		"office":    {Categories: []string{"work"}},
+		"chill":     {Tags: []string{"relaxed"}},
+	}
+	useCase := NewOccasionsUseCase(env.services)
+
+	target, opts, err := useCase.Target("interview")
+	if err != nil {
+		t.Fatalf("Target() error = %v", err)
+	}
+	if categories, ok := target.(entities.SelectionTargetCategories); !ok || len(categories.Categories) != 2 || len(opts) != 1 {
+		t.Errorf("Target(interview) = %#v with %d options, want two categories and a tag option", target, len(opts))
+	}
+	if target, _, _ := useCase.Target("office"); target.(entities.SelectionTargetCategory).Category.Name != "work" {
+		t.Errorf("Target(office) = %#v, want work", target)
+	}
+	if target, _, _ := useCase.Target("chill"); target != (entities.SelectionTargetAllCategories{}) {
+		t.Errorf("Target(chill) = %#v, want every category", target)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if _, _, err := useCase.Target("wedding"); !errors.As(err, &invalid) {
+		t.Errorf("Target() of an unknown occasion error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickAnyOutfitUseCase_ProposeFor(t *testing.T) {
+	env := newOccasionEnv(t)
+	env.config.Config.Occasions = map[string]entities.Occasion{
+		"interview": {Categories: []string{"work", "formal"}, Tags: []string{"smart"}},
+		"office":    {Categories: []string{"work"}, Tags: []string{"smart"}},
+		"chill":     {Tags: []string{"relaxed"}},
+	}
+	occasions := NewOccasionsUseCase(env.services)
+	pick := NewPickAnyOutfitUseCase(env.services)
+
+	for seed := range uint64(10) {
+		target, opts, err := occasions.Target("interview")
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := pick.ProposeFor(target, append(opts, WithPickSeed(seed))...)
+		if err != nil {
+			t.Fatalf("ProposeFor() error = %v", err)
+		}
+		if got := result.Outfit.Category.Name + "/" + result.Outfit.FileName; got != "work/blazer.avatar" && got != "formal/suit.avatar" {
+			t.Errorf("ProposeFor(interview) = %s, want a smart outfit from work or formal", got)
+		}
+	}
+
+	for name, want := range map[string]string{"office": "work/blazer.avatar", "chill": "casual/tee.avatar"} {
+		target, opts, err := occasions.Target(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := pick.ProposeFor(target, opts...)
+		if err != nil {
+			t.Fatalf("ProposeFor(%s) error = %v", name, err)
+		}
+		if got := result.Outfit.Category.Name + "/" + result.Outfit.FileName; got != want {
+			t.Errorf("ProposeFor(%s) = %s, want %s", name, got, want)
+		}
+		if err := pick.Commit(result); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+	}
+	if len(env.history.History.Records) != 2 {
+		t.Errorf("history = %+v, want the two committed picks", env.history.History.Records)
+	}
+}
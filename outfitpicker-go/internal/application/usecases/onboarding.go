@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// OnboardingStatusRequired is the guidance status reported before the first
+// configuration has been saved.
+const OnboardingStatusRequired = "onboarding_required"
+
+// OnboardingStep is one next step shown to a first-time user.
+type OnboardingStep struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Command     string `json:"command,omitempty"`
+}
+
+// OnboardingGuidance tells a first-time user, or a script acting for one,
+// how to get started.
+type OnboardingGuidance struct {
+	Status     string           `json:"status"`
+	ConfigPath string           `json:"configPath"`
+	Steps      []OnboardingStep `json:"steps"`
+}
+
+// OnboardingResult summarizes a completed first-run setup.
+type OnboardingResult struct {
+	Config     *entities.Config
+	Categories []entities.CategoryInfo
+}
+
+// OutfitCount returns the number of outfits found across all categories.
+func (r OnboardingResult) OutfitCount() int {
+	total := 0
+	for _, info := range r.Categories {
+		total += info.OutfitCount
+	}
+	return total
+}
+
+// OnboardingUseCase detects a first run and saves the initial configuration.
+type OnboardingUseCase struct {
+	services Services
+}
+
+// NewOnboardingUseCase creates a new onboarding use case.
+func NewOnboardingUseCase(services Services) *OnboardingUseCase {
+	return &OnboardingUseCase{services: services}
+}
+
+// Required reports whether no configuration has been saved yet.
+func (u *OnboardingUseCase) Required() (bool, error) {
+	_, err := u.services.Config.Load()
+	if errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+// Guidance returns the steps a first-time user should follow.
+func (u *OnboardingUseCase) Guidance() (OnboardingGuidance, error) {
+	path, err := u.services.Config.Path()
+	if err != nil {
+		return OnboardingGuidance{}, err
+	}
+	return OnboardingGuidance{
+		Status:     OnboardingStatusRequired,
+		ConfigPath: path,
+		Steps: []OnboardingStep{
+			{
+				ID:          "create-wardrobe",
+				Description: "Create a wardrobe directory with one sub-directory per category, each holding .avatar outfit files",
+			},
+			{
+				ID:          "init",
+				Description: "Save the configuration pointing at the wardrobe directory",
+				Command:     "outfitpicker init --root <wardrobe-directory>",
+			},
+			{
+				ID:          "list",
+				Description: "Check that your categories were found",
+				Command:     "outfitpicker list",
+			},
+		},
+	}, nil
+}
+
+// Complete saves config as the initial configuration after checking that its
+// root can be scanned. Every category found is recorded as known.
+func (u *OnboardingUseCase) Complete(config *entities.Config) (*OnboardingResult, error) {
+	infos, err := u.services.categories(config)
+	if errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		return nil, wardrobeNotFound(config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	config = withKnownFiles(config, snapshot)
+	if err := u.services.Config.Save(config); err != nil {
+		return nil, err
+	}
+	return &OnboardingResult{Config: config, Categories: infos}, nil
+}
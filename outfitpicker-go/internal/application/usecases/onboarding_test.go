@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestOnboardingUseCase_Required(t *testing.T) {
+	env := newTestEnv(t, nil)
+	useCase := NewOnboardingUseCase(env.services)
+
+	if required, err := useCase.Required(); err != nil || required {
+		t.Errorf("Required() = %v, %v; want false with config present", required, err)
+	}
+
+	env.config.Config = nil
+	if required, err := useCase.Required(); err != nil || !required {
+		t.Errorf("Required() = %v, %v; want true without config", required, err)
+	}
+
+	env.config.LoadErr = domainerrors.ErrFileSystem
+	if _, err := useCase.Required(); !errors.Is(err, domainerrors.ErrFileSystem) {
+		t.Errorf("Required() error = %v, want ErrFileSystem", err)
+	}
+}
+
+func TestOnboardingUseCase_Guidance(t *testing.T) {
+	env := newTestEnv(t, nil)
+
+	guidance, err := NewOnboardingUseCase(env.services).Guidance()
+	if err != nil {
+		t.Fatalf("Guidance() error = %v", err)
+	}
+	if guidance.Status != OnboardingStatusRequired || guidance.ConfigPath == "" {
+		t.Errorf("Guidance() = %+v", guidance)
+	}
+	if len(guidance.Steps) == 0 || guidance.Steps[1].Command == "" {
+		t.Errorf("Guidance().Steps = %+v", guidance.Steps)
+	}
+}
+
+func TestOnboardingUseCase_Complete(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config = nil
+
+	result, err := NewOnboardingUseCase(env.services).Complete(testhelpers.NewConfig(env.root))
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if len(result.Categories) != 2 || result.OutfitCount() != 3 {
+		t.Errorf("Complete() = %+v, want 2 categories and 3 outfits", result)
+	}
+	saved := env.config.Config
+	if saved == nil || !saved.KnownCategories["casual"] || !saved.KnownCategoryFiles["formal"]["suit.avatar"] {
+		t.Errorf("saved config = %+v", saved)
+	}
+}
+
+func TestOnboardingUseCase_CompleteMissingRoot(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Config = nil
+
+	_, err := NewOnboardingUseCase(env.services).Complete(testhelpers.NewConfig(filepath.Join(env.root, "missing")))
+
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Errorf("Complete() error = %v, want InvalidInputError", err)
+	}
+	if env.config.Config != nil {
+		t.Error("configuration saved despite missing root")
+	}
+}
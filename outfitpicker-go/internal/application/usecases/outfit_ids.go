@@ -0,0 +1,383 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// OutfitIDSync reports what a sync of outfit IDs did.
+type OutfitIDSync struct {
+	Scheme string `json:"scheme"`
+	// Outfits counts the outfits with an ID.
+	Outfits int `json:"outfits"`
+	// Assigned counts the outfits given a new ID.
+	Assigned int `json:"assigned"`
+	// Moves lists the outfits found renamed or moved, whose rotation state,
+	// metadata, weight, favorite mark and history followed them.
+	Moves []entities.OutfitMove `json:"moves,omitempty"`
+	// Linked counts the picks and wears in the history newly tied to their
+	// outfit's ID.
+	Linked int `json:"linked"`
+}
+
+// OutfitIDResolution is an outfit ID with where its outfit is.
+type OutfitIDResolution struct {
+	ID       string                  `json:"id"`
+	Location entities.OutfitLocation `json:"location"`
+}
+
+// OutfitIDsUseCase gives outfits stable IDs that follow them when their
+// files are renamed or moved.
+type OutfitIDsUseCase struct {
+	services Services
+}
+
+// NewOutfitIDsUseCase creates a new outfit IDs use case.
+func NewOutfitIDsUseCase(services Services) *OutfitIDsUseCase {
+	return &OutfitIDsUseCase{services: services}
+}
+
+// Migrate turns outfit IDs on with scheme, or switches the scheme new IDs
+// are made with, then syncs so every outfit and its history has an ID. IDs
+// already assigned never change.
+func (u *OutfitIDsUseCase) Migrate(scheme string) (*OutfitIDSync, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if config.OutfitIDs.Scheme == scheme {
+			return nil
+		}
+		if err := config.SetOutfitIDs(entities.OutfitIDSettings{Scheme: scheme}); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return u.Sync()
+}
+
+// Sync fingerprints every outfit in the categories that are not excluded
+// and matches the result to the known IDs: new outfits get an ID, and
+// outfits renamed or moved since the last sync keep theirs, with their
+// state moved to the new file name. Picks and wears recorded before their
+// outfit had an ID are tied to it.
+func (u *OutfitIDsUseCase) Sync() (*OutfitIDSync, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.enabledConfig()
+	if err != nil {
+		return nil, err
+	}
+	scheme, err := logic.NewOutfitIDScheme(config.OutfitIDs.Scheme, rand.Reader)
+	if err != nil {
+		return nil, errors.MapError(err)
+	}
+	scanned, err := u.fingerprints(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciled logic.OutfitIDReconciliation
+	err = retryOnConflict(func() error {
+		index, err := u.services.Metadata.Load()
+		if err != nil {
+			return err
+		}
+		reconciled, err = logic.ReconcileOutfitIDs(index.IDs, scanned, scheme)
+		if err != nil {
+			return err
+		}
+		for _, move := range reconciled.Moves {
+			index = index.Moving(move.From, move.To)
+		}
+		return u.services.Metadata.Save(index.WithIDs(reconciled.IDs))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := u.moveState(reconciled.Moves); err != nil {
+		return nil, err
+	}
+	linked, err := u.linkHistory(reconciled)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OutfitIDSync{Scheme: config.OutfitIDs.Scheme, Assigned: reconciled.Assigned, Moves: reconciled.Moves, Linked: linked}
+	for _, identities := range reconciled.IDs {
+		result.Outfits += len(identities)
+	}
+	return result, nil
+}
+
+// Resolve looks up an outfit by its ID, a unique prefix of it, or its
+// category/file name, and returns its ID and where it is.
+func (u *OutfitIDsUseCase) Resolve(ref string) (OutfitIDResolution, error) {
+	if _, err := u.enabledConfig(); err != nil {
+		return OutfitIDResolution{}, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return OutfitIDResolution{}, err
+	}
+	if categoryName, fileName, ok := strings.Cut(ref, "/"); ok {
+		category, err := NewResolveCategoryUseCase(u.services).Execute(categoryName)
+		if err != nil {
+			return OutfitIDResolution{}, err
+		}
+		location := entities.OutfitLocation{Category: category.Name, FileName: fileName}
+		id, ok := index.OutfitID(location.Category, location.FileName)
+		if !ok {
+			return OutfitIDResolution{}, errors.NewInvalidInputError(fmt.Sprintf("%s has no outfit ID yet; run ids sync", location))
+		}
+		return OutfitIDResolution{ID: id, Location: location}, nil
+	}
+	id, location, ok := index.LocateOutfit(ref)
+	if !ok {
+		return OutfitIDResolution{}, errors.NewInvalidInputError(fmt.Sprintf("no outfit has the ID %q", ref))
+	}
+	return OutfitIDResolution{ID: id, Location: location}, nil
+}
+
+// enabledConfig loads the configuration, failing when outfit IDs are off.
+func (u *OutfitIDsUseCase) enabledConfig() (*entities.Config, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !config.OutfitIDs.Enabled() {
+		return nil, errors.NewInvalidInputError("outfit IDs are off; turn them on with ids migrate")
+	}
+	return config, nil
+}
+
+// fingerprints maps every category that is not excluded to the content
+// fingerprints of its outfits by file name.
+func (u *OutfitIDsUseCase) fingerprints(config *entities.Config) (map[string]map[string]string, error) {
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	scanned := make(map[string]map[string]string, len(infos))
+	for _, info := range infos {
+		if info.State == entities.CategoryStateUserExcluded {
+			continue
+		}
+		files, err := u.services.outfitsIn(config, info.Category)
+		if err != nil {
+			return nil, err
+		}
+		fingerprints := make(map[string]string, len(files))
+		for _, file := range files {
+			fingerprint, err := u.services.Hasher.Hash(filepath.Join(info.Category.Path, file.FileName))
+			if err != nil {
+				return nil, err
+			}
+			fingerprints[file.FileName] = fingerprint
+		}
+		scanned[info.Category.Name] = fingerprints
+	}
+	return scanned, nil
+}
+
+// moveState moves the rotation state, weights, favorite marks, skips,
+// laundry states and sightings of moved outfits to their new file names.
+// An outfit moved to another category starts unworn in that category's
+// rotation.
+func (u *OutfitIDsUseCase) moveState(moves []entities.OutfitMove) error {
+	if len(moves) == 0 {
+		return nil
+	}
+	for _, move := range moves {
+		err := u.services.Cache.UpdateCategory(move.From.Category, func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+			if !exists || !current.WornOutfits[move.From.FileName] {
+				return current, nil
+			}
+			updated := current.Removing(move.From.FileName)
+			if move.To.Category == move.From.Category {
+				updated = updated.Adding(move.To.FileName)
+			}
+			return updated, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	err := retryOnConflict(func() error {
+		weights, err := u.services.Weights.Load()
+		if err != nil {
+			return err
+		}
+		for _, move := range moves {
+			weights = weights.Moving(move.From, move.To)
+		}
+		return u.services.Weights.Save(weights)
+	})
+	if err != nil {
+		return err
+	}
+	err = retryOnConflict(func() error {
+		favorites, err := u.services.Favorites.Load()
+		if err != nil {
+			return err
+		}
+		for _, move := range moves {
+			favorites = favorites.Moving(move.From, move.To)
+		}
+		return u.services.Favorites.Save(favorites)
+	})
+	if err != nil {
+		return err
+	}
+	err = retryOnConflict(func() error {
+		skipped, err := u.services.Skipped.Load()
+		if err != nil {
+			return err
+		}
+		for _, move := range moves {
+			skipped = skipped.Moving(move.From, move.To)
+		}
+		return u.services.Skipped.Save(skipped)
+	})
+	if err != nil {
+		return err
+	}
+	err = retryOnConflict(func() error {
+		laundry, err := u.services.Laundry.Load()
+		if err != nil {
+			return err
+		}
+		for _, move := range moves {
+			laundry = laundry.Moving(move.From, move.To)
+		}
+		return u.services.Laundry.Save(laundry)
+	})
+	if err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		arrivals, err := u.services.Arrivals.Load()
+		if err != nil {
+			return err
+		}
+		for _, move := range moves {
+			arrivals = arrivals.Moving(move.From, move.To)
+		}
+		return u.services.Arrivals.Save(arrivals)
+	})
+}
+
+// linkHistory ties picks and wears to the IDs of their outfits, following
+// moves, and returns how many were newly tied.
+func (u *OutfitIDsUseCase) linkHistory(reconciled logic.OutfitIDReconciliation) (int, error) {
+	linker := newOutfitLinker(reconciled)
+	var linkedPicks, linkedWears int
+	err := retryOnConflict(func() error {
+		history, err := u.services.History.Load()
+		if err != nil {
+			return err
+		}
+		history.Records = slices.Clone(history.Records)
+		changed := false
+		linkedPicks = 0
+		for i, record := range history.Records {
+			location, id := linker.link(entities.OutfitLocation{Category: record.Category, FileName: record.FileName}, record.OutfitID)
+			if location.Category != record.Category || location.FileName != record.FileName || id != record.OutfitID {
+				history.Records[i].Category, history.Records[i].FileName, history.Records[i].OutfitID = location.Category, location.FileName, id
+				changed = true
+			}
+			if record.OutfitID == "" && id != "" {
+				linkedPicks++
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return u.services.History.Save(history)
+	})
+	if err != nil {
+		return 0, err
+	}
+	err = retryOnConflict(func() error {
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return err
+		}
+		log.Events = slices.Clone(log.Events)
+		changed := false
+		linkedWears = 0
+		for i, event := range log.Events {
+			location, id := linker.link(entities.OutfitLocation{Category: event.Category, FileName: event.FileName}, event.OutfitID)
+			if location.Category != event.Category || location.FileName != event.FileName || id != event.OutfitID {
+				log.Events[i].Category, log.Events[i].FileName, log.Events[i].OutfitID = location.Category, location.FileName, id
+				changed = true
+			}
+			if event.OutfitID == "" && id != "" {
+				linkedWears++
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return u.services.WearLog.Save(log)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return linkedPicks + linkedWears, nil
+}
+
+// outfitLinker finds the ID and current location of the outfit a history
+// entry refers to.
+type outfitLinker struct {
+	ids       map[string]map[string]entities.OutfitIdentity
+	movedTo   map[string]entities.OutfitLocation
+	movedFrom map[entities.OutfitLocation]entities.OutfitMove
+}
+
+func newOutfitLinker(reconciled logic.OutfitIDReconciliation) outfitLinker {
+	linker := outfitLinker{
+		ids:       reconciled.IDs,
+		movedTo:   make(map[string]entities.OutfitLocation, len(reconciled.Moves)),
+		movedFrom: make(map[entities.OutfitLocation]entities.OutfitMove, len(reconciled.Moves)),
+	}
+	for _, move := range reconciled.Moves {
+		linker.movedTo[move.ID] = move.To
+		linker.movedFrom[move.From] = move
+	}
+	return linker
+}
+
+// link returns where the outfit of a history entry at location, with the
+// given ID if it has one, is now and its ID, which is empty for outfits
+// without one.
+func (l outfitLinker) link(location entities.OutfitLocation, id string) (entities.OutfitLocation, string) {
+	if id != "" {
+		if to, ok := l.movedTo[id]; ok {
+			return to, id
+		}
+		return location, id
+	}
+	if move, ok := l.movedFrom[location]; ok {
+		return move.To, move.ID
+	}
+	if identity, ok := l.ids[location.Category][location.FileName]; ok {
+		return location, identity.ID
+	}
+	return location, ""
+}
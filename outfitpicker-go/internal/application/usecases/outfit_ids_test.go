@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// writeOutfitContent writes an outfit whose content tells it apart from
+// others, so its fingerprint does too.
+func writeOutfitContent(t *testing.T, root, category, fileName, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, category, fileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutfitIDsUseCase_MigrateAndFollowRenames(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": nil, "work": nil})
+	writeOutfitContent(t, env.root, "casual", "tee.avatar", "tee")
+	writeOutfitContent(t, env.root, "casual", "jeans.avatar", "jeans")
+	writeOutfitContent(t, env.root, "work", "suit.avatar", "suit")
+	env.cache.Cache.Categories["casual"] = entities.NewCategoryCache(2).Adding("tee.avatar")
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{{Category: "casual", FileName: "tee.avatar", SelectedAt: may(1)}}}
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{{Category: "casual", FileName: "tee.avatar", WornAt: may(1)}}}
+	env.favorites.Favorites = entities.NewFavorites().Adding("casual", "tee.avatar")
+	useCase := NewOutfitIDsUseCase(env.services)
+
+	if _, err := useCase.Sync(); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Sync() with IDs off error = %v, want InvalidInputError", err)
+	}
+	migration, err := useCase.Migrate(entities.OutfitIDSchemeHash)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if migration.Outfits != 3 || migration.Assigned != 3 || migration.Linked != 2 || len(migration.Moves) != 0 {
+		t.Errorf("Migrate() = %+v, want 3 outfits given IDs and 2 history entries linked", migration)
+	}
+	teeID, ok := env.metadata.Index.OutfitID("casual", "tee.avatar")
+	if !ok || env.history.History.Records[0].OutfitID != teeID || env.wearLog.Log.Events[0].OutfitID != teeID {
+		t.Fatalf("tee ID %q not recorded in the history: %+v", teeID, env.history.History.Records)
+	}
+
+	if err := os.Rename(filepath.Join(env.root, "casual", "tee.avatar"), filepath.Join(env.root, "casual", "white-tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	sync, err := useCase.Sync()
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	want := entities.OutfitMove{ID: teeID, From: entities.OutfitLocation{Category: "casual", FileName: "tee.avatar"}, To: entities.OutfitLocation{Category: "casual", FileName: "white-tee.avatar"}}
+	if len(sync.Moves) != 1 || sync.Moves[0] != want || sync.Assigned != 0 {
+		t.Errorf("Sync() = %+v, want the rename of tee.avatar", sync)
+	}
+	if worn := env.cache.Cache.Categories["casual"].WornOutfits; !worn["white-tee.avatar"] || worn["tee.avatar"] {
+		t.Errorf("worn outfits = %v, want the renamed outfit still worn", worn)
+	}
+	if record := env.history.History.Records[0]; record.FileName != "white-tee.avatar" || record.OutfitID != teeID {
+		t.Errorf("history record = %+v, want it to follow the rename", record)
+	}
+	if !env.favorites.Favorites.Contains("casual", "white-tee.avatar") {
+		t.Error("the renamed outfit is no longer a favorite")
+	}
+
+	resolved, err := useCase.Resolve(teeID[:6])
+	if err != nil || resolved.Location != want.To {
+		t.Errorf("Resolve(prefix) = %+v, %v, want casual/white-tee.avatar", resolved, err)
+	}
+	if resolved, err := useCase.Resolve("casual/white-tee.avatar"); err != nil || resolved.ID != teeID {
+		t.Errorf("Resolve(name) = %+v, %v, want %s", resolved, err, teeID)
+	}
+	if _, err := useCase.Resolve("nope"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Resolve(unknown) error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestOutfitIDsUseCase_RecordsIDsOfNewPicksAndWears(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": nil})
+	writeOutfitContent(t, env.root, "casual", "tee.avatar", "tee")
+	writeOutfitContent(t, env.root, "casual", "jeans.avatar", "jeans")
+	if _, err := NewOutfitIDsUseCase(env.services).Migrate(entities.OutfitIDSchemeUUID); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	outfit, err := NewPickOutfitUseCase(env.services).Execute("casual")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewWearOutfitUseCase(env.services).Execute(*outfit); err != nil {
+		t.Fatal(err)
+	}
+	id, _ := env.metadata.Index.OutfitID("casual", outfit.FileName)
+	if id == "" || env.history.History.Records[0].OutfitID != id || env.wearLog.Log.Events[0].OutfitID != id {
+		t.Errorf("pick and wear of %s not recorded with its ID %q", outfit.FileName, id)
+	}
+}
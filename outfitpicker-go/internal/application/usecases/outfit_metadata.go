@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// OutfitMetadataUseCase reads and changes the metadata recorded for outfits.
+type OutfitMetadataUseCase struct {
+	services Services
+}
+
+// NewOutfitMetadataUseCase creates a new outfit metadata use case.
+func NewOutfitMetadataUseCase(services Services) *OutfitMetadataUseCase {
+	return &OutfitMetadataUseCase{services: services}
+}
+
+// Get returns the metadata recorded for an outfit, which is empty when none
+// has been set.
+func (u *OutfitMetadataUseCase) Get(outfit entities.OutfitReference) (entities.OutfitMetadata, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return entities.OutfitMetadata{}, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return entities.OutfitMetadata{}, err
+	}
+	metadata, _ := index.Get(outfit.Category.Name, outfit.FileName)
+	return metadata, nil
+}
+
+// Sidecar returns the sidecar metadata file kept next to an outfit,
+// reporting whether it has one.
+func (u *OutfitMetadataUseCase) Sidecar(outfit entities.OutfitReference) (entities.OutfitSidecar, bool, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return entities.OutfitSidecar{}, false, err
+	}
+	sidecars, err := u.services.Scanner.ReadSidecars(u.services.ctx(), outfit.Category.Path)
+	if err != nil {
+		return entities.OutfitSidecar{}, false, err
+	}
+	sidecar, ok := sidecars[outfit.FileName]
+	return sidecar, ok, nil
+}
+
+// Update replaces an outfit's metadata with change(current) after
+// validating it, reapplying change if another writer saved first.
+func (u *OutfitMetadataUseCase) Update(outfit entities.OutfitReference, change func(current entities.OutfitMetadata) entities.OutfitMetadata) (entities.OutfitMetadata, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return entities.OutfitMetadata{}, err
+	}
+	if err := u.services.ensureOutfitExists(outfit); err != nil {
+		return entities.OutfitMetadata{}, err
+	}
+
+	var updated entities.OutfitMetadata
+	err := retryOnConflict(func() error {
+		index, err := u.services.Metadata.Load()
+		if err != nil {
+			return err
+		}
+		current, _ := index.Get(outfit.Category.Name, outfit.FileName)
+		updated = change(current)
+		if err := updated.Validate(); err != nil {
+			return err
+		}
+		return u.services.Metadata.Save(index.Setting(outfit.Category.Name, outfit.FileName, updated))
+	})
+	return updated, err
+}
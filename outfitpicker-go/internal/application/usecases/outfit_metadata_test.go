@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func setCare(care ...string) func(entities.OutfitMetadata) entities.OutfitMetadata {
+	return func(current entities.OutfitMetadata) entities.OutfitMetadata {
+		current.Care = care
+		return current
+	}
+}
+
+func TestOutfitMetadataUseCase_Update(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewOutfitMetadataUseCase(env.services)
+	outfit := env.outfit("casual", "tee.avatar")
+
+	if _, err := useCase.Update(outfit, setCare("wash-40")); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := useCase.Get(outfit)
+	if err != nil || len(got.Care) != 1 || got.Care[0] != "wash-40" {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+}
+
+func TestOutfitMetadataUseCase_UpdateErrors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewOutfitMetadataUseCase(env.services)
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Update(env.outfit("casual", "missing.avatar"), setCare("wash-40")); !errors.As(err, &invalid) {
+		t.Errorf("Update(missing outfit) error = %v, want InvalidInputError", err)
+	}
+	if _, err := useCase.Update(env.outfit("casual", "tee.avatar"), setCare("wash-35")); !errors.As(err, &invalid) {
+		t.Errorf("Update(unknown symbol) error = %v, want InvalidInputError", err)
+	}
+	if env.metadata.Saves != 0 {
+		t.Errorf("Saves = %d, want 0", env.metadata.Saves)
+	}
+}
+
+func TestOutfitMetadataUseCase_Sidecar(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	writeOutfitContent(t, env.root, "casual", "tee.avatar"+entities.OutfitSidecarSuffix, `{"notes": "Dry flat", "seasons": ["summer"]}`)
+	useCase := NewOutfitMetadataUseCase(env.services)
+
+	sidecar, ok, err := useCase.Sidecar(env.outfit("casual", "tee.avatar"))
+	if err != nil || !ok || sidecar.Notes != "Dry flat" || len(sidecar.Seasons) != 1 {
+		t.Errorf("Sidecar(tee.avatar) = %+v, %v, %v; want its sidecar file", sidecar, ok, err)
+	}
+	if _, ok, err := useCase.Sidecar(env.outfit("casual", "jeans.avatar")); ok || err != nil {
+		t.Errorf("Sidecar(jeans.avatar) = %v, %v; want none", ok, err)
+	}
+}
+
+func TestLaundryReportUseCase(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar", "sweater.avatar"},
+		"work":   {"shirt.avatar"},
+	})
+	metadata := NewOutfitMetadataUseCase(env.services)
+	for _, m := range []struct {
+		outfit entities.OutfitReference
+		care   string
+	}{
+		{env.outfit("casual", "tee.avatar"), "wash-40"},
+		{env.outfit("casual", "sweater.avatar"), "hand-wash"},
+		{env.outfit("work", "shirt.avatar"), "wash-40"},
+	} {
+		if _, err := metadata.Update(m.outfit, setCare(m.care)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wear := NewWearOutfitUseCase(env.services)
+	for _, outfit := range []entities.OutfitReference{
+		env.outfit("casual", "tee.avatar"),
+		env.outfit("casual", "jeans.avatar"),
+		env.outfit("work", "shirt.avatar"),
+	} {
+		if err := wear.Execute(outfit); err != nil && !errors.As(err, new(*domainerrors.RotationCompletedError)) {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := NewLaundryReportUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	// work/shirt completed its rotation, so only casual outfits are dirty.
+	if len(report.Loads) != 1 || report.Loads[0].Program != (logic.WashProgram{Method: logic.WashMachine, Temperature: 40}) {
+		t.Fatalf("Loads = %+v", report.Loads)
+	}
+	if len(report.Unsorted) != 1 || report.Unsorted[0].FileName != "jeans.avatar" || report.OutfitCount() != 2 {
+		t.Errorf("report = %+v", report)
+	}
+}
@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// Plan lengths.
+const (
+	// DefaultPlanDays is how many days a plan covers unless told otherwise.
+	DefaultPlanDays = 7
+	// MaxPlanDays caps how far ahead outfits can be planned.
+	MaxPlanDays = 14
+)
+
+// OutfitPlanUseCase plans outfits ahead of time, one per category for each
+// day, and records the planned days as they are worn.
+type OutfitPlanUseCase struct {
+	services Services
+}
+
+// NewOutfitPlanUseCase creates a new outfit plan use case.
+func NewOutfitPlanUseCase(services Services) *OutfitPlanUseCase {
+	return &OutfitPlanUseCase{services: services}
+}
+
+// Generate replaces the plan with days days from today, picking an outfit
+// for each day from every category with outfits. Picks follow the same
+// rules as pick, and an outfit is planned again only once every outfit the
+// category can pick from has been planned. Categories nothing can be picked
+// from are left out. Nothing but the plan is saved: the rotation only
+// changes as planned days are worn.
+func (u *OutfitPlanUseCase) Generate(days int) (logic.PlanReport, error) {
+	if days < 1 || days > MaxPlanDays {
+		return logic.PlanReport{}, domainerrors.NewInvalidInputError(fmt.Sprintf("a plan covers 1 to %d days, got %d", MaxPlanDays, days))
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return logic.PlanReport{}, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	if err := u.services.sortCategories(config, infos); err != nil {
+		return logic.PlanReport{}, err
+	}
+
+	now := u.services.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	plan := entities.OutfitPlan{Days: make([]entities.PlanDay, 0, days), CreatedAt: now}
+	for i := range days {
+		day := entities.PlanDay{Date: today.AddDate(0, 0, i), Outfits: []entities.PlannedOutfit{}}
+		for _, info := range infos {
+			if info.State != entities.CategoryStateHasOutfits {
+				continue
+			}
+			category := info.Category.Name
+			fileName, ok, err := u.pickFor(category, plan.PlannedOn(category, -1))
+			if err != nil {
+				return logic.PlanReport{}, err
+			}
+			if ok {
+				day.Outfits = append(day.Outfits, entities.PlannedOutfit{Category: category, FileName: fileName})
+			}
+		}
+		plan.Days = append(plan.Days, day)
+	}
+
+	err = retryOnConflict(func() error {
+		current, err := u.services.Plan.Load()
+		if err != nil {
+			return err
+		}
+		plan.Revision = current.Revision
+		return u.services.Plan.Save(plan)
+	})
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	return u.check(config, plan)
+}
+
+// Show checks the plan against the wardrobe and the rotation cache. It
+// only reads, so it also works in maintenance mode.
+func (u *OutfitPlanUseCase) Show() (logic.PlanReport, error) {
+	plan, err := u.load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	return u.check(config, plan)
+}
+
+// Reroll picks new outfits for a day of the plan that has not been worn,
+// given as a weekday or YYYY-MM-DD. Where the category leaves a choice the
+// new outfits differ from the ones they replace and, after that, from the
+// outfits planned on other days.
+func (u *OutfitPlanUseCase) Reroll(dayRef string) (logic.PlanReport, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return logic.PlanReport{}, err
+	}
+	var rerolled entities.OutfitPlan
+	err := retryOnConflict(func() error {
+		plan, err := u.load()
+		if err != nil {
+			return err
+		}
+		i, err := plan.FindDay(dayRef)
+		if err != nil {
+			return err
+		}
+		day := plan.Days[i]
+		if day.Worn {
+			return domainerrors.NewInvalidInputError(fmt.Sprintf("%s has already been worn", day.Date.Format("Monday 2006-01-02")))
+		}
+		outfits := make([]entities.PlannedOutfit, 0, len(day.Outfits))
+		for _, outfit := range day.Outfits {
+			others := plan.PlannedOn(outfit.Category, i)
+			fileName, ok, err := u.pickFor(outfit.Category, append(others, outfit.FileName), []string{outfit.FileName})
+			if err != nil {
+				return err
+			}
+			if ok {
+				outfits = append(outfits, entities.PlannedOutfit{Category: outfit.Category, FileName: fileName})
+			}
+		}
+		day.Outfits = outfits
+		rerolled = plan.WithDay(i, day)
+		return u.services.Plan.Save(rerolled)
+	})
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	return u.check(config, rerolled)
+}
+
+// Wear records every outfit planned for a day, given as a weekday or
+// YYYY-MM-DD, as worn and marks the day worn. No outfit is recorded unless
+// all of them are still in the wardrobe. Rotations the wears complete are
+// reset as usual.
+func (u *OutfitPlanUseCase) Wear(dayRef string) (logic.PlanReport, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return logic.PlanReport{}, err
+	}
+	plan, err := u.load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	i, err := plan.FindDay(dayRef)
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	day := plan.Days[i]
+	if day.Worn {
+		return logic.PlanReport{}, domainerrors.NewInvalidInputError(fmt.Sprintf("%s has already been worn", day.Date.Format("Monday 2006-01-02")))
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	outfits := make([]entities.OutfitReference, len(day.Outfits))
+	for j, planned := range day.Outfits {
+		category, err := u.services.categoryReference(config, planned.Category)
+		if err != nil {
+			return logic.PlanReport{}, err
+		}
+		outfits[j] = entities.NewOutfitReference(planned.FileName, category)
+		if err := u.services.ensureOutfitExists(outfits[j]); err != nil {
+			return logic.PlanReport{}, err
+		}
+	}
+
+	wear := NewWearOutfitUseCase(u.services)
+	var completed *domainerrors.RotationCompletedError
+	for _, outfit := range outfits {
+		if err := wear.Execute(outfit); err != nil && !errors.As(err, &completed) {
+			return logic.PlanReport{}, err
+		}
+	}
+
+	var worn entities.OutfitPlan
+	err = retryOnConflict(func() error {
+		current, err := u.load()
+		if err != nil {
+			return err
+		}
+		i := current.DayOn(day.Date)
+		if i < 0 {
+			worn = current
+			return nil
+		}
+		marked := current.Days[i]
+		marked.Worn = true
+		worn = current.WithDay(i, marked)
+		return u.services.Plan.Save(worn)
+	})
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	return u.check(config, worn)
+}
+
+// load returns the saved plan, failing with an InvalidInputError when
+// none has been made.
+func (u *OutfitPlanUseCase) load() (entities.OutfitPlan, error) {
+	plan, err := u.services.Plan.Load()
+	if err != nil {
+		return entities.OutfitPlan{}, err
+	}
+	if len(plan.Days) == 0 {
+		return entities.OutfitPlan{}, domainerrors.NewInvalidInputError("no outfit plan has been made")
+	}
+	return plan, nil
+}
+
+// pickFor proposes an outfit of category, leaving out each list of file
+// names in exclusions in turn, and then none, until one leaves an outfit to
+// pick. It reports false when nothing can be picked from the category.
+func (u *OutfitPlanUseCase) pickFor(category string, exclusions ...[]string) (string, bool, error) {
+	picker := NewPickOutfitUseCase(u.services)
+	planning := func(o *pickOptions) { o.planning = true }
+	for _, without := range exclusions {
+		proposal, err := picker.Propose(category, planning, WithoutOutfits(without...))
+		if err == nil {
+			return proposal.Outfit.FileName, true, nil
+		}
+		if !errors.Is(err, domainerrors.ErrNoOutfitsAvailable) {
+			break
+		}
+	}
+	proposal, err := picker.Propose(category, planning)
+	if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) || errors.As(err, new(*domainerrors.InvalidInputError)) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return proposal.Outfit.FileName, true, nil
+}
+
+// check compares plan with the wardrobe on disk and the rotation cache.
+func (u *OutfitPlanUseCase) check(config *entities.Config, plan entities.OutfitPlan) (logic.PlanReport, error) {
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return logic.PlanReport{}, err
+	}
+	return logic.CheckPlan(plan, snapshot, cache), nil
+}
@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func TestOutfitPlanUseCase_Generate(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"},
+		"work":   {"shirt.avatar"},
+		"empty":  {},
+	})
+	plans := NewOutfitPlanUseCase(env.services)
+
+	report, err := plans.Generate(DefaultPlanDays)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(report.Days) != DefaultPlanDays || report.Conflicts != 0 {
+		t.Fatalf("Generate() = %+v, want %d days without conflicts", report, DefaultPlanDays)
+	}
+	if first := report.Days[0].Date; !first.Equal(testNow.Truncate(24 * time.Hour)) {
+		t.Errorf("first day = %v, want today", first)
+	}
+	seen := make(map[string]bool)
+	for i, day := range report.Days {
+		if len(day.Outfits) != 2 || day.Outfits[0].Category != "casual" || day.Outfits[1].Category != "work" {
+			t.Fatalf("day %d = %+v, want one casual and one work outfit", i, day.Outfits)
+		}
+		if i < 3 && seen[day.Outfits[0].FileName] {
+			t.Errorf("day %d repeats %s before every casual outfit was planned", i, day.Outfits[0].FileName)
+		}
+		seen[day.Outfits[0].FileName] = true
+	}
+	if len(env.cache.Cache.Categories["casual"].WornOutfits) != 0 || env.plan.Plan.Revision != 1 {
+		t.Errorf("Generate() changed the rotation or did not save the plan once")
+	}
+
+	if _, err := plans.Generate(MaxPlanDays + 1); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Generate(%d) error = %v, want InvalidInputError", MaxPlanDays+1, err)
+	}
+}
+
+func TestOutfitPlanUseCase_RerollAndWear(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}, "work": {"shirt.avatar"}})
+	plans := NewOutfitPlanUseCase(env.services)
+	if _, err := plans.Generate(2); err != nil {
+		t.Fatal(err)
+	}
+	saturday := env.plan.Plan.Days[0].Outfits[0].FileName
+	sunday := env.plan.Plan.Days[1].Outfits[0].FileName
+
+	report, err := plans.Reroll("saturday")
+	if err != nil {
+		t.Fatalf("Reroll() error = %v", err)
+	}
+	if got := report.Days[0].Outfits[0].FileName; got == saturday || got == sunday {
+		t.Errorf("Reroll() planned %s on Saturday, want the casual outfit planned on neither day", got)
+	}
+	if got := report.Days[0].Outfits[1].FileName; got != "shirt.avatar" {
+		t.Errorf("Reroll() planned %s for work, want the only work outfit", got)
+	}
+
+	report, err = plans.Wear("sat")
+	if err != nil {
+		t.Fatalf("Wear() error = %v", err)
+	}
+	if !report.Days[0].Worn || report.Days[0].Outfits[0].Status != logic.PlanStatusWorn {
+		t.Errorf("Wear() = %+v, want Saturday worn", report.Days[0])
+	}
+	if len(env.cache.Cache.Categories["casual"].WornOutfits) != 1 {
+		t.Errorf("casual worn outfits = %v, want the planned one", env.cache.Cache.Categories["casual"].WornOutfits)
+	}
+	if _, err := plans.Wear("saturday"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Wear() of a worn day error = %v, want InvalidInputError", err)
+	}
+	if _, err := plans.Reroll("saturday"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Reroll() of a worn day error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestOutfitPlanUseCase_ShowReportsConflicts(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	plans := NewOutfitPlanUseCase(env.services)
+	if _, err := plans.Show(); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Show() without a plan error = %v, want InvalidInputError", err)
+	}
+	if _, err := plans.Generate(2); err != nil {
+		t.Fatal(err)
+	}
+	first := env.plan.Plan.Days[0].Outfits[0].FileName
+	second := env.plan.Plan.Days[1].Outfits[0].FileName
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(env.root, "casual", first)); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := plans.Show()
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if report.Conflicts != 2 || report.Days[0].Outfits[0].Status != logic.PlanStatusMissing ||
+		report.Days[1].Outfits[0].Status != logic.PlanStatusAlreadyWorn {
+		t.Errorf("Show() = %+v, want a missing and an already worn outfit", report)
+	}
+	if _, err := plans.Wear(report.Days[0].Date.Format("2006-01-02")); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Wear() of a day with a missing outfit error = %v, want InvalidInputError", err)
+	}
+}
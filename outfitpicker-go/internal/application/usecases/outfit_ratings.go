@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// OutfitRatingsUseCase rates outfits and reports on their ratings, which are
+// kept in the outfits' metadata.
+type OutfitRatingsUseCase struct {
+	services Services
+}
+
+// NewOutfitRatingsUseCase creates a new outfit ratings use case.
+func NewOutfitRatingsUseCase(services Services) *OutfitRatingsUseCase {
+	return &OutfitRatingsUseCase{services: services}
+}
+
+// Rate sets an outfit's rating, from entities.MinRating to
+// entities.MaxRating, keeping the rest of its metadata.
+func (u *OutfitRatingsUseCase) Rate(outfit entities.OutfitReference, rating int) error {
+	if rating < entities.MinRating || rating > entities.MaxRating {
+		return errors.NewInvalidInputError(fmt.Sprintf("rating must be between %d and %d, got %d", entities.MinRating, entities.MaxRating, rating))
+	}
+	_, err := NewOutfitMetadataUseCase(u.services).Update(outfit, func(current entities.OutfitMetadata) entities.OutfitMetadata {
+		current.Rating = rating
+		return current
+	})
+	return err
+}
+
+// Report lists the rated outfits, best first, and averages the ratings of
+// each category, listing the categories in the configured order.
+func (u *OutfitRatingsUseCase) Report() (entities.RatingsReport, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.RatingsReport{}, err
+	}
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return entities.RatingsReport{}, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return entities.RatingsReport{}, err
+	}
+	compare, err := u.services.categoryComparison(config, infos)
+	if err != nil {
+		return entities.RatingsReport{}, err
+	}
+
+	report := entities.NewRatingsReport(index.Rated())
+	slices.SortStableFunc(report.Categories, func(a, b entities.CategoryRatings) int {
+		return compare(a.Category, b.Category)
+	})
+	return report, nil
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestOutfitRatingsUseCase_RateAndReport(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"suit.avatar"}})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "tee.avatar", entities.OutfitMetadata{Price: 20})
+	useCase := NewOutfitRatingsUseCase(env.services)
+
+	for _, rate := range []struct {
+		category, file string
+		rating         int
+	}{{"casual", "tee.avatar", 5}, {"casual", "jeans.avatar", 2}, {"work", "suit.avatar", 4}} {
+		if err := useCase.Rate(env.outfit(rate.category, rate.file), rate.rating); err != nil {
+			t.Fatalf("Rate(%s/%s) error = %v", rate.category, rate.file, err)
+		}
+	}
+	if metadata, _ := env.metadata.Index.Get("casual", "tee.avatar"); metadata.Rating != 5 || metadata.Price != 20 {
+		t.Errorf("metadata = %+v, want the rating added to the price", metadata)
+	}
+
+	report, err := useCase.Report()
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if len(report.Outfits) != 3 || report.Outfits[0].FileName != "tee.avatar" || report.Outfits[2].FileName != "jeans.avatar" {
+		t.Errorf("Outfits = %+v, want best first", report.Outfits)
+	}
+	if len(report.Categories) != 2 || report.Categories[0] != (entities.CategoryRatings{Category: "casual", Rated: 2, Average: 3.5}) {
+		t.Errorf("Categories = %+v", report.Categories)
+	}
+}
+
+func TestOutfitRatingsUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewOutfitRatingsUseCase(env.services)
+	tests := []struct {
+		name   string
+		outfit entities.OutfitReference
+		rating int
+	}{
+		{"zero", env.outfit("casual", "tee.avatar"), 0},
+		{"too high", env.outfit("casual", "tee.avatar"), entities.MaxRating + 1},
+		{"missing outfit", env.outfit("casual", "nope.avatar"), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalid *domainerrors.InvalidInputError
+			if err := useCase.Rate(tt.outfit, tt.rating); !errors.As(err, &invalid) {
+				t.Errorf("Rate() error = %v, want InvalidInputError", err)
+			}
+		})
+	}
+	if env.metadata.Saves != 0 {
+		t.Errorf("saves = %d, want 0", env.metadata.Saves)
+	}
+}
+
+func TestPickOutfitUseCase_RatedFavorsHigherRatings(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"loved.avatar", "meh.avatar", "worn.avatar"}})
+	env.config.Config.Selection = entities.SelectionPreferences{Strategy: entities.StrategyRated}
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("worn.avatar"))
+	env.metadata.Index = env.metadata.Index.
+		Setting("casual", "loved.avatar", entities.OutfitMetadata{Rating: 5}).
+		Setting("casual", "meh.avatar", entities.OutfitMetadata{Rating: 1}).
+		Setting("casual", "worn.avatar", entities.OutfitMetadata{Rating: 5})
+
+	const picks = 200
+	counts := make(map[string]int)
+	useCase := NewPickOutfitUseCase(env.services)
+	for range picks {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[outfit.FileName]++
+	}
+	// loved.avatar is five times as likely as meh.avatar; worn.avatar is
+	// left out until the rotation starts over, whatever its rating.
+	if counts["loved.avatar"] < picks*7/10 || counts["meh.avatar"] == 0 || counts["worn.avatar"] != 0 {
+		t.Errorf("picks = %v, want mostly loved.avatar, some meh.avatar and no worn.avatar", counts)
+	}
+}
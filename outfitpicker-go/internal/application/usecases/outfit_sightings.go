@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"errors"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// OutfitSightingsUseCase reports when the outfits of a category were first
+// and last seen on disk, including outfits that have since gone.
+type OutfitSightingsUseCase struct {
+	services Services
+}
+
+// NewOutfitSightingsUseCase creates a new outfit sightings use case.
+func NewOutfitSightingsUseCase(services Services) *OutfitSightingsUseCase {
+	return &OutfitSightingsUseCase{services: services}
+}
+
+// Execute scans the category, records what it found and returns its
+// sightings ordered by file name. In maintenance mode nothing is recorded and
+// the sightings of earlier scans are returned.
+func (u *OutfitSightingsUseCase) Execute(categoryName string) ([]entities.OutfitSighting, error) {
+	if err := logic.ValidateCategoryName(categoryName); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	category, err := u.services.categoryReference(config, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := u.services.outfitsIn(config, category)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.FileName
+	}
+
+	var arrivals entities.OutfitArrivals
+	switch err := u.services.ensureWritable(); {
+	case errors.Is(err, domainerrors.ErrMaintenanceMode):
+		arrivals, err = u.services.Arrivals.Load()
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if arrivals, err = u.services.recordArrivals(categoryName, files); err != nil {
+			return nil, err
+		}
+	}
+	return arrivals.Sightings(categoryName, names), nil
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestOutfitSightingsUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "coat.avatar"}})
+	sightings, err := NewOutfitSightingsUseCase(env.services).Execute("casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(sightings) != 2 || !sightings[0].FirstSeen.IsZero() || !sightings[0].LastSeen.Equal(testNow) || !sightings[0].Present {
+		t.Fatalf("first sightings = %+v, want both outfits owned since before tracking", sightings)
+	}
+
+	later := testNow.AddDate(0, 0, 2)
+	env.services.Now = func() time.Time { return later }
+	if err := os.Remove(filepath.Join(env.root, "casual", "coat.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	writeOutfit(t, env.root, "casual", "shirt.avatar")
+
+	sightings, err = NewOutfitSightingsUseCase(env.services).Execute("casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := []entities.OutfitSighting{
+		{Category: "casual", FileName: "coat.avatar", LastSeen: testNow},
+		{Category: "casual", FileName: "shirt.avatar", FirstSeen: later, LastSeen: later, Present: true},
+		{Category: "casual", FileName: "tee.avatar", LastSeen: later, Present: true},
+	}
+	if len(sightings) != len(want) {
+		t.Fatalf("sightings = %+v, want %+v", sightings, want)
+	}
+	for i := range want {
+		if sightings[i] != want[i] {
+			t.Errorf("sightings[%d] = %+v, want %+v", i, sightings[i], want[i])
+		}
+	}
+}
+
+func TestOutfitSightingsUseCase_MaintenanceMode(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	env.maintenance.State.Enabled = true
+
+	sightings, err := NewOutfitSightingsUseCase(env.services).Execute("casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(sightings) != 0 || env.arrivals.Saves != 0 {
+		t.Errorf("sightings = %+v, saves = %d; want nothing recorded in maintenance mode", sightings, env.arrivals.Saves)
+	}
+}
+
+func TestOutfitSightingsUseCase_UnknownCategory(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, err := NewOutfitSightingsUseCase(env.services).Execute("formal"); err == nil {
+		t.Error("Execute() expected an error for a missing category")
+	}
+}
@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// OutfitTagsUseCase adds and removes outfit tags, which are kept in the
+// outfits' metadata.
+type OutfitTagsUseCase struct {
+	services Services
+}
+
+// NewOutfitTagsUseCase creates a new outfit tags use case.
+func NewOutfitTagsUseCase(services Services) *OutfitTagsUseCase {
+	return &OutfitTagsUseCase{services: services}
+}
+
+// List returns the tagged outfits of a category, or of every category when
+// categoryName is empty.
+func (u *OutfitTagsUseCase) List(categoryName string) ([]entities.OutfitTags, error) {
+	index, err := u.services.Metadata.Load()
+	if err != nil {
+		return nil, err
+	}
+	return index.Tagged(categoryName), nil
+}
+
+// Add tags an outfit, keeping the tags it already has, and returns its tags.
+func (u *OutfitTagsUseCase) Add(outfit entities.OutfitReference, tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, errors.NewInvalidInputError("no tags given")
+	}
+	metadata, err := NewOutfitMetadataUseCase(u.services).Update(outfit, func(current entities.OutfitMetadata) entities.OutfitMetadata {
+		return current.AddingTags(tags)
+	})
+	return metadata.Tags, err
+}
+
+// Remove untags an outfit and returns its remaining tags. Every tag must be
+// on the outfit; the outfit need not exist on disk any more, so tags of
+// deleted outfits can be cleaned up.
+func (u *OutfitTagsUseCase) Remove(outfit entities.OutfitReference, tags []string) ([]string, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, errors.NewInvalidInputError("no tags given")
+	}
+	if err := validation.ValidateTags(tags); err != nil {
+		return nil, err
+	}
+
+	var remaining []string
+	err := retryOnConflict(func() error {
+		index, err := u.services.Metadata.Load()
+		if err != nil {
+			return err
+		}
+		current, _ := index.Get(outfit.Category.Name, outfit.FileName)
+		var missing []string
+		for _, tag := range tags {
+			if !current.HasTag(tag) {
+				missing = append(missing, tag)
+			}
+		}
+		if len(missing) > 0 {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s/%s is not tagged %s", outfit.Category.Name, outfit.FileName, strings.Join(missing, ", ")))
+		}
+		updated := current.RemovingTags(tags)
+		remaining = updated.Tags
+		return u.services.Metadata.Save(index.Setting(outfit.Category.Name, outfit.FileName, updated))
+	})
+	return remaining, err
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestOutfitTagsUseCase_AddAndRemove(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "tee.avatar", entities.OutfitMetadata{Price: 20})
+	useCase := NewOutfitTagsUseCase(env.services)
+
+	tags, err := useCase.Add(env.outfit("casual", "tee.avatar"), []string{"summer", "beach"})
+	if err != nil || !slices.Equal(tags, []string{"summer", "beach"}) {
+		t.Fatalf("Add() = %v, %v", tags, err)
+	}
+	if tags, _ := useCase.Add(env.outfit("casual", "tee.avatar"), []string{"summer"}); len(tags) != 2 {
+		t.Errorf("Add() of an existing tag = %v, want it listed once", tags)
+	}
+	if metadata, _ := env.metadata.Index.Get("casual", "tee.avatar"); metadata.Price != 20 {
+		t.Errorf("Add() lost the outfit's other metadata: %+v", metadata)
+	}
+	if listed, _ := useCase.List(""); len(listed) != 1 || listed[0].FileName != "tee.avatar" {
+		t.Errorf("List() = %+v", listed)
+	}
+
+	tags, err = useCase.Remove(env.outfit("casual", "tee.avatar"), []string{"summer"})
+	if err != nil || !slices.Equal(tags, []string{"beach"}) {
+		t.Errorf("Remove() = %v, %v", tags, err)
+	}
+}
+
+func TestOutfitTagsUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewOutfitTagsUseCase(env.services)
+	tee := env.outfit("casual", "tee.avatar")
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"add without tags", func() error { _, err := useCase.Add(tee, nil); return err }},
+		{"add invalid tag", func() error { _, err := useCase.Add(tee, []string{"Summer"}); return err }},
+		{"add to missing outfit", func() error {
+			_, err := useCase.Add(env.outfit("casual", "nope.avatar"), []string{"summer"})
+			return err
+		}},
+		{"remove missing tag", func() error { _, err := useCase.Remove(tee, []string{"summer"}); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalid *domainerrors.InvalidInputError
+			if err := tt.call(); !errors.As(err, &invalid) {
+				t.Errorf("error = %v, want InvalidInputError", err)
+			}
+		})
+	}
+	if env.metadata.Saves != 0 {
+		t.Errorf("failed calls saved metadata %d times", env.metadata.Saves)
+	}
+}
+
+func TestPickOutfitUseCase_Tag(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "b.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 20 {
+		outfit, err := useCase.Execute("casual", WithTag("summer"))
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "b.avatar" {
+			t.Fatalf("Execute() = %v, want the tagged b.avatar", outfit.FileName)
+		}
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("b.avatar"))
+	if _, err := useCase.Execute("casual", WithTag("summer")); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with every tagged outfit worn error = %v, want InvalidInputError", err)
+	}
+	if _, err := useCase.Execute("casual", WithTag("winter")); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with an unused tag error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickOutfitUseCase_SidecarTags(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	writeOutfitContent(t, env.root, "casual", "b.avatar"+entities.OutfitSidecarSuffix, `{"tags": ["summer"]}`)
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 10 {
+		outfit, err := useCase.Execute("casual", WithTag("summer"))
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "b.avatar" {
+			t.Fatalf("Execute() = %v, want b.avatar, tagged in its sidecar file", outfit.FileName)
+		}
+	}
+}
+
+func TestPickOutfitUseCase_CategoryDefaultTags(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "gym": {"kit.avatar"}})
+	description := `{"description": "Weekend clothes", "tags": ["summer"]}`
+	if err := os.WriteFile(filepath.Join(env.root, "casual", entities.CategoryDescriptionFileName), []byte(description), 0644); err != nil {
+		t.Fatal(err)
+	}
+	useCase := NewPickOutfitUseCase(env.services)
+
+	if outfit, err := useCase.Execute("casual", WithTag("summer")); err != nil || outfit.FileName != "a.avatar" {
+		t.Errorf("Execute() = %v, %v, want a.avatar tagged through its category", outfit, err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Execute("gym", WithTag("summer")); !errors.As(err, &invalid) {
+		t.Errorf("Execute() of a category without the default tag error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// OutfitWeightUseCase reads and changes the pick weights assigned to
+// outfits.
+type OutfitWeightUseCase struct {
+	services Services
+}
+
+// NewOutfitWeightUseCase creates a new outfit weight use case.
+func NewOutfitWeightUseCase(services Services) *OutfitWeightUseCase {
+	return &OutfitWeightUseCase{services: services}
+}
+
+// List returns every assigned weight.
+func (u *OutfitWeightUseCase) List() (entities.OutfitWeights, error) {
+	return u.services.Weights.Load()
+}
+
+// Set assigns an outfit's weight, reapplying it if another writer saved
+// first. Setting DefaultOutfitWeight clears the assignment.
+func (u *OutfitWeightUseCase) Set(outfit entities.OutfitReference, weight float64) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	if err := validation.ValidateOutfitWeight(weight); err != nil {
+		return err
+	}
+	if err := u.services.ensureOutfitExists(outfit); err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		weights, err := u.services.Weights.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.Weights.Save(weights.Setting(outfit.Category.Name, outfit.FileName, weight))
+	})
+}
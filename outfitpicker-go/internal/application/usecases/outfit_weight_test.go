@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestOutfitWeightUseCase_Set(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	useCase := NewOutfitWeightUseCase(env.services)
+
+	if err := useCase.Set(env.outfit("casual", "tee.avatar"), 4); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	weights, err := useCase.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := weights.Weight("casual", "tee.avatar"); got != 4 {
+		t.Errorf("Weight() = %v, want 4", got)
+	}
+
+	if err := useCase.Set(env.outfit("casual", "tee.avatar"), entities.DefaultOutfitWeight); err != nil {
+		t.Fatal(err)
+	}
+	if len(env.weights.Weights.Outfits) != 0 {
+		t.Errorf("default weight left %v", env.weights.Weights.Outfits)
+	}
+}
+
+func TestOutfitWeightUseCase_SetErrors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name   string
+		outfit entities.OutfitReference
+		weight float64
+	}{
+		{"negative", env.outfit("casual", "tee.avatar"), -1},
+		{"too heavy", env.outfit("casual", "tee.avatar"), 1000},
+		{"unknown outfit", env.outfit("casual", "nope.avatar"), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var invalid *domainerrors.InvalidInputError
+			if err := NewOutfitWeightUseCase(env.services).Set(tt.outfit, tt.weight); !errors.As(err, &invalid) {
+				t.Errorf("Set() error = %v, want InvalidInputError", err)
+			}
+		})
+	}
+	if env.weights.Saves != 0 {
+		t.Errorf("saves = %d, want 0", env.weights.Saves)
+	}
+}
+
+func TestPickOutfitUseCase_WeightedFollowsUserWeights(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"favorite.avatar", "plain.avatar", "retired.avatar"}})
+	env.config.Config.Selection = entities.SelectionPreferences{Strategy: entities.StrategyWeighted}
+	env.weights.Weights = entities.NewOutfitWeights().
+		Setting("casual", "favorite.avatar", 20).
+		Setting("casual", "retired.avatar", 0)
+
+	const picks = 200
+	counts := make(map[string]int)
+	useCase := NewPickOutfitUseCase(env.services)
+	for range picks {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[outfit.FileName]++
+	}
+	// favorite.avatar has weight 20 against 1; retired.avatar is left out.
+	if counts["favorite.avatar"] < picks*8/10 || counts["retired.avatar"] != 0 {
+		t.Errorf("picks = %v, want a strong preference for favorite.avatar and none of retired.avatar", counts)
+	}
+}
+
+func TestPickOutfitUseCase_WeightedFollowsSidecarWeights(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"favorite.avatar", "plain.avatar", "retired.avatar"}})
+	env.config.Config.Selection = entities.SelectionPreferences{Strategy: entities.StrategyWeighted}
+	writeOutfitContent(t, env.root, "casual", "favorite.avatar"+entities.OutfitSidecarSuffix, `{"weight": 0}`)
+	writeOutfitContent(t, env.root, "casual", "retired.avatar"+entities.OutfitSidecarSuffix, `{"weight": 0}`)
+	// A weight assigned with the weight command overrides the sidecar's.
+	env.weights.Weights = entities.NewOutfitWeights().Setting("casual", "favorite.avatar", 5)
+
+	useCase := NewPickOutfitUseCase(env.services)
+	for range 20 {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outfit.FileName == "retired.avatar" {
+			t.Fatal("Execute() picked retired.avatar, whose sidecar file gives it weight 0")
+		}
+		env.cache.Cache = entities.NewOutfitCache()
+	}
+}
@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// AggregatePick is an outfit picked across all categories.
+type AggregatePick struct {
+	Outfit entities.OutfitReference `json:"outfit"`
+	// Skipped lists the categories left out because they have no outfits,
+	// with the state that left them out.
+	Skipped []entities.CategoryInfo `json:"skipped,omitempty"`
+	// Policy is the empty category policy the pick followed.
+	Policy string `json:"policy"`
+	// Resting lists the categories picked from too recently to be tried
+	// before the others.
+	Resting []string `json:"resting,omitempty"`
+
+	proposal *PickProposal
+}
+
+// Changes returns the changes committing the pick makes.
+func (p *AggregatePick) Changes() []StateChange {
+	return p.proposal.Changes()
+}
+
+// PickAnyOutfitUseCase picks an outfit from any category that is not
+// excluded.
+type PickAnyOutfitUseCase struct {
+	services Services
+}
+
+// NewPickAnyOutfitUseCase creates a new pick any outfit use case.
+func NewPickAnyOutfitUseCase(services Services) *PickAnyOutfitUseCase {
+	return &PickAnyOutfitUseCase{services: services}
+}
+
+// Execute picks a random category the profile may pick from and an outfit
+// from it, trying the other categories in turn when the options or a daily
+// pick limit leave nothing to pick in one. When nothing can be picked and
+// some categories reached their limit, the RateLimitedError of the one
+// allowed again soonest is returned.
+// Categories are drawn by their configured priority and balance, and those
+// picked from within the configured rest days are only tried when no other
+// category has an outfit to pick. Empty categories and categories without
+// avatar files are handled by the configured empty category policy:
+// skipped, reported as skipped, or failing the pick with an
+// EmptyCategoriesError before anything changes.
+func (u *PickAnyOutfitUseCase) Execute(opts ...PickOption) (*AggregatePick, error) {
+	result, err := u.Propose(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Commit(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Commit records a pick made by Propose.
+func (u *PickAnyOutfitUseCase) Commit(result *AggregatePick) error {
+	return NewPickOutfitUseCase(u.services).Commit(result.proposal)
+}
+
+// ProposeFor picks as Propose does from target: one category, a set of
+// categories, or every category.
+func (u *PickAnyOutfitUseCase) ProposeFor(target entities.SelectionTarget, opts ...PickOption) (*AggregatePick, error) {
+	switch target := target.(type) {
+	case entities.SelectionTargetCategory:
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return nil, err
+		}
+		proposal, err := NewPickOutfitUseCase(u.services).Propose(target.Category.Name, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &AggregatePick{Outfit: proposal.Outfit, Policy: config.Selection.EmptyCategoryPolicy(), proposal: proposal}, nil
+	case entities.SelectionTargetCategories:
+		within := make([]string, len(target.Categories))
+		for i, category := range target.Categories {
+			within[i] = category.Name
+		}
+		return u.Propose(append(opts, withinCategories(within))...)
+	default:
+		return u.Propose(opts...)
+	}
+}
+
+// withinCategories limits a pick across categories to the named ones.
+func withinCategories(names []string) PickOption {
+	return func(o *pickOptions) {
+		o.within = names
+	}
+}
+
+// Propose picks as Execute does without saving anything. Commit records
+// the pick; a pick that is dropped leaves no trace.
+func (u *PickAnyOutfitUseCase) Propose(opts ...PickOption) (*AggregatePick, error) {
+	var options pickOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AggregatePick{Policy: config.Selection.EmptyCategoryPolicy()}
+	var candidates, empty []string
+	available := make(map[string]int)
+	for _, info := range infos {
+		if !config.Permissions.CanPick(info.Category.Name) || options.within != nil && !slices.Contains(options.within, info.Category.Name) {
+			continue
+		}
+		switch info.State {
+		case entities.CategoryStateHasOutfits:
+			candidates = append(candidates, info.Category.Name)
+			worn := len(cache.Categories[info.Category.Name].WornOutfits)
+			available[info.Category.Name] = entities.NewRotationProgress(info.Category, worn, info.OutfitCount).AvailableCount()
+		case entities.CategoryStateEmpty, entities.CategoryStateNoAvatarFiles:
+			result.Skipped = append(result.Skipped, info)
+			empty = append(empty, info.Category.Name)
+		}
+	}
+	if len(empty) > 0 && result.Policy == entities.EmptyCategoriesFail {
+		return nil, domainerrors.NewEmptyCategoriesError(empty)
+	}
+
+	source := randomSource(options.seed)
+	weighted := func(names []string) []string {
+		return logic.WeightedOrder(names, func(category string) float64 {
+			return logic.CategoryWeight(config.Selection, category, available[category])
+		}, source)
+	}
+	order, err := u.restedFirst(config, candidates, weighted, result)
+	if err != nil {
+		return nil, err
+	}
+	pick := NewPickOutfitUseCase(u.services)
+	var rateLimited *domainerrors.RateLimitedError
+	for _, category := range order {
+		proposal, err := pick.Propose(category, opts...)
+		var invalid *domainerrors.InvalidInputError
+		var limited *domainerrors.RateLimitedError
+		if errors.As(err, &limited) {
+			if rateLimited == nil || limited.NextAllowed.Before(rateLimited.NextAllowed) {
+				rateLimited = limited
+			}
+			continue
+		}
+		if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) || errors.As(err, &invalid) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Outfit = proposal.Outfit
+		result.proposal = proposal
+		return result, nil
+	}
+	if rateLimited != nil {
+		return nil, rateLimited
+	}
+	return nil, domainerrors.ErrNoOutfitsAvailable
+}
+
+// restedFirst orders the candidate categories for picking: the rested ones
+// in the order drawn by weighted, then the ones still resting, the longest
+// rested first, in case nothing else can be picked. The resting categories
+// are noted in result.
+func (u *PickAnyOutfitUseCase) restedFirst(config *entities.Config, candidates []string, weighted func([]string) []string, result *AggregatePick) ([]string, error) {
+	days := config.Selection.CategoryRestDays
+	if days == 0 {
+		return weighted(candidates), nil
+	}
+	picks, err := u.services.LastPicked.Load()
+	if err != nil {
+		return nil, err
+	}
+	now := u.services.now()
+	var rested, resting []string
+	for _, category := range candidates {
+		if logic.CategoryResting(picks.LastPicked[category], days, now) {
+			resting = append(resting, category)
+		} else {
+			rested = append(rested, category)
+		}
+	}
+	result.Resting = resting
+	slices.SortStableFunc(resting, func(a, b string) int {
+		return picks.LastPicked[a].Compare(picks.LastPicked[b])
+	})
+	return append(weighted(rested), resting...), nil
+}
+
+// randomSource returns a randomly seeded source, or one seeded with seed so
+// the same seed draws the same categories.
+func randomSource(seed *uint64) *rand.Rand {
+	if seed != nil {
+		return rand.New(rand.NewPCG(*seed, *seed))
+	}
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func newAggregateEnv(t *testing.T, policy string) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, map[string][]string{
+		"beach":  {},
+		"casual": {"a.avatar"},
+		"docs":   {"notes.txt"},
+		"formal": {"suit.avatar"},
+	})
+	env.config.Config.ExcludedCategories = map[string]bool{"formal": true}
+	env.config.Config.Selection.EmptyCategories = policy
+	return env
+}
+
+func TestPickAnyOutfitUseCase_SkipsEmptyCategories(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   string
+	}{
+		{"", entities.EmptyCategoriesSkip},
+		{entities.EmptyCategoriesSkip, entities.EmptyCategoriesSkip},
+		{entities.EmptyCategoriesWarn, entities.EmptyCategoriesWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			env := newAggregateEnv(t, tt.policy)
+
+			result, err := NewPickAnyOutfitUseCase(env.services).Execute()
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if result.Outfit.Category.Name != "casual" || result.Outfit.FileName != "a.avatar" {
+				t.Errorf("Execute() outfit = %v, want casual/a.avatar", result.Outfit)
+			}
+			if result.Policy != tt.want {
+				t.Errorf("Policy = %q, want %q", result.Policy, tt.want)
+			}
+			if len(result.Skipped) != 2 ||
+				result.Skipped[0].Category.Name != "beach" || result.Skipped[0].State != entities.CategoryStateEmpty ||
+				result.Skipped[1].Category.Name != "docs" || result.Skipped[1].State != entities.CategoryStateNoAvatarFiles {
+				t.Errorf("Skipped = %+v, want beach (empty) and docs (no avatar files)", result.Skipped)
+			}
+			if len(env.history.History.Records) != 1 {
+				t.Errorf("history = %+v, want the pick", env.history.History.Records)
+			}
+		})
+	}
+}
+
+func TestPickAnyOutfitUseCase_FailPolicy(t *testing.T) {
+	env := newAggregateEnv(t, entities.EmptyCategoriesFail)
+
+	_, err := NewPickAnyOutfitUseCase(env.services).Execute()
+	var empty *domainerrors.EmptyCategoriesError
+	if !errors.As(err, &empty) || len(empty.Categories) != 2 {
+		t.Fatalf("Execute() error = %v, want EmptyCategoriesError for beach and docs", err)
+	}
+	if env.history.Saves != 0 || env.cache.Saves != 0 {
+		t.Error("a failed pick saved state")
+	}
+}
+
+func TestPickAnyOutfitUseCase_TriesOtherCategories(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"a.avatar"},
+		"work":   {"b.avatar"},
+	})
+	env.metadata.Index = env.metadata.Index.Setting("work", "b.avatar", entities.OutfitMetadata{Tags: []string{"smart"}})
+
+	for seed := range uint64(10) {
+		result, err := NewPickAnyOutfitUseCase(env.services).Execute(WithTag("smart"), WithPickSeed(seed))
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if result.Outfit.Category.Name != "work" {
+			t.Fatalf("Execute() picked from %s, want the only category with the tag", result.Outfit.Category.Name)
+		}
+	}
+
+	_, err := NewPickAnyOutfitUseCase(env.services).Execute(WithTag("formal"))
+	if !errors.Is(err, domainerrors.ErrNoOutfitsAvailable) {
+		t.Errorf("Execute() with an unused tag error = %v, want ErrNoOutfitsAvailable", err)
+	}
+}
+
+func TestPickAnyOutfitUseCase_RestsRecentCategories(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"a.avatar", "b.avatar"},
+		"work":   {"c.avatar", "d.avatar"},
+	})
+	env.config.Config.Selection.CategoryRestDays = 1
+	useCase := NewPickAnyOutfitUseCase(env.services)
+
+	first, err := useCase.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := env.lastPicked.Picks.LastPicked[first.Outfit.Category.Name]; !got.Equal(testNow) {
+		t.Errorf("last picked = %v, want %v", got, testNow)
+	}
+
+	for _, day := range []int{0, 1} {
+		env.services.Now = func() time.Time { return testNow.AddDate(0, 0, day) }
+		env.lastPicked.Picks = entities.NewCategoryPicks().Recording(first.Outfit.Category.Name, testNow)
+		next, err := NewPickAnyOutfitUseCase(env.services).Execute()
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if next.Outfit.Category.Name == first.Outfit.Category.Name || !slices.Equal(next.Resting, []string{first.Outfit.Category.Name}) {
+			t.Errorf("day %d: picked %s, resting %v; want the other category", day, next.Outfit.Category.Name, next.Resting)
+		}
+	}
+}
+
+func TestPickAnyOutfitUseCase_FallsBackToRestingCategory(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	env.config.Config.Selection.CategoryRestDays = 3
+	env.lastPicked.Picks = entities.NewCategoryPicks().Recording("casual", testNow)
+
+	result, err := NewPickAnyOutfitUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Outfit.Category.Name != "casual" || !slices.Equal(result.Resting, []string{"casual"}) {
+		t.Errorf("Execute() = %+v, want casual despite resting", result)
+	}
+}
+
+func TestPickAnyOutfitUseCase_CategoryWeighting(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar"},
+		"work":   {"e.avatar", "f.avatar", "g.avatar", "h.avatar", "i.avatar", "j.avatar", "k.avatar", "l.avatar"},
+	})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(4).Adding("a.avatar").Adding("b.avatar").Adding("c.avatar"))
+	useCase := NewPickAnyOutfitUseCase(env.services)
+	countCasual := func() int {
+		t.Helper()
+		casual := 0
+		for seed := range uint64(100) {
+			result, err := useCase.Execute(WithPickSeed(seed))
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if result.Outfit.Category.Name == "casual" {
+				casual++
+			}
+		}
+		return casual
+	}
+
+	env.config.Config.Selection.CategoryBalance = entities.CategoryBalanceRemaining
+	if casual := countCasual(); casual < 80 {
+		t.Errorf("casual, with 1 outfit left against 8, picked %d times in 100, want about 89", casual)
+	}
+
+	env.config.Config.Selection.CategoryBalance = ""
+	env.config.Config.Selection.CategoryPriorities = map[string]float64{"casual": 0}
+	if casual := countCasual(); casual != 0 {
+		t.Errorf("casual, with priority 0, picked %d times in 100", casual)
+	}
+}
+
+func TestPickAnyOutfitUseCase_PickLimits(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "work": {"c.avatar"}})
+	env.config.Config.Selection.CategoryPickLimits = map[string]int{"casual": 1, "work": 1}
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "a.avatar", SelectedAt: testNow.Add(-time.Hour)},
+	}}
+
+	result, err := NewPickAnyOutfitUseCase(env.services).Execute()
+	if err != nil || result.Outfit.Category.Name != "work" {
+		t.Fatalf("Execute() = %+v, %v; want a pick from work, below its limit", result, err)
+	}
+	if _, err := NewPickAnyOutfitUseCase(env.services).Execute(); !errors.As(err, new(*domainerrors.RateLimitedError)) {
+		t.Errorf("Execute() with every category at its limit error = %v, want RateLimitedError", err)
+	}
+}
+
+func TestPickAnyOutfitUseCase_Permissions(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "work": {"b.avatar"}, "beach": {}})
+	env.config.Config.Selection.EmptyCategories = entities.EmptyCategoriesFail
+	env.config.Config.Permissions = entities.CategoryPermissions{Pick: []string{"work"}}
+
+	for seed := range uint64(5) {
+		result, err := NewPickAnyOutfitUseCase(env.services).Execute(WithPickSeed(seed))
+		if err != nil || result.Outfit.Category.Name != "work" {
+			t.Fatalf("Execute() = %+v, %v; want a pick from work, the only category allowed", result, err)
+		}
+		env.cache.Cache = entities.OutfitCache{}
+	}
+}
+
+func TestPickAnyOutfitUseCase_Weather(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"beach": {"shorts.avatar"}, "work": {"suit.avatar"}})
+	env.config.Config.Weather = entities.WeatherSettings{Location: &testLocation}.SettingCategory("beach", []string{entities.WeatherCold})
+	env.weather.Result = entities.Forecast{MaxTemperature: 3}
+
+	for seed := range uint64(5) {
+		result, err := NewPickAnyOutfitUseCase(env.services).Execute(WithWeather(), WithPickSeed(seed))
+		if err != nil || result.Outfit.Category.Name != "work" {
+			t.Fatalf("Execute() = %+v, %v; want a pick from work, beach being unsuitable for the cold", result, err)
+		}
+		env.cache.Cache = entities.OutfitCache{}
+	}
+	if env.weather.Calls != 1 {
+		t.Errorf("forecast fetched %d times, want once", env.weather.Calls)
+	}
+}
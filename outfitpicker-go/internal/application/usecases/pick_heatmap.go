@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// firstHeatmapYear is the earliest year a pick heatmap can be drawn for.
+const firstHeatmapYear = 2000
+
+// PickHeatmapUseCase counts the picks of each day of a year from the
+// selection history. It only reads, so it also works in maintenance mode.
+type PickHeatmapUseCase struct {
+	services Services
+}
+
+// NewPickHeatmapUseCase creates a new pick heatmap use case.
+func NewPickHeatmapUseCase(services Services) *PickHeatmapUseCase {
+	return &PickHeatmapUseCase{services: services}
+}
+
+// Execute counts the picks from the named category, or from every category
+// when it is empty, on each day of year. Zero means the current year.
+func (u *PickHeatmapUseCase) Execute(category string, year int) (logic.PickHeatmap, error) {
+	now := u.services.now()
+	if year == 0 {
+		year = now.Year()
+	}
+	if year < firstHeatmapYear || year > now.Year() {
+		return logic.PickHeatmap{}, errors.NewInvalidInputError(fmt.Sprintf("year must be between %d and %d", firstHeatmapYear, now.Year()))
+	}
+	if category != "" {
+		resolved, err := NewResolveCategoryUseCase(u.services).Execute(category)
+		if err != nil {
+			return logic.PickHeatmap{}, err
+		}
+		category = resolved.Name
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return logic.PickHeatmap{}, err
+	}
+	return logic.BuildPickHeatmap(history, category, year, now.Location()), nil
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestPickHeatmapUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"shirt.avatar"}})
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: may(10)},
+		{Category: "work", FileName: "shirt.avatar", SelectedAt: may(10)},
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: may(12)},
+	}}
+	env.maintenance.State.Enabled = true
+
+	heatmap, err := NewPickHeatmapUseCase(env.services).Execute("Casual", 0)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if heatmap.Year != 2024 || heatmap.Category != "casual" || heatmap.Total != 2 {
+		t.Errorf("Execute() = year %d, category %q, total %d; want 2024, casual, 2", heatmap.Year, heatmap.Category, heatmap.Total)
+	}
+	if all, err := NewPickHeatmapUseCase(env.services).Execute("", 2024); err != nil || all.Total != 3 || all.MaxPicks != 2 {
+		t.Errorf("Execute() of every category = total %d, max %d, %v; want 3, 2", all.Total, all.MaxPicks, err)
+	}
+
+	for _, year := range []int{1999, 2025} {
+		if _, err := NewPickHeatmapUseCase(env.services).Execute("", year); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+			t.Errorf("Execute() for %d error = %v, want InvalidInputError", year, err)
+		}
+	}
+	if _, err := NewPickHeatmapUseCase(env.services).Execute("gym", 0); !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("Execute() of an unknown category error = %v, want ErrCategoryNotFound", err)
+	}
+}
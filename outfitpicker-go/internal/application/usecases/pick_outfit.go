@@ -0,0 +1,634 @@
+package usecases
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// PickOutfitUseCase selects a random unworn outfit from a category.
+type PickOutfitUseCase struct {
+	services Services
+}
+
+// NewPickOutfitUseCase creates a new pick outfit use case.
+func NewPickOutfitUseCase(services Services) *PickOutfitUseCase {
+	return &PickOutfitUseCase{services: services}
+}
+
+// PickOption configures a single pick.
+type PickOption func(*pickOptions)
+
+type pickOptions struct {
+	seed          *uint64
+	favoritesOnly bool
+	tag           string
+	anyTags       []string
+	season        string
+	without       []string
+	policy        *entities.RotationPolicy
+	// planning picks for days to come, which do not count toward the
+	// daily pick limits of today.
+	planning bool
+	weather  bool
+	// within limits picks across categories to the named ones.
+	within []string
+}
+
+// WithPickSeed makes the pick reproducible: the same seed picks the same
+// outfit while the category's unworn outfits stay the same.
+func WithPickSeed(seed uint64) PickOption {
+	return func(o *pickOptions) {
+		o.seed = &seed
+	}
+}
+
+// WithFavoritesOnly restricts the pick to the category's favorites.
+func WithFavoritesOnly() PickOption {
+	return func(o *pickOptions) {
+		o.favoritesOnly = true
+	}
+}
+
+// WithTag restricts the pick to outfits tagged with tag.
+func WithTag(tag string) PickOption {
+	return func(o *pickOptions) {
+		o.tag = tag
+	}
+}
+
+// WithAnyTag restricts the pick to outfits tagged with any of tags.
+func WithAnyTag(tags ...string) PickOption {
+	return func(o *pickOptions) {
+		o.anyTags = append(o.anyTags, tags...)
+	}
+}
+
+// WithSeason restricts the pick to an in-season category and its in-season
+// outfits. entities.SeasonAuto uses the season of the current date.
+func WithSeason(season string) PickOption {
+	return func(o *pickOptions) {
+		o.season = season
+	}
+}
+
+// WithWeather leaves out a category unsuitable for today's forecast and
+// the outfits whose tags are.
+func WithWeather() PickOption {
+	return func(o *pickOptions) {
+		o.weather = true
+	}
+}
+
+// WithRotationPolicy picks under policy instead of the category's
+// configured rotation policy.
+func WithRotationPolicy(policy entities.RotationPolicy) PickOption {
+	return func(o *pickOptions) {
+		o.policy = &policy
+	}
+}
+
+// PickProposal is an outfit picked from a category but not yet recorded.
+// Nothing about the pick is saved until the proposal is committed.
+type PickProposal struct {
+	Outfit entities.OutfitReference
+	// Available is how many outfits the proposal was picked from.
+	Available int
+	// Total is how many outfits the category holds.
+	Total int
+
+	files         []entities.FileEntry
+	resetRotation bool
+	newSightings  bool
+}
+
+// StateChange is a change committing a proposal makes to a state file.
+type StateChange struct {
+	// Store names the state file changed, such as "cache" or "history".
+	Store string `json:"store"`
+	// Change describes the change.
+	Change string `json:"change"`
+}
+
+// Changes returns the changes committing the proposal makes, in the order
+// Commit makes them.
+func (p *PickProposal) Changes() []StateChange {
+	category := p.Outfit.Category.Name
+	var changes []StateChange
+	if p.newSightings {
+		changes = append(changes, StateChange{Store: "arrivals", Change: fmt.Sprintf("note when the outfits of %s were last seen", category)})
+	}
+	if p.resetRotation {
+		changes = append(changes, StateChange{Store: "cache", Change: fmt.Sprintf("start a new rotation of %s with all %d outfits unworn", category, p.Total)})
+	}
+	return append(changes,
+		StateChange{Store: "history", Change: fmt.Sprintf("record the pick of %s/%s", category, p.Outfit.FileName)},
+		StateChange{Store: "latest picks", Change: fmt.Sprintf("write %s/%s to picks/latest.json as the latest pick of %s", category, p.Outfit.FileName, category)},
+		StateChange{Store: "category picks", Change: fmt.Sprintf("note %s as picked from now", category)},
+	)
+}
+
+// WithoutOutfits leaves the named outfits out of the pick, such as ones
+// already turned down.
+func WithoutOutfits(fileNames ...string) PickOption {
+	return func(o *pickOptions) {
+		o.without = append(o.without, fileNames...)
+	}
+}
+
+// Execute picks an outfit from the named category and records it. If every
+// outfit has been worn the category's rotation is reset first. The scan of
+// the category records when outfits were first and last seen, for the new
+// arrival policy.
+func (u *PickOutfitUseCase) Execute(categoryName string, opts ...PickOption) (*entities.OutfitReference, error) {
+	proposal, err := u.Propose(categoryName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.Commit(proposal); err != nil {
+		return nil, err
+	}
+	return &proposal.Outfit, nil
+}
+
+// Propose picks an outfit from the named category without saving anything.
+// Commit records the proposal; a proposal that is dropped leaves no trace.
+// While a capsule challenge runs, only the capsule's outfits are picked. A
+// category picked from as often today as its daily pick limit allows fails
+// with a RateLimitedError. The profile's category permissions decide which
+// categories may be picked from and which rotations may be started over,
+// and a locked rotation is never started over by a pick.
+func (u *PickOutfitUseCase) Propose(categoryName string, opts ...PickOption) (*PickProposal, error) {
+	var options pickOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := logic.ValidateCategoryName(categoryName); err != nil {
+		return nil, err
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !config.Permissions.CanPick(categoryName) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("this profile may not pick from %s", categoryName))
+	}
+	if !options.planning {
+		if err := u.checkPickLimit(config, categoryName); err != nil {
+			return nil, err
+		}
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := u.services.categoryReference(config, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := u.services.outfitsIn(config, category)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.ErrNoOutfitsAvailable
+	}
+	challenge, err := u.services.Challenge.Load()
+	if err != nil {
+		return nil, err
+	}
+	inChallenge := challenge.ActiveAt(u.services.now())
+	if inChallenge && !challenge.HasCategory(categoryName) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("%s has no outfits in the capsule challenge", categoryName))
+	}
+	arrivals, err := u.services.Arrivals.Load()
+	if err != nil {
+		return nil, err
+	}
+	arrivals, newSightings := arrivals.Recording(categoryName, fileNames(files), u.services.now())
+	firstSeen := arrivals.Category(categoryName)
+
+	categoryCache, ok := cache.Categories[categoryName]
+	if !ok {
+		categoryCache = entities.NewCategoryCache(len(files))
+	}
+
+	reached, index, err := u.reachedTagConstraints(config)
+	if err != nil {
+		return nil, err
+	}
+	var limitWeight func(entities.FileEntry) float64
+	if slices.ContainsFunc(reached, func(c entities.TagConstraint) bool { return !c.Blocks() }) {
+		limitWeight = logic.TagConstraintWeights(reached, index, categoryName)
+	}
+	policy, lastWorn, err := u.rotationPolicy(config, categoryName, options)
+	if err != nil {
+		return nil, err
+	}
+	var narrow func([]entities.FileEntry) []entities.FileEntry
+	if policy.EffectiveName() == entities.RotationLeastRecentlyWorn {
+		narrow = logic.LeastRecentlyWorn(lastWorn)
+	}
+	selector, err := u.selector(config, categoryName, options, limitWeight, narrow)
+	if err != nil {
+		return nil, err
+	}
+	filters, prefer, err := u.filters(config, categoryName, files, firstSeen, options)
+	if err != nil {
+		return nil, err
+	}
+	pickable, err := u.pickable(categoryName, files)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skipped outfits and those in the laundry are not picked, so the
+	// rotation is complete once every outfit that may be is worn.
+	worn := categoryCache.WornOutfits
+	wornPickable := len(pickable) - len(logic.FilterAvailableOutfits(pickable, worn))
+	resetRotation := logic.ShouldResetRotation(wornPickable, len(pickable))
+	if resetRotation && config.Selection.RotationLocked(categoryName) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("every outfit in %s has been worn and its rotation is locked; start a new one with: rotation reset %s", categoryName, categoryName))
+	}
+	if resetRotation && !config.Permissions.CanReset(categoryName) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("every outfit in %s has been worn and this profile may not start a new rotation", categoryName))
+	}
+	if resetRotation {
+		worn = nil
+	}
+	excluded := logic.RotationExclusions(policy, worn, lastWorn, u.services.now())
+	pool := logic.FilterAvailableOutfits(pickable, excluded, filters...)
+	if prefer != nil {
+		if preferred := logic.FilterAvailableOutfits(pool, nil, prefer); len(preferred) > 0 {
+			pool = preferred
+		}
+	}
+	if len(pool) == 0 && options.tag != "" {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("no unworn outfits tagged %q in %s", options.tag, categoryName))
+	}
+	if len(pool) == 0 && options.season != "" {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("no unworn in-season outfits in %s", categoryName))
+	}
+	if len(pool) == 0 && options.weather {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("no unworn outfits in %s suit today's forecast", categoryName))
+	}
+	if len(pool) == 0 && config.Selection.NewArrivals.Mode == entities.NewArrivalsHold {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("every unworn outfit in %s is a new arrival waiting to be tagged; tag them or run triage", categoryName))
+	}
+	if len(pool) == 0 && policy.EffectiveName() == entities.RotationCooldown {
+		// Every outfit is cooling down, so any may be picked.
+		pool = pickable
+	}
+	if inChallenge {
+		// Capsule outfits are worn again and again during a challenge, so
+		// once none is left to pick in the rotation any of them can be.
+		inCapsule := logic.InCapsule(challenge, categoryName)
+		if pool = logic.FilterAvailableOutfits(pool, nil, inCapsule); len(pool) == 0 {
+			pool = logic.FilterAvailableOutfits(pickable, nil, inCapsule)
+		}
+		if len(pool) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("none of the capsule outfits of %s are in the wardrobe", categoryName))
+		}
+	}
+	// unexcluded is the pool before the outfits the caller left out were
+	// taken from it.
+	unexcluded := pool
+	if len(options.without) > 0 {
+		pool = logic.FilterAvailableOutfits(pool, nil, func(entry entities.FileEntry) bool {
+			return !slices.Contains(options.without, entry.FileName)
+		})
+	}
+
+	if days := config.Selection.CooldownDays; days > 0 && len(pool) > 0 {
+		pool = logic.FilterAvailableOutfits(pool, logic.CoolingDown(categoryCache.LastWorn, days, u.services.now()))
+		if len(pool) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("every outfit left to pick in %s was worn within the last %d days", categoryName, days))
+		}
+	}
+
+	if len(pool) > 0 && slices.ContainsFunc(reached, entities.TagConstraint.Blocks) {
+		pool = logic.FilterAvailableOutfits(pool, nil, logic.WithinTagConstraints(reached, index, categoryName))
+		if len(pool) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("every outfit left to pick in %s breaks a tag limit: %s", categoryName, blockingLimits(reached)))
+		}
+	}
+
+	selected, ok := selector.Select(pool)
+	// Favorites that ran out only because the caller left them out are no
+	// outfits available, like any other pool the caller emptied.
+	if !ok && options.favoritesOnly && !selector.Keeps(unexcluded) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("no unworn favorites in %s", categoryName))
+	}
+	if !ok {
+		return nil, errors.ErrNoOutfitsAvailable
+	}
+
+	return &PickProposal{
+		Outfit:        entities.NewOutfitReference(selected.FileName, category),
+		Available:     len(pool),
+		Total:         len(files),
+		files:         files,
+		resetRotation: resetRotation,
+		newSightings:  newSightings,
+	}, nil
+}
+
+// Commit records a proposal: the outfits its scan saw, the rotation reset
+// it relied on, the pick itself and when its category was last picked from.
+func (u *PickOutfitUseCase) Commit(proposal *PickProposal) error {
+	if err := u.services.ensureWritable(); err != nil {
+		return err
+	}
+	categoryName := proposal.Outfit.Category.Name
+	if _, err := u.services.recordArrivals(categoryName, proposal.files); err != nil {
+		return err
+	}
+	if proposal.resetRotation {
+		err := u.services.Cache.UpdateCategory(categoryName, func(current entities.CategoryCache, _ bool) (entities.CategoryCache, error) {
+			return current.Restarted(len(proposal.files)), nil
+		})
+		if err != nil {
+			return err
+		}
+		u.services.logger().Info("started a new rotation", "category", categoryName, "outfits", len(proposal.files))
+	}
+	if err := u.recordSelection(proposal.Outfit); err != nil {
+		return err
+	}
+	u.services.logger().Info("picked outfit", "category", categoryName, "outfit", proposal.Outfit.FileName, "available", proposal.Available, "total", proposal.Total)
+	return retryOnConflict(func() error {
+		picks, err := u.services.LastPicked.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.LastPicked.Save(picks.Recording(categoryName, u.services.now()))
+	})
+}
+
+// checkPickLimit fails with a RateLimitedError when the category has been
+// picked from as often today as its daily pick limit allows.
+func (u *PickOutfitUseCase) checkPickLimit(config *entities.Config, categoryName string) error {
+	limit := config.Selection.PickLimit(categoryName)
+	if limit == 0 {
+		return nil
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return err
+	}
+	if next, limited := logic.NextPickAllowed(history, categoryName, limit, u.services.now()); limited {
+		return errors.NewRateLimitedError(categoryName, limit, next)
+	}
+	return nil
+}
+
+// recordSelection appends the pick to the selection history and makes it
+// the latest pick of its category.
+func (u *PickOutfitUseCase) recordSelection(outfit entities.OutfitReference) error {
+	id, err := u.services.outfitID(outfit.Category.Name, outfit.FileName)
+	if err != nil {
+		return err
+	}
+	record := entities.SelectionRecord{
+		Category:   outfit.Category.Name,
+		FileName:   outfit.FileName,
+		SelectedAt: u.services.now(),
+		OutfitID:   id,
+	}
+	err = retryOnConflict(func() error {
+		history, err := u.services.History.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.History.Save(history.Appending(record))
+	})
+	if err != nil {
+		return err
+	}
+	latest := entities.LatestPick{FileName: outfit.FileName, Path: outfit.FilePath(), PickedAt: record.SelectedAt, OutfitID: id}
+	return retryOnConflict(func() error {
+		picks, err := u.services.LatestPicks.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.LatestPicks.Save(picks.Recording(outfit.Category.Name, latest))
+	})
+}
+
+// pickable returns the files that may be picked at all: those not skipped
+// and, while laundry is tracked, those that are clean. Both are left out
+// without being worn, so even the fallbacks of a pick never choose them.
+func (u *PickOutfitUseCase) pickable(categoryName string, files []entities.FileEntry) ([]entities.FileEntry, error) {
+	skipped, err := u.services.Skipped.Load()
+	if err != nil {
+		return nil, err
+	}
+	pickable := logic.FilterAvailableOutfits(files, skipped.CategoryAt(categoryName, u.services.now()))
+	if len(pickable) == 0 {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("every outfit in %s is skipped; unskip one first", categoryName))
+	}
+	laundry, err := u.services.Laundry.Load()
+	if err != nil || !laundry.Enabled {
+		return pickable, err
+	}
+	pickable = logic.FilterAvailableOutfits(pickable, laundry.Unavailable(categoryName))
+	if len(pickable) == 0 {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("no outfit in %s is clean; once the wash is done, run: laundry done", categoryName))
+	}
+	return pickable, nil
+}
+
+// filters returns the filters that narrow the pool of unworn outfits, and
+// a filter for the outfits to prefer when any of the pool passes it. A tag
+// no outfit of the category carries, or a category out of season or
+// unsuitable for today's forecast, is rejected before anything changes.
+func (u *PickOutfitUseCase) filters(config *entities.Config, categoryName string, files []entities.FileEntry, firstSeen map[string]time.Time, options pickOptions) ([]logic.OutfitFilter, logic.OutfitFilter, error) {
+	policy := config.Selection.NewArrivals
+	if options.tag == "" && len(options.anyTags) == 0 && options.season == "" && !options.weather && policy.Mode == "" {
+		return nil, nil, nil
+	}
+	if options.tag != "" {
+		if err := validation.ValidateTags([]string{options.tag}); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := validation.ValidateTags(options.anyTags); err != nil {
+		return nil, nil, err
+	}
+	index, err := u.services.taggedMetadata(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var filters []logic.OutfitFilter
+	var prefer logic.OutfitFilter
+	if options.tag != "" {
+		tagged := logic.TaggedWith(index, categoryName, options.tag)
+		if len(logic.FilterAvailableOutfits(files, nil, tagged)) == 0 {
+			return nil, nil, errors.NewInvalidInputError(fmt.Sprintf("no outfits in %s are tagged %q", categoryName, options.tag))
+		}
+		filters = append(filters, tagged)
+	}
+	if len(options.anyTags) > 0 {
+		tagged := logic.TaggedWithAny(index, categoryName, options.anyTags)
+		if len(logic.FilterAvailableOutfits(files, nil, tagged)) == 0 {
+			return nil, nil, errors.NewInvalidInputError(fmt.Sprintf("no outfits in %s are tagged %s", categoryName, strings.Join(options.anyTags, " or ")))
+		}
+		filters = append(filters, tagged)
+	}
+	if options.season != "" {
+		season, err := logic.ResolveSeason(options.season, config.Seasons, u.services.now())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !logic.CategoryInSeason(config.Seasons, categoryName, season) {
+			return nil, nil, errors.NewInvalidInputError(fmt.Sprintf("%s is out of season in %s", categoryName, season))
+		}
+		filters = append(filters, logic.InSeason(config.Seasons, index, categoryName, season))
+	}
+	if options.weather {
+		conditions, err := u.services.weatherConditions(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		if unsuitable := logic.UnsuitableFor(config.Weather.Categories[categoryName], conditions); len(unsuitable) > 0 {
+			return nil, nil, errors.NewInvalidInputError(fmt.Sprintf("%s is unsuitable for today's forecast: %s", categoryName, strings.Join(unsuitable, ", ")))
+		}
+		filters = append(filters, logic.SuitsWeather(config.Weather, index, categoryName, conditions))
+	}
+	switch policy.Mode {
+	case entities.NewArrivalsPrefer:
+		prefer = logic.NewArrivals(firstSeen, policy, u.services.now())
+	case entities.NewArrivalsHold:
+		filters = append(filters, logic.NotHeldBack(firstSeen, policy, u.services.now(), index, categoryName))
+	}
+	return filters, prefer, nil
+}
+
+// rotationPolicy returns the rotation policy the pick follows and, for the
+// policies that need it, when each outfit of the category was last worn.
+// A locked rotation stays strict whatever policy the pick asks for.
+func (u *PickOutfitUseCase) rotationPolicy(config *entities.Config, categoryName string, options pickOptions) (entities.RotationPolicy, map[string]time.Time, error) {
+	policy := config.Selection.RotationPolicyFor(categoryName)
+	if options.policy != nil && !config.Selection.RotationLocked(categoryName) {
+		policy = *options.policy
+	}
+	switch policy.EffectiveName() {
+	case entities.RotationCooldown, entities.RotationLeastRecentlyWorn:
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return entities.RotationPolicy{}, nil, err
+		}
+		return policy, log.LastWorn(categoryName), nil
+	default:
+		return policy, nil, nil
+	}
+}
+
+// reachedTagConstraints returns the configured tag constraints whose limit
+// recent picks have used up, with the metadata index that tells which
+// outfits they apply to.
+func (u *PickOutfitUseCase) reachedTagConstraints(config *entities.Config) ([]entities.TagConstraint, entities.MetadataIndex, error) {
+	if len(config.Selection.TagConstraints) == 0 {
+		return nil, entities.MetadataIndex{}, nil
+	}
+	index, err := u.services.taggedMetadata(config)
+	if err != nil {
+		return nil, entities.MetadataIndex{}, err
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return nil, entities.MetadataIndex{}, err
+	}
+	return logic.ReachedTagConstraints(config.Selection.TagConstraints, history, index, u.services.now()), index, nil
+}
+
+// blockingLimits describes the blocking constraints in reached.
+func blockingLimits(reached []entities.TagConstraint) string {
+	var limits []string
+	for _, constraint := range reached {
+		if constraint.Blocks() {
+			limits = append(limits, constraint.String())
+		}
+	}
+	return strings.Join(limits, ", ")
+}
+
+// selector returns the selector for the configured strategy. The weighted
+// strategy follows user-assigned weights, falling back to those of sidecar
+// files, and boosts outfits with positive feedback; the rated strategy
+// favors higher-rated outfits among those the rotation leaves. A non-nil
+// limitWeight scales the weights of any strategy, for downgrading tag
+// constraints. Favorites-only picks filter out everything else.
+// A non-nil narrow narrows what is left to the outfits the rotation policy
+// picks first.
+func (u *PickOutfitUseCase) selector(config *entities.Config, categoryName string, options pickOptions, limitWeight func(entities.FileEntry) float64, narrow func([]entities.FileEntry) []entities.FileEntry) (*logic.Selector, error) {
+	var selectorOptions []logic.SelectorOption
+	if options.seed != nil {
+		selectorOptions = append(selectorOptions, logic.WithSeed(*options.seed))
+	}
+	if narrow != nil {
+		selectorOptions = append(selectorOptions, logic.WithNarrowing(narrow))
+	}
+	if options.favoritesOnly {
+		favorites, err := u.services.Favorites.Load()
+		if err != nil {
+			return nil, err
+		}
+		if len(favorites.Category(categoryName)) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("%s has no favorites", categoryName))
+		}
+		selectorOptions = append(selectorOptions, logic.WithFilter(func(entry entities.FileEntry) bool {
+			return favorites.Contains(categoryName, entry.FileName)
+		}))
+	}
+	if config.Selection.IsWeighted() {
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return nil, err
+		}
+		weights, err := u.services.Weights.Load()
+		if err != nil {
+			return nil, err
+		}
+		index, err := u.services.taggedMetadata(config)
+		if err != nil {
+			return nil, err
+		}
+		weight := logic.WeightedPick(weights.CategoryOr(categoryName, index.SidecarWeights(categoryName)), log.FeedbackScores(categoryName), config.Selection.FeedbackBoost)
+		if limitWeight != nil {
+			userWeight := weight
+			weight = func(entry entities.FileEntry) float64 {
+				return userWeight(entry) * limitWeight(entry)
+			}
+		}
+		selectorOptions = append(selectorOptions, logic.WithWeights(weight))
+	} else if config.Selection.IsRated() {
+		index, err := u.services.Metadata.Load()
+		if err != nil {
+			return nil, err
+		}
+		weight := logic.RatedPick(index.RatingsIn(categoryName))
+		if limitWeight != nil {
+			ratedWeight := weight
+			weight = func(entry entities.FileEntry) float64 {
+				return ratedWeight(entry) * limitWeight(entry)
+			}
+		}
+		selectorOptions = append(selectorOptions, logic.WithWeights(weight))
+	} else if limitWeight != nil {
+		selectorOptions = append(selectorOptions, logic.WithWeights(limitWeight))
+	}
+	return logic.NewSelector(selectorOptions...), nil
+}
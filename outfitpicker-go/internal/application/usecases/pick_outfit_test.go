@@ -0,0 +1,452 @@
+package usecases
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestPickOutfitUseCase_PicksUnwornOutfit(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar"))
+
+	for i := 0; i < 10; i++ {
+		outfit, err := NewPickOutfitUseCase(env.services).Execute("casual")
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "b.avatar" {
+			t.Errorf("Execute() = %v, want b.avatar", outfit.FileName)
+		}
+	}
+}
+
+func TestPickOutfitUseCase_MultipleRoots(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	shared := t.TempDir()
+	for _, dir := range []string{"casual", "formal"} {
+		if err := os.MkdirAll(filepath.Join(shared, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeOutfit(t, shared, "casual", "hoodie.avatar")
+	writeOutfit(t, shared, "casual", "cardigan.avatar")
+	writeOutfit(t, shared, "formal", "suit.avatar")
+	env.config.Config.Roots = []string{env.root, shared}
+
+	for category, dir := range map[string]string{"casual": filepath.Join(env.root, "casual"), "casual@2": filepath.Join(shared, "casual"), "formal": filepath.Join(shared, "formal")} {
+		outfit, err := NewPickOutfitUseCase(env.services).Execute(category)
+		if err != nil {
+			t.Fatalf("Execute(%q) error = %v", category, err)
+		}
+		if outfit.Category.Name != category || outfit.Category.Path != dir {
+			t.Errorf("Execute(%q) = %+v, want an outfit from %s", category, outfit.Category, dir)
+		}
+	}
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual@2", "hoodie.avatar")); err != nil {
+		t.Fatalf("wear from the second root: %v", err)
+	}
+	if !env.cache.Cache.Categories["casual@2"].WornOutfits["hoodie.avatar"] || env.cache.Cache.Categories["casual"].WornOutfits["hoodie.avatar"] {
+		t.Errorf("cache = %+v, want the outfit worn under casual@2 only", env.cache.Cache.Categories)
+	}
+}
+
+func TestPickOutfitUseCase_SeedIsReproducible(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar"}})
+	useCase := NewPickOutfitUseCase(env.services)
+
+	first, err := useCase.Execute("casual", WithPickSeed(2024))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for range 10 {
+		again, err := useCase.Execute("casual", WithPickSeed(2024))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again.FileName != first.FileName {
+			t.Fatalf("seeded pick = %v, then %v", first.FileName, again.FileName)
+		}
+	}
+}
+
+func TestPickOutfitUseCase_ResetsCompletedRotation(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(1).Adding("a.avatar"))
+
+	outfit, err := NewPickOutfitUseCase(env.services).Execute("casual")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if outfit.FileName != "a.avatar" {
+		t.Errorf("Execute() = %v, want a.avatar", outfit.FileName)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after reset = %v, want 0", worn)
+	}
+}
+
+func TestPickOutfitUseCase_ResetsRotationWithSkippedOutfits(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.skipped.Skipped = entities.SkippedOutfits{Outfits: map[string]map[string]time.Time{"casual": {"c.avatar": {}}}}
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("a.avatar").Adding("b.avatar"))
+
+	proposal, err := NewPickOutfitUseCase(env.services).Propose("casual")
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	if !proposal.resetRotation || proposal.Outfit.FileName == "c.avatar" {
+		t.Errorf("Propose() = %s, reset = %v, want a new rotation without the skipped outfit", proposal.Outfit.FileName, proposal.resetRotation)
+	}
+	if err := NewPickOutfitUseCase(env.services).Commit(proposal); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after reset = %v, want 0", worn)
+	}
+}
+
+func TestPickOutfitUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		setup    func(*testEnv)
+		wantErr  error
+	}{
+		{"missing category", "formal", nil, domainerrors.ErrCategoryNotFound},
+		{"empty category", "empty", nil, domainerrors.ErrNoOutfitsAvailable},
+		{"maintenance mode", "casual", func(e *testEnv) {
+			e.maintenance.State = entities.NewMaintenanceState(nil, testNow)
+		}, domainerrors.ErrMaintenanceMode},
+		{"no configuration", "casual", func(e *testEnv) {
+			e.config.Config = nil
+		}, domainerrors.ErrConfigurationNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "empty": nil})
+			if tt.setup != nil {
+				tt.setup(env)
+			}
+			_, err := NewPickOutfitUseCase(env.services).Execute(tt.category)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPickOutfitUseCase_InvalidCategoryName(t *testing.T) {
+	env := newTestEnv(t, nil)
+	_, err := NewPickOutfitUseCase(env.services).Execute("  ")
+
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Errorf("Execute() error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickOutfitUseCase_NewArrivals(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	useCase := NewPickOutfitUseCase(env.services)
+
+	// The first pick records the existing outfits as already there.
+	if _, err := useCase.Execute("casual"); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if firstSeen := env.arrivals.Arrivals.Category("casual"); len(firstSeen) != 2 || !firstSeen["a.avatar"].IsZero() {
+		t.Fatalf("arrivals after the first pick = %v", firstSeen)
+	}
+	env.cache.Cache = entities.NewOutfitCache()
+	writeOutfit(t, env.root, "casual", "new.avatar")
+
+	env.config.Config.Selection.NewArrivals = entities.NewArrivalPolicy{Mode: entities.NewArrivalsPrefer, Days: 7}
+	for range 10 {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "new.avatar" {
+			t.Fatalf("Execute() = %v, want the new arrival", outfit.FileName)
+		}
+	}
+	if !env.arrivals.Arrivals.Category("casual")["new.avatar"].Equal(testNow) {
+		t.Errorf("new.avatar first seen = %v, want %v", env.arrivals.Arrivals.Category("casual")["new.avatar"], testNow)
+	}
+
+	env.config.Config.Selection.NewArrivals.Mode = entities.NewArrivalsHold
+	for range 10 {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName == "new.avatar" {
+			t.Fatal("Execute() picked an untagged new arrival under the hold policy")
+		}
+	}
+
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("a.avatar").Adding("b.avatar"))
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Execute("casual"); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with only held outfits unworn error = %v, want InvalidInputError", err)
+	}
+	env.metadata.Index = env.metadata.Index.Setting("casual", "new.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+	if outfit, err := useCase.Execute("casual"); err != nil || outfit.FileName != "new.avatar" {
+		t.Errorf("Execute() after tagging = %v, %v; want new.avatar", outfit, err)
+	}
+}
+
+func TestPickOutfitUseCase_ProposeSavesNothingUntilCommitted(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(1).Adding("a.avatar"))
+	useCase := NewPickOutfitUseCase(env.services)
+
+	proposal, err := useCase.Propose("casual")
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	if proposal.Outfit.FileName != "a.avatar" || proposal.Available != 1 || proposal.Total != 1 {
+		t.Errorf("Propose() = %+v, want a.avatar from 1 of 1", proposal)
+	}
+	if env.cache.Saves != 0 || env.history.Saves != 0 || env.arrivals.Saves != 0 || env.lastPicked.Saves != 0 || env.latestPicks.Saves != 0 {
+		t.Fatalf("Propose() saved cache %d, history %d, arrivals %d, last picked %d, latest picks %d times, want none",
+			env.cache.Saves, env.history.Saves, env.arrivals.Saves, env.lastPicked.Saves, env.latestPicks.Saves)
+	}
+
+	var stores []string
+	for _, change := range proposal.Changes() {
+		stores = append(stores, change.Store)
+	}
+	if !slices.Equal(stores, []string{"arrivals", "cache", "history", "latest picks", "category picks"}) {
+		t.Errorf("Changes() touch %v, want arrivals, cache, history, latest picks and category picks", stores)
+	}
+
+	if err := useCase.Commit(proposal); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after commit = %v, want the rotation reset", worn)
+	}
+	if records := env.history.History.Records; len(records) != 1 || records[0].FileName != "a.avatar" {
+		t.Errorf("history after commit = %+v, want the pick", records)
+	}
+	if env.arrivals.Saves != 1 {
+		t.Errorf("arrival saves after commit = %d, want 1", env.arrivals.Saves)
+	}
+	if got := env.lastPicked.Picks.LastPicked["casual"]; !got.Equal(testNow) {
+		t.Errorf("casual last picked = %v, want %v", got, testNow)
+	}
+	latest := env.latestPicks.Picks.Categories["casual"]
+	if latest.FileName != "a.avatar" || latest.Path != env.outfit("casual", "a.avatar").FilePath() || !latest.PickedAt.Equal(testNow) {
+		t.Errorf("latest pick of casual = %+v, want a.avatar picked now", latest)
+	}
+}
+
+func TestPickOutfitUseCase_WithoutOutfits(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 10 {
+		proposal, err := useCase.Propose("casual", WithoutOutfits("a.avatar", "c.avatar"))
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if proposal.Outfit.FileName != "b.avatar" || proposal.Available != 1 {
+			t.Fatalf("Propose() = %+v, want b.avatar from 1", proposal)
+		}
+	}
+
+	_, err := useCase.Propose("casual", WithoutOutfits("a.avatar", "b.avatar", "c.avatar"))
+	if !errors.Is(err, domainerrors.ErrNoOutfitsAvailable) {
+		t.Errorf("Propose() without every outfit error = %v, want ErrNoOutfitsAvailable", err)
+	}
+}
+
+func TestPickOutfitUseCase_TagConstraints(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"black-tee.avatar", "black-jeans.avatar", "white-tee.avatar"}})
+	for _, name := range []string{"black-tee.avatar", "black-jeans.avatar"} {
+		env.metadata.Index = env.metadata.Index.Setting("casual", name, entities.OutfitMetadata{Tags: []string{"black"}})
+	}
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "black-tee.avatar", SelectedAt: testNow.AddDate(0, 0, -2)},
+	}}
+	env.config.Config.Selection.TagConstraints = []entities.TagConstraint{{Tag: "black", Max: 1, Days: 7}}
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 10 {
+		proposal, err := useCase.Propose("casual")
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if proposal.Outfit.FileName != "white-tee.avatar" || proposal.Available != 1 {
+			t.Fatalf("Propose() = %+v, want white-tee.avatar from 1", proposal)
+		}
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	_, err := useCase.Propose("casual", WithoutOutfits("white-tee.avatar"))
+	if !errors.As(err, &invalid) || !strings.Contains(err.Error(), `at most 1 "black" per 7 days`) {
+		t.Errorf("Propose() with only blocked outfits error = %v, want the limit reported", err)
+	}
+
+	env.config.Config.Selection.TagConstraints[0].Severity = entities.ConstraintDowngrade
+	black := 0
+	for seed := range uint64(200) {
+		proposal, err := useCase.Propose("casual", WithPickSeed(seed))
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if proposal.Outfit.FileName != "white-tee.avatar" {
+			black++
+		}
+	}
+	// Downgraded to a tenth, each black outfit comes up 1 time in 12.
+	if black == 0 || black > 60 {
+		t.Errorf("downgraded black outfits picked %d of 200 times, want rarely", black)
+	}
+}
+
+func TestPickOutfitUseCase_RotationPolicies(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).Adding("a.avatar").Adding("b.avatar"))
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "a.avatar", WornAt: may(20)},
+		{Category: "casual", FileName: "b.avatar", WornAt: may(31)},
+		{Category: "casual", FileName: "c.avatar", WornAt: may(30)},
+	}}
+
+	tests := []struct {
+		name       string
+		configured map[string]entities.RotationPolicy
+		opts       []PickOption
+		want       []string
+	}{
+		{"strict by default", nil, nil, []string{"c.avatar"}},
+		{"least recently worn", nil, []PickOption{WithRotationPolicy(entities.RotationPolicy{Name: entities.RotationLeastRecentlyWorn})}, []string{"a.avatar"}},
+		{"cooldown", nil, []PickOption{WithRotationPolicy(entities.RotationPolicy{Name: entities.RotationCooldown, CooldownDays: 3})}, []string{"a.avatar"}},
+		{"random", nil, []PickOption{WithRotationPolicy(entities.RotationPolicy{Name: entities.RotationRandom})}, []string{"a.avatar", "b.avatar", "c.avatar"}},
+		{"configured for the category", map[string]entities.RotationPolicy{"casual": {Name: entities.RotationLeastRecentlyWorn}}, nil, []string{"a.avatar"}},
+		{"option overrides the category", map[string]entities.RotationPolicy{"casual": {Name: entities.RotationLeastRecentlyWorn}}, []PickOption{WithRotationPolicy(entities.RotationPolicy{Name: entities.RotationStrict})}, []string{"c.avatar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env.config.Config.Selection.CategoryRotationPolicies = tt.configured
+			picked := make(map[string]bool)
+			for range 30 {
+				proposal, err := NewPickOutfitUseCase(env.services).Propose("casual", tt.opts...)
+				if err != nil {
+					t.Fatalf("Propose() error = %v", err)
+				}
+				picked[proposal.Outfit.FileName] = true
+			}
+			if got := slices.Sorted(maps.Keys(picked)); !slices.Equal(got, tt.want) {
+				t.Errorf("picked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickOutfitUseCase_CooldownOutlivesReset(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(3).
+		Wearing("a.avatar", may(20)).Wearing("b.avatar", may(30)).Wearing("c.avatar", may(31)).Restarted(3))
+	env.config.Config.Selection.CooldownDays = 3
+
+	for range 20 {
+		proposal, err := NewPickOutfitUseCase(env.services).Propose("casual")
+		if err != nil {
+			t.Fatalf("Propose() error = %v", err)
+		}
+		if proposal.Outfit.FileName != "a.avatar" {
+			t.Fatalf("Propose() = %s, want the only outfit not worn in the last 3 days", proposal.Outfit.FileName)
+		}
+	}
+
+	env.config.Config.Selection.CooldownDays = 30
+	if _, err := NewPickOutfitUseCase(env.services).Propose("casual"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Propose() with every outfit cooling down error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickOutfitUseCase_PickLimit(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	env.config.Config.Selection.CategoryPickLimits = map[string]int{"casual": 2}
+	pick := NewPickOutfitUseCase(env.services)
+
+	for range 2 {
+		if _, err := pick.Execute("casual"); err != nil {
+			t.Fatalf("Execute() within the limit error = %v", err)
+		}
+	}
+	_, err := pick.Execute("casual")
+	var limited *domainerrors.RateLimitedError
+	if !errors.As(err, &limited) {
+		t.Fatalf("Execute() over the limit error = %v, want RateLimitedError", err)
+	}
+	if want := testNow.Truncate(24*time.Hour).AddDate(0, 0, 1); !limited.NextAllowed.Equal(want) || limited.Limit != 2 {
+		t.Errorf("RateLimitedError = %+v, want limit 2 until %v", limited, want)
+	}
+	if len(env.history.History.Records) != 2 {
+		t.Errorf("history has %d picks, want the 2 within the limit", len(env.history.History.Records))
+	}
+	if _, err := NewOutfitPlanUseCase(env.services).Generate(1); err != nil || len(env.plan.Plan.Days[0].Outfits) != 1 {
+		t.Errorf("Generate() with the limit reached = %+v, %v; want the category planned", env.plan.Plan, err)
+	}
+}
+
+func TestPickOutfitUseCase_Permissions(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "work": {"b.avatar"}})
+	env.config.Config.Permissions = entities.CategoryPermissions{Pick: []string{"casual"}, Reset: []string{"work"}}
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(1).Adding("a.avatar"))
+	pick := NewPickOutfitUseCase(env.services)
+
+	if _, err := pick.Execute("work"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Execute() of a category the profile may not pick from error = %v, want InvalidInputError", err)
+	}
+	if _, err := pick.Execute("casual"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Execute() needing a reset the profile may not start error = %v, want InvalidInputError", err)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 1 {
+		t.Errorf("worn outfits = %d, want the rotation left as it was", worn)
+	}
+
+	env.config.Config.Permissions.Reset = nil
+	if _, err := pick.Execute("casual"); err != nil {
+		t.Errorf("Execute() allowed to reset error = %v", err)
+	}
+}
+
+func TestPickOutfitUseCase_Weather(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"beach": {"shorts.avatar"}, "casual": {"jumper.avatar", "tee.avatar"}})
+	env.config.Config.Weather = entities.WeatherSettings{Location: &testLocation}.
+		SettingCategory("beach", []string{entities.WeatherRain}).
+		SettingTag("wool", []string{entities.WeatherHeat})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "jumper.avatar", entities.OutfitMetadata{Tags: []string{"wool"}})
+	env.weather.Result = entities.Forecast{MaxTemperature: 29, PrecipitationChance: 60}
+	pick := NewPickOutfitUseCase(env.services)
+
+	if _, err := pick.Execute("beach", WithWeather()); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Execute() of a category unsuitable for rain error = %v, want InvalidInputError", err)
+	}
+	for seed := range uint64(5) {
+		outfit, err := pick.Propose("casual", WithWeather(), WithPickSeed(seed))
+		if err != nil || outfit.Outfit.FileName != "tee.avatar" {
+			t.Fatalf("Propose() = %+v, %v; want tee.avatar, the only outfit for the heat", outfit, err)
+		}
+	}
+	if _, err := pick.Execute("beach"); err != nil {
+		t.Errorf("Execute() without --weather error = %v", err)
+	}
+	if env.weather.Calls != 1 {
+		t.Errorf("forecast fetched %d times, want once", env.weather.Calls)
+	}
+}
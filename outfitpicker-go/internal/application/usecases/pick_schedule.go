@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// PickWindow is a scheduled pick of watch that has come due.
+type PickWindow struct {
+	Due time.Time `json:"due"`
+	// Missed is set when the pick came due well before it was noticed, as
+	// when the machine was asleep or watch was stopped.
+	Missed bool `json:"missed"`
+}
+
+// PickScheduleUseCase keeps the pick schedule of watch, so that picks come
+// due by the wall clock across restarts of watch and sleeps of the machine.
+type PickScheduleUseCase struct {
+	services Services
+}
+
+// NewPickScheduleUseCase creates a new pick schedule use case.
+func NewPickScheduleUseCase(services Services) *PickScheduleUseCase {
+	return &PickScheduleUseCase{services: services}
+}
+
+// Due returns the latest pick of a schedule with one every interval that
+// has come due and is not yet handled, or nil when the next is still
+// ahead. A pick noticed more than grace after it came due is missed.
+func (u *PickScheduleUseCase) Due(interval, grace time.Duration) (*PickWindow, error) {
+	state, err := u.services.WatchState.Load()
+	if err != nil {
+		return nil, err
+	}
+	due, late, ok := logic.DuePickWindow(state.LastPickDue, interval, grace, u.services.now())
+	if !ok {
+		return nil, nil
+	}
+	return &PickWindow{Due: due, Missed: late}, nil
+}
+
+// Handled records that the pick of window was made or skipped, so the
+// next is due an interval later. It is bookkeeping of watch rather than
+// wardrobe state, so it also works in maintenance mode and a pick that
+// fails is not retried until the next is due.
+func (u *PickScheduleUseCase) Handled(window PickWindow) error {
+	return retryOnConflict(func() error {
+		state, err := u.services.WatchState.Load()
+		if err != nil {
+			return err
+		}
+		state.LastPickDue = window.Due
+		return u.services.WatchState.Save(state)
+	})
+}
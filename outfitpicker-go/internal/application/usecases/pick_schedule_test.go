@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPickScheduleUseCase_DueAndHandled(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	schedule := NewPickScheduleUseCase(env.services)
+	day := 24 * time.Hour
+
+	window, err := schedule.Due(day, time.Minute)
+	if err != nil || window == nil || !window.Due.Equal(testNow) || window.Missed {
+		t.Fatalf("Due() of the first pick = %+v, %v; want due now", window, err)
+	}
+	if err := schedule.Handled(*window); err != nil {
+		t.Fatalf("Handled() error = %v", err)
+	}
+	if window, err := schedule.Due(day, time.Minute); window != nil || err != nil {
+		t.Errorf("Due() right after a pick = %+v, %v; want none", window, err)
+	}
+
+	// The machine sleeps through the next pick and wakes two hours late.
+	env.services.Now = func() time.Time { return testNow.Add(day + 2*time.Hour) }
+	schedule = NewPickScheduleUseCase(env.services)
+	window, err = schedule.Due(day, time.Minute)
+	if err != nil || window == nil || !window.Due.Equal(testNow.Add(day)) || !window.Missed {
+		t.Errorf("Due() after sleeping = %+v, %v; want a missed pick due a day later", window, err)
+	}
+}
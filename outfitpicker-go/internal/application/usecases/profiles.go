@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// ProfilesUseCase manages the named profiles, each a wardrobe with its own
+// configuration and state.
+type ProfilesUseCase struct {
+	services Services
+}
+
+// NewProfilesUseCase creates a new profiles use case.
+func NewProfilesUseCase(services Services) *ProfilesUseCase {
+	return &ProfilesUseCase{services: services}
+}
+
+// Active returns the profile in use when none is named on the command line.
+// A saved profile that no longer exists falls back to the default one.
+func (u *ProfilesUseCase) Active() (string, error) {
+	selection, err := u.services.Profiles.LoadSelection()
+	if err != nil {
+		return "", err
+	}
+	if selection.Active == "" {
+		return entities.DefaultProfile, nil
+	}
+	exists, err := u.services.Profiles.Exists(selection.Active)
+	if err != nil || !exists {
+		return entities.DefaultProfile, err
+	}
+	return selection.Active, nil
+}
+
+// List returns the default profile followed by the named ones, sorted, with
+// the active profile marked.
+func (u *ProfilesUseCase) List() ([]entities.Profile, error) {
+	active, err := u.Active()
+	if err != nil {
+		return nil, err
+	}
+	names, err := u.services.Profiles.List()
+	if err != nil {
+		return nil, err
+	}
+	profiles := []entities.Profile{{Name: entities.DefaultProfile, Active: active == entities.DefaultProfile}}
+	for _, name := range names {
+		if name != entities.DefaultProfile {
+			profiles = append(profiles, entities.Profile{Name: name, Active: name == active})
+		}
+	}
+	return profiles, nil
+}
+
+// EnsureExists fails with an InvalidInputError unless name is the default
+// profile or a profile that was created.
+func (u *ProfilesUseCase) EnsureExists(name string) error {
+	if name == entities.DefaultProfile {
+		return nil
+	}
+	if err := validation.ValidateProfileName(name); err != nil {
+		return err
+	}
+	exists, err := u.services.Profiles.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("profile %q does not exist", name))
+	}
+	return nil
+}
+
+// EnsureNew fails with an InvalidInputError unless name is a valid name no
+// profile uses yet.
+func (u *ProfilesUseCase) EnsureNew(name string) error {
+	if err := validation.ValidateProfileName(name); err != nil {
+		return err
+	}
+	exists, err := u.services.Profiles.Exists(name)
+	if err != nil {
+		return err
+	}
+	if exists || name == entities.DefaultProfile {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("profile %q already exists", name))
+	}
+	return nil
+}
+
+// Switch makes name the active profile.
+func (u *ProfilesUseCase) Switch(name string) error {
+	if err := u.EnsureExists(name); err != nil {
+		return err
+	}
+	selection := entities.ProfileSelection{Active: name}
+	if name == entities.DefaultProfile {
+		selection.Active = ""
+	}
+	return u.services.Profiles.SaveSelection(selection)
+}
+
+// Delete removes a named profile with its configuration and state. Deleting
+// the active profile makes the default one active again.
+func (u *ProfilesUseCase) Delete(name string) error {
+	if name == entities.DefaultProfile {
+		return domainerrors.NewInvalidInputError("the default profile cannot be deleted")
+	}
+	if err := u.EnsureExists(name); err != nil {
+		return err
+	}
+	active, err := u.Active()
+	if err != nil {
+		return err
+	}
+	if err := u.services.Profiles.Delete(name); err != nil {
+		return err
+	}
+	if active == name {
+		return u.services.Profiles.SaveSelection(entities.ProfileSelection{})
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestProfilesUseCase_SwitchAndList(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.profiles.Names = []string{"work", "travel"}
+	useCase := NewProfilesUseCase(env.services)
+
+	if active, err := useCase.Active(); err != nil || active != entities.DefaultProfile {
+		t.Fatalf("Active() = %q, %v; want the default profile", active, err)
+	}
+	if err := useCase.Switch("travel"); err != nil {
+		t.Fatalf("Switch() error = %v", err)
+	}
+	profiles, err := useCase.List()
+	want := []entities.Profile{{Name: "default"}, {Name: "travel", Active: true}, {Name: "work"}}
+	if err != nil || !slices.Equal(profiles, want) {
+		t.Errorf("List() = %+v, %v; want %+v", profiles, err, want)
+	}
+
+	if err := useCase.Switch(entities.DefaultProfile); err != nil || env.profiles.Selection.Active != "" {
+		t.Errorf("Switch(default) = %v, selection = %+v", err, env.profiles.Selection)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Switch("beach"); !errors.As(err, &invalid) {
+		t.Errorf("Switch(missing) error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestProfilesUseCase_ActiveFallsBackWhenMissing(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.profiles.Selection = entities.ProfileSelection{Active: "gone"}
+
+	if active, err := NewProfilesUseCase(env.services).Active(); err != nil || active != entities.DefaultProfile {
+		t.Errorf("Active() = %q, %v; want the default profile", active, err)
+	}
+}
+
+func TestProfilesUseCase_EnsureNew(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.profiles.Names = []string{"travel"}
+	useCase := NewProfilesUseCase(env.services)
+
+	if err := useCase.EnsureNew("beach"); err != nil {
+		t.Errorf("EnsureNew(beach) error = %v", err)
+	}
+	for _, name := range []string{"travel", entities.DefaultProfile, "Beach"} {
+		var invalid *domainerrors.InvalidInputError
+		if err := useCase.EnsureNew(name); !errors.As(err, &invalid) {
+			t.Errorf("EnsureNew(%q) error = %v, want InvalidInputError", name, err)
+		}
+	}
+}
+
+func TestProfilesUseCase_Delete(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.profiles.Names = []string{"travel", "work"}
+	env.profiles.Selection = entities.ProfileSelection{Active: "travel"}
+	useCase := NewProfilesUseCase(env.services)
+
+	if err := useCase.Delete("work"); err != nil || env.profiles.Selection.Active != "travel" {
+		t.Errorf("Delete(inactive) = %v, selection = %+v", err, env.profiles.Selection)
+	}
+	if err := useCase.Delete("travel"); err != nil || env.profiles.Selection.Active != "" || len(env.profiles.Names) != 0 {
+		t.Errorf("Delete(active) = %v, selection = %+v, names = %v", err, env.profiles.Selection, env.profiles.Names)
+	}
+	var invalid *domainerrors.InvalidInputError
+	for _, name := range []string{entities.DefaultProfile, "travel"} {
+		if err := useCase.Delete(name); !errors.As(err, &invalid) {
+			t.Errorf("Delete(%q) error = %v, want InvalidInputError", name, err)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// CategoryRemoveRequest names a category to remove and what happens to its
+// files.
+type CategoryRemoveRequest struct {
+	Category string
+	Files    entities.FileDisposition
+}
+
+// CategoryReferences counts what the state files record about a category.
+type CategoryReferences struct {
+	Picks     int `json:"picks"`
+	Wears     int `json:"wears"`
+	Metadata  int `json:"metadata"`
+	Weights   int `json:"weights"`
+	Favorites int `json:"favorites"`
+	Skipped   int `json:"skipped"`
+	Laundry   int `json:"laundry"`
+	Arrivals  int `json:"arrivals"`
+	// Planned counts the outfits of the category in the plan.
+	Planned int `json:"planned"`
+	// Capsule counts the outfits of the category in the challenge capsule.
+	Capsule int `json:"capsule"`
+	// LastPicked is set when the time the category was last picked is
+	// recorded.
+	LastPicked bool `json:"lastPicked"`
+	// LatestPick is set when the category's latest pick is published for
+	// widgets.
+	LatestPick bool `json:"latestPick"`
+}
+
+// CategoryRemovalProposal is a category removal worked out but not yet
+// made.
+type CategoryRemovalProposal struct {
+	Category entities.CategoryReference `json:"category"`
+	Files    entities.FileDisposition   `json:"files"`
+	// Outfits counts the outfit files in the category.
+	Outfits int `json:"outfits"`
+	// Settings names the category's settings in the configuration, such
+	// as its priority or pick limit, which are dropped with it.
+	Settings   []string           `json:"settings,omitempty"`
+	References CategoryReferences `json:"references"`
+}
+
+// Changes returns the changes committing the removal makes to state files,
+// in the order Commit makes them. The configuration and cache are always
+// changed; the other files only when they mention the category.
+func (p *CategoryRemovalProposal) Changes() []StateChange {
+	name, refs := p.Category.Name, p.References
+	config := "forget " + name
+	if len(p.Settings) > 0 {
+		config += " with its " + strings.Join(p.Settings, ", ")
+	}
+	if p.Files == entities.FilesLeave {
+		config += "; exclude its directory from picks"
+	}
+	changes := []StateChange{
+		{Store: "config", Change: config},
+		{Store: "cache", Change: "drop the rotation of " + name},
+	}
+	add := func(n int, store, change string) {
+		if n > 0 {
+			changes = append(changes, StateChange{Store: store, Change: change})
+		}
+	}
+	add(refs.Picks, "history", "drop "+countOf(refs.Picks, "pick"))
+	add(refs.Wears, "wear log", "drop "+countOf(refs.Wears, "wear"))
+	add(refs.Metadata, "metadata", "drop the metadata of "+outfitCount(refs.Metadata))
+	add(refs.Weights, "weights", "drop the weights of "+outfitCount(refs.Weights))
+	add(refs.Favorites, "favorites", "drop "+countOf(refs.Favorites, "favorite"))
+	add(refs.Skipped, "skipped", "drop "+countOf(refs.Skipped, "skipped outfit"))
+	add(refs.Laundry, "laundry", "drop the laundry state of "+outfitCount(refs.Laundry))
+	add(refs.Arrivals, "arrivals", "drop the arrival dates of "+outfitCount(refs.Arrivals))
+	add(refs.Planned, "plan", "drop "+countOf(refs.Planned, "planned outfit"))
+	add(refs.Capsule, "challenge", "drop "+outfitCount(refs.Capsule)+" from the capsule")
+	if refs.LastPicked {
+		changes = append(changes, StateChange{Store: "category picks", Change: "drop when " + name + " was last picked"})
+	}
+	if refs.LatestPick {
+		changes = append(changes, StateChange{Store: "latest picks", Change: "drop the latest pick of " + name})
+	}
+	return changes
+}
+
+// CategoryRemoval reports a removed category.
+type CategoryRemoval struct {
+	*CategoryRemovalProposal
+	// FilesPath is where the category's files are now: its directory when
+	// they were left, or the directory they were archived or trashed to.
+	FilesPath string `json:"filesPath"`
+	// Previous is the backup of the configuration and cache taken before
+	// the removal.
+	Previous entities.Backup `json:"previous"`
+}
+
+// RemoveCategoryUseCase takes a category out of the wardrobe, dealing with
+// its files as asked and dropping every record of it from the state files.
+type RemoveCategoryUseCase struct {
+	services Services
+}
+
+// NewRemoveCategoryUseCase creates a new remove category use case.
+func NewRemoveCategoryUseCase(services Services) *RemoveCategoryUseCase {
+	return &RemoveCategoryUseCase{services: services}
+}
+
+// Propose finds the category and counts what the state files record about
+// it. Nothing is changed.
+func (u *RemoveCategoryUseCase) Propose(request CategoryRemoveRequest) (*CategoryRemovalProposal, error) {
+	if _, err := entities.ParseFileDisposition(string(request.Files)); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	var category *entities.CategoryReference
+	for _, info := range infos {
+		if info.Category.Name == request.Category {
+			category = &info.Category
+			break
+		}
+	}
+	if category == nil {
+		return nil, domainerrors.ErrCategoryNotFound
+	}
+	files, err := u.services.outfitsIn(config, *category)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &CategoryRemovalProposal{Category: *category, Files: request.Files, Outfits: len(files), Settings: config.CategorySettings(category.Name)}
+	if err := u.countReferences(category.Name, &proposal.References); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+func (u *RemoveCategoryUseCase) countReferences(category string, refs *CategoryReferences) error {
+	history, err := u.services.History.Load()
+	if err != nil {
+		return err
+	}
+	for _, record := range history.Records {
+		if record.Category == category {
+			refs.Picks++
+		}
+	}
+	wearLog, err := u.services.WearLog.Load()
+	if err != nil {
+		return err
+	}
+	for _, event := range wearLog.Events {
+		if event.Category == category {
+			refs.Wears++
+		}
+	}
+	metadata, err := u.services.Metadata.Load()
+	if err != nil {
+		return err
+	}
+	described := make(map[string]bool)
+	for fileName := range metadata.Outfits[category] {
+		described[fileName] = true
+	}
+	for fileName := range metadata.IDs[category] {
+		described[fileName] = true
+	}
+	refs.Metadata = len(described)
+	weights, err := u.services.Weights.Load()
+	if err != nil {
+		return err
+	}
+	refs.Weights = len(weights.Outfits[category])
+	favorites, err := u.services.Favorites.Load()
+	if err != nil {
+		return err
+	}
+	refs.Favorites = len(favorites.Outfits[category])
+	skipped, err := u.services.Skipped.Load()
+	if err != nil {
+		return err
+	}
+	refs.Skipped = len(skipped.Outfits[category])
+	laundry, err := u.services.Laundry.Load()
+	if err != nil {
+		return err
+	}
+	refs.Laundry = len(laundry.Outfits[category])
+	arrivals, err := u.services.Arrivals.Load()
+	if err != nil {
+		return err
+	}
+	refs.Arrivals = len(arrivals.Outfits[category])
+	picks, err := u.services.LastPicked.Load()
+	if err != nil {
+		return err
+	}
+	_, refs.LastPicked = picks.LastPicked[category]
+	plan, err := u.services.Plan.Load()
+	if err != nil {
+		return err
+	}
+	refs.Planned = len(plan.PlannedOn(category, -1))
+	challenge, err := u.services.Challenge.Load()
+	if err != nil {
+		return err
+	}
+	for _, outfit := range challenge.Capsule {
+		if outfit.Category == category {
+			refs.Capsule++
+		}
+	}
+	latest, err := u.services.LatestPicks.Load()
+	if err != nil {
+		return err
+	}
+	_, refs.LatestPick = latest.Categories[category]
+	return nil
+}
+
+// Commit removes the category. The configuration and cache are backed up
+// first. The state files are changed, then the files dealt with; when any
+// step fails, the state files already changed are put back, so the
+// category is removed either completely or not at all.
+func (u *RemoveCategoryUseCase) Commit(proposal *CategoryRemovalProposal) (*CategoryRemoval, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	backup, err := NewBackupUseCase(u.services).Create()
+	if err != nil {
+		return nil, err
+	}
+	result := &CategoryRemoval{CategoryRemovalProposal: proposal, FilesPath: proposal.Category.Path, Previous: backup.Backup}
+
+	var applied []*stateEdit
+	for _, edit := range u.edits(proposal) {
+		if err := edit.apply(); err != nil {
+			return nil, undoEdits(applied, err)
+		}
+		applied = append(applied, edit)
+	}
+
+	switch proposal.Files {
+	case entities.FilesArchive:
+		result.FilesPath, err = u.services.Archiver.ArchiveCategory(proposal.Category.Path)
+	case entities.FilesTrash:
+		result.FilesPath, err = u.services.Trash.Trash(proposal.Category.Path)
+	}
+	if err != nil {
+		return nil, undoEdits(applied, err)
+	}
+	return result, nil
+}
+
+// edits returns the changes to the state files listed by the proposal's
+// Changes, in the same order.
+func (u *RemoveCategoryUseCase) edits(proposal *CategoryRemovalProposal) []*stateEdit {
+	name, refs, s := proposal.Category.Name, proposal.References, u.services
+	edits := []*stateEdit{
+		editState(s.Config.Load, s.Config.Save, func(c **entities.Config) *int { return &(*c).Revision }, func(config *entities.Config) *entities.Config {
+			config.ForgetCategory(name)
+			if proposal.Files == entities.FilesLeave {
+				if config.ExcludedCategories == nil {
+					config.ExcludedCategories = make(map[string]bool)
+				}
+				config.ExcludedCategories[name] = true
+			}
+			return config
+		}),
+		editState(s.Cache.Load, s.Cache.Save, func(c *entities.OutfitCache) *int { return &c.Revision }, func(cache entities.OutfitCache) entities.OutfitCache {
+			return cache.Removing(name)
+		}),
+	}
+	if refs.Picks > 0 {
+		edits = append(edits, editState(s.History.Load, s.History.Save, func(h *entities.SelectionHistory) *int { return &h.Revision }, func(history entities.SelectionHistory) entities.SelectionHistory {
+			return history.WithoutCategory(name)
+		}))
+	}
+	if refs.Wears > 0 {
+		edits = append(edits, editState(s.WearLog.Load, s.WearLog.Save, func(l *entities.WearLog) *int { return &l.Revision }, func(log entities.WearLog) entities.WearLog {
+			return log.WithoutCategory(name)
+		}))
+	}
+	if refs.Metadata > 0 {
+		edits = append(edits, editState(s.Metadata.Load, s.Metadata.Save, func(i *entities.MetadataIndex) *int { return &i.Revision }, func(index entities.MetadataIndex) entities.MetadataIndex {
+			return index.WithoutCategory(name)
+		}))
+	}
+	if refs.Weights > 0 {
+		edits = append(edits, editState(s.Weights.Load, s.Weights.Save, func(w *entities.OutfitWeights) *int { return &w.Revision }, func(weights entities.OutfitWeights) entities.OutfitWeights {
+			return weights.WithoutCategory(name)
+		}))
+	}
+	if refs.Favorites > 0 {
+		edits = append(edits, editState(s.Favorites.Load, s.Favorites.Save, func(f *entities.Favorites) *int { return &f.Revision }, func(favorites entities.Favorites) entities.Favorites {
+			return favorites.WithoutCategory(name)
+		}))
+	}
+	if refs.Skipped > 0 {
+		edits = append(edits, editState(s.Skipped.Load, s.Skipped.Save, func(o *entities.SkippedOutfits) *int { return &o.Revision }, func(skipped entities.SkippedOutfits) entities.SkippedOutfits {
+			return skipped.WithoutCategory(name)
+		}))
+	}
+	if refs.Laundry > 0 {
+		edits = append(edits, editState(s.Laundry.Load, s.Laundry.Save, func(l *entities.Laundry) *int { return &l.Revision }, func(laundry entities.Laundry) entities.Laundry {
+			return laundry.WithoutCategory(name)
+		}))
+	}
+	if refs.Arrivals > 0 {
+		edits = append(edits, editState(s.Arrivals.Load, s.Arrivals.Save, func(a *entities.OutfitArrivals) *int { return &a.Revision }, func(arrivals entities.OutfitArrivals) entities.OutfitArrivals {
+			return arrivals.WithoutCategory(name)
+		}))
+	}
+	if refs.LastPicked {
+		edits = append(edits, editState(s.LastPicked.Load, s.LastPicked.Save, func(p *entities.CategoryPicks) *int { return &p.Revision }, func(picks entities.CategoryPicks) entities.CategoryPicks {
+			return picks.WithoutCategory(name)
+		}))
+	}
+	if refs.Planned > 0 {
+		edits = append(edits, editState(s.Plan.Load, s.Plan.Save, func(p *entities.OutfitPlan) *int { return &p.Revision }, func(plan entities.OutfitPlan) entities.OutfitPlan {
+			return plan.WithoutCategory(name)
+		}))
+	}
+	if refs.Capsule > 0 {
+		edits = append(edits, editState(s.Challenge.Load, s.Challenge.Save, func(c *entities.Challenge) *int { return &c.Revision }, func(challenge entities.Challenge) entities.Challenge {
+			return challenge.WithoutCategory(name)
+		}))
+	}
+	if refs.LatestPick {
+		edits = append(edits, editState(s.LatestPicks.Load, s.LatestPicks.Save, func(p *entities.LatestPicks) *int { return &p.Revision }, func(picks entities.LatestPicks) entities.LatestPicks {
+			return picks.WithoutCategory(name)
+		}))
+	}
+	return edits
+}
+
+// stateEdit is one store's part of a change spanning several stores.
+type stateEdit struct {
+	// apply saves the edited state.
+	apply func() error
+	// undo puts back the state apply replaced.
+	undo func() error
+}
+
+// editState returns the edit saving a store's state changed by edit. The
+// state is loaded twice, so edit may change the copy it is given in place
+// without touching the state kept for undo.
+func editState[T any](load func() (T, error), save func(T) error, revision func(*T) *int, edit func(T) T) *stateEdit {
+	var original T
+	return &stateEdit{
+		apply: func() error {
+			return retryOnConflict(func() error {
+				var err error
+				if original, err = load(); err != nil {
+					return err
+				}
+				current, err := load()
+				if err != nil {
+					return err
+				}
+				return save(edit(current))
+			})
+		},
+		undo: func() error {
+			return replaceState(load, save, original, revision)
+		},
+	}
+}
+
+// undoEdits puts back the state of the applied edits, newest first, and
+// returns err with any failure to do so.
+func undoEdits(applied []*stateEdit, err error) error {
+	errs := []error{err}
+	for i := len(applied) - 1; i >= 0; i-- {
+		if undoErr := applied[i].undo(); undoErr != nil {
+			errs = append(errs, fmt.Errorf("putting back the state: %w", undoErr))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// newRemovalEnv creates a wardrobe whose formal category is mentioned by
+// every state file the removal cleans up.
+func newRemovalEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, map[string][]string{"formal": {"suit.avatar", "tux.avatar"}, "casual": {"tee.avatar"}})
+	env.config.Config.KnownCategories = map[string]bool{"formal": true, "casual": true}
+	env.config.Config.Selection = entities.SelectionPreferences{
+		CategoryPriorities: map[string]float64{"formal": 2},
+		CategoryPickLimits: map[string]int{"formal": 1},
+		LockedRotations:    []string{"formal"},
+	}
+	env.cache.Cache.Categories["formal"] = entities.CategoryCache{WornOutfits: map[string]bool{"suit.avatar": true}, TotalOutfits: 2}
+	env.cache.Cache.Categories["casual"] = entities.NewCategoryCache(1)
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "formal", FileName: "suit.avatar", SelectedAt: testNow},
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: testNow},
+	}}
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{{Category: "formal", FileName: "suit.avatar", WornAt: testNow}}}
+	env.metadata.Index = env.metadata.Index.Setting("formal", "tux.avatar", entities.OutfitMetadata{Tags: []string{"black-tie"}})
+	env.weights.Weights = env.weights.Weights.Setting("formal", "suit.avatar", 2).Setting("casual", "tee.avatar", 3)
+	env.favorites.Favorites = env.favorites.Favorites.Adding("formal", "tux.avatar")
+	env.lastPicked.Picks = env.lastPicked.Picks.Recording("formal", testNow)
+	env.latestPicks.Picks = env.latestPicks.Picks.Recording("formal", entities.LatestPick{FileName: "suit.avatar", PickedAt: testNow})
+	env.plan.Plan = entities.OutfitPlan{Days: []entities.PlanDay{{Date: testNow, Outfits: []entities.PlannedOutfit{
+		{Category: "formal", FileName: "tux.avatar"},
+		{Category: "casual", FileName: "tee.avatar"},
+	}}}}
+	env.challenge.Challenge = entities.Challenge{Capsule: []entities.OutfitLocation{{Category: "formal", FileName: "suit.avatar"}, {Category: "casual", FileName: "tee.avatar"}}}
+	return env
+}
+
+func TestRemoveCategoryUseCase_Propose(t *testing.T) {
+	env := newRemovalEnv(t)
+
+	proposal, err := NewRemoveCategoryUseCase(env.services).Propose(CategoryRemoveRequest{Category: "formal", Files: entities.FilesTrash})
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	want := CategoryReferences{Picks: 1, Wears: 1, Metadata: 1, Weights: 1, Favorites: 1, Planned: 1, Capsule: 1, LastPicked: true, LatestPick: true}
+	if proposal.Outfits != 2 || proposal.References != want {
+		t.Errorf("Propose() = %+v, want 2 outfits and references %+v", proposal, want)
+	}
+	if got := proposal.Changes()[0].Change; got != "forget formal with its priority, pick limit, rotation lock" {
+		t.Errorf("config change = %q, want the dropped settings named", got)
+	}
+	if got := len(proposal.Changes()); got != 11 {
+		t.Errorf("Changes() = %v, want config, cache and nine stores mentioning formal", proposal.Changes())
+	}
+	if env.config.Saves != 0 || env.history.Saves != 0 {
+		t.Error("Propose() saved state")
+	}
+
+	_, err = NewRemoveCategoryUseCase(env.services).Propose(CategoryRemoveRequest{Category: "gym", Files: entities.FilesLeave})
+	if !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("Propose(gym) error = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestRemoveCategoryUseCase_Commit(t *testing.T) {
+	env := newRemovalEnv(t)
+	useCase := NewRemoveCategoryUseCase(env.services)
+	proposal, err := useCase.Propose(CategoryRemoveRequest{Category: "formal", Files: entities.FilesTrash})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := useCase.Commit(proposal)
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	formal := filepath.Join(env.root, "formal")
+	if len(env.trash.Trashed) != 1 || env.trash.Trashed[0] != formal || result.FilesPath == formal {
+		t.Errorf("trashed %v, files now at %q, want %s trashed", env.trash.Trashed, result.FilesPath, formal)
+	}
+	if len(env.backups.Backups) != 1 {
+		t.Error("Commit() did not back up the config and cache")
+	}
+	if env.config.Config.KnownCategories["formal"] || !env.config.Config.KnownCategories["casual"] {
+		t.Errorf("known categories = %v, want only casual", env.config.Config.KnownCategories)
+	}
+	if selection := env.config.Config.Selection; selection.CategoryPriorities["formal"] != 0 || selection.CategoryPickLimits["formal"] != 0 || len(selection.LockedRotations) != 0 {
+		t.Errorf("selection = %+v, want formal's priority, limit and lock dropped", selection)
+	}
+	if _, ok := env.cache.Cache.Categories["formal"]; ok {
+		t.Error("the cache still holds formal")
+	}
+	if records := env.history.History.Records; len(records) != 1 || records[0].Category != "casual" {
+		t.Errorf("history = %+v, want the casual pick", records)
+	}
+	if len(env.wearLog.Log.Events) != 0 || env.metadata.Index.HasTag("formal", "tux.avatar", "black-tie") {
+		t.Error("the wear log or metadata still mention formal")
+	}
+	if env.weights.Weights.Outfits["formal"] != nil || env.weights.Weights.Outfits["casual"] == nil {
+		t.Errorf("weights = %v, want only casual", env.weights.Weights.Outfits)
+	}
+	if env.favorites.Favorites.Contains("formal", "tux.avatar") {
+		t.Error("favorites still mention formal")
+	}
+	if _, ok := env.lastPicked.Picks.LastPicked["formal"]; ok {
+		t.Error("category picks still mention formal")
+	}
+	if _, ok := env.latestPicks.Picks.Categories["formal"]; ok {
+		t.Error("the latest picks still mention formal")
+	}
+	if days := env.plan.Plan.Days; len(days) != 1 || len(days[0].Outfits) != 1 || days[0].Outfits[0].Category != "casual" {
+		t.Errorf("plan = %+v, want only the casual outfit left on the day", days)
+	}
+	if capsule := env.challenge.Challenge.Capsule; len(capsule) != 1 || capsule[0].Category != "casual" {
+		t.Errorf("capsule = %v, want only the casual outfit", capsule)
+	}
+}
+
+func TestRemoveCategoryUseCase_CommitArchivesOrLeaves(t *testing.T) {
+	env := newRemovalEnv(t)
+	useCase := NewRemoveCategoryUseCase(env.services)
+
+	proposal, err := useCase.Propose(CategoryRemoveRequest{Category: "formal", Files: entities.FilesArchive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	archived, err := useCase.Commit(proposal)
+	if err != nil {
+		t.Fatalf("Commit() archiving error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(archived.FilesPath, "suit.avatar")); err != nil {
+		t.Errorf("the outfits were not archived to %s: %v", archived.FilesPath, err)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "formal")); !os.IsNotExist(err) {
+		t.Errorf("the category directory is still there: %v", err)
+	}
+
+	proposal, err = useCase.Propose(CategoryRemoveRequest{Category: "casual", Files: entities.FilesLeave})
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, err := useCase.Commit(proposal)
+	if err != nil {
+		t.Fatalf("Commit() leaving error = %v", err)
+	}
+	if left.FilesPath != filepath.Join(env.root, "casual") || !env.config.Config.ExcludedCategories["casual"] {
+		t.Errorf("Commit() leaving = %+v, excluded = %v, want casual left on disk and excluded", left, env.config.Config.ExcludedCategories)
+	}
+}
+
+func TestRemoveCategoryUseCase_CommitPutsBackStateOnFailure(t *testing.T) {
+	env := newRemovalEnv(t)
+	env.trash.Err = domainerrors.NewInvalidInputError("no trash here")
+	useCase := NewRemoveCategoryUseCase(env.services)
+	proposal, err := useCase.Propose(CategoryRemoveRequest{Category: "formal", Files: entities.FilesTrash})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = useCase.Commit(proposal)
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("Commit() error = %v, want the trash's error", err)
+	}
+	if !env.config.Config.KnownCategories["formal"] {
+		t.Error("the config was not put back")
+	}
+	if len(env.history.History.Records) != 2 || len(env.wearLog.Log.Events) != 1 {
+		t.Errorf("history = %+v, wear log = %+v, want both put back", env.history.History.Records, env.wearLog.Log.Events)
+	}
+	if !env.favorites.Favorites.Contains("formal", "tux.avatar") {
+		t.Error("favorites were not put back")
+	}
+}
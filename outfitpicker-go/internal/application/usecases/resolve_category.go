@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// ResolveCategoryUseCase turns a category name typed by the user into a
+// category under the configured root.
+type ResolveCategoryUseCase struct {
+	services Services
+}
+
+// NewResolveCategoryUseCase creates a new resolve category use case.
+func NewResolveCategoryUseCase(services Services) *ResolveCategoryUseCase {
+	return &ResolveCategoryUseCase{services: services}
+}
+
+// Execute matches input against the category names on disk and their
+// configured labels and aliases, ignoring case and accents. See
+// logic.MatchCategory for how ties are broken.
+func (u *ResolveCategoryUseCase) Execute(input string) (entities.CategoryReference, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.CategoryReference{}, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return entities.CategoryReference{}, err
+	}
+
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Category.Name
+	}
+	name, err := logic.MatchCategory(input, names, config.CategoryNames, config.Language)
+	if err != nil {
+		return entities.CategoryReference{}, err
+	}
+	for _, info := range infos {
+		if info.Category.Name == name {
+			return info.Category, nil
+		}
+	}
+	return entities.CategoryReference{}, nil
+}
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestResolveCategoryUseCase_MatchesLocalizedLabel(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config.Language = "fr"
+	if _, err := NewNameCategoryUseCase(env.services).Execute("casual", func(entities.CategoryNames) entities.CategoryNames {
+		return entities.CategoryNames{Labels: map[string]string{"fr": "Décontracté"}}
+	}); err != nil {
+		t.Fatalf("NameCategoryUseCase.Execute() error = %v", err)
+	}
+
+	category, err := NewResolveCategoryUseCase(env.services).Execute("decontracte")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if category.Name != "casual" || category.Path != env.outfit("casual", "tee.avatar").Category.Path {
+		t.Errorf("Execute() = %+v, want casual", category)
+	}
+}
+
+func TestResolveCategoryUseCase_UnknownCategory(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	if _, err := NewResolveCategoryUseCase(env.services).Execute("pyjamas"); !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("Execute() error = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestNameCategoryUseCase_InvalidLabel(t *testing.T) {
+	env := newTestEnv(t, nil)
+
+	_, err := NewNameCategoryUseCase(env.services).Execute("casual", func(entities.CategoryNames) entities.CategoryNames {
+		return entities.CategoryNames{Labels: map[string]string{"xx": "casual"}}
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute() error = %v, want ErrInvalidConfiguration", err)
+	}
+	if env.config.Saves != 0 {
+		t.Errorf("Saves = %d, want 0", env.config.Saves)
+	}
+}
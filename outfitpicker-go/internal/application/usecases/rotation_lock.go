@@ -0,0 +1,177 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/interfaces"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// RotationLockUseCase locks category rotations so they are only started
+// over by hand, and resets them.
+type RotationLockUseCase struct {
+	services Services
+}
+
+// NewRotationLockUseCase creates a new rotation lock use case.
+func NewRotationLockUseCase(services Services) *RotationLockUseCase {
+	return &RotationLockUseCase{services: services}
+}
+
+// Lock stops the rotation of the named category from being started over
+// automatically.
+func (u *RotationLockUseCase) Lock(categoryName string) error {
+	return u.update(categoryName, func(locked []string) []string {
+		if slices.Contains(locked, categoryName) {
+			return locked
+		}
+		return append(slices.Clone(locked), categoryName)
+	})
+}
+
+// Unlock lets the rotation of the named category start over automatically
+// again. Categories that no longer exist can be unlocked.
+func (u *RotationLockUseCase) Unlock(categoryName string) error {
+	return u.update(categoryName, func(locked []string) []string {
+		unlocked := slices.DeleteFunc(slices.Clone(locked), func(category string) bool { return category == categoryName })
+		if len(unlocked) == 0 {
+			return nil
+		}
+		return unlocked
+	})
+}
+
+// Status returns how far through its rotation each locked category is. It
+// only reads, so it also works in maintenance mode.
+func (u *RotationLockUseCase) Status() ([]logic.LockedRotation, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Selection.LockedRotations) == 0 {
+		return nil, nil
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	return logic.LockedRotations(config.Selection.LockedRotations, cache, snapshot), nil
+}
+
+// ResetProposal is a rotation reset worked out but not yet saved.
+type ResetProposal struct {
+	Category string `json:"category"`
+	// Worn lists the outfits worn in the current rotation, sorted, which
+	// the reset makes available again.
+	Worn []string `json:"worn"`
+	// Total is how many outfits the category holds.
+	Total int `json:"total"`
+	// Profile names the profile whose rotation is reset, when it is not
+	// the one making the reset.
+	Profile string `json:"profile,omitempty"`
+
+	cache interfaces.CacheService
+}
+
+// Changes returns the changes committing the reset makes.
+func (p *ResetProposal) Changes() []StateChange {
+	store := "cache"
+	if p.Profile != "" {
+		store = p.Profile + " cache"
+	}
+	return []StateChange{{Store: store, Change: fmt.Sprintf("start a new rotation of %s with all %d outfits unworn, %d of them worn now", p.Category, p.Total, len(p.Worn))}}
+}
+
+// Reset starts a new rotation of the named category, locked or not, with
+// every outfit unworn. The profile must be allowed to reset the category.
+func (u *RotationLockUseCase) Reset(categoryName string) error {
+	proposal, err := u.ProposeReset(categoryName)
+	if err != nil {
+		return err
+	}
+	return u.CommitReset(proposal)
+}
+
+// ProposeReset works out a reset of the named category as Reset does,
+// without saving anything. CommitReset saves it.
+func (u *RotationLockUseCase) ProposeReset(categoryName string) (*ResetProposal, error) {
+	return u.ProposeResetOf("", u.services, categoryName)
+}
+
+// ProposeResetOf works out a reset of the named category in the rotation of
+// profile, whose state target holds. This profile must be allowed to reset
+// the category but the other need not be, so a profile that may reset it
+// can start over a rotation that one which may not has completed.
+func (u *RotationLockUseCase) ProposeResetOf(profile string, target Services, categoryName string) (*ResetProposal, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if !config.Permissions.CanReset(categoryName) {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("this profile may not start a new rotation of %s", categoryName))
+	}
+	if profile != "" {
+		if config, err = target.Config.Load(); err != nil {
+			return nil, err
+		}
+	}
+	category, err := target.categoryReference(config, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := target.outfitsIn(config, category)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := target.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	worn := slices.Sorted(maps.Keys(cache.Categories[categoryName].WornOutfits))
+	return &ResetProposal{Category: categoryName, Worn: worn, Total: len(files), Profile: profile, cache: target.Cache}, nil
+}
+
+// CommitReset saves a reset worked out by ProposeReset.
+func (u *RotationLockUseCase) CommitReset(proposal *ResetProposal) error {
+	if err := u.services.ensureWritable(); err != nil {
+		return err
+	}
+	cache := proposal.cache
+	if cache == nil {
+		cache = u.services.Cache
+	}
+	return cache.UpdateCategory(proposal.Category, func(current entities.CategoryCache, _ bool) (entities.CategoryCache, error) {
+		return current.Restarted(proposal.Total), nil
+	})
+}
+
+// update replaces the locked rotations with change(current) and saves the
+// configuration, reapplying change if another writer saved first.
+func (u *RotationLockUseCase) update(categoryName string, change func(locked []string) []string) error {
+	if err := logic.ValidateCategoryName(categoryName); err != nil {
+		return err
+	}
+	return retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		selection := config.Selection
+		selection.LockedRotations = change(selection.LockedRotations)
+		if err := config.SetSelection(selection); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+}
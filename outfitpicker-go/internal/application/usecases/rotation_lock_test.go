@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func TestRotationLockUseCase_LockedRotationWaitsForReset(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"costumes": {"pirate.avatar", "robot.avatar"}})
+	locks := NewRotationLockUseCase(env.services)
+	if err := locks.Lock("costumes"); err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	wear := NewWearOutfitUseCase(env.services)
+	for _, file := range []string{"pirate.avatar", "robot.avatar"} {
+		if err := wear.Execute(env.outfit("costumes", file)); err != nil {
+			t.Fatalf("Execute(%s) error = %v, want no reset of the locked rotation", file, err)
+		}
+	}
+
+	_, err := NewPickOutfitUseCase(env.services).Execute("costumes")
+	if !errors.As(err, new(*domainerrors.InvalidInputError)) || !strings.Contains(err.Error(), "rotation reset costumes") {
+		t.Errorf("Execute() of a worn-through locked rotation error = %v, want a hint to reset it", err)
+	}
+	status, err := locks.Status()
+	if want := (logic.LockedRotation{Category: "costumes", Worn: 2, Total: 2, AwaitingReset: true}); err != nil || len(status) != 1 || status[0] != want {
+		t.Errorf("Status() = %+v, %v; want %+v", status, err, want)
+	}
+
+	analytics, err := NewWearAnalyticsUseCase(env.services).Execute()
+	if err != nil || len(analytics.LockedRotations) != 1 || !analytics.LockedRotations[0].AwaitingReset {
+		t.Errorf("WearAnalytics LockedRotations = %+v, %v; want costumes awaiting a reset", analytics.LockedRotations, err)
+	}
+
+	if err := locks.Reset("costumes"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if worn := len(env.cache.Cache.Categories["costumes"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after Reset() = %d, want 0", worn)
+	}
+	if _, err := NewPickOutfitUseCase(env.services).Execute("costumes"); err != nil {
+		t.Errorf("Execute() after Reset() error = %v", err)
+	}
+
+	if err := locks.Unlock("costumes"); err != nil || env.config.Config.Selection.LockedRotations != nil {
+		t.Errorf("Unlock() = %v, locked rotations = %v; want none", err, env.config.Config.Selection.LockedRotations)
+	}
+}
+
+func TestRotationLockUseCase_ProposeReset(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"costumes": {"pirate.avatar", "robot.avatar", "wizard.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("costumes", entities.NewCategoryCache(3).Adding("robot.avatar").Adding("pirate.avatar"))
+	locks := NewRotationLockUseCase(env.services)
+
+	proposal, err := locks.ProposeReset("costumes")
+	if err != nil {
+		t.Fatalf("ProposeReset() error = %v", err)
+	}
+	if !slices.Equal(proposal.Worn, []string{"pirate.avatar", "robot.avatar"}) || proposal.Total != 3 {
+		t.Errorf("ProposeReset() = %+v, want the two worn outfits of 3", proposal)
+	}
+	if env.cache.Saves != 0 {
+		t.Fatalf("ProposeReset() saved the cache %d times, want none", env.cache.Saves)
+	}
+	if err := locks.CommitReset(proposal); err != nil {
+		t.Fatalf("CommitReset() error = %v", err)
+	}
+	if worn := len(env.cache.Cache.Categories["costumes"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after CommitReset() = %d, want none", worn)
+	}
+}
+
+func TestRotationLockUseCase_OverridesRotationPolicy(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"costumes": {"pirate.avatar", "robot.avatar"}})
+	env.config.Config.Selection.RotationPolicy = entities.RotationPolicy{Name: entities.RotationRandom}
+	env.config.Config.Selection.LockedRotations = []string{"costumes"}
+	env.cache.Cache = env.cache.Cache.Updating("costumes", entities.NewCategoryCache(2).Adding("pirate.avatar"))
+
+	for seed := range uint64(5) {
+		proposal, err := NewPickOutfitUseCase(env.services).Propose("costumes", WithPickSeed(seed), WithRotationPolicy(entities.RotationPolicy{Name: entities.RotationRandom}))
+		if err != nil || proposal.Outfit.FileName != "robot.avatar" {
+			t.Fatalf("Propose() = %+v, %v; want robot.avatar, the only unworn outfit", proposal, err)
+		}
+	}
+}
+
+func TestRotationLockUseCase_Errors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"costumes": {"pirate.avatar"}})
+	locks := NewRotationLockUseCase(env.services)
+
+	if err := locks.Lock(""); err == nil {
+		t.Error("Lock(\"\") error = nil, want an error")
+	}
+	if err := locks.Reset("missing"); !errors.Is(err, domainerrors.ErrCategoryNotFound) {
+		t.Errorf("Reset(missing) error = %v, want ErrCategoryNotFound", err)
+	}
+	env.config.Config.Permissions = entities.CategoryPermissions{Reset: []string{"work"}}
+	if err := locks.Reset("costumes"); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Reset() without permission error = %v, want InvalidInputError", err)
+	}
+	env.maintenance.State.Enabled = true
+	if err := locks.Reset("costumes"); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Reset() in maintenance mode error = %v, want ErrMaintenanceMode", err)
+	}
+}
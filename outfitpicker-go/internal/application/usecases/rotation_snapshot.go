@@ -0,0 +1,42 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// RotationSnapshotUseCase rebuilds past rotation state from the history for
+// auditing. It only reads, so it also works in maintenance mode.
+type RotationSnapshotUseCase struct {
+	services Services
+}
+
+// NewRotationSnapshotUseCase creates a new rotation snapshot use case.
+func NewRotationSnapshotUseCase(services Services) *RotationSnapshotUseCase {
+	return &RotationSnapshotUseCase{services: services}
+}
+
+// Export returns the rotation state at the end of the calendar day
+// containing date, so everything recorded on that day is included.
+func (u *RotationSnapshotUseCase) Export(date time.Time) (entities.RotationSnapshot, error) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if start.After(u.services.now()) {
+		return entities.RotationSnapshot{}, errors.NewInvalidInputError("snapshot date " + start.Format("2006-01-02") + " is in the future")
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return entities.RotationSnapshot{}, err
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return entities.RotationSnapshot{}, err
+	}
+	arrivals, err := u.services.Arrivals.Load()
+	if err != nil {
+		return entities.RotationSnapshot{}, err
+	}
+	return logic.ReplayRotations(log, history, arrivals, start.AddDate(0, 0, 1)), nil
+}
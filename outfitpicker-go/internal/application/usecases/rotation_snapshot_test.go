@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestRotationSnapshotUseCase_Export(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: may(10)},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: may(11)},
+	}}
+	env.arrivals.Arrivals = entities.OutfitArrivals{Outfits: map[string]map[string]time.Time{
+		"casual": {"tee.avatar": {}, "jeans.avatar": {}},
+	}}
+	env.maintenance.State.Enabled = true
+
+	snapshot, err := NewRotationSnapshotUseCase(env.services).Export(time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !snapshot.At.Equal(may(11).Truncate(24 * time.Hour)) {
+		t.Errorf("At = %v, want the end of the day", snapshot.At)
+	}
+	if len(snapshot.Categories) != 1 || !slices.Equal(snapshot.Categories[0].Worn, []string{"tee.avatar"}) ||
+		!slices.Equal(snapshot.Categories[0].Available, []string{"jeans.avatar"}) {
+		t.Errorf("Export() = %+v, want tee.avatar worn and jeans.avatar available", snapshot.Categories)
+	}
+
+	if _, err := NewRotationSnapshotUseCase(env.services).Export(testNow.AddDate(0, 0, 1)); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Export() of a future date error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ScheduleUseCase manages the daily pick run by the operating system's
+// scheduler. The scheduler is outside the wardrobe's state, so it also
+// works in maintenance mode.
+type ScheduleUseCase struct {
+	services Services
+}
+
+// NewScheduleUseCase creates a new schedule use case.
+func NewScheduleUseCase(services Services) *ScheduleUseCase {
+	return &ScheduleUseCase{services: services}
+}
+
+// Install schedules command to run every day at, a time of day as HH:MM,
+// replacing any pick already scheduled.
+func (u *ScheduleUseCase) Install(at string, command []string) (entities.ScheduledPick, error) {
+	hour, minute, err := entities.ParseScheduleTime(at)
+	if err != nil {
+		return entities.ScheduledPick{}, err
+	}
+	if len(command) == 0 {
+		return entities.ScheduledPick{}, errors.NewInvalidInputError("the scheduled pick needs a command to run")
+	}
+	pick := entities.ScheduledPick{Hour: hour, Minute: minute, Command: command}
+	if err := u.services.Scheduler.Install(pick); err != nil {
+		return entities.ScheduledPick{}, err
+	}
+	return pick, nil
+}
+
+// Remove unschedules the daily pick and reports whether one was scheduled.
+func (u *ScheduleUseCase) Remove() (bool, error) {
+	return u.services.Scheduler.Remove()
+}
+
+// Status returns the scheduled daily pick, or nil when there is none.
+func (u *ScheduleUseCase) Status() (*entities.ScheduledPick, error) {
+	return u.services.Scheduler.Installed()
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestScheduleUseCase_InstallStatusRemove(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	schedule := NewScheduleUseCase(env.services)
+	command := []string{"/usr/local/bin/outfitpicker", "pick", "casual"}
+
+	pick, err := schedule.Install("07:30", command)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if pick.Hour != 7 || pick.Minute != 30 || !slices.Equal(pick.Command, command) {
+		t.Errorf("Install() = %+v", pick)
+	}
+	if installed, err := schedule.Status(); err != nil || installed == nil || installed.Time() != "07:30" {
+		t.Errorf("Status() = %+v, %v; want the pick at 07:30", installed, err)
+	}
+
+	if removed, err := schedule.Remove(); !removed || err != nil {
+		t.Errorf("Remove() = %v, %v; want true", removed, err)
+	}
+	if removed, err := schedule.Remove(); removed || err != nil {
+		t.Errorf("second Remove() = %v, %v; want false", removed, err)
+	}
+	if installed, err := schedule.Status(); installed != nil || err != nil {
+		t.Errorf("Status() after Remove() = %+v, %v; want none", installed, err)
+	}
+}
+
+func TestScheduleUseCase_InstallErrors(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	schedule := NewScheduleUseCase(env.services)
+
+	if _, err := schedule.Install("25:00", []string{"outfitpicker"}); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Install(25:00) error = %v, want InvalidInputError", err)
+	}
+	if _, err := schedule.Install("07:30", nil); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Install() without a command error = %v, want InvalidInputError", err)
+	}
+	env.scheduler.Err = errors.New("crontab: permission denied")
+	if _, err := schedule.Install("07:30", []string{"outfitpicker"}); err == nil {
+		t.Error("Install() error = nil, want the scheduler's error")
+	}
+	if env.scheduler.Pick != nil {
+		t.Errorf("scheduled pick = %+v, want none", env.scheduler.Pick)
+	}
+}
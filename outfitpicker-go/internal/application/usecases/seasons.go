@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// SeasonsUseCase reads and changes the seasons assigned to categories and
+// tags.
+type SeasonsUseCase struct {
+	services Services
+}
+
+// NewSeasonsUseCase creates a new seasons use case.
+func NewSeasonsUseCase(services Services) *SeasonsUseCase {
+	return &SeasonsUseCase{services: services}
+}
+
+// Current returns the season of the current date and the configured
+// assignments.
+func (u *SeasonsUseCase) Current() (string, entities.SeasonAssignments, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return "", entities.SeasonAssignments{}, err
+	}
+	return logic.SeasonAt(u.services.now(), config.Seasons.Southern), config.Seasons, nil
+}
+
+// Update replaces the assignments with change(current) and saves the
+// configuration, reapplying change if another writer saved first. It returns
+// the saved assignments.
+func (u *SeasonsUseCase) Update(change func(current entities.SeasonAssignments) entities.SeasonAssignments) (entities.SeasonAssignments, error) {
+	var saved entities.SeasonAssignments
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetSeasons(change(config.Seasons)); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config.Seasons
+		return nil
+	})
+	return saved, err
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestSeasonsUseCase_Update(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"knits": {"jumper.avatar"}})
+	useCase := NewSeasonsUseCase(env.services)
+
+	saved, err := useCase.Update(func(current entities.SeasonAssignments) entities.SeasonAssignments {
+		return current.SettingCategory("knits", []string{"autumn", "winter"})
+	})
+	if err != nil || len(saved.Category("knits")) != 2 {
+		t.Fatalf("Update() = %+v, %v", saved, err)
+	}
+	season, assignments, err := useCase.Current()
+	if err != nil || season != entities.SeasonSummer || len(assignments.Category("knits")) != 2 {
+		t.Errorf("Current() = %v, %+v, %v; want summer and the saved assignments", season, assignments, err)
+	}
+
+	_, err = useCase.Update(func(current entities.SeasonAssignments) entities.SeasonAssignments {
+		return current.SettingTag("beach", []string{"fall"})
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Update() with an unknown season error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestGetCategoriesUseCase_InSeason(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"knits": {"jumper.avatar"}, "casual": {"tee.avatar"}})
+	env.config.Config.Seasons = entities.SeasonAssignments{}.SettingCategory("knits", []string{"winter"})
+	useCase := NewGetCategoriesUseCase(env.services)
+
+	infos, season, err := useCase.InSeason(entities.SeasonAuto)
+	if err != nil || season != entities.SeasonSummer || len(infos) != 1 || infos[0].Category.Name != "casual" {
+		t.Errorf("InSeason(auto) = %+v, %v, %v; want only casual in summer", infos, season, err)
+	}
+	if infos, _, _ := useCase.InSeason(entities.SeasonWinter); len(infos) != 2 {
+		t.Errorf("InSeason(winter) returned %d categories, want 2", len(infos))
+	}
+}
+
+func TestPickOutfitUseCase_Season(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"knits": {"jumper.avatar"}, "casual": {"shorts.avatar", "coat.avatar"}})
+	env.config.Config.Seasons = entities.SeasonAssignments{}.
+		SettingCategory("knits", []string{"winter"}).
+		SettingTag("cold", []string{"autumn", "winter"})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "coat.avatar", entities.OutfitMetadata{Tags: []string{"cold"}})
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 10 {
+		outfit, err := useCase.Execute("casual", WithSeason(entities.SeasonAuto))
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "shorts.avatar" {
+			t.Fatalf("Execute() in summer = %v, want shorts.avatar", outfit.FileName)
+		}
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Execute("knits", WithSeason(entities.SeasonAuto)); !errors.As(err, &invalid) {
+		t.Errorf("Execute() of an off-season category error = %v, want InvalidInputError", err)
+	}
+	if outfit, err := useCase.Execute("knits", WithSeason(entities.SeasonWinter)); err != nil || outfit.FileName != "jumper.avatar" {
+		t.Errorf("Execute() in winter = %v, %v", outfit, err)
+	}
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("shorts.avatar"))
+	if _, err := useCase.Execute("casual", WithSeason(entities.SeasonSummer)); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with only off-season outfits unworn error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,331 @@
+// Package usecases implements the application's operations on top of the
+// domain ports.
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/interfaces"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// Services bundles the ports shared by the use cases.
+type Services struct {
+	Config      interfaces.ConfigService
+	Cache       interfaces.CacheService
+	Scanner     interfaces.CategoryScanner
+	Maintenance interfaces.MaintenanceStore
+	Metadata    interfaces.MetadataStore
+	Weights     interfaces.WeightStore
+	Favorites   interfaces.FavoritesStore
+	Skipped     interfaces.SkippedOutfitsStore
+	Laundry     interfaces.LaundryStore
+	Arrivals    interfaces.ArrivalStore
+	LastPicked  interfaces.CategoryPickStore
+	LatestPicks interfaces.LatestPickStore
+	Challenge   interfaces.ChallengeStore
+	Plan        interfaces.PlanStore
+	Forecasts   interfaces.ForecastStore
+	Weather     interfaces.WeatherProvider
+	WatchState  interfaces.WatchStateStore
+	Commands    interfaces.CommandHistoryStore
+	WearLog     interfaces.WearLogStore
+	History     interfaces.SelectionHistoryStore
+	Mailer      interfaces.Mailer
+	Scheduler   interfaces.Scheduler
+	Archiver    interfaces.OutfitArchiver
+	Importer    interfaces.ArchiveImporter
+	Bundles     interfaces.StateBundleArchive
+	Creator     interfaces.CategoryCreator
+	Trash       interfaces.Trash
+	Backups     interfaces.BackupStore
+	Profiles    interfaces.ProfileStore
+	Integrity   interfaces.IntegrityStore
+	Hasher      interfaces.OutfitHasher
+	// Context stops scans of the wardrobe and forecast lookups once it is
+	// done. Defaults to context.Background() when nil.
+	Context context.Context
+	// Now returns the current time. Defaults to time.Now when nil.
+	Now func() time.Time
+	// Progress receives progress events of long operations. Nothing is
+	// reported when nil.
+	Progress func(entities.ProgressEvent)
+	// Logger receives what the use cases do, for debugging. Nothing is
+	// logged when nil.
+	Logger *slog.Logger
+}
+
+// report sends a progress event to the Progress callback, if any.
+func (s Services) report(operation string, phase entities.ProgressPhase, current, total int, item string) {
+	if s.Progress != nil {
+		s.Progress(entities.ProgressEvent{Operation: operation, Phase: phase, Current: current, Total: total, Item: item})
+	}
+}
+
+// maxConflictAttempts bounds how often an operation is re-run after losing a
+// save to a concurrent writer.
+const maxConflictAttempts = 3
+
+// retryOnConflict runs op again when its save fails with a ConflictError. op
+// must reload the state it changes so each attempt merges with the newer
+// data.
+func retryOnConflict(op func() error) error {
+	var err error
+	for range maxConflictAttempts {
+		err = op()
+		var conflict *domainerrors.ConflictError
+		if !errors.As(err, &conflict) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s Services) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+	return s.Now()
+}
+
+func (s Services) logger() *slog.Logger {
+	if s.Logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	return s.Logger
+}
+
+func (s Services) ctx() context.Context {
+	if s.Context == nil {
+		return context.Background()
+	}
+	return s.Context
+}
+
+// ensureWritable fails with ErrMaintenanceMode while the wardrobe is locked.
+func (s Services) ensureWritable() error {
+	state, err := s.Maintenance.Load()
+	if err != nil {
+		return err
+	}
+	if state.Enabled {
+		return domainerrors.ErrMaintenanceMode
+	}
+	return nil
+}
+
+// categories scans the categories under the configured roots.
+func (s Services) categories(config *entities.Config) ([]entities.CategoryInfo, error) {
+	started := time.Now()
+	infos, err := s.Scanner.ScanCategories(s.ctx(), config.Roots, config.ExcludedAt(s.now()), config.Scan)
+	if err != nil {
+		s.logger().Debug("category scan failed", "roots", config.Roots, "error", err)
+		return nil, err
+	}
+	s.logger().Debug("scanned categories", "roots", config.Roots, "categories", len(infos), "took", time.Since(started))
+	return infos, nil
+}
+
+// taggedMetadata loads the outfit metadata with the default tags of every
+// category's description and the sidecar files of every outfit added, for
+// the rules that select outfits by tag, color, season or weight.
+func (s Services) taggedMetadata(config *entities.Config) (entities.MetadataIndex, error) {
+	index, err := s.Metadata.Load()
+	if err != nil {
+		return entities.MetadataIndex{}, err
+	}
+	infos, err := s.categories(config)
+	if err != nil {
+		return entities.MetadataIndex{}, err
+	}
+	index = index.WithCategoryTags(infos)
+	for _, info := range infos {
+		if info.State != entities.CategoryStateHasOutfits {
+			continue
+		}
+		sidecars, err := s.Scanner.ReadSidecars(s.ctx(), info.Category.Path)
+		if err != nil {
+			return entities.MetadataIndex{}, err
+		}
+		index = index.WithSidecars(info.Category.Name, sidecars)
+	}
+	return index, nil
+}
+
+// categoryComparison returns how the configured category order compares
+// category names, loading the health scores or rotation progress it sorts
+// by. infos are the categories being ordered.
+func (s Services) categoryComparison(config *entities.Config, infos []entities.CategoryInfo) (func(a, b string) int, error) {
+	health := make(map[string]int)
+	progress := make(map[string]float64)
+	switch config.Order.EffectiveSort() {
+	case entities.CategorySortHealth:
+		scores, err := s.categoryHealth(config, infos)
+		if err != nil {
+			return nil, err
+		}
+		for category, categoryHealth := range scores {
+			health[category] = categoryHealth.Score
+		}
+	case entities.CategorySortProgress:
+		cache, err := s.Cache.Load()
+		if err != nil {
+			return nil, err
+		}
+		for category, categoryCache := range cache.Categories {
+			progress[category] = categoryCache.RotationProgress()
+		}
+	}
+	return logic.CategoryComparison(config.Order, health, progress), nil
+}
+
+// sortCategories puts infos in the configured category order.
+func (s Services) sortCategories(config *entities.Config, infos []entities.CategoryInfo) error {
+	compare, err := s.categoryComparison(config, infos)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(infos, func(a, b entities.CategoryInfo) int {
+		return compare(a.Category.Name, b.Category.Name)
+	})
+	return nil
+}
+
+// wardrobeNotFound reports that a scan of config's roots found one missing.
+func wardrobeNotFound(config *entities.Config) error {
+	if len(config.Roots) == 1 {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("wardrobe directory %s does not exist", config.Roots[0]))
+	}
+	return domainerrors.NewInvalidInputError(fmt.Sprintf("one of the wardrobe directories %s does not exist", strings.Join(config.Roots, ", ")))
+}
+
+// outfitsIn lists the outfits of a category, reporting a missing directory as
+// ErrCategoryNotFound.
+func (s Services) outfitsIn(config *entities.Config, category entities.CategoryReference) ([]entities.FileEntry, error) {
+	files, err := s.Scanner.GetOutfits(s.ctx(), category.Path, config.Scan)
+	if errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		s.logger().Debug("category directory not found", "category", category.Name, "path", category.Path)
+		return nil, domainerrors.ErrCategoryNotFound
+	}
+	if err == nil {
+		s.logger().Debug("listed outfits", "category", category.Name, "outfits", len(files))
+	}
+	return files, err
+}
+
+// snapshot records the outfit file names of every category that is not
+// excluded, reporting scan progress one category at a time.
+func (s Services) snapshot(config *entities.Config) (entities.WardrobeSnapshot, error) {
+	infos, err := s.categories(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.report(entities.OperationScan, entities.ProgressStart, 0, len(infos), "")
+	snapshot := make(entities.WardrobeSnapshot, len(infos))
+	for i, info := range infos {
+		if info.State != entities.CategoryStateUserExcluded {
+			files, err := s.outfitsIn(config, info.Category)
+			if err != nil {
+				return nil, err
+			}
+			snapshot[info.Category.Name] = fileNames(files)
+		}
+		s.report(entities.OperationScan, entities.ProgressStep, i+1, len(infos), info.Category.Name)
+	}
+	s.report(entities.OperationScan, entities.ProgressDone, len(infos), len(infos), "")
+	return snapshot, nil
+}
+
+// recordArrivals records when the outfits a scan found in a category were
+// first and last seen, and returns the updated arrivals.
+func (s Services) recordArrivals(category string, files []entities.FileEntry) (entities.OutfitArrivals, error) {
+	names := fileNames(files)
+	var recorded entities.OutfitArrivals
+	err := retryOnConflict(func() error {
+		arrivals, err := s.Arrivals.Load()
+		if err != nil {
+			return err
+		}
+		updated, changed := arrivals.Recording(category, names, s.now())
+		recorded = updated
+		if !changed {
+			return nil
+		}
+		return s.Arrivals.Save(updated)
+	})
+	return recorded, err
+}
+
+// outfitID returns the stable ID of an outfit, or "" when outfit IDs are off
+// or the outfit has not been given one yet.
+func (s Services) outfitID(category, fileName string) (string, error) {
+	config, err := s.Config.Load()
+	if err != nil || !config.OutfitIDs.Enabled() {
+		return "", err
+	}
+	index, err := s.Metadata.Load()
+	if err != nil {
+		return "", err
+	}
+	id, _ := index.OutfitID(category, fileName)
+	return id, nil
+}
+
+// fileNames returns the file names of files.
+func fileNames(files []entities.FileEntry) []string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.FileName
+	}
+	return names
+}
+
+// ensureOutfitExists fails with an InvalidInputError unless the outfit is
+// in its category on disk.
+func (s Services) ensureOutfitExists(outfit entities.OutfitReference) error {
+	config, err := s.Config.Load()
+	if err != nil {
+		return err
+	}
+	category, err := s.categoryReference(config, outfit.Category.Name)
+	if err != nil {
+		return err
+	}
+	files, err := s.outfitsIn(config, category)
+	if err != nil {
+		return err
+	}
+	if !containsFile(files, outfit.FileName) {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("outfit %s not found in %s", outfit.FileName, outfit.Category.Name))
+	}
+	return nil
+}
+
+// categoryReference locates the named category. With a single root its
+// directory follows from the name; with several the roots are scanned to
+// find the one holding it. A category found in no root is placed in the
+// primary root.
+func (s Services) categoryReference(config *entities.Config, name string) (entities.CategoryReference, error) {
+	if len(config.Roots) > 1 {
+		infos, err := s.categories(config)
+		if err != nil {
+			return entities.CategoryReference{}, err
+		}
+		for _, info := range infos {
+			if info.Category.Name == name {
+				return info.Category, nil
+			}
+		}
+	}
+	return entities.NewCategoryReference(name, filepath.Join(config.PrimaryRoot(), name)), nil
+}
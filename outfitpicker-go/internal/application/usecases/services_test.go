@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+type testEnv struct {
+	root        string
+	services    Services
+	config      *testhelpers.FakeConfigService
+	cache       *testhelpers.FakeCacheService
+	maintenance *testhelpers.FakeMaintenanceStore
+	metadata    *testhelpers.FakeMetadataStore
+	weights     *testhelpers.FakeWeightStore
+	favorites   *testhelpers.FakeFavoritesStore
+	skipped     *testhelpers.FakeSkippedOutfitsStore
+	laundry     *testhelpers.FakeLaundryStore
+	arrivals    *testhelpers.FakeArrivalStore
+	lastPicked  *testhelpers.FakeCategoryPickStore
+	latestPicks *testhelpers.FakeLatestPickStore
+	challenge   *testhelpers.FakeChallengeStore
+	plan        *testhelpers.FakePlanStore
+	forecasts   *testhelpers.FakeForecastStore
+	weather     *testhelpers.FakeWeatherProvider
+	watchState  *testhelpers.FakeWatchStateStore
+	commands    *testhelpers.FakeCommandHistoryStore
+	wearLog     *testhelpers.FakeWearLogStore
+	history     *testhelpers.FakeHistoryStore
+	mailer      *testhelpers.FakeMailer
+	scheduler   *testhelpers.FakeScheduler
+	trash       *testhelpers.FakeTrash
+	backups     *testhelpers.FakeBackupStore
+	profiles    *testhelpers.FakeProfileStore
+	integrity   *testhelpers.FakeIntegrityStore
+}
+
+// newTestEnv creates services over a wardrobe with the given categories and
+// outfit file names.
+func newTestEnv(t *testing.T, wardrobe map[string][]string) *testEnv {
+	t.Helper()
+	return newTestEnvIn(t, t.TempDir(), wardrobe)
+}
+
+// newValidatedTestEnv is like newTestEnv but roots the wardrobe where it
+// passes path validation, for use cases that build configs with
+// entities.NewConfig.
+func newValidatedTestEnv(t *testing.T, wardrobe map[string][]string) *testEnv {
+	t.Helper()
+	return newTestEnvIn(t, testhelpers.UnrestrictedTempDir(t), wardrobe)
+}
+
+func newTestEnvIn(t *testing.T, root string, wardrobe map[string][]string) *testEnv {
+	t.Helper()
+	for category, files := range wardrobe {
+		if err := os.MkdirAll(filepath.Join(root, category), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			writeOutfit(t, root, category, file)
+		}
+	}
+
+	env := &testEnv{
+		root:        root,
+		config:      testhelpers.NewFakeConfigService(testhelpers.NewConfig(root)),
+		cache:       testhelpers.NewFakeCacheService(),
+		maintenance: &testhelpers.FakeMaintenanceStore{},
+		metadata:    testhelpers.NewFakeMetadataStore(),
+		weights:     testhelpers.NewFakeWeightStore(),
+		favorites:   testhelpers.NewFakeFavoritesStore(),
+		skipped:     testhelpers.NewFakeSkippedOutfitsStore(),
+		laundry:     testhelpers.NewFakeLaundryStore(),
+		arrivals:    testhelpers.NewFakeArrivalStore(),
+		lastPicked:  testhelpers.NewFakeCategoryPickStore(),
+		latestPicks: testhelpers.NewFakeLatestPickStore(),
+		challenge:   &testhelpers.FakeChallengeStore{},
+		plan:        &testhelpers.FakePlanStore{},
+		forecasts:   &testhelpers.FakeForecastStore{},
+		weather:     &testhelpers.FakeWeatherProvider{},
+		watchState:  &testhelpers.FakeWatchStateStore{},
+		commands:    &testhelpers.FakeCommandHistoryStore{},
+		wearLog:     &testhelpers.FakeWearLogStore{},
+		history:     &testhelpers.FakeHistoryStore{},
+		mailer:      &testhelpers.FakeMailer{},
+		scheduler:   &testhelpers.FakeScheduler{},
+		trash:       &testhelpers.FakeTrash{},
+		backups:     &testhelpers.FakeBackupStore{},
+		profiles:    &testhelpers.FakeProfileStore{},
+		integrity:   &testhelpers.FakeIntegrityStore{},
+	}
+	env.services = Services{
+		Config:      env.config,
+		Cache:       env.cache,
+		Scanner:     system.NewCategoryScanner(),
+		Maintenance: env.maintenance,
+		Metadata:    env.metadata,
+		Weights:     env.weights,
+		Favorites:   env.favorites,
+		Skipped:     env.skipped,
+		Laundry:     env.laundry,
+		Arrivals:    env.arrivals,
+		LastPicked:  env.lastPicked,
+		LatestPicks: env.latestPicks,
+		Challenge:   env.challenge,
+		Plan:        env.plan,
+		Forecasts:   env.forecasts,
+		Weather:     env.weather,
+		WatchState:  env.watchState,
+		Commands:    env.commands,
+		WearLog:     env.wearLog,
+		History:     env.history,
+		Mailer:      env.mailer,
+		Scheduler:   env.scheduler,
+		Archiver:    system.NewFileArchiver(),
+		Importer:    system.NewArchiveImporter(),
+		Bundles:     system.NewStateBundleArchive(),
+		Creator:     system.NewFileCategoryCreator(),
+		Trash:       env.trash,
+		Backups:     env.backups,
+		Profiles:    env.profiles,
+		Integrity:   env.integrity,
+		Hasher:      system.NewFileHasher(),
+		Now:         func() time.Time { return testNow },
+	}
+	return env
+}
+
+func (e *testEnv) outfit(category, fileName string) entities.OutfitReference {
+	return entities.NewOutfitReference(fileName, entities.NewCategoryReference(category, filepath.Join(e.root, category)))
+}
+
+func writeOutfit(t *testing.T, root, category, fileName string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(root, category, fileName), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
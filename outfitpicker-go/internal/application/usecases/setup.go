@@ -0,0 +1,394 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// SetupRequest describes the desired configuration. Empty fields keep the
+// current value; Roots is required when no configuration exists yet.
+type SetupRequest struct {
+	// Roots replaces the wardrobe roots, the first being the primary root.
+	Roots    []string
+	Language string
+	// Exclude lists categories that must be excluded.
+	Exclude []string
+	// Include lists categories that must be included and known.
+	Include []string
+	// Strategy selects the pick strategy; empty keeps the current one.
+	Strategy string
+	// FeedbackBoost sets how strongly positive feedback boosts weighted
+	// picks; nil keeps the current value.
+	FeedbackBoost *float64
+	// NewArrivalMode sets the new arrival grace period mode; "off" turns it
+	// off and empty keeps the current one.
+	NewArrivalMode string
+	// NewArrivalDays sets how long outfits count as new arrivals; zero keeps
+	// the current period, or uses the default when a mode is first chosen.
+	NewArrivalDays int
+	// EmptyCategories sets the empty category policy of picks across all
+	// categories; empty keeps the current one.
+	EmptyCategories string
+	// CategoryRestDays sets how long categories rest between picks across
+	// all categories; nil keeps the current value.
+	CategoryRestDays *int
+	// CategoryBalance sets how picks across all categories balance them;
+	// "off" turns balancing off and empty keeps the current one.
+	CategoryBalance string
+	// CategoryPriorities sets the priority of each named category, keeping
+	// the priorities of the others.
+	CategoryPriorities map[string]float64
+	// TagConstraints sets tag constraints, replacing any on the same tag and
+	// keeping the others.
+	TagConstraints []entities.TagConstraint
+	// RemoveTagConstraints lists tags whose constraint is removed.
+	RemoveTagConstraints []string
+	// CooldownDays sets how long worn outfits are left out of picks; nil
+	// keeps the current value.
+	CooldownDays *int
+	// RotationPolicy sets the rotation policy of categories without one of
+	// their own; nil keeps the current policy.
+	RotationPolicy *entities.RotationPolicy
+	// CategoryRotationPolicies sets the rotation policy of each named
+	// category, keeping the policies of the others.
+	CategoryRotationPolicies map[string]entities.RotationPolicy
+	// CategoryPickLimits sets the daily pick limit of each named category,
+	// keeping the limits of the others. A limit of 0 removes it.
+	CategoryPickLimits map[string]int
+	// IncludeHidden sets whether dotfiles are scanned; nil keeps the current
+	// value.
+	IncludeHidden *bool
+	// Ignore lists scan ignore patterns to add.
+	Ignore []string
+	// Health sets category health thresholds; zero fields keep the current
+	// values.
+	Health entities.HealthThresholds
+	// ProgressStyle sets how rotation progress is drawn; empty keeps the
+	// current style.
+	ProgressStyle string
+	// AllowPick replaces the categories the profile may pick from, and
+	// AllowReset those whose rotation it may start over. Nil keeps the
+	// current list and AllCategories alone lifts the restriction.
+	AllowPick  []string
+	AllowReset []string
+}
+
+// AllCategories, as the only category of SetupRequest.AllowPick or
+// AllowReset, allows every category.
+const AllCategories = "all"
+
+// SetupResult reports what Execute did.
+type SetupResult struct {
+	Config     *entities.Config
+	ConfigPath string
+	Created    bool
+	Changed    bool
+}
+
+// SetupUseCase converges the configuration to a desired state. Running it
+// again with the same request changes nothing.
+type SetupUseCase struct {
+	services Services
+}
+
+// NewSetupUseCase creates a new setup use case.
+func NewSetupUseCase(services Services) *SetupUseCase {
+	return &SetupUseCase{services: services}
+}
+
+// Execute creates or updates the configuration so that it matches request,
+// saving only when something changed.
+func (u *SetupUseCase) Execute(request SetupRequest) (*SetupResult, error) {
+	for _, name := range request.Exclude {
+		if slices.Contains(request.Include, name) {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("category %q cannot be both excluded and included", name))
+		}
+	}
+
+	var result *SetupResult
+	err := retryOnConflict(func() error {
+		var err error
+		result, err = u.converge(request)
+		return err
+	})
+	return result, err
+}
+
+func (u *SetupUseCase) converge(request SetupRequest) (*SetupResult, error) {
+	current, err := u.services.Config.Load()
+	if errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		current = nil
+	} else if err != nil {
+		return nil, err
+	}
+	if current == nil && len(request.Roots) == 0 {
+		return nil, domainerrors.NewInvalidInputError("root directory is required when no configuration exists")
+	}
+
+	desired, err := u.desiredConfig(current, request)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := u.services.Config.Path()
+	if err != nil {
+		return nil, err
+	}
+	result := &SetupResult{
+		Config:     desired,
+		ConfigPath: path,
+		Created:    current == nil,
+		Changed:    current == nil || !reflect.DeepEqual(current, desired),
+	}
+	if !result.Changed {
+		return result, nil
+	}
+	if err := u.services.Config.Save(desired); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u *SetupUseCase) desiredConfig(current *entities.Config, request SetupRequest) (*entities.Config, error) {
+	roots := request.Roots
+	var language *string
+	if request.Language != "" {
+		language = &request.Language
+	}
+	excluded := make(map[string]bool)
+	if current != nil {
+		if len(roots) == 0 {
+			roots = current.Roots
+		}
+		if language == nil {
+			language = &current.Language
+		}
+		maps.Copy(excluded, current.ExcludedCategories)
+	}
+	for _, name := range request.Exclude {
+		excluded[name] = true
+	}
+	for _, name := range request.Include {
+		delete(excluded, name)
+	}
+
+	desired, err := entities.NewConfig(roots[0], language, excluded, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := desired.SetRoots(roots); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		desired.Revision = current.Revision
+		desired.Selection = current.Selection
+		desired.Scan = current.Scan
+		desired.Reports = current.Reports
+		desired.Health = current.Health
+		desired.Seasons = current.Seasons
+		desired.Order = current.Order
+		desired.Accessibility = current.Accessibility
+		desired.OutfitIDs = current.OutfitIDs
+		desired.Permissions = current.Permissions
+	}
+
+	selection := desired.Selection
+	if request.Strategy != "" {
+		selection.Strategy = request.Strategy
+	}
+	if request.FeedbackBoost != nil {
+		selection.FeedbackBoost = *request.FeedbackBoost
+	}
+	selection.NewArrivals = newArrivalPolicy(selection.NewArrivals, request)
+	if request.EmptyCategories != "" {
+		selection.EmptyCategories = request.EmptyCategories
+	}
+	if request.CategoryRestDays != nil {
+		selection.CategoryRestDays = *request.CategoryRestDays
+	}
+	switch request.CategoryBalance {
+	case "":
+	case "off":
+		selection.CategoryBalance = ""
+	default:
+		selection.CategoryBalance = request.CategoryBalance
+	}
+	if len(request.CategoryPriorities) > 0 {
+		priorities := maps.Clone(selection.CategoryPriorities)
+		if priorities == nil {
+			priorities = make(map[string]float64)
+		}
+		maps.Copy(priorities, request.CategoryPriorities)
+		selection.CategoryPriorities = priorities
+	}
+	selection.TagConstraints = tagConstraints(selection.TagConstraints, request)
+	if request.CooldownDays != nil {
+		selection.CooldownDays = *request.CooldownDays
+	}
+	if request.RotationPolicy != nil {
+		selection.RotationPolicy = *request.RotationPolicy
+	}
+	if len(request.CategoryRotationPolicies) > 0 {
+		policies := maps.Clone(selection.CategoryRotationPolicies)
+		if policies == nil {
+			policies = make(map[string]entities.RotationPolicy)
+		}
+		maps.Copy(policies, request.CategoryRotationPolicies)
+		selection.CategoryRotationPolicies = policies
+	}
+	if len(request.CategoryPickLimits) > 0 {
+		limits := maps.Clone(selection.CategoryPickLimits)
+		if limits == nil {
+			limits = make(map[string]int)
+		}
+		for category, limit := range request.CategoryPickLimits {
+			if limit == 0 {
+				delete(limits, category)
+			} else {
+				limits[category] = limit
+			}
+		}
+		if len(limits) == 0 {
+			limits = nil
+		}
+		selection.CategoryPickLimits = limits
+	}
+	if err := desired.SetSelection(selection); err != nil {
+		return nil, err
+	}
+
+	scan := entities.ScanPolicy{IncludeHidden: desired.Scan.IncludeHidden, Ignore: slices.Clone(desired.Scan.Ignore)}
+	if request.IncludeHidden != nil {
+		scan.IncludeHidden = *request.IncludeHidden
+	}
+	for _, pattern := range request.Ignore {
+		if !slices.Contains(scan.Ignore, pattern) {
+			scan.Ignore = append(scan.Ignore, pattern)
+		}
+	}
+	if err := desired.SetScanPolicy(scan); err != nil {
+		return nil, err
+	}
+
+	health := desired.Health
+	if request.Health.HealthyScore != 0 {
+		health.HealthyScore = request.Health.HealthyScore
+	}
+	if request.Health.CriticalScore != 0 {
+		health.CriticalScore = request.Health.CriticalScore
+	}
+	if request.Health.StaleAfterDays != 0 {
+		health.StaleAfterDays = request.Health.StaleAfterDays
+	}
+	if request.Health.TargetRotationDays != 0 {
+		health.TargetRotationDays = request.Health.TargetRotationDays
+	}
+	if err := desired.SetHealth(health); err != nil {
+		return nil, err
+	}
+
+	accessibility := desired.Accessibility
+	if request.ProgressStyle != "" {
+		accessibility.ProgressStyle = request.ProgressStyle
+	}
+	if err := desired.SetAccessibility(accessibility); err != nil {
+		return nil, err
+	}
+
+	permissions := desired.Permissions
+	if request.AllowPick != nil {
+		permissions.Pick = allowedCategories(request.AllowPick)
+	}
+	if request.AllowReset != nil {
+		permissions.Reset = allowedCategories(request.AllowReset)
+	}
+	if err := desired.SetPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	if current != nil && slices.Equal(current.Roots, desired.Roots) {
+		desired.KnownCategories = maps.Clone(current.KnownCategories)
+		desired.KnownCategoryFiles = current.KnownCategoryFiles
+		desired.CategoryDecorations = current.CategoryDecorations
+		desired.CategoryNames = current.CategoryNames
+		if desired.KnownCategories == nil {
+			desired.KnownCategories = make(map[string]bool)
+		}
+	} else {
+		// Changed roots invalidate what was recorded about the categories.
+		// Decorations and labels stay while the primary root does, as its
+		// categories keep their names.
+		snapshot, err := u.services.snapshot(desired)
+		if errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+			return nil, wardrobeNotFound(desired)
+		}
+		if err != nil {
+			return nil, err
+		}
+		desired = withKnownFiles(desired, snapshot)
+		if current != nil && current.PrimaryRoot() == desired.PrimaryRoot() {
+			desired.CategoryDecorations = current.CategoryDecorations
+			desired.CategoryNames = current.CategoryNames
+		}
+	}
+
+	for _, name := range request.Include {
+		desired.KnownCategories[name] = true
+	}
+
+	return desired, nil
+}
+
+// allowedCategories returns the permission list for the categories of a
+// request, nil when they are AllCategories.
+func allowedCategories(categories []string) []string {
+	if slices.Equal(categories, []string{AllCategories}) {
+		return nil
+	}
+	return slices.Clone(categories)
+}
+
+// newArrivalPolicy applies the request's new arrival settings to current.
+func newArrivalPolicy(current entities.NewArrivalPolicy, request SetupRequest) entities.NewArrivalPolicy {
+	switch request.NewArrivalMode {
+	case "":
+	case "off":
+		return entities.NewArrivalPolicy{}
+	default:
+		current.Mode = request.NewArrivalMode
+	}
+	if request.NewArrivalDays != 0 {
+		current.Days = request.NewArrivalDays
+	}
+	if current.Mode != "" && current.Days == 0 {
+		current.Days = entities.DefaultNewArrivalDays
+	}
+	return current
+}
+
+// tagConstraints applies the constraints set and removed by request to
+// current, sorted by tag.
+func tagConstraints(current []entities.TagConstraint, request SetupRequest) []entities.TagConstraint {
+	if len(request.TagConstraints) == 0 && len(request.RemoveTagConstraints) == 0 {
+		return current
+	}
+	var constraints []entities.TagConstraint
+	for _, constraint := range current {
+		replaced := slices.ContainsFunc(request.TagConstraints, func(c entities.TagConstraint) bool { return c.Tag == constraint.Tag })
+		if !replaced && !slices.Contains(request.RemoveTagConstraints, constraint.Tag) {
+			constraints = append(constraints, constraint)
+		}
+	}
+	constraints = append(constraints, request.TagConstraints...)
+	slices.SortStableFunc(constraints, func(a, b entities.TagConstraint) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return constraints
+}
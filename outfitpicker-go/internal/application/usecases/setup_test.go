@@ -0,0 +1,362 @@
+package usecases
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestSetupUseCase_CreatesConfig(t *testing.T) {
+	env := newValidatedTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config = nil
+
+	result, err := NewSetupUseCase(env.services).Execute(SetupRequest{
+		Roots:    []string{env.root},
+		Language: "de",
+		Exclude:  []string{"formal"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Created || !result.Changed {
+		t.Errorf("Execute() = %+v, want created and changed", result)
+	}
+	saved := env.config.Config
+	if saved.Language != "de" || !saved.ExcludedCategories["formal"] || !saved.KnownCategories["casual"] {
+		t.Errorf("saved config = %+v", saved)
+	}
+}
+
+func TestSetupUseCase_IsIdempotent(t *testing.T) {
+	env := newValidatedTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "formal": {"suit.avatar"}})
+	env.config.Config = nil
+	request := SetupRequest{Roots: []string{env.root}, Language: "de", Exclude: []string{"formal"}, Include: []string{"casual"}}
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(request); err != nil {
+		t.Fatalf("first Execute() error = %v", err)
+	}
+	result, err := useCase.Execute(request)
+	if err != nil {
+		t.Fatalf("second Execute() error = %v", err)
+	}
+	if result.Created || result.Changed {
+		t.Errorf("second Execute() = %+v, want no change", result)
+	}
+	if env.config.Saves != 1 {
+		t.Errorf("Saves = %d, want 1", env.config.Saves)
+	}
+}
+
+func TestSetupUseCase_ConvergesExistingConfig(t *testing.T) {
+	env := newValidatedTestEnv(t, map[string][]string{"casual": {"a.avatar"}, "formal": {"suit.avatar"}})
+	useCase := NewSetupUseCase(env.services)
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, Exclude: []string{"formal"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := useCase.Execute(SetupRequest{Include: []string{"formal"}, Language: "fr"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Created || !result.Changed {
+		t.Errorf("Execute() = %+v, want changed", result)
+	}
+	saved := env.config.Config
+	if saved.PrimaryRoot() != env.root || saved.Language != "fr" || saved.ExcludedCategories["formal"] || !saved.KnownCategories["formal"] {
+		t.Errorf("saved config = %+v", saved)
+	}
+}
+
+func TestSetupUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		request SetupRequest
+		// want is nil when an InvalidInputError is expected.
+		want error
+	}{
+		{"missing root", SetupRequest{}, nil},
+		{"conflicting category", SetupRequest{Roots: []string{"/home/user/outfits"}, Exclude: []string{"a"}, Include: []string{"a"}}, nil},
+		{"restricted root", SetupRequest{Roots: []string{"/etc/outfits"}}, domainerrors.ErrInvalidConfiguration},
+		{"missing directory", SetupRequest{Roots: []string{"/home/nobody/outfitpicker-missing"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, nil)
+			env.config.Config = nil
+
+			_, err := NewSetupUseCase(env.services).Execute(tt.request)
+			var invalid *domainerrors.InvalidInputError
+			switch {
+			case tt.want == nil && !errors.As(err, &invalid):
+				t.Errorf("Execute() error = %v, want InvalidInputError", err)
+			case tt.want != nil && !errors.Is(domainerrors.MapError(err), tt.want):
+				t.Errorf("Execute() error = %v, want %v", err, tt.want)
+			}
+			if env.config.Config != nil {
+				t.Error("configuration saved despite error")
+			}
+		})
+	}
+}
+
+func TestSetupUseCase_SelectionPreferences(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+	boost := 0.5
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, Strategy: "weighted", FeedbackBoost: &boost}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := env.config.Config.Selection; got.Strategy != "weighted" || got.FeedbackBoost != 0.5 {
+		t.Errorf("Selection = %+v", got)
+	}
+
+	result, err := useCase.Execute(SetupRequest{Language: "de"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Changed || env.config.Config.Selection.Strategy != "weighted" {
+		t.Errorf("unrelated change reset selection: %+v", env.config.Config.Selection)
+	}
+
+	if _, err := useCase.Execute(SetupRequest{Strategy: "favorites"}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(unknown strategy) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_NewArrivals(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, NewArrivalMode: "prefer"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := entities.NewArrivalPolicy{Mode: "prefer", Days: entities.DefaultNewArrivalDays}
+	if got := env.config.Config.Selection.NewArrivals; got != want {
+		t.Errorf("NewArrivals = %+v, want %+v", got, want)
+	}
+
+	if _, err := useCase.Execute(SetupRequest{NewArrivalDays: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.config.Config.Selection.NewArrivals; got.Mode != "prefer" || got.Days != 3 {
+		t.Errorf("NewArrivals after changing the period = %+v", got)
+	}
+
+	if _, err := useCase.Execute(SetupRequest{NewArrivalMode: "off"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.config.Config.Selection.NewArrivals; got != (entities.NewArrivalPolicy{}) {
+		t.Errorf("NewArrivals after turning it off = %+v", got)
+	}
+
+	if _, err := useCase.Execute(SetupRequest{NewArrivalMode: "boost"}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(unknown mode) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_EmptyCategories(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, EmptyCategories: "fail"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := env.config.Config.Selection.EmptyCategories; got != entities.EmptyCategoriesFail {
+		t.Errorf("EmptyCategories = %q, want fail", got)
+	}
+	if _, err := useCase.Execute(SetupRequest{}); err != nil || env.config.Config.Selection.EmptyCategories != entities.EmptyCategoriesFail {
+		t.Errorf("Execute() without a policy = %v, changed the policy to %q", err, env.config.Config.Selection.EmptyCategories)
+	}
+	if _, err := useCase.Execute(SetupRequest{EmptyCategories: "ignore"}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(unknown policy) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_CategoryRestDays(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+	days := 2
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, CategoryRestDays: &days}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := env.config.Config.Selection.CategoryRestDays; got != 2 {
+		t.Errorf("CategoryRestDays = %d, want 2", got)
+	}
+	days = 0
+	if _, err := useCase.Execute(SetupRequest{CategoryRestDays: &days}); err != nil || env.config.Config.Selection.CategoryRestDays != 0 {
+		t.Errorf("turning the rest off = %v, CategoryRestDays = %d", err, env.config.Config.Selection.CategoryRestDays)
+	}
+	days = -1
+	if _, err := useCase.Execute(SetupRequest{CategoryRestDays: &days}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(negative rest) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_CategoryWeighting(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	request := SetupRequest{Roots: []string{env.root}, CategoryBalance: "remaining", CategoryPriorities: map[string]float64{"casual": 2}}
+	if _, err := useCase.Execute(request); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := useCase.Execute(SetupRequest{CategoryPriorities: map[string]float64{"work": 0.5}}); err != nil {
+		t.Fatal(err)
+	}
+	selection := env.config.Config.Selection
+	if selection.CategoryBalance != entities.CategoryBalanceRemaining || !maps.Equal(selection.CategoryPriorities, map[string]float64{"casual": 2, "work": 0.5}) {
+		t.Errorf("Selection = %+v", selection)
+	}
+
+	if _, err := useCase.Execute(SetupRequest{CategoryBalance: "off"}); err != nil || env.config.Config.Selection.CategoryBalance != "" {
+		t.Errorf("turning balancing off = %v, CategoryBalance = %q", err, env.config.Config.Selection.CategoryBalance)
+	}
+	if _, err := useCase.Execute(SetupRequest{CategoryPriorities: map[string]float64{"casual": -1}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(negative priority) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_RotationPolicies(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+	cooldown := entities.RotationPolicy{Name: entities.RotationCooldown, CooldownDays: 7}
+
+	request := SetupRequest{Roots: []string{env.root}, RotationPolicy: &entities.RotationPolicy{Name: entities.RotationRandom}, CategoryRotationPolicies: map[string]entities.RotationPolicy{"work": cooldown}}
+	if _, err := useCase.Execute(request); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := useCase.Execute(SetupRequest{CategoryRotationPolicies: map[string]entities.RotationPolicy{"casual": {Name: entities.RotationStrict}}}); err != nil {
+		t.Fatal(err)
+	}
+	selection := env.config.Config.Selection
+	if selection.RotationPolicy.Name != entities.RotationRandom || selection.RotationPolicyFor("work") != cooldown || selection.RotationPolicyFor("casual").Name != entities.RotationStrict {
+		t.Errorf("Selection = %+v", selection)
+	}
+	if _, err := useCase.Execute(SetupRequest{RotationPolicy: &entities.RotationPolicy{Name: entities.RotationCooldown}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(cooldown without days) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_TagConstraints(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+	black := entities.TagConstraint{Tag: "black", Max: 2, Days: 7}
+	wool := entities.TagConstraint{Tag: "wool", Max: 1, Days: 3, Severity: entities.ConstraintDowngrade}
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, TagConstraints: []entities.TagConstraint{wool, black}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	black.Max = 3
+	result, err := useCase.Execute(SetupRequest{TagConstraints: []entities.TagConstraint{black}, RemoveTagConstraints: []string{"wool"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := env.config.Config.Selection.TagConstraints; !result.Changed || !slices.Equal(got, []entities.TagConstraint{black}) {
+		t.Errorf("TagConstraints = %+v, changed = %v", got, result.Changed)
+	}
+	if result, _ := useCase.Execute(SetupRequest{TagConstraints: []entities.TagConstraint{black}}); result.Changed {
+		t.Error("setting the same constraint again changed the configuration")
+	}
+
+	duplicate := []entities.TagConstraint{wool, wool}
+	if _, err := useCase.Execute(SetupRequest{TagConstraints: duplicate}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(duplicate tags) error = %v, want ErrInvalidConfiguration", err)
+	}
+	if _, err := useCase.Execute(SetupRequest{TagConstraints: []entities.TagConstraint{{Tag: "black", Max: 1}}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(no days) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_ScanPolicy(t *testing.T) {
+	env := newValidatedTestEnv(t, map[string][]string{"casual": {"tee.avatar"}, ".archive": {"old.avatar"}})
+	useCase := NewSetupUseCase(env.services)
+	includeHidden := true
+	request := SetupRequest{Roots: []string{env.root}, IncludeHidden: &includeHidden, Ignore: []string{"*.bak.avatar"}}
+
+	if _, err := useCase.Execute(request); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	config := env.config.Config
+	if !config.Scan.IncludeHidden || !slices.Equal(config.Scan.Ignore, []string{"*.bak.avatar"}) {
+		t.Errorf("Scan = %+v", config.Scan)
+	}
+	infos, err := NewGetCategoriesUseCase(env.services).Execute()
+	if err != nil || len(infos) != 2 || infos[0].Category.Name != ".archive" {
+		t.Errorf("GetCategories() = %+v, %v; want the hidden category listed", infos, err)
+	}
+
+	result, err := useCase.Execute(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Changed {
+		t.Error("repeating the request changed the configuration")
+	}
+
+	if _, err := useCase.Execute(SetupRequest{Ignore: []string{"[a-"}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(bad pattern) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_ProgressStyle(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, ProgressStyle: "pattern"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := env.config.Config.Accessibility.ProgressStyle; got != entities.ProgressStylePattern {
+		t.Errorf("ProgressStyle = %q, want pattern", got)
+	}
+	if _, err := useCase.Execute(SetupRequest{}); err != nil || env.config.Config.Accessibility.ProgressStyle != entities.ProgressStylePattern {
+		t.Errorf("Execute() without a style = %v, changed the style to %q", err, env.config.Config.Accessibility.ProgressStyle)
+	}
+	if _, err := useCase.Execute(SetupRequest{ProgressStyle: "rainbow"}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(unknown style) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_PickLimits(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, CategoryPickLimits: map[string]int{"casual": 3, "work": 1}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := useCase.Execute(SetupRequest{CategoryPickLimits: map[string]int{"work": 0, "gym": 2}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := env.config.Config.Selection.CategoryPickLimits; !maps.Equal(got, map[string]int{"casual": 3, "gym": 2}) {
+		t.Errorf("CategoryPickLimits = %v, want work removed and gym added", got)
+	}
+	if _, err := useCase.Execute(SetupRequest{CategoryPickLimits: map[string]int{"casual": -1}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(negative limit) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
+
+func TestSetupUseCase_Permissions(t *testing.T) {
+	env := newValidatedTestEnv(t, nil)
+	useCase := NewSetupUseCase(env.services)
+
+	if _, err := useCase.Execute(SetupRequest{Roots: []string{env.root}, AllowPick: []string{"casual", "work"}, AllowReset: []string{"casual"}}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := useCase.Execute(SetupRequest{AllowReset: []string{AllCategories}}); err != nil {
+		t.Fatal(err)
+	}
+	want := entities.CategoryPermissions{Pick: []string{"casual", "work"}}
+	if got := env.config.Config.Permissions; !slices.Equal(got.Pick, want.Pick) || got.Reset != nil {
+		t.Errorf("Permissions = %+v, want %+v", got, want)
+	}
+	if _, err := useCase.Execute(SetupRequest{AllowPick: []string{"work", "work"}}); !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Execute(category listed twice) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// Shopping suggestion defaults.
+const (
+	// DefaultShoppingQuota is how many distinct outfits a month a category
+	// should offer.
+	DefaultShoppingQuota = 10
+	// DefaultShoppingDays is how many days of wears the cadence is taken
+	// from.
+	DefaultShoppingDays = 90
+	MaxShoppingDays     = 365
+)
+
+// ShoppingRequest configures shopping suggestions. Zero fields take their
+// defaults.
+type ShoppingRequest struct {
+	// Quota is the wanted number of distinct outfits worn a month.
+	Quota int
+	// Days is how far back wears are counted to find each category's
+	// cadence.
+	Days int
+}
+
+// ShoppingSuggestionsUseCase suggests where new outfits would add the most
+// variety.
+type ShoppingSuggestionsUseCase struct {
+	services Services
+}
+
+// NewShoppingSuggestionsUseCase creates a new shopping suggestions use case.
+func NewShoppingSuggestionsUseCase(services Services) *ShoppingSuggestionsUseCase {
+	return &ShoppingSuggestionsUseCase{services: services}
+}
+
+// Execute compares the size of every category with outfits that is not
+// excluded to how often it was worn recently, and returns the categories
+// whose rotation completes too quickly to meet the quota.
+func (u *ShoppingSuggestionsUseCase) Execute(request ShoppingRequest) ([]logic.ShoppingSuggestion, error) {
+	if request.Quota == 0 {
+		request.Quota = DefaultShoppingQuota
+	}
+	if request.Days == 0 {
+		request.Days = DefaultShoppingDays
+	}
+	if request.Quota < 1 {
+		return nil, errors.NewInvalidInputError("quota must be 1 or greater")
+	}
+	if request.Days < 1 || request.Days > MaxShoppingDays {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("days must be between 1 and %d", MaxShoppingDays))
+	}
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return nil, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	since := u.services.now().AddDate(0, 0, -request.Days)
+	wears := make(map[string]int)
+	for _, event := range log.Events {
+		if !event.WornAt.Before(since) {
+			wears[event.Category]++
+		}
+	}
+	var cadences []logic.CategoryCadence
+	for _, info := range infos {
+		if info.State != entities.CategoryStateHasOutfits {
+			continue
+		}
+		cadences = append(cadences, logic.CategoryCadence{
+			Category: info.Category.Name,
+			Outfits:  info.OutfitCount,
+			Wears:    wears[info.Category.Name],
+			Days:     request.Days,
+		})
+	}
+	return logic.ShoppingSuggestions(cadences, request.Quota), nil
+}
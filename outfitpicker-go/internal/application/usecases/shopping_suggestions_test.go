@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestShoppingSuggestionsUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"work":   {"suit.avatar", "blazer.avatar"},
+		"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"},
+	})
+	var events []entities.WearEvent
+	for day := 1; day <= 30; day++ {
+		events = append(events, entities.WearEvent{Category: "work", FileName: "suit.avatar", WornAt: testNow.AddDate(0, 0, -day)})
+	}
+	events = append(events,
+		entities.WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: testNow.AddDate(0, 0, -3)},
+		entities.WearEvent{Category: "casual", FileName: "jeans.avatar", WornAt: testNow.AddDate(0, -6, 0)},
+	)
+	env.wearLog.Log = entities.WearLog{Events: events}
+
+	suggestions, err := NewShoppingSuggestionsUseCase(env.services).Execute(ShoppingRequest{Days: 30})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Category != "work" || suggestions[0].Suggested != DefaultShoppingQuota-2 || suggestions[0].RotationDays != 2 {
+		t.Errorf("Execute() = %+v, want only work, short of the default quota", suggestions)
+	}
+
+	for _, request := range []ShoppingRequest{{Quota: -1}, {Days: MaxShoppingDays + 1}} {
+		if _, err := NewShoppingSuggestionsUseCase(env.services).Execute(request); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+			t.Errorf("Execute(%+v) error = %v, want InvalidInputError", request, err)
+		}
+	}
+}
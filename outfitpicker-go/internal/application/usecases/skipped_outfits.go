@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// SkipOutfitUseCase leaves outfits out of picks for a while, such as while
+// they are at the dry cleaner, without counting them as worn.
+type SkipOutfitUseCase struct {
+	services Services
+}
+
+// NewSkipOutfitUseCase creates a new skip outfit use case.
+func NewSkipOutfitUseCase(services Services) *SkipOutfitUseCase {
+	return &SkipOutfitUseCase{services: services}
+}
+
+// Skip leaves the outfit out of picks until it is unskipped or, when
+// period is positive, until period has passed. Skipping an outfit again
+// replaces its earlier skip. It returns when the skip ends, zero when it
+// does not.
+func (u *SkipOutfitUseCase) Skip(outfit entities.OutfitReference, period time.Duration) (time.Time, error) {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return time.Time{}, err
+	}
+	if err := u.services.ensureOutfitExists(outfit); err != nil {
+		return time.Time{}, err
+	}
+	var until time.Time
+	if period > 0 {
+		until = u.services.now().Add(period)
+	}
+	err := u.update(func(skipped entities.SkippedOutfits) (entities.SkippedOutfits, error) {
+		return skipped.Skipping(outfit.Category.Name, outfit.FileName, until), nil
+	})
+	return until, err
+}
+
+// Unskip lets a skipped outfit be picked again. The outfit need not exist
+// on disk any more, so skips of deleted outfits can be cleaned up.
+func (u *SkipOutfitUseCase) Unskip(outfit entities.OutfitReference) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	return u.update(func(skipped entities.SkippedOutfits) (entities.SkippedOutfits, error) {
+		if !skipped.SkippedAt(outfit.Category.Name, outfit.FileName, u.services.now()) {
+			return skipped, errors.NewInvalidInputError(fmt.Sprintf("%s/%s is not skipped", outfit.Category.Name, outfit.FileName))
+		}
+		return skipped.Unskipping(outfit.Category.Name, outfit.FileName), nil
+	})
+}
+
+// List returns the outfits skipped now, by category and file name.
+func (u *SkipOutfitUseCase) List() ([]entities.SkippedOutfit, error) {
+	skipped, err := u.services.Skipped.Load()
+	if err != nil {
+		return nil, err
+	}
+	return skipped.At(u.services.now()), nil
+}
+
+// update applies change to the skipped outfits and saves them, reapplying
+// change if another writer saved first. Skips that have ended are dropped
+// on the way.
+func (u *SkipOutfitUseCase) update(change func(entities.SkippedOutfits) (entities.SkippedOutfits, error)) error {
+	return retryOnConflict(func() error {
+		skipped, err := u.services.Skipped.Load()
+		if err != nil {
+			return err
+		}
+		skipped, err = change(skipped.DroppingExpired(u.services.now()))
+		if err != nil {
+			return err
+		}
+		return u.services.Skipped.Save(skipped)
+	})
+}
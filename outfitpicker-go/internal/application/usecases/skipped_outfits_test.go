@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestSkipOutfitUseCase_SkipAndUnskip(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	useCase := NewSkipOutfitUseCase(env.services)
+
+	until, err := useCase.Skip(env.outfit("casual", "tee.avatar"), 3*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Skip() error = %v", err)
+	}
+	if want := testNow.AddDate(0, 0, 3); !until.Equal(want) {
+		t.Errorf("Skip() until = %v, want %v", until, want)
+	}
+	if until, err := useCase.Skip(env.outfit("casual", "jeans.avatar"), 0); err != nil || !until.IsZero() {
+		t.Fatalf("Skip() until unskipped = %v, %v", until, err)
+	}
+	skipped, err := useCase.List()
+	if err != nil || len(skipped) != 2 || skipped[0].FileName != "jeans.avatar" || !skipped[1].Until.Equal(until) {
+		t.Errorf("List() = %+v, %v", skipped, err)
+	}
+	if cached := env.cache.Cache.Categories["casual"]; len(cached.WornOutfits) != 0 {
+		t.Errorf("skipping marked outfits worn: %v", cached.WornOutfits)
+	}
+
+	if err := useCase.Unskip(env.outfit("casual", "tee.avatar")); err != nil {
+		t.Fatalf("Unskip() error = %v", err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if err := useCase.Unskip(env.outfit("casual", "tee.avatar")); !errors.As(err, &invalid) {
+		t.Errorf("Unskip() of an outfit not skipped error = %v, want InvalidInputError", err)
+	}
+	if _, err := useCase.Skip(env.outfit("casual", "nope.avatar"), 0); !errors.As(err, &invalid) {
+		t.Errorf("Skip() of a missing outfit error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestPickOutfitUseCase_LeavesOutSkippedOutfits(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.skipped.Skipped = entities.NewSkippedOutfits().
+		Skipping("casual", "a.avatar", time.Time{}).
+		Skipping("casual", "b.avatar", testNow.Add(-time.Hour))
+	useCase := NewPickOutfitUseCase(env.services)
+
+	for range 10 {
+		outfit, err := useCase.Execute("casual")
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if outfit.FileName != "b.avatar" {
+			t.Fatalf("Execute() = %v, want b.avatar, whose skip has ended", outfit.FileName)
+		}
+	}
+
+	env.skipped.Skipped = env.skipped.Skipped.Skipping("casual", "b.avatar", time.Time{})
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Execute("casual"); !errors.As(err, &invalid) {
+		t.Errorf("Execute() with every outfit skipped error = %v, want InvalidInputError", err)
+	}
+}
@@ -0,0 +1,277 @@
+package usecases
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// StateBundleProposal is a state bundle read and checked, ready to replace
+// the current state.
+type StateBundleProposal struct {
+	Manifest entities.StateBundleManifest `json:"manifest"`
+	// Roots are the wardrobe roots of the bundled configuration.
+	Roots []string `json:"roots"`
+	// MissingRoots lists the roots that do not exist on this machine.
+	MissingRoots []string `json:"missingRoots,omitempty"`
+
+	bundle entities.StateBundle
+}
+
+// Changes returns the changes importing the bundle makes.
+func (p *StateBundleProposal) Changes() []StateChange {
+	return []StateChange{
+		{Store: "config", Change: "replace with the bundled configuration"},
+		{Store: "cache", Change: "replace with the bundled rotation state"},
+		{Store: "history", Change: "replace with " + countOf(len(p.bundle.History.Records), "pick")},
+		{Store: "wear log", Change: "replace with " + countOf(len(p.bundle.WearLog.Events), "wear")},
+		{Store: "metadata", Change: "replace with the bundled outfit metadata"},
+		{Store: "weights", Change: "replace with the bundled outfit weights"},
+		{Store: "favorites", Change: "replace with the bundled favorites"},
+		{Store: "skipped", Change: "replace with the bundled skipped outfits"},
+		{Store: "laundry", Change: "replace with the bundled laundry"},
+		{Store: "arrivals", Change: "replace with the bundled outfit arrivals"},
+		{Store: "category picks", Change: "replace with the bundled last picks of each category"},
+		{Store: "plan", Change: "replace with the bundled plan"},
+		{Store: "challenge", Change: "replace with the bundled challenge"},
+	}
+}
+
+// StateBundleImport reports a state bundle imported, and the backup of the
+// configuration and cache it replaced.
+type StateBundleImport struct {
+	*StateBundleProposal
+	// Previous is nil when there was no configuration to back up.
+	Previous *entities.Backup `json:"previous,omitempty"`
+}
+
+// StateBundleUseCase moves the state of a wardrobe between machines as a
+// single bundle file: the configuration, rotation cache, history, outfit
+// metadata and the other state files, without the outfit files.
+type StateBundleUseCase struct {
+	services Services
+}
+
+// NewStateBundleUseCase creates a new state bundle use case.
+func NewStateBundleUseCase(services Services) *StateBundleUseCase {
+	return &StateBundleUseCase{services: services}
+}
+
+// Export writes a state bundle to w, recording version as the version of
+// outfitpicker that wrote it.
+func (u *StateBundleUseCase) Export(w io.Writer, version string) (*entities.StateBundleManifest, error) {
+	s := u.services
+	stores := []struct {
+		name string
+		load func() (any, error)
+	}{
+		{entities.StateBundleConfig, func() (any, error) { return s.Config.Load() }},
+		{entities.StateBundleCache, func() (any, error) { return s.Cache.Load() }},
+		{entities.StateBundleHistory, func() (any, error) { return s.History.Load() }},
+		{entities.StateBundleWearLog, func() (any, error) { return s.WearLog.Load() }},
+		{entities.StateBundleMetadata, func() (any, error) { return s.Metadata.Load() }},
+		{entities.StateBundleWeights, func() (any, error) { return s.Weights.Load() }},
+		{entities.StateBundleFavorites, func() (any, error) { return s.Favorites.Load() }},
+		{entities.StateBundleSkipped, func() (any, error) { return s.Skipped.Load() }},
+		{entities.StateBundleLaundry, func() (any, error) { return s.Laundry.Load() }},
+		{entities.StateBundleArrivals, func() (any, error) { return s.Arrivals.Load() }},
+		{entities.StateBundleLastPicked, func() (any, error) { return s.LastPicked.Load() }},
+		{entities.StateBundlePlan, func() (any, error) { return s.Plan.Load() }},
+		{entities.StateBundleChallenge, func() (any, error) { return s.Challenge.Load() }},
+	}
+
+	manifest := &entities.StateBundleManifest{Format: entities.StateBundleFormat, CreatedAt: s.now(), Version: version}
+	files := []entities.StateBundleFile{{Name: entities.StateBundleManifestName}}
+	for _, store := range stores {
+		value, err := store.load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, store.name)
+		files = append(files, entities.StateBundleFile{Name: store.name, Data: data})
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[0].Data = data
+
+	if err := s.Bundles.Write(w, files, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Propose reads the state bundle at path and checks that this version of
+// outfitpicker can import it. Nothing is changed.
+func (u *StateBundleUseCase) Propose(path string) (*StateBundleProposal, error) {
+	files, err := u.services.Bundles.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := &StateBundleProposal{}
+	manifest, ok := files[entities.StateBundleManifestName]
+	if !ok {
+		return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("%s is not a state bundle: it has no %s", path, entities.StateBundleManifestName))
+	}
+	if err := decodeBundleFile(entities.StateBundleManifestName, manifest, &proposal.Manifest); err != nil {
+		return nil, err
+	}
+	if proposal.Manifest.Format > entities.StateBundleFormat {
+		return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("the bundle was written by outfitpicker %s in format %d, newer than the format %d this version reads; update outfitpicker to import it",
+			proposal.Manifest.Version, proposal.Manifest.Format, entities.StateBundleFormat))
+	}
+	if proposal.Manifest.Format < 1 {
+		return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("the bundle has unknown format %d", proposal.Manifest.Format))
+	}
+
+	bundle := &proposal.bundle
+	targets := []struct {
+		name   string
+		target any
+	}{
+		{entities.StateBundleConfig, &bundle.Config},
+		{entities.StateBundleCache, &bundle.Cache},
+		{entities.StateBundleHistory, &bundle.History},
+		{entities.StateBundleWearLog, &bundle.WearLog},
+		{entities.StateBundleMetadata, &bundle.Metadata},
+		{entities.StateBundleWeights, &bundle.Weights},
+		{entities.StateBundleFavorites, &bundle.Favorites},
+		{entities.StateBundleSkipped, &bundle.Skipped},
+		{entities.StateBundleLaundry, &bundle.Laundry},
+		{entities.StateBundleArrivals, &bundle.Arrivals},
+		{entities.StateBundleLastPicked, &bundle.LastPicked},
+		{entities.StateBundlePlan, &bundle.Plan},
+		{entities.StateBundleChallenge, &bundle.Challenge},
+	}
+	for _, target := range targets {
+		data, ok := files[target.name]
+		if !ok {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("the bundle has no %s", target.name))
+		}
+		if err := decodeBundleFile(target.name, data, target.target); err != nil {
+			return nil, err
+		}
+	}
+	if bundle.Config == nil || len(bundle.Config.Roots) == 0 {
+		return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("the bundle's %s has no wardrobe root", entities.StateBundleConfig))
+	}
+
+	proposal.Roots = bundle.Config.Roots
+	proposal.MissingRoots = u.services.Bundles.MissingRoots(bundle.Config.Roots)
+	return proposal, nil
+}
+
+// Commit replaces the current state with the bundle's. The current
+// configuration and cache are backed up first, so they can be brought back
+// with backup restore; the other state files are not. Should replacing any
+// state file fail, those already replaced are put back as they were.
+func (u *StateBundleUseCase) Commit(proposal *StateBundleProposal) (*StateBundleImport, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	result := &StateBundleImport{StateBundleProposal: proposal}
+	if _, err := u.services.Config.Load(); err == nil {
+		backup, err := NewBackupUseCase(u.services).Create()
+		if err != nil {
+			return nil, err
+		}
+		result.Previous = &backup.Backup
+	} else if !errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		return nil, err
+	}
+
+	var applied []*stateEdit
+	for _, edit := range u.edits(proposal) {
+		if err := edit.apply(); err != nil {
+			return nil, undoEdits(applied, err)
+		}
+		applied = append(applied, edit)
+	}
+	return result, nil
+}
+
+// edits returns the replacement of each state file the bundle carries.
+func (u *StateBundleUseCase) edits(proposal *StateBundleProposal) []*stateEdit {
+	s, bundle := u.services, proposal.bundle
+	return []*stateEdit{
+		u.configEdit(*bundle.Config),
+		replaceEdit(s.Cache.Load, s.Cache.Save, func(c *entities.OutfitCache) *int { return &c.Revision }, bundle.Cache),
+		replaceEdit(s.History.Load, s.History.Save, func(h *entities.SelectionHistory) *int { return &h.Revision }, bundle.History),
+		replaceEdit(s.WearLog.Load, s.WearLog.Save, func(l *entities.WearLog) *int { return &l.Revision }, bundle.WearLog),
+		replaceEdit(s.Metadata.Load, s.Metadata.Save, func(i *entities.MetadataIndex) *int { return &i.Revision }, bundle.Metadata),
+		replaceEdit(s.Weights.Load, s.Weights.Save, func(w *entities.OutfitWeights) *int { return &w.Revision }, bundle.Weights),
+		replaceEdit(s.Favorites.Load, s.Favorites.Save, func(f *entities.Favorites) *int { return &f.Revision }, bundle.Favorites),
+		replaceEdit(s.Skipped.Load, s.Skipped.Save, func(k *entities.SkippedOutfits) *int { return &k.Revision }, bundle.Skipped),
+		replaceEdit(s.Laundry.Load, s.Laundry.Save, func(l *entities.Laundry) *int { return &l.Revision }, bundle.Laundry),
+		replaceEdit(s.Arrivals.Load, s.Arrivals.Save, func(a *entities.OutfitArrivals) *int { return &a.Revision }, bundle.Arrivals),
+		replaceEdit(s.LastPicked.Load, s.LastPicked.Save, func(p *entities.CategoryPicks) *int { return &p.Revision }, bundle.LastPicked),
+		replaceEdit(s.Plan.Load, s.Plan.Save, func(p *entities.OutfitPlan) *int { return &p.Revision }, bundle.Plan),
+		replaceEdit(s.Challenge.Load, s.Challenge.Save, func(c *entities.Challenge) *int { return &c.Revision }, bundle.Challenge),
+	}
+}
+
+// configEdit returns the edit saving config in place of the current
+// configuration. Undoing it deletes the configuration when there was none.
+func (u *StateBundleUseCase) configEdit(config entities.Config) *stateEdit {
+	var original *entities.Config
+	return &stateEdit{
+		apply: func() error {
+			return retryOnConflict(func() error {
+				config.Revision = 0
+				current, err := u.services.Config.Load()
+				switch {
+				case err == nil:
+					original = current
+					config.Revision = current.Revision
+				case !errors.Is(err, domainerrors.ErrConfigurationNotFound):
+					return err
+				}
+				return u.services.Config.Save(&config)
+			})
+		},
+		undo: func() error {
+			if original == nil {
+				return u.services.Config.Delete()
+			}
+			return replaceState(u.services.Config.Load, u.services.Config.Save, original, func(c **entities.Config) *int { return &(*c).Revision })
+		},
+	}
+}
+
+// replaceEdit returns the edit saving value in place of a store's state.
+func replaceEdit[T any](load func() (T, error), save func(T) error, revision func(*T) *int, value T) *stateEdit {
+	return editState(load, save, revision, func(current T) T {
+		*revision(&value) = *revision(&current)
+		return value
+	})
+}
+
+// replaceState saves value in place of a store's state, taking over the
+// revision of the saved file so the save is not refused as stale.
+func replaceState[T any](load func() (T, error), save func(T) error, value T, revision func(*T) *int) error {
+	return retryOnConflict(func() error {
+		current, err := load()
+		if err != nil {
+			return err
+		}
+		*revision(&value) = *revision(&current)
+		return save(value)
+	})
+}
+
+func decodeBundleFile(name string, data []byte, target any) error {
+	if err := json.Unmarshal(data, target); err != nil {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("the bundle's %s is not valid: %v", name, err))
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// writeBundle writes files, each encoded as JSON, to a state bundle in a
+// temporary directory and returns its path.
+func writeBundle(t *testing.T, files map[string]any) string {
+	t.Helper()
+	var bundleFiles []entities.StateBundleFile
+	for name, value := range files {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bundleFiles = append(bundleFiles, entities.StateBundleFile{Name: name, Data: data})
+	}
+	var buf bytes.Buffer
+	if err := system.NewStateBundleArchive().Write(&buf, bundleFiles, testNow); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStateBundleUseCase_RoundTrip(t *testing.T) {
+	source := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	source.cache.Cache.Categories["casual"] = entities.CategoryCache{WornOutfits: map[string]bool{"tee.avatar": true}, TotalOutfits: 2}
+	source.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{{Category: "casual", FileName: "tee.avatar", SelectedAt: testNow}}}
+	source.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{{Category: "casual", FileName: "tee.avatar", WornAt: testNow}}}
+	source.metadata.Index = source.metadata.Index.Setting("casual", "tee.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+	source.skipped.Skipped = entities.SkippedOutfits{Outfits: map[string]map[string]time.Time{"casual": {"jeans.avatar": {}}}}
+	source.laundry.Laundry = source.laundry.Laundry.Wearing("casual", "tee.avatar")
+	source.lastPicked.Picks = source.lastPicked.Picks.Recording("casual", testNow)
+	source.plan.Plan = entities.OutfitPlan{Days: make([]entities.PlanDay, 3), CreatedAt: testNow}
+	source.challenge.Challenge = entities.Challenge{StartedAt: testNow}
+
+	var buf bytes.Buffer
+	manifest, err := NewStateBundleUseCase(source.services).Export(&buf, "v1.2.3")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if manifest.Format != entities.StateBundleFormat || manifest.Version != "v1.2.3" || len(manifest.Files) != 13 {
+		t.Errorf("Export() manifest = %+v", manifest)
+	}
+	path := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	target := newTestEnv(t, nil)
+	target.history.History.Revision = 4
+	bundles := NewStateBundleUseCase(target.services)
+	proposal, err := bundles.Propose(path)
+	if err != nil {
+		t.Fatalf("Propose() error = %v", err)
+	}
+	if len(proposal.MissingRoots) != 0 || len(proposal.Changes()) != 13 || target.history.Saves != 0 {
+		t.Errorf("Propose() = %+v, want no missing roots, a change to every store and nothing saved", proposal)
+	}
+
+	result, err := bundles.Commit(proposal)
+	if err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if result.Previous == nil || len(target.backups.Backups) != 1 {
+		t.Error("Commit() did not back up the replaced config and cache")
+	}
+	if got := target.config.Config.Roots; len(got) != 1 || got[0] != source.root {
+		t.Errorf("config roots = %v, want %v", got, source.root)
+	}
+	if !target.cache.Cache.Categories["casual"].WornOutfits["tee.avatar"] {
+		t.Error("cache rotation was not imported")
+	}
+	if len(target.history.History.Records) != 1 || len(target.wearLog.Log.Events) != 1 {
+		t.Errorf("history = %+v, wear log = %+v, want one pick and one wear", target.history.History, target.wearLog.Log)
+	}
+	if !target.metadata.Index.HasTag("casual", "tee.avatar", "summer") {
+		t.Error("metadata was not imported")
+	}
+	if !target.skipped.Skipped.SkippedAt("casual", "jeans.avatar", testNow) || target.laundry.Laundry.State("casual", "tee.avatar") != entities.LaundryWorn {
+		t.Errorf("skipped = %+v, laundry = %+v, want both imported", target.skipped.Skipped, target.laundry.Laundry)
+	}
+	if _, ok := target.lastPicked.Picks.LastPicked["casual"]; !ok || len(target.plan.Plan.Days) != 3 || !target.challenge.Challenge.StartedAt.Equal(testNow) {
+		t.Error("the category picks, plan or challenge were not imported")
+	}
+}
+
+func TestStateBundleUseCase_CommitPutsBackStateOnFailure(t *testing.T) {
+	source := newTestEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	source.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{{Category: "casual", FileName: "tee.avatar", SelectedAt: testNow}}}
+	var buf bytes.Buffer
+	if _, err := NewStateBundleUseCase(source.services).Export(&buf, "v1.2.3"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	target := newTestEnv(t, nil)
+	roots := target.config.Config.Roots
+	target.challenge.SaveErr = domainerrors.ErrOperationFailed
+	bundles := NewStateBundleUseCase(target.services)
+	proposal, err := bundles.Propose(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := bundles.Commit(proposal); !errors.Is(err, domainerrors.ErrOperationFailed) {
+		t.Fatalf("Commit() error = %v, want the challenge's save error", err)
+	}
+	if got := target.config.Config.Roots; len(got) != 1 || got[0] != roots[0] {
+		t.Errorf("config roots = %v, want %v put back", got, roots)
+	}
+	if len(target.history.History.Records) != 0 {
+		t.Errorf("history = %+v, want it put back empty", target.history.History)
+	}
+}
+
+func TestStateBundleUseCase_RefusesNewerFormat(t *testing.T) {
+	env := newTestEnv(t, nil)
+	path := writeBundle(t, map[string]any{
+		entities.StateBundleManifestName: entities.StateBundleManifest{Format: entities.StateBundleFormat + 1, Version: "v9.0.0"},
+	})
+
+	_, err := NewStateBundleUseCase(env.services).Propose(path)
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) || !strings.Contains(invalid.Message, "v9.0.0") {
+		t.Errorf("Propose() error = %v, want the newer format refused", err)
+	}
+}
+
+func TestStateBundleUseCase_RefusesOtherFiles(t *testing.T) {
+	env := newTestEnv(t, nil)
+	path := filepath.Join(t.TempDir(), "notes.tar.gz")
+	if err := os.WriteFile(path, []byte("not a bundle"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewStateBundleUseCase(env.services).Propose(path)
+	var invalid *domainerrors.InvalidInputError
+	if !errors.As(err, &invalid) {
+		t.Errorf("Propose() error = %v, want an InvalidInputError", err)
+	}
+}
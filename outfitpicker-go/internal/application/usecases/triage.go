@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// NewOutfit is an outfit on disk that the configuration does not know yet.
+type NewOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+}
+
+// TriageResult counts the decisions Apply carried out.
+type TriageResult struct {
+	Accepted int `json:"accepted"`
+	Tagged   int `json:"tagged"`
+	Excluded int `json:"excluded"`
+	Archived int `json:"archived"`
+}
+
+// TriageUseCase sorts newly detected outfits: each is accepted, tagged,
+// excluded from scans or archived.
+type TriageUseCase struct {
+	services Services
+}
+
+// NewTriageUseCase creates a new triage use case.
+func NewTriageUseCase(services Services) *TriageUseCase {
+	return &TriageUseCase{services: services}
+}
+
+// NewOutfits returns the outfits that are not yet known, ordered by category
+// and file name.
+func (u *TriageUseCase) NewOutfits() ([]NewOutfit, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return u.newOutfits(config)
+}
+
+func (u *TriageUseCase) newOutfits(config *entities.Config) ([]NewOutfit, error) {
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	var outfits []NewOutfit
+	for category, files := range snapshot {
+		for _, file := range files {
+			if !config.KnownCategoryFiles[category][file] {
+				outfits = append(outfits, NewOutfit{Category: category, FileName: file})
+			}
+		}
+	}
+	slices.SortFunc(outfits, func(a, b NewOutfit) int {
+		return cmp.Or(strings.Compare(a.Category, b.Category), strings.Compare(a.FileName, b.FileName))
+	})
+	return outfits, nil
+}
+
+// Apply carries out decisions about new outfits. Archived outfits are moved
+// first; the remaining decisions are then saved together, with one
+// configuration save for accepted, tagged and excluded outfits and one
+// metadata save for tags.
+func (u *TriageUseCase) Apply(decisions []entities.TriageDecision) (*TriageResult, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	if len(decisions) == 0 {
+		return &TriageResult{}, nil
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.validate(config, decisions); err != nil {
+		return nil, err
+	}
+
+	result := &TriageResult{}
+	for _, decision := range decisions {
+		if decision.Action != entities.TriageArchive {
+			continue
+		}
+		category, err := u.services.categoryReference(config, decision.Category)
+		if err != nil {
+			return result, err
+		}
+		if _, err := u.services.Archiver.Archive(category.Path, decision.FileName); err != nil {
+			return result, err
+		}
+		result.Archived++
+	}
+
+	err = retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		scan := entities.ScanPolicy{IncludeHidden: config.Scan.IncludeHidden, Ignore: slices.Clone(config.Scan.Ignore)}
+		for _, decision := range decisions {
+			switch decision.Action {
+			case entities.TriageAccept, entities.TriageTag:
+				knowFile(config, decision.Category, decision.FileName)
+			case entities.TriageExclude:
+				if pattern := logic.ExactIgnorePattern(decision.Category, decision.FileName); !slices.Contains(scan.Ignore, pattern) {
+					scan.Ignore = append(scan.Ignore, pattern)
+				}
+			}
+		}
+		if err := config.SetScanPolicy(scan); err != nil {
+			return err
+		}
+		return u.services.Config.Save(config)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if err := u.saveTags(decisions); err != nil {
+		return result, err
+	}
+	for _, decision := range decisions {
+		switch decision.Action {
+		case entities.TriageAccept:
+			result.Accepted++
+		case entities.TriageTag:
+			result.Tagged++
+		case entities.TriageExclude:
+			result.Excluded++
+		}
+	}
+	return result, nil
+}
+
+// validate checks every decision before anything is changed, so a bad
+// decision late in the batch does not leave the earlier ones half applied.
+func (u *TriageUseCase) validate(config *entities.Config, decisions []entities.TriageDecision) error {
+	outfits, err := u.newOutfits(config)
+	if err != nil {
+		return err
+	}
+	decided := make(map[NewOutfit]bool, len(decisions))
+	for _, decision := range decisions {
+		outfit := NewOutfit{Category: decision.Category, FileName: decision.FileName}
+		if !slices.Contains(outfits, outfit) {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s/%s is not a new outfit", decision.Category, decision.FileName))
+		}
+		if decided[outfit] {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s/%s has more than one decision", decision.Category, decision.FileName))
+		}
+		decided[outfit] = true
+
+		switch decision.Action {
+		case entities.TriageAccept, entities.TriageExclude, entities.TriageArchive:
+		case entities.TriageTag:
+			if len(decision.Tags) == 0 {
+				return errors.NewInvalidInputError(fmt.Sprintf("no tags given for %s/%s", decision.Category, decision.FileName))
+			}
+			if err := (entities.OutfitMetadata{Tags: decision.Tags}).Validate(); err != nil {
+				return err
+			}
+		default:
+			return errors.NewInvalidInputError(fmt.Sprintf("unknown triage action %q", decision.Action))
+		}
+	}
+	return nil
+}
+
+// saveTags adds the tags of tag decisions to the outfits' metadata in one
+// save, keeping tags they already have.
+func (u *TriageUseCase) saveTags(decisions []entities.TriageDecision) error {
+	if !slices.ContainsFunc(decisions, func(d entities.TriageDecision) bool { return d.Action == entities.TriageTag }) {
+		return nil
+	}
+	return retryOnConflict(func() error {
+		index, err := u.services.Metadata.Load()
+		if err != nil {
+			return err
+		}
+		for _, decision := range decisions {
+			if decision.Action != entities.TriageTag {
+				continue
+			}
+			metadata, _ := index.Get(decision.Category, decision.FileName)
+			index = index.Setting(decision.Category, decision.FileName, metadata.AddingTags(decision.Tags))
+		}
+		return u.services.Metadata.Save(index)
+	})
+}
+
+// knowFile records an outfit, and its category, as known.
+func knowFile(config *entities.Config, category, fileName string) {
+	if config.KnownCategories == nil {
+		config.KnownCategories = make(map[string]bool)
+	}
+	if config.KnownCategoryFiles == nil {
+		config.KnownCategoryFiles = make(map[string]map[string]bool)
+	}
+	if config.KnownCategoryFiles[category] == nil {
+		config.KnownCategoryFiles[category] = make(map[string]bool)
+	}
+	config.KnownCategories[category] = true
+	config.KnownCategoryFiles[category][fileName] = true
+}
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestTriageUseCase_NewOutfits(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{
+		"casual": {"tee.avatar", "jeans.avatar"},
+		"formal": {"suit.avatar"},
+	})
+	env.config.Config.KnownCategoryFiles = map[string]map[string]bool{"casual": {"tee.avatar": true}}
+
+	outfits, err := NewTriageUseCase(env.services).NewOutfits()
+	if err != nil {
+		t.Fatalf("NewOutfits() error = %v", err)
+	}
+	want := []NewOutfit{{"casual", "jeans.avatar"}, {"formal", "suit.avatar"}}
+	if !slices.Equal(outfits, want) {
+		t.Errorf("NewOutfits() = %v, want %v", outfits, want)
+	}
+}
+
+func TestTriageUseCase_Apply(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar"}})
+	env.metadata.Index = env.metadata.Index.Setting("casual", "b.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+	triage := NewTriageUseCase(env.services)
+
+	result, err := triage.Apply([]entities.TriageDecision{
+		{Category: "casual", FileName: "a.avatar", Action: entities.TriageAccept},
+		{Category: "casual", FileName: "b.avatar", Action: entities.TriageTag, Tags: []string{"summer", "beach"}},
+		{Category: "casual", FileName: "c.avatar", Action: entities.TriageExclude},
+		{Category: "casual", FileName: "d.avatar", Action: entities.TriageArchive},
+	})
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := (TriageResult{Accepted: 1, Tagged: 1, Excluded: 1, Archived: 1}); *result != want {
+		t.Errorf("Apply() = %+v, want %+v", *result, want)
+	}
+	if env.config.Saves != 1 || env.metadata.Saves != 1 {
+		t.Errorf("saves: config = %d, metadata = %d, want 1 each", env.config.Saves, env.metadata.Saves)
+	}
+
+	known := env.config.Config.KnownCategoryFiles["casual"]
+	if !known["a.avatar"] || !known["b.avatar"] || known["c.avatar"] {
+		t.Errorf("known files = %v", known)
+	}
+	if want := []string{"/casual/c.avatar"}; !slices.Equal(env.config.Config.Scan.Ignore, want) {
+		t.Errorf("ignore = %v, want %v", env.config.Config.Scan.Ignore, want)
+	}
+	if metadata, _ := env.metadata.Index.Get("casual", "b.avatar"); !slices.Equal(metadata.Tags, []string{"summer", "beach"}) {
+		t.Errorf("tags = %v", metadata.Tags)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, ".outfitarchive", "casual", "d.avatar")); err != nil {
+		t.Errorf("archived outfit missing: %v", err)
+	}
+
+	outfits, err := triage.NewOutfits()
+	if err != nil || len(outfits) != 0 {
+		t.Errorf("NewOutfits() after triage = %v, %v, want none", outfits, err)
+	}
+}
+
+func TestTriageUseCase_ApplyRejectsBadDecisions(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.config.Config.KnownCategoryFiles = map[string]map[string]bool{"casual": {"b.avatar": true}}
+	tests := []struct {
+		name     string
+		decision entities.TriageDecision
+	}{
+		{"known outfit", entities.TriageDecision{Category: "casual", FileName: "b.avatar", Action: entities.TriageAccept}},
+		{"missing outfit", entities.TriageDecision{Category: "casual", FileName: "z.avatar", Action: entities.TriageAccept}},
+		{"no tags", entities.TriageDecision{Category: "casual", FileName: "a.avatar", Action: entities.TriageTag}},
+		{"invalid tag", entities.TriageDecision{Category: "casual", FileName: "a.avatar", Action: entities.TriageTag, Tags: []string{"Beach"}}},
+		{"unknown action", entities.TriageDecision{Category: "casual", FileName: "a.avatar", Action: "burn"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decisions := []entities.TriageDecision{
+				{Category: "casual", FileName: "a.avatar", Action: entities.TriageArchive},
+				tt.decision,
+			}
+			var invalid *domainerrors.InvalidInputError
+			if _, err := NewTriageUseCase(env.services).Apply(decisions); !errors.As(err, &invalid) {
+				t.Errorf("Apply() error = %v, want InvalidInputError", err)
+			}
+			if _, err := os.Stat(filepath.Join(env.root, "casual", "a.avatar")); err != nil {
+				t.Errorf("outfit moved despite rejected batch: %v", err)
+			}
+		})
+	}
+}
+
+func TestTriageUseCase_MaintenanceMode(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	env.maintenance.State = entities.MaintenanceState{Enabled: true}
+	decisions := []entities.TriageDecision{{Category: "casual", FileName: "a.avatar", Action: entities.TriageAccept}}
+	if _, err := NewTriageUseCase(env.services).Apply(decisions); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Apply() error = %v, want ErrMaintenanceMode", err)
+	}
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// UndoResult reports what UndoLast reverted.
+type UndoResult struct {
+	// Event is the wear that was undone.
+	Event entities.WearEvent `json:"event"`
+	// RestoredRotation is set when the wear had completed its category's
+	// rotation, and the worn outfits of that rotation were restored.
+	RestoredRotation bool `json:"restoredRotation"`
+}
+
+// UndoWearUseCase reverts recorded wears, newest first.
+type UndoWearUseCase struct {
+	services Services
+}
+
+// NewUndoWearUseCase creates a new undo wear use case.
+func NewUndoWearUseCase(services Services) *UndoWearUseCase {
+	return &UndoWearUseCase{services: services}
+}
+
+// UndoLast removes the most recent wear from the wear log and unmarks the
+// outfit as worn, restoring when it was last worn before and dropping the
+// wear from the capsule challenge it counted toward. If that wear completed
+// a rotation, the reset is rolled back so the category holds every outfit
+// worn in that rotation except the undone one. The wear log serves as the
+// journal, so repeated calls undo earlier wears in turn. ErrNothingToUndo
+// is returned when the log is empty.
+func (u *UndoWearUseCase) UndoLast() (*UndoResult, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+
+	// Dropping the event first means two concurrent undos cannot both revert
+	// the same wear: the loser conflicts and moves on to the next event.
+	var result UndoResult
+	var rotation []string
+	var previousWear time.Time
+	err := retryOnConflict(func() error {
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return err
+		}
+		event, ok := log.Last()
+		if !ok {
+			return errors.ErrNothingToUndo
+		}
+		remaining := log.DroppingLast()
+		if err := u.services.WearLog.Save(remaining); err != nil {
+			return err
+		}
+		result = UndoResult{Event: event, RestoredRotation: event.CompletedRotation}
+		rotation = remaining.CurrentRotation(event.Category)
+		previousWear = remaining.LastWorn(event.Category)[event.FileName]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := result.Event
+	err = u.services.Cache.UpdateCategory(event.Category, func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+		current = current.WithLastWorn(event.FileName, previousWear)
+		if !result.RestoredRotation {
+			return current.Removing(event.FileName), nil
+		}
+		restored := current.Reset()
+		for _, fileName := range rotation {
+			restored = restored.Adding(fileName)
+		}
+		return restored, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = retryOnConflict(func() error {
+		challenge, err := u.services.Challenge.Load()
+		if err != nil {
+			return err
+		}
+		if dropped := challenge.DroppingWear(event); len(dropped.Wears) != len(challenge.Wears) {
+			return u.services.Challenge.Save(dropped)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
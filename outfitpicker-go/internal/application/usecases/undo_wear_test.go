@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestUndoWearUseCase_UndoesInOrder(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	wear := NewWearOutfitUseCase(env.services)
+	for _, file := range []string{"a.avatar", "b.avatar", "c.avatar"} {
+		var completed *domainerrors.RotationCompletedError
+		if err := wear.Execute(env.outfit("casual", file)); err != nil && !errors.As(err, &completed) {
+			t.Fatal(err)
+		}
+	}
+	if worn := env.cache.Cache.Categories["casual"].WornOutfits; len(worn) != 0 {
+		t.Fatalf("rotation was not reset: %v", worn)
+	}
+
+	undo := NewUndoWearUseCase(env.services)
+	for _, step := range []struct {
+		file     string
+		restored bool
+		worn     []string
+	}{
+		{"c.avatar", true, []string{"a.avatar", "b.avatar"}},
+		{"b.avatar", false, []string{"a.avatar"}},
+		{"a.avatar", false, nil},
+	} {
+		result, err := undo.UndoLast()
+		if err != nil {
+			t.Fatalf("UndoLast() error = %v", err)
+		}
+		if result.Event.FileName != step.file || result.RestoredRotation != step.restored {
+			t.Errorf("UndoLast() = %+v, want %s restored=%v", result, step.file, step.restored)
+		}
+		worn := slices.Sorted(maps.Keys(env.cache.Cache.Categories["casual"].WornOutfits))
+		if !slices.Equal(worn, step.worn) {
+			t.Errorf("after undoing %s worn = %v, want %v", step.file, worn, step.worn)
+		}
+		if lastWorn := env.cache.Cache.Categories["casual"].LastWorn; len(lastWorn) != len(step.worn) {
+			t.Errorf("after undoing %s last worn = %v, want only the outfits still worn", step.file, lastWorn)
+		}
+	}
+
+	if _, err := undo.UndoLast(); !errors.Is(err, domainerrors.ErrNothingToUndo) {
+		t.Errorf("UndoLast() on empty log error = %v, want ErrNothingToUndo", err)
+	}
+	if len(env.wearLog.Log.Events) != 0 {
+		t.Errorf("wear log = %+v, want empty", env.wearLog.Log.Events)
+	}
+}
+
+func TestUndoWearUseCase_MaintenanceMode(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{{Category: "casual", FileName: "a.avatar"}}}
+	env.maintenance.State = entities.MaintenanceState{Enabled: true}
+
+	if _, err := NewUndoWearUseCase(env.services).UndoLast(); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("UndoLast() error = %v, want ErrMaintenanceMode", err)
+	}
+	if len(env.wearLog.Log.Events) != 1 {
+		t.Error("wear was undone during maintenance")
+	}
+}
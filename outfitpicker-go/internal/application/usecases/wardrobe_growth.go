@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// Wardrobe growth timeline lengths, in months.
+const (
+	DefaultGrowthMonths = 12
+	MaxGrowthMonths     = 120
+)
+
+// WardrobeGrowthUseCase charts how many outfits each category held over
+// time, from when outfits were first seen and when they went.
+type WardrobeGrowthUseCase struct {
+	services Services
+}
+
+// NewWardrobeGrowthUseCase creates a new wardrobe growth use case.
+func NewWardrobeGrowthUseCase(services Services) *WardrobeGrowthUseCase {
+	return &WardrobeGrowthUseCase{services: services}
+}
+
+// Execute counts each category's outfits at the end of each of the last
+// months calendar months, the current one counted up to the end of today,
+// listing the categories in the configured order. Zero months means
+// DefaultGrowthMonths.
+func (u *WardrobeGrowthUseCase) Execute(months int) (entities.WardrobeGrowth, error) {
+	if months == 0 {
+		months = DefaultGrowthMonths
+	}
+	if months < 1 || months > MaxGrowthMonths {
+		return entities.WardrobeGrowth{}, errors.NewInvalidInputError(fmt.Sprintf("months must be between 1 and %d", MaxGrowthMonths))
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return entities.WardrobeGrowth{}, err
+	}
+	infos, err := u.services.categories(config)
+	if err != nil {
+		return entities.WardrobeGrowth{}, err
+	}
+	compare, err := u.services.categoryComparison(config, infos)
+	if err != nil {
+		return entities.WardrobeGrowth{}, err
+	}
+	arrivals, err := u.services.Arrivals.Load()
+	if err != nil {
+		return entities.WardrobeGrowth{}, err
+	}
+
+	now := u.services.now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	points := make([]time.Time, months)
+	for i := range points {
+		end := time.Date(now.Year(), now.Month()-time.Month(months-1-i)+1, 1, 0, 0, 0, 0, now.Location())
+		if end.After(tomorrow) {
+			end = tomorrow
+		}
+		points[i] = end
+	}
+	growth := logic.WardrobeGrowth(arrivals, points)
+	slices.SortStableFunc(growth.Categories, func(a, b entities.CategoryGrowth) int {
+		return compare(a.Category, b.Category)
+	})
+	return growth, nil
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestWardrobeGrowthUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.arrivals.Arrivals = entities.OutfitArrivals{Outfits: map[string]map[string]time.Time{
+		"casual": {"tee.avatar": {}, "jeans.avatar": may(10)},
+	}}
+
+	growth, err := NewWardrobeGrowthUseCase(env.services).Execute(3)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	wantPoints := []time.Time{
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+	}
+	if !slices.EqualFunc(growth.Points, wantPoints, time.Time.Equal) {
+		t.Errorf("Points = %v, want %v", growth.Points, wantPoints)
+	}
+	if len(growth.Categories) != 1 || !slices.Equal(growth.Categories[0].Counts, []int{1, 2, 2}) {
+		t.Errorf("Categories = %+v, want casual growing from 1 to 2", growth.Categories)
+	}
+
+	if growth, err := NewWardrobeGrowthUseCase(env.services).Execute(0); err != nil || len(growth.Points) != DefaultGrowthMonths {
+		t.Errorf("Execute(0) = %d points, %v, want %d", len(growth.Points), err, DefaultGrowthMonths)
+	}
+	if _, err := NewWardrobeGrowthUseCase(env.services).Execute(MaxGrowthMonths + 1); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Execute() with too many months error = %v, want InvalidInputError", err)
+	}
+}
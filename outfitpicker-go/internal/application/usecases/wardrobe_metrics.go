@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// WardrobeMetrics is a point-in-time reading of the wear and rotation
+// statistics, for export to a metrics database.
+type WardrobeMetrics struct {
+	At        time.Time                   `json:"at"`
+	Analytics logic.WearAnalytics         `json:"analytics"`
+	Rotations []entities.RotationProgress `json:"rotations"`
+}
+
+// WardrobeMetricsUseCase reads the wardrobe's metrics. It only reads, so it
+// also works in maintenance mode.
+type WardrobeMetricsUseCase struct {
+	services Services
+}
+
+// NewWardrobeMetricsUseCase creates a new wardrobe metrics use case.
+func NewWardrobeMetricsUseCase(services Services) *WardrobeMetricsUseCase {
+	return &WardrobeMetricsUseCase{services: services}
+}
+
+// Execute reads the wear analytics and the rotation progress of every
+// category with outfits, as of now.
+func (u *WardrobeMetricsUseCase) Execute() (WardrobeMetrics, error) {
+	analytics, err := NewWearAnalyticsUseCase(u.services).Execute()
+	if err != nil {
+		return WardrobeMetrics{}, err
+	}
+	rotations, err := NewGetCategoriesUseCase(u.services).Progress()
+	if err != nil {
+		return WardrobeMetrics{}, err
+	}
+	return WardrobeMetrics{At: u.services.now(), Analytics: analytics, Rotations: rotations}, nil
+}
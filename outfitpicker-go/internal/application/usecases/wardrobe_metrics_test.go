@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestWardrobeMetricsUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("tee.avatar"))
+	env.maintenance.State.Enabled = true
+
+	metrics, err := NewWardrobeMetricsUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !metrics.At.Equal(testNow) {
+		t.Errorf("At = %v, want %v", metrics.At, testNow)
+	}
+	if len(metrics.Analytics.Outfits) != 2 {
+		t.Errorf("Analytics.Outfits = %+v, want both casual outfits", metrics.Analytics.Outfits)
+	}
+	if len(metrics.Rotations) != 1 || metrics.Rotations[0].Category.Name != "casual" || metrics.Rotations[0].WornCount != 1 {
+		t.Errorf("Rotations = %+v, want casual with one outfit worn", metrics.Rotations)
+	}
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// OutfitCountChange is a category whose outfit count in the cache was
+// brought in line with the wardrobe.
+type OutfitCountChange struct {
+	Category string `json:"category"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+}
+
+// WardrobeSyncUseCase keeps the outfit counts in the cache in step with the
+// files in the wardrobe, for watching the wardrobe while it changes.
+type WardrobeSyncUseCase struct {
+	services Services
+}
+
+// NewWardrobeSyncUseCase creates a new wardrobe sync use case.
+func NewWardrobeSyncUseCase(services Services) *WardrobeSyncUseCase {
+	return &WardrobeSyncUseCase{services: services}
+}
+
+// Execute scans the wardrobe and updates the outfit count of every cached
+// category whose files were added or removed, recording the sightings of
+// their outfits. Categories not yet in the cache are left for their first
+// pick. It returns the changed categories by name, and nothing is written
+// when no count changed.
+func (u *WardrobeSyncUseCase) Execute() ([]OutfitCountChange, error) {
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []OutfitCountChange
+	for _, category := range slices.Sorted(maps.Keys(snapshot)) {
+		cached, ok := cache.Categories[category]
+		if ok && cached.TotalOutfits != len(snapshot[category]) {
+			changes = append(changes, OutfitCountChange{Category: category, Before: cached.TotalOutfits, After: len(snapshot[category])})
+		}
+	}
+	for _, change := range changes {
+		err := u.services.Cache.UpdateCategory(change.Category, func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+			if !exists {
+				return entities.NewCategoryCache(change.After), nil
+			}
+			current.TotalOutfits = change.After
+			return current, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	err = retryOnConflict(func() error {
+		arrivals, err := u.services.Arrivals.Load()
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			arrivals, _ = arrivals.Recording(change.Category, snapshot[change.Category], u.services.now())
+		}
+		return u.services.Arrivals.Save(arrivals)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
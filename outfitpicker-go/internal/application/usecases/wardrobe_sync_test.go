@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestWardrobeSyncUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"suit.avatar"}})
+	env.cache.Cache.Categories["casual"] = entities.CategoryCache{WornOutfits: map[string]bool{"tee.avatar": true}, TotalOutfits: 2}
+	useCase := NewWardrobeSyncUseCase(env.services)
+
+	if changes, err := useCase.Execute(); err != nil || len(changes) != 0 {
+		t.Fatalf("Execute() of an unchanged wardrobe = %v, %v, want no changes", changes, err)
+	}
+
+	writeOutfit(t, env.root, "casual", "hoodie.avatar")
+	writeOutfit(t, env.root, "work", "blazer.avatar")
+	changes, err := useCase.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if want := []OutfitCountChange{{Category: "casual", Before: 2, After: 3}}; !slices.Equal(changes, want) {
+		t.Errorf("Execute() = %v, want %v", changes, want)
+	}
+	if got := env.cache.Cache.Categories["casual"]; got.TotalOutfits != 3 || !got.WornOutfits["tee.avatar"] {
+		t.Errorf("casual cache = %+v, want 3 outfits with tee.avatar still worn", got)
+	}
+	if _, ok := env.cache.Cache.Categories["work"]; ok {
+		t.Error("a category not in the cache was added to it")
+	}
+	if _, ok := env.arrivals.Arrivals.Outfits["casual"]["hoodie.avatar"]; !ok {
+		t.Error("the new outfit was not recorded as seen")
+	}
+
+	if err := os.Remove(filepath.Join(env.root, "casual", "jeans.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	env.maintenance.State.Enabled = true
+	if _, err := useCase.Execute(); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Execute() in maintenance mode error = %v, want ErrMaintenanceMode", err)
+	}
+	env.maintenance.State.Enabled = false
+	if changes, err := useCase.Execute(); err != nil || len(changes) != 1 || changes[0].After != 2 {
+		t.Errorf("Execute() after a removal = %v, %v, want casual down to 2", changes, err)
+	}
+}
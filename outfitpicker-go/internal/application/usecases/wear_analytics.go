@@ -0,0 +1,44 @@
+package usecases
+
+import "github.com/dh85/outfitpicker/internal/domain/logic"
+
+// WearAnalyticsUseCase reports statistics on the wear and pick history. It
+// only reads, so it also works in maintenance mode.
+type WearAnalyticsUseCase struct {
+	services Services
+}
+
+// NewWearAnalyticsUseCase creates a new wear analytics use case.
+func NewWearAnalyticsUseCase(services Services) *WearAnalyticsUseCase {
+	return &WearAnalyticsUseCase{services: services}
+}
+
+// Execute analyzes the wears of the outfits in every category that is not
+// excluded.
+func (u *WearAnalyticsUseCase) Execute() (logic.WearAnalytics, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return logic.WearAnalytics{}, err
+	}
+	snapshot, err := u.services.snapshot(config)
+	if err != nil {
+		return logic.WearAnalytics{}, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return logic.WearAnalytics{}, err
+	}
+	history, err := u.services.History.Load()
+	if err != nil {
+		return logic.WearAnalytics{}, err
+	}
+	analytics := logic.AnalyzeWear(log, history, snapshot, u.services.now())
+	if len(config.Selection.LockedRotations) > 0 {
+		cache, err := u.services.Cache.Load()
+		if err != nil {
+			return logic.WearAnalytics{}, err
+		}
+		analytics.LockedRotations = logic.LockedRotations(config.Selection.LockedRotations, cache, snapshot)
+	}
+	return analytics, nil
+}
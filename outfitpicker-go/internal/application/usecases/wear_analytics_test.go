@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestWearAnalyticsUseCase_Execute(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"suit.avatar"}})
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: may(30)},
+		{Category: "casual", FileName: "tee.avatar", WornAt: may(31)},
+	}}
+	env.history.History = entities.SelectionHistory{Records: []entities.SelectionRecord{{SelectedAt: testNow}}}
+	env.maintenance.State.Enabled = true
+
+	analytics, err := NewWearAnalyticsUseCase(env.services).Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(analytics.Outfits) != 3 || analytics.Outfits[0].FileName != "tee.avatar" || analytics.Outfits[0].Wears != 2 {
+		t.Errorf("Outfits = %+v, want tee.avatar first with 2 wears", analytics.Outfits)
+	}
+	if analytics.LeastWornCategory == nil || analytics.LeastWornCategory.Category != "work" || analytics.PickStreakDays != 1 {
+		t.Errorf("Execute() = %+v", analytics)
+	}
+}
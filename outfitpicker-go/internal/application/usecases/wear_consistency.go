@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// WearConsistencyUseCase cross-checks the cache's worn outfits against the
+// wear history and reconciles them.
+type WearConsistencyUseCase struct {
+	services Services
+}
+
+// NewWearConsistencyUseCase creates a new wear consistency use case.
+func NewWearConsistencyUseCase(services Services) *WearConsistencyUseCase {
+	return &WearConsistencyUseCase{services: services}
+}
+
+// Check returns the outfits the cache and the wear history disagree on.
+func (u *WearConsistencyUseCase) Check() ([]entities.WearDiscrepancy, error) {
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return nil, err
+	}
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+	return logic.WearDiscrepancies(cache, log), nil
+}
+
+// Repair makes the cache and the wear history agree, trusting source, and
+// returns the discrepancies it resolved.
+func (u *WearConsistencyUseCase) Repair(source entities.RepairSource) ([]entities.WearDiscrepancy, error) {
+	if source != entities.RepairFromHistory && source != entities.RepairFromCache {
+		return nil, errors.NewInvalidInputError(fmt.Sprintf("unknown repair source %q (want %s or %s)", source, entities.RepairFromHistory, entities.RepairFromCache))
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return nil, err
+	}
+	discrepancies, err := u.Check()
+	if err != nil || len(discrepancies) == 0 {
+		return discrepancies, err
+	}
+
+	if source == entities.RepairFromCache {
+		err = retryOnConflict(func() error {
+			log, err := u.services.WearLog.Load()
+			if err != nil {
+				return err
+			}
+			return u.services.WearLog.Save(logic.HistoryMatchingCache(log, discrepancies, u.services.now()))
+		})
+		return discrepancies, err
+	}
+
+	log, err := u.services.WearLog.Load()
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range discrepancyCategories(discrepancies) {
+		rotation := log.CurrentRotation(category)
+		err := u.services.Cache.UpdateCategory(category, func(current entities.CategoryCache, _ bool) (entities.CategoryCache, error) {
+			restored := current.Reset()
+			for _, fileName := range rotation {
+				restored = restored.Adding(fileName)
+			}
+			restored.TotalOutfits = max(restored.TotalOutfits, len(restored.WornOutfits))
+			return restored, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return discrepancies, nil
+}
+
+// discrepancyCategories returns the categories of ordered discrepancies,
+// each once.
+func discrepancyCategories(discrepancies []entities.WearDiscrepancy) []string {
+	var categories []string
+	for _, discrepancy := range discrepancies {
+		if len(categories) == 0 || categories[len(categories)-1] != discrepancy.Category {
+			categories = append(categories, discrepancy.Category)
+		}
+	}
+	return categories
+}
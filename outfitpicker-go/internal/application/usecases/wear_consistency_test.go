@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func newWearConsistencyEnv(t *testing.T) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	env.cache.Cache = entities.NewOutfitCache().Updating("casual", entities.NewCategoryCache(3).Adding("tee.avatar").Adding("hoodie.avatar"))
+	env.wearLog.Log = entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: testNow},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: testNow},
+	}}
+	return env
+}
+
+func TestWearConsistencyUseCase_Check(t *testing.T) {
+	env := newWearConsistencyEnv(t)
+
+	discrepancies, err := NewWearConsistencyUseCase(env.services).Check()
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(discrepancies) != 2 || discrepancies[0].FileName != "hoodie.avatar" || discrepancies[1].Kind != entities.DiscrepancyHistoryOnly {
+		t.Errorf("Check() = %+v", discrepancies)
+	}
+}
+
+func TestWearConsistencyUseCase_RepairFromHistory(t *testing.T) {
+	env := newWearConsistencyEnv(t)
+
+	repaired, err := NewWearConsistencyUseCase(env.services).Repair(entities.RepairFromHistory)
+	if err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+	worn := env.cache.Cache.Categories["casual"].WornOutfits
+	if len(repaired) != 2 || len(worn) != 2 || !worn["tee.avatar"] || !worn["jeans.avatar"] {
+		t.Errorf("Repair() = %+v, worn = %v; want the cache rebuilt from the history", repaired, worn)
+	}
+	if env.wearLog.Saves != 0 {
+		t.Error("Repair() from the history rewrote the history")
+	}
+	if remaining, _ := NewWearConsistencyUseCase(env.services).Check(); len(remaining) != 0 {
+		t.Errorf("discrepancies after repair = %+v", remaining)
+	}
+}
+
+func TestWearConsistencyUseCase_RepairFromCache(t *testing.T) {
+	env := newWearConsistencyEnv(t)
+
+	if _, err := NewWearConsistencyUseCase(env.services).Repair(entities.RepairFromCache); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+	events := env.wearLog.Log.Events
+	if len(events) != 2 || events[0].FileName != "tee.avatar" || events[1].FileName != "hoodie.avatar" || !events[1].WornAt.Equal(testNow) {
+		t.Errorf("events after repair = %+v", events)
+	}
+	if env.cache.Saves != 0 {
+		t.Error("Repair() from the cache rewrote the cache")
+	}
+}
+
+func TestWearConsistencyUseCase_RepairErrors(t *testing.T) {
+	env := newWearConsistencyEnv(t)
+	useCase := NewWearConsistencyUseCase(env.services)
+
+	var invalid *domainerrors.InvalidInputError
+	if _, err := useCase.Repair("memory"); !errors.As(err, &invalid) {
+		t.Errorf("Repair(memory) error = %v, want InvalidInputError", err)
+	}
+	env.maintenance.State.Enabled = true
+	if _, err := useCase.Repair(entities.RepairFromHistory); !errors.Is(err, domainerrors.ErrMaintenanceMode) {
+		t.Errorf("Repair() in maintenance mode error = %v, want ErrMaintenanceMode", err)
+	}
+}
@@ -0,0 +1,218 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// WearOutfitUseCase records an outfit as worn.
+type WearOutfitUseCase struct {
+	services Services
+}
+
+// NewWearOutfitUseCase creates a new wear outfit use case.
+func NewWearOutfitUseCase(services Services) *WearOutfitUseCase {
+	return &WearOutfitUseCase{services: services}
+}
+
+// Execute marks the outfit as worn. When this completes the category's
+// rotation, the category is reset and a RotationCompletedError is returned,
+// unless the rotation is locked or the profile may not reset it.
+// Wears of capsule outfits also count toward the running capsule challenge,
+// and the outfit needs washing while laundry is tracked.
+func (u *WearOutfitUseCase) Execute(outfit entities.OutfitReference) error {
+	if err := logic.ValidateOutfit(outfit); err != nil {
+		return err
+	}
+	if err := u.services.ensureWritable(); err != nil {
+		return err
+	}
+
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return err
+	}
+
+	categoryName := outfit.Category.Name
+	category, err := u.services.categoryReference(config, categoryName)
+	if err != nil {
+		return err
+	}
+	files, err := u.services.outfitsIn(config, category)
+	if err != nil {
+		return err
+	}
+	if !containsFile(files, outfit.FileName) {
+		return domainerrors.ErrNoOutfitsAvailable
+	}
+	if err := u.recordLaundry(outfit); err != nil {
+		return err
+	}
+
+	cache, err := u.services.Cache.Load()
+	if err != nil {
+		return err
+	}
+	if cache.Categories[categoryName].WornOutfits[outfit.FileName] {
+		return u.recordChallengeWear(entities.WearEvent{Category: categoryName, FileName: outfit.FileName, WornAt: u.services.now()})
+	}
+
+	rotationCompleted := false
+	err = u.services.Cache.UpdateCategory(categoryName, func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+		if !exists {
+			current = entities.NewCategoryCache(len(files))
+		}
+		updated := current.Wearing(outfit.FileName, u.services.now())
+		// A locked rotation stays complete until it is reset by hand, and a
+		// profile that may not reset the category leaves it complete for a
+		// profile that may.
+		rotationCompleted = logic.ShouldResetRotation(len(updated.WornOutfits), len(files)) &&
+			!config.Selection.RotationLocked(categoryName) && config.Permissions.CanReset(categoryName)
+		if rotationCompleted {
+			return updated.Restarted(len(files)), nil
+		}
+		return updated, nil
+	})
+	if err != nil {
+		return err
+	}
+	event, err := u.recordWear(outfit, rotationCompleted)
+	if err != nil {
+		return err
+	}
+	if err := u.recordChallengeWear(event); err != nil {
+		return err
+	}
+	if rotationCompleted {
+		return domainerrors.NewRotationCompletedError(categoryName)
+	}
+	return nil
+}
+
+// WearResult is the outcome of marking one outfit worn.
+type WearResult struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	// RotationCompleted is set when the wear completed the category's
+	// rotation, which then started over.
+	RotationCompleted bool `json:"rotationCompleted,omitempty"`
+}
+
+// ExecuteAll marks outfits chosen outside the tool as worn, in order. Every
+// outfit is checked to be in the wardrobe first, so a mistyped entry fails
+// with an InvalidInputError before anything is recorded.
+func (u *WearOutfitUseCase) ExecuteAll(outfits []entities.OutfitReference) ([]WearResult, error) {
+	if err := u.checkExist(outfits); err != nil {
+		return nil, err
+	}
+	results := make([]WearResult, 0, len(outfits))
+	for _, outfit := range outfits {
+		result := WearResult{Category: outfit.Category.Name, FileName: outfit.FileName}
+		err := u.Execute(outfit)
+		var completed *domainerrors.RotationCompletedError
+		if errors.As(err, &completed) {
+			result.RotationCompleted, err = true, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// checkExist fails with an InvalidInputError naming the first of outfits
+// that is not in its category.
+func (u *WearOutfitUseCase) checkExist(outfits []entities.OutfitReference) error {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return err
+	}
+	filesOf := make(map[string][]entities.FileEntry)
+	for _, outfit := range outfits {
+		if err := logic.ValidateOutfit(outfit); err != nil {
+			return err
+		}
+		name := outfit.Category.Name
+		files, ok := filesOf[name]
+		if !ok {
+			category, err := u.services.categoryReference(config, name)
+			if err != nil {
+				return err
+			}
+			if files, err = u.services.outfitsIn(config, category); err != nil {
+				return err
+			}
+			filesOf[name] = files
+		}
+		if !containsFile(files, outfit.FileName) {
+			return domainerrors.NewInvalidInputError(fmt.Sprintf("'%s' has no outfit %q", name, outfit.FileName))
+		}
+	}
+	return nil
+}
+
+// recordWear appends a wear event so feedback can be attached to it later,
+// and returns it.
+func (u *WearOutfitUseCase) recordWear(outfit entities.OutfitReference, rotationCompleted bool) (entities.WearEvent, error) {
+	id, err := u.services.outfitID(outfit.Category.Name, outfit.FileName)
+	if err != nil {
+		return entities.WearEvent{}, err
+	}
+	event := entities.WearEvent{
+		Category:          outfit.Category.Name,
+		FileName:          outfit.FileName,
+		WornAt:            u.services.now(),
+		CompletedRotation: rotationCompleted,
+		OutfitID:          id,
+	}
+	err = retryOnConflict(func() error {
+		log, err := u.services.WearLog.Load()
+		if err != nil {
+			return err
+		}
+		return u.services.WearLog.Save(log.Appending(event))
+	})
+	return event, err
+}
+
+// recordLaundry marks the outfit worn, and so not clean, while laundry is
+// tracked.
+func (u *WearOutfitUseCase) recordLaundry(outfit entities.OutfitReference) error {
+	return retryOnConflict(func() error {
+		laundry, err := u.services.Laundry.Load()
+		if err != nil || !laundry.Enabled {
+			return err
+		}
+		return u.services.Laundry.Save(laundry.Wearing(outfit.Category.Name, outfit.FileName))
+	})
+}
+
+// recordChallengeWear counts event toward the running capsule challenge
+// when it wears one of the capsule's outfits, even one already worn in its
+// rotation.
+func (u *WearOutfitUseCase) recordChallengeWear(event entities.WearEvent) error {
+	return retryOnConflict(func() error {
+		challenge, err := u.services.Challenge.Load()
+		if err != nil {
+			return err
+		}
+		if !challenge.ActiveAt(event.WornAt) || !challenge.Contains(event.Category, event.FileName) {
+			return nil
+		}
+		return u.services.Challenge.Save(challenge.Recording(event))
+	})
+}
+
+func containsFile(files []entities.FileEntry, fileName string) bool {
+	for _, file := range files {
+		if file.FileName == fileName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestWearOutfitUseCase_MarksWorn(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "a.avatar")); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !env.cache.Cache.Categories["casual"].WornOutfits["a.avatar"] {
+		t.Error("a.avatar not marked worn")
+	}
+}
+
+func TestWearOutfitUseCase_AlreadyWornIsNoOp(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar"))
+
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "a.avatar")); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if env.cache.Saves != 0 {
+		t.Errorf("cache saves = %v, want 0", env.cache.Saves)
+	}
+}
+
+func TestWearOutfitUseCase_CompletesRotation(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar"))
+
+	err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "b.avatar"))
+
+	var completed *domainerrors.RotationCompletedError
+	if !errors.As(err, &completed) || completed.Category != "casual" {
+		t.Fatalf("Execute() error = %v, want RotationCompletedError for casual", err)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 0 {
+		t.Errorf("worn outfits after completion = %v, want 0", worn)
+	}
+	if lastWorn := env.cache.Cache.Categories["casual"].LastWorn; !lastWorn["b.avatar"].Equal(testNow) {
+		t.Errorf("last worn after completion = %v, want b.avatar kept at %v", lastWorn, testNow)
+	}
+	if events := env.wearLog.Log.Events; len(events) != 1 || !events[0].CompletedRotation {
+		t.Errorf("wear log = %+v, want one event marked as completing the rotation", events)
+	}
+}
+
+func TestWearOutfitUseCase_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		outfit  func(*testEnv) entities.OutfitReference
+		setup   func(*testEnv)
+		wantErr error
+	}{
+		{"unknown file", func(e *testEnv) entities.OutfitReference {
+			return e.outfit("casual", "missing.avatar")
+		}, nil, domainerrors.ErrNoOutfitsAvailable},
+		{"unknown category", func(e *testEnv) entities.OutfitReference {
+			return e.outfit("formal", "suit.avatar")
+		}, nil, domainerrors.ErrCategoryNotFound},
+		{"maintenance mode", func(e *testEnv) entities.OutfitReference {
+			return e.outfit("casual", "a.avatar")
+		}, func(e *testEnv) {
+			e.maintenance.State = entities.NewMaintenanceState(nil, testNow)
+		}, domainerrors.ErrMaintenanceMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t, map[string][]string{"casual": {"a.avatar"}})
+			if tt.setup != nil {
+				tt.setup(env)
+			}
+			err := NewWearOutfitUseCase(env.services).Execute(tt.outfit(env))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if env.cache.Saves != 0 {
+				t.Errorf("cache saves = %v, want 0", env.cache.Saves)
+			}
+		})
+	}
+}
+
+func TestWearOutfitUseCase_CompletesRotationWithoutReset(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+	env.config.Config.Permissions = entities.CategoryPermissions{Reset: []string{"work"}}
+	env.cache.Cache = env.cache.Cache.Updating("casual", entities.NewCategoryCache(2).Adding("a.avatar"))
+
+	if err := NewWearOutfitUseCase(env.services).Execute(env.outfit("casual", "b.avatar")); err != nil {
+		t.Fatalf("Execute() error = %v, want the rotation left complete", err)
+	}
+	if worn := len(env.cache.Cache.Categories["casual"].WornOutfits); worn != 2 {
+		t.Errorf("worn outfits = %d, want 2 until a profile that may reset picks", worn)
+	}
+}
+
+func TestWearOutfitUseCase_ExecuteAll(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "formal": {"suit.avatar", "tux.avatar"}})
+
+	results, err := NewWearOutfitUseCase(env.services).ExecuteAll([]entities.OutfitReference{
+		env.outfit("casual", "a.avatar"),
+		env.outfit("formal", "suit.avatar"),
+		env.outfit("casual", "b.avatar"),
+	})
+	if err != nil {
+		t.Fatalf("ExecuteAll() error = %v", err)
+	}
+	want := []WearResult{
+		{Category: "casual", FileName: "a.avatar"},
+		{Category: "formal", FileName: "suit.avatar"},
+		{Category: "casual", FileName: "b.avatar", RotationCompleted: true},
+	}
+	if !slices.Equal(results, want) {
+		t.Errorf("ExecuteAll() = %+v, want %+v", results, want)
+	}
+	if !env.cache.Cache.Categories["formal"].WornOutfits["suit.avatar"] || len(env.wearLog.Log.Events) != 3 {
+		t.Errorf("wears not recorded: cache = %+v, log = %+v", env.cache.Cache.Categories, env.wearLog.Log.Events)
+	}
+}
+
+func TestWearOutfitUseCase_ExecuteAll_MissingOutfitRecordsNothing(t *testing.T) {
+	env := newTestEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+
+	_, err := NewWearOutfitUseCase(env.services).ExecuteAll([]entities.OutfitReference{
+		env.outfit("casual", "a.avatar"),
+		env.outfit("casual", "gone.avatar"),
+	})
+	if !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Fatalf("ExecuteAll() error = %v, want InvalidInputError", err)
+	}
+	if env.cache.Saves != 0 || len(env.wearLog.Log.Events) != 0 {
+		t.Errorf("wears recorded before the missing outfit was found: saves = %d, log = %+v", env.cache.Saves, env.wearLog.Log.Events)
+	}
+}
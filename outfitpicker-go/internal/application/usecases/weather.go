@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// forecastMaxAge is how long a fetched forecast is used before it is fetched
+// again.
+const forecastMaxAge = 3 * time.Hour
+
+// WeatherReport is today's forecast at the configured location with the
+// weather conditions it calls for.
+type WeatherReport struct {
+	Forecast   entities.Forecast `json:"forecast"`
+	Conditions []string          `json:"conditions"`
+}
+
+// WeatherUseCase looks up the forecast and changes the weather settings.
+type WeatherUseCase struct {
+	services Services
+}
+
+// NewWeatherUseCase creates a new weather use case.
+func NewWeatherUseCase(services Services) *WeatherUseCase {
+	return &WeatherUseCase{services: services}
+}
+
+// Today returns today's forecast at the configured location, fetching it
+// only when the cached one is missing or stale.
+func (u *WeatherUseCase) Today() (WeatherReport, error) {
+	config, err := u.services.Config.Load()
+	if err != nil {
+		return WeatherReport{}, err
+	}
+	forecast, err := u.services.forecast(config)
+	if err != nil {
+		return WeatherReport{}, err
+	}
+	return WeatherReport{Forecast: forecast, Conditions: logic.ForecastConditions(forecast)}, nil
+}
+
+// Update replaces the settings with change(current) and saves the
+// configuration, reapplying change if another writer saved first. It returns
+// the saved settings.
+func (u *WeatherUseCase) Update(change func(current entities.WeatherSettings) entities.WeatherSettings) (entities.WeatherSettings, error) {
+	var saved entities.WeatherSettings
+	err := retryOnConflict(func() error {
+		config, err := u.services.Config.Load()
+		if err != nil {
+			return err
+		}
+		if err := config.SetWeather(change(config.Weather)); err != nil {
+			return err
+		}
+		if err := u.services.Config.Save(config); err != nil {
+			return err
+		}
+		saved = config.Weather
+		return nil
+	})
+	return saved, err
+}
+
+// forecast returns today's forecast at the configured location. A cached
+// forecast for the same day and place is used while it is younger than
+// forecastMaxAge; otherwise it is fetched and cached.
+func (s Services) forecast(config *entities.Config) (entities.Forecast, error) {
+	location := config.Weather.Location
+	if location == nil {
+		return entities.Forecast{}, errors.NewInvalidInputError("no weather location is set; set one with: weather location LAT,LON")
+	}
+	now := s.now()
+	cache, err := s.Forecasts.Load()
+	if err != nil {
+		return entities.Forecast{}, err
+	}
+	if cached := cache.Forecast; cached != nil && cached.Location == *location &&
+		cached.Date.Format("2006-01-02") == now.Format("2006-01-02") && now.Sub(cached.FetchedAt) < forecastMaxAge {
+		return *cached, nil
+	}
+
+	forecast, err := s.Weather.Forecast(s.ctx(), *location, now)
+	if err != nil {
+		return entities.Forecast{}, err
+	}
+	forecast.FetchedAt = now
+	err = retryOnConflict(func() error {
+		cache, err := s.Forecasts.Load()
+		if err != nil {
+			return err
+		}
+		cache.Forecast = &forecast
+		return s.Forecasts.Save(cache)
+	})
+	return forecast, err
+}
+
+// weatherConditions returns the weather conditions today's forecast calls
+// for.
+func (s Services) weatherConditions(config *entities.Config) ([]string, error) {
+	forecast, err := s.forecast(config)
+	if err != nil {
+		return nil, err
+	}
+	return logic.ForecastConditions(forecast), nil
+}
@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var testLocation = entities.WeatherLocation{Latitude: 51.5, Longitude: -0.12}
+
+func TestWeatherUseCase_TodayCachesTheForecast(t *testing.T) {
+	env := newTestEnv(t, nil)
+	env.config.Config.Weather.Location = &testLocation
+	env.weather.Result = entities.Forecast{MaxTemperature: 30, PrecipitationChance: 80}
+	weather := NewWeatherUseCase(env.services)
+
+	report, err := weather.Today()
+	if err != nil {
+		t.Fatalf("Today() error = %v", err)
+	}
+	if !slices.Equal(report.Conditions, []string{entities.WeatherRain, entities.WeatherHeat}) {
+		t.Errorf("Conditions = %v, want rain and heat", report.Conditions)
+	}
+	if _, err := weather.Today(); err != nil || env.weather.Calls != 1 {
+		t.Errorf("Today() again = %v with %d fetches, want the cached forecast", err, env.weather.Calls)
+	}
+
+	env.services.Now = func() time.Time { return testNow.Add(forecastMaxAge) }
+	weather = NewWeatherUseCase(env.services)
+	if _, err := weather.Today(); err != nil || env.weather.Calls != 2 {
+		t.Errorf("Today() with a stale forecast = %v with %d fetches, want it fetched again", err, env.weather.Calls)
+	}
+	moved := entities.WeatherLocation{Latitude: 48.85, Longitude: 2.35}
+	env.config.Config.Weather.Location = &moved
+	if report, err := weather.Today(); err != nil || env.weather.Calls != 3 || report.Forecast.Location != moved {
+		t.Errorf("Today() after moving = %+v, %v with %d fetches, want the new location fetched", report, err, env.weather.Calls)
+	}
+}
+
+func TestWeatherUseCase_TodayErrors(t *testing.T) {
+	env := newTestEnv(t, nil)
+	if _, err := NewWeatherUseCase(env.services).Today(); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+		t.Errorf("Today() without a location error = %v, want InvalidInputError", err)
+	}
+
+	env.config.Config.Weather.Location = &testLocation
+	env.weather.Err = errors.New("offline")
+	if _, err := NewWeatherUseCase(env.services).Today(); !errors.Is(err, env.weather.Err) {
+		t.Errorf("Today() offline error = %v, want the provider's error", err)
+	}
+	if env.forecasts.Saves != 0 {
+		t.Errorf("forecast saves = %d, want nothing cached", env.forecasts.Saves)
+	}
+}
+
+func TestWeatherUseCase_Update(t *testing.T) {
+	env := newTestEnv(t, nil)
+	weather := NewWeatherUseCase(env.services)
+
+	saved, err := weather.Update(func(current entities.WeatherSettings) entities.WeatherSettings {
+		current.Location = &testLocation
+		return current.SettingCategory("beach", []string{entities.WeatherRain})
+	})
+	if err != nil || *env.config.Config.Weather.Location != testLocation || !slices.Equal(saved.Categories["beach"], []string{"rain"}) {
+		t.Fatalf("Update() = %+v, %v", saved, err)
+	}
+	_, err = weather.Update(func(current entities.WeatherSettings) entities.WeatherSettings {
+		return current.SettingTag("wool", []string{"snow"})
+	})
+	if !errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		t.Errorf("Update(unknown condition) error = %v, want ErrInvalidConfiguration", err)
+	}
+}
@@ -0,0 +1,20 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCurrent(t *testing.T) {
+	info := Current()
+
+	if info.Version != Version {
+		t.Errorf("Version = %v, want %v", info.Version, Version)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("GoVersion = %v, want %v", info.GoVersion, runtime.Version())
+	}
+	if info.OS != runtime.GOOS || info.Arch != runtime.GOARCH {
+		t.Errorf("platform = %v/%v, want %v/%v", info.OS, info.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+}
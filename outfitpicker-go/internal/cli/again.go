@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func againCommand() *Command {
+	return &Command{
+		Name:    "again",
+		Summary: "Repeat the last pick command with the same flags",
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("again")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return usageErrorf("again takes no arguments, got %q", fs.Arg(0))
+			}
+
+			record, err := app.commandHistory().Last("pick")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(app.stderr, "outfitpicker %s\n", presentation.CommandLine(record.Args))
+			app.replay = record.Args
+			return nil
+		},
+	}
+}
+
+// commandHistory returns the history of commands run. It is shared by
+// every profile, since a command names its profile in its arguments.
+func (a *App) commandHistory() *usecases.CommandHistoryUseCase {
+	return usecases.NewCommandHistoryUseCase(a.servicesFor(entities.DefaultProfile))
+}
+
+// recordCommand adds a run of the named command to the command history.
+// The signer is looked up again since the command may have turned
+// integrity checks on or off. Failing to record is not worth failing the
+// command over, so errors are ignored.
+func (a *App) recordCommand(name string, args []string, code int) {
+	signer, err := a.integrityStore().Signer()
+	if err != nil {
+		return
+	}
+	a.signer = signer
+	a.commandHistory().Record(name, args, code)
+}
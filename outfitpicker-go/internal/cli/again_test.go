@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAgain(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"shirt.avatar"}})
+	if _, stderr, code := env.run("again"); code != ExitInvalidInput || !strings.Contains(stderr, "no pick command to repeat yet") {
+		t.Fatalf("again before any pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	if _, stderr, code := env.run("--json", "pick", "work"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("list"); code != ExitOK {
+		t.Fatalf("list: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("again")
+	if code != ExitOK {
+		t.Fatalf("again: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stderr, "outfitpicker --json pick work") {
+		t.Errorf("again stderr = %q, want the repeated command", stderr)
+	}
+	var picked struct {
+		Category struct {
+			Name string `json:"name"`
+		} `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &picked); err != nil || picked.Category.Name != "work" {
+		t.Errorf("again stdout = %q, want the JSON pick of work", stdout)
+	}
+
+	stdout, stderr, code = env.run("history", "commands", "--limit", "3")
+	if code != ExitOK {
+		t.Fatalf("history commands: code = %v, stderr = %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "--json pick work") || !strings.HasSuffix(lines[1], "list") {
+		t.Errorf("history commands = %q, want the replayed pick, list and the first pick", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "history", "commands")
+	var records []struct {
+		Command  string   `json:"command"`
+		Args     []string `json:"args"`
+		ExitCode int      `json:"exitCode"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &records); err != nil || code != ExitOK {
+		t.Fatalf("history commands --json: code = %v, %v\n%s", code, err, stdout)
+	}
+	if len(records) != 4 || records[0].Command != "history" {
+		t.Errorf("history commands --json = %+v, want the four commands before it, again left out", records)
+	}
+}
+
+func TestAgain_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := env.run("again", "casual"); code != ExitUsage {
+		t.Errorf("again casual: code = %v, want %v (stderr %q)", code, ExitUsage, stderr)
+	}
+}
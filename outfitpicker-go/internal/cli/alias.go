@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func aliasCommand() *Command {
+	return &Command{
+		Name:    "alias",
+		Summary: "Give a category localized labels or aliases to match when typed",
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("alias")
+			var labels, aliases stringList
+			fs.Var(&labels, "label", "localized label as LANG=TEXT (repeatable or comma-separated)")
+			fs.Var(&aliases, "alias", "alternative name (repeatable or comma-separated)")
+			clearNames := fs.Bool("clear", false, "remove the category's labels and aliases")
+			positional, err := parseArgs(fs, args)
+			if err != nil {
+				return err
+			}
+			if len(positional) != 1 {
+				return usageErrorf("usage: alias <category> [--label LANG=TEXT] [--alias NAME] [--clear]")
+			}
+			category := positional[0]
+
+			parsedLabels := make(map[string]string, len(labels))
+			for _, label := range labels {
+				language, text, ok := strings.Cut(label, "=")
+				if !ok {
+					return usageErrorf("label %q must be written as LANG=TEXT", label)
+				}
+				parsedLabels[strings.TrimSpace(language)] = strings.TrimSpace(text)
+			}
+
+			config, err := usecases.NewNameCategoryUseCase(app.services()).Execute(category, func(current entities.CategoryNames) entities.CategoryNames {
+				if *clearNames {
+					return entities.CategoryNames{}
+				}
+				updated := entities.CategoryNames{Labels: maps.Clone(current.Labels), Aliases: slices.Clone(current.Aliases)}
+				if len(parsedLabels) > 0 && updated.Labels == nil {
+					updated.Labels = make(map[string]string, len(parsedLabels))
+				}
+				maps.Copy(updated.Labels, parsedLabels)
+				for _, alias := range aliases {
+					if !slices.Contains(updated.Aliases, alias) {
+						updated.Aliases = append(updated.Aliases, alias)
+					}
+				}
+				return updated
+			})
+			if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+				return fmt.Errorf("%w: labels need a supported language code and every name must be 1-64 characters", err)
+			}
+			if err != nil {
+				return err
+			}
+
+			names := config.Names(category)
+			if names.IsEmpty() {
+				fmt.Fprintf(app.stdout, "%s has no labels or aliases.\n", category)
+				return nil
+			}
+			var alternatives []string
+			for _, language := range slices.Sorted(maps.Keys(names.Labels)) {
+				alternatives = append(alternatives, fmt.Sprintf("%s (%s)", names.Labels[language], language))
+			}
+			alternatives = append(alternatives, names.Aliases...)
+			fmt.Fprintf(app.stdout, "%s also answers to: %s\n", category, strings.Join(alternatives, ", "))
+			return nil
+		},
+	}
+}
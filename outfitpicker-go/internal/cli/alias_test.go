@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAlias_LocalizedLabelResolvesCategory(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.run("alias", "casual", "--label", "fr=Décontracté", "--alias", "chill")
+	if code != ExitOK {
+		t.Fatalf("alias: code = %v, stderr = %q", code, stderr)
+	}
+	if want := "casual also answers to: Décontracté (fr), chill\n"; stdout != want {
+		t.Errorf("alias output = %q, want %q", stdout, want)
+	}
+
+	stdout, stderr, code = env.run("metadata", "set", "decontracte", "tee.avatar", "--care", "wash-30")
+	if code != ExitOK {
+		t.Fatalf("metadata set via label: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "CHILL", "tee.avatar"); !strings.Contains(stdout, "wash-30") {
+		t.Errorf("metadata show via alias = %q", stdout)
+	}
+
+	if stdout, _, _ := env.run("alias", "casual", "--clear"); stdout != "casual has no labels or aliases.\n" {
+		t.Errorf("alias --clear output = %q", stdout)
+	}
+	if _, _, code := env.run("metadata", "show", "chill", "tee.avatar"); code != ExitCategoryNotFound {
+		t.Errorf("metadata show via cleared alias: code = %v, want ExitCategoryNotFound", code)
+	}
+}
+
+func TestAlias_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing category", []string{"alias"}, ExitUsage},
+		{"malformed label", []string{"alias", "casual", "--label", "décontracté"}, ExitUsage},
+		{"unsupported language", []string{"alias", "casual", "--label", "xx=casual"}, ExitInvalidConfiguration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
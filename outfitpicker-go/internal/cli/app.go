@@ -0,0 +1,682 @@
+// Package cli implements the outfitpicker command-line interface.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/interfaces"
+	"github.com/dh85/outfitpicker/internal/infrastructure/logging"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/internal/infrastructure/telegram"
+	"github.com/dh85/outfitpicker/internal/infrastructure/weather"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// Exit codes returned by App.Run. Domain errors exit with their stable
+// domainerrors.Code; ExitError covers every other error.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+
+	ExitConfigurationNotFound = int(domainerrors.CodeConfigurationNotFound)
+	ExitInvalidConfiguration  = int(domainerrors.CodeInvalidConfiguration)
+	ExitNoOutfits             = int(domainerrors.CodeNoOutfitsAvailable)
+	ExitCategoryNotFound      = int(domainerrors.CodeCategoryNotFound)
+	ExitEmptyCategories       = int(domainerrors.CodeEmptyCategories)
+	ExitInvalidInput          = int(domainerrors.CodeInvalidInput)
+	ExitMaintenanceMode       = int(domainerrors.CodeMaintenanceMode)
+	ExitConflict              = int(domainerrors.CodeConflict)
+	ExitNothingToUndo         = int(domainerrors.CodeNothingToUndo)
+	ExitRateLimited           = int(domainerrors.CodeRateLimited)
+	ExitFileSystem            = int(domainerrors.CodeFileSystem)
+	ExitCache                 = int(domainerrors.CodeCache)
+	ExitIntegrity             = int(domainerrors.CodeIntegrity)
+)
+
+// Command is a top-level CLI command.
+type Command struct {
+	Name    string
+	Summary string
+	// Hidden commands are omitted from help output.
+	Hidden bool
+	// DryRun marks commands that honor the global --dry-run flag; others
+	// refuse it.
+	DryRun bool
+	// JSON marks commands with JSON output, which honor the global --json
+	// and --fields flags; others refuse them.
+	JSON bool
+	Run  func(app *App, args []string) error
+}
+
+// App dispatches command-line arguments to commands.
+type App struct {
+	stdin             io.Reader
+	stdout            io.Writer
+	stderr            io.Writer
+	interactive       *bool
+	directoryProvider system.DirectoryProvider
+	keychain          system.Keychain
+	weather           interfaces.WeatherProvider
+	chat              interfaces.ChatService
+	scheduler         interfaces.Scheduler
+	trash             interfaces.Trash
+	ctx               context.Context
+	commands          map[string]*Command
+
+	// jsonOutput is set by the global --json flag, or by --fields.
+	jsonOutput bool
+	// fields are the fields JSON output is projected onto, set by the
+	// global --fields flag; nil keeps every field.
+	fields presentation.FieldSelection
+	// progressFormat is set by the global --progress flag.
+	progressFormat string
+	// profile is the profile in use, set by the global --profile flag or
+	// else the active profile.
+	profile string
+	// demo is set by the global --demo flag.
+	demo bool
+	// stateless is set by the global --stateless flag, or else the
+	// OUTFITPICKER_STATELESS environment variable.
+	stateless bool
+	// configPath and stateDir are set by the global --config and
+	// --state-dir flags, or else the OUTFITPICKER_CONFIG and
+	// OUTFITPICKER_STATE_DIR environment variables.
+	configPath, stateDir string
+	// dryRun is set by the global --dry-run flag.
+	dryRun bool
+	// verbose, debug and logFile are set by the global --verbose, --debug
+	// and --log-file flags.
+	verbose, debug, logFile bool
+	// logger receives the log of the running command; nil until Run opens
+	// it.
+	logger *slog.Logger
+	// signer signs state files when integrity checks are on, else nil.
+	signer *system.Signer
+	// locale holds the command and flag aliases of the configured language.
+	locale locale
+	// replay is set by again to the arguments Run goes on to run.
+	replay []string
+}
+
+// progressNDJSON is the --progress format that writes one JSON event per
+// line to stderr.
+const progressNDJSON = "ndjson"
+
+// Option configures an App.
+type Option func(*App)
+
+// WithOutput sets the writers used for normal and diagnostic output.
+func WithOutput(stdout, stderr io.Writer) Option {
+	return func(a *App) {
+		a.stdout = stdout
+		a.stderr = stderr
+	}
+}
+
+// WithInput sets the reader used for interactive prompts.
+func WithInput(stdin io.Reader) Option {
+	return func(a *App) {
+		a.stdin = stdin
+	}
+}
+
+// WithInteractive overrides terminal detection for interactive prompts.
+func WithInteractive(interactive bool) Option {
+	return func(a *App) {
+		a.interactive = &interactive
+	}
+}
+
+// WithDirectoryProvider sets where configuration and state files are stored.
+func WithDirectoryProvider(dp system.DirectoryProvider) Option {
+	return func(a *App) {
+		a.directoryProvider = dp
+	}
+}
+
+// WithKeychain sets where the key that signs state files is kept.
+func WithKeychain(keychain system.Keychain) Option {
+	return func(a *App) {
+		a.keychain = keychain
+	}
+}
+
+// WithWeatherProvider sets where pick --weather looks up forecasts.
+func WithWeatherProvider(provider interfaces.WeatherProvider) Option {
+	return func(a *App) {
+		a.weather = provider
+	}
+}
+
+// WithChatService sets how watch --telegram talks to the Telegram chat.
+func WithChatService(chat interfaces.ChatService) Option {
+	return func(a *App) {
+		a.chat = chat
+	}
+}
+
+// WithScheduler sets where schedule installs the daily pick.
+func WithScheduler(scheduler interfaces.Scheduler) Option {
+	return func(a *App) {
+		a.scheduler = scheduler
+	}
+}
+
+// WithTrash sets where category remove moves files sent to the trash.
+func WithTrash(trash interfaces.Trash) Option {
+	return func(a *App) {
+		a.trash = trash
+	}
+}
+
+// WithContext sets a context whose cancellation stops long-running commands
+// such as watch, which otherwise run until interrupted.
+func WithContext(ctx context.Context) Option {
+	return func(a *App) {
+		a.ctx = ctx
+	}
+}
+
+// New creates an App with every built-in command registered.
+func New(opts ...Option) *App {
+	app := &App{
+		stdin:             os.Stdin,
+		stdout:            os.Stdout,
+		stderr:            os.Stderr,
+		directoryProvider: system.NewDefaultDirectoryProvider(),
+		keychain:          system.NewOSKeychain(),
+		weather:           weather.NewOpenMeteoProvider(),
+		chat:              telegram.NewBotAPI(),
+		scheduler:         system.NewOSScheduler(),
+		trash:             system.NewOSTrash(),
+		ctx:               context.Background(),
+		commands:          make(map[string]*Command),
+	}
+
+	for _, opt := range opts {
+		opt(app)
+	}
+
+	app.register(aliasCommand())
+	app.register(againCommand())
+	app.register(backupCommand())
+	app.register(botCommand())
+	app.register(categoryCommand())
+	app.register(challengeCommand())
+	app.register(planCommand())
+	app.register(completionCommand())
+	app.register(completeCommand())
+	app.register(devtoolsCommand())
+	app.register(doctorCommand())
+	app.register(ensembleCommand())
+	app.register(excludeCommand())
+	app.register(exportCommand())
+	app.register(favoriteCommand())
+	app.register(feedbackCommand())
+	app.register(historyCommand())
+	app.register(importCommand())
+	app.register(includeCommand())
+	app.register(initCommand())
+	app.register(integrityCommand())
+	app.register(interactiveCommand())
+	app.register(laundryCommand())
+	app.register(listCommand())
+	app.register(markWornCommand())
+	app.register(occasionCommand())
+	app.register(orderCommand())
+	app.register(pickCommand())
+	app.register(profileCommand())
+	app.register(rateCommand())
+	app.register(repairCommand())
+	app.register(reportCommand())
+	app.register(rotationCommand())
+	app.register(rouletteCommand())
+	app.register(decorateCommand())
+	app.register(demoCommand())
+	app.register(scheduleCommand())
+	app.register(seasonCommand())
+	app.register(seenCommand())
+	app.register(setupCommand())
+	app.register(skipCommand())
+	app.register(snapshotCommand())
+	app.register(statsCommand())
+	app.register(tagCommand())
+	app.register(triageCommand())
+	app.register(undoCommand())
+	app.register(unskipCommand())
+	app.register(watchCommand())
+	app.register(weatherCommand())
+	app.register(weightCommand())
+	app.register(idsCommand())
+	app.register(maintenanceCommand())
+	app.register(metadataCommand())
+	app.register(debugCommand())
+	app.register(versionCommand())
+
+	return app
+}
+
+func (a *App) register(cmd *Command) {
+	a.commands[cmd.Name] = cmd
+}
+
+// Run executes the command named by args[0] and returns the process exit code.
+func (a *App) Run(args []string) int {
+	original := args
+	args, err := a.parseGlobalFlags(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	if err != nil {
+		fmt.Fprintf(a.stderr, "error: %v\n\n", err)
+		a.printUsage()
+		return ExitUsage
+	}
+	switch {
+	case a.demo:
+		if err := a.startDemo(); err != nil {
+			return a.fail(err)
+		}
+	case a.stateless:
+		if err := a.startStateless(); err != nil {
+			return a.fail(err)
+		}
+	default:
+		if err := a.resolveConfiguration(); err != nil {
+			return a.fail(err)
+		}
+	}
+	if a.logger == nil {
+		closeLog, err := a.openLog()
+		if err != nil {
+			return a.fail(err)
+		}
+		defer closeLog()
+	}
+	if err := a.resolveProfile(); err != nil {
+		return a.fail(err)
+	}
+	if a.signer, err = a.integrityStore().Signer(); err != nil {
+		return a.fail(err)
+	}
+	a.locale = a.configuredLocale()
+	if len(args) == 0 || args[0] == "help" {
+		a.printUsage()
+		return ExitOK
+	}
+
+	cmd, ok := a.commands[args[0]]
+	if english, localized := a.locale.command(args[0]); !ok && localized {
+		cmd, ok = a.commands[english]
+	}
+	if !ok {
+		fmt.Fprintf(a.stderr, "unknown command %q\n\n", args[0])
+		a.printUsage()
+		return ExitUsage
+	}
+
+	if !cmd.DryRun {
+		if err := refuseDryRun(a, cmd.Name); err != nil {
+			return a.fail(err)
+		}
+	}
+	if !cmd.JSON {
+		if err := refuseJSON(a, cmd.Name); err != nil {
+			return a.fail(err)
+		}
+	}
+
+	cmdArgs := a.locale.translateFlags(cmd.Name, args[1:])
+	started := time.Now()
+	a.log().Debug("running command", "command", cmd.Name, "args", cmdArgs, "profile", a.profile)
+	code := a.runCommand(cmd, cmdArgs)
+	a.log().Debug("command finished", "command", cmd.Name, "exitCode", code, "took", time.Since(started))
+	if !cmd.Hidden && cmd.Name != "again" {
+		a.recordCommand(cmd.Name, original, code)
+	}
+	if replay := a.replay; replay != nil {
+		a.replay = nil
+		return a.Run(replay)
+	}
+	return code
+}
+
+// runCommand runs cmd, onboarding the user or recovering from an integrity
+// failure along the way, and returns its exit code.
+func (a *App) runCommand(cmd *Command, args []string) int {
+	err := cmd.Run(a, args)
+	if errors.Is(err, domainerrors.ErrConfigurationNotFound) && cmd.Name != "init" {
+		err = a.onboard(func() error { return cmd.Run(a, args) })
+	}
+	var integrityErr *domainerrors.IntegrityError
+	if errors.As(err, &integrityErr) {
+		err = a.recoverIntegrity(err, integrityErr, func() error { return cmd.Run(a, args) })
+	}
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return a.fail(err)
+	}
+	return ExitOK
+}
+
+// openLog opens the logger the global flags ask for: text on stderr at
+// info level for --verbose or debug level for --debug, and JSON in the log
+// file for --log-file. The returned function closes the log file.
+func (a *App) openLog() (func(), error) {
+	opts := logging.Options{Level: slog.LevelInfo}
+	if a.debug {
+		opts.Level = slog.LevelDebug
+	}
+	if a.verbose || a.debug {
+		opts.Stderr = a.stderr
+	}
+	if a.logFile {
+		path, err := system.LogFilePath(a.directoryProvider)
+		if err != nil {
+			return nil, err
+		}
+		opts.File = path
+	}
+	logger, closeFile, err := logging.New(opts)
+	if err != nil {
+		return nil, err
+	}
+	a.logger = logger
+	return func() {
+		closeFile()
+		a.logger = nil
+	}, nil
+}
+
+// log returns the logger of the running command, one that writes nothing
+// before Run opens it.
+func (a *App) log() *slog.Logger {
+	if a.logger == nil {
+		return logging.Discard()
+	}
+	return a.logger
+}
+
+// writeJSON writes v to stdout as the JSON output of a command, projected
+// onto the fields selected with --fields.
+func (a *App) writeJSON(v any) error {
+	return presentation.WriteProjectedJSON(a.stdout, v, a.fields)
+}
+
+// writeJSONLine writes v to stdout as one line of JSON projected onto
+// --fields, for commands that report events as they happen.
+func (a *App) writeJSONLine(v any) error {
+	return presentation.WriteProjectedJSONLine(a.stdout, v, a.fields)
+}
+
+// refuseJSON fails with a usage error when --json or --fields is given to a
+// subcommand that has no JSON output.
+func refuseJSON(app *App, command string) error {
+	if app.jsonOutput {
+		return usageErrorf("%s has no JSON output; drop --json and --fields", command)
+	}
+	return nil
+}
+
+// fail reports err on stderr and returns its exit code.
+func (a *App) fail(err error) int {
+	code := exitCode(err)
+	a.log().Debug("command failed", "error", err, "exitCode", code)
+	if a.jsonOutput {
+		output := v1.Error{Error: err.Error(), Code: code}
+		var limited *domainerrors.RateLimitedError
+		if errors.As(err, &limited) {
+			output.RetryAt = limited.NextAllowed
+		}
+		var ambiguous *domainerrors.AmbiguousConfigurationError
+		if errors.As(err, &ambiguous) {
+			output.Candidates = ambiguous.Paths
+		}
+		presentation.WriteJSON(a.stderr, output)
+	} else {
+		fmt.Fprintf(a.stderr, "error: %v\n", err)
+	}
+	return code
+}
+
+// resolveProfile settles the profile in use: the one named by --profile,
+// which must exist, or else the active profile.
+func (a *App) resolveProfile() error {
+	profiles := usecases.NewProfilesUseCase(a.servicesFor(entities.DefaultProfile))
+	if a.profile != "" {
+		return profiles.EnsureExists(a.profile)
+	}
+	active, err := profiles.Active()
+	if err != nil {
+		return err
+	}
+	a.profile = active
+	return nil
+}
+
+// exitCode returns ExitUsage for usage errors and the domain error code of
+// any other error.
+func exitCode(err error) int {
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+	return int(domainerrors.CodeOf(err))
+}
+
+func (a *App) printUsage() {
+	fmt.Fprintln(a.stderr, "Usage: outfitpicker [--json] [--fields LIST] [--progress ndjson] [--profile NAME] [--config PATH] [--state-dir DIR] [--stateless] [--demo] [--dry-run] [--verbose | --debug] [--log-file] <command> [flags]")
+	fmt.Fprintln(a.stderr)
+	fmt.Fprintln(a.stderr, "Commands:")
+
+	tw := tabwriter.NewWriter(a.stderr, 0, 4, 2, ' ', 0)
+	for _, name := range a.commandNames() {
+		label := strings.Join(append([]string{name}, a.locale.aliasesOf(name)...), ", ")
+		fmt.Fprintf(tw, "  %s\t%s\n", label, a.commands[name].Summary)
+	}
+	tw.Flush()
+}
+
+// parseGlobalFlags parses the flags that precede the command name and returns
+// the remaining arguments.
+func (a *App) parseGlobalFlags(args []string) ([]string, error) {
+	fs := a.newFlagSet("outfitpicker")
+	fs.Usage = a.printUsage
+	fs.BoolVar(&a.jsonOutput, "json", false, "write machine-readable JSON output")
+	fields := fs.String("fields", "", "write only these comma-separated fields of the JSON output, such as category,fileName (implies --json)")
+	fs.StringVar(&a.progressFormat, "progress", "", "report progress of scans, rebuilds and imports on stderr (ndjson)")
+	fs.StringVar(&a.profile, "profile", "", "use the named profile instead of the active one")
+	fs.StringVar(&a.configPath, "config", "", "use the configuration in this directory, or this config.json, instead of the default location (or set "+configEnv+")")
+	fs.StringVar(&a.stateDir, "state-dir", "", "keep state files, backups and the log in this directory instead of next to the configuration (or set "+stateDirEnv+")")
+	fs.BoolVar(&a.stateless, "stateless", false, "take the configuration from "+rootEnv+" and other environment variables and keep all state in memory (or set "+statelessEnv+")")
+	fs.BoolVar(&a.demo, "demo", false, "explore a sample wardrobe in a temporary directory instead of your own")
+	fs.BoolVar(&a.dryRun, "dry-run", false, "show what pick, rotation reset, import csv, import bundle or category remove would do without saving anything")
+	fs.BoolVar(&a.verbose, "verbose", false, "log what the command does to stderr")
+	fs.BoolVar(&a.debug, "debug", false, "log what the command does to stderr in detail, including every scan and state file")
+	fs.BoolVar(&a.logFile, "log-file", false, "also log as JSON to "+logging.FileName+" in the config directory")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if *fields != "" {
+		selection, err := presentation.ParseFieldSelection(*fields)
+		if err != nil {
+			return nil, usageErrorf("--fields: %v", err)
+		}
+		a.fields, a.jsonOutput = selection, true
+	}
+	if a.progressFormat != "" && a.progressFormat != progressNDJSON {
+		return nil, usageErrorf("unknown progress format %q (want %s)", a.progressFormat, progressNDJSON)
+	}
+	if !a.stateless && !a.demo {
+		a.stateless, _ = strconv.ParseBool(os.Getenv(statelessEnv))
+	}
+	if a.demo && (a.configPath != "" || a.stateDir != "") {
+		return nil, usageErrorf("--demo keeps its own configuration and state and cannot be combined with --config or --state-dir")
+	}
+	if a.stateless && (a.demo || a.configPath != "" || a.stateDir != "" || a.logFile) {
+		return nil, usageErrorf("--stateless keeps nothing on disk and cannot be combined with --demo, --config, --state-dir or --log-file")
+	}
+	return fs.Args(), nil
+}
+
+// UsageError reports a malformed command line.
+type UsageError struct {
+	Message string
+}
+
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+func usageErrorf(format string, args ...any) error {
+	return &UsageError{Message: fmt.Sprintf(format, args...)}
+}
+
+// newFlagSet creates a flag set that reports errors instead of exiting.
+func (a *App) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	return fs
+}
+
+// parseFlags parses args and wraps parse failures in a UsageError.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &UsageError{Message: err.Error()}
+	}
+	return nil
+}
+
+// parseArgs parses flags that may appear before or after positional
+// arguments and returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := parseFlags(fs, args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// flagWasSet reports whether the named flag appeared on the command line.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// stringList is a repeatable flag whose values may also be comma-separated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+	return nil
+}
+
+// pathList is a repeatable flag of paths. Unlike stringList it does not
+// split values at commas, which paths may contain.
+type pathList []string
+
+func (l *pathList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *pathList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// expanded returns the paths with expandPath applied.
+func (l pathList) expanded() ([]string, error) {
+	paths := make([]string, len(l))
+	for i, path := range l {
+		var err error
+		if paths[i], err = expandPath(path); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// colorEnabled reports whether stdout is a terminal that should receive ANSI
+// colors. NO_COLOR disables color regardless.
+func (a *App) colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(a.stdout)
+}
+
+// isInteractive reports whether the user can answer prompts on stdin.
+func (a *App) isInteractive() bool {
+	if a.interactive != nil {
+		return *a.interactive
+	}
+	return isTerminal(a.stdin)
+}
+
+func isTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runSubcommand dispatches args[0] to one of the named handlers.
+func runSubcommand(app *App, parent string, args []string, handlers map[string]func(*App, []string) error) error {
+	names := make([]string, 0, len(handlers))
+	for name := range handlers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	if len(args) == 0 {
+		return usageErrorf("%s requires a subcommand: %s", parent, strings.Join(names, ", "))
+	}
+	handler, ok := handlers[args[0]]
+	if !ok {
+		return usageErrorf("unknown %s subcommand %q (want one of: %s)", parent, args[0], strings.Join(names, ", "))
+	}
+	return handler(app, args[1:])
+}
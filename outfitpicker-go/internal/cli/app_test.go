@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+// cliEnv is a wardrobe and state directory for running CLI commands against.
+type cliEnv struct {
+	t        *testing.T
+	root     string
+	stateDir string
+	// keychain holds the state signing key across runs.
+	keychain memoryKeychain
+	// weather answers forecast requests, so no test reaches the network.
+	weather *testhelpers.FakeWeatherProvider
+	// chat stands in for the Telegram Bot API.
+	chat *testhelpers.FakeChatService
+	// scheduler stands in for the operating system's scheduler, so no test
+	// touches the user's crontab.
+	scheduler *testhelpers.FakeScheduler
+	// trash stands in for the user's trash, so no test fills it.
+	trash *testhelpers.FakeTrash
+	// otherStateDirs are further base directories the app searches for a
+	// configuration after stateDir.
+	otherStateDirs []string
+}
+
+// memoryKeychain is an in-memory system.Keychain.
+type memoryKeychain map[string]string
+
+func (k memoryKeychain) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", system.ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeychain) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+// newCLIEnv creates a wardrobe with the given categories and outfit files and
+// saves a configuration pointing at it.
+func newCLIEnv(t *testing.T, wardrobe map[string][]string) *cliEnv {
+	t.Helper()
+	env := &cliEnv{t: t, root: t.TempDir(), stateDir: t.TempDir()}
+	for category, files := range wardrobe {
+		if err := os.MkdirAll(filepath.Join(env.root, category), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			env.writeOutfit(category, file)
+		}
+	}
+
+	service := configuration.NewConfigService(system.WithDirectoryProvider[entities.Config](env.directoryProvider()))
+	if err := service.Save(testhelpers.NewConfig(env.root)); err != nil {
+		t.Fatal(err)
+	}
+	return env
+}
+
+func (e *cliEnv) directoryProvider() system.DirectoryProvider {
+	if len(e.otherStateDirs) > 0 {
+		return system.NewCandidateDirectoryProvider(append([]string{e.stateDir}, e.otherStateDirs...)...)
+	}
+	return system.NewStaticDirectoryProvider(e.stateDir)
+}
+
+func (e *cliEnv) keychainOption() Option {
+	if e.keychain == nil {
+		e.keychain = memoryKeychain{}
+	}
+	return WithKeychain(e.keychain)
+}
+
+func (e *cliEnv) weatherOption() Option {
+	if e.weather == nil {
+		e.weather = &testhelpers.FakeWeatherProvider{}
+	}
+	return WithWeatherProvider(e.weather)
+}
+
+func (e *cliEnv) chatOption() Option {
+	if e.chat == nil {
+		e.chat = &testhelpers.FakeChatService{}
+	}
+	return WithChatService(e.chat)
+}
+
+func (e *cliEnv) schedulerOption() Option {
+	if e.scheduler == nil {
+		e.scheduler = &testhelpers.FakeScheduler{}
+	}
+	return WithScheduler(e.scheduler)
+}
+
+func (e *cliEnv) trashOption() Option {
+	if e.trash == nil {
+		e.trash = &testhelpers.FakeTrash{}
+	}
+	return WithTrash(e.trash)
+}
+
+// updateConfig changes the saved configuration directly, for settings the
+// test wardrobe cannot reach through setup.
+func (e *cliEnv) updateConfig(update func(*entities.Config)) {
+	e.t.Helper()
+	service := configuration.NewConfigService(system.WithDirectoryProvider[entities.Config](e.directoryProvider()))
+	config, err := service.Load()
+	if err != nil {
+		e.t.Fatal(err)
+	}
+	update(config)
+	if err := service.Save(config); err != nil {
+		e.t.Fatal(err)
+	}
+}
+
+func (e *cliEnv) writeOutfit(category, fileName string) {
+	e.t.Helper()
+	if err := os.WriteFile(filepath.Join(e.root, category, fileName), nil, 0644); err != nil {
+		e.t.Fatal(err)
+	}
+}
+
+// run runs the app. Long-running commands stop after their first round, as
+// if interrupted straight away.
+func (e *cliEnv) run(args ...string) (stdout, stderr string, code int) {
+	e.t.Helper()
+	var out, errOut bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	app := New(WithOutput(&out, &errOut), WithDirectoryProvider(e.directoryProvider()), e.keychainOption(), e.weatherOption(), e.chatOption(), e.schedulerOption(), e.trashOption(), WithContext(ctx))
+	code = app.Run(args)
+	return out.String(), errOut.String(), code
+}
+
+// runInteractive runs the app as if on a terminal, answering prompts from
+// stdin.
+func (e *cliEnv) runInteractive(stdin string, args ...string) (stdout, stderr string, code int) {
+	e.t.Helper()
+	var out, errOut bytes.Buffer
+	app := New(
+		WithOutput(&out, &errOut),
+		WithInput(strings.NewReader(stdin)),
+		WithInteractive(true),
+		WithDirectoryProvider(e.directoryProvider()),
+		e.keychainOption(),
+		e.weatherOption(),
+		e.chatOption(),
+		e.schedulerOption(),
+		e.trashOption(),
+	)
+	code = app.Run(args)
+	return out.String(), errOut.String(), code
+}
+
+func runApp(t *testing.T, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	app := New(WithOutput(&out, &errOut))
+	code = app.Run(args)
+	return out.String(), errOut.String(), code
+}
+
+func TestApp_Usage(t *testing.T) {
+	_, stderr, code := runApp(t)
+	if code != ExitOK {
+		t.Errorf("exit code = %v, want %v", code, ExitOK)
+	}
+	if !strings.Contains(stderr, "Usage: outfitpicker") {
+		t.Errorf("usage not printed: %q", stderr)
+	}
+	if strings.Contains(stderr, "devtools") {
+		t.Error("hidden command listed in usage")
+	}
+}
+
+func TestApp_UnknownCommand(t *testing.T) {
+	_, stderr, code := runApp(t, "frobnicate")
+	if code != ExitUsage {
+		t.Errorf("exit code = %v, want %v", code, ExitUsage)
+	}
+	if !strings.Contains(stderr, `unknown command "frobnicate"`) {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
+
+func TestApp_ErrorCodes(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	_, stderr, code := env.run("pick", "pyjamas")
+	if code != ExitCategoryNotFound || stderr != "error: category not found: \"pyjamas\"\n" {
+		t.Errorf("pick of an unknown category: code = %v, stderr = %q", code, stderr)
+	}
+
+	_, stderr, code = env.run("--json", "pick", "pyjamas")
+	var output struct {
+		Error string `json:"error"`
+		Code  int    `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &output); err != nil || output.Code != 21 || output.Error != `category not found: "pyjamas"` {
+		t.Errorf("--json error = %q, %v", stderr, err)
+	}
+	if code != 21 {
+		t.Errorf("--json exit code = %v, want 21", code)
+	}
+
+	_, stderr, code = env.run("--json", "pick")
+	if err := json.Unmarshal([]byte(stderr), &output); err != nil || output.Code != ExitUsage || code != ExitUsage {
+		t.Errorf("--json usage error: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestApp_Logging(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	if _, stderr, code := env.run("list"); code != ExitOK || strings.Contains(stderr, "level=") {
+		t.Errorf("list without logging flags: code = %v, stderr = %q", code, stderr)
+	}
+	_, stderr, code := env.run("--verbose", "pick", "casual")
+	if code != ExitOK || !strings.Contains(stderr, "msg=\"picked outfit\"") || strings.Contains(stderr, "level=DEBUG") {
+		t.Errorf("--verbose pick: code = %v, stderr = %q, want info lines only", code, stderr)
+	}
+	_, stderr, code = env.run("--debug", "list")
+	if code != ExitOK || !strings.Contains(stderr, "msg=\"scanned categories\"") || !strings.Contains(stderr, "msg=\"loaded state file\"") {
+		t.Errorf("--debug list: code = %v, stderr = %q, want scan and state file lines", code, stderr)
+	}
+
+	if _, stderr, code := env.run("--log-file", "pick", "casual"); code != ExitOK || stderr != "" {
+		t.Errorf("--log-file pick: code = %v, stderr = %q, want nothing on stderr", code, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(env.stateDir, "outfitpicker", "outfitpicker.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record struct {
+		Msg      string `json:"msg"`
+		Category string `json:"category"`
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &record); err != nil || record.Msg != "picked outfit" || record.Category != "casual" {
+		t.Errorf("last log line = %s (%v), want the pick as JSON", lines[len(lines)-1], err)
+	}
+}
+
+// wear marks an outfit as worn directly through the use case.
+func (e *cliEnv) wear(t *testing.T, category, fileName string) {
+	t.Helper()
+	app := New(WithDirectoryProvider(e.directoryProvider()))
+	outfit, err := app.outfitReference(category, fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := usecases.NewWearOutfitUseCase(app.services()).Execute(outfit); err != nil {
+		t.Fatal(err)
+	}
+}
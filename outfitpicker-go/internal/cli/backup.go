@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func backupCommand() *Command {
+	return &Command{
+		Name:    "backup",
+		Summary: "Back up and restore the config and rotation cache (create, list, restore)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "backup", args, map[string]func(*App, []string) error{
+				"create":  runBackupCreate,
+				"list":    runBackupList,
+				"restore": runBackupRestore,
+			})
+		},
+	}
+}
+
+func runBackupCreate(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("backup create"), args); err != nil {
+		return err
+	}
+	result, err := usecases.NewBackupUseCase(app.services()).Create()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.BackupCreatedV1(*result))
+	}
+	return presentation.RenderBackupCreated(app.stdout, result)
+}
+
+func runBackupList(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("backup list"), args); err != nil {
+		return err
+	}
+	backups, err := usecases.NewBackupUseCase(app.services()).List()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(backups, presentation.BackupV1))
+	}
+	return presentation.RenderBackups(app.stdout, backups)
+}
+
+func runBackupRestore(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("backup restore"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: backup restore <id>")
+	}
+	result, err := usecases.NewBackupUseCase(app.services()).Restore(positional[0])
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.BackupRestoredV1(*result))
+	}
+	return presentation.RenderBackupRestored(app.stdout, result)
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackup_CreateListRestore(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "coat.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+
+	if stdout, _, _ := env.run("backup", "list"); stdout != "No backups yet.\n" {
+		t.Errorf("backup list before any backup = %q", stdout)
+	}
+	stdout, stderr, code := env.run("--json", "backup", "create")
+	if code != ExitOK {
+		t.Fatalf("backup create: code = %v, stderr = %q", code, stderr)
+	}
+	var created struct {
+		Backup struct {
+			ID    string   `json:"id"`
+			Files []string `json:"files"`
+		} `json:"backup"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &created); err != nil || len(created.Backup.Files) != 2 {
+		t.Fatalf("backup create --json = %q, %v", stdout, err)
+	}
+	if stdout, _, _ := env.run("backup", "list"); stdout != created.Backup.ID+"  cache.json, config.json\n" {
+		t.Errorf("backup list = %q", stdout)
+	}
+
+	env.wear(t, "casual", "jeans.avatar")
+	stdout, stderr, code = env.run("backup", "restore", created.Backup.ID)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Restored cache.json, config.json from backup "+created.Backup.ID+".\n") {
+		t.Fatalf("backup restore: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	// Only tee.avatar was worn when the backup was made.
+	data, err := os.ReadFile(filepath.Join(env.stateDir, "outfitpicker", "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cache struct {
+		Categories map[string]struct {
+			WornOutfits map[string]bool `json:"wornOutfits"`
+		} `json:"categories"`
+	}
+	if err := json.Unmarshal(data, &cache); err != nil || len(cache.Categories["casual"].WornOutfits) != 1 {
+		t.Errorf("cache after restore = %s, %v", data, err)
+	}
+}
+
+func TestBackup_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"restore without id", []string{"backup", "restore"}, ExitUsage},
+		{"unknown backup", []string{"backup", "restore", "20240101-000000"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"backup", "prune"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/pkg/outfitpicker"
+	"github.com/dh85/outfitpicker/pkg/outfitpicker/bot"
+)
+
+// Keychain accounts holding what the bot checks slash command requests
+// against.
+const (
+	slackSecretAccount = "bot-slack-signing-secret"
+	discordKeyAccount  = "bot-discord-public-key"
+)
+
+const defaultBotAddress = "localhost:8080"
+
+// botSecrets describes the secret saved for each chat service: what Slack
+// and Discord requests are checked against, and the token watch sends picks
+// to Telegram with.
+var botSecrets = map[string]struct{ account, description string }{
+	"slack":    {slackSecretAccount, "Slack signing secret"},
+	"discord":  {discordKeyAccount, "Discord public key"},
+	"telegram": {telegramTokenAccount, "Telegram bot token"},
+}
+
+func botCommand() *Command {
+	return &Command{
+		Name:    "bot",
+		Summary: "Answer /outfit slash commands from Slack and Discord and serve a feed of picks (secret, serve)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "bot", args, map[string]func(*App, []string) error{
+				"secret": runBotSecret,
+				"serve":  runBotServe,
+			})
+		},
+	}
+}
+
+func runBotSecret(app *App, args []string) error {
+	if err := refuseJSON(app, "bot secret"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("bot secret"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: bot secret <slack|discord|telegram>")
+	}
+	secret, ok := botSecrets[positional[0]]
+	if !ok {
+		return usageErrorf("unknown chat service %q (want slack, discord or telegram)", positional[0])
+	}
+	// The secret is read from stdin so it never shows up in the process
+	// list or the shell history.
+	value, ok := app.prompt(bufio.NewScanner(app.stdin), fmt.Sprintf("Paste the %s: ", secret.description))
+	if !ok || value == "" {
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("no %s was given", secret.description))
+	}
+	if positional[0] == "discord" {
+		if _, err := bot.ParseDiscordPublicKey(value); err != nil {
+			return domainerrors.NewInvalidInputError(err.Error())
+		}
+	}
+	if err := app.keychain.Set(secret.account, value); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Saved the %s to the keychain.\n", secret.description)
+	return nil
+}
+
+func runBotServe(app *App, args []string) error {
+	fs := app.newFlagSet("bot serve")
+	addr := fs.String("addr", defaultBotAddress, "address to listen on for slash command and feed requests")
+	var channelFlags stringList
+	fs.Var(&channelFlags, "channel", "profile the commands of a channel use, as CHANNEL_ID=PROFILE (repeatable or comma-separated; default the active profile)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("bot serve takes no arguments, got %q", fs.Arg(0))
+	}
+	if app.stateless {
+		return usageErrorf("bot serve keeps its state on disk and cannot be used with --stateless")
+	}
+	channels := make(map[string]string, len(channelFlags))
+	profiles := usecases.NewProfilesUseCase(app.services())
+	for _, mapping := range channelFlags {
+		channel, profile, ok := strings.Cut(mapping, "=")
+		if !ok || channel == "" || profile == "" {
+			return usageErrorf("channel %q must be written as CHANNEL_ID=PROFILE", mapping)
+		}
+		if err := profiles.EnsureExists(profile); err != nil {
+			return err
+		}
+		channels[channel] = profile
+	}
+
+	b := bot.New(app.openWardrobe, channels)
+	mux := http.NewServeMux()
+	var endpoints []string
+	if secret, err := app.botSecret(slackSecretAccount); err != nil {
+		return err
+	} else if secret != "" {
+		mux.Handle("/slack", bot.SlackHandler(b, secret))
+		endpoints = append(endpoints, "/slack")
+	}
+	if secret, err := app.botSecret(discordKeyAccount); err != nil {
+		return err
+	} else if secret != "" {
+		publicKey, err := bot.ParseDiscordPublicKey(secret)
+		if err != nil {
+			return err
+		}
+		mux.Handle("/discord", bot.DiscordHandler(b, publicKey))
+		endpoints = append(endpoints, "/discord")
+	}
+	mux.Handle("/feed.atom", bot.FeedHandler(b))
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(app.ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+	base := "http://" + listener.Addr().String()
+	if len(endpoints) > 0 {
+		urls := make([]string, len(endpoints))
+		for i, endpoint := range endpoints {
+			urls[i] = base + endpoint
+		}
+		fmt.Fprintf(app.stdout, "Answering slash commands at %s\n", strings.Join(urls, " and "))
+	}
+	fmt.Fprintf(app.stdout, "Serving the Atom feed of picks at %s/feed.atom\n", base)
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// botSecret returns the secret saved in the keychain account, or "" when
+// none is saved.
+func (a *App) botSecret(account string) (string, error) {
+	secret, err := a.keychain.Get(account)
+	if errors.Is(err, system.ErrSecretNotFound) {
+		return "", nil
+	}
+	return secret, err
+}
+
+// openWardrobe opens a client of the named profile, or of the profile in
+// use when the name is empty, on the configuration and state the command
+// uses.
+func (a *App) openWardrobe(profile string) (bot.Wardrobe, error) {
+	appDir, err := system.AppDirectory(a.directoryProvider)
+	if err != nil {
+		return nil, err
+	}
+	stateDir, err := system.StateDirectory(a.directoryProvider)
+	if err != nil {
+		return nil, err
+	}
+	if profile == "" {
+		profile = a.profile
+	}
+	opts := []outfitpicker.Option{outfitpicker.WithAppDir(appDir), outfitpicker.WithProfile(profile), outfitpicker.WithLogger(a.log())}
+	if stateDir != appDir {
+		opts = append(opts, outfitpicker.WithStateDir(stateDir))
+	}
+	return outfitpicker.New(opts...)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestBotSecret(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.runInteractive("8f742231b10e8888abcd99yyyzzz85a5\n", "bot", "secret", "slack")
+	if code != ExitOK || stdout != "Saved the Slack signing secret to the keychain.\n" {
+		t.Fatalf("bot secret slack: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if got := env.keychain[slackSecretAccount]; got != "8f742231b10e8888abcd99yyyzzz85a5" {
+		t.Errorf("keychain holds %q", got)
+	}
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, stderr, code := env.runInteractive(hex.EncodeToString(publicKey)+"\n", "bot", "secret", "discord"); code != ExitOK {
+		t.Fatalf("bot secret discord: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.runInteractive("not a key\n", "bot", "secret", "discord"); code != ExitInvalidInput {
+		t.Errorf("bot secret discord with a malformed key: code = %v, want %v", code, ExitInvalidInput)
+	}
+	if _, stderr, code := env.runInteractive("123:abc\n", "bot", "secret", "telegram"); code != ExitOK || env.keychain[telegramTokenAccount] != "123:abc" {
+		t.Errorf("bot secret telegram: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestBotServe(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	stdout, stderr, code := env.run("bot", "serve", "--addr", "127.0.0.1:0")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Serving the Atom feed of picks at http://127.0.0.1:") {
+		t.Errorf("bot serve without a secret: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	env.keychain = memoryKeychain{slackSecretAccount: "secret"}
+	stdout, stderr, code = env.run("bot", "serve", "--addr", "127.0.0.1:0", "--channel", "C1=default")
+	lines := strings.Split(stdout, "\n")
+	if code != ExitOK || len(lines) != 3 || !strings.HasPrefix(lines[0], "Answering slash commands at http://127.0.0.1:") ||
+		!strings.HasSuffix(lines[0], "/slack") || !strings.HasSuffix(lines[1], "/feed.atom") {
+		t.Errorf("bot serve: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+}
+
+func TestBot_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	env.keychain = memoryKeychain{slackSecretAccount: "secret"}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unknown service", []string{"bot", "secret", "teams"}, ExitUsage},
+		{"missing service", []string{"bot", "secret"}, ExitUsage},
+		{"malformed channel", []string{"bot", "serve", "--channel", "C1"}, ExitUsage},
+		{"unknown profile", []string{"bot", "serve", "--channel", "C1=travel"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"bot", "start"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
+
+func TestBotServe_Stateless(t *testing.T) {
+	t.Setenv(rootEnv, newWardrobeRoot(t))
+	if _, stderr, code := runApp(t, "--stateless", "bot", "serve"); code != ExitUsage {
+		t.Errorf("bot serve --stateless: code = %v, want %v (stderr %q)", code, ExitUsage, stderr)
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func categoryCommand() *Command {
+	return &Command{
+		Name:    "category",
+		Summary: "Create or remove a category (add, remove)",
+		DryRun:  true,
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "category", args, map[string]func(*App, []string) error{
+				"add":    runCategoryAdd,
+				"remove": runCategoryRemove,
+			})
+		},
+	}
+}
+
+func runCategoryAdd(app *App, args []string) error {
+	fs := app.newFlagSet("category add")
+	from := fs.String("from", "", "copy the outfits of this category into the new one")
+	pack := fs.String("pack", "", "import the outfits of this pack or other zip or tar archive into the new one")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := refuseDryRun(app, "category add"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: category add <name> [--from CATEGORY | --pack FILE]")
+	}
+
+	services := app.services()
+	request := usecases.CategoryCreateRequest{Name: positional[0], Pack: *pack}
+	if *from != "" {
+		source, err := usecases.NewResolveCategoryUseCase(services).Execute(*from)
+		if err != nil {
+			return err
+		}
+		request.From = source.Name
+	}
+	result, err := usecases.NewCreateCategoryUseCase(services).Execute(request)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.CategoryCreatedV1(*result))
+	}
+	return presentation.RenderCategoryCreated(app.stdout, result)
+}
+
+func runCategoryRemove(app *App, args []string) error {
+	fs := app.newFlagSet("category remove")
+	files := fs.String("files", "", "what happens to the category's files: leave, archive or trash")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: category remove <category> [--files leave|archive|trash]")
+	}
+
+	services := app.services()
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	var disposition entities.FileDisposition
+	if *files != "" {
+		if disposition, err = entities.ParseFileDisposition(*files); err != nil {
+			return err
+		}
+	} else if disposition, err = app.promptFileDisposition(category.Name); err != nil {
+		return err
+	}
+
+	removals := usecases.NewRemoveCategoryUseCase(services)
+	proposal, err := removals.Propose(usecases.CategoryRemoveRequest{Category: category.Name, Files: disposition})
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		return writeDryRun(app, presentation.CategoryRemovalSummary(proposal), presentation.CategoryRemovalProposalV1(*proposal), proposal.Changes())
+	}
+	result, err := removals.Commit(proposal)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.CategoryRemovalV1(*result))
+	}
+	return presentation.RenderCategoryRemoved(app.stdout, result)
+}
+
+// promptFileDisposition asks what happens to the files of a category being
+// removed. Without a terminal to ask on, --files must be given.
+func (a *App) promptFileDisposition(category string) (entities.FileDisposition, error) {
+	if !a.isInteractive() || a.jsonOutput {
+		return "", usageErrorf("category remove needs --files leave, archive or trash when it cannot ask")
+	}
+	input := bufio.NewScanner(a.stdin)
+	for {
+		answer, ok := a.prompt(input, fmt.Sprintf("What happens to the files of %s? (l)eave, (a)rchive, (t)rash: ", category))
+		if !ok {
+			return "", usageErrorf("no choice made; nothing was removed")
+		}
+		for _, disposition := range entities.FileDispositions() {
+			if answer != "" && strings.HasPrefix(string(disposition), strings.ToLower(answer)) {
+				return disposition, nil
+			}
+		}
+	}
+}
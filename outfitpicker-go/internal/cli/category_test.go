@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCategoryAdd(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	stdout, stderr, code := env.run("category", "add", "date night")
+	want := "Created date night at " + filepath.Join(env.root, "date night") + ".\n"
+	if code != ExitOK || stdout != want {
+		t.Fatalf("category add: code = %v, stdout = %q, want %q, stderr = %q", code, stdout, want, stderr)
+	}
+	if info, err := os.Stat(filepath.Join(env.root, "date night")); err != nil || !info.IsDir() {
+		t.Errorf("category directory was not created: %v", err)
+	}
+
+	stdout, stderr, code = env.run("category", "add", "weekend", "--from", "casual")
+	if code != ExitOK || stdout != "Created weekend at "+filepath.Join(env.root, "weekend")+" with 2 outfits.\n" {
+		t.Fatalf("category add --from: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("pick", "weekend"); stdout != "weekend/jeans.avatar\n" && stdout != "weekend/tee.avatar\n" {
+		t.Errorf("pick from the new category = %q", stdout)
+	}
+}
+
+func TestCategoryAdd_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing name", []string{"category", "add"}, ExitUsage},
+		{"existing category", []string{"category", "add", "casual"}, ExitInvalidInput},
+		{"hidden name", []string{"category", "add", ".secret"}, ExitInvalidInput},
+		{"unknown source", []string{"category", "add", "new", "--from", "nope"}, ExitCategoryNotFound},
+		{"both seeds", []string{"category", "add", "new", "--from", "casual", "--pack", "pack.zip"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v, stderr = %q", code, tt.want, stderr)
+			}
+		})
+	}
+}
+
+func TestCategoryRemove(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "formal": {"suit.avatar"}, "gym": {"shorts.avatar"}})
+	if _, stderr, code := env.run("pick", "formal"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("--dry-run", "category", "remove", "formal", "--files", "archive")
+	if code != ExitOK || !strings.Contains(stdout, "Would remove formal and archive its 1 outfit") || !strings.Contains(stdout, "history: drop 1 pick") {
+		t.Fatalf("category remove --dry-run: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "formal")); err != nil {
+		t.Fatalf("--dry-run moved the category: %v", err)
+	}
+
+	stdout, stderr, code = env.run("category", "remove", "formal", "--files", "archive")
+	if code != ExitOK || !strings.Contains(stdout, "Removed formal.") || !strings.Contains(stdout, "history: drop 1 pick") {
+		t.Fatalf("category remove: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "formal")); !os.IsNotExist(err) {
+		t.Errorf("the category directory is still there: %v", err)
+	}
+	if _, _, code := env.run("pick", "formal"); code != ExitCategoryNotFound {
+		t.Errorf("pick from the removed category: code = %v, want %v", code, ExitCategoryNotFound)
+	}
+
+	if _, stderr, code := env.runInteractive("x\nt\n", "category", "remove", "gym"); code != ExitOK {
+		t.Fatalf("category remove answering trash: code = %v, stderr = %q", code, stderr)
+	}
+	if len(env.trash.Trashed) != 1 || env.trash.Trashed[0] != filepath.Join(env.root, "gym") {
+		t.Errorf("trashed %v, want the gym directory", env.trash.Trashed)
+	}
+}
+
+func TestCategoryRemove_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing category", []string{"category", "remove"}, ExitUsage},
+		{"no files choice without a terminal", []string{"category", "remove", "casual"}, ExitUsage},
+		{"unknown files choice", []string{"category", "remove", "casual", "--files", "shred"}, ExitInvalidInput},
+		{"unknown category", []string{"category", "remove", "nope", "--files", "leave"}, ExitCategoryNotFound},
+		{"dry run of add", []string{"--dry-run", "category", "add", "new"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v, stderr = %q", code, tt.want, stderr)
+			}
+		})
+	}
+}
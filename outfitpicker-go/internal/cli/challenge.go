@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func challengeCommand() *Command {
+	return &Command{
+		Name:    "challenge",
+		Summary: "Run a time-boxed capsule challenge that only picks from chosen outfits (start, status, end)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "challenge", args, map[string]func(*App, []string) error{
+				"start":  runChallengeStart,
+				"status": runChallengeStatus,
+				"end":    runChallengeEnd,
+			})
+		},
+	}
+}
+
+func runChallengeStart(app *App, args []string) error {
+	fs := app.newFlagSet("challenge start")
+	days := fs.Int("days", 0, "how many days the challenge lasts (required)")
+	outfits, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *days == 0 || len(outfits) == 0 {
+		return usageErrorf("usage: challenge start --days N category/file...")
+	}
+
+	report, err := usecases.NewChallengeUseCase(app.services()).Start(outfits, *days)
+	if err != nil {
+		return err
+	}
+	return writeChallengeReport(app, report)
+}
+
+func runChallengeStatus(app *App, args []string) error {
+	fs := app.newFlagSet("challenge status")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("challenge status takes no arguments, got %q", fs.Arg(0))
+	}
+
+	report, err := usecases.NewChallengeUseCase(app.services()).Report()
+	if err != nil {
+		return err
+	}
+	return writeChallengeReport(app, report)
+}
+
+func runChallengeEnd(app *App, args []string) error {
+	fs := app.newFlagSet("challenge end")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("challenge end takes no arguments, got %q", fs.Arg(0))
+	}
+
+	report, err := usecases.NewChallengeUseCase(app.services()).End()
+	if err != nil {
+		return err
+	}
+	return writeChallengeReport(app, report)
+}
+
+func writeChallengeReport(app *App, report logic.ChallengeReport) error {
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ChallengeReportV1(report))
+	}
+	return presentation.RenderChallengeReport(app.stdout, report)
+}
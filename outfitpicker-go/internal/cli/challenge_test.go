@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestChallenge(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"shirt.avatar"}})
+
+	stdout, stderr, code := env.run("challenge", "start", "--days", "14", "casual/tee.avatar", "work/shirt.avatar")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Capsule challenge: day 1 of 14") {
+		t.Fatalf("challenge start: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	for range 3 {
+		stdout, stderr, code := env.run("pick", "casual")
+		if code != ExitOK || !strings.Contains(stdout, "tee.avatar") {
+			t.Fatalf("pick during the challenge: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+		}
+	}
+	env.wear(t, "casual", "tee.avatar")
+
+	stdout, _, code = env.run("--json", "challenge", "status")
+	var report struct {
+		Finished bool `json:"finished"`
+		Wears    int  `json:"wears"`
+		Capsule  []struct {
+			FileName string `json:"fileName"`
+			Wears    int    `json:"wears"`
+		} `json:"capsule"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil || code != ExitOK {
+		t.Fatalf("challenge status JSON: code = %v, %v\n%s", code, err, stdout)
+	}
+	if report.Finished || report.Wears != 1 || len(report.Capsule) != 2 || report.Capsule[0].FileName != "tee.avatar" {
+		t.Errorf("challenge status = %+v, want one wear of tee.avatar", report)
+	}
+
+	stdout, _, code = env.run("challenge", "end")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Capsule challenge: ended early on day 1 of 14") {
+		t.Errorf("challenge end: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, code := env.run("pick", "casual"); code != ExitOK || !strings.Contains(stdout, "jeans.avatar") {
+		t.Errorf("pick after the challenge: code = %v, stdout = %q, want the unworn jeans.avatar", code, stdout)
+	}
+}
+
+func TestChallenge_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no subcommand", []string{"challenge"}, ExitUsage},
+		{"missing days", []string{"challenge", "start", "casual/tee.avatar"}, ExitUsage},
+		{"missing outfits", []string{"challenge", "start", "--days", "7"}, ExitUsage},
+		{"unknown outfit", []string{"challenge", "start", "--days", "7", "casual/jeans.avatar"}, ExitInvalidInput},
+		{"no challenge", []string{"challenge", "status"}, ExitInvalidInput},
+		{"nothing to end", []string{"challenge", "end"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// completionScripts holds the completion script of each supported shell,
+// named outfitpicker.<shell>. The scripts ask the hidden __complete command
+// for candidates, so category and outfit names stay current.
+//
+//go:embed completions/outfitpicker.*
+var completionScripts embed.FS
+
+var completionShells = []string{"bash", "fish", "zsh"}
+
+// completionKind is what a positional argument completes to.
+type completionKind int
+
+const (
+	completeCategory completionKind = iota
+	// completePickableCategory completes the categories the profile may
+	// pick from.
+	completePickableCategory
+	// completeOutfit completes the outfits of the category named by the
+	// argument before it.
+	completeOutfit
+	completeShell
+	completeProfile
+)
+
+// subcommandNames lists the subcommands of the commands that have them.
+var subcommandNames = map[string][]string{
+	"backup":      {"create", "list", "restore"},
+	"bot":         {"secret", "serve"},
+	"category":    {"add", "remove"},
+	"challenge":   {"end", "start", "status"},
+	"debug":       {"bundle"},
+	"demo":        {"reset"},
+	"devtools":    {"gen-wardrobe"},
+	"ensemble":    {"clash", "clear", "max-colors", "rule", "show", "slot"},
+	"export":      {"bundle", "pack"},
+	"favorite":    {"add", "list", "remove"},
+	"feedback":    {"add", "show"},
+	"history":     {"clear", "commands", "export", "list"},
+	"ids":         {"migrate", "resolve", "sync"},
+	"import":      {"archive", "bundle", "csv"},
+	"integrity":   {"accept", "disable", "enable", "status"},
+	"laundry":     {"disable", "done", "enable", "report", "status", "wash"},
+	"maintenance": {"off", "on", "status"},
+	"metadata":    {"set", "show"},
+	"occasion":    {"list", "remove", "set"},
+	"order":       {"set", "show"},
+	"plan":        {"export", "generate", "reroll", "show", "wear"},
+	"profile":     {"create", "delete", "list", "switch"},
+	"report":      {"configure", "monthly", "shopping"},
+	"rotation":    {"lock", "reset", "unlock"},
+	"schedule":    {"install", "remove", "status"},
+	"season":      {"clear", "hemisphere", "list", "set"},
+	"snapshot":    {"export"},
+	"stats":       {"export", "growth", "heatmap", "ratings", "summary"},
+	"tag":         {"add", "list", "remove"},
+	"weather":     {"avoid", "clear", "location", "show"},
+	"weight":      {"list", "set"},
+}
+
+// argumentCompletions lists what the positional arguments of a command, or
+// of "command subcommand", complete to.
+var argumentCompletions = map[string][]completionKind{
+	"alias":           {completeCategory},
+	"category remove": {completeCategory},
+	"completion":      {completeShell},
+	"decorate":        {completeCategory},
+	"ensemble slot":   {completeCategory},
+	"exclude":         {completeCategory},
+	"export pack":     {completeCategory},
+	"favorite add":    {completeCategory, completeOutfit},
+	"favorite remove": {completeCategory, completeOutfit},
+	"feedback add":    {completeCategory, completeOutfit},
+	"feedback show":   {completeCategory, completeOutfit},
+	"include":         {completeCategory},
+	"laundry done":    {completeCategory, completeOutfit},
+	"laundry wash":    {completeCategory, completeOutfit},
+	"mark-worn":       {completeCategory, completeOutfit},
+	"metadata set":    {completeCategory, completeOutfit},
+	"metadata show":   {completeCategory, completeOutfit},
+	"pick":            {completePickableCategory},
+	"profile delete":  {completeProfile},
+	"profile switch":  {completeProfile},
+	"rate":            {completeCategory, completeOutfit},
+	"roulette":        {completePickableCategory},
+	"rotation lock":   {completeCategory},
+	"rotation reset":  {completeCategory},
+	"rotation unlock": {completeCategory},
+	"season clear":    {completeCategory},
+	"season set":      {completeCategory},
+	"seen":            {completeCategory},
+	"skip":            {completeCategory, completeOutfit},
+	"tag add":         {completeCategory, completeOutfit},
+	"tag list":        {completeCategory},
+	"tag remove":      {completeCategory, completeOutfit},
+	"unskip":          {completeCategory, completeOutfit},
+	"weather avoid":   {completeCategory},
+	"weather clear":   {completeCategory},
+	"weight set":      {completeCategory, completeOutfit},
+}
+
+func completionCommand() *Command {
+	return &Command{
+		Name:    "completion",
+		Summary: "Print a shell completion script for bash, zsh or fish",
+		Run:     runCompletion,
+	}
+}
+
+func runCompletion(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("completion"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || !slices.Contains(completionShells, positional[0]) {
+		return usageErrorf("usage: completion %s", strings.Join(completionShells, "|"))
+	}
+	script, err := completionScripts.ReadFile("completions/outfitpicker." + positional[0])
+	if err != nil {
+		return err
+	}
+	_, err = app.stdout.Write(script)
+	return err
+}
+
+// completeCommand is called by the completion scripts with the words typed
+// after the program name, the last being the word under the cursor, and
+// prints the candidates for that word one per line. It never fails, so a
+// missing configuration just offers nothing.
+func completeCommand() *Command {
+	return &Command{
+		Name:    "__complete",
+		Summary: "Print completion candidates for the completion scripts",
+		Hidden:  true,
+		Run: func(app *App, args []string) error {
+			if len(args) == 0 {
+				args = []string{""}
+			}
+			current := args[len(args)-1]
+			for _, candidate := range app.completions(args[:len(args)-1]) {
+				if strings.HasPrefix(candidate, current) {
+					fmt.Fprintln(app.stdout, candidate)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// completions returns the candidates for the word after words. Flags are
+// not completed and are skipped when counting positional arguments, so
+// only flag values written as --flag=value are told apart from them.
+func (a *App) completions(words []string) []string {
+	for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+		if slices.Contains([]string{"--progress", "-progress", "--profile", "-profile"}, words[0]) {
+			words = words[1:]
+		}
+		words = words[1:]
+	}
+	if len(words) == 0 {
+		return a.commandNames()
+	}
+
+	command := words[0]
+	if english, ok := a.locale.command(command); ok {
+		command = english
+	}
+	var positional []string
+	for _, word := range words[1:] {
+		if !strings.HasPrefix(word, "-") {
+			positional = append(positional, word)
+		}
+	}
+	if subcommands, ok := subcommandNames[command]; ok {
+		if len(positional) == 0 {
+			return subcommands
+		}
+		command += " " + positional[0]
+		positional = positional[1:]
+	}
+
+	kinds := argumentCompletions[command]
+	if len(positional) >= len(kinds) {
+		return nil
+	}
+	switch kinds[len(positional)] {
+	case completeCategory:
+		return a.categoryNames(usecases.NewGetCategoriesUseCase(a.services()).Execute)
+	case completePickableCategory:
+		return a.categoryNames(usecases.NewGetCategoriesUseCase(a.services()).Pickable)
+	case completeOutfit:
+		return a.outfitNames(positional[len(positional)-1])
+	case completeShell:
+		return completionShells
+	case completeProfile:
+		return a.profileNames()
+	}
+	return nil
+}
+
+// commandNames returns the names of the commands shown in help, sorted.
+func (a *App) commandNames() []string {
+	var names []string
+	for name, cmd := range a.commands {
+		if !cmd.Hidden {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (a *App) categoryNames(list func() ([]entities.CategoryInfo, error)) []string {
+	infos, err := list()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(infos))
+	for i, info := range infos {
+		names[i] = info.Category.Name
+	}
+	return names
+}
+
+func (a *App) outfitNames(category string) []string {
+	reference, err := usecases.NewResolveCategoryUseCase(a.services()).Execute(category)
+	if err != nil {
+		return nil
+	}
+	names, err := usecases.NewGetCategoriesUseCase(a.services()).OutfitNames(reference.Name)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+func (a *App) profileNames() []string {
+	profiles, err := usecases.NewProfilesUseCase(a.services()).List()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(profiles))
+	for i, profile := range profiles {
+		names[i] = profile.Name
+	}
+	return names
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompletion_Scripts(t *testing.T) {
+	env := newCLIEnv(t, nil)
+	for _, shell := range completionShells {
+		stdout, stderr, code := env.run("completion", shell)
+		if code != ExitOK || !strings.Contains(stdout, "outfitpicker __complete") {
+			t.Errorf("completion %s: code = %v, stdout = %q, stderr = %q", shell, code, stdout, stderr)
+		}
+	}
+	if _, _, code := env.run("completion", "powershell"); code != ExitUsage {
+		t.Errorf("completion powershell: code = %v, want ExitUsage", code)
+	}
+}
+
+func TestCompletion_Candidates(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {"suit.avatar"}})
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"ro"}, []string{"rotation", "roulette"}},
+		{[]string{"--json", "pi"}, []string{"pick"}},
+		{[]string{"pick", ""}, []string{"casual", "formal"}},
+		{[]string{"pick", "--seed=7", "f"}, []string{"formal"}},
+		{[]string{"pick", "casual", ""}, nil},
+		{[]string{"tag", ""}, []string{"add", "list", "remove"}},
+		{[]string{"tag", "add", "casual", "t"}, []string{"tee.avatar"}},
+		{[]string{"favorite", "add", "formal", ""}, []string{"suit.avatar"}},
+		{[]string{"favorite", "add", "pyjamas", ""}, nil},
+		{[]string{"completion", ""}, []string{"bash", "fish", "zsh"}},
+		{[]string{"version", ""}, nil},
+	}
+	for _, tt := range tests {
+		stdout, stderr, code := env.run(append([]string{"__complete"}, tt.words...)...)
+		got := strings.Fields(stdout)
+		if code != ExitOK || !slices.Equal(got, tt.want) {
+			t.Errorf("__complete %q = %q (code %v, stderr %q), want %q", tt.words, got, code, stderr, tt.want)
+		}
+	}
+}
+
+func TestCompletion_WithoutConfiguration(t *testing.T) {
+	env := &cliEnv{t: t, stateDir: t.TempDir()}
+	stdout, stderr, code := env.run("__complete", "pick", "")
+	if code != ExitOK || stdout != "" || stderr != "" {
+		t.Errorf("__complete without a configuration: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+}
+
+func TestCompletion_SubcommandsMatchCommands(t *testing.T) {
+	env := newCLIEnv(t, nil)
+	for name := range New().commands {
+		_, stderr, _ := env.run(name)
+		_, listed, ok := strings.Cut(stderr, name+" requires a subcommand: ")
+		if want, known := subcommandNames[name]; ok != known {
+			t.Errorf("%s: subcommandNames has %q, but the command reports %q", name, want, stderr)
+			continue
+		}
+		if got := strings.Split(strings.TrimSpace(listed), ", "); ok && !slices.Equal(got, subcommandNames[name]) {
+			t.Errorf("%s: subcommandNames = %q, want %q", name, subcommandNames[name], got)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+# bash completion for outfitpicker
+# Load with: source <(outfitpicker completion bash)
+
+_outfitpicker() {
+    local cur=${COMP_WORDS[COMP_CWORD]}
+    local IFS=$'\n'
+    COMPREPLY=($(compgen -W "$(outfitpicker __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)" -- "$cur"))
+}
+
+complete -o default -F _outfitpicker outfitpicker
@@ -0,0 +1,9 @@
+# fish completion for outfitpicker
+# Load with: outfitpicker completion fish | source
+
+function __outfitpicker_complete
+    set -l words (commandline -opc)
+    outfitpicker __complete $words[2..-1] (commandline -ct) 2>/dev/null
+end
+
+complete -c outfitpicker -f -a '(__outfitpicker_complete)'
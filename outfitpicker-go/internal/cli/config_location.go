@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// Environment variables standing in for --config and --state-dir.
+const (
+	configEnv   = "OUTFITPICKER_CONFIG"
+	stateDirEnv = "OUTFITPICKER_STATE_DIR"
+)
+
+// resolveConfiguration settles where the configuration and state are kept.
+// State goes where --state-dir points, or else next to the configuration.
+func (a *App) resolveConfiguration() error {
+	if err := a.locateConfiguration(); err != nil {
+		return err
+	}
+	stateDir := cmp.Or(a.stateDir, os.Getenv(stateDirEnv))
+	if stateDir == "" {
+		return nil
+	}
+	dir, err := expandPath(stateDir)
+	if err != nil {
+		return err
+	}
+	a.directoryProvider = system.NewStateDirectoryProvider(a.directoryProvider, dir)
+	return nil
+}
+
+// locateConfiguration settles where the configuration is kept: where
+// --config points, or else the one default location holding one. When
+// more than one does, interactive sessions are asked which to use and
+// anything else fails with the places found.
+func (a *App) locateConfiguration() error {
+	if configPath := cmp.Or(a.configPath, os.Getenv(configEnv)); configPath != "" {
+		dir, err := configDirectory(configPath)
+		if err != nil {
+			return err
+		}
+		a.directoryProvider = system.NewAppDirectoryProvider(dir)
+		return nil
+	}
+
+	found, err := configuration.FindConfigurations(a.directoryProvider)
+	if err != nil || len(found) < 2 {
+		return err
+	}
+	ambiguous := domainerrors.NewAmbiguousConfigurationError(found)
+	if !a.isInteractive() || a.jsonOutput {
+		return ambiguous
+	}
+	dir, ok := a.chooseConfiguration(found)
+	if !ok {
+		return ambiguous
+	}
+	a.directoryProvider = system.NewAppDirectoryProvider(dir)
+	return nil
+}
+
+// chooseConfiguration asks which of the places found to use, and reports
+// false when no valid choice is made.
+func (a *App) chooseConfiguration(found []string) (string, bool) {
+	fmt.Fprintln(a.stderr, "A configuration was found in more than one place:")
+	for i, dir := range found {
+		fmt.Fprintf(a.stderr, "  %d) %s\n", i+1, dir)
+	}
+	answer, ok := a.prompt(bufio.NewScanner(a.stdin), fmt.Sprintf("Use which one? [1-%d] ", len(found)))
+	if !ok {
+		return "", false
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(found) {
+		return "", false
+	}
+	fmt.Fprintf(a.stderr, "Pass --config %s to skip this question, or remove the configuration you no longer use.\n", found[n-1])
+	return found[n-1], true
+}
+
+// configDirectory returns the app directory --config names: the directory
+// itself, or the one holding a config.json named directly.
+func configDirectory(path string) (string, error) {
+	path, err := expandPath(path)
+	if err != nil {
+		return "", err
+	}
+	if configuration.IsConfigFile(path) {
+		return filepath.Dir(path), nil
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		return "", usageErrorf("--config must be a directory or a config.json file, got %q", path)
+	}
+	return path, nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestConfigLocation_ConfigFlag(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	appDir := filepath.Join(env.stateDir, "outfitpicker")
+
+	for _, path := range []string{appDir, filepath.Join(appDir, "config.json")} {
+		if stdout, stderr, code := env.run("--config", path, "list"); code != ExitOK || !strings.Contains(stdout, "casual") {
+			t.Errorf("--config %s list: code = %v, stdout = %q, stderr = %q", path, code, stdout, stderr)
+		}
+	}
+
+	custom := filepath.Join(t.TempDir(), "wardrobe-settings")
+	if _, stderr, code := env.run("--config", custom, "setup", "--root", newWardrobeRoot(t)); code != ExitOK {
+		t.Fatalf("--config %s setup: code = %v, stderr = %q", custom, code, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(custom, "config.json")); err != nil {
+		t.Errorf("setup with --config did not save there: %v", err)
+	}
+
+	notConfig := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(notConfig, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, stderr, code := env.run("--config", notConfig, "list"); code != ExitUsage {
+		t.Errorf("--config of a file other than config.json: code = %v, want %v (stderr %q)", code, ExitUsage, stderr)
+	}
+	if _, _, code := env.run("--config", appDir, "--demo", "list"); code != ExitUsage {
+		t.Errorf("--config with --demo: code = %v, want %v", code, ExitUsage)
+	}
+}
+
+func TestConfigLocation_Ambiguous(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	otherRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(otherRoot, "formal"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(otherRoot, "formal", "suit.avatar"), []byte("suit"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherState := t.TempDir()
+	service := configuration.NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewStaticDirectoryProvider(otherState)))
+	if err := service.Save(testhelpers.NewConfig(otherRoot)); err != nil {
+		t.Fatal(err)
+	}
+	env.otherStateDirs = []string{otherState}
+	found := []string{filepath.Join(env.stateDir, "outfitpicker"), filepath.Join(otherState, "outfitpicker")}
+
+	_, stderr, code := env.run("list")
+	if code != 12 || !strings.Contains(stderr, found[0]) || !strings.Contains(stderr, found[1]) {
+		t.Errorf("list: code = %v, stderr = %q, want 12 naming both configurations", code, stderr)
+	}
+
+	_, stderr, code = env.run("--json", "list")
+	var output v1.Error
+	if err := json.Unmarshal([]byte(stderr), &output); err != nil {
+		t.Fatalf("--json error: %v\n%s", err, stderr)
+	}
+	if code != 12 || output.Code != 12 || len(output.Candidates) != 2 || output.Candidates[1] != found[1] {
+		t.Errorf("--json list: code = %v, output = %+v, want both candidates", code, output)
+	}
+
+	stdout, stderr, code := env.runInteractive("2\n", "list")
+	if code != ExitOK || !strings.Contains(stdout, "formal") || !strings.Contains(stderr, "--config "+found[1]) {
+		t.Errorf("choosing the second: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, _, code := env.runInteractive("3\n", "list"); code != 12 {
+		t.Errorf("choosing out of range: code = %v, want 12", code)
+	}
+
+	if stdout, _, code := env.run("--config", found[1], "list"); code != ExitOK || !strings.Contains(stdout, "formal") {
+		t.Errorf("--config settles the choice: code = %v, stdout = %q", code, stdout)
+	}
+}
+
+func TestConfigLocation_StateDir(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	appDir := filepath.Join(env.stateDir, "outfitpicker")
+	stateDir := filepath.Join(t.TempDir(), "state")
+
+	if _, stderr, code := env.run("--state-dir", stateDir, "pick", "casual"); code != ExitOK {
+		t.Fatalf("--state-dir pick: code = %v, stderr = %q", code, stderr)
+	}
+	entries, err := os.ReadDir(appDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if name := entry.Name(); strings.HasSuffix(name, ".json") && name != "config.json" {
+			t.Errorf("state file %s written next to the configuration", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, "history.json")); err != nil {
+		t.Errorf("pick did not record history in the state directory: %v", err)
+	}
+
+	t.Setenv(stateDirEnv, stateDir)
+	stdout, stderr, code := env.run("history", "list")
+	if code != ExitOK || !strings.Contains(stdout, "casual") {
+		t.Errorf("%s history: code = %v, stdout = %q, stderr = %q", stateDirEnv, code, stdout, stderr)
+	}
+	if _, _, code := env.run("--state-dir", stateDir, "--demo", "list"); code != ExitUsage {
+		t.Errorf("--state-dir with --demo: code = %v, want %v", code, ExitUsage)
+	}
+}
+
+func TestConfigLocation_ConfigEnv(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	elsewhere := t.TempDir()
+	t.Setenv(configEnv, filepath.Join(env.stateDir, "outfitpicker", "config.json"))
+	env.stateDir = elsewhere
+
+	if stdout, stderr, code := env.run("list"); code != ExitOK || !strings.Contains(stdout, "casual") {
+		t.Errorf("%s list: code = %v, stdout = %q, stderr = %q", configEnv, code, stdout, stderr)
+	}
+}
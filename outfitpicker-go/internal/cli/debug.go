@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/buildinfo"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func debugCommand() *Command {
+	return &Command{
+		Name:    "debug",
+		Summary: "Collect diagnostics for bug reports (bundle)",
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "debug", args, map[string]func(*App, []string) error{
+				"bundle": runDebugBundle,
+			})
+		},
+	}
+}
+
+func runDebugBundle(app *App, args []string) error {
+	fs := app.newFlagSet("debug bundle")
+	out := fs.String("out", "outfitpicker-debug.zip", "path of the zip file to write")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	logFiles, err := debugLogFiles(app)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	manifest, err := usecases.NewDebugBundleUseCase(app.services()).Write(f, usecases.DebugBundleRequest{
+		Build:    buildinfo.Current(),
+		LogFiles: logFiles,
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+
+	fmt.Fprintf(app.stdout, "Wrote %s with %d files.\n", *out, len(manifest.Files)+1)
+	fmt.Fprintln(app.stdout, "Paths are redacted and category and outfit names are hashed; review the contents before sharing.")
+	for _, note := range manifest.Notes {
+		fmt.Fprintf(app.stdout, "  note: %s\n", note)
+	}
+	return nil
+}
+
+// debugLogFiles returns the log file written by --log-file and the one it
+// was last rotated to, skipping any that do not exist yet.
+func debugLogFiles(app *App) ([]string, error) {
+	path, err := system.LogFilePath(app.directoryProvider)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, candidate := range []string{path, path + ".1"} {
+		if _, err := os.Stat(candidate); err == nil {
+			files = append(files, candidate)
+		}
+	}
+	return files, nil
+}
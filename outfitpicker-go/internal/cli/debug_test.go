@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDebugBundle(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	out := filepath.Join(t.TempDir(), "bundle.zip")
+	if _, stderr, code := env.run("--log-file", "pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("debug", "bundle", "--out", out)
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "Wrote "+out) {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	reader, err := zip.OpenReader(out)
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer reader.Close()
+	names := make(map[string]bool)
+	for _, f := range reader.File {
+		names[f.Name] = true
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"casual", "tee.avatar", env.root} {
+			if strings.Contains(string(content), secret) {
+				t.Errorf("%s leaks %q:\n%s", f.Name, secret, content)
+			}
+		}
+	}
+	for _, name := range []string{"manifest.json", "config.json", "history.json", "wearlog.json", "logs/outfitpicker.log"} {
+		if !names[name] {
+			t.Errorf("bundle files = %v, want %s", names, name)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	stdout, _, code := runApp(t, "version")
+	if code != ExitOK || !strings.HasPrefix(stdout, "outfitpicker dev") {
+		t.Errorf("version: code = %v, stdout = %q", code, stdout)
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func decorateCommand() *Command {
+	return &Command{
+		Name:    "decorate",
+		Summary: "Assign an emoji or color to a category",
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("decorate")
+			emoji := fs.String("emoji", "", "a single emoji shown before the category name")
+			color := fs.String("color", "", "a color name (red, blue, ...) or #RRGGBB")
+			clearDecoration := fs.Bool("clear", false, "remove the category's decoration")
+			positional, err := parseArgs(fs, args)
+			if err != nil {
+				return err
+			}
+			if len(positional) != 1 {
+				return usageErrorf("usage: decorate <category> [--emoji E] [--color C] [--clear]")
+			}
+			category := positional[0]
+
+			config, err := usecases.NewDecorateCategoryUseCase(app.services()).Execute(category, func(current entities.CategoryDecoration) entities.CategoryDecoration {
+				if *clearDecoration {
+					return entities.CategoryDecoration{}
+				}
+				if *emoji != "" {
+					current.Emoji = *emoji
+				}
+				if *color != "" {
+					current.Color = *color
+				}
+				return current
+			})
+			if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+				return fmt.Errorf("%w: emoji must be a single two-cell emoji and color a name or #RRGGBB", err)
+			}
+			if err != nil {
+				return err
+			}
+
+			decoration := config.Decoration(category)
+			if decoration.IsEmpty() {
+				fmt.Fprintf(app.stdout, "Removed decoration from %s.\n", category)
+				return nil
+			}
+			style := presentation.NewStyle(config, app.colorEnabled())
+			fmt.Fprintf(app.stdout, "Decorated %s.\n", style.CategoryLabel(category))
+			return nil
+		},
+	}
+}
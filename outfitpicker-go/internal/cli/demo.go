@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// demoDirectoryName is the directory under the OS temporary directory that
+// demo mode keeps its sample wardrobe and state in.
+const demoDirectoryName = "outfitpicker-demo"
+
+func demoCommand() *Command {
+	return &Command{
+		Name:    "demo",
+		Summary: "Manage the sample wardrobe that --demo runs commands against (reset)",
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "demo", args, map[string]func(*App, []string) error{
+				"reset": runDemoReset,
+			})
+		},
+	}
+}
+
+func runDemoReset(app *App, args []string) error {
+	fs := app.newFlagSet("demo reset")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("demo reset takes no arguments, got %q", fs.Arg(0))
+	}
+
+	demo := newDemoDirectoryProvider()
+	if err := demo.Reset(); err != nil {
+		return err
+	}
+	if err := app.useDemo(demo); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Reset the demo to the sample wardrobe in %s.\n", demo.WardrobeRoot())
+	return nil
+}
+
+func newDemoDirectoryProvider() *system.DemoDirectoryProvider {
+	return system.NewDemoDirectoryProvider(filepath.Join(os.TempDir(), demoDirectoryName))
+}
+
+// startDemo switches the app to the demo's sample wardrobe and state for
+// --demo, copying them into place the first time.
+func (a *App) startDemo() error {
+	demo := newDemoDirectoryProvider()
+	created, err := demo.Prepare()
+	if err != nil {
+		return err
+	}
+	if created {
+		fmt.Fprintf(a.stderr, "Demo mode: created a sample wardrobe in %s; your own wardrobe and settings are not touched. Run demo reset to start over.\n", demo.WardrobeRoot())
+	}
+	return a.useDemo(demo)
+}
+
+// useDemo stores state through demo and configures its sample wardrobe
+// unless a configuration is already there. The configuration is written
+// directly since the wardrobe is under the temporary directory, which
+// setup does not accept as a root.
+func (a *App) useDemo(demo *system.DemoDirectoryProvider) error {
+	a.directoryProvider = demo
+	a.signer = nil
+	services := a.servicesFor(entities.DefaultProfile)
+	_, err := services.Config.Load()
+	if !errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		return err
+	}
+	config := &entities.Config{
+		Roots:              []string{demo.WardrobeRoot()},
+		Language:           entities.DefaultLanguage,
+		ExcludedCategories: make(map[string]bool),
+		KnownCategories:    make(map[string]bool),
+		KnownCategoryFiles: make(map[string]map[string]bool),
+	}
+	_, err = usecases.NewOnboardingUseCase(services).Complete(config)
+	return err
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDemo(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	env := newCLIEnv(t, map[string][]string{"mine": {"own.avatar"}})
+
+	stdout, stderr, code := env.run("--demo", "list")
+	if code != ExitOK || !strings.Contains(stdout, "casual") || strings.Contains(stdout, "mine") {
+		t.Fatalf("--demo list: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if !strings.HasPrefix(stderr, "Demo mode: created a sample wardrobe") {
+		t.Errorf("--demo list stderr = %q, want the demo announced", stderr)
+	}
+	if stdout, _, code := env.run("--demo", "stats", "summary"); code != ExitOK || !strings.Contains(stdout, "  work/navy-suit.avatar ") {
+		t.Errorf("--demo stats summary: code = %v, stdout = %q, want the sample wears", code, stdout)
+	}
+
+	tee := filepath.Join(os.Getenv("TMPDIR"), demoDirectoryName, "wardrobe", "casual", "white-tee.avatar")
+	if err := os.Remove(tee); err != nil {
+		t.Fatal(err)
+	}
+	if _, stderr, code := env.run("--demo", "pick", "casual"); code != ExitOK || stderr != "" {
+		t.Errorf("--demo pick on a later run: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, code := env.run("list"); code != ExitOK || !strings.Contains(stdout, "mine") || strings.Contains(stdout, "casual") {
+		t.Errorf("list without --demo: code = %v, stdout = %q, want only the real wardrobe", code, stdout)
+	}
+
+	if stdout, _, code := env.run("demo", "reset"); code != ExitOK || !strings.HasPrefix(stdout, "Reset the demo") {
+		t.Errorf("demo reset: code = %v, stdout = %q", code, stdout)
+	}
+	if _, err := os.Stat(tee); err != nil {
+		t.Errorf("demo reset did not restore the sample outfit: %v", err)
+	}
+	if stdout, _, code := env.run("--demo", "list"); code != ExitOK || !strings.Contains(stdout, "casual") {
+		t.Errorf("--demo list after reset: code = %v, stdout = %q", code, stdout)
+	}
+}
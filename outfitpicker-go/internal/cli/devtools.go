@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/devtools"
+)
+
+func devtoolsCommand() *Command {
+	return &Command{
+		Name:    "devtools",
+		Summary: "Developer utilities",
+		Hidden:  true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "devtools", args, map[string]func(*App, []string) error{
+				"gen-wardrobe": runGenWardrobe,
+			})
+		},
+	}
+}
+
+func runGenWardrobe(app *App, args []string) error {
+	fs := app.newFlagSet("devtools gen-wardrobe")
+	out := fs.String("out", "", "directory to create the wardrobe in (required)")
+	shape := fs.String("shape", string(devtools.ShapeFlat), "wardrobe shape: flat, deep, unicode or huge")
+	categories := fs.Int("categories", 5, "number of populated categories")
+	outfits := fs.Int("outfits", 10, "outfits per category")
+	empty := fs.Int("empty", 0, "number of empty categories")
+	extra := fs.Int("extra-files", 0, "non-outfit files per category")
+	depth := fs.Int("depth", 0, "nesting depth for the deep shape")
+	huge := fs.Int("huge-size", 0, "outfit count of the largest category for the huge shape")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return usageErrorf("--out is required")
+	}
+
+	wardrobe, err := devtools.GenerateWardrobe(*out, devtools.WardrobeSpec{
+		Shape:              devtools.WardrobeShape(*shape),
+		Categories:         *categories,
+		OutfitsPerCategory: *outfits,
+		EmptyCategories:    *empty,
+		ExtraFiles:         *extra,
+		Depth:              *depth,
+		HugeCategorySize:   *huge,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(app.stdout, "Generated %d categories with %d outfits in %s\n",
+		len(wardrobe.Outfits), wardrobe.TotalOutfits(), wardrobe.Root)
+	return nil
+}
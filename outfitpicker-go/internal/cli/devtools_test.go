@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDevtools_GenWardrobe(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "wardrobe")
+	stdout, stderr, code := runApp(t, "devtools", "gen-wardrobe",
+		"--out", out, "--categories", "2", "--outfits", "3", "--empty", "1")
+
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "Generated 3 categories with 6 outfits") {
+		t.Errorf("stdout = %q", stdout)
+	}
+	entries, err := os.ReadDir(out)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("directories = %v, want 3", len(entries))
+	}
+}
+
+func TestDevtools_GenWardrobe_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"missing subcommand", []string{"devtools"}, ExitUsage},
+		{"unknown subcommand", []string{"devtools", "nope"}, ExitUsage},
+		{"missing out", []string{"devtools", "gen-wardrobe"}, ExitUsage},
+		{"bad flag", []string{"devtools", "gen-wardrobe", "--bogus"}, ExitUsage},
+		{"bad shape", []string{"devtools", "gen-wardrobe", "--out", t.TempDir(), "--shape", "spiral"}, ExitError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := runApp(t, tt.args...); code != tt.code {
+				t.Errorf("exit code = %v, want %v", code, tt.code)
+			}
+		})
+	}
+}
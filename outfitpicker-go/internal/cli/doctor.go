@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func doctorCommand() *Command {
+	return &Command{
+		Name:    "doctor",
+		Summary: "Score each category's health and explain which need attention",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("doctor")
+			deep := fs.Bool("deep", false, "also check the cache's worn outfits against the wear history")
+			repair := fs.String("repair", "", "with --deep, reconcile trusting \"history\" or \"cache\"")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return usageErrorf("doctor takes no arguments, got %q", fs.Arg(0))
+			}
+			if *repair != "" && !*deep {
+				return usageErrorf("--repair needs --deep")
+			}
+			health, err := usecases.NewCategoryHealthUseCase(app.services()).Execute()
+			if err != nil {
+				return err
+			}
+			if !*deep {
+				if app.jsonOutput {
+					return app.writeJSON(presentation.CategoryHealthV1(health))
+				}
+				return presentation.RenderCategoryHealth(app.stdout, health)
+			}
+			return runDoctorDeep(app, health, entities.RepairSource(*repair))
+		},
+	}
+}
+
+// runDoctorDeep reports category health and the wear discrepancies, repaired
+// from source when one is given.
+func runDoctorDeep(app *App, health map[string]logic.CategoryHealth, source entities.RepairSource) error {
+	consistency := usecases.NewWearConsistencyUseCase(app.services())
+	var discrepancies []entities.WearDiscrepancy
+	var err error
+	if source != "" {
+		discrepancies, err = consistency.Repair(source)
+	} else {
+		discrepancies, err = consistency.Check()
+	}
+	if err != nil {
+		return err
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(v1.Doctor{
+			Health:        presentation.CategoryHealthV1(health),
+			Discrepancies: presentation.ListV1(discrepancies, presentation.WearDiscrepancyV1),
+			RepairedFrom:  string(source),
+		})
+	}
+	if err := presentation.RenderCategoryHealth(app.stdout, health); err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout)
+	if source != "" {
+		return presentation.RenderWearRepair(app.stdout, discrepancies, source)
+	}
+	return presentation.RenderWearDiscrepancies(app.stdout, discrepancies)
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDoctor_Deep(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+
+	stdout, stderr, code := env.run("doctor", "--deep")
+	if code != ExitOK || !strings.HasSuffix(stdout, "\nWear history and cache agree.\n") {
+		t.Fatalf("doctor --deep: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	// Losing the cache leaves the wear in the history only.
+	if err := os.Remove(filepath.Join(env.stateDir, "outfitpicker", "cache.json")); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, _ = env.run("doctor", "--deep")
+	if !strings.Contains(stdout, "Wear history and cache disagree on 1 outfit:\n  casual/tee.avatar: worn in the wear history but not in the cache\n") {
+		t.Errorf("doctor --deep after losing the cache = %q", stdout)
+	}
+
+	stdout, _, _ = env.run("--json", "doctor", "--deep")
+	var report struct {
+		Discrepancies []struct {
+			FileName string `json:"fileName"`
+			Kind     string `json:"kind"`
+		} `json:"discrepancies"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil || len(report.Discrepancies) != 1 || report.Discrepancies[0].Kind != "history-only" {
+		t.Errorf("doctor --deep --json = %q, %v", stdout, err)
+	}
+
+	stdout, _, code = env.run("doctor", "--deep", "--repair", "history")
+	if code != ExitOK || !strings.Contains(stdout, "Repaired 1 outfit from the wear history:\n") {
+		t.Errorf("doctor --deep --repair history: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("doctor", "--deep"); !strings.HasSuffix(stdout, "\nWear history and cache agree.\n") {
+		t.Errorf("doctor --deep after repair = %q", stdout)
+	}
+}
+
+func TestDoctor_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"repair without deep", []string{"doctor", "--repair", "history"}, ExitUsage},
+		{"unknown repair source", []string{"doctor", "--deep", "--repair", "memory"}, ExitInvalidInput},
+		{"argument", []string{"doctor", "casual"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
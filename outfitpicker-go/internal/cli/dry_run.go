@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// writeDryRun reports what a command run with --dry-run would have done:
+// summary, such as the outfit it would pick, and the changes it would have
+// made to state files. result is the --json form of the summary.
+func writeDryRun[T any](app *App, summary string, result T, changes []usecases.StateChange) error {
+	if app.jsonOutput {
+		return app.writeJSON(v1.DryRun[T]{DryRun: true, Result: result, Changes: presentation.StateChangesV1(changes)})
+	}
+	fmt.Fprintln(app.stdout, summary)
+	fmt.Fprintln(app.stdout, "Would change:")
+	for _, change := range changes {
+		fmt.Fprintf(app.stdout, "  %s: %s\n", change.Store, change.Change)
+	}
+	fmt.Fprintln(app.stdout, "Nothing was saved (--dry-run).")
+	return nil
+}
+
+// refuseDryRun fails with a usage error when --dry-run is given to a
+// subcommand that cannot honor it.
+func refuseDryRun(app *App, command string) error {
+	if app.dryRun {
+		return usageErrorf("%s does not support --dry-run", command)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDryRun_Pick(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.run("--dry-run", "pick", "casual")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Would pick casual/tee.avatar\nWould change:\n") ||
+		!strings.Contains(stdout, "  history: record the pick of casual/tee.avatar\n") || !strings.HasSuffix(stdout, "Nothing was saved (--dry-run).\n") {
+		t.Fatalf("pick --dry-run: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("history", "list"); stdout != "No history yet.\n" {
+		t.Errorf("history after a dry run = %q, want nothing recorded", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "--dry-run", "pick", "--all")
+	var output struct {
+		DryRun bool `json:"dryRun"`
+		Result struct {
+			Outfit struct {
+				FileName string `json:"fileName"`
+			} `json:"outfit"`
+		} `json:"result"`
+		Changes []struct {
+			Store string `json:"store"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || code != ExitOK {
+		t.Fatalf("pick --all --dry-run --json: code = %v, %v\n%s", code, err, stdout)
+	}
+	if !output.DryRun || output.Result.Outfit.FileName != "tee.avatar" || len(output.Changes) == 0 || output.Changes[len(output.Changes)-1].Store != "category picks" {
+		t.Errorf("pick --all --dry-run = %+v, want the pick and its changes", output)
+	}
+	if stdout, _, _ := env.run("history", "list"); stdout != "No history yet.\n" {
+		t.Errorf("history after a dry run of pick --all = %q, want nothing recorded", stdout)
+	}
+}
+
+func TestDryRun_RotationReset(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+
+	for range 2 {
+		stdout, stderr, code := env.run("--dry-run", "rotation", "reset", "casual")
+		if code != ExitOK || !strings.HasPrefix(stdout, "Would start a new rotation of casual: 1 of 2 outfits worn would be unworn again\n") {
+			t.Fatalf("rotation reset --dry-run: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+		}
+	}
+	if stdout, _, code := env.run("rotation", "reset", "casual"); code != ExitOK || stdout != "Started a new rotation of casual.\n" {
+		t.Errorf("rotation reset: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("--dry-run", "rotation", "reset", "casual"); !strings.Contains(stdout, "0 of 2 outfits worn") {
+		t.Errorf("rotation reset --dry-run after a reset = %q, want nothing worn", stdout)
+	}
+}
+
+func TestDryRun_Unsupported(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	for _, args := range [][]string{
+		{"--dry-run", "undo"},
+		{"--dry-run", "rotation", "lock", "casual"},
+	} {
+		if _, stderr, code := env.run(args...); code != ExitUsage || !strings.Contains(stderr, "does not support --dry-run") {
+			t.Errorf("%v: code = %v, stderr = %q", args, code, stderr)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// ensembleKeyword is what pick takes in place of a category to pick an
+// ensemble.
+const ensembleKeyword = "ensemble"
+
+func ensembleCommand() *Command {
+	return &Command{
+		Name:    "ensemble",
+		Summary: "Set the slots categories fill and what goes together, for pick ensemble (show, slot, clash, rule, max-colors, clear)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "ensemble", args, map[string]func(*App, []string) error{
+				"show":       runEnsembleShow,
+				"slot":       runEnsembleSlot,
+				"clash":      runEnsembleClash,
+				"rule":       runEnsembleRule,
+				"max-colors": runEnsembleMaxColors,
+				"clear":      runEnsembleClear,
+			})
+		},
+	}
+}
+
+func runEnsembleShow(app *App, args []string) error {
+	fs := app.newFlagSet("ensemble show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("ensemble show takes no arguments, got %q", fs.Arg(0))
+	}
+	slots, settings, err := usecases.NewEnsembleUseCase(app.services()).Slots()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.EnsembleV1(slots, settings))
+	}
+	return presentation.RenderEnsembleSettings(app.stdout, slots, settings)
+}
+
+func runEnsembleSlot(app *App, args []string) error {
+	if err := refuseJSON(app, "ensemble slot"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("ensemble slot"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usageErrorf("usage: ensemble slot <category> <slot>|none")
+	}
+	services := app.services()
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	slot := positional[1]
+	if slot == "none" {
+		slot = ""
+	}
+	if _, err := usecases.NewEnsembleUseCase(services).Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		return current.SettingSlot(category.Name, slot)
+	}); err != nil {
+		return err
+	}
+	if slot == "" {
+		fmt.Fprintf(app.stdout, "%s fills the slot in its category.json, if any.\n", category.Name)
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "%s fills the %s slot.\n", category.Name, slot)
+	return nil
+}
+
+func runEnsembleClash(app *App, args []string) error {
+	if err := refuseJSON(app, "ensemble clash"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("ensemble clash"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 || positional[0] != "tag" && positional[0] != "color" {
+		return usageErrorf("usage: ensemble clash tag|color <a> <b>")
+	}
+	kind, a, b := positional[0], positional[1], positional[2]
+	if _, err := usecases.NewEnsembleUseCase(app.services()).Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		if kind == "tag" {
+			current.TagConflicts = entities.AddingRule(current.TagConflicts, a, b)
+		} else {
+			current.ColorClashes = entities.AddingRule(current.ColorClashes, a, b)
+		}
+		return current
+	}); err != nil {
+		return err
+	}
+	if a == b {
+		fmt.Fprintf(app.stdout, "At most one outfit of an ensemble has %s %s.\n", kind, a)
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Outfits with %s %s and %s %s are not picked together.\n", kind, a, kind, b)
+	return nil
+}
+
+func runEnsembleRule(app *App, args []string) error {
+	if err := refuseJSON(app, "ensemble rule"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("ensemble rule"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usageErrorf("usage: ensemble rule <name> = <colors> | avoid <colors> with <colors>")
+	}
+	rule := strings.Join(positional, " ")
+	if _, err := usecases.NewEnsembleUseCase(app.services()).Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		if !slices.Contains(current.ColorRules, rule) {
+			current.ColorRules = append(slices.Clone(current.ColorRules), rule)
+		}
+		return current
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Added the color rule: %s\n", rule)
+	return nil
+}
+
+func runEnsembleMaxColors(app *App, args []string) error {
+	if err := refuseJSON(app, "ensemble max-colors"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("ensemble max-colors"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: ensemble max-colors N")
+	}
+	n, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return usageErrorf("the most colors must be a number, got %q", positional[0])
+	}
+	if _, err := usecases.NewEnsembleUseCase(app.services()).Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		current.MaxColors = n
+		return current
+	}); err != nil {
+		return err
+	}
+	if n == 0 {
+		fmt.Fprintln(app.stdout, "Ensembles may have any number of colors.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Ensembles have at most %d colors.\n", n)
+	return nil
+}
+
+func runEnsembleClear(app *App, args []string) error {
+	if err := refuseJSON(app, "ensemble clear"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("ensemble clear")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("ensemble clear takes no arguments, got %q", fs.Arg(0))
+	}
+	if _, err := usecases.NewEnsembleUseCase(app.services()).Update(func(current entities.EnsembleSettings) entities.EnsembleSettings {
+		return entities.EnsembleSettings{Slots: current.Slots}
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout, "Removed the compatibility rules; any outfits go together.")
+	return nil
+}
+
+// runPickEnsemble picks an outfit for every slot of an ensemble.
+func runPickEnsemble(app *App, services usecases.Services, opts []usecases.PickOption) error {
+	ensemble := usecases.NewEnsembleUseCase(services)
+	pick, err := ensemble.Propose(opts...)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		return writeDryRun(app, fmt.Sprintf("Would pick an ensemble of %d outfits", len(pick.Pieces)), presentation.EnsemblePickV1(*pick), pick.Changes())
+	}
+	if err := ensemble.Commit(pick); err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.EnsemblePickV1(*pick))
+	}
+	return presentation.RenderEnsemble(app.stdout, pick.Pieces)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestEnsemble(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{
+		"shirts":   {"tee.avatar", "blouse.avatar"},
+		"trousers": {"chinos.avatar"},
+	})
+	for _, args := range [][]string{
+		{"ensemble", "slot", "shirts", "top"},
+		{"ensemble", "slot", "trousers", "bottom"},
+		{"metadata", "set", "shirts", "tee.avatar", "--colors", "red"},
+		{"metadata", "set", "shirts", "blouse.avatar", "--colors", "white"},
+		{"metadata", "set", "trousers", "chinos.avatar", "--colors", "pink"},
+		{"ensemble", "clash", "color", "red", "pink"},
+		{"ensemble", "max-colors", "3"},
+		{"ensemble", "rule", "warm", "=", "red,", "orange"},
+		{"ensemble", "rule", "avoid", "warm", "with", "orange"},
+	} {
+		if _, stderr, code := env.run(args...); code != ExitOK {
+			t.Fatalf("%v: code = %v, stderr = %q", args, code, stderr)
+		}
+	}
+
+	stdout, _, code := env.run("ensemble", "show")
+	want := "Slots:\n  bottom: trousers\n  top: shirts\nRules:\n  colors red and pink clash\n  color rule: warm = red, orange\n  color rule: avoid warm with orange\n  at most 3 colors\n"
+	if code != ExitOK || stdout != want {
+		t.Errorf("ensemble show = %q, want %q", stdout, want)
+	}
+
+	stdout, stderr, code := env.run("pick", "ensemble")
+	if want := "bottom: trousers/chinos.avatar\ntop:    shirts/blouse.avatar\n"; code != ExitOK || stdout != want {
+		t.Errorf("pick ensemble: code = %v, stdout = %q, want %q (stderr %q)", code, stdout, want, stderr)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "shirts", "tee.avatar"); !strings.Contains(stdout, "Colors:    red") {
+		t.Errorf("metadata show = %q, want the colors", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "--dry-run", "pick", "ensemble")
+	var dryRun struct {
+		DryRun bool `json:"dryRun"`
+		Result struct {
+			Pieces []struct {
+				Slot string `json:"slot"`
+			} `json:"pieces"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &dryRun); err != nil || code != ExitOK || !dryRun.DryRun || len(dryRun.Result.Pieces) != 2 {
+		t.Errorf("pick ensemble --dry-run --json = %q, %v", stdout, err)
+	}
+
+	if _, _, code := env.run("ensemble", "clear"); code != ExitOK {
+		t.Fatalf("ensemble clear: code = %v", code)
+	}
+	if stdout, _, _ := env.run("ensemble", "show"); !strings.HasSuffix(stdout, "No compatibility rules; any outfits go together.\n") {
+		t.Errorf("ensemble show after clear = %q", stdout)
+	}
+}
+
+func TestEnsemble_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"pick without slots", []string{"pick", "ensemble"}, ExitInvalidInput},
+		{"slot of an unknown category", []string{"ensemble", "slot", "pyjamas", "top"}, ExitCategoryNotFound},
+		{"bad slot", []string{"ensemble", "slot", "casual", "Top"}, ExitInvalidInput},
+		{"clash of an unknown kind", []string{"ensemble", "clash", "fabric", "wool", "silk"}, ExitUsage},
+		{"max colors not a number", []string{"ensemble", "max-colors", "lots"}, ExitUsage},
+		{"max colors out of range", []string{"ensemble", "max-colors", "99"}, ExitInvalidInput},
+		{"rule without words", []string{"ensemble", "rule"}, ExitUsage},
+		{"malformed color rule", []string{"ensemble", "rule", "clash", "red", "pink"}, ExitInvalidInput},
+		{"bad color", []string{"metadata", "set", "casual", "tee.avatar", "--colors", "Navy Blue"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
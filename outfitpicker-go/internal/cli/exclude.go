@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func excludeCommand() *Command {
+	return &Command{
+		Name:    "exclude",
+		Summary: "Leave a category out of picks, for good or --for a while; list exclusions without one",
+		JSON:    true,
+		Run:     runExclude,
+	}
+}
+
+func runExclude(app *App, args []string) error {
+	fs := app.newFlagSet("exclude")
+	period := fs.String("for", "", "include the category again after this long, e.g. 3d, 2w or 36h (default until include)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	services := app.services()
+	exclusions := usecases.NewCategoryExclusionUseCase(services)
+	if len(positional) == 0 {
+		if *period != "" {
+			return usageErrorf("usage: exclude <category> [--for 2w]")
+		}
+		excluded, err := exclusions.List()
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return app.writeJSON(presentation.ListV1(excluded, presentation.CategoryExclusionV1))
+		}
+		return renderExclusions(app, excluded)
+	}
+	if len(positional) > 1 {
+		return usageErrorf("usage: exclude <category> [--for 2w]")
+	}
+
+	var duration time.Duration
+	if *period != "" {
+		if duration, err = entities.ParseExclusionPeriod(*period); err != nil {
+			return err
+		}
+	}
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	until, err := exclusions.Exclude(category.Name, duration)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.Exclusion{Excluded: v1.CategoryExclusion{Category: category.Name, Until: until}})
+	}
+	if until.IsZero() {
+		fmt.Fprintf(app.stdout, "Excluded %s until you run: include %s\n", category.Name, category.Name)
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Excluded %s until %s.\n", category.Name, until.Local().Format(watchTimeLayout))
+	return nil
+}
+
+func renderExclusions(app *App, excluded []entities.CategoryExclusion) error {
+	if len(excluded) == 0 {
+		fmt.Fprintln(app.stdout, "No categories are excluded.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(app.stdout, 0, 4, 2, ' ', 0)
+	for _, exclusion := range excluded {
+		until := "until included"
+		if !exclusion.Until.IsZero() {
+			until = "until " + exclusion.Until.Local().Format(watchTimeLayout)
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", exclusion.Category, until)
+	}
+	return tw.Flush()
+}
+
+func includeCommand() *Command {
+	return &Command{
+		Name:    "include",
+		Summary: "Include an excluded category in picks again",
+		Run: func(app *App, args []string) error {
+			positional, err := parseArgs(app.newFlagSet("include"), args)
+			if err != nil {
+				return err
+			}
+			if len(positional) != 1 {
+				return usageErrorf("usage: include <category>")
+			}
+			services := app.services()
+			// Categories that no longer exist can still be included.
+			name := positional[0]
+			if category, err := usecases.NewResolveCategoryUseCase(services).Execute(name); err == nil {
+				name = category.Name
+			}
+			if err := usecases.NewCategoryExclusionUseCase(services).Include(name); err != nil {
+				return err
+			}
+			fmt.Fprintf(app.stdout, "Included %s again.\n", name)
+			return nil
+		},
+	}
+}
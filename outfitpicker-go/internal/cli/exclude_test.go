@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExclude_ForAWhile(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "office": {"suit.avatar"}})
+
+	stdout, stderr, code := env.run("exclude", "office", "--for", "2w")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Excluded office until ") {
+		t.Fatalf("exclude --for: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, code := env.run("pick", "--all"); code != ExitOK || strings.Contains(stdout, "office") {
+		t.Errorf("pick --all while office is excluded: code = %v, stdout = %q", code, stdout)
+	}
+
+	stdout, _, code = env.run("--json", "exclude")
+	var exclusions []struct {
+		Category string    `json:"category"`
+		Until    time.Time `json:"until"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &exclusions); err != nil || code != ExitOK {
+		t.Fatalf("exclude --json: code = %v, %v\n%s", code, err, stdout)
+	}
+	if len(exclusions) != 1 || exclusions[0].Category != "office" || time.Until(exclusions[0].Until) < 13*24*time.Hour {
+		t.Errorf("exclusions = %+v, want office for two weeks", exclusions)
+	}
+
+	if stdout, _, code := env.run("include", "office"); code != ExitOK || stdout != "Included office again.\n" {
+		t.Errorf("include: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("exclude"); stdout != "No categories are excluded.\n" {
+		t.Errorf("exclude after include = %q", stdout)
+	}
+}
+
+func TestExclude_ForGood(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "formal": {"suit.avatar"}})
+	if stdout, stderr, code := env.run("exclude", "formal"); code != ExitOK || !strings.Contains(stdout, "include formal") {
+		t.Fatalf("exclude: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("exclude"); !strings.Contains(stdout, "formal") || !strings.Contains(stdout, "until included") {
+		t.Errorf("exclude list = %q", stdout)
+	}
+}
+
+func TestExclude_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"bad period", []string{"exclude", "casual", "--for", "soon"}, ExitInvalidInput},
+		{"period without category", []string{"exclude", "--for", "2w"}, ExitUsage},
+		{"two categories", []string{"exclude", "casual", "formal"}, ExitUsage},
+		{"unknown category", []string{"exclude", "formal"}, ExitCategoryNotFound},
+		{"include not excluded", []string{"include", "casual"}, ExitInvalidInput},
+		{"include without category", []string{"include"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/buildinfo"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func exportCommand() *Command {
+	return &Command{
+		Name:    "export",
+		Summary: "Export a category as a shareable outfit pack, or the whole state for another machine (pack, bundle)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "export", args, map[string]func(*App, []string) error{
+				"pack":   runExportPack,
+				"bundle": runExportBundle,
+			})
+		},
+	}
+}
+
+func runExportPack(app *App, args []string) error {
+	fs := app.newFlagSet("export pack")
+	out := fs.String("out", "", "path of the zip file to write (default <category>.zip)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: export pack <category> [--out file.zip]")
+	}
+
+	services := app.services()
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	path := *out
+	if path == "" {
+		path = category.Name + ".zip"
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	manifest, err := usecases.NewExportPackUseCase(services).Write(f, category.Name)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(presentation.PackManifestV1(*manifest))
+	}
+	return presentation.RenderPackExport(app.stdout, path, manifest)
+}
+
+// defaultBundleName is the file export bundle writes when --out is not
+// given.
+const defaultBundleName = "outfitpicker-state.tar.gz"
+
+func runExportBundle(app *App, args []string) error {
+	fs := app.newFlagSet("export bundle")
+	out := fs.String("out", defaultBundleName, "path of the tar.gz file to write")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageErrorf("usage: export bundle [--out file.tar.gz]")
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	manifest, err := usecases.NewStateBundleUseCase(app.services()).Export(f, buildinfo.Current().Version)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(presentation.BundleManifestV1(*manifest))
+	}
+	return presentation.RenderStateBundleExport(app.stdout, *out, manifest)
+}
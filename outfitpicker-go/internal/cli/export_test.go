@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportPack(t *testing.T) {
+	source := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	pack := filepath.Join(t.TempDir(), "casual.zip")
+
+	stdout, stderr, code := source.run("export", "pack", "casual", "--out", pack)
+	if code != ExitOK {
+		t.Fatalf("export pack: code = %v, stderr = %q", code, stderr)
+	}
+	want := "Wrote " + pack + " with 2 outfits from casual.\n" +
+		"Prices, wear history and paths are left out; add it to a wardrobe with 'outfitpicker import archive'.\n"
+	if stdout != want {
+		t.Errorf("export pack = %q, want %q", stdout, want)
+	}
+
+	target := newCLIEnv(t, map[string][]string{"work": {"blazer.avatar"}})
+	stdout, stderr, code = target.run("import", "archive", pack, "--into", "casual")
+	if code != ExitOK || stdout != "Imported 2 outfits into casual (2 outfits now).\n" {
+		t.Errorf("import of exported pack: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+}
+
+func TestExportPack_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "empty": nil})
+	dir := t.TempDir()
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing category", []string{"export", "pack"}, ExitUsage},
+		{"unknown category", []string{"export", "pack", "nope", "--out", filepath.Join(dir, "nope.zip")}, ExitCategoryNotFound},
+		{"empty category", []string{"export", "pack", "empty", "--out", filepath.Join(dir, "empty.zip")}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+	if _, err := os.Stat(filepath.Join(dir, "empty.zip")); !os.IsNotExist(err) {
+		t.Errorf("failed export left a file behind: %v", err)
+	}
+}
+
+func TestExportBundle(t *testing.T) {
+	source := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := source.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	bundle := filepath.Join(t.TempDir(), "state.tar.gz")
+	stdout, stderr, code := source.run("export", "bundle", "--out", bundle)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Wrote "+bundle+" with config.json") {
+		t.Fatalf("export bundle: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	target := newCLIEnv(t, nil)
+	stdout, stderr, code = target.run("--dry-run", "import", "bundle", bundle)
+	if code != ExitOK || !strings.Contains(stdout, "replace with 1 pick") {
+		t.Fatalf("import bundle --dry-run: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	stdout, stderr, code = target.run("import", "bundle", bundle)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Imported the state bundle written by outfitpicker ") || !strings.Contains(stdout, "saved as backup") {
+		t.Fatalf("import bundle: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	stdout, _, _ = target.run("history", "list")
+	if !strings.Contains(stdout, "casual/tee.avatar") {
+		t.Errorf("history list after import = %q, want the bundled pick", stdout)
+	}
+
+	if _, _, code := target.run("import", "bundle", filepath.Join(t.TempDir(), "missing.tar.gz")); code == ExitOK {
+		t.Error("import of a missing bundle succeeded")
+	}
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func favoriteCommand() *Command {
+	return &Command{
+		Name:    "favorite",
+		Summary: "Mark outfits as favorites for pick --favorites-only (add, remove, list)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "favorite", args, map[string]func(*App, []string) error{
+				"add":    runFavoriteAdd,
+				"remove": runFavoriteRemove,
+				"list":   runFavoriteList,
+			})
+		},
+	}
+}
+
+func runFavoriteAdd(app *App, args []string) error {
+	if err := refuseJSON(app, "favorite add"); err != nil {
+		return err
+	}
+	outfit, err := app.favoriteOutfit("add", args)
+	if err != nil {
+		return err
+	}
+	if err := usecases.NewFavoritesUseCase(app.services()).Add(outfit); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Added %s/%s to favorites.\n", outfit.Category.Name, outfit.FileName)
+	return nil
+}
+
+func runFavoriteRemove(app *App, args []string) error {
+	if err := refuseJSON(app, "favorite remove"); err != nil {
+		return err
+	}
+	outfit, err := app.favoriteOutfit("remove", args)
+	if err != nil {
+		return err
+	}
+	if err := usecases.NewFavoritesUseCase(app.services()).Remove(outfit); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Removed %s/%s from favorites.\n", outfit.Category.Name, outfit.FileName)
+	return nil
+}
+
+func runFavoriteList(app *App, args []string) error {
+	fs := app.newFlagSet("favorite list")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("favorite list takes no arguments, got %q", fs.Arg(0))
+	}
+
+	favorites, err := usecases.NewFavoritesUseCase(app.services()).List()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.FavoritesV1(favorites))
+	}
+	return presentation.RenderFavorites(app.stdout, favorites)
+}
+
+// favoriteOutfit parses the <category> <outfit> arguments of favorite add
+// and remove.
+func (a *App) favoriteOutfit(subcommand string, args []string) (entities.OutfitReference, error) {
+	positional, err := parseArgs(a.newFlagSet("favorite "+subcommand), args)
+	if err != nil {
+		return entities.OutfitReference{}, err
+	}
+	if len(positional) != 2 {
+		return entities.OutfitReference{}, usageErrorf("usage: favorite %s <category> <outfit>", subcommand)
+	}
+	return a.outfitReference(positional[0], positional[1])
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFavorite_AddListRemove(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+
+	if stdout, _, _ := env.run("favorite", "list"); stdout != "No favorites yet.\n" {
+		t.Errorf("favorite list before adding = %q", stdout)
+	}
+	stdout, stderr, code := env.run("favorite", "add", "casual", "tee.avatar")
+	if code != ExitOK || stdout != "Added casual/tee.avatar to favorites.\n" {
+		t.Fatalf("favorite add: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("favorite", "list"); stdout != "casual/tee.avatar\n" {
+		t.Errorf("favorite list = %q", stdout)
+	}
+	stdout, _, _ = env.run("--json", "favorite", "list")
+	var favorites map[string][]string
+	if err := json.Unmarshal([]byte(stdout), &favorites); err != nil || len(favorites["casual"]) != 1 {
+		t.Errorf("favorite list --json = %q, %v", stdout, err)
+	}
+
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual", "--favorites-only"); stdout != "casual/tee.avatar\n" {
+			t.Fatalf("pick --favorites-only = %q", stdout)
+		}
+	}
+
+	if _, stderr, code := env.run("favorite", "remove", "casual", "tee.avatar"); code != ExitOK {
+		t.Fatalf("favorite remove: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("pick", "casual", "--favorites-only"); code != ExitInvalidInput {
+		t.Errorf("pick --favorites-only without favorites: code = %v, want %v", code, ExitInvalidInput)
+	}
+}
+
+func TestFavorite_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing outfit", []string{"favorite", "add", "casual"}, ExitUsage},
+		{"unknown outfit", []string{"favorite", "add", "casual", "nope.avatar"}, ExitInvalidInput},
+		{"not a favorite", []string{"favorite", "remove", "casual", "tee.avatar"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"favorite", "pin"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
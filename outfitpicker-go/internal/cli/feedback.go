@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func feedbackCommand() *Command {
+	return &Command{
+		Name:    "feedback",
+		Summary: "Record or show feedback on worn outfits (add, show)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "feedback", args, map[string]func(*App, []string) error{
+				"add":  runFeedbackAdd,
+				"show": runFeedbackShow,
+			})
+		},
+	}
+}
+
+func runFeedbackAdd(app *App, args []string) error {
+	fs := app.newFlagSet("feedback add")
+	note := fs.String("note", "", "free-text note to keep with the wear")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 {
+		return usageErrorf("usage: feedback add <category> <outfit> <%s> [--note TEXT]", joinFeedbackKinds())
+	}
+	kind, err := entities.ParseFeedbackKind(positional[2])
+	if err != nil {
+		return err
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	useCase := usecases.NewFeedbackUseCase(app.services())
+	if err := useCase.Add(outfit, kind, *note); err != nil {
+		return err
+	}
+	feedback, err := useCase.Show(outfit)
+	if err != nil {
+		return err
+	}
+	return renderFeedback(app, feedback)
+}
+
+func runFeedbackShow(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("feedback show"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usageErrorf("usage: feedback show <category> <outfit>")
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	feedback, err := usecases.NewFeedbackUseCase(app.services()).Show(outfit)
+	if err != nil {
+		return err
+	}
+	return renderFeedback(app, feedback)
+}
+
+func renderFeedback(app *App, feedback *usecases.OutfitFeedback) error {
+	if app.jsonOutput {
+		return app.writeJSON(presentation.OutfitFeedbackV1(*feedback))
+	}
+	return presentation.RenderOutfitFeedback(app.stdout, feedback)
+}
+
+func joinFeedbackKinds() string {
+	kinds := entities.FeedbackKinds()
+	names := make([]string, len(kinds))
+	for i, kind := range kinds {
+		names[i] = string(kind)
+	}
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFeedbackAddAndShow(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	if _, _, code := env.run("feedback", "add", "casual", "tee.avatar", "compliment"); code != ExitInvalidInput {
+		t.Errorf("feedback before wearing: code = %v, want ExitInvalidInput", code)
+	}
+	env.wear(t, "casual", "tee.avatar")
+
+	stdout, stderr, code := env.run("feedback", "add", "casual", "tee.avatar", "compliment", "--note", "great at dinner")
+	if code != ExitOK {
+		t.Fatalf("feedback add: code = %v, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{"Score:           +1", "compliment", `"great at dinner"`} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("feedback add output missing %q:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "Last compliment: never") {
+		t.Errorf("last compliment not recorded:\n%s", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "feedback", "show", "casual", "tee.avatar")
+	if code != ExitOK {
+		t.Fatalf("feedback show: code = %v", code)
+	}
+	var shown struct {
+		Score          int     `json:"score"`
+		LastCompliment *string `json:"lastCompliment"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &shown); err != nil {
+		t.Fatalf("feedback show JSON: %v\n%s", err, stdout)
+	}
+	if shown.Score != 1 || shown.LastCompliment == nil {
+		t.Errorf("feedback show = %+v", shown)
+	}
+
+	if stdout, _, _ := env.run("feedback", "show", "casual", "jeans.avatar"); !strings.Contains(stdout, "Not worn yet.") {
+		t.Errorf("feedback show unworn = %q", stdout)
+	}
+}
+
+func TestFeedback_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing kind", []string{"feedback", "add", "casual", "tee.avatar"}, ExitUsage},
+		{"unknown kind", []string{"feedback", "add", "casual", "tee.avatar", "meh"}, ExitInvalidInput},
+		{"show missing outfit", []string{"feedback", "show", "casual"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// historyDateLayout is the format of --since values.
+const historyDateLayout = "2006-01-02"
+
+func historyCommand() *Command {
+	return &Command{
+		Name:    "history",
+		Summary: "Show or clear what was picked and worn when, and the commands run (list, clear, commands, export)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "history", args, map[string]func(*App, []string) error{
+				"list":     runHistoryList,
+				"clear":    runHistoryClear,
+				"commands": runHistoryCommands,
+				"export":   runHistoryExport,
+			})
+		},
+	}
+}
+
+func runHistoryList(app *App, args []string) error {
+	fs := app.newFlagSet("history list")
+	category := fs.String("category", "", "only show this category")
+	limit := fs.Int("limit", entities.DefaultHistoryLimit, "entries per page")
+	pageNumber := fs.Int("page", 1, "page number, starting at 1")
+	since := fs.String("since", "", "only show entries on or after this date (YYYY-MM-DD)")
+	worn := fs.Bool("worn", false, "list outfits worn, with feedback, instead of picks")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("history list takes no arguments, got %q", fs.Arg(0))
+	}
+
+	query := entities.HistoryQuery{Limit: *limit, Page: *pageNumber}
+	if *since != "" {
+		start, err := time.ParseInLocation(historyDateLayout, *since, time.Local)
+		if err != nil {
+			return usageErrorf("--since must be a date like 2024-01-31, got %q", *since)
+		}
+		query.Since = start
+	}
+	services := app.services()
+	if *category != "" {
+		reference, err := usecases.NewResolveCategoryUseCase(services).Execute(*category)
+		if err != nil {
+			return err
+		}
+		query.Category = reference.Name
+	}
+
+	history := usecases.NewHistoryUseCase(services)
+	if *worn {
+		page, err := history.Worn(query)
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return app.writeJSON(presentation.PageV1(page, presentation.WearV1))
+		}
+		return presentation.RenderWearHistory(app.stdout, page)
+	}
+
+	page, err := history.List(query)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.PageV1(page, presentation.PickV1))
+	}
+	return presentation.RenderSelectionHistory(app.stdout, page)
+}
+
+func runHistoryClear(app *App, args []string) error {
+	if err := refuseJSON(app, "history clear"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("history clear")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("history clear takes no arguments, got %q", fs.Arg(0))
+	}
+
+	removed, err := usecases.NewHistoryUseCase(app.services()).Clear()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Cleared %d picks from the history.\n", removed)
+	return nil
+}
+
+func runHistoryCommands(app *App, args []string) error {
+	fs := app.newFlagSet("history commands")
+	limit := fs.Int("limit", entities.DefaultHistoryLimit, "number of commands to show, newest first (0 for all)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("history commands takes no arguments, got %q", fs.Arg(0))
+	}
+
+	records, err := app.commandHistory().List(*limit)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(records, presentation.CommandRecordV1))
+	}
+	return presentation.RenderCommandHistory(app.stdout, records)
+}
+
+func runHistoryExport(app *App, args []string) error {
+	fs := app.newFlagSet("history export")
+	out := fs.String("out", "", "path of the JSON file to write (default standard output)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("history export takes no arguments, got %q", fs.Arg(0))
+	}
+	if *out == "" && app.fields != nil {
+		return usageErrorf("history export writes the whole export to standard output; pass --out to use --fields on its summary")
+	}
+
+	export, err := usecases.NewHistoryUseCase(app.services()).Export()
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return presentation.WriteHistoryExport(app.stdout, export)
+	}
+
+	err = system.WriteFileAtomic(*out, func(w io.Writer) error {
+		return presentation.WriteHistoryExport(w, export)
+	})
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.HistoryExportSummary{Out: *out, Picks: len(export.Picks), Wears: len(export.Wears)})
+	}
+	return presentation.RenderHistoryExport(app.stdout, *out, export)
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHistoryList_Picks(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"shirt.avatar"}})
+	if stdout, _, _ := env.run("history", "list"); stdout != "No history yet.\n" {
+		t.Errorf("history list before picking = %q", stdout)
+	}
+	for _, category := range []string{"casual", "work", "casual"} {
+		if _, stderr, code := env.run("pick", category); code != ExitOK {
+			t.Fatalf("pick %s: code = %v, stderr = %q", category, code, stderr)
+		}
+	}
+
+	stdout, stderr, code := env.run("history", "list", "--category", "casual", "--limit", "1", "--page", "2")
+	if code != ExitOK {
+		t.Fatalf("history list: code = %v, stderr = %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "casual/") || lines[1] != "Page 2 of 2 (2 picks)" {
+		t.Errorf("history list output = %q", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "history", "list", "--since", "2000-01-01")
+	if code != ExitOK {
+		t.Fatalf("history list --json: code = %v", code)
+	}
+	var page struct {
+		Items []struct {
+			Category string `json:"category"`
+		} `json:"items"`
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &page); err != nil {
+		t.Fatalf("history list JSON: %v\n%s", err, stdout)
+	}
+	if page.Total != 3 || page.Items[1].Category != "work" {
+		t.Errorf("history list JSON = %+v", page)
+	}
+
+	if stdout, _, _ := env.run("history", "list", "--since", "2999-01-01"); !strings.HasPrefix(stdout, "No history yet.") {
+		t.Errorf("history list --since future = %q", stdout)
+	}
+
+	if stdout, _, _ := env.run("history", "clear"); stdout != "Cleared 3 picks from the history.\n" {
+		t.Errorf("history clear output = %q", stdout)
+	}
+	if stdout, _, _ := env.run("history", "list"); stdout != "No history yet.\n" {
+		t.Errorf("history list after clear = %q", stdout)
+	}
+}
+
+func TestHistoryList_Worn(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	env.wear(t, "casual", "jeans.avatar")
+	if _, stderr, code := env.run("feedback", "add", "casual", "jeans.avatar", "compliment"); code != ExitOK {
+		t.Fatalf("feedback add: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("history", "list", "--worn")
+	if code != ExitOK {
+		t.Fatalf("history list --worn: code = %v, stderr = %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "casual/jeans.avatar  compliment") || lines[2] != "Page 1 of 1 (2 wears)" {
+		t.Errorf("history list --worn output = %q", stdout)
+	}
+}
+
+func TestHistoryList_Fields(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("--fields", "category,fileName", "history", "list")
+	want := "{\n  \"items\": [\n    {\n      \"category\": \"casual\",\n      \"fileName\": \"tee.avatar\"\n    }\n  ]\n}\n"
+	if code != ExitOK || stdout != want {
+		t.Errorf("--fields history list: code = %v, stdout = %q, want %q (stderr %q)", code, stdout, want, stderr)
+	}
+	if _, _, code := env.run("--fields", "category..name", "history", "list"); code != ExitUsage {
+		t.Errorf("--fields with an empty name: code = %v, want %v", code, ExitUsage)
+	}
+}
+
+func TestHistoryExport(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	env.wear(t, "casual", "tee.avatar")
+	if _, stderr, code := env.run("metadata", "set", "casual", "tee.avatar", "--tags", "relaxed"); code != ExitOK {
+		t.Fatalf("metadata set: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("history", "export")
+	if code != ExitOK {
+		t.Fatalf("history export: code = %v, stderr = %q", code, stderr)
+	}
+	var export struct {
+		Picks    []struct{ FileName string } `json:"picks"`
+		Wears    []struct{ FileName string } `json:"wears"`
+		Metadata []struct {
+			Category string `json:"category"`
+			FileName string `json:"fileName"`
+			Metadata struct {
+				Tags []string `json:"tags"`
+			} `json:"metadata"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &export); err != nil {
+		t.Fatalf("history export JSON: %v\n%s", err, stdout)
+	}
+	if len(export.Picks) != 1 || len(export.Wears) != 1 || len(export.Metadata) != 1 || export.Metadata[0].Metadata.Tags[0] != "relaxed" {
+		t.Errorf("history export = %+v, want the pick, the wear and the tags", export)
+	}
+
+	out := filepath.Join(t.TempDir(), "history.json")
+	stdout, _, code = env.run("history", "export", "--out", out)
+	if code != ExitOK || stdout != "Wrote 1 pick and 1 wear to "+out+".\n" {
+		t.Errorf("history export --out: code = %v, stdout = %q", code, stdout)
+	}
+	if data, err := os.ReadFile(out); err != nil || !json.Valid(data) {
+		t.Errorf("history export file: %v\n%s", err, data)
+	}
+}
+
+func TestHistoryList_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"bad date", []string{"history", "list", "--since", "last tuesday"}, ExitUsage},
+		{"limit too large", []string{"history", "list", "--limit", "1000"}, ExitInvalidInput},
+		{"negative page", []string{"history", "list", "--page", "-1"}, ExitInvalidInput},
+		{"unknown category", []string{"history", "list", "--category", "pyjamas"}, ExitCategoryNotFound},
+		{"extra argument", []string{"history", "list", "casual"}, ExitUsage},
+		{"clear with argument", []string{"history", "clear", "casual"}, ExitUsage},
+		{"export with argument", []string{"history", "export", "casual"}, ExitUsage},
+		{"export to stdout with fields", []string{"--fields", "picks", "history", "export"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
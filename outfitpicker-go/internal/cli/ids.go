@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func idsCommand() *Command {
+	return &Command{
+		Name:    "ids",
+		Summary: "Give outfits stable IDs that follow renames and moves (migrate, sync, resolve)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "ids", args, map[string]func(*App, []string) error{
+				"migrate": runIDsMigrate,
+				"sync":    runIDsSync,
+				"resolve": runIDsResolve,
+			})
+		},
+	}
+}
+
+func runIDsMigrate(app *App, args []string) error {
+	fs := app.newFlagSet("ids migrate")
+	scheme := fs.String("scheme", "uuid", "how new IDs are made: uuid for random IDs, or hash to derive them from the outfit's content")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("ids migrate takes no arguments, got %q", fs.Arg(0))
+	}
+	sync, err := usecases.NewOutfitIDsUseCase(app.services()).Migrate(*scheme)
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: the scheme must be one of %s", err, strings.Join(validation.OutfitIDSchemes(), ", "))
+	}
+	if err != nil {
+		return err
+	}
+	return writeOutfitIDSync(app, sync)
+}
+
+func runIDsSync(app *App, args []string) error {
+	fs := app.newFlagSet("ids sync")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("ids sync takes no arguments, got %q", fs.Arg(0))
+	}
+	sync, err := usecases.NewOutfitIDsUseCase(app.services()).Sync()
+	if err != nil {
+		return err
+	}
+	return writeOutfitIDSync(app, sync)
+}
+
+func runIDsResolve(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("ids resolve"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: ids resolve <id>|<category>/<file>")
+	}
+	resolved, err := usecases.NewOutfitIDsUseCase(app.services()).Resolve(positional[0])
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.OutfitIDResolutionV1(resolved))
+	}
+	fmt.Fprintf(app.stdout, "%s  %s\n", resolved.ID, resolved.Location)
+	return nil
+}
+
+func writeOutfitIDSync(app *App, sync *usecases.OutfitIDSync) error {
+	if app.jsonOutput {
+		return app.writeJSON(presentation.OutfitIDSyncV1(*sync))
+	}
+	fmt.Fprintf(app.stdout, "%d outfits have %s IDs: %d new, %d picks and wears linked.\n", sync.Outfits, sync.Scheme, sync.Assigned, sync.Linked)
+	for _, move := range sync.Moves {
+		fmt.Fprintf(app.stdout, "  %s -> %s\n", move.From, move.To)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+func TestIDs(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": nil})
+	for name, content := range map[string]string{"tee.avatar": "tee", "jeans.avatar": "jeans"} {
+		if err := os.WriteFile(filepath.Join(env.root, "casual", name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, code := env.run("ids", "sync"); code != ExitInvalidInput {
+		t.Errorf("ids sync before migrating: code = %v, want ExitInvalidInput", code)
+	}
+
+	stdout, stderr, code := env.run("ids", "migrate", "--scheme", "hash")
+	if code != ExitOK || stdout != "2 outfits have hash IDs: 2 new, 0 picks and wears linked.\n" {
+		t.Fatalf("ids migrate: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	stdout, _, _ = env.run("--json", "ids", "resolve", "casual/tee.avatar")
+	var resolved usecases.OutfitIDResolution
+	if err := json.Unmarshal([]byte(stdout), &resolved); err != nil || resolved.ID == "" {
+		t.Fatalf("ids resolve --json = %q, %v", stdout, err)
+	}
+
+	if err := os.Rename(filepath.Join(env.root, "casual", "tee.avatar"), filepath.Join(env.root, "casual", "white-tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, code = env.run("ids", "sync")
+	if code != ExitOK || !strings.Contains(stdout, "  casual/tee.avatar -> casual/white-tee.avatar\n") {
+		t.Errorf("ids sync after a rename: code = %v, stdout = %q", code, stdout)
+	}
+	stdout, _, code = env.run("ids", "resolve", resolved.ID)
+	if code != ExitOK || stdout != resolved.ID+"  casual/white-tee.avatar\n" {
+		t.Errorf("ids resolve after the rename: code = %v, stdout = %q", code, stdout)
+	}
+}
+
+func TestIDs_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unknown scheme", []string{"ids", "migrate", "--scheme", "serial"}, ExitInvalidConfiguration},
+		{"resolve without a reference", []string{"ids", "resolve"}, ExitUsage},
+		{"resolve with IDs off", []string{"ids", "resolve", "abc"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func importCommand() *Command {
+	return &Command{
+		Name:    "import",
+		Summary: "Import outfits from an archive, their tags, weights and ratings from a CSV file, or a state bundle (archive, csv, bundle)",
+		DryRun:  true,
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "import", args, map[string]func(*App, []string) error{
+				"archive": runImportArchive,
+				"csv":     runImportCSV,
+				"bundle":  runImportBundle,
+			})
+		},
+	}
+}
+
+func runImportArchive(app *App, args []string) error {
+	fs := app.newFlagSet("import archive")
+	into := fs.String("into", "", "category to import into; created if it does not exist")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := refuseDryRun(app, "import archive"); err != nil {
+		return err
+	}
+	if len(positional) != 1 || *into == "" {
+		return usageErrorf("usage: import archive <file.zip|file.tar|file.tar.gz> --into <category>")
+	}
+
+	services := app.services()
+	category := *into
+	// An existing category may be named by label or alias; a new one is
+	// created under the name as given.
+	if reference, err := usecases.NewResolveCategoryUseCase(services).Execute(category); err == nil {
+		category = reference.Name
+	}
+	result, err := usecases.NewImportArchiveUseCase(services).Execute(positional[0], category)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ArchiveImportV1(*result))
+	}
+	return presentation.RenderArchiveImport(app.stdout, result)
+}
+
+func runImportCSV(app *App, args []string) error {
+	fs := app.newFlagSet("import csv")
+	columns := fs.String("map", "", "comma-separated HEADER=FIELD pairs naming the field of each column, e.g. Item=outfit,Labels=tags; fields are category, outfit, tags, weight and rating")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: import csv <file.csv> [--map HEADER=FIELD,...]")
+	}
+	mapping, err := logic.ParseColumnMapping(*columns)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(positional[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	rows, err := logic.ParseMetadataCSV(file, mapping)
+	if err != nil {
+		return err
+	}
+	imports := usecases.NewImportCSVUseCase(app.services())
+	proposal, err := imports.Propose(rows)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		return writeDryRun(app, presentation.CSVImportSummary(proposal), presentation.CSVImportV1(*proposal), proposal.Changes())
+	}
+	if err := imports.Commit(proposal); err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.CSVImportV1(*proposal))
+	}
+	return presentation.RenderCSVImport(app.stdout, proposal)
+}
+
+func runImportBundle(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("import bundle"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: import bundle <file.tar.gz>")
+	}
+
+	bundles := usecases.NewStateBundleUseCase(app.services())
+	proposal, err := bundles.Propose(positional[0])
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		return writeDryRun(app, presentation.StateBundleImportSummary(proposal), presentation.BundleImportProposalV1(*proposal), proposal.Changes())
+	}
+	result, err := bundles.Commit(proposal)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.BundleImportV1(*result))
+	}
+	return presentation.RenderStateBundleImport(app.stdout, result)
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeZip creates a zip archive holding an empty file for each entry.
+func writeZip(t *testing.T, entries ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wardrobe.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	writer := zip.NewWriter(file)
+	for _, entry := range entries {
+		if _, err := writer.Create(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportArchive(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeZip(t, "pack/tee.avatar", "pack/jeans.avatar", "../escape.avatar", "pack/notes.txt")
+
+	stdout, stderr, code := env.run("import", "archive", archive, "--into", "casual")
+	if code != ExitOK {
+		t.Fatalf("import archive: code = %v, stderr = %q", code, stderr)
+	}
+	want := "Imported 2 outfits into casual (3 outfits now).\n" +
+		"  pack/tee.avatar saved as tee-2.avatar: name already taken\n" +
+		"Skipped 2 entries:\n" +
+		"  ../escape.avatar: unsafe path\n" +
+		"  pack/notes.txt: not an outfit file\n"
+	if stdout != want {
+		t.Errorf("import archive = %q, want %q", stdout, want)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(env.root), "escape.avatar")); !os.IsNotExist(err) {
+		t.Errorf("entry escaped the wardrobe: %v", err)
+	}
+	// Imported outfits are known, so triage only offers the original outfit.
+	if stdout, _, _ := env.run("triage", "--list"); stdout != "casual/tee.avatar\n" {
+		t.Errorf("triage --list after import = %q", stdout)
+	}
+}
+
+func TestImportArchive_NewCategoryJSON(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeZip(t, "coat.avatar")
+
+	stdout, stderr, code := env.run("--json", "import", "archive", archive, "--into", "winter")
+	if code != ExitOK {
+		t.Fatalf("import archive: code = %v, stderr = %q", code, stderr)
+	}
+	var result struct {
+		Category string `json:"category"`
+		Outfits  int    `json:"outfits"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || result.Category != "winter" || result.Outfits != 1 {
+		t.Errorf("import archive --json = %q, %v", stdout, err)
+	}
+	if stdout, _, _ := env.run("pick", "winter"); stdout != "winter/coat.avatar\n" {
+		t.Errorf("pick from imported category = %q", stdout)
+	}
+}
+
+func TestImportArchive_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeZip(t, "coat.avatar")
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing --into", []string{"import", "archive", archive}, ExitUsage},
+		{"missing archive", []string{"import", "archive", "--into", "casual"}, ExitUsage},
+		{"archive not found", []string{"import", "archive", filepath.Join(t.TempDir(), "nope.zip"), "--into", "casual"}, ExitFileSystem},
+		{"hidden category", []string{"import", "archive", archive, "--into", ".secret"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"import", "folder"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
+
+// writeCSV creates a CSV file holding content.
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wardrobe.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportCSV(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	file := writeCSV(t, "Folder,Item,Labels,Score,Notes\n"+
+		"casual,tee.avatar,\"summer, beach\",4,favorite\n"+
+		"casual,shorts.avatar,summer,,sold\n")
+	mapping := "Folder=category,Item=outfit,Labels=tags,Score=rating"
+
+	stdout, stderr, code := env.run("--dry-run", "import", "csv", file, "--map", mapping)
+	want := "Would import metadata for 1 outfit: 1 tagged, 0 weighted, 1 rated.\n" +
+		"Skipped 1 row:\n" +
+		"  line 3, casual/shorts.avatar: outfit not found\n" +
+		"Would change:\n" +
+		"  metadata: add tags to 1 outfit, rate 1 outfit\n" +
+		"Nothing was saved (--dry-run).\n"
+	if code != ExitOK || stdout != want {
+		t.Fatalf("import csv --dry-run = %q, %q; want %q", stdout, stderr, want)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "casual", "tee.avatar"); !strings.Contains(stdout, "Tags:      -\n") {
+		t.Errorf("metadata show after --dry-run = %q, want nothing saved", stdout)
+	}
+
+	stdout, stderr, code = env.run("import", "csv", file, "--map", mapping)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Imported metadata for 1 outfit: 1 tagged, 0 weighted, 1 rated.\n") {
+		t.Fatalf("import csv = %q, %q", stdout, stderr)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "casual", "tee.avatar"); !strings.Contains(stdout, "Tags:      summer, beach\n") {
+		t.Errorf("metadata show after import = %q, want the imported tags", stdout)
+	}
+}
+
+func TestImportCSV_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	valid := writeCSV(t, "category,outfit,weight\ncasual,tee.avatar,2\n")
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing file", []string{"import", "csv"}, ExitUsage},
+		{"unknown field", []string{"import", "csv", valid, "--map", "Item=price"}, ExitInvalidInput},
+		{"no outfit column", []string{"import", "csv", writeCSV(t, "category,tags\ncasual,summer\n")}, ExitInvalidInput},
+		{"invalid rating", []string{"import", "csv", writeCSV(t, "category,outfit,rating\ncasual,tee.avatar,9\n")}, ExitInvalidInput},
+		{"dry run of archive import", []string{"--dry-run", "import", "archive", valid, "--into", "casual"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// integrityRecovery tells how to get past a failed integrity check.
+const integrityRecovery = "Run 'outfitpicker backup restore <id>' to go back to a backup, or 'outfitpicker integrity accept' to trust the current contents."
+
+func integrityCommand() *Command {
+	return &Command{
+		Name:    "integrity",
+		Summary: "Sign state files to detect outside changes (enable, disable, status, accept)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "integrity", args, map[string]func(*App, []string) error{
+				"enable":  runIntegrityEnable,
+				"disable": runIntegrityDisable,
+				"status":  runIntegrityStatus,
+				"accept":  runIntegrityAccept,
+			})
+		},
+	}
+}
+
+func runIntegrityEnable(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("integrity enable"), args); err != nil {
+		return err
+	}
+	changed, err := usecases.NewIntegrityUseCase(app.services()).Enable()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.IntegrityChange{Enabled: true, Changed: changed})
+	}
+	if changed {
+		fmt.Fprintln(app.stdout, "State files are now signed; changes made outside outfitpicker will be detected.")
+	} else {
+		fmt.Fprintln(app.stdout, "State files are already signed.")
+	}
+	return nil
+}
+
+func runIntegrityDisable(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("integrity disable"), args); err != nil {
+		return err
+	}
+	changed, err := usecases.NewIntegrityUseCase(app.services()).Disable()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.IntegrityChange{Changed: changed})
+	}
+	if changed {
+		fmt.Fprintln(app.stdout, "State files are no longer signed.")
+	} else {
+		fmt.Fprintln(app.stdout, "State files are not signed.")
+	}
+	return nil
+}
+
+func runIntegrityStatus(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("integrity status"), args); err != nil {
+		return err
+	}
+	status, err := usecases.NewIntegrityUseCase(app.services()).Status()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.IntegrityStatusV1(status))
+	}
+	switch {
+	case !status.Enabled:
+		fmt.Fprintln(app.stdout, "State files are not signed.")
+	case len(status.Failed) == 0:
+		fmt.Fprintln(app.stdout, "State files are signed and all pass their integrity check.")
+	default:
+		fmt.Fprintln(app.stdout, "These state files failed their integrity check:")
+		for _, file := range status.Failed {
+			fmt.Fprintf(app.stdout, "  %s\n", file)
+		}
+		fmt.Fprintln(app.stdout, integrityRecovery)
+	}
+	return nil
+}
+
+func runIntegrityAccept(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("integrity accept"), args); err != nil {
+		return err
+	}
+	accepted, err := usecases.NewIntegrityUseCase(app.services()).Accept()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.IntegrityChange{Enabled: true, Changed: len(accepted) > 0, Accepted: accepted})
+	}
+	if len(accepted) == 0 {
+		fmt.Fprintln(app.stdout, "All state files already pass their integrity check.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Accepted the current contents of %s.\n", strings.Join(accepted, ", "))
+	return nil
+}
+
+// recoverIntegrity handles a command that failed because a state file no
+// longer matches its signature. Interactive sessions are asked whether to
+// trust the file's current contents, and if so the state files are signed
+// again and the command retried; otherwise the ways to recover are printed.
+func (a *App) recoverIntegrity(err error, integrityErr *domainerrors.IntegrityError, retry func() error) error {
+	if !a.isInteractive() {
+		if !a.jsonOutput {
+			fmt.Fprintln(a.stderr, integrityRecovery)
+		}
+		return err
+	}
+	fmt.Fprintf(a.stderr, "%s was changed outside outfitpicker or is damaged.\n", integrityErr.File)
+	answer, ok := a.prompt(bufio.NewScanner(a.stdin), "Trust its current contents and continue? [y/N] ")
+	if !ok || !strings.EqualFold(answer, "y") {
+		fmt.Fprintln(a.stderr, integrityRecovery)
+		return err
+	}
+	if _, err := usecases.NewIntegrityUseCase(a.services()).Accept(); err != nil {
+		return err
+	}
+	return retry()
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestIntegrity_DetectsOutsideChanges(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if stdout, stderr, code := env.run("integrity", "enable"); code != ExitOK || !strings.Contains(stdout, "now signed") {
+		t.Fatalf("enable: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick with signed state: code = %v, stderr = %q", code, stderr)
+	}
+
+	configPath := filepath.Join(env.stateDir, "outfitpicker", "config.json")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, append(data, '\n'), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, code := env.run("pick", "casual")
+	if code != ExitIntegrity || !strings.Contains(stderr, "config.json failed its integrity check") || !strings.Contains(stderr, "integrity accept") {
+		t.Errorf("pick after tampering: code = %v, stderr = %q", code, stderr)
+	}
+	stdout, _, _ := env.run("--json", "integrity", "status")
+	var status entities.IntegrityStatus
+	if err := json.Unmarshal([]byte(stdout), &status); err != nil || !status.Enabled || len(status.Failed) != 1 || status.Failed[0] != "config.json" {
+		t.Errorf("status = %+v, %v", status, err)
+	}
+
+	if _, _, code := env.runInteractive("n\n", "pick", "casual"); code != ExitIntegrity {
+		t.Errorf("declined recovery: code = %v, want ExitIntegrity", code)
+	}
+	if stdout, stderr, code := env.runInteractive("y\n", "pick", "casual"); code != ExitOK || stdout != "casual/tee.avatar\n" {
+		t.Errorf("accepted recovery: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("integrity", "status"); !strings.Contains(stdout, "all pass") {
+		t.Errorf("status after accepting = %q", stdout)
+	}
+
+	if _, _, code := env.run("integrity", "disable"); code != ExitOK {
+		t.Fatalf("disable: code = %v", code)
+	}
+	if _, err := os.Stat(filepath.Join(env.stateDir, "outfitpicker", ".config.json.sig")); !os.IsNotExist(err) {
+		t.Errorf("signature left after disabling: %v", err)
+	}
+	if _, _, code := env.run("integrity", "accept"); code != ExitInvalidInput {
+		t.Errorf("accept while off: code = %v, want ExitInvalidInput", code)
+	}
+}
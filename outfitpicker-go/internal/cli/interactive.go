@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation/tui"
+)
+
+func interactiveCommand() *Command {
+	return &Command{
+		Name:    "interactive",
+		Summary: "Browse categories and pick outfits in a full-screen terminal view",
+		Run:     runInteractive,
+	}
+}
+
+func runInteractive(app *App, args []string) error {
+	fs := app.newFlagSet("interactive")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usageErrorf("usage: interactive")
+	}
+	if !app.isInteractive() {
+		return usageErrorf("interactive needs an interactive terminal; use list and pick instead")
+	}
+
+	services := app.services()
+	config, err := services.Config.Load()
+	if err != nil {
+		return err
+	}
+	categories := usecases.NewGetCategoriesUseCase(services)
+	progress, err := categories.Progress()
+	if err != nil {
+		return err
+	}
+
+	if f, ok := app.stdin.(*os.File); ok && isTerminal(f) {
+		restore, err := tui.EnableRaw(f)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	model := tui.NewModel(progress)
+	model.ProgressStyle = config.Accessibility.ProgressStyle
+	return app.browse(services, categories, model)
+}
+
+// browse redraws the screen after every key press until the user quits or
+// input ends. Picks are only proposed until the user confirms wearing them,
+// so declining leaves nothing saved.
+func (a *App) browse(services usecases.Services, categories *usecases.GetCategoriesUseCase, model *tui.Model) error {
+	input := bufio.NewReader(a.stdin)
+	pick := usecases.NewPickOutfitUseCase(services)
+	var proposal *usecases.PickProposal
+	for {
+		if err := tui.Render(a.stdout, model); err != nil {
+			return err
+		}
+		key, err := tui.ReadKey(input)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch model.Handle(key) {
+		case tui.ActionQuit:
+			return nil
+		case tui.ActionPick:
+			selected, _ := model.Selected()
+			proposal, err = pick.Propose(selected.Category.Name)
+			if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) {
+				model.Status = err.Error()
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			model.Pending = &proposal.Outfit
+			model.Status = ""
+		case tui.ActionWear:
+			model.Status, err = wearProposal(services, proposal.Outfit, func() error { return pick.Commit(proposal) })
+			if err != nil {
+				return err
+			}
+			model.Pending = nil
+			progress, err := categories.Progress()
+			if err != nil {
+				return err
+			}
+			model.Refresh(progress)
+		}
+	}
+}
+
+// wearProposal saves a proposed pick of outfit with commit and marks the
+// outfit worn, returning the status line to show.
+func wearProposal(services usecases.Services, outfit entities.OutfitReference, commit func() error) (string, error) {
+	if err := commit(); err != nil {
+		return "", err
+	}
+	err := usecases.NewWearOutfitUseCase(services).Execute(outfit)
+	var completed *domainerrors.RotationCompletedError
+	if errors.As(err, &completed) {
+		return fmt.Sprintf("Wearing %s/%s; %s.", outfit.Category.Name, outfit.FileName, err), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Wearing %s/%s.", outfit.Category.Name, outfit.FileName), nil
+}
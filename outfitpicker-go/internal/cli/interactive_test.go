@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInteractive_PicksAndWears(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {"suit.avatar"}})
+	keys := strings.Join([]string{
+		"\r", "n", // pick from casual, then decline
+		"\x1b[B", "\r", "y", // select formal, pick, and wear
+		"k", "\r", "y", // back to casual, pick, and wear
+		"q",
+	}, "")
+
+	stdout, stderr, code := env.runInteractive(keys, "interactive")
+	if code != ExitOK {
+		t.Fatalf("interactive: code = %v, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{
+		"> casual [--------------------] 0/2",
+		"Not worn.",
+		"Wear formal/suit.avatar? (y/n)",
+		"Wearing formal/suit.avatar; all outfits in 'formal' have been worn, category has been reset.",
+		"> casual [##########----------] 1/2",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("screen missing %q:\n%s", want, stdout)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(env.stateDir, "outfitpicker", "history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), `"fileName"`); got != 2 {
+		t.Errorf("history after interactive = %s, want the two worn outfits", data)
+	}
+}
+
+func TestInteractive_RequiresTerminal(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	var out, errOut bytes.Buffer
+	app := New(WithOutput(&out, &errOut), WithInteractive(false), WithDirectoryProvider(env.directoryProvider()))
+	if code := app.Run([]string{"interactive"}); code != ExitUsage {
+		t.Errorf("non-interactive: code = %v, want ExitUsage", code)
+	}
+	if _, _, code := env.runInteractive("q", "--json", "interactive"); code != ExitUsage {
+		t.Errorf("--json: code = %v, want ExitUsage", code)
+	}
+}
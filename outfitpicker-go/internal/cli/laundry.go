@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func laundryCommand() *Command {
+	return &Command{
+		Name:    "laundry",
+		Summary: "Track outfits through the wash so only clean ones are picked (enable, disable, status, wash, done, report)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "laundry", args, map[string]func(*App, []string) error{
+				"enable":  runLaundryEnable,
+				"disable": runLaundryDisable,
+				"status":  runLaundryStatus,
+				"wash":    runLaundryWash,
+				"done":    runLaundryDone,
+				"report":  runLaundryReport,
+			})
+		},
+	}
+}
+
+func runLaundryEnable(app *App, args []string) error {
+	if err := refuseJSON(app, "laundry enable"); err != nil {
+		return err
+	}
+	if err := parseFlags(app.newFlagSet("laundry enable"), args); err != nil {
+		return err
+	}
+	if err := usecases.NewLaundryUseCase(app.services()).Enable(); err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout, "Laundry tracking is on: only clean outfits are picked, and worn ones wait for: laundry wash")
+	return nil
+}
+
+func runLaundryDisable(app *App, args []string) error {
+	if err := refuseJSON(app, "laundry disable"); err != nil {
+		return err
+	}
+	if err := parseFlags(app.newFlagSet("laundry disable"), args); err != nil {
+		return err
+	}
+	if err := usecases.NewLaundryUseCase(app.services()).Disable(); err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout, "Laundry tracking is off.")
+	return nil
+}
+
+func runLaundryStatus(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("laundry status"), args); err != nil {
+		return err
+	}
+	laundry, err := usecases.NewLaundryUseCase(app.services()).Status()
+	if err != nil {
+		return err
+	}
+	outfits := slices.Concat(laundry.InState(entities.LaundryWorn), laundry.InState(entities.LaundryInWash))
+	if app.jsonOutput {
+		return app.writeJSON(v1.LaundryStatus{Enabled: laundry.Enabled, Outfits: presentation.ListV1(outfits, presentation.LaundryOutfitV1)})
+	}
+	if !laundry.Enabled {
+		fmt.Fprintln(app.stdout, "Laundry tracking is off; turn it on with: laundry enable")
+		return nil
+	}
+	if len(outfits) == 0 {
+		fmt.Fprintln(app.stdout, "Every outfit is clean.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(app.stdout, 0, 4, 2, ' ', 0)
+	for _, outfit := range outfits {
+		fmt.Fprintf(tw, "%s/%s\t%s\n", outfit.Category, outfit.FileName, outfit.State)
+	}
+	return tw.Flush()
+}
+
+func runLaundryWash(app *App, args []string) error {
+	return runLaundryTransition(app, "wash", args, (*usecases.LaundryUseCase).Wash, "%s/%s is in the laundry.\n")
+}
+
+func runLaundryDone(app *App, args []string) error {
+	return runLaundryTransition(app, "done", args, (*usecases.LaundryUseCase).Done, "%s/%s is clean.\n")
+}
+
+// runLaundryTransition runs laundry wash or done on the <category> <outfit>
+// pairs given, or on every outfit they apply to when none are.
+func runLaundryTransition(app *App, subcommand string, args []string, transition func(*usecases.LaundryUseCase, []entities.OutfitReference) ([]entities.LaundryOutfit, error), format string) error {
+	positional, err := parseArgs(app.newFlagSet("laundry "+subcommand), args)
+	if err != nil {
+		return err
+	}
+	if len(positional)%2 != 0 {
+		return usageErrorf("usage: laundry %s [<category> <outfit>]...", subcommand)
+	}
+	var outfits []entities.OutfitReference
+	for i := 0; i < len(positional); i += 2 {
+		outfit, err := app.outfitReference(positional[i], positional[i+1])
+		if err != nil {
+			return err
+		}
+		outfits = append(outfits, outfit)
+	}
+	moved, err := transition(usecases.NewLaundryUseCase(app.services()), outfits)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(moved, presentation.LaundryOutfitV1))
+	}
+	for _, outfit := range moved {
+		fmt.Fprintf(app.stdout, format, outfit.Category, outfit.FileName)
+	}
+	return nil
+}
+
+func runLaundryReport(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("laundry report"), args); err != nil {
+		return err
+	}
+	report, err := usecases.NewLaundryReportUseCase(app.services()).Execute()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.LaundryReportV1(*report))
+	}
+	return presentation.RenderLaundryReport(app.stdout, report)
+}
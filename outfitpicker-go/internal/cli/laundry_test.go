@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLaundry_Cycle(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+
+	if stdout, _, _ := env.run("laundry", "status"); stdout != "Laundry tracking is off; turn it on with: laundry enable\n" {
+		t.Errorf("laundry status before enabling = %q", stdout)
+	}
+	if _, stderr, code := env.run("laundry", "enable"); code != ExitOK {
+		t.Fatalf("laundry enable: code = %v, stderr = %q", code, stderr)
+	}
+	env.wear(t, "casual", "jeans.avatar")
+	if stdout, _, _ := env.run("laundry", "status"); stdout != "casual/jeans.avatar  worn\ncasual/tee.avatar    worn\n" {
+		t.Errorf("laundry status = %q", stdout)
+	}
+
+	if stdout, _, code := env.run("laundry", "wash", "casual", "tee.avatar"); code != ExitOK || stdout != "casual/tee.avatar is in the laundry.\n" {
+		t.Errorf("laundry wash: code = %v, stdout = %q", code, stdout)
+	}
+	stdout, _, code := env.run("--json", "laundry", "status")
+	var status struct {
+		Enabled bool `json:"enabled"`
+		Outfits []struct {
+			FileName string `json:"fileName"`
+			State    string `json:"state"`
+		} `json:"outfits"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &status); err != nil || code != ExitOK {
+		t.Fatalf("laundry status --json: code = %v, %v\n%s", code, err, stdout)
+	}
+	if !status.Enabled || len(status.Outfits) != 2 || status.Outfits[1].FileName != "tee.avatar" || status.Outfits[1].State != "in-laundry" {
+		t.Errorf("laundry status --json = %+v", status)
+	}
+
+	if _, stderr, code := env.run("rotation", "reset", "casual"); code != ExitOK {
+		t.Fatalf("rotation reset: code = %v, stderr = %q", code, stderr)
+	}
+	for range 5 {
+		if stdout, _, code := env.run("--dry-run", "pick", "casual"); code != ExitOK || !strings.HasPrefix(stdout, "Would pick casual/hoodie.avatar\n") {
+			t.Fatalf("pick after a rotation reset: code = %v, stdout = %q; want the only clean outfit", code, stdout)
+		}
+	}
+
+	if stdout, _, code := env.run("laundry", "done"); code != ExitOK || stdout != "casual/tee.avatar is clean.\n" {
+		t.Errorf("laundry done: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("laundry", "disable"); stdout != "Laundry tracking is off.\n" {
+		t.Errorf("laundry disable = %q", stdout)
+	}
+}
+
+func TestLaundry_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, _, code := env.run("laundry", "wash"); code != ExitInvalidInput {
+		t.Errorf("laundry wash with tracking off: code = %v, want %v", code, ExitInvalidInput)
+	}
+	if _, stderr, code := env.run("laundry", "enable"); code != ExitOK {
+		t.Fatalf("laundry enable: code = %v, stderr = %q", code, stderr)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"nothing worn", []string{"laundry", "wash"}, ExitInvalidInput},
+		{"clean outfit", []string{"laundry", "wash", "casual", "tee.avatar"}, ExitInvalidInput},
+		{"nothing in the laundry", []string{"laundry", "done"}, ExitInvalidInput},
+		{"missing outfit", []string{"laundry", "done", "casual"}, ExitUsage},
+		{"unknown category", []string{"laundry", "wash", "formal", "suit.avatar"}, ExitCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
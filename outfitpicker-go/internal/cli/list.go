@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func listCommand() *Command {
+	return &Command{
+		Name:    "list",
+		Summary: "List categories with their state, outfit count and health",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("list")
+			noColor := fs.Bool("no-color", false, "disable colored category names")
+			showProgress := fs.Bool("progress", false, "add a column showing how far through its rotation each category is")
+			season := fs.String("season", "", "list only categories in season: auto for the current season, or winter, spring, summer, autumn")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+
+			services := app.services()
+			config, err := services.Config.Load()
+			if err != nil {
+				return err
+			}
+			categories := usecases.NewGetCategoriesUseCase(services)
+			infos, err := categories.Execute()
+			if *season != "" {
+				infos, _, err = categories.InSeason(*season)
+			}
+			if err != nil {
+				return err
+			}
+			if app.jsonOutput {
+				return app.writeJSON(presentation.ListV1(infos, presentation.CategoryV1))
+			}
+			var progress map[string]entities.RotationProgress
+			if *showProgress {
+				rotations, err := categories.Progress()
+				if err != nil {
+					return err
+				}
+				progress = make(map[string]entities.RotationProgress, len(rotations))
+				for _, rotation := range rotations {
+					progress[rotation.Category.Name] = rotation
+				}
+			}
+			health, err := usecases.NewCategoryHealthUseCase(services).Execute()
+			if err != nil {
+				return err
+			}
+			lockedRotations, err := usecases.NewRotationLockUseCase(services).Status()
+			if err != nil {
+				return err
+			}
+			var locked map[string]logic.LockedRotation
+			if len(lockedRotations) > 0 {
+				locked = make(map[string]logic.LockedRotation, len(lockedRotations))
+				for _, rotation := range lockedRotations {
+					locked[rotation.Category] = rotation
+				}
+			}
+			style := presentation.NewStyle(config, app.colorEnabled() && !*noColor)
+			if err := presentation.RenderCategoryList(app.stdout, infos, progress, health, config.Permissions, locked, style); err != nil {
+				return err
+			}
+			suggestTriage(app.stderr, services)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"encoding/json"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "beach": nil})
+
+	stdout, stderr, code := env.run("list")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	want := "CATEGORY  STATE       OUTFITS  HEALTH\nbeach     empty       0        -\ncasual    hasOutfits  2        45 warning\n"
+	if stdout != want {
+		t.Errorf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+}
+
+func TestList_Progress(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar"}})
+	env.wear(t, "casual", "a.avatar")
+
+	stdout, stderr, code := env.run("list", "--progress")
+	if code != ExitOK || !strings.Contains(stdout, "ROTATION") || !strings.Contains(stdout, "[###-------]") {
+		t.Errorf("list --progress: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+}
+
+func TestList_JSON(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "beach": nil})
+
+	stdout, stderr, code := env.run("--json", "list")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	var categories []v1.Category
+	if err := json.Unmarshal([]byte(stdout), &categories); err != nil {
+		t.Fatalf("stdout = %q: %v", stdout, err)
+	}
+	if len(categories) != 2 || categories[0].Name != "beach" || categories[0].State != "empty" ||
+		categories[1].Name != "casual" || categories[1].OutfitCount != 2 {
+		t.Errorf("categories = %+v", categories)
+	}
+
+	stdout, _, _ = env.run("--fields", "name", "list")
+	if strings.Contains(stdout, "outfitCount") || !strings.Contains(stdout, `"name": "casual"`) {
+		t.Errorf("list --fields name = %q", stdout)
+	}
+}
+
+func TestJSON_Unsupported(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	for _, args := range [][]string{
+		{"--json", "version"},
+		{"--fields", "name", "decorate", "casual", "--emoji", "👕"},
+		{"--json", "favorite", "add", "casual", "a.avatar"},
+		{"--fields", "category", "tag", "add", "casual", "a.avatar", "denim"},
+	} {
+		if _, stderr, code := env.run(args...); code != ExitUsage || !strings.Contains(stderr, "has no JSON output") {
+			t.Errorf("%v: code = %v, stderr = %q", args, code, stderr)
+		}
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}, "work": {"suit.avatar", "blazer.avatar"}})
+	env.wear(t, "casual", "a.avatar")
+
+	stdout, stderr, code := env.run("doctor")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	want := "work: 45 (warning)\n  - never worn\ncasual: 80 (healthy)\n\n1 of 2 categories need attention.\n"
+	if stdout != want {
+		t.Errorf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}, "beach": nil})
+
+	stdout, stderr, code := env.run("decorate", "casual", "--emoji", "👕", "--color", "blue")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %q", code, stderr)
+	}
+	if stdout != "Decorated 👕 casual.\n" {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	stdout, _, _ = env.run("list")
+	if !strings.Contains(stdout, "👕 casual") || !strings.Contains(stdout, "   beach") {
+		t.Errorf("list stdout =\n%s", stdout)
+	}
+
+	if stdout, _, _ = env.run("decorate", "casual", "--clear"); stdout != "Removed decoration from casual.\n" {
+		t.Errorf("clear stdout = %q", stdout)
+	}
+}
+
+func TestDecorate_Invalid(t *testing.T) {
+	env := newCLIEnv(t, nil)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"missing category", []string{"decorate"}, ExitUsage},
+		{"wide sequence", []string{"decorate", "casual", "--emoji", "👩‍💻"}, ExitInvalidConfiguration},
+		{"unknown color", []string{"decorate", "casual", "--color", "chartreuse"}, ExitInvalidConfiguration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.code {
+				t.Errorf("exit code = %v, want %v", code, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"embed"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// localeFiles holds the command and flag aliases of each language, named
+// after its language code.
+//
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// locale maps localized command and flag names to the English ones.
+type locale struct {
+	// Commands maps a localized command name to the command it runs.
+	Commands map[string]string `json:"commands"`
+	// Flags maps, for each command, a localized flag name to the flag.
+	Flags map[string]map[string]string `json:"flags"`
+}
+
+// loadLocale returns the aliases of language. Languages without aliases
+// return an empty locale.
+func loadLocale(language string) locale {
+	var loc locale
+	data, err := localeFiles.ReadFile("locales/" + language + ".json")
+	if err != nil {
+		return loc
+	}
+	// The files are embedded and covered by tests, so they always decode.
+	json.Unmarshal(data, &loc)
+	return loc
+}
+
+// configuredLocale returns the aliases of the configured language. Without a
+// readable configuration no aliases are enabled.
+func (a *App) configuredLocale() locale {
+	config, err := a.services().Config.Load()
+	if err != nil || config == nil {
+		return locale{}
+	}
+	return loadLocale(config.Language)
+}
+
+// command returns the English name of a localized command name.
+func (l locale) command(name string) (string, bool) {
+	english, ok := l.Commands[name]
+	return english, ok
+}
+
+// aliasesOf returns the localized names of an English command, sorted.
+func (l locale) aliasesOf(command string) []string {
+	var aliases []string
+	for alias, english := range l.Commands {
+		if english == command {
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.Sort(aliases)
+	return aliases
+}
+
+// translateFlags rewrites the localized flags of command in args to their
+// English names. Arguments after a "--" terminator are left alone.
+func (l locale) translateFlags(command string, args []string) []string {
+	flags := l.Flags[command]
+	if len(flags) == 0 {
+		return args
+	}
+	translated := slices.Clone(args)
+	for i, arg := range translated {
+		if arg == "--" {
+			break
+		}
+		dashes := len(arg) - len(strings.TrimLeft(arg, "-"))
+		if dashes == 0 || dashes > 2 {
+			continue
+		}
+		name, value, hasValue := strings.Cut(arg[dashes:], "=")
+		english, ok := flags[name]
+		if !ok {
+			continue
+		}
+		translated[i] = arg[:dashes] + english
+		if hasValue {
+			translated[i] += "=" + value
+		}
+	}
+	return translated
+}
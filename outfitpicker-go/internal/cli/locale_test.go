@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+func TestLocales_AreValid(t *testing.T) {
+	app := New()
+	files, err := fs.Glob(localeFiles, "locales/*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("locale files = %v, %v", files, err)
+	}
+	for _, file := range files {
+		language := strings.TrimSuffix(strings.TrimPrefix(file, "locales/"), ".json")
+		if !slices.Contains(validation.SupportedLanguages(), language) {
+			t.Errorf("%s: %q is not a supported language", file, language)
+		}
+		data, _ := localeFiles.ReadFile(file)
+		var loc locale
+		if err := json.Unmarshal(data, &loc); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		for alias, command := range loc.Commands {
+			if _, ok := app.commands[command]; !ok {
+				t.Errorf("%s: %q aliases unknown command %q", file, alias, command)
+			}
+			if _, ok := app.commands[alias]; ok {
+				t.Errorf("%s: %q shadows a command", file, alias)
+			}
+		}
+		for command := range loc.Flags {
+			if _, ok := app.commands[command]; !ok {
+				t.Errorf("%s: flags for unknown command %q", file, command)
+			}
+		}
+	}
+}
+
+func TestLocale_TranslateFlags(t *testing.T) {
+	loc := loadLocale("es")
+	got := loc.translateFlags("pick", []string{"casual", "--semilla", "5", "-etiqueta=verano", "--seed=1", "--", "--semilla"})
+	want := []string{"casual", "--seed", "5", "-tag=verano", "--seed=1", "--", "--semilla"}
+	if !slices.Equal(got, want) {
+		t.Errorf("translateFlags() = %q, want %q", got, want)
+	}
+	if got := loadLocale("xx").translateFlags("pick", []string{"--semilla"}); !slices.Equal(got, []string{"--semilla"}) {
+		t.Errorf("translateFlags() without a locale = %q", got)
+	}
+}
+
+func TestLocale_Aliases(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+
+	if _, _, code := env.run("elegir", "casual"); code != ExitUsage {
+		t.Errorf("elegir in English: code = %v, want %v", code, ExitUsage)
+	}
+	env.updateConfig(func(config *entities.Config) { config.Language = "es" })
+
+	want, _, _ := env.run("pick", "casual", "--seed", "7")
+	got, stderr, code := env.run("elegir", "casual", "--semilla", "7")
+	if code != ExitOK || got != want {
+		t.Errorf("elegir --semilla 7 = %q (code %v, stderr %q), want %q", got, code, stderr, want)
+	}
+	// English names keep working alongside the aliases.
+	if _, _, code := env.run("pick", "casual", "--semilla", "7"); code != ExitOK {
+		t.Errorf("pick --semilla: code = %v, want %v", code, ExitOK)
+	}
+	if _, stderr, _ := env.run("help"); !strings.Contains(stderr, "pick, elegir") {
+		t.Errorf("help = %q, want the Spanish alias listed", stderr)
+	}
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func maintenanceCommand() *Command {
+	return &Command{
+		Name:    "maintenance",
+		Summary: "Lock the wardrobe while reorganizing directories (on, off, status)",
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "maintenance", args, map[string]func(*App, []string) error{
+				"on":     runMaintenanceOn,
+				"off":    runMaintenanceOff,
+				"status": runMaintenanceStatus,
+			})
+		},
+	}
+}
+
+func runMaintenanceOn(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("maintenance on"), args); err != nil {
+		return err
+	}
+	state, err := usecases.NewMaintenanceUseCase(app.services()).Enable()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Maintenance mode enabled since %s.\n", state.StartedAt.Format(time.RFC3339))
+	fmt.Fprintln(app.stdout, "Picks and cache writes are paused until you run 'outfitpicker maintenance off'.")
+	return nil
+}
+
+func runMaintenanceOff(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("maintenance off"), args); err != nil {
+		return err
+	}
+	report, err := usecases.NewMaintenanceUseCase(app.services()).Disable()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Maintenance mode disabled after %s.\n", report.EndedAt.Sub(report.StartedAt).Round(time.Second))
+	return presentation.RenderWardrobeChanges(app.stdout, report.Changes)
+}
+
+func runMaintenanceStatus(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("maintenance status"), args); err != nil {
+		return err
+	}
+	state, err := usecases.NewMaintenanceUseCase(app.services()).Status()
+	if err != nil {
+		return err
+	}
+	if !state.Enabled {
+		fmt.Fprintln(app.stdout, "Maintenance mode is off.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Maintenance mode is on since %s.\n", state.StartedAt.Format(time.RFC3339))
+	return nil
+}
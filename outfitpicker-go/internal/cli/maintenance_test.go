@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMaintenance_OnOff(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}})
+
+	stdout, stderr, code := env.run("maintenance", "on")
+	if code != ExitOK {
+		t.Fatalf("maintenance on: exit code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "Maintenance mode enabled") {
+		t.Errorf("stdout = %q", stdout)
+	}
+
+	if stdout, _, _ = env.run("maintenance", "status"); !strings.Contains(stdout, "Maintenance mode is on") {
+		t.Errorf("status stdout = %q", stdout)
+	}
+
+	env.writeOutfit("casual", "b.avatar")
+	if err := os.MkdirAll(filepath.Join(env.root, "gym"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code = env.run("maintenance", "off")
+	if code != ExitOK {
+		t.Fatalf("maintenance off: exit code = %v, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{"Maintenance mode disabled", "Added categories (1):\n  gym", "casual/b.avatar"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stdout missing %q:\n%s", want, stdout)
+		}
+	}
+
+	if stdout, _, _ = env.run("maintenance", "status"); !strings.Contains(stdout, "Maintenance mode is off") {
+		t.Errorf("status stdout = %q", stdout)
+	}
+}
+
+func TestMaintenance_OffWhenNotEnabled(t *testing.T) {
+	env := newCLIEnv(t, nil)
+
+	_, stderr, code := env.run("maintenance", "off")
+	if code != ExitInvalidInput {
+		t.Errorf("exit code = %v, want %v", code, ExitInvalidInput)
+	}
+	if !strings.Contains(stderr, "maintenance mode is not enabled") {
+		t.Errorf("stderr = %q", stderr)
+	}
+}
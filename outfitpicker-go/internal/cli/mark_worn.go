@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func markWornCommand() *Command {
+	return &Command{
+		Name:    "mark-worn",
+		Summary: "Record outfits chosen outside outfitpicker as worn, so they count toward the rotation",
+		JSON:    true,
+		Run:     runMarkWorn,
+	}
+}
+
+func runMarkWorn(app *App, args []string) error {
+	fs := app.newFlagSet("mark-worn")
+	fromFile := fs.String("from-file", "", "file listing one category/outfit per line to mark worn, or - for standard input")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	var outfits []entities.OutfitReference
+	switch {
+	case *fromFile != "" && len(positional) == 0:
+		if outfits, err = app.readWornOutfits(*fromFile); err != nil {
+			return err
+		}
+	case *fromFile == "" && len(positional) == 2:
+		outfit, err := app.outfitReference(positional[0], positional[1])
+		if err != nil {
+			return err
+		}
+		outfits = append(outfits, outfit)
+	default:
+		return usageErrorf("usage: mark-worn <category> <outfit> | mark-worn --from-file FILE")
+	}
+
+	results, err := usecases.NewWearOutfitUseCase(app.services()).ExecuteAll(outfits)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(results, presentation.OutfitWornV1))
+	}
+	for _, result := range results {
+		fmt.Fprintf(app.stdout, "Marked %s/%s as worn.\n", result.Category, result.FileName)
+		if result.RotationCompleted {
+			fmt.Fprintf(app.stdout, "Every outfit in %s has been worn; its rotation starts over.\n", result.Category)
+		}
+	}
+	return nil
+}
+
+// readWornOutfits reads the outfits listed in path, or standard input for
+// "-", one category/outfit per line. Blank lines and lines starting with #
+// are skipped.
+func (a *App) readWornOutfits(path string) ([]entities.OutfitReference, error) {
+	var input io.Reader = a.stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	var outfits []entities.OutfitReference
+	scanner := bufio.NewScanner(input)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		category, fileName, ok := strings.Cut(entry, "/")
+		if !ok || category == "" || fileName == "" {
+			return nil, usageErrorf("%s:%d: want category/outfit, got %q", path, line, entry)
+		}
+		outfit, err := a.outfitReference(category, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		outfits = append(outfits, outfit)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(outfits) == 0 {
+		return nil, usageErrorf("%s lists no outfits to mark worn", path)
+	}
+	return outfits, nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMarkWorn(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {"suit.avatar"}})
+
+	stdout, stderr, code := env.run("mark-worn", "casual", "tee.avatar")
+	if code != ExitOK || stdout != "Marked casual/tee.avatar as worn.\n" {
+		t.Fatalf("mark-worn: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	list := filepath.Join(t.TempDir(), "worn.txt")
+	if err := os.WriteFile(list, []byte("# worn this week\ncasual/jeans.avatar\n\nformal/suit.avatar\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr, code = env.run("--json", "mark-worn", "--from-file", list)
+	var results []struct {
+		Category          string `json:"category"`
+		FileName          string `json:"fileName"`
+		RotationCompleted bool   `json:"rotationCompleted"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("mark-worn --from-file: code = %v, stderr = %q, JSON: %v\n%s", code, stderr, err, stdout)
+	}
+	if len(results) != 2 || results[0].FileName != "jeans.avatar" || !results[0].RotationCompleted || !results[1].RotationCompleted {
+		t.Errorf("mark-worn --from-file = %+v, want both rotations completed", results)
+	}
+
+	stdout, _, code = env.run("history", "list", "--worn")
+	if code != ExitOK || strings.Count(stdout, "avatar") != 3 {
+		t.Errorf("history list --worn after mark-worn: code = %v, stdout = %q, want three wears", code, stdout)
+	}
+}
+
+func TestMarkWorn_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	list := filepath.Join(t.TempDir(), "worn.txt")
+	if err := os.WriteFile(list, []byte("casual/tee.avatar\ncasual tee.avatar\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"no arguments", []string{"mark-worn"}, ExitUsage},
+		{"file and arguments", []string{"mark-worn", "--from-file", list, "casual", "tee.avatar"}, ExitUsage},
+		{"malformed line", []string{"mark-worn", "--from-file", list}, ExitUsage},
+		{"unknown outfit", []string{"mark-worn", "casual", "gone.avatar"}, ExitInvalidInput},
+		{"unknown category", []string{"mark-worn", "beach", "towel.avatar"}, ExitCategoryNotFound},
+		{"dry run", []string{"--dry-run", "mark-worn", "casual", "tee.avatar"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+	if stdout, _, _ := env.run("history", "list", "--worn"); strings.Contains(stdout, "tee.avatar") {
+		t.Errorf("a failed mark-worn recorded a wear: %q", stdout)
+	}
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func metadataCommand() *Command {
+	return &Command{
+		Name:    "metadata",
+		Summary: "Show or set an outfit's materials, care symbols, tags and colors (show, set)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "metadata", args, map[string]func(*App, []string) error{
+				"show": runMetadataShow,
+				"set":  runMetadataSet,
+			})
+		},
+	}
+}
+
+func runMetadataShow(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("metadata show"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usageErrorf("usage: metadata show <category> <outfit>")
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	useCase := usecases.NewOutfitMetadataUseCase(app.services())
+	metadata, err := useCase.Get(outfit)
+	if err != nil {
+		return err
+	}
+	sidecar, hasSidecar, err := useCase.Sidecar(outfit)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		var shown *entities.OutfitSidecar
+		if hasSidecar {
+			shown = &sidecar
+		}
+		return app.writeJSON(presentation.OutfitDetailsV1(metadata, shown))
+	}
+	if err := presentation.RenderOutfitMetadata(app.stdout, metadata); err != nil || !hasSidecar {
+		return err
+	}
+	return presentation.RenderOutfitSidecar(app.stdout, outfit.FileName, sidecar)
+}
+
+func runMetadataSet(app *App, args []string) error {
+	if err := refuseJSON(app, "metadata set"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("metadata set")
+	material := fs.String("material", "", "material composition, e.g. cotton:95,elastane:5")
+	care := fs.String("care", "", "comma-separated care symbols, e.g. wash-40,do-not-tumble-dry")
+	price := fs.Float64("price", 0, "what the outfit cost; 0 removes the price")
+	tags := fs.String("tags", "", "comma-separated tags, e.g. summer,date-night")
+	colors := fs.String("colors", "", "comma-separated main colors for ensemble rules, e.g. navy,white")
+	clearMetadata := fs.Bool("clear", false, "remove all metadata from the outfit")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 2 {
+		return usageErrorf("usage: metadata set <category> <outfit> [--material F:P,...] [--care S,...] [--price N] [--tags T,...] [--colors C,...] [--clear]")
+	}
+
+	priceSet := flagWasSet(fs, "price")
+	materials, err := parseMaterials(*material)
+	if err != nil {
+		return err
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	metadata, err := usecases.NewOutfitMetadataUseCase(app.services()).Update(outfit, func(current entities.OutfitMetadata) entities.OutfitMetadata {
+		if *clearMetadata {
+			return entities.OutfitMetadata{}
+		}
+		if materials != nil {
+			current.Materials = materials
+		}
+		if *care != "" {
+			current.Care = splitList(*care)
+		}
+		if priceSet {
+			current.Price = *price
+		}
+		if *tags != "" {
+			current.Tags = splitList(*tags)
+		}
+		if *colors != "" {
+			current.Colors = splitList(*colors)
+		}
+		return current
+	})
+	if err != nil {
+		return err
+	}
+	return presentation.RenderOutfitMetadata(app.stdout, metadata)
+}
+
+// outfitReference resolves an outfit named on the command line against the
+// configured root. The category may be given by name, label or alias.
+func (a *App) outfitReference(category, fileName string) (entities.OutfitReference, error) {
+	reference, err := usecases.NewResolveCategoryUseCase(a.services()).Execute(category)
+	if err != nil {
+		return entities.OutfitReference{}, err
+	}
+	return entities.NewOutfitReference(fileName, reference), nil
+}
+
+// parseMaterials parses "fiber:percent" pairs separated by commas. An empty
+// value returns nil.
+func parseMaterials(value string) ([]entities.MaterialComponent, error) {
+	var materials []entities.MaterialComponent
+	for _, pair := range splitList(value) {
+		fiber, percent, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, usageErrorf("material %q must be written as fiber:percent", pair)
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(percent, "%"))
+		if err != nil {
+			return nil, usageErrorf("material %q has an invalid percentage", pair)
+		}
+		materials = append(materials, entities.MaterialComponent{Fiber: strings.ToLower(strings.TrimSpace(fiber)), Percent: n})
+	}
+	return materials, nil
+}
+
+func splitList(value string) []string {
+	var list stringList
+	list.Set(value)
+	return list
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetadataAndLaundryReport(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "sweater.avatar"}})
+
+	stdout, stderr, code := env.run("metadata", "set", "casual", "tee.avatar", "--material", "cotton:95,elastane:5", "--care", "wash-40,tumble-dry-low")
+	if code != ExitOK {
+		t.Fatalf("metadata set: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "95% cotton, 5% elastane") || !strings.Contains(stdout, "wash-40, tumble-dry-low") {
+		t.Errorf("metadata set output = %q", stdout)
+	}
+	if _, _, code := env.run("metadata", "set", "casual", "sweater.avatar", "--material", "wool:100"); code != ExitOK {
+		t.Fatalf("metadata set sweater: code = %v", code)
+	}
+
+	if stdout, _, _ := env.run("laundry", "report"); stdout != "Nothing to wash.\n" {
+		t.Errorf("laundry report before wearing = %q", stdout)
+	}
+	env.wear(t, "casual", "tee.avatar")
+
+	stdout, _, code = env.run("laundry", "report")
+	want := "Load 1: machine wash at 40°C, tumble dry (1 outfit):\n  casual/tee.avatar\n"
+	if code != ExitOK || stdout != want {
+		t.Errorf("laundry report = %q, want %q", stdout, want)
+	}
+}
+
+func TestMetadataShow_Sidecar(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	sidecar := `{"tags": ["beach"], "seasons": ["summer"], "weight": 2, "notes": "Dry flat"}`
+	if err := os.WriteFile(filepath.Join(env.root, "casual", "tee.avatar.meta.json"), []byte(sidecar), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, code := env.run("metadata", "show", "casual", "tee.avatar")
+	if code != ExitOK || !strings.Contains(stdout, "From tee.avatar.meta.json:\n") || !strings.Contains(stdout, "Seasons:   summer\n") || !strings.Contains(stdout, "Notes:     Dry flat\n") {
+		t.Errorf("metadata show = %q, %q; want the sidecar file", stdout, stderr)
+	}
+
+	stdout, _, _ = env.run("--json", "metadata", "show", "casual", "tee.avatar")
+	var output struct {
+		Sidecar struct {
+			Weight float64 `json:"weight"`
+			Notes  string  `json:"notes"`
+		} `json:"sidecar"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || output.Sidecar.Weight != 2 || output.Sidecar.Notes != "Dry flat" {
+		t.Errorf("metadata show --json = %q, %v", stdout, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(env.root, "casual", "tee.avatar.meta.json"), []byte(`{"seasons": ["monsoon"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, code := env.run("metadata", "show", "casual", "tee.avatar"); code != ExitInvalidConfiguration {
+		t.Errorf("metadata show with an invalid sidecar file: code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestMetadataSet_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing outfit", []string{"metadata", "set", "casual"}, ExitUsage},
+		{"malformed material", []string{"metadata", "set", "casual", "tee.avatar", "--material", "cotton"}, ExitUsage},
+		{"unknown fiber", []string{"metadata", "set", "casual", "tee.avatar", "--material", "denim:100"}, ExitInvalidInput},
+		{"partial composition", []string{"metadata", "set", "casual", "tee.avatar", "--material", "cotton:90"}, ExitInvalidInput},
+		{"unknown care", []string{"metadata", "set", "casual", "tee.avatar", "--care", "spin-dry"}, ExitInvalidInput},
+		{"unknown outfit", []string{"metadata", "set", "casual", "nope.avatar", "--care", "wash-30"}, ExitInvalidInput},
+		{"negative price", []string{"metadata", "set", "casual", "tee.avatar", "--price", "-5"}, ExitInvalidInput},
+		{"invalid tag", []string{"metadata", "set", "casual", "tee.avatar", "--tags", "Summer"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
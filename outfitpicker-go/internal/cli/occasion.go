@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func occasionCommand() *Command {
+	return &Command{
+		Name:    "occasion",
+		Summary: "Name the categories and tags to pick from for an occasion, for pick --occasion (list, set, remove)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "occasion", args, map[string]func(*App, []string) error{
+				"list":   runOccasionList,
+				"set":    runOccasionSet,
+				"remove": runOccasionRemove,
+			})
+		},
+	}
+}
+
+func runOccasionList(app *App, args []string) error {
+	fs := app.newFlagSet("occasion list")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("occasion list takes no arguments, got %q", fs.Arg(0))
+	}
+	occasions, err := usecases.NewOccasionsUseCase(app.services()).List()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		if occasions == nil {
+			occasions = map[string]entities.Occasion{}
+		}
+		return app.writeJSON(presentation.OccasionsV1(occasions))
+	}
+	return presentation.RenderOccasions(app.stdout, occasions)
+}
+
+func runOccasionSet(app *App, args []string) error {
+	if err := refuseJSON(app, "occasion set"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("occasion set")
+	var categories, tags stringList
+	fs.Var(&categories, "categories", "category to pick from (repeatable or comma-separated; default any category)")
+	fs.Var(&tags, "tags", "pick only outfits with any of these tags (repeatable or comma-separated; default any outfit)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len(categories) == 0 && len(tags) == 0 {
+		return usageErrorf("usage: occasion set <name> [--categories CATEGORY,...] [--tags TAG,...]")
+	}
+
+	services := app.services()
+	occasion := entities.Occasion{Tags: tags}
+	for _, name := range categories {
+		category, err := usecases.NewResolveCategoryUseCase(services).Execute(name)
+		if err != nil {
+			return err
+		}
+		occasion.Categories = append(occasion.Categories, category.Name)
+	}
+	if err := usecases.NewOccasionsUseCase(services).Set(positional[0], occasion); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Set occasion %s; pick for it with: pick --occasion %s\n", positional[0], positional[0])
+	return nil
+}
+
+func runOccasionRemove(app *App, args []string) error {
+	if err := refuseJSON(app, "occasion remove"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("occasion remove"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: occasion remove <name>")
+	}
+	if err := usecases.NewOccasionsUseCase(app.services()).Remove(positional[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Removed occasion %s.\n", positional[0])
+	return nil
+}
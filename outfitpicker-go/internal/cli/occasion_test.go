@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOccasion(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{
+		"casual": {"tee.avatar"},
+		"formal": {"suit.avatar", "tux.avatar"},
+		"work":   {"blazer.avatar", "hoodie.avatar"},
+	})
+	for _, args := range [][]string{
+		{"tag", "add", "formal", "suit.avatar", "smart"},
+		{"tag", "add", "work", "blazer.avatar", "smart"},
+		{"occasion", "set", "interview", "--categories", "work,formal", "--tags", "smart"},
+		{"occasion", "set", "office", "--categories", "work"},
+	} {
+		if _, stderr, code := env.run(args...); code != ExitOK {
+			t.Fatalf("%v: code = %v, stderr = %q", args, code, stderr)
+		}
+	}
+
+	stdout, _, code := env.run("occasion", "list")
+	if want := "  interview: from work or formal, tagged smart\n  office: from work, any outfit\n"; code != ExitOK || stdout != want {
+		t.Errorf("occasion list = %q, want %q", stdout, want)
+	}
+	stdout, _, _ = env.run("--json", "occasion", "list")
+	var occasions map[string]struct {
+		Categories []string `json:"categories"`
+		Tags       []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &occasions); err != nil || len(occasions["interview"].Categories) != 2 {
+		t.Errorf("occasion list --json = %q, %v", stdout, err)
+	}
+
+	for range 5 {
+		stdout, stderr, code := env.run("--dry-run", "pick", "--occasion", "interview")
+		if code != ExitOK || !strings.HasPrefix(stdout, "Would pick work/blazer.avatar\n") && !strings.HasPrefix(stdout, "Would pick formal/suit.avatar\n") {
+			t.Fatalf("pick --occasion interview: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+		}
+	}
+	if stdout, stderr, code := env.run("pick", "--occasion", "office", "--tag", "smart"); code != ExitOK || stdout != "work/blazer.avatar\n" {
+		t.Errorf("pick --occasion office: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	if stdout, _, code := env.run("occasion", "remove", "office"); code != ExitOK || stdout != "Removed occasion office.\n" {
+		t.Errorf("occasion remove: code = %v, stdout = %q", code, stdout)
+	}
+	if _, _, code := env.run("pick", "--occasion", "office"); code != ExitInvalidInput {
+		t.Errorf("pick for a removed occasion: code = %v, want %v", code, ExitInvalidInput)
+	}
+}
+
+func TestOccasion_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := env.run("occasion", "set", "chill", "--tags", "relaxed"); code != ExitOK {
+		t.Fatalf("occasion set: code = %v, stderr = %q", code, stderr)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"set without categories or tags", []string{"occasion", "set", "interview"}, ExitUsage},
+		{"set an unknown category", []string{"occasion", "set", "interview", "--categories", "pyjamas"}, ExitCategoryNotFound},
+		{"set a bad name", []string{"occasion", "set", "Date Night", "--tags", "dressy"}, ExitInvalidInput},
+		{"remove an unknown occasion", []string{"occasion", "remove", "wedding"}, ExitInvalidInput},
+		{"pick with a category too", []string{"pick", "casual", "--occasion", "chill"}, ExitUsage},
+		{"pick with --all too", []string{"pick", "--all", "--occasion", "chill"}, ExitUsage},
+		{"pick with no outfit tagged", []string{"pick", "--occasion", "chill"}, ExitNoOutfits},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
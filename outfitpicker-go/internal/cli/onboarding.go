@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// maxSetupAttempts bounds how often the guided setup re-prompts after an
+// invalid answer.
+const maxSetupAttempts = 3
+
+var errSetupAborted = errors.New("setup aborted")
+
+func initCommand() *Command {
+	return &Command{
+		Name:    "init",
+		Summary: "Create the initial configuration",
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("init")
+			root := fs.String("root", "", "wardrobe directory with one sub-directory per category")
+			language := fs.String("language", "", "language code (default "+entities.DefaultLanguage+")")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+
+			useCase := usecases.NewOnboardingUseCase(app.services())
+			required, err := useCase.Required()
+			if err != nil {
+				return err
+			}
+			if !required {
+				path, err := app.services().Config.Path()
+				if err != nil {
+					return err
+				}
+				return fmt.Errorf("already configured (%s)", path)
+			}
+
+			var result *usecases.OnboardingResult
+			switch {
+			case *root != "":
+				result, err = completeOnboarding(useCase, *root, *language)
+			case app.isInteractive():
+				result, err = app.guidedSetup(useCase)
+			default:
+				return usageErrorf("init requires --root when input is not a terminal")
+			}
+			if err != nil {
+				return err
+			}
+			return presentation.RenderOnboardingResult(app.stdout, result)
+		},
+	}
+}
+
+// onboard handles a command that failed because no configuration exists.
+// Interactive sessions run the guided setup and then retry the command;
+// otherwise the next steps are printed, as JSON when --json is set.
+func (a *App) onboard(retry func() error) error {
+	useCase := usecases.NewOnboardingUseCase(a.services())
+	if !a.isInteractive() {
+		guidance, err := useCase.Guidance()
+		if err != nil {
+			return err
+		}
+		if a.jsonOutput {
+			if err := a.writeJSON(presentation.OnboardingV1(guidance)); err != nil {
+				return err
+			}
+		} else if err := presentation.RenderOnboardingGuidance(a.stderr, guidance); err != nil {
+			return err
+		}
+		return errors.New("outfitpicker is not configured yet")
+	}
+
+	fmt.Fprintln(a.stderr, "No configuration found. Let's set up your wardrobe.")
+	result, err := a.guidedSetup(useCase)
+	if err != nil {
+		return err
+	}
+	if err := presentation.RenderOnboardingResult(a.stderr, result); err != nil {
+		return err
+	}
+	return retry()
+}
+
+// guidedSetup prompts for the wardrobe directory and language until a valid
+// configuration is saved. Prompts go to stderr so stdout carries only command
+// output.
+func (a *App) guidedSetup(useCase *usecases.OnboardingUseCase) (*usecases.OnboardingResult, error) {
+	input := bufio.NewScanner(a.stdin)
+	for range maxSetupAttempts {
+		root, ok := a.prompt(input, "Wardrobe directory (one sub-directory per category): ")
+		if !ok {
+			return nil, errSetupAborted
+		}
+		language, ok := a.prompt(input, fmt.Sprintf("Language [%s]: ", entities.DefaultLanguage))
+		if !ok {
+			return nil, errSetupAborted
+		}
+
+		result, err := completeOnboarding(useCase, root, language)
+		if err == nil {
+			return result, nil
+		}
+		fmt.Fprintf(a.stderr, "  %v\n", err)
+	}
+	return nil, fmt.Errorf("%w after %d attempts", errSetupAborted, maxSetupAttempts)
+}
+
+func (a *App) prompt(input *bufio.Scanner, question string) (string, bool) {
+	fmt.Fprint(a.stderr, question)
+	if !input.Scan() {
+		fmt.Fprintln(a.stderr)
+		return "", false
+	}
+	return strings.TrimSpace(input.Text()), true
+}
+
+// completeOnboarding builds a validated configuration and saves it. An empty
+// language selects the default.
+func completeOnboarding(useCase *usecases.OnboardingUseCase, root, language string) (*usecases.OnboardingResult, error) {
+	root, err := expandPath(root)
+	if err != nil {
+		return nil, err
+	}
+	builder := entities.NewConfigBuilder().RootDirectory(root)
+	if language != "" {
+		builder.Language(language)
+	}
+	config, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return useCase.Complete(config)
+}
+
+// expandPath resolves a leading ~ and makes path absolute.
+func expandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	if path == "" {
+		return "", nil
+	}
+	return filepath.Abs(path)
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+// runFirstRun runs the app against an empty state directory.
+func runFirstRun(t *testing.T, stdin string, interactive bool, args ...string) (stdout, stderr string, code int, stateDir string) {
+	t.Helper()
+	stateDir = t.TempDir()
+	var out, errOut bytes.Buffer
+	app := New(
+		WithOutput(&out, &errOut),
+		WithInput(strings.NewReader(stdin)),
+		WithInteractive(interactive),
+		WithDirectoryProvider(system.NewStaticDirectoryProvider(stateDir)),
+	)
+	code = app.Run(args)
+	return out.String(), errOut.String(), code, stateDir
+}
+
+func newWardrobeRoot(t *testing.T) string {
+	t.Helper()
+	root := testhelpers.UnrestrictedTempDir(t)
+	if err := os.MkdirAll(filepath.Join(root, "casual"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "casual", "tee.avatar"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestFirstRun_MachineModePrintsGuidance(t *testing.T) {
+	stdout, _, code, _ := runFirstRun(t, "", false, "--json", "list")
+	if code != ExitError {
+		t.Errorf("exit code = %v, want %v", code, ExitError)
+	}
+
+	var guidance usecases.OnboardingGuidance
+	if err := json.Unmarshal([]byte(stdout), &guidance); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if guidance.Status != usecases.OnboardingStatusRequired || len(guidance.Steps) == 0 {
+		t.Errorf("guidance = %+v", guidance)
+	}
+}
+
+func TestFirstRun_NonInteractivePrintsSteps(t *testing.T) {
+	stdout, stderr, code, _ := runFirstRun(t, "", false, "list")
+	if code != ExitError || stdout != "" {
+		t.Errorf("code = %v, stdout = %q", code, stdout)
+	}
+	if !strings.Contains(stderr, "outfitpicker init --root") {
+		t.Errorf("stderr missing next steps: %q", stderr)
+	}
+}
+
+func TestFirstRun_GuidedSetupThenRetries(t *testing.T) {
+	root := newWardrobeRoot(t)
+
+	stdout, stderr, code, _ := runFirstRun(t, root+"\n\n", true, "list")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %s", code, stderr)
+	}
+	if !strings.Contains(stderr, "Found 1 categories with 1 outfits") {
+		t.Errorf("setup summary missing: %q", stderr)
+	}
+	if !strings.Contains(stdout, "casual") {
+		t.Errorf("list not run after setup: %q", stdout)
+	}
+}
+
+func TestFirstRun_GuidedSetupRepromptsOnInvalidAnswer(t *testing.T) {
+	root := newWardrobeRoot(t)
+
+	_, stderr, code, _ := runFirstRun(t, "/etc/wardrobe\n\n"+root+"\nen\n", true, "list")
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %s", code, stderr)
+	}
+	if strings.Count(stderr, "Wardrobe directory") != 2 {
+		t.Errorf("expected a second prompt: %q", stderr)
+	}
+}
+
+func TestFirstRun_GuidedSetupAbortsOnEOF(t *testing.T) {
+	_, stderr, code, _ := runFirstRun(t, "", true, "list")
+	if code != ExitError || !strings.Contains(stderr, "setup aborted") {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestInit(t *testing.T) {
+	root := newWardrobeRoot(t)
+
+	stdout, stderr, code, stateDir := runFirstRun(t, "", false, "init", "--root", root)
+	if code != ExitOK {
+		t.Fatalf("exit code = %v, stderr = %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Found 1 categories") {
+		t.Errorf("stdout = %q", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, "outfitpicker", "config.json")); err != nil {
+		t.Errorf("config not saved: %v", err)
+	}
+}
+
+func TestInit_RequiresRootWhenNotInteractive(t *testing.T) {
+	_, _, code, _ := runFirstRun(t, "", false, "init")
+	if code != ExitUsage {
+		t.Errorf("exit code = %v, want %v", code, ExitUsage)
+	}
+}
+
+func TestInit_AlreadyConfigured(t *testing.T) {
+	env := newCLIEnv(t, nil)
+	_, stderr, code := env.run("init", "--root", env.root)
+	if code != ExitError || !strings.Contains(stderr, "already configured") {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+}
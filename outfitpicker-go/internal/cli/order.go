@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func orderCommand() *Command {
+	return &Command{
+		Name:    "order",
+		Summary: "Choose the order categories are listed in (show, set)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "order", args, map[string]func(*App, []string) error{
+				"show": runOrderShow,
+				"set":  runOrderSet,
+			})
+		},
+	}
+}
+
+func runOrderShow(app *App, args []string) error {
+	fs := app.newFlagSet("order show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("order show takes no arguments, got %q", fs.Arg(0))
+	}
+	order, err := usecases.NewCategoryOrderUseCase(app.services()).Current()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.CategoryOrderV1(order))
+	}
+	fmt.Fprintln(app.stdout, describeCategoryOrder(order))
+	return nil
+}
+
+func runOrderSet(app *App, args []string) error {
+	if err := refuseJSON(app, "order set"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("order set"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 || positional[0] != entities.CategorySortManual && len(positional) > 1 {
+		return usageErrorf("usage: order set name|health|progress | order set manual <category>...")
+	}
+
+	order := entities.CategoryOrder{Sort: positional[0]}
+	services := app.services()
+	for _, name := range positional[1:] {
+		category, err := usecases.NewResolveCategoryUseCase(services).Execute(name)
+		if err != nil {
+			return err
+		}
+		order.Manual = append(order.Manual, category.Name)
+	}
+	err = usecases.NewCategoryOrderUseCase(services).Set(order)
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: the order must be one of %s, and manual needs each category listed once", err, strings.Join(validation.CategorySorts(), ", "))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout, describeCategoryOrder(order))
+	return nil
+}
+
+func describeCategoryOrder(order entities.CategoryOrder) string {
+	switch order.EffectiveSort() {
+	case entities.CategorySortManual:
+		return fmt.Sprintf("Categories are listed as %s, then the rest by name.", strings.Join(order.Manual, ", "))
+	case entities.CategorySortHealth:
+		return "Categories are listed least healthy first."
+	case entities.CategorySortProgress:
+		return "Categories are listed furthest through their rotation first."
+	default:
+		return "Categories are listed by name."
+	}
+}
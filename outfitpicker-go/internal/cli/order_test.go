@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOrder(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"suit.avatar"}, "beach": {"shorts.avatar"}})
+	if stdout, _, _ := env.run("order", "show"); stdout != "Categories are listed by name.\n" {
+		t.Errorf("order show = %q", stdout)
+	}
+
+	stdout, stderr, code := env.run("order", "set", "manual", "work", "beach")
+	if code != ExitOK || stdout != "Categories are listed as work, beach, then the rest by name.\n" {
+		t.Fatalf("order set manual: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	stdout, _, _ = env.run("list")
+	var listed []string
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n")[1:] {
+		listed = append(listed, strings.Fields(line)[0])
+	}
+	if strings.Join(listed, " ") != "work beach casual" {
+		t.Errorf("list order = %v, want work beach casual", listed)
+	}
+	if stdout, _, _ := env.run("__complete", "pick", ""); stdout != "work\nbeach\ncasual\n" {
+		t.Errorf("completion order = %q", stdout)
+	}
+
+	if stdout, _, _ := env.run("--json", "order", "show"); !strings.Contains(stdout, `"sort": "manual"`) {
+		t.Errorf("order show --json = %q", stdout)
+	}
+}
+
+func TestOrder_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing sort", []string{"order", "set"}, ExitUsage},
+		{"categories without manual", []string{"order", "set", "name", "casual"}, ExitUsage},
+		{"unknown sort", []string{"order", "set", "size"}, ExitInvalidConfiguration},
+		{"unknown category", []string{"order", "set", "manual", "missing"}, ExitCategoryNotFound},
+		{"category listed twice", []string{"order", "set", "manual", "casual", "casual"}, ExitInvalidConfiguration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func pickCommand() *Command {
+	return &Command{
+		Name:    "pick",
+		Summary: "Pick a random unworn outfit from a category, from any with --all, or for an --occasion; pick ensemble picks one per slot",
+		DryRun:  true,
+		JSON:    true,
+		Run:     runPick,
+	}
+}
+
+func runPick(app *App, args []string) error {
+	category, occasion, opts, err := parsePickArgs(app, args)
+	if err != nil {
+		return err
+	}
+	services := app.services()
+	if occasion != "" {
+		target, occasionOpts, err := usecases.NewOccasionsUseCase(services).Target(occasion)
+		if err != nil {
+			return err
+		}
+		return runPickTarget(app, services, target, append(occasionOpts, opts...))
+	}
+	if category == "" {
+		return runPickTarget(app, services, entities.SelectionTargetAllCategories{}, opts)
+	}
+	if category == ensembleKeyword {
+		return runPickEnsemble(app, services, opts)
+	}
+	resolved, err := usecases.NewResolveCategoryUseCase(services).Execute(category)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		proposal, err := usecases.NewPickOutfitUseCase(services).Propose(resolved.Name, opts...)
+		if err != nil {
+			return err
+		}
+		return writeDryRun(app, fmt.Sprintf("Would pick %s/%s", proposal.Outfit.Category.Name, proposal.Outfit.FileName), presentation.OutfitRefV1(proposal.Outfit), proposal.Changes())
+	}
+	outfit, err := usecases.NewPickOutfitUseCase(services).Execute(resolved.Name, opts...)
+	if err != nil {
+		return err
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(presentation.OutfitRefV1(*outfit))
+	}
+	fmt.Fprintf(app.stdout, "%s/%s\n", outfit.Category.Name, outfit.FileName)
+	return nil
+}
+
+// parsePickArgs parses the arguments of pick into the category, empty for
+// --all or --occasion and ensembleKeyword for an ensemble, the occasion, empty unless --occasion is given, and
+// the pick options.
+func parsePickArgs(app *App, args []string) (string, string, []usecases.PickOption, error) {
+	fs := app.newFlagSet("pick")
+	seed := fs.Uint64("seed", 0, "seed for a reproducible pick")
+	all := fs.Bool("all", false, "pick from any category that is not excluded")
+	occasion := fs.String("occasion", "", "pick for an occasion set with the occasion command, from its categories and tags")
+	filters := addPickFilterFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return "", "", nil, err
+	}
+	targets := len(positional)
+	if *all {
+		targets++
+	}
+	if *occasion != "" {
+		targets++
+	}
+	if targets != 1 {
+		return "", "", nil, usageErrorf("usage: pick <category>|ensemble|--all|--occasion NAME [--seed N] [--favorites-only] [--tag TAG] [--season auto|SEASON] [--weather] [--policy POLICY]")
+	}
+
+	opts, err := filters.options()
+	if err != nil {
+		return "", "", nil, err
+	}
+	if flagWasSet(fs, "seed") {
+		opts = append(opts, usecases.WithPickSeed(*seed))
+	}
+	if len(positional) == 0 {
+		return "", *occasion, opts, nil
+	}
+	return positional[0], "", opts, nil
+}
+
+// runPickTarget picks from target, warning about the categories without
+// outfits when the empty category policy asks for it.
+func runPickTarget(app *App, services usecases.Services, target entities.SelectionTarget, opts []usecases.PickOption) error {
+	pickAny := usecases.NewPickAnyOutfitUseCase(services)
+	result, err := pickAny.ProposeFor(target, opts...)
+	if err != nil {
+		return err
+	}
+	if result.Policy == entities.EmptyCategoriesWarn {
+		presentation.RenderSkippedCategories(app.stderr, result.Skipped)
+	}
+	if app.dryRun {
+		return writeDryRun(app, fmt.Sprintf("Would pick %s/%s", result.Outfit.Category.Name, result.Outfit.FileName), aggregatePickV1(result), result.Changes())
+	}
+	if err := pickAny.Commit(result); err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(aggregatePickV1(result))
+	}
+	fmt.Fprintf(app.stdout, "%s/%s\n", result.Outfit.Category.Name, result.Outfit.FileName)
+	return nil
+}
+
+// aggregatePickV1 converts result into its version 1 output shape.
+func aggregatePickV1(result *usecases.AggregatePick) v1.AggregatePick {
+	return presentation.AggregatePickV1(result.Outfit, result.Skipped, result.Policy, result.Resting)
+}
+
+// pickFilterFlags holds the flags that narrow which outfits a pick chooses
+// from.
+type pickFilterFlags struct {
+	favoritesOnly *bool
+	tag           *string
+	season        *string
+	weather       *bool
+	policy        *string
+}
+
+func addPickFilterFlags(fs *flag.FlagSet) pickFilterFlags {
+	return pickFilterFlags{
+		favoritesOnly: fs.Bool("favorites-only", false, "pick only from the category's favorites"),
+		tag:           fs.String("tag", "", "pick only outfits with this tag"),
+		season:        fs.String("season", "", "pick only in season: auto for the current season, or winter, spring, summer, autumn"),
+		weather:       fs.Bool("weather", false, "leave out categories and outfits unsuitable for today's forecast"),
+		policy:        fs.String("policy", "", "rotation policy for this pick: strict, least-recently-worn, cooldown:DAYS or random"),
+	}
+}
+
+// options returns the pick options of the flags that were given.
+func (f pickFilterFlags) options() ([]usecases.PickOption, error) {
+	var opts []usecases.PickOption
+	if *f.favoritesOnly {
+		opts = append(opts, usecases.WithFavoritesOnly())
+	}
+	if *f.tag != "" {
+		opts = append(opts, usecases.WithTag(*f.tag))
+	}
+	if *f.season != "" {
+		opts = append(opts, usecases.WithSeason(*f.season))
+	}
+	if *f.weather {
+		opts = append(opts, usecases.WithWeather())
+	}
+	if *f.policy != "" {
+		policy, err := entities.ParseRotationPolicy(*f.policy)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, usecases.WithRotationPolicy(policy))
+	}
+	return opts, nil
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestPick_SeedIsReproducible(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar"}})
+
+	first, stderr, code := env.run("pick", "casual", "--seed", "7")
+	if code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.HasPrefix(first, "casual/") || !strings.HasSuffix(first, ".avatar\n") {
+		t.Errorf("pick output = %q", first)
+	}
+	for range 5 {
+		if again, _, _ := env.run("pick", "casual", "--seed", "7"); again != first {
+			t.Fatalf("seeded pick = %q, then %q", first, again)
+		}
+	}
+}
+
+func TestPick_LocalizedCategoryAndJSON(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := env.run("alias", "casual", "--label", "fr=décontracté"); code != ExitOK {
+		t.Fatalf("alias: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("--json", "pick", "décontracté")
+	if code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	var outfit struct {
+		FileName string `json:"fileName"`
+		Category struct {
+			Name string `json:"name"`
+		} `json:"category"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &outfit); err != nil {
+		t.Fatalf("pick JSON: %v\n%s", err, stdout)
+	}
+	if outfit.Category.Name != "casual" || outfit.FileName != "tee.avatar" {
+		t.Errorf("pick = %+v", outfit)
+	}
+}
+
+func TestPick_WritesLatestPicks(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"suit.avatar"}})
+	for _, category := range []string{"casual", "work"} {
+		if _, stderr, code := env.run("pick", category); code != ExitOK {
+			t.Fatalf("pick %s: code = %v, stderr = %q", category, code, stderr)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(env.stateDir, "outfitpicker", "picks", "latest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var latest struct {
+		Schema     int `json:"schema"`
+		Categories map[string]struct {
+			FileName string `json:"fileName"`
+			Path     string `json:"path"`
+		} `json:"categories"`
+	}
+	if err := json.Unmarshal(data, &latest); err != nil {
+		t.Fatalf("latest.json: %v\n%s", err, data)
+	}
+	if latest.Schema != 1 || latest.Categories["casual"].FileName != "tee.avatar" ||
+		latest.Categories["work"].Path != filepath.Join(env.root, "work", "suit.avatar") {
+		t.Errorf("latest.json = %s", data)
+	}
+}
+
+func TestPick_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "empty": nil})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing category", []string{"pick"}, ExitUsage},
+		{"bad seed", []string{"pick", "casual", "--seed", "-1"}, ExitUsage},
+		{"unknown category", []string{"pick", "pyjamas"}, ExitCategoryNotFound},
+		{"no outfits", []string{"pick", "empty"}, ExitNoOutfits},
+		{"unknown policy", []string{"pick", "casual", "--policy", "shuffle"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestPick_Policy(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual"); stdout != "casual/jeans.avatar\n" {
+			t.Fatalf("strict pick = %q, want the unworn outfit", stdout)
+		}
+	}
+	picked := make(map[string]bool)
+	for range 30 {
+		stdout, stderr, code := env.run("pick", "casual", "--policy", "random")
+		if code != ExitOK {
+			t.Fatalf("pick --policy random: code = %v, stderr = %q", code, stderr)
+		}
+		picked[stdout] = true
+	}
+	if !picked["casual/tee.avatar\n"] {
+		t.Errorf("pick --policy random picked %v, want the worn outfit too", picked)
+	}
+}
+
+func TestPick_All(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"beach": {}, "casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.run("pick", "--all")
+	if code != ExitOK || stdout != "casual/tee.avatar\n" || stderr != "" {
+		t.Errorf("pick --all: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	env.updateConfig(func(config *entities.Config) { config.Selection.EmptyCategories = entities.EmptyCategoriesWarn })
+	stdout, stderr, code = env.run("--json", "pick", "--all")
+	if code != ExitOK || stderr != "warning: skipped beach (empty)\n" {
+		t.Errorf("pick --all warning: code = %v, stderr = %q", code, stderr)
+	}
+	var result struct {
+		Skipped []struct {
+			State string `json:"state"`
+		} `json:"skipped"`
+		Policy string `json:"policy"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || result.Policy != "warn" || len(result.Skipped) != 1 || result.Skipped[0].State != "empty" {
+		t.Errorf("pick --all --json = %q, %v", stdout, err)
+	}
+
+	env.updateConfig(func(config *entities.Config) { config.Selection.EmptyCategories = entities.EmptyCategoriesFail })
+	if _, stderr, code := env.run("pick", "--all"); code != ExitEmptyCategories || !strings.Contains(stderr, "categories without outfits: beach") {
+		t.Errorf("pick --all failing: code = %v, stderr = %q", code, stderr)
+	}
+
+	for _, args := range [][]string{{"pick", "--all", "casual"}, {"pick"}} {
+		if _, _, code := env.run(args...); code != ExitUsage {
+			t.Errorf("%v: code = %v, want %v", args, code, ExitUsage)
+		}
+	}
+}
+
+func TestPick_AllRestsLastCategory(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"shirt.avatar"}})
+	env.updateConfig(func(config *entities.Config) { config.Selection.CategoryRestDays = 1 })
+
+	first, stderr, code := env.run("pick", "--all")
+	if code != ExitOK {
+		t.Fatalf("pick --all: code = %v, stderr = %q", code, stderr)
+	}
+	for range 5 {
+		second, _, _ := env.run("pick", "--all")
+		if second == first {
+			t.Fatalf("pick --all repeated %q on the same day", first)
+		}
+		first = second
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func planCommand() *Command {
+	return &Command{
+		Name:    "plan",
+		Summary: "Plan outfits a week ahead, one per category each day (generate, show, reroll, wear, export)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "plan", args, map[string]func(*App, []string) error{
+				"generate": runPlanGenerate,
+				"show":     runPlanShow,
+				"reroll":   runPlanReroll,
+				"wear":     runPlanWear,
+				"export":   runPlanExport,
+			})
+		},
+	}
+}
+
+func runPlanGenerate(app *App, args []string) error {
+	fs := app.newFlagSet("plan generate")
+	days := fs.Int("days", usecases.DefaultPlanDays, "how many days to plan, starting today")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("plan generate takes no arguments, got %q", fs.Arg(0))
+	}
+
+	report, err := usecases.NewOutfitPlanUseCase(app.services()).Generate(*days)
+	if err != nil {
+		return err
+	}
+	return writeOutfitPlan(app, report)
+}
+
+func runPlanShow(app *App, args []string) error {
+	fs := app.newFlagSet("plan show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("plan show takes no arguments, got %q", fs.Arg(0))
+	}
+
+	report, err := usecases.NewOutfitPlanUseCase(app.services()).Show()
+	if err != nil {
+		return err
+	}
+	return writeOutfitPlan(app, report)
+}
+
+func runPlanReroll(app *App, args []string) error {
+	fs := app.newFlagSet("plan reroll")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: plan reroll DAY (a weekday such as monday, or YYYY-MM-DD)")
+	}
+
+	report, err := usecases.NewOutfitPlanUseCase(app.services()).Reroll(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeOutfitPlan(app, report)
+}
+
+func runPlanWear(app *App, args []string) error {
+	fs := app.newFlagSet("plan wear")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageErrorf("usage: plan wear DAY (a weekday such as monday, or YYYY-MM-DD)")
+	}
+
+	report, err := usecases.NewOutfitPlanUseCase(app.services()).Wear(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeOutfitPlan(app, report)
+}
+
+func runPlanExport(app *App, args []string) error {
+	fs := app.newFlagSet("plan export")
+	format := fs.String("format", "ics", "export format: ics for an iCalendar file")
+	out := fs.String("out", "", "path of the file to write (default standard output)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("plan export takes no arguments, got %q", fs.Arg(0))
+	}
+	if *format != "ics" {
+		return usageErrorf("invalid --format %q (want ics)", *format)
+	}
+
+	report, err := usecases.NewOutfitPlanUseCase(app.services()).Show()
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return presentation.RenderOutfitPlanICS(app.stdout, report)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	err = presentation.RenderOutfitPlanICS(f, report)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+	events := 0
+	for _, day := range report.Days {
+		events += len(day.Outfits)
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.PlanExportSummary{Out: *out, Format: *format, Events: events})
+	}
+	fmt.Fprintf(app.stdout, "Wrote %d planned outfits to %s.\n", events, *out)
+	return nil
+}
+
+func writeOutfitPlan(app *App, report logic.PlanReport) error {
+	if app.jsonOutput {
+		return app.writeJSON(presentation.PlanReportV1(report))
+	}
+	return presentation.RenderOutfitPlan(app.stdout, report)
+}
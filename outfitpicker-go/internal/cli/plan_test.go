@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlan(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}, "work": {"shirt.avatar"}})
+
+	stdout, stderr, code := env.run("--json", "plan", "generate", "--days", "3")
+	if code != ExitOK {
+		t.Fatalf("plan generate: code = %v, stderr = %q", code, stderr)
+	}
+	var plan struct {
+		Days []struct {
+			Worn    bool `json:"worn"`
+			Outfits []struct {
+				Category string `json:"category"`
+				FileName string `json:"fileName"`
+			} `json:"outfits"`
+		} `json:"days"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &plan); err != nil {
+		t.Fatalf("plan JSON: %v\n%s", err, stdout)
+	}
+	if len(plan.Days) != 3 || len(plan.Days[0].Outfits) != 2 {
+		t.Fatalf("plan = %+v, want 3 days with two outfits each", plan)
+	}
+
+	today := time.Now().Format(historyDateLayout)
+	if _, stderr, code := env.run("plan", "reroll", today); code != ExitOK {
+		t.Fatalf("plan reroll: code = %v, stderr = %q", code, stderr)
+	}
+	stdout, stderr, code = env.run("plan", "wear", today)
+	if code != ExitOK || !strings.Contains(stdout, "  worn\n") {
+		t.Fatalf("plan wear: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	stdout, _, code = env.run("plan", "show")
+	if code != ExitOK || strings.Count(stdout, "work    shirt.avatar") != 3 {
+		t.Errorf("plan show: code = %v, stdout = %q", code, stdout)
+	}
+}
+
+func TestPlanExport(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}, "work": {"shirt.avatar"}})
+	if _, stderr, code := env.run("plan", "generate", "--days", "2"); code != ExitOK {
+		t.Fatalf("plan generate: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("plan", "export", "--format", "ics")
+	if code != ExitOK || !strings.HasPrefix(stdout, "BEGIN:VCALENDAR\r\n") || strings.Count(stdout, "BEGIN:VEVENT") != 4 {
+		t.Fatalf("plan export: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "DTSTART;VALUE=DATE:"+time.Now().Format("20060102")) {
+		t.Errorf("plan export has no event today:\n%s", stdout)
+	}
+
+	out := filepath.Join(t.TempDir(), "plan.ics")
+	stdout, _, code = env.run("plan", "export", "--out", out)
+	if code != ExitOK || stdout != "Wrote 4 planned outfits to "+out+".\n" {
+		t.Errorf("plan export --out: code = %v, stdout = %q", code, stdout)
+	}
+	if data, err := os.ReadFile(out); err != nil || !strings.HasSuffix(string(data), "END:VCALENDAR\r\n") {
+		t.Errorf("exported file = %q, %v", data, err)
+	}
+	if _, _, code := env.run("plan", "export", "--format", "csv"); code != ExitUsage {
+		t.Errorf("plan export --format csv: code = %v, want ExitUsage", code)
+	}
+}
+
+func TestPlan_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, stderr, code := env.run("plan", "show"); code != ExitInvalidInput {
+		t.Errorf("plan show without a plan: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("plan", "generate"); code != ExitOK {
+		t.Fatalf("plan generate: code = %v, stderr = %q", code, stderr)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"too many days", []string{"plan", "generate", "--days", "15"}, ExitInvalidInput},
+		{"reroll without a day", []string{"plan", "reroll"}, ExitUsage},
+		{"wear without a day", []string{"plan", "wear"}, ExitUsage},
+		{"unknown day", []string{"plan", "reroll", "someday"}, ExitInvalidInput},
+		{"day outside the plan", []string{"plan", "wear", "2000-01-01"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"plan", "clear"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func profileCommand() *Command {
+	return &Command{
+		Name:    "profile",
+		Summary: "Keep separate wardrobes as named profiles (create, list, switch, delete)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "profile", args, map[string]func(*App, []string) error{
+				"create": runProfileCreate,
+				"list":   runProfileList,
+				"switch": runProfileSwitch,
+				"delete": runProfileDelete,
+			})
+		},
+	}
+}
+
+func runProfileCreate(app *App, args []string) error {
+	fs := app.newFlagSet("profile create")
+	var roots pathList
+	fs.Var(&roots, "root", "wardrobe directory of the profile, repeatable (required)")
+	language := fs.String("language", "", "language code")
+	var exclude stringList
+	fs.Var(&exclude, "exclude", "category to exclude (repeatable or comma-separated)")
+	switchTo := fs.Bool("switch", false, "make the new profile the active one")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || len(roots) == 0 {
+		return usageErrorf("usage: profile create <name> --root DIR [--exclude CATEGORY] [--language LANG] [--switch]")
+	}
+	name := positional[0]
+	profiles := usecases.NewProfilesUseCase(app.services())
+	if err := profiles.EnsureNew(name); err != nil {
+		return err
+	}
+	rootPaths, err := roots.expanded()
+	if err != nil {
+		return err
+	}
+	request := usecases.SetupRequest{Roots: rootPaths, Language: *language, Exclude: exclude}
+	if _, err := usecases.NewSetupUseCase(app.servicesFor(name)).Execute(request); err != nil {
+		return err
+	}
+	active := app.profile
+	if *switchTo {
+		if err := profiles.Switch(name); err != nil {
+			return err
+		}
+		active = name
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(v1.ProfileChange{Profile: name, Active: active})
+	}
+	fmt.Fprintf(app.stdout, "Created profile %s for %s.\n", name, strings.Join(rootPaths, ", "))
+	if *switchTo {
+		fmt.Fprintf(app.stdout, "Switched to profile %s.\n", name)
+	}
+	return nil
+}
+
+func runProfileList(app *App, args []string) error {
+	if err := parseFlags(app.newFlagSet("profile list"), args); err != nil {
+		return err
+	}
+	profiles, err := usecases.NewProfilesUseCase(app.services()).List()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(profiles, presentation.ProfileV1))
+	}
+	return presentation.RenderProfiles(app.stdout, profiles)
+}
+
+func runProfileSwitch(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("profile switch"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: profile switch <name>")
+	}
+	name := positional[0]
+	if err := usecases.NewProfilesUseCase(app.services()).Switch(name); err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.ProfileChange{Profile: name, Active: name})
+	}
+	fmt.Fprintf(app.stdout, "Switched to profile %s.\n", name)
+	return nil
+}
+
+func runProfileDelete(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("profile delete"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: profile delete <name>")
+	}
+	name := positional[0]
+	profiles := usecases.NewProfilesUseCase(app.services())
+	if err := profiles.Delete(name); err != nil {
+		return err
+	}
+	active, err := profiles.Active()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.ProfileChange{Profile: name, Active: active})
+	}
+	fmt.Fprintf(app.stdout, "Deleted profile %s with its configuration and history.\n", name)
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func TestProfile_SeparateWardrobes(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"work": {"suit.avatar"}})
+	travelRoot := newWardrobeRoot(t)
+
+	stdout, stderr, code := env.run("profile", "create", "travel", "--root", travelRoot, "--exclude", "formal")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Created profile travel") {
+		t.Fatalf("profile create: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(env.stateDir, "outfitpicker", "profiles", "travel", "config.json")); err != nil {
+		t.Errorf("travel config: %v", err)
+	}
+
+	if stdout, _, _ := env.run("--profile", "travel", "pick", "casual"); stdout != "casual/tee.avatar\n" {
+		t.Errorf("pick in travel = %q", stdout)
+	}
+	if stdout, _, _ := env.run("pick", "work"); stdout != "work/suit.avatar\n" {
+		t.Errorf("pick in the default profile = %q", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(env.stateDir, "outfitpicker", "profiles", "travel", "history.json")); err != nil {
+		t.Errorf("travel history: %v", err)
+	}
+
+	if _, stderr, code := env.run("profile", "switch", "travel"); code != ExitOK {
+		t.Fatalf("profile switch: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("profile", "list"); stdout != "  default\n* travel\n" {
+		t.Errorf("profile list = %q", stdout)
+	}
+	if _, _, code := env.run("pick", "work"); code != ExitCategoryNotFound {
+		t.Errorf("pick work in travel: code = %v, want ExitCategoryNotFound", code)
+	}
+
+	stdout, _, code = env.run("--json", "profile", "delete", "travel")
+	var output v1.ProfileChange
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || code != ExitOK || output.Active != entities.DefaultProfile {
+		t.Errorf("profile delete = %q (code %v)", stdout, code)
+	}
+	if stdout, _, _ := env.run("pick", "work"); stdout != "work/suit.avatar\n" {
+		t.Errorf("pick after deleting the active profile = %q", stdout)
+	}
+}
+
+func TestProfile_Errors(t *testing.T) {
+	env := newCLIEnv(t, nil)
+	root := newWardrobeRoot(t)
+	if _, stderr, code := env.run("profile", "create", "travel", "--root", root); code != ExitOK {
+		t.Fatalf("profile create: code = %v, stderr = %q", code, stderr)
+	}
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"--profile", "beach", "list"}, ExitInvalidInput},
+		{[]string{"profile", "create", "travel", "--root", root}, ExitInvalidInput},
+		{[]string{"profile", "create", "Beach", "--root", root}, ExitInvalidInput},
+		{[]string{"profile", "create", "beach"}, ExitUsage},
+		{[]string{"profile", "switch", "beach"}, ExitInvalidInput},
+		{[]string{"profile", "delete", "default"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		if _, stderr, code := env.run(tt.args...); code != tt.want {
+			t.Errorf("%q: code = %v, want %v (stderr %q)", tt.args, code, tt.want, stderr)
+		}
+	}
+	if stdout, _, _ := env.run("__complete", "profile", "switch", ""); stdout != "default\ntravel\n" {
+		t.Errorf("profile name completions = %q", stdout)
+	}
+}
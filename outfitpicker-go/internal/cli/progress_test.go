@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProgress_NDJSON(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	archive := writeZip(t, "jeans.avatar", "coat.avatar")
+
+	stdout, stderr, code := env.run("--progress", "ndjson", "import", "archive", archive, "--into", "casual")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Imported 2 outfits") {
+		t.Fatalf("import archive: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	lines := strings.Split(strings.TrimSuffix(stderr, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("stderr = %q, want 4 progress events", stderr)
+	}
+	type progressEvent struct {
+		Operation string `json:"operation"`
+		Phase     string `json:"phase"`
+		Current   int    `json:"current"`
+		Item      string `json:"item"`
+	}
+	var events []progressEvent
+	for _, line := range lines {
+		var event progressEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("progress line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	if events[0].Operation != "import" || events[0].Phase != "start" || events[1].Item != "jeans.avatar" || events[3].Phase != "done" || events[3].Current != 2 {
+		t.Errorf("events = %+v", events)
+	}
+
+	// Without the flag nothing is written to stderr.
+	if _, stderr, _ := env.run("import", "archive", archive, "--into", "casual"); stderr != "" {
+		t.Errorf("stderr without --progress = %q", stderr)
+	}
+}
+
+func TestProgress_UnknownFormat(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	_, stderr, code := env.run("--progress", "bar", "list")
+	if code != ExitUsage || !strings.Contains(stderr, `unknown progress format "bar"`) {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func rateCommand() *Command {
+	return &Command{
+		Name:    "rate",
+		Summary: "Rate an outfit from 1 to 5; the rated strategy picks higher-rated outfits more often",
+		JSON:    true,
+		Run:     runRate,
+	}
+}
+
+func runRate(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("rate"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 {
+		return usageErrorf("usage: rate <category> <outfit> <1-5>")
+	}
+	rating, err := strconv.Atoi(positional[2])
+	if err != nil {
+		return usageErrorf("rating %q is not a whole number", positional[2])
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	services := app.services()
+	if err := usecases.NewOutfitRatingsUseCase(services).Rate(outfit, rating); err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.OutfitRating{Category: outfit.Category.Name, FileName: outfit.FileName, Rating: rating})
+	}
+	fmt.Fprintf(app.stdout, "Rated %s/%s %s.\n", outfit.Category.Name, outfit.FileName, presentation.FormatRating(rating))
+	if config, err := services.Config.Load(); err == nil && !config.Selection.IsRated() {
+		fmt.Fprintln(app.stderr, "Ratings steer picks under the rated strategy; enable it with 'outfitpicker setup --strategy rated'.")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRate_AndStatsRatings(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	if stdout, _, _ := env.run("stats", "ratings"); !strings.HasPrefix(stdout, "No rated outfits yet") {
+		t.Errorf("stats ratings before rating = %q", stdout)
+	}
+
+	stdout, stderr, code := env.run("rate", "casual", "tee.avatar", "5")
+	if code != ExitOK {
+		t.Fatalf("rate: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout != "Rated casual/tee.avatar 5/5.\n" {
+		t.Errorf("rate output = %q", stdout)
+	}
+	if !strings.Contains(stderr, "--strategy rated") {
+		t.Errorf("rate stderr = %q, want a hint to enable the rated strategy", stderr)
+	}
+	if _, _, code := env.run("rate", "casual", "jeans.avatar", "2"); code != ExitOK {
+		t.Fatalf("rate jeans: code = %v", code)
+	}
+
+	want := "Average rating by category:\n" +
+		"  casual: 3.5 (2 rated outfits)\n" +
+		"Rated outfits:\n" +
+		"  5/5  casual/tee.avatar\n" +
+		"  2/5  casual/jeans.avatar\n"
+	if stdout, _, _ := env.run("stats", "ratings"); stdout != want {
+		t.Errorf("stats ratings = %q, want %q", stdout, want)
+	}
+	stdout, _, _ = env.run("--json", "stats", "ratings")
+	var report struct {
+		Outfits []struct {
+			FileName string `json:"fileName"`
+			Rating   int    `json:"rating"`
+		} `json:"outfits"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil || len(report.Outfits) != 2 || report.Outfits[0].Rating != 5 {
+		t.Errorf("stats ratings --json = %q, %v", stdout, err)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "casual", "tee.avatar"); !strings.Contains(stdout, "Rating:    5/5") {
+		t.Errorf("metadata show = %q, want the rating", stdout)
+	}
+}
+
+func TestRate_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing rating", []string{"rate", "casual", "tee.avatar"}, ExitUsage},
+		{"not a number", []string{"rate", "casual", "tee.avatar", "great"}, ExitUsage},
+		{"out of range", []string{"rate", "casual", "tee.avatar", "6"}, ExitInvalidInput},
+		{"unknown outfit", []string{"rate", "casual", "nope.avatar", "3"}, ExitInvalidInput},
+		{"stats ratings argument", []string{"stats", "ratings", "casual"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func repairCommand() *Command {
+	return &Command{
+		Name:    "repair",
+		Summary: "Repair the rotation state in bulk, treating the wardrobe on disk as authoritative (--from-disk)",
+		JSON:    true,
+		Run:     runRepair,
+	}
+}
+
+func runRepair(app *App, args []string) error {
+	fs := app.newFlagSet("repair")
+	fromDisk := fs.Bool("from-disk", false, "rebuild outfit counts, drop worn outfits whose files are missing and rewrite the known files from the disk")
+	yes := fs.Bool("yes", false, "repair without asking for confirmation")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("repair takes no arguments, got %q", fs.Arg(0))
+	}
+	if !*fromDisk {
+		return usageErrorf("usage: repair --from-disk [--yes]")
+	}
+
+	useCase := usecases.NewDiskRepairUseCase(app.services())
+	repair, err := useCase.Plan()
+	if err != nil {
+		return err
+	}
+	if !app.jsonOutput {
+		if err := presentation.RenderDiskRepair(app.stdout, repair.Categories); err != nil {
+			return err
+		}
+	}
+	apply := !repair.IsEmpty() && (*yes || app.confirmRepair())
+	if apply {
+		if err := useCase.Apply(repair); err != nil {
+			return err
+		}
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(v1.Repair{Categories: presentation.ListV1(repair.Categories, presentation.CategoryRepairV1), Applied: apply})
+	}
+	switch {
+	case repair.IsEmpty():
+	case apply:
+		fmt.Fprintln(app.stdout, "Repaired the rotation state from the disk.")
+	case !app.isInteractive():
+		fmt.Fprintln(app.stderr, "Nothing was changed; run repair --from-disk --yes to apply these repairs.")
+	default:
+		fmt.Fprintln(app.stderr, "Nothing was changed.")
+	}
+	return nil
+}
+
+// confirmRepair asks whether to apply a planned repair. Without a terminal
+// to ask on, the repair is not applied.
+func (a *App) confirmRepair() bool {
+	if !a.isInteractive() {
+		return false
+	}
+	answer, ok := a.prompt(bufio.NewScanner(a.stdin), "Apply these repairs? [y/N] ")
+	return ok && strings.EqualFold(answer, "y")
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepair_FromDisk(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	if err := os.Remove(filepath.Join(env.root, "casual", "tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut bytes.Buffer
+	app := New(WithOutput(&out, &errOut), WithInteractive(false), WithDirectoryProvider(env.directoryProvider()))
+	if code := app.Run([]string{"repair", "--from-disk"}); code != ExitOK || !strings.Contains(out.String(), "casual:\n  outfits in rotation: 2 -> 1\n  - worn tee.avatar\n") {
+		t.Fatalf("repair without a terminal: code = %v, stdout = %q", code, out.String())
+	}
+	if !strings.Contains(errOut.String(), "--yes") {
+		t.Errorf("repair without a terminal: stderr = %q, want a hint to confirm with --yes", errOut.String())
+	}
+	if _, stderr, _ := env.runInteractive("n\n", "repair", "--from-disk"); !strings.Contains(stderr, "Nothing was changed.") {
+		t.Errorf("declined repair: stderr = %q", stderr)
+	}
+
+	stdout, stderr, code := env.runInteractive("y\n", "repair", "--from-disk")
+	if code != ExitOK || !strings.Contains(stdout, "Repaired the rotation state from the disk.") {
+		t.Fatalf("confirmed repair: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	stdout, _, _ = env.run("--json", "repair", "--from-disk", "--yes")
+	var output struct {
+		Categories []json.RawMessage `json:"categories"`
+		Applied    bool              `json:"applied"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || len(output.Categories) != 0 || output.Applied {
+		t.Errorf("repair after repairing = %q, want nothing to repair", stdout)
+	}
+}
+
+func TestRepair_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	for _, args := range [][]string{{"repair"}, {"repair", "--from-disk", "casual"}} {
+		if _, _, code := env.run(args...); code != ExitUsage {
+			t.Errorf("%v: exit code = %v, want ExitUsage", args, code)
+		}
+	}
+}
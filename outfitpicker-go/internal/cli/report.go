@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/infrastructure/mail"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+const reportMonthFormat = "2006-01"
+
+func reportCommand() *Command {
+	return &Command{
+		Name:    "report",
+		Summary: "Generate wardrobe reports (monthly, shopping, configure)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "report", args, map[string]func(*App, []string) error{
+				"monthly":   runReportMonthly,
+				"shopping":  runReportShopping,
+				"configure": runReportConfigure,
+			})
+		},
+	}
+}
+
+func runReportMonthly(app *App, args []string) error {
+	fs := app.newFlagSet("report monthly")
+	monthFlag := fs.String("month", "", "month to report on as YYYY-MM (default: last month)")
+	format := fs.String("format", "", "output format: "+strings.Join(validation.ReportFormats(), " or ")+" (default: configured format)")
+	outDir := fs.String("out-dir", "", "write report-YYYY-MM.<format> into this directory instead of stdout")
+	email := fs.Bool("email", false, "email the report through the configured SMTP server")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: report monthly [--month YYYY-MM] [--format text|html] [--out-dir DIR] [--email]")
+	}
+
+	var month time.Time
+	if *monthFlag != "" {
+		parsed, err := time.ParseInLocation(reportMonthFormat, *monthFlag, time.Local)
+		if err != nil {
+			return usageErrorf("invalid --month %q: want YYYY-MM", *monthFlag)
+		}
+		month = parsed
+	}
+
+	useCase := usecases.NewMonthlyReportUseCase(app.services())
+	if *format == "" {
+		settings, err := useCase.Settings()
+		if err != nil {
+			return err
+		}
+		*format = settings.FormatOrDefault()
+	}
+	if validation.ValidateReportFormat(*format) != nil {
+		return usageErrorf("invalid --format %q (want one of: %s)", *format, strings.Join(validation.ReportFormats(), ", "))
+	}
+
+	report, err := useCase.Generate(month)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput && *outDir == "" && !*email {
+		return app.writeJSON(presentation.MonthlyReportV1(*report))
+	}
+
+	var rendered bytes.Buffer
+	render := presentation.RenderMonthlyReport
+	if *format == entities.ReportFormatHTML {
+		render = presentation.RenderMonthlyReportHTML
+	}
+	if err := render(&rendered, report); err != nil {
+		return err
+	}
+
+	if *outDir == "" && !*email {
+		_, err := app.stdout.Write(rendered.Bytes())
+		return err
+	}
+	if *outDir != "" {
+		path := filepath.Join(*outDir, fmt.Sprintf("report-%s.%s", report.Month.Format(reportMonthFormat), reportExtension(*format)))
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, rendered.Bytes(), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.stdout, "Wrote %s.\n", path)
+	}
+	if *email {
+		err := useCase.Send(entities.MailMessage{
+			Subject: presentation.MonthlyReportSubject(report),
+			Body:    rendered.String(),
+			HTML:    *format == entities.ReportFormatHTML,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(app.stdout, "Emailed the report.")
+	}
+	return nil
+}
+
+func reportExtension(format string) string {
+	if format == entities.ReportFormatHTML {
+		return "html"
+	}
+	return "txt"
+}
+
+func runReportShopping(app *App, args []string) error {
+	fs := app.newFlagSet("report shopping")
+	quota := fs.Int("min", usecases.DefaultShoppingQuota, "distinct outfits a month each category should offer")
+	days := fs.Int("days", usecases.DefaultShoppingDays, "days of wears to measure each category's cadence over")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: report shopping [--min N] [--days N]")
+	}
+
+	suggestions, err := usecases.NewShoppingSuggestionsUseCase(app.services()).Execute(usecases.ShoppingRequest{Quota: *quota, Days: *days})
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(suggestions, presentation.ShoppingSuggestionV1))
+	}
+	return presentation.RenderShoppingSuggestions(app.stdout, suggestions, *quota)
+}
+
+func runReportConfigure(app *App, args []string) error {
+	if err := refuseJSON(app, "report configure"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("report configure")
+	format := fs.String("format", "", "default output format: "+strings.Join(validation.ReportFormats(), " or "))
+	host := fs.String("smtp-host", "", "SMTP server host name")
+	port := fs.Int("smtp-port", 587, "SMTP server port")
+	username := fs.String("smtp-user", "", "SMTP user name; the password is read from $"+mail.PasswordEnvVar)
+	from := fs.String("from", "", "sender address")
+	var to stringList
+	fs.Var(&to, "to", "recipient address (repeatable or comma-separated)")
+	noSMTP := fs.Bool("no-smtp", false, "remove the SMTP server settings")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return usageErrorf("usage: report configure [--format text|html] [--smtp-host HOST] [--smtp-port N] [--smtp-user USER] [--from ADDR] [--to ADDR] [--no-smtp]")
+	}
+
+	settings, err := usecases.NewMonthlyReportUseCase(app.services()).Configure(func(current entities.ReportSettings) entities.ReportSettings {
+		if flagWasSet(fs, "format") {
+			current.Format = *format
+		}
+		if *noSMTP {
+			current.SMTP = nil
+			return current
+		}
+		if !slices.ContainsFunc([]string{"smtp-host", "smtp-port", "smtp-user", "from", "to"}, func(name string) bool { return flagWasSet(fs, name) }) {
+			return current
+		}
+		smtp := entities.SMTPSettings{Port: *port}
+		if current.SMTP != nil {
+			smtp = *current.SMTP
+		}
+		if flagWasSet(fs, "smtp-host") {
+			smtp.Host = *host
+		}
+		if flagWasSet(fs, "smtp-port") || smtp.Port == 0 {
+			smtp.Port = *port
+		}
+		if flagWasSet(fs, "smtp-user") {
+			smtp.Username = *username
+		}
+		if flagWasSet(fs, "from") {
+			smtp.From = *from
+		}
+		if flagWasSet(fs, "to") {
+			smtp.To = to
+		}
+		current.SMTP = &smtp
+		return current
+	})
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: the format must be text or html, and SMTP needs a host, a port from 1 to 65535, a sender and at least one recipient", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(app.stdout, "Reports are rendered as %s.\n", settings.FormatOrDefault())
+	if settings.SMTP == nil {
+		fmt.Fprintln(app.stdout, "No SMTP server is configured.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Reports are emailed to %s through %s:%d.\n", strings.Join(settings.SMTP.To, ", "), settings.SMTP.Host, settings.SMTP.Port)
+	return nil
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportMonthly(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	month := time.Now().Format(reportMonthFormat)
+
+	stdout, stderr, code := env.run("report", "monthly", "--month", month)
+	if code != ExitOK {
+		t.Fatalf("report monthly: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "0 picks, 1 wear, 0 completed rotations") || !strings.Contains(stdout, "casual/jeans.avatar (never worn)") {
+		t.Errorf("report monthly output = %q", stdout)
+	}
+
+	dir := filepath.Join(t.TempDir(), "reports")
+	stdout, stderr, code = env.run("report", "monthly", "--month", month, "--format", "html", "--out-dir", dir)
+	if code != ExitOK {
+		t.Fatalf("report monthly --out-dir: code = %v, stderr = %q", code, stderr)
+	}
+	path := filepath.Join(dir, "report-"+month+".html")
+	if stdout != "Wrote "+path+".\n" {
+		t.Errorf("report monthly --out-dir output = %q", stdout)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("report file = %q, %v", data, err)
+	}
+}
+
+func TestReportMonthly_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	if _, _, code := env.run("report", "monthly", "--month", "May"); code != ExitUsage {
+		t.Errorf("invalid --month: code = %v, want ExitUsage", code)
+	}
+	if _, _, code := env.run("report", "monthly", "--format", "pdf"); code != ExitUsage {
+		t.Errorf("invalid --format: code = %v, want ExitUsage", code)
+	}
+	if _, stderr, code := env.run("report", "monthly", "--email"); code != ExitInvalidInput || !strings.Contains(stderr, "report configure") {
+		t.Errorf("--email without SMTP: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestReportConfigure(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.run("report", "configure", "--format", "html", "--smtp-host", "smtp.example.com", "--from", "me@example.com", "--to", "me@example.com,you@example.com")
+	if code != ExitOK {
+		t.Fatalf("report configure: code = %v, stderr = %q", code, stderr)
+	}
+	if want := "Reports are rendered as html.\nReports are emailed to me@example.com, you@example.com through smtp.example.com:587.\n"; stdout != want {
+		t.Errorf("report configure output = %q, want %q", stdout, want)
+	}
+
+	if _, stderr, code := env.run("report", "configure", "--smtp-port", "0"); code != ExitInvalidConfiguration || !strings.Contains(stderr, "port") {
+		t.Errorf("invalid port: code = %v, stderr = %q", code, stderr)
+	}
+
+	if stdout, _, _ := env.run("report", "configure", "--no-smtp"); !strings.HasSuffix(stdout, "No SMTP server is configured.\n") {
+		t.Errorf("report configure --no-smtp output = %q", stdout)
+	}
+}
+
+func TestReportShopping(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	if stdout, _, code := env.run("report", "shopping"); code != ExitOK || !strings.HasPrefix(stdout, "Every category offers enough variety") {
+		t.Errorf("report shopping before wearing: code = %v, stdout = %q", code, stdout)
+	}
+	env.wear(t, "casual", "tee.avatar")
+	env.wear(t, "casual", "jeans.avatar")
+
+	stdout, stderr, code := env.run("report", "shopping", "--min", "5", "--days", "1")
+	if code != ExitOK {
+		t.Fatalf("report shopping: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "casual: add 2 outfits (3 outfits,") {
+		t.Errorf("report shopping output = %q", stdout)
+	}
+
+	if _, _, code := env.run("report", "shopping", "--min", "-2"); code != ExitInvalidInput {
+		t.Errorf("report shopping --min -2: code = %v, want ExitInvalidInput", code)
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func rotationCommand() *Command {
+	return &Command{
+		Name:    "rotation",
+		Summary: "Lock categories so a new rotation starts only by hand (lock, unlock, reset)",
+		DryRun:  true,
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "rotation", args, map[string]func(*App, []string) error{
+				"lock":   runRotationLock,
+				"unlock": runRotationUnlock,
+				"reset":  runRotationReset,
+			})
+		},
+	}
+}
+
+func runRotationLock(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("rotation lock"), args)
+	if err != nil {
+		return err
+	}
+	if err := refuseDryRun(app, "rotation lock"); err != nil {
+		return err
+	}
+	if err := refuseJSON(app, "rotation lock"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: rotation lock <category>")
+	}
+	services := app.services()
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	if err := usecases.NewRotationLockUseCase(services).Lock(category.Name); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "The rotation of %s is locked; once every outfit is worn, start a new one with: rotation reset %s\n", category.Name, category.Name)
+	return nil
+}
+
+func runRotationUnlock(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("rotation unlock"), args)
+	if err != nil {
+		return err
+	}
+	if err := refuseDryRun(app, "rotation unlock"); err != nil {
+		return err
+	}
+	if err := refuseJSON(app, "rotation unlock"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: rotation unlock <category>")
+	}
+	services := app.services()
+	// Categories that no longer exist can still be unlocked.
+	name := positional[0]
+	if category, err := usecases.NewResolveCategoryUseCase(services).Execute(name); err == nil {
+		name = category.Name
+	}
+	if err := usecases.NewRotationLockUseCase(services).Unlock(name); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "The rotation of %s is unlocked.\n", name)
+	return nil
+}
+
+func runRotationReset(app *App, args []string) error {
+	fs := app.newFlagSet("rotation reset")
+	profile := fs.String("profile-target", "", "reset the rotation of this profile instead, as this profile")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: rotation reset <category> [--profile-target NAME]")
+	}
+	services, target := app.services(), app.services()
+	if *profile != "" {
+		if err := usecases.NewProfilesUseCase(app.servicesFor(entities.DefaultProfile)).EnsureExists(*profile); err != nil {
+			return err
+		}
+		target = app.servicesFor(*profile)
+	}
+	category, err := usecases.NewResolveCategoryUseCase(target).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	rotations := usecases.NewRotationLockUseCase(services)
+	proposal, err := rotations.ProposeResetOf(*profile, target, category.Name)
+	if err != nil {
+		return err
+	}
+	name := category.Name
+	if *profile != "" {
+		name = fmt.Sprintf("%s for %s", category.Name, *profile)
+	}
+	if app.dryRun {
+		summary := fmt.Sprintf("Would start a new rotation of %s: %d of %d outfits worn would be unworn again", name, len(proposal.Worn), proposal.Total)
+		return writeDryRun(app, summary, presentation.RotationResetV1(*proposal), proposal.Changes())
+	}
+	if err := rotations.CommitReset(proposal); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Started a new rotation of %s.\n", name)
+	return nil
+}
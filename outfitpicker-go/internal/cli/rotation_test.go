@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRotation_LockResetUnlock(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"costumes": {"pirate.avatar"}})
+
+	if stdout, stderr, code := env.run("rotation", "lock", "costumes"); code != ExitOK || !strings.HasPrefix(stdout, "The rotation of costumes is locked") {
+		t.Fatalf("rotation lock: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	env.wear(t, "costumes", "pirate.avatar")
+	if _, stderr, code := env.run("pick", "costumes"); code != ExitInvalidInput || !strings.Contains(stderr, "rotation reset costumes") {
+		t.Errorf("pick of a worn-through locked rotation: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("list"); !strings.Contains(stdout, "LOCK") || !strings.Contains(stdout, "reset needed") {
+		t.Errorf("list = %q, want the lock column", stdout)
+	}
+	if stdout, _, _ := env.run("stats", "summary"); !strings.Contains(stdout, "Locked rotations:    costumes (reset needed)") {
+		t.Errorf("stats = %q, want the locked rotation", stdout)
+	}
+
+	if stdout, _, code := env.run("rotation", "reset", "costumes"); code != ExitOK || stdout != "Started a new rotation of costumes.\n" {
+		t.Errorf("rotation reset: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("pick", "costumes"); stdout != "costumes/pirate.avatar\n" {
+		t.Errorf("pick after reset = %q", stdout)
+	}
+	if stdout, _, code := env.run("rotation", "unlock", "costumes"); code != ExitOK || stdout != "The rotation of costumes is unlocked.\n" {
+		t.Errorf("rotation unlock: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("list"); strings.Contains(stdout, "LOCK") {
+		t.Errorf("list after unlock = %q, want no lock column", stdout)
+	}
+}
+
+func TestRotation_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"costumes": {"pirate.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"lock without category", []string{"rotation", "lock"}, ExitUsage},
+		{"lock unknown category", []string{"rotation", "lock", "missing"}, ExitCategoryNotFound},
+		{"reset unknown category", []string{"rotation", "reset", "missing"}, ExitCategoryNotFound},
+		{"unknown subcommand", []string{"rotation", "start"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
+
+func TestRotation_ResetForAnotherProfile(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	if _, stderr, code := env.run("setup", "--root", root); code != ExitOK {
+		t.Fatalf("setup: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("profile", "create", "kids", "--root", root); code != ExitOK {
+		t.Fatalf("profile create: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("--profile", "kids", "setup", "--allow-reset", "school"); code != ExitOK {
+		t.Fatalf("setup kids: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("--profile", "kids", "mark-worn", "casual", "tee.avatar"); code != ExitOK {
+		t.Fatalf("mark-worn in kids: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("--profile", "kids", "pick", "casual"); code != ExitInvalidInput || !strings.Contains(stderr, "may not start a new rotation") {
+		t.Fatalf("pick in kids: code = %v, stderr = %q, want the reset refused", code, stderr)
+	}
+
+	if _, _, code := env.run("--profile", "kids", "rotation", "reset", "casual", "--profile-target", "kids"); code != ExitInvalidInput {
+		t.Errorf("reset by kids: code = %v, want the permission refused", code)
+	}
+	stdout, stderr, code := env.run("rotation", "reset", "casual", "--profile-target", "kids")
+	if code != ExitOK || stdout != "Started a new rotation of casual for kids.\n" {
+		t.Fatalf("reset for kids: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("--profile", "kids", "pick", "casual"); stdout != "casual/tee.avatar\n" {
+		t.Errorf("pick in kids after the reset = %q", stdout)
+	}
+	if _, _, code := env.run("rotation", "reset", "casual", "--profile-target", "nobody"); code == ExitOK {
+		t.Error("reset for a missing profile succeeded")
+	}
+}
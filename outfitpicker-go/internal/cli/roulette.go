@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	"github.com/dh85/outfitpicker/internal/presentation/tui"
+)
+
+var errRouletteAborted = errors.New("roulette aborted; nothing was saved")
+
+func rouletteCommand() *Command {
+	return &Command{
+		Name:    "roulette",
+		Summary: "Spin through a category's outfits, vetoing until one is accepted",
+		JSON:    true,
+		Run:     runRoulette,
+	}
+}
+
+func runRoulette(app *App, args []string) error {
+	fs := app.newFlagSet("roulette")
+	filters := addPickFilterFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: roulette <category> [--favorites-only] [--tag TAG] [--season auto|SEASON] [--weather] [--policy POLICY]")
+	}
+	if !app.isInteractive() {
+		return usageErrorf("roulette prompts after each spin and needs an interactive terminal; use pick instead")
+	}
+
+	opts, err := filters.options()
+	if err != nil {
+		return err
+	}
+
+	services := app.services()
+	category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+	pick := usecases.NewPickOutfitUseCase(services)
+	proposal, vetoes, err := app.spinRoulette(pick, category.Name, opts)
+	if err != nil {
+		return err
+	}
+	if proposal == nil {
+		fmt.Fprintln(app.stderr, "No outfit accepted; nothing was saved.")
+		return nil
+	}
+	if err := pick.Commit(proposal); err != nil {
+		return err
+	}
+
+	if app.jsonOutput {
+		return app.writeJSON(presentation.RouletteV1(proposal.Outfit, vetoes))
+	}
+	fmt.Fprintf(app.stdout, "%s/%s\n", proposal.Outfit.Category.Name, proposal.Outfit.FileName)
+	return nil
+}
+
+// spinRoulette proposes outfits until one is accepted, returning it with
+// the vetoes made on the way. Spinning on skips the current outfit until
+// every other one has come up; vetoed outfits never come up again. Only
+// proposals are made here, so quitting returns no outfit and saves nothing.
+// The roulette screen is drawn on stderr, leaving stdout to the outfit
+// accepted.
+func (a *App) spinRoulette(pick *usecases.PickOutfitUseCase, category string, opts []usecases.PickOption) (*usecases.PickProposal, []presentation.RouletteVeto, error) {
+	if f, ok := a.stdin.(*os.File); ok && isTerminal(f) {
+		restore, err := tui.EnableRaw(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer restore()
+	}
+	input := bufio.NewReader(a.stdin)
+	var model tui.RouletteModel
+	var vetoed, spun []string
+	var vetoes []presentation.RouletteVeto
+	for spin := 1; ; spin++ {
+		proposal, err := pick.Propose(category, append(slices.Clone(opts), usecases.WithoutOutfits(append(slices.Clone(vetoed), spun...)...))...)
+		if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) && len(spun) > 0 {
+			spun = nil
+			proposal, err = pick.Propose(category, append(slices.Clone(opts), usecases.WithoutOutfits(vetoed...))...)
+		}
+		if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) && len(vetoed) > 0 {
+			return nil, nil, fmt.Errorf("every outfit in %s was vetoed; nothing was saved", category)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		model.Land(spin, proposal.Outfit, proposal.Available, proposal.Total)
+
+		for action := tui.RouletteNone; action != tui.RouletteSpin; {
+			if err := tui.RenderRoulette(a.stderr, &model); err != nil {
+				return nil, nil, err
+			}
+			if model.Vetoing {
+				r, _, err := input.ReadRune()
+				if err != nil {
+					return nil, nil, rouletteInputError(err)
+				}
+				action = model.Type(r)
+			} else {
+				key, err := tui.ReadKey(input)
+				if err != nil {
+					return nil, nil, rouletteInputError(err)
+				}
+				action = model.Handle(key)
+			}
+			switch action {
+			case tui.RouletteAccept:
+				return proposal, vetoes, nil
+			case tui.RouletteQuit:
+				return nil, nil, nil
+			case tui.RouletteSpin:
+				spun = append(spun, proposal.Outfit.FileName)
+			case tui.RouletteVeto:
+				vetoed = append(vetoed, proposal.Outfit.FileName)
+				vetoes = append(vetoes, presentation.RouletteVeto{FileName: proposal.Outfit.FileName, Reason: model.Reason})
+				action = tui.RouletteSpin
+			}
+		}
+	}
+}
+
+// rouletteInputError is the error for input that failed or ended before an
+// outfit was accepted.
+func rouletteInputError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return errRouletteAborted
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func TestRoulette_VetoesUntilAccepted(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	answers := strings.Join([]string{
+		"x",                // ignored
+		"vtoo warmm\x7f\r", // veto the first spin, correcting the reason
+		"v\r",              // veto the second without a reason
+		" ",                // spin again; only one outfit is left
+		"\r",               // accept
+	}, "")
+
+	stdout, stderr, code := env.runInteractive(answers, "--json", "roulette", "casual")
+	if code != ExitOK {
+		t.Fatalf("roulette: code = %v, stderr = %q", code, stderr)
+	}
+	var result v1.Roulette
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("roulette --json = %q: %v", stdout, err)
+	}
+	if len(result.Vetoes) != 2 || result.Vetoes[0].Reason != "too warm" || result.Vetoes[1].Reason != "" {
+		t.Errorf("vetoes = %+v", result.Vetoes)
+	}
+	for _, veto := range result.Vetoes {
+		if veto.FileName == result.Outfit.FileName {
+			t.Errorf("accepted vetoed outfit %s", veto.FileName)
+		}
+	}
+	for _, want := range []string{"Spin 1: casual/", "3 of 3 outfits left to spin, 0 vetoed", "Spin 4: casual/" + result.Outfit.FileName + "\r\n1 of 3 outfits left to spin, 2 vetoed"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(env.stateDir, "outfitpicker", "history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), `"fileName"`); got != 1 || !strings.Contains(string(data), result.Outfit.FileName) {
+		t.Errorf("history after roulette = %s, want only the accepted outfit", data)
+	}
+}
+
+func TestRoulette_QuitSavesNothing(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar"}})
+
+	stdout, stderr, code := env.runInteractive(" q", "roulette", "casual")
+	if code != ExitOK || stdout != "" || !strings.Contains(stderr, "nothing was saved") {
+		t.Errorf("roulette quit: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	for _, name := range []string{"history.json", "arrivals.json"} {
+		if _, err := os.Stat(filepath.Join(env.stateDir, "outfitpicker", name)); !os.IsNotExist(err) {
+			t.Errorf("%s after quitting: %v, want none", name, err)
+		}
+	}
+
+	_, stderr, code = env.runInteractive("vtoo", "roulette", "casual")
+	if code != ExitError || !strings.Contains(stderr, errRouletteAborted.Error()) {
+		t.Errorf("closed input: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestRoulette_EveryOutfitVetoed(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}})
+
+	_, stderr, code := env.runInteractive("vno\r", "roulette", "casual")
+	if code != ExitError || !strings.Contains(stderr, "every outfit in casual was vetoed") {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestRoulette_FavoritesOnlyWrapsAround(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar"}})
+	for _, name := range []string{"a.avatar", "b.avatar"} {
+		if _, stderr, code := env.run("favorite", "add", "casual", name); code != ExitOK {
+			t.Fatalf("favorite add %s: code = %v, stderr = %q", name, code, stderr)
+		}
+	}
+
+	// Spinning past both favorites starts over on them rather than failing.
+	stdout, stderr, code := env.runInteractive("   \r", "--json", "roulette", "casual", "--favorites-only")
+	if code != ExitOK {
+		t.Fatalf("roulette --favorites-only: code = %v, stderr = %q", code, stderr)
+	}
+	var result v1.Roulette
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("roulette --json = %q: %v", stdout, err)
+	}
+	if name := result.Outfit.FileName; name != "a.avatar" && name != "b.avatar" {
+		t.Errorf("accepted %s, want a favorite", name)
+	}
+	if !strings.Contains(stderr, "Spin 4: casual/") {
+		t.Errorf("stderr missing the fourth spin:\n%s", stderr)
+	}
+
+	_, stderr, code = env.runInteractive("v\rv\r", "roulette", "casual", "--favorites-only")
+	if code != ExitError || !strings.Contains(stderr, "every outfit in casual was vetoed") {
+		t.Errorf("vetoing every favorite: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestRoulette_NeedsInteractiveTerminal(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}})
+
+	var out, errOut bytes.Buffer
+	app := New(WithOutput(&out, &errOut), WithInteractive(false), WithDirectoryProvider(env.directoryProvider()))
+	if code := app.Run([]string{"roulette", "casual"}); code != ExitUsage || !strings.Contains(errOut.String(), "interactive terminal") {
+		t.Errorf("code = %v, stderr = %q", code, errOut.String())
+	}
+}
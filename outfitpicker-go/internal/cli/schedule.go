@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func scheduleCommand() *Command {
+	return &Command{
+		Name:    "schedule",
+		Summary: "Run a daily pick from cron, launchd or the Windows Task Scheduler (install, remove, status)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "schedule", args, map[string]func(*App, []string) error{
+				"install": runScheduleInstall,
+				"remove":  runScheduleRemove,
+				"status":  runScheduleStatus,
+			})
+		},
+	}
+}
+
+func runScheduleInstall(app *App, args []string) error {
+	fs := app.newFlagSet("schedule install")
+	at := fs.String("at", "", "time of day to pick at, HH:MM on the 24-hour clock (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	pickArgs := fs.Args()
+	if *at == "" || len(pickArgs) == 0 {
+		return usageErrorf("usage: schedule install --at HH:MM [--] <category>|ensemble|--all|--occasion NAME [pick flags]")
+	}
+	if app.demo {
+		return usageErrorf("a daily pick cannot be scheduled in demo mode")
+	}
+	// Check the pick arguments now rather than at the first scheduled run.
+	category, occasion, _, err := parsePickArgs(app, pickArgs)
+	if err != nil {
+		return err
+	}
+	services := app.services()
+	if category != "" && category != ensembleKeyword {
+		if _, err := usecases.NewResolveCategoryUseCase(services).Execute(category); err != nil {
+			return err
+		}
+	}
+	if occasion != "" {
+		if _, _, err := usecases.NewOccasionsUseCase(services).Target(occasion); err != nil {
+			return err
+		}
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// The profile is pinned so that switching profiles later does not
+	// change what the daily pick picks from.
+	command := append([]string{executable, "--profile", app.profile, "pick"}, pickArgs...)
+	pick, err := usecases.NewScheduleUseCase(services).Install(*at, command)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ScheduleV1(&pick))
+	}
+	fmt.Fprintf(app.stdout, "Scheduled a daily pick at %s: %s\n", pick.Time(), strings.Join(pick.Command, " "))
+	return nil
+}
+
+func runScheduleRemove(app *App, args []string) error {
+	if err := refuseJSON(app, "schedule remove"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("schedule remove")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("schedule remove takes no arguments, got %q", fs.Arg(0))
+	}
+	removed, err := usecases.NewScheduleUseCase(app.services()).Remove()
+	if err != nil {
+		return err
+	}
+	if removed {
+		fmt.Fprintln(app.stdout, "Removed the daily pick.")
+	} else {
+		fmt.Fprintln(app.stdout, "No daily pick was scheduled.")
+	}
+	return nil
+}
+
+func runScheduleStatus(app *App, args []string) error {
+	fs := app.newFlagSet("schedule status")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("schedule status takes no arguments, got %q", fs.Arg(0))
+	}
+	pick, err := usecases.NewScheduleUseCase(app.services()).Status()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ScheduleV1(pick))
+	}
+	if pick == nil {
+		fmt.Fprintln(app.stdout, "No daily pick is scheduled.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "A daily pick runs at %s: %s\n", pick.Time(), strings.Join(pick.Command, " "))
+	return nil
+}
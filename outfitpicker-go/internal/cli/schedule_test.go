@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSchedule_InstallStatusRemove(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+
+	stdout, stderr, code := env.run("schedule", "install", "--at", "07:30", "casual", "--weather")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Scheduled a daily pick at 07:30: ") {
+		t.Fatalf("schedule install: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	pick := env.scheduler.Pick
+	if pick == nil || !slices.Equal(pick.Command[1:], []string{"--profile", "default", "pick", "casual", "--weather"}) {
+		t.Errorf("scheduled pick = %+v, want a pinned-profile pick of casual", pick)
+	}
+
+	if stdout, _, _ := env.run("schedule", "status"); !strings.HasPrefix(stdout, "A daily pick runs at 07:30: ") {
+		t.Errorf("schedule status = %q", stdout)
+	}
+	var output v1.Schedule
+	stdout, _, _ = env.run("--json", "schedule", "status")
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || !output.Scheduled || output.Pick.Minute != 30 {
+		t.Errorf("schedule status --json = %q, %v", stdout, err)
+	}
+
+	if _, stderr, code := env.run("schedule", "install", "--at", "18:00", "--", "--all"); code != ExitOK || env.scheduler.Pick.Hour != 18 {
+		t.Errorf("schedule install -- --all: code = %v, stderr = %q, pick = %+v", code, stderr, env.scheduler.Pick)
+	}
+	if stdout, _, _ := env.run("schedule", "remove"); stdout != "Removed the daily pick.\n" {
+		t.Errorf("schedule remove = %q", stdout)
+	}
+	if stdout, _, _ := env.run("schedule", "remove"); stdout != "No daily pick was scheduled.\n" {
+		t.Errorf("second schedule remove = %q", stdout)
+	}
+	if stdout, _, _ := env.run("schedule", "status"); stdout != "No daily pick is scheduled.\n" {
+		t.Errorf("schedule status after remove = %q", stdout)
+	}
+}
+
+func TestSchedule_InstallErrors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing time", []string{"schedule", "install", "casual"}, ExitUsage},
+		{"missing pick", []string{"schedule", "install", "--at", "07:30"}, ExitUsage},
+		{"malformed time", []string{"schedule", "install", "--at", "7am", "casual"}, ExitInvalidInput},
+		{"unknown category", []string{"schedule", "install", "--at", "07:30", "nope"}, ExitCategoryNotFound},
+		{"unknown pick flag", []string{"schedule", "install", "--at", "07:30", "casual", "--colour"}, ExitUsage},
+		{"unknown policy", []string{"schedule", "install", "--at", "07:30", "casual", "--policy", "lucky"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"schedule", "list"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+	if env.scheduler.Pick != nil {
+		t.Errorf("scheduled pick = %+v, want none after failed installs", env.scheduler.Pick)
+	}
+}
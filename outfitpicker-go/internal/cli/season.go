@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func seasonCommand() *Command {
+	return &Command{
+		Name:    "season",
+		Summary: "Assign seasons to categories and tags for --season auto (list, set, clear, hemisphere)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "season", args, map[string]func(*App, []string) error{
+				"list":       runSeasonList,
+				"set":        runSeasonSet,
+				"clear":      runSeasonClear,
+				"hemisphere": runSeasonHemisphere,
+			})
+		},
+	}
+}
+
+func runSeasonList(app *App, args []string) error {
+	fs := app.newFlagSet("season list")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("season list takes no arguments, got %q", fs.Arg(0))
+	}
+	current, assignments, err := usecases.NewSeasonsUseCase(app.services()).Current()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.Seasons{Current: current, Seasons: presentation.SeasonAssignmentsV1(assignments)})
+	}
+	return presentation.RenderSeasons(app.stdout, current, assignments)
+}
+
+func runSeasonSet(app *App, args []string) error {
+	if err := refuseJSON(app, "season set"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("season set")
+	tag := fs.String("tag", "", "assign the seasons to outfits with this tag instead of a category")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *tag == "" && len(positional) < 2 || *tag != "" && len(positional) < 1 {
+		return usageErrorf("usage: season set <category> <season>... | season set --tag <tag> <season>...")
+	}
+
+	target, seasons := *tag, positional
+	if *tag == "" {
+		category, err := usecases.NewResolveCategoryUseCase(app.services()).Execute(positional[0])
+		if err != nil {
+			return err
+		}
+		target, seasons = category.Name, positional[1:]
+	}
+	if err := app.updateSeasons(*tag != "", target, seasons); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "%s is worn in %s.\n", seasonTarget(*tag != "", target), strings.Join(seasons, ", "))
+	return nil
+}
+
+func runSeasonClear(app *App, args []string) error {
+	if err := refuseJSON(app, "season clear"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("season clear")
+	tag := fs.String("tag", "", "clear the seasons of a tag instead of a category")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *tag == "" && len(positional) != 1 || *tag != "" && len(positional) != 0 {
+		return usageErrorf("usage: season clear <category> | season clear --tag <tag>")
+	}
+
+	target := *tag
+	if *tag == "" {
+		// Categories that no longer exist can still be cleared.
+		target = positional[0]
+		if category, err := usecases.NewResolveCategoryUseCase(app.services()).Execute(target); err == nil {
+			target = category.Name
+		}
+	}
+	if err := app.updateSeasons(*tag != "", target, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "%s is worn all year.\n", seasonTarget(*tag != "", target))
+	return nil
+}
+
+func runSeasonHemisphere(app *App, args []string) error {
+	if err := refuseJSON(app, "season hemisphere"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("season hemisphere"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 || positional[0] != "north" && positional[0] != "south" {
+		return usageErrorf("usage: season hemisphere north|south")
+	}
+	southern := positional[0] == "south"
+	_, err = usecases.NewSeasonsUseCase(app.services()).Update(func(current entities.SeasonAssignments) entities.SeasonAssignments {
+		current.Southern = southern
+		return current
+	})
+	if err != nil {
+		return err
+	}
+	hemisphere := "northern"
+	if southern {
+		hemisphere = "southern"
+	}
+	fmt.Fprintf(app.stdout, "Seasons follow the %s hemisphere.\n", hemisphere)
+	return nil
+}
+
+// updateSeasons replaces the seasons of a category or tag; no seasons clear
+// them.
+func (a *App) updateSeasons(isTag bool, target string, seasons []string) error {
+	_, err := usecases.NewSeasonsUseCase(a.services()).Update(func(current entities.SeasonAssignments) entities.SeasonAssignments {
+		if isTag {
+			return current.SettingTag(target, seasons)
+		}
+		return current.SettingCategory(target, seasons)
+	})
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: seasons must be %s, each listed once, and tags lowercase without spaces", err, strings.Join(validation.Seasons(), ", "))
+	}
+	return err
+}
+
+func seasonTarget(isTag bool, target string) string {
+	if isTag {
+		return "Tag " + target
+	}
+	return target
+}
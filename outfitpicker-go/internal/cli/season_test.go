@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/json"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"strings"
+	"testing"
+)
+
+func TestSeason_SetListClear(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"knits": {"jumper.avatar"}, "casual": {"shorts.avatar", "coat.avatar"}})
+
+	stdout, stderr, code := env.run("season", "set", "knits", "autumn", "winter")
+	if code != ExitOK || stdout != "knits is worn in autumn, winter.\n" {
+		t.Fatalf("season set: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if _, stderr, code := env.run("season", "set", "--tag", "cold", "winter"); code != ExitOK {
+		t.Fatalf("season set --tag: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("tag", "add", "casual", "coat.avatar", "cold"); code != ExitOK {
+		t.Fatal("tag add failed")
+	}
+
+	stdout, _, _ = env.run("season", "list")
+	if !strings.Contains(stdout, "  knits: autumn, winter\n  tag cold: winter\n") {
+		t.Errorf("season list = %q", stdout)
+	}
+	var output v1.Seasons
+	stdout, _, _ = env.run("--json", "season", "list")
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || output.Current == "" || len(output.Seasons.Categories["knits"]) != 2 {
+		t.Errorf("season list --json = %q, %v", stdout, err)
+	}
+
+	if stdout, _, _ := env.run("list", "--season", "summer"); strings.Contains(stdout, "knits") || !strings.Contains(stdout, "casual") {
+		t.Errorf("list --season summer = %q, want knits left out", stdout)
+	}
+	if _, _, code := env.run("pick", "knits", "--season", "summer"); code != ExitInvalidInput {
+		t.Errorf("pick of an off-season category: code = %v, want %v", code, ExitInvalidInput)
+	}
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual", "--season", "summer"); stdout != "casual/shorts.avatar\n" {
+			t.Fatalf("pick --season summer = %q", stdout)
+		}
+	}
+	if _, stderr, code := env.run("pick", "casual", "--season", "auto"); code != ExitOK {
+		t.Errorf("pick --season auto: code = %v, stderr = %q", code, stderr)
+	}
+
+	if stdout, _, _ := env.run("season", "clear", "knits"); stdout != "knits is worn all year.\n" {
+		t.Errorf("season clear = %q", stdout)
+	}
+	if _, _, code := env.run("pick", "knits", "--season", "summer"); code != ExitOK {
+		t.Errorf("pick after clearing: code = %v, want %v", code, ExitOK)
+	}
+	if stdout, _, _ := env.run("season", "hemisphere", "south"); stdout != "Seasons follow the southern hemisphere.\n" {
+		t.Errorf("season hemisphere = %q", stdout)
+	}
+}
+
+func TestSeason_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"set without seasons", []string{"season", "set", "casual"}, ExitUsage},
+		{"unknown season", []string{"season", "set", "casual", "fall"}, ExitInvalidConfiguration},
+		{"unknown category", []string{"season", "set", "nope", "winter"}, ExitCategoryNotFound},
+		{"invalid tag", []string{"season", "set", "--tag", "Cold", "winter"}, ExitInvalidConfiguration},
+		{"unknown hemisphere", []string{"season", "hemisphere", "east"}, ExitUsage},
+		{"pick with unknown season", []string{"pick", "casual", "--season", "fall"}, ExitInvalidInput},
+		{"list with unknown season", []string{"list", "--season", "fall"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func seenCommand() *Command {
+	return &Command{
+		Name:    "seen",
+		Summary: "Show how long each outfit of a category has been owned and which have gone",
+		JSON:    true,
+		Run:     runSeen,
+	}
+}
+
+func runSeen(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("seen"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: seen <category>")
+	}
+	category, err := usecases.NewResolveCategoryUseCase(app.services()).Execute(positional[0])
+	if err != nil {
+		return err
+	}
+
+	sightings, err := usecases.NewOutfitSightingsUseCase(app.services()).Execute(category.Name)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.ListV1(sightings, presentation.OutfitSightingV1))
+	}
+	return presentation.RenderOutfitSightings(app.stdout, category.Name, sightings, time.Now())
+}
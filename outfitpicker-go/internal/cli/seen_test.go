@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSeen(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	stdout, stderr, code := env.run("seen", "casual")
+	if code != ExitOK {
+		t.Fatalf("seen: code = %v, stderr = %q", code, stderr)
+	}
+	want := "jeans.avatar  owned since before tracking began\ntee.avatar    owned since before tracking began\n"
+	if stdout != want {
+		t.Errorf("seen = %q, want %q", stdout, want)
+	}
+
+	if err := os.Remove(filepath.Join(env.root, "casual", "jeans.avatar")); err != nil {
+		t.Fatal(err)
+	}
+	env.writeOutfit("casual", "coat.avatar")
+	stdout, _, _ = env.run("seen", "casual")
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "coat.avatar   owned 0 days, since ") || !strings.HasPrefix(lines[1], "jeans.avatar  gone, last seen ") {
+		t.Errorf("seen after changes = %q", stdout)
+	}
+
+	stdout, _, _ = env.run("--json", "seen", "casual")
+	var sightings []struct {
+		FileName string `json:"fileName"`
+		Present  bool   `json:"present"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &sightings); err != nil || len(sightings) != 3 || sightings[1].Present {
+		t.Errorf("seen --json = %q, %v", stdout, err)
+	}
+}
+
+func TestSeen_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if _, _, code := env.run("seen"); code != ExitUsage {
+		t.Errorf("seen without a category: code = %v, want %v", code, ExitUsage)
+	}
+	if _, _, code := env.run("seen", "formal"); code != ExitCategoryNotFound {
+		t.Errorf("seen of an unknown category: code = %v, want %v", code, ExitCategoryNotFound)
+	}
+}
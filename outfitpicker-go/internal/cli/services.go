@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"log/slog"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/mail"
+	"github.com/dh85/outfitpicker/internal/infrastructure/persistence"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// services wires the production implementations of every port, storing
+// files under the app's directory provider in the profile in use.
+func (a *App) services() usecases.Services {
+	return a.servicesFor(a.profile)
+}
+
+// servicesFor wires the services of the named profile.
+func (a *App) servicesFor(profile string) usecases.Services {
+	dp := a.directoryProvider
+	if profile == entities.DefaultProfile {
+		profile = ""
+	}
+	var progress func(entities.ProgressEvent)
+	if a.progressFormat == progressNDJSON {
+		progress = presentation.NDJSONProgress(a.stderr)
+	}
+	return usecases.Services{
+		Config:      configuration.NewConfigService(storeOptions[entities.Config](dp, profile, a.signer, a.log())...),
+		Cache:       persistence.NewCacheService(storeOptions[entities.OutfitCache](dp, profile, a.signer, a.log())...),
+		Scanner:     system.NewCategoryScanner(system.WithScanLogger(a.log())),
+		Maintenance: persistence.NewMaintenanceStore(storeOptions[entities.MaintenanceState](dp, profile, a.signer, a.log())...),
+		Metadata:    persistence.NewMetadataStore(storeOptions[entities.MetadataIndex](dp, profile, a.signer, a.log())...),
+		Weights:     persistence.NewWeightStore(storeOptions[entities.OutfitWeights](dp, profile, a.signer, a.log())...),
+		Favorites:   persistence.NewFavoritesStore(storeOptions[entities.Favorites](dp, profile, a.signer, a.log())...),
+		Skipped:     persistence.NewSkippedOutfitsStore(storeOptions[entities.SkippedOutfits](dp, profile, a.signer, a.log())...),
+		Laundry:     persistence.NewLaundryStore(storeOptions[entities.Laundry](dp, profile, a.signer, a.log())...),
+		Arrivals:    persistence.NewArrivalStore(storeOptions[entities.OutfitArrivals](dp, profile, a.signer, a.log())...),
+		LastPicked:  persistence.NewCategoryPickStore(storeOptions[entities.CategoryPicks](dp, profile, a.signer, a.log())...),
+		LatestPicks: persistence.NewLatestPickStore(storeOptions[entities.LatestPicks](dp, profile, a.signer, a.log())...),
+		Challenge:   persistence.NewChallengeStore(storeOptions[entities.Challenge](dp, profile, a.signer, a.log())...),
+		Plan:        persistence.NewPlanStore(storeOptions[entities.OutfitPlan](dp, profile, a.signer, a.log())...),
+		Forecasts:   persistence.NewForecastStore(storeOptions[entities.ForecastCache](dp, profile, a.signer, a.log())...),
+		Weather:     a.weather,
+		WatchState:  persistence.NewWatchStateStore(storeOptions[entities.WatchState](dp, profile, a.signer, a.log())...),
+		Commands:    persistence.NewCommandHistoryStore(storeOptions[entities.CommandHistory](dp, profile, a.signer, a.log())...),
+		WearLog:     persistence.NewWearLogStore(storeOptions[entities.WearLog](dp, profile, a.signer, a.log())...),
+		History:     persistence.NewHistoryStore(storeOptions[entities.SelectionHistory](dp, profile, a.signer, a.log())...),
+		Mailer:      mail.NewSMTPMailer(),
+		Scheduler:   a.scheduler,
+		Archiver:    system.NewFileArchiver(),
+		Importer:    system.NewArchiveImporter(),
+		Bundles:     system.NewStateBundleArchive(),
+		Creator:     system.NewFileCategoryCreator(),
+		Trash:       a.trash,
+		Backups:     system.NewProfileBackupStore(dp, profile),
+		Profiles:    system.NewProfileStore(dp),
+		Integrity:   a.integrityStore(),
+		Hasher:      system.NewFileHasher(),
+		Progress:    progress,
+		Logger:      a.log(),
+	}
+}
+
+// integrityStore wires the store that signs state files, with its key in
+// the app's keychain.
+func (a *App) integrityStore() *system.IntegrityStore {
+	return system.NewIntegrityStore(a.directoryProvider, a.keychain)
+}
+
+// storeOptions places a store's file under dp, in the directory of the
+// named profile, signed by signer when it is not nil and logging to logger.
+func storeOptions[T any](dp system.DirectoryProvider, profile string, signer *system.Signer, logger *slog.Logger) []system.FileServiceOption[T] {
+	return []system.FileServiceOption[T]{
+		system.WithDirectoryProvider[T](dp),
+		system.WithProfile[T](profile),
+		system.WithSigner[T](signer),
+		system.WithLogger[T](logger),
+	}
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func setupCommand() *Command {
+	return &Command{
+		Name:    "setup",
+		Summary: "Create or update the configuration non-interactively",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("setup")
+			var roots pathList
+			fs.Var(&roots, "root", "wardrobe directory, repeatable to combine several with the first as primary (required on first run)")
+			language := fs.String("language", "", "language code")
+			var exclude, include stringList
+			fs.Var(&exclude, "exclude", "category to exclude (repeatable or comma-separated)")
+			fs.Var(&include, "include", "category to include (repeatable or comma-separated)")
+			strategy := fs.String("strategy", "", "pick strategy: uniform, weighted by assigned weights and feedback, or rated to favor higher-rated outfits")
+			includeHidden := fs.Bool("include-hidden", false, "scan dotfiles and dot-directories under the wardrobe root")
+			var ignore stringList
+			fs.Var(&ignore, "ignore", "ignore pattern to add, as in .outfitignore (repeatable or comma-separated)")
+			var health entities.HealthThresholds
+			fs.IntVar(&health.HealthyScore, "healthy-score", 0, "lowest category health score shown as healthy (default 70)")
+			fs.IntVar(&health.CriticalScore, "critical-score", 0, "category health score below which a category is critical (default 40)")
+			fs.IntVar(&health.StaleAfterDays, "stale-days", 0, "days without a wear after which a category counts as stale (default 30)")
+			fs.IntVar(&health.TargetRotationDays, "rotation-days", 0, "days a full rotation of a category should take (default 60)")
+			feedbackBoost := fs.Float64("feedback-boost", 0, "extra weight per point of positive feedback under the weighted strategy")
+			newArrivals := fs.String("new-arrivals", "", "new outfits: prefer picks them first, hold leaves them out until tagged, off")
+			newArrivalDays := fs.Int("new-arrival-days", 0, "days an outfit counts as a new arrival (default 14)")
+			categoryRestDays := fs.Int("category-rest-days", 0, "days after a category is picked from that pick --all leaves it out (0 turns this off)")
+			categoryBalance := fs.String("category-balance", "", "pick --all: remaining favors categories with the fewest outfits left in their rotation, off")
+			var priorities stringList
+			fs.Var(&priorities, "category-priority", "how often pick --all chooses a category, as NAME=N with 1 the default (repeatable or comma-separated)")
+			var tagLimits stringList
+			fs.Var(&tagLimits, "tag-limit", "limit picks of a tag, as TAG=MAX/DAYS with :downgrade to make them less likely instead of blocking them, or TAG=off (repeatable or comma-separated)")
+			rotationPolicy := fs.String("rotation-policy", "", "which outfits picks choose from: strict, least-recently-worn, cooldown:DAYS or random (default strict)")
+			cooldownDays := fs.Int("cooldown-days", 0, "days after an outfit is worn that picks leave it out, even after its rotation resets (0 turns this off)")
+			var categoryPolicies stringList
+			fs.Var(&categoryPolicies, "category-policy", "rotation policy of one category, as NAME=POLICY (repeatable or comma-separated)")
+			var pickLimits stringList
+			fs.Var(&pickLimits, "pick-limit", "most picks a day from one category, as NAME=N, or NAME=off to remove the limit (repeatable or comma-separated)")
+			var allowPick, allowReset stringList
+			fs.Var(&allowPick, "allow-pick", "categories this profile may pick from, or all (repeatable or comma-separated)")
+			fs.Var(&allowReset, "allow-reset", "categories whose rotation this profile may start over, or all (repeatable or comma-separated)")
+			progressStyle := fs.String("progress-style", "", "how rotation progress is drawn: bar, pattern to tell quarters apart by shape, or badge for a percentage")
+			emptyCategories := fs.String("empty-categories", "", "categories without outfits in pick --all: skip, warn or fail (default skip)")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return usageErrorf("setup takes no arguments, got %q", fs.Arg(0))
+			}
+
+			categoryPriorities, err := parseCategoryPriorities(priorities)
+			if err != nil {
+				return err
+			}
+			constraints, removed, err := parseTagLimits(tagLimits)
+			if err != nil {
+				return err
+			}
+			categoryRotationPolicies, err := parseCategoryPolicies(categoryPolicies)
+			if err != nil {
+				return err
+			}
+			categoryPickLimits, err := parsePickLimits(pickLimits)
+			if err != nil {
+				return err
+			}
+			rootPaths, err := roots.expanded()
+			if err != nil {
+				return err
+			}
+			request := usecases.SetupRequest{
+				Roots:                    rootPaths,
+				Language:                 *language,
+				Exclude:                  exclude,
+				Include:                  include,
+				Strategy:                 *strategy,
+				NewArrivalMode:           *newArrivals,
+				NewArrivalDays:           *newArrivalDays,
+				EmptyCategories:          *emptyCategories,
+				CategoryBalance:          *categoryBalance,
+				CategoryPriorities:       categoryPriorities,
+				TagConstraints:           constraints,
+				RemoveTagConstraints:     removed,
+				CategoryRotationPolicies: categoryRotationPolicies,
+				CategoryPickLimits:       categoryPickLimits,
+				Ignore:                   ignore,
+				Health:                   health,
+				ProgressStyle:            *progressStyle,
+				AllowPick:                allowPick,
+				AllowReset:               allowReset,
+			}
+			if flagWasSet(fs, "feedback-boost") {
+				request.FeedbackBoost = feedbackBoost
+			}
+			if flagWasSet(fs, "cooldown-days") {
+				request.CooldownDays = cooldownDays
+			}
+			if flagWasSet(fs, "category-rest-days") {
+				request.CategoryRestDays = categoryRestDays
+			}
+			if *rotationPolicy != "" {
+				policy, err := entities.ParseRotationPolicy(*rotationPolicy)
+				if err != nil {
+					return err
+				}
+				request.RotationPolicy = &policy
+			}
+			if flagWasSet(fs, "include-hidden") {
+				request.IncludeHidden = includeHidden
+			}
+			result, err := usecases.NewSetupUseCase(app.services()).Execute(request)
+			if err != nil {
+				return err
+			}
+
+			if app.jsonOutput {
+				return app.writeJSON(v1.Setup{
+					ConfigPath: result.ConfigPath,
+					Created:    result.Created,
+					Changed:    result.Changed,
+				})
+			}
+			switch {
+			case result.Created:
+				fmt.Fprintf(app.stdout, "Created configuration at %s.\n", result.ConfigPath)
+			case result.Changed:
+				fmt.Fprintf(app.stdout, "Updated configuration at %s.\n", result.ConfigPath)
+			default:
+				fmt.Fprintf(app.stdout, "Configuration at %s is already up to date.\n", result.ConfigPath)
+			}
+			return nil
+		},
+	}
+}
+
+// parseCategoryPriorities parses NAME=N category priorities.
+func parseCategoryPriorities(values []string) (map[string]float64, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	priorities := make(map[string]float64, len(values))
+	for _, value := range values {
+		name, number, ok := strings.Cut(value, "=")
+		priority, err := strconv.ParseFloat(number, 64)
+		if !ok || name == "" || err != nil {
+			return nil, usageErrorf("category priority %q must be written as NAME=N", value)
+		}
+		priorities[name] = priority
+	}
+	return priorities, nil
+}
+
+// parsePickLimits parses NAME=N daily pick limits, and NAME=off as a
+// limit of 0 that removes one.
+func parsePickLimits(values []string) (map[string]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	limits := make(map[string]int, len(values))
+	for _, value := range values {
+		name, number, ok := strings.Cut(value, "=")
+		if ok && name != "" && number == "off" {
+			limits[name] = 0
+			continue
+		}
+		limit, err := strconv.Atoi(number)
+		if !ok || name == "" || err != nil || limit < 1 {
+			return nil, usageErrorf("pick limit %q must be written as NAME=N with N at least 1, or NAME=off", value)
+		}
+		limits[name] = limit
+	}
+	return limits, nil
+}
+
+// parseCategoryPolicies parses NAME=POLICY category rotation policies.
+func parseCategoryPolicies(values []string) (map[string]entities.RotationPolicy, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	policies := make(map[string]entities.RotationPolicy, len(values))
+	for _, value := range values {
+		name, policy, ok := strings.Cut(value, "=")
+		if !ok || name == "" {
+			return nil, usageErrorf("category policy %q must be written as NAME=POLICY", value)
+		}
+		parsed, err := entities.ParseRotationPolicy(policy)
+		if err != nil {
+			return nil, err
+		}
+		policies[name] = parsed
+	}
+	return policies, nil
+}
+
+// parseTagLimits parses TAG=MAX/DAYS[:SEVERITY] tag constraints and the
+// TAG=off removals among them.
+func parseTagLimits(values []string) ([]entities.TagConstraint, []string, error) {
+	var constraints []entities.TagConstraint
+	var removed []string
+	for _, value := range values {
+		tag, limit, ok := strings.Cut(value, "=")
+		if ok && tag != "" && limit == "off" {
+			removed = append(removed, tag)
+			continue
+		}
+		limit, severity, _ := strings.Cut(limit, ":")
+		picks, days, hasDays := strings.Cut(limit, "/")
+		constraint := entities.TagConstraint{Tag: tag, Severity: severity}
+		var maxErr, daysErr error
+		constraint.Max, maxErr = strconv.Atoi(picks)
+		constraint.Days, daysErr = strconv.Atoi(days)
+		if !ok || tag == "" || !hasDays || maxErr != nil || daysErr != nil {
+			return nil, nil, usageErrorf("tag limit %q must be written as TAG=MAX/DAYS[:block|downgrade] or TAG=off", value)
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, removed, nil
+}
@@ -0,0 +1,306 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestSetup_IsIdempotent(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	args := []string{"setup", "--root", root, "--language", "de", "--exclude", "formal", "--include", "casual"}
+
+	stdout, stderr, code := env.run(args...)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Created configuration") {
+		t.Fatalf("first run: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	stdout, _, code = env.run(args...)
+	if code != ExitOK || !strings.Contains(stdout, "already up to date") {
+		t.Errorf("second run: code = %v, stdout = %q", code, stdout)
+	}
+
+	stdout, _, code = env.run("--json", "setup", "--include", "formal")
+	var output v1.Setup
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if code != ExitOK || output.Created || !output.Changed {
+		t.Errorf("third run: code = %v, output = %+v", code, output)
+	}
+}
+
+func TestSetup_HealthThresholds(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--healthy-score", "40", "--critical-score", "30"); code != ExitOK {
+		t.Fatalf("setup thresholds: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("doctor"); stdout != "casual: 45 (healthy)\n\nAll categories are healthy.\n" {
+		t.Errorf("doctor with lower thresholds = %q", stdout)
+	}
+	if _, _, code := env.run("setup", "--critical-score", "50"); code != ExitInvalidConfiguration {
+		t.Errorf("critical above healthy: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_ProgressStyle(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--progress-style", "badge"); code != ExitOK {
+		t.Fatalf("setup progress style: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("list", "--progress"); !strings.Contains(stdout, "casual    hasOutfits  1        [  0%]") {
+		t.Errorf("list --progress with badges = %q", stdout)
+	}
+	if _, _, code := env.run("setup", "--progress-style", "rainbow"); code != ExitInvalidConfiguration {
+		t.Errorf("unknown progress style: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_RotationPolicy(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	env.writeOutfit("casual", "coat.avatar")
+
+	if _, stderr, code := env.run("setup", "--root", root, "--rotation-policy", "random", "--category-policy", "casual=cooldown:7"); code != ExitOK {
+		t.Fatalf("setup rotation policy: code = %v, stderr = %q", code, stderr)
+	}
+	env.wear(t, "casual", "tee.avatar")
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual"); stdout != "casual/coat.avatar\n" {
+			t.Fatalf("pick with tee.avatar cooling down = %q", stdout)
+		}
+	}
+	if _, _, code := env.run("setup", "--category-policy", "casual=shuffle"); code != ExitInvalidInput {
+		t.Errorf("unknown policy: exit code = %v, want ExitInvalidInput", code)
+	}
+	if _, _, code := env.run("setup", "--category-policy", "shuffle"); code != ExitUsage {
+		t.Errorf("policy without a category: exit code = %v, want ExitUsage", code)
+	}
+}
+
+func TestSetup_PickLimit(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--pick-limit", "casual=1"); code != ExitOK {
+		t.Fatalf("setup pick limit: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick within the limit: code = %v, stderr = %q", code, stderr)
+	}
+	_, stderr, code := env.run("--json", "pick", "casual")
+	var output struct {
+		Code    int       `json:"code"`
+		RetryAt time.Time `json:"retryAt"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &output); err != nil || code != ExitRateLimited || output.RetryAt.IsZero() {
+		t.Fatalf("pick over the limit: code = %v, stderr = %q", code, stderr)
+	}
+
+	if _, _, code := env.run("setup", "--pick-limit", "casual=off"); code != ExitOK {
+		t.Fatalf("setup removing the limit: code = %v", code)
+	}
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Errorf("pick without a limit: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("setup", "--pick-limit", "casual=0"); code != ExitUsage {
+		t.Errorf("zero limit: exit code = %v, want ExitUsage", code)
+	}
+}
+
+func TestSetup_Permissions(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	if err := os.MkdirAll(filepath.Join(root, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	env.writeOutfit("work", "suit.avatar")
+
+	if _, stderr, code := env.run("setup", "--root", root, "--allow-pick", "casual", "--allow-reset", "casual"); code != ExitOK {
+		t.Fatalf("setup permissions: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("pick", "work"); code != ExitInvalidInput || !strings.Contains(stderr, "may not pick from work") {
+		t.Errorf("pick from a category not allowed: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("__complete", "pick", ""); stdout != "casual\n" {
+		t.Errorf("__complete pick = %q, want only casual", stdout)
+	}
+	if stdout, _, _ := env.run("list"); !strings.Contains(stdout, "ACCESS") || !strings.Contains(stdout, "view only") {
+		t.Errorf("list = %q, want an access column", stdout)
+	}
+
+	if _, _, code := env.run("setup", "--allow-pick", "all"); code != ExitOK {
+		t.Fatalf("setup lifting the restriction: code = %v", code)
+	}
+	if _, stderr, code := env.run("pick", "work"); code != ExitOK {
+		t.Errorf("pick once allowed: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestSetup_CooldownDays(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--cooldown-days", "5"); code != ExitOK {
+		t.Fatalf("setup cooldown: code = %v, stderr = %q", code, stderr)
+	}
+	// Wearing the only outfit resets the rotation, but the outfit is still cooling down.
+	app := New(WithDirectoryProvider(env.directoryProvider()))
+	outfit, err := app.outfitReference("casual", "tee.avatar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := usecases.NewWearOutfitUseCase(app.services()).Execute(outfit); !errors.As(err, new(*domainerrors.RotationCompletedError)) {
+		t.Fatalf("wear the only outfit: %v", err)
+	}
+	if _, stderr, code := env.run("pick", "casual"); code != ExitInvalidInput || !strings.Contains(stderr, "within the last 5 days") {
+		t.Errorf("pick during the cooldown: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("setup", "--cooldown-days", "-1"); code != ExitInvalidConfiguration {
+		t.Errorf("negative cooldown: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_NewArrivals(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--new-arrivals", "prefer", "--new-arrival-days", "3"); code != ExitOK {
+		t.Fatalf("setup new arrivals: code = %v, stderr = %q", code, stderr)
+	}
+	// The first pick records tee.avatar as already there; coat.avatar arrives later.
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	env.writeOutfit("casual", "coat.avatar")
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual"); stdout != "casual/coat.avatar\n" {
+			t.Fatalf("pick with a new arrival = %q", stdout)
+		}
+	}
+	if _, _, code := env.run("setup", "--new-arrivals", "always"); code != ExitInvalidConfiguration {
+		t.Errorf("unknown mode: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_CategoryWeighting(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	if err := os.MkdirAll(filepath.Join(root, "work"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	env.writeOutfit("work", "shirt.avatar")
+
+	if _, stderr, code := env.run("setup", "--root", root, "--category-balance", "remaining", "--category-priority", "casual=0"); code != ExitOK {
+		t.Fatalf("setup category weighting: code = %v, stderr = %q", code, stderr)
+	}
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "--all"); stdout != "work/shirt.avatar\n" {
+			t.Fatalf("pick --all with casual at priority 0 = %q", stdout)
+		}
+	}
+
+	if _, _, code := env.run("setup", "--category-priority", "casual"); code != ExitUsage {
+		t.Errorf("priority without a value: exit code = %v, want ExitUsage", code)
+	}
+	if _, _, code := env.run("setup", "--category-balance", "largest"); code != ExitInvalidConfiguration {
+		t.Errorf("unknown balance: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_TagLimits(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	env.writeOutfit("casual", "black-tee.avatar")
+
+	if _, stderr, code := env.run("setup", "--root", root, "--tag-limit", "black=0/7"); code != ExitOK {
+		t.Fatalf("setup tag limit: code = %v, stderr = %q", code, stderr)
+	}
+	if _, stderr, code := env.run("tag", "add", "casual", "black-tee.avatar", "black"); code != ExitOK {
+		t.Fatalf("tag add: code = %v, stderr = %q", code, stderr)
+	}
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual"); stdout != "casual/tee.avatar\n" {
+			t.Fatalf("pick with black blocked = %q", stdout)
+		}
+	}
+	env.wear(t, "casual", "tee.avatar")
+	if _, stderr, code := env.run("pick", "casual"); code != ExitInvalidInput || !strings.Contains(stderr, "breaks a tag limit") {
+		t.Errorf("pick with only blocked outfits left: code = %v, stderr = %q", code, stderr)
+	}
+
+	if _, stderr, code := env.run("setup", "--tag-limit", "black=off"); code != ExitOK {
+		t.Fatalf("setup tag limit off: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout, _, _ := env.run("pick", "casual"); stdout != "casual/black-tee.avatar\n" {
+		t.Errorf("pick after removing the limit = %q", stdout)
+	}
+
+	if _, _, code := env.run("setup", "--tag-limit", "black=2"); code != ExitUsage {
+		t.Errorf("limit without days: exit code = %v, want ExitUsage", code)
+	}
+	if _, _, code := env.run("setup", "--tag-limit", "black=2/7:warn"); code != ExitInvalidConfiguration {
+		t.Errorf("unknown severity: exit code = %v, want ExitInvalidConfiguration", code)
+	}
+}
+
+func TestSetup_RequiresRootOnFirstRun(t *testing.T) {
+	env := &cliEnv{t: t, stateDir: t.TempDir()}
+	_, stderr, code := env.run("setup", "--language", "de")
+	if code != ExitInvalidInput || !strings.Contains(stderr, "root directory is required") {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestStringList(t *testing.T) {
+	var list stringList
+	for _, value := range []string{"a,b", " c ", ""} {
+		if err := list.Set(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := list.String(); got != "a,b,c" {
+		t.Errorf("String() = %q, want %q", got, "a,b,c")
+	}
+}
+
+func TestSetup_MultipleRoots(t *testing.T) {
+	root := newWardrobeRoot(t)
+	env := &cliEnv{t: t, root: root, stateDir: t.TempDir()}
+	shared := testhelpers.UnrestrictedTempDir(t)
+	for _, path := range []string{filepath.Join(shared, "casual", "coat.avatar"), filepath.Join(shared, "work", "shirt.avatar")} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, stderr, code := env.run("setup", "--root", root, "--root", shared); code != ExitOK {
+		t.Fatalf("setup with two roots: code = %v, stderr = %q", code, stderr)
+	}
+	for category, want := range map[string]string{"casual": "casual/tee.avatar\n", "casual@2": "casual@2/coat.avatar\n", "work": "work/shirt.avatar\n"} {
+		if stdout, stderr, _ := env.run("pick", category); stdout != want {
+			t.Errorf("pick %s = %q, %q; want %q", category, stdout, stderr, want)
+		}
+	}
+
+	if _, _, code := env.run("setup", "--root", root, "--root", root); code != ExitInvalidInput {
+		t.Errorf("repeated root: exit code = %v, want ExitInvalidInput", code)
+	}
+}
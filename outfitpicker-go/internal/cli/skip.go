@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func skipCommand() *Command {
+	return &Command{
+		Name:    "skip",
+		Summary: "Leave an outfit out of picks without wearing it, for good or --for a while; list skips without one",
+		JSON:    true,
+		Run:     runSkip,
+	}
+}
+
+func runSkip(app *App, args []string) error {
+	fs := app.newFlagSet("skip")
+	period := fs.String("for", "", "pick the outfit again after this long, e.g. 3d, 2w or 36h (default until unskip)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	skips := usecases.NewSkipOutfitUseCase(app.services())
+	if len(positional) == 0 {
+		if *period != "" {
+			return usageErrorf("usage: skip <category> <outfit> [--for 2w]")
+		}
+		skipped, err := skips.List()
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return app.writeJSON(presentation.ListV1(skipped, presentation.SkippedOutfitV1))
+		}
+		return renderSkippedOutfits(app, skipped)
+	}
+	if len(positional) != 2 {
+		return usageErrorf("usage: skip <category> <outfit> [--for 2w]")
+	}
+
+	var duration time.Duration
+	if *period != "" {
+		if duration, err = entities.ParseExclusionPeriod(*period); err != nil {
+			return err
+		}
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+	until, err := skips.Skip(outfit, duration)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.Skip{Skipped: v1.SkippedOutfit{Category: outfit.Category.Name, FileName: outfit.FileName, Until: until}})
+	}
+	if until.IsZero() {
+		fmt.Fprintf(app.stdout, "Skipped %s/%s until you run: unskip %s %s\n", outfit.Category.Name, outfit.FileName, outfit.Category.Name, outfit.FileName)
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Skipped %s/%s until %s.\n", outfit.Category.Name, outfit.FileName, until.Local().Format(watchTimeLayout))
+	return nil
+}
+
+func renderSkippedOutfits(app *App, skipped []entities.SkippedOutfit) error {
+	if len(skipped) == 0 {
+		fmt.Fprintln(app.stdout, "No outfits are skipped.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(app.stdout, 0, 4, 2, ' ', 0)
+	for _, skip := range skipped {
+		until := "until unskipped"
+		if !skip.Until.IsZero() {
+			until = "until " + skip.Until.Local().Format(watchTimeLayout)
+		}
+		fmt.Fprintf(tw, "%s/%s\t%s\n", skip.Category, skip.FileName, until)
+	}
+	return tw.Flush()
+}
+
+func unskipCommand() *Command {
+	return &Command{
+		Name:    "unskip",
+		Summary: "Let a skipped outfit be picked again",
+		Run: func(app *App, args []string) error {
+			positional, err := parseArgs(app.newFlagSet("unskip"), args)
+			if err != nil {
+				return err
+			}
+			if len(positional) != 2 {
+				return usageErrorf("usage: unskip <category> <outfit>")
+			}
+			// Outfits of categories that no longer exist can still be
+			// unskipped.
+			outfit := entities.NewOutfitReference(positional[1], entities.NewCategoryReference(positional[0], ""))
+			if resolved, err := app.outfitReference(positional[0], positional[1]); err == nil {
+				outfit = resolved
+			}
+			if err := usecases.NewSkipOutfitUseCase(app.services()).Unskip(outfit); err != nil {
+				return err
+			}
+			fmt.Fprintf(app.stdout, "Unskipped %s/%s.\n", outfit.Category.Name, outfit.FileName)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSkip_LeavesOutfitOutOfPicks(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	stdout, stderr, code := env.run("skip", "casual", "tee.avatar", "--for", "1w")
+	if code != ExitOK || !strings.HasPrefix(stdout, "Skipped casual/tee.avatar until ") {
+		t.Fatalf("skip --for: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	for range 5 {
+		if stdout, _, code := env.run("--dry-run", "pick", "casual"); code != ExitOK || !strings.HasPrefix(stdout, "Would pick casual/jeans.avatar\n") {
+			t.Fatalf("pick while tee.avatar is skipped: code = %v, stdout = %q", code, stdout)
+		}
+	}
+	if stdout, _, _ := env.run("history", "list", "--worn"); strings.Contains(stdout, "tee.avatar") {
+		t.Errorf("skipping wore the outfit: %q", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "skip")
+	var skipped []struct {
+		Category string    `json:"category"`
+		FileName string    `json:"fileName"`
+		Until    time.Time `json:"until"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &skipped); err != nil || code != ExitOK {
+		t.Fatalf("skip --json: code = %v, %v\n%s", code, err, stdout)
+	}
+	if len(skipped) != 1 || skipped[0].FileName != "tee.avatar" || time.Until(skipped[0].Until) < 6*24*time.Hour {
+		t.Errorf("skipped = %+v, want tee.avatar for a week", skipped)
+	}
+
+	if stdout, _, code := env.run("unskip", "casual", "tee.avatar"); code != ExitOK || stdout != "Unskipped casual/tee.avatar.\n" {
+		t.Errorf("unskip: code = %v, stdout = %q", code, stdout)
+	}
+	if stdout, _, _ := env.run("skip"); stdout != "No outfits are skipped.\n" {
+		t.Errorf("skip after unskip = %q", stdout)
+	}
+}
+
+func TestSkip_UntilUnskipped(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	if stdout, stderr, code := env.run("skip", "casual", "tee.avatar"); code != ExitOK || !strings.Contains(stdout, "unskip casual tee.avatar") {
+		t.Fatalf("skip: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("skip"); !strings.Contains(stdout, "casual/tee.avatar") || !strings.Contains(stdout, "until unskipped") {
+		t.Errorf("skip list = %q", stdout)
+	}
+	if _, _, code := env.run("pick", "casual"); code != ExitInvalidInput {
+		t.Errorf("pick with every outfit skipped: code = %v, want %v", code, ExitInvalidInput)
+	}
+}
+
+func TestSkip_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"bad period", []string{"skip", "casual", "tee.avatar", "--for", "soon"}, ExitInvalidInput},
+		{"period without outfit", []string{"skip", "--for", "2w"}, ExitUsage},
+		{"missing outfit", []string{"skip", "casual"}, ExitUsage},
+		{"unknown outfit", []string{"skip", "casual", "nope.avatar"}, ExitInvalidInput},
+		{"not skipped", []string{"unskip", "casual", "tee.avatar"}, ExitInvalidInput},
+		{"unskip missing outfit", []string{"unskip", "casual"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func snapshotCommand() *Command {
+	return &Command{
+		Name:    "snapshot",
+		Summary: "Rebuild past rotation state from the history for auditing (export)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "snapshot", args, map[string]func(*App, []string) error{
+				"export": runSnapshotExport,
+			})
+		},
+	}
+}
+
+func runSnapshotExport(app *App, args []string) error {
+	fs := app.newFlagSet("snapshot export")
+	at := fs.String("at", "", "date to rebuild the rotation state at, YYYY-MM-DD (required)")
+	out := fs.String("out", "", "path of the JSON file to write (default standard output)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("snapshot export takes no arguments, got %q", fs.Arg(0))
+	}
+	if *at == "" {
+		return usageErrorf("usage: snapshot export --at YYYY-MM-DD [--out file.json]")
+	}
+	date, err := time.ParseInLocation(historyDateLayout, *at, time.Local)
+	if err != nil {
+		return usageErrorf("--at must be a date like 2024-01-31, got %q", *at)
+	}
+
+	snapshot, err := usecases.NewRotationSnapshotUseCase(app.services()).Export(date)
+	if err != nil {
+		return err
+	}
+	exported := presentation.RotationSnapshotV1(snapshot)
+	if *out == "" {
+		return app.writeJSON(exported)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	err = presentation.WriteJSON(f, exported)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(v1.SnapshotExportSummary{Out: *out, At: snapshot.At, Categories: len(snapshot.Categories)})
+	}
+	fmt.Fprintf(app.stdout, "Wrote the rotation state at the end of %s to %s.\n", *at, *out)
+	return nil
+}
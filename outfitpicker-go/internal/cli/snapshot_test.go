@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotExport(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+	env.wear(t, "casual", "tee.avatar")
+	today := time.Now().Format(historyDateLayout)
+
+	stdout, stderr, code := env.run("snapshot", "export", "--at", today)
+	if code != ExitOK {
+		t.Fatalf("snapshot export: code = %v, stderr = %q", code, stderr)
+	}
+	type snapshotOutput struct {
+		Categories []struct {
+			Category  string   `json:"category"`
+			Worn      []string `json:"worn"`
+			Available []string `json:"available"`
+		} `json:"categories"`
+	}
+	var snapshot snapshotOutput
+	if err := json.Unmarshal([]byte(stdout), &snapshot); err != nil {
+		t.Fatalf("snapshot JSON: %v\n%s", err, stdout)
+	}
+	if len(snapshot.Categories) != 1 || !slices.Equal(snapshot.Categories[0].Worn, []string{"tee.avatar"}) ||
+		!slices.Equal(snapshot.Categories[0].Available, []string{"jeans.avatar"}) {
+		t.Errorf("snapshot = %+v, want tee.avatar worn and jeans.avatar available", snapshot)
+	}
+
+	out := filepath.Join(t.TempDir(), "snapshot.json")
+	stdout, _, code = env.run("snapshot", "export", "--at", "2000-01-01", "--out", out)
+	if code != ExitOK || !strings.HasPrefix(stdout, "Wrote the rotation state at the end of 2000-01-01") {
+		t.Errorf("snapshot export --out: code = %v, stdout = %q", code, stdout)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var before snapshotOutput
+	if err := json.Unmarshal(data, &before); err != nil {
+		t.Fatal(err)
+	}
+	if len(before.Categories) != 1 || len(before.Categories[0].Worn) != 0 || len(before.Categories[0].Available) != 2 {
+		t.Errorf("snapshot before any wear = %+v, want every outfit available", before)
+	}
+}
+
+func TestSnapshotExport_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing date", []string{"snapshot", "export"}, ExitUsage},
+		{"malformed date", []string{"snapshot", "export", "--at", "June"}, ExitUsage},
+		{"future date", []string{"snapshot", "export", "--at", "2999-01-01"}, ExitInvalidInput},
+		{"unknown subcommand", []string{"snapshot", "import"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// Environment variables of stateless mode: whether it is on, and the
+// configuration it runs with.
+const (
+	statelessEnv      = "OUTFITPICKER_STATELESS"
+	rootEnv           = "OUTFITPICKER_ROOT"
+	languageEnv       = "OUTFITPICKER_LANGUAGE"
+	excludeEnv        = "OUTFITPICKER_EXCLUDE"
+	includeEnv        = "OUTFITPICKER_INCLUDE"
+	strategyEnv       = "OUTFITPICKER_STRATEGY"
+	rotationPolicyEnv = "OUTFITPICKER_ROTATION_POLICY"
+)
+
+// startStateless switches the app to state kept in memory for --stateless,
+// configured from the environment, so a run reads and writes nothing but
+// the wardrobe.
+func (a *App) startStateless() error {
+	request, err := statelessSetupRequest()
+	if err != nil {
+		return err
+	}
+	a.directoryProvider = system.NewMemoryDirectoryProvider()
+	a.signer = nil
+	_, err = usecases.NewSetupUseCase(a.servicesFor(entities.DefaultProfile)).Execute(request)
+	return err
+}
+
+// statelessSetupRequest builds the configuration of a stateless run from
+// the environment. The wardrobe root is required; OUTFITPICKER_ROOT may
+// list several, separated as in PATH.
+func statelessSetupRequest() (usecases.SetupRequest, error) {
+	roots := filepath.SplitList(os.Getenv(rootEnv))
+	if len(roots) == 0 {
+		return usecases.SetupRequest{}, fmt.Errorf("%w: stateless mode takes the wardrobe root from %s, which is not set", domainerrors.ErrConfigurationNotFound, rootEnv)
+	}
+	for i, root := range roots {
+		var err error
+		if roots[i], err = expandPath(root); err != nil {
+			return usecases.SetupRequest{}, err
+		}
+	}
+	request := usecases.SetupRequest{
+		Roots:    roots,
+		Language: os.Getenv(languageEnv),
+		Exclude:  splitList(os.Getenv(excludeEnv)),
+		Include:  splitList(os.Getenv(includeEnv)),
+		Strategy: os.Getenv(strategyEnv),
+	}
+	if value := os.Getenv(rotationPolicyEnv); value != "" {
+		policy, err := entities.ParseRotationPolicy(value)
+		if err != nil {
+			return usecases.SetupRequest{}, err
+		}
+		request.RotationPolicy = &policy
+	}
+	return request, nil
+}
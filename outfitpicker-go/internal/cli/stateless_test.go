@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStateless(t *testing.T) {
+	env := &cliEnv{t: t, stateDir: t.TempDir()}
+	t.Setenv(rootEnv, newWardrobeRoot(t))
+
+	stdout, stderr, code := env.run("--stateless", "pick", "casual")
+	if code != ExitOK || !strings.Contains(stdout, "tee.avatar") {
+		t.Fatalf("--stateless pick: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if entries, err := os.ReadDir(env.stateDir); err != nil || len(entries) != 0 {
+		t.Errorf("stateless run wrote %v to the state directory (%v)", entries, err)
+	}
+
+	t.Setenv(statelessEnv, "1")
+	if stdout, _, code := env.run("history", "list"); code != ExitOK || strings.Contains(stdout, "tee.avatar") {
+		t.Errorf("%s history list: code = %v, stdout = %q, want no pick kept from the last run", statelessEnv, code, stdout)
+	}
+	if _, stderr, code := env.run("backup", "create"); code != ExitInvalidInput {
+		t.Errorf("stateless backup create: code = %v, want %v (stderr %q)", code, ExitInvalidInput, stderr)
+	}
+}
+
+func TestStateless_Errors(t *testing.T) {
+	env := &cliEnv{t: t, stateDir: t.TempDir()}
+	t.Setenv(rootEnv, newWardrobeRoot(t))
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"with --demo", []string{"--stateless", "--demo", "list"}, ExitUsage},
+		{"with --config", []string{"--stateless", "--config", env.stateDir, "list"}, ExitUsage},
+		{"with --state-dir", []string{"--stateless", "--state-dir", env.stateDir, "list"}, ExitUsage},
+		{"with --log-file", []string{"--stateless", "--log-file", "list"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+
+	t.Setenv(rootEnv, "")
+	if _, stderr, code := env.run("--stateless", "list"); code != ExitConfigurationNotFound || !strings.Contains(stderr, rootEnv) {
+		t.Errorf("without %s: code = %v, stderr = %q", rootEnv, code, stderr)
+	}
+	t.Setenv(rootEnv, newWardrobeRoot(t))
+	t.Setenv(rotationPolicyEnv, "sometimes")
+	if _, _, code := env.run("--stateless", "list"); code != ExitInvalidInput {
+		t.Errorf("unknown %s: code = %v, want %v", rotationPolicyEnv, code, ExitInvalidInput)
+	}
+}
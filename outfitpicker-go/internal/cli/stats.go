@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/infrastructure/metrics"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func statsCommand() *Command {
+	return &Command{
+		Name:    "stats",
+		Summary: "Show wear statistics and wardrobe statistics over time (export, growth, heatmap, ratings, summary)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "stats", args, map[string]func(*App, []string) error{
+				"export":  runStatsExport,
+				"growth":  runStatsGrowth,
+				"heatmap": runStatsHeatmap,
+				"ratings": runStatsRatings,
+				"summary": runStatsSummary,
+			})
+		},
+	}
+}
+
+func runStatsSummary(app *App, args []string) error {
+	fs := app.newFlagSet("stats summary")
+	csv := fs.Bool("csv", false, "write the wears of each outfit as CSV")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats summary takes no arguments, got %q", fs.Arg(0))
+	}
+	if *csv && app.jsonOutput {
+		return usageErrorf("--csv and --json cannot be combined")
+	}
+
+	analytics, err := usecases.NewWearAnalyticsUseCase(app.services()).Execute()
+	if err != nil {
+		return err
+	}
+	switch {
+	case app.jsonOutput:
+		return app.writeJSON(presentation.WearAnalyticsV1(analytics))
+	case *csv:
+		return presentation.WriteWearAnalyticsCSV(app.stdout, analytics)
+	default:
+		return presentation.RenderWearAnalytics(app.stdout, analytics)
+	}
+}
+
+func runStatsRatings(app *App, args []string) error {
+	fs := app.newFlagSet("stats ratings")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats ratings takes no arguments, got %q", fs.Arg(0))
+	}
+
+	report, err := usecases.NewOutfitRatingsUseCase(app.services()).Report()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.RatingsReportV1(report))
+	}
+	return presentation.RenderRatingsReport(app.stdout, report)
+}
+
+func runStatsGrowth(app *App, args []string) error {
+	fs := app.newFlagSet("stats growth")
+	months := fs.Int("months", usecases.DefaultGrowthMonths, "number of months to chart, ending with this one")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats growth takes no arguments, got %q", fs.Arg(0))
+	}
+
+	growth, err := usecases.NewWardrobeGrowthUseCase(app.services()).Execute(*months)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.WardrobeGrowthV1(growth))
+	}
+	return presentation.RenderWardrobeGrowth(app.stdout, growth)
+}
+
+func runStatsHeatmap(app *App, args []string) error {
+	fs := app.newFlagSet("stats heatmap")
+	category := fs.String("category", "", "count only picks from this category (default every category)")
+	year := fs.Int("year", 0, "year to draw (default this year)")
+	format := fs.String("format", "text", "output format: text or svg")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats heatmap takes no arguments, got %q", fs.Arg(0))
+	}
+	render := presentation.RenderPickHeatmap
+	switch *format {
+	case "text":
+	case "svg":
+		render = presentation.RenderPickHeatmapSVG
+	default:
+		return usageErrorf("invalid --format %q (want text or svg)", *format)
+	}
+
+	heatmap, err := usecases.NewPickHeatmapUseCase(app.services()).Execute(*category, *year)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.PickHeatmapV1(heatmap))
+	}
+	return render(app.stdout, heatmap)
+}
+
+func runStatsExport(app *App, args []string) error {
+	if err := refuseJSON(app, "stats export"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("stats export")
+	out := fs.String("out", "", "append the metrics in InfluxDB line protocol to this file")
+	url := fs.String("url", "", "push the metrics to this InfluxDB write URL, with the token in "+metrics.TokenEnvVar)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("stats export takes no arguments, got %q", fs.Arg(0))
+	}
+	if *out == "" && *url == "" {
+		return usageErrorf("usage: stats export --out file.lp | --url URL")
+	}
+	if err := exportMetrics(app.services(), *out, *url); err != nil {
+		return err
+	}
+	fmt.Fprintln(app.stdout, "Exported the wardrobe metrics.")
+	return nil
+}
+
+// exportMetrics writes the wardrobe metrics in InfluxDB line protocol,
+// appending them to the file out and pushing them to url for each that is
+// set.
+func exportMetrics(services usecases.Services, out, url string) error {
+	wardrobeMetrics, err := usecases.NewWardrobeMetricsUseCase(services).Execute()
+	if err != nil {
+		return err
+	}
+	var lines bytes.Buffer
+	if err := presentation.WriteLineProtocol(&lines, wardrobeMetrics); err != nil {
+		return err
+	}
+	if out != "" {
+		f, err := os.OpenFile(out, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(lines.Bytes())
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if url != "" {
+		return metrics.NewInfluxWriter().Write(url, lines.Bytes())
+	}
+	return nil
+}
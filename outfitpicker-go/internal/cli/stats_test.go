@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsGrowth(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	if stdout, _, code := env.run("stats", "growth"); code != ExitOK || !strings.HasPrefix(stdout, "No outfits tracked yet") {
+		t.Errorf("stats growth before picking: code = %v, stdout = %q", code, stdout)
+	}
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("stats", "growth", "--months", "3")
+	if code != ExitOK {
+		t.Fatalf("stats growth: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.Contains(stdout, "casual  ███  2 → 2 (+0)\n") {
+		t.Errorf("stats growth output = %q", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "stats", "growth", "--months", "2")
+	var growth struct {
+		Categories []struct {
+			Category string `json:"category"`
+			Counts   []int  `json:"counts"`
+		} `json:"categories"`
+		Totals []int `json:"totals"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &growth); err != nil || code != ExitOK {
+		t.Fatalf("stats growth JSON: code = %v, %v\n%s", code, err, stdout)
+	}
+	if len(growth.Categories) != 1 || len(growth.Totals) != 2 || growth.Totals[1] != 2 {
+		t.Errorf("stats growth JSON = %+v", growth)
+	}
+
+	if _, _, code := env.run("stats", "growth", "--months", "-1"); code != ExitInvalidInput {
+		t.Errorf("stats growth --months -1: code = %v, want ExitInvalidInput", code)
+	}
+}
+
+func TestStatsSummary(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("stats", "summary")
+	if code != ExitOK {
+		t.Fatalf("stats summary: code = %v, stderr = %q", code, stderr)
+	}
+	for _, want := range []string{"  casual/tee.avatar    1\n", "Most worn category:  casual (1 wear)\n", "Pick streak:         1 day\n"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("stats summary output = %q, want %q", stdout, want)
+		}
+	}
+
+	stdout, _, code = env.run("stats", "summary", "--csv")
+	if code != ExitOK || !strings.HasPrefix(stdout, "category,outfit,wears,last_worn\ncasual,tee.avatar,1,") || !strings.HasSuffix(stdout, "casual,jeans.avatar,0,\n") {
+		t.Errorf("stats --csv: code = %v, stdout = %q", code, stdout)
+	}
+
+	stdout, _, code = env.run("--json", "stats", "summary")
+	var analytics struct {
+		Outfits        []json.RawMessage `json:"outfits"`
+		PickStreakDays int               `json:"pickStreakDays"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &analytics); err != nil || code != ExitOK || len(analytics.Outfits) != 2 || analytics.PickStreakDays != 1 {
+		t.Errorf("stats JSON: code = %v, %v\n%s", code, err, stdout)
+	}
+
+	if _, _, code := env.run("--json", "stats", "summary", "--csv"); code != ExitUsage {
+		t.Errorf("stats --json --csv: code = %v, want ExitUsage", code)
+	}
+}
+
+func TestStatsHeatmap(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"shirt.avatar"}})
+	if _, stderr, code := env.run("pick", "casual"); code != ExitOK {
+		t.Fatalf("pick: code = %v, stderr = %q", code, stderr)
+	}
+
+	stdout, stderr, code := env.run("stats", "heatmap", "--category", "casual")
+	if code != ExitOK || !strings.HasPrefix(stdout, fmt.Sprintf("Picks from casual in %d (1 pick)\n", time.Now().Year())) {
+		t.Fatalf("stats heatmap: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "█") {
+		t.Errorf("stats heatmap output = %q, want today drawn", stdout)
+	}
+	if stdout, _, code := env.run("stats", "heatmap", "--category", "work", "--format", "svg"); code != ExitOK ||
+		!strings.HasPrefix(stdout, "<svg ") || !strings.Contains(stdout, "(0 picks)") {
+		t.Errorf("stats heatmap --format svg: code = %v, stdout = %.80q", code, stdout)
+	}
+
+	stdout, _, code = env.run("--json", "stats", "heatmap")
+	var heatmap struct {
+		Total int `json:"total"`
+		Days  []struct {
+			Picks int `json:"picks"`
+		} `json:"days"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &heatmap); err != nil || code != ExitOK {
+		t.Fatalf("stats heatmap JSON: code = %v, %v", code, err)
+	}
+	if heatmap.Total != 1 || len(heatmap.Days) < 365 {
+		t.Errorf("stats heatmap JSON = total %d over %d days", heatmap.Total, len(heatmap.Days))
+	}
+
+	for _, args := range [][]string{{"--format", "png"}, {"extra"}} {
+		if _, _, code := env.run(append([]string{"stats", "heatmap"}, args...)...); code != ExitUsage {
+			t.Errorf("stats heatmap %v: code = %v, want ExitUsage", args, code)
+		}
+	}
+	if _, _, code := env.run("stats", "heatmap", "--year", "1990"); code != ExitInvalidInput {
+		t.Errorf("stats heatmap --year 1990: code = %v, want ExitInvalidInput", code)
+	}
+	if _, _, code := env.run("stats", "heatmap", "--category", "gym"); code != ExitCategoryNotFound {
+		t.Errorf("stats heatmap --category gym: code = %v, want ExitCategoryNotFound", code)
+	}
+}
+
+func TestStatsExport(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+	out := filepath.Join(t.TempDir(), "metrics.lp")
+
+	if stdout, stderr, code := env.run("stats", "export", "--out", out); code != ExitOK || stdout != "Exported the wardrobe metrics.\n" {
+		t.Fatalf("stats export: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "outfitpicker_outfit,category=casual,outfit=tee.avatar wears=1i ") {
+		t.Errorf("metrics file = %q, want the wears of tee.avatar", data)
+	}
+
+	if _, stderr, code := env.run("stats", "export", "--url", server.URL); code != ExitError || !strings.Contains(stderr, "401") {
+		t.Errorf("stats export to a rejecting server: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("stats", "export"); code != ExitUsage {
+		t.Errorf("stats export without a target: code = %v, want ExitUsage", code)
+	}
+}
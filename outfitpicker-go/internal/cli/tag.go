@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func tagCommand() *Command {
+	return &Command{
+		Name:    "tag",
+		Summary: "Tag outfits with labels for pick --tag (add, remove, list)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "tag", args, map[string]func(*App, []string) error{
+				"add":    runTagAdd,
+				"remove": runTagRemove,
+				"list":   runTagList,
+			})
+		},
+	}
+}
+
+func runTagAdd(app *App, args []string) error {
+	if err := refuseJSON(app, "tag add"); err != nil {
+		return err
+	}
+	outfit, tags, err := app.tagArgs("add", args)
+	if err != nil {
+		return err
+	}
+	current, err := usecases.NewOutfitTagsUseCase(app.services()).Add(outfit, tags)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "%s/%s tags: %s\n", outfit.Category.Name, outfit.FileName, strings.Join(current, ", "))
+	return nil
+}
+
+func runTagRemove(app *App, args []string) error {
+	if err := refuseJSON(app, "tag remove"); err != nil {
+		return err
+	}
+	outfit, tags, err := app.tagArgs("remove", args)
+	if err != nil {
+		return err
+	}
+	current, err := usecases.NewOutfitTagsUseCase(app.services()).Remove(outfit, tags)
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		fmt.Fprintf(app.stdout, "%s/%s has no tags left.\n", outfit.Category.Name, outfit.FileName)
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "%s/%s tags: %s\n", outfit.Category.Name, outfit.FileName, strings.Join(current, ", "))
+	return nil
+}
+
+func runTagList(app *App, args []string) error {
+	positional, err := parseArgs(app.newFlagSet("tag list"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usageErrorf("usage: tag list [category]")
+	}
+
+	services := app.services()
+	categoryName := ""
+	if len(positional) == 1 {
+		category, err := usecases.NewResolveCategoryUseCase(services).Execute(positional[0])
+		if err != nil {
+			return err
+		}
+		categoryName = category.Name
+	}
+	tagged, err := usecases.NewOutfitTagsUseCase(services).List(categoryName)
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		if tagged == nil {
+			tagged = []entities.OutfitTags{}
+		}
+		return app.writeJSON(presentation.ListV1(tagged, presentation.OutfitTagsV1))
+	}
+	return presentation.RenderOutfitTags(app.stdout, tagged)
+}
+
+// tagArgs parses the <category> <outfit> <tag>... arguments of tag add and
+// remove.
+func (a *App) tagArgs(subcommand string, args []string) (entities.OutfitReference, []string, error) {
+	positional, err := parseArgs(a.newFlagSet("tag "+subcommand), args)
+	if err != nil {
+		return entities.OutfitReference{}, nil, err
+	}
+	if len(positional) < 3 {
+		return entities.OutfitReference{}, nil, usageErrorf("usage: tag %s <category> <outfit> <tag>...", subcommand)
+	}
+	outfit, err := a.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return entities.OutfitReference{}, nil, err
+	}
+	return outfit, positional[2:], nil
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTag_AddListRemove(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+
+	if stdout, _, _ := env.run("tag", "list"); stdout != "No tagged outfits yet.\n" {
+		t.Errorf("tag list before tagging = %q", stdout)
+	}
+	stdout, stderr, code := env.run("tag", "add", "casual", "tee.avatar", "summer", "beach")
+	if code != ExitOK || stdout != "casual/tee.avatar tags: summer, beach\n" {
+		t.Fatalf("tag add: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("tag", "list", "casual"); stdout != "casual/tee.avatar: summer, beach\n" {
+		t.Errorf("tag list = %q", stdout)
+	}
+	stdout, _, _ = env.run("--json", "tag", "list")
+	var tagged []struct {
+		FileName string   `json:"fileName"`
+		Tags     []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &tagged); err != nil || len(tagged) != 1 || len(tagged[0].Tags) != 2 {
+		t.Errorf("tag list --json = %q, %v", stdout, err)
+	}
+
+	for range 5 {
+		if stdout, _, _ := env.run("pick", "casual", "--tag", "summer"); stdout != "casual/tee.avatar\n" {
+			t.Fatalf("pick --tag summer = %q", stdout)
+		}
+	}
+
+	stdout, _, _ = env.run("tag", "remove", "casual", "tee.avatar", "summer", "beach")
+	if stdout != "casual/tee.avatar has no tags left.\n" {
+		t.Errorf("tag remove = %q", stdout)
+	}
+	if _, _, code := env.run("pick", "casual", "--tag", "summer"); code != ExitInvalidInput {
+		t.Errorf("pick --tag without tagged outfits: code = %v, want %v", code, ExitInvalidInput)
+	}
+}
+
+func TestTag_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing tag", []string{"tag", "add", "casual", "tee.avatar"}, ExitUsage},
+		{"invalid tag", []string{"tag", "add", "casual", "tee.avatar", "Summer"}, ExitInvalidInput},
+		{"unknown outfit", []string{"tag", "add", "casual", "nope.avatar", "summer"}, ExitInvalidInput},
+		{"tag not on outfit", []string{"tag", "remove", "casual", "tee.avatar", "summer"}, ExitInvalidInput},
+		{"too many list arguments", []string{"tag", "list", "casual", "work"}, ExitUsage},
+		{"unknown subcommand", []string{"tag", "rename"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
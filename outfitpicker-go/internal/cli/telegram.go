@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// telegramTokenAccount is the keychain account holding the token of the
+// Telegram bot watch sends picks as.
+const telegramTokenAccount = "bot-telegram-token"
+
+// Replies a Telegram chat answers a pick with. A leading slash is ignored,
+// so they also work as bot commands.
+const (
+	chatReplyWorn = "worn"
+	chatReplySkip = "skip"
+)
+
+// telegramChat sends the scheduled picks of watch to a Telegram chat and
+// takes the replies to them: worn commits the pick and marks the outfit
+// worn, skip vetoes it and sends another. Picks are only proposed until
+// then, and wait for their reply in memory, so a pick left unanswered when
+// watch stops or the next pick comes due is dropped without a trace.
+type telegramChat struct {
+	ctx      context.Context
+	app      *App
+	services usecases.Services
+	token    string
+	chatID   string
+	// category is the category picks come from, or "" for any.
+	category string
+	// offset is the update ID of the next message to read.
+	offset int64
+	// pending is the pick waiting for a reply, or nil.
+	pending *chatPick
+}
+
+// chatPick is a pick sent to the chat and waiting for a reply.
+type chatPick struct {
+	outfit entities.OutfitReference
+	commit func() error
+	// vetoed lists the outfits skipped before this one was proposed.
+	vetoed []string
+}
+
+// start passes over the messages sent before watch started, so replies to
+// picks of an earlier run are not taken as replies to this run's picks.
+func (c *telegramChat) start() error {
+	messages, err := c.app.chat.Messages(c.ctx, c.token, 0)
+	if err != nil {
+		return err
+	}
+	if len(messages) > 0 {
+		c.offset = messages[len(messages)-1].UpdateID + 1
+	}
+	return nil
+}
+
+// propose sends a new pick to the chat, dropping any pick still waiting
+// for a reply.
+func (c *telegramChat) propose(window *usecases.PickWindow) error {
+	intro := "Your pick:"
+	if window.Missed {
+		intro = fmt.Sprintf("Catching up on the pick due at %s:", window.Due.Local().Format(watchTimeLayout))
+	}
+	return c.offer(intro, nil)
+}
+
+// offer proposes a pick without the vetoed outfits and sends it to the
+// chat.
+func (c *telegramChat) offer(intro string, vetoed []string) error {
+	c.pending = nil
+	pick, err := c.proposePick(vetoed)
+	if errors.Is(err, domainerrors.ErrNoOutfitsAvailable) && len(vetoed) > 0 {
+		return c.send("Every outfit left to pick was skipped; nothing was saved. The next pick comes when it is due.")
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.send(fmt.Sprintf("%s %s/%s\nReply %s to wear it, or %s for another.", intro, pick.outfit.Category.Name, pick.outfit.FileName, chatReplyWorn, chatReplySkip)); err != nil {
+		return err
+	}
+	c.pending = pick
+	return c.event("sent", pick.outfit, "Sent %s/%s to the Telegram chat.")
+}
+
+func (c *telegramChat) proposePick(vetoed []string) (*chatPick, error) {
+	without := usecases.WithoutOutfits(vetoed...)
+	if c.category != "" {
+		pick := usecases.NewPickOutfitUseCase(c.services)
+		proposal, err := pick.Propose(c.category, without)
+		if err != nil {
+			return nil, err
+		}
+		return &chatPick{outfit: proposal.Outfit, commit: func() error { return pick.Commit(proposal) }, vetoed: vetoed}, nil
+	}
+	pick := usecases.NewPickAnyOutfitUseCase(c.services)
+	result, err := pick.Propose(without)
+	if err != nil {
+		return nil, err
+	}
+	return &chatPick{outfit: result.Outfit, commit: func() error { return pick.Commit(result) }, vetoed: vetoed}, nil
+}
+
+// answer takes every reply sent to the chat since the last look. Messages
+// from other chats are passed over.
+func (c *telegramChat) answer() error {
+	for {
+		messages, err := c.app.chat.Messages(c.ctx, c.token, c.offset)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		for _, message := range messages {
+			c.offset = message.UpdateID + 1
+			if message.ChatID != c.chatID {
+				continue
+			}
+			if err := c.reply(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(message.Text), "/"))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *telegramChat) reply(text string) error {
+	pick := c.pending
+	switch {
+	case pick == nil:
+		return c.send("No pick is waiting for an answer; the next comes when it is due.")
+	case text == chatReplyWorn:
+		c.pending = nil
+		status, err := wearProposal(c.services, pick.outfit, pick.commit)
+		if err != nil {
+			c.send(fmt.Sprintf("Nothing was saved: %v", err))
+			return err
+		}
+		if err := c.send(status); err != nil {
+			return err
+		}
+		return c.event("worn", pick.outfit, "Wearing %s/%s, as answered in the Telegram chat.")
+	case text == chatReplySkip:
+		if err := c.event("vetoed", pick.outfit, "Skipped %s/%s, as answered in the Telegram chat."); err != nil {
+			return err
+		}
+		return c.offer("Next up:", append(pick.vetoed, pick.outfit.FileName))
+	default:
+		return c.send(fmt.Sprintf("Reply %s to wear %s/%s, or %s for another.", chatReplyWorn, pick.outfit.Category.Name, pick.outfit.FileName, chatReplySkip))
+	}
+}
+
+func (c *telegramChat) send(text string) error {
+	return c.app.chat.Send(c.ctx, c.token, c.chatID, text)
+}
+
+// event reports what happened to a pick on stdout, as a watch event with
+// --json or else as format filled in with the outfit's category and file
+// name.
+func (c *telegramChat) event(event string, outfit entities.OutfitReference, format string) error {
+	if c.app.jsonOutput {
+		return c.app.writeJSONLine(presentation.PickEventV1(event, outfit, nil))
+	}
+	_, err := fmt.Fprintf(c.app.stdout, format+"\n", outfit.Category.Name, outfit.FileName)
+	return err
+}
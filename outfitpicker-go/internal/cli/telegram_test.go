@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestWatch_TelegramSkipThenWorn(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.keychain = memoryKeychain{telegramTokenAccount: "123:abc"}
+	env.chat = &testhelpers.FakeChatService{
+		Replies:  []string{"skip", "/Worn"},
+		Received: []entities.ChatMessage{{UpdateID: 1, ChatID: "-42", Text: "worn"}},
+	}
+
+	stdout, stderr, code := env.run("watch", "--pick-every", "24h", "--category", "casual", "--telegram", "-42")
+	if code != ExitOK || stderr != "" {
+		t.Fatalf("watch --telegram: code = %v, stderr = %q", code, stderr)
+	}
+	sent := env.chat.Sent
+	if len(sent) != 3 || !strings.HasPrefix(sent[0], "Your pick: casual/") || !strings.HasPrefix(sent[1], "Next up: casual/") {
+		t.Fatalf("sent = %q, want a pick, the next pick after skip and what is worn, and no answer to the old reply", sent)
+	}
+	first := strings.TrimPrefix(strings.SplitN(sent[0], "\n", 2)[0], "Your pick: ")
+	second := strings.TrimPrefix(strings.SplitN(sent[1], "\n", 2)[0], "Next up: ")
+	if first == second {
+		t.Errorf("the skipped outfit %s was proposed again", first)
+	}
+	if sent[2] != "Wearing "+second+"." {
+		t.Errorf("answer to worn = %q", sent[2])
+	}
+	want := "Sent " + first + " to the Telegram chat.\n" +
+		"Skipped " + first + ", as answered in the Telegram chat.\n" +
+		"Sent " + second + " to the Telegram chat.\n" +
+		"Wearing " + second + ", as answered in the Telegram chat.\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+	if stdout, _, _ := env.run("history", "list"); strings.Count(stdout, "casual/") != 1 || !strings.Contains(stdout, second) {
+		t.Errorf("history = %q, want only the worn pick recorded", stdout)
+	}
+}
+
+func TestWatch_TelegramEveryOutfitSkipped(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	env.keychain = memoryKeychain{telegramTokenAccount: "123:abc"}
+	env.chat = &testhelpers.FakeChatService{Replies: []string{"skip", "worn"}}
+
+	if _, stderr, code := env.run("watch", "--pick-every", "24h", "--telegram", "-42"); code != ExitOK || stderr != "" {
+		t.Fatalf("watch --telegram: code = %v, stderr = %q", code, stderr)
+	}
+	sent := env.chat.Sent
+	if len(sent) != 3 || !strings.HasPrefix(sent[1], "Every outfit left to pick was skipped") || !strings.HasPrefix(sent[2], "No pick is waiting") {
+		t.Errorf("sent = %q, want the pick, word that nothing is left and no pick to wear", sent)
+	}
+	if stdout, _, _ := env.run("history", "list"); strings.Contains(stdout, "tee.avatar") {
+		t.Errorf("history = %q, want nothing recorded", stdout)
+	}
+}
+
+func TestWatch_TelegramUsage(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"without a schedule", []string{"watch", "--telegram", "-42"}, ExitUsage},
+		{"once", []string{"watch", "--once", "--pick-every", "24h", "--telegram", "-42"}, ExitUsage},
+		{"no token", []string{"watch", "--pick-every", "24h", "--telegram", "-42"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+	if len(env.chat.Sent) != 0 {
+		t.Errorf("sent = %q, want nothing", env.chat.Sent)
+	}
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// triageSuggestionThreshold is how many new outfits make list suggest
+// running triage.
+const triageSuggestionThreshold = 5
+
+var errTriageAborted = errors.New("triage aborted; nothing was saved")
+
+// triageChoices maps the keys accepted at the triage prompt to actions.
+var triageChoices = map[string]entities.TriageAction{
+	"a": entities.TriageAccept,
+	"t": entities.TriageTag,
+	"e": entities.TriageExclude,
+	"r": entities.TriageArchive,
+}
+
+func triageCommand() *Command {
+	return &Command{
+		Name:    "triage",
+		Summary: "Step through new outfits to accept, tag, exclude or archive each",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("triage")
+			list := fs.Bool("list", false, "list new outfits without prompting")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return usageErrorf("triage takes no arguments, got %q", fs.Arg(0))
+			}
+
+			triage := usecases.NewTriageUseCase(app.services())
+			outfits, err := triage.NewOutfits()
+			if err != nil {
+				return err
+			}
+			if *list || app.jsonOutput {
+				if app.jsonOutput {
+					return app.writeJSON(presentation.ListV1(outfits, presentation.NewOutfitV1))
+				}
+				return presentation.RenderNewOutfits(app.stdout, outfits)
+			}
+			if !app.isInteractive() {
+				return usageErrorf("triage prompts for each outfit and needs an interactive terminal; use --list to see new outfits")
+			}
+			if len(outfits) == 0 {
+				return presentation.RenderNewOutfits(app.stdout, outfits)
+			}
+
+			decisions, err := app.promptTriage(outfits)
+			if err != nil {
+				return err
+			}
+			result, err := triage.Apply(decisions)
+			if err != nil {
+				return err
+			}
+			return presentation.RenderTriageResult(app.stdout, result)
+		},
+	}
+}
+
+// promptTriage asks for a decision about each outfit in turn. Outfits can
+// be skipped or revisited; quitting keeps the decisions made so far, while
+// closing the input discards them.
+func (a *App) promptTriage(outfits []usecases.NewOutfit) ([]entities.TriageDecision, error) {
+	input := bufio.NewScanner(a.stdin)
+	chosen := make([]*entities.TriageDecision, len(outfits))
+	for i := 0; i < len(outfits); {
+		outfit := outfits[i]
+		current := ""
+		if chosen[i] != nil {
+			current = fmt.Sprintf(" [%s]", chosen[i].Action)
+		}
+		answer, ok := a.prompt(input, fmt.Sprintf("[%d/%d] %s/%s%s (a)ccept, (t)ag, (e)xclude, a(r)chive, (s)kip, (b)ack, (q)uit: ",
+			i+1, len(outfits), outfit.Category, outfit.FileName, current))
+		if !ok {
+			return nil, errTriageAborted
+		}
+
+		switch answer {
+		case "s", "":
+			i++
+		case "b":
+			i = max(i-1, 0)
+		case "q":
+			return collectDecisions(chosen), nil
+		default:
+			action, ok := triageChoices[answer]
+			if !ok {
+				fmt.Fprintf(a.stderr, "unrecognized choice %q\n", answer)
+				continue
+			}
+			decision := &entities.TriageDecision{Category: outfit.Category, FileName: outfit.FileName, Action: action}
+			if action == entities.TriageTag {
+				tags, ok := a.promptTags(input)
+				if !ok {
+					return nil, errTriageAborted
+				}
+				if tags == nil {
+					continue
+				}
+				decision.Tags = tags
+			}
+			chosen[i] = decision
+			i++
+		}
+	}
+	return collectDecisions(chosen), nil
+}
+
+// promptTags asks for comma-separated tags, returning nil when the answer is
+// empty or invalid so the outfit is asked about again.
+func (a *App) promptTags(input *bufio.Scanner) ([]string, bool) {
+	answer, ok := a.prompt(input, "Tags (comma-separated): ")
+	if !ok {
+		return nil, false
+	}
+	tags := splitList(answer)
+	if len(tags) == 0 {
+		return nil, true
+	}
+	if err := (entities.OutfitMetadata{Tags: tags}).Validate(); err != nil {
+		fmt.Fprintf(a.stderr, "error: %v\n", err)
+		return nil, true
+	}
+	return tags, true
+}
+
+func collectDecisions(chosen []*entities.TriageDecision) []entities.TriageDecision {
+	var decisions []entities.TriageDecision
+	for _, decision := range chosen {
+		if decision != nil {
+			decisions = append(decisions, *decision)
+		}
+	}
+	return decisions
+}
+
+// suggestTriage points at the triage command when many new outfits are
+// waiting.
+func suggestTriage(w io.Writer, services usecases.Services) {
+	outfits, err := usecases.NewTriageUseCase(services).NewOutfits()
+	if err != nil || len(outfits) < triageSuggestionThreshold {
+		return
+	}
+	fmt.Fprintf(w, "%d new outfits found; run 'outfitpicker triage' to sort them.\n", len(outfits))
+}
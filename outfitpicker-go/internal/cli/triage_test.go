@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+func newTriageEnv(t *testing.T) *cliEnv {
+	t.Helper()
+	return newCLIEnv(t, map[string][]string{"casual": {"a.avatar", "b.avatar", "c.avatar", "d.avatar", "e.avatar"}})
+}
+
+func TestTriage_Interactive(t *testing.T) {
+	env := newTriageEnv(t)
+	answers := strings.Join([]string{
+		"a",                              // accept a
+		"t", "Summer Fun", "t", "summer", // invalid tag, then tag b
+		"x", "e", // unrecognized, then exclude c
+		"b", "r", // back to c and archive it instead
+		"s", // skip d
+		"q", // quit at e, saving
+	}, "\n") + "\n"
+
+	stdout, stderr, code := env.runInteractive(answers, "triage")
+	if code != ExitOK {
+		t.Fatalf("triage: code = %v, stderr = %q", code, stderr)
+	}
+	if want := "Triage saved: 1 accepted, 1 tagged, 1 archived.\n"; stdout != want {
+		t.Errorf("triage output = %q, want %q", stdout, want)
+	}
+	for _, want := range []string{"[1/5] casual/a.avatar", `unrecognized choice "x"`, "casual/c.avatar [exclude]", "must be lowercase"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr)
+		}
+	}
+
+	if stdout, _, _ := env.run("triage", "--list"); stdout != "casual/d.avatar\ncasual/e.avatar\n" {
+		t.Errorf("triage --list after triage = %q", stdout)
+	}
+	if stdout, _, _ := env.run("metadata", "show", "casual", "b.avatar"); !strings.Contains(stdout, "Tags:      summer") {
+		t.Errorf("metadata show = %q", stdout)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, ".outfitarchive", "casual", "c.avatar")); err != nil {
+		t.Errorf("archived outfit missing: %v", err)
+	}
+}
+
+func TestTriage_ClosedInputSavesNothing(t *testing.T) {
+	env := newTriageEnv(t)
+	_, stderr, code := env.runInteractive("a\nr\n", "triage")
+	if code != ExitError || !strings.Contains(stderr, "nothing was saved") {
+		t.Errorf("code = %v, stderr = %q", code, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(env.root, "casual", "b.avatar")); err != nil {
+		t.Errorf("outfit archived despite abort: %v", err)
+	}
+
+	stdout, _, _ := env.run("--json", "triage")
+	var outfits []usecases.NewOutfit
+	if err := json.Unmarshal([]byte(stdout), &outfits); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout)
+	}
+	if len(outfits) != 5 {
+		t.Errorf("new outfits after abort = %v, want 5", outfits)
+	}
+}
+
+func TestTriage_NonInteractive(t *testing.T) {
+	env := newTriageEnv(t)
+	var out, errOut bytes.Buffer
+	app := New(WithOutput(&out, &errOut), WithInteractive(false), WithDirectoryProvider(env.directoryProvider()))
+	if code := app.Run([]string{"triage"}); code != ExitUsage {
+		t.Errorf("exit code = %v, want %v", code, ExitUsage)
+	}
+}
+
+func TestList_SuggestsTriage(t *testing.T) {
+	env := newTriageEnv(t)
+	if _, stderr, _ := env.run("list"); !strings.Contains(stderr, "5 new outfits found; run 'outfitpicker triage'") {
+		t.Errorf("list stderr = %q", stderr)
+	}
+
+	env = newCLIEnv(t, map[string][]string{"casual": {"a.avatar"}})
+	if _, stderr, _ := env.run("list"); stderr != "" {
+		t.Errorf("list stderr with one new outfit = %q", stderr)
+	}
+}
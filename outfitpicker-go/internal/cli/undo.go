@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func undoCommand() *Command {
+	return &Command{
+		Name:    "undo",
+		Summary: "Undo the most recent wear, including any rotation reset it caused",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			fs := app.newFlagSet("undo")
+			if err := parseFlags(fs, args); err != nil {
+				return err
+			}
+			if fs.NArg() > 0 {
+				return usageErrorf("undo takes no arguments, got %q", fs.Arg(0))
+			}
+
+			result, err := usecases.NewUndoWearUseCase(app.services()).UndoLast()
+			if err != nil {
+				return err
+			}
+			if app.jsonOutput {
+				return app.writeJSON(presentation.UndoV1(*result))
+			}
+			return presentation.RenderUndoResult(app.stdout, result)
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar", "hoodie.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+	env.wear(t, "casual", "jeans.avatar")
+
+	stdout, stderr, code := env.run("undo")
+	if code != ExitOK {
+		t.Fatalf("undo: code = %v, stderr = %q", code, stderr)
+	}
+	if !strings.HasPrefix(stdout, "Undid wearing casual/jeans.avatar on ") {
+		t.Errorf("undo output = %q", stdout)
+	}
+
+	stdout, _, code = env.run("--json", "undo")
+	var result struct {
+		Event struct {
+			FileName string `json:"fileName"`
+		} `json:"event"`
+		RestoredRotation bool `json:"restoredRotation"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil || code != ExitOK {
+		t.Fatalf("undo --json: code = %v, err = %v\n%s", code, err, stdout)
+	}
+	if result.Event.FileName != "tee.avatar" || result.RestoredRotation {
+		t.Errorf("undo --json = %+v", result)
+	}
+
+	if _, stderr, code := env.run("undo"); code != ExitNothingToUndo || !strings.Contains(stderr, "nothing to undo") {
+		t.Errorf("undo with empty log: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("undo", "extra"); code != ExitUsage {
+		t.Errorf("undo extra: code = %v, want ExitUsage", code)
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/buildinfo"
+)
+
+func versionCommand() *Command {
+	return &Command{
+		Name:    "version",
+		Summary: "Print version information",
+		Run: func(app *App, args []string) error {
+			if err := parseFlags(app.newFlagSet("version"), args); err != nil {
+				return err
+			}
+			info := buildinfo.Current()
+			fmt.Fprintf(app.stdout, "outfitpicker %s (%s, %s/%s)\n", info.Version, info.GoVersion, info.OS, info.Arch)
+			if info.Commit != "" {
+				fmt.Fprintf(app.stdout, "commit %s\n", info.Commit)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// defaultWatchInterval is how often watch looks for added or removed files.
+const defaultWatchInterval = 2 * time.Second
+
+// missedPickGrace is how much later than a sync interval a scheduled pick
+// may be noticed and still count as on time rather than missed.
+const missedPickGrace = time.Minute
+
+const watchTimeLayout = "2006-01-02 15:04"
+
+func watchCommand() *Command {
+	return &Command{
+		Name:    "watch",
+		Summary: "Keep outfit counts in step with the wardrobe and optionally pick on a schedule",
+		JSON:    true,
+		Run:     runWatch,
+	}
+}
+
+func runWatch(app *App, args []string) error {
+	fs := app.newFlagSet("watch")
+	interval := fs.Duration("interval", defaultWatchInterval, "how often to look for added or removed outfit files")
+	pickEvery := fs.Duration("pick-every", 0, "print a picked outfit this often, e.g. 24h, on a schedule kept across restarts (default off)")
+	category := fs.String("category", "", "category scheduled picks come from (default any)")
+	metricsEvery := fs.Duration("metrics-every", 0, "export the wardrobe metrics at start and then this often, e.g. 5m (default off)")
+	metricsOut := fs.String("metrics-out", "", "file scheduled metrics are appended to in InfluxDB line protocol")
+	metricsURL := fs.String("metrics-url", "", "InfluxDB write URL scheduled metrics are pushed to")
+	missed := fs.String("missed", entities.MissedPicksCatchUp, "what to do about a scheduled pick missed while the machine slept: catch-up, skip or ask")
+	once := fs.Bool("once", false, "sync once, pick if a scheduled pick is due and export metrics if scheduled, then exit")
+	telegramChatID := fs.String("telegram", "", "send scheduled picks to this Telegram chat ID and take worn or skip replies, with the token saved by 'bot secret telegram'")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("watch takes no arguments, got %q", fs.Arg(0))
+	}
+	if *interval <= 0 || *pickEvery < 0 || *metricsEvery < 0 {
+		return usageErrorf("--interval must be positive and --pick-every and --metrics-every must not be negative")
+	}
+	if (*category != "" || flagWasSet(fs, "missed")) && *pickEvery == 0 {
+		return usageErrorf("--category and --missed need --pick-every")
+	}
+	if *telegramChatID != "" && (*pickEvery == 0 || *once) {
+		return usageErrorf("--telegram needs --pick-every and waits for replies, so it cannot be used with --once")
+	}
+	if !slices.Contains(entities.MissedPickPolicies(), *missed) {
+		return usageErrorf("invalid --missed %q (want catch-up, skip or ask)", *missed)
+	}
+	hasMetricsTarget := *metricsOut != "" || *metricsURL != ""
+	if *metricsEvery > 0 && !hasMetricsTarget {
+		return usageErrorf("--metrics-every needs --metrics-out or --metrics-url")
+	}
+	if *metricsEvery == 0 && hasMetricsTarget {
+		return usageErrorf("--metrics-out and --metrics-url need --metrics-every")
+	}
+
+	services := app.services()
+	if *category != "" {
+		resolved, err := usecases.NewResolveCategoryUseCase(services).Execute(*category)
+		if err != nil {
+			return err
+		}
+		*category = resolved.Name
+	}
+	w := &watcher{
+		app:        app,
+		services:   services,
+		category:   *category,
+		pickEvery:  *pickEvery,
+		pickGrace:  *interval + missedPickGrace,
+		missed:     *missed,
+		metricsOut: *metricsOut,
+		metricsURL: *metricsURL,
+	}
+	if *once {
+		if err := w.sync(); err != nil {
+			return err
+		}
+		if *pickEvery > 0 {
+			if err := w.scheduledPick(); err != nil {
+				return err
+			}
+		}
+		if *metricsEvery > 0 {
+			return w.exportMetrics()
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(app.ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *telegramChatID != "" {
+		token, err := app.botSecret(telegramTokenAccount)
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			return domainerrors.NewInvalidInputError("no Telegram bot token is saved; save it first with: bot secret telegram")
+		}
+		w.chat = &telegramChat{ctx: ctx, app: app, services: services, token: token, chatID: *telegramChatID, category: *category}
+	}
+	return w.run(ctx, *interval, *metricsEvery)
+}
+
+// watcher polls the wardrobe for a running watch command. Errors while
+// watching are reported on stderr without stopping it, so that a wardrobe
+// briefly in maintenance mode or on an unmounted drive is picked up again
+// once it is back.
+type watcher struct {
+	app      *App
+	services usecases.Services
+	category string
+	// pickEvery is the time between scheduled picks, zero for none.
+	pickEvery time.Duration
+	// pickGrace is how late a scheduled pick may be noticed before it
+	// counts as missed.
+	pickGrace time.Duration
+	// missed is what to do about a missed pick, one of the missed pick
+	// policies.
+	missed     string
+	metricsOut string
+	metricsURL string
+	// chat is the Telegram chat scheduled picks are sent to, or nil to
+	// print them.
+	chat *telegramChat
+}
+
+// run syncs every interval, and exports metrics every metricsEvery when
+// set, until ctx is done. Scheduled picks are checked on every sync, by the
+// wall clock, so that picks that came due while the machine slept are
+// noticed on wake; a ticker of the pick interval would run late by as long
+// as the machine slept. Replies in the Telegram chat are taken on every
+// sync too.
+func (w *watcher) run(ctx context.Context, interval, metricsEvery time.Duration) error {
+	w.report(w.sync())
+	if w.chat != nil {
+		w.report(w.chat.start())
+	}
+	if w.pickEvery > 0 {
+		w.report(w.scheduledPick())
+	}
+	if w.chat != nil {
+		w.report(w.chat.answer())
+	}
+	syncs := time.NewTicker(interval)
+	defer syncs.Stop()
+	var exports <-chan time.Time
+	if metricsEvery > 0 {
+		w.report(w.exportMetrics())
+		ticker := time.NewTicker(metricsEvery)
+		defer ticker.Stop()
+		exports = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-syncs.C:
+			w.report(w.sync())
+			if w.pickEvery > 0 {
+				w.report(w.scheduledPick())
+			}
+			if w.chat != nil {
+				w.report(w.chat.answer())
+			}
+		case <-exports:
+			w.report(w.exportMetrics())
+		}
+	}
+}
+
+func (w *watcher) sync() error {
+	changes, err := usecases.NewWardrobeSyncUseCase(w.services).Execute()
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if w.app.jsonOutput {
+			if err := w.app.writeJSONLine(presentation.SyncEventV1(change)); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(w.app.stdout, "%s now has %d outfits (was %d).\n", change.Category, change.After, change.Before)
+	}
+	return nil
+}
+
+// scheduledPick picks when a scheduled pick has come due, first settling a
+// missed pick as the missed policy says.
+func (w *watcher) scheduledPick() error {
+	schedule := usecases.NewPickScheduleUseCase(w.services)
+	window, err := schedule.Due(w.pickEvery, w.pickGrace)
+	if err != nil || window == nil {
+		return err
+	}
+	if window.Missed && !w.catchUp(*window) {
+		if err := w.skipped(*window); err != nil {
+			return err
+		}
+		return schedule.Handled(*window)
+	}
+	err = w.pick(window)
+	if handledErr := schedule.Handled(*window); err == nil {
+		err = handledErr
+	}
+	return err
+}
+
+// catchUp reports whether to make a missed pick.
+func (w *watcher) catchUp(window usecases.PickWindow) bool {
+	switch w.missed {
+	case entities.MissedPicksSkip:
+		return false
+	case entities.MissedPicksAsk:
+		if !w.app.isInteractive() {
+			return false
+		}
+		question := fmt.Sprintf("The pick due at %s was missed. Pick now? [y/N] ", window.Due.Local().Format(watchTimeLayout))
+		answer, ok := w.app.prompt(bufio.NewScanner(w.app.stdin), question)
+		return ok && strings.EqualFold(answer, "y")
+	}
+	return true
+}
+
+func (w *watcher) skipped(window usecases.PickWindow) error {
+	if w.app.jsonOutput {
+		return w.app.writeJSONLine(presentation.MissedPickEventV1(window))
+	}
+	_, err := fmt.Fprintf(w.app.stdout, "Skipped the pick due at %s.\n", window.Due.Local().Format(watchTimeLayout))
+	return err
+}
+
+func (w *watcher) pick(window *usecases.PickWindow) error {
+	if w.chat != nil {
+		return w.chat.propose(window)
+	}
+	var outfit entities.OutfitReference
+	if w.category != "" {
+		picked, err := usecases.NewPickOutfitUseCase(w.services).Execute(w.category)
+		if err != nil {
+			return err
+		}
+		outfit = *picked
+	} else {
+		result, err := usecases.NewPickAnyOutfitUseCase(w.services).Execute()
+		if err != nil {
+			return err
+		}
+		outfit = result.Outfit
+	}
+	if w.app.jsonOutput {
+		return w.app.writeJSONLine(presentation.PickEventV1("pick", outfit, window))
+	}
+	if window.Missed {
+		fmt.Fprintf(w.app.stdout, "Catching up on the pick due at %s.\n", window.Due.Local().Format(watchTimeLayout))
+	}
+	_, err := fmt.Fprintf(w.app.stdout, "%s/%s\n", outfit.Category.Name, outfit.FileName)
+	return err
+}
+
+func (w *watcher) exportMetrics() error {
+	return exportMetrics(w.services, w.metricsOut, w.metricsURL)
+}
+
+// report writes an error met while watching to stderr.
+func (w *watcher) report(err error) {
+	if err != nil {
+		fmt.Fprintf(w.app.stderr, "watch: %v\n", err)
+	}
+}
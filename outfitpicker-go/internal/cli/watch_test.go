@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/persistence"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func TestWatch_Once(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	env.wear(t, "casual", "tee.avatar")
+
+	if stdout, stderr, code := env.run("watch", "--once"); code != ExitOK || stdout != "" {
+		t.Errorf("watch --once of an unchanged wardrobe: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+
+	env.writeOutfit("casual", "hoodie.avatar")
+	stdout, stderr, code := env.run("watch", "--once", "--pick-every", "24h", "--category", "casual")
+	if code != ExitOK {
+		t.Fatalf("watch --once --pick-every: code = %v, stderr = %q", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != 2 || lines[0] != "casual now has 3 outfits (was 2)." || !strings.HasPrefix(lines[1], "casual/") {
+		t.Errorf("watch --once --pick-every stdout = %q, want the count change and a pick", stdout)
+	}
+
+	env.writeOutfit("casual", "shorts.avatar")
+	stdout, _, _ = env.run("--json", "watch", "--once")
+	var event v1.WatchEvent
+	if err := json.Unmarshal([]byte(stdout), &event); err != nil {
+		t.Fatalf("watch --json: %v\n%s", err, stdout)
+	}
+	if event.Event != "sync" || event.Change == nil || event.Change.After != 4 {
+		t.Errorf("watch --json event = %+v, want casual synced to 4", event)
+	}
+
+	env.writeOutfit("casual", "socks.avatar")
+	stdout, _, _ = env.run("--fields", "event", "watch", "--once")
+	if stdout != "{\"event\":\"sync\"}\n" {
+		t.Errorf("watch --fields event stdout = %q, want only the event name", stdout)
+	}
+}
+
+func TestWatch_ExportsMetrics(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	var pushed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		pushed = append(pushed, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	out := filepath.Join(t.TempDir(), "metrics.lp")
+
+	for range 2 {
+		if _, stderr, code := env.run("watch", "--once", "--metrics-every", "5m", "--metrics-out", out, "--metrics-url", server.URL); code != ExitOK {
+			t.Fatalf("watch --once --metrics-every: code = %v, stderr = %q", code, stderr)
+		}
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), "outfitpicker_wardrobe outfits=2i"); got != 2 {
+		t.Errorf("metrics file has %d wardrobe points, want one appended per export:\n%s", got, data)
+	}
+	if len(pushed) != 2 || !strings.Contains(pushed[0], "outfitpicker_rotation,category=casual worn=0i,total=2i") {
+		t.Errorf("pushed metrics = %q, want two pushes with the casual rotation", pushed)
+	}
+}
+
+func TestWatch_MissedPicks(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+	store := persistence.NewWatchStateStore(system.WithDirectoryProvider[entities.WatchState](env.directoryProvider()))
+	// sleep records a last pick due long enough ago that the next was
+	// missed, as if the machine had slept through it.
+	sleep := func() {
+		t.Helper()
+		state, err := store.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.LastPickDue = time.Now().Add(-27 * time.Hour)
+		if err := store.Save(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sleep()
+	stdout, stderr, code := env.run("watch", "--once", "--pick-every", "24h")
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if code != ExitOK || len(lines) != 2 || !strings.HasPrefix(lines[0], "Catching up on the pick due at ") || !strings.HasPrefix(lines[1], "casual/") {
+		t.Errorf("watch catching up: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, _ := env.run("watch", "--once", "--pick-every", "24h"); stdout != "" {
+		t.Errorf("watch before the next pick is due = %q, want nothing", stdout)
+	}
+
+	sleep()
+	if stdout, _, _ := env.run("watch", "--once", "--pick-every", "24h", "--missed", "skip"); !strings.HasPrefix(stdout, "Skipped the pick due at ") || strings.Contains(stdout, "casual/") {
+		t.Errorf("watch --missed skip = %q, want the pick skipped", stdout)
+	}
+	sleep()
+	if stdout, _, _ := env.run("watch", "--once", "--pick-every", "24h", "--missed", "ask"); !strings.HasPrefix(stdout, "Skipped the pick due at ") {
+		t.Errorf("watch --missed ask without a terminal = %q, want the pick skipped", stdout)
+	}
+	sleep()
+	if stdout, _, _ := env.runInteractive("y\n", "watch", "--once", "--pick-every", "24h", "--missed", "ask"); !strings.Contains(stdout, "casual/") {
+		t.Errorf("watch --missed ask answered yes = %q, want a pick", stdout)
+	}
+
+	sleep()
+	stdout, _, _ = env.run("--json", "watch", "--once", "--pick-every", "24h", "--missed", "skip")
+	var event v1.WatchEvent
+	if err := json.Unmarshal([]byte(stdout), &event); err != nil || event.Event != "missed" || event.Window == nil || !event.Window.Missed {
+		t.Errorf("watch --json of a skipped pick = %q, %v", stdout, err)
+	}
+}
+
+func TestWatch_StopsWhenInterrupted(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	stdout, stderr, code := env.run("watch", "--pick-every", "1h")
+	if code != ExitOK || !strings.HasPrefix(stdout, "casual/") || stderr != "" {
+		t.Errorf("interrupted watch: code = %v, stdout = %q, stderr = %q, want the first pick", code, stdout, stderr)
+	}
+}
+
+func TestWatch_Usage(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	for _, args := range [][]string{
+		{"watch", "now"},
+		{"watch", "--interval", "0s"},
+		{"watch", "--pick-every", "-1h"},
+		{"watch", "--category", "casual"},
+		{"watch", "--missed", "skip"},
+		{"watch", "--pick-every", "24h", "--missed", "later"},
+		{"watch", "--metrics-every", "5m"},
+		{"watch", "--metrics-out", "metrics.lp"},
+	} {
+		if _, _, code := env.run(args...); code != ExitUsage {
+			t.Errorf("%v: code = %v, want ExitUsage", args, code)
+		}
+	}
+}
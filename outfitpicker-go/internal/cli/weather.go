@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func weatherCommand() *Command {
+	return &Command{
+		Name:    "weather",
+		Summary: "Set the forecast location and what is unsuitable for which weather, for pick --weather (show, location, avoid, clear)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "weather", args, map[string]func(*App, []string) error{
+				"show":     runWeatherShow,
+				"location": runWeatherLocation,
+				"avoid":    runWeatherAvoid,
+				"clear":    runWeatherClear,
+			})
+		},
+	}
+}
+
+func runWeatherShow(app *App, args []string) error {
+	fs := app.newFlagSet("weather show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("weather show takes no arguments, got %q", fs.Arg(0))
+	}
+	services := app.services()
+	config, err := services.Config.Load()
+	if err != nil {
+		return err
+	}
+	var report *usecases.WeatherReport
+	if config.Weather.Location != nil {
+		today, err := usecases.NewWeatherUseCase(services).Today()
+		if err != nil {
+			return err
+		}
+		report = &today
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.WeatherV1(report, config.Weather))
+	}
+	return presentation.RenderWeather(app.stdout, report, config.Weather)
+}
+
+func runWeatherLocation(app *App, args []string) error {
+	if err := refuseJSON(app, "weather location"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("weather location"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usageErrorf("usage: weather location LAT,LON|off")
+	}
+	var location *entities.WeatherLocation
+	if positional[0] != "off" {
+		parsed, err := entities.ParseWeatherLocation(positional[0])
+		if err != nil {
+			return err
+		}
+		location = &parsed
+	}
+	_, err = usecases.NewWeatherUseCase(app.services()).Update(func(current entities.WeatherSettings) entities.WeatherSettings {
+		current.Location = location
+		return current
+	})
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", err)
+	}
+	if err != nil {
+		return err
+	}
+	if location == nil {
+		fmt.Fprintln(app.stdout, "Weather location removed; pick --weather is off.")
+		return nil
+	}
+	fmt.Fprintf(app.stdout, "Forecasts are looked up at %s.\n", location)
+	return nil
+}
+
+func runWeatherAvoid(app *App, args []string) error {
+	if err := refuseJSON(app, "weather avoid"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("weather avoid")
+	tag := fs.String("tag", "", "mark outfits with this tag unsuitable instead of a category")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *tag == "" && len(positional) < 2 || *tag != "" && len(positional) < 1 {
+		return usageErrorf("usage: weather avoid <category> <condition>... | weather avoid --tag <tag> <condition>...")
+	}
+
+	target, conditions := *tag, positional
+	if *tag == "" {
+		category, err := usecases.NewResolveCategoryUseCase(app.services()).Execute(positional[0])
+		if err != nil {
+			return err
+		}
+		target, conditions = category.Name, positional[1:]
+	}
+	if err := app.updateWeather(*tag != "", target, conditions); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "%s is left out of picks for %s.\n", seasonTarget(*tag != "", target), strings.Join(conditions, ", "))
+	return nil
+}
+
+func runWeatherClear(app *App, args []string) error {
+	if err := refuseJSON(app, "weather clear"); err != nil {
+		return err
+	}
+	fs := app.newFlagSet("weather clear")
+	tag := fs.String("tag", "", "clear the conditions of a tag instead of a category")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *tag == "" && len(positional) != 1 || *tag != "" && len(positional) != 0 {
+		return usageErrorf("usage: weather clear <category> | weather clear --tag <tag>")
+	}
+
+	target := *tag
+	if *tag == "" {
+		// Categories that no longer exist can still be cleared.
+		target = positional[0]
+		if category, err := usecases.NewResolveCategoryUseCase(app.services()).Execute(target); err == nil {
+			target = category.Name
+		}
+	}
+	if err := app.updateWeather(*tag != "", target, nil); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "%s suits any weather.\n", seasonTarget(*tag != "", target))
+	return nil
+}
+
+// updateWeather replaces the conditions a category or tag is unsuitable
+// for; no conditions clear them.
+func (a *App) updateWeather(isTag bool, target string, conditions []string) error {
+	_, err := usecases.NewWeatherUseCase(a.services()).Update(func(current entities.WeatherSettings) entities.WeatherSettings {
+		if isTag {
+			return current.SettingTag(target, conditions)
+		}
+		return current.SettingCategory(target, conditions)
+	})
+	if errors.Is(err, domainerrors.ErrInvalidConfiguration) {
+		return fmt.Errorf("%w: conditions must be %s, each listed once, and tags lowercase without spaces", err, strings.Join(validation.WeatherConditions(), ", "))
+	}
+	return err
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestWeather_PickByForecast(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"beach": {"shorts.avatar"}, "casual": {"jumper.avatar", "tee.avatar"}})
+	env.weather = &testhelpers.FakeWeatherProvider{Result: entities.Forecast{MinTemperature: 18, MaxTemperature: 30, PrecipitationChance: 70}}
+
+	if stdout, stderr, code := env.run("weather", "location", "51.5,-0.12"); code != ExitOK || stdout != "Forecasts are looked up at 51.5,-0.12.\n" {
+		t.Fatalf("weather location: code = %v, stdout = %q, stderr = %q", code, stdout, stderr)
+	}
+	if stdout, _, code := env.run("weather", "avoid", "beach", "rain"); code != ExitOK || stdout != "beach is left out of picks for rain.\n" {
+		t.Fatalf("weather avoid: code = %v, stdout = %q", code, stdout)
+	}
+	if _, stderr, code := env.run("weather", "avoid", "--tag", "wool", "heat"); code != ExitOK {
+		t.Fatalf("weather avoid --tag: code = %v, stderr = %q", code, stderr)
+	}
+	if _, _, code := env.run("tag", "add", "casual", "jumper.avatar", "wool"); code != ExitOK {
+		t.Fatal("tag add failed")
+	}
+
+	stdout, _, _ := env.run("weather", "show")
+	if !strings.Contains(stdout, "Conditions: rain, heat\n") || !strings.Contains(stdout, "  tag wool: not for heat\n") {
+		t.Errorf("weather show = %q", stdout)
+	}
+	var output v1.Weather
+	stdout, _, _ = env.run("--json", "weather", "show")
+	if err := json.Unmarshal([]byte(stdout), &output); err != nil || output.Today == nil || len(output.Weather.Categories) != 1 {
+		t.Errorf("weather show --json = %q, %v", stdout, err)
+	}
+
+	if _, stderr, code := env.run("pick", "beach", "--weather"); code != ExitInvalidInput || !strings.Contains(stderr, "unsuitable for today's forecast: rain") {
+		t.Errorf("pick of a category unsuitable for rain: code = %v, stderr = %q", code, stderr)
+	}
+	for range 3 {
+		if stdout, _, _ := env.run("pick", "casual", "--weather"); stdout != "casual/tee.avatar\n" {
+			t.Fatalf("pick --weather = %q, want the outfit without wool", stdout)
+		}
+	}
+	if env.weather.Calls != 1 {
+		t.Errorf("forecast fetched %d times, want once while it is fresh", env.weather.Calls)
+	}
+
+	if stdout, _, _ := env.run("weather", "clear", "beach"); stdout != "beach suits any weather.\n" {
+		t.Errorf("weather clear = %q", stdout)
+	}
+	if _, stderr, code := env.run("pick", "beach", "--weather"); code != ExitOK {
+		t.Errorf("pick after clearing: code = %v, stderr = %q", code, stderr)
+	}
+}
+
+func TestWeather_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"pick without a location", []string{"pick", "casual", "--weather"}, ExitInvalidInput},
+		{"malformed location", []string{"weather", "location", "london"}, ExitInvalidInput},
+		{"location off the globe", []string{"weather", "location", "95,0"}, ExitInvalidConfiguration},
+		{"unknown condition", []string{"weather", "avoid", "casual", "snow"}, ExitInvalidConfiguration},
+		{"missing condition", []string{"weather", "avoid", "casual"}, ExitUsage},
+		{"unknown subcommand", []string{"weather", "forecast"}, ExitUsage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, stderr, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("code = %v, want %v (stderr %q)", code, tt.want, stderr)
+			}
+		})
+	}
+	if stdout, _, code := env.run("weather", "show"); code != ExitOK || !strings.HasPrefix(stdout, "No weather location set.\n") {
+		t.Errorf("weather show without a location: code = %v, stdout = %q", code, stdout)
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+func weightCommand() *Command {
+	return &Command{
+		Name:    "weight",
+		Summary: "Show or set how often outfits are picked by the weighted strategy (list, set)",
+		JSON:    true,
+		Run: func(app *App, args []string) error {
+			return runSubcommand(app, "weight", args, map[string]func(*App, []string) error{
+				"list": runWeightList,
+				"set":  runWeightSet,
+			})
+		},
+	}
+}
+
+func runWeightList(app *App, args []string) error {
+	fs := app.newFlagSet("weight list")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("weight list takes no arguments, got %q", fs.Arg(0))
+	}
+
+	weights, err := usecases.NewOutfitWeightUseCase(app.services()).List()
+	if err != nil {
+		return err
+	}
+	if app.jsonOutput {
+		return app.writeJSON(presentation.WeightsV1(weights))
+	}
+	return presentation.RenderOutfitWeights(app.stdout, weights)
+}
+
+func runWeightSet(app *App, args []string) error {
+	if err := refuseJSON(app, "weight set"); err != nil {
+		return err
+	}
+	positional, err := parseArgs(app.newFlagSet("weight set"), args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 3 {
+		return usageErrorf("usage: weight set <category> <outfit> <weight>")
+	}
+	weight, err := strconv.ParseFloat(positional[2], 64)
+	if err != nil {
+		return usageErrorf("weight %q is not a number", positional[2])
+	}
+	outfit, err := app.outfitReference(positional[0], positional[1])
+	if err != nil {
+		return err
+	}
+
+	services := app.services()
+	if err := usecases.NewOutfitWeightUseCase(services).Set(outfit, weight); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.stdout, "Set the weight of %s/%s to %s.\n", outfit.Category.Name, outfit.FileName, presentation.FormatWeight(weight))
+	if config, err := services.Config.Load(); err == nil && !config.Selection.IsWeighted() {
+		fmt.Fprintln(app.stderr, "Weights apply to the weighted strategy; enable it with 'outfitpicker setup --strategy weighted'.")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWeight_SetAndList(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}})
+
+	if stdout, _, _ := env.run("weight", "list"); !strings.HasPrefix(stdout, "No weights assigned") {
+		t.Errorf("weight list before setting = %q", stdout)
+	}
+
+	stdout, stderr, code := env.run("weight", "set", "casual", "tee.avatar", "2.5")
+	if code != ExitOK {
+		t.Fatalf("weight set: code = %v, stderr = %q", code, stderr)
+	}
+	if stdout != "Set the weight of casual/tee.avatar to 2.5.\n" {
+		t.Errorf("weight set output = %q", stdout)
+	}
+	if !strings.Contains(stderr, "--strategy weighted") {
+		t.Errorf("weight set stderr = %q, want a hint to enable the weighted strategy", stderr)
+	}
+
+	if stdout, _, _ := env.run("weight", "list"); stdout != "casual/tee.avatar  2.5\n" {
+		t.Errorf("weight list = %q", stdout)
+	}
+	stdout, _, _ = env.run("--json", "weight", "list")
+	var weights map[string]map[string]float64
+	if err := json.Unmarshal([]byte(stdout), &weights); err != nil || weights["casual"]["tee.avatar"] != 2.5 {
+		t.Errorf("weight list --json = %q, %v", stdout, err)
+	}
+}
+
+func TestWeightSet_Errors(t *testing.T) {
+	env := newCLIEnv(t, map[string][]string{"casual": {"tee.avatar"}})
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"missing weight", []string{"weight", "set", "casual", "tee.avatar"}, ExitUsage},
+		{"not a number", []string{"weight", "set", "casual", "tee.avatar", "heavy"}, ExitUsage},
+		{"too heavy", []string{"weight", "set", "casual", "tee.avatar", "1000"}, ExitInvalidInput},
+		{"unknown outfit", []string{"weight", "set", "casual", "nope.avatar", "2"}, ExitInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, code := env.run(tt.args...); code != tt.want {
+				t.Errorf("exit code = %v, want %v", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+// Package devtools generates synthetic wardrobe trees for the devtools
+// command, tests and benchmarks.
+package devtools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// WardrobeShape selects the layout produced by GenerateWardrobe.
+type WardrobeShape string
+
+const (
+	// ShapeFlat creates categories directly under the root with outfit files.
+	ShapeFlat WardrobeShape = "flat"
+	// ShapeDeep additionally nests subdirectories inside every category.
+	ShapeDeep WardrobeShape = "deep"
+	// ShapeUnicode uses non-ASCII category and outfit names.
+	ShapeUnicode WardrobeShape = "unicode"
+	// ShapeHuge makes the first category much larger than the rest.
+	ShapeHuge WardrobeShape = "huge"
+)
+
+// Shapes returns every supported wardrobe shape.
+func Shapes() []WardrobeShape {
+	return []WardrobeShape{ShapeFlat, ShapeDeep, ShapeUnicode, ShapeHuge}
+}
+
+const (
+	defaultDepth            = 5
+	defaultHugeCategorySize = 10000
+)
+
+var unicodeStems = []string{
+	"décontracté", "Straßenkleidung", "カジュアル", "休闲", "повседневный", "καθημερινό", "👕 weekend",
+}
+
+// WardrobeSpec describes the wardrobe tree to generate.
+type WardrobeSpec struct {
+	Shape              WardrobeShape
+	Categories         int
+	OutfitsPerCategory int
+	// EmptyCategories adds this many categories containing no files at all.
+	EmptyCategories int
+	// ExtraFiles adds this many non-outfit files to every populated category.
+	ExtraFiles int
+	// Depth is the nesting depth used by ShapeDeep. Defaults to 5.
+	Depth int
+	// HugeCategorySize is the outfit count of the first category in
+	// ShapeHuge. Defaults to 10000.
+	HugeCategorySize int
+}
+
+// Wardrobe describes a generated wardrobe tree.
+type Wardrobe struct {
+	Root string
+	// Outfits maps each category name to its sorted outfit file names.
+	// Empty categories are present with no outfits.
+	Outfits map[string][]string
+}
+
+// CategoryNames returns the generated category names in ascending order.
+func (w *Wardrobe) CategoryNames() []string {
+	names := make([]string, 0, len(w.Outfits))
+	for name := range w.Outfits {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// TotalOutfits returns the number of outfit files across all categories.
+func (w *Wardrobe) TotalOutfits() int {
+	total := 0
+	for _, outfits := range w.Outfits {
+		total += len(outfits)
+	}
+	return total
+}
+
+// GenerateWardrobe creates a synthetic wardrobe under root according to spec.
+// Generation is deterministic: the same spec always yields the same tree.
+func GenerateWardrobe(root string, spec WardrobeSpec) (*Wardrobe, error) {
+	if spec.Categories < 0 || spec.OutfitsPerCategory < 0 || spec.EmptyCategories < 0 || spec.ExtraFiles < 0 {
+		return nil, fmt.Errorf("wardrobe spec counts must not be negative")
+	}
+	if spec.Shape == "" {
+		spec.Shape = ShapeFlat
+	}
+	if !slices.Contains(Shapes(), spec.Shape) {
+		return nil, fmt.Errorf("unknown wardrobe shape %q", spec.Shape)
+	}
+	if spec.Depth <= 0 {
+		spec.Depth = defaultDepth
+	}
+	if spec.HugeCategorySize <= 0 {
+		spec.HugeCategorySize = defaultHugeCategorySize
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+
+	wardrobe := &Wardrobe{Root: root, Outfits: make(map[string][]string)}
+
+	for i := 0; i < spec.Categories; i++ {
+		name := categoryName(spec.Shape, i)
+		count := spec.OutfitsPerCategory
+		if spec.Shape == ShapeHuge && i == 0 {
+			count = spec.HugeCategorySize
+		}
+
+		outfits, err := generateCategory(filepath.Join(root, name), spec, count)
+		if err != nil {
+			return nil, err
+		}
+		wardrobe.Outfits[name] = outfits
+	}
+
+	for i := 0; i < spec.EmptyCategories; i++ {
+		name := fmt.Sprintf("empty-%03d", i)
+		if err := os.MkdirAll(filepath.Join(root, name), 0755); err != nil {
+			return nil, err
+		}
+		wardrobe.Outfits[name] = nil
+	}
+
+	return wardrobe, nil
+}
+
+func generateCategory(dir string, spec WardrobeSpec, count int) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	outfits := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		fileName := outfitName(spec.Shape, i)
+		if err := writeFixtureFile(filepath.Join(dir, fileName)); err != nil {
+			return nil, err
+		}
+		outfits = append(outfits, fileName)
+	}
+
+	for i := 0; i < spec.ExtraFiles; i++ {
+		if err := writeFixtureFile(filepath.Join(dir, fmt.Sprintf("notes-%03d.txt", i))); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Shape == ShapeDeep {
+		nested := dir
+		for level := 1; level <= spec.Depth; level++ {
+			nested = filepath.Join(nested, fmt.Sprintf("level-%d", level))
+		}
+		if err := os.MkdirAll(nested, 0755); err != nil {
+			return nil, err
+		}
+		// Outfits below the category's top level are not part of the category.
+		if err := writeFixtureFile(filepath.Join(nested, "nested.avatar")); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.Sort(outfits)
+	return outfits, nil
+}
+
+func categoryName(shape WardrobeShape, i int) string {
+	if shape == ShapeUnicode {
+		return fmt.Sprintf("%s-%03d", unicodeStems[i%len(unicodeStems)], i)
+	}
+	return fmt.Sprintf("category-%03d", i)
+}
+
+func outfitName(shape WardrobeShape, i int) string {
+	if shape == ShapeUnicode {
+		return fmt.Sprintf("tenue-été-%05d.avatar", i)
+	}
+	return fmt.Sprintf("outfit-%05d.avatar", i)
+}
+
+func writeFixtureFile(path string) error {
+	return os.WriteFile(path, []byte(filepath.Base(path)), 0644)
+}
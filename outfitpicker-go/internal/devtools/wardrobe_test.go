@@ -0,0 +1,135 @@
+package devtools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func generate(t *testing.T, spec WardrobeSpec) *Wardrobe {
+	t.Helper()
+	w, err := GenerateWardrobe(t.TempDir(), spec)
+	if err != nil {
+		t.Fatalf("GenerateWardrobe() error = %v", err)
+	}
+	return w
+}
+
+func countFiles(t *testing.T, dir, suffix string) int {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), suffix) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGenerateWardrobe_Flat(t *testing.T) {
+	w := generate(t, WardrobeSpec{
+		Categories:         3,
+		OutfitsPerCategory: 4,
+		EmptyCategories:    1,
+		ExtraFiles:         2,
+	})
+
+	if got := len(w.CategoryNames()); got != 4 {
+		t.Errorf("category count = %v, want 4", got)
+	}
+	if got := w.TotalOutfits(); got != 12 {
+		t.Errorf("TotalOutfits() = %v, want 12", got)
+	}
+	if got := countFiles(t, filepath.Join(w.Root, "category-000"), ".avatar"); got != 4 {
+		t.Errorf("avatar files = %v, want 4", got)
+	}
+	if got := countFiles(t, filepath.Join(w.Root, "category-000"), ".txt"); got != 2 {
+		t.Errorf("extra files = %v, want 2", got)
+	}
+	if got := countFiles(t, filepath.Join(w.Root, "empty-000"), ""); got != 0 {
+		t.Errorf("empty category files = %v, want 0", got)
+	}
+}
+
+func TestGenerateWardrobe_Deep(t *testing.T) {
+	w := generate(t, WardrobeSpec{
+		Shape:              ShapeDeep,
+		Categories:         1,
+		OutfitsPerCategory: 1,
+		Depth:              3,
+	})
+
+	nested := filepath.Join(w.Root, "category-000", "level-1", "level-2", "level-3", "nested.avatar")
+	if _, err := os.Stat(nested); err != nil {
+		t.Errorf("nested outfit missing: %v", err)
+	}
+	if got := w.TotalOutfits(); got != 1 {
+		t.Errorf("TotalOutfits() = %v, want 1 (nested files are not outfits)", got)
+	}
+}
+
+func TestGenerateWardrobe_Unicode(t *testing.T) {
+	w := generate(t, WardrobeSpec{
+		Shape:              ShapeUnicode,
+		Categories:         2,
+		OutfitsPerCategory: 1,
+	})
+
+	for _, name := range w.CategoryNames() {
+		if _, err := os.Stat(filepath.Join(w.Root, name, w.Outfits[name][0])); err != nil {
+			t.Errorf("unicode outfit missing: %v", err)
+		}
+	}
+	if w.CategoryNames()[0] == "category-000" {
+		t.Error("unicode shape produced ASCII category names")
+	}
+}
+
+func TestGenerateWardrobe_Huge(t *testing.T) {
+	w := generate(t, WardrobeSpec{
+		Shape:              ShapeHuge,
+		Categories:         2,
+		OutfitsPerCategory: 1,
+		HugeCategorySize:   250,
+	})
+
+	if got := len(w.Outfits["category-000"]); got != 250 {
+		t.Errorf("huge category size = %v, want 250", got)
+	}
+	if got := len(w.Outfits["category-001"]); got != 1 {
+		t.Errorf("regular category size = %v, want 1", got)
+	}
+}
+
+func TestGenerateWardrobe_Deterministic(t *testing.T) {
+	spec := WardrobeSpec{Shape: ShapeUnicode, Categories: 3, OutfitsPerCategory: 2}
+	a := generate(t, spec)
+	b := generate(t, spec)
+
+	if strings.Join(a.CategoryNames(), ",") != strings.Join(b.CategoryNames(), ",") {
+		t.Errorf("category names differ: %v vs %v", a.CategoryNames(), b.CategoryNames())
+	}
+}
+
+func TestGenerateWardrobe_InvalidSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		spec WardrobeSpec
+	}{
+		{"unknown shape", WardrobeSpec{Shape: "spiral"}},
+		{"negative categories", WardrobeSpec{Categories: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := GenerateWardrobe(t.TempDir(), tt.spec); err == nil {
+				t.Error("GenerateWardrobe() expected error, got nil")
+			}
+		})
+	}
+}
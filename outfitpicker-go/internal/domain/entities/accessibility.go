@@ -0,0 +1,30 @@
+package entities
+
+// Progress styles.
+const (
+	// ProgressStyleBar draws rotation progress as a bar of filled cells; it
+	// is the default.
+	ProgressStyleBar = "bar"
+	// ProgressStylePattern fills the bar with a different pattern in each
+	// quarter of the rotation, so progress reads from the shape alone.
+	ProgressStylePattern = "pattern"
+	// ProgressStyleBadge shows rotation progress as a percentage.
+	ProgressStyleBadge = "badge"
+)
+
+// AccessibilitySettings adapts rendered output to needs such as color
+// blindness.
+type AccessibilitySettings struct {
+	// ProgressStyle is one of the progress styles; empty means
+	// ProgressStyleBar.
+	ProgressStyle string `json:"progressStyle,omitempty"`
+}
+
+// EffectiveProgressStyle returns the progress style, defaulting to
+// ProgressStyleBar.
+func (s AccessibilitySettings) EffectiveProgressStyle() string {
+	if s.ProgressStyle == "" {
+		return ProgressStyleBar
+	}
+	return s.ProgressStyle
+}
@@ -0,0 +1,24 @@
+package entities
+
+// ArchiveImport reports what was extracted from an outfit archive.
+type ArchiveImport struct {
+	Imported []ImportedOutfit `json:"imported"`
+	Skipped  []SkippedEntry   `json:"skipped,omitempty"`
+}
+
+// ImportedOutfit is an archive entry extracted as an outfit.
+type ImportedOutfit struct {
+	// Entry is the entry's path inside the archive.
+	Entry string `json:"entry"`
+	// FileName is the name the outfit was saved under.
+	FileName string `json:"fileName"`
+	// Renamed is set when the entry's own name was already taken, so the
+	// outfit was saved under a new one rather than overwriting.
+	Renamed bool `json:"renamed,omitempty"`
+}
+
+// SkippedEntry is an archive entry that was not imported.
+type SkippedEntry struct {
+	Entry  string `json:"entry"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// MaxBackups is how many backups are kept. Creating another removes the
+// oldest.
+const MaxBackups = 10
+
+// Backup is a timestamped copy of the config and cache files.
+type Backup struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Files are the names of the files the backup holds.
+	Files []string `json:"files"`
+}
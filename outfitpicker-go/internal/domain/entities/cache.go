@@ -1,12 +1,21 @@
 package entities
 
-import "time"
+import (
+	"maps"
+	"time"
+)
 
 // CategoryCache tracks worn outfits for a single category.
 type CategoryCache struct {
 	WornOutfits  map[string]bool `json:"wornOutfits"`
 	TotalOutfits int             `json:"totalOutfits"`
 	LastUpdated  time.Time       `json:"lastUpdated"`
+	// Revision counts saved changes to this category. Stores compare it to
+	// detect concurrent updates of the same category.
+	Revision int `json:"revision,omitempty"`
+	// LastWorn records when each outfit was last worn. Unlike WornOutfits
+	// it outlives rotation resets, so a cooldown can span them.
+	LastWorn map[string]time.Time `json:"lastWorn,omitempty"`
 }
 
 // NewCategoryCache creates a new category cache.
@@ -54,12 +63,70 @@ func (c CategoryCache) Adding(fileName string) CategoryCache {
 		WornOutfits:  newWorn,
 		TotalOutfits: c.TotalOutfits,
 		LastUpdated:  time.Now(),
+		Revision:     c.Revision,
+		LastWorn:     c.LastWorn,
+	}
+}
+
+// Wearing returns a new cache with the outfit marked as worn and last worn
+// at at.
+func (c CategoryCache) Wearing(fileName string, at time.Time) CategoryCache {
+	return c.Adding(fileName).WithLastWorn(fileName, at)
+}
+
+// WithLastWorn returns a new cache that records the outfit as last worn at
+// at, or as never worn when at is zero.
+func (c CategoryCache) WithLastWorn(fileName string, at time.Time) CategoryCache {
+	lastWorn := maps.Clone(c.LastWorn)
+	if lastWorn == nil {
+		lastWorn = make(map[string]time.Time)
+	}
+	if at.IsZero() {
+		delete(lastWorn, fileName)
+	} else {
+		lastWorn[fileName] = at
+	}
+	if len(lastWorn) == 0 {
+		lastWorn = nil
+	}
+	updated := c
+	updated.LastWorn = lastWorn
+	updated.LastUpdated = time.Now()
+	return updated
+}
+
+// Removing returns a new cache with the outfit no longer marked as worn.
+func (c CategoryCache) Removing(fileName string) CategoryCache {
+	if !c.WornOutfits[fileName] {
+		return c
+	}
+	newWorn := make(map[string]bool, len(c.WornOutfits))
+	for k, v := range c.WornOutfits {
+		if k != fileName {
+			newWorn[k] = v
+		}
+	}
+	return CategoryCache{
+		WornOutfits:  newWorn,
+		TotalOutfits: c.TotalOutfits,
+		LastUpdated:  time.Now(),
+		Revision:     c.Revision,
+		LastWorn:     c.LastWorn,
 	}
 }
 
 // Reset returns a new cache with no worn outfits.
 func (c CategoryCache) Reset() CategoryCache {
-	return NewCategoryCache(c.TotalOutfits)
+	return c.Restarted(c.TotalOutfits)
+}
+
+// Restarted returns a new cache for a fresh rotation of totalOutfits
+// outfits, keeping when each outfit was last worn.
+func (c CategoryCache) Restarted(totalOutfits int) CategoryCache {
+	reset := NewCategoryCache(totalOutfits)
+	reset.Revision = c.Revision
+	reset.LastWorn = c.LastWorn
+	return reset
 }
 
 // OutfitCache tracks all category caches.
@@ -67,6 +134,9 @@ type OutfitCache struct {
 	Categories map[string]CategoryCache `json:"categories"`
 	Version    int                      `json:"version"`
 	CreatedAt  time.Time                `json:"createdAt"`
+	// Revision counts saves of the whole cache file and is used to reject
+	// saves based on stale data.
+	Revision int `json:"revision,omitempty"`
 }
 
 // NewOutfitCache creates a new outfit cache.
@@ -89,6 +159,7 @@ func (o OutfitCache) Updating(path string, cache CategoryCache) OutfitCache {
 		Categories: newCategories,
 		Version:    o.Version,
 		CreatedAt:  o.CreatedAt,
+		Revision:   o.Revision,
 	}
 }
 
@@ -107,6 +178,7 @@ func (o OutfitCache) Removing(path string) OutfitCache {
 		Categories: newCategories,
 		Version:    o.Version,
 		CreatedAt:  o.CreatedAt,
+		Revision:   o.Revision,
 	}
 }
 
@@ -130,5 +202,6 @@ func (o OutfitCache) ResetAll() OutfitCache {
 		Categories: newCategories,
 		Version:    o.Version,
 		CreatedAt:  o.CreatedAt,
+		Revision:   o.Revision,
 	}
 }
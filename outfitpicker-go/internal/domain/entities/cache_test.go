@@ -3,6 +3,7 @@ package entities
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestNewCategoryCache(t *testing.T) {
@@ -67,6 +68,23 @@ func TestCategoryCache_Adding(t *testing.T) {
 	}
 }
 
+func TestCategoryCache_Removing(t *testing.T) {
+	cache := NewCategoryCache(5).
+		Adding("outfit1.avatar").
+		Adding("outfit2.avatar")
+
+	removed := cache.Removing("outfit1.avatar")
+	if len(removed.WornOutfits) != 1 || removed.WornOutfits["outfit1.avatar"] {
+		t.Errorf("Removing WornOutfits = %v, want only outfit2.avatar", removed.WornOutfits)
+	}
+	if len(cache.WornOutfits) != 2 {
+		t.Error("Removing modified the original cache")
+	}
+	if unchanged := removed.Removing("outfit1.avatar"); len(unchanged.WornOutfits) != 1 {
+		t.Error("Removing an unworn outfit should change nothing")
+	}
+}
+
 func TestCategoryCache_Reset(t *testing.T) {
 	cache := NewCategoryCache(5).
 		Adding("outfit1.avatar").
@@ -81,6 +99,22 @@ func TestCategoryCache_Reset(t *testing.T) {
 	}
 }
 
+func TestCategoryCache_LastWornOutlivesReset(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewCategoryCache(2).Wearing("outfit1.avatar", day).Wearing("outfit2.avatar", day.AddDate(0, 0, 1))
+	if !cache.WornOutfits["outfit1.avatar"] || !cache.LastWorn["outfit2.avatar"].Equal(day.AddDate(0, 0, 1)) {
+		t.Fatalf("Wearing() = %+v", cache)
+	}
+
+	restarted := cache.Removing("outfit1.avatar").Restarted(3)
+	if len(restarted.WornOutfits) != 0 || restarted.TotalOutfits != 3 || len(restarted.LastWorn) != 2 {
+		t.Errorf("Restarted() = %+v, want a fresh rotation of 3 that keeps both last-worn times", restarted)
+	}
+	if forgotten := restarted.WithLastWorn("outfit1.avatar", time.Time{}); len(forgotten.LastWorn) != 1 || len(restarted.LastWorn) != 2 {
+		t.Errorf("WithLastWorn(zero) = %v, original %v", forgotten.LastWorn, restarted.LastWorn)
+	}
+}
+
 func TestCategoryCache_RemainingOutfits(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -269,3 +303,15 @@ func TestOutfitCache_JSONMarshaling(t *testing.T) {
 		t.Errorf("Categories length = %v, want %v", len(unmarshaled.Categories), len(cache.Categories))
 	}
 }
+
+func TestCategoryCache_PreservesRevision(t *testing.T) {
+	cache := NewCategoryCache(3)
+	cache.Revision = 4
+
+	if got := cache.Adding("a.avatar").Revision; got != 4 {
+		t.Errorf("Adding().Revision = %d, want 4", got)
+	}
+	if got := cache.Reset().Revision; got != 4 {
+		t.Errorf("Reset().Revision = %d, want 4", got)
+	}
+}
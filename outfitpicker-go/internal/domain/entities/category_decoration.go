@@ -0,0 +1,12 @@
+package entities
+
+// CategoryDecoration is the emoji and color shown next to a category name.
+type CategoryDecoration struct {
+	Emoji string `json:"emoji,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// IsEmpty reports whether the decoration has neither an emoji nor a color.
+func (d CategoryDecoration) IsEmpty() bool {
+	return d.Emoji == "" && d.Color == ""
+}
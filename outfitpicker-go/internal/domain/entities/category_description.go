@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// CategoryDescriptionFileName is the optional file inside a category
+// directory that describes the category.
+const CategoryDescriptionFileName = "category.json"
+
+// CategoryDescription is what a category's category.json says about it.
+// Every field is optional.
+type CategoryDescription struct {
+	// Description is a sentence or two about the category.
+	Description string `json:"description,omitempty"`
+	// Label is the name shown for the category in place of its directory
+	// name.
+	Label string `json:"label,omitempty"`
+	// Tags are tags every outfit of the category carries in addition to
+	// its own.
+	Tags []string `json:"tags,omitempty"`
+	// Slot is the role the category fills in an ensemble, such as "top" or
+	// "shoes".
+	Slot string `json:"slot,omitempty"`
+}
+
+// Validate checks the description's length, label, tags and slot.
+func (d CategoryDescription) Validate() error {
+	return validation.ValidateCategoryDescription(d.Description, d.Label, d.Tags, d.Slot)
+}
+
+// HasTag reports whether every outfit of the category carries tag.
+func (d CategoryDescription) HasTag(tag string) bool {
+	return slices.Contains(d.Tags, tag)
+}
+
+// DefaultTags returns the tags every outfit of the category carries, none
+// when it has no description.
+func (i CategoryInfo) DefaultTags() []string {
+	if i.Description == nil {
+		return nil
+	}
+	return i.Description.Tags
+}
+
+// Summary is the label and the description, whichever are set, for a
+// single line of a table.
+func (d CategoryDescription) Summary() string {
+	switch {
+	case d.Label == "":
+		return d.Description
+	case d.Description == "":
+		return d.Label
+	}
+	return d.Label + " - " + d.Description
+}
+
+// DisplayName returns the category's label, or its name when the category
+// has no label.
+func (i CategoryInfo) DisplayName() string {
+	if i.Description != nil && i.Description.Label != "" {
+		return i.Description.Label
+	}
+	return i.Category.Name
+}
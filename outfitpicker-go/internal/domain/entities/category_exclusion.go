@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// CategoryExclusion is a category left out of picks and listings.
+type CategoryExclusion struct {
+	Category string `json:"category"`
+	// Until is when a temporary exclusion ends; zero when the category is
+	// excluded until it is included again.
+	Until time.Time `json:"until,omitzero"`
+}
+
+// ParseExclusionPeriod parses how long a category is excluded for: a
+// number of days or weeks such as 3d or 2w, or a duration such as 36h.
+func ParseExclusionPeriod(value string) (time.Duration, error) {
+	period, err := parsePeriod(value)
+	if err != nil || period <= 0 {
+		return 0, errors.NewInvalidInputError(fmt.Sprintf("exclusion period must be a positive number of days or weeks like 3d or 2w, or a duration like 36h, got %q", value))
+	}
+	return period, nil
+}
+
+func parsePeriod(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			return time.Duration(n) * unit, err
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// ExcludedAt returns the categories excluded at now: those excluded until
+// they are included again and those whose temporary exclusion has not yet
+// ended.
+func (c *Config) ExcludedAt(now time.Time) map[string]bool {
+	if len(c.ExcludedUntil) == 0 {
+		return c.ExcludedCategories
+	}
+	excluded := maps.Clone(c.ExcludedCategories)
+	if excluded == nil {
+		excluded = make(map[string]bool)
+	}
+	for category, until := range c.ExcludedUntil {
+		if now.Before(until) {
+			excluded[category] = true
+		}
+	}
+	return excluded
+}
+
+// DropExpiredExclusions removes the temporary exclusions that have ended
+// by now, and reports whether there were any.
+func (c *Config) DropExpiredExclusions(now time.Time) bool {
+	dropped := false
+	for category, until := range c.ExcludedUntil {
+		if !now.Before(until) {
+			delete(c.ExcludedUntil, category)
+			dropped = true
+		}
+	}
+	if len(c.ExcludedUntil) == 0 {
+		c.ExcludedUntil = nil
+	}
+	return dropped
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestParseExclusionPeriod(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"3d", 3 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got, err := ParseExclusionPeriod(tt.value); err != nil || got != tt.want {
+			t.Errorf("ParseExclusionPeriod(%q) = %v, %v; want %v", tt.value, got, err, tt.want)
+		}
+	}
+	for _, value := range []string{"", "2", "w", "0d", "-1w", "two weeks"} {
+		if _, err := ParseExclusionPeriod(value); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+			t.Errorf("ParseExclusionPeriod(%q) error = %v, want InvalidInputError", value, err)
+		}
+	}
+}
+
+func TestConfig_ExcludedAt(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	config := &Config{
+		ExcludedCategories: map[string]bool{"formal": true},
+		ExcludedUntil:      map[string]time.Time{"office": now.Add(time.Hour), "beach": now},
+	}
+
+	want := map[string]bool{"formal": true, "office": true}
+	if got := config.ExcludedAt(now); !maps.Equal(got, want) {
+		t.Errorf("ExcludedAt() = %v, want %v", got, want)
+	}
+	if len(config.ExcludedCategories) != 1 {
+		t.Errorf("ExcludedAt() changed the permanent exclusions: %v", config.ExcludedCategories)
+	}
+
+	if !config.DropExpiredExclusions(now) || len(config.ExcludedUntil) != 1 {
+		t.Errorf("DropExpiredExclusions() left %v, want only office", config.ExcludedUntil)
+	}
+	if config.DropExpiredExclusions(now) {
+		t.Error("DropExpiredExclusions() reported a drop with nothing expired")
+	}
+	if config.DropExpiredExclusions(now.Add(time.Hour)); config.ExcludedUntil != nil {
+		t.Errorf("ExcludedUntil = %v after every exclusion ended, want nil", config.ExcludedUntil)
+	}
+}
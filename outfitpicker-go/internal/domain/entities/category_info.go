@@ -15,6 +15,9 @@ type CategoryInfo struct {
 	Category    CategoryReference `json:"category"`
 	State       CategoryState     `json:"state"`
 	OutfitCount int               `json:"outfitCount"`
+	// Description is what the category's category.json says about it, nil
+	// when the category has none.
+	Description *CategoryDescription `json:"description,omitempty"`
 }
 
 // NewCategoryInfo creates a new category info.
@@ -0,0 +1,19 @@
+package entities
+
+// CategoryNames are the alternative names a user may type for a category:
+// a label per language code and any number of language-neutral aliases.
+type CategoryNames struct {
+	Labels  map[string]string `json:"labels,omitempty"`
+	Aliases []string          `json:"aliases,omitempty"`
+}
+
+// IsEmpty reports whether the category has neither labels nor aliases.
+func (n CategoryNames) IsEmpty() bool {
+	return len(n.Labels) == 0 && len(n.Aliases) == 0
+}
+
+// Label returns the category's label in language, if one is set.
+func (n CategoryNames) Label(language string) (string, bool) {
+	label, ok := n.Labels[language]
+	return label, ok
+}
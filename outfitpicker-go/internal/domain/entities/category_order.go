@@ -0,0 +1,32 @@
+package entities
+
+// Category sorts.
+const (
+	// CategorySortName orders categories by name; it is the default.
+	CategorySortName = "name"
+	// CategorySortManual puts the listed categories first, in the order
+	// listed, and the rest after them by name.
+	CategorySortManual = "manual"
+	// CategorySortHealth puts the least healthy categories first.
+	CategorySortHealth = "health"
+	// CategorySortProgress puts the categories furthest through their
+	// rotation first.
+	CategorySortProgress = "progress"
+)
+
+// CategoryOrder is the order categories are shown in wherever they are
+// listed. Ties are broken by name.
+type CategoryOrder struct {
+	// Sort is one of the category sorts; empty means CategorySortName.
+	Sort string `json:"sort,omitempty"`
+	// Manual lists categories for CategorySortManual.
+	Manual []string `json:"manual,omitempty"`
+}
+
+// EffectiveSort returns the sort, defaulting to CategorySortName.
+func (o CategoryOrder) EffectiveSort() string {
+	if o.Sort == "" {
+		return CategorySortName
+	}
+	return o.Sort
+}
@@ -0,0 +1,29 @@
+package entities
+
+import "slices"
+
+// CategoryPermissions restricts what a profile may do with the categories
+// of a wardrobe root shared with other profiles. A nil list allows every
+// category.
+type CategoryPermissions struct {
+	// Pick lists the categories picks may choose from.
+	Pick []string `json:"pick,omitempty"`
+	// Reset lists the categories whose rotation may be started over once
+	// every outfit in it has been worn.
+	Reset []string `json:"reset,omitempty"`
+}
+
+// CanPick reports whether picks may choose from category.
+func (p CategoryPermissions) CanPick(category string) bool {
+	return p.Pick == nil || slices.Contains(p.Pick, category)
+}
+
+// CanReset reports whether the rotation of category may be started over.
+func (p CategoryPermissions) CanReset(category string) bool {
+	return p.Reset == nil || slices.Contains(p.Reset, category)
+}
+
+// Restricted reports whether any category is restricted.
+func (p CategoryPermissions) Restricted() bool {
+	return p.Pick != nil || p.Reset != nil
+}
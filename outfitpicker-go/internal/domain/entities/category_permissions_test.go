@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestCategoryPermissions(t *testing.T) {
+	var everything CategoryPermissions
+	if !everything.CanPick("casual") || !everything.CanReset("casual") || everything.Restricted() {
+		t.Errorf("zero permissions = %+v, want every category allowed", everything)
+	}
+
+	restricted := CategoryPermissions{Pick: []string{"casual", "work"}, Reset: []string{"casual"}}
+	tests := []struct {
+		category string
+		pick     bool
+		reset    bool
+	}{
+		{"casual", true, true},
+		{"work", true, false},
+		{"formal", false, false},
+	}
+	for _, tt := range tests {
+		if got := restricted.CanPick(tt.category); got != tt.pick {
+			t.Errorf("CanPick(%q) = %v, want %v", tt.category, got, tt.pick)
+		}
+		if got := restricted.CanReset(tt.category); got != tt.reset {
+			t.Errorf("CanReset(%q) = %v, want %v", tt.category, got, tt.reset)
+		}
+	}
+	if !restricted.Restricted() {
+		t.Error("Restricted() = false, want true")
+	}
+}
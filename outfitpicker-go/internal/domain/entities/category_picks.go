@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"maps"
+	"time"
+)
+
+// CategoryPicks records when an outfit was last picked from each category.
+// It is kept apart from the selection history, which records outfits.
+type CategoryPicks struct {
+	LastPicked map[string]time.Time `json:"lastPicked"`
+	// Revision counts saves of the category picks file and is used to
+	// reject saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewCategoryPicks creates a record with no picks.
+func NewCategoryPicks() CategoryPicks {
+	return CategoryPicks{LastPicked: make(map[string]time.Time)}
+}
+
+// Recording returns new category picks with category last picked at.
+func (p CategoryPicks) Recording(category string, at time.Time) CategoryPicks {
+	lastPicked := maps.Clone(p.LastPicked)
+	if lastPicked == nil {
+		lastPicked = make(map[string]time.Time)
+	}
+	lastPicked[category] = at
+	p.LastPicked = lastPicked
+	return p
+}
+
+// WithoutCategory returns new category picks without category.
+func (p CategoryPicks) WithoutCategory(category string) CategoryPicks {
+	p.LastPicked = maps.Clone(p.LastPicked)
+	delete(p.LastPicked, category)
+	return p
+}
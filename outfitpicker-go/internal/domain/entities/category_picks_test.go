@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCategoryPicks_Recording(t *testing.T) {
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	picks := NewCategoryPicks().Recording("casual", first)
+	updated := picks.Recording("casual", first.Add(time.Hour)).Recording("work", first)
+
+	if got := picks.LastPicked["casual"]; !got.Equal(first) || len(picks.LastPicked) != 1 {
+		t.Errorf("original picks changed: %v", picks.LastPicked)
+	}
+	if got := updated.LastPicked["casual"]; !got.Equal(first.Add(time.Hour)) {
+		t.Errorf("casual last picked = %v, want %v", got, first.Add(time.Hour))
+	}
+	if got := updated.LastPicked["work"]; !got.Equal(first) {
+		t.Errorf("work last picked = %v, want %v", got, first)
+	}
+	if got := (CategoryPicks{}).Recording("casual", first).LastPicked["casual"]; !got.Equal(first) {
+		t.Errorf("recording into zero picks = %v", got)
+	}
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "strconv"
+
 // CategoryReference identifies a category directory containing outfit files.
 type CategoryReference struct {
 	Name string `json:"name"`
@@ -14,3 +16,12 @@ func NewCategoryReference(name, path string) CategoryReference {
 func (c CategoryReference) String() string {
 	return c.Name
 }
+
+// RootCategoryName returns the name of the category in directory dir of the
+// root at index root in Config.Roots, when a root before it already has a
+// category of that name: dir followed by "@" and the root's position
+// counting from 1, such as "casual@2". Other categories are named after
+// their directory.
+func RootCategoryName(dir string, root int) string {
+	return dir + "@" + strconv.Itoa(root+1)
+}
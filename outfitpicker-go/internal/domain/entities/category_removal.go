@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// FileDisposition is what happens to the files of a removed category.
+type FileDisposition string
+
+// File dispositions.
+const (
+	// FilesLeave leaves the category's directory on disk, excluded from
+	// picks so it is not found again as a new category.
+	FilesLeave FileDisposition = "leave"
+	// FilesArchive moves the category's directory into the archive of the
+	// root holding it.
+	FilesArchive FileDisposition = "archive"
+	// FilesTrash moves the category's directory to the user's trash.
+	FilesTrash FileDisposition = "trash"
+)
+
+// FileDispositions returns every file disposition.
+func FileDispositions() []FileDisposition {
+	return []FileDisposition{FilesLeave, FilesArchive, FilesTrash}
+}
+
+// ParseFileDisposition validates a file disposition typed by the user.
+func ParseFileDisposition(value string) (FileDisposition, error) {
+	disposition := FileDisposition(value)
+	if !slices.Contains(FileDispositions(), disposition) {
+		return "", errors.NewInvalidInputError(fmt.Sprintf("unknown file disposition %q (want one of: %v)", value, FileDispositions()))
+	}
+	return disposition, nil
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "testing"
+
+func TestParseFileDisposition(t *testing.T) {
+	if disposition, err := ParseFileDisposition("trash"); err != nil || disposition != FilesTrash {
+		t.Errorf("ParseFileDisposition(trash) = %v, %v", disposition, err)
+	}
+	if _, err := ParseFileDisposition("shred"); err == nil {
+		t.Error("ParseFileDisposition(shred) succeeded")
+	}
+}
@@ -0,0 +1,85 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// Challenge is a time-boxed capsule challenge: until it ends, picks only
+// suggest the outfits of its capsule, and wears of them are counted apart
+// from the wear log. The zero Challenge means none was ever started.
+type Challenge struct {
+	// Capsule lists the outfits the challenge is worn from.
+	Capsule []OutfitLocation `json:"capsule,omitempty"`
+	// StartedAt and EndsAt bound the challenge as planned.
+	StartedAt time.Time `json:"startedAt,omitzero"`
+	EndsAt    time.Time `json:"endsAt,omitzero"`
+	// EndedAt is set when the challenge was ended before EndsAt.
+	EndedAt time.Time `json:"endedAt,omitzero"`
+	// Wears records every wear of a capsule outfit during the challenge,
+	// including outfits already worn in their rotation.
+	Wears []WearEvent `json:"wears,omitempty"`
+	// Revision counts saves of the challenge file and is used to reject
+	// saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// IsZero reports whether no challenge was ever started.
+func (c Challenge) IsZero() bool {
+	return c.StartedAt.IsZero()
+}
+
+// EndTime returns when the challenge ended or will end.
+func (c Challenge) EndTime() time.Time {
+	if !c.EndedAt.IsZero() {
+		return c.EndedAt
+	}
+	return c.EndsAt
+}
+
+// ActiveAt reports whether the challenge is running at t.
+func (c Challenge) ActiveAt(t time.Time) bool {
+	return !c.IsZero() && !t.Before(c.StartedAt) && t.Before(c.EndTime())
+}
+
+// Contains reports whether category/fileName is in the capsule.
+func (c Challenge) Contains(category, fileName string) bool {
+	return slices.Contains(c.Capsule, OutfitLocation{Category: category, FileName: fileName})
+}
+
+// HasCategory reports whether the capsule holds any outfit of category.
+func (c Challenge) HasCategory(category string) bool {
+	return slices.ContainsFunc(c.Capsule, func(l OutfitLocation) bool { return l.Category == category })
+}
+
+// Recording returns the challenge with event added to its wears.
+func (c Challenge) Recording(event WearEvent) Challenge {
+	c.Wears = append(slices.Clip(c.Wears), event)
+	return c
+}
+
+// DroppingWear returns the challenge without its wear of the same outfit
+// at the same time as event, if any.
+func (c Challenge) DroppingWear(event WearEvent) Challenge {
+	i := slices.IndexFunc(c.Wears, func(wear WearEvent) bool {
+		return wear.Category == event.Category && wear.FileName == event.FileName && wear.WornAt.Equal(event.WornAt)
+	})
+	if i >= 0 {
+		c.Wears = slices.Delete(slices.Clone(c.Wears), i, i+1)
+	}
+	return c
+}
+
+// Ending returns the challenge ended at t.
+func (c Challenge) Ending(t time.Time) Challenge {
+	c.EndedAt = t
+	return c
+}
+
+// WithoutCategory returns the challenge without the capsule outfits and
+// wears of category.
+func (c Challenge) WithoutCategory(category string) Challenge {
+	c.Capsule = slices.DeleteFunc(slices.Clone(c.Capsule), func(l OutfitLocation) bool { return l.Category == category })
+	c.Wears = slices.DeleteFunc(slices.Clone(c.Wears), func(wear WearEvent) bool { return wear.Category == category })
+	return c
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChallenge_ActiveAt(t *testing.T) {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	challenge := Challenge{StartedAt: start, EndsAt: start.AddDate(0, 0, 7)}
+
+	if (Challenge{}).ActiveAt(start) {
+		t.Error("zero challenge is active")
+	}
+	if !challenge.ActiveAt(start) || !challenge.ActiveAt(start.AddDate(0, 0, 6)) {
+		t.Error("challenge not active during its days")
+	}
+	if challenge.ActiveAt(start.Add(-time.Second)) || challenge.ActiveAt(start.AddDate(0, 0, 7)) {
+		t.Error("challenge active outside its days")
+	}
+	ended := challenge.Ending(start.AddDate(0, 0, 2))
+	if ended.ActiveAt(start.AddDate(0, 0, 3)) || !ended.EndTime().Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("ended challenge still active, ends %v", ended.EndTime())
+	}
+	if !challenge.ActiveAt(start.AddDate(0, 0, 3)) {
+		t.Error("Ending changed the original challenge")
+	}
+}
+
+func TestChallenge_Wears(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	challenge := Challenge{Capsule: []OutfitLocation{{Category: "casual", FileName: "tee.avatar"}}}
+	if !challenge.Contains("casual", "tee.avatar") || challenge.Contains("casual", "jeans.avatar") {
+		t.Error("Contains() does not match the capsule")
+	}
+	if !challenge.HasCategory("casual") || challenge.HasCategory("work") {
+		t.Error("HasCategory() does not match the capsule")
+	}
+
+	first := WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: at}
+	second := WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: at.AddDate(0, 0, 1)}
+	worn := challenge.Recording(first).Recording(second)
+	if len(worn.Wears) != 2 || len(challenge.Wears) != 0 {
+		t.Fatalf("Recording() wears = %v, original %v", worn.Wears, challenge.Wears)
+	}
+	dropped := worn.DroppingWear(second)
+	if len(dropped.Wears) != 1 || !dropped.Wears[0].WornAt.Equal(at) || len(worn.Wears) != 2 {
+		t.Errorf("DroppingWear() = %v, original %v", dropped.Wears, worn.Wears)
+	}
+	if got := dropped.DroppingWear(second); len(got.Wears) != 1 {
+		t.Errorf("DroppingWear() of an unknown wear = %v", got.Wears)
+	}
+}
+
+func TestChallenge_WithoutCategory(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	challenge := Challenge{
+		Capsule: []OutfitLocation{{Category: "casual", FileName: "tee.avatar"}, {Category: "work", FileName: "suit.avatar"}},
+		Wears:   []WearEvent{{Category: "casual", FileName: "tee.avatar", WornAt: at}, {Category: "work", FileName: "suit.avatar", WornAt: at}},
+	}
+
+	updated := challenge.WithoutCategory("casual")
+	if updated.HasCategory("casual") || len(updated.Capsule) != 1 {
+		t.Errorf("WithoutCategory() capsule = %v, want only work", updated.Capsule)
+	}
+	if len(updated.Wears) != 1 || updated.Wears[0].Category != "work" {
+		t.Errorf("WithoutCategory() wears = %v, want only work", updated.Wears)
+	}
+	if len(challenge.Capsule) != 2 || len(challenge.Wears) != 2 || challenge.Capsule[0].Category != "casual" {
+		t.Errorf("original challenge changed: %+v", challenge)
+	}
+}
@@ -0,0 +1,11 @@
+package entities
+
+// ChatMessage is a message sent to the bot in a chat, such as a reply to a
+// pick sent there.
+type ChatMessage struct {
+	// UpdateID orders the messages the bot receives; later messages have
+	// higher IDs.
+	UpdateID int64
+	ChatID   string
+	Text     string
+}
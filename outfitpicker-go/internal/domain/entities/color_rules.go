@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// ParseColorRules turns the color rules of ensemble settings into the
+// color clashes they add. A rule is one of:
+//
+//	warm = red, orange, yellow
+//	avoid warm with pink, purple
+//
+// The first names a palette of colors; the second makes every color on the
+// left clash with every color on the right, where palettes stand for their
+// colors. Palettes may be named after the rules using them. A color avoided
+// with itself is worn by at most one outfit of an ensemble, so avoid warm
+// with warm allows one warm outfit.
+func ParseColorRules(rules []string) ([][2]string, error) {
+	palettes := make(map[string][]string)
+	var avoids [][2][]string
+	for _, rule := range rules {
+		normalized := strings.Join(strings.Fields(rule), " ")
+		if rest, ok := strings.CutPrefix(normalized, "avoid "); ok {
+			left, right, ok := strings.Cut(rest, " with ")
+			if !ok {
+				return nil, invalidColorRule(rule)
+			}
+			avoids = append(avoids, [2][]string{colorRuleList(left), colorRuleList(right)})
+			continue
+		}
+		name, colors, ok := strings.Cut(normalized, "=")
+		if !ok {
+			return nil, invalidColorRule(rule)
+		}
+		name = strings.TrimSpace(name)
+		if _, defined := palettes[name]; defined {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("palette %q is named by more than one color rule", name))
+		}
+		if err := validation.ValidatePalette(name, colorRuleList(colors)); err != nil {
+			return nil, err
+		}
+		palettes[name] = colorRuleList(colors)
+	}
+
+	var clashes [][2]string
+	for _, avoid := range avoids {
+		left, err := expandPalettes(avoid[0], palettes)
+		if err != nil {
+			return nil, err
+		}
+		right, err := expandPalettes(avoid[1], palettes)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range left {
+			for _, b := range right {
+				clashes = AddingRule(clashes, a, b)
+			}
+		}
+	}
+	return clashes, nil
+}
+
+// colorRuleList splits the comma-separated colors or palettes of a rule.
+func colorRuleList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// expandPalettes replaces the palettes among items with their colors and
+// checks the rest are colors.
+func expandPalettes(items []string, palettes map[string][]string) ([]string, error) {
+	var colors []string
+	for _, item := range items {
+		if palette, ok := palettes[item]; ok {
+			colors = append(colors, palette...)
+			continue
+		}
+		if err := validation.ValidateColors([]string{item}); err != nil {
+			return nil, err
+		}
+		colors = append(colors, item)
+	}
+	if len(colors) == 0 {
+		return nil, errors.NewInvalidInputError("avoid color rules need colors on both sides of with")
+	}
+	return colors, nil
+}
+
+func invalidColorRule(rule string) error {
+	return errors.NewInvalidInputError(fmt.Sprintf("color rule %q is neither NAME = COLORS nor avoid COLORS with COLORS", rule))
+}
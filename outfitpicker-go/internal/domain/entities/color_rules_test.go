@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestParseColorRules(t *testing.T) {
+	clashes, err := ParseColorRules([]string{
+		"avoid warm with pink,  purple",
+		"warm = red, orange",
+		"avoid navy with black",
+		"avoid warm with warm",
+	})
+	if err != nil {
+		t.Fatalf("ParseColorRules() error = %v", err)
+	}
+	want := [][2]string{
+		{"red", "pink"}, {"red", "purple"}, {"orange", "pink"}, {"orange", "purple"},
+		{"navy", "black"},
+		{"red", "red"}, {"red", "orange"}, {"orange", "orange"},
+	}
+	if !slices.Equal(clashes, want) {
+		t.Errorf("ParseColorRules() = %v, want %v", clashes, want)
+	}
+}
+
+func TestParseColorRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []string
+	}{
+		{"no verb", []string{"red pink"}},
+		{"avoid without with", []string{"avoid red pink"}},
+		{"empty side", []string{"avoid red with"}},
+		{"bad color", []string{"avoid Red with pink"}},
+		{"empty palette", []string{"warm ="}},
+		{"palette named twice", []string{"warm = red", "warm = orange"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseColorRules(tt.rules); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+				t.Errorf("ParseColorRules(%q) error = %v, want InvalidInputError", tt.rules, err)
+			}
+		})
+	}
+}
+
+func TestEnsembleSettings_WithColorRules(t *testing.T) {
+	settings := EnsembleSettings{ColorClashes: [][2]string{{"red", "pink"}}, ColorRules: []string{"avoid pink with red, green"}}
+	got, err := settings.WithColorRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]string{{"red", "pink"}, {"pink", "green"}}; !slices.Equal(got.ColorClashes, want) {
+		t.Errorf("WithColorRules() clashes = %v, want %v", got.ColorClashes, want)
+	}
+	if len(settings.ColorClashes) != 1 {
+		t.Errorf("WithColorRules() changed the settings: %v", settings.ColorClashes)
+	}
+	if err := (EnsembleSettings{ColorRules: []string{"clash red pink"}}).Validate(); err == nil {
+		t.Error("Validate() accepted a malformed color rule")
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Size limits of the command history.
+const (
+	// MaxCommandHistory is how many commands the history keeps; older ones
+	// are dropped.
+	MaxCommandHistory = 100
+	// MaxCommandLength is the longest command line, in bytes, that is
+	// recorded. Longer ones are left out.
+	MaxCommandLength = 1024
+)
+
+// CommandRecord is one run of the program.
+type CommandRecord struct {
+	// Command is the command run, by its English name whatever alias was
+	// typed.
+	Command string `json:"command"`
+	// Args are the arguments the program was run with, global flags
+	// included, so that the run can be repeated as it was.
+	Args     []string  `json:"args"`
+	RanAt    time.Time `json:"ranAt"`
+	ExitCode int       `json:"exitCode"`
+}
+
+// CommandHistory is the list of recent runs of the program, oldest first.
+type CommandHistory struct {
+	Commands []CommandRecord `json:"commands"`
+	// Revision counts saves of the history file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// Appending returns a new history with record added at the end, dropping
+// the oldest records beyond MaxCommandHistory. A record whose command line
+// is longer than MaxCommandLength is not added.
+func (h CommandHistory) Appending(record CommandRecord) CommandHistory {
+	if len(strings.Join(record.Args, " ")) > MaxCommandLength {
+		return h
+	}
+	commands := append(slices.Clone(h.Commands), record)
+	if len(commands) > MaxCommandHistory {
+		commands = commands[len(commands)-MaxCommandHistory:]
+	}
+	return CommandHistory{Commands: commands, Revision: h.Revision}
+}
+
+// Last returns the most recent run of the named command, and whether there
+// is one.
+func (h CommandHistory) Last(command string) (CommandRecord, bool) {
+	for _, record := range slices.Backward(h.Commands) {
+		if record.Command == command {
+			return record, true
+		}
+	}
+	return CommandRecord{}, false
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommandHistory_Appending(t *testing.T) {
+	var history CommandHistory
+	for i := range MaxCommandHistory + 5 {
+		history = history.Appending(CommandRecord{Command: "list", ExitCode: i})
+	}
+	if len(history.Commands) != MaxCommandHistory || history.Commands[0].ExitCode != 5 {
+		t.Errorf("history holds %d commands from exit code %d, want the last %d", len(history.Commands), history.Commands[0].ExitCode, MaxCommandHistory)
+	}
+
+	long := CommandRecord{Command: "tag", Args: []string{"tag", "add", strings.Repeat("x", MaxCommandLength)}}
+	if got := history.Appending(long); len(got.Commands) != MaxCommandHistory || got.Commands[len(got.Commands)-1].Command == "tag" {
+		t.Error("Appending() added a command line longer than MaxCommandLength")
+	}
+}
+
+func TestCommandHistory_Last(t *testing.T) {
+	history := CommandHistory{Commands: []CommandRecord{
+		{Command: "pick", Args: []string{"pick", "casual"}},
+		{Command: "pick", Args: []string{"pick", "work", "--weather"}},
+		{Command: "list", Args: []string{"list"}},
+	}}
+
+	record, ok := history.Last("pick")
+	if !ok || !slices.Equal(record.Args, []string{"pick", "work", "--weather"}) {
+		t.Errorf("Last(pick) = %+v, %v; want the pick of work", record, ok)
+	}
+	if _, ok := history.Last("wear"); ok {
+		t.Error("Last(wear) found a command that was never run")
+	}
+}
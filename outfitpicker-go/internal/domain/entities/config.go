@@ -1,7 +1,12 @@
 package entities
 
 import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/dh85/outfitpicker/internal/domain/errors"
 	"github.com/dh85/outfitpicker/internal/domain/validation"
@@ -11,11 +16,38 @@ const DefaultLanguage = "en"
 
 // Config represents the application configuration.
 type Config struct {
-	Root               string                     `json:"root"`
+	// Roots are the wardrobe directories whose categories are combined. The
+	// first root's categories keep their names; see RootCategoryName.
+	Roots              []string                   `json:"roots"`
 	Language           string                     `json:"language"`
 	ExcludedCategories map[string]bool            `json:"excludedCategories"`
 	KnownCategories    map[string]bool            `json:"knownCategories"`
 	KnownCategoryFiles map[string]map[string]bool `json:"knownCategoryFiles"`
+	// ExcludedUntil maps categories excluded for a while to when they are
+	// included again.
+	ExcludedUntil map[string]time.Time `json:"excludedUntil,omitempty"`
+	// CategoryDecorations maps category names to their display decoration.
+	CategoryDecorations map[string]CategoryDecoration `json:"categoryDecorations,omitempty"`
+	// CategoryNames maps category names to localized labels and aliases
+	// accepted wherever a category is typed.
+	CategoryNames map[string]CategoryNames `json:"categoryNames,omitempty"`
+	Selection     SelectionPreferences     `json:"selection,omitzero"`
+	Scan          ScanPolicy               `json:"scan,omitzero"`
+	Reports       ReportSettings           `json:"reports,omitzero"`
+	Health        HealthThresholds         `json:"health,omitzero"`
+	Seasons       SeasonAssignments        `json:"seasons,omitzero"`
+	Order         CategoryOrder            `json:"categoryOrder,omitzero"`
+	Accessibility AccessibilitySettings    `json:"accessibility,omitzero"`
+	OutfitIDs     OutfitIDSettings         `json:"outfitIds,omitzero"`
+	Permissions   CategoryPermissions      `json:"permissions,omitzero"`
+	Weather       WeatherSettings          `json:"weather,omitzero"`
+	Ensemble      EnsembleSettings         `json:"ensemble,omitzero"`
+	// Occasions maps occasion names to the categories and tags picks for
+	// them choose from.
+	Occasions map[string]Occasion `json:"occasions,omitempty"`
+	// Revision counts saves of the config file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
 }
 
 // NewConfig creates and validates a new configuration.
@@ -59,10 +91,340 @@ func NewConfig(
 	}
 
 	return &Config{
-		Root:               root,
+		Roots:              []string{root},
 		Language:           lang,
 		ExcludedCategories: excludedCategories,
 		KnownCategories:    knownCategories,
 		KnownCategoryFiles: knownCategoryFiles,
 	}, nil
 }
+
+// UnmarshalJSON reads the roots, also accepting the single "root" string
+// that configurations written before multiple roots were supported hold.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	type plain Config
+	var decoded struct {
+		plain
+		Root string `json:"root"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*c = Config(decoded.plain)
+	if len(c.Roots) == 0 && decoded.Root != "" {
+		c.Roots = []string{decoded.Root}
+	}
+	return nil
+}
+
+// PrimaryRoot returns the first root, where categories that are not on
+// disk yet are created.
+func (c *Config) PrimaryRoot() string {
+	if len(c.Roots) == 0 {
+		return ""
+	}
+	return c.Roots[0]
+}
+
+// SetRoots validates and assigns the wardrobe roots. At least one is
+// required and none may repeat.
+func (c *Config) SetRoots(roots []string) error {
+	if len(roots) == 0 {
+		return errors.NewInvalidInputError("root directory cannot be empty")
+	}
+	for i, root := range roots {
+		if strings.TrimSpace(root) == "" {
+			return errors.NewInvalidInputError("root directory cannot be empty")
+		}
+		if err := validation.ValidatePath(root); err != nil {
+			return errors.MapError(err)
+		}
+		if slices.Contains(roots[:i], root) {
+			return errors.NewInvalidInputError(fmt.Sprintf("root directory %s is listed twice", root))
+		}
+	}
+	c.Roots = slices.Clone(roots)
+	return nil
+}
+
+// Decoration returns the decoration assigned to a category, if any.
+func (c *Config) Decoration(category string) CategoryDecoration {
+	return c.CategoryDecorations[category]
+}
+
+// SetDecoration validates and assigns a decoration to a category. An empty
+// decoration removes any existing one.
+func (c *Config) SetDecoration(category string, decoration CategoryDecoration) error {
+	if err := validation.ValidateCategoryDecoration(decoration.Emoji, decoration.Color); err != nil {
+		return errors.MapError(err)
+	}
+	if decoration.IsEmpty() {
+		delete(c.CategoryDecorations, category)
+		return nil
+	}
+	if c.CategoryDecorations == nil {
+		c.CategoryDecorations = make(map[string]CategoryDecoration)
+	}
+	c.CategoryDecorations[category] = decoration
+	return nil
+}
+
+// Names returns the labels and aliases assigned to a category, if any.
+func (c *Config) Names(category string) CategoryNames {
+	return c.CategoryNames[category]
+}
+
+// SetNames validates and assigns labels and aliases to a category. Empty
+// names remove any existing ones.
+func (c *Config) SetNames(category string, names CategoryNames) error {
+	if err := validation.ValidateCategoryNames(names.Labels, names.Aliases); err != nil {
+		return errors.MapError(err)
+	}
+	if names.IsEmpty() {
+		delete(c.CategoryNames, category)
+		return nil
+	}
+	if c.CategoryNames == nil {
+		c.CategoryNames = make(map[string]CategoryNames)
+	}
+	c.CategoryNames[category] = names
+	return nil
+}
+
+// SetScanPolicy validates and assigns the scan policy.
+func (c *Config) SetScanPolicy(policy ScanPolicy) error {
+	if err := validation.ValidateIgnorePatterns(policy.Ignore); err != nil {
+		return errors.MapError(err)
+	}
+	c.Scan = policy
+	return nil
+}
+
+// SetHealth validates and assigns the category health thresholds. Zero
+// fields are stored as zero and mean the defaults.
+func (c *Config) SetHealth(thresholds HealthThresholds) error {
+	effective := thresholds.WithDefaults()
+	if err := validation.ValidateHealthThresholds(effective.HealthyScore, effective.CriticalScore, effective.StaleAfterDays, effective.TargetRotationDays); err != nil {
+		return errors.MapError(err)
+	}
+	c.Health = thresholds
+	return nil
+}
+
+// ForgetCategory drops everything the configuration records about a
+// category: whether it is known or excluded, its known files, decoration,
+// names, seasons, ensemble slot, place in the manual order, and its
+// priority, rotation policy, pick limit and rotation lock. Permissions
+// and occasions are left alone, since removing a category from them could
+// widen or empty their lists. The maps are copied before they are changed,
+// so configurations sharing them are not affected.
+func (c *Config) ForgetCategory(category string) {
+	c.KnownCategories = without(c.KnownCategories, category)
+	c.KnownCategoryFiles = without(c.KnownCategoryFiles, category)
+	c.ExcludedCategories = without(c.ExcludedCategories, category)
+	c.ExcludedUntil = without(c.ExcludedUntil, category)
+	c.CategoryDecorations = without(c.CategoryDecorations, category)
+	c.CategoryNames = without(c.CategoryNames, category)
+	c.Seasons.Categories = without(c.Seasons.Categories, category)
+	c.Ensemble.Slots = without(c.Ensemble.Slots, category)
+	c.Order.Manual = withoutName(c.Order.Manual, category)
+	c.Selection.CategoryPriorities = without(c.Selection.CategoryPriorities, category)
+	c.Selection.CategoryRotationPolicies = without(c.Selection.CategoryRotationPolicies, category)
+	c.Selection.CategoryPickLimits = without(c.Selection.CategoryPickLimits, category)
+	c.Selection.LockedRotations = withoutName(c.Selection.LockedRotations, category)
+}
+
+// CategorySettings names the settings the configuration holds for a
+// category, among those ForgetCategory drops beyond its known files.
+func (c *Config) CategorySettings(category string) []string {
+	var settings []string
+	add := func(set bool, setting string) {
+		if set {
+			settings = append(settings, setting)
+		}
+	}
+	_, decorated := c.CategoryDecorations[category]
+	_, named := c.CategoryNames[category]
+	_, prioritized := c.Selection.CategoryPriorities[category]
+	_, policy := c.Selection.CategoryRotationPolicies[category]
+	_, limited := c.Selection.CategoryPickLimits[category]
+	add(decorated, "decoration")
+	add(named, "names")
+	add(len(c.Seasons.Categories[category]) > 0, "seasons")
+	add(c.Ensemble.Slots[category] != "", "ensemble slot")
+	add(slices.Contains(c.Order.Manual, category), "manual order")
+	add(prioritized, "priority")
+	add(policy, "rotation policy")
+	add(limited, "pick limit")
+	add(slices.Contains(c.Selection.LockedRotations, category), "rotation lock")
+	return settings
+}
+
+// without returns a copy of m without key.
+func without[V any](m map[string]V, key string) map[string]V {
+	m = maps.Clone(m)
+	delete(m, key)
+	return m
+}
+
+// withoutName returns a copy of names without name, or nil when no name is
+// left.
+func withoutName(names []string, name string) []string {
+	names = slices.DeleteFunc(slices.Clone(names), func(n string) bool { return n == name })
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+// SetSeasons validates and assigns the season assignments.
+func (c *Config) SetSeasons(assignments SeasonAssignments) error {
+	if err := validation.ValidateSeasonAssignments(assignments.Categories, assignments.Tags); err != nil {
+		return errors.MapError(err)
+	}
+	c.Seasons = assignments
+	return nil
+}
+
+// SetOrder validates and assigns the category display order.
+func (c *Config) SetOrder(order CategoryOrder) error {
+	if err := validation.ValidateCategoryOrder(order.Sort, order.Manual); err != nil {
+		return errors.MapError(err)
+	}
+	c.Order = order
+	return nil
+}
+
+// SetAccessibility validates and assigns the accessibility settings.
+func (c *Config) SetAccessibility(settings AccessibilitySettings) error {
+	if err := validation.ValidateAccessibility(settings.ProgressStyle); err != nil {
+		return errors.MapError(err)
+	}
+	c.Accessibility = settings
+	return nil
+}
+
+// SetOutfitIDs validates and assigns the outfit ID settings.
+func (c *Config) SetOutfitIDs(settings OutfitIDSettings) error {
+	if err := validation.ValidateOutfitIDScheme(settings.Scheme); err != nil {
+		return errors.MapError(err)
+	}
+	c.OutfitIDs = settings
+	return nil
+}
+
+// SetPermissions validates and assigns the category permissions.
+func (c *Config) SetPermissions(permissions CategoryPermissions) error {
+	if err := validation.ValidateCategoryPermissions(permissions.Pick, permissions.Reset); err != nil {
+		return errors.MapError(err)
+	}
+	c.Permissions = permissions
+	return nil
+}
+
+// SetWeather validates and assigns the weather settings.
+func (c *Config) SetWeather(settings WeatherSettings) error {
+	var location WeatherLocation
+	if settings.Location != nil {
+		location = *settings.Location
+	}
+	if err := validation.ValidateWeatherSettings(settings.Location != nil, location.Latitude, location.Longitude, settings.Categories, settings.Tags); err != nil {
+		return errors.MapError(err)
+	}
+	c.Weather = settings
+	return nil
+}
+
+// SetEnsemble validates and assigns the ensemble settings.
+func (c *Config) SetEnsemble(settings EnsembleSettings) error {
+	if err := settings.Validate(); err != nil {
+		return errors.MapError(err)
+	}
+	c.Ensemble = settings
+	return nil
+}
+
+// SetOccasion validates and assigns an occasion. An empty occasion removes
+// any existing one.
+func (c *Config) SetOccasion(name string, occasion Occasion) error {
+	if occasion.IsEmpty() {
+		delete(c.Occasions, name)
+		return nil
+	}
+	if err := validation.ValidateOccasion(name, occasion.Categories, occasion.Tags); err != nil {
+		return errors.MapError(err)
+	}
+	if c.Occasions == nil {
+		c.Occasions = make(map[string]Occasion)
+	}
+	c.Occasions[name] = occasion
+	return nil
+}
+
+// SetReports validates and assigns the report settings.
+func (c *Config) SetReports(settings ReportSettings) error {
+	if err := validation.ValidateReportFormat(settings.Format); err != nil {
+		return errors.MapError(err)
+	}
+	if smtp := settings.SMTP; smtp != nil {
+		if err := validation.ValidateSMTPSettings(smtp.Host, smtp.Port, smtp.From, smtp.To); err != nil {
+			return errors.MapError(err)
+		}
+	}
+	c.Reports = settings
+	return nil
+}
+
+// SetSelection validates and assigns the selection preferences.
+func (c *Config) SetSelection(preferences SelectionPreferences) error {
+	if err := validation.ValidateSelectionPreferences(preferences.Strategy, preferences.FeedbackBoost); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateNewArrivalPolicy(preferences.NewArrivals.Mode, preferences.NewArrivals.Days); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateEmptyCategoryPolicy(preferences.EmptyCategories); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateCategoryRestDays(preferences.CategoryRestDays); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateCategoryBalance(preferences.CategoryBalance); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateCategoryPriorities(preferences.CategoryPriorities); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateCooldownDays(preferences.CooldownDays); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateCategoryPickLimits(preferences.CategoryPickLimits); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateLockedRotations(preferences.LockedRotations); err != nil {
+		return errors.MapError(err)
+	}
+	if err := validation.ValidateRotationPolicy(preferences.RotationPolicy.Name, preferences.RotationPolicy.CooldownDays); err != nil {
+		return errors.MapError(err)
+	}
+	for category, policy := range preferences.CategoryRotationPolicies {
+		if category == "" {
+			return errors.MapError(errors.ErrInvalidSelection)
+		}
+		if err := validation.ValidateRotationPolicy(policy.Name, policy.CooldownDays); err != nil {
+			return errors.MapError(err)
+		}
+	}
+	tags := make(map[string]bool)
+	for _, constraint := range preferences.TagConstraints {
+		if err := validation.ValidateTagConstraint(constraint.Tag, constraint.Max, constraint.Days, constraint.Severity); err != nil {
+			return errors.MapError(err)
+		}
+		if tags[constraint.Tag] {
+			return errors.MapError(errors.ErrInvalidSelection)
+		}
+		tags[constraint.Tag] = true
+	}
+	c.Selection = preferences
+	return nil
+}
@@ -8,6 +8,7 @@ type ConfigBuilder struct {
 	language           *string
 	excludedCategories map[string]bool
 	knownCategories    map[string]bool
+	decorations        map[string]CategoryDecoration
 }
 
 // NewConfigBuilder creates a new ConfigBuilder.
@@ -15,6 +16,7 @@ func NewConfigBuilder() *ConfigBuilder {
 	return &ConfigBuilder{
 		excludedCategories: make(map[string]bool),
 		knownCategories:    make(map[string]bool),
+		decorations:        make(map[string]CategoryDecoration),
 	}
 }
 
@@ -58,17 +60,33 @@ func (b *ConfigBuilder) IncludeCategory(category string) *ConfigBuilder {
 	return b
 }
 
+// Decorate assigns an emoji and color to a category. Either may be empty.
+func (b *ConfigBuilder) Decorate(category, emoji, color string) *ConfigBuilder {
+	b.decorations[category] = CategoryDecoration{Emoji: emoji, Color: color}
+	return b
+}
+
 // Build creates a validated Config instance.
 func (b *ConfigBuilder) Build() (*Config, error) {
 	if b.rootPath == nil {
 		return nil, errors.NewInvalidInputError("root directory must be set before building config")
 	}
 
-	return NewConfig(
+	config, err := NewConfig(
 		*b.rootPath,
 		b.language,
 		b.excludedCategories,
 		b.knownCategories,
 		nil,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	for category, decoration := range b.decorations {
+		if err := config.SetDecoration(category, decoration); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
 }
@@ -1,6 +1,10 @@
 package entities
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
 
 func TestConfigBuilder_Basic(t *testing.T) {
 	builder := NewConfigBuilder()
@@ -11,8 +15,8 @@ func TestConfigBuilder_Basic(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Build() error = %v", err)
 	}
-	if config.Root != "/home/user/outfits" {
-		t.Errorf("Root = %v, want /home/user/outfits", config.Root)
+	if config.PrimaryRoot() != "/home/user/outfits" {
+		t.Errorf("PrimaryRoot() = %v, want /home/user/outfits", config.PrimaryRoot())
 	}
 	if config.Language != DefaultLanguage {
 		t.Errorf("Language = %v, want %v", config.Language, DefaultLanguage)
@@ -147,8 +151,8 @@ func TestConfigBuilder_Chaining(t *testing.T) {
 		t.Fatalf("Build() error = %v", err)
 	}
 
-	if config.Root != "/home/user/outfits" {
-		t.Errorf("Root = %v, want /home/user/outfits", config.Root)
+	if config.PrimaryRoot() != "/home/user/outfits" {
+		t.Errorf("PrimaryRoot() = %v, want /home/user/outfits", config.PrimaryRoot())
 	}
 	if config.Language != "fr" {
 		t.Errorf("Language = %v, want fr", config.Language)
@@ -160,3 +164,32 @@ func TestConfigBuilder_Chaining(t *testing.T) {
 		t.Errorf("KnownCategories length = %v, want 3", len(config.KnownCategories))
 	}
 }
+
+func TestConfigBuilder_Decorate(t *testing.T) {
+	config, err := NewConfigBuilder().
+		RootDirectory("/home/user/outfits").
+		Decorate("casual", "👕", "blue").
+		Decorate("formal", "", "#333333").
+		Build()
+
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := config.Decoration("casual"); got.Emoji != "👕" || got.Color != "blue" {
+		t.Errorf("Decoration(casual) = %+v", got)
+	}
+	if got := config.Decoration("formal"); got.Emoji != "" || got.Color != "#333333" {
+		t.Errorf("Decoration(formal) = %+v", got)
+	}
+}
+
+func TestConfigBuilder_DecorateInvalid(t *testing.T) {
+	_, err := NewConfigBuilder().
+		RootDirectory("/home/user/outfits").
+		Decorate("casual", "👕👖", "").
+		Build()
+
+	if err != errors.ErrInvalidConfiguration {
+		t.Errorf("Build() error = %v, want ErrInvalidConfiguration", err)
+	}
+}
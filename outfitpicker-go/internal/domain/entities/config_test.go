@@ -2,6 +2,7 @@ package entities
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -55,8 +56,8 @@ func TestNewConfig(t *testing.T) {
 				return
 			}
 			if !tt.wantErr {
-				if config.Root != tt.root {
-					t.Errorf("Root = %v, want %v", config.Root, tt.root)
+				if config.PrimaryRoot() != tt.root {
+					t.Errorf("PrimaryRoot() = %v, want %v", config.PrimaryRoot(), tt.root)
 				}
 				if tt.lang == nil && config.Language != "en" {
 					t.Errorf("Language = %v, want en (default)", config.Language)
@@ -129,14 +130,131 @@ func TestConfig_JSONMarshaling(t *testing.T) {
 		t.Fatalf("Unmarshal() error = %v", err)
 	}
 
-	if unmarshaled.Root != config.Root {
-		t.Errorf("Root = %v, want %v", unmarshaled.Root, config.Root)
+	if !slices.Equal(unmarshaled.Roots, config.Roots) {
+		t.Errorf("Roots = %v, want %v", unmarshaled.Roots, config.Roots)
 	}
 	if unmarshaled.Language != config.Language {
 		t.Errorf("Language = %v, want %v", unmarshaled.Language, config.Language)
 	}
 }
 
+func TestConfig_UnmarshalSingleRoot(t *testing.T) {
+	var config Config
+	if err := json.Unmarshal([]byte(`{"root": "/home/user/outfits", "language": "en"}`), &config); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !slices.Equal(config.Roots, []string{"/home/user/outfits"}) {
+		t.Errorf("Roots = %v, want the old single root", config.Roots)
+	}
+
+	data, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["root"]; ok {
+		t.Errorf("Marshal() = %s, want roots only", data)
+	}
+}
+
+func TestConfig_SetRoots(t *testing.T) {
+	config, err := NewConfig("/home/user/outfits", nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SetRoots([]string{"/home/user/outfits", "/home/shared/outfits"}); err != nil {
+		t.Fatalf("SetRoots() error = %v", err)
+	}
+	if config.PrimaryRoot() != "/home/user/outfits" || len(config.Roots) != 2 {
+		t.Errorf("Roots = %v", config.Roots)
+	}
+
+	for _, roots := range [][]string{nil, {"/home/user/outfits", ""}, {"/home/user/outfits", "/home/user/outfits"}, {"/etc"}} {
+		if err := config.SetRoots(roots); err == nil {
+			t.Errorf("SetRoots(%q) accepted invalid roots", roots)
+		}
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestConfig_SetDecoration(t *testing.T) {
+	config, err := NewConfig("/home/user/outfits", nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SetDecoration("casual", CategoryDecoration{Emoji: "👕"}); err != nil {
+		t.Fatalf("SetDecoration() error = %v", err)
+	}
+	if config.Decoration("casual").Emoji != "👕" {
+		t.Errorf("Decoration() = %+v", config.Decoration("casual"))
+	}
+
+	if err := config.SetDecoration("casual", CategoryDecoration{Color: "chartreuse"}); err == nil {
+		t.Error("SetDecoration() accepted an invalid color")
+	}
+	if config.Decoration("casual").Emoji != "👕" {
+		t.Error("invalid SetDecoration() replaced the existing decoration")
+	}
+
+	if err := config.SetDecoration("casual", CategoryDecoration{}); err != nil {
+		t.Fatalf("SetDecoration() clear error = %v", err)
+	}
+	if _, ok := config.CategoryDecorations["casual"]; ok {
+		t.Error("empty decoration was not removed")
+	}
+}
+
+func TestConfig_ForgetCategory(t *testing.T) {
+	config := &Config{
+		Roots:              []string{"/outfits"},
+		KnownCategories:    map[string]bool{"formal": true, "casual": true},
+		KnownCategoryFiles: map[string]map[string]bool{"formal": {"suit.avatar": true}},
+		ExcludedCategories: map[string]bool{"formal": true},
+		CategoryNames:      map[string]CategoryNames{"formal": {Aliases: []string{"suits"}}},
+		Seasons:            SeasonAssignments{Categories: map[string][]string{"formal": {"winter"}}},
+		Order:              CategoryOrder{Sort: CategorySortManual, Manual: []string{"formal", "casual"}},
+		Permissions:        CategoryPermissions{Pick: []string{"formal"}},
+		Selection: SelectionPreferences{
+			CategoryPriorities:       map[string]float64{"formal": 2, "casual": 1},
+			CategoryRotationPolicies: map[string]RotationPolicy{"formal": {Name: RotationRandom}},
+			CategoryPickLimits:       map[string]int{"formal": 1},
+			LockedRotations:          []string{"formal"},
+		},
+	}
+
+	want := []string{"names", "seasons", "manual order", "priority", "rotation policy", "pick limit", "rotation lock"}
+	if got := config.CategorySettings("formal"); !slices.Equal(got, want) {
+		t.Errorf("CategorySettings() = %v, want %v", got, want)
+	}
+
+	config.ForgetCategory("formal")
+	if got := config.CategorySettings("formal"); got != nil {
+		t.Errorf("CategorySettings() after ForgetCategory() = %v, want none", got)
+	}
+	if config.KnownCategories["formal"] || !config.KnownCategories["casual"] || config.KnownCategoryFiles["formal"] != nil || config.ExcludedCategories["formal"] {
+		t.Errorf("ForgetCategory() left known %v, files %v, excluded %v", config.KnownCategories, config.KnownCategoryFiles, config.ExcludedCategories)
+	}
+	if _, ok := config.CategoryNames["formal"]; ok || config.Seasons.Categories["formal"] != nil {
+		t.Errorf("ForgetCategory() left names %v, seasons %v", config.CategoryNames, config.Seasons.Categories)
+	}
+	if !slices.Equal(config.Order.Manual, []string{"casual"}) {
+		t.Errorf("Order.Manual = %v, want [casual]", config.Order.Manual)
+	}
+	selection := config.Selection
+	if _, ok := selection.CategoryPriorities["formal"]; ok || selection.CategoryPriorities["casual"] != 1 {
+		t.Errorf("CategoryPriorities = %v, want only casual", selection.CategoryPriorities)
+	}
+	if _, ok := selection.CategoryRotationPolicies["formal"]; ok || selection.CategoryPickLimits["formal"] != 0 || selection.LockedRotations != nil {
+		t.Errorf("Selection = %+v, want no rotation policy, pick limit or lock for formal", selection)
+	}
+	if !slices.Equal(config.Permissions.Pick, []string{"formal"}) {
+		t.Errorf("Permissions.Pick = %v, want it left alone", config.Permissions.Pick)
+	}
+}
@@ -0,0 +1,20 @@
+package entities
+
+// CategoryRepair is what repairing a category's state from the wardrobe on
+// disk changes. All slices are sorted.
+type CategoryRepair struct {
+	Category string `json:"category"`
+	// Removed is set when the category is no longer on disk, so its
+	// rotation and known outfits are dropped.
+	Removed bool `json:"removed,omitempty"`
+	// TotalBefore and TotalAfter are the outfit counts of the category's
+	// rotation; they are zero when it has no rotation yet.
+	TotalBefore int `json:"totalBefore"`
+	TotalAfter  int `json:"totalAfter"`
+	// DroppedWorn lists the worn outfits whose files are missing.
+	DroppedWorn []string `json:"droppedWorn,omitempty"`
+	// AddedKnown and RemovedKnown list the outfits added to and removed from
+	// the category's known files.
+	AddedKnown   []string `json:"addedKnown,omitempty"`
+	RemovedKnown []string `json:"removedKnown,omitempty"`
+}
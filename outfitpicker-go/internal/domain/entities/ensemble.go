@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// EnsembleSettings sets which categories fill which slot of an ensemble,
+// such as "top", "bottom" or "shoes", and which outfits may be worn
+// together in one.
+type EnsembleSettings struct {
+	// Slots maps category names to the slot they fill, in place of the
+	// slot in their category.json.
+	Slots map[string]string `json:"slots,omitempty"`
+	// TagConflicts are pairs of tags never worn together. A tag paired with
+	// itself is worn by at most one outfit of an ensemble.
+	TagConflicts [][2]string `json:"tagConflicts,omitempty"`
+	// ColorClashes are pairs of colors never worn together. A color paired
+	// with itself is worn by at most one outfit of an ensemble.
+	ColorClashes [][2]string `json:"colorClashes,omitempty"`
+	// MaxColors caps how many different colors an ensemble has; zero means
+	// no cap.
+	MaxColors int `json:"maxColors,omitempty"`
+	// ColorRules name palettes and the colors to avoid together, as
+	// ParseColorRules reads them; they add to ColorClashes.
+	ColorRules []string `json:"colorRules,omitempty"`
+}
+
+// Validate checks the slots and compatibility rules.
+func (s EnsembleSettings) Validate() error {
+	if err := validation.ValidateEnsemble(s.Slots, s.TagConflicts, s.ColorClashes, s.MaxColors); err != nil {
+		return err
+	}
+	_, err := ParseColorRules(s.ColorRules)
+	return err
+}
+
+// WithColorRules returns the settings with the color clashes their color
+// rules add, for the composition of ensembles.
+func (s EnsembleSettings) WithColorRules() (EnsembleSettings, error) {
+	clashes, err := ParseColorRules(s.ColorRules)
+	if err != nil {
+		return EnsembleSettings{}, err
+	}
+	for _, clash := range clashes {
+		s.ColorClashes = AddingRule(s.ColorClashes, clash[0], clash[1])
+	}
+	return s, nil
+}
+
+// SlotOf returns the slot the category fills: the configured one, else
+// the one in its category.json, else "" when it fills none.
+func (s EnsembleSettings) SlotOf(info CategoryInfo) string {
+	if slot, ok := s.Slots[info.Category.Name]; ok {
+		return slot
+	}
+	if info.Description != nil {
+		return info.Description.Slot
+	}
+	return ""
+}
+
+// SettingSlot returns the settings with the category filling slot; an
+// empty slot removes the configured one.
+func (s EnsembleSettings) SettingSlot(category, slot string) EnsembleSettings {
+	slots := make(map[string]string, len(s.Slots)+1)
+	for k, v := range s.Slots {
+		slots[k] = v
+	}
+	if slot == "" {
+		delete(slots, category)
+	} else {
+		slots[category] = slot
+	}
+	if len(slots) == 0 {
+		slots = nil
+	}
+	s.Slots = slots
+	return s
+}
+
+// AddingRule returns rules, tag conflicts or color clashes, with the pair
+// of a and b added unless it is already there either way round.
+func AddingRule(rules [][2]string, a, b string) [][2]string {
+	if slices.Contains(rules, [2]string{a, b}) || slices.Contains(rules, [2]string{b, a}) {
+		return rules
+	}
+	return append(slices.Clone(rules), [2]string{a, b})
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEnsembleSettings_SlotOf(t *testing.T) {
+	shirts := CategoryInfo{Category: CategoryReference{Name: "shirts"}, Description: &CategoryDescription{Slot: "top"}}
+	boots := CategoryInfo{Category: CategoryReference{Name: "boots"}}
+	settings := EnsembleSettings{}.SettingSlot("boots", "shoes")
+
+	if got := settings.SlotOf(shirts); got != "top" {
+		t.Errorf("SlotOf(shirts) = %q, want the category.json slot", got)
+	}
+	if got := settings.SlotOf(boots); got != "shoes" {
+		t.Errorf("SlotOf(boots) = %q, want the configured slot", got)
+	}
+	if got := settings.SettingSlot("shirts", "layer").SlotOf(shirts); got != "layer" {
+		t.Errorf("SlotOf(shirts) = %q, want the configured slot over category.json", got)
+	}
+	if cleared := settings.SettingSlot("boots", ""); cleared.Slots != nil || cleared.SlotOf(boots) != "" {
+		t.Errorf("SettingSlot() clear = %+v, want no slots", cleared)
+	}
+}
+
+func TestAddingRule(t *testing.T) {
+	rules := AddingRule(nil, "formal", "sporty")
+	rules = AddingRule(rules, "sporty", "formal")
+	rules = AddingRule(rules, "pattern", "pattern")
+	if want := [][2]string{{"formal", "sporty"}, {"pattern", "pattern"}}; !slices.Equal(rules, want) {
+		t.Errorf("AddingRule() = %v, want %v", rules, want)
+	}
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+)
+
+// Favorites records the outfits the user has marked as favorites, by
+// category and file name.
+type Favorites struct {
+	Outfits map[string][]string `json:"outfits"`
+	// Revision counts saves of the favorites file and is used to reject
+	// saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewFavorites creates an empty set of favorites.
+func NewFavorites() Favorites {
+	return Favorites{Outfits: make(map[string][]string)}
+}
+
+// Contains reports whether the outfit is a favorite.
+func (f Favorites) Contains(category, fileName string) bool {
+	return slices.Contains(f.Outfits[category], fileName)
+}
+
+// Category returns the favorite file names in a category, sorted.
+func (f Favorites) Category(category string) []string {
+	return f.Outfits[category]
+}
+
+// Categories returns the names of categories with favorites, sorted.
+func (f Favorites) Categories() []string {
+	return slices.Sorted(maps.Keys(f.Outfits))
+}
+
+// Adding returns new favorites that include the outfit.
+func (f Favorites) Adding(category, fileName string) Favorites {
+	if f.Contains(category, fileName) {
+		return f
+	}
+	files := append(slices.Clone(f.Outfits[category]), fileName)
+	slices.Sort(files)
+	return f.with(category, files)
+}
+
+// Removing returns new favorites without the outfit.
+func (f Favorites) Removing(category, fileName string) Favorites {
+	files := slices.DeleteFunc(slices.Clone(f.Outfits[category]), func(name string) bool { return name == fileName })
+	return f.with(category, files)
+}
+
+// Moving returns new favorites with the outfit at from, if a favorite,
+// replaced by the outfit at to.
+func (f Favorites) Moving(from, to OutfitLocation) Favorites {
+	if !f.Contains(from.Category, from.FileName) {
+		return f
+	}
+	return f.Removing(from.Category, from.FileName).Adding(to.Category, to.FileName)
+}
+
+func (f Favorites) with(category string, files []string) Favorites {
+	outfits := maps.Clone(f.Outfits)
+	if outfits == nil {
+		outfits = make(map[string][]string)
+	}
+	if len(files) == 0 {
+		delete(outfits, category)
+	} else {
+		outfits[category] = files
+	}
+	return Favorites{Outfits: outfits, Revision: f.Revision}
+}
+
+// WithoutCategory returns new favorites without category's outfits.
+func (f Favorites) WithoutCategory(category string) Favorites {
+	return f.with(category, nil)
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFavorites_AddingAndRemoving(t *testing.T) {
+	original := NewFavorites()
+
+	updated := original.Adding("casual", "tee.avatar").Adding("casual", "jeans.avatar").Adding("casual", "tee.avatar")
+	if original.Contains("casual", "tee.avatar") {
+		t.Error("Adding() modified the original favorites")
+	}
+	if got, want := updated.Category("casual"), []string{"jeans.avatar", "tee.avatar"}; !slices.Equal(got, want) {
+		t.Errorf("Category() = %v, want %v", got, want)
+	}
+
+	removed := updated.Removing("casual", "tee.avatar")
+	if removed.Contains("casual", "tee.avatar") || !updated.Contains("casual", "tee.avatar") {
+		t.Errorf("Removing() = %v, original = %v", removed.Outfits, updated.Outfits)
+	}
+	if cleared := removed.Removing("casual", "jeans.avatar"); len(cleared.Categories()) != 0 {
+		t.Errorf("removing the last favorite left %v", cleared.Outfits)
+	}
+}
@@ -0,0 +1,41 @@
+package entities
+
+// Default category health thresholds.
+const (
+	DefaultHealthyScore       = 70
+	DefaultCriticalScore      = 40
+	DefaultStaleAfterDays     = 30
+	DefaultTargetRotationDays = 60
+)
+
+// HealthThresholds tunes the category health score. Zero fields use the
+// defaults.
+type HealthThresholds struct {
+	// HealthyScore is the lowest score, out of 100, reported as healthy.
+	HealthyScore int `json:"healthyScore,omitempty"`
+	// CriticalScore is the score below which a category is critical.
+	CriticalScore int `json:"criticalScore,omitempty"`
+	// StaleAfterDays is how long a category can go unworn before it scores
+	// nothing for staleness.
+	StaleAfterDays int `json:"staleAfterDays,omitempty"`
+	// TargetRotationDays is how long a full rotation should take.
+	TargetRotationDays int `json:"targetRotationDays,omitempty"`
+}
+
+// WithDefaults returns the thresholds with zero fields replaced by the
+// defaults.
+func (t HealthThresholds) WithDefaults() HealthThresholds {
+	if t.HealthyScore == 0 {
+		t.HealthyScore = DefaultHealthyScore
+	}
+	if t.CriticalScore == 0 {
+		t.CriticalScore = DefaultCriticalScore
+	}
+	if t.StaleAfterDays == 0 {
+		t.StaleAfterDays = DefaultStaleAfterDays
+	}
+	if t.TargetRotationDays == 0 {
+		t.TargetRotationDays = DefaultTargetRotationDays
+	}
+	return t
+}
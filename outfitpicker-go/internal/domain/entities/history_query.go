@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// History page size limits.
+const (
+	DefaultHistoryLimit = 20
+	MaxHistoryLimit     = 500
+)
+
+// HistoryQuery selects one page of history entries, newest first.
+type HistoryQuery struct {
+	// Category restricts results to one category when not empty.
+	Category string
+	// Since drops entries before this time when not zero.
+	Since time.Time
+	// Limit is the page size; zero means DefaultHistoryLimit.
+	Limit int
+	// Page is the 1-based page number; zero means the first page.
+	Page int
+}
+
+// Normalized returns the query with defaults filled in, or an error if the
+// limit or page is out of range.
+func (q HistoryQuery) Normalized() (HistoryQuery, error) {
+	if q.Limit == 0 {
+		q.Limit = DefaultHistoryLimit
+	}
+	if q.Page == 0 {
+		q.Page = 1
+	}
+	if q.Limit < 1 || q.Limit > MaxHistoryLimit {
+		return q, errors.NewInvalidInputError(fmt.Sprintf("limit must be between 1 and %d", MaxHistoryLimit))
+	}
+	if q.Page < 1 {
+		return q, errors.NewInvalidInputError("page must be 1 or greater")
+	}
+	return q, nil
+}
+
+// Matches reports whether an entry for category at the given time passes the
+// query's filters.
+func (q HistoryQuery) Matches(category string, at time.Time) bool {
+	if q.Category != "" && category != q.Category {
+		return false
+	}
+	return q.Since.IsZero() || !at.Before(q.Since)
+}
+
+// Page is one page of query results.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Total counts every entry that matched, across all pages.
+	Total int `json:"total"`
+}
+
+// PageCount returns the number of pages needed for every matching entry.
+func (p Page[T]) PageCount() int {
+	if p.Limit == 0 {
+		return 0
+	}
+	return (p.Total + p.Limit - 1) / p.Limit
+}
+
+// HasMore reports whether later pages hold more entries.
+func (p Page[T]) HasMore() bool {
+	return p.Page < p.PageCount()
+}
+
+// pageCollector gathers the entries of one page from a stream of matches
+// while counting the total.
+type pageCollector[T any] struct {
+	page Page[T]
+	skip int
+}
+
+func newPageCollector[T any](query HistoryQuery) *pageCollector[T] {
+	return &pageCollector[T]{
+		page: Page[T]{Items: []T{}, Page: query.Page, Limit: query.Limit},
+		skip: (query.Page - 1) * query.Limit,
+	}
+}
+
+func (c *pageCollector[T]) add(item T) {
+	if c.page.Total >= c.skip && len(c.page.Items) < c.page.Limit {
+		c.page.Items = append(c.page.Items, item)
+	}
+	c.page.Total++
+}
@@ -0,0 +1,10 @@
+package entities
+
+// IntegrityStatus reports whether state files are signed and which of them
+// no longer match their signature.
+type IntegrityStatus struct {
+	Enabled bool `json:"enabled"`
+	// Failed lists the state files, relative to the app directory, whose
+	// signature is missing or does not match.
+	Failed []string `json:"failed"`
+}
@@ -0,0 +1,57 @@
+package entities
+
+import (
+	"maps"
+	"time"
+)
+
+// LatestPicksSchema is the version of the latest picks file's layout. It
+// changes only when a field is removed or changes meaning; fields may be
+// added within a version.
+const LatestPicksSchema = 1
+
+// LatestPick is the last outfit picked from a category.
+type LatestPick struct {
+	FileName string `json:"fileName"`
+	// Path is where the outfit file was when it was picked.
+	Path     string    `json:"path"`
+	PickedAt time.Time `json:"pickedAt"`
+	// OutfitID is the outfit's stable ID when outfit IDs are enabled.
+	OutfitID string `json:"outfitId,omitempty"`
+}
+
+// LatestPicks holds the last pick of every category, kept in a file of its
+// own for status bars and widgets to read without running outfitpicker.
+type LatestPicks struct {
+	Schema     int                   `json:"schema"`
+	UpdatedAt  time.Time             `json:"updatedAt"`
+	Categories map[string]LatestPick `json:"categories"`
+	// Revision counts saves of the latest picks file and is used to reject
+	// saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewLatestPicks creates a record with no picks.
+func NewLatestPicks() LatestPicks {
+	return LatestPicks{Schema: LatestPicksSchema, Categories: make(map[string]LatestPick)}
+}
+
+// Recording returns new latest picks with pick as the last of category.
+func (p LatestPicks) Recording(category string, pick LatestPick) LatestPicks {
+	categories := maps.Clone(p.Categories)
+	if categories == nil {
+		categories = make(map[string]LatestPick)
+	}
+	categories[category] = pick
+	p.Categories = categories
+	p.Schema = LatestPicksSchema
+	p.UpdatedAt = pick.PickedAt
+	return p
+}
+
+// WithoutCategory returns new latest picks without the pick of category.
+func (p LatestPicks) WithoutCategory(category string) LatestPicks {
+	p.Categories = maps.Clone(p.Categories)
+	delete(p.Categories, category)
+	return p
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatestPicks_Recording(t *testing.T) {
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	picks := NewLatestPicks().Recording("casual", LatestPick{FileName: "tee.avatar", PickedAt: first})
+	updated := picks.Recording("casual", LatestPick{FileName: "jeans.avatar", PickedAt: first.Add(time.Hour)})
+
+	if got := picks.Categories["casual"].FileName; got != "tee.avatar" || len(picks.Categories) != 1 {
+		t.Errorf("original picks changed: %v", picks.Categories)
+	}
+	if got := updated.Categories["casual"].FileName; got != "jeans.avatar" || !updated.UpdatedAt.Equal(first.Add(time.Hour)) {
+		t.Errorf("updated = %+v, want jeans.avatar picked an hour later", updated)
+	}
+	if got := (LatestPicks{}).Recording("work", LatestPick{PickedAt: first}); got.Schema != LatestPicksSchema || len(got.Categories) != 1 {
+		t.Errorf("recording into zero picks = %+v", got)
+	}
+}
+
+func TestLatestPicks_WithoutCategory(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	picks := NewLatestPicks().
+		Recording("casual", LatestPick{FileName: "tee.avatar", PickedAt: at}).
+		Recording("work", LatestPick{FileName: "suit.avatar", PickedAt: at})
+
+	updated := picks.WithoutCategory("casual")
+	if _, ok := updated.Categories["casual"]; ok || len(updated.Categories) != 1 {
+		t.Errorf("WithoutCategory() = %v, want only work", updated.Categories)
+	}
+	if len(picks.Categories) != 2 {
+		t.Errorf("original picks changed: %v", picks.Categories)
+	}
+}
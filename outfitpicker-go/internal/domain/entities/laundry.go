@@ -0,0 +1,144 @@
+package entities
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// LaundryState is where an outfit is in the laundry cycle.
+type LaundryState string
+
+// Laundry states. An outfit goes from clean to worn when it is worn, from
+// worn into the laundry when it is washed, and back to clean when the wash
+// is done.
+const (
+	LaundryClean  LaundryState = "clean"
+	LaundryWorn   LaundryState = "worn"
+	LaundryInWash LaundryState = "in-laundry"
+)
+
+// laundryTransitions lists the states an outfit may move to from each
+// state. Any outfit can be worn, even one taken out of the laundry early.
+var laundryTransitions = map[LaundryState][]LaundryState{
+	LaundryClean:  {LaundryWorn},
+	LaundryWorn:   {LaundryWorn, LaundryInWash},
+	LaundryInWash: {LaundryWorn, LaundryClean},
+}
+
+// LaundryOutfit is an outfit and its laundry state.
+type LaundryOutfit struct {
+	Category string       `json:"category"`
+	FileName string       `json:"fileName"`
+	State    LaundryState `json:"state"`
+}
+
+// Laundry tracks which outfits are clean, worn or in the laundry, by
+// category and file name. Outfits without a state are clean. While
+// tracking is enabled only clean outfits are picked.
+type Laundry struct {
+	Enabled bool                               `json:"enabled"`
+	Outfits map[string]map[string]LaundryState `json:"outfits"`
+	// Revision counts saves of the laundry file and is used to reject
+	// saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewLaundry creates laundry tracking that is off, with every outfit clean.
+func NewLaundry() Laundry {
+	return Laundry{Outfits: make(map[string]map[string]LaundryState)}
+}
+
+// State returns the laundry state of the outfit.
+func (l Laundry) State(category, fileName string) LaundryState {
+	if state, ok := l.Outfits[category][fileName]; ok {
+		return state
+	}
+	return LaundryClean
+}
+
+// Unavailable returns the file names in a category that are not clean.
+func (l Laundry) Unavailable(category string) map[string]bool {
+	unavailable := make(map[string]bool, len(l.Outfits[category]))
+	for fileName := range l.Outfits[category] {
+		unavailable[fileName] = true
+	}
+	return unavailable
+}
+
+// InState returns the outfits in the given state, sorted by category and
+// file name. Clean outfits are not tracked, so none are returned for
+// LaundryClean.
+func (l Laundry) InState(state LaundryState) []LaundryOutfit {
+	var outfits []LaundryOutfit
+	for _, category := range slices.Sorted(maps.Keys(l.Outfits)) {
+		for _, fileName := range slices.Sorted(maps.Keys(l.Outfits[category])) {
+			if l.Outfits[category][fileName] == state {
+				outfits = append(outfits, LaundryOutfit{Category: category, FileName: fileName, State: state})
+			}
+		}
+	}
+	return outfits
+}
+
+// Transition returns new laundry with the outfit moved to the given state.
+// An InvalidInputError is returned when the outfit cannot move there from
+// its current state, such as a clean outfit going into the laundry.
+func (l Laundry) Transition(category, fileName string, to LaundryState) (Laundry, error) {
+	from := l.State(category, fileName)
+	if !slices.Contains(laundryTransitions[from], to) {
+		return l, errors.NewInvalidInputError(fmt.Sprintf("%s/%s is %s and cannot become %s", category, fileName, from, to))
+	}
+	return l.with(category, fileName, to), nil
+}
+
+// Wearing returns new laundry with the outfit worn, whatever its state.
+func (l Laundry) Wearing(category, fileName string) Laundry {
+	return l.with(category, fileName, LaundryWorn)
+}
+
+// Moving returns new laundry with the state of the outfit at from, if it
+// is not clean, moved to the outfit at to.
+func (l Laundry) Moving(from, to OutfitLocation) Laundry {
+	state := l.State(from.Category, from.FileName)
+	if state == LaundryClean {
+		return l
+	}
+	return l.with(from.Category, from.FileName, LaundryClean).with(to.Category, to.FileName, state)
+}
+
+// Clearing returns new laundry with every outfit clean.
+func (l Laundry) Clearing() Laundry {
+	return Laundry{Enabled: l.Enabled, Outfits: make(map[string]map[string]LaundryState), Revision: l.Revision}
+}
+
+func (l Laundry) with(category, fileName string, state LaundryState) Laundry {
+	outfits := maps.Clone(l.Outfits)
+	if outfits == nil {
+		outfits = make(map[string]map[string]LaundryState)
+	}
+	files := maps.Clone(outfits[category])
+	if files == nil {
+		files = make(map[string]LaundryState)
+	}
+	if state == LaundryClean {
+		delete(files, fileName)
+	} else {
+		files[fileName] = state
+	}
+	if len(files) == 0 {
+		delete(outfits, category)
+	} else {
+		outfits[category] = files
+	}
+	return Laundry{Enabled: l.Enabled, Outfits: outfits, Revision: l.Revision}
+}
+
+// WithoutCategory returns new laundry without category's outfits.
+func (l Laundry) WithoutCategory(category string) Laundry {
+	l.Outfits = maps.Clone(l.Outfits)
+	delete(l.Outfits, category)
+	return l
+}
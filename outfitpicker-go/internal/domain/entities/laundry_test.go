@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestLaundry_Transitions(t *testing.T) {
+	original := NewLaundry()
+	if got := original.State("casual", "tee.avatar"); got != LaundryClean {
+		t.Fatalf("State() of an untracked outfit = %q, want clean", got)
+	}
+
+	worn := original.Wearing("casual", "tee.avatar")
+	if original.State("casual", "tee.avatar") != LaundryClean {
+		t.Error("Wearing() modified the original laundry")
+	}
+	washing, err := worn.Transition("casual", "tee.avatar", LaundryInWash)
+	if err != nil || washing.State("casual", "tee.avatar") != LaundryInWash {
+		t.Fatalf("Transition() to in-laundry = %v, %v", washing.Outfits, err)
+	}
+	if !washing.Unavailable("casual")["tee.avatar"] {
+		t.Error("an outfit in the laundry is available")
+	}
+	clean, err := washing.Transition("casual", "tee.avatar", LaundryClean)
+	if err != nil || len(clean.Outfits) != 0 {
+		t.Fatalf("Transition() to clean = %v, %v; want nothing tracked", clean.Outfits, err)
+	}
+
+	var invalid *domainerrors.InvalidInputError
+	tests := []struct {
+		name    string
+		laundry Laundry
+		to      LaundryState
+	}{
+		{"wash a clean outfit", clean, LaundryInWash},
+		{"finish washing a worn outfit", worn, LaundryClean},
+		{"clean a clean outfit", clean, LaundryClean},
+	}
+	for _, tt := range tests {
+		if _, err := tt.laundry.Transition("casual", "tee.avatar", tt.to); !errors.As(err, &invalid) {
+			t.Errorf("%s: error = %v, want InvalidInputError", tt.name, err)
+		}
+	}
+}
+
+func TestLaundry_InStateAndMoving(t *testing.T) {
+	laundry := NewLaundry().Wearing("casual", "tee.avatar").Wearing("casual", "jeans.avatar")
+	laundry, err := laundry.Transition("casual", "jeans.avatar", LaundryInWash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := laundry.InState(LaundryWorn); len(got) != 1 || got[0].FileName != "tee.avatar" {
+		t.Errorf("InState(worn) = %+v", got)
+	}
+
+	moved := laundry.Moving(OutfitLocation{Category: "casual", FileName: "jeans.avatar"}, OutfitLocation{Category: "work", FileName: "chinos.avatar"})
+	if moved.State("casual", "jeans.avatar") != LaundryClean || moved.State("work", "chinos.avatar") != LaundryInWash {
+		t.Errorf("Moving() = %v, want the state to follow the outfit", moved.Outfits)
+	}
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// WardrobeSnapshot maps category names to the outfit file names they contain.
+type WardrobeSnapshot map[string][]string
+
+// MaintenanceState records whether the wardrobe is locked for reorganization.
+type MaintenanceState struct {
+	Enabled   bool             `json:"enabled"`
+	StartedAt time.Time        `json:"startedAt"`
+	Snapshot  WardrobeSnapshot `json:"snapshot,omitempty"`
+}
+
+// NewMaintenanceState creates an enabled maintenance state holding the
+// wardrobe as it looked when maintenance began.
+func NewMaintenanceState(snapshot WardrobeSnapshot, startedAt time.Time) MaintenanceState {
+	return MaintenanceState{
+		Enabled:   true,
+		StartedAt: startedAt,
+		Snapshot:  snapshot,
+	}
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewMaintenanceState(t *testing.T) {
+	startedAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	snapshot := WardrobeSnapshot{"casual": {"tee.avatar"}}
+
+	state := NewMaintenanceState(snapshot, startedAt)
+
+	if !state.Enabled {
+		t.Error("Enabled = false, want true")
+	}
+	if !state.StartedAt.Equal(startedAt) {
+		t.Errorf("StartedAt = %v, want %v", state.StartedAt, startedAt)
+	}
+	if len(state.Snapshot["casual"]) != 1 {
+		t.Errorf("Snapshot = %v, want casual with one outfit", state.Snapshot)
+	}
+}
+
+func TestMaintenanceState_JSONMarshaling(t *testing.T) {
+	state := NewMaintenanceState(WardrobeSnapshot{"work": {"suit.avatar", "tie.avatar"}}, time.Now().UTC())
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var unmarshaled MaintenanceState
+	if err := json.Unmarshal(data, &unmarshaled); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !unmarshaled.Enabled || len(unmarshaled.Snapshot["work"]) != 2 {
+		t.Errorf("round-trip failed: got %+v", unmarshaled)
+	}
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// Metadata import fields, the details a column of an imported spreadsheet
+// can hold.
+const (
+	ImportFieldCategory = "category"
+	ImportFieldOutfit   = "outfit"
+	ImportFieldTags     = "tags"
+	ImportFieldWeight   = "weight"
+	ImportFieldRating   = "rating"
+)
+
+// ImportFields returns the metadata import fields.
+func ImportFields() []string {
+	return []string{ImportFieldCategory, ImportFieldOutfit, ImportFieldTags, ImportFieldWeight, ImportFieldRating}
+}
+
+// MetadataImportRow is one row of an imported spreadsheet: the metadata to
+// give an outfit. Empty fields leave what the outfit has.
+type MetadataImportRow struct {
+	// Line is the row's line in the file, for messages.
+	Line     int    `json:"line"`
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	// Tags are added to the outfit's own.
+	Tags   []string `json:"tags,omitempty"`
+	Weight *float64 `json:"weight,omitempty"`
+	// Rating is from MinRating to MaxRating; zero leaves the rating.
+	Rating int `json:"rating,omitempty"`
+}
+
+// Validate checks the row's tags, weight and rating.
+func (r MetadataImportRow) Validate() error {
+	if err := validation.ValidateTags(r.Tags); err != nil {
+		return err
+	}
+	if r.Weight != nil {
+		if err := validation.ValidateOutfitWeight(*r.Weight); err != nil {
+			return err
+		}
+	}
+	if r.Rating != 0 && (r.Rating < MinRating || r.Rating > MaxRating) {
+		return errors.NewInvalidInputError(fmt.Sprintf("rating must be between %d and %d, got %d", MinRating, MaxRating, r.Rating))
+	}
+	return nil
+}
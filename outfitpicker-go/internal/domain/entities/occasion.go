@@ -0,0 +1,29 @@
+package entities
+
+// Occasion is a kind of day, such as "interview" or "date-night", that
+// outfits can be picked for: from its categories, or any category when it
+// has none, and among the outfits with any of its tags, or any outfit when
+// it has none.
+type Occasion struct {
+	Categories []string `json:"categories,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// IsEmpty reports whether the occasion has neither categories nor tags.
+func (o Occasion) IsEmpty() bool {
+	return len(o.Categories) == 0 && len(o.Tags) == 0
+}
+
+// Target returns the selection target of picks for the occasion, given the
+// references of its categories: every category when it has none, else its
+// only category or its set of categories.
+func (o Occasion) Target(categories []CategoryReference) SelectionTarget {
+	switch len(categories) {
+	case 0:
+		return SelectionTargetAllCategories{}
+	case 1:
+		return SelectionTargetCategory{Category: categories[0]}
+	default:
+		return SelectionTargetCategories{Categories: categories}
+	}
+}
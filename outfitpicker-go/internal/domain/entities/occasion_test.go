@@ -0,0 +1,47 @@
+package entities
+
+import "testing"
+
+func TestConfig_SetOccasion(t *testing.T) {
+	config, err := NewConfig("/home/user/outfits", nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interview := Occasion{Categories: []string{"work"}, Tags: []string{"smart"}}
+	if err := config.SetOccasion("interview", interview); err != nil {
+		t.Fatalf("SetOccasion() error = %v", err)
+	}
+	if got := config.Occasions["interview"]; len(got.Categories) != 1 || got.Tags[0] != "smart" {
+		t.Errorf("Occasions[interview] = %+v", got)
+	}
+
+	if err := config.SetOccasion("interview", Occasion{Tags: []string{"Smart"}}); err == nil {
+		t.Error("SetOccasion() accepted an invalid tag")
+	}
+	if config.Occasions["interview"].Categories[0] != "work" {
+		t.Error("invalid SetOccasion() replaced the existing occasion")
+	}
+
+	if err := config.SetOccasion("interview", Occasion{}); err != nil {
+		t.Fatalf("SetOccasion() clear error = %v", err)
+	}
+	if _, ok := config.Occasions["interview"]; ok {
+		t.Error("empty occasion was not removed")
+	}
+}
+
+func TestOccasion_Target(t *testing.T) {
+	work := CategoryReference{Name: "work", Path: "/outfits/work"}
+	formal := CategoryReference{Name: "formal", Path: "/outfits/formal"}
+
+	if _, ok := (Occasion{}).Target(nil).(SelectionTargetAllCategories); !ok {
+		t.Error("Target() without categories is not every category")
+	}
+	if target, ok := (Occasion{}).Target([]CategoryReference{work}).(SelectionTargetCategory); !ok || target.Category != work {
+		t.Errorf("Target() of one category = %#v", target)
+	}
+	if target, ok := (Occasion{}).Target([]CategoryReference{work, formal}).(SelectionTargetCategories); !ok || len(target.Categories) != 2 {
+		t.Errorf("Target() of two categories = %#v", target)
+	}
+}
@@ -0,0 +1,153 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// OutfitArrivals records when each outfit was first and last seen in its
+// category, by category and file name.
+type OutfitArrivals struct {
+	Outfits  map[string]map[string]time.Time `json:"outfits"`
+	LastSeen map[string]map[string]time.Time `json:"lastSeen,omitempty"`
+	// Revision counts saves of the arrivals file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// OutfitSighting is when an outfit was first and last seen on disk. A zero
+// FirstSeen means the outfit was there before its category was tracked.
+type OutfitSighting struct {
+	Category  string    `json:"category"`
+	FileName  string    `json:"fileName"`
+	FirstSeen time.Time `json:"firstSeen,omitzero"`
+	LastSeen  time.Time `json:"lastSeen,omitzero"`
+	Present   bool      `json:"present"`
+}
+
+// NewOutfitArrivals creates arrivals with nothing recorded.
+func NewOutfitArrivals() OutfitArrivals {
+	return OutfitArrivals{
+		Outfits:  make(map[string]map[string]time.Time),
+		LastSeen: make(map[string]map[string]time.Time),
+	}
+}
+
+// Category returns when the outfits of a category were first seen, keyed by
+// file name. Outfits that were already there when the category was first
+// scanned have the zero time.
+func (a OutfitArrivals) Category(category string) map[string]time.Time {
+	return a.Outfits[category]
+}
+
+// Sightings returns every outfit recorded in category, ordered by file name.
+// Outfits named in present are marked present; the rest have gone from disk
+// since they were last seen.
+func (a OutfitArrivals) Sightings(category string, present []string) []OutfitSighting {
+	sightings := make([]OutfitSighting, 0, len(a.Outfits[category]))
+	for file, firstSeen := range a.Outfits[category] {
+		sightings = append(sightings, OutfitSighting{
+			Category:  category,
+			FileName:  file,
+			FirstSeen: firstSeen,
+			LastSeen:  a.LastSeen[category][file],
+			Present:   slices.Contains(present, file),
+		})
+	}
+	slices.SortFunc(sightings, func(x, y OutfitSighting) int {
+		return strings.Compare(x.FileName, y.FileName)
+	})
+	return sightings
+}
+
+// Recording returns arrivals updated for a scan of category at now that found
+// files, and whether anything changed. New files are recorded as first seen
+// at now, except on the category's first scan, whose files are recorded with
+// the zero time so only later additions count as new. Every file found is
+// last seen at now. Files that are gone keep when they were first and last
+// seen, so an outfit that comes back is not new again.
+func (a OutfitArrivals) Recording(category string, files []string, now time.Time) (OutfitArrivals, bool) {
+	current, tracked := a.Outfits[category]
+	seen := now
+	if !tracked {
+		seen = time.Time{}
+	}
+
+	firstSeen := maps.Clone(current)
+	if firstSeen == nil {
+		firstSeen = make(map[string]time.Time, len(files))
+	}
+	lastSeen := maps.Clone(a.LastSeen[category])
+	if lastSeen == nil {
+		lastSeen = make(map[string]time.Time, len(files))
+	}
+	for _, file := range files {
+		if _, ok := firstSeen[file]; !ok {
+			firstSeen[file] = seen
+		}
+		lastSeen[file] = now
+	}
+	if tracked && maps.EqualFunc(current, firstSeen, time.Time.Equal) && maps.EqualFunc(a.LastSeen[category], lastSeen, time.Time.Equal) {
+		return a, false
+	}
+
+	outfits := maps.Clone(a.Outfits)
+	if outfits == nil {
+		outfits = make(map[string]map[string]time.Time, 1)
+	}
+	outfits[category] = firstSeen
+	lastSeenByCategory := maps.Clone(a.LastSeen)
+	if lastSeenByCategory == nil {
+		lastSeenByCategory = make(map[string]map[string]time.Time, 1)
+	}
+	lastSeenByCategory[category] = lastSeen
+	return OutfitArrivals{Outfits: outfits, LastSeen: lastSeenByCategory, Revision: a.Revision}, true
+}
+
+// Moving returns arrivals with when the outfit at from was first and last
+// seen moved to the outfit at to, so a renamed or moved outfit is not taken
+// for a new one.
+func (a OutfitArrivals) Moving(from, to OutfitLocation) OutfitArrivals {
+	firstSeen, ok := a.Outfits[from.Category][from.FileName]
+	if !ok {
+		return a
+	}
+	lastSeen, seen := a.LastSeen[from.Category][from.FileName]
+	outfits := moveTime(a.Outfits, from, to, firstSeen, true)
+	lastSeenByCategory := moveTime(a.LastSeen, from, to, lastSeen, seen)
+	return OutfitArrivals{Outfits: outfits, LastSeen: lastSeenByCategory, Revision: a.Revision}
+}
+
+// moveTime returns a copy of times without from, and with to set to t when
+// set is true.
+func moveTime(times map[string]map[string]time.Time, from, to OutfitLocation, t time.Time, set bool) map[string]map[string]time.Time {
+	moved := maps.Clone(times)
+	if moved == nil {
+		moved = make(map[string]map[string]time.Time, 1)
+	}
+	source := maps.Clone(moved[from.Category])
+	delete(source, from.FileName)
+	moved[from.Category] = source
+	if set {
+		target := maps.Clone(moved[to.Category])
+		if target == nil {
+			target = make(map[string]time.Time, 1)
+		}
+		target[to.FileName] = t
+		moved[to.Category] = target
+	}
+	return moved
+}
+
+// WithoutCategory returns new arrivals without category's outfits.
+func (a OutfitArrivals) WithoutCategory(category string) OutfitArrivals {
+	a.Outfits = maps.Clone(a.Outfits)
+	delete(a.Outfits, category)
+	if a.LastSeen != nil {
+		a.LastSeen = maps.Clone(a.LastSeen)
+		delete(a.LastSeen, category)
+	}
+	return a
+}
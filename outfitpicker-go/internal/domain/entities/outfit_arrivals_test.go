@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutfitArrivals_Recording(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	baseline, changed := NewOutfitArrivals().Recording("casual", []string{"tee.avatar"}, day)
+	if !changed || !baseline.Category("casual")["tee.avatar"].IsZero() {
+		t.Fatalf("first scan = %+v, %v; want the existing outfit recorded with the zero time", baseline, changed)
+	}
+
+	later := day.AddDate(0, 0, 3)
+	updated, changed := baseline.Recording("casual", []string{"tee.avatar", "coat.avatar"}, later)
+	if !changed || !updated.Category("casual")["coat.avatar"].Equal(later) || !updated.Category("casual")["tee.avatar"].IsZero() {
+		t.Errorf("second scan = %+v, %v; want only coat.avatar first seen now", updated, changed)
+	}
+	if _, ok := baseline.Category("casual")["coat.avatar"]; ok {
+		t.Error("Recording() modified the original arrivals")
+	}
+
+	if _, changed := updated.Recording("casual", []string{"coat.avatar", "tee.avatar"}, later); changed {
+		t.Error("a scan finding the same outfits at the same time reported a change")
+	}
+	next := later.AddDate(0, 0, 1)
+	removed, changed := updated.Recording("casual", []string{"coat.avatar"}, next)
+	if !changed || !removed.LastSeen["casual"]["coat.avatar"].Equal(next) || !removed.LastSeen["casual"]["tee.avatar"].Equal(later) {
+		t.Errorf("scan after a removal = %+v, %v; want coat.avatar last seen now and tee.avatar kept", removed, changed)
+	}
+	empty, _ := removed.Recording("casual", nil, next)
+	readded, _ := empty.Recording("casual", []string{"tee.avatar", "shirt.avatar"}, next)
+	if !readded.Category("casual")["tee.avatar"].IsZero() {
+		t.Error("an outfit that came back was recorded as a new arrival")
+	}
+	if readded.Category("casual")["shirt.avatar"].IsZero() {
+		t.Error("an outfit added to an emptied category was treated as a first scan")
+	}
+}
+
+func TestOutfitArrivals_Sightings(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	arrivals, _ := NewOutfitArrivals().Recording("casual", []string{"tee.avatar", "coat.avatar"}, day)
+	arrivals, _ = arrivals.Recording("casual", []string{"tee.avatar", "shirt.avatar"}, day.AddDate(0, 0, 2))
+
+	want := []OutfitSighting{
+		{Category: "casual", FileName: "coat.avatar", LastSeen: day},
+		{Category: "casual", FileName: "shirt.avatar", FirstSeen: day.AddDate(0, 0, 2), LastSeen: day.AddDate(0, 0, 2), Present: true},
+		{Category: "casual", FileName: "tee.avatar", LastSeen: day.AddDate(0, 0, 2), Present: true},
+	}
+	got := arrivals.Sightings("casual", []string{"tee.avatar", "shirt.avatar"})
+	if len(got) != len(want) {
+		t.Fatalf("Sightings() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].FileName != want[i].FileName || !got[i].FirstSeen.Equal(want[i].FirstSeen) || !got[i].LastSeen.Equal(want[i].LastSeen) || got[i].Present != want[i].Present {
+			t.Errorf("Sightings()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got := arrivals.Sightings("formal", nil); len(got) != 0 {
+		t.Errorf("Sightings() of an untracked category = %+v, want none", got)
+	}
+}
+
+func TestOutfitArrivals_WithoutCategory(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	arrivals := OutfitArrivals{
+		Outfits:  map[string]map[string]time.Time{"formal": {"suit.avatar": day}, "casual": {"tee.avatar": day}},
+		LastSeen: map[string]map[string]time.Time{"formal": {"suit.avatar": day}},
+	}
+
+	removed := arrivals.WithoutCategory("formal")
+	if _, ok := removed.Outfits["formal"]; ok || removed.LastSeen["formal"] != nil || removed.Outfits["casual"] == nil {
+		t.Errorf("WithoutCategory() = %+v, want only casual left", removed)
+	}
+	if arrivals.Outfits["formal"] == nil {
+		t.Error("WithoutCategory() modified the original arrivals")
+	}
+}
@@ -0,0 +1,90 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Outfit ID schemes.
+const (
+	// OutfitIDSchemeUUID gives each outfit a random ID.
+	OutfitIDSchemeUUID = "uuid"
+	// OutfitIDSchemeHash derives an outfit's ID from its content when it is
+	// first seen.
+	OutfitIDSchemeHash = "hash"
+)
+
+// OutfitIDSettings configures stable outfit IDs.
+type OutfitIDSettings struct {
+	// Scheme is how IDs are made for outfits seen for the first time; empty
+	// means outfits have no IDs.
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Enabled reports whether outfits have IDs.
+func (s OutfitIDSettings) Enabled() bool {
+	return s.Scheme != ""
+}
+
+// OutfitIdentity is the stable ID of an outfit and the fingerprint of its
+// content when it was last seen. An ID never changes once assigned, even
+// when the file is edited; the fingerprint recognizes the outfit after it is
+// renamed or moved to another category.
+type OutfitIdentity struct {
+	ID          string `json:"id"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// OutfitLocation is where an outfit file is: its category and file name.
+type OutfitLocation struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+}
+
+// String returns the location as category/file.
+func (l OutfitLocation) String() string {
+	return l.Category + "/" + l.FileName
+}
+
+// OutfitMove is an outfit found under a new file name or in another
+// category than it was last seen in.
+type OutfitMove struct {
+	ID   string         `json:"id"`
+	From OutfitLocation `json:"from"`
+	To   OutfitLocation `json:"to"`
+}
+
+// OutfitID returns the ID of the outfit at category/fileName.
+func (m MetadataIndex) OutfitID(category, fileName string) (string, bool) {
+	identity, ok := m.IDs[category][fileName]
+	return identity.ID, ok
+}
+
+// LocateOutfit returns where the outfit with the given ID is. A unique
+// prefix of an ID is accepted too.
+func (m MetadataIndex) LocateOutfit(id string) (string, OutfitLocation, bool) {
+	var found []OutfitLocation
+	var foundID string
+	for _, category := range slices.Sorted(maps.Keys(m.IDs)) {
+		for fileName, identity := range m.IDs[category] {
+			if identity.ID == id {
+				return id, OutfitLocation{Category: category, FileName: fileName}, true
+			}
+			if id != "" && strings.HasPrefix(identity.ID, id) {
+				found = append(found, OutfitLocation{Category: category, FileName: fileName})
+				foundID = identity.ID
+			}
+		}
+	}
+	if len(found) != 1 {
+		return "", OutfitLocation{}, false
+	}
+	return foundID, found[0], true
+}
+
+// WithIDs returns the index with the outfit IDs replaced.
+func (m MetadataIndex) WithIDs(ids map[string]map[string]OutfitIdentity) MetadataIndex {
+	m.IDs = ids
+	return m
+}
@@ -0,0 +1,219 @@
+package entities
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// Outfit ratings. A rating of 0 means the outfit is unrated.
+const (
+	MinRating = 1
+	MaxRating = 5
+	// NeutralRating is how the rated strategy counts an unrated outfit.
+	NeutralRating = 3
+)
+
+// MaterialComponent is one fiber in an outfit's material composition.
+type MaterialComponent struct {
+	Fiber   string `json:"fiber"`
+	Percent int    `json:"percent"`
+}
+
+// OutfitMetadata holds user-supplied details about an outfit.
+type OutfitMetadata struct {
+	Materials []MaterialComponent `json:"materials,omitempty"`
+	// Care lists care-label symbols such as "wash-40" or "do-not-tumble-dry".
+	Care []string `json:"care,omitempty"`
+	// Price is what the outfit cost, in the user's currency. Zero means
+	// unknown.
+	Price float64 `json:"price,omitempty"`
+	// Tags are free-form lowercase labels such as "summer" or "date-night".
+	Tags []string `json:"tags,omitempty"`
+	// Rating is how much the user likes the outfit, from MinRating to
+	// MaxRating. Zero means unrated.
+	Rating int `json:"rating,omitempty"`
+	// Colors are the outfit's main colors, such as "navy", which ensemble
+	// compatibility rules look at.
+	Colors []string `json:"colors,omitempty"`
+}
+
+// IsEmpty reports whether no metadata is set.
+func (m OutfitMetadata) IsEmpty() bool {
+	return len(m.Materials) == 0 && len(m.Care) == 0 && m.Price == 0 && len(m.Tags) == 0 && m.Rating == 0 && len(m.Colors) == 0
+}
+
+// HasTag reports whether the outfit is tagged with tag.
+func (m OutfitMetadata) HasTag(tag string) bool {
+	return slices.Contains(m.Tags, tag)
+}
+
+// AddingTags returns the metadata with tags added after the ones it already
+// has. Tags it already has are not repeated.
+func (m OutfitMetadata) AddingTags(tags []string) OutfitMetadata {
+	m.Tags = slices.Clone(m.Tags)
+	for _, tag := range tags {
+		if !m.HasTag(tag) {
+			m.Tags = append(m.Tags, tag)
+		}
+	}
+	return m
+}
+
+// RemovingTags returns the metadata without tags.
+func (m OutfitMetadata) RemovingTags(tags []string) OutfitMetadata {
+	m.Tags = slices.DeleteFunc(slices.Clone(m.Tags), func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	if len(m.Tags) == 0 {
+		m.Tags = nil
+	}
+	return m
+}
+
+// Shareable returns the metadata without personal details, for outfits
+// shared with others. Only the price is dropped.
+func (m OutfitMetadata) Shareable() OutfitMetadata {
+	m.Price = 0
+	return m
+}
+
+// DominantFiber returns the fiber with the largest share, or "" when no
+// materials are recorded.
+func (m OutfitMetadata) DominantFiber() string {
+	dominant := ""
+	best := 0
+	for _, component := range m.Materials {
+		if component.Percent > best {
+			dominant, best = component.Fiber, component.Percent
+		}
+	}
+	return dominant
+}
+
+// MetadataIndex maps category names to the metadata of their outfits, keyed
+// by file name.
+type MetadataIndex struct {
+	Outfits map[string]map[string]OutfitMetadata `json:"outfits"`
+	// IDs maps category names to the identities of their outfits, keyed by
+	// file name, when outfit IDs are enabled.
+	IDs map[string]map[string]OutfitIdentity `json:"ids,omitempty"`
+	// Revision counts saves of the metadata file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+	// CategoryTags maps category names to the tags every outfit of the
+	// category carries, from the category's description. They are not
+	// saved with the index.
+	CategoryTags map[string][]string `json:"-"`
+	// Sidecars maps category names to the sidecar files of their outfits,
+	// keyed by file name. They are read from the wardrobe, not saved with
+	// the index.
+	Sidecars map[string]map[string]OutfitSidecar `json:"-"`
+}
+
+// NewMetadataIndex creates an empty metadata index.
+func NewMetadataIndex() MetadataIndex {
+	return MetadataIndex{Outfits: make(map[string]map[string]OutfitMetadata)}
+}
+
+// Get returns the metadata recorded for an outfit.
+func (m MetadataIndex) Get(category, fileName string) (OutfitMetadata, bool) {
+	metadata, ok := m.Outfits[category][fileName]
+	return metadata, ok
+}
+
+// Setting returns a new index with the outfit's metadata replaced. Empty
+// metadata removes the outfit's entry.
+func (m MetadataIndex) Setting(category, fileName string, metadata OutfitMetadata) MetadataIndex {
+	outfits := make(map[string]map[string]OutfitMetadata, len(m.Outfits)+1)
+	for k, v := range m.Outfits {
+		outfits[k] = v
+	}
+
+	files := make(map[string]OutfitMetadata, len(outfits[category])+1)
+	for k, v := range outfits[category] {
+		files[k] = v
+	}
+	if metadata.IsEmpty() {
+		delete(files, fileName)
+	} else {
+		files[fileName] = metadata
+	}
+
+	if len(files) == 0 {
+		delete(outfits, category)
+	} else {
+		outfits[category] = files
+	}
+	m.Outfits = outfits
+	return m
+}
+
+// Moving returns a new index with the metadata of the outfit at from moved
+// to the outfit at to.
+func (m MetadataIndex) Moving(from, to OutfitLocation) MetadataIndex {
+	metadata, ok := m.Get(from.Category, from.FileName)
+	if !ok {
+		return m
+	}
+	return m.Setting(from.Category, from.FileName, OutfitMetadata{}).Setting(to.Category, to.FileName, metadata)
+}
+
+// Validate checks that every fiber and care symbol is recognized, that the
+// material composition adds up to 100%, that the price is not negative,
+// that the tags are well formed and that the rating is in range.
+func (m OutfitMetadata) Validate() error {
+	if err := validation.ValidateCareSymbols(m.Care); err != nil {
+		return err
+	}
+	if err := validation.ValidateTags(m.Tags); err != nil {
+		return err
+	}
+	if err := validation.ValidateColors(m.Colors); err != nil {
+		return err
+	}
+	if m.Price < 0 {
+		return errors.NewInvalidInputError(fmt.Sprintf("price cannot be negative, got %.2f", m.Price))
+	}
+	if m.Rating != 0 && (m.Rating < MinRating || m.Rating > MaxRating) {
+		return errors.NewInvalidInputError(fmt.Sprintf("rating must be between %d and %d, got %d", MinRating, MaxRating, m.Rating))
+	}
+	if len(m.Materials) == 0 {
+		return nil
+	}
+
+	total := 0
+	seen := make(map[string]bool, len(m.Materials))
+	for _, component := range m.Materials {
+		if err := validation.ValidateFiber(component.Fiber); err != nil {
+			return err
+		}
+		if seen[component.Fiber] {
+			return errors.NewInvalidInputError(fmt.Sprintf("fiber %q listed more than once", component.Fiber))
+		}
+		seen[component.Fiber] = true
+		if component.Percent < 1 || component.Percent > 100 {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s share must be between 1 and 100%%, got %d%%", component.Fiber, component.Percent))
+		}
+		total += component.Percent
+	}
+	if total != 100 {
+		return errors.NewInvalidInputError(fmt.Sprintf("material composition must add up to 100%%, got %d%%", total))
+	}
+	return nil
+}
+
+// WithoutCategory returns a new index without the metadata and identities
+// of category's outfits.
+func (m MetadataIndex) WithoutCategory(category string) MetadataIndex {
+	m.Outfits = maps.Clone(m.Outfits)
+	delete(m.Outfits, category)
+	if m.IDs != nil {
+		m.IDs = maps.Clone(m.IDs)
+		delete(m.IDs, category)
+	}
+	return m
+}
@@ -0,0 +1,68 @@
+package entities
+
+import "testing"
+
+func TestOutfitMetadata_DominantFiber(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata OutfitMetadata
+		want     string
+	}{
+		{"none", OutfitMetadata{}, ""},
+		{"single", OutfitMetadata{Materials: []MaterialComponent{{"wool", 100}}}, "wool"},
+		{"blend", OutfitMetadata{Materials: []MaterialComponent{{"elastane", 5}, {"cotton", 95}}}, "cotton"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.metadata.DominantFiber(); got != tt.want {
+				t.Errorf("DominantFiber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataIndex_Setting(t *testing.T) {
+	original := NewMetadataIndex()
+	metadata := OutfitMetadata{Care: []string{"wash-40"}}
+
+	updated := original.Setting("casual", "tee.avatar", metadata)
+	if _, ok := original.Get("casual", "tee.avatar"); ok {
+		t.Error("Setting() modified the original index")
+	}
+	if got, ok := updated.Get("casual", "tee.avatar"); !ok || got.Care[0] != "wash-40" {
+		t.Errorf("Get() = %+v, %v", got, ok)
+	}
+
+	cleared := updated.Setting("casual", "tee.avatar", OutfitMetadata{})
+	if _, ok := cleared.Outfits["casual"]; ok {
+		t.Error("empty metadata left an empty category entry")
+	}
+}
+
+func TestOutfitMetadata_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata OutfitMetadata
+		wantErr  bool
+	}{
+		{"empty", OutfitMetadata{}, false},
+		{"valid", OutfitMetadata{Materials: []MaterialComponent{{"cotton", 95}, {"elastane", 5}}, Care: []string{"wash-40"}}, false},
+		{"unknown fiber", OutfitMetadata{Materials: []MaterialComponent{{"denim", 100}}}, true},
+		{"short of 100", OutfitMetadata{Materials: []MaterialComponent{{"cotton", 90}}}, true},
+		{"zero share", OutfitMetadata{Materials: []MaterialComponent{{"cotton", 100}, {"wool", 0}}}, true},
+		{"repeated fiber", OutfitMetadata{Materials: []MaterialComponent{{"cotton", 50}, {"cotton", 50}}}, true},
+		{"conflicting care", OutfitMetadata{Care: []string{"iron-low", "do-not-iron"}}, true},
+		{"tags", OutfitMetadata{Tags: []string{"summer", "date-night"}}, false},
+		{"invalid tag", OutfitMetadata{Tags: []string{"Date Night"}}, true},
+		{"rating", OutfitMetadata{Rating: MaxRating}, false},
+		{"rating out of range", OutfitMetadata{Rating: MaxRating + 1}, true},
+		{"negative rating", OutfitMetadata{Rating: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.metadata.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
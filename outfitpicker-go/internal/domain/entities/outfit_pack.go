@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// PackManifestName is the file at the root of an outfit pack that describes
+// its outfits.
+const PackManifestName = "outfitpack.json"
+
+// PackFormat is the version of the pack manifest layout.
+const PackFormat = 1
+
+// PackManifest describes a category exported as an outfit pack. It holds
+// only what is useful to someone else: no paths, wear history or prices.
+type PackManifest struct {
+	Format    int       `json:"format"`
+	Category  string    `json:"category"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Labels are the category's localized labels, keyed by language.
+	Labels  map[string]string `json:"labels,omitempty"`
+	Outfits []PackOutfit      `json:"outfits"`
+}
+
+// PackOutfit is one outfit in a pack, stored at Category/FileName.
+type PackOutfit struct {
+	FileName string          `json:"fileName"`
+	Metadata *OutfitMetadata `json:"metadata,omitempty"`
+}
@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// planDateFormat is how plan days are typed by date.
+const planDateFormat = "2006-01-02"
+
+// PlannedOutfit is an outfit planned for a day.
+type PlannedOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+}
+
+// PlanDay is the outfits planned for one day, at most one per category.
+type PlanDay struct {
+	// Date is midnight of the day.
+	Date    time.Time       `json:"date"`
+	Outfits []PlannedOutfit `json:"outfits"`
+	// Worn is set once the day's outfits are recorded as worn.
+	Worn bool `json:"worn,omitempty"`
+}
+
+// OutfitPlan is a schedule of outfits picked ahead of time, one day after
+// another. The zero OutfitPlan has no days.
+type OutfitPlan struct {
+	Days      []PlanDay `json:"days"`
+	CreatedAt time.Time `json:"createdAt,omitzero"`
+	// Revision counts saves of the plan file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// FindDay returns the index of the day typed by the user: a date as
+// YYYY-MM-DD, or a weekday name such as monday or mon for the first day of
+// the plan that falls on it.
+func (p OutfitPlan) FindDay(ref string) (int, error) {
+	if date, err := time.Parse(planDateFormat, ref); err == nil {
+		i := p.DayOn(date)
+		if i < 0 {
+			return 0, errors.NewInvalidInputError(fmt.Sprintf("%s is not in the plan", ref))
+		}
+		return i, nil
+	}
+	weekday, ok := parseWeekday(ref)
+	if !ok {
+		return 0, errors.NewInvalidInputError(fmt.Sprintf("unknown day %q (want a weekday such as monday, or YYYY-MM-DD)", ref))
+	}
+	i := slices.IndexFunc(p.Days, func(day PlanDay) bool { return day.Date.Weekday() == weekday })
+	if i < 0 {
+		return 0, errors.NewInvalidInputError(fmt.Sprintf("no %s in the plan", weekday))
+	}
+	return i, nil
+}
+
+// DayOn returns the index of the day on the same date as date, or -1 when
+// the plan does not cover it.
+func (p OutfitPlan) DayOn(date time.Time) int {
+	return slices.IndexFunc(p.Days, func(day PlanDay) bool { return day.Date.Format(planDateFormat) == date.Format(planDateFormat) })
+}
+
+// PlannedOn returns the outfits of category planned on any day but skip.
+func (p OutfitPlan) PlannedOn(category string, skip int) []string {
+	var fileNames []string
+	for i, day := range p.Days {
+		if i == skip {
+			continue
+		}
+		for _, outfit := range day.Outfits {
+			if outfit.Category == category {
+				fileNames = append(fileNames, outfit.FileName)
+			}
+		}
+	}
+	return fileNames
+}
+
+// WithDay returns the plan with the day at index i replaced by day.
+func (p OutfitPlan) WithDay(i int, day PlanDay) OutfitPlan {
+	p.Days = slices.Clone(p.Days)
+	p.Days[i] = day
+	return p
+}
+
+// WithoutCategory returns the plan without the outfits planned from
+// category. Days keep their place even when nothing is left on them.
+func (p OutfitPlan) WithoutCategory(category string) OutfitPlan {
+	days := make([]PlanDay, len(p.Days))
+	for i, day := range p.Days {
+		day.Outfits = slices.DeleteFunc(slices.Clone(day.Outfits), func(outfit PlannedOutfit) bool { return outfit.Category == category })
+		days[i] = day
+	}
+	p.Days = days
+	return p
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(name)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		full := strings.ToLower(weekday.String())
+		if name == full || name == full[:3] {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func testPlan() OutfitPlan {
+	// June 1st 2024 is a Saturday.
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	var plan OutfitPlan
+	for i := range 7 {
+		plan.Days = append(plan.Days, PlanDay{
+			Date:    start.AddDate(0, 0, i),
+			Outfits: []PlannedOutfit{{Category: "casual", FileName: string(rune('a'+i)) + ".avatar"}},
+		})
+	}
+	return plan
+}
+
+func TestOutfitPlan_FindDay(t *testing.T) {
+	plan := testPlan()
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{"saturday", 0},
+		{"Mon", 2},
+		{"FRIDAY", 6},
+		{"2024-06-04", 3},
+	}
+	for _, tt := range tests {
+		if got, err := plan.FindDay(tt.ref); err != nil || got != tt.want {
+			t.Errorf("FindDay(%q) = %d, %v; want %d", tt.ref, got, err, tt.want)
+		}
+	}
+	for _, ref := range []string{"someday", "2024-06-08", "mo"} {
+		if _, err := plan.FindDay(ref); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+			t.Errorf("FindDay(%q) error = %v, want InvalidInputError", ref, err)
+		}
+	}
+	if _, err := (OutfitPlan{}).FindDay("monday"); err == nil {
+		t.Error("FindDay() in an empty plan succeeded")
+	}
+}
+
+func TestOutfitPlan_PlannedOnAndWithDay(t *testing.T) {
+	plan := testPlan()
+	if got := plan.PlannedOn("casual", 1); len(got) != 6 || slices.Contains(got, "b.avatar") {
+		t.Errorf("PlannedOn() = %v, want every day but the skipped one", got)
+	}
+	if got := plan.PlannedOn("work", -1); len(got) != 0 {
+		t.Errorf("PlannedOn() of an unplanned category = %v", got)
+	}
+
+	updated := plan.WithDay(1, PlanDay{Date: plan.Days[1].Date, Worn: true})
+	if !updated.Days[1].Worn || plan.Days[1].Worn {
+		t.Errorf("WithDay() changed the original plan or did not replace the day")
+	}
+}
+
+func TestOutfitPlan_WithoutCategory(t *testing.T) {
+	plan := testPlan()
+	plan.Days[0].Outfits = append(plan.Days[0].Outfits, PlannedOutfit{Category: "work", FileName: "suit.avatar"})
+
+	updated := plan.WithoutCategory("casual")
+	if len(updated.Days) != 7 {
+		t.Fatalf("WithoutCategory() kept %d days, want 7", len(updated.Days))
+	}
+	if got := updated.PlannedOn("casual", -1); len(got) != 0 {
+		t.Errorf("WithoutCategory() still plans casual: %v", got)
+	}
+	if got := updated.PlannedOn("work", -1); !slices.Equal(got, []string{"suit.avatar"}) {
+		t.Errorf("WithoutCategory() planned work = %v, want suit.avatar", got)
+	}
+	if got := plan.PlannedOn("casual", -1); len(got) != 7 {
+		t.Errorf("original plan changed: %v", got)
+	}
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// OutfitRating is a rated outfit and its rating, which is kept in the
+// outfit's metadata.
+type OutfitRating struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Rating   int    `json:"rating"`
+}
+
+// CategoryRatings sums up the ratings of one category's outfits.
+type CategoryRatings struct {
+	Category string  `json:"category"`
+	Rated    int     `json:"rated"`
+	Average  float64 `json:"average"`
+}
+
+// RatingsReport lists the rated outfits, best first, and the average rating
+// of each category with rated outfits.
+type RatingsReport struct {
+	Categories []CategoryRatings `json:"categories"`
+	Outfits    []OutfitRating    `json:"outfits"`
+}
+
+// Rated returns every rated outfit, highest rating first, then ordered by
+// category and file name.
+func (m MetadataIndex) Rated() []OutfitRating {
+	var rated []OutfitRating
+	for name, files := range m.Outfits {
+		for file, metadata := range files {
+			if metadata.Rating > 0 {
+				rated = append(rated, OutfitRating{Category: name, FileName: file, Rating: metadata.Rating})
+			}
+		}
+	}
+	slices.SortFunc(rated, func(a, b OutfitRating) int {
+		return cmp.Or(cmp.Compare(b.Rating, a.Rating), strings.Compare(a.Category, b.Category), strings.Compare(a.FileName, b.FileName))
+	})
+	return rated
+}
+
+// RatingsIn returns the ratings of a category's rated outfits, keyed by file
+// name.
+func (m MetadataIndex) RatingsIn(category string) map[string]int {
+	ratings := make(map[string]int)
+	for file, metadata := range m.Outfits[category] {
+		if metadata.Rating > 0 {
+			ratings[file] = metadata.Rating
+		}
+	}
+	return ratings
+}
+
+// NewRatingsReport reports on rated, averaging the ratings of each category
+// in category name order.
+func NewRatingsReport(rated []OutfitRating) RatingsReport {
+	totals := make(map[string]int)
+	counts := make(map[string]int)
+	for _, outfit := range rated {
+		totals[outfit.Category] += outfit.Rating
+		counts[outfit.Category]++
+	}
+	report := RatingsReport{Categories: []CategoryRatings{}, Outfits: rated}
+	if report.Outfits == nil {
+		report.Outfits = []OutfitRating{}
+	}
+	for _, category := range slices.Sorted(maps.Keys(counts)) {
+		report.Categories = append(report.Categories, CategoryRatings{
+			Category: category,
+			Rated:    counts[category],
+			Average:  float64(totals[category]) / float64(counts[category]),
+		})
+	}
+	return report
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMetadataIndex_Rated(t *testing.T) {
+	index := NewMetadataIndex().
+		Setting("work", "blazer.avatar", OutfitMetadata{Rating: 4}).
+		Setting("casual", "tee.avatar", OutfitMetadata{Rating: 2}).
+		Setting("casual", "jeans.avatar", OutfitMetadata{Price: 40}).
+		Setting("casual", "hat.avatar", OutfitMetadata{Rating: 4, Tags: []string{"summer"}})
+
+	rated := index.Rated()
+	var names []string
+	for _, outfit := range rated {
+		names = append(names, outfit.Category+"/"+outfit.FileName)
+	}
+	if want := []string{"casual/hat.avatar", "work/blazer.avatar", "casual/tee.avatar"}; !slices.Equal(names, want) {
+		t.Errorf("Rated() = %v, want %v", names, want)
+	}
+	if ratings := index.RatingsIn("casual"); len(ratings) != 2 || ratings["hat.avatar"] != 4 {
+		t.Errorf("RatingsIn(casual) = %v, want hat.avatar and tee.avatar", ratings)
+	}
+
+	report := NewRatingsReport(rated)
+	want := []CategoryRatings{{Category: "casual", Rated: 2, Average: 3}, {Category: "work", Rated: 1, Average: 4}}
+	if !slices.Equal(report.Categories, want) || len(report.Outfits) != 3 {
+		t.Errorf("NewRatingsReport() = %+v, want categories %+v", report, want)
+	}
+	if empty := NewRatingsReport(nil); empty.Categories == nil || empty.Outfits == nil {
+		t.Errorf("NewRatingsReport(nil) = %+v, want empty lists for JSON", empty)
+	}
+}
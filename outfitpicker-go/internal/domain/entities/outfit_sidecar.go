@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// OutfitSidecarSuffix ends the name of an outfit's sidecar metadata file,
+// kept next to it: tee.avatar.meta.json describes tee.avatar.
+const OutfitSidecarSuffix = ".meta.json"
+
+// OutfitSidecar is what an outfit's sidecar file says about it, so metadata
+// can live in the wardrobe alongside the outfit. Every field is optional.
+type OutfitSidecar struct {
+	// Tags are tags the outfit carries in addition to those in its
+	// metadata.
+	Tags []string `json:"tags,omitempty"`
+	// Colors are the outfit's main colors, used when its metadata sets none.
+	Colors []string `json:"colors,omitempty"`
+	// Seasons are the seasons the outfit is worn in. When set they decide
+	// whether the outfit is in season, whatever its tags say.
+	Seasons []string `json:"seasons,omitempty"`
+	// Weight is the outfit's weight for weighted picks, used when none has
+	// been assigned with the weight command.
+	Weight *float64 `json:"weight,omitempty"`
+	// Notes are free text about the outfit.
+	Notes string `json:"notes,omitempty"`
+}
+
+// SidecarOutfit returns the outfit a sidecar file describes, reporting
+// whether fileName names a sidecar file at all.
+func SidecarOutfit(fileName string) (string, bool) {
+	outfit, ok := strings.CutSuffix(fileName, OutfitSidecarSuffix)
+	return outfit, ok && outfit != ""
+}
+
+// Validate checks the sidecar's tags, colors, seasons, weight and notes.
+func (s OutfitSidecar) Validate() error {
+	return validation.ValidateOutfitSidecar(s.Tags, s.Colors, s.Seasons, s.Weight, s.Notes)
+}
+
+// Sidecar returns the sidecar file read for an outfit.
+func (m MetadataIndex) Sidecar(category, fileName string) (OutfitSidecar, bool) {
+	sidecar, ok := m.Sidecars[category][fileName]
+	return sidecar, ok
+}
+
+// WithSidecars returns the index with the sidecar files read for the
+// outfits of category, keyed by file name, replacing any read before.
+func (m MetadataIndex) WithSidecars(category string, sidecars map[string]OutfitSidecar) MetadataIndex {
+	all := make(map[string]map[string]OutfitSidecar, len(m.Sidecars)+1)
+	for k, v := range m.Sidecars {
+		all[k] = v
+	}
+	if len(sidecars) == 0 {
+		delete(all, category)
+	} else {
+		all[category] = sidecars
+	}
+	m.Sidecars = all
+	return m
+}
+
+// Effective returns the outfit's metadata with its sidecar file applied:
+// the sidecar's tags follow the outfit's own, and its colors are used when
+// the metadata sets none.
+func (m MetadataIndex) Effective(category, fileName string) OutfitMetadata {
+	metadata, _ := m.Get(category, fileName)
+	sidecar, ok := m.Sidecar(category, fileName)
+	if !ok {
+		return metadata
+	}
+	metadata = metadata.AddingTags(sidecar.Tags)
+	if len(metadata.Colors) == 0 {
+		metadata.Colors = sidecar.Colors
+	}
+	return metadata
+}
+
+// SeasonsOf returns the seasons the outfit's sidecar file says it is worn
+// in, none when it does not say.
+func (m MetadataIndex) SeasonsOf(category, fileName string) []string {
+	sidecar, _ := m.Sidecar(category, fileName)
+	return sidecar.Seasons
+}
+
+// SidecarWeights returns the weights the sidecar files of category set,
+// keyed by file name.
+func (m MetadataIndex) SidecarWeights(category string) map[string]float64 {
+	weights := make(map[string]float64)
+	for fileName, sidecar := range m.Sidecars[category] {
+		if sidecar.Weight != nil {
+			weights[fileName] = *sidecar.Weight
+		}
+	}
+	return weights
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestSidecarOutfit(t *testing.T) {
+	if outfit, ok := SidecarOutfit("tee.avatar.meta.json"); !ok || outfit != "tee.avatar" {
+		t.Errorf("SidecarOutfit(tee.avatar.meta.json) = %q, %v; want tee.avatar", outfit, ok)
+	}
+	for _, name := range []string{"tee.avatar", "tee.avatar.colors", ".meta.json"} {
+		if _, ok := SidecarOutfit(name); ok {
+			t.Errorf("SidecarOutfit(%s) reported a sidecar file", name)
+		}
+	}
+}
+
+func TestMetadataIndex_Sidecars(t *testing.T) {
+	weight := 3.0
+	index := NewMetadataIndex().
+		Setting("casual", "tee.avatar", OutfitMetadata{Tags: []string{"summer"}, Colors: []string{"navy"}}).
+		WithSidecars("casual", map[string]OutfitSidecar{
+			"tee.avatar":   {Tags: []string{"beach", "summer"}, Colors: []string{"white"}, Seasons: []string{"summer"}},
+			"jeans.avatar": {Colors: []string{"blue"}, Weight: &weight, Notes: "Hem is loose"},
+		})
+
+	tee := index.Effective("casual", "tee.avatar")
+	if !slices.Equal(tee.Tags, []string{"summer", "beach"}) || !slices.Equal(tee.Colors, []string{"navy"}) {
+		t.Errorf("Effective(tee.avatar) = %+v, want the sidecar's tags added and the metadata's colors kept", tee)
+	}
+	if jeans := index.Effective("casual", "jeans.avatar"); !slices.Equal(jeans.Colors, []string{"blue"}) {
+		t.Errorf("Effective(jeans.avatar) colors = %v, want the sidecar's", jeans.Colors)
+	}
+	if !index.HasTag("casual", "tee.avatar", "beach") {
+		t.Error("HasTag() did not see the sidecar's tags")
+	}
+	if got := index.SeasonsOf("casual", "tee.avatar"); !slices.Equal(got, []string{"summer"}) {
+		t.Errorf("SeasonsOf(tee.avatar) = %v, want [summer]", got)
+	}
+	if got := index.SidecarWeights("casual"); !maps.Equal(got, map[string]float64{"jeans.avatar": 3}) {
+		t.Errorf("SidecarWeights(casual) = %v, want jeans.avatar's weight", got)
+	}
+	if len(index.Outfits["casual"]) != 1 {
+		t.Errorf("WithSidecars() changed the stored metadata: %v", index.Outfits)
+	}
+	if cleared := index.WithSidecars("casual", nil); len(cleared.Sidecars) != 0 || len(index.Sidecars) != 1 {
+		t.Error("WithSidecars(nil) did not clear the category without changing the original")
+	}
+}
+
+func TestOutfitWeights_CategoryOr(t *testing.T) {
+	weights := NewOutfitWeights().Setting("casual", "tee.avatar", 5)
+	got := weights.CategoryOr("casual", map[string]float64{"tee.avatar": 2, "jeans.avatar": 0})
+	if want := map[string]float64{"tee.avatar": 5, "jeans.avatar": 0}; !maps.Equal(got, want) {
+		t.Errorf("CategoryOr() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// OutfitTags is a tagged outfit and its tags, which are kept in the outfit's
+// metadata.
+type OutfitTags struct {
+	Category string   `json:"category"`
+	FileName string   `json:"fileName"`
+	Tags     []string `json:"tags"`
+}
+
+// Tagged returns every tagged outfit, ordered by category and file name.
+// An empty category lists the outfits of all categories.
+func (m MetadataIndex) Tagged(category string) []OutfitTags {
+	var tagged []OutfitTags
+	for name, files := range m.Outfits {
+		if category != "" && name != category {
+			continue
+		}
+		for file, metadata := range files {
+			if len(metadata.Tags) > 0 {
+				tagged = append(tagged, OutfitTags{Category: name, FileName: file, Tags: slices.Clone(metadata.Tags)})
+			}
+		}
+	}
+	slices.SortFunc(tagged, func(a, b OutfitTags) int {
+		return cmp.Or(strings.Compare(a.Category, b.Category), strings.Compare(a.FileName, b.FileName))
+	})
+	return tagged
+}
+
+// HasTag reports whether the outfit is tagged with tag, itself or through
+// its category's default tags.
+func (m MetadataIndex) HasTag(category, fileName, tag string) bool {
+	return slices.Contains(m.TagsOf(category, fileName), tag)
+}
+
+// TagsOf returns the outfit's own tags, then those of its sidecar file,
+// then its category's default tags, each tag once.
+func (m MetadataIndex) TagsOf(category, fileName string) []string {
+	return m.Effective(category, fileName).AddingTags(m.CategoryTags[category]).Tags
+}
+
+// WithCategoryTags returns the index with the default tags of the
+// categories' descriptions added.
+func (m MetadataIndex) WithCategoryTags(infos []CategoryInfo) MetadataIndex {
+	tags := make(map[string][]string, len(infos))
+	for _, info := range infos {
+		if defaults := info.DefaultTags(); len(defaults) > 0 {
+			tags[info.Category.Name] = defaults
+		}
+	}
+	m.CategoryTags = tags
+	return m
+}
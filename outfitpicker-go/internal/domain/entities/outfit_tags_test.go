@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOutfitMetadata_AddingAndRemovingTags(t *testing.T) {
+	original := OutfitMetadata{Tags: []string{"summer"}}
+
+	added := original.AddingTags([]string{"beach", "summer"})
+	if !slices.Equal(added.Tags, []string{"summer", "beach"}) {
+		t.Errorf("AddingTags() = %v, want [summer beach]", added.Tags)
+	}
+	if len(original.Tags) != 1 {
+		t.Errorf("AddingTags() modified the original: %v", original.Tags)
+	}
+
+	removed := added.RemovingTags([]string{"summer", "beach"})
+	if removed.Tags != nil || !removed.IsEmpty() {
+		t.Errorf("RemovingTags() = %v, want no tags", removed.Tags)
+	}
+	if len(added.Tags) != 2 {
+		t.Errorf("RemovingTags() modified the original: %v", added.Tags)
+	}
+}
+
+func TestMetadataIndex_Tagged(t *testing.T) {
+	index := NewMetadataIndex().
+		Setting("work", "blazer.avatar", OutfitMetadata{Tags: []string{"office"}}).
+		Setting("casual", "tee.avatar", OutfitMetadata{Tags: []string{"summer"}}).
+		Setting("casual", "jeans.avatar", OutfitMetadata{Price: 40}).
+		Setting("casual", "hat.avatar", OutfitMetadata{Tags: []string{"summer", "beach"}})
+
+	all := index.Tagged("")
+	var names []string
+	for _, outfit := range all {
+		names = append(names, outfit.Category+"/"+outfit.FileName)
+	}
+	if want := []string{"casual/hat.avatar", "casual/tee.avatar", "work/blazer.avatar"}; !slices.Equal(names, want) {
+		t.Errorf("Tagged(\"\") = %v, want %v", names, want)
+	}
+	if got := index.Tagged("work"); len(got) != 1 || got[0].FileName != "blazer.avatar" {
+		t.Errorf("Tagged(work) = %+v", got)
+	}
+	if !index.HasTag("casual", "hat.avatar", "beach") || index.HasTag("casual", "jeans.avatar", "beach") {
+		t.Error("HasTag() did not follow the outfits' tags")
+	}
+}
+
+func TestMetadataIndex_CategoryTags(t *testing.T) {
+	index := NewMetadataIndex().Setting("casual", "tee.avatar", OutfitMetadata{Tags: []string{"summer"}})
+	described := NewCategoryInfo(NewCategoryReference("casual", "/outfits/casual"), CategoryStateHasOutfits, 2)
+	described.Description = &CategoryDescription{Tags: []string{"relaxed", "summer"}}
+	index = index.WithCategoryTags([]CategoryInfo{described, NewCategoryInfo(NewCategoryReference("gym", "/outfits/gym"), CategoryStateHasOutfits, 1)})
+
+	if got := index.TagsOf("casual", "tee.avatar"); !slices.Equal(got, []string{"summer", "relaxed"}) {
+		t.Errorf("TagsOf(tee.avatar) = %v, want its own tags then the category's", got)
+	}
+	if !index.HasTag("casual", "jeans.avatar", "relaxed") || index.HasTag("gym", "kit.avatar", "relaxed") {
+		t.Error("HasTag() should follow the category's default tags only")
+	}
+	if tagged := index.Tagged("casual"); len(tagged) != 1 || !slices.Equal(tagged[0].Tags, []string{"summer"}) {
+		t.Errorf("Tagged() = %+v, want only the outfit's own tags", tagged)
+	}
+}
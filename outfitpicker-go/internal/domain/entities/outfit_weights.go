@@ -0,0 +1,89 @@
+package entities
+
+import "maps"
+
+// DefaultOutfitWeight is the weight of an outfit that has not been given one.
+const DefaultOutfitWeight = 1.0
+
+// OutfitWeights maps category names to user-assigned pick weights of their
+// outfits, keyed by file name. Weighted picks choose an outfit with a chance
+// proportional to its weight.
+type OutfitWeights struct {
+	Outfits map[string]map[string]float64 `json:"outfits"`
+	// Revision counts saves of the weights file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewOutfitWeights creates an empty set of weights.
+func NewOutfitWeights() OutfitWeights {
+	return OutfitWeights{Outfits: make(map[string]map[string]float64)}
+}
+
+// Weight returns the outfit's weight, or DefaultOutfitWeight when none has
+// been assigned.
+func (w OutfitWeights) Weight(category, fileName string) float64 {
+	if weight, ok := w.Outfits[category][fileName]; ok {
+		return weight
+	}
+	return DefaultOutfitWeight
+}
+
+// Category returns the weights assigned in a category, keyed by file name.
+func (w OutfitWeights) Category(category string) map[string]float64 {
+	return w.Outfits[category]
+}
+
+// CategoryOr returns the weights assigned in a category, with defaults
+// filling in for the outfits that have not been given one.
+func (w OutfitWeights) CategoryOr(category string, defaults map[string]float64) map[string]float64 {
+	if len(defaults) == 0 {
+		return w.Category(category)
+	}
+	weights := maps.Clone(defaults)
+	maps.Copy(weights, w.Category(category))
+	return weights
+}
+
+// Setting returns new weights with the outfit's weight replaced. Setting
+// DefaultOutfitWeight removes the outfit's entry.
+func (w OutfitWeights) Setting(category, fileName string, weight float64) OutfitWeights {
+	outfits := make(map[string]map[string]float64, len(w.Outfits)+1)
+	for k, v := range w.Outfits {
+		outfits[k] = v
+	}
+
+	files := make(map[string]float64, len(outfits[category])+1)
+	for k, v := range outfits[category] {
+		files[k] = v
+	}
+	if weight == DefaultOutfitWeight {
+		delete(files, fileName)
+	} else {
+		files[fileName] = weight
+	}
+
+	if len(files) == 0 {
+		delete(outfits, category)
+	} else {
+		outfits[category] = files
+	}
+	return OutfitWeights{Outfits: outfits, Revision: w.Revision}
+}
+
+// Moving returns new weights with the weight of the outfit at from moved to
+// the outfit at to.
+func (w OutfitWeights) Moving(from, to OutfitLocation) OutfitWeights {
+	weight, ok := w.Outfits[from.Category][from.FileName]
+	if !ok {
+		return w
+	}
+	return w.Setting(from.Category, from.FileName, DefaultOutfitWeight).Setting(to.Category, to.FileName, weight)
+}
+
+// WithoutCategory returns new weights without those of category's outfits.
+func (w OutfitWeights) WithoutCategory(category string) OutfitWeights {
+	w.Outfits = maps.Clone(w.Outfits)
+	delete(w.Outfits, category)
+	return w
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "testing"
+
+func TestOutfitWeights_Setting(t *testing.T) {
+	original := NewOutfitWeights()
+
+	updated := original.Setting("casual", "tee.avatar", 3)
+	if got := original.Weight("casual", "tee.avatar"); got != DefaultOutfitWeight {
+		t.Errorf("Setting() modified the original weights, Weight() = %v", got)
+	}
+	if got := updated.Weight("casual", "tee.avatar"); got != 3 {
+		t.Errorf("Weight() = %v, want 3", got)
+	}
+	if got := updated.Weight("casual", "jeans.avatar"); got != DefaultOutfitWeight {
+		t.Errorf("Weight() of unweighted outfit = %v, want %v", got, DefaultOutfitWeight)
+	}
+
+	reset := updated.Setting("casual", "tee.avatar", DefaultOutfitWeight)
+	if _, ok := reset.Outfits["casual"]; ok {
+		t.Error("default weight left an empty category entry")
+	}
+}
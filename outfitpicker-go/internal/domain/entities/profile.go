@@ -0,0 +1,18 @@
+package entities
+
+// DefaultProfile names the wardrobe kept directly in the app directory. It
+// is used whenever no other profile is chosen.
+const DefaultProfile = "default"
+
+// ProfileSelection records the profile commands use when none is given on
+// the command line.
+type ProfileSelection struct {
+	// Active is the name of the profile in use. Empty means DefaultProfile.
+	Active string `json:"active,omitempty"`
+}
+
+// Profile is a named wardrobe with its own configuration and state.
+type Profile struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+// ProgressPhase is where a long operation is when it reports progress.
+type ProgressPhase string
+
+const (
+	ProgressStart ProgressPhase = "start"
+	ProgressStep  ProgressPhase = "step"
+	ProgressDone  ProgressPhase = "done"
+)
+
+// Operations that report progress.
+const (
+	OperationScan    = "scan"
+	OperationRebuild = "rebuild"
+	OperationImport  = "import"
+)
+
+// ProgressEvent reports how far a long operation has got. Total is zero when
+// the amount of work is not known in advance.
+type ProgressEvent struct {
+	Operation string        `json:"operation"`
+	Phase     ProgressPhase `json:"phase"`
+	Current   int           `json:"current"`
+	Total     int           `json:"total,omitempty"`
+	Item      string        `json:"item,omitempty"`
+}
@@ -0,0 +1,41 @@
+package entities
+
+// Report formats.
+const (
+	ReportFormatText = "text"
+	ReportFormatHTML = "html"
+)
+
+// ReportSettings configures how generated reports are rendered and sent.
+type ReportSettings struct {
+	// Format is ReportFormatText or ReportFormatHTML. Empty means text.
+	Format string `json:"format,omitempty"`
+	// SMTP is the mail server reports are sent through, if any.
+	SMTP *SMTPSettings `json:"smtp,omitempty"`
+}
+
+// SMTPSettings identifies a mail server and the report recipients. The
+// password is never stored; it is read from the environment when sending.
+type SMTPSettings struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username string   `json:"username,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// FormatOrDefault returns the configured format, or text when none is set.
+func (s ReportSettings) FormatOrDefault() string {
+	if s.Format == "" {
+		return ReportFormatText
+	}
+	return s.Format
+}
+
+// MailMessage is an email ready to be sent through the configured server.
+type MailMessage struct {
+	Subject string
+	Body    string
+	// HTML marks Body as an HTML document rather than plain text.
+	HTML bool
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// Rotation policies.
+const (
+	// RotationStrict picks only outfits not yet worn in the current
+	// rotation, and starts a new rotation once every outfit is worn. It is
+	// the default.
+	RotationStrict = "strict"
+	// RotationLeastRecentlyWorn picks among the outfits worn longest ago,
+	// never-worn outfits first.
+	RotationLeastRecentlyWorn = "least-recently-worn"
+	// RotationCooldown picks any outfit not worn within the last
+	// CooldownDays days.
+	RotationCooldown = "cooldown"
+	// RotationRandom picks any outfit, worn or not.
+	RotationRandom = "random"
+)
+
+// RotationPolicy sets which outfits of a category a pick may choose from.
+type RotationPolicy struct {
+	// Name is one of the rotation policies; empty means RotationStrict.
+	Name string `json:"name,omitempty"`
+	// CooldownDays is how long a worn outfit rests under RotationCooldown.
+	CooldownDays int `json:"cooldownDays,omitempty"`
+}
+
+// ParseRotationPolicy parses a policy typed by the user: a policy name, or
+// cooldown:N for a cooldown of N days.
+func ParseRotationPolicy(value string) (RotationPolicy, error) {
+	name, days, hasDays := strings.Cut(value, ":")
+	policy := RotationPolicy{Name: name}
+	if hasDays {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return RotationPolicy{}, errors.NewInvalidInputError(fmt.Sprintf("cooldown days must be a number, got %q", days))
+		}
+		policy.CooldownDays = n
+	}
+	if name == "" || validation.ValidateRotationPolicy(policy.Name, policy.CooldownDays) != nil {
+		return RotationPolicy{}, errors.NewInvalidInputError(fmt.Sprintf("unknown rotation policy %q (want one of: strict, least-recently-worn, cooldown:DAYS, random)", value))
+	}
+	return policy, nil
+}
+
+// EffectiveName returns the policy name, defaulting to RotationStrict.
+func (p RotationPolicy) EffectiveName() string {
+	if p.Name == "" {
+		return RotationStrict
+	}
+	return p.Name
+}
+
+// String returns the policy as ParseRotationPolicy accepts it.
+func (p RotationPolicy) String() string {
+	if p.Name == RotationCooldown {
+		return fmt.Sprintf("%s:%d", p.Name, p.CooldownDays)
+	}
+	return p.EffectiveName()
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "testing"
+
+func TestParseRotationPolicy(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    RotationPolicy
+		wantErr bool
+	}{
+		{"strict", RotationPolicy{Name: RotationStrict}, false},
+		{"least-recently-worn", RotationPolicy{Name: RotationLeastRecentlyWorn}, false},
+		{"cooldown:7", RotationPolicy{Name: RotationCooldown, CooldownDays: 7}, false},
+		{"random", RotationPolicy{Name: RotationRandom}, false},
+		{"cooldown", RotationPolicy{}, true},
+		{"cooldown:soon", RotationPolicy{}, true},
+		{"random:3", RotationPolicy{}, true},
+		{"", RotationPolicy{}, true},
+		{"shuffle", RotationPolicy{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRotationPolicy(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseRotationPolicy(%q) = %+v, %v", tt.value, got, err)
+			}
+			if err == nil && got.String() != tt.value {
+				t.Errorf("String() = %q, want %q", got.String(), tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// RotationSnapshot is the rotation state of every category as it was at a
+// past time, rebuilt from the recorded history.
+type RotationSnapshot struct {
+	// At is the moment the snapshot describes; events at or after it are
+	// left out.
+	At         time.Time          `json:"at"`
+	Categories []CategorySnapshot `json:"categories"`
+}
+
+// CategorySnapshot is one category's rotation at a snapshot's time.
+type CategorySnapshot struct {
+	Category string `json:"category"`
+	// Outfits are the outfits known to be in the category then, sorted.
+	Outfits []string `json:"outfits"`
+	// Worn are the outfits worn in the rotation then in progress, oldest
+	// first.
+	Worn []string `json:"worn"`
+	// Available are the outfits not yet worn in that rotation, sorted.
+	Available          []string         `json:"available"`
+	CompletedRotations int              `json:"completedRotations"`
+	LastPick           *SelectionRecord `json:"lastPick,omitempty"`
+}
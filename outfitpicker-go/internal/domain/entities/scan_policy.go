@@ -0,0 +1,11 @@
+package entities
+
+// ScanPolicy controls which entries under the wardrobe root are scanned.
+type ScanPolicy struct {
+	// IncludeHidden scans dotfiles and dot-directories. Version control and
+	// OS metadata such as .git and .DS_Store are skipped regardless.
+	IncludeHidden bool `json:"includeHidden,omitempty"`
+	// Ignore lists extra gitignore-style patterns, relative to the wardrobe
+	// root, applied before the wardrobe's .outfitignore files.
+	Ignore []string `json:"ignore,omitempty"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ScheduledPick is a pick the operating system's scheduler runs every day.
+type ScheduledPick struct {
+	Hour   int `json:"hour"`
+	Minute int `json:"minute"`
+	// Command is the program run, followed by its arguments.
+	Command []string `json:"command"`
+}
+
+// ParseScheduleTime parses a time of day typed by the user as HH:MM, on
+// the 24-hour clock.
+func ParseScheduleTime(value string) (hour, minute int, err error) {
+	at, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, errors.NewInvalidInputError(fmt.Sprintf("the time must be HH:MM on the 24-hour clock, like 07:30, got %q", value))
+	}
+	return at.Hour(), at.Minute(), nil
+}
+
+// Time returns when the pick runs, as HH:MM.
+func (p ScheduledPick) Time() string {
+	return fmt.Sprintf("%02d:%02d", p.Hour, p.Minute)
+}
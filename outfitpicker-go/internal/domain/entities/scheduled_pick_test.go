@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	hour, minute, err := ParseScheduleTime("07:30")
+	if err != nil || hour != 7 || minute != 30 {
+		t.Errorf("ParseScheduleTime(07:30) = %d, %d, %v", hour, minute, err)
+	}
+	if got := (ScheduledPick{Hour: hour, Minute: minute}).Time(); got != "07:30" {
+		t.Errorf("Time() = %q, want 07:30", got)
+	}
+	for _, value := range []string{"", "24:00", "07:60", "7am"} {
+		if _, _, err := ParseScheduleTime(value); !errors.As(err, new(*domainerrors.InvalidInputError)) {
+			t.Errorf("ParseScheduleTime(%q) error = %v, want InvalidInputError", value, err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package entities
+
+import "slices"
+
+// Seasons, in calendar order from the start of the year.
+const (
+	SeasonWinter = "winter"
+	SeasonSpring = "spring"
+	SeasonSummer = "summer"
+	SeasonAutumn = "autumn"
+)
+
+// SeasonAuto stands for the season of the current date.
+const SeasonAuto = "auto"
+
+// SeasonAssignments limits categories and tagged outfits to the seasons they
+// are worn in. Categories and tags without an assignment are in season all
+// year.
+type SeasonAssignments struct {
+	// Categories maps category names to their seasons.
+	Categories map[string][]string `json:"categories,omitempty"`
+	// Tags maps outfit tags to their seasons.
+	Tags map[string][]string `json:"tags,omitempty"`
+	// Southern derives the current season for the southern hemisphere.
+	Southern bool `json:"southern,omitempty"`
+}
+
+// Category returns the seasons assigned to a category, or none when it is
+// worn all year.
+func (s SeasonAssignments) Category(category string) []string {
+	return s.Categories[category]
+}
+
+// Tag returns the seasons assigned to a tag, or none when it does not
+// restrict the season.
+func (s SeasonAssignments) Tag(tag string) []string {
+	return s.Tags[tag]
+}
+
+// SettingCategory returns assignments with the category's seasons replaced.
+// No seasons remove the category's assignment.
+func (s SeasonAssignments) SettingCategory(category string, seasons []string) SeasonAssignments {
+	s.Categories = settingValues(s.Categories, category, seasons)
+	return s
+}
+
+// SettingTag returns assignments with the tag's seasons replaced. No seasons
+// remove the tag's assignment.
+func (s SeasonAssignments) SettingTag(tag string, seasons []string) SeasonAssignments {
+	s.Tags = settingValues(s.Tags, tag, seasons)
+	return s
+}
+
+func settingValues(current map[string][]string, key string, values []string) map[string][]string {
+	updated := make(map[string][]string, len(current)+1)
+	for k, v := range current {
+		updated[k] = v
+	}
+	if len(values) == 0 {
+		delete(updated, key)
+	} else {
+		updated[key] = slices.Clone(values)
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+	return updated
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+// SelectionRecord is one outfit picked at one time.
+type SelectionRecord struct {
+	Category   string    `json:"category"`
+	FileName   string    `json:"fileName"`
+	SelectedAt time.Time `json:"selectedAt"`
+	// OutfitID is the outfit's stable ID when outfit IDs are enabled.
+	OutfitID string `json:"outfitId,omitempty"`
+}
+
+// SelectionHistory is the append-only list of picks, oldest first.
+type SelectionHistory struct {
+	Records []SelectionRecord `json:"records"`
+	// Revision counts saves of the history file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// Appending returns a new history with record added at the end.
+func (h SelectionHistory) Appending(record SelectionRecord) SelectionHistory {
+	return SelectionHistory{Records: append(slices.Clone(h.Records), record), Revision: h.Revision}
+}
+
+// Cleared returns an empty history that replaces h when saved.
+func (h SelectionHistory) Cleared() SelectionHistory {
+	return SelectionHistory{Revision: h.Revision}
+}
+
+// Query returns one page of the records that match query, newest first.
+// query must already be normalized.
+func (h SelectionHistory) Query(query HistoryQuery) Page[SelectionRecord] {
+	collector := newPageCollector[SelectionRecord](query)
+	for i := len(h.Records) - 1; i >= 0; i-- {
+		if record := h.Records[i]; query.Matches(record.Category, record.SelectedAt) {
+			collector.add(record)
+		}
+	}
+	return collector.page
+}
+
+// WithoutCategory returns a new history without the picks from category.
+func (h SelectionHistory) WithoutCategory(category string) SelectionHistory {
+	h.Records = slices.DeleteFunc(slices.Clone(h.Records), func(record SelectionRecord) bool {
+		return record.Category == category
+	})
+	return h
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectionHistory_AppendingAndQuery(t *testing.T) {
+	start := time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC)
+	var history SelectionHistory
+	history = history.Appending(SelectionRecord{Category: "casual", FileName: "tee.avatar", SelectedAt: start})
+	history = history.Appending(SelectionRecord{Category: "work", FileName: "shirt.avatar", SelectedAt: start.Add(24 * time.Hour)})
+	history = history.Appending(SelectionRecord{Category: "casual", FileName: "jeans.avatar", SelectedAt: start.Add(48 * time.Hour)})
+
+	page := history.Query(HistoryQuery{Category: "casual", Since: start.Add(time.Hour), Limit: 10, Page: 1})
+	if page.Total != 1 || page.Items[0].FileName != "jeans.avatar" {
+		t.Errorf("Query() = %+v", page)
+	}
+
+	cleared := history.Cleared()
+	if len(cleared.Records) != 0 || len(history.Records) != 3 {
+		t.Errorf("Cleared() = %+v, original now %d records", cleared, len(history.Records))
+	}
+}
+
+func TestSelectionHistory_WithoutCategory(t *testing.T) {
+	at := time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC)
+	history := SelectionHistory{Revision: 2, Records: []SelectionRecord{
+		{Category: "formal", FileName: "suit.avatar", SelectedAt: at},
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: at},
+	}}
+
+	removed := history.WithoutCategory("formal")
+	if len(removed.Records) != 1 || removed.Records[0].Category != "casual" || removed.Revision != 2 {
+		t.Errorf("WithoutCategory() = %+v, want the casual pick at revision 2", removed)
+	}
+	if len(history.Records) != 2 || history.Records[0].Category != "formal" {
+		t.Errorf("WithoutCategory() modified the original history: %+v", history.Records)
+	}
+}
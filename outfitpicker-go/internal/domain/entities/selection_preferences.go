@@ -0,0 +1,181 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Selection strategies.
+const (
+	StrategyUniform  = "uniform"
+	StrategyWeighted = "weighted"
+	StrategyRated    = "rated"
+)
+
+// New arrival modes.
+const (
+	NewArrivalsPrefer = "prefer"
+	NewArrivalsHold   = "hold"
+)
+
+// Empty category policies.
+const (
+	EmptyCategoriesSkip = "skip"
+	EmptyCategoriesWarn = "warn"
+	EmptyCategoriesFail = "fail"
+)
+
+// CategoryBalanceRemaining weights picks across all categories against the
+// outfits each category has left in its rotation.
+const CategoryBalanceRemaining = "remaining"
+
+// DefaultCategoryPriority is the priority of a category without one.
+const DefaultCategoryPriority = 1.0
+
+// Tag constraint severities.
+const (
+	ConstraintBlock     = "block"
+	ConstraintDowngrade = "downgrade"
+)
+
+// DowngradeFactor scales the chance of picking an outfit for each
+// downgrading tag constraint it would break.
+const DowngradeFactor = 0.1
+
+// DefaultNewArrivalDays is how long an outfit counts as a new arrival when
+// a mode is chosen without a period.
+const DefaultNewArrivalDays = 14
+
+// NewArrivalPolicy sets how picks treat outfits first seen within the last
+// Days days.
+type NewArrivalPolicy struct {
+	// Mode is NewArrivalsPrefer to pick new arrivals before other unworn
+	// outfits, or NewArrivalsHold to leave them out of picks until they are
+	// tagged. Empty turns the grace period off.
+	Mode string `json:"mode,omitempty"`
+	Days int    `json:"days,omitempty"`
+}
+
+// TagConstraint limits how often outfits with a tag are picked: at most Max
+// of them in any Days days, across all categories.
+type TagConstraint struct {
+	Tag  string `json:"tag"`
+	Max  int    `json:"max"`
+	Days int    `json:"days"`
+	// Severity is ConstraintBlock to leave outfits that would break the
+	// limit out of picks, or ConstraintDowngrade to make them less likely.
+	// Empty means block.
+	Severity string `json:"severity,omitempty"`
+}
+
+// Blocks reports whether breaking the constraint leaves an outfit out.
+func (c TagConstraint) Blocks() bool {
+	return c.Severity != ConstraintDowngrade
+}
+
+// String describes the limit, such as at most 2 "black" per 7 days.
+func (c TagConstraint) String() string {
+	return fmt.Sprintf("at most %d %q per %d days", c.Max, c.Tag, c.Days)
+}
+
+// SelectionPreferences configures how outfits are picked.
+type SelectionPreferences struct {
+	// Strategy is StrategyUniform, StrategyWeighted or StrategyRated. Empty
+	// means uniform.
+	Strategy string `json:"strategy,omitempty"`
+	// FeedbackBoost is added to an outfit's weight for each point of net
+	// positive feedback when the weighted strategy is used.
+	FeedbackBoost float64 `json:"feedbackBoost,omitempty"`
+	// NewArrivals is the grace period for newly added outfits.
+	NewArrivals NewArrivalPolicy `json:"newArrivals,omitzero"`
+	// EmptyCategories sets what a pick across all categories does about
+	// categories without outfits: EmptyCategoriesSkip, EmptyCategoriesWarn
+	// or EmptyCategoriesFail. Empty means skip.
+	EmptyCategories string `json:"emptyCategories,omitempty"`
+	// CategoryRestDays is how many days after a category is picked from a
+	// pick across all categories leaves it out. Zero turns the rest off.
+	CategoryRestDays int `json:"categoryRestDays,omitempty"`
+	// CategoryBalance is CategoryBalanceRemaining to favor, in picks across
+	// all categories, the categories with the fewest outfits left in their
+	// rotation, so nearly finished rotations get finished and large
+	// categories don't dominate. Empty weighs categories by priority alone.
+	CategoryBalance string `json:"categoryBalance,omitempty"`
+	// CategoryPriorities scales how often picks across all categories
+	// choose a category, relative to DefaultCategoryPriority. A priority of
+	// 0 chooses the category only when no other has an outfit to pick.
+	CategoryPriorities map[string]float64 `json:"categoryPriorities,omitempty"`
+	// TagConstraints limit how often outfits with a tag are picked, counted
+	// against the selection history. At most one applies to each tag.
+	TagConstraints []TagConstraint `json:"tagConstraints,omitempty"`
+	// RotationPolicy sets which outfits picks choose from in categories
+	// without a policy of their own. The zero value is strict rotation.
+	RotationPolicy RotationPolicy `json:"rotationPolicy,omitzero"`
+	// CooldownDays is how many days after an outfit is worn picks leave it
+	// out, whatever the rotation policy and even after its rotation is
+	// reset. Zero turns the cooldown off.
+	CooldownDays int `json:"cooldownDays,omitempty"`
+	// CategoryRotationPolicies overrides RotationPolicy for single
+	// categories.
+	CategoryRotationPolicies map[string]RotationPolicy `json:"categoryRotationPolicies,omitempty"`
+	// CategoryPickLimits caps how many picks can be made from a category
+	// each day. Categories without a limit can be picked from any number
+	// of times.
+	CategoryPickLimits map[string]int `json:"categoryPickLimits,omitempty"`
+	// LockedRotations lists the categories whose rotation is never started
+	// over automatically: once every outfit is worn, picks fail until the
+	// rotation is reset by hand. Picks from them follow strict rotation
+	// whatever the configured rotation policy.
+	LockedRotations []string `json:"lockedRotations,omitempty"`
+}
+
+// IsWeighted reports whether picks use the weighted strategy.
+func (p SelectionPreferences) IsWeighted() bool {
+	return p.Strategy == StrategyWeighted
+}
+
+// IsRated reports whether picks use the rated strategy.
+func (p SelectionPreferences) IsRated() bool {
+	return p.Strategy == StrategyRated
+}
+
+// EmptyCategoryPolicy returns the empty category policy, defaulting to
+// EmptyCategoriesSkip.
+func (p SelectionPreferences) EmptyCategoryPolicy() string {
+	if p.EmptyCategories == "" {
+		return EmptyCategoriesSkip
+	}
+	return p.EmptyCategories
+}
+
+// CategoryPriority returns the priority of category, defaulting to
+// DefaultCategoryPriority.
+func (p SelectionPreferences) CategoryPriority(category string) float64 {
+	if priority, ok := p.CategoryPriorities[category]; ok {
+		return priority
+	}
+	return DefaultCategoryPriority
+}
+
+// PickLimit returns the daily pick limit of category, or 0 when it has
+// none.
+func (p SelectionPreferences) PickLimit(category string) int {
+	return p.CategoryPickLimits[category]
+}
+
+// RotationLocked reports whether the rotation of category is only reset by
+// hand.
+func (p SelectionPreferences) RotationLocked(category string) bool {
+	return slices.Contains(p.LockedRotations, category)
+}
+
+// RotationPolicyFor returns the rotation policy of category: strict for a
+// locked rotation, else its own policy, defaulting to RotationPolicy.
+func (p SelectionPreferences) RotationPolicyFor(category string) RotationPolicy {
+	if p.RotationLocked(category) {
+		return RotationPolicy{Name: RotationStrict}
+	}
+	if policy, ok := p.CategoryRotationPolicies[category]; ok {
+		return policy
+	}
+	return p.RotationPolicy
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// SkippedOutfit is an outfit left out of picks for a while, such as one at
+// the dry cleaner.
+type SkippedOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	// Until is when the outfit can be picked again; zero when it is
+	// skipped until it is unskipped.
+	Until time.Time `json:"until,omitzero"`
+}
+
+// SkippedOutfits records the outfits left out of picks, by category and
+// file name, with when each can be picked again. Skipped outfits are not
+// worn, so they do not count towards the rotation.
+type SkippedOutfits struct {
+	Outfits map[string]map[string]time.Time `json:"outfits"`
+	// Revision counts saves of the skipped outfits file and is used to
+	// reject saves based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// NewSkippedOutfits creates an empty set of skipped outfits.
+func NewSkippedOutfits() SkippedOutfits {
+	return SkippedOutfits{Outfits: make(map[string]map[string]time.Time)}
+}
+
+// SkippedAt reports whether the outfit is skipped at now.
+func (s SkippedOutfits) SkippedAt(category, fileName string, now time.Time) bool {
+	until, ok := s.Outfits[category][fileName]
+	return ok && (until.IsZero() || now.Before(until))
+}
+
+// CategoryAt returns the file names in a category skipped at now.
+func (s SkippedOutfits) CategoryAt(category string, now time.Time) map[string]bool {
+	skipped := make(map[string]bool)
+	for fileName := range s.Outfits[category] {
+		if s.SkippedAt(category, fileName, now) {
+			skipped[fileName] = true
+		}
+	}
+	return skipped
+}
+
+// At returns the outfits skipped at now, sorted by category and file name.
+func (s SkippedOutfits) At(now time.Time) []SkippedOutfit {
+	var skipped []SkippedOutfit
+	for _, category := range slices.Sorted(maps.Keys(s.Outfits)) {
+		for _, fileName := range slices.Sorted(maps.Keys(s.Outfits[category])) {
+			if s.SkippedAt(category, fileName, now) {
+				skipped = append(skipped, SkippedOutfit{Category: category, FileName: fileName, Until: s.Outfits[category][fileName]})
+			}
+		}
+	}
+	return skipped
+}
+
+// Skipping returns new skipped outfits that include the outfit until the
+// given time, or until it is unskipped when until is zero.
+func (s SkippedOutfits) Skipping(category, fileName string, until time.Time) SkippedOutfits {
+	files := maps.Clone(s.Outfits[category])
+	if files == nil {
+		files = make(map[string]time.Time)
+	}
+	files[fileName] = until
+	return s.with(category, files)
+}
+
+// Unskipping returns new skipped outfits without the outfit.
+func (s SkippedOutfits) Unskipping(category, fileName string) SkippedOutfits {
+	if _, ok := s.Outfits[category][fileName]; !ok {
+		return s
+	}
+	files := maps.Clone(s.Outfits[category])
+	delete(files, fileName)
+	return s.with(category, files)
+}
+
+// Moving returns new skipped outfits with the outfit at from, if skipped,
+// replaced by the outfit at to.
+func (s SkippedOutfits) Moving(from, to OutfitLocation) SkippedOutfits {
+	until, ok := s.Outfits[from.Category][from.FileName]
+	if !ok {
+		return s
+	}
+	return s.Unskipping(from.Category, from.FileName).Skipping(to.Category, to.FileName, until)
+}
+
+// DroppingExpired returns new skipped outfits without the skips that have
+// ended by now.
+func (s SkippedOutfits) DroppingExpired(now time.Time) SkippedOutfits {
+	for category, files := range s.Outfits {
+		for fileName := range files {
+			if !s.SkippedAt(category, fileName, now) {
+				s = s.Unskipping(category, fileName)
+			}
+		}
+	}
+	return s
+}
+
+func (s SkippedOutfits) with(category string, files map[string]time.Time) SkippedOutfits {
+	outfits := maps.Clone(s.Outfits)
+	if outfits == nil {
+		outfits = make(map[string]map[string]time.Time)
+	}
+	if len(files) == 0 {
+		delete(outfits, category)
+	} else {
+		outfits[category] = files
+	}
+	return SkippedOutfits{Outfits: outfits, Revision: s.Revision}
+}
+
+// WithoutCategory returns new skipped outfits without category's outfits.
+func (s SkippedOutfits) WithoutCategory(category string) SkippedOutfits {
+	return s.with(category, nil)
+}
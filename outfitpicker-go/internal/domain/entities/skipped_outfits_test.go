@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkippedOutfits_SkippingAndUnskipping(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	original := NewSkippedOutfits()
+
+	updated := original.Skipping("casual", "tee.avatar", time.Time{}).Skipping("casual", "jeans.avatar", now.Add(time.Hour))
+	if original.SkippedAt("casual", "tee.avatar", now) {
+		t.Error("Skipping() modified the original skipped outfits")
+	}
+	if !updated.SkippedAt("casual", "tee.avatar", now.AddDate(1, 0, 0)) {
+		t.Error("an outfit skipped until unskipped is not skipped a year later")
+	}
+	if !updated.SkippedAt("casual", "jeans.avatar", now) || updated.SkippedAt("casual", "jeans.avatar", now.Add(time.Hour)) {
+		t.Error("a temporary skip does not end when it should")
+	}
+	if got := updated.At(now.Add(time.Hour)); len(got) != 1 || got[0].FileName != "tee.avatar" {
+		t.Errorf("At() = %+v, want only tee.avatar", got)
+	}
+	if got := updated.DroppingExpired(now.Add(time.Hour)); len(got.Outfits["casual"]) != 1 {
+		t.Errorf("DroppingExpired() = %v, want only tee.avatar left", got.Outfits)
+	}
+
+	cleared := updated.Unskipping("casual", "tee.avatar").Unskipping("casual", "jeans.avatar")
+	if len(cleared.Outfits) != 0 || len(updated.Outfits["casual"]) != 2 {
+		t.Errorf("Unskipping() = %v, original = %v", cleared.Outfits, updated.Outfits)
+	}
+}
+
+func TestSkippedOutfits_Moving(t *testing.T) {
+	until := time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)
+	skipped := NewSkippedOutfits().Skipping("casual", "tee.avatar", until)
+
+	moved := skipped.Moving(OutfitLocation{Category: "casual", FileName: "tee.avatar"}, OutfitLocation{Category: "work", FileName: "shirt.avatar"})
+	if _, ok := moved.Outfits["casual"]; ok || !moved.Outfits["work"]["shirt.avatar"].Equal(until) {
+		t.Errorf("Moving() = %v, want the skip to follow the outfit", moved.Outfits)
+	}
+}
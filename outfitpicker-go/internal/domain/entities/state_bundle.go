@@ -0,0 +1,63 @@
+package entities
+
+import "time"
+
+// StateBundleManifestName is the file at the root of a state bundle that
+// describes it.
+const StateBundleManifestName = "manifest.json"
+
+// StateBundleFormat is the version of the state bundle layout. Bundles of a
+// newer format are refused, since their files may not read correctly.
+const StateBundleFormat = 1
+
+// Files of a state bundle, besides its manifest.
+const (
+	StateBundleConfig     = "config.json"
+	StateBundleCache      = "cache.json"
+	StateBundleHistory    = "history.json"
+	StateBundleWearLog    = "wear_log.json"
+	StateBundleMetadata   = "metadata.json"
+	StateBundleWeights    = "weights.json"
+	StateBundleFavorites  = "favorites.json"
+	StateBundleSkipped    = "skipped.json"
+	StateBundleLaundry    = "laundry.json"
+	StateBundleArrivals   = "arrivals.json"
+	StateBundleLastPicked = "category_picks.json"
+	StateBundlePlan       = "plan.json"
+	StateBundleChallenge  = "challenge.json"
+)
+
+// StateBundleManifest describes a state bundle: the configuration, rotation
+// cache, history, outfit metadata and the rest of the state of a wardrobe,
+// for moving them to another machine. The outfit files themselves are not
+// part of it.
+type StateBundleManifest struct {
+	Format    int       `json:"format"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Version is the version of outfitpicker that wrote the bundle.
+	Version string   `json:"version"`
+	Files   []string `json:"files"`
+}
+
+// StateBundle is the state a state bundle carries.
+type StateBundle struct {
+	Config     *Config
+	Cache      OutfitCache
+	History    SelectionHistory
+	WearLog    WearLog
+	Metadata   MetadataIndex
+	Weights    OutfitWeights
+	Favorites  Favorites
+	Skipped    SkippedOutfits
+	Laundry    Laundry
+	Arrivals   OutfitArrivals
+	LastPicked CategoryPicks
+	Plan       OutfitPlan
+	Challenge  Challenge
+}
+
+// StateBundleFile is one file of a state bundle.
+type StateBundleFile struct {
+	Name string
+	Data []byte
+}
@@ -0,0 +1,25 @@
+package entities
+
+// TriageAction is what to do with a newly detected outfit.
+type TriageAction string
+
+const (
+	// TriageAccept records the outfit as known.
+	TriageAccept TriageAction = "accept"
+	// TriageTag records the outfit as known and tags it.
+	TriageTag TriageAction = "tag"
+	// TriageExclude adds an ignore pattern so the outfit is no longer
+	// scanned.
+	TriageExclude TriageAction = "exclude"
+	// TriageArchive moves the outfit out of the wardrobe into its archive.
+	TriageArchive TriageAction = "archive"
+)
+
+// TriageDecision is the action chosen for one new outfit.
+type TriageDecision struct {
+	Category string       `json:"category"`
+	FileName string       `json:"fileName"`
+	Action   TriageAction `json:"action"`
+	// Tags are added to the outfit's metadata when Action is TriageTag.
+	Tags []string `json:"tags,omitempty"`
+}
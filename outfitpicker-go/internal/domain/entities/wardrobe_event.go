@@ -0,0 +1,22 @@
+package entities
+
+import "time"
+
+// Wardrobe event kinds.
+const (
+	// WardrobeEventPick is an outfit picked from its category.
+	WardrobeEventPick = "pick"
+	// WardrobeEventRotationCompleted is a wear of the last unworn outfit of
+	// a category, completing its rotation.
+	WardrobeEventRotationCompleted = "rotation-completed"
+)
+
+// WardrobeEvent is something that happened to the wardrobe worth telling
+// others about, as recorded in the history and the wear log.
+type WardrobeEvent struct {
+	// Kind is one of the wardrobe event kinds.
+	Kind     string    `json:"kind"`
+	Category string    `json:"category"`
+	FileName string    `json:"fileName"`
+	At       time.Time `json:"at"`
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// WardrobeGrowth is how many outfits each category held at a series of
+// points in time, oldest first. Each point ends the period it counts, so an
+// outfit first seen at a point is counted from the next one.
+type WardrobeGrowth struct {
+	Points     []time.Time      `json:"points"`
+	Categories []CategoryGrowth `json:"categories"`
+	// Totals sums every category at each point.
+	Totals []int `json:"totals"`
+}
+
+// CategoryGrowth is the outfit count of one category at each point of a
+// WardrobeGrowth.
+type CategoryGrowth struct {
+	Category string `json:"category"`
+	Counts   []int  `json:"counts"`
+}
+
+// Change returns the difference between the last and first counts.
+func (g CategoryGrowth) Change() int {
+	if len(g.Counts) == 0 {
+		return 0
+	}
+	return g.Counts[len(g.Counts)-1] - g.Counts[0]
+}
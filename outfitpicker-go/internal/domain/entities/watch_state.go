@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// What watch does about a scheduled pick that was missed, as when the
+// machine was asleep when it was due.
+const (
+	// MissedPicksCatchUp picks once as soon as the miss is noticed. It is
+	// the default.
+	MissedPicksCatchUp = "catch-up"
+	// MissedPicksSkip reports the miss and waits for the next pick.
+	MissedPicksSkip = "skip"
+	// MissedPicksAsk asks whether to pick now, and skips when there is no
+	// terminal to ask on.
+	MissedPicksAsk = "ask"
+)
+
+// MissedPickPolicies lists what watch can do about a missed pick.
+func MissedPickPolicies() []string {
+	return []string{MissedPicksCatchUp, MissedPicksSkip, MissedPicksAsk}
+}
+
+// WatchState is the pick schedule of watch, kept so that a pick that came
+// due while the machine slept or watch was stopped is noticed afterwards.
+type WatchState struct {
+	// LastPickDue is when the last scheduled pick handled was due, whether
+	// it was made or skipped. It is zero before the first.
+	LastPickDue time.Time `json:"lastPickDue,omitzero"`
+	// Revision counts saves of the state file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
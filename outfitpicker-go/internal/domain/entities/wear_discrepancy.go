@@ -0,0 +1,32 @@
+package entities
+
+// DiscrepancyKind says which record an outfit's wear is missing from.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyCacheOnly marks an outfit worn in the cache with no wear
+	// event in its category's current rotation.
+	DiscrepancyCacheOnly DiscrepancyKind = "cache-only"
+	// DiscrepancyHistoryOnly marks an outfit with a wear event in its
+	// category's current rotation that the cache does not count as worn.
+	DiscrepancyHistoryOnly DiscrepancyKind = "history-only"
+)
+
+// WearDiscrepancy is an outfit the cache and the wear history disagree on.
+type WearDiscrepancy struct {
+	Category string          `json:"category"`
+	FileName string          `json:"fileName"`
+	Kind     DiscrepancyKind `json:"kind"`
+}
+
+// RepairSource names the record a repair treats as correct.
+type RepairSource string
+
+const (
+	// RepairFromHistory rewrites the cache's worn outfits from the wear
+	// history.
+	RepairFromHistory RepairSource = "history"
+	// RepairFromCache rewrites the current rotations in the wear history to
+	// match the cache.
+	RepairFromCache RepairSource = "cache"
+)
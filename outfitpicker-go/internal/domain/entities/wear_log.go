@@ -0,0 +1,209 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// FeedbackKind is a short reaction recorded against a wear event.
+type FeedbackKind string
+
+const (
+	FeedbackCompliment    FeedbackKind = "compliment"
+	FeedbackComfortable   FeedbackKind = "comfortable"
+	FeedbackUncomfortable FeedbackKind = "uncomfortable"
+	FeedbackPoorFit       FeedbackKind = "poor-fit"
+)
+
+var (
+	positiveFeedback = []FeedbackKind{FeedbackCompliment, FeedbackComfortable}
+	negativeFeedback = []FeedbackKind{FeedbackUncomfortable, FeedbackPoorFit}
+)
+
+// FeedbackKinds returns every recognized feedback kind.
+func FeedbackKinds() []FeedbackKind {
+	return slices.Concat(positiveFeedback, negativeFeedback)
+}
+
+// ParseFeedbackKind validates a feedback kind typed by the user.
+func ParseFeedbackKind(value string) (FeedbackKind, error) {
+	kind := FeedbackKind(value)
+	if !slices.Contains(FeedbackKinds(), kind) {
+		return "", errors.NewInvalidInputError(fmt.Sprintf("unknown feedback %q (want one of: %v)", value, FeedbackKinds()))
+	}
+	return kind, nil
+}
+
+// Sentiment returns +1 for positive feedback, -1 for negative feedback and 0
+// otherwise.
+func (k FeedbackKind) Sentiment() int {
+	switch {
+	case slices.Contains(positiveFeedback, k):
+		return 1
+	case slices.Contains(negativeFeedback, k):
+		return -1
+	default:
+		return 0
+	}
+}
+
+// WearEvent records one time an outfit was worn.
+type WearEvent struct {
+	Category string         `json:"category"`
+	FileName string         `json:"fileName"`
+	WornAt   time.Time      `json:"wornAt"`
+	Feedback []FeedbackKind `json:"feedback,omitempty"`
+	Note     string         `json:"note,omitempty"`
+	// CompletedRotation is set when this wear was the last unworn outfit of
+	// its category, which reset the category's rotation.
+	CompletedRotation bool `json:"completedRotation,omitempty"`
+	// OutfitID is the outfit's stable ID when outfit IDs are enabled.
+	OutfitID string `json:"outfitId,omitempty"`
+}
+
+// Score returns the sum of the event's feedback sentiments.
+func (e WearEvent) Score() int {
+	score := 0
+	for _, kind := range e.Feedback {
+		score += kind.Sentiment()
+	}
+	return score
+}
+
+// WearLog is the append-only list of wear events, oldest first.
+type WearLog struct {
+	Events []WearEvent `json:"events"`
+	// Revision counts saves of the log file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
+
+// Appending returns a new log with event added at the end.
+func (l WearLog) Appending(event WearEvent) WearLog {
+	return WearLog{Events: append(slices.Clone(l.Events), event), Revision: l.Revision}
+}
+
+// Last returns the most recent wear event. It reports false if the log is
+// empty.
+func (l WearLog) Last() (WearEvent, bool) {
+	if len(l.Events) == 0 {
+		return WearEvent{}, false
+	}
+	return l.Events[len(l.Events)-1], true
+}
+
+// DroppingLast returns a new log without the most recent event.
+func (l WearLog) DroppingLast() WearLog {
+	if len(l.Events) == 0 {
+		return l
+	}
+	return WearLog{Events: slices.Clone(l.Events[:len(l.Events)-1]), Revision: l.Revision}
+}
+
+// CurrentRotation returns the file names worn in a category since its last
+// completed rotation, oldest first.
+func (l WearLog) CurrentRotation(category string) []string {
+	var worn []string
+	for _, event := range l.Events {
+		if event.Category != category {
+			continue
+		}
+		if event.CompletedRotation {
+			worn = nil
+			continue
+		}
+		worn = append(worn, event.FileName)
+	}
+	return worn
+}
+
+// EventsFor returns the wear events of one outfit, oldest first.
+func (l WearLog) EventsFor(category, fileName string) []WearEvent {
+	var events []WearEvent
+	for _, event := range l.Events {
+		if event.Category == category && event.FileName == fileName {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// AddingFeedback returns a new log with kind, and note if not empty, added to
+// the outfit's most recent wear event. It reports false if the outfit has
+// never been worn.
+func (l WearLog) AddingFeedback(category, fileName string, kind FeedbackKind, note string) (WearLog, bool) {
+	for i := len(l.Events) - 1; i >= 0; i-- {
+		event := l.Events[i]
+		if event.Category != category || event.FileName != fileName {
+			continue
+		}
+		if !slices.Contains(event.Feedback, kind) {
+			event.Feedback = append(slices.Clone(event.Feedback), kind)
+		}
+		if note != "" {
+			event.Note = note
+		}
+		events := slices.Clone(l.Events)
+		events[i] = event
+		return WearLog{Events: events, Revision: l.Revision}, true
+	}
+	return l, false
+}
+
+// LastCompliment returns when the outfit was last worn to a compliment.
+func (l WearLog) LastCompliment(category, fileName string) (time.Time, bool) {
+	for i := len(l.Events) - 1; i >= 0; i-- {
+		event := l.Events[i]
+		if event.Category == category && event.FileName == fileName && slices.Contains(event.Feedback, FeedbackCompliment) {
+			return event.WornAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// LastWorn returns when each outfit of a category that has been worn was
+// last worn, keyed by file name.
+func (l WearLog) LastWorn(category string) map[string]time.Time {
+	lastWorn := make(map[string]time.Time)
+	for _, event := range l.Events {
+		if event.Category == category && event.WornAt.After(lastWorn[event.FileName]) {
+			lastWorn[event.FileName] = event.WornAt
+		}
+	}
+	return lastWorn
+}
+
+// FeedbackScores returns the summed feedback sentiment of every outfit in a
+// category that has any feedback, keyed by file name.
+func (l WearLog) FeedbackScores(category string) map[string]int {
+	scores := make(map[string]int)
+	for _, event := range l.Events {
+		if event.Category == category && len(event.Feedback) > 0 {
+			scores[event.FileName] += event.Score()
+		}
+	}
+	return scores
+}
+
+// Query returns one page of the wear events that match query, newest first.
+// query must already be normalized.
+func (l WearLog) Query(query HistoryQuery) Page[WearEvent] {
+	collector := newPageCollector[WearEvent](query)
+	for i := len(l.Events) - 1; i >= 0; i-- {
+		if event := l.Events[i]; query.Matches(event.Category, event.WornAt) {
+			collector.add(event)
+		}
+	}
+	return collector.page
+}
+
+// WithoutCategory returns a new log without the wears from category.
+func (l WearLog) WithoutCategory(category string) WearLog {
+	l.Events = slices.DeleteFunc(slices.Clone(l.Events), func(event WearEvent) bool {
+		return event.Category == category
+	})
+	return l
+}
@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseFeedbackKind(t *testing.T) {
+	if kind, err := ParseFeedbackKind("compliment"); err != nil || kind != FeedbackCompliment {
+		t.Errorf("ParseFeedbackKind(compliment) = %v, %v", kind, err)
+	}
+	if _, err := ParseFeedbackKind("great"); err == nil {
+		t.Error("ParseFeedbackKind(great) succeeded")
+	}
+}
+
+func TestFeedbackKind_Sentiment(t *testing.T) {
+	tests := []struct {
+		kind FeedbackKind
+		want int
+	}{
+		{FeedbackCompliment, 1},
+		{FeedbackComfortable, 1},
+		{FeedbackUncomfortable, -1},
+		{FeedbackPoorFit, -1},
+		{FeedbackKind("other"), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.Sentiment(); got != tt.want {
+			t.Errorf("%s.Sentiment() = %d, want %d", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestWearLog_AddingFeedback(t *testing.T) {
+	first := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 7)
+	log := WearLog{}.
+		Appending(WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: first}).
+		Appending(WearEvent{Category: "work", FileName: "suit.avatar", WornAt: first}).
+		Appending(WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: second})
+
+	updated, ok := log.AddingFeedback("casual", "tee.avatar", FeedbackCompliment, "wedding")
+	if !ok {
+		t.Fatal("AddingFeedback() = false for a worn outfit")
+	}
+	if len(log.Events[2].Feedback) != 0 {
+		t.Error("AddingFeedback() modified the original log")
+	}
+	events := updated.EventsFor("casual", "tee.avatar")
+	if len(events[0].Feedback) != 0 || events[1].Feedback[0] != FeedbackCompliment || events[1].Note != "wedding" {
+		t.Errorf("feedback not attached to the latest event: %+v", events)
+	}
+	if at, ok := updated.LastCompliment("casual", "tee.avatar"); !ok || !at.Equal(second) {
+		t.Errorf("LastCompliment() = %v, %v; want %v", at, ok, second)
+	}
+
+	if _, ok := updated.AddingFeedback("casual", "never.avatar", FeedbackCompliment, ""); ok {
+		t.Error("AddingFeedback() = true for an outfit never worn")
+	}
+}
+
+func TestWearLog_FeedbackScores(t *testing.T) {
+	log := WearLog{Events: []WearEvent{
+		{Category: "casual", FileName: "tee.avatar", Feedback: []FeedbackKind{FeedbackCompliment, FeedbackComfortable}},
+		{Category: "casual", FileName: "tee.avatar", Feedback: []FeedbackKind{FeedbackPoorFit}},
+		{Category: "casual", FileName: "jeans.avatar", Feedback: []FeedbackKind{FeedbackUncomfortable}},
+		{Category: "casual", FileName: "shorts.avatar"},
+		{Category: "work", FileName: "suit.avatar", Feedback: []FeedbackKind{FeedbackCompliment}},
+	}}
+
+	scores := log.FeedbackScores("casual")
+	if len(scores) != 2 || scores["tee.avatar"] != 1 || scores["jeans.avatar"] != -1 {
+		t.Errorf("FeedbackScores() = %v", scores)
+	}
+}
+
+func TestWearLog_LastWorn(t *testing.T) {
+	day := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	log := WearLog{Events: []WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: day.AddDate(0, 0, 2)},
+		{Category: "casual", FileName: "tee.avatar", WornAt: day},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day.AddDate(0, 0, 1)},
+		{Category: "work", FileName: "suit.avatar", WornAt: day},
+	}}
+
+	lastWorn := log.LastWorn("casual")
+	if len(lastWorn) != 2 || !lastWorn["tee.avatar"].Equal(day.AddDate(0, 0, 2)) || !lastWorn["jeans.avatar"].Equal(day.AddDate(0, 0, 1)) {
+		t.Errorf("LastWorn() = %v", lastWorn)
+	}
+}
+
+func TestWearLog_Query(t *testing.T) {
+	day := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	var log WearLog
+	for i := range 25 {
+		category := "casual"
+		if i%5 == 0 {
+			category = "formal"
+		}
+		log = log.Appending(WearEvent{Category: category, FileName: "outfit.avatar", WornAt: day.AddDate(0, 0, i)})
+	}
+
+	tests := []struct {
+		name      string
+		query     HistoryQuery
+		wantTotal int
+		wantDays  []int
+	}{
+		{"first page, newest first", HistoryQuery{Limit: 3, Page: 1}, 25, []int{24, 23, 22}},
+		{"second page", HistoryQuery{Limit: 3, Page: 2}, 25, []int{21, 20, 19}},
+		{"last partial page", HistoryQuery{Limit: 10, Page: 3}, 25, []int{4, 3, 2, 1, 0}},
+		{"past the end", HistoryQuery{Limit: 10, Page: 4}, 25, nil},
+		{"category", HistoryQuery{Category: "formal", Limit: 2, Page: 2}, 5, []int{10, 5}},
+		{"since", HistoryQuery{Since: day.AddDate(0, 0, 22), Limit: 20, Page: 1}, 3, []int{24, 23, 22}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := log.Query(tt.query)
+			if page.Total != tt.wantTotal || len(page.Items) != len(tt.wantDays) {
+				t.Fatalf("Query() total = %d, items = %d; want %d, %d", page.Total, len(page.Items), tt.wantTotal, len(tt.wantDays))
+			}
+			for i, days := range tt.wantDays {
+				if want := day.AddDate(0, 0, days); !page.Items[i].WornAt.Equal(want) {
+					t.Errorf("Items[%d].WornAt = %v, want %v", i, page.Items[i].WornAt, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHistoryQuery_Normalized(t *testing.T) {
+	query, err := HistoryQuery{}.Normalized()
+	if err != nil || query.Limit != DefaultHistoryLimit || query.Page != 1 {
+		t.Errorf("Normalized() = %+v, %v", query, err)
+	}
+	for _, bad := range []HistoryQuery{{Limit: -1}, {Limit: MaxHistoryLimit + 1}, {Page: -2}} {
+		if _, err := bad.Normalized(); err == nil {
+			t.Errorf("Normalized(%+v) succeeded", bad)
+		}
+	}
+}
+
+func TestPage_PageCount(t *testing.T) {
+	page := Page[int]{Page: 2, Limit: 10, Total: 21}
+	if page.PageCount() != 3 || !page.HasMore() {
+		t.Errorf("PageCount() = %d, HasMore() = %v", page.PageCount(), page.HasMore())
+	}
+}
+
+func TestWearLog_DroppingLast(t *testing.T) {
+	if _, ok := (WearLog{}).Last(); ok {
+		t.Error("Last() on an empty log reported an event")
+	}
+	log := WearLog{Revision: 3}.
+		Appending(WearEvent{Category: "casual", FileName: "tee.avatar"}).
+		Appending(WearEvent{Category: "casual", FileName: "jeans.avatar"})
+
+	if last, ok := log.Last(); !ok || last.FileName != "jeans.avatar" {
+		t.Errorf("Last() = %+v, %v", last, ok)
+	}
+	dropped := log.DroppingLast()
+	if len(dropped.Events) != 1 || dropped.Events[0].FileName != "tee.avatar" || dropped.Revision != 3 {
+		t.Errorf("DroppingLast() = %+v", dropped)
+	}
+	if len(log.Events) != 2 {
+		t.Error("DroppingLast() modified the original log")
+	}
+}
+
+func TestWearLog_CurrentRotation(t *testing.T) {
+	log := WearLog{Events: []WearEvent{
+		{Category: "casual", FileName: "tee.avatar"},
+		{Category: "casual", FileName: "jeans.avatar", CompletedRotation: true},
+		{Category: "casual", FileName: "jeans.avatar"},
+		{Category: "work", FileName: "suit.avatar"},
+		{Category: "casual", FileName: "hoodie.avatar"},
+	}}
+
+	if got, want := log.CurrentRotation("casual"), []string{"jeans.avatar", "hoodie.avatar"}; !slices.Equal(got, want) {
+		t.Errorf("CurrentRotation(casual) = %v, want %v", got, want)
+	}
+	if got := log.CurrentRotation("formal"); got != nil {
+		t.Errorf("CurrentRotation(formal) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// Weather conditions a forecast can call for.
+const (
+	WeatherRain = "rain"
+	WeatherHeat = "heat"
+	WeatherCold = "cold"
+)
+
+// WeatherLocation is where forecasts are looked up.
+type WeatherLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ParseWeatherLocation parses a location typed by the user as LAT,LON in
+// decimal degrees.
+func ParseWeatherLocation(value string) (WeatherLocation, error) {
+	latitude, longitude, ok := strings.Cut(value, ",")
+	lat, latErr := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	lon, lonErr := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if !ok || latErr != nil || lonErr != nil {
+		return WeatherLocation{}, errors.NewInvalidInputError(fmt.Sprintf("location must be LAT,LON in decimal degrees, got %q", value))
+	}
+	return WeatherLocation{Latitude: lat, Longitude: lon}, nil
+}
+
+// String returns the location as ParseWeatherLocation accepts it.
+func (l WeatherLocation) String() string {
+	return strconv.FormatFloat(l.Latitude, 'f', -1, 64) + "," + strconv.FormatFloat(l.Longitude, 'f', -1, 64)
+}
+
+// WeatherSettings sets where the forecast is looked up and which categories
+// and tagged outfits are unsuitable for which weather conditions, for
+// pick --weather.
+type WeatherSettings struct {
+	// Location is where the forecast is looked up; nil turns weather-aware
+	// picks off.
+	Location *WeatherLocation `json:"location,omitempty"`
+	// Categories maps category names to the conditions they are unsuitable
+	// for.
+	Categories map[string][]string `json:"categories,omitempty"`
+	// Tags maps outfit tags to the conditions they are unsuitable for.
+	Tags map[string][]string `json:"tags,omitempty"`
+}
+
+// SettingCategory returns settings with the category's unsuitable conditions
+// replaced. No conditions remove the category's entry.
+func (s WeatherSettings) SettingCategory(category string, conditions []string) WeatherSettings {
+	s.Categories = settingValues(s.Categories, category, conditions)
+	return s
+}
+
+// SettingTag returns settings with the tag's unsuitable conditions replaced.
+// No conditions remove the tag's entry.
+func (s WeatherSettings) SettingTag(tag string, conditions []string) WeatherSettings {
+	s.Tags = settingValues(s.Tags, tag, conditions)
+	return s
+}
+
+// Forecast is the weather expected on one day at a location.
+type Forecast struct {
+	Location WeatherLocation `json:"location"`
+	// Date is the day forecast, at midnight UTC.
+	Date time.Time `json:"date"`
+	// MinTemperature and MaxTemperature are in degrees Celsius.
+	MinTemperature float64 `json:"minTemperature"`
+	MaxTemperature float64 `json:"maxTemperature"`
+	// PrecipitationChance is the highest chance of rain in the day, in
+	// percent.
+	PrecipitationChance int `json:"precipitationChance"`
+	// FetchedAt is when the forecast was fetched, for caching it.
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// ForecastCache keeps the last forecast fetched, so picks made shortly
+// after each other do not fetch it again.
+type ForecastCache struct {
+	Forecast *Forecast `json:"forecast,omitempty"`
+	// Revision counts saves of the cache file and is used to reject saves
+	// based on stale data.
+	Revision int `json:"revision,omitempty"`
+}
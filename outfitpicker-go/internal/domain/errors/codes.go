@@ -0,0 +1,76 @@
+package errors
+
+import "errors"
+
+// Code is the stable numeric code of an error. The CLI exits with it and
+// includes it in JSON error output, so scripts need not parse messages.
+// Codes never change meaning once published.
+type Code int
+
+// Error codes, grouped by tens: configuration, wardrobe contents, input,
+// state and storage.
+const (
+	// CodeUnknown is any error without a more specific code.
+	CodeUnknown Code = 1
+
+	CodeConfigurationNotFound  Code = 10
+	CodeInvalidConfiguration   Code = 11
+	CodeAmbiguousConfiguration Code = 12
+
+	CodeNoOutfitsAvailable Code = 20
+	CodeCategoryNotFound   Code = 21
+	CodeEmptyCategories    Code = 22
+
+	CodeInvalidInput Code = 30
+
+	CodeMaintenanceMode Code = 40
+	CodeConflict        Code = 41
+	CodeNothingToUndo   Code = 42
+	CodeRateLimited     Code = 43
+
+	CodeFileSystem Code = 50
+	CodeCache      Code = 51
+	CodeIntegrity  Code = 52
+)
+
+// CodeOf returns the code of err, CodeUnknown for errors this package does
+// not define.
+func CodeOf(err error) Code {
+	var invalidInput *InvalidInputError
+	var conflict *ConflictError
+	var emptyCategories *EmptyCategoriesError
+	var integrity *IntegrityError
+	var rateLimited *RateLimitedError
+	var ambiguous *AmbiguousConfigurationError
+	switch {
+	case errors.Is(err, ErrConfigurationNotFound):
+		return CodeConfigurationNotFound
+	case errors.As(err, &ambiguous):
+		return CodeAmbiguousConfiguration
+	case errors.Is(err, ErrInvalidConfiguration), isOneOf(err, configErrors):
+		return CodeInvalidConfiguration
+	case errors.Is(err, ErrNoOutfitsAvailable):
+		return CodeNoOutfitsAvailable
+	case errors.Is(err, ErrCategoryNotFound):
+		return CodeCategoryNotFound
+	case errors.As(err, &emptyCategories):
+		return CodeEmptyCategories
+	case errors.As(err, &invalidInput):
+		return CodeInvalidInput
+	case errors.Is(err, ErrMaintenanceMode):
+		return CodeMaintenanceMode
+	case errors.As(err, &conflict):
+		return CodeConflict
+	case errors.Is(err, ErrNothingToUndo):
+		return CodeNothingToUndo
+	case errors.As(err, &rateLimited):
+		return CodeRateLimited
+	case errors.As(err, &integrity):
+		return CodeIntegrity
+	case errors.Is(err, ErrCache), isOneOf(err, cacheErrors):
+		return CodeCache
+	case errors.Is(err, ErrFileSystem), isOneOf(err, fileSystemErrors):
+		return CodeFileSystem
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{"configuration not found", ErrConfigurationNotFound, 10},
+		{"invalid configuration", ErrInvalidConfiguration, 11},
+		{"config validation", ErrPathTraversal, 11},
+		{"ambiguous configuration", NewAmbiguousConfigurationError([]string{"/a", "/b"}), 12},
+		{"no outfits", ErrNoOutfitsAvailable, 20},
+		{"category not found", ErrCategoryNotFound, 21},
+		{"empty categories", NewEmptyCategoriesError([]string{"beach"}), 22},
+		{"invalid input", NewInvalidInputError("bad"), 30},
+		{"maintenance mode", ErrMaintenanceMode, 40},
+		{"conflict", NewConflictError("cache.json", 1, 2), 41},
+		{"nothing to undo", ErrNothingToUndo, 42},
+		{"rate limited", NewRateLimitedError("casual", 3, time.Time{}), 43},
+		{"file system", ErrPermissionDenied, 50},
+		{"cache", ErrCacheDecoding, 51},
+		{"integrity", NewIntegrityError("cache.json"), 52},
+		{"wrapped", Wrap(fmt.Errorf("load: %w", ErrDirectoryNotFound)), 50},
+		{"foreign", errors.New("something else"), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Top-level errors
@@ -13,15 +15,31 @@ var (
 	ErrFileSystem            = errors.New("file system error")
 	ErrCache                 = errors.New("cache error")
 	ErrInvalidConfiguration  = errors.New("invalid configuration")
+	ErrMaintenanceMode       = errors.New("wardrobe is in maintenance mode")
+	ErrNothingToUndo         = errors.New("nothing to undo")
 )
 
 // Config errors
 var (
-	ErrPathTraversal     = errors.New("path traversal not allowed")
-	ErrPathTooLong       = errors.New("path too long")
-	ErrRestrictedPath    = errors.New("restricted path")
-	ErrSymlinkNotAllowed = errors.New("symlink not allowed")
-	ErrInvalidCharacters = errors.New("invalid characters")
+	ErrPathTraversal              = errors.New("path traversal not allowed")
+	ErrPathTooLong                = errors.New("path too long")
+	ErrRestrictedPath             = errors.New("restricted path")
+	ErrSymlinkNotAllowed          = errors.New("symlink not allowed")
+	ErrInvalidCharacters          = errors.New("invalid characters")
+	ErrInvalidDecoration          = errors.New("invalid category decoration")
+	ErrInvalidSelection           = errors.New("invalid selection preferences")
+	ErrInvalidLabel               = errors.New("invalid category label")
+	ErrInvalidPattern             = errors.New("invalid ignore pattern")
+	ErrInvalidReportSettings      = errors.New("invalid report settings")
+	ErrInvalidHealthThresholds    = errors.New("invalid health thresholds")
+	ErrInvalidSeasons             = errors.New("invalid season assignments")
+	ErrInvalidCategoryOrder       = errors.New("invalid category order")
+	ErrInvalidAccessibility       = errors.New("invalid accessibility settings")
+	ErrInvalidOutfitIDs           = errors.New("invalid outfit ID settings")
+	ErrInvalidPermissions         = errors.New("invalid category permissions")
+	ErrInvalidWeather             = errors.New("invalid weather settings")
+	ErrInvalidCategoryDescription = errors.New("invalid category description")
+	ErrInvalidOutfitSidecar       = errors.New("invalid outfit metadata file")
 )
 
 // File system errors
@@ -31,6 +49,7 @@ var (
 	ErrPermissionDenied  = errors.New("permission denied")
 	ErrInvalidPath       = errors.New("invalid path")
 	ErrOperationFailed   = errors.New("operation failed")
+	ErrLockTimeout       = errors.New("timed out waiting for file lock")
 )
 
 // Cache errors
@@ -58,6 +77,22 @@ func NewInvalidInputError(message string) error {
 	return &InvalidInputError{Message: message}
 }
 
+// ConflictError reports that persisted state changed between loading and
+// saving it. Callers should reload, reapply their change and save again.
+type ConflictError struct {
+	Resource string
+	Expected int
+	Actual   int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s was modified concurrently (expected revision %d, found %d)", e.Resource, e.Expected, e.Actual)
+}
+
+func NewConflictError(resource string, expected, actual int) error {
+	return &ConflictError{Resource: resource, Expected: expected, Actual: actual}
+}
+
 type RotationCompletedError struct {
 	Category string
 }
@@ -70,14 +105,80 @@ func NewRotationCompletedError(category string) error {
 	return &RotationCompletedError{Category: category}
 }
 
+// EmptyCategoriesError reports that a pick across all categories refused to
+// run because some categories have no outfits.
+type EmptyCategoriesError struct {
+	Categories []string
+}
+
+func (e *EmptyCategoriesError) Error() string {
+	return fmt.Sprintf("categories without outfits: %s", strings.Join(e.Categories, ", "))
+}
+
+func NewEmptyCategoriesError(categories []string) error {
+	return &EmptyCategoriesError{Categories: categories}
+}
+
+// RateLimitedError reports that a category has been picked from as often
+// as its daily pick limit allows. NextAllowed is when the next pick from it
+// will be.
+type RateLimitedError struct {
+	Category    string
+	Limit       int
+	NextAllowed time.Time
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("'%s' has been picked from %d times today, its daily limit; next pick allowed at %s", e.Category, e.Limit, e.NextAllowed.Format("2006-01-02 15:04"))
+}
+
+func NewRateLimitedError(category string, limit int, nextAllowed time.Time) error {
+	return &RateLimitedError{Category: category, Limit: limit, NextAllowed: nextAllowed}
+}
+
+// IntegrityError reports that a state file does not match its signature:
+// it was changed outside outfitpicker, or damaged, since it was last saved.
+type IntegrityError struct {
+	File string
+}
+
+func (e *IntegrityError) Error() string {
+	return fmt.Sprintf("%s failed its integrity check: it was changed outside outfitpicker or is damaged", e.File)
+}
+
+func NewIntegrityError(file string) error {
+	return &IntegrityError{File: file}
+}
+
+// AmbiguousConfigurationError reports that a configuration was found in
+// more than one place, so which to use cannot be told. Paths are the app
+// directories holding one, most preferred first.
+type AmbiguousConfigurationError struct {
+	Paths []string
+}
+
+func (e *AmbiguousConfigurationError) Error() string {
+	return fmt.Sprintf("a configuration was found in more than one place (%s); pass --config to choose one", strings.Join(e.Paths, ", "))
+}
+
+func NewAmbiguousConfigurationError(paths []string) error {
+	return &AmbiguousConfigurationError{Paths: paths}
+}
+
 var (
 	topLevelErrors = []error{
 		ErrConfigurationNotFound, ErrCategoryNotFound, ErrNoOutfitsAvailable,
-		ErrFileSystem, ErrCache, ErrInvalidConfiguration,
+		ErrFileSystem, ErrCache, ErrInvalidConfiguration, ErrMaintenanceMode,
+		ErrNothingToUndo,
 	}
 	configErrors = []error{
 		ErrPathTraversal, ErrPathTooLong, ErrRestrictedPath,
-		ErrSymlinkNotAllowed, ErrInvalidCharacters,
+		ErrSymlinkNotAllowed, ErrInvalidCharacters, ErrInvalidDecoration,
+		ErrInvalidSelection, ErrInvalidLabel, ErrInvalidPattern,
+		ErrInvalidReportSettings, ErrInvalidHealthThresholds, ErrInvalidSeasons,
+		ErrInvalidCategoryOrder, ErrInvalidAccessibility, ErrInvalidOutfitIDs,
+		ErrInvalidPermissions, ErrInvalidWeather, ErrInvalidCategoryDescription,
+		ErrInvalidOutfitSidecar,
 	}
 	cacheErrors = []error{
 		ErrCacheEncoding, ErrCacheDecoding, ErrInvalidData,
@@ -85,7 +186,7 @@ var (
 	}
 	fileSystemErrors = []error{
 		ErrFileNotFound, ErrDirectoryNotFound, ErrPermissionDenied,
-		ErrInvalidPath, ErrOperationFailed,
+		ErrInvalidPath, ErrOperationFailed, ErrLockTimeout,
 	}
 )
 
@@ -113,11 +214,31 @@ func MapError(err error) error {
 		return err
 	}
 
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		return err
+	}
+
 	var rotationCompleted *RotationCompletedError
 	if errors.As(err, &rotationCompleted) {
 		return err
 	}
 
+	var emptyCategories *EmptyCategoriesError
+	if errors.As(err, &emptyCategories) {
+		return err
+	}
+
+	var integrity *IntegrityError
+	if errors.As(err, &integrity) {
+		return err
+	}
+
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return err
+	}
+
 	if isOneOf(err, configErrors) {
 		return ErrInvalidConfiguration
 	}
@@ -132,3 +253,13 @@ func MapError(err error) error {
 
 	return ErrFileSystem
 }
+
+// Wrap maps err like MapError but keeps the original error in the chain so
+// its message and identity survive the conversion.
+func Wrap(err error) error {
+	mapped := MapError(err)
+	if mapped == err {
+		return err
+	}
+	return fmt.Errorf("%w: %w", mapped, err)
+}
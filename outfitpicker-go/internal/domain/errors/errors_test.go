@@ -2,7 +2,9 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestOutfitPickerError_Error(t *testing.T) {
@@ -16,6 +18,8 @@ func TestOutfitPickerError_Error(t *testing.T) {
 		{ErrFileSystem, "file system error"},
 		{ErrCache, "cache error"},
 		{ErrInvalidConfiguration, "invalid configuration"},
+		{ErrMaintenanceMode, "wardrobe is in maintenance mode"},
+		{ErrNothingToUndo, "nothing to undo"},
 	}
 
 	for _, tt := range tests {
@@ -43,6 +47,38 @@ func TestNewRotationCompletedError(t *testing.T) {
 	}
 }
 
+func TestNewConflictError(t *testing.T) {
+	err := NewConflictError("config.json", 3, 4)
+	want := "config.json was modified concurrently (expected revision 3, found 4)"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestNewEmptyCategoriesError(t *testing.T) {
+	err := NewEmptyCategoriesError([]string{"beach", "docs"})
+	want := "categories without outfits: beach, docs"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestNewIntegrityError(t *testing.T) {
+	err := NewIntegrityError("cache.json")
+	want := "cache.json failed its integrity check: it was changed outside outfitpicker or is damaged"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
+func TestNewRateLimitedError(t *testing.T) {
+	err := NewRateLimitedError("casual", 3, time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC))
+	want := "'casual' has been picked from 3 times today, its daily limit; next pick allowed at 2024-06-02 00:00"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %v, want %v", got, want)
+	}
+}
+
 func TestMapError(t *testing.T) {
 	tests := []struct {
 		name string
@@ -53,6 +89,10 @@ func TestMapError(t *testing.T) {
 		{"already top-level", ErrCategoryNotFound, ErrCategoryNotFound},
 		{"invalid input", NewInvalidInputError("test"), NewInvalidInputError("test")},
 		{"rotation completed", NewRotationCompletedError("casual"), NewRotationCompletedError("casual")},
+		{"conflict", NewConflictError("config.json", 1, 2), NewConflictError("config.json", 1, 2)},
+		{"empty categories", NewEmptyCategoriesError([]string{"beach"}), NewEmptyCategoriesError([]string{"beach"})},
+		{"integrity", NewIntegrityError("config.json"), NewIntegrityError("config.json")},
+		{"rate limited", NewRateLimitedError("casual", 1, time.Time{}), NewRateLimitedError("casual", 1, time.Time{})},
 	}
 
 	configErrors := []struct {
@@ -64,6 +104,18 @@ func TestMapError(t *testing.T) {
 		{"restricted path", ErrRestrictedPath},
 		{"symlink", ErrSymlinkNotAllowed},
 		{"invalid chars", ErrInvalidCharacters},
+		{"invalid decoration", ErrInvalidDecoration},
+		{"invalid selection", ErrInvalidSelection},
+		{"invalid label", ErrInvalidLabel},
+		{"invalid pattern", ErrInvalidPattern},
+		{"invalid report settings", ErrInvalidReportSettings},
+		{"invalid health thresholds", ErrInvalidHealthThresholds},
+		{"invalid seasons", ErrInvalidSeasons},
+		{"invalid category order", ErrInvalidCategoryOrder},
+		{"invalid accessibility", ErrInvalidAccessibility},
+		{"invalid outfit IDs", ErrInvalidOutfitIDs},
+		{"invalid permissions", ErrInvalidPermissions},
+		{"invalid weather", ErrInvalidWeather},
 	}
 	for _, ce := range configErrors {
 		tests = append(tests, struct {
@@ -100,6 +152,7 @@ func TestMapError(t *testing.T) {
 		{"permission", ErrPermissionDenied},
 		{"invalid path", ErrInvalidPath},
 		{"operation failed", ErrOperationFailed},
+		{"lock timeout", ErrLockTimeout},
 	}
 	for _, fe := range fsErrors {
 		tests = append(tests, struct {
@@ -130,3 +183,31 @@ func TestMapError(t *testing.T) {
 		})
 	}
 }
+
+func TestWrap(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		if err := Wrap(nil); err != nil {
+			t.Errorf("Wrap(nil) = %v, want nil", err)
+		}
+	})
+
+	t.Run("top-level error is returned unchanged", func(t *testing.T) {
+		if err := Wrap(ErrMaintenanceMode); err != ErrMaintenanceMode {
+			t.Errorf("Wrap() = %v, want %v", err, ErrMaintenanceMode)
+		}
+	})
+
+	t.Run("lower-level error keeps its identity", func(t *testing.T) {
+		original := fmt.Errorf("%w: /outfits", ErrDirectoryNotFound)
+		err := Wrap(original)
+		if !errors.Is(err, ErrFileSystem) {
+			t.Errorf("Wrap() = %v, want it to match ErrFileSystem", err)
+		}
+		if !errors.Is(err, ErrDirectoryNotFound) {
+			t.Errorf("Wrap() = %v, want it to match ErrDirectoryNotFound", err)
+		}
+		if want := "file system error: directory not found: /outfits"; err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+	})
+}
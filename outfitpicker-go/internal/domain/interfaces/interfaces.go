@@ -0,0 +1,273 @@
+// Package interfaces defines the ports the application layer depends on.
+// Infrastructure packages provide the production implementations.
+package interfaces
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// CategoryScanner discovers categories and outfit files on disk. Scans
+// stop with ctx.Err() once ctx is done.
+type CategoryScanner interface {
+	// ScanCategories combines the categories of every root, naming those
+	// whose name an earlier root already has with entities.RootCategoryName.
+	ScanCategories(ctx context.Context, roots []string, excludedCategories map[string]bool, policy entities.ScanPolicy) ([]entities.CategoryInfo, error)
+	GetOutfits(ctx context.Context, categoryPath string, policy entities.ScanPolicy) ([]entities.FileEntry, error)
+	// ReadColorFiles returns the colors in the color files of the category,
+	// keyed by the outfit file each describes.
+	ReadColorFiles(ctx context.Context, categoryPath string) (map[string][]string, error)
+	// ReadSidecars returns the sidecar metadata files of the category,
+	// keyed by the outfit file each describes.
+	ReadSidecars(ctx context.Context, categoryPath string) (map[string]entities.OutfitSidecar, error)
+}
+
+// ConfigService persists the application configuration.
+type ConfigService interface {
+	Load() (*entities.Config, error)
+	Save(config *entities.Config) error
+	Delete() error
+	Path() (string, error)
+}
+
+// CacheService persists rotation state.
+type CacheService interface {
+	Load() (entities.OutfitCache, error)
+	Save(cache entities.OutfitCache) error
+	// UpdateCategory applies update to a single category's state, merging
+	// with concurrent changes to other categories.
+	UpdateCategory(name string, update func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error)) error
+	Delete() error
+	Path() (string, error)
+}
+
+// MaintenanceStore persists the maintenance lock.
+type MaintenanceStore interface {
+	Load() (entities.MaintenanceState, error)
+	Save(state entities.MaintenanceState) error
+}
+
+// MetadataStore persists user-supplied outfit metadata.
+type MetadataStore interface {
+	Load() (entities.MetadataIndex, error)
+	Save(index entities.MetadataIndex) error
+}
+
+// WeightStore persists user-assigned outfit pick weights.
+type WeightStore interface {
+	Load() (entities.OutfitWeights, error)
+	Save(weights entities.OutfitWeights) error
+}
+
+// FavoritesStore persists the outfits marked as favorites.
+type FavoritesStore interface {
+	Load() (entities.Favorites, error)
+	Save(favorites entities.Favorites) error
+}
+
+// SkippedOutfitsStore persists the outfits left out of picks for a while.
+type SkippedOutfitsStore interface {
+	Load() (entities.SkippedOutfits, error)
+	Save(skipped entities.SkippedOutfits) error
+}
+
+// LaundryStore persists which outfits are clean, worn or in the laundry.
+type LaundryStore interface {
+	Load() (entities.Laundry, error)
+	Save(laundry entities.Laundry) error
+}
+
+// ArrivalStore persists when outfits were first seen.
+type ArrivalStore interface {
+	Load() (entities.OutfitArrivals, error)
+	Save(arrivals entities.OutfitArrivals) error
+}
+
+// CategoryPickStore persists when each category was last picked from.
+type CategoryPickStore interface {
+	Load() (entities.CategoryPicks, error)
+	Save(picks entities.CategoryPicks) error
+}
+
+// LatestPickStore persists the last pick of every category for widgets.
+type LatestPickStore interface {
+	Load() (entities.LatestPicks, error)
+	Save(picks entities.LatestPicks) error
+}
+
+// ChallengeStore persists the current or last capsule challenge.
+type ChallengeStore interface {
+	Load() (entities.Challenge, error)
+	Save(challenge entities.Challenge) error
+}
+
+// PlanStore persists the outfits planned ahead of time.
+type PlanStore interface {
+	Load() (entities.OutfitPlan, error)
+	Save(plan entities.OutfitPlan) error
+}
+
+// ForecastStore persists the last weather forecast fetched.
+type ForecastStore interface {
+	Load() (entities.ForecastCache, error)
+	Save(cache entities.ForecastCache) error
+}
+
+// WatchStateStore persists the pick schedule of watch.
+type WatchStateStore interface {
+	Load() (entities.WatchState, error)
+	Save(state entities.WatchState) error
+}
+
+// CommandHistoryStore persists the recent runs of the program.
+type CommandHistoryStore interface {
+	Load() (entities.CommandHistory, error)
+	Save(history entities.CommandHistory) error
+}
+
+// WeatherProvider looks up weather forecasts.
+type WeatherProvider interface {
+	// Forecast returns the weather expected at location on the day of date.
+	// The lookup is abandoned once ctx is done.
+	Forecast(ctx context.Context, location entities.WeatherLocation, date time.Time) (entities.Forecast, error)
+}
+
+// ChatService sends messages to a chat as a bot and reads the messages
+// sent to the bot, authenticating with the bot's token.
+type ChatService interface {
+	// Send posts text to the chat. It is abandoned once ctx is done.
+	Send(ctx context.Context, token, chatID, text string) error
+	// Messages returns the messages sent to the bot with an update ID of at
+	// least offset, oldest first, and confirms every message before offset
+	// so it is not returned again. It is abandoned once ctx is done.
+	Messages(ctx context.Context, token string, offset int64) ([]entities.ChatMessage, error)
+}
+
+// WearLogStore persists the log of wear events and their feedback.
+type WearLogStore interface {
+	Load() (entities.WearLog, error)
+	Save(log entities.WearLog) error
+	// Query returns one page of wear events, newest first. The query must be
+	// normalized.
+	Query(query entities.HistoryQuery) (entities.Page[entities.WearEvent], error)
+}
+
+// SelectionHistoryStore persists the history of picks.
+type SelectionHistoryStore interface {
+	Load() (entities.SelectionHistory, error)
+	Save(history entities.SelectionHistory) error
+	// Query returns one page of records, newest first. The query must be
+	// normalized.
+	Query(query entities.HistoryQuery) (entities.Page[entities.SelectionRecord], error)
+}
+
+// Mailer delivers email through an SMTP server.
+type Mailer interface {
+	Send(server entities.SMTPSettings, message entities.MailMessage) error
+}
+
+// Scheduler installs a daily pick in the operating system's scheduler:
+// cron, launchd or the Windows Task Scheduler. There is at most one.
+type Scheduler interface {
+	// Install schedules pick, replacing any pick already scheduled.
+	Install(pick entities.ScheduledPick) error
+	// Remove unschedules the pick and reports whether there was one.
+	Remove() (bool, error)
+	// Installed returns the scheduled pick, or nil when there is none.
+	Installed() (*entities.ScheduledPick, error)
+}
+
+// OutfitArchiver moves outfit files out of the scanned wardrobe.
+type OutfitArchiver interface {
+	// Archive moves an outfit out of the category directory into the
+	// archive of the root holding it and returns its new path.
+	Archive(categoryPath, fileName string) (string, error)
+	// ArchiveCategory moves a whole category into the archive of the root
+	// holding it and returns the archive directory.
+	ArchiveCategory(categoryPath string) (string, error)
+}
+
+// Trash moves files to the user's trash, from which they can be restored.
+type Trash interface {
+	// Trash moves the file or directory at path to the trash and returns
+	// its path there.
+	Trash(path string) (string, error)
+}
+
+// CategoryCreator makes the directories of new categories.
+type CategoryCreator interface {
+	// Create makes the directory of a new category, failing if it exists.
+	Create(categoryDir string) error
+	// Copy copies the outfit file at sourcePath into categoryDir under the
+	// same name, never overwriting a file already there.
+	Copy(sourcePath, categoryDir string) error
+}
+
+// OutfitHasher identifies outfit files by their content.
+type OutfitHasher interface {
+	// Hash returns a digest of the content of the file at path.
+	Hash(path string) (string, error)
+}
+
+// ArchiveImporter extracts outfit files from zip and tar archives.
+type ArchiveImporter interface {
+	// Import extracts the archive's outfits into categoryDir, creating it if
+	// needed, without overwriting existing files. visited, when not nil, is
+	// called with the name of each entry once it has been handled.
+	Import(archivePath, categoryDir string, visited func(entry string)) (entities.ArchiveImport, error)
+}
+
+// StateBundleArchive reads and writes state bundles.
+type StateBundleArchive interface {
+	// Write writes files to w as a bundle, in order, each modified at.
+	Write(w io.Writer, files []entities.StateBundleFile, modified time.Time) error
+	// Read returns the files of the bundle at path, keyed by name. It fails
+	// with ErrFileNotFound when path does not exist and with an
+	// InvalidInputError when it is not a bundle.
+	Read(path string) (map[string][]byte, error)
+	// MissingRoots returns those of roots that are not directories here.
+	MissingRoots(roots []string) []string
+}
+
+// BackupStore keeps timestamped copies of state files.
+type BackupStore interface {
+	// Create copies those of paths that exist into a new backup made at.
+	Create(at time.Time, paths []string) (entities.Backup, error)
+	// List returns every backup, oldest first.
+	List() ([]entities.Backup, error)
+	// Restore copies the backup's files over the paths with the same names.
+	Restore(id string, paths []string) (entities.Backup, error)
+	Delete(id string) error
+}
+
+// ProfileStore keeps the named profiles and which one is active. Each named
+// profile other than entities.DefaultProfile has a directory of its own.
+type ProfileStore interface {
+	// List returns the names of the profiles with a directory, sorted.
+	List() ([]string, error)
+	Exists(name string) (bool, error)
+	// Delete removes the profile's directory with everything in it.
+	Delete(name string) error
+	LoadSelection() (entities.ProfileSelection, error)
+	SaveSelection(selection entities.ProfileSelection) error
+}
+
+// IntegrityStore signs the saved state files of every profile, so changes
+// made outside the app are detected when the files are loaded.
+type IntegrityStore interface {
+	Enabled() (bool, error)
+	// Enable creates the signing key if there is none and signs every state
+	// file.
+	Enable() error
+	// Disable stops signing and removes the signatures.
+	Disable() error
+	// Verify returns the state files whose signature is missing or does not
+	// match, relative to the app directory.
+	Verify() ([]string, error)
+	// Sign signs the current contents of every state file, accepting any
+	// changes made to them.
+	Sign() error
+}
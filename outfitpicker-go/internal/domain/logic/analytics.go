@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// OutfitWears counts the wears of one outfit.
+type OutfitWears struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Wears    int    `json:"wears"`
+	// LastWorn is nil when the outfit has never been worn.
+	LastWorn *time.Time `json:"lastWorn,omitempty"`
+}
+
+// CategoryWears counts the wears of every outfit in a category.
+type CategoryWears struct {
+	Category string `json:"category"`
+	Wears    int    `json:"wears"`
+}
+
+// WearAnalytics summarizes the wear and pick history of a wardrobe.
+type WearAnalytics struct {
+	// Outfits lists every outfit in the wardrobe, most worn first, then by
+	// category and file name.
+	Outfits []OutfitWears `json:"outfits"`
+	// MostWornCategory and LeastWornCategory are nil when the wardrobe
+	// has no categories. Ties go to the category first by name.
+	MostWornCategory  *CategoryWears `json:"mostWornCategory,omitempty"`
+	LeastWornCategory *CategoryWears `json:"leastWornCategory,omitempty"`
+	// CompletedRotations counts the rotations worn through, and
+	// AverageRotationDays is how long they took on average, from the
+	// first wear of a rotation to the wear that completed it.
+	CompletedRotations  int     `json:"completedRotations"`
+	AverageRotationDays float64 `json:"averageRotationDays"`
+	// PickStreakDays counts the consecutive days with a pick, ending today
+	// or, before today's first pick, yesterday.
+	PickStreakDays int `json:"pickStreakDays"`
+	// LockedRotations is the state of the categories whose rotation is
+	// locked. AnalyzeWear leaves it empty.
+	LockedRotations []LockedRotation `json:"lockedRotations,omitempty"`
+}
+
+// AnalyzeWear computes the wear analytics of the outfits in snapshot from
+// the wear log and the selection history. Wears of outfits no longer in
+// the wardrobe count toward rotations but are not listed.
+func AnalyzeWear(log entities.WearLog, history entities.SelectionHistory, snapshot entities.WardrobeSnapshot, now time.Time) WearAnalytics {
+	analytics := WearAnalytics{Outfits: []OutfitWears{}}
+	for _, category := range slices.Sorted(maps.Keys(snapshot)) {
+		lastWorn := log.LastWorn(category)
+		wears := make(map[string]int)
+		for _, event := range log.Events {
+			if event.Category == category {
+				wears[event.FileName]++
+			}
+		}
+		total := CategoryWears{Category: category}
+		for _, fileName := range snapshot[category] {
+			outfit := OutfitWears{Category: category, FileName: fileName, Wears: wears[fileName]}
+			if at, ok := lastWorn[fileName]; ok {
+				outfit.LastWorn = &at
+			}
+			analytics.Outfits = append(analytics.Outfits, outfit)
+			total.Wears += outfit.Wears
+		}
+		if analytics.MostWornCategory == nil || total.Wears > analytics.MostWornCategory.Wears {
+			most := total
+			analytics.MostWornCategory = &most
+		}
+		if analytics.LeastWornCategory == nil || total.Wears < analytics.LeastWornCategory.Wears {
+			least := total
+			analytics.LeastWornCategory = &least
+		}
+	}
+	slices.SortStableFunc(analytics.Outfits, func(a, b OutfitWears) int {
+		return cmp.Or(cmp.Compare(b.Wears, a.Wears), cmp.Compare(a.Category, b.Category), cmp.Compare(a.FileName, b.FileName))
+	})
+
+	analytics.CompletedRotations, analytics.AverageRotationDays = rotationCycles(log)
+	analytics.PickStreakDays = pickStreak(history, now)
+	return analytics
+}
+
+// rotationCycles counts the completed rotations in log and their average
+// length in days.
+func rotationCycles(log entities.WearLog) (int, float64) {
+	started := make(map[string]time.Time)
+	completed := 0
+	var total time.Duration
+	for _, event := range log.Events {
+		start, ok := started[event.Category]
+		if !ok {
+			start = event.WornAt
+			started[event.Category] = start
+		}
+		if event.CompletedRotation {
+			completed++
+			total += event.WornAt.Sub(start)
+			delete(started, event.Category)
+		}
+	}
+	if completed == 0 {
+		return 0, 0
+	}
+	return completed, total.Hours() / 24 / float64(completed)
+}
+
+// pickStreak counts the consecutive calendar days with a pick in history,
+// ending today or yesterday.
+func pickStreak(history entities.SelectionHistory, now time.Time) int {
+	picked := make(map[time.Time]bool)
+	for _, record := range history.Records {
+		picked[calendarDay(record.SelectedAt, now.Location())] = true
+	}
+	day := calendarDay(now, now.Location())
+	if !picked[day] {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for picked[day] {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// calendarDay returns midnight of the day containing t in loc.
+func calendarDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
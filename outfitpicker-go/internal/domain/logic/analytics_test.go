@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestAnalyzeWear(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 9, 0, 0, 0, time.UTC) }
+	log := entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: day(1)},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day(5), CompletedRotation: true},
+		{Category: "casual", FileName: "tee.avatar", WornAt: day(6)},
+		{Category: "work", FileName: "suit.avatar", WornAt: day(7), CompletedRotation: true},
+		{Category: "casual", FileName: "gone.avatar", WornAt: day(8)},
+	}}
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{SelectedAt: day(6)}, {SelectedAt: day(8)}, {SelectedAt: day(9)}, {SelectedAt: day(9).Add(time.Hour)},
+	}}
+	snapshot := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "jeans.avatar"},
+		"work":   {"suit.avatar", "blazer.avatar"},
+		"gym":    {"shorts.avatar"},
+	}
+
+	analytics := AnalyzeWear(log, history, snapshot, day(10))
+
+	want := []struct {
+		category, fileName string
+		wears              int
+	}{
+		{"casual", "tee.avatar", 2},
+		{"casual", "jeans.avatar", 1},
+		{"work", "suit.avatar", 1},
+		{"gym", "shorts.avatar", 0},
+		{"work", "blazer.avatar", 0},
+	}
+	if len(analytics.Outfits) != len(want) {
+		t.Fatalf("Outfits = %+v", analytics.Outfits)
+	}
+	for i, w := range want {
+		got := analytics.Outfits[i]
+		if got.Category != w.category || got.FileName != w.fileName || got.Wears != w.wears {
+			t.Errorf("Outfits[%d] = %+v, want %s/%s worn %d times", i, got, w.category, w.fileName, w.wears)
+		}
+	}
+	if last := analytics.Outfits[0].LastWorn; last == nil || !last.Equal(day(6)) {
+		t.Errorf("LastWorn of tee.avatar = %v, want %v", last, day(6))
+	}
+	if analytics.Outfits[3].LastWorn != nil {
+		t.Error("LastWorn of an outfit never worn is set")
+	}
+	if m := analytics.MostWornCategory; m == nil || m.Category != "casual" || m.Wears != 3 {
+		t.Errorf("MostWornCategory = %+v, want casual with 3 wears", m)
+	}
+	if l := analytics.LeastWornCategory; l == nil || l.Category != "gym" || l.Wears != 0 {
+		t.Errorf("LeastWornCategory = %+v, want gym with 0 wears", l)
+	}
+	if analytics.CompletedRotations != 2 || analytics.AverageRotationDays != 2 {
+		t.Errorf("rotations = %d averaging %v days, want 2 averaging 2", analytics.CompletedRotations, analytics.AverageRotationDays)
+	}
+	if analytics.PickStreakDays != 2 {
+		t.Errorf("PickStreakDays = %d, want 2 ending yesterday", analytics.PickStreakDays)
+	}
+	if streak := AnalyzeWear(log, history, snapshot, day(12)).PickStreakDays; streak != 0 {
+		t.Errorf("PickStreakDays after a day without picks = %d, want 0", streak)
+	}
+}
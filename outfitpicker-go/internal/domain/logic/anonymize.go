@@ -0,0 +1,262 @@
+package logic
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RedactedValue replaces values that must never leave the machine.
+const RedactedValue = "<redacted>"
+
+const hashLength = 12
+
+// Anonymizer replaces category and outfit names with salted hashes so state
+// can be shared without revealing the wardrobe. The same name always maps to
+// the same hash for a given salt, so relationships between files survive.
+type Anonymizer struct {
+	salt []byte
+}
+
+// NewAnonymizer creates an anonymizer keyed with salt.
+func NewAnonymizer(salt []byte) *Anonymizer {
+	return &Anonymizer{salt: salt}
+}
+
+// Hash returns a short salted hash of value.
+func (a *Anonymizer) Hash(value string) string {
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
+
+// HashFileName hashes a file name while keeping its extension.
+func (a *Anonymizer) HashFileName(fileName string) string {
+	ext := filepath.Ext(fileName)
+	return a.Hash(fileName[:len(fileName)-len(ext)]) + ext
+}
+
+// Config returns a copy of config with paths redacted and names hashed.
+func (a *Anonymizer) Config(config entities.Config) entities.Config {
+	knownFiles := make(map[string]map[string]bool, len(config.KnownCategoryFiles))
+	for category, files := range config.KnownCategoryFiles {
+		knownFiles[a.Hash(category)] = a.fileSet(files)
+	}
+	var decorations map[string]entities.CategoryDecoration
+	if len(config.CategoryDecorations) > 0 {
+		decorations = make(map[string]entities.CategoryDecoration, len(config.CategoryDecorations))
+		for category, decoration := range config.CategoryDecorations {
+			decorations[a.Hash(category)] = decoration
+		}
+	}
+	var names map[string]entities.CategoryNames
+	if len(config.CategoryNames) > 0 {
+		names = make(map[string]entities.CategoryNames, len(config.CategoryNames))
+		for category, categoryNames := range config.CategoryNames {
+			names[a.Hash(category)] = a.categoryNames(categoryNames)
+		}
+	}
+	var excludedUntil map[string]time.Time
+	if len(config.ExcludedUntil) > 0 {
+		excludedUntil = make(map[string]time.Time, len(config.ExcludedUntil))
+		for category, until := range config.ExcludedUntil {
+			excludedUntil[a.Hash(category)] = until
+		}
+	}
+	order := entities.CategoryOrder{Sort: config.Order.Sort}
+	for _, category := range config.Order.Manual {
+		order.Manual = append(order.Manual, a.Hash(category))
+	}
+	roots := make([]string, len(config.Roots))
+	for i := range roots {
+		roots[i] = RedactedValue
+	}
+	return entities.Config{
+		Roots:               roots,
+		Language:            config.Language,
+		ExcludedCategories:  a.nameSet(config.ExcludedCategories),
+		ExcludedUntil:       excludedUntil,
+		KnownCategories:     a.nameSet(config.KnownCategories),
+		KnownCategoryFiles:  knownFiles,
+		CategoryDecorations: decorations,
+		CategoryNames:       names,
+		Selection:           a.selection(config.Selection),
+		Seasons:             a.seasons(config.Seasons),
+		Order:               order,
+		Revision:            config.Revision,
+	}
+}
+
+// selection hashes the categories and tags the selection preferences name,
+// keeping the settings themselves.
+func (a *Anonymizer) selection(preferences entities.SelectionPreferences) entities.SelectionPreferences {
+	hashed := preferences
+	hashed.CategoryPriorities = hashKeys(a, preferences.CategoryPriorities)
+	hashed.CategoryRotationPolicies = hashKeys(a, preferences.CategoryRotationPolicies)
+	hashed.CategoryPickLimits = hashKeys(a, preferences.CategoryPickLimits)
+	hashed.TagConstraints = nil
+	for _, constraint := range preferences.TagConstraints {
+		constraint.Tag = a.Hash(constraint.Tag)
+		hashed.TagConstraints = append(hashed.TagConstraints, constraint)
+	}
+	hashed.LockedRotations = nil
+	for _, category := range preferences.LockedRotations {
+		hashed.LockedRotations = append(hashed.LockedRotations, a.Hash(category))
+	}
+	return hashed
+}
+
+// hashKeys returns a copy of m with its keys hashed by a.
+func hashKeys[V any](a *Anonymizer, m map[string]V) map[string]V {
+	if m == nil {
+		return nil
+	}
+	hashed := make(map[string]V, len(m))
+	for key, value := range m {
+		hashed[a.Hash(key)] = value
+	}
+	return hashed
+}
+
+// seasons hashes the categories and tags that seasons are assigned to.
+func (a *Anonymizer) seasons(assignments entities.SeasonAssignments) entities.SeasonAssignments {
+	hashed := entities.SeasonAssignments{Southern: assignments.Southern}
+	for category, seasons := range assignments.Categories {
+		hashed = hashed.SettingCategory(a.Hash(category), seasons)
+	}
+	for tag, seasons := range assignments.Tags {
+		hashed = hashed.SettingTag(a.Hash(tag), seasons)
+	}
+	return hashed
+}
+
+// categoryNames hashes labels and aliases, which are as identifying as the
+// category names themselves, keeping the label languages.
+func (a *Anonymizer) categoryNames(names entities.CategoryNames) entities.CategoryNames {
+	hashed := entities.CategoryNames{}
+	if len(names.Labels) > 0 {
+		hashed.Labels = make(map[string]string, len(names.Labels))
+		for language, label := range names.Labels {
+			hashed.Labels[language] = a.Hash(label)
+		}
+	}
+	for _, alias := range names.Aliases {
+		hashed.Aliases = append(hashed.Aliases, a.Hash(alias))
+	}
+	return hashed
+}
+
+// Cache returns a copy of cache with category and outfit names hashed.
+func (a *Anonymizer) Cache(cache entities.OutfitCache) entities.OutfitCache {
+	categories := make(map[string]entities.CategoryCache, len(cache.Categories))
+	for category, categoryCache := range cache.Categories {
+		categories[a.Hash(category)] = entities.CategoryCache{
+			WornOutfits:  a.fileSet(categoryCache.WornOutfits),
+			TotalOutfits: categoryCache.TotalOutfits,
+			LastUpdated:  categoryCache.LastUpdated,
+			Revision:     categoryCache.Revision,
+			LastWorn:     a.fileTimes(categoryCache.LastWorn),
+		}
+	}
+	return entities.OutfitCache{
+		Categories: categories,
+		Version:    cache.Version,
+		CreatedAt:  cache.CreatedAt,
+		Revision:   cache.Revision,
+	}
+}
+
+// History returns a copy of history with category and outfit names hashed.
+func (a *Anonymizer) History(history entities.SelectionHistory) entities.SelectionHistory {
+	records := make([]entities.SelectionRecord, len(history.Records))
+	for i, record := range history.Records {
+		records[i] = entities.SelectionRecord{
+			Category:   a.Hash(record.Category),
+			FileName:   a.HashFileName(record.FileName),
+			SelectedAt: record.SelectedAt,
+			OutfitID:   a.outfitID(record.OutfitID),
+		}
+	}
+	return entities.SelectionHistory{Records: records, Revision: history.Revision}
+}
+
+// WearLog returns a copy of log with names hashed and notes redacted.
+func (a *Anonymizer) WearLog(log entities.WearLog) entities.WearLog {
+	events := make([]entities.WearEvent, len(log.Events))
+	for i, event := range log.Events {
+		event.Category = a.Hash(event.Category)
+		event.FileName = a.HashFileName(event.FileName)
+		event.OutfitID = a.outfitID(event.OutfitID)
+		if event.Note != "" {
+			event.Note = RedactedValue
+		}
+		events[i] = event
+	}
+	return entities.WearLog{Events: events, Revision: log.Revision}
+}
+
+// LogRecord returns a copy of a decoded JSON log record that keeps its
+// message, level, time, command and numbers, hashes the category and outfit
+// it names and redacts every other value, since paths, arguments and errors
+// can all name the wardrobe.
+func (a *Anonymizer) LogRecord(record map[string]any) map[string]any {
+	anonymized := make(map[string]any, len(record))
+	for key, value := range record {
+		switch value := value.(type) {
+		case float64, bool:
+			anonymized[key] = value
+		case string:
+			switch key {
+			case "time", "level", "msg", "command":
+				anonymized[key] = value
+			case "category":
+				anonymized[key] = a.Hash(value)
+			case "outfit":
+				anonymized[key] = a.HashFileName(value)
+			default:
+				anonymized[key] = RedactedValue
+			}
+		default:
+			anonymized[key] = RedactedValue
+		}
+	}
+	return anonymized
+}
+
+func (a *Anonymizer) outfitID(id string) string {
+	if id == "" {
+		return ""
+	}
+	return a.Hash(id)
+}
+
+func (a *Anonymizer) nameSet(names map[string]bool) map[string]bool {
+	hashed := make(map[string]bool, len(names))
+	for name, value := range names {
+		hashed[a.Hash(name)] = value
+	}
+	return hashed
+}
+
+func (a *Anonymizer) fileSet(fileNames map[string]bool) map[string]bool {
+	hashed := make(map[string]bool, len(fileNames))
+	for fileName, value := range fileNames {
+		hashed[a.HashFileName(fileName)] = value
+	}
+	return hashed
+}
+
+func (a *Anonymizer) fileTimes(times map[string]time.Time) map[string]time.Time {
+	if times == nil {
+		return nil
+	}
+	hashed := make(map[string]time.Time, len(times))
+	for fileName, at := range times {
+		hashed[a.HashFileName(fileName)] = at
+	}
+	return hashed
+}
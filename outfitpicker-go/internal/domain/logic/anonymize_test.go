@@ -0,0 +1,172 @@
+package logic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestAnonymizer_Hash(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+
+	if a.Hash("casual") != a.Hash("casual") {
+		t.Error("Hash() is not stable for the same input")
+	}
+	if a.Hash("casual") == a.Hash("formal") {
+		t.Error("Hash() collides for different inputs")
+	}
+	if a.Hash("casual") == NewAnonymizer([]byte("other")).Hash("casual") {
+		t.Error("Hash() ignores the salt")
+	}
+	if got := len(a.Hash("casual")); got != hashLength {
+		t.Errorf("len(Hash()) = %v, want %v", got, hashLength)
+	}
+}
+
+func TestAnonymizer_HashFileName(t *testing.T) {
+	got := NewAnonymizer([]byte("salt")).HashFileName("red-dress.avatar")
+
+	if !strings.HasSuffix(got, ".avatar") {
+		t.Errorf("HashFileName() = %v, want .avatar extension", got)
+	}
+	if strings.Contains(got, "red-dress") {
+		t.Errorf("HashFileName() = %v leaks the original name", got)
+	}
+}
+
+func TestAnonymizer_Config(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+	config := entities.Config{
+		Roots:              []string{"/home/alice/outfits"},
+		Language:           "fr",
+		ExcludedCategories: map[string]bool{"formal": true},
+		KnownCategories:    map[string]bool{"casual": true},
+		KnownCategoryFiles: map[string]map[string]bool{"casual": {"tee.avatar": true}},
+		CategoryNames: map[string]entities.CategoryNames{
+			"casual": {Labels: map[string]string{"fr": "décontracté"}, Aliases: []string{"chill"}},
+		},
+		Seasons: entities.SeasonAssignments{Categories: map[string][]string{"casual": {"summer"}}},
+		Order:   entities.CategoryOrder{Sort: entities.CategorySortManual, Manual: []string{"casual"}},
+	}
+
+	got := a.Config(config)
+
+	if len(got.Roots) != 1 || got.Roots[0] != RedactedValue {
+		t.Errorf("Roots = %v, want [%v]", got.Roots, RedactedValue)
+	}
+	if got.Language != "fr" {
+		t.Errorf("Language = %v, want fr", got.Language)
+	}
+	if !got.ExcludedCategories[a.Hash("formal")] || got.ExcludedCategories["formal"] {
+		t.Errorf("ExcludedCategories = %v, want hashed names", got.ExcludedCategories)
+	}
+	if !got.KnownCategoryFiles[a.Hash("casual")][a.HashFileName("tee.avatar")] {
+		t.Errorf("KnownCategoryFiles = %v, want hashed names", got.KnownCategoryFiles)
+	}
+	names := got.Names(a.Hash("casual"))
+	if names.Labels["fr"] != a.Hash("décontracté") || len(names.Aliases) != 1 || names.Aliases[0] != a.Hash("chill") {
+		t.Errorf("CategoryNames = %v, want hashed labels and aliases", got.CategoryNames)
+	}
+	if seasons := got.Seasons.Category(a.Hash("casual")); len(seasons) != 1 || seasons[0] != "summer" {
+		t.Errorf("Seasons = %+v, want hashed categories", got.Seasons)
+	}
+	if got.Order.Sort != entities.CategorySortManual || len(got.Order.Manual) != 1 || got.Order.Manual[0] != a.Hash("casual") {
+		t.Errorf("Order = %+v, want hashed categories", got.Order)
+	}
+	if config.Roots[0] != "/home/alice/outfits" {
+		t.Error("Config() mutated its input")
+	}
+}
+
+func TestAnonymizer_ConfigSelection(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+	config := entities.Config{
+		KnownCategories: map[string]bool{"gala": true},
+		Selection: entities.SelectionPreferences{
+			Strategy:                 entities.StrategyWeighted,
+			CategoryPriorities:       map[string]float64{"gala": 2},
+			CategoryRotationPolicies: map[string]entities.RotationPolicy{"costumes": {Name: entities.RotationRandom}},
+			CategoryPickLimits:       map[string]int{"uniforms": 1},
+			LockedRotations:          []string{"heirlooms"},
+			TagConstraints:           []entities.TagConstraint{{Tag: "sequins", Max: 1, Days: 7}},
+		},
+	}
+
+	got := a.Config(config)
+
+	data, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"gala", "costumes", "uniforms", "heirlooms", "sequins"} {
+		if strings.Contains(string(data), name) {
+			t.Errorf("anonymized config %s leaks %q", data, name)
+		}
+	}
+	selection := got.Selection
+	if selection.Strategy != entities.StrategyWeighted || selection.CategoryPriorities[a.Hash("gala")] != 2 || selection.CategoryPickLimits[a.Hash("uniforms")] != 1 {
+		t.Errorf("Selection = %+v, want the settings kept under hashed names", selection)
+	}
+	if selection.CategoryRotationPolicies[a.Hash("costumes")].Name != entities.RotationRandom || selection.LockedRotations[0] != a.Hash("heirlooms") {
+		t.Errorf("Selection = %+v, want rotation settings kept under hashed names", selection)
+	}
+	if constraint := selection.TagConstraints[0]; constraint.Tag != a.Hash("sequins") || constraint.Max != 1 || constraint.Days != 7 {
+		t.Errorf("TagConstraints = %+v, want the tag hashed", selection.TagConstraints)
+	}
+	if config.Selection.TagConstraints[0].Tag != "sequins" {
+		t.Error("Config() mutated its input")
+	}
+}
+
+func TestAnonymizer_Cache(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+	cache := entities.NewOutfitCache().Updating("casual", entities.NewCategoryCache(4).Adding("tee.avatar"))
+
+	got := a.Cache(cache)
+
+	hashed, ok := got.Categories[a.Hash("casual")]
+	if !ok {
+		t.Fatalf("Categories = %v, want hashed casual", got.Categories)
+	}
+	if hashed.TotalOutfits != 4 || !hashed.WornOutfits[a.HashFileName("tee.avatar")] {
+		t.Errorf("category = %+v, want totals kept and worn names hashed", hashed)
+	}
+}
+
+func TestAnonymizer_HistoryAndWearLog(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{{Category: "casual", FileName: "tee.avatar", OutfitID: "casual-tee"}}}
+	log := entities.WearLog{Events: []entities.WearEvent{{Category: "casual", FileName: "tee.avatar", Note: "wedding at the lake", Feedback: []entities.FeedbackKind{entities.FeedbackCompliment}}}}
+
+	record := a.History(history).Records[0]
+	if record.Category != a.Hash("casual") || record.FileName != a.HashFileName("tee.avatar") || record.OutfitID != a.Hash("casual-tee") {
+		t.Errorf("History() record = %+v, want names and ID hashed", record)
+	}
+	event := a.WearLog(log).Events[0]
+	if event.Category != a.Hash("casual") || event.FileName != a.HashFileName("tee.avatar") || event.Note != RedactedValue || len(event.Feedback) != 1 {
+		t.Errorf("WearLog() event = %+v, want names hashed, note redacted and feedback kept", event)
+	}
+	if log.Events[0].Category != "casual" {
+		t.Error("WearLog() changed its argument")
+	}
+}
+
+func TestAnonymizer_LogRecord(t *testing.T) {
+	a := NewAnonymizer([]byte("salt"))
+	got := a.LogRecord(map[string]any{
+		"msg": "picked outfit", "level": "INFO", "category": "casual", "outfit": "tee.avatar",
+		"available": float64(2), "path": "/home/sam/Wardrobe/casual", "args": []any{"casual"},
+	})
+
+	if got["msg"] != "picked outfit" || got["level"] != "INFO" || got["available"] != float64(2) {
+		t.Errorf("LogRecord() = %v, want the message, level and counts kept", got)
+	}
+	if got["category"] != a.Hash("casual") || got["outfit"] != a.HashFileName("tee.avatar") {
+		t.Errorf("LogRecord() = %v, want the category and outfit hashed", got)
+	}
+	if got["path"] != RedactedValue || got["args"] != RedactedValue {
+		t.Errorf("LogRecord() = %v, want other values redacted", got)
+	}
+}
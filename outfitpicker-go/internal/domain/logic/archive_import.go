@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"path"
+	"strings"
+)
+
+// Reasons an archive entry is not imported.
+const (
+	SkipUnsafePath = "unsafe path"
+	SkipHidden     = "hidden file"
+	SkipNotOutfit  = "not an outfit file"
+	SkipNotRegular = "not a regular file"
+	SkipTooLarge   = "file too large"
+)
+
+// MaxImportedOutfitSize is the largest outfit file an archive import
+// extracts, which bounds what a malicious archive can write.
+const MaxImportedOutfitSize = 64 << 20
+
+// ArchiveEntryOutfitName returns the file name an archive entry is imported
+// under, or the reason it is skipped. Entries are flattened into the
+// category, so only the base name is kept; entries with absolute paths or
+// .. segments, hidden entries and non-outfit files are skipped.
+func ArchiveEntryOutfitName(entry string) (string, string) {
+	name := strings.ReplaceAll(entry, `\`, "/")
+	if strings.HasPrefix(name, "/") || (len(name) > 1 && name[1] == ':') {
+		return "", SkipUnsafePath
+	}
+	segments := strings.Split(name, "/")
+	for _, segment := range segments {
+		if segment == ".." {
+			return "", SkipUnsafePath
+		}
+		if (strings.HasPrefix(segment, ".") && segment != ".") || segment == "__MACOSX" {
+			return "", SkipHidden
+		}
+	}
+	base := path.Base(name)
+	if !IsValidOutfitFile(base) {
+		return "", SkipNotOutfit
+	}
+	return base, ""
+}
@@ -0,0 +1,32 @@
+package logic
+
+import "testing"
+
+func TestArchiveEntryOutfitName(t *testing.T) {
+	tests := []struct {
+		entry      string
+		wantName   string
+		wantReason string
+	}{
+		{"tee.avatar", "tee.avatar", ""},
+		{"pack/summer/Tee.AVATAR", "Tee.AVATAR", ""},
+		{"./tee.avatar", "tee.avatar", ""},
+		{`pack\tee.avatar`, "tee.avatar", ""},
+		{"../tee.avatar", "", SkipUnsafePath},
+		{"pack/../../tee.avatar", "", SkipUnsafePath},
+		{"/etc/tee.avatar", "", SkipUnsafePath},
+		{`C:\tee.avatar`, "", SkipUnsafePath},
+		{"pack/.tee.avatar", "", SkipHidden},
+		{"__MACOSX/pack/tee.avatar", "", SkipHidden},
+		{"pack/readme.txt", "", SkipNotOutfit},
+		{"pack/", "", SkipNotOutfit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			name, reason := ArchiveEntryOutfitName(tt.entry)
+			if name != tt.wantName || reason != tt.wantReason {
+				t.Errorf("ArchiveEntryOutfitName(%q) = %q, %q; want %q, %q", tt.entry, name, reason, tt.wantName, tt.wantReason)
+			}
+		})
+	}
+}
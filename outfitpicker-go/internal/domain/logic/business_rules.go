@@ -1,7 +1,11 @@
 package logic
 
 import (
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/dh85/outfitpicker/internal/domain/entities"
 	"github.com/dh85/outfitpicker/internal/domain/errors"
@@ -52,6 +56,28 @@ func ValidateCategoryName(categoryName string) error {
 	return nil
 }
 
+// ValidateNewCategoryName checks that name can be the directory of a new
+// category: a single directory name that is not hidden, without control
+// characters or surrounding spaces, and not in the form categories of later
+// roots are given, such as "casual@2".
+func ValidateNewCategoryName(name string) error {
+	if err := ValidateCategoryName(name); err != nil {
+		return err
+	}
+	if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return errors.NewInvalidInputError(fmt.Sprintf("category %q must be a single, non-hidden directory name", name))
+	}
+	if strings.TrimSpace(name) != name || strings.ContainsFunc(name, unicode.IsControl) {
+		return errors.NewInvalidInputError(fmt.Sprintf("category %q must not contain control characters or start or end with a space", name))
+	}
+	if at := strings.LastIndex(name, "@"); at >= 0 {
+		if _, err := strconv.Atoi(name[at+1:]); err == nil {
+			return errors.NewInvalidInputError(fmt.Sprintf("category %q must not end in @ and a number, which names categories of other roots", name))
+		}
+	}
+	return nil
+}
+
 // ValidateOutfit validates outfit and returns error if invalid.
 func ValidateOutfit(outfit entities.OutfitReference) error {
 	if !IsValidOutfitFileName(outfit.FileName) {
@@ -60,13 +86,42 @@ func ValidateOutfit(outfit entities.OutfitReference) error {
 	return ValidateCategoryName(outfit.Category.Name)
 }
 
-// FilterAvailableOutfits filters available outfits based on worn status.
-func FilterAvailableOutfits(files []entities.FileEntry, wornOutfits map[string]bool) []entities.FileEntry {
+// OutfitFilter reports whether an outfit may be picked.
+type OutfitFilter func(entities.FileEntry) bool
+
+// TaggedWith keeps the outfits of category that are tagged with tag.
+func TaggedWith(index entities.MetadataIndex, category, tag string) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		return index.HasTag(category, file.FileName, tag)
+	}
+}
+
+// TaggedWithAny keeps the outfits of category tagged with any of tags.
+func TaggedWithAny(index entities.MetadataIndex, category string, tags []string) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		return slices.ContainsFunc(tags, func(tag string) bool {
+			return index.HasTag(category, file.FileName, tag)
+		})
+	}
+}
+
+// FilterAvailableOutfits returns the outfits that have not been worn and
+// pass every filter.
+func FilterAvailableOutfits(files []entities.FileEntry, wornOutfits map[string]bool, filters ...OutfitFilter) []entities.FileEntry {
 	var available []entities.FileEntry
 	for _, file := range files {
-		if !wornOutfits[file.FileName] {
+		if !wornOutfits[file.FileName] && passes(file, filters) {
 			available = append(available, file)
 		}
 	}
 	return available
 }
+
+func passes(file entities.FileEntry, filters []OutfitFilter) bool {
+	for _, keep := range filters {
+		if !keep(file) {
+			return false
+		}
+	}
+	return true
+}
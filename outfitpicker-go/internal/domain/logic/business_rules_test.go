@@ -141,6 +141,33 @@ func TestValidateCategoryName(t *testing.T) {
 	}
 }
 
+func TestValidateNewCategoryName(t *testing.T) {
+	tests := []struct {
+		name    string
+		catName string
+		wantErr bool
+	}{
+		{"valid name", "date night", false},
+		{"at sign", "black@white", false},
+		{"empty name", "", true},
+		{"nested", "work/suits", true},
+		{"backslash", `work\suits`, true},
+		{"hidden", ".secret", true},
+		{"leading space", " casual", true},
+		{"control character", "casual\n", true},
+		{"root suffix", "casual@2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNewCategoryName(tt.catName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNewCategoryName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateOutfit(t *testing.T) {
 	category := entities.NewCategoryReference("casual", "/path/to/casual")
 
@@ -197,3 +224,39 @@ func TestFilterAvailableOutfits(t *testing.T) {
 		t.Errorf("FilterAvailableOutfits()[0].FileName = %v, want outfit2.avatar", available[0].FileName)
 	}
 }
+
+func TestFilterAvailableOutfits_TaggedWith(t *testing.T) {
+	files := []entities.FileEntry{
+		entities.NewFileEntry("/path/to/casual/outfit1.avatar"),
+		entities.NewFileEntry("/path/to/casual/outfit2.avatar"),
+		entities.NewFileEntry("/path/to/casual/outfit3.avatar"),
+	}
+	index := entities.NewMetadataIndex().
+		Setting("casual", "outfit1.avatar", entities.OutfitMetadata{Tags: []string{"summer"}}).
+		Setting("casual", "outfit2.avatar", entities.OutfitMetadata{Tags: []string{"summer"}}).
+		Setting("work", "outfit3.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+
+	available := FilterAvailableOutfits(files, map[string]bool{"outfit1.avatar": true}, TaggedWith(index, "casual", "summer"))
+
+	if len(available) != 1 || available[0].FileName != "outfit2.avatar" {
+		t.Errorf("FilterAvailableOutfits() = %v, want only outfit2.avatar", available)
+	}
+}
+
+func TestFilterAvailableOutfits_TaggedWithAny(t *testing.T) {
+	files := []entities.FileEntry{
+		entities.NewFileEntry("/path/to/work/suit.avatar"),
+		entities.NewFileEntry("/path/to/work/blazer.avatar"),
+		entities.NewFileEntry("/path/to/work/hoodie.avatar"),
+	}
+	index := entities.NewMetadataIndex().
+		Setting("work", "suit.avatar", entities.OutfitMetadata{Tags: []string{"formal"}}).
+		Setting("work", "blazer.avatar", entities.OutfitMetadata{Tags: []string{"smart"}}).
+		Setting("work", "hoodie.avatar", entities.OutfitMetadata{Tags: []string{"casual"}})
+
+	available := FilterAvailableOutfits(files, nil, TaggedWithAny(index, "work", []string{"formal", "smart"}))
+
+	if len(available) != 2 || available[0].FileName != "suit.avatar" || available[1].FileName != "blazer.avatar" {
+		t.Errorf("FilterAvailableOutfits() = %v, want suit.avatar and blazer.avatar", available)
+	}
+}
@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// HealthStatus buckets a category health score.
+type HealthStatus string
+
+const (
+	HealthHealthy  HealthStatus = "healthy"
+	HealthWarning  HealthStatus = "warning"
+	HealthCritical HealthStatus = "critical"
+)
+
+// Weights of the health components; they sum to 1.
+const (
+	progressWeight  = 0.30
+	stalenessWeight = 0.30
+	wearRateWeight  = 0.25
+	laundryWeight   = 0.15
+)
+
+// componentConcern is the component score below which a reason is given.
+const componentConcern = 0.5
+
+// CategoryActivity is what is known about a category's use when its health
+// is computed.
+type CategoryActivity struct {
+	// Outfits is the number of outfits in the category.
+	Outfits int
+	// WornInRotation is the number of outfits worn in the current rotation,
+	// which are also the ones waiting to be washed.
+	WornInRotation int
+	// RotationStarted is when the current rotation's first outfit was worn;
+	// zero when unknown.
+	RotationStarted time.Time
+	// LastWorn is when an outfit in the category was last worn; zero when
+	// never.
+	LastWorn time.Time
+	// RecentWears counts wears within the thresholds' target rotation period
+	// before now.
+	RecentWears int
+}
+
+// HealthComponents are the parts of a health score, each between 0 and 1
+// where 1 is healthy.
+type HealthComponents struct {
+	// Progress compares the current rotation's progress to the target pace.
+	Progress float64 `json:"progress"`
+	// Staleness falls as the time since the last wear approaches the stale
+	// threshold.
+	Staleness float64 `json:"staleness"`
+	// WearRate compares recent wears to the category's size: a category worn
+	// too rarely to cycle within the target period scores low.
+	WearRate float64 `json:"wearRate"`
+	// Laundry is the share of outfits not waiting to be washed.
+	Laundry float64 `json:"laundry"`
+}
+
+// CategoryHealth is a category's health score, out of 100, with the reasons
+// it is not higher.
+type CategoryHealth struct {
+	Score      int              `json:"score"`
+	Status     HealthStatus     `json:"status"`
+	Components HealthComponents `json:"components"`
+	Reasons    []string         `json:"reasons,omitempty"`
+}
+
+// CategoryHealthScore combines rotation progress, staleness, size against
+// wear rate and laundry backlog into a score. Categories without outfits
+// score 100.
+func CategoryHealthScore(activity CategoryActivity, thresholds entities.HealthThresholds, now time.Time) CategoryHealth {
+	thresholds = thresholds.WithDefaults()
+	if activity.Outfits == 0 {
+		return CategoryHealth{Score: 100, Status: HealthHealthy, Components: HealthComponents{1, 1, 1, 1}}
+	}
+	outfits := float64(activity.Outfits)
+	target := float64(thresholds.TargetRotationDays)
+
+	var health CategoryHealth
+	components := &health.Components
+
+	components.Progress = 1
+	if activity.WornInRotation > 0 && !activity.RotationStarted.IsZero() {
+		elapsed := max(now.Sub(activity.RotationStarted).Hours()/24, 1)
+		expected := min(elapsed/target, 1)
+		components.Progress = clamp01((float64(activity.WornInRotation) / outfits) / expected)
+	}
+	if components.Progress < componentConcern {
+		health.Reasons = append(health.Reasons, "rotation is behind schedule")
+	}
+
+	if activity.LastWorn.IsZero() {
+		health.Reasons = append(health.Reasons, "never worn")
+	} else {
+		days := now.Sub(activity.LastWorn).Hours() / 24
+		components.Staleness = 1 - clamp01(days/float64(thresholds.StaleAfterDays))
+		if components.Staleness < componentConcern {
+			health.Reasons = append(health.Reasons, fmt.Sprintf("not worn for %d days", int(days)))
+		}
+	}
+
+	components.WearRate = clamp01(float64(activity.RecentWears) / outfits)
+	if components.WearRate < componentConcern && !activity.LastWorn.IsZero() {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("too many outfits to cycle in %d days at the current wear rate", thresholds.TargetRotationDays))
+	}
+
+	components.Laundry = 1 - clamp01(float64(activity.WornInRotation)/outfits)
+	if components.Laundry < componentConcern {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("%d of %d outfits waiting to be washed", activity.WornInRotation, activity.Outfits))
+	}
+
+	weighted := progressWeight*components.Progress +
+		stalenessWeight*components.Staleness +
+		wearRateWeight*components.WearRate +
+		laundryWeight*components.Laundry
+	health.Score = int(math.Round(weighted * 100))
+	health.Status = HealthStatusFor(health.Score, thresholds)
+	return health
+}
+
+// HealthStatusFor buckets score using the thresholds.
+func HealthStatusFor(score int, thresholds entities.HealthThresholds) HealthStatus {
+	thresholds = thresholds.WithDefaults()
+	switch {
+	case score >= thresholds.HealthyScore:
+		return HealthHealthy
+	case score >= thresholds.CriticalScore:
+		return HealthWarning
+	default:
+		return HealthCritical
+	}
+}
+
+func clamp01(value float64) float64 {
+	return max(0, min(value, 1))
+}
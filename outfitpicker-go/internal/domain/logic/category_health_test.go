@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestCategoryHealthScore(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name       string
+		activity   CategoryActivity
+		thresholds entities.HealthThresholds
+		wantScore  int
+		wantStatus HealthStatus
+		wantReason string
+	}{
+		{
+			name:       "empty category",
+			activity:   CategoryActivity{},
+			wantScore:  100,
+			wantStatus: HealthHealthy,
+		},
+		{
+			name:       "worn yesterday, cycling on pace",
+			activity:   CategoryActivity{Outfits: 4, WornInRotation: 1, RotationStarted: daysAgo(10), LastWorn: daysAgo(1), RecentWears: 8},
+			wantScore:  95,
+			wantStatus: HealthHealthy,
+		},
+		{
+			name:       "never worn",
+			activity:   CategoryActivity{Outfits: 5},
+			wantScore:  45,
+			wantStatus: HealthWarning,
+			wantReason: "never worn",
+		},
+		{
+			name:       "stale and behind schedule",
+			activity:   CategoryActivity{Outfits: 10, WornInRotation: 1, RotationStarted: daysAgo(90), LastWorn: daysAgo(45)},
+			wantScore:  17,
+			wantStatus: HealthCritical,
+			wantReason: "not worn for 45 days",
+		},
+		{
+			name:       "laundry backlog",
+			activity:   CategoryActivity{Outfits: 4, WornInRotation: 3, RotationStarted: daysAgo(3), LastWorn: daysAgo(0), RecentWears: 3},
+			wantScore:  83,
+			wantStatus: HealthHealthy,
+			wantReason: "3 of 4 outfits waiting to be washed",
+		},
+		{
+			name:       "custom thresholds",
+			activity:   CategoryActivity{Outfits: 4, WornInRotation: 3, RotationStarted: daysAgo(3), LastWorn: daysAgo(0), RecentWears: 3},
+			thresholds: entities.HealthThresholds{HealthyScore: 90, CriticalScore: 85},
+			wantScore:  83,
+			wantStatus: HealthCritical,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CategoryHealthScore(tt.activity, tt.thresholds, now)
+			if got.Score != tt.wantScore || got.Status != tt.wantStatus {
+				t.Errorf("CategoryHealthScore() = %d %s, want %d %s (%+v)", got.Score, got.Status, tt.wantScore, tt.wantStatus, got.Components)
+			}
+			if tt.wantReason != "" && !slices.Contains(got.Reasons, tt.wantReason) {
+				t.Errorf("Reasons = %q, want %q", got.Reasons, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestHealthStatusFor(t *testing.T) {
+	for score, want := range map[int]HealthStatus{100: HealthHealthy, 70: HealthHealthy, 69: HealthWarning, 40: HealthWarning, 39: HealthCritical} {
+		if got := HealthStatusFor(score, entities.HealthThresholds{}); got != want {
+			t.Errorf("HealthStatusFor(%d) = %s, want %s", score, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// accentFolds maps accented Latin letters to their unaccented spelling. It
+// covers Latin-1 Supplement and Latin Extended-A, which is every letter used
+// by the supported Latin-script languages.
+var accentFolds = func() map[rune]string {
+	groups := map[string]string{
+		"a":  "àáâãäåāăą",
+		"c":  "çćĉċč",
+		"d":  "ďđð",
+		"e":  "èéêëēĕėęě",
+		"g":  "ĝğġģ",
+		"h":  "ĥħ",
+		"i":  "ìíîïĩīĭįı",
+		"j":  "ĵ",
+		"k":  "ķ",
+		"l":  "ĺļľŀł",
+		"n":  "ñńņňŉ",
+		"o":  "òóôõöøōŏő",
+		"r":  "ŕŗř",
+		"s":  "śŝşš",
+		"t":  "ţťŧ",
+		"u":  "ùúûüũūŭůűų",
+		"w":  "ŵ",
+		"y":  "ýÿŷ",
+		"z":  "źżž",
+		"ae": "æ",
+		"oe": "œ",
+		"ss": "ß",
+		"th": "þ",
+	}
+	folds := make(map[rune]string)
+	for base, accented := range groups {
+		for _, r := range accented {
+			folds[r] = base
+		}
+	}
+	return folds
+}()
+
+// FoldName normalizes a category name for comparison: surrounding space is
+// trimmed, letters are lowercased and accents removed, so "Décontracté"
+// and "decontracte" fold to the same string.
+func FoldName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(name) {
+		r = unicode.ToLower(r)
+		if unicode.Is(unicode.Mn, r) {
+			continue // combining accent from a decomposed spelling
+		}
+		if folded, ok := accentFolds[r]; ok {
+			b.WriteString(folded)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// MatchCategory resolves a typed category name against the category names
+// in categories. An exact name always wins. Otherwise the input is folded
+// with FoldName and compared to each category's name, labels and aliases,
+// first for equality and then as a prefix. When several categories match,
+// those matched by name or by their label in language are preferred; any
+// remaining tie is reported as ambiguous.
+func MatchCategory(input string, categories []string, names map[string]entities.CategoryNames, language string) (string, error) {
+	if slices.Contains(categories, input) {
+		return input, nil
+	}
+
+	folded := FoldName(input)
+	if folded == "" {
+		return "", errors.NewInvalidInputError("category name cannot be empty")
+	}
+	for _, matches := range []func(candidate string) bool{
+		func(candidate string) bool { return candidate == folded },
+		func(candidate string) bool { return strings.HasPrefix(candidate, folded) },
+	} {
+		preferred, others := matchingCategories(categories, names, language, matches)
+		if len(preferred) == 0 {
+			preferred = others
+		}
+		switch len(preferred) {
+		case 0:
+			continue
+		case 1:
+			return preferred[0], nil
+		default:
+			return "", errors.NewInvalidInputError(fmt.Sprintf("%q matches several categories: %s", input, strings.Join(preferred, ", ")))
+		}
+	}
+	return "", fmt.Errorf("%w: %q", errors.ErrCategoryNotFound, input)
+}
+
+// matchingCategories returns the sorted categories whose name or label in
+// language satisfies matches, and separately those matched only by another
+// language's label or an alias.
+func matchingCategories(categories []string, names map[string]entities.CategoryNames, language string, matches func(string) bool) (preferred, others []string) {
+	for _, category := range categories {
+		categoryNames := names[category]
+		label, _ := categoryNames.Label(language)
+		if matches(FoldName(category)) || (label != "" && matches(FoldName(label))) {
+			preferred = append(preferred, category)
+			continue
+		}
+		for _, alternative := range alternativeNames(categoryNames) {
+			if matches(FoldName(alternative)) {
+				others = append(others, category)
+				break
+			}
+		}
+	}
+	slices.Sort(preferred)
+	slices.Sort(others)
+	return preferred, others
+}
+
+func alternativeNames(names entities.CategoryNames) []string {
+	alternatives := slices.Clone(names.Aliases)
+	for _, label := range names.Labels {
+		alternatives = append(alternatives, label)
+	}
+	return alternatives
+}
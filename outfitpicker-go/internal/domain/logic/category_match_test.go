@@ -0,0 +1,73 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestFoldName(t *testing.T) {
+	tests := map[string]string{
+		"Décontracté": "decontracte",
+		"  Straße ":   "strasse",
+		"élégant":   "elegant",
+		"ŁÓDŹ":        "lodz",
+		"casual":      "casual",
+	}
+	for input, want := range tests {
+		if got := FoldName(input); got != want {
+			t.Errorf("FoldName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMatchCategory(t *testing.T) {
+	categories := []string{"casual", "formal", "Sport", "sporty"}
+	names := map[string]entities.CategoryNames{
+		"casual": {Labels: map[string]string{"fr": "Décontracté", "de": "Lässig"}, Aliases: []string{"chill"}},
+		"formal": {Labels: map[string]string{"fr": "Élégant", "de": "Elegant"}},
+		"sporty": {Labels: map[string]string{"de": "Sportlich"}},
+	}
+	tests := []struct {
+		name     string
+		input    string
+		language string
+		want     string
+		wantErr  error
+	}{
+		{"exact name", "casual", "en", "casual", nil},
+		{"case-insensitive name", "CASUAL", "en", "casual", nil},
+		{"accent-insensitive label", "décontracté", "fr", "casual", nil},
+		{"label without accents", "decontracte", "fr", "casual", nil},
+		{"label of another language", "lassig", "fr", "casual", nil},
+		{"alias", "Chill", "en", "casual", nil},
+		{"unique prefix", "form", "en", "formal", nil},
+		{"label prefix", "deco", "fr", "casual", nil},
+		{"name beats other label", "sport", "de", "Sport", nil},
+		{"active label beats other label", "elegant", "fr", "formal", nil},
+		{"ambiguous prefix", "spo", "en", "", &domainerrors.InvalidInputError{}},
+		{"blank", "  ", "en", "", &domainerrors.InvalidInputError{}},
+		{"unknown", "pyjamas", "en", "", domainerrors.ErrCategoryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchCategory(tt.input, categories, names, tt.language)
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil || got != tt.want {
+					t.Errorf("MatchCategory(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+				}
+			case *domainerrors.InvalidInputError:
+				if !errors.As(err, &want) {
+					t.Errorf("MatchCategory(%q) error = %v, want InvalidInputError", tt.input, err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("MatchCategory(%q) error = %v, want %v", tt.input, err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// CategoryComparison compares category names for the configured display
+// order. health holds the scores of scored categories and progress the
+// share of each category's rotation worn; only the sort that uses one needs
+// it. Categories the sort knows nothing about, such as unscored ones, come
+// after the rest, and ties are broken by name.
+func CategoryComparison(order entities.CategoryOrder, health map[string]int, progress map[string]float64) func(a, b string) int {
+	var rank func(a, b string) int
+	switch order.EffectiveSort() {
+	case entities.CategorySortManual:
+		rank = func(a, b string) int {
+			indexA, indexB := slices.Index(order.Manual, a), slices.Index(order.Manual, b)
+			return compareKnown(indexA, indexB, indexA >= 0, indexB >= 0)
+		}
+	case entities.CategorySortHealth:
+		rank = func(a, b string) int {
+			scoreA, okA := health[a]
+			scoreB, okB := health[b]
+			return compareKnown(scoreA, scoreB, okA, okB)
+		}
+	case entities.CategorySortProgress:
+		rank = func(a, b string) int {
+			progressA, okA := progress[a]
+			progressB, okB := progress[b]
+			return compareKnown(-progressA, -progressB, okA, okB)
+		}
+	default:
+		rank = func(a, b string) int { return 0 }
+	}
+	return func(a, b string) int {
+		return cmp.Or(rank(a, b), cmp.Compare(a, b))
+	}
+}
+
+// compareKnown compares two values, putting unknown ones last.
+func compareKnown[T cmp.Ordered](a, b T, knownA, knownB bool) int {
+	switch {
+	case knownA && knownB:
+		return cmp.Compare(a, b)
+	case knownA:
+		return -1
+	case knownB:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,33 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestCategoryComparison(t *testing.T) {
+	names := []string{"work", "casual", "formal", "beach"}
+	health := map[string]int{"work": 40, "casual": 90, "beach": 40}
+	progress := map[string]float64{"work": 0.5, "formal": 0.75, "casual": 0.5}
+
+	tests := []struct {
+		order entities.CategoryOrder
+		want  []string
+	}{
+		{entities.CategoryOrder{}, []string{"beach", "casual", "formal", "work"}},
+		{entities.CategoryOrder{Sort: entities.CategorySortManual, Manual: []string{"work", "missing", "formal"}}, []string{"work", "formal", "beach", "casual"}},
+		{entities.CategoryOrder{Sort: entities.CategorySortHealth}, []string{"beach", "work", "casual", "formal"}},
+		{entities.CategoryOrder{Sort: entities.CategorySortProgress}, []string{"formal", "casual", "work", "beach"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.order.EffectiveSort(), func(t *testing.T) {
+			got := slices.Clone(names)
+			slices.SortFunc(got, CategoryComparison(tt.order, health, progress))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sorted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package logic
+
+import "time"
+
+// CategoryResting reports whether a category last picked at lastPicked is
+// still resting at now. A category rests for days calendar days after the
+// day it was picked, so with one rest day it is not picked two days in a
+// row. Zero days, or a category never picked, never rests.
+func CategoryResting(lastPicked time.Time, days int, now time.Time) bool {
+	if days <= 0 || lastPicked.IsZero() {
+		return false
+	}
+	year, month, day := lastPicked.In(now.Location()).Date()
+	rested := time.Date(year, month, day+days+1, 0, 0, 0, 0, now.Location())
+	return now.Before(rested)
+}
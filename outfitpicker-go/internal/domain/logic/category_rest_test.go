@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCategoryResting(t *testing.T) {
+	picked := time.Date(2024, 6, 1, 21, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		lastPicked time.Time
+		days       int
+		now        time.Time
+		want       bool
+	}{
+		{"never picked", time.Time{}, 1, picked, false},
+		{"no rest days", picked, 0, picked.Add(time.Hour), false},
+		{"later the same day", picked, 1, picked.Add(2 * time.Hour), true},
+		{"the next morning", picked, 1, time.Date(2024, 6, 2, 7, 0, 0, 0, time.UTC), true},
+		{"two days later", picked, 1, time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), false},
+		{"within a longer rest", picked, 3, time.Date(2024, 6, 4, 23, 0, 0, 0, time.UTC), true},
+		{"after a longer rest", picked, 3, time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CategoryResting(tt.lastPicked, tt.days, tt.now); got != tt.want {
+				t.Errorf("CategoryResting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"math/rand/v2"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// CategoryWeight returns how likely picks across all categories are to
+// choose category, which has available outfits left in its rotation: its
+// priority, divided by available under CategoryBalanceRemaining.
+func CategoryWeight(preferences entities.SelectionPreferences, category string, available int) float64 {
+	weight := preferences.CategoryPriority(category)
+	if preferences.CategoryBalance == entities.CategoryBalanceRemaining && available > 0 {
+		weight /= float64(available)
+	}
+	return weight
+}
+
+// WeightedOrder returns names in a random order in which each next name is
+// drawn with a chance proportional to its weight. Names with non-positive
+// weights come last, in random order.
+func WeightedOrder(names []string, weight func(string) float64, source *rand.Rand) []string {
+	var weighted, rest []string
+	weights := make(map[string]float64, len(names))
+	for _, name := range names {
+		if w := weight(name); w > 0 {
+			weighted = append(weighted, name)
+			weights[name] = w
+		} else {
+			rest = append(rest, name)
+		}
+	}
+
+	order := make([]string, 0, len(names))
+	for len(weighted) > 0 {
+		total := 0.0
+		for _, name := range weighted {
+			total += weights[name]
+		}
+		target := source.Float64() * total
+		chosen := len(weighted) - 1
+		for i, name := range weighted {
+			if target < weights[name] {
+				chosen = i
+				break
+			}
+			target -= weights[name]
+		}
+		order = append(order, weighted[chosen])
+		weighted = append(weighted[:chosen], weighted[chosen+1:]...)
+	}
+	for _, i := range source.Perm(len(rest)) {
+		order = append(order, rest[i])
+	}
+	return order
+}
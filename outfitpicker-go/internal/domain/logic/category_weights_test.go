@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestCategoryWeight(t *testing.T) {
+	preferences := entities.SelectionPreferences{CategoryPriorities: map[string]float64{"work": 3}}
+	if got := CategoryWeight(preferences, "casual", 10); got != 1 {
+		t.Errorf("default weight = %v, want 1", got)
+	}
+	if got := CategoryWeight(preferences, "work", 10); got != 3 {
+		t.Errorf("prioritized weight = %v, want 3", got)
+	}
+
+	preferences.CategoryBalance = entities.CategoryBalanceRemaining
+	if got := CategoryWeight(preferences, "casual", 4); got != 0.25 {
+		t.Errorf("balanced weight = %v, want 0.25", got)
+	}
+	if got := CategoryWeight(preferences, "work", 2); got != 1.5 {
+		t.Errorf("balanced prioritized weight = %v, want 1.5", got)
+	}
+}
+
+func TestWeightedOrder(t *testing.T) {
+	weights := map[string]float64{"casual": 1, "work": 9, "gym": 0}
+	weight := func(name string) float64 { return weights[name] }
+	names := []string{"casual", "gym", "work"}
+
+	firsts := make(map[string]int)
+	for seed := range uint64(1000) {
+		order := WeightedOrder(names, weight, rand.New(rand.NewPCG(seed, seed)))
+		if len(order) != 3 || order[2] != "gym" {
+			t.Fatalf("WeightedOrder() = %v, want the zero weight last", order)
+		}
+		sorted := slices.Sorted(slices.Values(order))
+		if !slices.Equal(sorted, names) {
+			t.Fatalf("WeightedOrder() = %v, want every name once", order)
+		}
+		firsts[order[0]]++
+	}
+	if firsts["work"] < 850 || firsts["work"] > 950 {
+		t.Errorf("work came first %d times in 1000, want about 900", firsts["work"])
+	}
+
+	again := WeightedOrder(names, weight, rand.New(rand.NewPCG(7, 7)))
+	if first := WeightedOrder(names, weight, rand.New(rand.NewPCG(7, 7))); !slices.Equal(first, again) {
+		t.Errorf("seeded orders differ: %v, %v", first, again)
+	}
+}
@@ -0,0 +1,85 @@
+package logic
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// ChallengeOutfit counts the challenge wears of one capsule outfit.
+type ChallengeOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Wears    int    `json:"wears"`
+}
+
+// ChallengeReport sums up a capsule challenge, running or over.
+type ChallengeReport struct {
+	StartedAt time.Time `json:"startedAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	// Finished is set once the challenge is over, and EndedEarly when it
+	// was ended before EndsAt.
+	Finished   bool `json:"finished"`
+	EndedEarly bool `json:"endedEarly"`
+	// Day is the day of the challenge it is on, or was on when it ended,
+	// counted from 1; Days is how many days it was planned to last.
+	Day  int `json:"day"`
+	Days int `json:"days"`
+	// Capsule lists every capsule outfit, most worn first, then by
+	// category and file name.
+	Capsule []ChallengeOutfit `json:"capsule"`
+	// Wears counts the wears during the challenge, WornOutfits the
+	// capsule outfits worn at least once and DaysWorn the calendar days
+	// with a wear.
+	Wears       int `json:"wears"`
+	WornOutfits int `json:"wornOutfits"`
+	DaysWorn    int `json:"daysWorn"`
+}
+
+// InCapsule keeps the outfits of category in the challenge's capsule.
+func InCapsule(challenge entities.Challenge, category string) OutfitFilter {
+	return func(entry entities.FileEntry) bool {
+		return challenge.Contains(category, entry.FileName)
+	}
+}
+
+// SummarizeChallenge reports on challenge as of now.
+func SummarizeChallenge(challenge entities.Challenge, now time.Time) ChallengeReport {
+	const day = 24 * time.Hour
+	end := challenge.EndTime()
+	report := ChallengeReport{
+		StartedAt:  challenge.StartedAt,
+		EndsAt:     challenge.EndsAt,
+		Finished:   !now.Before(end),
+		EndedEarly: !challenge.EndedAt.IsZero(),
+		Days:       int(challenge.EndsAt.Sub(challenge.StartedAt) / day),
+		Capsule:    []ChallengeOutfit{},
+	}
+	reached := now
+	if report.Finished {
+		reached = end
+	}
+	report.Day = min(int(reached.Sub(challenge.StartedAt)/day)+1, report.Days)
+
+	wears := make(map[entities.OutfitLocation]int)
+	days := make(map[time.Time]bool)
+	for _, wear := range challenge.Wears {
+		wears[entities.OutfitLocation{Category: wear.Category, FileName: wear.FileName}]++
+		days[calendarDay(wear.WornAt, now.Location())] = true
+	}
+	for _, location := range challenge.Capsule {
+		outfit := ChallengeOutfit{Category: location.Category, FileName: location.FileName, Wears: wears[location]}
+		report.Capsule = append(report.Capsule, outfit)
+		report.Wears += outfit.Wears
+		if outfit.Wears > 0 {
+			report.WornOutfits++
+		}
+	}
+	report.DaysWorn = len(days)
+	slices.SortStableFunc(report.Capsule, func(a, b ChallengeOutfit) int {
+		return cmp.Or(cmp.Compare(b.Wears, a.Wears), cmp.Compare(a.Category, b.Category), cmp.Compare(a.FileName, b.FileName))
+	})
+	return report
+}
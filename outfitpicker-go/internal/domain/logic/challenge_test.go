@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestSummarizeChallenge(t *testing.T) {
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	challenge := entities.Challenge{
+		Capsule: []entities.OutfitLocation{
+			{Category: "casual", FileName: "tee.avatar"},
+			{Category: "casual", FileName: "jeans.avatar"},
+			{Category: "work", FileName: "shirt.avatar"},
+		},
+		StartedAt: start,
+		EndsAt:    start.AddDate(0, 0, 7),
+		Wears: []entities.WearEvent{
+			{Category: "work", FileName: "shirt.avatar", WornAt: start},
+			{Category: "casual", FileName: "tee.avatar", WornAt: start.Add(time.Hour)},
+			{Category: "work", FileName: "shirt.avatar", WornAt: start.AddDate(0, 0, 2)},
+		},
+	}
+
+	report := SummarizeChallenge(challenge, start.AddDate(0, 0, 2).Add(time.Hour))
+	if report.Finished || report.EndedEarly || report.Day != 3 || report.Days != 7 {
+		t.Errorf("report = %+v, want day 3 of 7 running", report)
+	}
+	if report.Wears != 3 || report.WornOutfits != 2 || report.DaysWorn != 2 {
+		t.Errorf("wears = %d, worn outfits = %d, days worn = %d; want 3, 2, 2", report.Wears, report.WornOutfits, report.DaysWorn)
+	}
+	want := []ChallengeOutfit{
+		{Category: "work", FileName: "shirt.avatar", Wears: 2},
+		{Category: "casual", FileName: "tee.avatar", Wears: 1},
+		{Category: "casual", FileName: "jeans.avatar", Wears: 0},
+	}
+	for i, outfit := range report.Capsule {
+		if outfit != want[i] {
+			t.Errorf("Capsule[%d] = %+v, want %+v", i, outfit, want[i])
+		}
+	}
+
+	if over := SummarizeChallenge(challenge, start.AddDate(0, 1, 0)); !over.Finished || over.EndedEarly || over.Day != 7 {
+		t.Errorf("report after the end = %+v, want finished on day 7", over)
+	}
+	ended := SummarizeChallenge(challenge.Ending(start.AddDate(0, 0, 3)), start.AddDate(0, 1, 0))
+	if !ended.Finished || !ended.EndedEarly || ended.Day != 4 {
+		t.Errorf("report of an ended challenge = %+v, want ended early on day 4", ended)
+	}
+}
+
+func TestInCapsule(t *testing.T) {
+	challenge := entities.Challenge{Capsule: []entities.OutfitLocation{{Category: "casual", FileName: "tee.avatar"}}}
+	files := []entities.FileEntry{entities.NewFileEntry("/w/casual/tee.avatar"), entities.NewFileEntry("/w/casual/jeans.avatar")}
+	got := FilterAvailableOutfits(files, nil, InCapsule(challenge, "casual"))
+	if len(got) != 1 || got[0].FileName != "tee.avatar" {
+		t.Errorf("InCapsule() kept %v, want tee.avatar", fileNamesOf(got))
+	}
+	if got := FilterAvailableOutfits(files, nil, InCapsule(challenge, "work")); len(got) != 0 {
+		t.Errorf("InCapsule() of another category kept %v", fileNamesOf(got))
+	}
+}
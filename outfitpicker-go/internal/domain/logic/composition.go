@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// maxCompositionSteps bounds how many pieces Compose tries before giving
+// up, so a large wardrobe with strict rules cannot stall a pick.
+const maxCompositionSteps = 10000
+
+// Piece is an outfit that may fill a slot of an ensemble, with the tags
+// and colors the compatibility rules look at.
+type Piece struct {
+	Slot     string
+	Category string
+	FileName string
+	Tags     []string
+	Colors   []string
+}
+
+func (p Piece) String() string {
+	return p.Category + "/" + p.FileName
+}
+
+// Compose chooses one piece for each slot, in slot order, from candidates:
+// the pieces that may fill each slot, most preferred first. The first
+// ensemble in order of preference that breaks none of the compatibility
+// rules of settings is chosen; a slot nothing can fill, or candidates that
+// never go together, fail with an InvalidInputError naming the reason.
+func Compose(slots []string, candidates map[string][]Piece, settings entities.EnsembleSettings) ([]Piece, error) {
+	for _, slot := range slots {
+		if len(candidates[slot]) == 0 {
+			return nil, errors.NewInvalidInputError(fmt.Sprintf("no outfit is left to pick for the %s slot", slot))
+		}
+	}
+	c := composer{slots: slots, candidates: candidates, settings: settings}
+	if c.fill(nil) {
+		return c.chosen, nil
+	}
+	return nil, errors.NewInvalidInputError("no ensemble of the outfits left to pick follows the compatibility rules, such as: " + c.reason)
+}
+
+// composer searches for an ensemble depth first.
+type composer struct {
+	slots      []string
+	candidates map[string][]Piece
+	settings   entities.EnsembleSettings
+	steps      int
+	chosen     []Piece
+	// reason is why the first piece turned down could not join the others.
+	reason string
+}
+
+// fill adds a piece for each slot after the ones in chosen, reporting
+// whether every slot was filled.
+func (c *composer) fill(chosen []Piece) bool {
+	if len(chosen) == len(c.slots) {
+		c.chosen = chosen
+		return true
+	}
+	for _, piece := range c.candidates[c.slots[len(chosen)]] {
+		if c.steps++; c.steps > maxCompositionSteps {
+			return false
+		}
+		if reason := Incompatibility(chosen, piece, c.settings); reason != "" {
+			if c.reason == "" {
+				c.reason = reason
+			}
+			continue
+		}
+		if c.fill(append(slices.Clone(chosen), piece)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Incompatibility returns why piece cannot join the pieces already chosen
+// for an ensemble under the compatibility rules of settings, or "" when it
+// can.
+func Incompatibility(chosen []Piece, piece Piece, settings entities.EnsembleSettings) string {
+	for _, other := range chosen {
+		if a, b, ok := clash(settings.TagConflicts, other.Tags, piece.Tags); ok {
+			return fmt.Sprintf("%s and %s: tags %s and %s conflict", other, piece, a, b)
+		}
+		if a, b, ok := clash(settings.ColorClashes, other.Colors, piece.Colors); ok {
+			return fmt.Sprintf("%s and %s: colors %s and %s clash", other, piece, a, b)
+		}
+	}
+	if settings.MaxColors > 0 {
+		colors := make(map[string]bool)
+		for _, p := range append(slices.Clone(chosen), piece) {
+			for _, color := range p.Colors {
+				colors[color] = true
+			}
+		}
+		if len(colors) > settings.MaxColors {
+			return fmt.Sprintf("%s would make %d colors, more than the %d allowed", piece, len(colors), settings.MaxColors)
+		}
+	}
+	return ""
+}
+
+// clash returns the first rule pairing a label of one piece with a label
+// of the other.
+func clash(rules [][2]string, first, second []string) (string, string, bool) {
+	for _, rule := range rules {
+		switch {
+		case slices.Contains(first, rule[0]) && slices.Contains(second, rule[1]):
+			return rule[0], rule[1], true
+		case slices.Contains(first, rule[1]) && slices.Contains(second, rule[0]):
+			return rule[1], rule[0], true
+		}
+	}
+	return "", "", false
+}
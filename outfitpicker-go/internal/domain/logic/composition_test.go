@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestCompose(t *testing.T) {
+	tee := Piece{Slot: "top", Category: "shirts", FileName: "tee.avatar", Tags: []string{"sporty"}, Colors: []string{"red"}}
+	blouse := Piece{Slot: "top", Category: "shirts", FileName: "blouse.avatar", Colors: []string{"white"}}
+	chinos := Piece{Slot: "bottom", Category: "trousers", FileName: "chinos.avatar", Tags: []string{"formal"}, Colors: []string{"navy"}}
+	joggers := Piece{Slot: "bottom", Category: "trousers", FileName: "joggers.avatar", Tags: []string{"sporty"}, Colors: []string{"pink"}}
+	slots := []string{"top", "bottom"}
+	candidates := map[string][]Piece{"top": {tee, blouse}, "bottom": {chinos, joggers}}
+
+	tests := []struct {
+		name     string
+		settings entities.EnsembleSettings
+		want     string
+	}{
+		{"no rules takes the preferred pieces", entities.EnsembleSettings{}, "shirts/tee.avatar trousers/chinos.avatar"},
+		{"tag conflict", entities.EnsembleSettings{TagConflicts: [][2]string{{"formal", "sporty"}}}, "shirts/tee.avatar trousers/joggers.avatar"},
+		{"tag paired with itself", entities.EnsembleSettings{TagConflicts: [][2]string{{"formal", "sporty"}, {"sporty", "sporty"}}}, "shirts/blouse.avatar trousers/chinos.avatar"},
+		{"color clash", entities.EnsembleSettings{ColorClashes: [][2]string{{"navy", "red"}}}, "shirts/tee.avatar trousers/joggers.avatar"},
+		{"color cap", entities.EnsembleSettings{MaxColors: 1}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieces, err := Compose(slots, candidates, tt.settings)
+			if tt.want == "" {
+				var invalid *domainerrors.InvalidInputError
+				if !errors.As(err, &invalid) || !strings.Contains(err.Error(), "more than the 1 allowed") {
+					t.Errorf("Compose() error = %v, want the rule it breaks", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Compose() error = %v", err)
+			}
+			if got := pieces[0].String() + " " + pieces[1].String(); got != tt.want {
+				t.Errorf("Compose() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Compose([]string{"top", "shoes"}, candidates, entities.EnsembleSettings{}); err == nil || !strings.Contains(err.Error(), "shoes slot") {
+		t.Errorf("Compose() with an empty slot error = %v, want the slot named", err)
+	}
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// PlanDiskRepair compares the cache and the known files of the
+// configuration with the wardrobe on disk, and returns what treating the
+// disk as authoritative would change in each category that differs, sorted
+// by category. ReconcileCache and the known files of snapshot make those
+// changes.
+func PlanDiskRepair(cache entities.OutfitCache, knownFiles map[string]map[string]bool, snapshot entities.WardrobeSnapshot) []entities.CategoryRepair {
+	categories := make(map[string]bool)
+	for category := range cache.Categories {
+		categories[category] = true
+	}
+	for category := range knownFiles {
+		categories[category] = true
+	}
+	for category := range snapshot {
+		categories[category] = true
+	}
+
+	var repairs []entities.CategoryRepair
+	for _, category := range slices.Sorted(maps.Keys(categories)) {
+		files, onDisk := snapshot[category]
+		present := make(map[string]bool, len(files))
+		for _, file := range files {
+			present[file] = true
+		}
+		repair := entities.CategoryRepair{Category: category, Removed: !onDisk}
+		if categoryCache, cached := cache.Categories[category]; cached {
+			repair.TotalBefore = categoryCache.TotalOutfits
+			if onDisk {
+				repair.TotalAfter = len(files)
+			}
+			for fileName := range categoryCache.WornOutfits {
+				if !present[fileName] {
+					repair.DroppedWorn = append(repair.DroppedWorn, fileName)
+				}
+			}
+			slices.Sort(repair.DroppedWorn)
+		}
+		known := slices.Sorted(maps.Keys(knownFiles[category]))
+		repair.AddedKnown = difference(files, known)
+		repair.RemovedKnown = difference(known, files)
+
+		if repair.Removed || repair.TotalBefore != repair.TotalAfter || len(repair.DroppedWorn) > 0 ||
+			len(repair.AddedKnown) > 0 || len(repair.RemovedKnown) > 0 {
+			repairs = append(repairs, repair)
+		}
+	}
+	return repairs
+}
@@ -0,0 +1,39 @@
+package logic
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestPlanDiskRepair(t *testing.T) {
+	cache := entities.OutfitCache{Categories: map[string]entities.CategoryCache{
+		"casual": entities.NewCategoryCache(3).Adding("jeans.avatar").Adding("tee.avatar"),
+		"work":   entities.NewCategoryCache(1).Adding("suit.avatar"),
+		"formal": entities.NewCategoryCache(1),
+	}}
+	known := map[string]map[string]bool{
+		"casual": {"tee.avatar": true, "jeans.avatar": true},
+		"work":   {"suit.avatar": true},
+	}
+	snapshot := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "hoodie.avatar"},
+		"work":   {"suit.avatar"},
+		"gym":    nil,
+	}
+
+	want := []entities.CategoryRepair{
+		{Category: "casual", TotalBefore: 3, TotalAfter: 2, DroppedWorn: []string{"jeans.avatar"}, AddedKnown: []string{"hoodie.avatar"}, RemovedKnown: []string{"jeans.avatar"}},
+		{Category: "formal", Removed: true, TotalBefore: 1},
+	}
+	if got := PlanDiskRepair(cache, known, snapshot); !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanDiskRepair() = %+v, want %+v", got, want)
+	}
+	if got := PlanDiskRepair(ReconcileCache(cache, snapshot), map[string]map[string]bool{
+		"casual": {"tee.avatar": true, "hoodie.avatar": true},
+		"work":   {"suit.avatar": true},
+	}, snapshot); len(got) != 0 {
+		t.Errorf("PlanDiskRepair() after repairing = %+v, want nothing left", got)
+	}
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// HeatmapDay counts the picks of one calendar day.
+type HeatmapDay struct {
+	Date  time.Time `json:"date"`
+	Picks int       `json:"picks"`
+}
+
+// PickHeatmap counts the picks of every day of a year, for drawing as a
+// calendar.
+type PickHeatmap struct {
+	Year int `json:"year"`
+	// Category is the category counted, or empty for every category.
+	Category string `json:"category,omitempty"`
+	// Days lists every day of the year in order, from January 1st.
+	Days []HeatmapDay `json:"days"`
+	// MaxPicks is the most picks on any one day.
+	MaxPicks int `json:"maxPicks"`
+	// Total counts the picks of the whole year.
+	Total int `json:"total"`
+}
+
+// BuildPickHeatmap counts the picks in history from category, or from every
+// category when it is empty, on each day of year in loc.
+func BuildPickHeatmap(history entities.SelectionHistory, category string, year int, loc *time.Location) PickHeatmap {
+	heatmap := PickHeatmap{Year: year, Category: category, Days: []HeatmapDay{}}
+	picks := make(map[time.Time]int)
+	for _, record := range history.Records {
+		if category == "" || record.Category == category {
+			picks[calendarDay(record.SelectedAt, loc)]++
+		}
+	}
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, loc); day.Year() == year; day = day.AddDate(0, 0, 1) {
+		count := picks[day]
+		heatmap.Days = append(heatmap.Days, HeatmapDay{Date: day, Picks: count})
+		heatmap.MaxPicks = max(heatmap.MaxPicks, count)
+		heatmap.Total += count
+	}
+	return heatmap
+}
+
+// HeatLevel grades picks from 0 for none to 4 for the busiest days,
+// relative to maxPicks.
+func HeatLevel(picks, maxPicks int) int {
+	if picks <= 0 || maxPicks <= 0 {
+		return 0
+	}
+	return min((picks*4+maxPicks-1)/maxPicks, 4)
+}
@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestBuildPickHeatmap(t *testing.T) {
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", SelectedAt: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)},
+		{Category: "casual", SelectedAt: time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)},
+		{Category: "work", SelectedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)},
+		{Category: "casual", SelectedAt: time.Date(2024, 12, 31, 9, 0, 0, 0, time.UTC)},
+		{Category: "casual", SelectedAt: time.Date(2023, 12, 31, 9, 0, 0, 0, time.UTC)},
+	}}
+
+	heatmap := BuildPickHeatmap(history, "casual", 2024, time.UTC)
+	if len(heatmap.Days) != 366 {
+		t.Fatalf("days = %d, want 366 in a leap year", len(heatmap.Days))
+	}
+	if heatmap.Days[0].Picks != 2 || heatmap.Days[365].Picks != 1 || heatmap.Days[1].Picks != 0 {
+		t.Errorf("picks = %d, %d, %d; want 2 on January 1st, 1 on December 31st", heatmap.Days[0].Picks, heatmap.Days[365].Picks, heatmap.Days[1].Picks)
+	}
+	if heatmap.MaxPicks != 2 || heatmap.Total != 3 {
+		t.Errorf("max = %d, total = %d; want 2, 3", heatmap.MaxPicks, heatmap.Total)
+	}
+
+	all := BuildPickHeatmap(history, "", 2024, time.UTC)
+	if all.Days[0].Picks != 3 || all.Total != 4 {
+		t.Errorf("every category: January 1st = %d, total = %d; want 3, 4", all.Days[0].Picks, all.Total)
+	}
+	if got := len(BuildPickHeatmap(history, "", 2023, time.UTC).Days); got != 365 {
+		t.Errorf("days of 2023 = %d, want 365", got)
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	tests := []struct{ picks, max, want int }{
+		{0, 0, 0},
+		{0, 4, 0},
+		{1, 1, 4},
+		{1, 4, 1},
+		{2, 4, 2},
+		{3, 4, 3},
+		{4, 4, 4},
+		{1, 8, 1},
+		{5, 8, 3},
+	}
+	for _, tt := range tests {
+		if got := HeatLevel(tt.picks, tt.max); got != tt.want {
+			t.Errorf("HeatLevel(%d, %d) = %d, want %d", tt.picks, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package logic
+
+import (
+	"path"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// IgnoreFileName is the file, at the wardrobe root or inside a category,
+// that lists ignore patterns in gitignore syntax.
+const IgnoreFileName = ".outfitignore"
+
+// ArchiveDirName is the directory under the wardrobe root that archived
+// outfits are moved into, one sub-directory per category.
+const ArchiveDirName = ".outfitarchive"
+
+// builtinIgnorePatterns are skipped even when hidden entries are scanned:
+// version control, OS metadata, files left behind by sync conflicts and
+// archived outfits.
+var builtinIgnorePatterns = []string{".git", ".DS_Store", "*.sync-conflict-*", ArchiveDirName}
+
+// IgnoreRules decides which entries under the wardrobe root a scan skips.
+// Patterns follow gitignore: the last matching pattern wins, a leading !
+// re-includes, a trailing / matches only directories, a pattern containing
+// a / is anchored to the directory of the file that defines it, ** matches
+// any number of directories, and nothing inside an ignored directory can be
+// re-included.
+type IgnoreRules struct {
+	includeHidden bool
+	patterns      []ignorePattern
+}
+
+type ignorePattern struct {
+	// base is the slash-separated directory, relative to the root, of the
+	// ignore file that defined the pattern; empty for the root.
+	base     string
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewIgnoreRules returns the rules for the scan policy, including its
+// configured patterns, which are relative to the wardrobe root.
+func NewIgnoreRules(policy entities.ScanPolicy) IgnoreRules {
+	rules := IgnoreRules{includeHidden: policy.IncludeHidden}
+	return rules.WithPatterns("", policy.Ignore)
+}
+
+// WithFile returns a copy of the rules extended with the patterns of the
+// ignore file in dir, a slash-separated path relative to the wardrobe root.
+// Patterns from deeper files take precedence, as they are added later.
+func (r IgnoreRules) WithFile(dir, content string) IgnoreRules {
+	return r.WithPatterns(dir, ParseIgnoreFile(content))
+}
+
+// WithPatterns returns a copy of the rules extended with patterns relative
+// to dir.
+func (r IgnoreRules) WithPatterns(dir string, patterns []string) IgnoreRules {
+	extended := IgnoreRules{includeHidden: r.includeHidden}
+	extended.patterns = make([]ignorePattern, 0, len(r.patterns)+len(patterns))
+	extended.patterns = append(extended.patterns, r.patterns...)
+	for _, line := range patterns {
+		if pattern, ok := parseIgnorePattern(dir, line); ok {
+			extended.patterns = append(extended.patterns, pattern)
+		}
+	}
+	return extended
+}
+
+// ParseIgnoreFile returns the patterns in an ignore file, one per line.
+// Blank lines and lines starting with # are skipped; trailing spaces are
+// dropped unless escaped with a backslash.
+func ParseIgnoreFile(content string) []string {
+	var patterns []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		line = trimUnescapedTrailingSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns
+}
+
+func trimUnescapedTrailingSpace(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+func parseIgnorePattern(base, line string) (ignorePattern, bool) {
+	pattern := ignorePattern{base: base}
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if strings.Contains(line, "/") {
+		pattern.anchored = true
+		line = strings.TrimPrefix(line, "/")
+	}
+	if line == "" {
+		return ignorePattern{}, false
+	}
+	pattern.segments = strings.Split(line, "/")
+	return pattern, true
+}
+
+// Ignores reports whether the entry at relPath, a slash-separated path
+// relative to the wardrobe root, is skipped. isDir tells whether the entry
+// is a directory.
+func (r IgnoreRules) Ignores(relPath string, isDir bool) bool {
+	segments := strings.Split(relPath, "/")
+	for i := 1; i < len(segments); i++ {
+		if r.ignoresEntry(segments[:i], true) {
+			return true
+		}
+	}
+	return r.ignoresEntry(segments, isDir)
+}
+
+func (r IgnoreRules) ignoresEntry(segments []string, isDir bool) bool {
+	name := segments[len(segments)-1]
+	if !r.includeHidden && strings.HasPrefix(name, ".") {
+		return true
+	}
+	for _, pattern := range builtinIgnorePatterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	ignored := false
+	for _, pattern := range r.patterns {
+		if pattern.matches(segments, isDir) {
+			ignored = !pattern.negate
+		}
+	}
+	return ignored
+}
+
+func (p ignorePattern) matches(segments []string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if p.base != "" {
+		baseSegments := strings.Split(p.base, "/")
+		if len(segments) <= len(baseSegments) {
+			return false
+		}
+		for i, segment := range baseSegments {
+			if segments[i] != segment {
+				return false
+			}
+		}
+		segments = segments[len(baseSegments):]
+	}
+	if !p.anchored {
+		matched, _ := path.Match(p.segments[0], segments[len(segments)-1])
+		return matched
+	}
+	return matchSegments(p.segments, segments)
+}
+
+// matchSegments matches path segments against glob segments, where a "**"
+// segment matches any number of path segments, or at least one when it ends
+// the pattern.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		minimum := 0
+		if len(pattern) == 1 {
+			minimum = 1
+		}
+		for skip := minimum; skip <= len(segments); skip++ {
+			if matchSegments(pattern[1:], segments[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// ExactIgnorePattern returns a pattern, relative to the wardrobe root, that
+// matches only the named outfit file, escaping any glob metacharacters in
+// its name.
+func ExactIgnorePattern(category, fileName string) string {
+	return "/" + escapeGlob(category) + "/" + escapeGlob(fileName)
+}
+
+func escapeGlob(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestParseIgnoreFile(t *testing.T) {
+	got := ParseIgnoreFile("# drafts\n\n*.bak\nold  \r\nkeep\\ \n\\#hash\n")
+	if want := []string{"*.bak", "old", `keep\ `, `\#hash`}; !slices.Equal(got, want) {
+		t.Errorf("ParseIgnoreFile() = %q, want %q", got, want)
+	}
+}
+
+func TestIgnoreRules_Policy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy entities.ScanPolicy
+		path   string
+		isDir  bool
+		want   bool
+	}{
+		{"regular outfit", entities.ScanPolicy{}, "casual/tee.avatar", false, false},
+		{"hidden file skipped by default", entities.ScanPolicy{}, "casual/.tee.avatar", false, true},
+		{"hidden directory skipped by default", entities.ScanPolicy{}, ".archive", true, true},
+		{"file in hidden directory", entities.ScanPolicy{}, ".archive/old.avatar", false, true},
+		{"hidden file included", entities.ScanPolicy{IncludeHidden: true}, "casual/.tee.avatar", false, false},
+		{".git always skipped", entities.ScanPolicy{IncludeHidden: true}, ".git", true, true},
+		{".DS_Store always skipped", entities.ScanPolicy{IncludeHidden: true}, "casual/.DS_Store", false, true},
+		{"sync conflict always skipped", entities.ScanPolicy{IncludeHidden: true}, "casual/tee.sync-conflict-20240601-090000-ABC.avatar", false, true},
+		{"archive always skipped", entities.ScanPolicy{IncludeHidden: true}, ".outfitarchive/casual/tee.avatar", false, true},
+		{"config pattern", entities.ScanPolicy{Ignore: []string{"*.bak.avatar"}}, "casual/tee.bak.avatar", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIgnoreRules(tt.policy).Ignores(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("Ignores(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreRules_GitignoreSyntax(t *testing.T) {
+	rules := NewIgnoreRules(entities.ScanPolicy{}).WithFile("", `
+# root rules
+*.bak.avatar
+!keep.bak.avatar
+drafts/
+/formal/old-*.avatar
+archive/**
+**/retired.avatar
+casual/secret.avatar
+\!bang.avatar
+`)
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"casual/tee.avatar", false, false},
+		{"casual/tee.bak.avatar", false, true},
+		{"casual/keep.bak.avatar", false, false},
+		{"drafts", true, true},
+		{"drafts/idea.avatar", false, true},
+		{"casual/drafts", false, false},
+		{"formal/old-suit.avatar", false, true},
+		{"casual/old-suit.avatar", false, false},
+		{"archive", true, false},
+		{"archive/coat.avatar", false, true},
+		{"casual/retired.avatar", false, true},
+		{"retired.avatar", false, true},
+		{"casual/secret.avatar", false, true},
+		{"!bang.avatar", false, true},
+	}
+	for _, tt := range tests {
+		if got := rules.Ignores(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Ignores(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExactIgnorePattern(t *testing.T) {
+	pattern := ExactIgnorePattern("casual", "tee[1]*.avatar")
+	if want := `/casual/tee\[1\]\*.avatar`; pattern != want {
+		t.Fatalf("ExactIgnorePattern() = %q, want %q", pattern, want)
+	}
+	rules := NewIgnoreRules(entities.ScanPolicy{Ignore: []string{pattern}})
+	for _, tt := range []struct {
+		path string
+		want bool
+	}{
+		{"casual/tee[1]*.avatar", true},
+		{"casual/tee1x.avatar", false},
+		{"formal/tee[1]*.avatar", false},
+	} {
+		if got := rules.Ignores(tt.path, false); got != tt.want {
+			t.Errorf("Ignores(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIgnoreRules_NegationCannotReincludeFromIgnoredDirectory(t *testing.T) {
+	rules := NewIgnoreRules(entities.ScanPolicy{}).WithFile("", "drafts/\n!drafts/keep.avatar\n")
+
+	if !rules.Ignores("drafts/keep.avatar", false) {
+		t.Error("file inside an ignored directory was re-included")
+	}
+}
+
+func TestIgnoreRules_NestedFiles(t *testing.T) {
+	rules := NewIgnoreRules(entities.ScanPolicy{}).
+		WithFile("", "*.bak.avatar\n").
+		WithFile("casual", "!tee.bak.avatar\n/jeans.avatar\n")
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"casual/tee.bak.avatar", false},
+		{"formal/tee.bak.avatar", true},
+		{"casual/jeans.avatar", true},
+		{"formal/jeans.avatar", false},
+		{"casual/shirt.bak.avatar", true},
+	}
+	for _, tt := range tests {
+		if got := rules.Ignores(tt.path, false); got != tt.want {
+			t.Errorf("Ignores(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package logic
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ParseColumnMapping parses a comma-separated list of HEADER=FIELD pairs,
+// such as "Item=outfit,Labels=tags", naming the metadata import field each
+// spreadsheet column holds. The result is keyed by header, lowercased.
+func ParseColumnMapping(spec string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		header, field, ok := strings.Cut(pair, "=")
+		header, field = strings.TrimSpace(header), strings.ToLower(strings.TrimSpace(field))
+		if !ok || header == "" {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("column mapping %q is not HEADER=FIELD", pair))
+		}
+		if !slices.Contains(entities.ImportFields(), field) {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("unknown import field %q (want one of: %s)", field, strings.Join(entities.ImportFields(), ", ")))
+		}
+		mapping[strings.ToLower(header)] = field
+	}
+	return mapping, nil
+}
+
+// ParseMetadataCSV reads the rows of a spreadsheet export whose first line
+// names its columns. mapping, from ParseColumnMapping, names the field of
+// each column; a column it leaves out holds the field it is named after,
+// if any, and is ignored otherwise. Category and outfit columns are
+// required, with at least one of tags, weight and rating. Tags in a cell
+// are separated by commas or semicolons. Blank rows are skipped.
+func ParseMetadataCSV(r io.Reader, mapping map[string]string) ([]entities.MetadataImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, domainerrors.NewInvalidInputError("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+	columns, err := importColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []entities.MetadataImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+		line, _ := reader.FieldPos(0)
+		row, err := importRow(record, columns)
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("line %d: %s", line, importErrorMessage(err)))
+		}
+		if row == nil {
+			continue
+		}
+		row.Line = line
+		rows = append(rows, *row)
+	}
+	return rows, nil
+}
+
+// importColumns returns the column index of each field named by header.
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		field, ok := mapping[name]
+		if !ok {
+			if !slices.Contains(entities.ImportFields(), name) {
+				continue
+			}
+			field = name
+		}
+		if _, taken := columns[field]; taken {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("more than one column holds the %s field", field))
+		}
+		columns[field] = i
+	}
+	for _, field := range []string{entities.ImportFieldCategory, entities.ImportFieldOutfit} {
+		if _, ok := columns[field]; !ok {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("no column holds the %s field; name one with --map HEADER=%s", field, field))
+		}
+	}
+	if len(columns) == 2 {
+		return nil, domainerrors.NewInvalidInputError("no column holds tags, weight or rating")
+	}
+	return columns, nil
+}
+
+// importRow reads the fields of record, returning nil for a blank row.
+func importRow(record []string, columns map[string]int) (*entities.MetadataImportRow, error) {
+	cell := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	if strings.TrimSpace(strings.Join(record, "")) == "" {
+		return nil, nil
+	}
+	row := &entities.MetadataImportRow{Category: cell(entities.ImportFieldCategory), FileName: cell(entities.ImportFieldOutfit)}
+	if row.Category == "" || row.FileName == "" {
+		return nil, domainerrors.NewInvalidInputError("category and outfit are required")
+	}
+	for _, tag := range strings.FieldsFunc(cell(entities.ImportFieldTags), func(r rune) bool { return r == ',' || r == ';' }) {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(row.Tags, tag) {
+			row.Tags = append(row.Tags, tag)
+		}
+	}
+	if value := cell(entities.ImportFieldWeight); value != "" {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("weight must be a number, got %q", value))
+		}
+		row.Weight = &weight
+	}
+	if value := cell(entities.ImportFieldRating); value != "" {
+		rating, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("rating must be a whole number, got %q", value))
+		}
+		row.Rating = rating
+	}
+	if err := row.Validate(); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// importErrorMessage returns the message of an invalid input error, or the
+// error itself otherwise.
+func importErrorMessage(err error) string {
+	var invalid *domainerrors.InvalidInputError
+	if errors.As(err, &invalid) {
+		return invalid.Message
+	}
+	return err.Error()
+}
+
+func csvError(err error) error {
+	return domainerrors.NewInvalidInputError(fmt.Sprintf("malformed CSV: %v", err))
+}
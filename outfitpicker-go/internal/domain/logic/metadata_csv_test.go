@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestParseMetadataCSV(t *testing.T) {
+	mapping, err := ParseColumnMapping("Item=outfit, Labels=tags")
+	if err != nil {
+		t.Fatalf("ParseColumnMapping() error = %v", err)
+	}
+	content := "\ufeffCategory,Item,Labels,Weight,Rating,Notes\n" +
+		"casual,tee.avatar,\"summer, beach\",2,4,worn a lot\n" +
+		"\n" +
+		"work,blazer.avatar,office;office,,,\n"
+
+	rows, err := ParseMetadataCSV(strings.NewReader(content), mapping)
+	if err != nil {
+		t.Fatalf("ParseMetadataCSV() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("ParseMetadataCSV() = %+v, want 2 rows", rows)
+	}
+	tee, blazer := rows[0], rows[1]
+	if tee.Line != 2 || tee.Category != "casual" || tee.FileName != "tee.avatar" || !slices.Equal(tee.Tags, []string{"summer", "beach"}) || tee.Weight == nil || *tee.Weight != 2 || tee.Rating != 4 {
+		t.Errorf("row 1 = %+v", tee)
+	}
+	if blazer.Line != 4 || !slices.Equal(blazer.Tags, []string{"office"}) || blazer.Weight != nil || blazer.Rating != 0 {
+		t.Errorf("row 2 = %+v, want its tag once and nothing else", blazer)
+	}
+}
+
+func TestParseMetadataCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		mapping  map[string]string
+		wantLine string
+	}{
+		{"empty", "", nil, ""},
+		{"no outfit column", "category,tags\ncasual,summer\n", nil, ""},
+		{"no metadata columns", "category,outfit,notes\ncasual,tee.avatar,hi\n", nil, ""},
+		{"column mapped twice", "category,outfit,tags,labels\n", map[string]string{"labels": "tags"}, ""},
+		{"missing outfit", "category,outfit,tags\ncasual,,summer\n", nil, "line 2"},
+		{"malformed tag", "category,outfit,tags\ncasual,tee.avatar,Summer\n", nil, "line 2"},
+		{"weight not a number", "category,outfit,weight\ncasual,tee.avatar,heavy\n", nil, "line 2"},
+		{"rating out of range", "category,outfit,rating\ncasual,tee.avatar,9\n", nil, "line 2"},
+		{"malformed CSV", "category,outfit,tags\ncasual,\"tee.avatar,summer\n", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMetadataCSV(strings.NewReader(tt.content), tt.mapping)
+			var invalid *domainerrors.InvalidInputError
+			if !errors.As(err, &invalid) || !strings.Contains(invalid.Message, tt.wantLine) {
+				t.Errorf("ParseMetadataCSV() error = %v, want an InvalidInputError naming %q", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestParseColumnMapping_Errors(t *testing.T) {
+	for _, spec := range []string{"Item", "=outfit", "Item=notes"} {
+		if _, err := ParseColumnMapping(spec); err == nil {
+			t.Errorf("ParseColumnMapping(%q) succeeded", spec)
+		}
+	}
+}
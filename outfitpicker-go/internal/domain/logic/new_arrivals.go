@@ -0,0 +1,32 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// IsNewArrival reports whether an outfit first seen at firstSeen is still
+// within its first days days at now. The zero time marks outfits that were
+// there before arrivals were tracked, which are never new.
+func IsNewArrival(firstSeen time.Time, days int, now time.Time) bool {
+	return !firstSeen.IsZero() && now.Before(firstSeen.AddDate(0, 0, days))
+}
+
+// NewArrivals keeps the outfits that are new arrivals under policy.
+func NewArrivals(firstSeen map[string]time.Time, policy entities.NewArrivalPolicy, now time.Time) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		seen, ok := firstSeen[file.FileName]
+		return ok && IsNewArrival(seen, policy.Days, now)
+	}
+}
+
+// NotHeldBack keeps the outfits of category that are not untagged new
+// arrivals, which the hold policy leaves out of picks.
+func NotHeldBack(firstSeen map[string]time.Time, policy entities.NewArrivalPolicy, now time.Time, index entities.MetadataIndex, category string) OutfitFilter {
+	isNew := NewArrivals(firstSeen, policy, now)
+	return func(file entities.FileEntry) bool {
+		metadata, _ := index.Get(category, file.FileName)
+		return !isNew(file) || len(metadata.Tags) > 0
+	}
+}
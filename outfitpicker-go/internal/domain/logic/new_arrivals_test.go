@@ -0,0 +1,49 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestIsNewArrival(t *testing.T) {
+	now := time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		firstSeen time.Time
+		want      bool
+	}{
+		{"before tracking", time.Time{}, false},
+		{"today", now, true},
+		{"within the period", now.AddDate(0, 0, -6), true},
+		{"period over", now.AddDate(0, 0, -7), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNewArrival(tt.firstSeen, 7, now); got != tt.want {
+				t.Errorf("IsNewArrival() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewArrivalFilters(t *testing.T) {
+	now := time.Date(2024, 6, 15, 9, 0, 0, 0, time.UTC)
+	files := []entities.FileEntry{
+		entities.NewFileEntry("/wardrobe/casual/old.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/new.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/tagged.avatar"),
+	}
+	firstSeen := map[string]time.Time{"old.avatar": {}, "new.avatar": now, "tagged.avatar": now}
+	policy := entities.NewArrivalPolicy{Mode: entities.NewArrivalsHold, Days: 7}
+	index := entities.NewMetadataIndex().Setting("casual", "tagged.avatar", entities.OutfitMetadata{Tags: []string{"summer"}})
+
+	if got := FilterAvailableOutfits(files, nil, NewArrivals(firstSeen, policy, now)); len(got) != 2 || got[0].FileName != "new.avatar" {
+		t.Errorf("NewArrivals kept %v, want new.avatar and tagged.avatar", got)
+	}
+	got := FilterAvailableOutfits(files, nil, NotHeldBack(firstSeen, policy, now, index, "casual"))
+	if len(got) != 2 || got[0].FileName != "old.avatar" || got[1].FileName != "tagged.avatar" {
+		t.Errorf("NotHeldBack kept %v, want old.avatar and tagged.avatar", got)
+	}
+}
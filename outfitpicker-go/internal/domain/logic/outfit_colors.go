@@ -0,0 +1,68 @@
+package logic
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// ColorFileSuffix ends the name of an outfit's color file, kept next to it:
+// tee.avatar.colors lists the colors of tee.avatar.
+const ColorFileSuffix = ".colors"
+
+// ColorFileOutfit returns the outfit a color file describes, reporting
+// whether fileName names a color file at all.
+func ColorFileOutfit(fileName string) (string, bool) {
+	outfit, ok := strings.CutSuffix(fileName, ColorFileSuffix)
+	return outfit, ok && IsValidOutfitFile(outfit)
+}
+
+// ParseColorFile returns the colors listed in a color file, separated by
+// commas, spaces or lines. Case is ignored and words that are not valid
+// colors are left out.
+func ParseColorFile(content string) []string {
+	return colorWords(strings.FieldsFunc(content, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }))
+}
+
+// FileNameColors returns the colors named in brackets at the end of an
+// outfit's file name, as in "tee [navy, white].avatar". Case is ignored and
+// words that are not valid colors are left out.
+func FileNameColors(fileName string) []string {
+	name := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	name, ok := strings.CutSuffix(strings.TrimSpace(name), "]")
+	if !ok {
+		return nil
+	}
+	start := strings.LastIndex(name, "[")
+	if start < 0 {
+		return nil
+	}
+	return ParseColorFile(name[start+1:])
+}
+
+// OutfitColors returns an outfit's colors: those set in its metadata, else
+// those in its color file, else those in its file name.
+func OutfitColors(metadata, colorFile []string, fileName string) []string {
+	switch {
+	case len(metadata) > 0:
+		return metadata
+	case len(colorFile) > 0:
+		return colorFile
+	default:
+		return FileNameColors(fileName)
+	}
+}
+
+func colorWords(words []string) []string {
+	var colors []string
+	for _, word := range words {
+		color := strings.ToLower(word)
+		if !slices.Contains(colors, color) && validation.ValidateColors([]string{color}) == nil {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
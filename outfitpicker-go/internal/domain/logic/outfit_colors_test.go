@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFileNameColors(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     []string
+	}{
+		{"tee [navy, White].avatar", []string{"navy", "white"}},
+		{"tee[red].avatar", []string{"red"}},
+		{"tee [red red].avatar", []string{"red"}},
+		{"tee.avatar", nil},
+		{"[red] tee.avatar", nil},
+		{"tee [].avatar", nil},
+	}
+	for _, tt := range tests {
+		if got := FileNameColors(tt.fileName); !slices.Equal(got, tt.want) {
+			t.Errorf("FileNameColors(%q) = %v, want %v", tt.fileName, got, tt.want)
+		}
+	}
+}
+
+func TestParseColorFile(t *testing.T) {
+	if got, want := ParseColorFile("Navy, white\nred\n"), []string{"navy", "white", "red"}; !slices.Equal(got, want) {
+		t.Errorf("ParseColorFile() = %v, want %v", got, want)
+	}
+}
+
+func TestColorFileOutfit(t *testing.T) {
+	if outfit, ok := ColorFileOutfit("tee.avatar.colors"); !ok || outfit != "tee.avatar" {
+		t.Errorf("ColorFileOutfit(tee.avatar.colors) = %q, %v", outfit, ok)
+	}
+	if _, ok := ColorFileOutfit("notes.colors"); ok {
+		t.Error("ColorFileOutfit(notes.colors) names an outfit")
+	}
+}
+
+func TestOutfitColors(t *testing.T) {
+	fileName := "tee [red].avatar"
+	if got := OutfitColors([]string{"navy"}, []string{"white"}, fileName); !slices.Equal(got, []string{"navy"}) {
+		t.Errorf("OutfitColors() = %v, want the metadata colors", got)
+	}
+	if got := OutfitColors(nil, []string{"white"}, fileName); !slices.Equal(got, []string{"white"}) {
+		t.Errorf("OutfitColors() = %v, want the color file", got)
+	}
+	if got := OutfitColors(nil, nil, fileName); !slices.Equal(got, []string{"red"}) {
+		t.Errorf("OutfitColors() = %v, want the file name colors", got)
+	}
+}
@@ -0,0 +1,160 @@
+package logic
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// OutfitIDScheme makes the IDs of outfits seen for the first time.
+type OutfitIDScheme interface {
+	// NewID returns an ID for an outfit whose content has the given
+	// fingerprint.
+	NewID(fingerprint string) (string, error)
+}
+
+// NewOutfitIDScheme returns the named scheme. UUIDs are drawn from random.
+func NewOutfitIDScheme(name string, random io.Reader) (OutfitIDScheme, error) {
+	switch name {
+	case entities.OutfitIDSchemeUUID:
+		return uuidScheme{random: random}, nil
+	case entities.OutfitIDSchemeHash:
+		return hashScheme{}, nil
+	default:
+		return nil, errors.ErrInvalidOutfitIDs
+	}
+}
+
+// uuidScheme makes random version 4 UUIDs.
+type uuidScheme struct {
+	random io.Reader
+}
+
+func (s uuidScheme) NewID(string) (string, error) {
+	var b [16]byte
+	if _, err := io.ReadFull(s.random, b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// hashIDLength is how many leading digits of a fingerprint make a hash ID.
+const hashIDLength = 12
+
+// hashScheme makes IDs from the leading digits of the content fingerprint.
+type hashScheme struct{}
+
+func (hashScheme) NewID(fingerprint string) (string, error) {
+	return fingerprint[:min(len(fingerprint), hashIDLength)], nil
+}
+
+// OutfitIDReconciliation is the outcome of matching outfit IDs to a scan.
+type OutfitIDReconciliation struct {
+	// IDs are the identities of the outfits scanned, and the unchanged
+	// identities of the categories that were not.
+	IDs map[string]map[string]entities.OutfitIdentity
+	// Assigned counts the outfits given a new ID.
+	Assigned int
+	// Moves lists the outfits found under a new name or category.
+	Moves []entities.OutfitMove
+}
+
+// ReconcileOutfitIDs matches the known outfit identities to a scan, which
+// maps each scanned category to the fingerprints of its outfits by file
+// name. An outfit keeps its ID while its file does, whatever its content.
+// A new file whose content matches that of a file gone since the last scan
+// takes over its ID as a move, preferring one in the same category. Any
+// other new file gets an ID from scheme, numbered when it is taken, as it
+// is for duplicate files under the hash scheme. Identities of files that
+// are gone without a match are dropped.
+func ReconcileOutfitIDs(ids map[string]map[string]entities.OutfitIdentity, scanned map[string]map[string]string, scheme OutfitIDScheme) (OutfitIDReconciliation, error) {
+	result := OutfitIDReconciliation{IDs: make(map[string]map[string]entities.OutfitIdentity)}
+	used := make(map[string]bool)
+	for category, identities := range ids {
+		if _, ok := scanned[category]; !ok {
+			result.IDs[category] = identities
+			for _, identity := range identities {
+				used[identity.ID] = true
+			}
+		}
+	}
+	assign := func(location entities.OutfitLocation, identity entities.OutfitIdentity) {
+		if result.IDs[location.Category] == nil {
+			result.IDs[location.Category] = make(map[string]entities.OutfitIdentity)
+		}
+		result.IDs[location.Category][location.FileName] = identity
+		used[identity.ID] = true
+	}
+
+	var unknown []entities.OutfitLocation
+	for _, category := range slices.Sorted(maps.Keys(scanned)) {
+		for _, fileName := range slices.Sorted(maps.Keys(scanned[category])) {
+			location := entities.OutfitLocation{Category: category, FileName: fileName}
+			fingerprint := scanned[category][fileName]
+			if identity, ok := ids[category][fileName]; ok {
+				assign(location, entities.OutfitIdentity{ID: identity.ID, Fingerprint: fingerprint})
+			} else {
+				unknown = append(unknown, location)
+			}
+		}
+	}
+
+	var gone []entities.OutfitLocation
+	for _, category := range slices.Sorted(maps.Keys(ids)) {
+		if _, ok := scanned[category]; !ok {
+			continue
+		}
+		for _, fileName := range slices.Sorted(maps.Keys(ids[category])) {
+			if _, ok := scanned[category][fileName]; !ok {
+				gone = append(gone, entities.OutfitLocation{Category: category, FileName: fileName})
+			}
+		}
+	}
+
+	for _, location := range unknown {
+		fingerprint := scanned[location.Category][location.FileName]
+		if i := movedFrom(ids, gone, location, fingerprint); i >= 0 {
+			from := gone[i]
+			gone = slices.Delete(gone, i, i+1)
+			id := ids[from.Category][from.FileName].ID
+			assign(location, entities.OutfitIdentity{ID: id, Fingerprint: fingerprint})
+			result.Moves = append(result.Moves, entities.OutfitMove{ID: id, From: from, To: location})
+			continue
+		}
+		id, err := scheme.NewID(fingerprint)
+		if err != nil {
+			return OutfitIDReconciliation{}, err
+		}
+		unique := id
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s-%d", id, n)
+		}
+		assign(location, entities.OutfitIdentity{ID: unique, Fingerprint: fingerprint})
+		result.Assigned++
+	}
+	return result, nil
+}
+
+// movedFrom returns the index in gone of the file that the file at location
+// with the given fingerprint was moved from, or -1 when there is none.
+func movedFrom(ids map[string]map[string]entities.OutfitIdentity, gone []entities.OutfitLocation, location entities.OutfitLocation, fingerprint string) int {
+	match := -1
+	for i, from := range gone {
+		if ids[from.Category][from.FileName].Fingerprint != fingerprint {
+			continue
+		}
+		if from.Category == location.Category {
+			return i
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	return match
+}
@@ -0,0 +1,99 @@
+package logic
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestNewOutfitIDScheme(t *testing.T) {
+	uuid, err := NewOutfitIDScheme(entities.OutfitIDSchemeUUID, bytes.NewReader(make([]byte, 16)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := uuid.NewID("abc")
+	if err != nil || id != "00000000-0000-4000-8000-000000000000" {
+		t.Errorf("uuid NewID() = %q, %v, want a version 4 UUID", id, err)
+	}
+	if _, err := uuid.NewID("abc"); err == nil {
+		t.Error("uuid NewID() with the random source used up should fail")
+	}
+
+	hash, _ := NewOutfitIDScheme(entities.OutfitIDSchemeHash, nil)
+	if id, _ := hash.NewID("0123456789abcdef"); id != "0123456789ab" {
+		t.Errorf("hash NewID() = %q, want the first 12 digits", id)
+	}
+	if _, err := NewOutfitIDScheme("serial", nil); err == nil {
+		t.Error("NewOutfitIDScheme(serial) should fail")
+	}
+}
+
+func TestReconcileOutfitIDs(t *testing.T) {
+	ids := map[string]map[string]entities.OutfitIdentity{
+		"casual": {
+			"tee.avatar":   {ID: "id-tee", Fingerprint: "f-tee"},
+			"jeans.avatar": {ID: "id-jeans", Fingerprint: "f-jeans"},
+			"gone.avatar":  {ID: "id-gone", Fingerprint: "f-gone"},
+		},
+		"work":   {"suit.avatar": {ID: "id-suit", Fingerprint: "f-suit"}},
+		"formal": {"gown.avatar": {ID: "id-gown", Fingerprint: "f-gown"}},
+	}
+	scanned := map[string]map[string]string{
+		"casual": {
+			"tee.avatar":     "f-tee-edited",
+			"denim.avatar":   "f-jeans",
+			"hoodie.avatar":  "f-new",
+			"hoodie2.avatar": "f-new",
+		},
+		"work": {"suit-moved.avatar": "f-suit", "blazer.avatar": "f-gone"},
+	}
+	hash, _ := NewOutfitIDScheme(entities.OutfitIDSchemeHash, nil)
+
+	got, err := ReconcileOutfitIDs(ids, scanned, hash)
+	if err != nil {
+		t.Fatalf("ReconcileOutfitIDs() error = %v", err)
+	}
+	want := map[string]map[string]entities.OutfitIdentity{
+		"casual": {
+			"tee.avatar":     {ID: "id-tee", Fingerprint: "f-tee-edited"},
+			"denim.avatar":   {ID: "id-jeans", Fingerprint: "f-jeans"},
+			"hoodie.avatar":  {ID: "f-new", Fingerprint: "f-new"},
+			"hoodie2.avatar": {ID: "f-new-2", Fingerprint: "f-new"},
+		},
+		"work": {
+			"suit-moved.avatar": {ID: "id-suit", Fingerprint: "f-suit"},
+			"blazer.avatar":     {ID: "id-gone", Fingerprint: "f-gone"},
+		},
+		"formal": {"gown.avatar": {ID: "id-gown", Fingerprint: "f-gown"}},
+	}
+	for category, identities := range want {
+		for fileName, identity := range identities {
+			if got.IDs[category][fileName] != identity {
+				t.Errorf("%s/%s = %+v, want %+v", category, fileName, got.IDs[category][fileName], identity)
+			}
+		}
+		if len(got.IDs[category]) != len(identities) {
+			t.Errorf("%s has %d identities, want %d", category, len(got.IDs[category]), len(identities))
+		}
+	}
+	if got.Assigned != 2 {
+		t.Errorf("Assigned = %d, want 2", got.Assigned)
+	}
+	wantMoves := []entities.OutfitMove{
+		{ID: "id-jeans", From: entities.OutfitLocation{Category: "casual", FileName: "jeans.avatar"}, To: entities.OutfitLocation{Category: "casual", FileName: "denim.avatar"}},
+		{ID: "id-gone", From: entities.OutfitLocation{Category: "casual", FileName: "gone.avatar"}, To: entities.OutfitLocation{Category: "work", FileName: "blazer.avatar"}},
+		{ID: "id-suit", From: entities.OutfitLocation{Category: "work", FileName: "suit.avatar"}, To: entities.OutfitLocation{Category: "work", FileName: "suit-moved.avatar"}},
+	}
+	if !slices.Equal(got.Moves, wantMoves) {
+		t.Errorf("Moves = %+v, want %+v", got.Moves, wantMoves)
+	}
+
+	uuid, _ := NewOutfitIDScheme(entities.OutfitIDSchemeUUID, bytes.NewReader(bytes.Repeat([]byte{7}, 32)))
+	fresh, err := ReconcileOutfitIDs(nil, map[string]map[string]string{"casual": {"a.avatar": "f-a"}}, uuid)
+	if err != nil || fresh.Assigned != 1 || !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4`).MatchString(fresh.IDs["casual"]["a.avatar"].ID) {
+		t.Errorf("ReconcileOutfitIDs() with uuids = %+v, %v", fresh, err)
+	}
+}
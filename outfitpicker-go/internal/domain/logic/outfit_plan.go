@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Statuses of a planned outfit.
+const (
+	// PlanStatusPlanned is an outfit still to be worn on its day.
+	PlanStatusPlanned = "planned"
+	// PlanStatusWorn is an outfit of a day marked as worn.
+	PlanStatusWorn = "worn"
+	// PlanStatusMissing is an outfit whose file is no longer in the
+	// wardrobe.
+	PlanStatusMissing = "missing"
+	// PlanStatusAlreadyWorn is an outfit still to be worn that the
+	// rotation has already recorded as worn since the plan was made, so
+	// wearing it on its day would repeat it within the rotation.
+	PlanStatusAlreadyWorn = "already-worn"
+)
+
+// PlannedOutfitStatus is a planned outfit and whether it can still be worn
+// as planned.
+type PlannedOutfitStatus struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Status   string `json:"status"`
+}
+
+// PlanDayStatus is a day of a plan with the status of each of its outfits.
+type PlanDayStatus struct {
+	Date    time.Time             `json:"date"`
+	Worn    bool                  `json:"worn"`
+	Outfits []PlannedOutfitStatus `json:"outfits"`
+}
+
+// PlanReport is a plan checked against the wardrobe and the rotation cache.
+type PlanReport struct {
+	// CreatedAt is when the plan was generated.
+	CreatedAt time.Time       `json:"createdAt"`
+	Days      []PlanDayStatus `json:"days"`
+	// Conflicts counts the planned outfits that are missing or already
+	// worn; rerolling their days replaces them.
+	Conflicts int `json:"conflicts"`
+}
+
+// CheckPlan compares plan with the outfits in the wardrobe, by category,
+// and the worn outfits of each category's current rotation.
+func CheckPlan(plan entities.OutfitPlan, snapshot entities.WardrobeSnapshot, cache entities.OutfitCache) PlanReport {
+	report := PlanReport{CreatedAt: plan.CreatedAt, Days: make([]PlanDayStatus, 0, len(plan.Days))}
+	for _, day := range plan.Days {
+		status := PlanDayStatus{Date: day.Date, Worn: day.Worn, Outfits: make([]PlannedOutfitStatus, 0, len(day.Outfits))}
+		for _, outfit := range day.Outfits {
+			outfitStatus := PlanStatusPlanned
+			switch {
+			case day.Worn:
+				outfitStatus = PlanStatusWorn
+			case !slices.Contains(snapshot[outfit.Category], outfit.FileName):
+				outfitStatus = PlanStatusMissing
+			case cache.Categories[outfit.Category].WornOutfits[outfit.FileName]:
+				outfitStatus = PlanStatusAlreadyWorn
+			}
+			if outfitStatus == PlanStatusMissing || outfitStatus == PlanStatusAlreadyWorn {
+				report.Conflicts++
+			}
+			status.Outfits = append(status.Outfits, PlannedOutfitStatus{Category: outfit.Category, FileName: outfit.FileName, Status: outfitStatus})
+		}
+		report.Days = append(report.Days, status)
+	}
+	return report
+}
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestCheckPlan(t *testing.T) {
+	day := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	plan := entities.OutfitPlan{Days: []entities.PlanDay{
+		{Date: day, Worn: true, Outfits: []entities.PlannedOutfit{{Category: "casual", FileName: "tee.avatar"}}},
+		{Date: day.AddDate(0, 0, 1), Outfits: []entities.PlannedOutfit{
+			{Category: "casual", FileName: "jeans.avatar"},
+			{Category: "work", FileName: "shirt.avatar"},
+		}},
+		{Date: day.AddDate(0, 0, 2), Outfits: []entities.PlannedOutfit{{Category: "casual", FileName: "gone.avatar"}}},
+	}}
+	snapshot := entities.WardrobeSnapshot{"casual": {"tee.avatar", "jeans.avatar"}, "work": {"shirt.avatar"}}
+	cache := entities.OutfitCache{Categories: map[string]entities.CategoryCache{
+		"casual": {WornOutfits: map[string]bool{"tee.avatar": true, "jeans.avatar": true}},
+	}}
+
+	report := CheckPlan(plan, snapshot, cache)
+	want := [][]string{
+		{PlanStatusWorn},
+		{PlanStatusAlreadyWorn, PlanStatusPlanned},
+		{PlanStatusMissing},
+	}
+	if len(report.Days) != len(want) {
+		t.Fatalf("days = %d, want %d", len(report.Days), len(want))
+	}
+	for i, day := range report.Days {
+		for j, outfit := range day.Outfits {
+			if outfit.Status != want[i][j] {
+				t.Errorf("day %d outfit %s status = %q, want %q", i, outfit.FileName, outfit.Status, want[i][j])
+			}
+		}
+	}
+	if report.Conflicts != 2 {
+		t.Errorf("Conflicts = %d, want 2", report.Conflicts)
+	}
+}
@@ -0,0 +1,16 @@
+package logic
+
+import (
+	"math/rand/v2"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// SelectRandom selects a random outfit from the pool. It returns false if the
+// pool is empty.
+func SelectRandom(pool []entities.FileEntry) (entities.FileEntry, bool) {
+	if len(pool) == 0 {
+		return entities.FileEntry{}, false
+	}
+	return pool[rand.IntN(len(pool))], true
+}
@@ -0,0 +1,31 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestSelectRandom(t *testing.T) {
+	t.Run("empty pool", func(t *testing.T) {
+		if _, ok := SelectRandom(nil); ok {
+			t.Error("SelectRandom() on empty pool returned ok")
+		}
+	})
+
+	t.Run("selects from pool", func(t *testing.T) {
+		pool := []entities.FileEntry{
+			entities.NewFileEntry("/outfits/casual/a.avatar"),
+			entities.NewFileEntry("/outfits/casual/b.avatar"),
+		}
+		for i := 0; i < 20; i++ {
+			got, ok := SelectRandom(pool)
+			if !ok {
+				t.Fatal("SelectRandom() returned !ok for non-empty pool")
+			}
+			if got.FileName != "a.avatar" && got.FileName != "b.avatar" {
+				t.Errorf("SelectRandom() = %v, not in pool", got.FileName)
+			}
+		}
+	})
+}
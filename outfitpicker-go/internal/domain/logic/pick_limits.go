@@ -0,0 +1,29 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// NextPickAllowed returns when category may next be picked from under a
+// limit of limit picks a calendar day, counting the picks from it in
+// history on the day of now. It reports false when the limit is not
+// reached, or when limit is zero.
+func NextPickAllowed(history entities.SelectionHistory, category string, limit int, now time.Time) (time.Time, bool) {
+	if limit <= 0 {
+		return time.Time{}, false
+	}
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	picks := 0
+	for _, record := range history.Records {
+		if record.Category == category && !record.SelectedAt.Before(today) && !record.SelectedAt.After(now) {
+			picks++
+		}
+	}
+	if picks < limit {
+		return time.Time{}, false
+	}
+	return today.AddDate(0, 0, 1), true
+}
@@ -0,0 +1,40 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestNextPickAllowed(t *testing.T) {
+	now := time.Date(2024, 6, 1, 21, 0, 0, 0, time.UTC)
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: time.Date(2024, 5, 31, 23, 0, 0, 0, time.UTC)},
+		{Category: "casual", FileName: "jeans.avatar", SelectedAt: time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)},
+		{Category: "work", FileName: "shirt.avatar", SelectedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{Category: "casual", FileName: "hoodie.avatar", SelectedAt: time.Date(2024, 6, 1, 20, 0, 0, 0, time.UTC)},
+	}}
+	tomorrow := time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		category string
+		limit    int
+		want     time.Time
+		limited  bool
+	}{
+		{"no limit", "casual", 0, time.Time{}, false},
+		{"limit reached", "casual", 2, tomorrow, true},
+		{"limit not reached", "casual", 3, time.Time{}, false},
+		{"other category", "work", 1, tomorrow, true},
+		{"never picked", "formal", 1, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, limited := NextPickAllowed(history, tt.category, tt.limit, now)
+			if limited != tt.limited || !got.Equal(tt.want) {
+				t.Errorf("NextPickAllowed() = %v, %v; want %v, %v", got, limited, tt.want, tt.limited)
+			}
+		})
+	}
+}
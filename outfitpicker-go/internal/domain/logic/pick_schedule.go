@@ -0,0 +1,24 @@
+package logic
+
+import "time"
+
+// DuePickWindow returns the latest scheduled pick due at or before now, one
+// every interval after the pick due at last, and whether there is one. The
+// first pick, with last zero, is due now. late reports whether the first
+// pick not yet handled came due more than grace ago, so it was missed
+// rather than merely noticed on the next check.
+//
+// Times are compared by the wall clock: a monotonic clock stops while the
+// machine sleeps, so it would not see the picks that came due meanwhile.
+func DuePickWindow(last time.Time, interval, grace time.Duration, now time.Time) (due time.Time, late, ok bool) {
+	now = now.Round(0)
+	if last.IsZero() {
+		return now, false, true
+	}
+	next := last.Round(0).Add(interval)
+	if now.Before(next) {
+		return time.Time{}, false, false
+	}
+	behind := now.Sub(next)
+	return next.Add(behind / interval * interval), behind > grace, true
+}
@@ -0,0 +1,33 @@
+package logic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuePickWindow(t *testing.T) {
+	last := time.Date(2024, 6, 1, 7, 30, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		name     string
+		last     time.Time
+		now      time.Time
+		wantDue  time.Time
+		wantLate bool
+		wantOK   bool
+	}{
+		{"first pick", time.Time{}, last, last, false, true},
+		{"not yet due", last, last.Add(23 * time.Hour), time.Time{}, false, false},
+		{"due on time", last, last.Add(day + 5*time.Second), last.Add(day), false, true},
+		{"missed while asleep", last, last.Add(day + 3*time.Hour), last.Add(day), true, true},
+		{"several missed", last, last.Add(3*day + time.Hour), last.Add(3 * day), true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, late, ok := DuePickWindow(tt.last, day, time.Minute, tt.now)
+			if !due.Equal(tt.wantDue) || late != tt.wantLate || ok != tt.wantOK {
+				t.Errorf("DuePickWindow() = %v, %v, %v; want %v, %v, %v", due, late, ok, tt.wantDue, tt.wantLate, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package logic
+
+import (
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// WardrobeChanges describes how a wardrobe differs between two snapshots.
+// All slices are sorted.
+type WardrobeChanges struct {
+	AddedCategories   []string            `json:"addedCategories"`
+	RemovedCategories []string            `json:"removedCategories"`
+	AddedOutfits      map[string][]string `json:"addedOutfits"`
+	RemovedOutfits    map[string][]string `json:"removedOutfits"`
+}
+
+// IsEmpty reports whether the snapshots were identical.
+func (c WardrobeChanges) IsEmpty() bool {
+	return len(c.AddedCategories) == 0 && len(c.RemovedCategories) == 0 &&
+		len(c.AddedOutfits) == 0 && len(c.RemovedOutfits) == 0
+}
+
+// DiffSnapshots compares two wardrobe snapshots. Outfits in added or removed
+// categories are reported along with the category itself.
+func DiffSnapshots(before, after entities.WardrobeSnapshot) WardrobeChanges {
+	changes := WardrobeChanges{
+		AddedOutfits:   make(map[string][]string),
+		RemovedOutfits: make(map[string][]string),
+	}
+
+	for category, files := range after {
+		previous, existed := before[category]
+		if !existed {
+			changes.AddedCategories = append(changes.AddedCategories, category)
+		}
+		if added := difference(files, previous); len(added) > 0 {
+			changes.AddedOutfits[category] = added
+		}
+	}
+	for category, files := range before {
+		current, exists := after[category]
+		if !exists {
+			changes.RemovedCategories = append(changes.RemovedCategories, category)
+		}
+		if removed := difference(files, current); len(removed) > 0 {
+			changes.RemovedOutfits[category] = removed
+		}
+	}
+
+	slices.Sort(changes.AddedCategories)
+	slices.Sort(changes.RemovedCategories)
+	return changes
+}
+
+// ReconcileCache brings a cache in line with the wardrobe on disk: categories
+// that no longer exist are dropped, totals are refreshed and worn entries and
+// last-worn times for missing files are removed.
+func ReconcileCache(cache entities.OutfitCache, snapshot entities.WardrobeSnapshot) entities.OutfitCache {
+	result := cache
+	for category, categoryCache := range cache.Categories {
+		files, exists := snapshot[category]
+		if !exists {
+			result = result.Removing(category)
+			continue
+		}
+
+		present := make(map[string]bool, len(files))
+		for _, file := range files {
+			present[file] = true
+		}
+
+		worn := make(map[string]bool, len(categoryCache.WornOutfits))
+		for fileName, isWorn := range categoryCache.WornOutfits {
+			if present[fileName] {
+				worn[fileName] = isWorn
+			}
+		}
+
+		var lastWorn map[string]time.Time
+		for fileName, at := range categoryCache.LastWorn {
+			if present[fileName] {
+				if lastWorn == nil {
+					lastWorn = make(map[string]time.Time)
+				}
+				lastWorn[fileName] = at
+			}
+		}
+
+		if len(worn) == len(categoryCache.WornOutfits) && len(lastWorn) == len(categoryCache.LastWorn) && categoryCache.TotalOutfits == len(files) {
+			continue
+		}
+		result = result.Updating(category, entities.CategoryCache{
+			WornOutfits:  worn,
+			TotalOutfits: len(files),
+			LastUpdated:  time.Now(),
+			Revision:     categoryCache.Revision + 1,
+			LastWorn:     lastWorn,
+		})
+	}
+	return result
+}
+
+// difference returns the sorted elements of a that are not in b.
+func difference(a, b []string) []string {
+	var result []string
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			result = append(result, item)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
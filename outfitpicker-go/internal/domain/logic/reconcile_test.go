@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "jeans.avatar"},
+		"formal": {"suit.avatar"},
+	}
+	after := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "hoodie.avatar"},
+		"gym":    {"shorts.avatar"},
+	}
+
+	changes := DiffSnapshots(before, after)
+
+	if !slices.Equal(changes.AddedCategories, []string{"gym"}) {
+		t.Errorf("AddedCategories = %v, want [gym]", changes.AddedCategories)
+	}
+	if !slices.Equal(changes.RemovedCategories, []string{"formal"}) {
+		t.Errorf("RemovedCategories = %v, want [formal]", changes.RemovedCategories)
+	}
+	if !slices.Equal(changes.AddedOutfits["casual"], []string{"hoodie.avatar"}) {
+		t.Errorf("AddedOutfits[casual] = %v, want [hoodie.avatar]", changes.AddedOutfits["casual"])
+	}
+	if !slices.Equal(changes.RemovedOutfits["casual"], []string{"jeans.avatar"}) {
+		t.Errorf("RemovedOutfits[casual] = %v, want [jeans.avatar]", changes.RemovedOutfits["casual"])
+	}
+	if !slices.Equal(changes.RemovedOutfits["formal"], []string{"suit.avatar"}) {
+		t.Errorf("RemovedOutfits[formal] = %v, want [suit.avatar]", changes.RemovedOutfits["formal"])
+	}
+	if changes.IsEmpty() {
+		t.Error("IsEmpty() = true, want false")
+	}
+}
+
+func TestDiffSnapshots_Identical(t *testing.T) {
+	snapshot := entities.WardrobeSnapshot{"casual": {"tee.avatar"}}
+	if changes := DiffSnapshots(snapshot, snapshot); !changes.IsEmpty() {
+		t.Errorf("DiffSnapshots() = %+v, want no changes", changes)
+	}
+}
+
+func TestReconcileCache(t *testing.T) {
+	cache := entities.NewOutfitCache().
+		Updating("casual", entities.NewCategoryCache(3).Adding("tee.avatar").Adding("jeans.avatar")).
+		Updating("formal", entities.NewCategoryCache(1).Adding("suit.avatar")).
+		Updating("gym", entities.NewCategoryCache(1))
+	snapshot := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "hoodie.avatar", "cap.avatar", "scarf.avatar"},
+		"gym":    {"shorts.avatar"},
+	}
+
+	reconciled := ReconcileCache(cache, snapshot)
+
+	if _, ok := reconciled.Categories["formal"]; ok {
+		t.Error("removed category still present in cache")
+	}
+	casual := reconciled.Categories["casual"]
+	if casual.TotalOutfits != 4 {
+		t.Errorf("casual TotalOutfits = %v, want 4", casual.TotalOutfits)
+	}
+	if len(casual.WornOutfits) != 1 || !casual.WornOutfits["tee.avatar"] {
+		t.Errorf("casual WornOutfits = %v, want only tee.avatar", casual.WornOutfits)
+	}
+	if gym := reconciled.Categories["gym"]; gym.LastUpdated != cache.Categories["gym"].LastUpdated {
+		t.Error("unchanged category was rewritten")
+	}
+	if len(cache.Categories) != 3 {
+		t.Error("ReconcileCache() mutated its input")
+	}
+}
@@ -0,0 +1,42 @@
+package logic
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// LockedRotation is how far through its rotation a locked category is.
+type LockedRotation struct {
+	Category string `json:"category"`
+	Worn     int    `json:"worn"`
+	Total    int    `json:"total"`
+	// AwaitingReset is set once every outfit has been worn, when picks
+	// from the category fail until its rotation is reset by hand.
+	AwaitingReset bool `json:"awaitingReset"`
+}
+
+// LockedRotations returns the state of the locked categories in snapshot,
+// sorted by name. Locked categories no longer in the wardrobe are left out.
+func LockedRotations(locked []string, cache entities.OutfitCache, snapshot entities.WardrobeSnapshot) []LockedRotation {
+	var rotations []LockedRotation
+	for _, category := range slices.Sorted(slices.Values(locked)) {
+		files, ok := snapshot[category]
+		if !ok {
+			continue
+		}
+		worn := 0
+		for file := range cache.Categories[category].WornOutfits {
+			if slices.Contains(files, file) {
+				worn++
+			}
+		}
+		rotations = append(rotations, LockedRotation{
+			Category:      category,
+			Worn:          worn,
+			Total:         len(files),
+			AwaitingReset: len(files) > 0 && ShouldResetRotation(worn, len(files)),
+		})
+	}
+	return rotations
+}
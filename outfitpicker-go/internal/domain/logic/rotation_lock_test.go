@@ -0,0 +1,28 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestLockedRotations(t *testing.T) {
+	snapshot := entities.WardrobeSnapshot{
+		"costumes": {"pirate.avatar", "robot.avatar"},
+		"formal":   {"suit.avatar", "gown.avatar"},
+		"casual":   {"tee.avatar"},
+	}
+	cache := entities.OutfitCache{Categories: map[string]entities.CategoryCache{
+		"costumes": entities.NewCategoryCache(2).Adding("pirate.avatar").Adding("robot.avatar"),
+		"formal":   entities.NewCategoryCache(2).Adding("suit.avatar").Adding("removed.avatar"),
+	}}
+
+	got := LockedRotations([]string{"formal", "costumes", "gone"}, cache, snapshot)
+	want := []LockedRotation{
+		{Category: "costumes", Worn: 2, Total: 2, AwaitingReset: true},
+		{Category: "formal", Worn: 1, Total: 2},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("LockedRotations() = %+v, want %+v", got, want)
+	}
+}
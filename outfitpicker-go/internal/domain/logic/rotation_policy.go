@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RotationExclusions returns the outfits policy leaves out of a pick. Strict
+// rotation leaves out worn, the outfits worn in the current rotation;
+// cooldown leaves out those last worn, according to lastWorn, less than its
+// days before now. The other policies leave nothing out.
+func RotationExclusions(policy entities.RotationPolicy, worn map[string]bool, lastWorn map[string]time.Time, now time.Time) map[string]bool {
+	switch policy.EffectiveName() {
+	case entities.RotationStrict:
+		return worn
+	case entities.RotationCooldown:
+		return CoolingDown(lastWorn, policy.CooldownDays, now)
+	default:
+		return nil
+	}
+}
+
+// CoolingDown returns the outfits last worn, according to lastWorn, less
+// than days days before now.
+func CoolingDown(lastWorn map[string]time.Time, days int, now time.Time) map[string]bool {
+	cooling := make(map[string]bool)
+	for fileName, at := range lastWorn {
+		if now.Before(at.AddDate(0, 0, days)) {
+			cooling[fileName] = true
+		}
+	}
+	return cooling
+}
+
+// LeastRecentlyWorn narrows a pool to the outfits worn longest ago according
+// to lastWorn. Outfits never worn come before any that have been.
+func LeastRecentlyWorn(lastWorn map[string]time.Time) func([]entities.FileEntry) []entities.FileEntry {
+	return func(pool []entities.FileEntry) []entities.FileEntry {
+		var oldest []entities.FileEntry
+		var oldestAt time.Time
+		for _, entry := range pool {
+			at := lastWorn[entry.FileName]
+			switch {
+			case len(oldest) == 0 || at.Before(oldestAt):
+				oldest = []entities.FileEntry{entry}
+				oldestAt = at
+			case at.Equal(oldestAt):
+				oldest = append(oldest, entry)
+			}
+		}
+		return oldest
+	}
+}
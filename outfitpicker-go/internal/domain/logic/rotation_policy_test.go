@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestRotationExclusions(t *testing.T) {
+	now := time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC)
+	worn := map[string]bool{"a.avatar": true}
+	lastWorn := map[string]time.Time{
+		"a.avatar": now.AddDate(0, 0, -10),
+		"b.avatar": now.AddDate(0, 0, -2),
+		"c.avatar": now.AddDate(0, 0, -3),
+	}
+	tests := []struct {
+		policy entities.RotationPolicy
+		want   []string
+	}{
+		{entities.RotationPolicy{}, []string{"a.avatar"}},
+		{entities.RotationPolicy{Name: entities.RotationCooldown, CooldownDays: 3}, []string{"b.avatar"}},
+		{entities.RotationPolicy{Name: entities.RotationLeastRecentlyWorn}, nil},
+		{entities.RotationPolicy{Name: entities.RotationRandom}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			got := slices.Sorted(maps.Keys(RotationExclusions(tt.policy, worn, lastWorn, now)))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("RotationExclusions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLeastRecentlyWorn(t *testing.T) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	pool := testPool("a.avatar", "b.avatar", "c.avatar", "d.avatar")
+	narrow := LeastRecentlyWorn(map[string]time.Time{
+		"a.avatar": day.AddDate(0, 0, 1),
+		"b.avatar": day,
+		"c.avatar": day,
+		"d.avatar": day.AddDate(0, 0, 2),
+	})
+	if got := fileNamesOf(narrow(pool)); !slices.Equal(got, []string{"b.avatar", "c.avatar"}) {
+		t.Errorf("narrow() = %v, want the two worn longest ago", got)
+	}
+	if got := fileNamesOf(narrow(testPool("a.avatar", "e.avatar"))); !slices.Equal(got, []string{"e.avatar"}) {
+		t.Errorf("narrow() = %v, want the never-worn outfit", got)
+	}
+
+	selector := NewSelector(WithNarrowing(narrow), WithFilter(func(entry entities.FileEntry) bool { return entry.FileName != "b.avatar" }))
+	for range 20 {
+		if got, ok := selector.Select(pool); !ok || got.FileName != "c.avatar" {
+			t.Fatalf("Select() = %v, %v, want c.avatar", got.FileName, ok)
+		}
+	}
+}
+
+func fileNamesOf(pool []entities.FileEntry) []string {
+	names := make([]string, len(pool))
+	for i, entry := range pool {
+		names[i] = entry.FileName
+	}
+	return names
+}
@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// ReplayRotations rebuilds every category's rotation as it was at at by
+// replaying the wear events and picks recorded before it.
+//
+// The outfits a category held then come from its sightings, see ownedAt.
+// Outfits worn before at always count.
+// Categories with no outfits and no events by then are left out.
+func ReplayRotations(log entities.WearLog, history entities.SelectionHistory, arrivals entities.OutfitArrivals, at time.Time) entities.RotationSnapshot {
+	var events []entities.WearEvent
+	for _, event := range log.Events {
+		if event.WornAt.Before(at) {
+			events = append(events, event)
+		}
+	}
+	past := entities.WearLog{Events: events}
+
+	owned := make(map[string]map[string]bool)
+	own := func(category, fileName string) {
+		if owned[category] == nil {
+			owned[category] = make(map[string]bool)
+		}
+		owned[category][fileName] = true
+	}
+	for category, outfits := range arrivals.Outfits {
+		for fileName := range outfits {
+			if ownedAt(arrivals, category, fileName, at) {
+				own(category, fileName)
+			}
+		}
+	}
+	completed := make(map[string]int)
+	for _, event := range events {
+		own(event.Category, event.FileName)
+		if event.CompletedRotation {
+			completed[event.Category]++
+		}
+	}
+
+	lastPick := make(map[string]entities.SelectionRecord)
+	for _, record := range history.Records {
+		if !record.SelectedAt.Before(at) {
+			continue
+		}
+		if _, ok := owned[record.Category]; !ok {
+			owned[record.Category] = make(map[string]bool)
+		}
+		if previous, ok := lastPick[record.Category]; !ok || !record.SelectedAt.Before(previous.SelectedAt) {
+			lastPick[record.Category] = record
+		}
+	}
+
+	snapshot := entities.RotationSnapshot{At: at, Categories: []entities.CategorySnapshot{}}
+	for category, files := range owned {
+		worn := past.CurrentRotation(category)
+		outfits := make([]string, 0, len(files))
+		available := []string{}
+		for fileName := range files {
+			outfits = append(outfits, fileName)
+			if !slices.Contains(worn, fileName) {
+				available = append(available, fileName)
+			}
+		}
+		slices.Sort(outfits)
+		slices.Sort(available)
+		if worn == nil {
+			worn = []string{}
+		}
+		categorySnapshot := entities.CategorySnapshot{
+			Category:           category,
+			Outfits:            outfits,
+			Worn:               worn,
+			Available:          available,
+			CompletedRotations: completed[category],
+		}
+		if record, ok := lastPick[category]; ok {
+			categorySnapshot.LastPick = &record
+		}
+		snapshot.Categories = append(snapshot.Categories, categorySnapshot)
+	}
+	slices.SortFunc(snapshot.Categories, func(a, b entities.CategorySnapshot) int {
+		return strings.Compare(a.Category, b.Category)
+	})
+	return snapshot
+}
+
+// ownedAt reports whether the sightings of a category say fileName was in
+// it at at: it was first seen before at, or was there before tracking began,
+// and no scan before at missed it. Every last-seen time is when some scan of
+// the category found an outfit, so one after fileName was last seen is a scan
+// that did not find it.
+func ownedAt(arrivals entities.OutfitArrivals, category, fileName string, at time.Time) bool {
+	firstSeen, ok := arrivals.Outfits[category][fileName]
+	if !ok || !firstSeen.IsZero() && !firstSeen.Before(at) {
+		return false
+	}
+	lastSeen := arrivals.LastSeen[category]
+	seen, ok := lastSeen[fileName]
+	if !ok {
+		return true
+	}
+	for _, scan := range lastSeen {
+		if scan.After(seen) && scan.Before(at) {
+			return false
+		}
+	}
+	return true
+}
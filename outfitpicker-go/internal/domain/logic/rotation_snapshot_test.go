@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestReplayRotations(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 9, 0, 0, 0, time.UTC) }
+	log := entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: day(1)},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day(2), CompletedRotation: true},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day(5)},
+		{Category: "casual", FileName: "tee.avatar", WornAt: day(20)},
+		{Category: "formal", FileName: "suit.avatar", WornAt: day(21)},
+	}}
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "jeans.avatar", SelectedAt: day(5)},
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: day(20)},
+	}}
+	arrivals := entities.OutfitArrivals{
+		Outfits: map[string]map[string]time.Time{
+			"casual": {"tee.avatar": {}, "jeans.avatar": {}, "sold.avatar": {}, "new.avatar": day(15)},
+			"formal": {"suit.avatar": day(18)},
+		},
+		LastSeen: map[string]map[string]time.Time{
+			"casual": {"tee.avatar": day(20), "jeans.avatar": day(20), "sold.avatar": day(3), "new.avatar": day(20)},
+		},
+	}
+
+	snapshot := ReplayRotations(log, history, arrivals, day(10))
+
+	want := []entities.CategorySnapshot{{
+		Category:           "casual",
+		Outfits:            []string{"jeans.avatar", "sold.avatar", "tee.avatar"},
+		Worn:               []string{"jeans.avatar"},
+		Available:          []string{"sold.avatar", "tee.avatar"},
+		CompletedRotations: 1,
+		LastPick:           &history.Records[0],
+	}}
+	if !snapshot.At.Equal(day(10)) || len(snapshot.Categories) != len(want) {
+		t.Fatalf("ReplayRotations() = %+v, want %+v", snapshot, want)
+	}
+	got := snapshot.Categories[0]
+	if got.Category != want[0].Category || !slices.Equal(got.Outfits, want[0].Outfits) || !slices.Equal(got.Worn, want[0].Worn) ||
+		!slices.Equal(got.Available, want[0].Available) || got.CompletedRotations != want[0].CompletedRotations || *got.LastPick != *want[0].LastPick {
+		t.Errorf("ReplayRotations() casual = %+v, want %+v", got, want[0])
+	}
+
+	later := ReplayRotations(log, history, arrivals, day(22))
+	if len(later.Categories) != 2 {
+		t.Fatalf("ReplayRotations() later = %+v, want casual and formal", later.Categories)
+	}
+	if casual := later.Categories[0]; !slices.Equal(casual.Outfits, []string{"jeans.avatar", "new.avatar", "tee.avatar"}) || !slices.Equal(casual.Worn, []string{"jeans.avatar", "tee.avatar"}) {
+		t.Errorf("ReplayRotations() later casual = %+v, want sold.avatar gone and new.avatar arrived", casual)
+	}
+	if formal := later.Categories[1]; !slices.Equal(formal.Available, []string{}) || formal.LastPick != nil {
+		t.Errorf("ReplayRotations() later formal = %+v", formal)
+	}
+
+	if empty := ReplayRotations(entities.WearLog{}, entities.SelectionHistory{}, entities.NewOutfitArrivals(), day(10)); len(empty.Categories) != 0 {
+		t.Errorf("ReplayRotations() of nothing = %+v, want no categories", empty)
+	}
+}
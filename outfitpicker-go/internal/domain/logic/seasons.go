@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// SeasonAt returns the meteorological season at t: spring from March, summer
+// from June, autumn from September and winter from December, shifted by half
+// a year in the southern hemisphere.
+func SeasonAt(t time.Time, southern bool) string {
+	month := int(t.Month())
+	if southern {
+		month = (month+5)%12 + 1
+	}
+	return validation.Seasons()[month%12/3]
+}
+
+// ResolveSeason validates a season name, turning entities.SeasonAuto into the
+// season at now.
+func ResolveSeason(season string, assignments entities.SeasonAssignments, now time.Time) (string, error) {
+	if season == entities.SeasonAuto {
+		return SeasonAt(now, assignments.Southern), nil
+	}
+	if validation.ValidateSeason(season) != nil {
+		return "", errors.NewInvalidInputError(fmt.Sprintf("unknown season %q (want %s or %s)", season, strings.Join(validation.Seasons(), ", "), entities.SeasonAuto))
+	}
+	return season, nil
+}
+
+// CategoryInSeason reports whether a category is worn in season.
+func CategoryInSeason(assignments entities.SeasonAssignments, category, season string) bool {
+	seasons := assignments.Category(category)
+	return len(seasons) == 0 || slices.Contains(seasons, season)
+}
+
+// OutfitInSeason reports whether an outfit with tags is worn in season. An
+// outfit is out of season when some of its tags have seasons assigned and
+// none of them includes season.
+func OutfitInSeason(assignments entities.SeasonAssignments, tags []string, season string) bool {
+	restricted := false
+	for _, tag := range tags {
+		seasons := assignments.Tag(tag)
+		if slices.Contains(seasons, season) {
+			return true
+		}
+		restricted = restricted || len(seasons) > 0
+	}
+	return !restricted
+}
+
+// InSeason keeps the outfits of category that are worn in season. An
+// outfit whose sidecar file names its seasons is worn in those alone.
+func InSeason(assignments entities.SeasonAssignments, index entities.MetadataIndex, category, season string) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		if seasons := index.SeasonsOf(category, file.FileName); len(seasons) > 0 {
+			return slices.Contains(seasons, season)
+		}
+		return OutfitInSeason(assignments, index.TagsOf(category, file.FileName), season)
+	}
+}
@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestSeasonAt(t *testing.T) {
+	tests := []struct {
+		month    time.Month
+		southern bool
+		want     string
+	}{
+		{time.January, false, "winter"},
+		{time.February, false, "winter"},
+		{time.March, false, "spring"},
+		{time.June, false, "summer"},
+		{time.September, false, "autumn"},
+		{time.November, false, "autumn"},
+		{time.December, false, "winter"},
+		{time.January, true, "summer"},
+		{time.April, true, "autumn"},
+		{time.July, true, "winter"},
+		{time.October, true, "spring"},
+	}
+	for _, tt := range tests {
+		if got := SeasonAt(time.Date(2024, tt.month, 15, 0, 0, 0, 0, time.UTC), tt.southern); got != tt.want {
+			t.Errorf("SeasonAt(%v, southern=%v) = %v, want %v", tt.month, tt.southern, got, tt.want)
+		}
+	}
+}
+
+func TestResolveSeason(t *testing.T) {
+	july := time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC)
+	if got, err := ResolveSeason(entities.SeasonAuto, entities.SeasonAssignments{}, july); err != nil || got != "summer" {
+		t.Errorf("ResolveSeason(auto) = %v, %v; want summer", got, err)
+	}
+	if got, err := ResolveSeason("winter", entities.SeasonAssignments{}, july); err != nil || got != "winter" {
+		t.Errorf("ResolveSeason(winter) = %v, %v", got, err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := ResolveSeason("fall", entities.SeasonAssignments{}, july); !errors.As(err, &invalid) {
+		t.Errorf("ResolveSeason(fall) error = %v, want InvalidInputError", err)
+	}
+}
+
+func TestSeasonFilters(t *testing.T) {
+	assignments := entities.SeasonAssignments{}.
+		SettingCategory("knits", []string{"autumn", "winter"}).
+		SettingTag("beach", []string{"summer"}).
+		SettingTag("layering", []string{"spring", "autumn"})
+
+	if CategoryInSeason(assignments, "knits", "summer") || !CategoryInSeason(assignments, "knits", "winter") || !CategoryInSeason(assignments, "casual", "summer") {
+		t.Error("CategoryInSeason() did not follow the category assignments")
+	}
+
+	index := entities.NewMetadataIndex().
+		Setting("casual", "shorts.avatar", entities.OutfitMetadata{Tags: []string{"beach"}}).
+		Setting("casual", "cardigan.avatar", entities.OutfitMetadata{Tags: []string{"beach", "layering"}}).
+		Setting("casual", "tee.avatar", entities.OutfitMetadata{Tags: []string{"favorite"}})
+	files := []entities.FileEntry{
+		entities.NewFileEntry("/wardrobe/casual/shorts.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/cardigan.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/tee.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/untagged.avatar"),
+	}
+	got := FilterAvailableOutfits(files, nil, InSeason(assignments, index, "casual", "autumn"))
+	var names []string
+	for _, file := range got {
+		names = append(names, file.FileName)
+	}
+	if len(names) != 3 || names[0] != "cardigan.avatar" {
+		t.Errorf("InSeason(autumn) kept %v, want everything but shorts.avatar", names)
+	}
+}
+
+func TestInSeason_SidecarSeasons(t *testing.T) {
+	assignments := entities.SeasonAssignments{}.SettingTag("beach", []string{"summer"})
+	index := entities.NewMetadataIndex().
+		Setting("casual", "shorts.avatar", entities.OutfitMetadata{Tags: []string{"beach"}}).
+		WithSidecars("casual", map[string]entities.OutfitSidecar{
+			"shorts.avatar": {Seasons: []string{"spring", "summer"}},
+			"coat.avatar":   {Seasons: []string{"winter"}},
+		})
+	inSpring := InSeason(assignments, index, "casual", "spring")
+
+	if !inSpring(entities.NewFileEntry("/wardrobe/casual/shorts.avatar")) {
+		t.Error("InSeason(spring) left out an outfit whose sidecar names spring, despite its tags")
+	}
+	if inSpring(entities.NewFileEntry("/wardrobe/casual/coat.avatar")) {
+		t.Error("InSeason(spring) kept an outfit whose sidecar names only winter")
+	}
+	if !inSpring(entities.NewFileEntry("/wardrobe/casual/tee.avatar")) {
+		t.Error("InSeason(spring) left out an outfit with no seasons")
+	}
+}
@@ -0,0 +1,154 @@
+package logic
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Selector chooses one outfit from a pool, uniformly unless weights are
+// configured.
+type Selector struct {
+	rand   *rand.Rand
+	weight func(entities.FileEntry) float64
+	keep   OutfitFilter
+	narrow func([]entities.FileEntry) []entities.FileEntry
+}
+
+// SelectorOption configures a Selector.
+type SelectorOption func(*Selector)
+
+// WithWeights makes the chance of picking an outfit proportional to
+// weight(outfit). Non-positive weights exclude an outfit unless every weight
+// is non-positive, in which case selection falls back to uniform.
+func WithWeights(weight func(entities.FileEntry) float64) SelectorOption {
+	return func(s *Selector) {
+		s.weight = weight
+	}
+}
+
+// WithFilter restricts selection to the outfits for which keep returns true.
+// Select fails when it keeps none of the pool.
+func WithFilter(keep OutfitFilter) SelectorOption {
+	return func(s *Selector) {
+		s.keep = keep
+	}
+}
+
+// WithNarrowing narrows the filtered pool before choosing from it, such as
+// to the outfits most due under a rotation policy.
+func WithNarrowing(narrow func([]entities.FileEntry) []entities.FileEntry) SelectorOption {
+	return func(s *Selector) {
+		s.narrow = narrow
+	}
+}
+
+// WithSeed makes selection deterministic: selectors created with the same
+// seed pick the same outfits from the same pools, on any machine.
+func WithSeed(seed uint64) SelectorOption {
+	return func(s *Selector) {
+		s.rand = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// NewSelector creates a selector. Without WithSeed it draws from a randomly
+// seeded source.
+func NewSelector(opts ...SelectorOption) *Selector {
+	s := &Selector{
+		rand: rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Keeps reports whether the filter keeps any of pool, so that Select can
+// pick from it once the pool is not narrowed further.
+func (s *Selector) Keeps(pool []entities.FileEntry) bool {
+	if s.keep == nil {
+		return len(pool) > 0
+	}
+	return slices.ContainsFunc(pool, s.keep)
+}
+
+// Select picks an outfit from pool. It returns false if the pool is empty
+// or the filter keeps none of it.
+func (s *Selector) Select(pool []entities.FileEntry) (entities.FileEntry, bool) {
+	if s.keep != nil {
+		pool = slices.DeleteFunc(slices.Clone(pool), func(entry entities.FileEntry) bool { return !s.keep(entry) })
+	}
+	if s.narrow != nil {
+		pool = s.narrow(pool)
+	}
+	if len(pool) == 0 {
+		return entities.FileEntry{}, false
+	}
+	if s.weight == nil {
+		return pool[s.rand.IntN(len(pool))], true
+	}
+
+	weights := make([]float64, len(pool))
+	total := 0.0
+	for i, entry := range pool {
+		if w := s.weight(entry); w > 0 {
+			weights[i] = w
+			total += w
+		}
+	}
+	if total == 0 {
+		return pool[s.rand.IntN(len(pool))], true
+	}
+
+	target := s.rand.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return pool[i], true
+		}
+		target -= w
+	}
+	// Rounding can leave target just above the last weight.
+	for i := len(pool) - 1; ; i-- {
+		if weights[i] > 0 {
+			return pool[i], true
+		}
+	}
+}
+
+// FeedbackWeights returns a weight function that gives every outfit a base
+// weight of 1 plus boost for each point of net positive feedback in scores.
+// Negative feedback never lowers an outfit below the base weight.
+func FeedbackWeights(scores map[string]int, boost float64) func(entities.FileEntry) float64 {
+	return func(entry entities.FileEntry) float64 {
+		return 1 + boost*float64(max(scores[entry.FileName], 0))
+	}
+}
+
+// WeightedPick returns the weight function of the weighted strategy: an
+// outfit's user-assigned weight, DefaultOutfitWeight when it has none, scaled
+// by its feedback weight. A user weight of 0 leaves the outfit out regardless
+// of feedback.
+func WeightedPick(weights map[string]float64, scores map[string]int, boost float64) func(entities.FileEntry) float64 {
+	feedback := FeedbackWeights(scores, boost)
+	return func(entry entities.FileEntry) float64 {
+		weight, ok := weights[entry.FileName]
+		if !ok {
+			weight = entities.DefaultOutfitWeight
+		}
+		return weight * feedback(entry)
+	}
+}
+
+// RatedPick returns the weight function of the rated strategy: an outfit's
+// rating, entities.NeutralRating when it is unrated, so a five-star outfit
+// is five times as likely to be picked as a one-star one.
+func RatedPick(ratings map[string]int) func(entities.FileEntry) float64 {
+	return func(entry entities.FileEntry) float64 {
+		rating, ok := ratings[entry.FileName]
+		if !ok {
+			rating = entities.NeutralRating
+		}
+		return float64(rating)
+	}
+}
@@ -0,0 +1,174 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func testPool(names ...string) []entities.FileEntry {
+	pool := make([]entities.FileEntry, len(names))
+	for i, name := range names {
+		pool[i] = entities.NewFileEntry("/outfits/casual/" + name)
+	}
+	return pool
+}
+
+func TestSelector_EmptyPool(t *testing.T) {
+	if _, ok := NewSelector().Select(nil); ok {
+		t.Error("Select() on empty pool returned ok")
+	}
+}
+
+func TestSelector_WithSeedIsReproducible(t *testing.T) {
+	pool := testPool("a.avatar", "b.avatar", "c.avatar", "d.avatar", "e.avatar")
+	picks := func(opts ...SelectorOption) []string {
+		selector := NewSelector(opts...)
+		var names []string
+		for range 20 {
+			got, _ := selector.Select(pool)
+			names = append(names, got.FileName)
+		}
+		return names
+	}
+
+	first := picks(WithSeed(42))
+	if again := picks(WithSeed(42)); !slices.Equal(first, again) {
+		t.Errorf("same seed picked %v then %v", first, again)
+	}
+	if other := picks(WithSeed(43)); slices.Equal(first, other) {
+		t.Errorf("seeds 42 and 43 both picked %v", first)
+	}
+	weights := WithWeights(FeedbackWeights(map[string]int{"c.avatar": 2}, 1))
+	if a, b := picks(WithSeed(7), weights), picks(weights, WithSeed(7)); !slices.Equal(a, b) {
+		t.Errorf("weighted picks depend on option order: %v vs %v", a, b)
+	}
+}
+
+func TestSelector_Weighted(t *testing.T) {
+	pool := testPool("a.avatar", "b.avatar", "c.avatar")
+	selector := NewSelector(WithWeights(func(entry entities.FileEntry) float64 {
+		if entry.FileName == "b.avatar" {
+			return 1
+		}
+		return 0
+	}))
+
+	for range 50 {
+		got, ok := selector.Select(pool)
+		if !ok || got.FileName != "b.avatar" {
+			t.Fatalf("Select() = %v, %v; want only b.avatar", got.FileName, ok)
+		}
+	}
+}
+
+func TestSelector_AllZeroWeightsFallBackToUniform(t *testing.T) {
+	pool := testPool("a.avatar", "b.avatar")
+	selector := NewSelector(WithWeights(func(entities.FileEntry) float64 { return 0 }))
+
+	seen := make(map[string]bool)
+	for range 200 {
+		got, _ := selector.Select(pool)
+		seen[got.FileName] = true
+	}
+	if len(seen) != 2 {
+		t.Errorf("Select() only returned %v", seen)
+	}
+}
+
+func TestSelector_WeightedDistribution(t *testing.T) {
+	pool := testPool("liked.avatar", "plain.avatar")
+	selector := NewSelector(WithWeights(FeedbackWeights(map[string]int{"liked.avatar": 3}, 1)))
+
+	const picks = 4000
+	liked := 0
+	for range picks {
+		if got, _ := selector.Select(pool); got.FileName == "liked.avatar" {
+			liked++
+		}
+	}
+	// liked.avatar has weight 4 against 1, so it should win about 80% of picks.
+	if ratio := float64(liked) / picks; ratio < 0.75 || ratio > 0.85 {
+		t.Errorf("liked.avatar picked %.2f of the time, want about 0.80", ratio)
+	}
+}
+
+func TestFeedbackWeights(t *testing.T) {
+	weight := FeedbackWeights(map[string]int{"liked.avatar": 2, "disliked.avatar": -3}, 0.5)
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"liked.avatar", 2},
+		{"disliked.avatar", 1},
+		{"unrated.avatar", 1},
+	}
+	for _, tt := range tests {
+		if got := weight(entities.NewFileEntry("/outfits/casual/" + tt.name)); got != tt.want {
+			t.Errorf("weight(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWeightedPick(t *testing.T) {
+	weight := WeightedPick(map[string]float64{"favorite.avatar": 3, "retired.avatar": 0}, map[string]int{"favorite.avatar": 1, "liked.avatar": 2}, 0.5)
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"favorite.avatar", 4.5},
+		{"liked.avatar", 2},
+		{"retired.avatar", 0},
+		{"plain.avatar", 1},
+	}
+	for _, tt := range tests {
+		if got := weight(entities.NewFileEntry("/outfits/casual/" + tt.name)); got != tt.want {
+			t.Errorf("weight(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRatedPick(t *testing.T) {
+	weight := RatedPick(map[string]int{"loved.avatar": 5, "meh.avatar": 1})
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"loved.avatar", 5},
+		{"meh.avatar", 1},
+		{"unrated.avatar", entities.NeutralRating},
+	}
+	for _, tt := range tests {
+		if got := weight(entities.NewFileEntry("/outfits/casual/" + tt.name)); got != tt.want {
+			t.Errorf("weight(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSelector_WithFilter(t *testing.T) {
+	pool := testPool("a.avatar", "b.avatar", "c.avatar")
+	keep := func(entry entities.FileEntry) bool { return entry.FileName != "b.avatar" }
+	selector := NewSelector(WithFilter(keep), WithWeights(func(entry entities.FileEntry) float64 {
+		if entry.FileName == "b.avatar" {
+			return 100
+		}
+		return 1
+	}))
+
+	for range 50 {
+		got, ok := selector.Select(pool)
+		if !ok || got.FileName == "b.avatar" {
+			t.Fatalf("Select() = %v, %v; want a kept outfit", got.FileName, ok)
+		}
+	}
+	if _, ok := selector.Select(testPool("b.avatar")); ok {
+		t.Error("Select() returned ok when the filter kept nothing")
+	}
+	if len(pool) != 3 || pool[1].FileName != "b.avatar" {
+		t.Errorf("Select() modified the pool: %v", pool)
+	}
+	if !selector.Keeps(pool) || selector.Keeps(testPool("b.avatar")) {
+		t.Error("Keeps() disagrees with the filter")
+	}
+}
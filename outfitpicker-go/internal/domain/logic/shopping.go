@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+)
+
+// daysPerMonth is the month length wear cadence is scaled to.
+const daysPerMonth = 30
+
+// CategoryCadence is how big a category is and how often it was worn over
+// a recent period.
+type CategoryCadence struct {
+	Category string
+	Outfits  int
+	// Wears counts the wears in the last Days days.
+	Wears int
+	Days  int
+}
+
+// ShoppingSuggestion is a category whose rotation completes too quickly to
+// give the wanted variety, with how many outfits to add.
+type ShoppingSuggestion struct {
+	Category string `json:"category"`
+	Outfits  int    `json:"outfits"`
+	// WearsPerMonth is the category's wear cadence scaled to 30 days.
+	WearsPerMonth float64 `json:"wearsPerMonth"`
+	// RotationDays is how long a full rotation takes at that cadence.
+	RotationDays float64 `json:"rotationDays"`
+	// Suggested is how many new outfits would let a month's wears all be
+	// different, up to the wanted number of distinct outfits per month.
+	Suggested int `json:"suggested"`
+}
+
+// ShoppingSuggestions flags the categories that show fewer than
+// minPerMonth distinct outfits a month because their rotation completes in
+// under a month. Buying for a category worn less often than minPerMonth
+// times a month only helps until every wear in a month is different, so
+// suggestions stop there. The categories where new outfits add the most
+// variety come first.
+func ShoppingSuggestions(cadences []CategoryCadence, minPerMonth int) []ShoppingSuggestion {
+	suggestions := []ShoppingSuggestion{}
+	for _, cadence := range cadences {
+		if cadence.Wears == 0 || cadence.Days <= 0 {
+			continue
+		}
+		wearsPerMonth := float64(cadence.Wears) * daysPerMonth / float64(cadence.Days)
+		wanted := min(minPerMonth, int(math.Ceil(wearsPerMonth)))
+		if cadence.Outfits >= wanted {
+			continue
+		}
+		suggestions = append(suggestions, ShoppingSuggestion{
+			Category:      cadence.Category,
+			Outfits:       cadence.Outfits,
+			WearsPerMonth: math.Round(wearsPerMonth*10) / 10,
+			RotationDays:  math.Round(float64(cadence.Outfits)*float64(cadence.Days)/float64(cadence.Wears)*10) / 10,
+			Suggested:     wanted - cadence.Outfits,
+		})
+	}
+	slices.SortFunc(suggestions, func(a, b ShoppingSuggestion) int {
+		return cmp.Or(cmp.Compare(b.Suggested, a.Suggested), cmp.Compare(a.RotationDays, b.RotationDays), strings.Compare(a.Category, b.Category))
+	})
+	return suggestions
+}
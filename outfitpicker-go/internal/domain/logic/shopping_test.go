@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShoppingSuggestions(t *testing.T) {
+	cadences := []CategoryCadence{
+		// Worn every day with 6 outfits: a rotation takes 6 days.
+		{Category: "work", Outfits: 6, Wears: 90, Days: 90},
+		// Worn 4 times a month with 2 outfits: only 2 more are useful.
+		{Category: "gym", Outfits: 2, Wears: 12, Days: 90},
+		// Plenty for how often it is worn.
+		{Category: "casual", Outfits: 20, Wears: 45, Days: 90},
+		{Category: "formal", Outfits: 1, Wears: 0, Days: 90},
+	}
+
+	got := ShoppingSuggestions(cadences, 10)
+	want := []ShoppingSuggestion{
+		{Category: "work", Outfits: 6, WearsPerMonth: 30, RotationDays: 6, Suggested: 4},
+		{Category: "gym", Outfits: 2, WearsPerMonth: 4, RotationDays: 15, Suggested: 2},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ShoppingSuggestions() = %+v, want %+v", got, want)
+	}
+	if got := ShoppingSuggestions(cadences, 3); len(got) != 1 || got[0].Category != "gym" || got[0].Suggested != 1 {
+		t.Errorf("ShoppingSuggestions() with a lower quota = %+v, want one more gym outfit", got)
+	}
+}
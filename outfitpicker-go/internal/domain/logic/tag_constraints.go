@@ -0,0 +1,56 @@
+package logic
+
+import (
+	"math"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// ReachedTagConstraints returns the constraints whose limit is used up:
+// outfits with their tag were picked Max times or more within the last Days
+// days of history, so picking one more would break them.
+func ReachedTagConstraints(constraints []entities.TagConstraint, history entities.SelectionHistory, index entities.MetadataIndex, now time.Time) []entities.TagConstraint {
+	var reached []entities.TagConstraint
+	for _, constraint := range constraints {
+		since := now.AddDate(0, 0, -constraint.Days)
+		picks := 0
+		for _, record := range history.Records {
+			if record.SelectedAt.After(since) && index.HasTag(record.Category, record.FileName, constraint.Tag) {
+				picks++
+			}
+		}
+		if picks >= constraint.Max {
+			reached = append(reached, constraint)
+		}
+	}
+	return reached
+}
+
+// WithinTagConstraints keeps the outfits of category that break none of the
+// blocking constraints in reached.
+func WithinTagConstraints(reached []entities.TagConstraint, index entities.MetadataIndex, category string) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		for _, constraint := range reached {
+			if constraint.Blocks() && index.HasTag(category, file.FileName, constraint.Tag) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// TagConstraintWeights returns a weight function that scales an outfit of
+// category by entities.DowngradeFactor for each downgrading constraint in
+// reached it would break, and leaves other outfits at 1.
+func TagConstraintWeights(reached []entities.TagConstraint, index entities.MetadataIndex, category string) func(entities.FileEntry) float64 {
+	return func(file entities.FileEntry) float64 {
+		broken := 0
+		for _, constraint := range reached {
+			if !constraint.Blocks() && index.HasTag(category, file.FileName, constraint.Tag) {
+				broken++
+			}
+		}
+		return math.Pow(entities.DowngradeFactor, float64(broken))
+	}
+}
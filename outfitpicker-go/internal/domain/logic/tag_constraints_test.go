@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestTagConstraints(t *testing.T) {
+	now := time.Date(2024, 6, 8, 9, 0, 0, 0, time.UTC)
+	index := entities.MetadataIndex{}.
+		Setting("casual", "black-tee.avatar", entities.OutfitMetadata{Tags: []string{"black"}}).
+		Setting("formal", "black-suit.avatar", entities.OutfitMetadata{Tags: []string{"black", "wool"}}).
+		Setting("formal", "grey-suit.avatar", entities.OutfitMetadata{Tags: []string{"wool"}})
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "black-tee.avatar", SelectedAt: now.AddDate(0, 0, -8)},
+		{Category: "casual", FileName: "black-tee.avatar", SelectedAt: now.AddDate(0, 0, -3)},
+		{Category: "formal", FileName: "black-suit.avatar", SelectedAt: now.AddDate(0, 0, -1)},
+	}}
+	constraints := []entities.TagConstraint{
+		{Tag: "black", Max: 2, Days: 7},
+		{Tag: "wool", Max: 1, Days: 7, Severity: entities.ConstraintDowngrade},
+		{Tag: "black", Max: 3, Days: 7},
+		{Tag: "linen", Max: 0, Days: 7},
+	}
+
+	reached := ReachedTagConstraints(constraints, history, index, now)
+	if len(reached) != 3 || reached[0] != constraints[0] || reached[1] != constraints[1] || reached[2] != constraints[3] {
+		t.Fatalf("ReachedTagConstraints() = %+v", reached)
+	}
+
+	within := WithinTagConstraints(reached, index, "formal")
+	weight := TagConstraintWeights(reached, index, "formal")
+	tests := []struct {
+		fileName   string
+		wantWithin bool
+		wantWeight float64
+	}{
+		{"black-suit.avatar", false, entities.DowngradeFactor},
+		{"grey-suit.avatar", true, entities.DowngradeFactor},
+		{"blazer.avatar", true, 1},
+	}
+	for _, tt := range tests {
+		file := entities.FileEntry{FileName: tt.fileName}
+		if got := within(file); got != tt.wantWithin {
+			t.Errorf("WithinTagConstraints(%s) = %v, want %v", tt.fileName, got, tt.wantWithin)
+		}
+		if got := weight(file); got != tt.wantWeight {
+			t.Errorf("TagConstraintWeights(%s) = %v, want %v", tt.fileName, got, tt.wantWeight)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package logic
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RecentWardrobeEvents returns up to limit of the picks in history and the
+// rotations completed in log, newest first.
+func RecentWardrobeEvents(history entities.SelectionHistory, log entities.WearLog, limit int) []entities.WardrobeEvent {
+	events := make([]entities.WardrobeEvent, 0, len(history.Records))
+	for _, record := range history.Records {
+		events = append(events, entities.WardrobeEvent{Kind: entities.WardrobeEventPick, Category: record.Category, FileName: record.FileName, At: record.SelectedAt})
+	}
+	for _, event := range log.Events {
+		if event.CompletedRotation {
+			events = append(events, entities.WardrobeEvent{Kind: entities.WardrobeEventRotationCompleted, Category: event.Category, FileName: event.FileName, At: event.WornAt})
+		}
+	}
+	slices.SortStableFunc(events, func(a, b entities.WardrobeEvent) int {
+		return b.At.Compare(a.At)
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events
+}
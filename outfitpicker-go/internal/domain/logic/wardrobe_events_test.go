@@ -0,0 +1,37 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestRecentWardrobeEvents(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 8, 0, 0, 0, time.UTC) }
+	history := entities.SelectionHistory{Records: []entities.SelectionRecord{
+		{Category: "casual", FileName: "tee.avatar", SelectedAt: day(1)},
+		{Category: "casual", FileName: "jeans.avatar", SelectedAt: day(3)},
+		{Category: "work", FileName: "suit.avatar", SelectedAt: day(4)},
+	}}
+	log := entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "tee.avatar", WornAt: day(1)},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day(3).Add(time.Hour), CompletedRotation: true},
+	}}
+
+	events := RecentWardrobeEvents(history, log, 3)
+
+	want := []entities.WardrobeEvent{
+		{Kind: entities.WardrobeEventPick, Category: "work", FileName: "suit.avatar", At: day(4)},
+		{Kind: entities.WardrobeEventRotationCompleted, Category: "casual", FileName: "jeans.avatar", At: day(3).Add(time.Hour)},
+		{Kind: entities.WardrobeEventPick, Category: "casual", FileName: "jeans.avatar", At: day(3)},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("RecentWardrobeEvents() = %+v, want %+v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("events[%d] = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// WardrobeGrowth counts the outfits each tracked category held at each of
+// points, which must be in order, using the same sightings rules as
+// ReplayRotations. Categories are sorted by name.
+func WardrobeGrowth(arrivals entities.OutfitArrivals, points []time.Time) entities.WardrobeGrowth {
+	growth := entities.WardrobeGrowth{
+		Points:     points,
+		Categories: []entities.CategoryGrowth{},
+		Totals:     make([]int, len(points)),
+	}
+	for category, outfits := range arrivals.Outfits {
+		counts := make([]int, len(points))
+		for i, point := range points {
+			for fileName := range outfits {
+				if ownedAt(arrivals, category, fileName, point) {
+					counts[i]++
+				}
+			}
+			growth.Totals[i] += counts[i]
+		}
+		growth.Categories = append(growth.Categories, entities.CategoryGrowth{Category: category, Counts: counts})
+	}
+	slices.SortFunc(growth.Categories, func(a, b entities.CategoryGrowth) int {
+		return strings.Compare(a.Category, b.Category)
+	})
+	return growth
+}
@@ -0,0 +1,43 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestWardrobeGrowth(t *testing.T) {
+	month := func(m time.Month) time.Time { return time.Date(2024, m, 1, 0, 0, 0, 0, time.UTC) }
+	arrivals := entities.OutfitArrivals{
+		Outfits: map[string]map[string]time.Time{
+			"work":   {"suit.avatar": {}, "blazer.avatar": month(2).Add(time.Hour)},
+			"casual": {"tee.avatar": {}, "old.avatar": {}, "jeans.avatar": {}},
+		},
+		LastSeen: map[string]map[string]time.Time{
+			"casual": {"tee.avatar": month(4), "old.avatar": month(2), "jeans.avatar": month(3)},
+		},
+	}
+
+	growth := WardrobeGrowth(arrivals, []time.Time{month(2), month(3), month(4), month(5)})
+
+	want := []entities.CategoryGrowth{
+		{Category: "casual", Counts: []int{3, 3, 2, 1}},
+		{Category: "work", Counts: []int{1, 2, 2, 2}},
+	}
+	if len(growth.Categories) != len(want) {
+		t.Fatalf("WardrobeGrowth() = %+v, want %+v", growth.Categories, want)
+	}
+	for i, w := range want {
+		if got := growth.Categories[i]; got.Category != w.Category || !slices.Equal(got.Counts, w.Counts) {
+			t.Errorf("Categories[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+	if !slices.Equal(growth.Totals, []int{4, 5, 4, 3}) {
+		t.Errorf("Totals = %v, want [4 5 4 3]", growth.Totals)
+	}
+	if change := growth.Categories[0].Change(); change != -2 {
+		t.Errorf("Change() = %d, want -2", change)
+	}
+}
@@ -0,0 +1,172 @@
+package logic
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// WashMethod is how a load of laundry is cleaned.
+type WashMethod string
+
+const (
+	WashMachine      WashMethod = "machine"
+	WashHand         WashMethod = "hand"
+	WashProfessional WashMethod = "professional"
+)
+
+// Fallback programs for outfits whose label has no wash symbol, chosen by
+// their dominant fiber.
+var (
+	delicateFibers     = []string{"alpaca", "cashmere", "mohair", "silk", "wool"}
+	professionalFibers = []string{"down", "leather"}
+	syntheticFibers    = []string{"acrylic", "elastane", "lyocell", "modal", "nylon", "polyester", "viscose"}
+)
+
+const (
+	defaultWashTemperature   = 40
+	syntheticWashTemperature = 30
+)
+
+// WashProgram is the set of care settings shared by one load.
+type WashProgram struct {
+	Method WashMethod `json:"method"`
+	// Temperature is the maximum wash temperature in °C for machine washes.
+	Temperature int  `json:"temperature,omitempty"`
+	TumbleDry   bool `json:"tumbleDry"`
+}
+
+func (p WashProgram) String() string {
+	switch p.Method {
+	case WashMachine:
+		drying := "air dry"
+		if p.TumbleDry {
+			drying = "tumble dry"
+		}
+		return fmt.Sprintf("machine wash at %d°C, %s", p.Temperature, drying)
+	case WashHand:
+		return "hand wash, air dry"
+	default:
+		return "professional clean"
+	}
+}
+
+// LaundryItem is an outfit waiting to be washed together with its metadata.
+type LaundryItem struct {
+	Outfit   entities.OutfitReference
+	Metadata entities.OutfitMetadata
+}
+
+// WashLoad is a group of outfits that can be washed together.
+type WashLoad struct {
+	Program WashProgram                `json:"program"`
+	Outfits []entities.OutfitReference `json:"outfits"`
+}
+
+// WashProgramFor derives the wash program from an outfit's care symbols,
+// falling back to its dominant fiber. It reports false when the metadata
+// says nothing about washing.
+func WashProgramFor(metadata entities.OutfitMetadata) (WashProgram, bool) {
+	program := WashProgram{}
+	found := false
+	for _, symbol := range metadata.Care {
+		switch {
+		case strings.HasPrefix(symbol, "wash-"):
+			temperature, err := strconv.Atoi(strings.TrimPrefix(symbol, "wash-"))
+			if err == nil {
+				program.Method, program.Temperature, found = WashMachine, temperature, true
+			}
+		case symbol == "hand-wash":
+			program.Method, found = WashHand, true
+		case symbol == "do-not-wash":
+			program.Method, found = WashProfessional, true
+		case symbol == "tumble-dry-low" || symbol == "tumble-dry-normal":
+			program.TumbleDry = true
+		}
+	}
+	if !found && slices.Contains(metadata.Care, "dry-clean") {
+		program.Method, found = WashProfessional, true
+	}
+	if !found {
+		program.Method, program.Temperature, found = programForFiber(metadata.DominantFiber())
+	}
+	if program.Method != WashMachine {
+		program.Temperature, program.TumbleDry = 0, false
+	}
+	return program, found
+}
+
+func programForFiber(fiber string) (WashMethod, int, bool) {
+	switch {
+	case fiber == "":
+		return "", 0, false
+	case slices.Contains(delicateFibers, fiber):
+		return WashHand, 0, true
+	case slices.Contains(professionalFibers, fiber):
+		return WashProfessional, 0, true
+	case slices.Contains(syntheticFibers, fiber):
+		return WashMachine, syntheticWashTemperature, true
+	default:
+		return WashMachine, defaultWashTemperature, true
+	}
+}
+
+// GroupWashLoads groups items that share a wash program. Items without any
+// washing information are returned separately. Loads are ordered machine
+// washes by temperature, then hand washes, then professional cleaning, and
+// outfits within a load by category and file name.
+func GroupWashLoads(items []LaundryItem) (loads []WashLoad, unknown []entities.OutfitReference) {
+	byProgram := make(map[WashProgram][]entities.OutfitReference)
+	for _, item := range items {
+		program, ok := WashProgramFor(item.Metadata)
+		if !ok {
+			unknown = append(unknown, item.Outfit)
+			continue
+		}
+		byProgram[program] = append(byProgram[program], item.Outfit)
+	}
+
+	for program, outfits := range byProgram {
+		slices.SortFunc(outfits, compareOutfits)
+		loads = append(loads, WashLoad{Program: program, Outfits: outfits})
+	}
+	slices.SortFunc(loads, func(a, b WashLoad) int {
+		return cmp.Or(
+			cmp.Compare(washMethodOrder(a.Program.Method), washMethodOrder(b.Program.Method)),
+			cmp.Compare(a.Program.Temperature, b.Program.Temperature),
+			compareBool(a.Program.TumbleDry, b.Program.TumbleDry),
+		)
+	})
+	slices.SortFunc(unknown, compareOutfits)
+	return loads, unknown
+}
+
+func washMethodOrder(method WashMethod) int {
+	switch method {
+	case WashMachine:
+		return 0
+	case WashHand:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func compareOutfits(a, b entities.OutfitReference) int {
+	return cmp.Or(cmp.Compare(a.Category.Name, b.Category.Name), cmp.Compare(a.FileName, b.FileName))
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
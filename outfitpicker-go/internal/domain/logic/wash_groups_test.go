@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func materials(fiber string) []entities.MaterialComponent {
+	return []entities.MaterialComponent{{Fiber: fiber, Percent: 100}}
+}
+
+func TestWashProgramFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata entities.OutfitMetadata
+		want     WashProgram
+		wantOK   bool
+	}{
+		{"no metadata", entities.OutfitMetadata{}, WashProgram{}, false},
+		{"machine with tumble", entities.OutfitMetadata{Care: []string{"wash-60", "tumble-dry-low"}}, WashProgram{WashMachine, 60, true}, true},
+		{"machine no tumble", entities.OutfitMetadata{Care: []string{"wash-30", "do-not-tumble-dry"}}, WashProgram{WashMachine, 30, false}, true},
+		{"hand wash ignores tumble", entities.OutfitMetadata{Care: []string{"hand-wash", "tumble-dry-low"}}, WashProgram{Method: WashHand}, true},
+		{"dry clean only", entities.OutfitMetadata{Care: []string{"dry-clean"}}, WashProgram{Method: WashProfessional}, true},
+		{"do not wash", entities.OutfitMetadata{Care: []string{"do-not-wash"}}, WashProgram{Method: WashProfessional}, true},
+		{"care beats fiber", entities.OutfitMetadata{Materials: materials("wool"), Care: []string{"wash-30"}}, WashProgram{WashMachine, 30, false}, true},
+		{"wool falls back to hand", entities.OutfitMetadata{Materials: materials("wool")}, WashProgram{Method: WashHand}, true},
+		{"cotton falls back to 40", entities.OutfitMetadata{Materials: materials("cotton")}, WashProgram{WashMachine, 40, false}, true},
+		{"polyester falls back to 30", entities.OutfitMetadata{Materials: materials("polyester")}, WashProgram{WashMachine, 30, false}, true},
+		{"leather falls back to professional", entities.OutfitMetadata{Materials: materials("leather")}, WashProgram{Method: WashProfessional}, true},
+		{"iron only", entities.OutfitMetadata{Care: []string{"iron-low"}}, WashProgram{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := WashProgramFor(tt.metadata)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("WashProgramFor() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGroupWashLoads(t *testing.T) {
+	outfit := func(category, file string) entities.OutfitReference {
+		return entities.NewOutfitReference(file, entities.NewCategoryReference(category, "/outfits/"+category))
+	}
+	items := []LaundryItem{
+		{outfit("work", "shirt.avatar"), entities.OutfitMetadata{Materials: materials("cotton")}},
+		{outfit("casual", "sweater.avatar"), entities.OutfitMetadata{Materials: materials("wool")}},
+		{outfit("casual", "tee.avatar"), entities.OutfitMetadata{Care: []string{"wash-40"}}},
+		{outfit("gym", "shorts.avatar"), entities.OutfitMetadata{Care: []string{"wash-30"}}},
+		{outfit("casual", "mystery.avatar"), entities.OutfitMetadata{}},
+	}
+
+	loads, unknown := GroupWashLoads(items)
+
+	if len(loads) != 3 {
+		t.Fatalf("GroupWashLoads() returned %d loads, want 3: %+v", len(loads), loads)
+	}
+	if loads[0].Program.Temperature != 30 || loads[1].Program.Temperature != 40 || loads[2].Program.Method != WashHand {
+		t.Errorf("loads out of order: %+v", loads)
+	}
+	if got := loads[1].Outfits; len(got) != 2 || got[0].FileName != "tee.avatar" || got[1].FileName != "shirt.avatar" {
+		t.Errorf("40°C load = %+v, want casual/tee then work/shirt", got)
+	}
+	if len(unknown) != 1 || unknown[0].FileName != "mystery.avatar" {
+		t.Errorf("unknown = %+v", unknown)
+	}
+}
@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RepairNote is the note on wear events added to the history by a repair
+// that trusts the cache.
+const RepairNote = "added by doctor --repair"
+
+// WearDiscrepancies compares each category's worn outfits in the cache with
+// the outfits worn since the category's last completed rotation in the wear
+// log. Discrepancies are ordered by category and file name.
+func WearDiscrepancies(cache entities.OutfitCache, log entities.WearLog) []entities.WearDiscrepancy {
+	categories := make(map[string]bool, len(cache.Categories))
+	for category := range cache.Categories {
+		categories[category] = true
+	}
+	for _, event := range log.Events {
+		categories[event.Category] = true
+	}
+
+	var discrepancies []entities.WearDiscrepancy
+	for category := range categories {
+		rotation := make(map[string]bool)
+		for _, fileName := range log.CurrentRotation(category) {
+			rotation[fileName] = true
+		}
+		worn := cache.Categories[category].WornOutfits
+		for fileName, isWorn := range worn {
+			if isWorn && !rotation[fileName] {
+				discrepancies = append(discrepancies, entities.WearDiscrepancy{Category: category, FileName: fileName, Kind: entities.DiscrepancyCacheOnly})
+			}
+		}
+		for fileName := range rotation {
+			if !worn[fileName] {
+				discrepancies = append(discrepancies, entities.WearDiscrepancy{Category: category, FileName: fileName, Kind: entities.DiscrepancyHistoryOnly})
+			}
+		}
+	}
+	slices.SortFunc(discrepancies, func(a, b entities.WearDiscrepancy) int {
+		return cmp.Or(strings.Compare(a.Category, b.Category), strings.Compare(a.FileName, b.FileName))
+	})
+	return discrepancies
+}
+
+// HistoryMatchingCache returns the wear log changed so its current rotations
+// agree with the cache: events of history-only outfits since their
+// category's last completed rotation are dropped, and a wear event at now is
+// added for each cache-only outfit.
+func HistoryMatchingCache(log entities.WearLog, discrepancies []entities.WearDiscrepancy, now time.Time) entities.WearLog {
+	type outfit struct{ category, fileName string }
+	historyOnly := make(map[outfit]bool)
+	var added []entities.WearEvent
+	for _, discrepancy := range discrepancies {
+		switch discrepancy.Kind {
+		case entities.DiscrepancyHistoryOnly:
+			historyOnly[outfit{discrepancy.Category, discrepancy.FileName}] = true
+		case entities.DiscrepancyCacheOnly:
+			added = append(added, entities.WearEvent{Category: discrepancy.Category, FileName: discrepancy.FileName, WornAt: now, Note: RepairNote})
+		}
+	}
+
+	rotationStart := make(map[string]int)
+	for i, event := range log.Events {
+		if event.CompletedRotation {
+			rotationStart[event.Category] = i + 1
+		}
+	}
+	events := make([]entities.WearEvent, 0, len(log.Events)+len(added))
+	for i, event := range log.Events {
+		if i >= rotationStart[event.Category] && historyOnly[outfit{event.Category, event.FileName}] {
+			continue
+		}
+		events = append(events, event)
+	}
+	return entities.WearLog{Events: append(events, added...), Revision: log.Revision}
+}
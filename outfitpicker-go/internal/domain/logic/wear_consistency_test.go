@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func wearConsistencyFixture() (entities.OutfitCache, entities.WearLog) {
+	day := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	log := entities.WearLog{Events: []entities.WearEvent{
+		{Category: "casual", FileName: "old.avatar", WornAt: day},
+		{Category: "casual", FileName: "last.avatar", WornAt: day, CompletedRotation: true},
+		{Category: "casual", FileName: "tee.avatar", WornAt: day.AddDate(0, 0, 1)},
+		{Category: "casual", FileName: "jeans.avatar", WornAt: day.AddDate(0, 0, 2)},
+		{Category: "formal", FileName: "suit.avatar", WornAt: day.AddDate(0, 0, 2)},
+	}}
+	cache := entities.NewOutfitCache().
+		Updating("casual", entities.NewCategoryCache(4).Adding("tee.avatar").Adding("hoodie.avatar")).
+		Updating("formal", entities.NewCategoryCache(2).Adding("suit.avatar"))
+	return cache, log
+}
+
+func TestWearDiscrepancies(t *testing.T) {
+	cache, log := wearConsistencyFixture()
+
+	got := WearDiscrepancies(cache, log)
+	want := []entities.WearDiscrepancy{
+		{Category: "casual", FileName: "hoodie.avatar", Kind: entities.DiscrepancyCacheOnly},
+		{Category: "casual", FileName: "jeans.avatar", Kind: entities.DiscrepancyHistoryOnly},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("WearDiscrepancies() = %+v, want %+v", got, want)
+	}
+	if got := WearDiscrepancies(entities.NewOutfitCache(), entities.WearLog{}); len(got) != 0 {
+		t.Errorf("WearDiscrepancies() of nothing = %+v, want none", got)
+	}
+}
+
+func TestHistoryMatchingCache(t *testing.T) {
+	cache, log := wearConsistencyFixture()
+	now := time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)
+
+	repaired := HistoryMatchingCache(log, WearDiscrepancies(cache, log), now)
+
+	if got := WearDiscrepancies(cache, repaired); len(got) != 0 {
+		t.Errorf("discrepancies after repair = %+v, want none", got)
+	}
+	if len(repaired.Events) != 5 {
+		t.Fatalf("repaired events = %+v, want jeans dropped and hoodie added", repaired.Events)
+	}
+	added := repaired.Events[len(repaired.Events)-1]
+	if added.FileName != "hoodie.avatar" || !added.WornAt.Equal(now) || added.Note != RepairNote {
+		t.Errorf("added event = %+v", added)
+	}
+	if log.Events[3].FileName != "jeans.avatar" {
+		t.Error("HistoryMatchingCache() modified the original log")
+	}
+}
@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Forecast thresholds of the weather conditions.
+const (
+	// RainChance is the chance of rain, in percent, from which a day calls
+	// for entities.WeatherRain.
+	RainChance = 50
+	// HeatTemperature is the highest temperature, in degrees Celsius, from
+	// which a day calls for entities.WeatherHeat.
+	HeatTemperature = 27.0
+	// ColdTemperature is the highest temperature, in degrees Celsius, up to
+	// which a day calls for entities.WeatherCold.
+	ColdTemperature = 8.0
+)
+
+// ForecastConditions returns the weather conditions a forecast calls for,
+// in the order rain, heat, cold.
+func ForecastConditions(forecast entities.Forecast) []string {
+	var conditions []string
+	if forecast.PrecipitationChance >= RainChance {
+		conditions = append(conditions, entities.WeatherRain)
+	}
+	if forecast.MaxTemperature >= HeatTemperature {
+		conditions = append(conditions, entities.WeatherHeat)
+	}
+	if forecast.MaxTemperature <= ColdTemperature {
+		conditions = append(conditions, entities.WeatherCold)
+	}
+	return conditions
+}
+
+// UnsuitableFor returns those of conditions that unsuitable lists.
+func UnsuitableFor(unsuitable, conditions []string) []string {
+	var matched []string
+	for _, condition := range conditions {
+		if slices.Contains(unsuitable, condition) {
+			matched = append(matched, condition)
+		}
+	}
+	return matched
+}
+
+// SuitsWeather keeps the outfits of category none of whose tags is
+// unsuitable for the conditions.
+func SuitsWeather(settings entities.WeatherSettings, index entities.MetadataIndex, category string, conditions []string) OutfitFilter {
+	return func(file entities.FileEntry) bool {
+		return !slices.ContainsFunc(index.TagsOf(category, file.FileName), func(tag string) bool {
+			return len(UnsuitableFor(settings.Tags[tag], conditions)) > 0
+		})
+	}
+}
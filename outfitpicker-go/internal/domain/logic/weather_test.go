@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestForecastConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		forecast entities.Forecast
+		want     []string
+	}{
+		{"mild and dry", entities.Forecast{MinTemperature: 10, MaxTemperature: 18, PrecipitationChance: 20}, nil},
+		{"hot and stormy", entities.Forecast{MinTemperature: 20, MaxTemperature: 31, PrecipitationChance: 70}, []string{"rain", "heat"}},
+		{"cold", entities.Forecast{MinTemperature: -3, MaxTemperature: 4}, []string{"cold"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ForecastConditions(tt.forecast); !slices.Equal(got, tt.want) {
+				t.Errorf("ForecastConditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuitsWeather(t *testing.T) {
+	settings := entities.WeatherSettings{}.
+		SettingCategory("beach", []string{"rain", "cold"}).
+		SettingTag("wool", []string{"heat"}).
+		SettingTag("suede", []string{"rain"})
+
+	if got := UnsuitableFor(settings.Categories["beach"], []string{"rain", "heat"}); !slices.Equal(got, []string{"rain"}) {
+		t.Errorf("UnsuitableFor(beach) = %v, want [rain]", got)
+	}
+
+	index := entities.NewMetadataIndex().
+		Setting("casual", "jumper.avatar", entities.OutfitMetadata{Tags: []string{"wool"}}).
+		Setting("casual", "boots.avatar", entities.OutfitMetadata{Tags: []string{"favorite", "suede"}})
+	files := []entities.FileEntry{
+		entities.NewFileEntry("/wardrobe/casual/jumper.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/boots.avatar"),
+		entities.NewFileEntry("/wardrobe/casual/tee.avatar"),
+	}
+	got := FilterAvailableOutfits(files, nil, SuitsWeather(settings, index, "casual", []string{"heat"}))
+	if len(got) != 2 || got[0].FileName != "boots.avatar" {
+		t.Errorf("SuitsWeather(heat) kept %v, want everything but jumper.avatar", got)
+	}
+}
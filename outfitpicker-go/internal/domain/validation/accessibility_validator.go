@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var progressStyles = []string{"bar", "pattern", "badge"}
+
+// ProgressStyles returns the supported progress style names.
+func ProgressStyles() []string {
+	return slices.Clone(progressStyles)
+}
+
+// ValidateAccessibility accepts a known progress style, or none.
+func ValidateAccessibility(progressStyle string) error {
+	if progressStyle != "" && !slices.Contains(progressStyles, progressStyle) {
+		return errors.ErrInvalidAccessibility
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package validation
+
+import "testing"
+
+func TestValidateAccessibility(t *testing.T) {
+	tests := []struct {
+		name          string
+		progressStyle string
+		wantErr       bool
+	}{
+		{"default", "", false},
+		{"bar", "bar", false},
+		{"pattern", "pattern", false},
+		{"badge", "badge", false},
+		{"unknown style", "rainbow", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateAccessibility(tt.progressStyle); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAccessibility() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
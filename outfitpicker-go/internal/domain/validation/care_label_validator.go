@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var recognizedFibers = []string{
+	"acrylic", "alpaca", "cashmere", "cotton", "down", "elastane", "hemp",
+	"leather", "linen", "lyocell", "modal", "mohair", "nylon", "polyester",
+	"silk", "viscose", "wool",
+}
+
+// careSymbolFamilies groups the recognized care-label symbols. A label may
+// carry at most one symbol from each family.
+var careSymbolFamilies = []struct {
+	name    string
+	symbols []string
+}{
+	{"wash", []string{"wash-30", "wash-40", "wash-60", "wash-95", "hand-wash", "do-not-wash"}},
+	{"bleach", []string{"bleach", "non-chlorine-bleach", "do-not-bleach"}},
+	{"tumble dry", []string{"tumble-dry-low", "tumble-dry-normal", "do-not-tumble-dry"}},
+	{"natural dry", []string{"line-dry", "dry-flat"}},
+	{"iron", []string{"iron-low", "iron-medium", "iron-high", "do-not-iron"}},
+	{"professional", []string{"dry-clean", "do-not-dry-clean"}},
+}
+
+// ValidateFiber accepts one of the recognized fiber names.
+func ValidateFiber(fiber string) error {
+	if slices.Contains(recognizedFibers, fiber) {
+		return nil
+	}
+	return errors.NewInvalidInputError(fmt.Sprintf("unknown fiber %q (want one of: %s)", fiber, strings.Join(recognizedFibers, ", ")))
+}
+
+// ValidateCareSymbols accepts recognized care-label symbols with at most one
+// symbol from each family, so a label cannot say both "wash-30" and
+// "do-not-wash".
+func ValidateCareSymbols(symbols []string) error {
+	seen := make(map[string]string, len(symbols))
+	for _, symbol := range symbols {
+		family := CareSymbolFamily(symbol)
+		if family == "" {
+			return errors.NewInvalidInputError(fmt.Sprintf("unknown care symbol %q (want one of: %s)", symbol, strings.Join(RecognizedCareSymbols(), ", ")))
+		}
+		if previous, ok := seen[family]; ok {
+			return errors.NewInvalidInputError(fmt.Sprintf("care symbols %q and %q conflict", previous, symbol))
+		}
+		seen[family] = symbol
+	}
+	return nil
+}
+
+// CareSymbolFamily returns the family a care symbol belongs to, or "" if the
+// symbol is not recognized.
+func CareSymbolFamily(symbol string) string {
+	for _, family := range careSymbolFamilies {
+		if slices.Contains(family.symbols, symbol) {
+			return family.name
+		}
+	}
+	return ""
+}
+
+// RecognizedFibers returns the supported fiber names.
+func RecognizedFibers() []string {
+	return recognizedFibers
+}
+
+// RecognizedCareSymbols returns every supported care symbol, grouped by
+// family.
+func RecognizedCareSymbols() []string {
+	var symbols []string
+	for _, family := range careSymbolFamilies {
+		symbols = append(symbols, family.symbols...)
+	}
+	return symbols
+}
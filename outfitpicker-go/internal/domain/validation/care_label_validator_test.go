@@ -0,0 +1,53 @@
+package validation
+
+import "testing"
+
+func TestValidateFiber(t *testing.T) {
+	tests := []struct {
+		fiber   string
+		wantErr bool
+	}{
+		{"cotton", false},
+		{"wool", false},
+		{"Cotton", true},
+		{"denim", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fiber, func(t *testing.T) {
+			if err := ValidateFiber(tt.fiber); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFiber(%q) error = %v, wantErr %v", tt.fiber, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCareSymbols(t *testing.T) {
+	tests := []struct {
+		name    string
+		symbols []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"one per family", []string{"wash-40", "do-not-bleach", "do-not-tumble-dry", "dry-flat", "iron-low"}, false},
+		{"unknown", []string{"wash-35"}, true},
+		{"conflicting wash", []string{"wash-30", "do-not-wash"}, true},
+		{"duplicate", []string{"iron-low", "iron-low"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCareSymbols(tt.symbols); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCareSymbols(%v) error = %v, wantErr %v", tt.symbols, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCareSymbolFamily(t *testing.T) {
+	if got := CareSymbolFamily("hand-wash"); got != "wash" {
+		t.Errorf("CareSymbolFamily(hand-wash) = %q, want wash", got)
+	}
+	if got := CareSymbolFamily("spin"); got != "" {
+		t.Errorf("CareSymbolFamily(spin) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxCategoryDescriptionLength is the longest category description, in
+// characters.
+const MaxCategoryDescriptionLength = 280
+
+// ValidateCategoryDescription accepts a description of up to
+// MaxCategoryDescriptionLength characters, a label as ValidateCategoryNames
+// accepts one, well-formed default tags and a slot written like a tag. Any
+// of them may be empty.
+func ValidateCategoryDescription(description, label string, tags []string, slot string) error {
+	if utf8.RuneCountInString(description) > MaxCategoryDescriptionLength {
+		return errors.ErrInvalidCategoryDescription
+	}
+	if label != "" && validateCategoryLabel(label) != nil {
+		return errors.ErrInvalidCategoryDescription
+	}
+	if ValidateTags(tags) != nil {
+		return errors.ErrInvalidCategoryDescription
+	}
+	if slot != "" && ValidateTags([]string{slot}) != nil {
+		return errors.ErrInvalidCategoryDescription
+	}
+	return nil
+}
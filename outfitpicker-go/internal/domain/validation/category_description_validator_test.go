@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateCategoryDescription(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		label       string
+		tags        []string
+		slot        string
+		wantErr     bool
+	}{
+		{"empty", "", "", nil, "", false},
+		{"everything", "Weekend clothes", "Weekend", []string{"relaxed"}, "top", false},
+		{"description too long", strings.Repeat("a", MaxCategoryDescriptionLength+1), "", nil, "", true},
+		{"blank label", "", "  ", nil, "", true},
+		{"malformed tag", "", "", []string{"Date Night"}, "", true},
+		{"repeated tag", "", "", []string{"relaxed", "relaxed"}, "", true},
+		{"malformed slot", "", "", nil, "Top Layer", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCategoryDescription(tt.description, tt.label, tt.tags, tt.slot)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidCategoryDescription) {
+				t.Errorf("ValidateCategoryDescription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxCategoryLabelLength is the longest label or alias, in characters.
+const MaxCategoryLabelLength = 64
+
+// ValidateCategoryNames accepts labels keyed by a supported language code and
+// aliases that are neither blank nor longer than MaxCategoryLabelLength.
+func ValidateCategoryNames(labels map[string]string, aliases []string) error {
+	for language, label := range labels {
+		if !IsLanguageSupported(language) {
+			return errors.ErrInvalidLabel
+		}
+		if err := validateCategoryLabel(label); err != nil {
+			return err
+		}
+	}
+	for _, alias := range aliases {
+		if err := validateCategoryLabel(alias); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateCategoryLabel(label string) error {
+	if strings.TrimSpace(label) == "" || utf8.RuneCountInString(label) > MaxCategoryLabelLength {
+		return errors.ErrInvalidLabel
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateCategoryNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		aliases []string
+		wantErr bool
+	}{
+		{"empty", nil, nil, false},
+		{"label and alias", map[string]string{"fr": "décontracté"}, []string{"chill"}, false},
+		{"unsupported language", map[string]string{"xx": "casual"}, nil, true},
+		{"blank label", map[string]string{"fr": "  "}, nil, true},
+		{"blank alias", nil, []string{""}, true},
+		{"alias too long", nil, []string{strings.Repeat("é", MaxCategoryLabelLength+1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCategoryNames(tt.labels, tt.aliases)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidLabel) {
+				t.Errorf("ValidateCategoryNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var categorySorts = []string{"name", "manual", "health", "progress"}
+
+// CategorySorts returns the supported category sort names.
+func CategorySorts() []string {
+	return slices.Clone(categorySorts)
+}
+
+// ValidateCategoryOrder accepts a known sort, or none, and a list of
+// categories, each named once, that only the manual sort may have.
+func ValidateCategoryOrder(sort string, manual []string) error {
+	if sort != "" && !slices.Contains(categorySorts, sort) {
+		return errors.ErrInvalidCategoryOrder
+	}
+	if sort != "manual" && len(manual) > 0 {
+		return errors.ErrInvalidCategoryOrder
+	}
+	for i, category := range manual {
+		if strings.TrimSpace(category) == "" || slices.Contains(manual[:i], category) {
+			return errors.ErrInvalidCategoryOrder
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package validation
+
+import "testing"
+
+func TestValidateCategoryOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    string
+		manual  []string
+		wantErr bool
+	}{
+		{"default", "", nil, false},
+		{"health", "health", nil, false},
+		{"manual", "manual", []string{"work", "casual"}, false},
+		{"unknown sort", "size", nil, true},
+		{"list without manual sort", "name", []string{"work"}, true},
+		{"category listed twice", "manual", []string{"work", "work"}, true},
+		{"blank category", "manual", []string{" "}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategoryOrder(tt.sort, tt.manual); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
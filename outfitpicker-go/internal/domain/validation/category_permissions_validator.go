@@ -0,0 +1,25 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ValidateCategoryPermissions accepts lists of categories, each named
+// once, that a profile may pick from and reset. A nil list allows every
+// category; an empty one is rejected as it would read back as nil.
+func ValidateCategoryPermissions(pick, reset []string) error {
+	for _, categories := range [][]string{pick, reset} {
+		if categories != nil && len(categories) == 0 {
+			return errors.ErrInvalidPermissions
+		}
+		for i, category := range categories {
+			if strings.TrimSpace(category) == "" || slices.Contains(categories[:i], category) {
+				return errors.ErrInvalidPermissions
+			}
+		}
+	}
+	return nil
+}
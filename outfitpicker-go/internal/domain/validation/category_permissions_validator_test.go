@@ -0,0 +1,25 @@
+package validation
+
+import "testing"
+
+func TestValidateCategoryPermissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		pick    []string
+		reset   []string
+		wantErr bool
+	}{
+		{"unrestricted", nil, nil, false},
+		{"restricted", []string{"casual", "work"}, []string{"casual"}, false},
+		{"category listed twice", []string{"work", "work"}, nil, true},
+		{"blank category", nil, []string{" "}, true},
+		{"empty list", []string{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategoryPermissions(tt.pick, tt.reset); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// EmojiWidth is the display width every category emoji must have so category
+// tables stay aligned.
+const EmojiWidth = 2
+
+var namedColors = []string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ValidateCategoryDecoration validates the emoji and color assigned to a
+// category. Either may be empty.
+func ValidateCategoryDecoration(emoji, color string) error {
+	if err := ValidateEmoji(emoji); err != nil {
+		return err
+	}
+	return ValidateColor(color)
+}
+
+// ValidateEmoji accepts a single emoji, optionally followed by the emoji
+// variation selector, that renders exactly two cells wide. Sequences such as
+// flags, skin tones and ZWJ combinations are rejected because terminals
+// disagree on their width.
+func ValidateEmoji(emoji string) error {
+	if emoji == "" {
+		return nil
+	}
+	base := strings.TrimSuffix(emoji, string(variationSelectorEmoji))
+	if utf8.RuneCountInString(base) != 1 || DisplayWidth(emoji) != EmojiWidth {
+		return errors.ErrInvalidDecoration
+	}
+	return nil
+}
+
+// ValidateColor accepts one of the named terminal colors or a #RRGGBB value.
+func ValidateColor(color string) error {
+	if color == "" || IsNamedColor(color) || hexColorPattern.MatchString(color) {
+		return nil
+	}
+	return errors.ErrInvalidDecoration
+}
+
+// IsNamedColor reports whether color is one of the named terminal colors.
+func IsNamedColor(color string) bool {
+	for _, named := range namedColors {
+		if named == color {
+			return true
+		}
+	}
+	return false
+}
+
+// NamedColors returns the supported color names.
+func NamedColors() []string {
+	return namedColors
+}
@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{"ascii", "casual", 6},
+		{"accented", "décontracté", 11},
+		{"combining accent", "dé", 2},
+		{"cjk", "休闲", 4},
+		{"emoji", "👕", 2},
+		{"symbol with variation selector", "☀️", 2},
+		{"symbol without variation selector", "☀", 1},
+		{"emoji and text", "👕 casual", 9},
+		{"empty", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayWidth(tt.s); got != tt.want {
+				t.Errorf("DisplayWidth(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEmoji(t *testing.T) {
+	tests := []struct {
+		name    string
+		emoji   string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"single emoji", "👕", false},
+		{"symbol with variation selector", "☀️", false},
+		{"narrow symbol", "☀", true},
+		{"plain text", "ab", true},
+		{"two emoji", "👕👖", true},
+		{"zwj sequence", "👩‍💻", true},
+		{"skin tone", "👋🏽", true},
+		{"flag", "🇫🇷", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmoji(tt.emoji)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEmoji(%q) error = %v, wantErr %v", tt.emoji, err, tt.wantErr)
+			}
+			if err != nil && err != errors.ErrInvalidDecoration {
+				t.Errorf("ValidateEmoji(%q) error = %v, want ErrInvalidDecoration", tt.emoji, err)
+			}
+		})
+	}
+}
+
+func TestValidateColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		wantErr bool
+	}{
+		{"", false},
+		{"blue", false},
+		{"#1e90ff", false},
+		{"#1E90FF", false},
+		{"navy", true},
+		{"#12345", true},
+		{"1e90ff", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			if err := ValidateColor(tt.color); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateColor(%q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCategoryDecoration(t *testing.T) {
+	if err := ValidateCategoryDecoration("👕", "blue"); err != nil {
+		t.Errorf("ValidateCategoryDecoration() error = %v", err)
+	}
+	if err := ValidateCategoryDecoration("👕", "navy"); err == nil {
+		t.Error("ValidateCategoryDecoration() accepted invalid color")
+	}
+	if err := ValidateCategoryDecoration("x", "blue"); err == nil {
+		t.Error("ValidateCategoryDecoration() accepted invalid emoji")
+	}
+}
@@ -0,0 +1,67 @@
+package validation
+
+import "unicode"
+
+const variationSelectorEmoji = '\uFE0F'
+
+// wideRanges lists code point ranges that terminals render two cells wide.
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK radicals and punctuation
+	{0x3041, 0x33FF},   // Kana and CJK compatibility
+	{0x3400, 0x4DBF},   // CJK extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Pictographs and emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F900, 0x1FAFF}, // Supplemental symbols and pictographs
+	{0x20000, 0x3FFFD}, // CJK extensions B and beyond
+}
+
+// DisplayWidth returns the number of terminal cells s occupies. Combining
+// marks and zero-width characters take no space, wide characters take two,
+// and a narrow symbol followed by the emoji variation selector is widened to
+// two cells.
+func DisplayWidth(s string) int {
+	width := 0
+	previous := 0
+	for _, r := range s {
+		if r == variationSelectorEmoji && previous == 1 {
+			width++
+			previous = 2
+			continue
+		}
+		previous = runeWidth(r)
+		width += previous
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0xFE00 && r <= 0xFE0F:
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+func isWide(r rune) bool {
+	for _, wide := range wideRanges {
+		if r >= wide.lo && r <= wide.hi {
+			return true
+		}
+	}
+	return false
+}
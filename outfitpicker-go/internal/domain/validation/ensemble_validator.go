@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxEnsembleColors is the highest cap on the colors of an ensemble.
+const MaxEnsembleColors = 10
+
+// ValidateEnsemble accepts ensemble settings whose slots, keyed by
+// category, are named like tags, whose tag conflicts and color clashes
+// pair tags and colors, and whose color cap is zero for none or up to
+// MaxEnsembleColors.
+func ValidateEnsemble(slots map[string]string, tagConflicts, colorClashes [][2]string, maxColors int) error {
+	for category, slot := range slots {
+		if category == "" {
+			return errors.NewInvalidInputError("ensemble slots need a category")
+		}
+		if err := validateLabels("slot", []string{slot}); err != nil {
+			return err
+		}
+	}
+	for _, pair := range tagConflicts {
+		if err := validatePair("tag", pair); err != nil {
+			return err
+		}
+	}
+	for _, pair := range colorClashes {
+		if err := validatePair("color", pair); err != nil {
+			return err
+		}
+	}
+	if maxColors < 0 || maxColors > MaxEnsembleColors {
+		return errors.NewInvalidInputError(fmt.Sprintf("the most colors of an ensemble must be between 1 and %d, or 0 for no limit, got %d", MaxEnsembleColors, maxColors))
+	}
+	return nil
+}
+
+// validatePair checks both labels of a pair. A label may be paired with
+// itself.
+func validatePair(kind string, pair [2]string) error {
+	if err := validateLabels(kind, pair[:1]); err != nil {
+		return err
+	}
+	return validateLabels(kind, pair[1:])
+}
+
+// ValidatePalette accepts a palette of color rules: a name written like a
+// tag for at least one color.
+func ValidatePalette(name string, colors []string) error {
+	if err := validateLabels("palette", []string{name}); err != nil {
+		return err
+	}
+	if len(colors) == 0 {
+		return errors.NewInvalidInputError(fmt.Sprintf("palette %q needs at least one color", name))
+	}
+	return ValidateColors(colors)
+}
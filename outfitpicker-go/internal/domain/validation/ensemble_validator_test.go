@@ -0,0 +1,51 @@
+package validation
+
+import "testing"
+
+func TestValidateEnsemble(t *testing.T) {
+	tests := []struct {
+		name         string
+		slots        map[string]string
+		tagConflicts [][2]string
+		colorClashes [][2]string
+		maxColors    int
+		wantErr      bool
+	}{
+		{"none", nil, nil, nil, 0, false},
+		{"valid", map[string]string{"shirts": "top"}, [][2]string{{"formal", "sporty"}, {"pattern", "pattern"}}, [][2]string{{"red", "pink"}}, 3, false},
+		{"bad slot", map[string]string{"shirts": "Top"}, nil, nil, 0, true},
+		{"empty category", map[string]string{"": "top"}, nil, nil, 0, true},
+		{"bad tag", nil, [][2]string{{"formal", ""}}, nil, 0, true},
+		{"bad color", nil, nil, [][2]string{{"Red", "pink"}}, 0, true},
+		{"negative colors", nil, nil, nil, -1, true},
+		{"too many colors", nil, nil, nil, MaxEnsembleColors + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateEnsemble(tt.slots, tt.tagConflicts, tt.colorClashes, tt.maxColors); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEnsemble() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePalette(t *testing.T) {
+	tests := []struct {
+		name    string
+		palette string
+		colors  []string
+		wantErr bool
+	}{
+		{"valid", "warm", []string{"red", "orange"}, false},
+		{"bad name", "Warm", []string{"red"}, true},
+		{"no colors", "warm", nil, true},
+		{"bad color", "warm", []string{"red", "red"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePalette(tt.palette, tt.colors); (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePalette() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
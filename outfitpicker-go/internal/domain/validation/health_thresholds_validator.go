@@ -0,0 +1,20 @@
+package validation
+
+import "github.com/dh85/outfitpicker/internal/domain/errors"
+
+// MaxHealthDays bounds the day-based health thresholds.
+const MaxHealthDays = 3650
+
+// ValidateHealthThresholds requires 0 < critical < healthy <= 100 and day
+// counts between 1 and MaxHealthDays.
+func ValidateHealthThresholds(healthy, critical, staleAfterDays, targetRotationDays int) error {
+	if critical <= 0 || critical >= healthy || healthy > 100 {
+		return errors.ErrInvalidHealthThresholds
+	}
+	for _, days := range []int{staleAfterDays, targetRotationDays} {
+		if days < 1 || days > MaxHealthDays {
+			return errors.ErrInvalidHealthThresholds
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateHealthThresholds(t *testing.T) {
+	tests := []struct {
+		name                    string
+		healthy, critical       int
+		staleDays, rotationDays int
+		wantErr                 bool
+	}{
+		{"defaults", 70, 40, 30, 60, false},
+		{"bounds", 100, 1, 1, MaxHealthDays, false},
+		{"critical above healthy", 50, 60, 30, 60, true},
+		{"critical equals healthy", 50, 50, 30, 60, true},
+		{"healthy over 100", 101, 40, 30, 60, true},
+		{"zero critical", 70, 0, 30, 60, true},
+		{"zero stale days", 70, 40, 0, 60, true},
+		{"rotation too long", 70, 40, 30, MaxHealthDays + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHealthThresholds(tt.healthy, tt.critical, tt.staleDays, tt.rotationDays)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidHealthThresholds) {
+				t.Errorf("ValidateHealthThresholds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"path"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ValidateIgnorePatterns accepts gitignore-style patterns: globs as
+// understood by path.Match, optionally negated with a leading ! and limited
+// to directories with a trailing /.
+func ValidateIgnorePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		glob := strings.Trim(strings.TrimPrefix(pattern, "!"), "/")
+		if strings.TrimSpace(glob) == "" {
+			return errors.ErrInvalidPattern
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return errors.ErrInvalidPattern
+		}
+	}
+	return nil
+}
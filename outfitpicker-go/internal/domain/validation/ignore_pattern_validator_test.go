@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{"none", nil, false},
+		{"globs", []string{"*.bak", "drafts", "old-?.avatar"}, false},
+		{"gitignore syntax", []string{"!keep.avatar", "drafts/", "/casual/**/*.bak"}, false},
+		{"blank", []string{" "}, true},
+		{"bare negation", []string{"!"}, true},
+		{"unterminated class", []string{"[a-"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIgnorePatterns(tt.patterns)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidPattern) {
+				t.Errorf("ValidateIgnorePatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// ValidateOccasion accepts an occasion named like a tag, lowercase with up
+// to MaxTagLength characters and no spaces or commas, with at least one
+// category or tag. Its categories must be named and its tags well formed,
+// each listed once.
+func ValidateOccasion(name string, categories, tags []string) error {
+	if name == "" || utf8.RuneCountInString(name) > MaxTagLength ||
+		name != strings.ToLower(name) || strings.ContainsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return errors.NewInvalidInputError(fmt.Sprintf("occasion name %q must be lowercase, without spaces or commas and at most %d characters", name, MaxTagLength))
+	}
+	if len(categories) == 0 && len(tags) == 0 {
+		return errors.NewInvalidInputError(fmt.Sprintf("occasion %q needs categories, tags or both", name))
+	}
+	for i, category := range categories {
+		if strings.TrimSpace(category) == "" {
+			return errors.NewInvalidInputError("occasion categories cannot be empty")
+		}
+		if slices.Contains(categories[:i], category) {
+			return errors.NewInvalidInputError(fmt.Sprintf("category %q listed more than once", category))
+		}
+	}
+	return ValidateTags(tags)
+}
@@ -0,0 +1,31 @@
+package validation
+
+import "testing"
+
+func TestValidateOccasion(t *testing.T) {
+	tests := []struct {
+		name       string
+		occasion   string
+		categories []string
+		tags       []string
+		wantErr    bool
+	}{
+		{"categories and tags", "interview", []string{"work", "formal"}, []string{"smart"}, false},
+		{"tags only", "date-night", nil, []string{"dressy"}, false},
+		{"categories only", "gym", []string{"sport"}, nil, false},
+		{"empty name", "", []string{"work"}, nil, true},
+		{"uppercase name", "Interview", []string{"work"}, nil, true},
+		{"name with spaces", "date night", []string{"work"}, nil, true},
+		{"nothing to pick from", "interview", nil, nil, true},
+		{"blank category", "interview", []string{" "}, nil, true},
+		{"repeated category", "interview", []string{"work", "work"}, nil, true},
+		{"invalid tag", "interview", nil, []string{"Smart"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateOccasion(tt.occasion, tt.categories, tt.tags); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOccasion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
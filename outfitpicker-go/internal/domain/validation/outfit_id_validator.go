@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var outfitIDSchemes = []string{"uuid", "hash"}
+
+// OutfitIDSchemes returns the supported outfit ID scheme names.
+func OutfitIDSchemes() []string {
+	return slices.Clone(outfitIDSchemes)
+}
+
+// ValidateOutfitIDScheme accepts a known outfit ID scheme, or none.
+func ValidateOutfitIDScheme(scheme string) error {
+	if scheme != "" && !slices.Contains(outfitIDSchemes, scheme) {
+		return errors.ErrInvalidOutfitIDs
+	}
+	return nil
+}
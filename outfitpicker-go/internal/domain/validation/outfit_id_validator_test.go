@@ -0,0 +1,20 @@
+package validation
+
+import "testing"
+
+func TestValidateOutfitIDScheme(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		wantErr bool
+	}{
+		{"", false},
+		{"uuid", false},
+		{"hash", false},
+		{"serial", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateOutfitIDScheme(tt.scheme); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateOutfitIDScheme(%q) error = %v, wantErr %v", tt.scheme, err, tt.wantErr)
+		}
+	}
+}
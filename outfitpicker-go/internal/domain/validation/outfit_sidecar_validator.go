@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"slices"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxOutfitNotesLength is the longest note a sidecar file may keep about an
+// outfit, in characters.
+const MaxOutfitNotesLength = 1000
+
+// ValidateOutfitSidecar accepts well-formed tags and colors, distinct
+// season names, a weight ValidateOutfitWeight accepts and notes of up to
+// MaxOutfitNotesLength characters. Any of them may be empty, and a nil
+// weight is unset.
+func ValidateOutfitSidecar(tags, colors, seasons []string, weight *float64, notes string) error {
+	if ValidateTags(tags) != nil || ValidateColors(colors) != nil {
+		return errors.ErrInvalidOutfitSidecar
+	}
+	for i, season := range seasons {
+		if ValidateSeason(season) != nil || slices.Contains(seasons[:i], season) {
+			return errors.ErrInvalidOutfitSidecar
+		}
+	}
+	if weight != nil && ValidateOutfitWeight(*weight) != nil {
+		return errors.ErrInvalidOutfitSidecar
+	}
+	if utf8.RuneCountInString(notes) > MaxOutfitNotesLength {
+		return errors.ErrInvalidOutfitSidecar
+	}
+	return nil
+}
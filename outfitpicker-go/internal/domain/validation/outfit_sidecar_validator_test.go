@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateOutfitSidecar(t *testing.T) {
+	weight := func(w float64) *float64 { return &w }
+	tests := []struct {
+		name    string
+		tags    []string
+		colors  []string
+		seasons []string
+		weight  *float64
+		notes   string
+		wantErr bool
+	}{
+		{"empty", nil, nil, nil, nil, "", false},
+		{"everything", []string{"relaxed"}, []string{"navy"}, []string{"summer", "spring"}, weight(2), "Dry flat", false},
+		{"zero weight", nil, nil, nil, weight(0), "", false},
+		{"malformed tag", []string{"Date Night"}, nil, nil, nil, "", true},
+		{"malformed color", nil, []string{"Navy Blue"}, nil, nil, "", true},
+		{"unknown season", nil, nil, []string{"monsoon"}, nil, "", true},
+		{"repeated season", nil, nil, []string{"summer", "summer"}, nil, "", true},
+		{"negative weight", nil, nil, nil, weight(-1), "", true},
+		{"weight too large", nil, nil, nil, weight(MaxOutfitWeight + 1), "", true},
+		{"notes too long", nil, nil, nil, nil, strings.Repeat("a", MaxOutfitNotesLength+1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOutfitSidecar(tt.tags, tt.colors, tt.seasons, tt.weight, tt.notes)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidOutfitSidecar) {
+				t.Errorf("ValidateOutfitSidecar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
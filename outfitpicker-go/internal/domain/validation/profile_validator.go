@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxProfileNameLength is the longest profile name accepted.
+const MaxProfileNameLength = 32
+
+// ValidateProfileName accepts names of up to MaxProfileNameLength lowercase
+// letters, digits, dashes and underscores that start with a letter or
+// digit, so every name is also a safe directory name.
+func ValidateProfileName(name string) error {
+	if name == "" {
+		return errors.NewInvalidInputError("profile name cannot be empty")
+	}
+	if len(name) > MaxProfileNameLength {
+		return errors.NewInvalidInputError(fmt.Sprintf("profile name %q is longer than %d characters", name, MaxProfileNameLength))
+	}
+	valid := strings.Trim(name, "abcdefghijklmnopqrstuvwxyz0123456789-_") == "" && strings.IndexAny(name[:1], "-_") < 0
+	if !valid {
+		return errors.NewInvalidInputError(fmt.Sprintf("profile name %q must be lowercase letters, digits, dashes and underscores, starting with a letter or digit", name))
+	}
+	return nil
+}
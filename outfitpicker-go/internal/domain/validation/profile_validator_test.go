@@ -0,0 +1,30 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"travel", false},
+		{"summer-2024_beach", false},
+		{"9to5", false},
+		{strings.Repeat("a", MaxProfileNameLength), false},
+		{"", true},
+		{strings.Repeat("a", MaxProfileNameLength+1), true},
+		{"Travel", true},
+		{"-travel", true},
+		{"_travel", true},
+		{"../travel", true},
+		{"my travel", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateProfileName(tt.name); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateProfileName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
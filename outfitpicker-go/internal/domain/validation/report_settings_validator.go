@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var reportFormats = []string{"text", "html"}
+
+// ValidateReportFormat accepts a known report format, or none.
+func ValidateReportFormat(format string) error {
+	if format != "" && !slices.Contains(reportFormats, format) {
+		return errors.ErrInvalidReportSettings
+	}
+	return nil
+}
+
+// ValidateSMTPSettings requires a host, a port between 1 and 65535, a valid
+// sender and at least one valid recipient.
+func ValidateSMTPSettings(host string, port int, from string, to []string) error {
+	if strings.TrimSpace(host) == "" || port < 1 || port > 65535 || len(to) == 0 {
+		return errors.ErrInvalidReportSettings
+	}
+	for _, address := range append([]string{from}, to...) {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return errors.ErrInvalidReportSettings
+		}
+	}
+	return nil
+}
+
+// ReportFormats returns the supported report formats.
+func ReportFormats() []string {
+	return reportFormats
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestValidateReportFormat(t *testing.T) {
+	for _, format := range []string{"", "text", "html"} {
+		if err := ValidateReportFormat(format); err != nil {
+			t.Errorf("ValidateReportFormat(%q) error = %v", format, err)
+		}
+	}
+	if err := ValidateReportFormat("pdf"); !errors.Is(err, domainerrors.ErrInvalidReportSettings) {
+		t.Errorf("ValidateReportFormat(pdf) error = %v", err)
+	}
+}
+
+func TestValidateSMTPSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    string
+		port    int
+		from    string
+		to      []string
+		wantErr bool
+	}{
+		{"valid", "smtp.example.com", 587, "Outfits <me@example.com>", []string{"me@example.com"}, false},
+		{"missing host", " ", 587, "me@example.com", []string{"me@example.com"}, true},
+		{"bad port", "smtp.example.com", 0, "me@example.com", []string{"me@example.com"}, true},
+		{"no recipients", "smtp.example.com", 25, "me@example.com", nil, true},
+		{"bad sender", "smtp.example.com", 25, "me", []string{"me@example.com"}, true},
+		{"bad recipient", "smtp.example.com", 25, "me@example.com", []string{"you"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSMTPSettings(tt.host, tt.port, tt.from, tt.to)
+			if tt.wantErr != errors.Is(err, domainerrors.ErrInvalidReportSettings) {
+				t.Errorf("ValidateSMTPSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var seasons = []string{"winter", "spring", "summer", "autumn"}
+
+// ValidateSeasonAssignments accepts categories and well-formed tags mapped to
+// known seasons, each listed once.
+func ValidateSeasonAssignments(categories, tags map[string][]string) error {
+	for category, assigned := range categories {
+		if strings.TrimSpace(category) == "" {
+			return errors.ErrInvalidSeasons
+		}
+		if err := validateSeasonList(assigned); err != nil {
+			return err
+		}
+	}
+	for tag, assigned := range tags {
+		if ValidateTags([]string{tag}) != nil {
+			return errors.ErrInvalidSeasons
+		}
+		if err := validateSeasonList(assigned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateSeason accepts a known season name.
+func ValidateSeason(season string) error {
+	if !slices.Contains(seasons, season) {
+		return errors.ErrInvalidSeasons
+	}
+	return nil
+}
+
+func validateSeasonList(assigned []string) error {
+	if len(assigned) == 0 {
+		return errors.ErrInvalidSeasons
+	}
+	for i, season := range assigned {
+		if ValidateSeason(season) != nil || slices.Contains(assigned[:i], season) {
+			return errors.ErrInvalidSeasons
+		}
+	}
+	return nil
+}
+
+// Seasons returns the supported season names in calendar order.
+func Seasons() []string {
+	return seasons
+}
@@ -0,0 +1,27 @@
+package validation
+
+import "testing"
+
+func TestValidateSeasonAssignments(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories map[string][]string
+		tags       map[string][]string
+		wantErr    bool
+	}{
+		{"none", nil, nil, false},
+		{"category and tag", map[string][]string{"knits": {"autumn", "winter"}}, map[string][]string{"beach": {"summer"}}, false},
+		{"unknown season", map[string][]string{"knits": {"fall"}}, nil, true},
+		{"season listed twice", map[string][]string{"knits": {"winter", "winter"}}, nil, true},
+		{"no seasons", map[string][]string{"knits": {}}, nil, true},
+		{"blank category", map[string][]string{" ": {"winter"}}, nil, true},
+		{"invalid tag", nil, map[string][]string{"Beach": {"summer"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSeasonAssignments(tt.categories, tt.tags); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSeasonAssignments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
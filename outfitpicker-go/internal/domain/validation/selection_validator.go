@@ -0,0 +1,188 @@
+package validation
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxFeedbackBoost caps how strongly feedback can skew weighted picks.
+const MaxFeedbackBoost = 10
+
+// MaxNewArrivalDays caps how long an outfit can count as a new arrival.
+const MaxNewArrivalDays = 365
+
+// MaxCategoryRestDays caps how long a category can rest between picks
+// across all categories.
+const MaxCategoryRestDays = 30
+
+// MaxCategoryPriority caps how much more often one category can be chosen
+// than a category without a priority.
+const MaxCategoryPriority = 100
+
+// MaxCategoryPickLimit caps the daily pick limit of a category.
+const MaxCategoryPickLimit = 100
+
+// MaxTagConstraintDays caps the period a tag constraint counts picks over.
+const MaxTagConstraintDays = 365
+
+// MaxCooldownDays caps how long an outfit can rest after it is worn, under
+// the cooldown rotation policy or the cooldown of every policy.
+const MaxCooldownDays = 365
+
+var selectionStrategies = []string{"uniform", "weighted", "rated"}
+
+var newArrivalModes = []string{"prefer", "hold"}
+
+var emptyCategoryPolicies = []string{"skip", "warn", "fail"}
+
+var categoryBalances = []string{"remaining"}
+
+var constraintSeverities = []string{"block", "downgrade"}
+
+var rotationPolicies = []string{"strict", "least-recently-worn", "cooldown", "random"}
+
+// ValidateSelectionPreferences accepts a known strategy, or none, and a
+// feedback boost between 0 and MaxFeedbackBoost.
+func ValidateSelectionPreferences(strategy string, feedbackBoost float64) error {
+	if strategy != "" && !slices.Contains(selectionStrategies, strategy) {
+		return errors.ErrInvalidSelection
+	}
+	if feedbackBoost < 0 || feedbackBoost > MaxFeedbackBoost {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateNewArrivalPolicy accepts no grace period, or a known mode with a
+// period of 1 to MaxNewArrivalDays days.
+func ValidateNewArrivalPolicy(mode string, days int) error {
+	if mode == "" {
+		if days != 0 {
+			return errors.ErrInvalidSelection
+		}
+		return nil
+	}
+	if !slices.Contains(newArrivalModes, mode) || days < 1 || days > MaxNewArrivalDays {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateEmptyCategoryPolicy accepts a known empty category policy, or none.
+func ValidateEmptyCategoryPolicy(policy string) error {
+	if policy != "" && !slices.Contains(emptyCategoryPolicies, policy) {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateCategoryRestDays accepts a rest of 0 to MaxCategoryRestDays days.
+func ValidateCategoryRestDays(days int) error {
+	if days < 0 || days > MaxCategoryRestDays {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateCategoryBalance accepts a known category balance, or none.
+func ValidateCategoryBalance(balance string) error {
+	if balance != "" && !slices.Contains(categoryBalances, balance) {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateCategoryPriorities accepts priorities from 0 to
+// MaxCategoryPriority for named categories.
+func ValidateCategoryPriorities(priorities map[string]float64) error {
+	for category, priority := range priorities {
+		if category == "" || math.IsNaN(priority) || priority < 0 || priority > MaxCategoryPriority {
+			return errors.ErrInvalidSelection
+		}
+	}
+	return nil
+}
+
+// ValidateCategoryPickLimits accepts daily pick limits from 1 to
+// MaxCategoryPickLimit for named categories.
+func ValidateCategoryPickLimits(limits map[string]int) error {
+	for category, limit := range limits {
+		if category == "" || limit < 1 || limit > MaxCategoryPickLimit {
+			return errors.ErrInvalidSelection
+		}
+	}
+	return nil
+}
+
+// ValidateLockedRotations accepts categories, each named once.
+func ValidateLockedRotations(categories []string) error {
+	for i, category := range categories {
+		if strings.TrimSpace(category) == "" || slices.Contains(categories[:i], category) {
+			return errors.ErrInvalidSelection
+		}
+	}
+	return nil
+}
+
+// ValidateTagConstraint accepts a limit of at least 0 picks of a valid tag
+// over 1 to MaxTagConstraintDays days, with a known severity or none.
+func ValidateTagConstraint(tag string, limit, days int, severity string) error {
+	if err := ValidateTags([]string{tag}); err != nil {
+		return errors.ErrInvalidSelection
+	}
+	if limit < 0 || days < 1 || days > MaxTagConstraintDays {
+		return errors.ErrInvalidSelection
+	}
+	if severity != "" && !slices.Contains(constraintSeverities, severity) {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateCooldownDays accepts a cooldown of 0 to MaxCooldownDays days.
+func ValidateCooldownDays(days int) error {
+	if days < 0 || days > MaxCooldownDays {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// ValidateRotationPolicy accepts a known rotation policy, or none. The
+// cooldown policy needs 1 to MaxCooldownDays days and no other policy takes
+// any.
+func ValidateRotationPolicy(name string, cooldownDays int) error {
+	if name != "" && !slices.Contains(rotationPolicies, name) {
+		return errors.ErrInvalidSelection
+	}
+	if name == "cooldown" {
+		if cooldownDays < 1 || cooldownDays > MaxCooldownDays {
+			return errors.ErrInvalidSelection
+		}
+	} else if cooldownDays != 0 {
+		return errors.ErrInvalidSelection
+	}
+	return nil
+}
+
+// EmptyCategoryPolicies returns the supported empty category policy names.
+func EmptyCategoryPolicies() []string {
+	return emptyCategoryPolicies
+}
+
+// NewArrivalModes returns the supported new arrival mode names.
+func NewArrivalModes() []string {
+	return newArrivalModes
+}
+
+// SelectionStrategies returns the supported strategy names.
+func SelectionStrategies() []string {
+	return selectionStrategies
+}
+
+// RotationPolicies returns the supported rotation policy names.
+func RotationPolicies() []string {
+	return rotationPolicies
+}
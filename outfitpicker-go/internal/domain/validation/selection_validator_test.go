@@ -0,0 +1,217 @@
+package validation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidateSelectionPreferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		boost    float64
+		wantErr  bool
+	}{
+		{"defaults", "", 0, false},
+		{"weighted with boost", "weighted", 0.5, false},
+		{"rated", "rated", 0, false},
+		{"maximum boost", "uniform", MaxFeedbackBoost, false},
+		{"unknown strategy", "favorites", 0, true},
+		{"negative boost", "weighted", -1, true},
+		{"excessive boost", "weighted", MaxFeedbackBoost + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSelectionPreferences(tt.strategy, tt.boost); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSelectionPreferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNewArrivalPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		days    int
+		wantErr bool
+	}{
+		{"off", "", 0, false},
+		{"prefer", "prefer", 14, false},
+		{"hold for the maximum", "hold", MaxNewArrivalDays, false},
+		{"days without a mode", "", 7, true},
+		{"unknown mode", "boost", 7, true},
+		{"no period", "prefer", 0, true},
+		{"excessive period", "hold", MaxNewArrivalDays + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNewArrivalPolicy(tt.mode, tt.days); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNewArrivalPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateEmptyCategoryPolicy(t *testing.T) {
+	for _, policy := range append([]string{""}, EmptyCategoryPolicies()...) {
+		if err := ValidateEmptyCategoryPolicy(policy); err != nil {
+			t.Errorf("ValidateEmptyCategoryPolicy(%q) error = %v", policy, err)
+		}
+	}
+	if err := ValidateEmptyCategoryPolicy("ignore"); err == nil {
+		t.Error("ValidateEmptyCategoryPolicy(\"ignore\") accepted an unknown policy")
+	}
+}
+
+func TestValidateCategoryRestDays(t *testing.T) {
+	for _, days := range []int{0, 1, MaxCategoryRestDays} {
+		if err := ValidateCategoryRestDays(days); err != nil {
+			t.Errorf("ValidateCategoryRestDays(%d) error = %v", days, err)
+		}
+	}
+	for _, days := range []int{-1, MaxCategoryRestDays + 1} {
+		if err := ValidateCategoryRestDays(days); err == nil {
+			t.Errorf("ValidateCategoryRestDays(%d) accepted an invalid rest", days)
+		}
+	}
+}
+
+func TestValidateCategoryBalance(t *testing.T) {
+	for _, balance := range []string{"", "remaining"} {
+		if err := ValidateCategoryBalance(balance); err != nil {
+			t.Errorf("ValidateCategoryBalance(%q) error = %v", balance, err)
+		}
+	}
+	if err := ValidateCategoryBalance("largest"); err == nil {
+		t.Error("ValidateCategoryBalance(\"largest\") accepted an unknown balance")
+	}
+}
+
+func TestValidateCategoryPriorities(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities map[string]float64
+		wantErr    bool
+	}{
+		{"none", nil, false},
+		{"in range", map[string]float64{"casual": 0, "work": MaxCategoryPriority}, false},
+		{"negative", map[string]float64{"casual": -1}, true},
+		{"too high", map[string]float64{"casual": MaxCategoryPriority + 1}, true},
+		{"not a number", map[string]float64{"casual": math.NaN()}, true},
+		{"unnamed category", map[string]float64{"": 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategoryPriorities(tt.priorities); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryPriorities() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCategoryPickLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  map[string]int
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"in range", map[string]int{"casual": 1, "work": MaxCategoryPickLimit}, false},
+		{"zero", map[string]int{"casual": 0}, true},
+		{"too high", map[string]int{"casual": MaxCategoryPickLimit + 1}, true},
+		{"unnamed category", map[string]int{"": 2}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCategoryPickLimits(tt.limits); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCategoryPickLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateLockedRotations(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		wantErr    bool
+	}{
+		{"none", nil, false},
+		{"locked", []string{"costumes", "formal"}, false},
+		{"locked twice", []string{"costumes", "costumes"}, true},
+		{"blank category", []string{" "}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLockedRotations(tt.categories); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateLockedRotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTagConstraint(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		limit    int
+		days     int
+		severity string
+		wantErr  bool
+	}{
+		{"blocking", "black", 2, 7, "", false},
+		{"never", "black", 0, 1, "block", false},
+		{"downgrading", "black", 2, MaxTagConstraintDays, "downgrade", false},
+		{"no tag", "", 2, 7, "", true},
+		{"invalid tag", "Black", 2, 7, "", true},
+		{"negative limit", "black", -1, 7, "", true},
+		{"no days", "black", 2, 0, "", true},
+		{"too many days", "black", 2, MaxTagConstraintDays + 1, "", true},
+		{"unknown severity", "black", 2, 7, "warn", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTagConstraint(tt.tag, tt.limit, tt.days, tt.severity); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTagConstraint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCooldownDays(t *testing.T) {
+	for _, days := range []int{0, 1, MaxCooldownDays} {
+		if err := ValidateCooldownDays(days); err != nil {
+			t.Errorf("ValidateCooldownDays(%d) error = %v", days, err)
+		}
+	}
+	for _, days := range []int{-1, MaxCooldownDays + 1} {
+		if err := ValidateCooldownDays(days); err == nil {
+			t.Errorf("ValidateCooldownDays(%d) accepted", days)
+		}
+	}
+}
+
+func TestValidateRotationPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		days    int
+		wantErr bool
+	}{
+		{"default", "", 0, false},
+		{"least recently worn", "least-recently-worn", 0, false},
+		{"cooldown for the maximum", "cooldown", MaxCooldownDays, false},
+		{"cooldown without days", "cooldown", 0, true},
+		{"excessive cooldown", "cooldown", MaxCooldownDays + 1, true},
+		{"days without cooldown", "random", 3, true},
+		{"unknown policy", "shuffle", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRotationPolicy(tt.policy, tt.days); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRotationPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxTagLength is the longest outfit tag accepted, in characters.
+const MaxTagLength = 32
+
+// ValidateTags accepts lowercase tags of up to MaxTagLength characters
+// without spaces or commas, each listed once.
+func ValidateTags(tags []string) error {
+	return validateLabels("tag", tags)
+}
+
+// ValidateColors accepts outfit colors written as tags are: lowercase, up
+// to MaxTagLength characters without spaces or commas, each listed once.
+func ValidateColors(colors []string) error {
+	return validateLabels("color", colors)
+}
+
+// validateLabels checks a list of tag-like labels of the named kind.
+func validateLabels(kind string, labels []string) error {
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if label == "" {
+			return errors.NewInvalidInputError(kind + "s cannot be empty")
+		}
+		if utf8.RuneCountInString(label) > MaxTagLength {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s %q is longer than %d characters", kind, label, MaxTagLength))
+		}
+		if label != strings.ToLower(label) || strings.ContainsFunc(label, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s %q must be lowercase without spaces or commas", kind, label))
+		}
+		if seen[label] {
+			return errors.NewInvalidInputError(fmt.Sprintf("%s %q listed more than once", kind, label))
+		}
+		seen[label] = true
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"valid", []string{"summer", "date-night"}, false},
+		{"empty", []string{""}, true},
+		{"uppercase", []string{"Summer"}, true},
+		{"space", []string{"date night"}, true},
+		{"comma", []string{"a,b"}, true},
+		{"too long", []string{strings.Repeat("a", MaxTagLength+1)}, true},
+		{"duplicate", []string{"summer", "summer"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTags(tt.tags); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTags(%q) error = %v, wantErr %v", tt.tags, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateColors(t *testing.T) {
+	if err := ValidateColors([]string{"navy", "off-white"}); err != nil {
+		t.Errorf("ValidateColors() error = %v", err)
+	}
+	if err := ValidateColors([]string{"Navy"}); err == nil || !strings.Contains(err.Error(), `color "Navy"`) {
+		t.Errorf("ValidateColors() of an uppercase color error = %v, want it named as a color", err)
+	}
+}
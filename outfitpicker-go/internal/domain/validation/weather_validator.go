@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var weatherConditions = []string{"rain", "heat", "cold"}
+
+// ValidateWeatherSettings accepts a location on the globe, when there is one,
+// and categories and well-formed tags mapped to known weather conditions,
+// each listed once.
+func ValidateWeatherSettings(located bool, latitude, longitude float64, categories, tags map[string][]string) error {
+	if located && (latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180) {
+		return errors.ErrInvalidWeather
+	}
+	for category, conditions := range categories {
+		if strings.TrimSpace(category) == "" || validateWeatherConditions(conditions) != nil {
+			return errors.ErrInvalidWeather
+		}
+	}
+	for tag, conditions := range tags {
+		if ValidateTags([]string{tag}) != nil || validateWeatherConditions(conditions) != nil {
+			return errors.ErrInvalidWeather
+		}
+	}
+	return nil
+}
+
+func validateWeatherConditions(conditions []string) error {
+	if len(conditions) == 0 {
+		return errors.ErrInvalidWeather
+	}
+	for i, condition := range conditions {
+		if !slices.Contains(weatherConditions, condition) || slices.Contains(conditions[:i], condition) {
+			return errors.ErrInvalidWeather
+		}
+	}
+	return nil
+}
+
+// WeatherConditions returns the supported weather condition names.
+func WeatherConditions() []string {
+	return weatherConditions
+}
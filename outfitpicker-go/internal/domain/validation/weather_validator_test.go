@@ -0,0 +1,32 @@
+package validation
+
+import "testing"
+
+func TestValidateWeatherSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		located    bool
+		latitude   float64
+		longitude  float64
+		categories map[string][]string
+		tags       map[string][]string
+		wantErr    bool
+	}{
+		{"none", false, 0, 0, nil, nil, false},
+		{"location, category and tag", true, 51.5, -0.12, map[string][]string{"beach": {"rain", "cold"}}, map[string][]string{"wool": {"heat"}}, false},
+		{"latitude off the globe", true, 91, 0, nil, nil, true},
+		{"longitude off the globe", true, 0, -181, nil, nil, true},
+		{"unknown condition", false, 0, 0, map[string][]string{"beach": {"snow"}}, nil, true},
+		{"condition listed twice", false, 0, 0, map[string][]string{"beach": {"rain", "rain"}}, nil, true},
+		{"no conditions", false, 0, 0, map[string][]string{"beach": {}}, nil, true},
+		{"invalid tag", false, 0, 0, nil, map[string][]string{"Wool": {"heat"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWeatherSettings(tt.located, tt.latitude, tt.longitude, tt.categories, tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWeatherSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
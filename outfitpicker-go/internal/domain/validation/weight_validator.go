@@ -0,0 +1,21 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// MaxOutfitWeight caps how much more often one outfit can be picked than an
+// unweighted one.
+const MaxOutfitWeight = 100
+
+// ValidateOutfitWeight accepts weights from 0, which leaves an outfit out of
+// weighted picks while others remain, to MaxOutfitWeight.
+func ValidateOutfitWeight(weight float64) error {
+	if math.IsNaN(weight) || weight < 0 || weight > MaxOutfitWeight {
+		return errors.NewInvalidInputError(fmt.Sprintf("weight must be between 0 and %d, got %g", MaxOutfitWeight, weight))
+	}
+	return nil
+}
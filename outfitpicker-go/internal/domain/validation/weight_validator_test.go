@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestValidateOutfitWeight(t *testing.T) {
+	tests := []struct {
+		weight  float64
+		wantErr bool
+	}{
+		{0, false},
+		{0.5, false},
+		{1, false},
+		{MaxOutfitWeight, false},
+		{-1, true},
+		{MaxOutfitWeight + 1, true},
+		{math.NaN(), true},
+	}
+	for _, tt := range tests {
+		if err := ValidateOutfitWeight(tt.weight); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateOutfitWeight(%v) error = %v, wantErr %v", tt.weight, err, tt.wantErr)
+		}
+	}
+}
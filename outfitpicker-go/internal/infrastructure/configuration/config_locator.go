@@ -0,0 +1,44 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// FindConfigurations returns the app directories holding a config file
+// among those dp may resolve to, most preferred first. Only a provider
+// that searches several base directories can find more than one.
+func FindConfigurations(dp system.DirectoryProvider) ([]string, error) {
+	providers := []system.DirectoryProvider{dp}
+	if candidates, ok := dp.(system.CandidateDirectoryProvider); ok {
+		dirs, err := candidates.CandidateDirectories()
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		providers = providers[:0]
+		for _, dir := range dirs {
+			providers = append(providers, system.NewStaticDirectoryProvider(dir))
+		}
+	}
+
+	var found []string
+	for _, provider := range providers {
+		appDir, err := system.AppDirectory(provider)
+		if err != nil {
+			return nil, errors.Wrap(err)
+		}
+		if info, err := os.Stat(filepath.Join(appDir, configFileName)); err == nil && info.Mode().IsRegular() {
+			found = append(found, appDir)
+		}
+	}
+	return found, nil
+}
+
+// IsConfigFile reports whether path names a config file by its name, as
+// opposed to the app directory holding one.
+func IsConfigFile(path string) bool {
+	return filepath.Base(path) == configFileName
+}
@@ -0,0 +1,69 @@
+package configuration
+
+import (
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func TestFindConfigurations(t *testing.T) {
+	first, second, empty := t.TempDir(), t.TempDir(), t.TempDir()
+	for _, dir := range []string{first, second} {
+		service := NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewStaticDirectoryProvider(dir)))
+		if err := service.Save(&entities.Config{Roots: []string{"/home/user/outfits"}, Language: entities.DefaultLanguage}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appDir := func(base string) string { return filepath.Join(base, "outfitpicker") }
+
+	tests := []struct {
+		name string
+		dp   system.DirectoryProvider
+		want []string
+	}{
+		{"single location", system.NewStaticDirectoryProvider(first), []string{appDir(first)}},
+		{"nothing saved", system.NewStaticDirectoryProvider(empty), nil},
+		{"one of several candidates", system.NewCandidateDirectoryProvider(empty, second), []string{appDir(second)}},
+		{"several candidates", system.NewCandidateDirectoryProvider(second, empty, first), []string{appDir(second), appDir(first)}},
+		{"app directory", system.NewAppDirectoryProvider(appDir(first)), []string{appDir(first)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindConfigurations(tt.dp)
+			if err != nil {
+				t.Fatalf("FindConfigurations() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindConfigurations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindConfigurations_XDGAndPlatformDirectory(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the platform config directory is under HOME only on Linux")
+	}
+	home, xdg := t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	for _, dir := range []string{xdg, filepath.Join(home, ".config")} {
+		service := NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewStaticDirectoryProvider(dir)))
+		if err := service.Save(&entities.Config{Roots: []string{"/home/user/outfits"}, Language: entities.DefaultLanguage}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := FindConfigurations(system.NewDefaultDirectoryProvider())
+	if err != nil {
+		t.Fatalf("FindConfigurations() error = %v", err)
+	}
+	want := []string{filepath.Join(xdg, "outfitpicker"), filepath.Join(home, ".config", "outfitpicker")}
+	if !slices.Equal(got, want) {
+		t.Errorf("FindConfigurations() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,82 @@
+// Package configuration persists the application configuration.
+package configuration
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const configFileName = "config.json"
+
+// ConfigService loads and saves config.json through a FileService.
+type ConfigService struct {
+	fileService *system.FileService[entities.Config]
+}
+
+// NewConfigService creates a config service. Options are forwarded to the
+// underlying FileService, which keeps the file with the configuration even
+// where state files are kept apart.
+func NewConfigService(opts ...system.FileServiceOption[entities.Config]) *ConfigService {
+	opts = append(opts, system.AsConfiguration[entities.Config]())
+	return &ConfigService{
+		fileService: system.NewFileService(configFileName, opts...),
+	}
+}
+
+// Path returns the location of the config file.
+func (s *ConfigService) Path() (string, error) {
+	path, err := s.fileService.FilePath()
+	if err != nil {
+		return "", errors.Wrap(err)
+	}
+	return path, nil
+}
+
+// Load returns the saved configuration, or ErrConfigurationNotFound if none
+// has been saved yet. Temporary category exclusions that have ended are
+// dropped, and so leave the file at its next save.
+func (s *ConfigService) Load() (*entities.Config, error) {
+	config, err := s.fileService.Load()
+	if err != nil {
+		return nil, errors.Wrap(err)
+	}
+	if config == nil {
+		return nil, errors.ErrConfigurationNotFound
+	}
+	config.DropExpiredExclusions(time.Now())
+	return config, nil
+}
+
+// Save writes the configuration if the saved file is still at
+// config.Revision, then advances config.Revision. A ConflictError is returned
+// when another writer saved since config was loaded.
+func (s *ConfigService) Save(config *entities.Config) error {
+	next := *config
+	next.Revision++
+	err := s.fileService.Update(func(current *entities.Config) (entities.Config, error) {
+		if actual := revisionOf(current); actual != config.Revision {
+			return entities.Config{}, errors.NewConflictError(configFileName, config.Revision, actual)
+		}
+		return next, nil
+	})
+	if err != nil {
+		return errors.Wrap(err)
+	}
+	config.Revision = next.Revision
+	return nil
+}
+
+func revisionOf(config *entities.Config) int {
+	if config == nil {
+		return 0
+	}
+	return config.Revision
+}
+
+// Delete removes the config file if it exists.
+func (s *ConfigService) Delete() error {
+	return errors.Wrap(s.fileService.Delete())
+}
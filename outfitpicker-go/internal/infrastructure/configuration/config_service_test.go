@@ -0,0 +1,123 @@
+package configuration
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestService(t *testing.T) (*ConfigService, string) {
+	t.Helper()
+	dir := t.TempDir()
+	return NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewStaticDirectoryProvider(dir))), dir
+}
+
+func TestConfigService_LoadMissing(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.Load()
+	if !errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		t.Errorf("Load() error = %v, want ErrConfigurationNotFound", err)
+	}
+}
+
+func TestConfigService_RoundTrip(t *testing.T) {
+	service, dir := newTestService(t)
+	config, err := entities.NewConfigBuilder().RootDirectory("/home/user/outfits").Exclude("formal").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if err := service.Save(config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.PrimaryRoot() != config.PrimaryRoot() || !loaded.ExcludedCategories["formal"] {
+		t.Errorf("Load() = %+v, want %+v", loaded, config)
+	}
+
+	path, err := service.Path()
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if want := filepath.Join(dir, "outfitpicker", "config.json"); path != want {
+		t.Errorf("Path() = %v, want %v", path, want)
+	}
+
+	if err := service.Delete(); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := service.Load(); !errors.Is(err, domainerrors.ErrConfigurationNotFound) {
+		t.Errorf("Load() after Delete() error = %v, want ErrConfigurationNotFound", err)
+	}
+}
+
+func TestConfigService_SaveRejectsStaleConfig(t *testing.T) {
+	service, _ := newTestService(t)
+	if err := service.Save(&entities.Config{Roots: []string{"/outfits"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.Language = "de"
+	if err := service.Save(first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if first.Revision != 2 {
+		t.Errorf("Revision after save = %d, want 2", first.Revision)
+	}
+
+	second.Language = "fr"
+	err = service.Save(second)
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) || conflict.Expected != 1 || conflict.Actual != 2 {
+		t.Errorf("Save() error = %v, want ConflictError expecting 1, found 2", err)
+	}
+
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Language != "de" {
+		t.Errorf("Language = %q, want the first writer's change kept", loaded.Language)
+	}
+}
+
+func TestConfigService_LoadDropsEndedExclusions(t *testing.T) {
+	service, _ := newTestService(t)
+	config, err := entities.NewConfigBuilder().RootDirectory("/home/user/outfits").Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.ExcludedUntil = map[string]time.Time{
+		"office": time.Now().Add(-time.Minute),
+		"beach":  time.Now().Add(time.Hour),
+	}
+	if err := service.Save(config); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := loaded.ExcludedUntil["office"]; ok || len(loaded.ExcludedUntil) != 1 {
+		t.Errorf("ExcludedUntil = %v, want only beach", loaded.ExcludedUntil)
+	}
+}
@@ -0,0 +1,114 @@
+// Package logging builds the structured logger that infrastructure and
+// application services report what they do to, for debugging scan and
+// cache problems.
+package logging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// FileName is the JSON log file written under the app directory.
+const FileName = "outfitpicker.log"
+
+// MaxFileSize is the size past which the log file is moved aside to
+// FileName.1, replacing any older one, when it is next opened.
+const MaxFileSize = 1 << 20
+
+// Options sets where a logger writes and how much.
+type Options struct {
+	// Level is the least severe level written to Stderr.
+	Level slog.Level
+	// Stderr receives human-readable log lines; nil writes none.
+	Stderr io.Writer
+	// File, when not empty, is the path of a file that receives JSON log
+	// lines of Level or of slog.LevelInfo, whichever is less severe.
+	File string
+}
+
+// New returns a logger writing as opts says, and a function that closes
+// the log file, if there is one.
+func New(opts Options) (*slog.Logger, func() error, error) {
+	var handlers []slog.Handler
+	if opts.Stderr != nil {
+		handlers = append(handlers, slog.NewTextHandler(opts.Stderr, &slog.HandlerOptions{Level: opts.Level}))
+	}
+	closeFile := func() error { return nil }
+	if opts.File != "" {
+		f, err := openFile(opts.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		handlers = append(handlers, slog.NewJSONHandler(f, &slog.HandlerOptions{Level: min(opts.Level, slog.LevelInfo)}))
+		closeFile = f.Close
+	}
+	switch len(handlers) {
+	case 0:
+		return Discard(), closeFile, nil
+	case 1:
+		return slog.New(handlers[0]), closeFile, nil
+	}
+	return slog.New(teeHandler(handlers)), closeFile, nil
+}
+
+// Discard returns a logger that writes nothing, for services given none.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// openFile opens the log file for appending, first moving it aside when it
+// has grown past MaxFileSize.
+func openFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > MaxFileSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// teeHandler passes every record to each of its handlers that is enabled
+// for the record's level.
+type teeHandler []slog.Handler
+
+func (h teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, record.Level) {
+			errs = append(errs, handler.Handle(ctx, record.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (h teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
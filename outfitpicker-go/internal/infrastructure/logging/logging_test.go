@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew_StderrAndFile(t *testing.T) {
+	var stderr bytes.Buffer
+	path := filepath.Join(t.TempDir(), "logs", FileName)
+	logger, closeLog, err := New(Options{Level: slog.LevelWarn, Stderr: &stderr, File: path})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger.Debug("scan detail")
+	logger.Info("scanned categories", "count", 3)
+	logger.Warn("cache rebuilt")
+	if err := closeLog(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := stderr.String(); strings.Contains(got, "scanned categories") || !strings.Contains(got, "cache rebuilt") {
+		t.Errorf("stderr = %q, want only the warning", got)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log file has %d lines, want the info and warning:\n%s", len(lines), data)
+	}
+	var record struct {
+		Msg   string `json:"msg"`
+		Count int    `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil || record.Msg != "scanned categories" || record.Count != 3 {
+		t.Errorf("first log line = %s (%v), want the info record as JSON", lines[0], err)
+	}
+}
+
+func TestNew_MovesAsideAFullLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), MaxFileSize+1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logger, closeLog, err := New(Options{File: path})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger.Info("fresh start")
+	closeLog()
+
+	if info, err := os.Stat(path + ".1"); err != nil || info.Size() != MaxFileSize+1 {
+		t.Errorf("old log = %v, %v, want it moved aside whole", info, err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "fresh start") || len(data) > 200 {
+		t.Errorf("new log = %q, want only the new record", data)
+	}
+}
+
+func TestNew_WritesNothingByDefault(t *testing.T) {
+	logger, closeLog, err := New(Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer closeLog()
+	if logger.Enabled(t.Context(), slog.LevelError) {
+		t.Error("a logger with nowhere to write should be disabled")
+	}
+}
@@ -0,0 +1,119 @@
+// Package mail delivers email through an SMTP server.
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// PasswordEnvVar names the environment variable holding the SMTP password,
+// which is never written to the configuration file.
+const PasswordEnvVar = "OUTFITPICKER_SMTP_PASSWORD"
+
+// SendFunc matches smtp.SendMail.
+type SendFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// SMTPMailer sends messages with net/smtp, authenticating with PLAIN auth
+// when the server settings name a user.
+type SMTPMailer struct {
+	send     SendFunc
+	password func() string
+	now      func() time.Time
+}
+
+// Option configures an SMTPMailer.
+type Option func(*SMTPMailer)
+
+// WithSendFunc replaces smtp.SendMail, for tests.
+func WithSendFunc(send SendFunc) Option {
+	return func(m *SMTPMailer) {
+		m.send = send
+	}
+}
+
+// WithPassword replaces reading the password from PasswordEnvVar.
+func WithPassword(password func() string) Option {
+	return func(m *SMTPMailer) {
+		m.password = password
+	}
+}
+
+// WithClock sets the time used for the Date header.
+func WithClock(now func() time.Time) Option {
+	return func(m *SMTPMailer) {
+		m.now = now
+	}
+}
+
+// NewSMTPMailer creates a mailer that sends through smtp.SendMail.
+func NewSMTPMailer(opts ...Option) *SMTPMailer {
+	m := &SMTPMailer{
+		send:     smtp.SendMail,
+		password: func() string { return os.Getenv(PasswordEnvVar) },
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Send delivers message to every recipient in server.
+func (m *SMTPMailer) Send(server entities.SMTPSettings, message entities.MailMessage) error {
+	var auth smtp.Auth
+	if server.Username != "" {
+		auth = smtp.PlainAuth("", server.Username, m.password(), server.Host)
+	}
+	addr := net.JoinHostPort(server.Host, strconv.Itoa(server.Port))
+	if err := m.send(addr, auth, envelopeAddress(server.From), envelopeAddresses(server.To), m.compose(server, message)); err != nil {
+		return fmt.Errorf("sending mail through %s: %w", addr, err)
+	}
+	return nil
+}
+
+// compose builds an RFC 5322 message with CRLF line endings.
+func (m *SMTPMailer) compose(server entities.SMTPSettings, message entities.MailMessage) []byte {
+	contentType := "text/plain"
+	if message.HTML {
+		contentType = "text/html"
+	}
+	var b strings.Builder
+	for _, header := range [][2]string{
+		{"From", server.From},
+		{"To", strings.Join(server.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", message.Subject)},
+		{"Date", m.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", contentType + "; charset=utf-8"},
+	} {
+		fmt.Fprintf(&b, "%s: %s\r\n", header[0], header[1])
+	}
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// envelopeAddress strips a display name, which SMTP envelopes do not allow.
+// Addresses have already been validated with net/mail.
+func envelopeAddress(address string) string {
+	if start, end := strings.LastIndex(address, "<"), strings.LastIndex(address, ">"); start >= 0 && end > start {
+		return address[start+1 : end]
+	}
+	return address
+}
+
+func envelopeAddresses(addresses []string) []string {
+	envelope := make([]string, len(addresses))
+	for i, address := range addresses {
+		envelope[i] = envelopeAddress(address)
+	}
+	return envelope
+}
@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"errors"
+	"net/smtp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+type sentMail struct {
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	msg  string
+}
+
+func newTestMailer(sent *sentMail, sendErr error) *SMTPMailer {
+	return NewSMTPMailer(
+		WithSendFunc(func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+			*sent = sentMail{addr, auth, from, to, string(msg)}
+			return sendErr
+		}),
+		WithPassword(func() string { return "secret" }),
+		WithClock(func() time.Time { return time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC) }),
+	)
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	var sent sentMail
+	server := entities.SMTPSettings{
+		Host:     "smtp.example.com",
+		Port:     587,
+		Username: "me",
+		From:     "Outfit Picker <me@example.com>",
+		To:       []string{"me@example.com", "You <you@example.com>"},
+	}
+	message := entities.MailMessage{Subject: "Your May report", Body: "<p>hi</p>\n", HTML: true}
+
+	if err := newTestMailer(&sent, nil).Send(server, message); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if sent.addr != "smtp.example.com:587" || sent.auth == nil || sent.from != "me@example.com" {
+		t.Errorf("sent to %s as %s, auth %v", sent.addr, sent.from, sent.auth)
+	}
+	if !slices.Equal(sent.to, []string{"me@example.com", "you@example.com"}) {
+		t.Errorf("recipients = %v", sent.to)
+	}
+	for _, want := range []string{
+		"From: Outfit Picker <me@example.com>\r\n",
+		"To: me@example.com, You <you@example.com>\r\n",
+		"Subject: Your May report\r\n",
+		"Date: Sat, 01 Jun 2024 09:00:00 +0000\r\n",
+		"Content-Type: text/html; charset=utf-8\r\n\r\n<p>hi</p>\r\n",
+	} {
+		if !strings.Contains(sent.msg, want) {
+			t.Errorf("message missing %q:\n%s", want, sent.msg)
+		}
+	}
+}
+
+func TestSMTPMailer_SendWithoutUsernameSkipsAuth(t *testing.T) {
+	var sent sentMail
+	server := entities.SMTPSettings{Host: "localhost", Port: 25, From: "me@example.com", To: []string{"me@example.com"}}
+
+	if err := newTestMailer(&sent, nil).Send(server, entities.MailMessage{Subject: "s", Body: "b"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if sent.auth != nil || !strings.Contains(sent.msg, "Content-Type: text/plain") {
+		t.Errorf("auth = %v, message:\n%s", sent.auth, sent.msg)
+	}
+}
+
+func TestSMTPMailer_SendError(t *testing.T) {
+	var sent sentMail
+	refused := errors.New("connection refused")
+	server := entities.SMTPSettings{Host: "localhost", Port: 25, From: "me@example.com", To: []string{"me@example.com"}}
+
+	err := newTestMailer(&sent, refused).Send(server, entities.MailMessage{})
+	if !errors.Is(err, refused) || !strings.Contains(err.Error(), "localhost:25") {
+		t.Errorf("Send() error = %v", err)
+	}
+}
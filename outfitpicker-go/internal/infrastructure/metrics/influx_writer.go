@@ -0,0 +1,76 @@
+// Package metrics pushes wardrobe metrics to a metrics database.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// TokenEnvVar names the environment variable holding the InfluxDB API
+// token, which is never passed on the command line.
+const TokenEnvVar = "OUTFITPICKER_INFLUX_TOKEN"
+
+const requestTimeout = 10 * time.Second
+
+// InfluxWriter pushes InfluxDB line protocol to a write endpoint, such as
+// InfluxDB's /api/v2/write or Telegraf's http_listener_v2.
+type InfluxWriter struct {
+	client *http.Client
+	token  func() string
+}
+
+// Option configures an InfluxWriter.
+type Option func(*InfluxWriter)
+
+// WithHTTPClient replaces the HTTP client, for tests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(w *InfluxWriter) {
+		w.client = client
+	}
+}
+
+// WithToken replaces reading the token from TokenEnvVar.
+func WithToken(token func() string) Option {
+	return func(w *InfluxWriter) {
+		w.token = token
+	}
+}
+
+// NewInfluxWriter creates a writer that authenticates with the token in
+// TokenEnvVar, when set.
+func NewInfluxWriter(opts ...Option) *InfluxWriter {
+	w := &InfluxWriter{
+		client: &http.Client{Timeout: requestTimeout},
+		token:  func() string { return os.Getenv(TokenEnvVar) },
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Write posts lines, in line protocol, to url.
+func (w *InfluxWriter) Write(url string, lines []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(lines))
+	if err != nil {
+		return fmt.Errorf("pushing metrics: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if token := w.token(); token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pushing metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pushing metrics to %s: %s: %s", url, resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestInfluxWriter_Write(t *testing.T) {
+	var body, auth, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, auth, contentType = string(data), r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	writer := NewInfluxWriter(WithHTTPClient(server.Client()), WithToken(func() string { return "secret" }))
+
+	lines := "outfitpicker_wardrobe outfits=3i 1717232400000000000\n"
+	if err := writer.Write(server.URL+"/api/v2/write?bucket=home", []byte(lines)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if body != lines || auth != "Token secret" || !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("request body = %q, Authorization = %q, Content-Type = %q", body, auth, contentType)
+	}
+}
+
+func TestInfluxWriter_WithoutToken(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Values("Authorization")
+	}))
+	t.Cleanup(server.Close)
+
+	if err := NewInfluxWriter(WithToken(func() string { return "" })).Write(server.URL, nil); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if len(auth) != 0 {
+		t.Errorf("Authorization = %q, want none", auth)
+	}
+}
+
+func TestInfluxWriter_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"bucket not found"}`, http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	writer := NewInfluxWriter(WithToken(func() string { return "" }))
+
+	if err := writer.Write(server.URL, nil); err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("Write() error = %v, want the server's message", err)
+	}
+	if err := writer.Write("://missing-scheme", nil); err == nil {
+		t.Error("Write() to a malformed URL error = nil, want an error")
+	}
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const arrivalsFileName = "arrivals.json"
+
+// ArrivalStore loads and saves arrivals.json through a FileService.
+type ArrivalStore struct {
+	fileService *system.FileService[entities.OutfitArrivals]
+}
+
+// NewArrivalStore creates an arrival store. Options are forwarded to the
+// underlying FileService.
+func NewArrivalStore(opts ...system.FileServiceOption[entities.OutfitArrivals]) *ArrivalStore {
+	return &ArrivalStore{
+		fileService: system.NewFileService(arrivalsFileName, opts...),
+	}
+}
+
+// Load returns the recorded arrivals, or none if nothing has been saved yet.
+func (s *ArrivalStore) Load() (entities.OutfitArrivals, error) {
+	arrivals, err := s.fileService.Load()
+	if err != nil {
+		return entities.OutfitArrivals{}, errors.Wrap(err)
+	}
+	return normalizedArrivals(arrivals), nil
+}
+
+// Save writes the arrivals if the saved file is still at arrivals.Revision.
+// A ConflictError is returned when another writer saved since arrivals was
+// loaded.
+func (s *ArrivalStore) Save(arrivals entities.OutfitArrivals) error {
+	expected := arrivals.Revision
+	arrivals.Revision++
+	return compareAndSave(s.fileService, arrivalsFileName, expected, arrivals, func(current *entities.OutfitArrivals) int {
+		return normalizedArrivals(current).Revision
+	})
+}
+
+func normalizedArrivals(arrivals *entities.OutfitArrivals) entities.OutfitArrivals {
+	if arrivals == nil {
+		return entities.NewOutfitArrivals()
+	}
+	if arrivals.Outfits == nil {
+		arrivals.Outfits = make(map[string]map[string]time.Time)
+	}
+	if arrivals.LastSeen == nil {
+		arrivals.LastSeen = make(map[string]map[string]time.Time)
+	}
+	return *arrivals
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestArrivalStore(t *testing.T) *ArrivalStore {
+	t.Helper()
+	return NewArrivalStore(system.WithDirectoryProvider[entities.OutfitArrivals](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestArrivalStore_RoundTrip(t *testing.T) {
+	store := newTestArrivalStore(t)
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	arrivals, err := store.Load()
+	if err != nil || len(arrivals.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want no arrivals", arrivals, err)
+	}
+	arrivals, _ = arrivals.Recording("casual", []string{"tee.avatar"}, now)
+	arrivals, _ = arrivals.Recording("casual", []string{"tee.avatar", "coat.avatar"}, now)
+	if err := store.Save(arrivals); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Category("casual")["coat.avatar"].Equal(now) || !loaded.Category("casual")["tee.avatar"].IsZero() || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestArrivalStore_SaveRejectsStaleArrivals(t *testing.T) {
+	store := newTestArrivalStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := stale.Recording("casual", []string{"tee.avatar"}, time.Now())
+	if err := store.Save(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second, _ := stale.Recording("work", []string{"blazer.avatar"}, time.Now())
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(second); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
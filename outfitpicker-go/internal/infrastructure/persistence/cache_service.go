@@ -0,0 +1,113 @@
+// Package persistence stores rotation state and other per-user data files.
+package persistence
+
+import (
+	"errors"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const (
+	cacheFileName = "cache.json"
+	// maxUpdateAttempts bounds how often UpdateCategory reapplies an update
+	// after another writer changed the same category.
+	maxUpdateAttempts = 3
+)
+
+// errRevisionChanged aborts a commit whose category changed since it was read.
+var errRevisionChanged = errors.New("category revision changed")
+
+// CacheService loads and saves cache.json through a FileService.
+type CacheService struct {
+	fileService *system.FileService[entities.OutfitCache]
+}
+
+// NewCacheService creates a cache service. Options are forwarded to the
+// underlying FileService.
+func NewCacheService(opts ...system.FileServiceOption[entities.OutfitCache]) *CacheService {
+	return &CacheService{
+		fileService: system.NewFileService(cacheFileName, opts...),
+	}
+}
+
+// Path returns the location of the cache file.
+func (s *CacheService) Path() (string, error) {
+	path, err := s.fileService.FilePath()
+	if err != nil {
+		return "", domainerrors.Wrap(err)
+	}
+	return path, nil
+}
+
+// Load returns the saved cache, or an empty cache if none has been saved yet.
+func (s *CacheService) Load() (entities.OutfitCache, error) {
+	cache, err := s.fileService.Load()
+	if err != nil {
+		return entities.OutfitCache{}, domainerrors.Wrap(err)
+	}
+	return normalizedCache(cache), nil
+}
+
+// Save writes the cache if the saved file is still at cache.Revision. A
+// ConflictError is returned when another writer saved since cache was loaded;
+// load again before saving a second time.
+func (s *CacheService) Save(cache entities.OutfitCache) error {
+	expected := cache.Revision
+	cache.Revision++
+	return compareAndSave(s.fileService, cacheFileName, expected, cache, func(current *entities.OutfitCache) int {
+		return normalizedCache(current).Revision
+	})
+}
+
+// UpdateCategory applies update to one category and saves it without holding
+// a lock while update runs. The category's revision is checked when the
+// result is committed: changes to other categories in the meantime are
+// merged, while a change to the same category causes update to be reapplied
+// to the newer state. A ConflictError is returned if that keeps happening.
+func (s *CacheService) UpdateCategory(name string, update func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error)) error {
+	var expected, actual int
+	for range maxUpdateAttempts {
+		cache, err := s.Load()
+		if err != nil {
+			return err
+		}
+		base, exists := cache.Categories[name]
+		updated, err := update(base, exists)
+		if err != nil {
+			return err
+		}
+		updated.Revision = base.Revision + 1
+
+		err = s.fileService.Update(func(current *entities.OutfitCache) (entities.OutfitCache, error) {
+			latest := normalizedCache(current)
+			expected, actual = base.Revision, latest.Categories[name].Revision
+			if actual != expected {
+				return entities.OutfitCache{}, errRevisionChanged
+			}
+			merged := latest.Updating(name, updated)
+			merged.Revision++
+			return merged, nil
+		})
+		if !errors.Is(err, errRevisionChanged) {
+			return domainerrors.Wrap(err)
+		}
+	}
+	return domainerrors.NewConflictError(cacheFileName+" category "+name, expected, actual)
+}
+
+// Delete removes the cache file if it exists.
+func (s *CacheService) Delete() error {
+	return domainerrors.Wrap(s.fileService.Delete())
+}
+
+func normalizedCache(cache *entities.OutfitCache) entities.OutfitCache {
+	if cache == nil {
+		return entities.NewOutfitCache()
+	}
+	if cache.Categories == nil {
+		cache.Categories = make(map[string]entities.CategoryCache)
+	}
+	return *cache
+}
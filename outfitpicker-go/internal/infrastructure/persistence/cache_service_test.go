@@ -0,0 +1,189 @@
+package persistence
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestCacheService(t *testing.T) *CacheService {
+	t.Helper()
+	return NewCacheService(system.WithDirectoryProvider[entities.OutfitCache](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestCacheService_LoadMissing(t *testing.T) {
+	cache, err := newTestCacheService(t).Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cache.Categories == nil || len(cache.Categories) != 0 {
+		t.Errorf("Load() = %+v, want empty cache", cache)
+	}
+}
+
+func TestCacheService_RoundTrip(t *testing.T) {
+	service := newTestCacheService(t)
+	cache := entities.NewOutfitCache().Updating("casual", entities.NewCategoryCache(3).Adding("tee.avatar"))
+
+	if err := service.Save(cache); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Categories["casual"].WornOutfits["tee.avatar"] {
+		t.Errorf("Load() = %+v, want casual/tee.avatar worn", loaded)
+	}
+
+	if err := service.Delete(); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+}
+
+func TestCacheService_CorruptFile(t *testing.T) {
+	service := newTestCacheService(t)
+	if err := service.Save(entities.NewOutfitCache()); err != nil {
+		t.Fatal(err)
+	}
+	path, err := service.Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Load(); !errors.Is(err, domainerrors.ErrFileSystem) {
+		t.Errorf("Load() error = %v, want ErrFileSystem", err)
+	}
+}
+
+func TestCacheService_UpdateCategoryMergesOtherCategories(t *testing.T) {
+	service := newTestCacheService(t)
+
+	err := service.UpdateCategory("casual", func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+		if exists {
+			t.Error("exists = true for a new category")
+		}
+		// Another writer updates a different category while this one works.
+		if err := service.Save(entities.NewOutfitCache().Updating("formal", entities.NewCategoryCache(1))); err != nil {
+			t.Fatal(err)
+		}
+		return entities.NewCategoryCache(2).Adding("tee.avatar"), nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Categories["formal"]; !ok {
+		t.Error("concurrent change to another category was lost")
+	}
+	if got := loaded.Categories["casual"]; !got.WornOutfits["tee.avatar"] || got.Revision != 1 {
+		t.Errorf("casual = %+v, want tee.avatar worn at revision 1", got)
+	}
+}
+
+func TestCacheService_UpdateCategoryRetriesOnSameCategory(t *testing.T) {
+	service := newTestCacheService(t)
+	calls := 0
+
+	err := service.UpdateCategory("casual", func(current entities.CategoryCache, exists bool) (entities.CategoryCache, error) {
+		calls++
+		if calls == 1 {
+			// Another writer changes the same category first.
+			if err := service.UpdateCategory("casual", func(entities.CategoryCache, bool) (entities.CategoryCache, error) {
+				return entities.NewCategoryCache(2).Adding("hoodie.avatar"), nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !exists {
+			current = entities.NewCategoryCache(2)
+		}
+		return current.Adding("tee.avatar"), nil
+	})
+	if err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("update called %d times, want 2", calls)
+	}
+
+	loaded, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := loaded.Categories["casual"]
+	if !got.WornOutfits["hoodie.avatar"] || !got.WornOutfits["tee.avatar"] || got.Revision != 2 {
+		t.Errorf("casual = %+v, want both outfits worn at revision 2", got)
+	}
+}
+
+func TestCacheService_UpdateCategoryConflict(t *testing.T) {
+	service := newTestCacheService(t)
+
+	err := service.UpdateCategory("casual", func(current entities.CategoryCache, _ bool) (entities.CategoryCache, error) {
+		if err := service.UpdateCategory("casual", func(c entities.CategoryCache, _ bool) (entities.CategoryCache, error) {
+			return c, nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return current, nil
+	})
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("UpdateCategory() error = %v, want ConflictError", err)
+	}
+}
+
+func TestCacheService_UpdateCategoryPropagatesUpdateError(t *testing.T) {
+	service := newTestCacheService(t)
+	want := errors.New("boom")
+
+	err := service.UpdateCategory("casual", func(entities.CategoryCache, bool) (entities.CategoryCache, error) {
+		return entities.CategoryCache{}, want
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("UpdateCategory() error = %v, want %v", err, want)
+	}
+	if path, _ := service.Path(); fileExists(path) {
+		t.Error("cache written despite update error")
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCacheService_SaveRejectsStaleCache(t *testing.T) {
+	service := newTestCacheService(t)
+	if err := service.Save(entities.NewOutfitCache()); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := service.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.UpdateCategory("casual", func(entities.CategoryCache, bool) (entities.CategoryCache, error) {
+		return entities.NewCategoryCache(1), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = service.Save(stale.Updating("formal", entities.NewCategoryCache(1)))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
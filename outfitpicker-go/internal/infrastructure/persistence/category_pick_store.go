@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const categoryPicksFileName = "category_picks.json"
+
+// CategoryPickStore loads and saves category_picks.json through a
+// FileService.
+type CategoryPickStore struct {
+	fileService *system.FileService[entities.CategoryPicks]
+}
+
+// NewCategoryPickStore creates a category pick store. Options are forwarded
+// to the underlying FileService.
+func NewCategoryPickStore(opts ...system.FileServiceOption[entities.CategoryPicks]) *CategoryPickStore {
+	return &CategoryPickStore{
+		fileService: system.NewFileService(categoryPicksFileName, opts...),
+	}
+}
+
+// Load returns when each category was last picked, or no picks if nothing
+// has been saved yet.
+func (s *CategoryPickStore) Load() (entities.CategoryPicks, error) {
+	picks, err := s.fileService.Load()
+	if err != nil {
+		return entities.CategoryPicks{}, errors.Wrap(err)
+	}
+	return normalizedCategoryPicks(picks), nil
+}
+
+// Save writes the picks if the saved file is still at picks.Revision. A
+// ConflictError is returned when another writer saved since picks was
+// loaded.
+func (s *CategoryPickStore) Save(picks entities.CategoryPicks) error {
+	expected := picks.Revision
+	picks.Revision++
+	return compareAndSave(s.fileService, categoryPicksFileName, expected, picks, func(current *entities.CategoryPicks) int {
+		return normalizedCategoryPicks(current).Revision
+	})
+}
+
+func normalizedCategoryPicks(picks *entities.CategoryPicks) entities.CategoryPicks {
+	if picks == nil {
+		return entities.NewCategoryPicks()
+	}
+	if picks.LastPicked == nil {
+		picks.LastPicked = make(map[string]time.Time)
+	}
+	return *picks
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestCategoryPickStore(t *testing.T) *CategoryPickStore {
+	t.Helper()
+	return NewCategoryPickStore(system.WithDirectoryProvider[entities.CategoryPicks](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestCategoryPickStore_RoundTrip(t *testing.T) {
+	store := newTestCategoryPickStore(t)
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	picks, err := store.Load()
+	if err != nil || len(picks.LastPicked) != 0 {
+		t.Fatalf("Load() = %+v, %v; want no picks", picks, err)
+	}
+	if err := store.Save(picks.Recording("casual", now)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.LastPicked["casual"].Equal(now) || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestCategoryPickStore_SaveRejectsStalePicks(t *testing.T) {
+	store := newTestCategoryPickStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Recording("casual", time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale.Recording("work", time.Now())); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const challengeFileName = "challenge.json"
+
+// ChallengeStore loads and saves challenge.json through a FileService.
+type ChallengeStore struct {
+	fileService *system.FileService[entities.Challenge]
+}
+
+// NewChallengeStore creates a challenge store. Options are forwarded to the
+// underlying FileService.
+func NewChallengeStore(opts ...system.FileServiceOption[entities.Challenge]) *ChallengeStore {
+	return &ChallengeStore{
+		fileService: system.NewFileService(challengeFileName, opts...),
+	}
+}
+
+// Load returns the current or last capsule challenge, or the zero
+// challenge if none was ever started.
+func (s *ChallengeStore) Load() (entities.Challenge, error) {
+	challenge, err := s.fileService.Load()
+	if err != nil {
+		return entities.Challenge{}, errors.Wrap(err)
+	}
+	return normalizedChallenge(challenge), nil
+}
+
+// Save writes the challenge if the saved file is still at
+// challenge.Revision. A ConflictError is returned when another writer saved
+// since challenge was loaded.
+func (s *ChallengeStore) Save(challenge entities.Challenge) error {
+	expected := challenge.Revision
+	challenge.Revision++
+	return compareAndSave(s.fileService, challengeFileName, expected, challenge, func(current *entities.Challenge) int {
+		return normalizedChallenge(current).Revision
+	})
+}
+
+func normalizedChallenge(challenge *entities.Challenge) entities.Challenge {
+	if challenge == nil {
+		return entities.Challenge{}
+	}
+	return *challenge
+}
@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestChallengeStore(t *testing.T) *ChallengeStore {
+	t.Helper()
+	return NewChallengeStore(system.WithDirectoryProvider[entities.Challenge](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestChallengeStore_RoundTrip(t *testing.T) {
+	store := newTestChallengeStore(t)
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	challenge, err := store.Load()
+	if err != nil || !challenge.IsZero() {
+		t.Fatalf("Load() = %+v, %v; want no challenge", challenge, err)
+	}
+	challenge = entities.Challenge{
+		Capsule:   []entities.OutfitLocation{{Category: "casual", FileName: "tee.avatar"}},
+		StartedAt: now,
+		EndsAt:    now.AddDate(0, 0, 30),
+	}
+	if err := store.Save(challenge); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.ActiveAt(now) || !loaded.Contains("casual", "tee.avatar") || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestChallengeStore_SaveRejectsStaleChallenge(t *testing.T) {
+	store := newTestChallengeStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Ending(time.Now())); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const commandHistoryFileName = "command_history.json"
+
+// CommandHistoryStore loads and saves command_history.json through a
+// FileService.
+type CommandHistoryStore struct {
+	fileService *system.FileService[entities.CommandHistory]
+}
+
+// NewCommandHistoryStore creates a command history store. Options are
+// forwarded to the underlying FileService.
+func NewCommandHistoryStore(opts ...system.FileServiceOption[entities.CommandHistory]) *CommandHistoryStore {
+	return &CommandHistoryStore{
+		fileService: system.NewFileService(commandHistoryFileName, opts...),
+	}
+}
+
+// Load returns the command history, or an empty history if none has been
+// saved yet.
+func (s *CommandHistoryStore) Load() (entities.CommandHistory, error) {
+	history, err := s.fileService.Load()
+	if err != nil {
+		return entities.CommandHistory{}, errors.Wrap(err)
+	}
+	return normalizedCommandHistory(history), nil
+}
+
+// Save writes the history if the saved file is still at history.Revision. A
+// ConflictError is returned when another writer saved since history was
+// loaded.
+func (s *CommandHistoryStore) Save(history entities.CommandHistory) error {
+	expected := history.Revision
+	history.Revision++
+	return compareAndSave(s.fileService, commandHistoryFileName, expected, history, func(current *entities.CommandHistory) int {
+		return normalizedCommandHistory(current).Revision
+	})
+}
+
+func normalizedCommandHistory(history *entities.CommandHistory) entities.CommandHistory {
+	if history == nil {
+		return entities.CommandHistory{}
+	}
+	return *history
+}
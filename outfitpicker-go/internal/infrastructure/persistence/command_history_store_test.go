@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestCommandHistoryStore(t *testing.T) *CommandHistoryStore {
+	t.Helper()
+	return NewCommandHistoryStore(system.WithDirectoryProvider[entities.CommandHistory](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestCommandHistoryStore_RoundTrip(t *testing.T) {
+	store := newTestCommandHistoryStore(t)
+
+	history, err := store.Load()
+	if err != nil || len(history.Commands) != 0 {
+		t.Fatalf("Load() = %+v, %v; want an empty history", history, err)
+	}
+	ranAt := time.Date(2024, 6, 1, 7, 30, 0, 0, time.UTC)
+	history = history.Appending(entities.CommandRecord{Command: "pick", Args: []string{"pick", "casual"}, RanAt: ranAt})
+	if err := store.Save(history); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Commands) != 1 || !loaded.Commands[0].RanAt.Equal(ranAt) || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestCommandHistoryStore_SaveRejectsStaleHistory(t *testing.T) {
+	store := newTestCommandHistoryStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const favoritesFileName = "favorites.json"
+
+// FavoritesStore loads and saves favorites.json through a FileService.
+type FavoritesStore struct {
+	fileService *system.FileService[entities.Favorites]
+}
+
+// NewFavoritesStore creates a favorites store. Options are forwarded to the
+// underlying FileService.
+func NewFavoritesStore(opts ...system.FileServiceOption[entities.Favorites]) *FavoritesStore {
+	return &FavoritesStore{
+		fileService: system.NewFileService(favoritesFileName, opts...),
+	}
+}
+
+// Load returns the saved favorites, or no favorites if none have been saved
+// yet.
+func (s *FavoritesStore) Load() (entities.Favorites, error) {
+	favorites, err := s.fileService.Load()
+	if err != nil {
+		return entities.Favorites{}, errors.Wrap(err)
+	}
+	return normalizedFavorites(favorites), nil
+}
+
+// Save writes the favorites if the saved file is still at
+// favorites.Revision. A ConflictError is returned when another writer saved
+// since favorites was loaded.
+func (s *FavoritesStore) Save(favorites entities.Favorites) error {
+	expected := favorites.Revision
+	favorites.Revision++
+	return compareAndSave(s.fileService, favoritesFileName, expected, favorites, func(current *entities.Favorites) int {
+		return normalizedFavorites(current).Revision
+	})
+}
+
+func normalizedFavorites(favorites *entities.Favorites) entities.Favorites {
+	if favorites == nil {
+		return entities.NewFavorites()
+	}
+	if favorites.Outfits == nil {
+		favorites.Outfits = make(map[string][]string)
+	}
+	return *favorites
+}
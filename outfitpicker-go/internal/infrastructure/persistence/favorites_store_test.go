@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestFavoritesStore(t *testing.T) *FavoritesStore {
+	t.Helper()
+	return NewFavoritesStore(system.WithDirectoryProvider[entities.Favorites](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestFavoritesStore_RoundTrip(t *testing.T) {
+	store := newTestFavoritesStore(t)
+
+	favorites, err := store.Load()
+	if err != nil || len(favorites.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want no favorites", favorites, err)
+	}
+	if err := store.Save(favorites.Adding("casual", "tee.avatar")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Contains("casual", "tee.avatar") || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestFavoritesStore_SaveRejectsStaleFavorites(t *testing.T) {
+	store := newTestFavoritesStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Adding("casual", "tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(stale.Adding("casual", "jeans.avatar"))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const forecastFileName = "forecast.json"
+
+// ForecastStore loads and saves forecast.json through a FileService.
+type ForecastStore struct {
+	fileService *system.FileService[entities.ForecastCache]
+}
+
+// NewForecastStore creates a forecast store. Options are forwarded to the
+// underlying FileService.
+func NewForecastStore(opts ...system.FileServiceOption[entities.ForecastCache]) *ForecastStore {
+	return &ForecastStore{
+		fileService: system.NewFileService(forecastFileName, opts...),
+	}
+}
+
+// Load returns the cached forecast, or a cache without a forecast if none
+// has been saved yet.
+func (s *ForecastStore) Load() (entities.ForecastCache, error) {
+	cache, err := s.fileService.Load()
+	if err != nil {
+		return entities.ForecastCache{}, errors.Wrap(err)
+	}
+	return normalizedForecast(cache), nil
+}
+
+// Save writes the cache if the saved file is still at cache.Revision. A
+// ConflictError is returned when another writer saved since cache was
+// loaded.
+func (s *ForecastStore) Save(cache entities.ForecastCache) error {
+	expected := cache.Revision
+	cache.Revision++
+	return compareAndSave(s.fileService, forecastFileName, expected, cache, func(current *entities.ForecastCache) int {
+		return normalizedForecast(current).Revision
+	})
+}
+
+func normalizedForecast(cache *entities.ForecastCache) entities.ForecastCache {
+	if cache == nil {
+		return entities.ForecastCache{}
+	}
+	return *cache
+}
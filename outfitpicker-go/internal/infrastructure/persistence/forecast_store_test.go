@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestForecastStore(t *testing.T) *ForecastStore {
+	t.Helper()
+	return NewForecastStore(system.WithDirectoryProvider[entities.ForecastCache](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestForecastStore_RoundTrip(t *testing.T) {
+	store := newTestForecastStore(t)
+
+	cache, err := store.Load()
+	if err != nil || cache.Forecast != nil {
+		t.Fatalf("Load() = %+v, %v; want no forecast", cache, err)
+	}
+	cache.Forecast = &entities.Forecast{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), MaxTemperature: 21.5, PrecipitationChance: 40}
+	if err := store.Save(cache); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Forecast == nil || loaded.Forecast.MaxTemperature != 21.5 || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestForecastStore_SaveRejectsStaleCache(t *testing.T) {
+	store := newTestForecastStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
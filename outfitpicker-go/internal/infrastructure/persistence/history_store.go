@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const historyFileName = "history.json"
+
+// HistoryStore loads and saves history.json through a FileService.
+type HistoryStore struct {
+	fileService *system.FileService[entities.SelectionHistory]
+}
+
+// NewHistoryStore creates a selection history store. Options are forwarded
+// to the underlying FileService.
+func NewHistoryStore(opts ...system.FileServiceOption[entities.SelectionHistory]) *HistoryStore {
+	return &HistoryStore{
+		fileService: system.NewFileService(historyFileName, opts...),
+	}
+}
+
+// Load returns the saved history, or an empty history if none has been saved
+// yet.
+func (s *HistoryStore) Load() (entities.SelectionHistory, error) {
+	history, err := s.fileService.Load()
+	if err != nil {
+		return entities.SelectionHistory{}, errors.Wrap(err)
+	}
+	if history == nil {
+		return entities.SelectionHistory{}, nil
+	}
+	return *history, nil
+}
+
+// Save writes the history if the saved file is still at history.Revision. A
+// ConflictError is returned when another writer saved since history was
+// loaded.
+func (s *HistoryStore) Save(history entities.SelectionHistory) error {
+	expected := history.Revision
+	history.Revision++
+	return compareAndSave(s.fileService, historyFileName, expected, history, func(current *entities.SelectionHistory) int {
+		if current == nil {
+			return 0
+		}
+		return current.Revision
+	})
+}
+
+// Query returns one page of the saved records that match query, newest
+// first. Filtering and paging happen here so callers only ever receive the
+// requested page.
+func (s *HistoryStore) Query(query entities.HistoryQuery) (entities.Page[entities.SelectionRecord], error) {
+	history, err := s.Load()
+	if err != nil {
+		return entities.Page[entities.SelectionRecord]{}, err
+	}
+	return history.Query(query), nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func TestHistoryStore_RoundTripAndQuery(t *testing.T) {
+	store := NewHistoryStore(system.WithDirectoryProvider[entities.SelectionHistory](system.NewStaticDirectoryProvider(t.TempDir())))
+
+	history, err := store.Load()
+	if err != nil || len(history.Records) != 0 {
+		t.Fatalf("Load() = %+v, %v; want empty history", history, err)
+	}
+
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	updated := history
+	for i, name := range []string{"tee.avatar", "jeans.avatar", "hoodie.avatar"} {
+		updated = updated.Appending(entities.SelectionRecord{Category: "casual", FileName: name, SelectedAt: start.AddDate(0, 0, i)})
+	}
+	if err := store.Save(updated); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	page, err := store.Query(entities.HistoryQuery{Since: start.AddDate(0, 0, 1), Limit: 1, Page: 1})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if page.Total != 2 || page.Items[0].FileName != "hoodie.avatar" || !page.Items[0].SelectedAt.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("Query() = %+v", page)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(history.Appending(entities.SelectionRecord{})); !errors.As(err, &conflict) {
+		t.Errorf("Save(stale) error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// latestPicksFileName is where the latest picks are kept in the state
+// directory of a profile. Widgets read it directly, so it must not move.
+const latestPicksFileName = "picks/latest.json"
+
+// LatestPickStore loads and saves picks/latest.json through a FileService,
+// which replaces the file atomically so readers never see half of it.
+type LatestPickStore struct {
+	fileService *system.FileService[entities.LatestPicks]
+}
+
+// NewLatestPickStore creates a latest pick store. Options are forwarded to
+// the underlying FileService.
+func NewLatestPickStore(opts ...system.FileServiceOption[entities.LatestPicks]) *LatestPickStore {
+	return &LatestPickStore{
+		fileService: system.NewFileService(latestPicksFileName, opts...),
+	}
+}
+
+// Load returns the last pick of every category, or no picks if nothing has
+// been saved yet.
+func (s *LatestPickStore) Load() (entities.LatestPicks, error) {
+	picks, err := s.fileService.Load()
+	if err != nil {
+		return entities.LatestPicks{}, errors.Wrap(err)
+	}
+	return normalizedLatestPicks(picks), nil
+}
+
+// Save writes the picks if the saved file is still at picks.Revision. A
+// ConflictError is returned when another writer saved since picks was
+// loaded.
+func (s *LatestPickStore) Save(picks entities.LatestPicks) error {
+	expected := picks.Revision
+	picks.Revision++
+	return compareAndSave(s.fileService, latestPicksFileName, expected, picks, func(current *entities.LatestPicks) int {
+		return normalizedLatestPicks(current).Revision
+	})
+}
+
+func normalizedLatestPicks(picks *entities.LatestPicks) entities.LatestPicks {
+	if picks == nil {
+		return entities.NewLatestPicks()
+	}
+	if picks.Categories == nil {
+		picks.Categories = make(map[string]entities.LatestPick)
+	}
+	return *picks
+}
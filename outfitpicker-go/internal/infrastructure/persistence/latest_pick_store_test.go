@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func TestLatestPickStore_RoundTrip(t *testing.T) {
+	base := t.TempDir()
+	store := NewLatestPickStore(system.WithDirectoryProvider[entities.LatestPicks](system.NewStaticDirectoryProvider(base)))
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	picks, err := store.Load()
+	if err != nil || len(picks.Categories) != 0 || picks.Schema != entities.LatestPicksSchema {
+		t.Fatalf("Load() = %+v, %v; want no picks", picks, err)
+	}
+	if err := store.Save(picks.Recording("casual", entities.LatestPick{FileName: "tee.avatar", Path: "/w/casual/tee.avatar", PickedAt: now})); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Categories["casual"].FileName != "tee.avatar" || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	data, err := os.ReadFile(filepath.Join(base, "outfitpicker", "picks", "latest.json"))
+	if err != nil {
+		t.Fatalf("picks/latest.json not written: %v", err)
+	}
+	var file struct {
+		Schema     int `json:"schema"`
+		Categories map[string]struct {
+			FileName string    `json:"fileName"`
+			Path     string    `json:"path"`
+			PickedAt time.Time `json:"pickedAt"`
+		} `json:"categories"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if file.Schema != 1 || file.Categories["casual"].Path != "/w/casual/tee.avatar" || !file.Categories["casual"].PickedAt.Equal(now) {
+		t.Errorf("latest.json = %s", data)
+	}
+}
+
+func TestLatestPickStore_SaveRejectsStalePicks(t *testing.T) {
+	store := NewLatestPickStore(system.WithDirectoryProvider[entities.LatestPicks](system.NewStaticDirectoryProvider(t.TempDir())))
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Recording("casual", entities.LatestPick{PickedAt: time.Now()})); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale.Recording("work", entities.LatestPick{PickedAt: time.Now()})); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const laundryFileName = "laundry.json"
+
+// LaundryStore loads and saves laundry.json through a FileService.
+type LaundryStore struct {
+	fileService *system.FileService[entities.Laundry]
+}
+
+// NewLaundryStore creates a laundry store. Options are forwarded to the
+// underlying FileService.
+func NewLaundryStore(opts ...system.FileServiceOption[entities.Laundry]) *LaundryStore {
+	return &LaundryStore{
+		fileService: system.NewFileService(laundryFileName, opts...),
+	}
+}
+
+// Load returns the saved laundry states, or tracking that is off if none
+// have been saved yet.
+func (s *LaundryStore) Load() (entities.Laundry, error) {
+	laundry, err := s.fileService.Load()
+	if err != nil {
+		return entities.Laundry{}, errors.Wrap(err)
+	}
+	return normalizedLaundry(laundry), nil
+}
+
+// Save writes the laundry states if the saved file is still at
+// laundry.Revision. A ConflictError is returned when another writer saved
+// since laundry was loaded.
+func (s *LaundryStore) Save(laundry entities.Laundry) error {
+	expected := laundry.Revision
+	laundry.Revision++
+	return compareAndSave(s.fileService, laundryFileName, expected, laundry, func(current *entities.Laundry) int {
+		return normalizedLaundry(current).Revision
+	})
+}
+
+func normalizedLaundry(laundry *entities.Laundry) entities.Laundry {
+	if laundry == nil {
+		return entities.NewLaundry()
+	}
+	if laundry.Outfits == nil {
+		laundry.Outfits = make(map[string]map[string]entities.LaundryState)
+	}
+	return *laundry
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestLaundryStore(t *testing.T) *LaundryStore {
+	t.Helper()
+	return NewLaundryStore(system.WithDirectoryProvider[entities.Laundry](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestLaundryStore_RoundTrip(t *testing.T) {
+	store := newTestLaundryStore(t)
+
+	laundry, err := store.Load()
+	if err != nil || laundry.Enabled || len(laundry.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want tracking off", laundry, err)
+	}
+	laundry.Enabled = true
+	if err := store.Save(laundry.Wearing("casual", "tee.avatar")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Enabled || loaded.State("casual", "tee.avatar") != entities.LaundryWorn || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestLaundryStore_SaveRejectsStaleLaundry(t *testing.T) {
+	store := newTestLaundryStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Wearing("casual", "tee.avatar")); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(stale.Wearing("casual", "jeans.avatar"))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const maintenanceFileName = "maintenance.json"
+
+// MaintenanceStore loads and saves maintenance.json through a FileService.
+type MaintenanceStore struct {
+	fileService *system.FileService[entities.MaintenanceState]
+}
+
+// NewMaintenanceStore creates a maintenance store. Options are forwarded to
+// the underlying FileService.
+func NewMaintenanceStore(opts ...system.FileServiceOption[entities.MaintenanceState]) *MaintenanceStore {
+	return &MaintenanceStore{
+		fileService: system.NewFileService(maintenanceFileName, opts...),
+	}
+}
+
+// Load returns the saved maintenance state, or a disabled state if none has
+// been saved yet.
+func (s *MaintenanceStore) Load() (entities.MaintenanceState, error) {
+	state, err := s.fileService.Load()
+	if err != nil {
+		return entities.MaintenanceState{}, errors.Wrap(err)
+	}
+	if state == nil {
+		return entities.MaintenanceState{}, nil
+	}
+	return *state, nil
+}
+
+// Save writes the maintenance state. A disabled state removes the file.
+func (s *MaintenanceStore) Save(state entities.MaintenanceState) error {
+	if !state.Enabled {
+		return errors.Wrap(s.fileService.Delete())
+	}
+	return errors.Wrap(s.fileService.Save(state))
+}
@@ -0,0 +1,36 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func TestMaintenanceStore(t *testing.T) {
+	store := NewMaintenanceStore(system.WithDirectoryProvider[entities.MaintenanceState](system.NewStaticDirectoryProvider(t.TempDir())))
+
+	state, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if state.Enabled {
+		t.Error("Load() on empty store returned enabled state")
+	}
+
+	enabled := entities.NewMaintenanceState(entities.WardrobeSnapshot{"casual": {"tee.avatar"}}, time.Now())
+	if err := store.Save(enabled); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if state, _ = store.Load(); !state.Enabled || len(state.Snapshot["casual"]) != 1 {
+		t.Errorf("Load() = %+v, want enabled state with snapshot", state)
+	}
+
+	if err := store.Save(entities.MaintenanceState{}); err != nil {
+		t.Fatalf("Save() disabled error = %v", err)
+	}
+	if state, _ = store.Load(); state.Enabled {
+		t.Error("Load() after disabling returned enabled state")
+	}
+}
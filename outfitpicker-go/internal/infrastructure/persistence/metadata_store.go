@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const metadataFileName = "metadata.json"
+
+// MetadataStore loads and saves metadata.json through a FileService.
+type MetadataStore struct {
+	fileService *system.FileService[entities.MetadataIndex]
+}
+
+// NewMetadataStore creates a metadata store. Options are forwarded to the
+// underlying FileService.
+func NewMetadataStore(opts ...system.FileServiceOption[entities.MetadataIndex]) *MetadataStore {
+	return &MetadataStore{
+		fileService: system.NewFileService(metadataFileName, opts...),
+	}
+}
+
+// Load returns the saved metadata, or an empty index if none has been saved
+// yet.
+func (s *MetadataStore) Load() (entities.MetadataIndex, error) {
+	index, err := s.fileService.Load()
+	if err != nil {
+		return entities.MetadataIndex{}, errors.Wrap(err)
+	}
+	return normalizedMetadata(index), nil
+}
+
+// Save writes the metadata if the saved file is still at index.Revision. A
+// ConflictError is returned when another writer saved since index was loaded.
+func (s *MetadataStore) Save(index entities.MetadataIndex) error {
+	expected := index.Revision
+	index.Revision++
+	return compareAndSave(s.fileService, metadataFileName, expected, index, func(current *entities.MetadataIndex) int {
+		return normalizedMetadata(current).Revision
+	})
+}
+
+func normalizedMetadata(index *entities.MetadataIndex) entities.MetadataIndex {
+	if index == nil {
+		return entities.NewMetadataIndex()
+	}
+	if index.Outfits == nil {
+		index.Outfits = make(map[string]map[string]entities.OutfitMetadata)
+	}
+	return *index
+}
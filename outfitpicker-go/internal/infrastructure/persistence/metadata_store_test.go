@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestMetadataStore(t *testing.T) *MetadataStore {
+	t.Helper()
+	return NewMetadataStore(system.WithDirectoryProvider[entities.MetadataIndex](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestMetadataStore_RoundTrip(t *testing.T) {
+	store := newTestMetadataStore(t)
+
+	index, err := store.Load()
+	if err != nil || len(index.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want empty index", index, err)
+	}
+
+	metadata := entities.OutfitMetadata{
+		Materials: []entities.MaterialComponent{{Fiber: "cotton", Percent: 100}},
+		Care:      []string{"wash-40"},
+	}
+	if err := store.Save(index.Setting("casual", "tee.avatar", metadata)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := loaded.Get("casual", "tee.avatar")
+	if !ok || got.Materials[0].Fiber != "cotton" || got.Care[0] != "wash-40" || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestMetadataStore_SaveRejectsStaleIndex(t *testing.T) {
+	store := newTestMetadataStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Setting("casual", "tee.avatar", entities.OutfitMetadata{Care: []string{"wash-30"}})); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(stale.Setting("casual", "jeans.avatar", entities.OutfitMetadata{Care: []string{"wash-40"}}))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const planFileName = "plan.json"
+
+// PlanStore loads and saves plan.json through a FileService.
+type PlanStore struct {
+	fileService *system.FileService[entities.OutfitPlan]
+}
+
+// NewPlanStore creates a plan store. Options are forwarded to the
+// underlying FileService.
+func NewPlanStore(opts ...system.FileServiceOption[entities.OutfitPlan]) *PlanStore {
+	return &PlanStore{
+		fileService: system.NewFileService(planFileName, opts...),
+	}
+}
+
+// Load returns the outfit plan, or a plan without days if none has been
+// saved yet.
+func (s *PlanStore) Load() (entities.OutfitPlan, error) {
+	plan, err := s.fileService.Load()
+	if err != nil {
+		return entities.OutfitPlan{}, errors.Wrap(err)
+	}
+	return normalizedPlan(plan), nil
+}
+
+// Save writes the plan if the saved file is still at plan.Revision. A
+// ConflictError is returned when another writer saved since plan was
+// loaded.
+func (s *PlanStore) Save(plan entities.OutfitPlan) error {
+	expected := plan.Revision
+	plan.Revision++
+	return compareAndSave(s.fileService, planFileName, expected, plan, func(current *entities.OutfitPlan) int {
+		return normalizedPlan(current).Revision
+	})
+}
+
+func normalizedPlan(plan *entities.OutfitPlan) entities.OutfitPlan {
+	if plan == nil {
+		return entities.OutfitPlan{}
+	}
+	return *plan
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestPlanStore(t *testing.T) *PlanStore {
+	t.Helper()
+	return NewPlanStore(system.WithDirectoryProvider[entities.OutfitPlan](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestPlanStore_RoundTrip(t *testing.T) {
+	store := newTestPlanStore(t)
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	plan, err := store.Load()
+	if err != nil || len(plan.Days) != 0 {
+		t.Fatalf("Load() = %+v, %v; want no days", plan, err)
+	}
+	plan.Days = []entities.PlanDay{{Date: day, Outfits: []entities.PlannedOutfit{{Category: "casual", FileName: "tee.avatar"}}}}
+	if err := store.Save(plan); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Days) != 1 || loaded.Days[0].Outfits[0].FileName != "tee.avatar" || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestPlanStore_SaveRejectsStalePlan(t *testing.T) {
+	store := newTestPlanStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
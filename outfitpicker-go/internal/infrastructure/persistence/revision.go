@@ -0,0 +1,19 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// compareAndSave writes next through fileService only if the stored revision,
+// read with revisionOf, still equals expected. Otherwise a ConflictError
+// naming fileName is returned and nothing is written.
+func compareAndSave[T any](fileService *system.FileService[T], fileName string, expected int, next T, revisionOf func(current *T) int) error {
+	return errors.Wrap(fileService.Update(func(current *T) (T, error) {
+		if actual := revisionOf(current); actual != expected {
+			var zero T
+			return zero, errors.NewConflictError(fileName, expected, actual)
+		}
+		return next, nil
+	}))
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const skippedOutfitsFileName = "skipped.json"
+
+// SkippedOutfitsStore loads and saves skipped.json through a FileService.
+type SkippedOutfitsStore struct {
+	fileService *system.FileService[entities.SkippedOutfits]
+}
+
+// NewSkippedOutfitsStore creates a skipped outfits store. Options are
+// forwarded to the underlying FileService.
+func NewSkippedOutfitsStore(opts ...system.FileServiceOption[entities.SkippedOutfits]) *SkippedOutfitsStore {
+	return &SkippedOutfitsStore{
+		fileService: system.NewFileService(skippedOutfitsFileName, opts...),
+	}
+}
+
+// Load returns the saved skipped outfits, or none if none have been saved
+// yet.
+func (s *SkippedOutfitsStore) Load() (entities.SkippedOutfits, error) {
+	skipped, err := s.fileService.Load()
+	if err != nil {
+		return entities.SkippedOutfits{}, errors.Wrap(err)
+	}
+	return normalizedSkippedOutfits(skipped), nil
+}
+
+// Save writes the skipped outfits if the saved file is still at
+// skipped.Revision. A ConflictError is returned when another writer saved
+// since skipped was loaded.
+func (s *SkippedOutfitsStore) Save(skipped entities.SkippedOutfits) error {
+	expected := skipped.Revision
+	skipped.Revision++
+	return compareAndSave(s.fileService, skippedOutfitsFileName, expected, skipped, func(current *entities.SkippedOutfits) int {
+		return normalizedSkippedOutfits(current).Revision
+	})
+}
+
+func normalizedSkippedOutfits(skipped *entities.SkippedOutfits) entities.SkippedOutfits {
+	if skipped == nil {
+		return entities.NewSkippedOutfits()
+	}
+	if skipped.Outfits == nil {
+		skipped.Outfits = make(map[string]map[string]time.Time)
+	}
+	return *skipped
+}
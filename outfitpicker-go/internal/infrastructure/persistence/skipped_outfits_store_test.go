@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestSkippedOutfitsStore(t *testing.T) *SkippedOutfitsStore {
+	t.Helper()
+	return NewSkippedOutfitsStore(system.WithDirectoryProvider[entities.SkippedOutfits](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestSkippedOutfitsStore_RoundTrip(t *testing.T) {
+	store := newTestSkippedOutfitsStore(t)
+
+	skipped, err := store.Load()
+	if err != nil || len(skipped.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want no skipped outfits", skipped, err)
+	}
+	until := time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)
+	if err := store.Save(skipped.Skipping("casual", "tee.avatar", until).Skipping("casual", "jeans.avatar", time.Time{})); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Outfits["casual"]["tee.avatar"]; !got.Equal(until) || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v, want tee.avatar skipped until %v", loaded, until)
+	}
+	if got, ok := loaded.Outfits["casual"]["jeans.avatar"]; !ok || !got.IsZero() {
+		t.Errorf("Load() = %+v, want jeans.avatar skipped until unskipped", loaded)
+	}
+}
+
+func TestSkippedOutfitsStore_SaveRejectsStaleSkips(t *testing.T) {
+	store := newTestSkippedOutfitsStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Skipping("casual", "tee.avatar", time.Time{})); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(stale.Skipping("casual", "jeans.avatar", time.Time{}))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
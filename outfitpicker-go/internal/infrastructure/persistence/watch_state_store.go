@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const watchStateFileName = "watch_state.json"
+
+// WatchStateStore loads and saves watch_state.json through a FileService.
+type WatchStateStore struct {
+	fileService *system.FileService[entities.WatchState]
+}
+
+// NewWatchStateStore creates a watch state store. Options are forwarded to the
+// underlying FileService.
+func NewWatchStateStore(opts ...system.FileServiceOption[entities.WatchState]) *WatchStateStore {
+	return &WatchStateStore{
+		fileService: system.NewFileService(watchStateFileName, opts...),
+	}
+}
+
+// Load returns the watch state, or an empty state if none has been saved
+// yet.
+func (s *WatchStateStore) Load() (entities.WatchState, error) {
+	state, err := s.fileService.Load()
+	if err != nil {
+		return entities.WatchState{}, errors.Wrap(err)
+	}
+	return normalizedWatchState(state), nil
+}
+
+// Save writes the state if the saved file is still at state.Revision. A
+// ConflictError is returned when another writer saved since state was
+// loaded.
+func (s *WatchStateStore) Save(state entities.WatchState) error {
+	expected := state.Revision
+	state.Revision++
+	return compareAndSave(s.fileService, watchStateFileName, expected, state, func(current *entities.WatchState) int {
+		return normalizedWatchState(current).Revision
+	})
+}
+
+func normalizedWatchState(state *entities.WatchState) entities.WatchState {
+	if state == nil {
+		return entities.WatchState{}
+	}
+	return *state
+}
@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestWatchStateStore(t *testing.T) *WatchStateStore {
+	t.Helper()
+	return NewWatchStateStore(system.WithDirectoryProvider[entities.WatchState](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestWatchStateStore_RoundTrip(t *testing.T) {
+	store := newTestWatchStateStore(t)
+
+	state, err := store.Load()
+	if err != nil || !state.LastPickDue.IsZero() {
+		t.Fatalf("Load() = %+v, %v; want an empty state", state, err)
+	}
+	due := time.Date(2024, 6, 1, 7, 30, 0, 0, time.UTC)
+	state.LastPickDue = due
+	if err := store.Save(state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.LastPickDue.Equal(due) || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestWatchStateStore_SaveRejectsStaleState(t *testing.T) {
+	store := newTestWatchStateStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale); err != nil {
+		t.Fatal(err)
+	}
+
+	var conflict *domainerrors.ConflictError
+	if err := store.Save(stale); !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const wearLogFileName = "wear_log.json"
+
+// WearLogStore loads and saves wear_log.json through a FileService.
+type WearLogStore struct {
+	fileService *system.FileService[entities.WearLog]
+}
+
+// NewWearLogStore creates a wear log store. Options are forwarded to the
+// underlying FileService.
+func NewWearLogStore(opts ...system.FileServiceOption[entities.WearLog]) *WearLogStore {
+	return &WearLogStore{
+		fileService: system.NewFileService(wearLogFileName, opts...),
+	}
+}
+
+// Load returns the saved wear log, or an empty log if none has been saved yet.
+func (s *WearLogStore) Load() (entities.WearLog, error) {
+	log, err := s.fileService.Load()
+	if err != nil {
+		return entities.WearLog{}, errors.Wrap(err)
+	}
+	if log == nil {
+		return entities.WearLog{}, nil
+	}
+	return *log, nil
+}
+
+// Save writes the wear log if the saved file is still at log.Revision. A
+// ConflictError is returned when another writer saved since log was loaded.
+func (s *WearLogStore) Save(log entities.WearLog) error {
+	expected := log.Revision
+	log.Revision++
+	return compareAndSave(s.fileService, wearLogFileName, expected, log, func(current *entities.WearLog) int {
+		if current == nil {
+			return 0
+		}
+		return current.Revision
+	})
+}
+
+// Query returns one page of the saved wear events that match query, newest
+// first. Filtering and paging happen here so callers only ever receive the
+// requested page.
+func (s *WearLogStore) Query(query entities.HistoryQuery) (entities.Page[entities.WearEvent], error) {
+	log, err := s.Load()
+	if err != nil {
+		return entities.Page[entities.WearEvent]{}, err
+	}
+	return log.Query(query), nil
+}
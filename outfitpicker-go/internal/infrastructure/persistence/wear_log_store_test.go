@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func TestWearLogStore_RoundTrip(t *testing.T) {
+	store := NewWearLogStore(system.WithDirectoryProvider[entities.WearLog](system.NewStaticDirectoryProvider(t.TempDir())))
+
+	log, err := store.Load()
+	if err != nil || len(log.Events) != 0 {
+		t.Fatalf("Load() = %+v, %v; want empty log", log, err)
+	}
+
+	wornAt := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	event := entities.WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: wornAt, Feedback: []entities.FeedbackKind{entities.FeedbackCompliment}}
+	if err := store.Save(log.Appending(event)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Events) != 1 || !loaded.Events[0].WornAt.Equal(wornAt) || loaded.Events[0].Feedback[0] != entities.FeedbackCompliment {
+		t.Errorf("Load() = %+v", loaded)
+	}
+
+	err = store.Save(log.Appending(event))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save(stale) error = %v, want ConflictError", err)
+	}
+}
+
+func TestWearLogStore_Query(t *testing.T) {
+	store := NewWearLogStore(system.WithDirectoryProvider[entities.WearLog](system.NewStaticDirectoryProvider(t.TempDir())))
+	start := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	var log entities.WearLog
+	for i := range 5 {
+		log = log.Appending(entities.WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: start.AddDate(0, 0, i)})
+	}
+	if err := store.Save(log); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := store.Query(entities.HistoryQuery{Since: start.AddDate(0, 0, 1), Limit: 3, Page: 2})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if page.Total != 4 || len(page.Items) != 1 || !page.Items[0].WornAt.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("Query() = %+v", page)
+	}
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+const weightsFileName = "outfit_meta.json"
+
+// WeightStore loads and saves outfit_meta.json through a FileService.
+type WeightStore struct {
+	fileService *system.FileService[entities.OutfitWeights]
+}
+
+// NewWeightStore creates a weight store. Options are forwarded to the
+// underlying FileService.
+func NewWeightStore(opts ...system.FileServiceOption[entities.OutfitWeights]) *WeightStore {
+	return &WeightStore{
+		fileService: system.NewFileService(weightsFileName, opts...),
+	}
+}
+
+// Load returns the saved weights, or empty weights if none have been saved
+// yet.
+func (s *WeightStore) Load() (entities.OutfitWeights, error) {
+	weights, err := s.fileService.Load()
+	if err != nil {
+		return entities.OutfitWeights{}, errors.Wrap(err)
+	}
+	return normalizedWeights(weights), nil
+}
+
+// Save writes the weights if the saved file is still at weights.Revision. A
+// ConflictError is returned when another writer saved since weights was
+// loaded.
+func (s *WeightStore) Save(weights entities.OutfitWeights) error {
+	expected := weights.Revision
+	weights.Revision++
+	return compareAndSave(s.fileService, weightsFileName, expected, weights, func(current *entities.OutfitWeights) int {
+		return normalizedWeights(current).Revision
+	})
+}
+
+func normalizedWeights(weights *entities.OutfitWeights) entities.OutfitWeights {
+	if weights == nil {
+		return entities.NewOutfitWeights()
+	}
+	if weights.Outfits == nil {
+		weights.Outfits = make(map[string]map[string]float64)
+	}
+	return *weights
+}
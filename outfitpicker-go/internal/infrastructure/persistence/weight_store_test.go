@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+func newTestWeightStore(t *testing.T) *WeightStore {
+	t.Helper()
+	return NewWeightStore(system.WithDirectoryProvider[entities.OutfitWeights](system.NewStaticDirectoryProvider(t.TempDir())))
+}
+
+func TestWeightStore_RoundTrip(t *testing.T) {
+	store := newTestWeightStore(t)
+
+	weights, err := store.Load()
+	if err != nil || len(weights.Outfits) != 0 {
+		t.Fatalf("Load() = %+v, %v; want empty weights", weights, err)
+	}
+	if err := store.Save(weights.Setting("casual", "tee.avatar", 2.5)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Weight("casual", "tee.avatar"); got != 2.5 || loaded.Revision != 1 {
+		t.Errorf("Load() = %+v", loaded)
+	}
+}
+
+func TestWeightStore_SaveRejectsStaleWeights(t *testing.T) {
+	store := newTestWeightStore(t)
+	stale, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(stale.Setting("casual", "tee.avatar", 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(stale.Setting("casual", "jeans.avatar", 3))
+	var conflict *domainerrors.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Errorf("Save() error = %v, want ConflictError", err)
+	}
+}
@@ -0,0 +1,204 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// ArchiveImporter extracts outfits from .zip, .tar, .tar.gz and .tgz files
+// on the local filesystem.
+type ArchiveImporter struct{}
+
+// NewArchiveImporter creates a new archive importer.
+func NewArchiveImporter() *ArchiveImporter {
+	return &ArchiveImporter{}
+}
+
+// archiveEntry is one entry of an archive being walked.
+type archiveEntry struct {
+	name    string
+	regular bool
+	open    func() (io.ReadCloser, error)
+}
+
+// Import extracts every outfit entry of the archive into categoryDir. Nested
+// directories are flattened, entries with unsafe paths, links and other
+// non-regular entries are skipped, the manifest of an outfit pack is passed
+// over, and an outfit whose name is taken is saved under a numbered name
+// instead. Outfits extracted before an error are kept and reported. visited,
+// when not nil, is called after each entry.
+func (i *ArchiveImporter) Import(archivePath, categoryDir string, visited func(entry string)) (entities.ArchiveImport, error) {
+	result := entities.ArchiveImport{}
+	if _, err := os.Stat(archivePath); os.IsNotExist(err) {
+		return result, fmt.Errorf("%w: %s", domainerrors.ErrFileNotFound, archivePath)
+	}
+	if err := os.MkdirAll(categoryDir, 0755); err != nil {
+		return result, mapFileSystemError(err, categoryDir)
+	}
+
+	err := walkArchive(archivePath, func(entry archiveEntry) error {
+		if visited != nil {
+			defer visited(entry.name)
+		}
+		// A pack's manifest describes the outfits rather than being one.
+		if strings.HasSuffix(entry.name, "/") || entry.name == entities.PackManifestName {
+			return nil
+		}
+		fileName, reason := logic.ArchiveEntryOutfitName(entry.name)
+		if reason == "" && !entry.regular {
+			reason = logic.SkipNotRegular
+		}
+		if reason != "" {
+			result.Skipped = append(result.Skipped, entities.SkippedEntry{Entry: entry.name, Reason: reason})
+			return nil
+		}
+
+		target, ok, err := extractOutfit(entry, categoryDir, fileName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			result.Skipped = append(result.Skipped, entities.SkippedEntry{Entry: entry.name, Reason: logic.SkipTooLarge})
+			return nil
+		}
+		saved := filepath.Base(target)
+		result.Imported = append(result.Imported, entities.ImportedOutfit{Entry: entry.name, FileName: saved, Renamed: saved != fileName})
+		return nil
+	})
+	return result, err
+}
+
+// extractOutfit writes the entry to a free name in dir. It returns false,
+// leaving nothing behind, when the entry exceeds MaxImportedOutfitSize.
+func extractOutfit(entry archiveEntry, dir, fileName string) (string, bool, error) {
+	source, err := entry.open()
+	if err != nil {
+		return "", false, archiveError(err)
+	}
+	defer source.Close()
+
+	var target string
+	var file *os.File
+	for {
+		if target, err = availablePath(dir, fileName); err != nil {
+			return "", false, err
+		}
+		// O_EXCL keeps a concurrently created file of the same name intact.
+		file, err = os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !os.IsExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return "", false, mapFileSystemError(err, target)
+	}
+
+	written, err := io.Copy(file, io.LimitReader(source, logic.MaxImportedOutfitSize+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || written > logic.MaxImportedOutfitSize {
+		os.Remove(target)
+	}
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return "", false, mapFileSystemError(err, target)
+	}
+	if err != nil {
+		return "", false, archiveError(err)
+	}
+	return target, written <= logic.MaxImportedOutfitSize, nil
+}
+
+// walkArchive calls visit for each entry of the archive, choosing the format
+// from the file extension.
+func walkArchive(archivePath string, visit func(archiveEntry) error) error {
+	lower := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return walkZip(archivePath, visit)
+	case strings.HasSuffix(lower, ".tar"):
+		return walkTar(archivePath, false, visit)
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return walkTar(archivePath, true, visit)
+	default:
+		return domainerrors.NewInvalidInputError(fmt.Sprintf("unsupported archive %s (want .zip, .tar, .tar.gz or .tgz)", filepath.Base(archivePath)))
+	}
+}
+
+func walkZip(archivePath string, visit func(archiveEntry) error) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return archiveError(err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		entry := archiveEntry{
+			name:    file.Name,
+			regular: file.Mode().IsRegular(),
+			open:    file.Open,
+		}
+		if err := visit(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkTar(archivePath string, compressed bool, visit func(archiveEntry) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return mapFileSystemError(err, archivePath)
+	}
+	defer file.Close()
+
+	var source io.Reader = file
+	if compressed {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return archiveError(err)
+		}
+		defer gz.Close()
+		source = gz
+	}
+
+	reader := tar.NewReader(source)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return archiveError(err)
+		}
+		entry := archiveEntry{
+			name:    header.Name,
+			regular: header.Typeflag == tar.TypeReg,
+			open:    func() (io.ReadCloser, error) { return io.NopCloser(reader), nil },
+		}
+		if header.Typeflag == tar.TypeDir {
+			entry.name = strings.TrimSuffix(entry.name, "/") + "/"
+		}
+		if err := visit(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// archiveError reports an archive that cannot be read as invalid input.
+func archiveError(err error) error {
+	return domainerrors.NewInvalidInputError(fmt.Sprintf("cannot read archive: %v", err))
+}
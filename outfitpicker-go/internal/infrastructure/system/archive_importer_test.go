@@ -0,0 +1,148 @@
+package system
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+func writeZip(t *testing.T, path string, files map[string]string, symlinks ...string) {
+	t.Helper()
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	writer := zip.NewWriter(out)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(files[name]))
+	}
+	for _, name := range symlinks {
+		header := &zip.FileHeader{Name: name}
+		header.SetMode(os.ModeSymlink | 0777)
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte("/etc/passwd"))
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveImporter_Zip(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "pack.zip")
+	writeZip(t, archive, map[string]string{
+		"pack/tee.avatar":   "new tee",
+		"pack/jeans.avatar": "jeans",
+		"pack/readme.txt":   "hello",
+		"../evil.avatar":    "evil",
+	}, "pack/link.avatar")
+	category := filepath.Join(dir, "wardrobe", "casual")
+	mustWrite(t, filepath.Join(category, "tee.avatar"))
+
+	result, err := NewArchiveImporter().Import(archive, category, nil)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	wantImported := []entities.ImportedOutfit{
+		{Entry: "pack/jeans.avatar", FileName: "jeans.avatar"},
+		{Entry: "pack/tee.avatar", FileName: "tee-2.avatar", Renamed: true},
+	}
+	if !slices.Equal(result.Imported, wantImported) {
+		t.Errorf("Imported = %+v, want %+v", result.Imported, wantImported)
+	}
+	wantSkipped := []entities.SkippedEntry{
+		{Entry: "../evil.avatar", Reason: logic.SkipUnsafePath},
+		{Entry: "pack/readme.txt", Reason: logic.SkipNotOutfit},
+		{Entry: "pack/link.avatar", Reason: logic.SkipNotRegular},
+	}
+	if !slices.Equal(result.Skipped, wantSkipped) {
+		t.Errorf("Skipped = %+v, want %+v", result.Skipped, wantSkipped)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(category, "tee-2.avatar")); err != nil || string(data) != "new tee" {
+		t.Errorf("tee-2.avatar = %q, %v", data, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(category, "tee.avatar")); len(data) != 0 {
+		t.Errorf("existing tee.avatar was overwritten with %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "wardrobe", "evil.avatar")); !os.IsNotExist(err) {
+		t.Errorf("unsafe entry escaped the category, stat error = %v", err)
+	}
+}
+
+func TestArchiveImporter_TarGz(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "pack.tgz")
+	out, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(out)
+	writer := tar.NewWriter(gz)
+	for _, header := range []*tar.Header{
+		{Name: "pack/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "pack/suit.avatar", Typeflag: tar.TypeReg, Mode: 0644, Size: 4},
+		{Name: "pack/link.avatar", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+	} {
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if header.Size > 0 {
+			writer.Write([]byte("suit"))
+		}
+	}
+	writer.Close()
+	gz.Close()
+	out.Close()
+
+	category := filepath.Join(dir, "formal")
+	result, err := NewArchiveImporter().Import(archive, category, nil)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if len(result.Imported) != 1 || result.Imported[0].FileName != "suit.avatar" {
+		t.Errorf("Imported = %+v", result.Imported)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != logic.SkipNotRegular {
+		t.Errorf("Skipped = %+v", result.Skipped)
+	}
+	if data, err := os.ReadFile(filepath.Join(category, "suit.avatar")); err != nil || string(data) != "suit" {
+		t.Errorf("suit.avatar = %q, %v", data, err)
+	}
+}
+
+func TestArchiveImporter_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewArchiveImporter().Import(filepath.Join(dir, "missing.zip"), filepath.Join(dir, "casual"), nil); !errors.Is(err, domainerrors.ErrFileNotFound) {
+		t.Errorf("missing archive error = %v, want ErrFileNotFound", err)
+	}
+
+	for _, name := range []string{"pack.rar", "corrupt.zip"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("not an archive"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var invalid *domainerrors.InvalidInputError
+		if _, err := NewArchiveImporter().Import(path, filepath.Join(dir, "casual"), nil); !errors.As(err, &invalid) {
+			t.Errorf("Import(%s) error = %v, want InvalidInputError", name, err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package system
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+const (
+	backupsDirName = "backups"
+	// backupIDFormat names a backup after when it was made, so IDs sort in
+	// time order.
+	backupIDFormat = "20060102-150405"
+)
+
+// BackupStore keeps each backup in its own timestamped directory under the
+// app's backups directory.
+type BackupStore struct {
+	directoryProvider DirectoryProvider
+	dataManager       defaultDataManager
+	profile           string
+}
+
+// NewBackupStore creates a backup store under the directory provider's base
+// directory.
+func NewBackupStore(dp DirectoryProvider) *BackupStore {
+	return NewProfileBackupStore(dp, "")
+}
+
+// NewProfileBackupStore creates a backup store in the directory of the
+// named profile.
+func NewProfileBackupStore(dp DirectoryProvider, profile string) *BackupStore {
+	return &BackupStore{directoryProvider: dp, profile: profile}
+}
+
+func (s *BackupStore) dir() (string, error) {
+	if _, inMemory := s.directoryProvider.(storageResolver); inMemory {
+		return "", domainerrors.NewInvalidInputError("backups are kept on disk, and this run keeps its state in memory")
+	}
+	dir, err := stateProfileDirectory(s.directoryProvider, s.profile)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, backupsDirName), nil
+}
+
+// Create copies those of paths that exist into a new backup. Backups made in
+// the same second get a numbered ID.
+func (s *BackupStore) Create(at time.Time, paths []string) (entities.Backup, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return entities.Backup{}, mapFileSystemError(err, dir)
+	}
+
+	var sources []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			sources = append(sources, path)
+		}
+	}
+	if len(sources) == 0 {
+		return entities.Backup{}, domainerrors.NewInvalidInputError("nothing to back up yet")
+	}
+
+	id := at.UTC().Format(backupIDFormat)
+	for n := 2; ; n++ {
+		err := os.Mkdir(filepath.Join(dir, id), 0700)
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) {
+			return entities.Backup{}, mapFileSystemError(err, dir)
+		}
+		id = fmt.Sprintf("%s-%d", at.UTC().Format(backupIDFormat), n)
+	}
+
+	backup := entities.Backup{ID: id, CreatedAt: at.UTC().Truncate(time.Second)}
+	for _, source := range sources {
+		name := filepath.Base(source)
+		if err := s.copy(source, filepath.Join(dir, id, name)); err != nil {
+			os.RemoveAll(filepath.Join(dir, id))
+			return entities.Backup{}, err
+		}
+		backup.Files = append(backup.Files, name)
+	}
+	return backup, nil
+}
+
+// List returns every backup, oldest first.
+func (s *BackupStore) List() ([]entities.Backup, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapFileSystemError(err, dir)
+	}
+
+	var backups []entities.Backup
+	for _, entry := range entries {
+		if backup, ok := s.read(dir, entry); ok {
+			backups = append(backups, backup)
+		}
+	}
+	slices.SortFunc(backups, func(a, b entities.Backup) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return backups, nil
+}
+
+// Restore copies the backup's files over the paths with the same names.
+// Paths the backup has no copy of are left alone.
+func (s *BackupStore) Restore(id string, paths []string) (entities.Backup, error) {
+	backup, err := s.find(id)
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	dir, err := s.dir()
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	for _, path := range paths {
+		if slices.Contains(backup.Files, filepath.Base(path)) {
+			if err := s.copy(filepath.Join(dir, id, filepath.Base(path)), path); err != nil {
+				return entities.Backup{}, err
+			}
+		}
+	}
+	return backup, nil
+}
+
+// Delete removes a backup.
+func (s *BackupStore) Delete(id string) error {
+	if _, err := s.find(id); err != nil {
+		return err
+	}
+	dir, err := s.dir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(dir, id)); err != nil {
+		return mapFileSystemError(err, filepath.Join(dir, id))
+	}
+	return nil
+}
+
+// find returns the backup with the given ID. IDs that are not backups,
+// including ones naming other paths, are rejected.
+func (s *BackupStore) find(id string) (entities.Backup, error) {
+	backups, err := s.List()
+	if err != nil {
+		return entities.Backup{}, err
+	}
+	for _, backup := range backups {
+		if backup.ID == id {
+			return backup, nil
+		}
+	}
+	return entities.Backup{}, domainerrors.NewInvalidInputError(fmt.Sprintf("no backup %q", id))
+}
+
+// read describes the backup directory entry. It reports false for entries
+// that are not backups.
+func (s *BackupStore) read(dir string, entry os.DirEntry) (entities.Backup, bool) {
+	id := entry.Name()
+	createdAt, ok := parseBackupID(id)
+	if !entry.IsDir() || !ok {
+		return entities.Backup{}, false
+	}
+	files, err := os.ReadDir(filepath.Join(dir, id))
+	if err != nil {
+		return entities.Backup{}, false
+	}
+	backup := entities.Backup{ID: id, CreatedAt: createdAt}
+	for _, file := range files {
+		if file.Type().IsRegular() && !strings.HasPrefix(file.Name(), ".") {
+			backup.Files = append(backup.Files, file.Name())
+		}
+	}
+	return backup, true
+}
+
+// parseBackupID returns when the backup with the given ID was made. IDs are
+// the time, followed by "-N" for the Nth backup made in the same second.
+func parseBackupID(id string) (time.Time, bool) {
+	if len(id) < len(backupIDFormat) {
+		return time.Time{}, false
+	}
+	createdAt, err := time.Parse(backupIDFormat, id[:len(backupIDFormat)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if suffix := id[len(backupIDFormat):]; suffix != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(suffix, "-"))
+		if !strings.HasPrefix(suffix, "-") || err != nil || n < 2 {
+			return time.Time{}, false
+		}
+	}
+	return createdAt, true
+}
+
+// copy copies source to target along with its signature, so a restored
+// file still passes its integrity check. A target whose source is unsigned
+// loses its signature.
+func (s *BackupStore) copy(source, target string) error {
+	if err := s.copyFile(source, target); err != nil {
+		return err
+	}
+	if _, err := os.Stat(signaturePath(source)); err == nil {
+		return s.copyFile(signaturePath(source), signaturePath(target))
+	}
+	if err := os.Remove(signaturePath(target)); err != nil && !os.IsNotExist(err) {
+		return mapFileSystemError(err, target)
+	}
+	return nil
+}
+
+func (s *BackupStore) copyFile(source, target string) error {
+	data, err := s.dataManager.Read(source)
+	if err != nil {
+		return mapFileSystemError(err, source)
+	}
+	if err := s.dataManager.WriteAtomic(target, data); err != nil {
+		return mapFileSystemError(err, target)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestBackupStore_CreateListRestore(t *testing.T) {
+	base := t.TempDir()
+	store := NewBackupStore(NewStaticDirectoryProvider(base))
+	configPath := filepath.Join(base, appName, "config.json")
+	cachePath := filepath.Join(base, appName, "cache.json")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	first, err := store.Create(at, []string{configPath, cachePath})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.ID != "20240601-090000" || len(first.Files) != 1 || first.Files[0] != "config.json" {
+		t.Errorf("Create() = %+v, want only the existing config backed up", first)
+	}
+	second, err := store.Create(at, []string{configPath})
+	if err != nil {
+		t.Fatalf("second Create() error = %v", err)
+	}
+	if second.ID != "20240601-090000-2" {
+		t.Errorf("second ID = %q, want a numbered ID", second.ID)
+	}
+
+	backups, err := store.List()
+	if err != nil || len(backups) != 2 || backups[0].ID != first.ID || !backups[0].CreatedAt.Equal(at) {
+		t.Fatalf("List() = %+v, %v", backups, err)
+	}
+
+	if err := os.WriteFile(configPath, []byte("changed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Restore(first.ID, []string{configPath, cachePath}); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if data, _ := os.ReadFile(configPath); string(data) != "original" {
+		t.Errorf("config after restore = %q", data)
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Errorf("Restore() created a cache the backup did not hold: %v", err)
+	}
+
+	if err := store.Delete(first.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if backups, _ := store.List(); len(backups) != 1 {
+		t.Errorf("List() after Delete() = %+v", backups)
+	}
+}
+
+func TestBackupStore_Errors(t *testing.T) {
+	base := t.TempDir()
+	store := NewBackupStore(NewStaticDirectoryProvider(base))
+
+	if backups, err := store.List(); err != nil || len(backups) != 0 {
+		t.Errorf("List() before any backup = %+v, %v", backups, err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := store.Create(time.Now(), []string{filepath.Join(base, "missing.json")}); !errors.As(err, &invalid) {
+		t.Errorf("Create() with nothing to back up error = %v, want InvalidInputError", err)
+	}
+	for _, id := range []string{"missing", "../outfitpicker", ""} {
+		if _, err := store.Restore(id, nil); !errors.As(err, &invalid) {
+			t.Errorf("Restore(%q) error = %v, want InvalidInputError", id, err)
+		}
+	}
+}
+
+func TestBackupStore_KeepsSignatures(t *testing.T) {
+	base := t.TempDir()
+	store := NewBackupStore(NewStaticDirectoryProvider(base))
+	configPath := filepath.Join(base, appName, "config.json")
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		t.Fatal(err)
+	}
+	for path, data := range map[string]string{configPath: "signed", signaturePath(configPath): "abc"} {
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backup, err := store.Create(time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC), []string{configPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backup.Files) != 1 {
+		t.Errorf("Create() files = %v, want the signature left out of the listing", backup.Files)
+	}
+
+	if err := os.WriteFile(signaturePath(configPath), []byte("changed"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Restore(backup.ID, []string{configPath}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(signaturePath(configPath)); string(data) != "abc" {
+		t.Errorf("restored signature = %q, want %q", data, "abc")
+	}
+}
@@ -0,0 +1,58 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// FileCategoryCreator makes category directories in the wardrobe.
+type FileCategoryCreator struct{}
+
+// NewFileCategoryCreator creates a new file category creator.
+func NewFileCategoryCreator() *FileCategoryCreator {
+	return &FileCategoryCreator{}
+}
+
+// Create makes categoryDir. Its parent, the wardrobe root, must exist.
+func (c *FileCategoryCreator) Create(categoryDir string) error {
+	if err := os.Mkdir(categoryDir, 0755); err != nil {
+		if os.IsExist(err) {
+			return domainerrors.NewInvalidInputError(fmt.Sprintf("%s already exists", categoryDir))
+		}
+		return mapFileSystemError(err, categoryDir)
+	}
+	return nil
+}
+
+// Copy copies the file at sourcePath to categoryDir. A file of the same name
+// in categoryDir is left alone and reported as an error.
+func (c *FileCategoryCreator) Copy(sourcePath, categoryDir string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return mapFileSystemError(err, sourcePath)
+	}
+	defer source.Close()
+
+	targetPath := filepath.Join(categoryDir, filepath.Base(sourcePath))
+	target, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return domainerrors.NewInvalidInputError(fmt.Sprintf("%s already exists", targetPath))
+		}
+		return mapFileSystemError(err, targetPath)
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		os.Remove(targetPath)
+		return mapFileSystemError(err, targetPath)
+	}
+	if err := target.Close(); err != nil {
+		os.Remove(targetPath)
+		return mapFileSystemError(err, targetPath)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestFileCategoryCreator(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "casual", "tee.avatar")
+	if err := os.MkdirAll(filepath.Dir(source), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(source, []byte("tee"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	creator := NewFileCategoryCreator()
+	dir := filepath.Join(root, "date night")
+
+	if err := creator.Create(dir); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if err := creator.Create(dir); !errors.As(err, &invalid) {
+		t.Errorf("Create() of an existing directory error = %v, want an InvalidInputError", err)
+	}
+
+	if err := creator.Copy(source, dir); err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "tee.avatar")); err != nil || string(data) != "tee" {
+		t.Errorf("copied file = %q, %v, want tee", data, err)
+	}
+	if err := creator.Copy(source, dir); !errors.As(err, &invalid) {
+		t.Errorf("Copy() over an existing file error = %v, want an InvalidInputError", err)
+	}
+	if err := creator.Copy(filepath.Join(root, "missing.avatar"), dir); !errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		t.Errorf("Copy() of a missing file error = %v, want ErrDirectoryNotFound", err)
+	}
+}
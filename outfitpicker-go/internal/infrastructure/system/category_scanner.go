@@ -0,0 +1,290 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/infrastructure/logging"
+)
+
+// CategoryScanner discovers categories and outfit files on the local filesystem.
+// Every immediate subdirectory of the root is a category; files nested deeper
+// are not part of any category.
+type CategoryScanner struct {
+	logger *slog.Logger
+}
+
+// CategoryScannerOption configures a CategoryScanner.
+type CategoryScannerOption func(*CategoryScanner)
+
+// WithScanLogger reports each scanned root and category to logger at debug
+// level. By default nothing is logged.
+func WithScanLogger(logger *slog.Logger) CategoryScannerOption {
+	return func(s *CategoryScanner) {
+		s.logger = logger
+	}
+}
+
+// NewCategoryScanner creates a new category scanner.
+func NewCategoryScanner(opts ...CategoryScannerOption) *CategoryScanner {
+	s := &CategoryScanner{logger: logging.Discard()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ScanCategories returns every category under the roots that policy and the
+// wardrobe's ignore files do not skip, sorted by name. A category whose name
+// an earlier root already has is named with entities.RootCategoryName. Each
+// ignore file is read once per scan. The scan stops with ctx.Err() once ctx
+// is done. A category's category.json, when it has one, fills in its
+// description.
+func (s *CategoryScanner) ScanCategories(ctx context.Context, roots []string, excludedCategories map[string]bool, policy entities.ScanPolicy) ([]entities.CategoryInfo, error) {
+	var infos []entities.CategoryInfo
+	seen := make(map[string]bool)
+	for i, rootPath := range roots {
+		entries, err := os.ReadDir(rootPath)
+		if err != nil {
+			return nil, mapFileSystemError(err, rootPath)
+		}
+		rules, err := loadIgnoreRules(rootPath, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		s.logger.Debug("scanning wardrobe root", "root", rootPath, "entries", len(entries))
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			dir := entry.Name()
+			if !entry.IsDir() {
+				continue
+			}
+			if rules.Ignores(dir, true) {
+				s.logger.Debug("skipped ignored category", "root", rootPath, "dir", dir)
+				continue
+			}
+			name := dir
+			if seen[dir] {
+				name = entities.RootCategoryName(dir, i)
+			}
+			seen[dir] = true
+			category := entities.NewCategoryReference(name, filepath.Join(rootPath, dir))
+
+			info := entities.NewCategoryInfo(category, entities.CategoryStateUserExcluded, 0)
+			if !excludedCategories[name] {
+				if info, err = s.inspectCategory(category, rules); err != nil {
+					return nil, err
+				}
+			}
+			if info.Description, err = readCategoryDescription(category.Path); err != nil {
+				return nil, err
+			}
+			s.logger.Debug("scanned category", "category", name, "state", info.State, "outfits", info.OutfitCount)
+			infos = append(infos, info)
+		}
+	}
+
+	slices.SortFunc(infos, func(a, b entities.CategoryInfo) int {
+		return strings.Compare(a.Category.Name, b.Category.Name)
+	})
+	return infos, nil
+}
+
+// GetOutfits returns the outfit files directly inside categoryPath that
+// policy and the wardrobe's ignore files do not skip, sorted by file name.
+func (s *CategoryScanner) GetOutfits(ctx context.Context, categoryPath string, policy entities.ScanPolicy) ([]entities.FileEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(categoryPath)
+	if err != nil {
+		return nil, mapFileSystemError(err, categoryPath)
+	}
+	rules, err := loadIgnoreRules(filepath.Dir(categoryPath), policy)
+	if err != nil {
+		return nil, err
+	}
+	category := filepath.Base(categoryPath)
+	if rules, err = withCategoryIgnoreFile(rules, category, categoryPath); err != nil {
+		return nil, err
+	}
+
+	var outfits []entities.FileEntry
+	for _, entry := range entries {
+		if entry.IsDir() || !logic.IsValidOutfitFile(entry.Name()) || rules.Ignores(category+"/"+entry.Name(), false) {
+			continue
+		}
+		outfits = append(outfits, entities.NewFileEntry(filepath.Join(categoryPath, entry.Name())))
+	}
+	return outfits, nil
+}
+
+// ReadColorFiles returns the colors listed in the color files directly
+// inside categoryPath, keyed by the outfit file each describes.
+func (s *CategoryScanner) ReadColorFiles(ctx context.Context, categoryPath string) (map[string][]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(categoryPath)
+	if err != nil {
+		return nil, mapFileSystemError(err, categoryPath)
+	}
+	colors := make(map[string][]string)
+	for _, entry := range entries {
+		outfit, ok := logic.ColorFileOutfit(entry.Name())
+		if entry.IsDir() || !ok {
+			continue
+		}
+		path := filepath.Join(categoryPath, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, mapFileSystemError(err, path)
+		}
+		colors[outfit] = logic.ParseColorFile(string(content))
+	}
+	return colors, nil
+}
+
+// ReadSidecars returns the sidecar metadata files directly inside
+// categoryPath, keyed by the outfit file each describes.
+func (s *CategoryScanner) ReadSidecars(ctx context.Context, categoryPath string) (map[string]entities.OutfitSidecar, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(categoryPath)
+	if err != nil {
+		return nil, mapFileSystemError(err, categoryPath)
+	}
+	sidecars := make(map[string]entities.OutfitSidecar)
+	for _, entry := range entries {
+		outfit, ok := entities.SidecarOutfit(entry.Name())
+		if entry.IsDir() || !ok || !logic.IsValidOutfitFile(outfit) {
+			continue
+		}
+		sidecar, err := readSidecar(filepath.Join(categoryPath, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sidecars[outfit] = sidecar
+	}
+	return sidecars, nil
+}
+
+func (s *CategoryScanner) inspectCategory(category entities.CategoryReference, rules logic.IgnoreRules) (entities.CategoryInfo, error) {
+	entries, err := os.ReadDir(category.Path)
+	if err != nil {
+		return entities.CategoryInfo{}, mapFileSystemError(err, category.Path)
+	}
+	dir := filepath.Base(category.Path)
+	if rules, err = withCategoryIgnoreFile(rules, dir, category.Path); err != nil {
+		return entities.CategoryInfo{}, err
+	}
+
+	files, outfits := 0, 0
+	for _, entry := range entries {
+		if entry.IsDir() || rules.Ignores(dir+"/"+entry.Name(), false) || entry.Name() == logic.IgnoreFileName || entry.Name() == entities.CategoryDescriptionFileName {
+			continue
+		}
+		files++
+		if logic.IsValidOutfitFile(entry.Name()) {
+			outfits++
+		}
+	}
+
+	state := entities.CategoryStateHasOutfits
+	switch {
+	case files == 0:
+		state = entities.CategoryStateEmpty
+	case outfits == 0:
+		state = entities.CategoryStateNoAvatarFiles
+	}
+	return entities.NewCategoryInfo(category, state, outfits), nil
+}
+
+// loadIgnoreRules combines policy with the ignore file at the wardrobe root,
+// if there is one.
+func loadIgnoreRules(rootPath string, policy entities.ScanPolicy) (logic.IgnoreRules, error) {
+	content, err := readIgnoreFile(rootPath)
+	if err != nil {
+		return logic.IgnoreRules{}, err
+	}
+	return logic.NewIgnoreRules(policy).WithFile("", content), nil
+}
+
+// withCategoryIgnoreFile extends rules with the category's own ignore file,
+// if there is one.
+func withCategoryIgnoreFile(rules logic.IgnoreRules, category, categoryPath string) (logic.IgnoreRules, error) {
+	content, err := readIgnoreFile(categoryPath)
+	if err != nil {
+		return logic.IgnoreRules{}, err
+	}
+	return rules.WithFile(category, content), nil
+}
+
+func readIgnoreFile(dir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, logic.IgnoreFileName))
+	if err != nil && !os.IsNotExist(err) {
+		return "", mapFileSystemError(err, dir)
+	}
+	return string(content), nil
+}
+
+// readCategoryDescription reads the category.json in dir, returning nil when
+// there is none.
+func readCategoryDescription(dir string) (*entities.CategoryDescription, error) {
+	path := filepath.Join(dir, entities.CategoryDescriptionFileName)
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapFileSystemError(err, path)
+	}
+	var description entities.CategoryDescription
+	if err := json.Unmarshal(content, &description); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", domainerrors.ErrInvalidCategoryDescription, path, err)
+	}
+	if err := description.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, path)
+	}
+	return &description, nil
+}
+
+// readSidecar reads and checks the sidecar metadata file at path.
+func readSidecar(path string) (entities.OutfitSidecar, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return entities.OutfitSidecar{}, mapFileSystemError(err, path)
+	}
+	var sidecar entities.OutfitSidecar
+	if err := json.Unmarshal(content, &sidecar); err != nil {
+		return entities.OutfitSidecar{}, fmt.Errorf("%w: %s: %v", domainerrors.ErrInvalidOutfitSidecar, path, err)
+	}
+	if err := sidecar.Validate(); err != nil {
+		return entities.OutfitSidecar{}, fmt.Errorf("%w: %s", err, path)
+	}
+	return sidecar, nil
+}
+
+func mapFileSystemError(err error, path string) error {
+	switch {
+	case os.IsNotExist(err):
+		return fmt.Errorf("%w: %s", domainerrors.ErrDirectoryNotFound, path)
+	case os.IsPermission(err):
+		return fmt.Errorf("%w: %s", domainerrors.ErrPermissionDenied, path)
+	default:
+		return fmt.Errorf("%w: %w", domainerrors.ErrOperationFailed, err)
+	}
+}
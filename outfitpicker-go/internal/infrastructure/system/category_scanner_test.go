@@ -0,0 +1,332 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/devtools"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+func TestCategoryScanner_ScanCategories(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", "jeans.AVATAR"))
+	mustWrite(t, filepath.Join(root, "casual", "notes.txt"))
+	mustWrite(t, filepath.Join(root, "docs", "readme.md"))
+	mustWrite(t, filepath.Join(root, "formal", "suit.avatar"))
+	mustWrite(t, filepath.Join(root, "loose.avatar"))
+	if err := os.MkdirAll(filepath.Join(root, "beach"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := NewCategoryScanner().ScanCategories(context.Background(), []string{root}, map[string]bool{"formal": true}, entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("ScanCategories() error = %v", err)
+	}
+
+	want := []struct {
+		name  string
+		state entities.CategoryState
+		count int
+	}{
+		{"beach", entities.CategoryStateEmpty, 0},
+		{"casual", entities.CategoryStateHasOutfits, 2},
+		{"docs", entities.CategoryStateNoAvatarFiles, 0},
+		{"formal", entities.CategoryStateUserExcluded, 0},
+	}
+	if len(infos) != len(want) {
+		t.Fatalf("ScanCategories() returned %d categories, want %d", len(infos), len(want))
+	}
+	for i, w := range want {
+		got := infos[i]
+		if got.Category.Name != w.name || got.State != w.state || got.OutfitCount != w.count {
+			t.Errorf("infos[%d] = %+v, want %s/%s/%d", i, got, w.name, w.state, w.count)
+		}
+		if got.Category.Path != filepath.Join(root, w.name) {
+			t.Errorf("infos[%d].Category.Path = %v", i, got.Category.Path)
+		}
+	}
+}
+
+func TestCategoryScanner_GetOutfits(t *testing.T) {
+	wardrobe := testhelpers.MustGenerateWardrobe(t, devtools.WardrobeSpec{
+		Shape:              devtools.ShapeDeep,
+		Categories:         1,
+		OutfitsPerCategory: 3,
+		ExtraFiles:         2,
+	})
+
+	outfits, err := NewCategoryScanner().GetOutfits(context.Background(), filepath.Join(wardrobe.Root, "category-000"), entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("GetOutfits() error = %v", err)
+	}
+	if len(outfits) != 3 {
+		t.Fatalf("GetOutfits() returned %d outfits, want 3", len(outfits))
+	}
+	for i, outfit := range outfits {
+		if outfit.FileName != wardrobe.Outfits["category-000"][i] {
+			t.Errorf("outfits[%d] = %v, want %v", i, outfit.FileName, wardrobe.Outfits["category-000"][i])
+		}
+	}
+}
+
+func TestCategoryScanner_HiddenAndIgnoredEntries(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", ".secret.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", ".DS_Store"))
+	mustWrite(t, filepath.Join(root, "casual", "tee.sync-conflict-20240601-090000-ABC.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", "tee.bak.avatar"))
+	mustWrite(t, filepath.Join(root, ".git", "HEAD.avatar"))
+	mustWrite(t, filepath.Join(root, ".archive", "old.avatar"))
+	mustWrite(t, filepath.Join(root, "drafts", "idea.avatar"))
+	writeIgnoreFile(t, root, "# work in progress\ndrafts\n")
+
+	tests := []struct {
+		name       string
+		policy     entities.ScanPolicy
+		categories []string
+		outfits    int
+	}{
+		{"default", entities.ScanPolicy{}, []string{"casual"}, 2},
+		{"include hidden", entities.ScanPolicy{IncludeHidden: true}, []string{".archive", "casual"}, 3},
+		{"config pattern", entities.ScanPolicy{Ignore: []string{"*.bak.avatar"}}, []string{"casual"}, 1},
+	}
+	scanner := NewCategoryScanner()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			infos, err := scanner.ScanCategories(context.Background(), []string{root}, nil, tt.policy)
+			if err != nil {
+				t.Fatalf("ScanCategories() error = %v", err)
+			}
+			var names []string
+			for _, info := range infos {
+				names = append(names, info.Category.Name)
+			}
+			if !slices.Equal(names, tt.categories) {
+				t.Errorf("ScanCategories() = %v, want %v", names, tt.categories)
+			}
+
+			outfits, err := scanner.GetOutfits(context.Background(), filepath.Join(root, "casual"), tt.policy)
+			if err != nil {
+				t.Fatalf("GetOutfits() error = %v", err)
+			}
+			if len(outfits) != tt.outfits || infos[len(infos)-1].OutfitCount != tt.outfits {
+				t.Errorf("GetOutfits() = %v, OutfitCount = %d, want %d outfits", outfits, infos[len(infos)-1].OutfitCount, tt.outfits)
+			}
+		})
+	}
+}
+
+func TestCategoryScanner_NestedIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", "tee.bak.avatar"))
+	mustWrite(t, filepath.Join(root, "casual", "jeans.bak.avatar"))
+	mustWrite(t, filepath.Join(root, "formal", "suit.bak.avatar"))
+	writeIgnoreFile(t, root, "*.bak.avatar\n")
+	writeIgnoreFile(t, filepath.Join(root, "casual"), "!jeans.bak.avatar\n")
+
+	scanner := NewCategoryScanner()
+	infos, err := scanner.ScanCategories(context.Background(), []string{root}, nil, entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("ScanCategories() error = %v", err)
+	}
+	if infos[0].OutfitCount != 2 || infos[1].State != entities.CategoryStateEmpty {
+		t.Errorf("ScanCategories() = %+v, want 2 casual outfits and an empty formal category", infos)
+	}
+
+	outfits, err := scanner.GetOutfits(context.Background(), filepath.Join(root, "casual"), entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("GetOutfits() error = %v", err)
+	}
+	var names []string
+	for _, outfit := range outfits {
+		names = append(names, outfit.FileName)
+	}
+	if want := []string{"jeans.bak.avatar", "tee.avatar"}; !slices.Equal(names, want) {
+		t.Errorf("GetOutfits() = %v, want %v", names, want)
+	}
+}
+
+func TestCategoryScanner_MultipleRoots(t *testing.T) {
+	personal, shared := t.TempDir(), t.TempDir()
+	mustWrite(t, filepath.Join(personal, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(shared, "casual", "hoodie.avatar"))
+	mustWrite(t, filepath.Join(shared, "formal", "suit.avatar"))
+	mustWrite(t, filepath.Join(shared, "formal", "tie.avatar"))
+
+	infos, err := NewCategoryScanner().ScanCategories(context.Background(), []string{personal, shared}, map[string]bool{"casual@2": true}, entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("ScanCategories() error = %v", err)
+	}
+	want := []entities.CategoryInfo{
+		entities.NewCategoryInfo(entities.NewCategoryReference("casual", filepath.Join(personal, "casual")), entities.CategoryStateHasOutfits, 1),
+		entities.NewCategoryInfo(entities.NewCategoryReference("casual@2", filepath.Join(shared, "casual")), entities.CategoryStateUserExcluded, 0),
+		entities.NewCategoryInfo(entities.NewCategoryReference("formal", filepath.Join(shared, "formal")), entities.CategoryStateHasOutfits, 2),
+	}
+	if !slices.Equal(infos, want) {
+		t.Errorf("ScanCategories() = %+v, want %+v", infos, want)
+	}
+}
+
+func writeIgnoreFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ".outfitignore"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCategoryScanner_MissingDirectory(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+	scanner := NewCategoryScanner()
+
+	if _, err := scanner.ScanCategories(context.Background(), []string{missing}, nil, entities.ScanPolicy{}); !errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		t.Errorf("ScanCategories() error = %v, want ErrDirectoryNotFound", err)
+	}
+	if _, err := scanner.GetOutfits(context.Background(), missing, entities.ScanPolicy{}); !errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		t.Errorf("GetOutfits() error = %v, want ErrDirectoryNotFound", err)
+	}
+}
+
+func TestCategoryScanner_CategoryDescriptions(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(root, "gym", "kit.avatar"))
+	writeCategoryDescription(t, filepath.Join(root, "casual"), `{"label": "Weekend", "description": "Off-duty clothes", "tags": ["relaxed"], "slot": "top"}`)
+
+	infos, err := NewCategoryScanner().ScanCategories(context.Background(), []string{root}, nil, entities.ScanPolicy{})
+	if err != nil {
+		t.Fatalf("ScanCategories() error = %v", err)
+	}
+	want := entities.CategoryDescription{Label: "Weekend", Description: "Off-duty clothes", Tags: []string{"relaxed"}, Slot: "top"}
+	if got := infos[0].Description; got == nil || got.Label != want.Label || got.Description != want.Description || !slices.Equal(got.Tags, want.Tags) || got.Slot != want.Slot {
+		t.Errorf("casual description = %+v, want %+v", got, want)
+	}
+	if infos[0].OutfitCount != 1 || infos[0].State != entities.CategoryStateHasOutfits {
+		t.Errorf("casual = %+v, want category.json left out of its files", infos[0])
+	}
+	if infos[1].Description != nil {
+		t.Errorf("gym description = %+v, want empty", infos[1].Description)
+	}
+
+	for _, content := range []string{`{"label": `, `{"tags": ["Not A Tag"]}`} {
+		writeCategoryDescription(t, filepath.Join(root, "gym"), content)
+		if _, err := NewCategoryScanner().ScanCategories(context.Background(), []string{root}, nil, entities.ScanPolicy{}); !errors.Is(err, domainerrors.ErrInvalidCategoryDescription) {
+			t.Errorf("ScanCategories() with category.json %s error = %v, want ErrInvalidCategoryDescription", content, err)
+		}
+	}
+}
+
+func writeCategoryDescription(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, entities.CategoryDescriptionFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCategoryScanner_ReadColorFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "casual")
+	mustWrite(t, filepath.Join(dir, "tee.avatar"))
+	mustWrite(t, filepath.Join(dir, "notes.colors"))
+	if err := os.WriteFile(filepath.Join(dir, "tee.avatar.colors"), []byte("Navy, white\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	colors, err := NewCategoryScanner().ReadColorFiles(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("ReadColorFiles() error = %v", err)
+	}
+	if len(colors) != 1 || !slices.Equal(colors["tee.avatar"], []string{"navy", "white"}) {
+		t.Errorf("ReadColorFiles() = %v, want the colors of tee.avatar", colors)
+	}
+	outfits, err := NewCategoryScanner().GetOutfits(context.Background(), dir, entities.ScanPolicy{})
+	if err != nil || len(outfits) != 1 {
+		t.Errorf("GetOutfits() = %v, %v; want the color files left out", outfits, err)
+	}
+}
+
+func TestCategoryScanner_ReadSidecars(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "casual")
+	mustWrite(t, filepath.Join(dir, "tee.avatar"))
+	writeSidecar(t, dir, "tee.avatar", `{"tags": ["beach"], "seasons": ["summer"], "weight": 2, "notes": "Dry flat"}`)
+	writeSidecar(t, dir, "notes", `{"notes": "not an outfit"}`)
+
+	sidecars, err := NewCategoryScanner().ReadSidecars(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("ReadSidecars() error = %v", err)
+	}
+	tee, ok := sidecars["tee.avatar"]
+	if len(sidecars) != 1 || !ok || !slices.Equal(tee.Tags, []string{"beach"}) || tee.Weight == nil || *tee.Weight != 2 || tee.Notes != "Dry flat" {
+		t.Errorf("ReadSidecars() = %+v, want the sidecar of tee.avatar", sidecars)
+	}
+	outfits, err := NewCategoryScanner().GetOutfits(context.Background(), dir, entities.ScanPolicy{})
+	if err != nil || len(outfits) != 1 {
+		t.Errorf("GetOutfits() = %v, %v; want the sidecar files left out", outfits, err)
+	}
+
+	for _, content := range []string{`{"tags": `, `{"seasons": ["monsoon"]}`} {
+		writeSidecar(t, dir, "tee.avatar", content)
+		if _, err := NewCategoryScanner().ReadSidecars(context.Background(), dir); !errors.Is(err, domainerrors.ErrInvalidOutfitSidecar) {
+			t.Errorf("ReadSidecars() with %s error = %v, want ErrInvalidOutfitSidecar", content, err)
+		}
+	}
+}
+
+func writeSidecar(t *testing.T, dir, outfit, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, outfit+entities.OutfitSidecarSuffix), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCategoryScanner_StopsWhenCancelled(t *testing.T) {
+	wardrobe := testhelpers.MustGenerateWardrobe(t, devtools.WardrobeSpec{Shape: devtools.ShapeFlat, Categories: 3, OutfitsPerCategory: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	scanner := NewCategoryScanner()
+
+	if _, err := scanner.ScanCategories(ctx, []string{wardrobe.Root}, nil, entities.ScanPolicy{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("ScanCategories() error = %v, want context.Canceled", err)
+	}
+	if _, err := scanner.GetOutfits(ctx, filepath.Join(wardrobe.Root, "category-000"), entities.ScanPolicy{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetOutfits() error = %v, want context.Canceled", err)
+	}
+}
+
+func BenchmarkCategoryScanner_ScanCategories(b *testing.B) {
+	for _, shape := range devtools.Shapes() {
+		b.Run(string(shape), func(b *testing.B) {
+			wardrobe := testhelpers.MustGenerateWardrobe(b, devtools.WardrobeSpec{
+				Shape:              shape,
+				Categories:         20,
+				OutfitsPerCategory: 50,
+				HugeCategorySize:   2000,
+			})
+			scanner := NewCategoryScanner()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanner.ScanCategories(context.Background(), []string{wardrobe.Root}, nil, entities.ScanPolicy{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func mustWrite(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,11 @@
 package system
 
-import "os"
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
 
 type defaultDataManager struct{}
 
@@ -12,6 +17,68 @@ func (d *defaultDataManager) Write(path string, data []byte) error {
 	return os.WriteFile(path, data, 0644)
 }
 
+// WriteAtomic writes data to a temporary file in the same directory, syncs
+// it and renames it over path. The file keeps its permissions when it
+// already exists.
+func (d *defaultDataManager) WriteAtomic(path string, data []byte) error {
+	return WriteFileAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteFileAtomic is WriteAtomic for output written a piece at a time:
+// write fills a temporary file in the same directory, which is synced and
+// renamed over path only once write succeeds, so a failure leaves any
+// earlier file at path untouched.
+func WriteFileAtomic(path string, write func(w io.Writer) error) (err error) {
+	mode := os.FileMode(0644)
+	if info, statErr := os.Stat(path); statErr == nil {
+		mode = info.Mode().Perm()
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err = write(tmp); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(mode); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	syncDir(dir)
+	return nil
+}
+
+// syncDir flushes a directory entry change to disk. Not every platform can
+// sync a directory, so failures are ignored: the rename itself has happened.
+func syncDir(dir string) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	f.Sync()
+	f.Close()
+}
+
 type defaultDirectoryProvider struct{}
 
 func NewDefaultDirectoryProvider() DirectoryProvider {
@@ -25,6 +92,46 @@ func (d *defaultDirectoryProvider) BaseDirectory() (string, error) {
 	return os.UserConfigDir()
 }
 
+// CandidateDirectories returns XDG_CONFIG_HOME, when set, followed by the
+// platform's own config directory, so a configuration left in the one not
+// in use can be noticed.
+func (d *defaultDirectoryProvider) CandidateDirectories() ([]string, error) {
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		return []string{dir}, nil
+	}
+	platform, err := platformConfigDirectory()
+	if err != nil || filepath.Clean(platform) == filepath.Clean(xdg) {
+		return []string{xdg}, nil
+	}
+	return []string{xdg, platform}, nil
+}
+
+// platformConfigDirectory returns the config directory the platform uses
+// when XDG_CONFIG_HOME is not set.
+func platformConfigDirectory() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config"), nil
+}
+
+// CandidateDirectoryProvider is implemented by a DirectoryProvider that
+// chooses its base directory among several, most preferred first.
+type CandidateDirectoryProvider interface {
+	DirectoryProvider
+	CandidateDirectories() ([]string, error)
+}
+
 type defaultFileManager struct{}
 
 func (d *defaultFileManager) Exists(path string) bool {
@@ -39,3 +146,74 @@ func (d *defaultFileManager) Remove(path string) error {
 func (d *defaultFileManager) MkdirAll(path string) error {
 	return os.MkdirAll(path, 0700)
 }
+
+type staticDirectoryProvider struct {
+	dir string
+}
+
+// NewStaticDirectoryProvider returns a DirectoryProvider that always resolves
+// to dir.
+func NewStaticDirectoryProvider(dir string) DirectoryProvider {
+	return &staticDirectoryProvider{dir: dir}
+}
+
+func (s *staticDirectoryProvider) BaseDirectory() (string, error) {
+	return s.dir, nil
+}
+
+type candidateDirectoryProvider struct {
+	dirs []string
+}
+
+// NewCandidateDirectoryProvider returns a DirectoryProvider that resolves to
+// the first of dirs and reports all of them as candidates.
+func NewCandidateDirectoryProvider(dirs ...string) CandidateDirectoryProvider {
+	return &candidateDirectoryProvider{dirs: dirs}
+}
+
+func (c *candidateDirectoryProvider) BaseDirectory() (string, error) {
+	return c.dirs[0], nil
+}
+
+func (c *candidateDirectoryProvider) CandidateDirectories() ([]string, error) {
+	return c.dirs, nil
+}
+
+type appDirectoryProvider struct {
+	dir string
+}
+
+// NewAppDirectoryProvider returns a DirectoryProvider whose app directory
+// is dir itself, whatever it is named, for a configuration kept somewhere
+// of the user's choosing.
+func NewAppDirectoryProvider(dir string) DirectoryProvider {
+	return &appDirectoryProvider{dir: dir}
+}
+
+func (a *appDirectoryProvider) BaseDirectory() (string, error) {
+	return filepath.Dir(a.dir), nil
+}
+
+func (a *appDirectoryProvider) appDirectory() (string, error) {
+	return a.dir, nil
+}
+
+type stateDirectoryProvider struct {
+	DirectoryProvider
+	dir string
+}
+
+// NewStateDirectoryProvider returns a DirectoryProvider that keeps state
+// files, backups and the log in dir, laid out like the app directory, and
+// leaves the configuration wherever dp keeps it.
+func NewStateDirectoryProvider(dp DirectoryProvider, dir string) DirectoryProvider {
+	return &stateDirectoryProvider{DirectoryProvider: dp, dir: dir}
+}
+
+func (s *stateDirectoryProvider) appDirectory() (string, error) {
+	return AppDirectory(s.DirectoryProvider)
+}
+
+func (s *stateDirectoryProvider) stateDirectory() string {
+	return s.dir
+}
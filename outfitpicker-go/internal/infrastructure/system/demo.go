@@ -0,0 +1,99 @@
+package system
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// demoFiles holds the sample wardrobe and the state worn into it that demo
+// mode starts from.
+//
+//go:embed all:demo
+var demoFiles embed.FS
+
+// DemoDirectoryProvider keeps the state files of demo mode next to a copy of
+// the bundled sample wardrobe in a directory of its own, so the demo never
+// touches the real configuration.
+type DemoDirectoryProvider struct {
+	dir string
+}
+
+// NewDemoDirectoryProvider creates a demo directory provider over dir. The
+// sample wardrobe is copied there by Prepare.
+func NewDemoDirectoryProvider(dir string) *DemoDirectoryProvider {
+	return &DemoDirectoryProvider{dir: dir}
+}
+
+// BaseDirectory returns where the demo's state files are stored.
+func (d *DemoDirectoryProvider) BaseDirectory() (string, error) {
+	return filepath.Join(d.dir, "state"), nil
+}
+
+// WardrobeRoot returns the root of the demo's sample wardrobe.
+func (d *DemoDirectoryProvider) WardrobeRoot() string {
+	return filepath.Join(d.dir, "wardrobe")
+}
+
+// Prepare copies the sample wardrobe and its state into the demo directory
+// unless an earlier run already did, so changes made in the demo last
+// across runs. It reports whether the directory was created.
+func (d *DemoDirectoryProvider) Prepare() (bool, error) {
+	if _, err := os.Stat(d.dir); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, mapFileSystemError(err, d.dir)
+	}
+	if err := os.MkdirAll(filepath.Dir(d.dir), 0755); err != nil {
+		return false, mapFileSystemError(err, filepath.Dir(d.dir))
+	}
+	// Copying next to the final directory and renaming it into place keeps
+	// an interrupted copy from being taken for a complete one.
+	staging, err := os.MkdirTemp(filepath.Dir(d.dir), ".outfitpicker-demo-")
+	if err != nil {
+		return false, mapFileSystemError(err, filepath.Dir(d.dir))
+	}
+	defer os.RemoveAll(staging)
+	if err := copyDemoFiles(staging); err != nil {
+		return false, err
+	}
+	if err := os.Rename(staging, d.dir); err != nil {
+		return false, mapFileSystemError(err, d.dir)
+	}
+	return true, nil
+}
+
+// Reset discards every change made in the demo and copies the sample
+// wardrobe and its state afresh.
+func (d *DemoDirectoryProvider) Reset() error {
+	if err := os.RemoveAll(d.dir); err != nil {
+		return mapFileSystemError(err, d.dir)
+	}
+	_, err := d.Prepare()
+	return err
+}
+
+func copyDemoFiles(dir string) error {
+	sample, err := fs.Sub(demoFiles, "demo")
+	if err != nil {
+		return err
+	}
+	return fs.WalkDir(sample, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		data, err := fs.ReadFile(sample, path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return mapFileSystemError(err, target)
+		}
+		return nil
+	})
+}
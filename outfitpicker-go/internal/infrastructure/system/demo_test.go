@@ -0,0 +1,45 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDemoDirectoryProvider_PrepareAndReset(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	demo := NewDemoDirectoryProvider(dir)
+
+	created, err := demo.Prepare()
+	if err != nil || !created {
+		t.Fatalf("Prepare() = %v, %v; want the demo created", created, err)
+	}
+	tee := filepath.Join(demo.WardrobeRoot(), "casual", "white-tee.avatar")
+	if _, err := os.Stat(tee); err != nil {
+		t.Errorf("sample outfit missing: %v", err)
+	}
+	base, _ := demo.BaseDirectory()
+	if _, err := os.Stat(filepath.Join(base, appName, "wear_log.json")); err != nil {
+		t.Errorf("sample state missing: %v", err)
+	}
+	if entries, err := os.ReadDir(filepath.Dir(dir)); err != nil || len(entries) != 1 {
+		t.Errorf("demo parent holds %v, %v; want only the demo", entries, err)
+	}
+
+	if err := os.Remove(tee); err != nil {
+		t.Fatal(err)
+	}
+	if created, err := demo.Prepare(); err != nil || created {
+		t.Errorf("second Prepare() = %v, %v; want the demo kept", created, err)
+	}
+	if _, err := os.Stat(tee); !os.IsNotExist(err) {
+		t.Errorf("second Prepare() restored the removed outfit")
+	}
+
+	if err := demo.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if _, err := os.Stat(tee); err != nil {
+		t.Errorf("Reset() did not restore the sample outfit: %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package system
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// FileHasher hashes outfit files with the SHA-256 digest of
+// their content.
+type FileHasher struct{}
+
+// NewFileHasher creates a new file hasher.
+func NewFileHasher() *FileHasher {
+	return &FileHasher{}
+}
+
+// Hash returns the hex-encoded SHA-256 digest of the file at path.
+func (f *FileHasher) Hash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", mapFileSystemError(err, path)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", mapFileSystemError(err, path)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,36 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestFileHasher_Hash(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{"tee.avatar": "tee", "copy.avatar": "tee", "jeans.avatar": "jeans"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	hasher := NewFileHasher()
+	hash := func(name string) string {
+		t.Helper()
+		digest, err := hasher.Hash(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Hash(%s) error = %v", name, err)
+		}
+		return digest
+	}
+
+	tee := hash("tee.avatar")
+	if len(tee) != 64 || tee != hash("copy.avatar") || tee == hash("jeans.avatar") {
+		t.Errorf("Hash() = %q, want one 64-digit digest per content", tee)
+	}
+	if _, err := hasher.Hash(filepath.Join(dir, "missing.avatar")); !errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		t.Errorf("Hash(missing) error = %v, want ErrDirectoryNotFound", err)
+	}
+}
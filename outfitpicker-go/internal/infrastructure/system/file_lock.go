@@ -0,0 +1,71 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// Locker serializes read-modify-write cycles on a file across processes.
+type Locker interface {
+	// Lock blocks until the lock for path is held, or ctx is done, and
+	// returns a function that releases it.
+	Lock(ctx context.Context, path string) (unlock func() error, err error)
+}
+
+const (
+	defaultLockTimeout    = 5 * time.Second
+	defaultLockStaleAfter = 30 * time.Second
+	lockRetryInterval     = 10 * time.Millisecond
+)
+
+// lockFileLocker holds a lock by exclusively creating path.lock. Lock files
+// older than staleAfter are assumed to belong to a crashed process and are
+// broken.
+type lockFileLocker struct {
+	timeout    time.Duration
+	staleAfter time.Duration
+}
+
+// NewLockFileLocker returns the default, portable Locker.
+func NewLockFileLocker() Locker {
+	return &lockFileLocker{timeout: defaultLockTimeout, staleAfter: defaultLockStaleAfter}
+}
+
+func (l *lockFileLocker) Lock(ctx context.Context, path string) (func() error, error) {
+	lockPath := path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(l.timeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() error { return os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > l.staleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrLockTimeout, lockPath)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
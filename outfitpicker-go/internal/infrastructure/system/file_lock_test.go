@@ -0,0 +1,111 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestLockFileLocker_Exclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	locker := &lockFileLocker{timeout: 50 * time.Millisecond, staleAfter: time.Minute}
+
+	unlock, err := locker.Lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if _, err := locker.Lock(context.Background(), path); !errors.Is(err, domainerrors.ErrLockTimeout) {
+		t.Errorf("second Lock() error = %v, want ErrLockTimeout", err)
+	}
+
+	if err := unlock(); err != nil {
+		t.Fatalf("unlock() error = %v", err)
+	}
+	unlock, err = locker.Lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Lock() after unlock error = %v", err)
+	}
+	unlock()
+}
+
+func TestFileService_UpdateContextStopsWaitingForTheLock(t *testing.T) {
+	dir := t.TempDir()
+	fs := NewFileService[testConfig]("state.json", WithDirectoryProvider[testConfig](NewStaticDirectoryProvider(dir)))
+	path, err := fs.FilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock, err := NewLockFileLocker().Lock(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = fs.UpdateContext(ctx, func(*testConfig) (testConfig, error) { return testConfig{Value: 1}, nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("UpdateContext() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewFileService[testConfig]("state.json", WithDirectoryProvider[testConfig](NewStaticDirectoryProvider(dir)), WithContext[testConfig](cancelled)).Load(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestLockFileLocker_BreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	stale := time.Now().Add(-time.Hour)
+	if err := os.WriteFile(path+".lock", []byte("1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path+".lock", stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	locker := &lockFileLocker{timeout: 50 * time.Millisecond, staleAfter: time.Minute}
+	unlock, err := locker.Lock(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	unlock()
+}
+
+func TestFileService_UpdateSerializesWriters(t *testing.T) {
+	fs := NewFileService[testConfig]("counter.json", WithDirectoryProvider[testConfig](NewStaticDirectoryProvider(t.TempDir())))
+
+	const writers = 10
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := fs.Update(func(current *testConfig) (testConfig, error) {
+				if current == nil {
+					return testConfig{Value: 1}, nil
+				}
+				return testConfig{Value: current.Value + 1}, nil
+			})
+			if err != nil {
+				t.Errorf("Update() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := fs.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Value != writers {
+		t.Errorf("Value = %d, want %d", got.Value, writers)
+	}
+}
@@ -1,17 +1,33 @@
 package system
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
 	"path/filepath"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	"github.com/dh85/outfitpicker/internal/infrastructure/logging"
 )
 
 const appName = "outfitpicker"
 
+// profilesDirName is the directory under the app directory that holds one
+// directory per named profile.
+const profilesDirName = "profiles"
+
 type DataManager interface {
 	Read(path string) ([]byte, error)
 	Write(path string, data []byte) error
 }
 
+// AtomicWriter is implemented by a DataManager that can replace a file in a
+// single step, so a crash mid-write leaves either the old or the new
+// contents and never a torn file.
+type AtomicWriter interface {
+	WriteAtomic(path string, data []byte) error
+}
+
 type DirectoryProvider interface {
 	BaseDirectory() (string, error)
 }
@@ -27,6 +43,13 @@ type FileService[T any] struct {
 	dataManager       DataManager
 	directoryProvider DirectoryProvider
 	fileManager       FileManager
+	locker            Locker
+	atomicWrites      bool
+	profile           string
+	configuration     bool
+	signer            *Signer
+	ctx               context.Context
+	logger            *slog.Logger
 }
 
 type FileServiceOption[T any] func(*FileService[T])
@@ -49,36 +72,188 @@ func WithFileManager[T any](fm FileManager) FileServiceOption[T] {
 	}
 }
 
+func WithLocker[T any](l Locker) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.locker = l
+	}
+}
+
+// WithAtomicWrites controls whether Save replaces the file atomically when
+// the data manager is an AtomicWriter. Atomic writes are on by default.
+func WithAtomicWrites[T any](enabled bool) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.atomicWrites = enabled
+	}
+}
+
+// WithProfile stores the file in the directory of the named profile instead
+// of the app directory. An empty name keeps the app directory.
+func WithProfile[T any](name string) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.profile = name
+	}
+}
+
+// AsConfiguration keeps the file with the configuration rather than with
+// the state, for directory providers that keep the two apart.
+func AsConfiguration[T any]() FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.configuration = true
+	}
+}
+
+// WithSigner signs the file whenever it is saved and checks the signature
+// whenever it is loaded. A nil signer leaves the file unsigned.
+func WithSigner[T any](signer *Signer) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.signer = signer
+	}
+}
+
+// WithContext sets the context whose cancellation stops Load and Update,
+// including a wait for the file's lock. It defaults to
+// context.Background(); LoadContext and UpdateContext take their own.
+func WithContext[T any](ctx context.Context) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.ctx = ctx
+	}
+}
+
+// WithLogger reports loads, saves and failed integrity checks to logger at
+// debug and warning levels. By default nothing is logged.
+func WithLogger[T any](logger *slog.Logger) FileServiceOption[T] {
+	return func(fs *FileService[T]) {
+		fs.logger = logger
+	}
+}
+
+// NewFileService creates a file service for fileName. A directory provider
+// that stores files itself, such as a MemoryDirectoryProvider, replaces the
+// data manager, file manager and locker.
 func NewFileService[T any](fileName string, opts ...FileServiceOption[T]) *FileService[T] {
 	fs := &FileService[T]{
 		fileName:          fileName,
 		dataManager:       &defaultDataManager{},
 		directoryProvider: NewDefaultDirectoryProvider(),
 		fileManager:       &defaultFileManager{},
+		locker:            NewLockFileLocker(),
+		atomicWrites:      true,
+		ctx:               context.Background(),
+		logger:            logging.Discard(),
 	}
 
 	for _, opt := range opts {
 		opt(fs)
 	}
+	if storage, ok := fs.directoryProvider.(storageResolver); ok {
+		fs.dataManager, fs.fileManager, fs.locker = storage.storage()
+	}
 
 	return fs
 }
 
 func (fs *FileService[T]) FilePath() (string, error) {
-	baseDir, err := fs.directoryProvider.BaseDirectory()
+	dir, err := stateProfileDirectory(fs.directoryProvider, fs.profile)
+	if fs.configuration {
+		dir, err = profileDirectory(fs.directoryProvider, fs.profile)
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fs.fileName), nil
+}
+
+// profileDirectory returns the directory holding the configuration of the
+// named profile: the app directory itself for an empty name.
+func profileDirectory(dp DirectoryProvider, profile string) (string, error) {
+	appDir, err := AppDirectory(dp)
+	if err != nil {
+		return "", err
+	}
+	return profileUnder(appDir, profile), nil
+}
+
+// stateProfileDirectory returns the directory holding the state files of
+// the named profile, which is its profileDirectory unless dp keeps state
+// apart.
+func stateProfileDirectory(dp DirectoryProvider, profile string) (string, error) {
+	stateDir, err := StateDirectory(dp)
+	if err != nil {
+		return "", err
+	}
+	return profileUnder(stateDir, profile), nil
+}
+
+// profileUnder returns the directory of the named profile under an app or
+// state directory.
+func profileUnder(dir, profile string) string {
+	if profile == "" {
+		return dir
+	}
+	return filepath.Join(dir, profilesDirName, profile)
+}
+
+// appDirectoryResolver is implemented by a DirectoryProvider that names the
+// app directory itself rather than the base directory it is made in.
+type appDirectoryResolver interface {
+	appDirectory() (string, error)
+}
+
+// stateDirectoryResolver is implemented by a DirectoryProvider that keeps
+// state files in a directory apart from the configuration.
+type stateDirectoryResolver interface {
+	stateDirectory() string
+}
+
+// AppDirectory returns the app directory under dp, which holds the
+// configuration and the directories of named profiles.
+func AppDirectory(dp DirectoryProvider) (string, error) {
+	if app, ok := dp.(appDirectoryResolver); ok {
+		return app.appDirectory()
+	}
+	baseDir, err := dp.BaseDirectory()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(baseDir, appName), nil
+}
+
+// StateDirectory returns the directory under dp holding the state files,
+// laid out like the app directory. It is the app directory unless dp
+// keeps state apart.
+func StateDirectory(dp DirectoryProvider) (string, error) {
+	if state, ok := dp.(stateDirectoryResolver); ok {
+		return state.stateDirectory(), nil
+	}
+	return AppDirectory(dp)
+}
+
+// LogFilePath returns where the JSON log file is kept under dp, in the
+// state directory shared by every profile.
+func LogFilePath(dp DirectoryProvider) (string, error) {
+	dir, err := StateDirectory(dp)
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(baseDir, appName, fs.fileName), nil
+	return filepath.Join(dir, logging.FileName), nil
 }
 
 func (fs *FileService[T]) Load() (*T, error) {
+	return fs.LoadContext(fs.ctx)
+}
+
+// LoadContext is like Load, failing with ctx.Err() once ctx is done.
+func (fs *FileService[T]) LoadContext(ctx context.Context) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	path, err := fs.FilePath()
 	if err != nil {
 		return nil, err
 	}
 
 	if !fs.fileManager.Exists(path) {
+		fs.logger.Debug("state file not found", "file", path)
 		return nil, nil
 	}
 
@@ -86,12 +261,18 @@ func (fs *FileService[T]) Load() (*T, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := fs.verify(path, data); err != nil {
+		fs.logger.Warn("state file failed its integrity check", "file", path, "error", err)
+		return nil, err
+	}
 
 	var result T
 	if err := json.Unmarshal(data, &result); err != nil {
+		fs.logger.Warn("state file is not valid JSON", "file", path, "error", err)
 		return nil, err
 	}
 
+	fs.logger.Debug("loaded state file", "file", path, "bytes", len(data))
 	return &result, nil
 }
 
@@ -110,9 +291,55 @@ func (fs *FileService[T]) Save(obj T) error {
 		return err
 	}
 
+	if err := fs.write(path, data); err != nil {
+		return err
+	}
+	fs.logger.Debug("saved state file", "file", path, "bytes", len(data))
+	return fs.sign(path, data)
+}
+
+func (fs *FileService[T]) write(path string, data []byte) error {
+	if writer, ok := fs.dataManager.(AtomicWriter); ok && fs.atomicWrites {
+		return writer.WriteAtomic(path, data)
+	}
 	return fs.dataManager.Write(path, data)
 }
 
+// sign saves the signature of data, the contents just written to path.
+func (fs *FileService[T]) sign(path string, data []byte) error {
+	if fs.signer == nil {
+		return nil
+	}
+	signature, err := fs.signer.Sign(data)
+	if err != nil {
+		return err
+	}
+	return fs.write(signaturePath(path), signature)
+}
+
+// verify returns an IntegrityError when data, read from path, does not
+// match its saved signature or has none.
+func (fs *FileService[T]) verify(path string, data []byte) error {
+	if fs.signer == nil {
+		return nil
+	}
+	var signature []byte
+	if sigPath := signaturePath(path); fs.fileManager.Exists(sigPath) {
+		var err error
+		if signature, err = fs.dataManager.Read(sigPath); err != nil {
+			return err
+		}
+	}
+	ok, err := fs.signer.Verify(data, signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domainerrors.NewIntegrityError(fs.fileName)
+	}
+	return nil
+}
+
 func (fs *FileService[T]) Delete() error {
 	path, err := fs.FilePath()
 	if err != nil {
@@ -122,6 +349,44 @@ func (fs *FileService[T]) Delete() error {
 	if !fs.fileManager.Exists(path) {
 		return nil
 	}
+	if sigPath := signaturePath(path); fs.fileManager.Exists(sigPath) {
+		if err := fs.fileManager.Remove(sigPath); err != nil {
+			return err
+		}
+	}
 
 	return fs.fileManager.Remove(path)
 }
+
+// Update applies fn to the current contents, nil when the file does not exist,
+// and saves the result while holding the file's lock. Concurrent updates from
+// other processes are serialized rather than lost. If fn fails nothing is
+// written.
+func (fs *FileService[T]) Update(fn func(current *T) (T, error)) error {
+	return fs.UpdateContext(fs.ctx, fn)
+}
+
+// UpdateContext is like Update, giving up on waiting for the lock and
+// failing with ctx.Err() once ctx is done. Nothing is written then.
+func (fs *FileService[T]) UpdateContext(ctx context.Context, fn func(current *T) (T, error)) error {
+	path, err := fs.FilePath()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := fs.locker.Lock(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	current, err := fs.LoadContext(ctx)
+	if err != nil {
+		return err
+	}
+	updated, err := fn(current)
+	if err != nil {
+		return err
+	}
+	return fs.Save(updated)
+}
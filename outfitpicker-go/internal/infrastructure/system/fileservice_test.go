@@ -276,6 +276,28 @@ func TestFileService_Delete(t *testing.T) {
 	}
 }
 
+func TestFileService_FilePath_StateDirectory(t *testing.T) {
+	dp := NewStateDirectoryProvider(NewAppDirectoryProvider("/etc/wardrobe"), "/var/lib/wardrobe")
+	tests := []struct {
+		name string
+		opts []FileServiceOption[testConfig]
+		want string
+	}{
+		{"state file", nil, "/var/lib/wardrobe/test.json"},
+		{"state file of a profile", []FileServiceOption[testConfig]{WithProfile[testConfig]("work")}, "/var/lib/wardrobe/profiles/work/test.json"},
+		{"configuration", []FileServiceOption[testConfig]{AsConfiguration[testConfig]()}, "/etc/wardrobe/test.json"},
+		{"configuration of a profile", []FileServiceOption[testConfig]{AsConfiguration[testConfig](), WithProfile[testConfig]("work")}, "/etc/wardrobe/profiles/work/test.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := NewFileService("test.json", append(tt.opts, WithDirectoryProvider[testConfig](dp))...)
+			if got, err := fs.FilePath(); err != nil || got != filepath.FromSlash(tt.want) {
+				t.Errorf("FilePath() = %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
 func TestDefaultDirectoryProvider(t *testing.T) {
 	t.Run("uses XDG_CONFIG_HOME when set", func(t *testing.T) {
 		os.Setenv("XDG_CONFIG_HOME", "/custom/config")
@@ -372,3 +394,105 @@ func TestFileService_Save_WriteError(t *testing.T) {
 		t.Error("Save() expected write error, got nil")
 	}
 }
+
+func TestFileService_Save_Atomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	fs := NewFileService[testConfig]("test.json",
+		WithDirectoryProvider[testConfig](newMockDirProvider(tmpDir, nil)))
+	path, _ := fs.FilePath()
+
+	if err := fs.Save(testConfig{Name: "first", Value: 1}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Save(testConfig{Name: "second", Value: 2}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := fs.Load()
+	if err != nil || loaded == nil || loaded.Name != "second" {
+		t.Fatalf("Load() = %v, %v, want second", loaded, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, entry := range entries {
+		if entry.Name() != "test.json" {
+			t.Errorf("unexpected file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestFileService_Save_AtomicFailureLeavesNoTempFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	fs := NewFileService[testConfig]("test.json",
+		WithDirectoryProvider[testConfig](newMockDirProvider(tmpDir, nil)))
+	path, _ := fs.FilePath()
+	// A non-empty directory in the file's place makes the rename fail.
+	if err := os.MkdirAll(filepath.Join(path, "occupied"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fs.Save(testConfig{Name: "test"}); err == nil {
+		t.Fatal("Save() expected rename error, got nil")
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 || entries[0].Name() != "test.json" {
+		t.Errorf("entries = %v, want only the original test.json", entries)
+	}
+}
+
+type recordingDataManager struct {
+	mockDataManager
+	atomic int
+	plain  int
+}
+
+func (m *recordingDataManager) Write(path string, data []byte) error {
+	m.plain++
+	return nil
+}
+
+func (m *recordingDataManager) WriteAtomic(path string, data []byte) error {
+	m.atomic++
+	return nil
+}
+
+func TestFileService_WithAtomicWrites(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []FileServiceOption[testConfig]
+		wantAtomic int
+		wantPlain  int
+	}{
+		{name: "default", wantAtomic: 1},
+		{name: "enabled", opts: []FileServiceOption[testConfig]{WithAtomicWrites[testConfig](true)}, wantAtomic: 1},
+		{name: "disabled", opts: []FileServiceOption[testConfig]{WithAtomicWrites[testConfig](false)}, wantPlain: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dm := &recordingDataManager{}
+			opts := append([]FileServiceOption[testConfig]{
+				WithDirectoryProvider[testConfig](newMockDirProvider("/tmp", nil)),
+				WithDataManager[testConfig](dm),
+				WithFileManager[testConfig](newMockFileManager(false, nil, nil)),
+			}, tt.opts...)
+			fs := NewFileService("test.json", opts...)
+
+			if err := fs.Save(testConfig{Name: "test"}); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if dm.atomic != tt.wantAtomic || dm.plain != tt.wantPlain {
+				t.Errorf("atomic = %d, plain = %d, want %d, %d", dm.atomic, dm.plain, tt.wantAtomic, tt.wantPlain)
+			}
+		})
+	}
+}
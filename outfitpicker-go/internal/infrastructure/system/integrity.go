@@ -0,0 +1,295 @@
+package system
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	integrityFileName = "integrity.json"
+	// signingKeyAccount is the keychain account holding the signing key.
+	signingKeyAccount = "state-signing-key"
+	signingKeySize    = 32
+)
+
+// ErrSecretNotFound is returned by a Keychain that holds no secret for an
+// account.
+var ErrSecretNotFound = errors.New("secret not found in keychain")
+
+// Keychain keeps secrets in the operating system's credential store, under
+// the app's service name.
+type Keychain interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+}
+
+// Signer signs state files with HMAC-SHA256. The key is read from the
+// keychain the first time it is needed, so commands that never touch a
+// state file work without keychain access.
+type Signer struct {
+	keychain Keychain
+	once     sync.Once
+	key      []byte
+	err      error
+}
+
+// NewSigner creates a signer whose key is kept in keychain.
+func NewSigner(keychain Keychain) *Signer {
+	return &Signer{keychain: keychain}
+}
+
+func (s *Signer) loadKey() ([]byte, error) {
+	s.once.Do(func() {
+		secret, err := s.keychain.Get(signingKeyAccount)
+		if err != nil {
+			s.err = fmt.Errorf("read state signing key: %w", err)
+			return
+		}
+		s.key, err = hex.DecodeString(secret)
+		if err != nil || len(s.key) != signingKeySize {
+			s.err = errors.New("state signing key in keychain is malformed")
+		}
+	})
+	return s.key, s.err
+}
+
+// Sign returns the hex-encoded signature of data.
+func (s *Signer) Sign(data []byte) ([]byte, error) {
+	key, err := s.loadKey()
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return []byte(hex.EncodeToString(mac.Sum(nil))), nil
+}
+
+// Verify reports whether signature is the signature of data.
+func (s *Signer) Verify(data, signature []byte) (bool, error) {
+	expected, err := s.Sign(data)
+	if err != nil {
+		return false, err
+	}
+	return hmac.Equal(expected, []byte(strings.TrimSpace(string(signature)))), nil
+}
+
+// signaturePath returns where the signature of the file at path is kept: a
+// dotfile next to it, which backup listings and scans leave out.
+func signaturePath(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".sig")
+}
+
+type integritySettings struct {
+	Enabled bool `json:"enabled"`
+}
+
+// IntegrityStore turns state file signing on and off for every profile. The
+// setting is kept in the app directory and the key in the keychain.
+type IntegrityStore struct {
+	directoryProvider DirectoryProvider
+	keychain          Keychain
+	settings          *FileService[integritySettings]
+	dataManager       defaultDataManager
+}
+
+// NewIntegrityStore creates an integrity store under the directory
+// provider's base directory, keeping its key in keychain.
+func NewIntegrityStore(dp DirectoryProvider, keychain Keychain) *IntegrityStore {
+	return &IntegrityStore{
+		directoryProvider: dp,
+		keychain:          keychain,
+		settings:          NewFileService(integrityFileName, WithDirectoryProvider[integritySettings](dp), AsConfiguration[integritySettings]()),
+	}
+}
+
+// Enabled reports whether state files are signed.
+func (s *IntegrityStore) Enabled() (bool, error) {
+	settings, err := s.settings.Load()
+	if err != nil {
+		return false, err
+	}
+	return settings != nil && settings.Enabled, nil
+}
+
+// Signer returns the signer state files are saved with, or nil when signing
+// is off.
+func (s *IntegrityStore) Signer() (*Signer, error) {
+	enabled, err := s.Enabled()
+	if err != nil || !enabled {
+		return nil, err
+	}
+	return NewSigner(s.keychain), nil
+}
+
+// Enable creates the signing key if the keychain has none and signs every
+// state file.
+func (s *IntegrityStore) Enable() error {
+	_, err := s.keychain.Get(signingKeyAccount)
+	if errors.Is(err, ErrSecretNotFound) {
+		key := make([]byte, signingKeySize)
+		rand.Read(key)
+		err = s.keychain.Set(signingKeyAccount, hex.EncodeToString(key))
+	}
+	if err != nil {
+		return fmt.Errorf("create state signing key: %w", err)
+	}
+	if err := s.Sign(); err != nil {
+		return err
+	}
+	return s.settings.Save(integritySettings{Enabled: true})
+}
+
+// Disable stops signing and removes the signatures. The key stays in the
+// keychain so signing can be turned back on.
+func (s *IntegrityStore) Disable() error {
+	if err := s.settings.Save(integritySettings{}); err != nil {
+		return err
+	}
+	paths, err := s.stateFiles()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := os.Remove(signaturePath(path)); err != nil && !os.IsNotExist(err) {
+			return mapFileSystemError(err, path)
+		}
+	}
+	return nil
+}
+
+// Verify returns the state files whose signature is missing or does not
+// match, relative to the app or state directory holding them.
+func (s *IntegrityStore) Verify() ([]string, error) {
+	paths, err := s.stateFiles()
+	if err != nil {
+		return nil, err
+	}
+	roots, err := s.roots()
+	if err != nil {
+		return nil, err
+	}
+	signer := NewSigner(s.keychain)
+	var failed []string
+	for _, path := range paths {
+		data, err := s.dataManager.Read(path)
+		if err != nil {
+			return nil, mapFileSystemError(err, path)
+		}
+		signature, err := s.dataManager.Read(signaturePath(path))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, mapFileSystemError(err, path)
+		}
+		ok, err := signer.Verify(data, signature)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			failed = append(failed, relativeName(roots, path))
+		}
+	}
+	return failed, nil
+}
+
+// Sign signs the current contents of every state file.
+func (s *IntegrityStore) Sign() error {
+	paths, err := s.stateFiles()
+	if err != nil {
+		return err
+	}
+	signer := NewSigner(s.keychain)
+	for _, path := range paths {
+		data, err := s.dataManager.Read(path)
+		if err != nil {
+			return mapFileSystemError(err, path)
+		}
+		signature, err := signer.Sign(data)
+		if err != nil {
+			return err
+		}
+		if err := s.dataManager.WriteAtomic(signaturePath(path), signature); err != nil {
+			return mapFileSystemError(err, path)
+		}
+	}
+	return nil
+}
+
+// roots returns the app directory, followed by the state directory when
+// it is kept apart.
+func (s *IntegrityStore) roots() ([]string, error) {
+	appDir, err := AppDirectory(s.directoryProvider)
+	if err != nil {
+		return nil, err
+	}
+	stateDir, err := StateDirectory(s.directoryProvider)
+	if err != nil {
+		return nil, err
+	}
+	if stateDir == appDir {
+		return []string{appDir}, nil
+	}
+	return []string{appDir, stateDir}, nil
+}
+
+// relativeName returns path relative to the first of roots holding it.
+func relativeName(roots []string, path string) string {
+	for _, root := range roots {
+		if name, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(name, "..") {
+			return filepath.ToSlash(name)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+// stateFiles returns the state files of every profile: the JSON files in
+// the app and state directories and in each profile directory under them,
+// apart from the files that say which profile is active and whether
+// signing is on.
+func (s *IntegrityStore) stateFiles() ([]string, error) {
+	roots, err := s.roots()
+	if err != nil {
+		return nil, err
+	}
+	appDir := roots[0]
+	profiles, err := NewProfileStore(s.directoryProvider).List()
+	if err != nil {
+		return nil, err
+	}
+	var dirs []string
+	for _, root := range roots {
+		dirs = append(dirs, root)
+		for _, profile := range profiles {
+			dirs = append(dirs, profileUnder(root, profile))
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, mapFileSystemError(err, dir)
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.Type().IsRegular() || filepath.Ext(name) != ".json" || strings.HasPrefix(name, ".") {
+				continue
+			}
+			if dir == appDir && slices.Contains([]string{profileSelectionFileName, integrityFileName}, name) {
+				continue
+			}
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	return paths, nil
+}
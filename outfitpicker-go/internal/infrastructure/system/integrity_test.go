@@ -0,0 +1,164 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// memoryKeychain is an in-memory Keychain.
+type memoryKeychain map[string]string
+
+func (k memoryKeychain) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeychain) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func TestFileService_Signed(t *testing.T) {
+	dir := t.TempDir()
+	keychain := memoryKeychain{}
+	if err := NewIntegrityStore(NewStaticDirectoryProvider(dir), keychain).Enable(); err != nil {
+		t.Fatal(err)
+	}
+	fs := NewFileService("test.json",
+		WithDirectoryProvider[testConfig](NewStaticDirectoryProvider(dir)),
+		WithSigner[testConfig](NewSigner(keychain)))
+	path, _ := fs.FilePath()
+
+	if err := fs.Save(testConfig{Name: "signed", Value: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := fs.Load(); err != nil || got.Name != "signed" {
+		t.Fatalf("Load() = %+v, %v", got, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"name":"forged","value":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var integrity *domainerrors.IntegrityError
+	if _, err := fs.Load(); !errors.As(err, &integrity) || integrity.File != "test.json" {
+		t.Errorf("Load() of a changed file error = %v, want IntegrityError", err)
+	}
+	if err := os.Remove(signaturePath(path)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Load(); !errors.As(err, &integrity) {
+		t.Errorf("Load() without a signature error = %v, want IntegrityError", err)
+	}
+
+	if err := fs.Save(testConfig{Name: "again"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(signaturePath(path)); !os.IsNotExist(err) {
+		t.Errorf("signature left after Delete: %v", err)
+	}
+}
+
+func TestFileService_SignerWithoutKey(t *testing.T) {
+	fs := NewFileService("test.json",
+		WithDirectoryProvider[testConfig](NewStaticDirectoryProvider(t.TempDir())),
+		WithSigner[testConfig](NewSigner(memoryKeychain{})))
+	if err := fs.Save(testConfig{}); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Save() error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+func TestIntegrityStore(t *testing.T) {
+	base := t.TempDir()
+	dp := NewStaticDirectoryProvider(base)
+	appDir := filepath.Join(base, appName)
+	for _, path := range []string{
+		filepath.Join(appDir, "config.json"),
+		filepath.Join(appDir, profileSelectionFileName),
+		filepath.Join(appDir, profilesDirName, "work", "cache.json"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := NewIntegrityStore(dp, memoryKeychain{})
+
+	if enabled, err := store.Enabled(); err != nil || enabled {
+		t.Fatalf("Enabled() before Enable = %v, %v", enabled, err)
+	}
+	if err := store.Enable(); err != nil {
+		t.Fatal(err)
+	}
+	if signer, err := store.Signer(); err != nil || signer == nil {
+		t.Fatalf("Signer() = %v, %v", signer, err)
+	}
+	if failed, err := store.Verify(); err != nil || len(failed) != 0 {
+		t.Errorf("Verify() after Enable = %v, %v", failed, err)
+	}
+	if _, err := os.Stat(filepath.Join(appDir, "."+profileSelectionFileName+".sig")); !os.IsNotExist(err) {
+		t.Errorf("profile selection was signed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(appDir, profilesDirName, "work", "cache.json"), []byte(`{"x":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if failed, err := store.Verify(); err != nil || !slices.Equal(failed, []string{"profiles/work/cache.json"}) {
+		t.Errorf("Verify() after a change = %v, %v", failed, err)
+	}
+	if err := store.Sign(); err != nil {
+		t.Fatal(err)
+	}
+	if failed, _ := store.Verify(); len(failed) != 0 {
+		t.Errorf("Verify() after Sign = %v", failed)
+	}
+
+	if err := store.Disable(); err != nil {
+		t.Fatal(err)
+	}
+	if signer, err := store.Signer(); err != nil || signer != nil {
+		t.Errorf("Signer() after Disable = %v, %v; want nil", signer, err)
+	}
+	if _, err := os.Stat(signaturePath(filepath.Join(appDir, "config.json"))); !os.IsNotExist(err) {
+		t.Errorf("signature left after Disable: %v", err)
+	}
+}
+
+func TestIntegrityStore_StateDirectory(t *testing.T) {
+	appDir, stateDir := t.TempDir(), t.TempDir()
+	dp := NewStateDirectoryProvider(NewAppDirectoryProvider(appDir), stateDir)
+	for _, path := range []string{
+		filepath.Join(appDir, "config.json"),
+		filepath.Join(stateDir, "cache.json"),
+	} {
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := NewIntegrityStore(dp, memoryKeychain{})
+	if err := store.Enable(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(appDir, integrityFileName)); err != nil {
+		t.Errorf("integrity setting not kept with the configuration: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(stateDir, "cache.json"), []byte(`{"x":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if failed, err := store.Verify(); err != nil || !slices.Equal(failed, []string{"cache.json"}) {
+		t.Errorf("Verify() after a change in the state directory = %v, %v", failed, err)
+	}
+}
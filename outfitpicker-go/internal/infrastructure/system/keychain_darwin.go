@@ -0,0 +1,42 @@
+package system
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// securityItemNotFound is the exit status of security(1) when the keychain
+// holds no matching item.
+const securityItemNotFound = 44
+
+type osKeychain struct{}
+
+// NewOSKeychain returns the login keychain, reached through security(1).
+func NewOSKeychain() Keychain {
+	return osKeychain{}
+}
+
+func (osKeychain) Get(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", appName, "-a", account, "-w").Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Set passes the secret on stdin so it never shows up in the process list.
+// Given -w last and without a value, security(1) prompts for the secret and
+// then for it again; run in a new session it has no terminal to prompt on,
+// so it reads both answers from stdin.
+func (osKeychain) Set(account, secret string) error {
+	cmd := exec.Command("security", "add-generic-password", "-U", "-s", appName, "-a", account, "-w")
+	cmd.Stdin = strings.NewReader(secret + "\n" + secret + "\n")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	return cmd.Run()
+}
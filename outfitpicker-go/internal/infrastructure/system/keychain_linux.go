@@ -0,0 +1,34 @@
+package system
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+type osKeychain struct{}
+
+// NewOSKeychain returns the Secret Service keyring, reached through
+// secret-tool(1).
+func NewOSKeychain() Keychain {
+	return osKeychain{}
+}
+
+func (osKeychain) Get(account string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", appName, "account", account).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(out) == 0 {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Set passes the secret on stdin so it never shows up in the process list.
+func (osKeychain) Set(account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", appName+" "+account, "service", appName, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	return cmd.Run()
+}
@@ -0,0 +1,24 @@
+//go:build !linux && !darwin
+
+package system
+
+import "errors"
+
+var errNoKeychain = errors.New("no keychain is supported on this platform, so integrity checks and chat bot secrets are unavailable")
+
+type osKeychain struct{}
+
+// NewOSKeychain returns a keychain that fails: there is no supported
+// credential store on this platform, Windows included. Keeping secrets in a
+// file instead would put the integrity key next to the files it protects.
+func NewOSKeychain() Keychain {
+	return osKeychain{}
+}
+
+func (osKeychain) Get(account string) (string, error) {
+	return "", errNoKeychain
+}
+
+func (osKeychain) Set(account, secret string) error {
+	return errNoKeychain
+}
@@ -0,0 +1,83 @@
+package system
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// MemoryDirectoryProvider keeps the files of every FileService under it in
+// memory instead of on disk, for stateless runs that must leave nothing
+// behind. The files last as long as the provider.
+type MemoryDirectoryProvider struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemoryDirectoryProvider creates an empty in-memory directory provider.
+func NewMemoryDirectoryProvider() *MemoryDirectoryProvider {
+	return &MemoryDirectoryProvider{files: make(map[string][]byte)}
+}
+
+// BaseDirectory returns the directory the in-memory files are named under.
+// Nothing is written there.
+func (m *MemoryDirectoryProvider) BaseDirectory() (string, error) {
+	return filepath.Join(os.TempDir(), "outfitpicker-memory"), nil
+}
+
+// storage stands in for the disk, and for the lock files a single process
+// holding its files in memory has no use for.
+func (m *MemoryDirectoryProvider) storage() (DataManager, FileManager, Locker) {
+	return m, m, m
+}
+
+func (m *MemoryDirectoryProvider) Read(path string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[path]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(data), nil
+}
+
+func (m *MemoryDirectoryProvider) Write(path string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[path] = slices.Clone(data)
+	return nil
+}
+
+func (m *MemoryDirectoryProvider) Exists(path string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.files[path]
+	return ok
+}
+
+func (m *MemoryDirectoryProvider) Remove(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.files, path)
+	return nil
+}
+
+func (m *MemoryDirectoryProvider) MkdirAll(string) error {
+	return nil
+}
+
+func (m *MemoryDirectoryProvider) Lock(ctx context.Context, _ string) (func() error, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return func() error { return nil }, nil
+}
+
+// storageResolver is implemented by a DirectoryProvider that stores files
+// itself rather than on disk.
+type storageResolver interface {
+	storage() (DataManager, FileManager, Locker)
+}
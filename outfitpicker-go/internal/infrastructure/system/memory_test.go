@@ -0,0 +1,49 @@
+package system
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMemoryDirectoryProvider(t *testing.T) {
+	dp := NewMemoryDirectoryProvider()
+	fs := NewFileService("test.json", WithDirectoryProvider[testConfig](dp))
+
+	if got, err := fs.Load(); err != nil || got != nil {
+		t.Fatalf("Load() of a new provider = %v, %v; want nil", got, err)
+	}
+	if err := fs.Save(testConfig{Name: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	err := fs.Update(func(current *testConfig) (testConfig, error) {
+		return testConfig{Name: current.Name + " and second"}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := NewFileService("test.json", WithDirectoryProvider[testConfig](dp))
+	if got, err := other.Load(); err != nil || got == nil || got.Name != "first and second" {
+		t.Errorf("Load() through another service = %+v, %v", got, err)
+	}
+
+	path, err := fs.FilePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("in-memory file written to disk at %s: %v", path, err)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file written to disk: %v", err)
+	}
+
+	if err := fs.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := other.Load(); got != nil {
+		t.Errorf("Load() after Delete = %+v, want nil", got)
+	}
+	if _, err := NewBackupStore(dp).List(); err == nil {
+		t.Error("BackupStore.List() in memory: want an error, backups are kept on disk")
+	}
+}
@@ -0,0 +1,90 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// FileArchiver archives outfits by moving them into the wardrobe's archive
+// directory, which scans skip.
+type FileArchiver struct{}
+
+// NewFileArchiver creates a new file archiver.
+func NewFileArchiver() *FileArchiver {
+	return &FileArchiver{}
+}
+
+// Archive moves root/category/fileName, where categoryPath is
+// root/category, to root/.archive/category/fileName. A numeric suffix is
+// added when an outfit of the same name was archived before, so earlier
+// archives are never overwritten.
+func (a *FileArchiver) Archive(categoryPath, fileName string) (string, error) {
+	root, category := filepath.Split(filepath.Clean(categoryPath))
+	source := filepath.Join(categoryPath, fileName)
+	if _, err := os.Lstat(source); err != nil {
+		return "", mapFileSystemError(err, source)
+	}
+	dir := filepath.Join(root, logic.ArchiveDirName, category)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", mapFileSystemError(err, dir)
+	}
+
+	target, err := availablePath(dir, fileName)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(source, target); err != nil {
+		return "", mapFileSystemError(err, source)
+	}
+	return target, nil
+}
+
+// ArchiveCategory moves every file of the category at categoryPath, such as
+// root/category, into root/.outfitarchive/category, then removes the
+// emptied directory. Names taken by outfits archived before get a numeric
+// suffix. It returns the archive directory.
+func (a *FileArchiver) ArchiveCategory(categoryPath string) (string, error) {
+	root, category := filepath.Split(filepath.Clean(categoryPath))
+	entries, err := os.ReadDir(categoryPath)
+	if err != nil {
+		return "", mapFileSystemError(err, categoryPath)
+	}
+	dir := filepath.Join(root, logic.ArchiveDirName, category)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", mapFileSystemError(err, dir)
+	}
+	for _, entry := range entries {
+		target, err := availablePath(dir, entry.Name())
+		if err != nil {
+			return "", err
+		}
+		source := filepath.Join(categoryPath, entry.Name())
+		if err := os.Rename(source, target); err != nil {
+			return "", mapFileSystemError(err, source)
+		}
+	}
+	if err := os.Remove(categoryPath); err != nil {
+		return "", mapFileSystemError(err, categoryPath)
+	}
+	return dir, nil
+}
+
+// availablePath returns dir/fileName, or dir/stem-N.ext with the smallest N
+// from 2 when that name is taken.
+func availablePath(dir, fileName string) (string, error) {
+	target := filepath.Join(dir, fileName)
+	ext := filepath.Ext(fileName)
+	stem := strings.TrimSuffix(fileName, ext)
+	for n := 2; ; n++ {
+		if _, err := os.Lstat(target); os.IsNotExist(err) {
+			return target, nil
+		} else if err != nil {
+			return "", mapFileSystemError(err, target)
+		}
+		target = filepath.Join(dir, fmt.Sprintf("%s-%d%s", stem, n, ext))
+	}
+}
@@ -0,0 +1,60 @@
+package system
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestFileArchiver_Archive(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "casual", "tee.avatar"))
+	mustWrite(t, filepath.Join(root, ".outfitarchive", "casual", "tee.avatar"))
+
+	target, err := NewFileArchiver().Archive(filepath.Join(root, "casual"), "tee.avatar")
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if want := filepath.Join(root, ".outfitarchive", "casual", "tee-2.avatar"); target != want {
+		t.Errorf("Archive() = %v, want %v", target, want)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("archived file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "casual", "tee.avatar")); !os.IsNotExist(err) {
+		t.Errorf("source still present, stat error = %v", err)
+	}
+}
+
+func TestFileArchiver_MissingOutfit(t *testing.T) {
+	_, err := NewFileArchiver().Archive(filepath.Join(t.TempDir(), "casual"), "tee.avatar")
+	if !errors.Is(err, domainerrors.ErrDirectoryNotFound) {
+		t.Errorf("Archive() error = %v, want ErrDirectoryNotFound", err)
+	}
+}
+
+func TestFileArchiver_ArchiveCategory(t *testing.T) {
+	root := t.TempDir()
+	mustWrite(t, filepath.Join(root, "formal", "suit.avatar"))
+	mustWrite(t, filepath.Join(root, "formal", "category.json"))
+	mustWrite(t, filepath.Join(root, ".outfitarchive", "formal", "suit.avatar"))
+
+	dir, err := NewFileArchiver().ArchiveCategory(filepath.Join(root, "formal"))
+	if err != nil {
+		t.Fatalf("ArchiveCategory() error = %v", err)
+	}
+	if want := filepath.Join(root, ".outfitarchive", "formal"); dir != want {
+		t.Errorf("ArchiveCategory() = %v, want %v", dir, want)
+	}
+	for _, name := range []string{"suit.avatar", "suit-2.avatar", "category.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("archived %s missing: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "formal")); !os.IsNotExist(err) {
+		t.Errorf("category directory still present, stat error = %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+const profileSelectionFileName = "profile.json"
+
+// ProfileStore keeps each named profile in its own directory under the app's
+// profiles directory, and the active profile in the app directory.
+type ProfileStore struct {
+	directoryProvider DirectoryProvider
+	selection         *FileService[entities.ProfileSelection]
+}
+
+// NewProfileStore creates a profile store under the directory provider's
+// base directory.
+func NewProfileStore(dp DirectoryProvider) *ProfileStore {
+	return &ProfileStore{
+		directoryProvider: dp,
+		selection:         NewFileService(profileSelectionFileName, WithDirectoryProvider[entities.ProfileSelection](dp), AsConfiguration[entities.ProfileSelection]()),
+	}
+}
+
+func (s *ProfileStore) dir() (string, error) {
+	dir, err := profileDirectory(s.directoryProvider, "")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, profilesDirName), nil
+}
+
+// List returns the names of the profile directories, sorted.
+func (s *ProfileStore) List() ([]string, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapFileSystemError(err, dir)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// Exists reports whether the named profile has a directory.
+func (s *ProfileStore) Exists(name string) (bool, error) {
+	dir, err := profileDirectory(s.directoryProvider, name)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, mapFileSystemError(err, dir)
+	}
+	return info.IsDir(), nil
+}
+
+// Delete removes the named profile's directory with everything in it, and
+// its state directory when that is kept apart.
+func (s *ProfileStore) Delete(name string) error {
+	for _, directory := range []func(DirectoryProvider, string) (string, error){stateProfileDirectory, profileDirectory} {
+		dir, err := directory(s.directoryProvider, name)
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return mapFileSystemError(err, dir)
+		}
+	}
+	return nil
+}
+
+// LoadSelection returns the saved profile selection, empty when none has
+// been saved.
+func (s *ProfileStore) LoadSelection() (entities.ProfileSelection, error) {
+	selection, err := s.selection.Load()
+	if err != nil || selection == nil {
+		return entities.ProfileSelection{}, err
+	}
+	return *selection, nil
+}
+
+// SaveSelection saves the profile selection.
+func (s *ProfileStore) SaveSelection(selection entities.ProfileSelection) error {
+	return s.selection.Save(selection)
+}
@@ -0,0 +1,54 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestProfileStore(t *testing.T) {
+	base := t.TempDir()
+	dp := NewStaticDirectoryProvider(base)
+	store := NewProfileStore(dp)
+
+	if names, err := store.List(); err != nil || names != nil {
+		t.Fatalf("List() before any profile = %v, %v", names, err)
+	}
+	config := NewFileService("config.json", WithDirectoryProvider[entities.Config](dp), WithProfile[entities.Config]("travel"))
+	if err := config.Save(entities.Config{Roots: []string{"/travel"}}); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := config.FilePath(); path != filepath.Join(base, "outfitpicker", "profiles", "travel", "config.json") {
+		t.Errorf("FilePath() = %q", path)
+	}
+	if err := os.MkdirAll(filepath.Join(base, "outfitpicker", "profiles", "work"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if names, err := store.List(); err != nil || !slices.Equal(names, []string{"travel", "work"}) {
+		t.Errorf("List() = %v, %v", names, err)
+	}
+	if ok, err := store.Exists("travel"); err != nil || !ok {
+		t.Errorf("Exists(travel) = %v, %v", ok, err)
+	}
+	if ok, _ := store.Exists("beach"); ok {
+		t.Error("Exists(beach) = true for a profile without a directory")
+	}
+
+	if err := store.SaveSelection(entities.ProfileSelection{Active: "travel"}); err != nil {
+		t.Fatal(err)
+	}
+	if selection, err := store.LoadSelection(); err != nil || selection.Active != "travel" {
+		t.Errorf("LoadSelection() = %+v, %v", selection, err)
+	}
+
+	if err := store.Delete("travel"); err != nil {
+		t.Fatal(err)
+	}
+	if names, _ := store.List(); !slices.Equal(names, []string{"work"}) {
+		t.Errorf("List() after Delete = %v", names)
+	}
+}
@@ -0,0 +1,358 @@
+package system
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// How the daily pick is known to each scheduler.
+const (
+	// cronMarker ends the crontab line of the daily pick.
+	cronMarker = "# " + appName + " daily pick"
+	// launchdLabel labels the launchd job, and names its plist.
+	launchdLabel = "com." + appName + ".daily-pick"
+	// taskName names the Windows scheduled task.
+	taskName = appName + "-daily-pick"
+)
+
+// runFunc runs a program, with stdin as its standard input when not nil,
+// and returns its standard output.
+type runFunc func(stdin []byte, name string, args ...string) ([]byte, error)
+
+// OSScheduler installs the daily pick in the operating system's scheduler:
+// the user's crontab, a launchd agent on macOS or a task of the Windows Task
+// Scheduler. The scheduler formats are plain functions so that each is
+// tested on every platform.
+type OSScheduler struct {
+	run  runFunc
+	home func() (string, error)
+}
+
+// NewOSScheduler returns the scheduler of the current platform.
+func NewOSScheduler() *OSScheduler {
+	return &OSScheduler{run: runCommand, home: os.UserHomeDir}
+}
+
+func runCommand(stdin []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return out, fmt.Errorf("%s: %w: %s", name, err, bytes.TrimSpace(exitErr.Stderr))
+	}
+	if err != nil {
+		return out, fmt.Errorf("%s: %w", name, err)
+	}
+	return out, nil
+}
+
+// cronLine is the crontab line running pick. cron turns an unescaped % into
+// a newline even inside quotes, so every % is escaped; a newline would end
+// the line, so arguments holding one are refused.
+func cronLine(pick entities.ScheduledPick) (string, error) {
+	quoted := make([]string, len(pick.Command))
+	for i, arg := range pick.Command {
+		if strings.ContainsAny(arg, "\r\n") {
+			return "", domainerrors.NewInvalidInputError(fmt.Sprintf("cron cannot run an argument containing a line break: %q", arg))
+		}
+		quoted[i] = strings.ReplaceAll(shellQuote(arg), "%", `\%`)
+	}
+	return fmt.Sprintf("%d %d * * * %s %s", pick.Minute, pick.Hour, strings.Join(quoted, " "), cronMarker), nil
+}
+
+// withCronLine returns crontab with the line of the daily pick replaced by
+// line, or removed when line is empty. Every other line is kept as is.
+func withCronLine(crontab, line string) string {
+	var b strings.Builder
+	for kept := range strings.Lines(crontab) {
+		if strings.HasSuffix(strings.TrimRight(kept, "\r\n"), cronMarker) {
+			continue
+		}
+		b.WriteString(kept)
+		if !strings.HasSuffix(kept, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	if line != "" {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// parseCronLine finds the daily pick in crontab, returning nil when there is
+// none.
+func parseCronLine(crontab string) (*entities.ScheduledPick, error) {
+	for line := range strings.Lines(crontab) {
+		line = strings.TrimRight(line, "\r\n")
+		if !strings.HasSuffix(line, cronMarker) {
+			continue
+		}
+		fields := strings.SplitN(strings.TrimSuffix(line, cronMarker), " ", 6)
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("malformed crontab line %q", line)
+		}
+		minute, minuteErr := strconv.Atoi(fields[0])
+		hour, hourErr := strconv.Atoi(fields[1])
+		command, err := shellSplit(fields[5])
+		if minuteErr != nil || hourErr != nil || err != nil {
+			return nil, fmt.Errorf("malformed crontab line %q", line)
+		}
+		return &entities.ScheduledPick{Hour: hour, Minute: minute, Command: command}, nil
+	}
+	return nil, nil
+}
+
+// shellQuote quotes arg for a POSIX shell.
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// shellSplit splits a command line written by shellQuote, also accepting
+// unquoted words and backslash escapes. A \% is read as %, inside quotes
+// too, as cron passes it on.
+func shellSplit(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	for i, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case quoted:
+			switch {
+			case r == '\'':
+				quoted = false
+			case r == '\\' && strings.HasPrefix(line[i+1:], "%"):
+				// The escape cronLine adds; the % follows.
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'':
+			quoted, inWord = true, true
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quoted || escaped {
+		return nil, fmt.Errorf("unterminated quote in %q", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// launchdPlist is the launchd job running pick, appending its output to
+// logPath.
+func launchdPlist(pick entities.ScheduledPick, logPath string) []byte {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	b.WriteString("\t<key>Label</key>\n\t<string>" + launchdLabel + "</string>\n")
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range pick.Command {
+		b.WriteString("\t\t<string>")
+		xml.EscapeText(&b, []byte(arg))
+		b.WriteString("</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	fmt.Fprintf(&b, "\t<key>StartCalendarInterval</key>\n\t<dict>\n\t\t<key>Hour</key>\n\t\t<integer>%d</integer>\n\t\t<key>Minute</key>\n\t\t<integer>%d</integer>\n\t</dict>\n", pick.Hour, pick.Minute)
+	for _, key := range []string{"StandardOutPath", "StandardErrorPath"} {
+		b.WriteString("\t<key>" + key + "</key>\n\t<string>")
+		xml.EscapeText(&b, []byte(logPath))
+		b.WriteString("</string>\n")
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes()
+}
+
+// parseLaunchdPlist reads the schedule and command back from a plist
+// written by launchdPlist.
+func parseLaunchdPlist(data []byte) (*entities.ScheduledPick, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	pick := &entities.ScheduledPick{}
+	var key, text string
+	inArguments := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading launchd job: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			text = ""
+			if t.Name.Local == "array" && key == "ProgramArguments" {
+				inArguments = true
+			}
+		case xml.CharData:
+			text += string(t)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "key":
+				key = text
+			case "array":
+				inArguments = false
+			case "string":
+				if inArguments {
+					pick.Command = append(pick.Command, text)
+				}
+			case "integer":
+				n, err := strconv.Atoi(strings.TrimSpace(text))
+				if err != nil {
+					return nil, fmt.Errorf("reading launchd job: %s is not a number", key)
+				}
+				switch key {
+				case "Hour":
+					pick.Hour = n
+				case "Minute":
+					pick.Minute = n
+				}
+			}
+		}
+	}
+	if len(pick.Command) == 0 {
+		return nil, errors.New("reading launchd job: no ProgramArguments")
+	}
+	return pick, nil
+}
+
+// windowsCommandLine joins args into a command line that Windows programs
+// split back into the same arguments.
+func windowsCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = windowsQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// windowsQuote quotes arg when it holds spaces or quotes, doubling the
+// backslashes that precede a quote.
+func windowsQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\"") {
+		return arg
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	backslashes := 0
+	for _, r := range arg {
+		switch r {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			b.WriteString(strings.Repeat(`\`, 2*backslashes+1))
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		b.WriteRune(r)
+	}
+	b.WriteString(strings.Repeat(`\`, 2*backslashes))
+	b.WriteByte('"')
+	return b.String()
+}
+
+// splitWindowsCommandLine splits a command line the way Windows programs
+// do, undoing windowsCommandLine.
+func splitWindowsCommandLine(line string) []string {
+	var args []string
+	var arg strings.Builder
+	inArg, quoted, backslashes := false, false, 0
+	for _, r := range line {
+		if r == '\\' {
+			backslashes++
+			inArg = true
+			continue
+		}
+		if r == '"' {
+			arg.WriteString(strings.Repeat(`\`, backslashes/2))
+			if backslashes%2 == 1 {
+				arg.WriteRune('"')
+			} else {
+				quoted = !quoted
+			}
+			backslashes = 0
+			inArg = true
+			continue
+		}
+		arg.WriteString(strings.Repeat(`\`, backslashes))
+		backslashes = 0
+		if (r == ' ' || r == '\t') && !quoted {
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+			continue
+		}
+		arg.WriteRune(r)
+		inArg = true
+	}
+	arg.WriteString(strings.Repeat(`\`, backslashes))
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// taskXML is the part of a Task Scheduler task definition that is read.
+type taskXML struct {
+	StartBoundary string `xml:"Triggers>CalendarTrigger>StartBoundary"`
+	Command       string `xml:"Actions>Exec>Command"`
+	Arguments     string `xml:"Actions>Exec>Arguments"`
+}
+
+// parseTaskXML reads the schedule and command back from the task
+// definition printed by schtasks /Query /XML, which may be UTF-16.
+func parseTaskXML(data []byte) (*entities.ScheduledPick, error) {
+	if len(data) >= 2 && data[0] == 0xff && data[1] == 0xfe {
+		units := make([]uint16, 0, len(data)/2)
+		for i := 2; i+1 < len(data); i += 2 {
+			units = append(units, uint16(data[i])|uint16(data[i+1])<<8)
+		}
+		data = []byte(string(utf16.Decode(units)))
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) { return input, nil }
+	var task taskXML
+	if err := decoder.Decode(&task); err != nil {
+		return nil, fmt.Errorf("reading scheduled task: %w", err)
+	}
+	start, err := time.Parse("2006-01-02T15:04:05", task.StartBoundary)
+	if err != nil || task.Command == "" {
+		return nil, errors.New("reading scheduled task: not a daily task with a command")
+	}
+	command := append([]string{task.Command}, splitWindowsCommandLine(task.Arguments)...)
+	return &entities.ScheduledPick{Hour: start.Hour(), Minute: start.Minute(), Command: command}, nil
+}
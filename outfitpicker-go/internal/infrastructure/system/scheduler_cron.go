@@ -0,0 +1,55 @@
+//go:build !darwin && !windows
+
+package system
+
+import (
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Install writes the daily pick into the user's crontab through crontab(1),
+// keeping every other entry.
+func (s *OSScheduler) Install(pick entities.ScheduledPick) error {
+	line, err := cronLine(pick)
+	if err != nil {
+		return err
+	}
+	crontab, err := s.crontab()
+	if err != nil {
+		return err
+	}
+	_, err = s.run([]byte(withCronLine(crontab, line)), "crontab", "-")
+	return err
+}
+
+func (s *OSScheduler) Remove() (bool, error) {
+	crontab, err := s.crontab()
+	if err != nil {
+		return false, err
+	}
+	if pick, err := parseCronLine(crontab); pick == nil || err != nil {
+		return false, err
+	}
+	if _, err := s.run([]byte(withCronLine(crontab, "")), "crontab", "-"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *OSScheduler) Installed() (*entities.ScheduledPick, error) {
+	crontab, err := s.crontab()
+	if err != nil {
+		return nil, err
+	}
+	return parseCronLine(crontab)
+}
+
+// crontab returns the user's crontab, empty when they have none.
+func (s *OSScheduler) crontab() (string, error) {
+	out, err := s.run(nil, "crontab", "-l")
+	if err != nil && strings.Contains(err.Error(), "no crontab") {
+		return "", nil
+	}
+	return string(out), err
+}
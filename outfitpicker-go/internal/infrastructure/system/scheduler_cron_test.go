@@ -0,0 +1,56 @@
+//go:build !darwin && !windows
+
+package system
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// fakeCrontab stands in for crontab(1), holding the crontab in memory; nil
+// means the user has none.
+type fakeCrontab struct {
+	crontab *string
+}
+
+func (f *fakeCrontab) run(stdin []byte, name string, args ...string) ([]byte, error) {
+	switch {
+	case name != "crontab":
+		return nil, errors.New("unexpected command " + name)
+	case args[0] == "-l" && f.crontab == nil:
+		return nil, errors.New("crontab: exit status 1: no crontab for sam")
+	case args[0] == "-l":
+		return []byte(*f.crontab), nil
+	}
+	written := string(stdin)
+	f.crontab = &written
+	return nil, nil
+}
+
+func TestOSScheduler_Crontab(t *testing.T) {
+	fake := &fakeCrontab{}
+	scheduler := &OSScheduler{run: fake.run}
+
+	if pick, err := scheduler.Installed(); pick != nil || err != nil {
+		t.Errorf("Installed() without a crontab = %+v, %v; want none", pick, err)
+	}
+	if removed, err := scheduler.Remove(); removed || err != nil {
+		t.Errorf("Remove() without a crontab = %v, %v; want false", removed, err)
+	}
+	if err := scheduler.Install(entities.ScheduledPick{Hour: 7, Minute: 30, Command: []string{"outfitpicker", "pick", "work"}}); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	*fake.crontab = "0 1 * * * backup\n" + *fake.crontab
+	if pick, err := scheduler.Installed(); err != nil || pick == nil || pick.Time() != "07:30" {
+		t.Errorf("Installed() = %+v, %v; want the pick at 07:30", pick, err)
+	}
+	if removed, err := scheduler.Remove(); !removed || err != nil {
+		t.Errorf("Remove() = %v, %v; want true", removed, err)
+	}
+	if *fake.crontab != "0 1 * * * backup\n" || strings.Contains(*fake.crontab, cronMarker) {
+		t.Errorf("crontab after Remove() = %q, want only the other entry", *fake.crontab)
+	}
+}
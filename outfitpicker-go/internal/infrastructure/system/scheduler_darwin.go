@@ -0,0 +1,70 @@
+package system
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Install writes the daily pick as a launchd agent of the user and loads
+// it, replacing the agent when it is already loaded.
+func (s *OSScheduler) Install(pick entities.ScheduledPick) error {
+	home, err := s.home()
+	if err != nil {
+		return err
+	}
+	path := launchdPlistPath(home)
+	logPath := filepath.Join(home, "Library", "Logs", launchdLabel+".log")
+	for _, dir := range []string{filepath.Dir(path), filepath.Dir(logPath)} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		// A failed unload only means the agent was not loaded.
+		s.run(nil, "launchctl", "unload", path)
+	}
+	if err := os.WriteFile(path, launchdPlist(pick, logPath), 0o644); err != nil {
+		return err
+	}
+	_, err = s.run(nil, "launchctl", "load", path)
+	return err
+}
+
+func (s *OSScheduler) Remove() (bool, error) {
+	home, err := s.home()
+	if err != nil {
+		return false, err
+	}
+	path := launchdPlistPath(home)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	s.run(nil, "launchctl", "unload", path)
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *OSScheduler) Installed() (*entities.ScheduledPick, error) {
+	home, err := s.home()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(launchdPlistPath(home))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseLaunchdPlist(data)
+}
+
+func launchdPlistPath(home string) string {
+	return filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist")
+}
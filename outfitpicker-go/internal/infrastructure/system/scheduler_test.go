@@ -0,0 +1,128 @@
+package system
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+var testScheduledPick = entities.ScheduledPick{
+	Hour:    7,
+	Minute:  30,
+	Command: []string{"/Users/sam/My Tools/outfitpicker", "--profile", "sam's", "pick", "work", "--tag", "a&b"},
+}
+
+func TestCronLine_RoundTrip(t *testing.T) {
+	line, err := cronLine(testScheduledPick)
+	if err != nil {
+		t.Fatalf("cronLine() error = %v", err)
+	}
+	if !strings.HasPrefix(line, "30 7 * * * '/Users/sam/My Tools/outfitpicker' ") || !strings.HasSuffix(line, cronMarker) {
+		t.Errorf("cronLine() = %q", line)
+	}
+	crontab := withCronLine("MAILTO=sam\n0 1 * * * backup\n", line)
+	if want := "MAILTO=sam\n0 1 * * * backup\n" + line + "\n"; crontab != want {
+		t.Errorf("withCronLine() = %q, want %q", crontab, want)
+	}
+	pick, err := parseCronLine(crontab)
+	if err != nil || pick == nil || pick.Time() != "07:30" || !slices.Equal(pick.Command, testScheduledPick.Command) {
+		t.Errorf("parseCronLine() = %+v, %v; want %+v", pick, err, testScheduledPick)
+	}
+
+	discounted := entities.ScheduledPick{Hour: 8, Command: []string{"/opt/100%/outfitpicker", "pick", "50%-off"}}
+	line, err = cronLine(discounted)
+	if err != nil || strings.Count(line, "%") != strings.Count(line, `\%`) || !strings.Contains(line, `'50\%-off'`) {
+		t.Errorf("cronLine() with percent signs = %q, %v; want every %% escaped", line, err)
+	}
+	if pick, err := parseCronLine(line); err != nil || pick == nil || !slices.Equal(pick.Command, discounted.Command) {
+		t.Errorf("parseCronLine() with percent signs = %+v, %v; want %q", pick, err, discounted.Command)
+	}
+	for _, arg := range []string{"work\n0 * * * * evil", "work\r"} {
+		var invalid *domainerrors.InvalidInputError
+		if _, err := cronLine(entities.ScheduledPick{Command: []string{"outfitpicker", "pick", arg}}); !errors.As(err, &invalid) {
+			t.Errorf("cronLine() of %q error = %v, want an InvalidInputError", arg, err)
+		}
+	}
+
+	line, err = cronLine(entities.ScheduledPick{Hour: 8, Command: []string{"outfitpicker"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	replaced := withCronLine(crontab, line)
+	if strings.Count(replaced, cronMarker) != 1 || !strings.Contains(replaced, "0 8 * * * 'outfitpicker'") {
+		t.Errorf("withCronLine() of a replacement = %q", replaced)
+	}
+	if removed := withCronLine(crontab, ""); removed != "MAILTO=sam\n0 1 * * * backup\n" {
+		t.Errorf("withCronLine() of a removal = %q", removed)
+	}
+	if pick, err := parseCronLine("0 1 * * * backup"); pick != nil || err != nil {
+		t.Errorf("parseCronLine() without the daily pick = %+v, %v", pick, err)
+	}
+	if _, err := parseCronLine("* * 'broken " + cronMarker); err == nil {
+		t.Error("parseCronLine() of a malformed line error = nil")
+	}
+}
+
+func TestShellSplit(t *testing.T) {
+	words, err := shellSplit(`outfitpicker pick 'my work' it\'s`)
+	if err != nil || !slices.Equal(words, []string{"outfitpicker", "pick", "my work", "it's"}) {
+		t.Errorf("shellSplit() = %q, %v", words, err)
+	}
+	if _, err := shellSplit("'open"); err == nil {
+		t.Error("shellSplit() of an unterminated quote error = nil")
+	}
+}
+
+func TestLaunchdPlist_RoundTrip(t *testing.T) {
+	data := launchdPlist(testScheduledPick, "/Users/sam/Library/Logs/pick.log")
+	for _, want := range []string{"<string>" + launchdLabel + "</string>", "<string>a&amp;b</string>", "<integer>30</integer>"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("launchdPlist() is missing %s:\n%s", want, data)
+		}
+	}
+	pick, err := parseLaunchdPlist(data)
+	if err != nil || pick.Hour != 7 || pick.Minute != 30 || !slices.Equal(pick.Command, testScheduledPick.Command) {
+		t.Errorf("parseLaunchdPlist() = %+v, %v; want %+v", pick, err, testScheduledPick)
+	}
+	if _, err := parseLaunchdPlist([]byte("<plist><dict></dict></plist>")); err == nil {
+		t.Error("parseLaunchdPlist() without arguments error = nil")
+	}
+}
+
+func TestWindowsCommandLine_RoundTrip(t *testing.T) {
+	args := []string{`C:\Program Files\outfitpicker.exe`, "pick", `say "hi"`, `trailing\`, `C:\dir with space\`, ""}
+	line := windowsCommandLine(args)
+	if !strings.HasPrefix(line, `"C:\Program Files\outfitpicker.exe" pick "say \"hi\"" trailing\ `) {
+		t.Errorf("windowsCommandLine() = %s", line)
+	}
+	if got := splitWindowsCommandLine(line); !slices.Equal(got, args) {
+		t.Errorf("splitWindowsCommandLine() = %q, want %q", got, args)
+	}
+}
+
+func TestParseTaskXML(t *testing.T) {
+	task := `<?xml version="1.0" encoding="UTF-16"?>
+<Task version="1.2" xmlns="http://schemas.microsoft.com/windows/2004/02/mit/task">
+  <Triggers><CalendarTrigger><StartBoundary>2024-06-01T07:30:00</StartBoundary><ScheduleByDay><DaysInterval>1</DaysInterval></ScheduleByDay></CalendarTrigger></Triggers>
+  <Actions Context="Author"><Exec><Command>C:\Tools\outfitpicker.exe</Command><Arguments>pick "my work"</Arguments></Exec></Actions>
+</Task>`
+	units := utf16.Encode([]rune(task))
+	data := []byte{0xff, 0xfe}
+	for _, unit := range units {
+		data = append(data, byte(unit), byte(unit>>8))
+	}
+	for name, input := range map[string][]byte{"UTF-8": []byte(task), "UTF-16": data} {
+		pick, err := parseTaskXML(input)
+		if err != nil || pick.Time() != "07:30" || !slices.Equal(pick.Command, []string{`C:\Tools\outfitpicker.exe`, "pick", "my work"}) {
+			t.Errorf("parseTaskXML(%s) = %+v, %v", name, pick, err)
+		}
+	}
+	if _, err := parseTaskXML([]byte("<Task></Task>")); err == nil {
+		t.Error("parseTaskXML() of a task without a trigger error = nil")
+	}
+}
@@ -0,0 +1,39 @@
+package system
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Install creates the daily pick as a task of the Task Scheduler through
+// schtasks(1), replacing the task when it exists.
+func (s *OSScheduler) Install(pick entities.ScheduledPick) error {
+	_, err := s.run(nil, "schtasks", "/Create", "/F", "/SC", "DAILY", "/TN", taskName, "/ST", pick.Time(), "/TR", windowsCommandLine(pick.Command))
+	return err
+}
+
+func (s *OSScheduler) Remove() (bool, error) {
+	pick, err := s.Installed()
+	if pick == nil || err != nil {
+		return false, err
+	}
+	if _, err := s.run(nil, "schtasks", "/Delete", "/F", "/TN", taskName); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *OSScheduler) Installed() (*entities.ScheduledPick, error) {
+	out, err := s.run(nil, "schtasks", "/Query", "/TN", taskName, "/XML")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// schtasks fails when there is no such task.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseTaskXML(out)
+}
@@ -0,0 +1,104 @@
+package system
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// maxStateBundleFileSize bounds each file read from a state bundle, so a
+// damaged or hostile bundle cannot exhaust memory.
+const maxStateBundleFileSize = 64 << 20
+
+// StateBundleArchive keeps state bundles as gzipped tar files on the local
+// filesystem.
+type StateBundleArchive struct{}
+
+// NewStateBundleArchive creates a new state bundle archive.
+func NewStateBundleArchive() *StateBundleArchive {
+	return &StateBundleArchive{}
+}
+
+// Write writes files to w as a gzipped tar of regular files.
+func (a *StateBundleArchive) Write(w io.Writer, files []entities.StateBundleFile, modified time.Time) error {
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+	for _, file := range files {
+		if err := archive.WriteHeader(&tar.Header{Name: file.Name, Mode: 0600, Size: int64(len(file.Data)), ModTime: modified}); err != nil {
+			return err
+		}
+		if _, err := archive.Write(file.Data); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// Read returns the regular files of the gzipped tar at path, keyed by name.
+// Other entries are skipped.
+func (a *StateBundleArchive) Read(path string) (map[string][]byte, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrFileNotFound, path)
+	}
+	if err != nil {
+		return nil, mapFileSystemError(err, path)
+	}
+	defer f.Close()
+	files, err := readStateBundle(f)
+	if err != nil {
+		return nil, domainerrors.NewInvalidInputError(fmt.Sprintf("%s is not a state bundle: %v", path, err))
+	}
+	return files, nil
+}
+
+// MissingRoots returns those of roots that do not exist or are not
+// directories.
+func (a *StateBundleArchive) MissingRoots(roots []string) []string {
+	var missing []string
+	for _, root := range roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			missing = append(missing, root)
+		}
+	}
+	return missing
+}
+
+func readStateBundle(r io.Reader) (map[string][]byte, error) {
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer compressed.Close()
+	archive := tar.NewReader(compressed)
+	files := map[string][]byte{}
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxStateBundleFileSize {
+			return nil, fmt.Errorf("%s is larger than %d MiB", header.Name, maxStateBundleFileSize>>20)
+		}
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = data
+	}
+}
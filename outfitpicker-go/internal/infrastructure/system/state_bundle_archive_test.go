@@ -0,0 +1,63 @@
+package system
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+func TestStateBundleArchive_RoundTrip(t *testing.T) {
+	archive := NewStateBundleArchive()
+	var buf bytes.Buffer
+	files := []entities.StateBundleFile{{Name: "manifest.json", Data: []byte(`{"format":2}`)}, {Name: "config.json", Data: []byte(`{}`)}}
+	if err := archive.Write(&buf, files, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := archive.Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(read) != 2 || string(read["manifest.json"]) != `{"format":2}` || string(read["config.json"]) != `{}` {
+		t.Errorf("Read() = %q, want the written files", read)
+	}
+}
+
+func TestStateBundleArchive_ReadErrors(t *testing.T) {
+	archive := NewStateBundleArchive()
+	dir := t.TempDir()
+	if _, err := archive.Read(filepath.Join(dir, "missing.tar.gz")); !errors.Is(err, domainerrors.ErrFileNotFound) {
+		t.Errorf("Read(missing) error = %v, want ErrFileNotFound", err)
+	}
+
+	notes := filepath.Join(dir, "notes.tar.gz")
+	if err := os.WriteFile(notes, []byte("not a bundle"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var invalid *domainerrors.InvalidInputError
+	if _, err := archive.Read(notes); !errors.As(err, &invalid) {
+		t.Errorf("Read(notes) error = %v, want an InvalidInputError", err)
+	}
+}
+
+func TestStateBundleArchive_MissingRoots(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	missing := NewStateBundleArchive().MissingRoots([]string{dir, file, filepath.Join(dir, "gone")})
+	if len(missing) != 2 || missing[0] != file {
+		t.Errorf("MissingRoots() = %v, want the file and the missing directory", missing)
+	}
+}
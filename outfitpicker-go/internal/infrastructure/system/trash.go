@@ -0,0 +1,43 @@
+package system
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// trashInfoTimeLayout is the layout of DeletionDate in a .trashinfo file.
+const trashInfoTimeLayout = "2006-01-02T15:04:05"
+
+// OSTrash moves files to the user's trash, from which they can be restored
+// with the desktop's file manager: the freedesktop.org trash of the home
+// directory, ~/.Trash on macOS. Windows has no trash reachable this way.
+type OSTrash struct {
+	home func() (string, error)
+	now  func() time.Time
+}
+
+// NewOSTrash returns the trash of the current platform.
+func NewOSTrash() *OSTrash {
+	return &OSTrash{home: os.UserHomeDir, now: time.Now}
+}
+
+// trashInfo is the content of the .trashinfo file recording that path was
+// moved to the trash at deletedAt.
+func trashInfo(path string, deletedAt time.Time) string {
+	escaped := (&url.URL{Path: path}).EscapedPath()
+	return fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", escaped, deletedAt.Format(trashInfoTimeLayout))
+}
+
+// trashName returns the n-th name tried for a file called name in the
+// trash: name itself, then name followed by " 2", " 3" and so on before its
+// extension.
+func trashName(name string, n int) string {
+	if n < 2 {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return fmt.Sprintf("%s %d%s", name[:len(name)-len(ext)], n, ext)
+}
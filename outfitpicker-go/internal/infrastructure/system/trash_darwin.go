@@ -0,0 +1,29 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Trash moves path to ~/.Trash under a name not yet taken there, and
+// returns the path in the trash.
+func (t *OSTrash) Trash(path string) (string, error) {
+	home, err := t.home()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".Trash")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", mapFileSystemError(err, dir)
+	}
+	for n := 1; ; n++ {
+		target := filepath.Join(dir, trashName(filepath.Base(path), n))
+		if _, err := os.Lstat(target); err == nil {
+			continue
+		}
+		if err := os.Rename(path, target); err != nil {
+			return "", mapFileSystemError(err, path)
+		}
+		return target, nil
+	}
+}
@@ -0,0 +1,71 @@
+//go:build !darwin && !windows
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Trash moves path to the freedesktop.org trash of the home directory,
+// $XDG_DATA_HOME/Trash or ~/.local/share/Trash, recording where it came
+// from in a .trashinfo file so it can be restored. It returns the path in
+// the trash.
+func (t *OSTrash) Trash(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	dir, err := t.dir()
+	if err != nil {
+		return "", err
+	}
+	files, info := filepath.Join(dir, "files"), filepath.Join(dir, "info")
+	for _, d := range []string{files, info} {
+		if err := os.MkdirAll(d, 0700); err != nil {
+			return "", mapFileSystemError(err, d)
+		}
+	}
+
+	for n := 1; ; n++ {
+		name := trashName(filepath.Base(path), n)
+		// Creating the info file first claims the name, as the
+		// specification asks.
+		infoPath := filepath.Join(info, name+".trashinfo")
+		f, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", mapFileSystemError(err, infoPath)
+		}
+		_, err = f.WriteString(trashInfo(path, t.now()))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		target := filepath.Join(files, name)
+		if err == nil {
+			if _, statErr := os.Lstat(target); statErr == nil {
+				os.Remove(infoPath)
+				continue
+			}
+			err = os.Rename(path, target)
+		}
+		if err != nil {
+			os.Remove(infoPath)
+			return "", mapFileSystemError(err, path)
+		}
+		return target, nil
+	}
+}
+
+func (t *OSTrash) dir() (string, error) {
+	if data := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(data) {
+		return filepath.Join(data, "Trash"), nil
+	}
+	home, err := t.home()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
@@ -0,0 +1,42 @@
+//go:build !darwin && !windows
+
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOSTrash_Trash(t *testing.T) {
+	data := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", data)
+	root := t.TempDir()
+	trash := &OSTrash{home: os.UserHomeDir, now: func() time.Time { return time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local) }}
+
+	var targets []string
+	for range 2 {
+		mustWrite(t, filepath.Join(root, "formal", "suit.avatar"))
+		target, err := trash.Trash(filepath.Join(root, "formal"))
+		if err != nil {
+			t.Fatalf("Trash() error = %v", err)
+		}
+		targets = append(targets, target)
+	}
+
+	if want := filepath.Join(data, "Trash", "files", "formal 2"); targets[1] != want {
+		t.Errorf("second Trash() = %v, want %v", targets[1], want)
+	}
+	if _, err := os.Stat(filepath.Join(targets[0], "suit.avatar")); err != nil {
+		t.Errorf("trashed file missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "formal")); !os.IsNotExist(err) {
+		t.Errorf("source still present, stat error = %v", err)
+	}
+	info, err := os.ReadFile(filepath.Join(data, "Trash", "info", "formal.trashinfo"))
+	if err != nil || !strings.Contains(string(info), "Path="+filepath.Join(root, "formal")+"\n") {
+		t.Errorf("trashinfo = %q, %v", info, err)
+	}
+}
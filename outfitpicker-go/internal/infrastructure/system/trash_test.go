@@ -0,0 +1,31 @@
+package system
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrashInfo(t *testing.T) {
+	got := trashInfo("/home/sam/Wardrobe/date night", time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC))
+	want := "[Trash Info]\nPath=/home/sam/Wardrobe/date%20night\nDeletionDate=2024-03-01T09:30:00\n"
+	if got != want {
+		t.Errorf("trashInfo() = %q, want %q", got, want)
+	}
+}
+
+func TestTrashName(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{"formal", 1, "formal"},
+		{"formal", 2, "formal 2"},
+		{"tee.avatar", 3, "tee 3.avatar"},
+	}
+	for _, tt := range tests {
+		if got := trashName(tt.name, tt.n); got != tt.want {
+			t.Errorf("trashName(%q, %d) = %q, want %q", tt.name, tt.n, got, tt.want)
+		}
+	}
+}
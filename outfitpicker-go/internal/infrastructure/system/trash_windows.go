@@ -0,0 +1,8 @@
+package system
+
+import domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+
+// Trash fails: the Recycle Bin is not reachable without the shell API.
+func (t *OSTrash) Trash(path string) (string, error) {
+	return "", domainerrors.NewInvalidInputError("moving files to the Recycle Bin is not supported; archive them or leave them on disk instead")
+}
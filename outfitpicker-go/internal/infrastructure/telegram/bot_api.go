@@ -0,0 +1,150 @@
+// Package telegram talks to chats through the Telegram Bot API.
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// BotAPIURL is the base URL of the Telegram Bot API.
+const BotAPIURL = "https://api.telegram.org"
+
+const requestTimeout = 10 * time.Second
+
+// BotAPI sends and reads chat messages as a Telegram bot.
+type BotAPI struct {
+	client  *http.Client
+	baseURL string
+}
+
+// Option configures a BotAPI.
+type Option func(*BotAPI)
+
+// WithHTTPClient replaces the HTTP client, for tests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *BotAPI) {
+		b.client = client
+	}
+}
+
+// WithBaseURL replaces BotAPIURL, for tests and self-hosted Bot API servers.
+func WithBaseURL(baseURL string) Option {
+	return func(b *BotAPI) {
+		b.baseURL = baseURL
+	}
+}
+
+// NewBotAPI creates a BotAPI that calls BotAPIURL.
+func NewBotAPI(opts ...Option) *BotAPI {
+	b := &BotAPI{
+		client:  &http.Client{Timeout: requestTimeout},
+		baseURL: BotAPIURL,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// botResponse is the envelope of every Bot API response.
+type botResponse struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description"`
+	Result      json.RawMessage `json:"result"`
+}
+
+// update is the part of a Bot API update that is read: a text message and
+// the chat it was sent in.
+type update struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+// Send posts text to the chat with the given ID.
+func (b *BotAPI) Send(ctx context.Context, token, chatID, text string) error {
+	body, err := json.Marshal(map[string]string{"chat_id": chatID, "text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.methodURL(token, "sendMessage"), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	_, err = b.call(req, "sending the Telegram message")
+	return err
+}
+
+// Messages returns the text messages sent to the bot from offset on. Other
+// updates, such as edits and joins, are confirmed but left out.
+func (b *BotAPI) Messages(ctx context.Context, token string, offset int64) ([]entities.ChatMessage, error) {
+	query := url.Values{
+		"offset":          {strconv.FormatInt(offset, 10)},
+		"timeout":         {"0"},
+		"allowed_updates": {`["message"]`},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.methodURL(token, "getUpdates")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.call(req, "reading Telegram messages")
+	if err != nil {
+		return nil, err
+	}
+	var updates []update
+	if err := json.Unmarshal(result, &updates); err != nil {
+		return nil, fmt.Errorf("reading Telegram messages: %w", err)
+	}
+	messages := make([]entities.ChatMessage, 0, len(updates))
+	for _, u := range updates {
+		if u.Message == nil || u.Message.Text == "" {
+			continue
+		}
+		messages = append(messages, entities.ChatMessage{
+			UpdateID: u.UpdateID,
+			ChatID:   strconv.FormatInt(u.Message.Chat.ID, 10),
+			Text:     u.Message.Text,
+		})
+	}
+	return messages, nil
+}
+
+func (b *BotAPI) methodURL(token, method string) string {
+	return b.baseURL + "/bot" + token + "/" + method
+}
+
+// call makes a Bot API request and returns its result. Errors describe
+// what was being done, never the request URL, which holds the token.
+func (b *BotAPI) call(req *http.Request, doing string) (json.RawMessage, error) {
+	resp, err := b.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%s: %w", doing, err)
+	}
+	defer resp.Body.Close()
+	var body botResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("%s: %s", doing, resp.Status)
+	}
+	if !body.OK {
+		return nil, fmt.Errorf("%s: %s", doing, body.Description)
+	}
+	return body.Result, nil
+}
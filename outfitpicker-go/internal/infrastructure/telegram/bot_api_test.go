@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func newTestBotAPI(t *testing.T, handler http.HandlerFunc) *BotAPI {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewBotAPI(WithHTTPClient(server.Client()), WithBaseURL(server.URL))
+}
+
+func TestBotAPI_Send(t *testing.T) {
+	var path string
+	var sent map[string]string
+	api := newTestBotAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":7}}`))
+	})
+
+	if err := api.Send(context.Background(), "123:abc", "-42", "casual/tee.avatar"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if path != "/bot123:abc/sendMessage" {
+		t.Errorf("path = %q", path)
+	}
+	if sent["chat_id"] != "-42" || sent["text"] != "casual/tee.avatar" {
+		t.Errorf("sent = %v", sent)
+	}
+}
+
+func TestBotAPI_Messages(t *testing.T) {
+	var query string
+	api := newTestBotAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"ok":true,"result":[
+			{"update_id":10,"message":{"chat":{"id":-42},"text":"skip"}},
+			{"update_id":11,"edited_message":{"chat":{"id":-42},"text":"worn"}},
+			{"update_id":12,"message":{"chat":{"id":99},"text":"worn"}}
+		]}`))
+	})
+
+	messages, err := api.Messages(context.Background(), "123:abc", 10)
+	if err != nil {
+		t.Fatalf("Messages() error = %v", err)
+	}
+	want := []entities.ChatMessage{{UpdateID: 10, ChatID: "-42", Text: "skip"}, {UpdateID: 12, ChatID: "99", Text: "worn"}}
+	if len(messages) != len(want) || messages[0] != want[0] || messages[1] != want[1] {
+		t.Errorf("Messages() = %+v, want %+v", messages, want)
+	}
+	if !strings.Contains(query, "offset=10") {
+		t.Errorf("query %q is missing the offset", query)
+	}
+}
+
+func TestBotAPI_Errors(t *testing.T) {
+	api := newTestBotAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+	})
+	err := api.Send(context.Background(), "123:secret", "-42", "hi")
+	if err == nil || err.Error() != "sending the Telegram message: Unauthorized" {
+		t.Errorf("Send() error = %v, want the API's description", err)
+	}
+
+	unreachable := NewBotAPI(WithBaseURL("http://127.0.0.1:1"))
+	_, err = unreachable.Messages(context.Background(), "123:secret", 0)
+	if err == nil || strings.Contains(err.Error(), "secret") {
+		t.Errorf("Messages() error = %v, want one that leaves the token out", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package weather looks up weather forecasts.
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// OpenMeteoURL is the forecast endpoint of the Open-Meteo API, which needs
+// no API key.
+const OpenMeteoURL = "https://api.open-meteo.com/v1/forecast"
+
+const (
+	requestTimeout = 10 * time.Second
+	dateLayout     = "2006-01-02"
+)
+
+// OpenMeteoProvider looks up daily forecasts from the Open-Meteo API.
+type OpenMeteoProvider struct {
+	client  *http.Client
+	baseURL string
+}
+
+// Option configures an OpenMeteoProvider.
+type Option func(*OpenMeteoProvider)
+
+// WithHTTPClient replaces the HTTP client, for tests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *OpenMeteoProvider) {
+		p.client = client
+	}
+}
+
+// WithBaseURL replaces OpenMeteoURL, for tests and self-hosted instances.
+func WithBaseURL(baseURL string) Option {
+	return func(p *OpenMeteoProvider) {
+		p.baseURL = baseURL
+	}
+}
+
+// NewOpenMeteoProvider creates a provider that queries OpenMeteoURL.
+func NewOpenMeteoProvider(opts ...Option) *OpenMeteoProvider {
+	p := &OpenMeteoProvider{
+		client:  &http.Client{Timeout: requestTimeout},
+		baseURL: OpenMeteoURL,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// openMeteoResponse is the part of an Open-Meteo forecast response that is
+// read: one value per day for each requested daily variable.
+type openMeteoResponse struct {
+	Daily struct {
+		Time                        []string  `json:"time"`
+		Temperature2mMax            []float64 `json:"temperature_2m_max"`
+		Temperature2mMin            []float64 `json:"temperature_2m_min"`
+		PrecipitationProbabilityMax []*int    `json:"precipitation_probability_max"`
+	} `json:"daily"`
+}
+
+// Forecast returns the weather expected at location on the day of date, in
+// the location's time zone. The request is abandoned once ctx is done.
+func (p *OpenMeteoProvider) Forecast(ctx context.Context, location entities.WeatherLocation, date time.Time) (entities.Forecast, error) {
+	day := date.Format(dateLayout)
+	query := url.Values{
+		"latitude":   {strconv.FormatFloat(location.Latitude, 'f', -1, 64)},
+		"longitude":  {strconv.FormatFloat(location.Longitude, 'f', -1, 64)},
+		"daily":      {"temperature_2m_max,temperature_2m_min,precipitation_probability_max"},
+		"timezone":   {"auto"},
+		"start_date": {day},
+		"end_date":   {day},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return entities.Forecast{}, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return entities.Forecast{}, fmt.Errorf("fetching the forecast: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return entities.Forecast{}, fmt.Errorf("fetching the forecast: %s", resp.Status)
+	}
+
+	var body openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return entities.Forecast{}, fmt.Errorf("reading the forecast: %w", err)
+	}
+	daily := body.Daily
+	if len(daily.Time) == 0 || daily.Time[0] != day || len(daily.Temperature2mMax) == 0 || len(daily.Temperature2mMin) == 0 {
+		return entities.Forecast{}, fmt.Errorf("reading the forecast: no forecast for %s", day)
+	}
+	forecastDate, err := time.Parse(dateLayout, day)
+	if err != nil {
+		return entities.Forecast{}, err
+	}
+	forecast := entities.Forecast{
+		Location:       location,
+		Date:           forecastDate,
+		MinTemperature: daily.Temperature2mMin[0],
+		MaxTemperature: daily.Temperature2mMax[0],
+	}
+	// Open-Meteo has no precipitation chance for some models and days.
+	if len(daily.PrecipitationProbabilityMax) > 0 && daily.PrecipitationProbabilityMax[0] != nil {
+		forecast.PrecipitationChance = *daily.PrecipitationProbabilityMax[0]
+	}
+	return forecast, nil
+}
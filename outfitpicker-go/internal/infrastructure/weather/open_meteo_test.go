@@ -0,0 +1,87 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+var testNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *OpenMeteoProvider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewOpenMeteoProvider(
+		WithHTTPClient(server.Client()),
+		WithBaseURL(server.URL),
+	)
+}
+
+func TestOpenMeteoProvider_Forecast(t *testing.T) {
+	var query string
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"daily":{"time":["2024-06-01"],"temperature_2m_max":[28.4],"temperature_2m_min":[15.1],"precipitation_probability_max":[65]}}`))
+	})
+	location := entities.WeatherLocation{Latitude: 51.5, Longitude: -0.12}
+
+	forecast, err := provider.Forecast(context.Background(), location, testNow)
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+	want := entities.Forecast{
+		Location:            location,
+		Date:                time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		MinTemperature:      15.1,
+		MaxTemperature:      28.4,
+		PrecipitationChance: 65,
+	}
+	if forecast != want {
+		t.Errorf("Forecast() = %+v, want %+v", forecast, want)
+	}
+	for _, param := range []string{"latitude=51.5", "longitude=-0.12", "start_date=2024-06-01", "end_date=2024-06-01", "timezone=auto"} {
+		if !strings.Contains(query, param) {
+			t.Errorf("query %q is missing %s", query, param)
+		}
+	}
+}
+
+func TestOpenMeteoProvider_MissingPrecipitation(t *testing.T) {
+	provider := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"daily":{"time":["2024-06-01"],"temperature_2m_max":[12],"temperature_2m_min":[4],"precipitation_probability_max":[null]}}`))
+	})
+	forecast, err := provider.Forecast(context.Background(), entities.WeatherLocation{}, testNow)
+	if err != nil || forecast.PrecipitationChance != 0 {
+		t.Errorf("Forecast() = %+v, %v; want no chance of rain", forecast, err)
+	}
+}
+
+func TestOpenMeteoProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+		}},
+		{"malformed body", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"daily":`))
+		}},
+		{"other day", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"daily":{"time":["2024-06-02"],"temperature_2m_max":[12],"temperature_2m_min":[4]}}`))
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newTestProvider(t, tt.handler).Forecast(context.Background(), entities.WeatherLocation{}, testNow); err == nil {
+				t.Error("Forecast() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package presentation
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// OutfitRefV1 converts an outfit reference into its version 1 output shape.
+func OutfitRefV1(outfit entities.OutfitReference) v1.OutfitRef {
+	return v1.OutfitRef{
+		FileName: outfit.FileName,
+		Category: categoryRefV1(outfit.Category),
+	}
+}
+
+// categoryRefV1 converts a category reference into its version 1 output
+// shape.
+func categoryRefV1(category entities.CategoryReference) v1.CategoryRef {
+	return v1.CategoryRef{Name: category.Name, Path: category.Path}
+}
+
+// AggregatePickV1 converts a pick from more than one category into its
+// version 1 output shape.
+func AggregatePickV1(outfit entities.OutfitReference, skipped []entities.CategoryInfo, policy string, resting []string) v1.AggregatePick {
+	result := v1.AggregatePick{Outfit: OutfitRefV1(outfit), Policy: policy, Resting: slices.Clone(resting)}
+	for _, info := range skipped {
+		category := v1.SkippedCategory{
+			Category:    categoryRefV1(info.Category),
+			State:       string(info.State),
+			OutfitCount: info.OutfitCount,
+		}
+		if description := info.Description; description != nil {
+			category.Description = &v1.CategoryDescription{
+				Description: description.Description,
+				Label:       description.Label,
+				Tags:        slices.Clone(description.Tags),
+				Slot:        description.Slot,
+			}
+		}
+		result.Skipped = append(result.Skipped, category)
+	}
+	return result
+}
+
+// PickV1 converts a selection record into its version 1 output shape.
+func PickV1(record entities.SelectionRecord) v1.Pick {
+	return v1.Pick{Category: record.Category, FileName: record.FileName, SelectedAt: record.SelectedAt, OutfitID: record.OutfitID}
+}
+
+// WearV1 converts a wear event into its version 1 output shape.
+func WearV1(event entities.WearEvent) v1.Wear {
+	var feedback []string
+	for _, kind := range event.Feedback {
+		feedback = append(feedback, string(kind))
+	}
+	return v1.Wear{
+		Category:          event.Category,
+		FileName:          event.FileName,
+		WornAt:            event.WornAt,
+		Feedback:          feedback,
+		Note:              event.Note,
+		CompletedRotation: event.CompletedRotation,
+		OutfitID:          event.OutfitID,
+	}
+}
+
+// MetadataV1 converts an outfit's metadata into its version 1 output shape.
+func MetadataV1(metadata entities.OutfitMetadata) v1.Metadata {
+	var materials []v1.Material
+	for _, component := range metadata.Materials {
+		materials = append(materials, v1.Material{Fiber: component.Fiber, Percent: component.Percent})
+	}
+	return v1.Metadata{
+		Materials: materials,
+		Care:      slices.Clone(metadata.Care),
+		Price:     metadata.Price,
+		Tags:      slices.Clone(metadata.Tags),
+		Rating:    metadata.Rating,
+		Colors:    slices.Clone(metadata.Colors),
+	}
+}
+
+// ListV1 converts a list into its version 1 output shape, each item
+// converted by convert. A nil list stays nil so optional lists are still
+// left out.
+func ListV1[T, U any](items []T, convert func(T) U) []U {
+	if items == nil {
+		return nil
+	}
+	converted := make([]U, len(items))
+	for i, item := range items {
+		converted[i] = convert(item)
+	}
+	return converted
+}
+
+// PageV1 converts a page of history into its version 1 output shape, each
+// item converted by convert.
+func PageV1[T, U any](page entities.Page[T], convert func(T) U) v1.Page[U] {
+	items := make([]U, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = convert(item)
+	}
+	return v1.Page[U]{Items: items, Page: page.Page, Limit: page.Limit, Total: page.Total}
+}
+
+// RouletteV1 converts the outfit a roulette settled on, with the vetoes made
+// on the way, into its version 1 output shape.
+func RouletteV1(outfit entities.OutfitReference, vetoes []RouletteVeto) v1.Roulette {
+	return v1.Roulette{
+		Outfit: OutfitRefV1(outfit),
+		Vetoes: ListV1(vetoes, func(veto RouletteVeto) v1.RouletteVeto {
+			return v1.RouletteVeto{FileName: veto.FileName, Reason: veto.Reason}
+		}),
+	}
+}
@@ -0,0 +1,199 @@
+package presentation
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// WearAnalyticsV1 converts wear analytics into their version 1 output
+// shape.
+func WearAnalyticsV1(analytics logic.WearAnalytics) v1.WearAnalytics {
+	return v1.WearAnalytics{
+		Outfits: ListV1(analytics.Outfits, func(outfit logic.OutfitWears) v1.OutfitWears {
+			return v1.OutfitWears{Category: outfit.Category, FileName: outfit.FileName, Wears: outfit.Wears, LastWorn: outfit.LastWorn}
+		}),
+		MostWornCategory:    categoryWearsV1(analytics.MostWornCategory),
+		LeastWornCategory:   categoryWearsV1(analytics.LeastWornCategory),
+		CompletedRotations:  analytics.CompletedRotations,
+		AverageRotationDays: analytics.AverageRotationDays,
+		PickStreakDays:      analytics.PickStreakDays,
+		LockedRotations: ListV1(analytics.LockedRotations, func(locked logic.LockedRotation) v1.LockedRotation {
+			return v1.LockedRotation{Category: locked.Category, Worn: locked.Worn, Total: locked.Total, AwaitingReset: locked.AwaitingReset}
+		}),
+	}
+}
+
+func categoryWearsV1(wears *logic.CategoryWears) *v1.CategoryWears {
+	if wears == nil {
+		return nil
+	}
+	return &v1.CategoryWears{Category: wears.Category, Wears: wears.Wears}
+}
+
+// OutfitRatingV1 converts an outfit's rating into its version 1 output
+// shape.
+func OutfitRatingV1(rating entities.OutfitRating) v1.OutfitRating {
+	return v1.OutfitRating{Category: rating.Category, FileName: rating.FileName, Rating: rating.Rating}
+}
+
+// RatingsReportV1 converts a ratings report into its version 1 output
+// shape.
+func RatingsReportV1(report entities.RatingsReport) v1.RatingsReport {
+	return v1.RatingsReport{
+		Categories: ListV1(report.Categories, func(category entities.CategoryRatings) v1.CategoryRatings {
+			return v1.CategoryRatings{Category: category.Category, Rated: category.Rated, Average: category.Average}
+		}),
+		Outfits: ListV1(report.Outfits, OutfitRatingV1),
+	}
+}
+
+// WardrobeGrowthV1 converts a growth report into its version 1 output
+// shape.
+func WardrobeGrowthV1(growth entities.WardrobeGrowth) v1.WardrobeGrowth {
+	return v1.WardrobeGrowth{
+		Points: slices.Clone(growth.Points),
+		Categories: ListV1(growth.Categories, func(category entities.CategoryGrowth) v1.CategoryGrowth {
+			return v1.CategoryGrowth{Category: category.Category, Counts: slices.Clone(category.Counts)}
+		}),
+		Totals: slices.Clone(growth.Totals),
+	}
+}
+
+// PickHeatmapV1 converts a pick heatmap into its version 1 output shape.
+func PickHeatmapV1(heatmap logic.PickHeatmap) v1.PickHeatmap {
+	return v1.PickHeatmap{
+		Year:     heatmap.Year,
+		Category: heatmap.Category,
+		Days: ListV1(heatmap.Days, func(day logic.HeatmapDay) v1.HeatmapDay {
+			return v1.HeatmapDay{Date: day.Date, Picks: day.Picks}
+		}),
+		MaxPicks: heatmap.MaxPicks,
+		Total:    heatmap.Total,
+	}
+}
+
+// MonthlyReportV1 converts a monthly report into its version 1 output
+// shape.
+func MonthlyReportV1(report usecases.MonthlyReport) v1.MonthlyReport {
+	return v1.MonthlyReport{
+		Month: report.Month,
+		Picks: report.Picks,
+		PicksByCategory: ListV1(report.PicksByCategory, func(count usecases.CategoryCount) v1.CategoryCount {
+			return v1.CategoryCount{Category: count.Category, Count: count.Count}
+		}),
+		Wears: report.Wears,
+		CompletedRotations: ListV1(report.CompletedRotations, func(rotation usecases.CompletedRotation) v1.CompletedRotation {
+			return v1.CompletedRotation{Category: rotation.Category, CompletedAt: rotation.CompletedAt}
+		}),
+		Neglected: ListV1(report.Neglected, func(outfit usecases.NeglectedOutfit) v1.NeglectedOutfit {
+			return v1.NeglectedOutfit{Category: outfit.Category, FileName: outfit.FileName, LastWorn: outfit.LastWorn}
+		}),
+		NeglectedTotal: report.NeglectedTotal,
+		SpendPerWear: ListV1(report.SpendPerWear, func(outfit usecases.OutfitSpendPerWear) v1.OutfitSpendPerWear {
+			return v1.OutfitSpendPerWear{
+				Category:      outfit.Category,
+				FileName:      outfit.FileName,
+				Price:         outfit.Price,
+				Wears:         outfit.Wears,
+				PerWear:       outfit.PerWear,
+				WornThisMonth: outfit.WornThisMonth,
+			}
+		}),
+	}
+}
+
+// ShoppingSuggestionV1 converts a shopping suggestion into its version 1
+// output shape.
+func ShoppingSuggestionV1(suggestion logic.ShoppingSuggestion) v1.ShoppingSuggestion {
+	return v1.ShoppingSuggestion{
+		Category:      suggestion.Category,
+		Outfits:       suggestion.Outfits,
+		WearsPerMonth: suggestion.WearsPerMonth,
+		RotationDays:  suggestion.RotationDays,
+		Suggested:     suggestion.Suggested,
+	}
+}
+
+// ChallengeReportV1 converts a challenge report into its version 1 output
+// shape.
+func ChallengeReportV1(report logic.ChallengeReport) v1.ChallengeReport {
+	return v1.ChallengeReport{
+		StartedAt:  report.StartedAt,
+		EndsAt:     report.EndsAt,
+		Finished:   report.Finished,
+		EndedEarly: report.EndedEarly,
+		Day:        report.Day,
+		Days:       report.Days,
+		Capsule: ListV1(report.Capsule, func(outfit logic.ChallengeOutfit) v1.ChallengeOutfit {
+			return v1.ChallengeOutfit{Category: outfit.Category, FileName: outfit.FileName, Wears: outfit.Wears}
+		}),
+		Wears:       report.Wears,
+		WornOutfits: report.WornOutfits,
+		DaysWorn:    report.DaysWorn,
+	}
+}
+
+// PlanReportV1 converts a plan report into its version 1 output shape.
+func PlanReportV1(report logic.PlanReport) v1.PlanReport {
+	return v1.PlanReport{
+		CreatedAt: report.CreatedAt,
+		Days: ListV1(report.Days, func(day logic.PlanDayStatus) v1.PlanDayStatus {
+			return v1.PlanDayStatus{
+				Date: day.Date,
+				Worn: day.Worn,
+				Outfits: ListV1(day.Outfits, func(outfit logic.PlannedOutfitStatus) v1.PlannedOutfitStatus {
+					return v1.PlannedOutfitStatus{Category: outfit.Category, FileName: outfit.FileName, Status: outfit.Status}
+				}),
+			}
+		}),
+		Conflicts: report.Conflicts,
+	}
+}
+
+// CategoryHealthV1 converts the health of each category into its version 1
+// output shape.
+func CategoryHealthV1(health map[string]logic.CategoryHealth) map[string]v1.CategoryHealth {
+	if health == nil {
+		return nil
+	}
+	converted := make(map[string]v1.CategoryHealth, len(health))
+	for name, category := range health {
+		components := category.Components
+		converted[name] = v1.CategoryHealth{
+			Score:  category.Score,
+			Status: string(category.Status),
+			Components: v1.HealthComponents{
+				Progress:  components.Progress,
+				Staleness: components.Staleness,
+				WearRate:  components.WearRate,
+				Laundry:   components.Laundry,
+			},
+			Reasons: slices.Clone(category.Reasons),
+		}
+	}
+	return converted
+}
+
+// WearDiscrepancyV1 converts a wear discrepancy into its version 1 output
+// shape.
+func WearDiscrepancyV1(discrepancy entities.WearDiscrepancy) v1.WearDiscrepancy {
+	return v1.WearDiscrepancy{Category: discrepancy.Category, FileName: discrepancy.FileName, Kind: string(discrepancy.Kind)}
+}
+
+// CategoryRepairV1 converts a category repair into its version 1 output
+// shape.
+func CategoryRepairV1(repair entities.CategoryRepair) v1.CategoryRepair {
+	return v1.CategoryRepair{
+		Category:     repair.Category,
+		Removed:      repair.Removed,
+		TotalBefore:  repair.TotalBefore,
+		TotalAfter:   repair.TotalAfter,
+		DroppedWorn:  slices.Clone(repair.DroppedWorn),
+		AddedKnown:   slices.Clone(repair.AddedKnown),
+		RemovedKnown: slices.Clone(repair.RemovedKnown),
+	}
+}
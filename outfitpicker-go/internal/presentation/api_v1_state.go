@@ -0,0 +1,250 @@
+package presentation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// BackupV1 converts a backup into its version 1 output shape.
+func BackupV1(backup entities.Backup) v1.Backup {
+	return v1.Backup{ID: backup.ID, CreatedAt: backup.CreatedAt, Files: slices.Clone(backup.Files)}
+}
+
+// BackupCreatedV1 converts a new backup into its version 1 output shape.
+func BackupCreatedV1(result usecases.BackupResult) v1.BackupCreated {
+	return v1.BackupCreated{Backup: BackupV1(result.Backup), Removed: ListV1(result.Removed, BackupV1)}
+}
+
+// BackupRestoredV1 converts a restored backup into its version 1 output
+// shape.
+func BackupRestoredV1(result usecases.RestoreResult) v1.BackupRestored {
+	return v1.BackupRestored{Restored: BackupV1(result.Restored), Previous: BackupV1(result.Previous)}
+}
+
+// BundleManifestV1 converts a state bundle manifest into its version 1
+// output shape.
+func BundleManifestV1(manifest entities.StateBundleManifest) v1.BundleManifest {
+	return v1.BundleManifest{
+		Format:    manifest.Format,
+		CreatedAt: manifest.CreatedAt,
+		Version:   manifest.Version,
+		Files:     slices.Clone(manifest.Files),
+	}
+}
+
+// BundleImportProposalV1 converts a state bundle ready to import into its
+// version 1 output shape.
+func BundleImportProposalV1(proposal usecases.StateBundleProposal) v1.BundleImportProposal {
+	return v1.BundleImportProposal{
+		Manifest:     BundleManifestV1(proposal.Manifest),
+		Roots:        slices.Clone(proposal.Roots),
+		MissingRoots: slices.Clone(proposal.MissingRoots),
+	}
+}
+
+// BundleImportV1 converts an imported state bundle into its version 1
+// output shape.
+func BundleImportV1(result usecases.StateBundleImport) v1.BundleImport {
+	imported := v1.BundleImport{BundleImportProposal: BundleImportProposalV1(*result.StateBundleProposal)}
+	if result.Previous != nil {
+		previous := BackupV1(*result.Previous)
+		imported.Previous = &previous
+	}
+	return imported
+}
+
+// PackManifestV1 converts an outfit pack manifest into its version 1 output
+// shape.
+func PackManifestV1(manifest entities.PackManifest) v1.PackManifest {
+	return v1.PackManifest{
+		Format:    manifest.Format,
+		Category:  manifest.Category,
+		CreatedAt: manifest.CreatedAt,
+		Labels:    maps.Clone(manifest.Labels),
+		Outfits: ListV1(manifest.Outfits, func(outfit entities.PackOutfit) v1.PackOutfit {
+			converted := v1.PackOutfit{FileName: outfit.FileName}
+			if outfit.Metadata != nil {
+				metadata := MetadataV1(*outfit.Metadata)
+				converted.Metadata = &metadata
+			}
+			return converted
+		}),
+	}
+}
+
+// ArchiveImportV1 converts an archive import into its version 1 output
+// shape.
+func ArchiveImportV1(result usecases.ArchiveImportResult) v1.ArchiveImport {
+	return v1.ArchiveImport{
+		Category: result.Category,
+		Imported: ListV1(result.Imported, func(outfit entities.ImportedOutfit) v1.ImportedOutfit {
+			return v1.ImportedOutfit{Entry: outfit.Entry, FileName: outfit.FileName, Renamed: outfit.Renamed}
+		}),
+		Skipped: ListV1(result.Skipped, skippedEntryV1),
+		Outfits: result.Outfits,
+	}
+}
+
+// CSVImportV1 converts a spreadsheet metadata import into its version 1
+// output shape.
+func CSVImportV1(proposal usecases.CSVImportProposal) v1.CSVImport {
+	return v1.CSVImport{
+		Rows: ListV1(proposal.Rows, func(row entities.MetadataImportRow) v1.MetadataImportRow {
+			return v1.MetadataImportRow{
+				Line:     row.Line,
+				Category: row.Category,
+				FileName: row.FileName,
+				Tags:     slices.Clone(row.Tags),
+				Weight:   row.Weight,
+				Rating:   row.Rating,
+			}
+		}),
+		Skipped: ListV1(proposal.Skipped, func(skip usecases.CSVImportSkip) v1.CSVImportSkip {
+			return v1.CSVImportSkip{Line: skip.Line, Category: skip.Category, FileName: skip.FileName, Reason: skip.Reason}
+		}),
+	}
+}
+
+// IntegrityStatusV1 converts the integrity status into its version 1
+// output shape.
+func IntegrityStatusV1(status entities.IntegrityStatus) v1.IntegrityStatus {
+	return v1.IntegrityStatus{Enabled: status.Enabled, Failed: slices.Clone(status.Failed)}
+}
+
+// ProfileV1 converts a profile into its version 1 output shape.
+func ProfileV1(profile entities.Profile) v1.Profile {
+	return v1.Profile{Name: profile.Name, Active: profile.Active}
+}
+
+// OnboardingV1 converts onboarding guidance into its version 1 output
+// shape.
+func OnboardingV1(guidance usecases.OnboardingGuidance) v1.Onboarding {
+	return v1.Onboarding{
+		Status:     guidance.Status,
+		ConfigPath: guidance.ConfigPath,
+		Steps: ListV1(guidance.Steps, func(step usecases.OnboardingStep) v1.OnboardingStep {
+			return v1.OnboardingStep{ID: step.ID, Description: step.Description, Command: step.Command}
+		}),
+	}
+}
+
+// RotationResetV1 converts a rotation reset into its version 1 output
+// shape.
+func RotationResetV1(proposal usecases.ResetProposal) v1.RotationReset {
+	return v1.RotationReset{
+		Category: proposal.Category,
+		Worn:     slices.Clone(proposal.Worn),
+		Total:    proposal.Total,
+		Profile:  proposal.Profile,
+	}
+}
+
+// RotationSnapshotV1 converts a rotation snapshot into its version 1 output
+// shape.
+func RotationSnapshotV1(snapshot entities.RotationSnapshot) v1.RotationSnapshot {
+	return v1.RotationSnapshot{
+		At: snapshot.At,
+		Categories: ListV1(snapshot.Categories, func(category entities.CategorySnapshot) v1.CategorySnapshot {
+			converted := v1.CategorySnapshot{
+				Category:           category.Category,
+				Outfits:            slices.Clone(category.Outfits),
+				Worn:               slices.Clone(category.Worn),
+				Available:          slices.Clone(category.Available),
+				CompletedRotations: category.CompletedRotations,
+			}
+			if category.LastPick != nil {
+				pick := PickV1(*category.LastPick)
+				converted.LastPick = &pick
+			}
+			return converted
+		}),
+	}
+}
+
+// CommandRecordV1 converts a recorded command into its version 1 output
+// shape.
+func CommandRecordV1(record entities.CommandRecord) v1.CommandRecord {
+	return v1.CommandRecord{
+		Command:  record.Command,
+		Args:     slices.Clone(record.Args),
+		RanAt:    record.RanAt,
+		ExitCode: record.ExitCode,
+	}
+}
+
+// UndoV1 converts an undone wear into its version 1 output shape.
+func UndoV1(result usecases.UndoResult) v1.Undo {
+	return v1.Undo{Event: WearV1(result.Event), RestoredRotation: result.RestoredRotation}
+}
+
+// OutfitCountChangeV1 converts a change in a category's outfit count into
+// its version 1 output shape.
+func OutfitCountChangeV1(change usecases.OutfitCountChange) v1.OutfitCountChange {
+	return v1.OutfitCountChange{Category: change.Category, Before: change.Before, After: change.After}
+}
+
+// PickWindowV1 converts a pick window into its version 1 output shape.
+func PickWindowV1(window usecases.PickWindow) v1.PickWindow {
+	return v1.PickWindow{Due: window.Due, Missed: window.Missed}
+}
+
+// SyncEventV1 is the watch event for a category whose outfit count changed.
+func SyncEventV1(change usecases.OutfitCountChange) v1.WatchEvent {
+	converted := OutfitCountChangeV1(change)
+	return v1.WatchEvent{Event: "sync", Change: &converted}
+}
+
+// MissedPickEventV1 is the watch event for a scheduled pick that was missed
+// and skipped.
+func MissedPickEventV1(window usecases.PickWindow) v1.WatchEvent {
+	converted := PickWindowV1(window)
+	return v1.WatchEvent{Event: "missed", Window: &converted}
+}
+
+// PickEventV1 is the watch event named event for what happened to a pick of
+// outfit, with the window it was scheduled in when it was scheduled.
+func PickEventV1(event string, outfit entities.OutfitReference, window *usecases.PickWindow) v1.WatchEvent {
+	ref := OutfitRefV1(outfit)
+	converted := v1.WatchEvent{Event: event, Outfit: &ref}
+	if window != nil {
+		pickWindow := PickWindowV1(*window)
+		converted.Window = &pickWindow
+	}
+	return converted
+}
+
+// StateChangesV1 converts the state changes a dry run would make into their
+// version 1 output shape.
+func StateChangesV1(changes []usecases.StateChange) []v1.StateChange {
+	return ListV1(changes, func(change usecases.StateChange) v1.StateChange {
+		return v1.StateChange{Store: change.Store, Change: change.Change}
+	})
+}
+
+// ProgressEventV1 converts a progress event into its version 1 output
+// shape.
+func ProgressEventV1(event entities.ProgressEvent) v1.ProgressEvent {
+	return v1.ProgressEvent{
+		Operation: event.Operation,
+		Phase:     string(event.Phase),
+		Current:   event.Current,
+		Total:     event.Total,
+		Item:      event.Item,
+	}
+}
+
+// ScheduleV1 converts the scheduled daily pick, nil when none is installed,
+// into its version 1 output shape.
+func ScheduleV1(pick *entities.ScheduledPick) v1.Schedule {
+	if pick == nil {
+		return v1.Schedule{}
+	}
+	return v1.Schedule{
+		Scheduled: true,
+		Pick:      &v1.ScheduledPick{Hour: pick.Hour, Minute: pick.Minute, Command: slices.Clone(pick.Command)},
+	}
+}
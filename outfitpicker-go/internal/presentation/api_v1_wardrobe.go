@@ -0,0 +1,317 @@
+package presentation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// outfitLocationV1 converts an outfit location into its version 1 output
+// shape.
+func outfitLocationV1(location entities.OutfitLocation) v1.OutfitLocation {
+	return v1.OutfitLocation{Category: location.Category, FileName: location.FileName}
+}
+
+// skippedEntryV1 converts a skipped archive or directory entry into its
+// version 1 output shape.
+func skippedEntryV1(entry entities.SkippedEntry) v1.SkippedEntry {
+	return v1.SkippedEntry{Entry: entry.Entry, Reason: entry.Reason}
+}
+
+// CategoryV1 converts a category of the wardrobe into its version 1 output
+// shape.
+func CategoryV1(info entities.CategoryInfo) v1.Category {
+	category := v1.Category{
+		Name:        info.Category.Name,
+		Path:        info.Category.Path,
+		State:       string(info.State),
+		OutfitCount: info.OutfitCount,
+	}
+	if description := info.Description; description != nil {
+		category.Label = description.Label
+		category.Description = description.Description
+		category.Tags = slices.Clone(description.Tags)
+		category.Slot = description.Slot
+	}
+	return category
+}
+
+// CategoryCreatedV1 converts a created category into its version 1 output
+// shape.
+func CategoryCreatedV1(result usecases.CategoryCreateResult) v1.CategoryCreated {
+	return v1.CategoryCreated{
+		Category: result.Category,
+		Path:     result.Path,
+		Seeded:   slices.Clone(result.Seeded),
+		Skipped:  ListV1(result.Skipped, skippedEntryV1),
+	}
+}
+
+// CategoryRemovalProposalV1 converts a category removal worked out but not
+// yet made into its version 1 output shape.
+func CategoryRemovalProposalV1(proposal usecases.CategoryRemovalProposal) v1.CategoryRemovalProposal {
+	refs := proposal.References
+	return v1.CategoryRemovalProposal{
+		Category: categoryRefV1(proposal.Category),
+		Files:    string(proposal.Files),
+		Outfits:  proposal.Outfits,
+		Settings: slices.Clone(proposal.Settings),
+		References: v1.CategoryReferences{
+			Picks:      refs.Picks,
+			Wears:      refs.Wears,
+			Metadata:   refs.Metadata,
+			Weights:    refs.Weights,
+			Favorites:  refs.Favorites,
+			Skipped:    refs.Skipped,
+			Laundry:    refs.Laundry,
+			Arrivals:   refs.Arrivals,
+			Planned:    refs.Planned,
+			Capsule:    refs.Capsule,
+			LastPicked: refs.LastPicked,
+			LatestPick: refs.LatestPick,
+		},
+	}
+}
+
+// CategoryRemovalV1 converts a category removal into its version 1 output
+// shape.
+func CategoryRemovalV1(removal usecases.CategoryRemoval) v1.CategoryRemoval {
+	return v1.CategoryRemoval{
+		CategoryRemovalProposal: CategoryRemovalProposalV1(*removal.CategoryRemovalProposal),
+		FilesPath:               removal.FilesPath,
+		Previous:                BackupV1(removal.Previous),
+	}
+}
+
+// CategoryExclusionV1 converts a category exclusion into its version 1
+// output shape.
+func CategoryExclusionV1(exclusion entities.CategoryExclusion) v1.CategoryExclusion {
+	return v1.CategoryExclusion{Category: exclusion.Category, Until: exclusion.Until}
+}
+
+// CategoryOrderV1 converts the category order into its version 1 output
+// shape.
+func CategoryOrderV1(order entities.CategoryOrder) v1.CategoryOrder {
+	return v1.CategoryOrder{Sort: order.Sort, Manual: slices.Clone(order.Manual)}
+}
+
+// SeasonAssignmentsV1 converts season assignments into their version 1
+// output shape.
+func SeasonAssignmentsV1(seasons entities.SeasonAssignments) v1.SeasonAssignments {
+	return v1.SeasonAssignments{
+		Categories: cloneLists(seasons.Categories),
+		Tags:       cloneLists(seasons.Tags),
+		Southern:   seasons.Southern,
+	}
+}
+
+// OccasionsV1 converts occasions by name into their version 1 output
+// shape.
+func OccasionsV1(occasions map[string]entities.Occasion) map[string]v1.Occasion {
+	if occasions == nil {
+		return nil
+	}
+	converted := make(map[string]v1.Occasion, len(occasions))
+	for name, occasion := range occasions {
+		converted[name] = v1.Occasion{Categories: slices.Clone(occasion.Categories), Tags: slices.Clone(occasion.Tags)}
+	}
+	return converted
+}
+
+// EnsembleRulesV1 converts the ensemble settings into their version 1
+// output shape.
+func EnsembleRulesV1(settings entities.EnsembleSettings) v1.EnsembleRules {
+	return v1.EnsembleRules{
+		Slots:        maps.Clone(settings.Slots),
+		TagConflicts: slices.Clone(settings.TagConflicts),
+		ColorClashes: slices.Clone(settings.ColorClashes),
+		MaxColors:    settings.MaxColors,
+		ColorRules:   slices.Clone(settings.ColorRules),
+	}
+}
+
+// EnsembleV1 converts the categories of each ensemble slot and the
+// ensemble settings into their version 1 output shape.
+func EnsembleV1(slots map[string][]string, settings entities.EnsembleSettings) v1.Ensemble {
+	return v1.Ensemble{Slots: cloneLists(slots), Ensemble: EnsembleRulesV1(settings)}
+}
+
+// EnsemblePickV1 converts an ensemble pick into its version 1 output shape.
+func EnsemblePickV1(pick usecases.EnsemblePick) v1.EnsemblePick {
+	return v1.EnsemblePick{Pieces: ListV1(pick.Pieces, func(piece usecases.EnsemblePiece) v1.EnsemblePiece {
+		return v1.EnsemblePiece{Slot: piece.Slot, Outfit: OutfitRefV1(piece.Outfit)}
+	})}
+}
+
+// FavoritesV1 converts the favorite outfits of each category into their
+// version 1 output shape.
+func FavoritesV1(favorites entities.Favorites) v1.Favorites {
+	return cloneLists(favorites.Outfits)
+}
+
+// WeightsV1 converts outfit weights into their version 1 output shape.
+func WeightsV1(weights entities.OutfitWeights) v1.Weights {
+	if weights.Outfits == nil {
+		return nil
+	}
+	converted := make(v1.Weights, len(weights.Outfits))
+	for category, outfits := range weights.Outfits {
+		converted[category] = maps.Clone(outfits)
+	}
+	return converted
+}
+
+// OutfitTagsV1 converts an outfit's tags into their version 1 output shape.
+func OutfitTagsV1(tags entities.OutfitTags) v1.OutfitTags {
+	return v1.OutfitTags{Category: tags.Category, FileName: tags.FileName, Tags: slices.Clone(tags.Tags)}
+}
+
+// OutfitDetailsV1 converts an outfit's metadata, and its sidecar file when
+// it has one, into their version 1 output shape.
+func OutfitDetailsV1(metadata entities.OutfitMetadata, sidecar *entities.OutfitSidecar) v1.OutfitDetails {
+	details := v1.OutfitDetails{Metadata: MetadataV1(metadata)}
+	if sidecar != nil {
+		details.Sidecar = &v1.Sidecar{
+			Tags:    slices.Clone(sidecar.Tags),
+			Colors:  slices.Clone(sidecar.Colors),
+			Seasons: slices.Clone(sidecar.Seasons),
+			Weight:  sidecar.Weight,
+			Notes:   sidecar.Notes,
+		}
+	}
+	return details
+}
+
+// SkippedOutfitV1 converts a skipped outfit into its version 1 output
+// shape.
+func SkippedOutfitV1(skipped entities.SkippedOutfit) v1.SkippedOutfit {
+	return v1.SkippedOutfit{Category: skipped.Category, FileName: skipped.FileName, Until: skipped.Until}
+}
+
+// LaundryOutfitV1 converts an outfit in the laundry into its version 1
+// output shape.
+func LaundryOutfitV1(outfit entities.LaundryOutfit) v1.LaundryOutfit {
+	return v1.LaundryOutfit{Category: outfit.Category, FileName: outfit.FileName, State: string(outfit.State)}
+}
+
+// LaundryReportV1 converts a laundry report into its version 1 output
+// shape.
+func LaundryReportV1(report usecases.LaundryReport) v1.LaundryReport {
+	return v1.LaundryReport{
+		Loads: ListV1(report.Loads, func(load logic.WashLoad) v1.WashLoad {
+			return v1.WashLoad{
+				Program: v1.WashProgram{
+					Method:      string(load.Program.Method),
+					Temperature: load.Program.Temperature,
+					TumbleDry:   load.Program.TumbleDry,
+				},
+				Outfits: ListV1(load.Outfits, OutfitRefV1),
+			}
+		}),
+		Unsorted: ListV1(report.Unsorted, OutfitRefV1),
+	}
+}
+
+// OutfitSightingV1 converts when an outfit was seen into its version 1
+// output shape.
+func OutfitSightingV1(sighting entities.OutfitSighting) v1.OutfitSighting {
+	return v1.OutfitSighting{
+		Category:  sighting.Category,
+		FileName:  sighting.FileName,
+		FirstSeen: sighting.FirstSeen,
+		LastSeen:  sighting.LastSeen,
+		Present:   sighting.Present,
+	}
+}
+
+// NewOutfitV1 converts an outfit awaiting triage into its version 1 output
+// shape.
+func NewOutfitV1(outfit usecases.NewOutfit) v1.NewOutfit {
+	return v1.NewOutfit{Category: outfit.Category, FileName: outfit.FileName}
+}
+
+// OutfitWornV1 converts an outfit marked worn into its version 1 output
+// shape.
+func OutfitWornV1(result usecases.WearResult) v1.OutfitWorn {
+	return v1.OutfitWorn{Category: result.Category, FileName: result.FileName, RotationCompleted: result.RotationCompleted}
+}
+
+// OutfitFeedbackV1 converts an outfit's feedback into its version 1 output
+// shape.
+func OutfitFeedbackV1(feedback usecases.OutfitFeedback) v1.OutfitFeedback {
+	return v1.OutfitFeedback{
+		Outfit:         OutfitRefV1(feedback.Outfit),
+		Events:         ListV1(feedback.Events, WearV1),
+		Score:          feedback.Score,
+		LastCompliment: feedback.LastCompliment,
+	}
+}
+
+// OutfitIDResolutionV1 converts a resolved outfit ID into its version 1
+// output shape.
+func OutfitIDResolutionV1(resolution usecases.OutfitIDResolution) v1.OutfitIDResolution {
+	return v1.OutfitIDResolution{ID: resolution.ID, Location: outfitLocationV1(resolution.Location)}
+}
+
+// OutfitIDSyncV1 converts an outfit ID sync into its version 1 output
+// shape.
+func OutfitIDSyncV1(sync usecases.OutfitIDSync) v1.OutfitIDSync {
+	return v1.OutfitIDSync{
+		Scheme:   sync.Scheme,
+		Outfits:  sync.Outfits,
+		Assigned: sync.Assigned,
+		Moves: ListV1(sync.Moves, func(move entities.OutfitMove) v1.OutfitMove {
+			return v1.OutfitMove{ID: move.ID, From: outfitLocationV1(move.From), To: outfitLocationV1(move.To)}
+		}),
+		Linked: sync.Linked,
+	}
+}
+
+// WeatherV1 converts today's weather report, nil when there is none, and
+// the weather settings into their version 1 output shape.
+func WeatherV1(today *usecases.WeatherReport, settings entities.WeatherSettings) v1.Weather {
+	weather := v1.Weather{Weather: v1.WeatherSettings{
+		Location:   weatherLocationV1(settings.Location),
+		Categories: cloneLists(settings.Categories),
+		Tags:       cloneLists(settings.Tags),
+	}}
+	if today != nil {
+		forecast := today.Forecast
+		weather.Today = &v1.WeatherReport{
+			Forecast: v1.Forecast{
+				Location:            *weatherLocationV1(&forecast.Location),
+				Date:                forecast.Date,
+				MinTemperature:      forecast.MinTemperature,
+				MaxTemperature:      forecast.MaxTemperature,
+				PrecipitationChance: forecast.PrecipitationChance,
+				FetchedAt:           forecast.FetchedAt,
+			},
+			Conditions: slices.Clone(today.Conditions),
+		}
+	}
+	return weather
+}
+
+func weatherLocationV1(location *entities.WeatherLocation) *v1.WeatherLocation {
+	if location == nil {
+		return nil
+	}
+	return &v1.WeatherLocation{Latitude: location.Latitude, Longitude: location.Longitude}
+}
+
+// cloneLists copies a map of lists, such as the favorites of each
+// category, keeping nil as nil.
+func cloneLists(lists map[string][]string) map[string][]string {
+	if lists == nil {
+		return nil
+	}
+	cloned := make(map[string][]string, len(lists))
+	for key, list := range lists {
+		cloned[key] = slices.Clone(list)
+	}
+	return cloned
+}
@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderBackupCreated describes a new backup and any old backups rotated out.
+func RenderBackupCreated(w io.Writer, result *usecases.BackupResult) error {
+	if _, err := fmt.Fprintf(w, "Created backup %s (%s).\n", result.Backup.ID, strings.Join(result.Backup.Files, ", ")); err != nil {
+		return err
+	}
+	if len(result.Removed) > 0 {
+		_, err := fmt.Fprintf(w, "Removed %s to keep the newest %d.\n", pluralize(len(result.Removed), "old backup"), entities.MaxBackups)
+		return err
+	}
+	return nil
+}
+
+// RenderBackups lists backups, newest first. Backup IDs are the UTC time
+// each backup was made.
+func RenderBackups(w io.Writer, backups []entities.Backup) error {
+	if len(backups) == 0 {
+		_, err := fmt.Fprintln(w, "No backups yet.")
+		return err
+	}
+	for i := len(backups) - 1; i >= 0; i-- {
+		backup := backups[i]
+		if _, err := fmt.Fprintf(w, "%s  %s\n", backup.ID, strings.Join(backup.Files, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderBackupRestored describes a restore and where the replaced state was
+// saved.
+func RenderBackupRestored(w io.Writer, result *usecases.RestoreResult) error {
+	if _, err := fmt.Fprintf(w, "Restored %s from backup %s.\n", strings.Join(result.Restored.Files, ", "), result.Restored.ID); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "The previous state was saved as backup %s.\n", result.Previous.ID)
+	return err
+}
@@ -0,0 +1,79 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// categoryStateDescriptions describes why a category has nothing to pick.
+var categoryStateDescriptions = map[entities.CategoryState]string{
+	entities.CategoryStateEmpty:         "empty",
+	entities.CategoryStateNoAvatarFiles: "no avatar files",
+}
+
+// RenderSkippedCategories warns about each category a pick left out, with
+// the state that left it out.
+func RenderSkippedCategories(w io.Writer, skipped []entities.CategoryInfo) error {
+	for _, info := range skipped {
+		if _, err := fmt.Fprintf(w, "warning: skipped %s (%s)\n", info.Category.Name, categoryStateDescriptions[info.State]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderCategoryCreated reports a new category, with the outfits it was
+// seeded with and the pack entries skipped.
+func RenderCategoryCreated(w io.Writer, result *usecases.CategoryCreateResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Created %s at %s", result.Category, result.Path)
+	if len(result.Seeded) > 0 {
+		fmt.Fprintf(&b, " with %s", pluralize(len(result.Seeded), "outfit"))
+	}
+	b.WriteString(".\n")
+	for _, entry := range result.Skipped {
+		fmt.Fprintf(&b, "  skipped %s: %s\n", entry.Entry, entry.Reason)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// CategoryRemovalSummary is the one-line summary of a category removal.
+func CategoryRemovalSummary(proposal *usecases.CategoryRemovalProposal) string {
+	outfits := pluralize(proposal.Outfits, "outfit")
+	switch proposal.Files {
+	case entities.FilesArchive:
+		return fmt.Sprintf("Would remove %s and archive its %s", proposal.Category.Name, outfits)
+	case entities.FilesTrash:
+		return fmt.Sprintf("Would remove %s and move its %s to the trash", proposal.Category.Name, outfits)
+	default:
+		return fmt.Sprintf("Would remove %s and leave its %s in %s", proposal.Category.Name, outfits, proposal.Category.Path)
+	}
+}
+
+// RenderCategoryRemoved reports a removed category: where its files went,
+// the state files cleaned up and the backup taken first.
+func RenderCategoryRemoved(w io.Writer, result *usecases.CategoryRemoval) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Removed %s.\n", result.Category.Name)
+	outfits := pluralize(result.Outfits, "outfit")
+	switch result.Files {
+	case entities.FilesArchive:
+		fmt.Fprintf(&b, "Archived its %s to %s.\n", outfits, result.FilesPath)
+	case entities.FilesTrash:
+		fmt.Fprintf(&b, "Moved its %s to the trash at %s.\n", outfits, result.FilesPath)
+	default:
+		fmt.Fprintf(&b, "Left its %s in %s, excluded from picks.\n", outfits, result.FilesPath)
+	}
+	b.WriteString("Cleaned up:\n")
+	for _, change := range result.Changes() {
+		fmt.Fprintf(&b, "  %s: %s\n", change.Store, change.Change)
+	}
+	fmt.Fprintf(&b, "The previous config and cache were saved as backup %s.\n", result.Previous.ID)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,57 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+const challengeDateFormat = "2006-01-02"
+
+// RenderChallengeReport writes where a capsule challenge stands, or how it
+// went once it is over, and the wears of each capsule outfit, most worn
+// first.
+func RenderChallengeReport(w io.Writer, report logic.ChallengeReport) error {
+	var status string
+	switch {
+	case report.EndedEarly:
+		status = fmt.Sprintf("ended early on day %d of %d", report.Day, report.Days)
+	case report.Finished:
+		status = fmt.Sprintf("completed all %s", pluralize(report.Days, "day"))
+	default:
+		status = fmt.Sprintf("day %d of %d, ends %s", report.Day, report.Days, report.EndsAt.Format(challengeDateFormat))
+	}
+	if _, err := fmt.Fprintf(w, "Capsule challenge: %s\n\n", status); err != nil {
+		return err
+	}
+
+	width := 0
+	for _, outfit := range report.Capsule {
+		width = max(width, validation.DisplayWidth(outfit.Category+"/"+outfit.FileName))
+	}
+	for _, outfit := range report.Capsule {
+		name := outfit.Category + "/" + outfit.FileName
+		padding := strings.Repeat(" ", width-validation.DisplayWidth(name))
+		if _, err := fmt.Fprintf(w, "  %s%s  %d\n", name, padding, outfit.Wears); err != nil {
+			return err
+		}
+	}
+
+	lines := [][2]string{
+		{"Wears", fmt.Sprint(report.Wears)},
+		{"Outfits worn", fmt.Sprintf("%d of %d", report.WornOutfits, len(report.Capsule))},
+		{"Days with a wear", fmt.Sprintf("%d of %d", report.DaysWorn, report.Day)},
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(w, "%-18s%s\n", line[0]+":", line[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
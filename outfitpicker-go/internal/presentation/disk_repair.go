@@ -0,0 +1,50 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderDiskRepair shows what repairing the state from the wardrobe on disk
+// changes, as a diff of each category: - for what is dropped, + for what is
+// added.
+func RenderDiskRepair(w io.Writer, repairs []entities.CategoryRepair) error {
+	if len(repairs) == 0 {
+		_, err := fmt.Fprintln(w, "The rotation state matches the wardrobe on disk; nothing to repair.")
+		return err
+	}
+	for _, repair := range repairs {
+		if repair.Removed {
+			if _, err := fmt.Fprintf(w, "%s: no longer on disk\n  - rotation and known outfits\n", repair.Category); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s:\n", repair.Category); err != nil {
+			return err
+		}
+		if repair.TotalBefore != repair.TotalAfter {
+			if _, err := fmt.Fprintf(w, "  outfits in rotation: %d -> %d\n", repair.TotalBefore, repair.TotalAfter); err != nil {
+				return err
+			}
+		}
+		lines := []struct {
+			prefix string
+			files  []string
+		}{
+			{"- worn ", repair.DroppedWorn},
+			{"- known ", repair.RemovedKnown},
+			{"+ known ", repair.AddedKnown},
+		}
+		for _, line := range lines {
+			for _, file := range line.files {
+				if _, err := fmt.Fprintf(w, "  %s%s\n", line.prefix, file); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
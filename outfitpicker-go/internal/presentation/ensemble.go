@@ -0,0 +1,61 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderEnsemble shows the outfit picked for each slot of an ensemble, one
+// slot a line.
+func RenderEnsemble(w io.Writer, pieces []usecases.EnsemblePiece) error {
+	width := 0
+	for _, piece := range pieces {
+		width = max(width, len(piece.Slot)+1)
+	}
+	var b strings.Builder
+	for _, piece := range pieces {
+		fmt.Fprintf(&b, "%-*s %s/%s\n", width, piece.Slot+":", piece.Outfit.Category.Name, piece.Outfit.FileName)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderEnsembleSettings shows the categories filling each slot and the
+// compatibility rules of ensembles.
+func RenderEnsembleSettings(w io.Writer, slots map[string][]string, settings entities.EnsembleSettings) error {
+	var b strings.Builder
+	if len(slots) == 0 {
+		b.WriteString("No category fills an ensemble slot; assign one with: ensemble slot <category> <slot>\n")
+	} else {
+		b.WriteString("Slots:\n")
+	}
+	for _, slot := range slices.Sorted(maps.Keys(slots)) {
+		fmt.Fprintf(&b, "  %s: %s\n", slot, strings.Join(slots[slot], ", "))
+	}
+	if len(settings.TagConflicts) == 0 && len(settings.ColorClashes) == 0 && len(settings.ColorRules) == 0 && settings.MaxColors == 0 {
+		b.WriteString("No compatibility rules; any outfits go together.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("Rules:\n")
+	for _, pair := range settings.TagConflicts {
+		fmt.Fprintf(&b, "  tags %s and %s conflict\n", pair[0], pair[1])
+	}
+	for _, pair := range settings.ColorClashes {
+		fmt.Fprintf(&b, "  colors %s and %s clash\n", pair[0], pair[1])
+	}
+	for _, rule := range settings.ColorRules {
+		fmt.Fprintf(&b, "  color rule: %s\n", rule)
+	}
+	if settings.MaxColors > 0 {
+		fmt.Fprintf(&b, "  at most %d colors\n", settings.MaxColors)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
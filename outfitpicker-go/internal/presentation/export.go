@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// CacheExport is the export shape of an OutfitCache, version 1 of its
+// public shape. Maps are flattened into sorted slices so the document diffs
+// cleanly between runs.
+type CacheExport = v1.CacheExport
+
+// CategoryCacheExport is the export shape of a single CategoryCache.
+type CategoryCacheExport = v1.CategoryCache
+
+// NewCacheExport converts a cache into its canonical export shape.
+func NewCacheExport(cache entities.OutfitCache) CacheExport {
+	categories := make([]CategoryCacheExport, 0, len(cache.Categories))
+	for _, path := range SortedKeys(cache.Categories) {
+		category := cache.Categories[path]
+		categories = append(categories, CategoryCacheExport{
+			Path:         path,
+			TotalOutfits: category.TotalOutfits,
+			WornOutfits:  SortedWornOutfits(category),
+			LastUpdated:  category.LastUpdated,
+		})
+	}
+	return CacheExport{
+		Version:    cache.Version,
+		CreatedAt:  cache.CreatedAt,
+		Categories: categories,
+	}
+}
+
+// WriteJSON writes v as indented JSON followed by a newline.
+func WriteJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// ExportCache writes the canonical export of a cache as JSON.
+func ExportCache(w io.Writer, cache entities.OutfitCache) error {
+	return WriteJSON(w, NewCacheExport(cache))
+}
@@ -0,0 +1,25 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderFavorites lists favorites by category and file name.
+func RenderFavorites(w io.Writer, favorites entities.Favorites) error {
+	categories := favorites.Categories()
+	if len(categories) == 0 {
+		_, err := fmt.Fprintln(w, "No favorites yet.")
+		return err
+	}
+	for _, category := range categories {
+		for _, file := range favorites.Category(category) {
+			if _, err := fmt.Fprintf(w, "%s/%s\n", category, file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+const feedbackDateFormat = "2006-01-02 15:04"
+
+// RenderOutfitFeedback writes an outfit's net feedback score, when it was last
+// complimented and one line per wear event.
+func RenderOutfitFeedback(w io.Writer, feedback *usecases.OutfitFeedback) error {
+	lastCompliment := "never"
+	if feedback.LastCompliment != nil {
+		lastCompliment = feedback.LastCompliment.Format(feedbackDateFormat)
+	}
+	if _, err := fmt.Fprintf(w, "Score:           %+d\nLast compliment: %s\n", feedback.Score, lastCompliment); err != nil {
+		return err
+	}
+	if len(feedback.Events) == 0 {
+		_, err := fmt.Fprintln(w, "Not worn yet.")
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "Worn (%s):\n", pluralize(len(feedback.Events), "time")); err != nil {
+		return err
+	}
+	for _, event := range feedback.Events {
+		kinds := make([]string, 0, len(event.Feedback))
+		for _, kind := range event.Feedback {
+			kinds = append(kinds, string(kind))
+		}
+		line := "  " + event.WornAt.Format(feedbackDateFormat)
+		if len(kinds) > 0 {
+			line += "  " + strings.Join(kinds, ", ")
+		}
+		if event.Note != "" {
+			line += fmt.Sprintf("  %q", event.Note)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,727 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output mismatch for %s\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+var fixedTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func fixtureCategories() []entities.CategoryInfo {
+	return []entities.CategoryInfo{
+		entities.NewCategoryInfo(entities.NewCategoryReference("beach", "/outfits/beach"), entities.CategoryStateEmpty, 0),
+		entities.NewCategoryInfo(entities.NewCategoryReference("casual", "/outfits/casual"), entities.CategoryStateHasOutfits, 5),
+		entities.NewCategoryInfo(entities.NewCategoryReference("formal", "/outfits/formal"), entities.CategoryStateUserExcluded, 0),
+		entities.NewCategoryInfo(entities.NewCategoryReference("work", "/outfits/work"), entities.CategoryStateHasOutfits, 12),
+	}
+}
+
+func fixtureCache() entities.OutfitCache {
+	cache := entities.OutfitCache{
+		Categories: map[string]entities.CategoryCache{},
+		Version:    1,
+		CreatedAt:  fixedTime,
+	}
+	for _, c := range []struct {
+		path  string
+		total int
+		worn  []string
+	}{
+		{"work", 3, []string{"suit.avatar", "blazer.avatar"}},
+		{"casual", 4, []string{"tee.avatar", "hoodie.avatar", "denim.avatar"}},
+		{"beach", 2, nil},
+	} {
+		worn := make(map[string]bool)
+		for _, fileName := range c.worn {
+			worn[fileName] = true
+		}
+		cache.Categories[c.path] = entities.CategoryCache{
+			WornOutfits:  worn,
+			TotalOutfits: c.total,
+			LastUpdated:  fixedTime,
+		}
+	}
+	return cache
+}
+
+func TestRenderCategoryList_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), nil, nil, entities.CategoryPermissions{}, nil, PlainStyle); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list", buf.Bytes())
+}
+
+func TestRenderCategoryList_WithDescriptions(t *testing.T) {
+	infos := fixtureCategories()
+	infos[1].Description = &entities.CategoryDescription{Label: "Weekend", Description: "Off-duty clothes"}
+	infos[3].Description = &entities.CategoryDescription{Description: "Office wear", Tags: []string{"smart"}}
+	health := map[string]logic.CategoryHealth{"work": {Score: 82, Status: logic.HealthHealthy}}
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, infos, nil, health, entities.CategoryPermissions{}, nil, PlainStyle); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_descriptions", buf.Bytes())
+}
+
+func TestRenderCategoryList_WithHealth(t *testing.T) {
+	health := map[string]logic.CategoryHealth{
+		"work":   {Score: 82, Status: logic.HealthHealthy},
+		"casual": {Score: 35, Status: logic.HealthCritical},
+	}
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), nil, health, entities.CategoryPermissions{}, nil, PlainStyle); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_health", buf.Bytes())
+}
+
+func TestRenderCategoryList_WithProgress(t *testing.T) {
+	progress := map[string]entities.RotationProgress{
+		"casual": entities.NewRotationProgress(entities.NewCategoryReference("casual", "/outfits/casual"), 4, 5),
+		"work":   entities.NewRotationProgress(entities.NewCategoryReference("work", "/outfits/work"), 3, 12),
+	}
+	health := map[string]logic.CategoryHealth{"work": {Score: 82, Status: logic.HealthHealthy}}
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), progress, health, entities.CategoryPermissions{}, nil, Style{ProgressStyle: entities.ProgressStylePattern}); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_progress", buf.Bytes())
+}
+
+func TestRenderCategoryList_WithPermissions(t *testing.T) {
+	permissions := entities.CategoryPermissions{Pick: []string{"casual", "work"}, Reset: []string{"casual"}}
+	health := map[string]logic.CategoryHealth{"work": {Score: 82, Status: logic.HealthHealthy}}
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), nil, health, permissions, nil, PlainStyle); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_permissions", buf.Bytes())
+}
+
+func TestRenderCategoryList_WithLockedRotations(t *testing.T) {
+	locked := map[string]logic.LockedRotation{
+		"casual": {Category: "casual", Worn: 5, Total: 5, AwaitingReset: true},
+		"work":   {Category: "work", Worn: 3, Total: 12},
+	}
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), nil, nil, entities.CategoryPermissions{}, locked, PlainStyle); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_locked", buf.Bytes())
+}
+
+func TestRenderOutfitList_Golden(t *testing.T) {
+	casual := entities.NewCategoryReference("casual", "/outfits/casual")
+	work := entities.NewCategoryReference("work", "/outfits/work")
+	outfits := []entities.OutfitReference{
+		entities.NewOutfitReference("suit.avatar", work),
+		entities.NewOutfitReference("tee.avatar", casual),
+		entities.NewOutfitReference("blazer.avatar", work),
+		entities.NewOutfitReference("denim.avatar", casual),
+	}
+
+	var buf bytes.Buffer
+	if err := RenderOutfitList(&buf, outfits); err != nil {
+		t.Fatalf("RenderOutfitList() error = %v", err)
+	}
+	assertGolden(t, "outfit_list", buf.Bytes())
+}
+
+func TestRenderWornOutfits_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderWornOutfits(&buf, fixtureCache()); err != nil {
+		t.Fatalf("RenderWornOutfits() error = %v", err)
+	}
+	assertGolden(t, "worn_outfits", buf.Bytes())
+}
+
+func TestExportCache_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportCache(&buf, fixtureCache()); err != nil {
+		t.Fatalf("ExportCache() error = %v", err)
+	}
+	assertGolden(t, "cache_export", buf.Bytes())
+}
+
+func TestExportCache_StableAcrossRuns(t *testing.T) {
+	var first bytes.Buffer
+	if err := ExportCache(&first, fixtureCache()); err != nil {
+		t.Fatalf("ExportCache() error = %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		var buf bytes.Buffer
+		if err := ExportCache(&buf, fixtureCache()); err != nil {
+			t.Fatalf("ExportCache() error = %v", err)
+		}
+		if !bytes.Equal(buf.Bytes(), first.Bytes()) {
+			t.Fatalf("run %d produced different output", i)
+		}
+	}
+}
+
+func TestRenderWardrobeChanges_Golden(t *testing.T) {
+	changes := logic.DiffSnapshots(
+		entities.WardrobeSnapshot{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {"suit.avatar"}},
+		entities.WardrobeSnapshot{"casual": {"tee.avatar", "hoodie.avatar"}, "gym": {"shorts.avatar"}},
+	)
+
+	var buf bytes.Buffer
+	if err := RenderWardrobeChanges(&buf, changes); err != nil {
+		t.Fatalf("RenderWardrobeChanges() error = %v", err)
+	}
+	assertGolden(t, "wardrobe_changes", buf.Bytes())
+}
+
+func TestRenderWardrobeChanges_NoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderWardrobeChanges(&buf, logic.WardrobeChanges{}); err != nil {
+		t.Fatalf("RenderWardrobeChanges() error = %v", err)
+	}
+	if buf.String() != "No changes detected.\n" {
+		t.Errorf("RenderWardrobeChanges() = %q", buf.String())
+	}
+}
+
+func TestRenderDiskRepair_Golden(t *testing.T) {
+	repairs := logic.PlanDiskRepair(
+		entities.OutfitCache{Categories: map[string]entities.CategoryCache{
+			"casual": entities.NewCategoryCache(3).Adding("jeans.avatar").Adding("tee.avatar"),
+			"formal": entities.NewCategoryCache(1),
+		}},
+		map[string]map[string]bool{"casual": {"tee.avatar": true, "jeans.avatar": true}, "formal": {"suit.avatar": true}},
+		entities.WardrobeSnapshot{"casual": {"tee.avatar", "hoodie.avatar"}, "gym": {"shorts.avatar"}},
+	)
+
+	var buf bytes.Buffer
+	if err := RenderDiskRepair(&buf, repairs); err != nil {
+		t.Fatalf("RenderDiskRepair() error = %v", err)
+	}
+	assertGolden(t, "disk_repair", buf.Bytes())
+}
+
+func fixtureWearAnalytics() logic.WearAnalytics {
+	return logic.AnalyzeWear(
+		entities.WearLog{Events: []entities.WearEvent{
+			{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime.AddDate(0, 0, -9)},
+			{Category: "casual", FileName: "jeans.avatar", WornAt: fixedTime.AddDate(0, 0, -6), CompletedRotation: true},
+			{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime.AddDate(0, 0, -1)},
+		}},
+		entities.SelectionHistory{Records: []entities.SelectionRecord{{SelectedAt: fixedTime.AddDate(0, 0, -1)}, {SelectedAt: fixedTime}}},
+		entities.WardrobeSnapshot{"casual": {"tee.avatar", "jeans.avatar"}, "formal": {"suit.avatar"}},
+		fixedTime,
+	)
+}
+
+func TestRenderWearAnalytics_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderWearAnalytics(&buf, fixtureWearAnalytics()); err != nil {
+		t.Fatalf("RenderWearAnalytics() error = %v", err)
+	}
+	assertGolden(t, "wear_analytics", buf.Bytes())
+}
+
+func TestRenderWearAnalytics_WithLockedRotations(t *testing.T) {
+	analytics := fixtureWearAnalytics()
+	analytics.LockedRotations = []logic.LockedRotation{
+		{Category: "casual", Worn: 2, Total: 2, AwaitingReset: true},
+		{Category: "formal", Worn: 0, Total: 1},
+	}
+	var buf bytes.Buffer
+	if err := RenderWearAnalytics(&buf, analytics); err != nil {
+		t.Fatalf("RenderWearAnalytics() error = %v", err)
+	}
+	assertGolden(t, "wear_analytics_locked", buf.Bytes())
+}
+
+func TestWriteLineProtocol_Golden(t *testing.T) {
+	metrics := usecases.WardrobeMetrics{
+		At:        fixedTime,
+		Analytics: fixtureWearAnalytics(),
+		Rotations: []entities.RotationProgress{
+			entities.NewRotationProgress(entities.NewCategoryReference("casual", "/outfits/casual"), 1, 2),
+			entities.NewRotationProgress(entities.NewCategoryReference("black tie", "/outfits/black tie"), 0, 1),
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteLineProtocol(&buf, metrics); err != nil {
+		t.Fatalf("WriteLineProtocol() error = %v", err)
+	}
+	assertGolden(t, "line_protocol", buf.Bytes())
+}
+
+func TestWriteWearAnalyticsCSV_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteWearAnalyticsCSV(&buf, fixtureWearAnalytics()); err != nil {
+		t.Fatalf("WriteWearAnalyticsCSV() error = %v", err)
+	}
+	assertGolden(t, "wear_analytics_csv", buf.Bytes())
+}
+
+func fixtureChallenge() entities.Challenge {
+	return entities.Challenge{
+		Capsule: []entities.OutfitLocation{
+			{Category: "casual", FileName: "tee.avatar"},
+			{Category: "casual", FileName: "jeans.avatar"},
+			{Category: "formal", FileName: "suit.avatar"},
+		},
+		StartedAt: fixedTime.AddDate(0, 0, -4),
+		EndsAt:    fixedTime.AddDate(0, 0, 10),
+		Wears: []entities.WearEvent{
+			{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime.AddDate(0, 0, -4)},
+			{Category: "formal", FileName: "suit.avatar", WornAt: fixedTime.AddDate(0, 0, -2)},
+			{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime.AddDate(0, 0, -1)},
+		},
+	}
+}
+
+func TestRenderChallengeReport_Golden(t *testing.T) {
+	tests := []struct {
+		name   string
+		report logic.ChallengeReport
+	}{
+		{"challenge_running", logic.SummarizeChallenge(fixtureChallenge(), fixedTime)},
+		{"challenge_completed", logic.SummarizeChallenge(fixtureChallenge(), fixedTime.AddDate(0, 1, 0))},
+		{"challenge_ended_early", logic.SummarizeChallenge(fixtureChallenge().Ending(fixedTime), fixedTime)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderChallengeReport(&buf, tt.report); err != nil {
+				t.Fatalf("RenderChallengeReport() error = %v", err)
+			}
+			assertGolden(t, tt.name, buf.Bytes())
+		})
+	}
+}
+
+func fixturePickHeatmap() logic.PickHeatmap {
+	var records []entities.SelectionRecord
+	for i, day := range []int{0, 0, 3, 10, 40, 41, 41, 41, 100, 200, 365} {
+		records = append(records, entities.SelectionRecord{
+			Category:   "casual",
+			FileName:   fmt.Sprintf("outfit-%d.avatar", i),
+			SelectedAt: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, day),
+		})
+	}
+	return logic.BuildPickHeatmap(entities.SelectionHistory{Records: records}, "casual", 2024, time.UTC)
+}
+
+func TestRenderPickHeatmap_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderPickHeatmap(&buf, fixturePickHeatmap()); err != nil {
+		t.Fatalf("RenderPickHeatmap() error = %v", err)
+	}
+	assertGolden(t, "pick_heatmap", buf.Bytes())
+}
+
+func TestRenderPickHeatmapSVG_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderPickHeatmapSVG(&buf, fixturePickHeatmap()); err != nil {
+		t.Fatalf("RenderPickHeatmapSVG() error = %v", err)
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("SVG is not well-formed XML: %v", err)
+	}
+	assertGolden(t, "pick_heatmap_svg", buf.Bytes())
+}
+
+func fixtureOutfitPlan() logic.PlanReport {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	plan := entities.OutfitPlan{CreatedAt: fixedTime, Days: []entities.PlanDay{
+		{Date: day, Worn: true, Outfits: []entities.PlannedOutfit{
+			{Category: "casual", FileName: "tee.avatar"},
+			{Category: "work", FileName: "suit.avatar"},
+		}},
+		{Date: day.AddDate(0, 0, 1), Outfits: []entities.PlannedOutfit{
+			{Category: "casual", FileName: "hoodie.avatar"},
+			{Category: "work", FileName: "blazer.avatar"},
+		}},
+		{Date: day.AddDate(0, 0, 2), Outfits: []entities.PlannedOutfit{
+			{Category: "casual", FileName: "gone.avatar"},
+		}},
+		{Date: day.AddDate(0, 0, 3), Outfits: []entities.PlannedOutfit{}},
+	}}
+	snapshot := entities.WardrobeSnapshot{
+		"casual": {"tee.avatar", "hoodie.avatar", "denim.avatar"},
+		"work":   {"suit.avatar", "blazer.avatar"},
+	}
+	return logic.CheckPlan(plan, snapshot, fixtureCache())
+}
+
+func TestRenderOutfitPlan_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderOutfitPlan(&buf, fixtureOutfitPlan()); err != nil {
+		t.Fatalf("RenderOutfitPlan() error = %v", err)
+	}
+	assertGolden(t, "outfit_plan", buf.Bytes())
+}
+
+func TestRenderOutfitPlanICS_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderOutfitPlanICS(&buf, fixtureOutfitPlan()); err != nil {
+		t.Fatalf("RenderOutfitPlanICS() error = %v", err)
+	}
+	for line := range strings.SplitSeq(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 || strings.Contains(line, "\n") {
+			t.Errorf("content line %q is longer than 75 octets or not ended by CRLF", line)
+		}
+	}
+	assertGolden(t, "outfit_plan_ics", buf.Bytes())
+}
+
+func TestWriteICSLine_FoldsLongLines(t *testing.T) {
+	var b strings.Builder
+	long := "SUMMARY:" + strings.Repeat("é", 80)
+	writeICSLine(&b, long)
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n ")
+	if len(lines) < 3 || strings.Join(lines, "") != long {
+		t.Fatalf("folded %q into %q", long, lines)
+	}
+	for _, line := range lines {
+		if len(line) > 75 || !utf8.ValidString(line) {
+			t.Errorf("folded line %q is too long or splits a character", line)
+		}
+	}
+}
+
+func TestRenderCategoryList_Decorated_Golden(t *testing.T) {
+	style := Style{Decorations: map[string]entities.CategoryDecoration{
+		"casual": {Emoji: "👕", Color: "blue"},
+		"work":   {Emoji: "☀️"},
+		"beach":  {Color: "#ffcc00"},
+	}}
+
+	var buf bytes.Buffer
+	if err := RenderCategoryList(&buf, fixtureCategories(), nil, nil, entities.CategoryPermissions{}, nil, style); err != nil {
+		t.Fatalf("RenderCategoryList() error = %v", err)
+	}
+	assertGolden(t, "category_list_decorated", buf.Bytes())
+}
+
+func TestRenderOnboardingGuidance_Golden(t *testing.T) {
+	guidance := usecases.OnboardingGuidance{
+		Status:     usecases.OnboardingStatusRequired,
+		ConfigPath: "/home/user/.config/outfitpicker/config.json",
+		Steps: []usecases.OnboardingStep{
+			{ID: "create-wardrobe", Description: "Create a wardrobe directory"},
+			{ID: "init", Description: "Save the configuration", Command: "outfitpicker init --root <wardrobe-directory>"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderOnboardingGuidance(&buf, guidance); err != nil {
+		t.Fatalf("RenderOnboardingGuidance() error = %v", err)
+	}
+	assertGolden(t, "onboarding_guidance", buf.Bytes())
+}
+
+func TestRenderLaundryReport_Golden(t *testing.T) {
+	outfit := func(category, file string) entities.OutfitReference {
+		return entities.NewOutfitReference(file, entities.NewCategoryReference(category, "/outfits/"+category))
+	}
+	report := &usecases.LaundryReport{
+		Loads: []logic.WashLoad{
+			{Program: logic.WashProgram{Method: logic.WashMachine, Temperature: 30}, Outfits: []entities.OutfitReference{outfit("gym", "shorts.avatar")}},
+			{Program: logic.WashProgram{Method: logic.WashMachine, Temperature: 40, TumbleDry: true}, Outfits: []entities.OutfitReference{outfit("casual", "tee.avatar"), outfit("work", "shirt.avatar")}},
+			{Program: logic.WashProgram{Method: logic.WashHand}, Outfits: []entities.OutfitReference{outfit("casual", "sweater.avatar")}},
+		},
+		Unsorted: []entities.OutfitReference{outfit("casual", "jeans.avatar")},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderLaundryReport(&buf, report); err != nil {
+		t.Fatalf("RenderLaundryReport() error = %v", err)
+	}
+	assertGolden(t, "laundry_report", buf.Bytes())
+}
+
+func TestRenderLaundryReport_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderLaundryReport(&buf, &usecases.LaundryReport{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "Nothing to wash.\n" {
+		t.Errorf("RenderLaundryReport() = %q", got)
+	}
+}
+
+func TestRenderSelectionHistory_Golden(t *testing.T) {
+	page := entities.Page[entities.SelectionRecord]{
+		Items: []entities.SelectionRecord{
+			{Category: "work", FileName: "blazer.avatar", SelectedAt: fixedTime},
+			{Category: "casual", FileName: "tee.avatar", SelectedAt: fixedTime.Add(-26 * time.Hour)},
+		},
+		Page:  1,
+		Limit: 2,
+		Total: 2,
+	}
+
+	var buf bytes.Buffer
+	if err := RenderSelectionHistory(&buf, page); err != nil {
+		t.Fatalf("RenderSelectionHistory() error = %v", err)
+	}
+	assertGolden(t, "selection_history", buf.Bytes())
+}
+
+func TestRenderWearHistory_Golden(t *testing.T) {
+	page := entities.Page[entities.WearEvent]{
+		Items: []entities.WearEvent{
+			{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime, Feedback: []entities.FeedbackKind{entities.FeedbackCompliment, entities.FeedbackComfortable}},
+			{Category: "work", FileName: "shirt.avatar", WornAt: fixedTime.AddDate(0, 0, -1)},
+			{Category: "casual", FileName: "hoodie.avatar", WornAt: fixedTime.AddDate(0, 0, -2), Feedback: []entities.FeedbackKind{entities.FeedbackPoorFit}},
+		},
+		Page:  2,
+		Limit: 3,
+		Total: 7,
+	}
+
+	var buf bytes.Buffer
+	if err := RenderWearHistory(&buf, page); err != nil {
+		t.Fatalf("RenderWearHistory() error = %v", err)
+	}
+	assertGolden(t, "wear_history", buf.Bytes())
+}
+
+func TestRenderCommandHistory_Golden(t *testing.T) {
+	records := []entities.CommandRecord{
+		{Command: "pick", Args: []string{"--profile", "work", "pick", "office wear", "--weather"}, RanAt: fixedTime},
+		{Command: "tag", Args: []string{"tag", "add", "casual/tee.avatar", "it's comfy"}, RanAt: fixedTime.Add(-time.Hour), ExitCode: 30},
+		{Command: "list", Args: []string{"list"}, RanAt: fixedTime.AddDate(0, 0, -1)},
+	}
+
+	var buf bytes.Buffer
+	if err := RenderCommandHistory(&buf, records); err != nil {
+		t.Fatalf("RenderCommandHistory() error = %v", err)
+	}
+	assertGolden(t, "command_history", buf.Bytes())
+}
+
+func fixtureMonthlyReport() *usecases.MonthlyReport {
+	lastWorn := time.Date(2024, 3, 14, 8, 0, 0, 0, time.UTC)
+	return &usecases.MonthlyReport{
+		Month:           time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Picks:           9,
+		PicksByCategory: []usecases.CategoryCount{{Category: "work", Count: 6}, {Category: "casual", Count: 3}},
+		Wears:           7,
+		CompletedRotations: []usecases.CompletedRotation{
+			{Category: "work", CompletedAt: time.Date(2024, 5, 17, 8, 0, 0, 0, time.UTC)},
+		},
+		Neglected: []usecases.NeglectedOutfit{
+			{Category: "formal", FileName: "tux.avatar"},
+			{Category: "casual", FileName: "hoodie & cap.avatar", LastWorn: &lastWorn},
+		},
+		NeglectedTotal: 5,
+		SpendPerWear: []usecases.OutfitSpendPerWear{
+			{Category: "work", FileName: "suit.avatar", Price: 300, Wears: 4, PerWear: 75, WornThisMonth: 2},
+		},
+	}
+}
+
+func TestRenderMonthlyReport_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderMonthlyReport(&buf, fixtureMonthlyReport()); err != nil {
+		t.Fatalf("RenderMonthlyReport() error = %v", err)
+	}
+	assertGolden(t, "monthly_report", buf.Bytes())
+}
+
+func TestRenderMonthlyReportHTML_Golden(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderMonthlyReportHTML(&buf, fixtureMonthlyReport()); err != nil {
+		t.Fatalf("RenderMonthlyReportHTML() error = %v", err)
+	}
+	assertGolden(t, "monthly_report_html", buf.Bytes())
+}
+
+func TestRenderCategoryHealth_Golden(t *testing.T) {
+	health := map[string]logic.CategoryHealth{
+		"work":   {Score: 82, Status: logic.HealthHealthy},
+		"casual": {Score: 35, Status: logic.HealthCritical, Reasons: []string{"not worn for 45 days", "rotation is behind schedule"}},
+		"gym":    {Score: 45, Status: logic.HealthWarning, Reasons: []string{"never worn"}},
+	}
+	var buf bytes.Buffer
+	if err := RenderCategoryHealth(&buf, health); err != nil {
+		t.Fatalf("RenderCategoryHealth() error = %v", err)
+	}
+	assertGolden(t, "category_health", buf.Bytes())
+}
+
+func TestRenderUndoResult(t *testing.T) {
+	var buf bytes.Buffer
+	result := &usecases.UndoResult{
+		Event:            entities.WearEvent{Category: "casual", FileName: "tee.avatar", WornAt: fixedTime},
+		RestoredRotation: true,
+	}
+	if err := RenderUndoResult(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	want := "Undid wearing casual/tee.avatar on 2024-06-01 12:00.\nRestored the casual rotation it had completed.\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderUndoResult() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderTriageResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		result usecases.TriageResult
+		want   string
+	}{
+		{"mixed", usecases.TriageResult{Accepted: 3, Excluded: 1, Archived: 2}, "Triage saved: 3 accepted, 1 excluded, 2 archived.\n"},
+		{"nothing", usecases.TriageResult{}, "No triage decisions saved.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderTriageResult(&buf, &tt.result); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("RenderTriageResult() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderOutfitWeights(t *testing.T) {
+	var buf bytes.Buffer
+	weights := entities.NewOutfitWeights().
+		Setting("work", "suit.avatar", 0.5).
+		Setting("casual", "tee.avatar", 3)
+	if err := RenderOutfitWeights(&buf, weights); err != nil {
+		t.Fatal(err)
+	}
+	want := "casual/tee.avatar  3\nwork/suit.avatar  0.5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderOutfitWeights() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderArchiveImport(t *testing.T) {
+	var buf bytes.Buffer
+	result := &usecases.ArchiveImportResult{
+		Category: "casual",
+		ArchiveImport: entities.ArchiveImport{
+			Imported: []entities.ImportedOutfit{
+				{Entry: "pack/jeans.avatar", FileName: "jeans.avatar"},
+				{Entry: "pack/tee.avatar", FileName: "tee-2.avatar", Renamed: true},
+			},
+			Skipped: []entities.SkippedEntry{{Entry: "../evil.avatar", Reason: "unsafe path"}},
+		},
+		Outfits: 5,
+	}
+	if err := RenderArchiveImport(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	want := "Imported 2 outfits into casual (5 outfits now).\n" +
+		"  pack/tee.avatar saved as tee-2.avatar: name already taken\n" +
+		"Skipped 1 entry:\n" +
+		"  ../evil.avatar: unsafe path\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderArchiveImport() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderWardrobeGrowth_Golden(t *testing.T) {
+	var points []time.Time
+	for month := time.January; month <= time.June; month++ {
+		points = append(points, time.Date(2024, month+1, 1, 0, 0, 0, 0, time.UTC))
+	}
+	growth := entities.WardrobeGrowth{
+		Points: points,
+		Categories: []entities.CategoryGrowth{
+			{Category: "casual", Counts: []int{12, 12, 11, 9, 8, 8}},
+			{Category: "work", Counts: []int{2, 3, 3, 4, 4, 5}},
+		},
+		Totals: []int{14, 15, 14, 13, 12, 13},
+	}
+	var buf bytes.Buffer
+	if err := RenderWardrobeGrowth(&buf, growth); err != nil {
+		t.Fatalf("RenderWardrobeGrowth() error = %v", err)
+	}
+	assertGolden(t, "wardrobe_growth", buf.Bytes())
+}
+
+func TestRenderShoppingSuggestions(t *testing.T) {
+	var buf bytes.Buffer
+	suggestions := []logic.ShoppingSuggestion{
+		{Category: "work", Outfits: 6, WearsPerMonth: 21.7, RotationDays: 8.3, Suggested: 4},
+		{Category: "gym", Outfits: 1, WearsPerMonth: 2, RotationDays: 15, Suggested: 1},
+	}
+	if err := RenderShoppingSuggestions(&buf, suggestions, 10); err != nil {
+		t.Fatal(err)
+	}
+	want := "Categories that offer fewer than 10 distinct outfits a month:\n" +
+		"work: add 4 outfits (6 outfits, worn 21.7 times a month, a rotation takes 8.3 days)\n" +
+		"gym: add 1 outfit (1 outfit, worn 2 times a month, a rotation takes 15 days)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderShoppingSuggestions() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderWeather(t *testing.T) {
+	settings := entities.WeatherSettings{}.
+		SettingCategory("beach", []string{entities.WeatherRain, entities.WeatherCold}).
+		SettingTag("wool", []string{entities.WeatherHeat})
+	report := &usecases.WeatherReport{
+		Forecast: entities.Forecast{
+			Location:            entities.WeatherLocation{Latitude: 51.5, Longitude: -0.12},
+			Date:                fixedTime.Truncate(24 * time.Hour),
+			MinTemperature:      14.2,
+			MaxTemperature:      27.6,
+			PrecipitationChance: 55,
+		},
+		Conditions: []string{entities.WeatherRain, entities.WeatherHeat},
+	}
+	var buf bytes.Buffer
+	if err := RenderWeather(&buf, report, settings); err != nil {
+		t.Fatal(err)
+	}
+	want := "Forecast for 2024-06-01 at 51.5,-0.12: 14-28°C, 55% chance of rain\n" +
+		"Conditions: rain, heat\n" +
+		"  beach: not for rain, cold\n" +
+		"  tag wool: not for heat\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RenderWeather() = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := RenderWeather(&buf, nil, entities.WeatherSettings{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "No weather location set.\nNothing is marked unsuitable for any weather.\n"; buf.String() != want {
+		t.Errorf("RenderWeather(no location) = %q, want %q", buf.String(), want)
+	}
+}
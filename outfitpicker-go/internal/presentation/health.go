@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// RenderCategoryHealth writes each scored category, least healthy first,
+// with the reasons behind scores that are not healthy, followed by a count
+// of the categories needing attention.
+func RenderCategoryHealth(w io.Writer, health map[string]logic.CategoryHealth) error {
+	if len(health) == 0 {
+		_, err := fmt.Fprintln(w, "No categories to check.")
+		return err
+	}
+
+	categories := slices.SortedFunc(maps.Keys(health), func(a, b string) int {
+		return cmp.Or(cmp.Compare(health[a].Score, health[b].Score), cmp.Compare(a, b))
+	})
+	attention := 0
+	for _, category := range categories {
+		categoryHealth := health[category]
+		if _, err := fmt.Fprintf(w, "%s: %d (%s)\n", category, categoryHealth.Score, categoryHealth.Status); err != nil {
+			return err
+		}
+		if categoryHealth.Status == logic.HealthHealthy {
+			continue
+		}
+		attention++
+		for _, reason := range categoryHealth.Reasons {
+			if _, err := fmt.Fprintf(w, "  - %s\n", reason); err != nil {
+				return err
+			}
+		}
+	}
+
+	summary := "All categories are healthy."
+	if attention > 0 {
+		summary = fmt.Sprintf("%d of %d categories need attention.", attention, len(categories))
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", summary)
+	return err
+}
@@ -0,0 +1,133 @@
+package presentation
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+const heatmapDateFormat = "2006-01-02"
+
+// heatmapGlyphs draws the heat levels of logic.HeatLevel in the text grid.
+var heatmapGlyphs = []string{"·", "░", "▒", "▓", "█"}
+
+// heatmapColors fills the heat levels of logic.HeatLevel in the SVG grid.
+var heatmapColors = []string{"#ebedf0", "#9be9a8", "#40c463", "#30a14e", "#216e39"}
+
+var heatmapWeekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// SVG layout, in pixels.
+const (
+	heatmapCell   = 11
+	heatmapStep   = 13
+	heatmapLeft   = 32
+	heatmapTop    = 20
+	heatmapBottom = 8
+)
+
+// heatmapPlacement places a day in the calendar grid: its column is the week of
+// the year, starting on Monday, and its row the day of the week.
+type heatmapPlacement struct {
+	column, row int
+}
+
+// placeHeatmapDays returns where each day of heatmap goes in the grid, and
+// how many week columns the grid has.
+func placeHeatmapDays(heatmap logic.PickHeatmap) ([]heatmapPlacement, int) {
+	if len(heatmap.Days) == 0 {
+		return nil, 0
+	}
+	offset := mondayIndex(heatmap.Days[0].Date)
+	placements := make([]heatmapPlacement, len(heatmap.Days))
+	for i := range heatmap.Days {
+		placements[i] = heatmapPlacement{column: (i + offset) / 7, row: (i + offset) % 7}
+	}
+	return placements, (len(heatmap.Days) + offset + 6) / 7
+}
+
+func mondayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func heatmapTitle(heatmap logic.PickHeatmap) string {
+	from := "every category"
+	if heatmap.Category != "" {
+		from = heatmap.Category
+	}
+	return fmt.Sprintf("Picks from %s in %d (%s)", from, heatmap.Year, pluralize(heatmap.Total, "pick"))
+}
+
+// RenderPickHeatmap writes the picks of each day as a calendar grid, one
+// column per week and one row per day of the week, shaded by how many
+// picks the day had compared to the busiest one.
+func RenderPickHeatmap(w io.Writer, heatmap logic.PickHeatmap) error {
+	placements, columns := placeHeatmapDays(heatmap)
+	grid := make([][]string, 7)
+	for row := range grid {
+		grid[row] = make([]string, columns)
+		for column := range grid[row] {
+			grid[row][column] = " "
+		}
+	}
+	months := []byte(strings.Repeat(" ", columns+3))
+	free := 0
+	for i, day := range heatmap.Days {
+		at := placements[i]
+		grid[at.row][at.column] = heatmapGlyphs[logic.HeatLevel(day.Picks, heatmap.MaxPicks)]
+		// A month is labeled above the week holding its 1st, if the
+		// previous label leaves room.
+		if day.Date.Day() == 1 && at.column >= free {
+			copy(months[at.column:], day.Date.Format("Jan"))
+			free = at.column + 4
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", heatmapTitle(heatmap))
+	fmt.Fprintf(&b, "    %s\n", strings.TrimRight(string(months), " "))
+	for row, weekday := range heatmapWeekdays {
+		fmt.Fprintf(&b, "%s %s\n", weekday, strings.TrimRight(strings.Join(grid[row], ""), " "))
+	}
+	fmt.Fprintf(&b, "\nLess %s More", strings.Join(heatmapGlyphs, " "))
+	if heatmap.MaxPicks > 0 {
+		fmt.Fprintf(&b, " (busiest day: %s)", pluralize(heatmap.MaxPicks, "pick"))
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderPickHeatmapSVG draws the same calendar grid as RenderPickHeatmap as
+// an SVG image, each day a square whose tooltip gives its picks.
+func RenderPickHeatmapSVG(w io.Writer, heatmap logic.PickHeatmap) error {
+	placements, columns := placeHeatmapDays(heatmap)
+	width := heatmapLeft + columns*heatmapStep
+	height := heatmapTop + 7*heatmapStep + heatmapBottom
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"sans-serif\" font-size=\"9\">\n", width, height, width, height)
+	fmt.Fprintf(&b, "  <title>%s</title>\n", html.EscapeString(heatmapTitle(heatmap)))
+	for row, weekday := range heatmapWeekdays {
+		if row%2 == 0 {
+			fmt.Fprintf(&b, "  <text x=\"0\" y=\"%d\">%s</text>\n", heatmapTop+row*heatmapStep+heatmapCell-2, weekday)
+		}
+	}
+	for i, day := range heatmap.Days {
+		if day.Date.Day() == 1 {
+			fmt.Fprintf(&b, "  <text x=\"%d\" y=\"%d\">%s</text>\n", heatmapLeft+placements[i].column*heatmapStep, heatmapTop-6, day.Date.Format("Jan"))
+		}
+	}
+	for i, day := range heatmap.Days {
+		at := placements[i]
+		fmt.Fprintf(&b, "  <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"2\" fill=\"%s\"><title>%s: %s</title></rect>\n",
+			heatmapLeft+at.column*heatmapStep, heatmapTop+at.row*heatmapStep, heatmapCell, heatmapCell,
+			heatmapColors[logic.HeatLevel(day.Picks, heatmap.MaxPicks)], day.Date.Format(heatmapDateFormat), pluralize(day.Picks, "pick"))
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,146 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// historyRow is one rendered history line.
+type historyRow struct {
+	at     time.Time
+	outfit string
+	detail string
+}
+
+// RenderSelectionHistory writes one line per pick followed by the page
+// position.
+func RenderSelectionHistory(w io.Writer, page entities.Page[entities.SelectionRecord]) error {
+	rows := make([]historyRow, len(page.Items))
+	for i, record := range page.Items {
+		rows[i] = historyRow{at: record.SelectedAt, outfit: record.Category + "/" + record.FileName}
+	}
+	return renderHistory(w, rows, page.Page, page.PageCount(), page.Total, "pick")
+}
+
+// RenderWearHistory writes one line per wear event, with its feedback,
+// followed by the page position.
+func RenderWearHistory(w io.Writer, page entities.Page[entities.WearEvent]) error {
+	rows := make([]historyRow, len(page.Items))
+	for i, event := range page.Items {
+		feedback := make([]string, 0, len(event.Feedback))
+		for _, kind := range event.Feedback {
+			feedback = append(feedback, string(kind))
+		}
+		rows[i] = historyRow{at: event.WornAt, outfit: event.Category + "/" + event.FileName, detail: strings.Join(feedback, ", ")}
+	}
+	return renderHistory(w, rows, page.Page, page.PageCount(), page.Total, "wear")
+}
+
+func renderHistory(w io.Writer, rows []historyRow, page, pageCount, total int, noun string) error {
+	if total == 0 {
+		_, err := fmt.Fprintln(w, "No history yet.")
+		return err
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, validation.DisplayWidth(row.outfit))
+	}
+	for _, row := range rows {
+		padding := strings.Repeat(" ", width-validation.DisplayWidth(row.outfit))
+		line := fmt.Sprintf("%s  %s%s  %s", row.at.Format(feedbackDateFormat), row.outfit, padding, row.detail)
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "Page %d of %d (%s)\n", page, max(pageCount, 1), pluralize(total, noun))
+	return err
+}
+
+// RenderCommandHistory writes one line per command run, with its exit code
+// when it failed.
+func RenderCommandHistory(w io.Writer, records []entities.CommandRecord) error {
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "No commands yet.")
+		return err
+	}
+	for _, record := range records {
+		line := fmt.Sprintf("%s  %s", record.RanAt.Format(feedbackDateFormat), CommandLine(record.Args))
+		if record.ExitCode != 0 {
+			line += fmt.Sprintf("  (exit %d)", record.ExitCode)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CommandLine joins args as they would be typed in a shell, quoting those
+// that need it.
+func CommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = arg
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\$`*?&|;<>()#~") {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// RenderUndoResult describes the wear that was undone and whether a rotation
+// was restored.
+func RenderUndoResult(w io.Writer, result *usecases.UndoResult) error {
+	event := result.Event
+	if _, err := fmt.Fprintf(w, "Undid wearing %s/%s on %s.\n", event.Category, event.FileName, event.WornAt.Format(feedbackDateFormat)); err != nil {
+		return err
+	}
+	if result.RestoredRotation {
+		_, err := fmt.Fprintf(w, "Restored the %s rotation it had completed.\n", event.Category)
+		return err
+	}
+	return nil
+}
+
+// WriteHistoryExport streams a history export as JSON in the shape of
+// v1.HistoryExport: when it was made, every pick and wear, and the metadata
+// of each outfit sorted by category and file name. Records are encoded one
+// at a time rather than as a whole document, though export itself still
+// holds every record in memory.
+func WriteHistoryExport(w io.Writer, export usecases.HistoryExport) error {
+	stream := NewJSONStream(w)
+	stream.Field("exportedAt", export.ExportedAt)
+	stream.BeginArray("picks")
+	for _, record := range export.Picks {
+		stream.Element(PickV1(record))
+	}
+	stream.EndArray()
+	stream.BeginArray("wears")
+	for _, event := range export.Wears {
+		stream.Element(WearV1(event))
+	}
+	stream.EndArray()
+	stream.BeginArray("metadata")
+	for _, category := range SortedKeys(export.Metadata.Outfits) {
+		outfits := export.Metadata.Outfits[category]
+		for _, fileName := range SortedKeys(outfits) {
+			stream.Element(v1.OutfitMetadata{Category: category, FileName: fileName, Metadata: MetadataV1(outfits[fileName])})
+		}
+	}
+	stream.EndArray()
+	return stream.Close()
+}
+
+// RenderHistoryExport reports how much of the history was written to path.
+func RenderHistoryExport(w io.Writer, path string, export usecases.HistoryExport) error {
+	_, err := fmt.Fprintf(w, "Wrote %s and %s to %s.\n", pluralize(len(export.Picks), "pick"), pluralize(len(export.Wears), "wear"), path)
+	return err
+}
@@ -0,0 +1,72 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+// RenderArchiveImport summarizes an archive import, listing renamed outfits
+// and skipped entries.
+func RenderArchiveImport(w io.Writer, result *usecases.ArchiveImportResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Imported %s into %s (%s now).\n",
+		pluralize(len(result.Imported), "outfit"), result.Category, pluralize(result.Outfits, "outfit"))
+	for _, outfit := range result.Imported {
+		if outfit.Renamed {
+			fmt.Fprintf(&b, "  %s saved as %s: name already taken\n", outfit.Entry, outfit.FileName)
+		}
+	}
+	if len(result.Skipped) > 0 {
+		noun := "entries"
+		if len(result.Skipped) == 1 {
+			noun = "entry"
+		}
+		fmt.Fprintf(&b, "Skipped %d %s:\n", len(result.Skipped), noun)
+		for _, entry := range result.Skipped {
+			fmt.Fprintf(&b, "  %s: %s\n", entry.Entry, entry.Reason)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RenderCSVImport summarizes a metadata import from a CSV file, listing the
+// rows that matched no outfit.
+func RenderCSVImport(w io.Writer, proposal *usecases.CSVImportProposal) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Imported metadata for %s: %d tagged, %d weighted, %d rated.\n",
+		pluralize(len(proposal.Rows), "outfit"), proposal.Tagged(), proposal.Weighted(), proposal.Rated())
+	writeCSVImportSkips(&b, proposal.Skipped)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// CSVImportSummary is the line --dry-run shows for a CSV import, followed
+// by the rows that matched no outfit.
+func CSVImportSummary(proposal *usecases.CSVImportProposal) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Would import metadata for %s: %d tagged, %d weighted, %d rated.",
+		pluralize(len(proposal.Rows), "outfit"), proposal.Tagged(), proposal.Weighted(), proposal.Rated())
+	if len(proposal.Skipped) > 0 {
+		b.WriteString("\n")
+		writeCSVImportSkips(&b, proposal.Skipped)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeCSVImportSkips(b *strings.Builder, skipped []usecases.CSVImportSkip) {
+	if len(skipped) == 0 {
+		return
+	}
+	noun := "rows"
+	if len(skipped) == 1 {
+		noun = "row"
+	}
+	fmt.Fprintf(b, "Skipped %d %s:\n", len(skipped), noun)
+	for _, skip := range skipped {
+		fmt.Fprintf(b, "  line %d, %s/%s: %s\n", skip.Line, skip.Category, skip.FileName, skip.Reason)
+	}
+}
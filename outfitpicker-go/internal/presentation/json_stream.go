@@ -0,0 +1,107 @@
+package presentation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// JSONStream writes one JSON object a field at a time, with arrays written
+// an element at a time, so a large export never holds more than one
+// element encoded in memory. The document is indented like WriteJSON's.
+// The first error is kept and returned by Close; later writes do nothing.
+type JSONStream struct {
+	w *bufio.Writer
+	// buf holds the value being encoded by encoder, reused for every value.
+	buf     bytes.Buffer
+	encoder *json.Encoder
+	// fields counts the fields written, and elements the elements of the
+	// array being written.
+	fields   int
+	elements int
+	err      error
+}
+
+// NewJSONStream starts a JSON object on w.
+func NewJSONStream(w io.Writer) *JSONStream {
+	s := &JSONStream{w: bufio.NewWriter(w)}
+	s.encoder = json.NewEncoder(&s.buf)
+	s.write("{")
+	return s
+}
+
+// Field writes a field of the object whose value is v.
+func (s *JSONStream) Field(name string, v any) {
+	s.name(name)
+	s.value(v, "  ")
+}
+
+// BeginArray starts a field of the object whose value is an array, filled
+// by Element until EndArray.
+func (s *JSONStream) BeginArray(name string) {
+	s.name(name)
+	s.write("[")
+	s.elements = 0
+}
+
+// Element writes the next element of the array being written.
+func (s *JSONStream) Element(v any) {
+	if s.elements > 0 {
+		s.write(",")
+	}
+	s.write("\n    ")
+	s.value(v, "    ")
+	s.elements++
+}
+
+// EndArray ends the array being written.
+func (s *JSONStream) EndArray() {
+	if s.elements > 0 {
+		s.write("\n  ")
+	}
+	s.write("]")
+}
+
+// Close ends the object, flushes what is buffered and returns the first
+// error met.
+func (s *JSONStream) Close() error {
+	if s.fields > 0 {
+		s.write("\n")
+	}
+	s.write("}\n")
+	if s.err == nil {
+		s.err = s.w.Flush()
+	}
+	return s.err
+}
+
+// name writes the name of the next field.
+func (s *JSONStream) name(name string) {
+	if s.fields > 0 {
+		s.write(",")
+	}
+	s.write("\n  ")
+	s.value(name, "  ")
+	s.write(": ")
+	s.fields++
+}
+
+// value writes v encoded, its nested lines indented by prefix.
+func (s *JSONStream) value(v any, prefix string) {
+	if s.err != nil {
+		return
+	}
+	s.buf.Reset()
+	s.encoder.SetIndent(prefix, "  ")
+	if s.err = s.encoder.Encode(v); s.err != nil {
+		return
+	}
+	_, s.err = s.w.Write(bytes.TrimSuffix(s.buf.Bytes(), []byte("\n")))
+}
+
+func (s *JSONStream) write(text string) {
+	if s.err == nil {
+		_, s.err = s.w.WriteString(text)
+	}
+}
@@ -0,0 +1,144 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+func newHistoryExport(events int) usecases.HistoryExport {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	export := usecases.HistoryExport{
+		ExportedAt: at,
+		Picks:      make([]entities.SelectionRecord, 0, events),
+		Wears:      make([]entities.WearEvent, 0, events),
+		Metadata:   entities.MetadataIndex{Outfits: map[string]map[string]entities.OutfitMetadata{}},
+	}
+	for i := range events {
+		fileName := "outfit-" + strconv.Itoa(i) + ".avatar"
+		export.Picks = append(export.Picks, entities.SelectionRecord{Category: "casual", FileName: fileName, SelectedAt: at.Add(time.Duration(i) * time.Minute)})
+		export.Wears = append(export.Wears, entities.WearEvent{Category: "casual", FileName: fileName, WornAt: at.Add(time.Duration(i) * time.Minute), Feedback: []entities.FeedbackKind{"comfy"}})
+	}
+	export.Metadata = export.Metadata.
+		Setting("work", "suit.avatar", entities.OutfitMetadata{Tags: []string{"formal"}, Colors: []string{"navy"}}).
+		Setting("casual", "tee.avatar", entities.OutfitMetadata{Rating: 4})
+	return export
+}
+
+// historyExportDocumentOf builds the document WriteHistoryExport streams
+// in memory, for comparison.
+func historyExportDocumentOf(export usecases.HistoryExport) v1.HistoryExport {
+	document := v1.HistoryExport{ExportedAt: export.ExportedAt, Picks: []v1.Pick{}, Wears: []v1.Wear{}, Metadata: []v1.OutfitMetadata{}}
+	for _, record := range export.Picks {
+		document.Picks = append(document.Picks, PickV1(record))
+	}
+	for _, event := range export.Wears {
+		document.Wears = append(document.Wears, WearV1(event))
+	}
+	for _, category := range SortedKeys(export.Metadata.Outfits) {
+		for _, fileName := range SortedKeys(export.Metadata.Outfits[category]) {
+			document.Metadata = append(document.Metadata, v1.OutfitMetadata{Category: category, FileName: fileName, Metadata: MetadataV1(export.Metadata.Outfits[category][fileName])})
+		}
+	}
+	return document
+}
+
+func TestWriteHistoryExport_MatchesWriteJSON(t *testing.T) {
+	for _, events := range []int{0, 1, 3} {
+		export := newHistoryExport(events)
+		var streamed, encoded bytes.Buffer
+		if err := WriteHistoryExport(&streamed, export); err != nil {
+			t.Fatalf("WriteHistoryExport() error = %v", err)
+		}
+		if err := WriteJSON(&encoded, historyExportDocumentOf(export)); err != nil {
+			t.Fatal(err)
+		}
+		if streamed.String() != encoded.String() {
+			t.Errorf("%d events: streamed\n%s\nwant\n%s", events, streamed.String(), encoded.String())
+		}
+		if !json.Valid(streamed.Bytes()) {
+			t.Errorf("%d events: streamed export is not valid JSON", events)
+		}
+	}
+}
+
+func TestJSONStream_EmptyObject(t *testing.T) {
+	var b bytes.Buffer
+	if err := NewJSONStream(&b).Close(); err != nil || b.String() != "{}\n" {
+		t.Errorf("empty stream = %q, %v", b.String(), err)
+	}
+}
+
+// largestWriteRecorder records the largest write made to it.
+type largestWriteRecorder struct {
+	largest int
+}
+
+func (r *largestWriteRecorder) Write(p []byte) (int, error) {
+	r.largest = max(r.largest, len(p))
+	return len(p), nil
+}
+
+func TestWriteHistoryExport_WritesAsItGoes(t *testing.T) {
+	var recorder largestWriteRecorder
+	if err := WriteHistoryExport(&recorder, newHistoryExport(10_000)); err != nil {
+		t.Fatal(err)
+	}
+	if recorder.largest > 4096 {
+		t.Errorf("largest write = %d bytes, want the export written in small pieces", recorder.largest)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestJSONStream_KeepsFirstError(t *testing.T) {
+	stream := NewJSONStream(failingWriter{})
+	stream.Field("bad", func() {})
+	stream.Field("ok", 1)
+	var unsupported *json.UnsupportedTypeError
+	if err := stream.Close(); !errors.As(err, &unsupported) {
+		t.Errorf("Close() error = %v, want the encoding error", err)
+	}
+	if err := WriteHistoryExport(failingWriter{}, newHistoryExport(1)); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("WriteHistoryExport() error = %v, want the write error", err)
+	}
+}
+
+// benchmarkHistoryEvents is the size of the histories exported by the
+// benchmarks.
+const benchmarkHistoryEvents = 100_000
+
+func BenchmarkWriteHistoryExport(b *testing.B) {
+	export := newHistoryExport(benchmarkHistoryEvents)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if err := WriteHistoryExport(io.Discard, export); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkWriteJSON_HistoryExport encodes the same export in one piece,
+// for comparison with BenchmarkWriteHistoryExport.
+func BenchmarkWriteJSON_HistoryExport(b *testing.B) {
+	export := newHistoryExport(benchmarkHistoryEvents)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if err := WriteJSON(io.Discard, historyExportDocumentOf(export)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
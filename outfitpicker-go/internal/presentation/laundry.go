@@ -0,0 +1,124 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderLaundryReport writes one section per wash load, followed by worn
+// outfits that lack care information.
+func RenderLaundryReport(w io.Writer, report *usecases.LaundryReport) error {
+	if report.OutfitCount() == 0 {
+		_, err := fmt.Fprintln(w, "Nothing to wash.")
+		return err
+	}
+
+	for i, load := range report.Loads {
+		title := fmt.Sprintf("Load %d: %s", i+1, load.Program)
+		if err := renderOutfitSection(w, title, load.Outfits); err != nil {
+			return err
+		}
+	}
+	if len(report.Unsorted) > 0 {
+		return renderOutfitSection(w, "No care information", report.Unsorted)
+	}
+	return nil
+}
+
+func renderOutfitSection(w io.Writer, title string, outfits []entities.OutfitReference) error {
+	if _, err := fmt.Fprintf(w, "%s (%s):\n", title, pluralize(len(outfits), "outfit")); err != nil {
+		return err
+	}
+	for _, outfit := range outfits {
+		if _, err := fmt.Fprintf(w, "  %s/%s\n", outfit.Category.Name, outfit.FileName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderOutfitMetadata writes an outfit's materials, care symbols, price,
+// tags and rating.
+func RenderOutfitMetadata(w io.Writer, metadata entities.OutfitMetadata) error {
+	materials := make([]string, 0, len(metadata.Materials))
+	for _, component := range metadata.Materials {
+		materials = append(materials, fmt.Sprintf("%d%% %s", component.Percent, component.Fiber))
+	}
+	var price []string
+	if metadata.Price > 0 {
+		price = []string{formatMoney(metadata.Price)}
+	}
+	var rating []string
+	if metadata.Rating > 0 {
+		rating = []string{FormatRating(metadata.Rating)}
+	}
+	for _, line := range []struct {
+		label  string
+		values []string
+	}{
+		{"Materials", materials},
+		{"Care", metadata.Care},
+		{"Price", price},
+		{"Tags", metadata.Tags},
+		{"Rating", rating},
+		{"Colors", metadata.Colors},
+	} {
+		value := "-"
+		if len(line.values) > 0 {
+			value = strings.Join(line.values, ", ")
+		}
+		if _, err := fmt.Fprintf(w, "%-10s %s\n", line.label+":", value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderOutfitSidecar writes what the sidecar file kept next to the outfit
+// fileName says about it, under a heading naming the file.
+func RenderOutfitSidecar(w io.Writer, fileName string, sidecar entities.OutfitSidecar) error {
+	if _, err := fmt.Fprintf(w, "\nFrom %s%s:\n", fileName, entities.OutfitSidecarSuffix); err != nil {
+		return err
+	}
+	var weight []string
+	if sidecar.Weight != nil {
+		weight = []string{FormatWeight(*sidecar.Weight)}
+	}
+	var notes []string
+	if sidecar.Notes != "" {
+		notes = []string{sidecar.Notes}
+	}
+	for _, line := range []struct {
+		label  string
+		values []string
+	}{
+		{"Tags", sidecar.Tags},
+		{"Colors", sidecar.Colors},
+		{"Seasons", sidecar.Seasons},
+		{"Weight", weight},
+		{"Notes", notes},
+	} {
+		if len(line.values) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%-10s %s\n", line.label+":", strings.Join(line.values, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,45 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+// Measurements written by WriteLineProtocol.
+const (
+	wardrobeMeasurement = "outfitpicker_wardrobe"
+	rotationMeasurement = "outfitpicker_rotation"
+	outfitMeasurement   = "outfitpicker_outfit"
+)
+
+// lineProtocolEscaper escapes tag keys and values, which may not contain
+// unescaped commas, equals signs or spaces.
+var lineProtocolEscaper = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+
+// WriteLineProtocol writes metrics in InfluxDB line protocol, every point
+// stamped with metrics.At in nanoseconds: one wardrobe point with the
+// overall statistics, one rotation point per category with outfits and one
+// outfit point per outfit.
+func WriteLineProtocol(w io.Writer, metrics usecases.WardrobeMetrics) error {
+	timestamp := strconv.FormatInt(metrics.At.UnixNano(), 10)
+	analytics := metrics.Analytics
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s outfits=%di,completed_rotations=%di,average_rotation_days=%s,pick_streak_days=%di %s\n",
+		wardrobeMeasurement, len(analytics.Outfits), analytics.CompletedRotations,
+		strconv.FormatFloat(analytics.AverageRotationDays, 'f', -1, 64), analytics.PickStreakDays, timestamp)
+	for _, rotation := range metrics.Rotations {
+		fmt.Fprintf(&b, "%s,category=%s worn=%di,total=%di,progress=%s %s\n",
+			rotationMeasurement, lineProtocolEscaper.Replace(rotation.Category.Name), rotation.WornCount, rotation.TotalOutfitCount,
+			strconv.FormatFloat(rotation.Progress(), 'f', -1, 64), timestamp)
+	}
+	for _, outfit := range analytics.Outfits {
+		fmt.Fprintf(&b, "%s,category=%s,outfit=%s wears=%di %s\n",
+			outfitMeasurement, lineProtocolEscaper.Replace(outfit.Category), lineProtocolEscaper.Replace(outfit.FileName), outfit.Wears, timestamp)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,129 @@
+package presentation
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+const (
+	monthTitleFormat = "January 2006"
+	reportDateFormat = "2006-01-02"
+)
+
+// monthlyReportView holds the report's values formatted for display, so the
+// text and HTML renderers agree on wording.
+type monthlyReportView struct {
+	Title      string
+	Summary    []string
+	Categories []string
+	Rotations  []string
+	Neglected  []string
+	// NeglectedMore counts neglected outfits left out of the list.
+	NeglectedMore int
+	Spend         []string
+}
+
+func newMonthlyReportView(report *usecases.MonthlyReport) monthlyReportView {
+	view := monthlyReportView{
+		Title: "Outfit report for " + report.Month.Format(monthTitleFormat),
+		Summary: []string{
+			pluralize(report.Picks, "pick"),
+			pluralize(report.Wears, "wear"),
+			pluralize(len(report.CompletedRotations), "completed rotation"),
+		},
+		NeglectedMore: report.NeglectedTotal - len(report.Neglected),
+	}
+	for _, count := range report.PicksByCategory {
+		view.Categories = append(view.Categories, fmt.Sprintf("%s: %s", count.Category, pluralize(count.Count, "pick")))
+	}
+	for _, rotation := range report.CompletedRotations {
+		view.Rotations = append(view.Rotations, fmt.Sprintf("%s on %s", rotation.Category, rotation.CompletedAt.Format(reportDateFormat)))
+	}
+	for _, outfit := range report.Neglected {
+		lastWorn := "never worn"
+		if outfit.LastWorn != nil {
+			lastWorn = "last worn " + outfit.LastWorn.Format(reportDateFormat)
+		}
+		view.Neglected = append(view.Neglected, fmt.Sprintf("%s/%s (%s)", outfit.Category, outfit.FileName, lastWorn))
+	}
+	for _, spend := range report.SpendPerWear {
+		view.Spend = append(view.Spend, fmt.Sprintf("%s/%s: %s per wear (%s over %s)",
+			spend.Category, spend.FileName, formatMoney(spend.PerWear), formatMoney(spend.Price), pluralize(spend.Wears, "wear")))
+	}
+	return view
+}
+
+// MonthlyReportSubject returns the email subject line for a report.
+func MonthlyReportSubject(report *usecases.MonthlyReport) string {
+	return newMonthlyReportView(report).Title
+}
+
+// RenderMonthlyReport writes the report as plain text.
+func RenderMonthlyReport(w io.Writer, report *usecases.MonthlyReport) error {
+	view := newMonthlyReportView(report)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n%s\n", view.Title, strings.Join(view.Summary, ", "))
+	for _, section := range []struct {
+		title string
+		lines []string
+		more  int
+	}{
+		{"Picks by category", view.Categories, 0},
+		{"Completed rotations", view.Rotations, 0},
+		{"Neglected outfits", view.Neglected, view.NeglectedMore},
+		{"Spend per wear", view.Spend, 0},
+	} {
+		if len(section.lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s:\n", section.title)
+		for _, line := range section.lines {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		if section.more > 0 {
+			fmt.Fprintf(&b, "  ...and %d more\n", section.more)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var monthlyReportTemplate = template.Must(template.New("monthly").Funcs(template.FuncMap{
+	"section": func(title string, lines []string, more int) map[string]any {
+		return map[string]any{"Title": title, "Lines": lines, "More": more}
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{range $i, $s := .Summary}}{{if $i}}, {{end}}{{$s}}{{end}}</p>
+{{- template "section" (section "Picks by category" .Categories 0)}}
+{{- template "section" (section "Completed rotations" .Rotations 0)}}
+{{- template "section" (section "Neglected outfits" .Neglected .NeglectedMore)}}
+{{- template "section" (section "Spend per wear" .Spend 0)}}
+</body>
+</html>
+{{define "section"}}{{if .Lines}}
+<h2>{{.Title}}</h2>
+<ul>
+{{- range .Lines}}
+<li>{{.}}</li>
+{{- end}}
+{{- if .More}}
+<li>...and {{.More}} more</li>
+{{- end}}
+</ul>
+{{- end}}{{end}}`))
+
+// RenderMonthlyReportHTML writes the report as a standalone HTML document.
+func RenderMonthlyReportHTML(w io.Writer, report *usecases.MonthlyReport) error {
+	return monthlyReportTemplate.Execute(w, newMonthlyReportView(report))
+}
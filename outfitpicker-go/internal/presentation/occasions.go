@@ -0,0 +1,34 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderOccasions shows the occasions in name order with the categories
+// and tags picks for them choose from.
+func RenderOccasions(w io.Writer, occasions map[string]entities.Occasion) error {
+	var b strings.Builder
+	if len(occasions) == 0 {
+		b.WriteString("No occasions set; add one with: occasion set <name> --categories a,b --tags x,y\n")
+	}
+	for _, name := range slices.Sorted(maps.Keys(occasions)) {
+		occasion := occasions[name]
+		from := "any category"
+		if len(occasion.Categories) > 0 {
+			from = strings.Join(occasion.Categories, " or ")
+		}
+		tagged := "any outfit"
+		if len(occasion.Tags) > 0 {
+			tagged = "tagged " + strings.Join(occasion.Tags, " or ")
+		}
+		fmt.Fprintf(&b, "  %s: from %s, %s\n", name, from, tagged)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
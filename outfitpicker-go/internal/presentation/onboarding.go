@@ -0,0 +1,35 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+// RenderOnboardingGuidance writes numbered first-run steps.
+func RenderOnboardingGuidance(w io.Writer, guidance usecases.OnboardingGuidance) error {
+	if _, err := fmt.Fprintf(w, "No configuration found at %s.\n\nTo get started:\n", guidance.ConfigPath); err != nil {
+		return err
+	}
+	for i, step := range guidance.Steps {
+		if _, err := fmt.Fprintf(w, "  %d. %s\n", i+1, step.Description); err != nil {
+			return err
+		}
+		if step.Command == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "     $ %s\n", step.Command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderOnboardingResult summarizes a completed first-run setup.
+func RenderOnboardingResult(w io.Writer, result *usecases.OnboardingResult) error {
+	_, err := fmt.Fprintf(w, "Found %d categories with %d outfits in %s.\n",
+		len(result.Categories), result.OutfitCount(), strings.Join(result.Config.Roots, ", "))
+	return err
+}
@@ -0,0 +1,64 @@
+// Package presentation formats domain entities for terminal and export output.
+// Lists of categories keep the configured category order they are given in;
+// everything else it emits is sorted canonically so output is stable between
+// runs.
+package presentation
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// SortCategoryInfos sorts category infos by name, then by path.
+func SortCategoryInfos(infos []entities.CategoryInfo) {
+	slices.SortStableFunc(infos, func(a, b entities.CategoryInfo) int {
+		return compareCategories(a.Category, b.Category)
+	})
+}
+
+// SortCategoryReferences sorts category references by name, then by path.
+func SortCategoryReferences(categories []entities.CategoryReference) {
+	slices.SortStableFunc(categories, compareCategories)
+}
+
+// SortOutfitReferences sorts outfits by category, then by file name.
+func SortOutfitReferences(outfits []entities.OutfitReference) {
+	slices.SortStableFunc(outfits, func(a, b entities.OutfitReference) int {
+		if c := compareCategories(a.Category, b.Category); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.FileName, b.FileName)
+	})
+}
+
+// SortedKeys returns the keys of a string-keyed map in ascending order.
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// SortedWornOutfits returns the worn outfit file names of a category cache in
+// ascending order.
+func SortedWornOutfits(cache entities.CategoryCache) []string {
+	worn := make([]string, 0, len(cache.WornOutfits))
+	for fileName, isWorn := range cache.WornOutfits {
+		if isWorn {
+			worn = append(worn, fileName)
+		}
+	}
+	slices.Sort(worn)
+	return worn
+}
+
+func compareCategories(a, b entities.CategoryReference) int {
+	if c := cmp.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Path, b.Path)
+}
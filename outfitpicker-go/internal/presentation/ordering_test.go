@@ -0,0 +1,74 @@
+package presentation
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestSortCategoryInfos(t *testing.T) {
+	infos := fixtureCategories()
+	SortCategoryInfos(infos)
+
+	var got []string
+	for _, info := range infos {
+		got = append(got, info.Category.Name)
+	}
+	want := []string{"beach", "casual", "formal", "work"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortCategoryInfos() = %v, want %v", got, want)
+	}
+}
+
+func TestSortCategoryReferences_TiesBrokenByPath(t *testing.T) {
+	refs := []entities.CategoryReference{
+		entities.NewCategoryReference("casual", "/b/casual"),
+		entities.NewCategoryReference("casual", "/a/casual"),
+	}
+	SortCategoryReferences(refs)
+
+	if refs[0].Path != "/a/casual" || refs[1].Path != "/b/casual" {
+		t.Errorf("SortCategoryReferences() = %v, want /a before /b", refs)
+	}
+}
+
+func TestSortOutfitReferences(t *testing.T) {
+	casual := entities.NewCategoryReference("casual", "/outfits/casual")
+	work := entities.NewCategoryReference("work", "/outfits/work")
+	outfits := []entities.OutfitReference{
+		entities.NewOutfitReference("b.avatar", work),
+		entities.NewOutfitReference("z.avatar", casual),
+		entities.NewOutfitReference("a.avatar", work),
+		entities.NewOutfitReference("a.avatar", casual),
+	}
+	SortOutfitReferences(outfits)
+
+	var got []string
+	for _, outfit := range outfits {
+		got = append(got, outfit.Category.Name+"/"+outfit.FileName)
+	}
+	want := []string{"casual/a.avatar", "casual/z.avatar", "work/a.avatar", "work/b.avatar"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortOutfitReferences() = %v, want %v", got, want)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	got := SortedKeys(map[string]int{"c": 3, "a": 1, "b": 2})
+	want := []string{"a", "b", "c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestSortedWornOutfits(t *testing.T) {
+	cache := entities.CategoryCache{
+		WornOutfits: map[string]bool{"c.avatar": true, "a.avatar": true, "b.avatar": false},
+	}
+	got := SortedWornOutfits(cache)
+	want := []string{"a.avatar", "c.avatar"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SortedWornOutfits() = %v, want %v", got, want)
+	}
+}
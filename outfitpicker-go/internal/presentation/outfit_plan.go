@@ -0,0 +1,134 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+const planDayFormat = "Mon 2006-01-02"
+
+// iCalendar value formats and the longest content line, in octets, before
+// it is folded.
+const (
+	icsDateFormat     = "20060102"
+	icsDateTimeFormat = "20060102T150405Z"
+	icsLineLength     = 75
+)
+
+// icsEscaper escapes the characters iCalendar text values reserve.
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// planConflicts describe why planned outfits cannot be worn as planned.
+var planConflicts = map[string]string{
+	logic.PlanStatusMissing:     "missing",
+	logic.PlanStatusAlreadyWorn: "already worn this rotation",
+}
+
+// RenderOutfitPlan writes each planned day with its outfits, one per
+// category, noting the days already worn and the outfits that can no
+// longer be worn as planned.
+func RenderOutfitPlan(w io.Writer, report logic.PlanReport) error {
+	width := 0
+	for _, day := range report.Days {
+		for _, outfit := range day.Outfits {
+			width = max(width, validation.DisplayWidth(outfit.Category))
+		}
+	}
+	for i, day := range report.Days {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		heading := day.Date.Format(planDayFormat)
+		if day.Worn {
+			heading += "  worn"
+		}
+		if _, err := fmt.Fprintln(w, heading); err != nil {
+			return err
+		}
+		if len(day.Outfits) == 0 {
+			if _, err := fmt.Fprintln(w, "  nothing to pick"); err != nil {
+				return err
+			}
+		}
+		for _, outfit := range day.Outfits {
+			padding := strings.Repeat(" ", width-validation.DisplayWidth(outfit.Category))
+			var note string
+			if conflict, ok := planConflicts[outfit.Status]; ok {
+				note = "  (" + conflict + ")"
+			}
+			if _, err := fmt.Fprintf(w, "  %s%s  %s%s\n", outfit.Category, padding, outfit.FileName, note); err != nil {
+				return err
+			}
+		}
+	}
+	if report.Conflicts > 0 {
+		if _, err := fmt.Fprintf(w, "\n%s can no longer be worn as planned. Reroll a day to replace its outfits.\n", pluralize(report.Conflicts, "outfit")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderOutfitPlanICS writes the plan as an iCalendar file with an all-day
+// event for each planned outfit, which calendar applications can import
+// or subscribe to. Event UIDs follow from the day and outfit, so
+// importing a regenerated plan again updates the days that kept their
+// outfits.
+func RenderOutfitPlanICS(w io.Writer, report logic.PlanReport) error {
+	stamp := report.CreatedAt.UTC().Format(icsDateTimeFormat)
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//outfitpicker//outfit plan//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "X-WR-CALNAME:Outfit plan")
+	for _, day := range report.Days {
+		start := day.Date.Format(icsDateFormat)
+		end := time.Date(day.Date.Year(), day.Date.Month(), day.Date.Day()+1, 0, 0, 0, 0, time.UTC).Format(icsDateFormat)
+		for _, outfit := range day.Outfits {
+			writeICSLine(&b, "BEGIN:VEVENT")
+			writeICSLine(&b, "UID:"+icsEscaper.Replace(fmt.Sprintf("%s-%s-%s@outfitpicker", start, outfit.Category, outfit.FileName)))
+			writeICSLine(&b, "DTSTAMP:"+stamp)
+			writeICSLine(&b, "DTSTART;VALUE=DATE:"+start)
+			writeICSLine(&b, "DTEND;VALUE=DATE:"+end)
+			writeICSLine(&b, "SUMMARY:"+icsEscaper.Replace(outfit.Category+": "+outfit.FileName))
+			if conflict, ok := planConflicts[outfit.Status]; ok {
+				writeICSLine(&b, "DESCRIPTION:"+icsEscaper.Replace(conflict))
+			}
+			writeICSLine(&b, "TRANSP:TRANSPARENT")
+			writeICSLine(&b, "END:VEVENT")
+		}
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeICSLine writes an iCalendar content line ended by CRLF, folding it
+// into continuation lines so none is longer than icsLineLength octets.
+// Lines are only folded between UTF-8 characters.
+func writeICSLine(b *strings.Builder, line string) {
+	limit := icsLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward
+		// their length.
+		limit = icsLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
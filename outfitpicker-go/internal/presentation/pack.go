@@ -0,0 +1,15 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderPackExport reports an outfit pack written to path.
+func RenderPackExport(w io.Writer, path string, manifest *entities.PackManifest) error {
+	_, err := fmt.Fprintf(w, "Wrote %s with %s from %s.\nPrices, wear history and paths are left out; add it to a wardrobe with 'outfitpicker import archive'.\n",
+		path, pluralize(len(manifest.Outfits), "outfit"), manifest.Category)
+	return err
+}
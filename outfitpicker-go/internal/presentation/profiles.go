@@ -0,0 +1,22 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderProfiles lists the profiles, marking the active one with "*".
+func RenderProfiles(w io.Writer, profiles []entities.Profile) error {
+	for _, profile := range profiles {
+		marker := " "
+		if profile.Active {
+			marker = "*"
+		}
+		if _, err := fmt.Fprintf(w, "%s %s\n", marker, profile.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
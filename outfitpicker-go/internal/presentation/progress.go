@@ -0,0 +1,18 @@
+package presentation
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// NDJSONProgress returns a progress callback that writes each event to w as a
+// single line of JSON. Write errors are ignored so a closed progress stream
+// never fails the operation it reports on.
+func NDJSONProgress(w io.Writer) func(entities.ProgressEvent) {
+	encoder := json.NewEncoder(w)
+	return func(event entities.ProgressEvent) {
+		encoder.Encode(ProgressEventV1(event))
+	}
+}
@@ -0,0 +1,217 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FieldSelection is the set of JSON fields an output is projected onto,
+// parsed by ParseFieldSelection. Each selected field maps to the fields
+// selected within it, or to nil when it is kept whole.
+type FieldSelection map[string]FieldSelection
+
+// ParseFieldSelection parses a comma-separated list of field names, such as
+// "category,fileName,lastWorn". A dotted name such as "outfit.fileName"
+// selects a field within another.
+func ParseFieldSelection(list string) (FieldSelection, error) {
+	selection := FieldSelection{}
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		current := selection
+		names := strings.Split(field, ".")
+		for i, name := range names {
+			if name == "" {
+				return nil, fmt.Errorf("field %q has an empty name", field)
+			}
+			sub, seen := current[name]
+			if i == len(names)-1 {
+				current[name] = nil
+				break
+			}
+			if seen && sub == nil {
+				// The whole field is already selected.
+				break
+			}
+			if sub == nil {
+				sub = FieldSelection{}
+				current[name] = sub
+			}
+			current = sub
+		}
+	}
+	if len(selection) == 0 {
+		return nil, fmt.Errorf("no fields selected in %q", list)
+	}
+	return selection, nil
+}
+
+// Project returns the JSON encoding of v with only the selected fields of
+// each object kept, in their original order. A field that is not selected
+// but holds objects is projected in turn, so selecting "fileName" keeps the
+// file names of a list nested in a page of results. Arrays keep every
+// element.
+func (s FieldSelection) Project(v any) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := decodeNode(decoder)
+	if err != nil {
+		return nil, err
+	}
+	projected, kept := s.project(node)
+	if !kept {
+		switch node.(type) {
+		case orderedObject:
+			projected = orderedObject{}
+		case []any:
+			projected = []any{}
+		}
+	}
+	return json.Marshal(projected)
+}
+
+// WriteProjectedJSON writes v as WriteJSON does, projected onto the fields
+// of selection; a nil selection keeps every field.
+func WriteProjectedJSON(w io.Writer, v any, selection FieldSelection) error {
+	if selection == nil {
+		return WriteJSON(w, v)
+	}
+	projected, err := selection.Project(v)
+	if err != nil {
+		return err
+	}
+	return WriteJSON(w, projected)
+}
+
+// WriteProjectedJSONLine writes v as one line of compact JSON, projected
+// onto the fields of selection, for output that streams a record at a time;
+// a nil selection keeps every field.
+func WriteProjectedJSONLine(w io.Writer, v any, selection FieldSelection) error {
+	if selection == nil {
+		return json.NewEncoder(w).Encode(v)
+	}
+	projected, err := selection.Project(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", projected)
+	return err
+}
+
+// project returns node with only the selected fields of its objects,
+// reporting whether anything in it was selected.
+func (s FieldSelection) project(node any) (any, bool) {
+	switch node := node.(type) {
+	case orderedObject:
+		var projected orderedObject
+		for _, member := range node {
+			sub, selected := s[member.Key]
+			switch {
+			case selected && sub == nil:
+				projected = append(projected, member)
+			case selected:
+				if value, ok := sub.project(member.Value); ok {
+					projected = append(projected, objectMember{Key: member.Key, Value: value})
+				}
+			default:
+				if value, ok := s.project(member.Value); ok {
+					projected = append(projected, objectMember{Key: member.Key, Value: value})
+				}
+			}
+		}
+		if projected == nil {
+			return orderedObject{}, false
+		}
+		return projected, true
+	case []any:
+		projected := make([]any, len(node))
+		anyKept := false
+		for i, element := range node {
+			value, kept := s.project(element)
+			projected[i] = value
+			anyKept = anyKept || kept
+		}
+		return projected, anyKept
+	default:
+		return nil, false
+	}
+}
+
+// orderedObject is a JSON object that keeps the order of its members.
+type orderedObject []objectMember
+
+type objectMember struct {
+	Key   string
+	Value any
+}
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, member := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(member.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(member.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// decodeNode reads the next JSON value from decoder, with objects as
+// orderedObjects, arrays as []any and numbers as json.Numbers.
+func decodeNode(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := orderedObject{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, objectMember{Key: key.(string), Value: value})
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []any{}
+		for decoder.More() {
+			value, err := decodeNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	default:
+		return token, nil
+	}
+}
@@ -0,0 +1,94 @@
+package presentation
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestFieldSelection_Project(t *testing.T) {
+	page := entities.Page[entities.SelectionRecord]{
+		Items: []entities.SelectionRecord{
+			{Category: "casual", FileName: "tee.avatar", SelectedAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC), OutfitID: "a1"},
+			{Category: "work", FileName: "suit.avatar"},
+		},
+		Total: 2,
+		Page:  1,
+		Limit: 20,
+	}
+	outfit := entities.NewOutfitReference("tee.avatar", entities.NewCategoryReference("casual", "/w/casual"))
+	tests := []struct {
+		name   string
+		fields string
+		value  any
+		want   string
+	}{
+		{"fields of nested records", "fileName,category", page, `{"items":[{"category":"casual","fileName":"tee.avatar"},{"category":"work","fileName":"suit.avatar"}]}`},
+		{"top-level field", "total", page, `{"total":2}`},
+		{"missing in some records", "outfitId", page, `{"items":[{"outfitId":"a1"},{}]}`},
+		{"dotted name", "category.name", outfit, `{"category":{"name":"casual"}}`},
+		{"whole field wins", "category.name,category", outfit, `{"category":{"name":"casual","path":"/w/casual"}}`},
+		{"array at the top", "fileName", []entities.OutfitReference{outfit}, `[{"fileName":"tee.avatar"}]`},
+		{"nothing matches", "colour", outfit, `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection, err := ParseFieldSelection(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := selection.Project(tt.value)
+			if err != nil {
+				t.Fatalf("Project() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Project() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldSelection_Invalid(t *testing.T) {
+	for _, list := range []string{"", " , ", "category..name", ".fileName"} {
+		if _, err := ParseFieldSelection(list); err == nil {
+			t.Errorf("ParseFieldSelection(%q) accepted an empty field", list)
+		}
+	}
+}
+
+func TestWriteProjectedJSON(t *testing.T) {
+	var b bytes.Buffer
+	outfit := entities.NewOutfitReference("tee.avatar", entities.NewCategoryReference("casual", "/w/casual"))
+	if err := WriteProjectedJSON(&b, outfit, FieldSelection{"fileName": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"fileName\": \"tee.avatar\"\n}\n"; b.String() != want {
+		t.Errorf("WriteProjectedJSON() = %q, want %q", b.String(), want)
+	}
+
+	var all, plain bytes.Buffer
+	if err := WriteProjectedJSON(&all, outfit, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteJSON(&plain, outfit); err != nil {
+		t.Fatal(err)
+	}
+	if all.String() != plain.String() {
+		t.Errorf("WriteProjectedJSON() with no selection = %q, want %q", all.String(), plain.String())
+	}
+}
+
+func TestWriteProjectedJSONLine(t *testing.T) {
+	outfit := entities.NewOutfitReference("tee.avatar", entities.NewCategoryReference("casual", "/w/casual"))
+	var b bytes.Buffer
+	for range 2 {
+		if err := WriteProjectedJSONLine(&b, outfit, FieldSelection{"fileName": nil}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := "{\"fileName\":\"tee.avatar\"}\n{\"fileName\":\"tee.avatar\"}\n"; b.String() != want {
+		t.Errorf("WriteProjectedJSONLine() = %q, want %q", b.String(), want)
+	}
+}
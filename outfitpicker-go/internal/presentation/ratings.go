@@ -0,0 +1,39 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderRatingsReport writes the average rating of each category and the
+// rated outfits, best first.
+func RenderRatingsReport(w io.Writer, report entities.RatingsReport) error {
+	if len(report.Outfits) == 0 {
+		_, err := fmt.Fprintln(w, "No rated outfits yet; rate one with 'outfitpicker rate <category> <outfit> <1-5>'.")
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "Average rating by category:"); err != nil {
+		return err
+	}
+	for _, category := range report.Categories {
+		if _, err := fmt.Fprintf(w, "  %s: %.1f (%s)\n", category.Category, category.Average, pluralize(category.Rated, "rated outfit")); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w, "Rated outfits:"); err != nil {
+		return err
+	}
+	for _, outfit := range report.Outfits {
+		if _, err := fmt.Fprintf(w, "  %s  %s/%s\n", FormatRating(outfit.Rating), outfit.Category, outfit.FileName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FormatRating formats a rating out of entities.MaxRating, such as 4/5.
+func FormatRating(rating int) string {
+	return fmt.Sprintf("%d/%d", rating, entities.MaxRating)
+}
@@ -0,0 +1,275 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// RenderCategoryList writes a table of categories with their state and outfit
+// count, in the order given, which is the configured category order.
+// Names are decorated and progress drawn according to style. When progress
+// is not nil a rotation column shows how far through its rotation each
+// category is, and when health is not nil a health column shows each scored
+// category's score and status. When permissions restrict the profile an
+// access column shows what it may do with each category, and when locked is
+// not nil a lock column marks categories whose rotation is locked. When any
+// category has a description, a description column shows its label and
+// description.
+func RenderCategoryList(w io.Writer, infos []entities.CategoryInfo, progress map[string]entities.RotationProgress, health map[string]logic.CategoryHealth, permissions entities.CategoryPermissions, locked map[string]logic.LockedRotation, style Style) error {
+	reserveEmoji := style.hasEmoji()
+	labels := make([]string, len(infos))
+	widths := make([]int, len(infos))
+	rotations := make([]string, len(infos))
+	access := make([]string, len(infos))
+	locks := make([]string, len(infos))
+	descriptions := make([]string, len(infos))
+	described := false
+	nameWidth := len("CATEGORY")
+	stateWidth := len("STATE")
+	countWidth := len("OUTFITS")
+	rotationWidth := len("ROTATION")
+	accessWidth := len("ACCESS")
+	lockWidth := len("LOCK")
+	descriptionWidth := len("DESCRIPTION")
+	for i, info := range infos {
+		labels[i], widths[i] = style.alignedLabel(info.Category.Name, reserveEmoji)
+		nameWidth = max(nameWidth, widths[i])
+		stateWidth = max(stateWidth, len(info.State))
+		countWidth = max(countWidth, len(strconv.Itoa(info.OutfitCount)))
+		if progress != nil {
+			rotations[i] = rotationLabel(progress, info.Category.Name, style.ProgressStyle)
+			rotationWidth = max(rotationWidth, len(rotations[i]))
+		}
+		if permissions.Restricted() {
+			access[i] = accessLabel(permissions, info.Category.Name)
+			accessWidth = max(accessWidth, len(access[i]))
+		}
+		if locked != nil {
+			locks[i] = lockLabel(locked, info.Category.Name)
+			lockWidth = max(lockWidth, len(locks[i]))
+		}
+		if info.Description != nil {
+			descriptions[i] = info.Description.Summary()
+			described = true
+		}
+	}
+	if described {
+		for i, description := range descriptions {
+			if description == "" {
+				descriptions[i] = "-"
+			}
+			descriptionWidth = max(descriptionWidth, utf8.RuneCountInString(descriptions[i]))
+		}
+	}
+
+	columns := []string{"CATEGORY", "STATE", "OUTFITS"}
+	widthsByColumn := []int{nameWidth, stateWidth, countWidth}
+	if progress != nil {
+		columns = append(columns, "ROTATION")
+		widthsByColumn = append(widthsByColumn, rotationWidth)
+	}
+	if permissions.Restricted() {
+		columns = append(columns, "ACCESS")
+		widthsByColumn = append(widthsByColumn, accessWidth)
+	}
+	if locked != nil {
+		columns = append(columns, "LOCK")
+		widthsByColumn = append(widthsByColumn, lockWidth)
+	}
+	if described {
+		columns = append(columns, "DESCRIPTION")
+		widthsByColumn = append(widthsByColumn, descriptionWidth)
+	}
+	if health != nil {
+		columns = append(columns, "HEALTH")
+		widthsByColumn = append(widthsByColumn, 0)
+	}
+	if _, err := io.WriteString(w, tableRow(columns, nil, widthsByColumn)+"\n"); err != nil {
+		return err
+	}
+	for i, info := range infos {
+		cells := []string{labels[i], string(info.State), strconv.Itoa(info.OutfitCount)}
+		cellWidths := []int{widths[i], len(info.State), len(cells[2])}
+		if progress != nil {
+			cells = append(cells, rotations[i])
+			cellWidths = append(cellWidths, len(rotations[i]))
+		}
+		if permissions.Restricted() {
+			cells = append(cells, access[i])
+			cellWidths = append(cellWidths, len(access[i]))
+		}
+		if locked != nil {
+			cells = append(cells, locks[i])
+			cellWidths = append(cellWidths, len(locks[i]))
+		}
+		if described {
+			cells = append(cells, descriptions[i])
+			cellWidths = append(cellWidths, utf8.RuneCountInString(descriptions[i]))
+		}
+		if health != nil {
+			cells = append(cells, healthLabel(health, info.Category.Name))
+			cellWidths = append(cellWidths, 0)
+		}
+		if _, err := io.WriteString(w, tableRow(cells, cellWidths, widthsByColumn)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accessLabel returns what the profile may do with a category: pick from it
+// and start its rotation over, only pick from it, or only view it.
+func accessLabel(permissions entities.CategoryPermissions, category string) string {
+	switch {
+	case !permissions.CanPick(category):
+		return "view only"
+	case !permissions.CanReset(category):
+		return "no reset"
+	}
+	return "full"
+}
+
+// lockLabel is the lock cell of a category: "-" when its rotation is not
+// locked.
+func lockLabel(locked map[string]logic.LockedRotation, category string) string {
+	rotation, ok := locked[category]
+	switch {
+	case !ok:
+		return "-"
+	case rotation.AwaitingReset:
+		return "reset needed"
+	}
+	return "locked"
+}
+
+// tableRow pads every cell but the last to its column. widths holds the
+// display width of each cell; nil means the cells are as wide as their
+// length.
+func tableRow(cells []string, widths, columns []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+		width := len(cell)
+		if widths != nil {
+			width = widths[i]
+		}
+		b.WriteString(padRight(cell, width, columns[i]))
+	}
+	return b.String()
+}
+
+// rotationProgressBarWidth is the number of cells in a rotation progress bar
+// in the category list.
+const rotationProgressBarWidth = 10
+
+// rotationLabel returns a category's rotation progress drawn in the progress
+// style, or "-" for categories without outfits.
+func rotationLabel(progress map[string]entities.RotationProgress, category, style string) string {
+	categoryProgress, ok := progress[category]
+	if !ok {
+		return "-"
+	}
+	return ProgressIndicator(style, categoryProgress, rotationProgressBarWidth)
+}
+
+// healthLabel returns a category's score and status, or "-" for categories
+// that are not scored.
+func healthLabel(health map[string]logic.CategoryHealth, category string) string {
+	categoryHealth, ok := health[category]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%d %s", categoryHealth.Score, categoryHealth.Status)
+}
+
+// padRight pads text, whose display width is width, to the column width plus
+// the gap between columns.
+func padRight(text string, width, column int) string {
+	return text + strings.Repeat(" ", column-width+columnGap)
+}
+
+const columnGap = 2
+
+// RenderOutfitList writes one outfit per line, grouped by category.
+func RenderOutfitList(w io.Writer, outfits []entities.OutfitReference) error {
+	sorted := slices.Clone(outfits)
+	SortOutfitReferences(sorted)
+
+	for _, outfit := range sorted {
+		if _, err := fmt.Fprintf(w, "%s/%s\n", outfit.Category.Name, outfit.FileName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderWornOutfits writes the worn outfits of every category in the cache.
+func RenderWornOutfits(w io.Writer, cache entities.OutfitCache) error {
+	for _, path := range SortedKeys(cache.Categories) {
+		category := cache.Categories[path]
+		if _, err := fmt.Fprintf(w, "%s (%d/%d)\n", path, len(category.WornOutfits), category.TotalOutfits); err != nil {
+			return err
+		}
+		for _, fileName := range SortedWornOutfits(category) {
+			if _, err := fmt.Fprintf(w, "  %s\n", fileName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RenderWardrobeChanges writes a summary of the differences between two
+// wardrobe snapshots.
+func RenderWardrobeChanges(w io.Writer, changes logic.WardrobeChanges) error {
+	if changes.IsEmpty() {
+		_, err := fmt.Fprintln(w, "No changes detected.")
+		return err
+	}
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"Added categories", changes.AddedCategories},
+		{"Removed categories", changes.RemovedCategories},
+		{"Added outfits", qualifiedOutfits(changes.AddedOutfits)},
+		{"Removed outfits", qualifiedOutfits(changes.RemovedOutfits)},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s (%d):\n", section.title, len(section.items)); err != nil {
+			return err
+		}
+		for _, item := range section.items {
+			if _, err := fmt.Fprintf(w, "  %s\n", item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func qualifiedOutfits(outfits map[string][]string) []string {
+	var items []string
+	for _, category := range SortedKeys(outfits) {
+		files := slices.Clone(outfits[category])
+		slices.Sort(files)
+		for _, file := range files {
+			items = append(items, category+"/"+file)
+		}
+	}
+	return items
+}
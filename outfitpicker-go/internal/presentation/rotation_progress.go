@@ -0,0 +1,31 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// progressPatterns fill the cells of a pattern progress bar, one for each
+// quarter of the rotation, so how far a rotation has got can be told from
+// the shape of the bar rather than its length or color.
+var progressPatterns = []string{".", ":", "x", "#"}
+
+// ProgressIndicator draws the share of a rotation already worn in the given
+// progress style: a bar of width cells, a bar of width cells filled with
+// the pattern of the rotation's quarter, or a percentage badge.
+func ProgressIndicator(style string, progress entities.RotationProgress, width int) string {
+	share := progress.Progress()
+	if style == entities.ProgressStyleBadge {
+		return fmt.Sprintf("[%3d%%]", int(share*100+0.5))
+	}
+	filled := int(share*float64(width) + 0.5)
+	filled = min(max(filled, 0), width)
+	fill, empty := "#", "-"
+	if style == entities.ProgressStylePattern {
+		fill = progressPatterns[min(int(share*float64(len(progressPatterns))), len(progressPatterns)-1)]
+		empty = " "
+	}
+	return "[" + strings.Repeat(fill, filled) + strings.Repeat(empty, width-filled) + "]"
+}
@@ -0,0 +1,35 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestProgressIndicator(t *testing.T) {
+	tests := []struct {
+		style       string
+		worn, total int
+		want        string
+	}{
+		{"", 0, 4, "[----]"},
+		{"bar", 1, 4, "[#---]"},
+		{"bar", 3, 4, "[###-]"},
+		{"bar", 0, 0, "[####]"},
+		{"pattern", 0, 4, "[    ]"},
+		{"pattern", 1, 8, "[.   ]"},
+		{"pattern", 1, 4, "[:   ]"},
+		{"pattern", 2, 4, "[xx  ]"},
+		{"pattern", 3, 4, "[### ]"},
+		{"pattern", 4, 4, "[####]"},
+		{"badge", 0, 4, "[  0%]"},
+		{"badge", 1, 3, "[ 33%]"},
+		{"badge", 4, 4, "[100%]"},
+	}
+	for _, tt := range tests {
+		progress := entities.NewRotationProgress(entities.NewCategoryReference("casual", "/outfits/casual"), tt.worn, tt.total)
+		if got := ProgressIndicator(tt.style, progress, 4); got != tt.want {
+			t.Errorf("ProgressIndicator(%q, %d/%d) = %q, want %q", tt.style, tt.worn, tt.total, got, tt.want)
+		}
+	}
+}
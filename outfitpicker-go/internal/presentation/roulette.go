@@ -0,0 +1,8 @@
+package presentation
+
+// RouletteVeto is an outfit turned down during a roulette, with the reason
+// given, if any.
+type RouletteVeto struct {
+	FileName string
+	Reason   string
+}
@@ -0,0 +1,33 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderSeasons shows the current season and the seasons assigned to
+// categories and tags.
+func RenderSeasons(w io.Writer, current string, assignments entities.SeasonAssignments) error {
+	var b strings.Builder
+	hemisphere := "northern"
+	if assignments.Southern {
+		hemisphere = "southern"
+	}
+	fmt.Fprintf(&b, "Current season: %s (%s hemisphere)\n", current, hemisphere)
+	if len(assignments.Categories) == 0 && len(assignments.Tags) == 0 {
+		b.WriteString("No seasons assigned; everything is worn all year.\n")
+	}
+	for _, category := range slices.Sorted(maps.Keys(assignments.Categories)) {
+		fmt.Fprintf(&b, "  %s: %s\n", category, strings.Join(assignments.Category(category), ", "))
+	}
+	for _, tag := range slices.Sorted(maps.Keys(assignments.Tags)) {
+		fmt.Fprintf(&b, "  tag %s: %s\n", tag, strings.Join(assignments.Tag(tag), ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,33 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+)
+
+// RenderShoppingSuggestions writes the categories where new outfits would
+// add the most variety, first the most needed, with why each is listed.
+func RenderShoppingSuggestions(w io.Writer, suggestions []logic.ShoppingSuggestion, quota int) error {
+	if len(suggestions) == 0 {
+		_, err := fmt.Fprintf(w, "Every category offers enough variety for up to %d distinct outfits a month.\n", quota)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Categories that offer fewer than %d distinct outfits a month:\n", quota); err != nil {
+		return err
+	}
+	for _, suggestion := range suggestions {
+		if _, err := fmt.Fprintf(w, "%s: add %s (%s, worn %s times a month, a rotation takes %s days)\n",
+			suggestion.Category,
+			pluralize(suggestion.Suggested, "outfit"),
+			pluralize(suggestion.Outfits, "outfit"),
+			strconv.FormatFloat(suggestion.WearsPerMonth, 'f', -1, 64),
+			strconv.FormatFloat(suggestion.RotationDays, 'f', -1, 64),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
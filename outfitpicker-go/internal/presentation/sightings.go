@@ -0,0 +1,48 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+const sightingDateFormat = "2006-01-02"
+
+// RenderOutfitSightings writes how long each outfit of a category has been
+// owned, and when outfits that have gone were last seen.
+func RenderOutfitSightings(w io.Writer, category string, sightings []entities.OutfitSighting, now time.Time) error {
+	if len(sightings) == 0 {
+		_, err := fmt.Fprintf(w, "No outfits seen in %s yet.\n", category)
+		return err
+	}
+
+	width := 0
+	for _, sighting := range sightings {
+		width = max(width, validation.DisplayWidth(sighting.FileName))
+	}
+	for _, sighting := range sightings {
+		padding := strings.Repeat(" ", width-validation.DisplayWidth(sighting.FileName))
+		if _, err := fmt.Fprintf(w, "%s%s  %s\n", sighting.FileName, padding, sightingDetail(sighting, now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sightingDetail(sighting entities.OutfitSighting, now time.Time) string {
+	switch {
+	case !sighting.Present && sighting.LastSeen.IsZero():
+		return "gone"
+	case !sighting.Present:
+		return "gone, last seen " + sighting.LastSeen.Format(sightingDateFormat)
+	case sighting.FirstSeen.IsZero():
+		return "owned since before tracking began"
+	default:
+		days := int(now.Sub(sighting.FirstSeen).Hours() / 24)
+		return fmt.Sprintf("owned %s, since %s", pluralize(days, "day"), sighting.FirstSeen.Format(sightingDateFormat))
+	}
+}
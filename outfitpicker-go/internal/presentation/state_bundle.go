@@ -0,0 +1,40 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderStateBundleExport reports a state bundle written to path.
+func RenderStateBundleExport(w io.Writer, path string, manifest *entities.StateBundleManifest) error {
+	_, err := fmt.Fprintf(w, "Wrote %s with %s.\nThe outfit files are left out; copy them separately, then run 'outfitpicker import bundle' on the new machine.\n",
+		path, strings.Join(manifest.Files, ", "))
+	return err
+}
+
+// StateBundleImportSummary is the one-line summary of a state bundle
+// import.
+func StateBundleImportSummary(proposal *usecases.StateBundleProposal) string {
+	return fmt.Sprintf("Would import the state bundle written by outfitpicker %s on %s",
+		proposal.Manifest.Version, proposal.Manifest.CreatedAt.Local().Format(feedbackDateFormat))
+}
+
+// RenderStateBundleImport reports a state bundle imported, warning about
+// the wardrobe roots that do not exist on this machine.
+func RenderStateBundleImport(w io.Writer, result *usecases.StateBundleImport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Imported the state bundle written by outfitpicker %s on %s.\n",
+		result.Manifest.Version, result.Manifest.CreatedAt.Local().Format(feedbackDateFormat))
+	if result.Previous != nil {
+		fmt.Fprintf(&b, "The previous config and cache were saved as backup %s.\n", result.Previous.ID)
+	}
+	for _, root := range result.MissingRoots {
+		fmt.Fprintf(&b, "Wardrobe root %s does not exist here; copy the outfit files to it before picking.\n", root)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
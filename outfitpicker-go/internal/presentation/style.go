@@ -0,0 +1,96 @@
+package presentation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// emojiSlot is the width reserved for an emoji and the space after it.
+const emojiSlot = validation.EmojiWidth + 1
+
+var ansiColorCodes = map[string]int{
+	"black": 30, "red": 31, "green": 32, "yellow": 33,
+	"blue": 34, "magenta": 35, "cyan": 36, "white": 37,
+}
+
+// Style controls how category names are decorated and rotation progress is
+// drawn in rendered output.
+type Style struct {
+	Decorations map[string]entities.CategoryDecoration
+	// Color enables ANSI color escapes for categories with a color.
+	Color bool
+	// ProgressStyle is one of the entities progress styles, passed to
+	// ProgressIndicator.
+	ProgressStyle string
+}
+
+// PlainStyle renders category names without decorations.
+var PlainStyle = Style{}
+
+// NewStyle creates a style using the decorations and progress style from
+// config.
+func NewStyle(config *entities.Config, color bool) Style {
+	return Style{Decorations: config.CategoryDecorations, Color: color, ProgressStyle: config.Accessibility.ProgressStyle}
+}
+
+// CategoryLabel returns the category name prefixed with its emoji, if any,
+// and colored when color is enabled.
+func (s Style) CategoryLabel(name string) string {
+	decoration := s.Decorations[name]
+	label := name
+	if decoration.Emoji != "" {
+		label = decoration.Emoji + " " + name
+	}
+	return s.colorize(label, decoration.Color)
+}
+
+// alignedLabel returns the label of name padded so that names line up in a
+// column whether or not they have an emoji, along with its display width.
+func (s Style) alignedLabel(name string, reserveEmoji bool) (string, int) {
+	decoration := s.Decorations[name]
+	label := name
+	switch {
+	case decoration.Emoji != "":
+		label = decoration.Emoji + " " + name
+	case reserveEmoji:
+		label = strings.Repeat(" ", emojiSlot) + name
+	}
+	return s.colorize(label, decoration.Color), validation.DisplayWidth(label)
+}
+
+func (s Style) hasEmoji() bool {
+	for _, decoration := range s.Decorations {
+		if decoration.Emoji != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Style) colorize(text, color string) string {
+	if !s.Color || color == "" {
+		return text
+	}
+	if code, ok := ansiColorCodes[color]; ok {
+		return fmt.Sprintf("\x1b[%dm%s\x1b[0m", code, text)
+	}
+	if r, g, b, ok := parseHexColor(color); ok {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", r, g, b, text)
+	}
+	return text
+}
+
+func parseHexColor(color string) (r, g, b int64, ok bool) {
+	if len(color) != 7 || color[0] != '#' {
+		return 0, 0, 0, false
+	}
+	value, err := strconv.ParseInt(color[1:], 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return value >> 16 & 0xff, value >> 8 & 0xff, value & 0xff, true
+}
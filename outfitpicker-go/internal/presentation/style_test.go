@@ -0,0 +1,46 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestStyle_CategoryLabel(t *testing.T) {
+	decorations := map[string]entities.CategoryDecoration{
+		"casual": {Emoji: "👕", Color: "blue"},
+		"formal": {Color: "#ff8000"},
+		"gym":    {Emoji: "🏃"},
+	}
+
+	tests := []struct {
+		name     string
+		color    bool
+		category string
+		want     string
+	}{
+		{"plain name", false, "work", "work"},
+		{"emoji without color", false, "casual", "👕 casual"},
+		{"named color", true, "casual", "\x1b[34m👕 casual\x1b[0m"},
+		{"hex color", true, "formal", "\x1b[38;2;255;128;0mformal\x1b[0m"},
+		{"emoji only", true, "gym", "🏃 gym"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			style := Style{Decorations: decorations, Color: tt.color}
+			if got := style.CategoryLabel(tt.category); got != tt.want {
+				t.Errorf("CategoryLabel(%q) = %q, want %q", tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStyle(t *testing.T) {
+	config := &entities.Config{CategoryDecorations: map[string]entities.CategoryDecoration{"casual": {Emoji: "👕"}}}
+	style := NewStyle(config, true)
+
+	if !style.Color || style.CategoryLabel("casual") != "👕 casual" {
+		t.Errorf("NewStyle() = %+v", style)
+	}
+}
@@ -0,0 +1,23 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderOutfitTags lists tagged outfits with their tags.
+func RenderOutfitTags(w io.Writer, tagged []entities.OutfitTags) error {
+	if len(tagged) == 0 {
+		_, err := fmt.Fprintln(w, "No tagged outfits yet.")
+		return err
+	}
+	for _, outfit := range tagged {
+		if _, err := fmt.Fprintf(w, "%s/%s: %s\n", outfit.Category, outfit.FileName, strings.Join(outfit.Tags, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
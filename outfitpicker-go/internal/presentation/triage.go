@@ -0,0 +1,47 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+)
+
+// RenderNewOutfits lists outfits awaiting triage, one per line.
+func RenderNewOutfits(w io.Writer, outfits []usecases.NewOutfit) error {
+	if len(outfits) == 0 {
+		_, err := fmt.Fprintln(w, "No new outfits to triage.")
+		return err
+	}
+	for _, outfit := range outfits {
+		if _, err := fmt.Fprintf(w, "%s/%s\n", outfit.Category, outfit.FileName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderTriageResult summarizes the triage decisions that were saved.
+func RenderTriageResult(w io.Writer, result *usecases.TriageResult) error {
+	var parts []string
+	for _, count := range []struct {
+		n    int
+		verb string
+	}{
+		{result.Accepted, "accepted"},
+		{result.Tagged, "tagged"},
+		{result.Excluded, "excluded"},
+		{result.Archived, "archived"},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.verb))
+		}
+	}
+	if len(parts) == 0 {
+		_, err := fmt.Fprintln(w, "No triage decisions saved.")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Triage saved: %s.\n", strings.Join(parts, ", "))
+	return err
+}
@@ -0,0 +1,78 @@
+// Package tui implements the full-screen terminal interfaces of the
+// interactive and roulette commands: key decoding, models of the screen
+// state, and their rendering.
+package tui
+
+import (
+	"bufio"
+)
+
+// Key is a key press the interface reacts to.
+type Key int
+
+const (
+	// KeyOther is any key the interface ignores.
+	KeyOther Key = iota
+	KeyUp
+	KeyDown
+	KeyEnter
+	KeyYes
+	KeyNo
+	KeySpace
+	KeyVeto
+	KeyQuit
+)
+
+// ReadKey reads one key press from r. Arrow keys arrive as the escape
+// sequences ESC [ A and ESC [ B; j and k move the cursor too. Ctrl-C and
+// Escape quit like q.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return KeyOther, err
+	}
+	switch b {
+	case 0x1b:
+		return readEscape(r), nil
+	case 'k':
+		return KeyUp, nil
+	case 'j':
+		return KeyDown, nil
+	case '\r', '\n':
+		return KeyEnter, nil
+	case 'y', 'Y':
+		return KeyYes, nil
+	case 'n', 'N':
+		return KeyNo, nil
+	case ' ':
+		return KeySpace, nil
+	case 'v', 'V':
+		return KeyVeto, nil
+	case 'q', 'Q', 0x03:
+		return KeyQuit, nil
+	}
+	return KeyOther, nil
+}
+
+// readEscape decodes the rest of an escape sequence. A lone Escape, with
+// nothing buffered after it, quits.
+func readEscape(r *bufio.Reader) Key {
+	if r.Buffered() == 0 {
+		return KeyQuit
+	}
+	if next, _ := r.Peek(1); next[0] != '[' {
+		return KeyQuit
+	}
+	r.ReadByte()
+	b, err := r.ReadByte()
+	if err != nil {
+		return KeyOther
+	}
+	switch b {
+	case 'A':
+		return KeyUp
+	case 'B':
+		return KeyDown
+	}
+	return KeyOther
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	input := bufio.NewReader(strings.NewReader("\x1b[A\x1b[Bkj\r\nyN vq\x03x\x1b[C\x1b"))
+	want := []Key{KeyUp, KeyDown, KeyUp, KeyDown, KeyEnter, KeyEnter, KeyYes, KeyNo, KeySpace, KeyVeto, KeyQuit, KeyQuit, KeyOther, KeyOther, KeyQuit}
+	var got []Key
+	for {
+		key, err := ReadKey(input)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, key)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ReadKey() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// Action is what the caller must do after a key press.
+type Action int
+
+const (
+	// ActionNone needs nothing beyond redrawing.
+	ActionNone Action = iota
+	// ActionPick asks for an outfit from the selected category.
+	ActionPick
+	// ActionWear confirms wearing the pending outfit.
+	ActionWear
+	// ActionQuit leaves the interface.
+	ActionQuit
+)
+
+// Model is the state of the interactive screen: the categories with their
+// rotation progress, the selected one, and the outfit awaiting confirmation.
+type Model struct {
+	Categories []entities.RotationProgress
+	Cursor     int
+	// Pending is the picked outfit waiting to be confirmed, if any.
+	Pending *entities.OutfitReference
+	// Status is a one-line message shown under the list.
+	Status string
+	// ProgressStyle is how rotation progress is drawn, one of the
+	// entities progress styles.
+	ProgressStyle string
+}
+
+// NewModel creates a model with the first category selected.
+func NewModel(categories []entities.RotationProgress) *Model {
+	return &Model{Categories: categories}
+}
+
+// Selected returns the selected category, or false when there are none.
+func (m *Model) Selected() (entities.RotationProgress, bool) {
+	if m.Cursor < 0 || m.Cursor >= len(m.Categories) {
+		return entities.RotationProgress{}, false
+	}
+	return m.Categories[m.Cursor], true
+}
+
+// Handle applies a key press and returns the action it requests. While an
+// outfit is pending only y, n, and quitting are accepted.
+func (m *Model) Handle(key Key) Action {
+	if key == KeyQuit {
+		return ActionQuit
+	}
+	if m.Pending != nil {
+		switch key {
+		case KeyYes:
+			return ActionWear
+		case KeyNo:
+			m.Pending = nil
+			m.Status = "Not worn."
+		}
+		return ActionNone
+	}
+	switch key {
+	case KeyUp:
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+	case KeyDown:
+		if m.Cursor < len(m.Categories)-1 {
+			m.Cursor++
+		}
+	case KeyEnter:
+		if _, ok := m.Selected(); ok {
+			return ActionPick
+		}
+	}
+	return ActionNone
+}
+
+// Refresh replaces the categories, keeping the selection on the same
+// category when it is still listed.
+func (m *Model) Refresh(categories []entities.RotationProgress) {
+	selected, ok := m.Selected()
+	m.Categories = categories
+	m.Cursor = 0
+	for i, category := range categories {
+		if ok && category.Category.Name == selected.Category.Name {
+			m.Cursor = i
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func progress(name string, worn, total int) entities.RotationProgress {
+	return entities.NewRotationProgress(entities.NewCategoryReference(name, "/w/"+name), worn, total)
+}
+
+func TestModel_Handle(t *testing.T) {
+	m := NewModel([]entities.RotationProgress{progress("casual", 0, 2), progress("formal", 1, 4)})
+
+	for _, key := range []Key{KeyUp, KeyDown, KeyDown} {
+		if action := m.Handle(key); action != ActionNone {
+			t.Fatalf("Handle(%v) = %v, want ActionNone", key, action)
+		}
+	}
+	if m.Cursor != 1 {
+		t.Errorf("Cursor = %d, want 1 after moving past both ends", m.Cursor)
+	}
+	if action := m.Handle(KeyEnter); action != ActionPick {
+		t.Fatalf("Handle(KeyEnter) = %v, want ActionPick", action)
+	}
+
+	outfit := entities.NewOutfitReference("suit.avatar", m.Categories[1].Category)
+	m.Pending = &outfit
+	if action := m.Handle(KeyDown); action != ActionNone || m.Cursor != 1 {
+		t.Errorf("moving while pending: action = %v, cursor = %d", action, m.Cursor)
+	}
+	if action := m.Handle(KeyYes); action != ActionWear {
+		t.Errorf("Handle(KeyYes) = %v, want ActionWear", action)
+	}
+	if action := m.Handle(KeyNo); action != ActionNone || m.Pending != nil || m.Status != "Not worn." {
+		t.Errorf("Handle(KeyNo): action = %v, model = %+v", action, m)
+	}
+	if action := m.Handle(KeyQuit); action != ActionQuit {
+		t.Errorf("Handle(KeyQuit) = %v, want ActionQuit", action)
+	}
+}
+
+func TestModel_RefreshKeepsSelection(t *testing.T) {
+	m := NewModel([]entities.RotationProgress{progress("casual", 0, 2), progress("formal", 1, 4)})
+	m.Cursor = 1
+
+	m.Refresh([]entities.RotationProgress{progress("beach", 0, 1), progress("casual", 1, 2), progress("formal", 2, 4)})
+	if selected, _ := m.Selected(); selected.Category.Name != "formal" || selected.WornCount != 2 {
+		t.Errorf("Selected() after refresh = %+v, want formal", selected)
+	}
+	m.Refresh(nil)
+	if _, ok := m.Selected(); ok || m.Handle(KeyEnter) != ActionNone {
+		t.Error("an empty model should have nothing to pick")
+	}
+}
+
+func TestRender(t *testing.T) {
+	m := NewModel([]entities.RotationProgress{progress("casual", 5, 10), progress("formalwear", 0, 3)})
+	var out bytes.Buffer
+	if err := Render(&out, m); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		clearScreen,
+		"> casual     [##########----------] 5/10\r\n",
+		"  formalwear [--------------------] 0/3\r\n",
+		"enter picks",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Render() missing %q:\n%s", want, out.String())
+		}
+	}
+
+	outfit := entities.NewOutfitReference("tee.avatar", m.Categories[0].Category)
+	m.Pending = &outfit
+	m.Status = "hello"
+	out.Reset()
+	Render(&out, m)
+	if !strings.Contains(out.String(), "Wear casual/tee.avatar? (y/n)\r\nhello\r\n") {
+		t.Errorf("Render() with a pending outfit:\n%s", out.String())
+	}
+
+	m.ProgressStyle = entities.ProgressStyleBadge
+	out.Reset()
+	Render(&out, m)
+	if !strings.Contains(out.String(), "> casual     [ 50%] 5/10\r\n") {
+		t.Errorf("Render() with badges:\n%s", out.String())
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+// EnableRaw is not supported on this platform.
+func EnableRaw(f *os.File) (restore func() error, err error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
@@ -0,0 +1,36 @@
+//go:build linux || darwin
+
+package tui
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// EnableRaw switches the terminal f to raw mode so key presses arrive one
+// at a time without echo, and returns a function restoring the previous
+// mode.
+func EnableRaw(f *os.File) (restore func() error, err error) {
+	var original syscall.Termios
+	if err := termios(f, ioctlGetTermios, &original); err != nil {
+		return nil, err
+	}
+	raw := original
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(f, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() error { return termios(f, ioctlSetTermios, &original) }, nil
+}
+
+func termios(f *os.File, request uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), request, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return os.NewSyscallError("ioctl", errno)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+	"github.com/dh85/outfitpicker/internal/presentation"
+)
+
+// barWidth is the number of cells in a rotation progress bar.
+const barWidth = 20
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// Render redraws the whole screen for m. Lines end in "\r\n" because the
+// terminal is in raw mode and does not translate newlines.
+func Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	b.WriteString("Outfit Picker\r\n\r\n")
+	if len(m.Categories) == 0 {
+		b.WriteString("  No categories with outfits.\r\n")
+	}
+
+	width := 0
+	for _, category := range m.Categories {
+		width = max(width, validation.DisplayWidth(category.Category.Name))
+	}
+	for i, category := range m.Categories {
+		cursor := "  "
+		if i == m.Cursor {
+			cursor = "> "
+		}
+		name := category.Category.Name
+		padding := strings.Repeat(" ", width-validation.DisplayWidth(name))
+		fmt.Fprintf(&b, "%s%s%s %s %d/%d\r\n", cursor, name, padding, presentation.ProgressIndicator(m.ProgressStyle, category, barWidth), category.WornCount, category.TotalOutfitCount)
+	}
+
+	b.WriteString("\r\n")
+	if m.Pending != nil {
+		fmt.Fprintf(&b, "Wear %s/%s? (y/n)\r\n", m.Pending.Category.Name, m.Pending.FileName)
+	} else {
+		b.WriteString("up/down or k/j select, enter picks, q quits\r\n")
+	}
+	if m.Status != "" {
+		b.WriteString(m.Status + "\r\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
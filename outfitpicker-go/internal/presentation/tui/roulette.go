@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RouletteAction is what the caller of the roulette screen must do after a
+// key press.
+type RouletteAction int
+
+const (
+	// RouletteNone needs nothing beyond redrawing.
+	RouletteNone RouletteAction = iota
+	// RouletteAccept accepts the outfit the spin landed on.
+	RouletteAccept
+	// RouletteSpin spins again, passing over the outfit for now.
+	RouletteSpin
+	// RouletteVeto vetoes the outfit with the reason typed.
+	RouletteVeto
+	// RouletteQuit leaves without accepting an outfit.
+	RouletteQuit
+)
+
+// RouletteModel is the state of the roulette screen: the outfit the last
+// spin landed on and, while a veto is being typed, its reason.
+type RouletteModel struct {
+	Spin   int
+	Outfit entities.OutfitReference
+	// Available and Total are the outfits left to spin and in the
+	// category.
+	Available int
+	Total     int
+	Vetoed    int
+	// Vetoing is set while the reason for vetoing Outfit is typed.
+	Vetoing bool
+	Reason  string
+}
+
+// Land shows the outfit spin number spin landed on, with the outfits left
+// to spin and in the category.
+func (m *RouletteModel) Land(spin int, outfit entities.OutfitReference, available, total int) {
+	m.Spin, m.Outfit, m.Available, m.Total = spin, outfit, available, total
+	m.Vetoing, m.Reason = false, ""
+}
+
+// Handle applies a key press and returns the action it requests: enter
+// accepts, space spins again, v starts typing a veto reason and q quits.
+func (m *RouletteModel) Handle(key Key) RouletteAction {
+	switch key {
+	case KeyQuit:
+		return RouletteQuit
+	case KeyEnter:
+		return RouletteAccept
+	case KeySpace:
+		return RouletteSpin
+	case KeyVeto:
+		m.Vetoing, m.Reason = true, ""
+	}
+	return RouletteNone
+}
+
+// Type applies a character typed while a veto reason is being entered.
+// Enter vetoes the outfit, backspace deletes the last character, and Ctrl-C
+// or Escape take the veto back.
+func (m *RouletteModel) Type(r rune) RouletteAction {
+	switch r {
+	case '\r', '\n':
+		m.Vetoed++
+		return RouletteVeto
+	case 0x7f, '\b':
+		if reason := []rune(m.Reason); len(reason) > 0 {
+			m.Reason = string(reason[:len(reason)-1])
+		}
+	case 0x03, 0x1b:
+		m.Vetoing, m.Reason = false, ""
+	default:
+		if unicode.IsPrint(r) {
+			m.Reason += string(r)
+		}
+	}
+	return RouletteNone
+}
+
+// RenderRoulette redraws the whole roulette screen for m, with the same
+// raw mode line endings as Render.
+func RenderRoulette(w io.Writer, m *RouletteModel) error {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	b.WriteString("Outfit Roulette\r\n\r\n")
+	fmt.Fprintf(&b, "Spin %d: %s/%s\r\n", m.Spin, m.Outfit.Category.Name, m.Outfit.FileName)
+	fmt.Fprintf(&b, "%d of %d outfits left to spin, %d vetoed\r\n\r\n", m.Available, m.Total, m.Vetoed)
+	if m.Vetoing {
+		fmt.Fprintf(&b, "Reason for the veto (optional, enter vetoes, esc takes it back): %s", m.Reason)
+	} else {
+		b.WriteString("enter accepts, space spins again, v vetoes, q quits\r\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
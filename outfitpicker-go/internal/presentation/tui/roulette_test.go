@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+func TestRouletteModel_Handle(t *testing.T) {
+	var m RouletteModel
+	m.Land(1, entities.NewOutfitReference("tee.avatar", entities.NewCategoryReference("casual", "/w/casual")), 3, 3)
+
+	for key, want := range map[Key]RouletteAction{KeyEnter: RouletteAccept, KeySpace: RouletteSpin, KeyQuit: RouletteQuit, KeyUp: RouletteNone} {
+		if action := m.Handle(key); action != want {
+			t.Errorf("Handle(%v) = %v, want %v", key, action, want)
+		}
+	}
+	if action := m.Handle(KeyVeto); action != RouletteNone || !m.Vetoing {
+		t.Fatalf("Handle(KeyVeto): action = %v, vetoing = %v", action, m.Vetoing)
+	}
+	for _, r := range "too warmm\x7f\x01" {
+		if action := m.Type(r); action != RouletteNone {
+			t.Fatalf("Type(%q) = %v, want RouletteNone", r, action)
+		}
+	}
+	if action := m.Type('\r'); action != RouletteVeto || m.Reason != "too warm" || m.Vetoed != 1 {
+		t.Errorf("Type(enter): action = %v, model = %+v", action, m)
+	}
+
+	m.Land(2, m.Outfit, 2, 3)
+	m.Handle(KeyVeto)
+	m.Type('x')
+	if action := m.Type(0x1b); action != RouletteNone || m.Vetoing || m.Reason != "" || m.Vetoed != 1 {
+		t.Errorf("Type(escape): action = %v, model = %+v", action, m)
+	}
+}
+
+func TestRenderRoulette(t *testing.T) {
+	m := RouletteModel{Vetoed: 1}
+	m.Land(2, entities.NewOutfitReference("tee.avatar", entities.NewCategoryReference("casual", "/w/casual")), 2, 3)
+
+	var buf bytes.Buffer
+	if err := RenderRoulette(&buf, &m); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Spin 2: casual/tee.avatar\r\n", "2 of 3 outfits left to spin, 1 vetoed\r\n", "space spins again"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("screen missing %q:\n%q", want, buf.String())
+		}
+	}
+
+	m.Handle(KeyVeto)
+	m.Type('n')
+	buf.Reset()
+	RenderRoulette(&buf, &m)
+	if !strings.HasSuffix(buf.String(), "Reason for the veto (optional, enter vetoes, esc takes it back): n") {
+		t.Errorf("screen while vetoing = %q", buf.String())
+	}
+}
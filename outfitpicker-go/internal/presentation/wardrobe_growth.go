@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// sparkBars are the bar heights of a sparkline, lowest first.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// RenderWardrobeGrowth writes a sparkline of each category's size over the
+// timeline, then the totals, with the first and last counts and the change.
+func RenderWardrobeGrowth(w io.Writer, growth entities.WardrobeGrowth) error {
+	if len(growth.Categories) == 0 || len(growth.Points) == 0 {
+		_, err := fmt.Fprintln(w, "No outfits tracked yet; outfits are tracked from the first time their category is picked from.")
+		return err
+	}
+
+	first, last := growthMonth(growth.Points[0]), growthMonth(growth.Points[len(growth.Points)-1])
+	if _, err := fmt.Fprintf(w, "Outfits owned at the end of each month, %s to %s:\n", first, last); err != nil {
+		return err
+	}
+	rows := slices.Concat(growth.Categories, []entities.CategoryGrowth{{Category: "total", Counts: growth.Totals}})
+	width := 0
+	for _, row := range rows {
+		width = max(width, validation.DisplayWidth(row.Category))
+	}
+	for _, row := range rows {
+		padding := strings.Repeat(" ", width-validation.DisplayWidth(row.Category))
+		from, to := row.Counts[0], row.Counts[len(row.Counts)-1]
+		if _, err := fmt.Fprintf(w, "%s%s  %s  %d → %d (%+d)\n", row.Category, padding, Sparkline(row.Counts), from, to, row.Change()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sparkline draws counts as bars scaled to the largest count.
+func Sparkline(counts []int) string {
+	highest := 0
+	for _, count := range counts {
+		highest = max(highest, count)
+	}
+	var line strings.Builder
+	for _, count := range counts {
+		bar := 0
+		if highest > 0 {
+			bar = count * (len(sparkBars) - 1) / highest
+		}
+		line.WriteRune(sparkBars[bar])
+	}
+	return line.String()
+}
+
+// growthMonth names the month a growth point ends.
+func growthMonth(point time.Time) string {
+	return point.Add(-time.Nanosecond).Format("Jan 2006")
+}
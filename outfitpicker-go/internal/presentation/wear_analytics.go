@@ -0,0 +1,94 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/logic"
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// RenderWearAnalytics writes how often each outfit was worn, most worn
+// first, followed by the summary figures.
+func RenderWearAnalytics(w io.Writer, analytics logic.WearAnalytics) error {
+	if len(analytics.Outfits) == 0 {
+		if _, err := fmt.Fprintln(w, "No outfits in the wardrobe."); err != nil {
+			return err
+		}
+	} else {
+		if _, err := fmt.Fprintln(w, "Wears per outfit:"); err != nil {
+			return err
+		}
+		width := 0
+		for _, outfit := range analytics.Outfits {
+			width = max(width, validation.DisplayWidth(outfit.Category+"/"+outfit.FileName))
+		}
+		for _, outfit := range analytics.Outfits {
+			name := outfit.Category + "/" + outfit.FileName
+			padding := strings.Repeat(" ", width-validation.DisplayWidth(name))
+			if _, err := fmt.Fprintf(w, "  %s%s  %d\n", name, padding, outfit.Wears); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	lines := [][2]string{}
+	if most, least := analytics.MostWornCategory, analytics.LeastWornCategory; most != nil && least != nil {
+		lines = append(lines,
+			[2]string{"Most worn category", fmt.Sprintf("%s (%s)", most.Category, pluralize(most.Wears, "wear"))},
+			[2]string{"Least worn category", fmt.Sprintf("%s (%s)", least.Category, pluralize(least.Wears, "wear"))},
+		)
+	}
+	rotation := "no rotation completed yet"
+	if analytics.CompletedRotations > 0 {
+		rotation = fmt.Sprintf("%.1f days over %s", analytics.AverageRotationDays, pluralize(analytics.CompletedRotations, "completed rotation"))
+	}
+	lines = append(lines,
+		[2]string{"Average rotation", rotation},
+		[2]string{"Pick streak", pluralize(analytics.PickStreakDays, "day")},
+	)
+	if len(analytics.LockedRotations) > 0 {
+		locked := make([]string, len(analytics.LockedRotations))
+		for i, rotation := range analytics.LockedRotations {
+			state := fmt.Sprintf("%d/%d worn", rotation.Worn, rotation.Total)
+			if rotation.AwaitingReset {
+				state = "reset needed"
+			}
+			locked[i] = fmt.Sprintf("%s (%s)", rotation.Category, state)
+		}
+		lines = append(lines, [2]string{"Locked rotations", strings.Join(locked, ", ")})
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(w, "%-21s%s\n", line[0]+":", line[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteWearAnalyticsCSV writes the wears of each outfit as CSV with a
+// header row. Outfits never worn have an empty last_worn.
+func WriteWearAnalyticsCSV(w io.Writer, analytics logic.WearAnalytics) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"category", "outfit", "wears", "last_worn"}); err != nil {
+		return err
+	}
+	for _, outfit := range analytics.Outfits {
+		lastWorn := ""
+		if outfit.LastWorn != nil {
+			lastWorn = outfit.LastWorn.Format(time.RFC3339)
+		}
+		if err := out.Write([]string{outfit.Category, outfit.FileName, strconv.Itoa(outfit.Wears), lastWorn}); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,55 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderWearDiscrepancies lists the outfits the cache and the wear history
+// disagree on, with how to repair them.
+func RenderWearDiscrepancies(w io.Writer, discrepancies []entities.WearDiscrepancy) error {
+	if len(discrepancies) == 0 {
+		_, err := fmt.Fprintln(w, "Wear history and cache agree.")
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Wear history and cache disagree on %s:\n", pluralize(len(discrepancies), "outfit")); err != nil {
+		return err
+	}
+	if err := renderDiscrepancies(w, discrepancies); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Run doctor --deep --repair %s or --repair %s to reconcile them.\n", entities.RepairFromHistory, entities.RepairFromCache)
+	return err
+}
+
+// RenderWearRepair describes the discrepancies a repair resolved and which
+// record it trusted.
+func RenderWearRepair(w io.Writer, discrepancies []entities.WearDiscrepancy, source entities.RepairSource) error {
+	if len(discrepancies) == 0 {
+		_, err := fmt.Fprintln(w, "Wear history and cache agree; nothing to repair.")
+		return err
+	}
+	record := "wear history"
+	if source == entities.RepairFromCache {
+		record = "cache"
+	}
+	if _, err := fmt.Fprintf(w, "Repaired %s from the %s:\n", pluralize(len(discrepancies), "outfit"), record); err != nil {
+		return err
+	}
+	return renderDiscrepancies(w, discrepancies)
+}
+
+func renderDiscrepancies(w io.Writer, discrepancies []entities.WearDiscrepancy) error {
+	for _, discrepancy := range discrepancies {
+		detail := "worn in the cache but not in the wear history"
+		if discrepancy.Kind == entities.DiscrepancyHistoryOnly {
+			detail = "worn in the wear history but not in the cache"
+		}
+		if _, err := fmt.Fprintf(w, "  %s/%s: %s\n", discrepancy.Category, discrepancy.FileName, detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
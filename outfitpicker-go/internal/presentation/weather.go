@@ -0,0 +1,44 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+const weatherDateFormat = "2006-01-02"
+
+// RenderWeather shows today's forecast with the conditions it calls for,
+// and the categories and tags unsuitable for each condition. A nil report
+// shows only the settings, for when no location is set.
+func RenderWeather(w io.Writer, report *usecases.WeatherReport, settings entities.WeatherSettings) error {
+	var b strings.Builder
+	if report == nil {
+		b.WriteString("No weather location set.\n")
+	} else {
+		forecast := report.Forecast
+		fmt.Fprintf(&b, "Forecast for %s at %s: %.0f-%.0f°C, %d%% chance of rain\n",
+			forecast.Date.Format(weatherDateFormat), forecast.Location, forecast.MinTemperature, forecast.MaxTemperature, forecast.PrecipitationChance)
+		conditions := "none"
+		if len(report.Conditions) > 0 {
+			conditions = strings.Join(report.Conditions, ", ")
+		}
+		fmt.Fprintf(&b, "Conditions: %s\n", conditions)
+	}
+	if len(settings.Categories) == 0 && len(settings.Tags) == 0 {
+		b.WriteString("Nothing is marked unsuitable for any weather.\n")
+	}
+	for _, category := range slices.Sorted(maps.Keys(settings.Categories)) {
+		fmt.Fprintf(&b, "  %s: not for %s\n", category, strings.Join(settings.Categories[category], ", "))
+	}
+	for _, tag := range slices.Sorted(maps.Keys(settings.Tags)) {
+		fmt.Fprintf(&b, "  tag %s: not for %s\n", tag, strings.Join(settings.Tags[tag], ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
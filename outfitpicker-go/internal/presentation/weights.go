@@ -0,0 +1,33 @@
+package presentation
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+)
+
+// RenderOutfitWeights lists assigned weights by category and file name.
+func RenderOutfitWeights(w io.Writer, weights entities.OutfitWeights) error {
+	if len(weights.Outfits) == 0 {
+		_, err := fmt.Fprintln(w, "No weights assigned; every outfit has weight 1.")
+		return err
+	}
+	for _, category := range slices.Sorted(maps.Keys(weights.Outfits)) {
+		files := weights.Outfits[category]
+		for _, file := range slices.Sorted(maps.Keys(files)) {
+			if _, err := fmt.Fprintf(w, "%s/%s  %s\n", category, file, FormatWeight(files[file])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FormatWeight formats a weight without trailing zeros.
+func FormatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
@@ -0,0 +1,45 @@
+package v1
+
+import "time"
+
+// CacheExport is the export of the rotation cache. Categories are sorted
+// by path and worn outfits by file name, so the document diffs cleanly
+// between runs.
+type CacheExport struct {
+	Version    int             `json:"version"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Categories []CategoryCache `json:"categories"`
+}
+
+// CategoryCache is the rotation of one category.
+type CategoryCache struct {
+	Path         string    `json:"path"`
+	TotalOutfits int       `json:"totalOutfits"`
+	WornOutfits  []string  `json:"wornOutfits"`
+	LastUpdated  time.Time `json:"lastUpdated"`
+}
+
+// CategorySnapshot is the rotation of one category at a past time.
+type CategorySnapshot struct {
+	Category           string   `json:"category"`
+	Outfits            []string `json:"outfits"`
+	Worn               []string `json:"worn"`
+	Available          []string `json:"available"`
+	CompletedRotations int      `json:"completedRotations"`
+	LastPick           *Pick    `json:"lastPick,omitempty"`
+}
+
+// RotationSnapshot is the rotation state rebuilt from the history at a
+// past time.
+type RotationSnapshot struct {
+	At         time.Time          `json:"at"`
+	Categories []CategorySnapshot `json:"categories"`
+}
+
+// SnapshotExportSummary is what snapshot export reports after writing a
+// snapshot to a file.
+type SnapshotExportSummary struct {
+	Out        string    `json:"out"`
+	At         time.Time `json:"at"`
+	Categories int       `json:"categories"`
+}
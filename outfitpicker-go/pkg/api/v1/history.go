@@ -0,0 +1,92 @@
+package v1
+
+import "time"
+
+// Page is one page of a history listing.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+	// Total counts every entry that matched, across all pages.
+	Total int `json:"total"`
+}
+
+// Pick is an outfit picked from a category.
+type Pick struct {
+	Category   string    `json:"category"`
+	FileName   string    `json:"fileName"`
+	SelectedAt time.Time `json:"selectedAt"`
+	// OutfitID is the outfit's stable ID when outfit IDs are enabled.
+	OutfitID string `json:"outfitId,omitempty"`
+}
+
+// Wear is an outfit marked worn.
+type Wear struct {
+	Category string    `json:"category"`
+	FileName string    `json:"fileName"`
+	WornAt   time.Time `json:"wornAt"`
+	// Feedback lists the feedback given for the wear, such as "compliment".
+	Feedback []string `json:"feedback,omitempty"`
+	Note     string   `json:"note,omitempty"`
+	// CompletedRotation is set when the wear was the last unworn outfit of
+	// its category, which started a new rotation.
+	CompletedRotation bool `json:"completedRotation,omitempty"`
+	// OutfitID is the outfit's stable ID when outfit IDs are enabled.
+	OutfitID string `json:"outfitId,omitempty"`
+}
+
+// Material is one fiber in an outfit's material composition.
+type Material struct {
+	Fiber   string `json:"fiber"`
+	Percent int    `json:"percent"`
+}
+
+// Metadata is the metadata recorded for an outfit.
+type Metadata struct {
+	Materials []Material `json:"materials,omitempty"`
+	Care      []string   `json:"care,omitempty"`
+	Price     float64    `json:"price,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Rating    int        `json:"rating,omitempty"`
+	Colors    []string   `json:"colors,omitempty"`
+}
+
+// OutfitMetadata is the metadata of one outfit in a history export.
+type OutfitMetadata struct {
+	Category string   `json:"category"`
+	FileName string   `json:"fileName"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// HistoryExport is the document history export writes. Its picks and wears
+// are oldest first, and its metadata is sorted by category and file name.
+type HistoryExport struct {
+	ExportedAt time.Time        `json:"exportedAt"`
+	Picks      []Pick           `json:"picks"`
+	Wears      []Wear           `json:"wears"`
+	Metadata   []OutfitMetadata `json:"metadata"`
+}
+
+// HistoryExportSummary is what history export reports after writing an
+// export to a file.
+type HistoryExportSummary struct {
+	Out   string `json:"out"`
+	Picks int    `json:"picks"`
+	Wears int    `json:"wears"`
+}
+
+// CommandRecord is a command run against the wardrobe.
+type CommandRecord struct {
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	RanAt    time.Time `json:"ranAt"`
+	ExitCode int       `json:"exitCode"`
+}
+
+// Undo is a wear taken back.
+type Undo struct {
+	Event Wear `json:"event"`
+	// RestoredRotation is set when the wear had completed its category's
+	// rotation, which was put back as it was.
+	RestoredRotation bool `json:"restoredRotation"`
+}
@@ -0,0 +1,101 @@
+package v1
+
+import "time"
+
+// CategoryDescription is what a category's category.json says about it.
+type CategoryDescription struct {
+	Description string   `json:"description,omitempty"`
+	Label       string   `json:"label,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Slot        string   `json:"slot,omitempty"`
+}
+
+// SkippedCategory is a category a pick left out because it has no outfits.
+type SkippedCategory struct {
+	Category CategoryRef `json:"category"`
+	// State is why the category was left out, such as "empty".
+	State       string `json:"state"`
+	OutfitCount int    `json:"outfitCount"`
+	// Description is nil when the category has no category.json.
+	Description *CategoryDescription `json:"description,omitempty"`
+}
+
+// AggregatePick is an outfit picked from more than one category, as pick
+// --all and pick --occasion report it.
+type AggregatePick struct {
+	Outfit  OutfitRef         `json:"outfit"`
+	Skipped []SkippedCategory `json:"skipped,omitempty"`
+	// Policy is the empty category policy the pick followed.
+	Policy string `json:"policy"`
+	// Resting lists the categories picked from too recently to be tried
+	// before the others.
+	Resting []string `json:"resting,omitempty"`
+}
+
+// EnsemblePiece is the outfit picked for one slot of an ensemble.
+type EnsemblePiece struct {
+	Slot   string    `json:"slot"`
+	Outfit OutfitRef `json:"outfit"`
+}
+
+// EnsemblePick is an outfit picked for every slot of an ensemble.
+type EnsemblePick struct {
+	Pieces []EnsemblePiece `json:"pieces"`
+}
+
+// RouletteVeto is an outfit vetoed during a roulette spin.
+type RouletteVeto struct {
+	FileName string `json:"fileName"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Roulette is the outfit a roulette spin settled on.
+type Roulette struct {
+	Outfit OutfitRef      `json:"outfit"`
+	Vetoes []RouletteVeto `json:"vetoes,omitempty"`
+}
+
+// RotationReset is a new rotation of a category, with the outfits worn in
+// the rotation it ends.
+type RotationReset struct {
+	Category string   `json:"category"`
+	Worn     []string `json:"worn"`
+	Total    int      `json:"total"`
+	// Profile is set when the rotation of another profile is reset.
+	Profile string `json:"profile,omitempty"`
+}
+
+// ScheduledPick is the time of day a pick is scheduled at.
+type ScheduledPick struct {
+	Hour    int      `json:"hour"`
+	Minute  int      `json:"minute"`
+	Command []string `json:"command"`
+}
+
+// Schedule is whether a daily pick is scheduled, and when.
+type Schedule struct {
+	Scheduled bool           `json:"scheduled"`
+	Pick      *ScheduledPick `json:"pick,omitempty"`
+}
+
+// OutfitCountChange is a change in how many outfits a category holds.
+type OutfitCountChange struct {
+	Category string `json:"category"`
+	Before   int    `json:"before"`
+	After    int    `json:"after"`
+}
+
+// PickWindow is when a scheduled pick was due.
+type PickWindow struct {
+	Due    time.Time `json:"due"`
+	Missed bool      `json:"missed"`
+}
+
+// WatchEvent is one line watch writes: a sync of the cache with the
+// wardrobe, a pick or a missed pick.
+type WatchEvent struct {
+	Event  string             `json:"event"`
+	Change *OutfitCountChange `json:"change,omitempty"`
+	Outfit *OutfitRef         `json:"outfit,omitempty"`
+	Window *PickWindow        `json:"window,omitempty"`
+}
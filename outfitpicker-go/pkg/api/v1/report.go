@@ -0,0 +1,241 @@
+package v1
+
+import "time"
+
+// OutfitWears counts the wears of one outfit.
+type OutfitWears struct {
+	Category string     `json:"category"`
+	FileName string     `json:"fileName"`
+	Wears    int        `json:"wears"`
+	LastWorn *time.Time `json:"lastWorn,omitempty"`
+}
+
+// CategoryWears counts the wears of every outfit in a category.
+type CategoryWears struct {
+	Category string `json:"category"`
+	Wears    int    `json:"wears"`
+}
+
+// LockedRotation is how far through its rotation a locked category is.
+type LockedRotation struct {
+	Category      string `json:"category"`
+	Worn          int    `json:"worn"`
+	Total         int    `json:"total"`
+	AwaitingReset bool   `json:"awaitingReset"`
+}
+
+// WearAnalytics summarizes the wear and pick history of a wardrobe, as
+// stats reports it.
+type WearAnalytics struct {
+	Outfits             []OutfitWears    `json:"outfits"`
+	MostWornCategory    *CategoryWears   `json:"mostWornCategory,omitempty"`
+	LeastWornCategory   *CategoryWears   `json:"leastWornCategory,omitempty"`
+	CompletedRotations  int              `json:"completedRotations"`
+	AverageRotationDays float64          `json:"averageRotationDays"`
+	PickStreakDays      int              `json:"pickStreakDays"`
+	LockedRotations     []LockedRotation `json:"lockedRotations,omitempty"`
+}
+
+// OutfitRating is the rating given to an outfit.
+type OutfitRating struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Rating   int    `json:"rating"`
+}
+
+// CategoryRatings is the average rating of a category's rated outfits.
+type CategoryRatings struct {
+	Category string  `json:"category"`
+	Rated    int     `json:"rated"`
+	Average  float64 `json:"average"`
+}
+
+// RatingsReport is the ratings of a wardrobe by category and by outfit.
+type RatingsReport struct {
+	Categories []CategoryRatings `json:"categories"`
+	Outfits    []OutfitRating    `json:"outfits"`
+}
+
+// CategoryGrowth counts a category's outfits at each point of a growth
+// report.
+type CategoryGrowth struct {
+	Category string `json:"category"`
+	Counts   []int  `json:"counts"`
+}
+
+// WardrobeGrowth is how many outfits the wardrobe held over time.
+type WardrobeGrowth struct {
+	Points     []time.Time      `json:"points"`
+	Categories []CategoryGrowth `json:"categories"`
+	Totals     []int            `json:"totals"`
+}
+
+// HeatmapDay counts the picks of one calendar day.
+type HeatmapDay struct {
+	Date  time.Time `json:"date"`
+	Picks int       `json:"picks"`
+}
+
+// PickHeatmap counts the picks of every day of a year.
+type PickHeatmap struct {
+	Year     int          `json:"year"`
+	Category string       `json:"category,omitempty"`
+	Days     []HeatmapDay `json:"days"`
+	MaxPicks int          `json:"maxPicks"`
+	Total    int          `json:"total"`
+}
+
+// CategoryCount counts something of a category, such as its picks.
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// CompletedRotation is a rotation of a category that was completed.
+type CompletedRotation struct {
+	Category    string    `json:"category"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// NeglectedOutfit is an outfit not worn for a long time, or never.
+type NeglectedOutfit struct {
+	Category string     `json:"category"`
+	FileName string     `json:"fileName"`
+	LastWorn *time.Time `json:"lastWorn,omitempty"`
+}
+
+// OutfitSpendPerWear is what each wear of a priced outfit has cost.
+type OutfitSpendPerWear struct {
+	Category      string  `json:"category"`
+	FileName      string  `json:"fileName"`
+	Price         float64 `json:"price"`
+	Wears         int     `json:"wears"`
+	PerWear       float64 `json:"perWear"`
+	WornThisMonth int     `json:"wornThisMonth"`
+}
+
+// MonthlyReport sums up a month of picks and wears.
+type MonthlyReport struct {
+	Month              time.Time            `json:"month"`
+	Picks              int                  `json:"picks"`
+	PicksByCategory    []CategoryCount      `json:"picksByCategory"`
+	Wears              int                  `json:"wears"`
+	CompletedRotations []CompletedRotation  `json:"completedRotations"`
+	Neglected          []NeglectedOutfit    `json:"neglected"`
+	NeglectedTotal     int                  `json:"neglectedTotal"`
+	SpendPerWear       []OutfitSpendPerWear `json:"spendPerWear"`
+}
+
+// ShoppingSuggestion is a category whose rotation completes too quickly,
+// with how many outfits to add.
+type ShoppingSuggestion struct {
+	Category      string  `json:"category"`
+	Outfits       int     `json:"outfits"`
+	WearsPerMonth float64 `json:"wearsPerMonth"`
+	RotationDays  float64 `json:"rotationDays"`
+	Suggested     int     `json:"suggested"`
+}
+
+// ChallengeOutfit counts the challenge wears of one capsule outfit.
+type ChallengeOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Wears    int    `json:"wears"`
+}
+
+// ChallengeReport sums up a capsule challenge, running or over.
+type ChallengeReport struct {
+	StartedAt   time.Time         `json:"startedAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+	Finished    bool              `json:"finished"`
+	EndedEarly  bool              `json:"endedEarly"`
+	Day         int               `json:"day"`
+	Days        int               `json:"days"`
+	Capsule     []ChallengeOutfit `json:"capsule"`
+	Wears       int               `json:"wears"`
+	WornOutfits int               `json:"wornOutfits"`
+	DaysWorn    int               `json:"daysWorn"`
+}
+
+// PlannedOutfitStatus is a planned outfit and whether it can still be worn
+// as planned.
+type PlannedOutfitStatus struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Status   string `json:"status"`
+}
+
+// PlanDayStatus is a day of a plan with the status of each of its outfits.
+type PlanDayStatus struct {
+	Date    time.Time             `json:"date"`
+	Worn    bool                  `json:"worn"`
+	Outfits []PlannedOutfitStatus `json:"outfits"`
+}
+
+// PlanReport is a plan checked against the wardrobe and the rotation.
+type PlanReport struct {
+	CreatedAt time.Time       `json:"createdAt"`
+	Days      []PlanDayStatus `json:"days"`
+	Conflicts int             `json:"conflicts"`
+}
+
+// PlanExportSummary is what plan export reports after writing the plan to
+// a file.
+type PlanExportSummary struct {
+	Out    string `json:"out"`
+	Format string `json:"format"`
+	Events int    `json:"events"`
+}
+
+// HealthComponents are the parts of a health score, each between 0 and 1
+// where 1 is healthy.
+type HealthComponents struct {
+	Progress  float64 `json:"progress"`
+	Staleness float64 `json:"staleness"`
+	WearRate  float64 `json:"wearRate"`
+	Laundry   float64 `json:"laundry"`
+}
+
+// CategoryHealth is a category's health score, out of 100, with the reasons
+// it is not higher.
+type CategoryHealth struct {
+	Score      int              `json:"score"`
+	Status     string           `json:"status"`
+	Components HealthComponents `json:"components"`
+	Reasons    []string         `json:"reasons,omitempty"`
+}
+
+// WearDiscrepancy is an outfit the rotation cache and the wear log
+// disagree about.
+type WearDiscrepancy struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Kind     string `json:"kind"`
+}
+
+// Doctor is what doctor finds: the health of each category by name and
+// where the rotation cache and the wear log disagree.
+type Doctor struct {
+	Health        map[string]CategoryHealth `json:"health"`
+	Discrepancies []WearDiscrepancy         `json:"discrepancies"`
+	// RepairedFrom is the record, "history" or "cache", trusted to repair
+	// the disagreements, when they were repaired.
+	RepairedFrom string `json:"repairedFrom,omitempty"`
+}
+
+// CategoryRepair is how repair fixes, or would fix, a category's rotation.
+type CategoryRepair struct {
+	Category     string   `json:"category"`
+	Removed      bool     `json:"removed,omitempty"`
+	TotalBefore  int      `json:"totalBefore"`
+	TotalAfter   int      `json:"totalAfter"`
+	DroppedWorn  []string `json:"droppedWorn,omitempty"`
+	AddedKnown   []string `json:"addedKnown,omitempty"`
+	RemovedKnown []string `json:"removedKnown,omitempty"`
+}
+
+// Repair is the repairs of every category, and whether they were applied.
+type Repair struct {
+	Categories []CategoryRepair `json:"categories"`
+	Applied    bool             `json:"applied"`
+}
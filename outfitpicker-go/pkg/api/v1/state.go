@@ -0,0 +1,195 @@
+package v1
+
+import "time"
+
+// Backup is a timestamped copy of the config and cache files.
+type Backup struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Files     []string  `json:"files"`
+}
+
+// BackupCreated is a new backup and the old backups removed to make room
+// for it.
+type BackupCreated struct {
+	Backup  Backup   `json:"backup"`
+	Removed []Backup `json:"removed,omitempty"`
+}
+
+// BackupRestored is a restored backup and the backup of the state it
+// replaced.
+type BackupRestored struct {
+	Restored Backup `json:"restored"`
+	Previous Backup `json:"previous"`
+}
+
+// BundleManifest describes a state bundle.
+type BundleManifest struct {
+	Format    int       `json:"format"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Version is the version of outfitpicker that wrote the bundle.
+	Version string   `json:"version"`
+	Files   []string `json:"files"`
+}
+
+// BundleImportProposal is a state bundle read and checked, ready to
+// replace the current state.
+type BundleImportProposal struct {
+	Manifest BundleManifest `json:"manifest"`
+	// Roots are the wardrobe roots of the bundled configuration.
+	Roots []string `json:"roots"`
+	// MissingRoots lists the roots that do not exist on this machine.
+	MissingRoots []string `json:"missingRoots,omitempty"`
+}
+
+// BundleImport is a state bundle that replaced the current state.
+type BundleImport struct {
+	BundleImportProposal
+	// Previous is the backup of the state the bundle replaced, nil when
+	// there was no configuration to back up.
+	Previous *Backup `json:"previous,omitempty"`
+}
+
+// PackOutfit is an outfit of an outfit pack.
+type PackOutfit struct {
+	FileName string    `json:"fileName"`
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// PackManifest describes an outfit pack exported from a category.
+type PackManifest struct {
+	Format    int               `json:"format"`
+	Category  string            `json:"category"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Outfits   []PackOutfit      `json:"outfits"`
+}
+
+// ImportedOutfit is an archive entry imported as an outfit.
+type ImportedOutfit struct {
+	Entry    string `json:"entry"`
+	FileName string `json:"fileName"`
+	// Renamed is set when the file name was taken and the outfit was
+	// given another.
+	Renamed bool `json:"renamed,omitempty"`
+}
+
+// ArchiveImport is an archive imported into a category.
+type ArchiveImport struct {
+	Category string           `json:"category"`
+	Imported []ImportedOutfit `json:"imported"`
+	Skipped  []SkippedEntry   `json:"skipped,omitempty"`
+	// Outfits is the category's outfit count after the import.
+	Outfits int `json:"outfits"`
+}
+
+// MetadataImportRow is a spreadsheet row that matched an outfit.
+type MetadataImportRow struct {
+	Line     int      `json:"line"`
+	Category string   `json:"category"`
+	FileName string   `json:"fileName"`
+	Tags     []string `json:"tags,omitempty"`
+	Weight   *float64 `json:"weight,omitempty"`
+	Rating   int      `json:"rating,omitempty"`
+}
+
+// CSVImportSkip is a spreadsheet row that matched no outfit.
+type CSVImportSkip struct {
+	Line     int    `json:"line"`
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	Reason   string `json:"reason"`
+}
+
+// CSVImport is a metadata import from a spreadsheet.
+type CSVImport struct {
+	Rows    []MetadataImportRow `json:"rows"`
+	Skipped []CSVImportSkip     `json:"skipped,omitempty"`
+}
+
+// IntegrityStatus is whether state files are signed and those failing
+// their integrity check.
+type IntegrityStatus struct {
+	Enabled bool     `json:"enabled"`
+	Failed  []string `json:"failed"`
+}
+
+// IntegrityChange is what an integrity command changed.
+type IntegrityChange struct {
+	Enabled bool `json:"enabled"`
+	Changed bool `json:"changed"`
+	// Accepted lists the state files whose changes were accepted.
+	Accepted []string `json:"accepted,omitempty"`
+}
+
+// Profile is a named profile and whether it is the active one.
+type Profile struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// ProfileChange is a profile just created, switched to or removed, with
+// the active profile after the change.
+type ProfileChange struct {
+	Profile string `json:"profile"`
+	Active  string `json:"active"`
+}
+
+// Setup is the configuration written by setup.
+type Setup struct {
+	ConfigPath string `json:"configPath"`
+	Created    bool   `json:"created"`
+	Changed    bool   `json:"changed"`
+}
+
+// OnboardingStep is one next step shown to a first-time user.
+type OnboardingStep struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Command     string `json:"command,omitempty"`
+}
+
+// Onboarding tells a first-time user, or a script acting for one, how to
+// get started.
+type Onboarding struct {
+	Status     string           `json:"status"`
+	ConfigPath string           `json:"configPath"`
+	Steps      []OnboardingStep `json:"steps"`
+}
+
+// StateChange is a change a command would make to a state file.
+type StateChange struct {
+	Store  string `json:"store"`
+	Change string `json:"change"`
+}
+
+// DryRun is what a command run with --dry-run would have done.
+type DryRun[T any] struct {
+	DryRun bool `json:"dryRun"`
+	// Result is the command's output as it would have been.
+	Result  T             `json:"result"`
+	Changes []StateChange `json:"changes"`
+}
+
+// ProgressEvent reports the progress of a long operation.
+type ProgressEvent struct {
+	Operation string `json:"operation"`
+	// Phase is one of "start", "step" and "done".
+	Phase   string `json:"phase"`
+	Current int    `json:"current"`
+	Total   int    `json:"total,omitempty"`
+	Item    string `json:"item,omitempty"`
+}
+
+// Error is a failed command, as written to standard error.
+type Error struct {
+	Error string `json:"error"`
+	// Code is the command's exit code.
+	Code int `json:"code"`
+	// RetryAt is when a pick refused by a daily pick limit is allowed
+	// again.
+	RetryAt time.Time `json:"retryAt,omitzero"`
+	// Candidates are the places a configuration was found in when more
+	// than one was.
+	Candidates []string `json:"candidates,omitempty"`
+}
@@ -0,0 +1,65 @@
+// Package v1 holds version 1 of the shapes outfitpicker writes for other
+// programs: the --json output of its commands, its exports and the types of
+// the Go library. They are kept apart from the application's internal
+// types, which may change from one release to the next, so the serialized
+// shapes stay as they are for as long as version 1 is written. A change
+// that would break them belongs in a new version.
+package v1
+
+import "time"
+
+// Version is the version of the shapes in this package.
+const Version = 1
+
+// CategoryRef names a category directory of the wardrobe.
+type CategoryRef struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// OutfitRef is an outfit file in a category, as commands such as pick and
+// watch report it.
+type OutfitRef struct {
+	FileName string      `json:"fileName"`
+	Category CategoryRef `json:"category"`
+}
+
+// Category is a category directory of the wardrobe, as the Go library
+// lists it.
+type Category struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// State is one of the category states of the Go library.
+	State       string `json:"state"`
+	OutfitCount int    `json:"outfitCount"`
+	// Label, Description, Tags and Slot come from the category's
+	// category.json, and are empty when it has none.
+	Label       string   `json:"label,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Slot        string   `json:"slot,omitempty"`
+}
+
+// Outfit is an outfit file in a category, as the Go library returns it.
+type Outfit struct {
+	FileName string `json:"fileName"`
+	Category string `json:"category"`
+	// Path is the full path of the outfit file.
+	Path string `json:"path"`
+}
+
+// Progress is how far a category is through its current rotation.
+type Progress struct {
+	Category string `json:"category"`
+	Worn     int    `json:"worn"`
+	Total    int    `json:"total"`
+}
+
+// Event is a pick, or a wear that completed a category's rotation.
+type Event struct {
+	// Kind is one of the event kinds of the Go library.
+	Kind     string    `json:"kind"`
+	Category string    `json:"category"`
+	FileName string    `json:"fileName"`
+	At       time.Time `json:"at"`
+}
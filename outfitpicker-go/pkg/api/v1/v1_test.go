@@ -0,0 +1,175 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestShapes locks the serialized shapes of version 1. A failure here means
+// a change would break programs reading outfitpicker's output: make it in a
+// new version instead.
+func TestShapes(t *testing.T) {
+	at := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{
+			name:  "outfit ref",
+			value: OutfitRef{FileName: "tee.avatar", Category: CategoryRef{Name: "casual", Path: "/w/casual"}},
+			want:  `{"fileName":"tee.avatar","category":{"name":"casual","path":"/w/casual"}}`,
+		},
+		{
+			name: "aggregate pick",
+			value: AggregatePick{
+				Outfit: OutfitRef{FileName: "tee.avatar", Category: CategoryRef{Name: "casual", Path: "/w/casual"}},
+				Skipped: []SkippedCategory{{
+					Category:    CategoryRef{Name: "gym", Path: "/w/gym"},
+					State:       "empty",
+					Description: &CategoryDescription{Label: "Gym", Tags: []string{"sport"}},
+				}},
+				Policy:  "warn",
+				Resting: []string{"work"},
+			},
+			want: `{"outfit":{"fileName":"tee.avatar","category":{"name":"casual","path":"/w/casual"}},` +
+				`"skipped":[{"category":{"name":"gym","path":"/w/gym"},"state":"empty","outfitCount":0,"description":{"label":"Gym","tags":["sport"]}}],` +
+				`"policy":"warn","resting":["work"]}`,
+		},
+		{
+			name:  "aggregate pick, nothing skipped",
+			value: AggregatePick{Policy: "skip"},
+			want:  `{"outfit":{"fileName":"","category":{"name":"","path":""}},"policy":"skip"}`,
+		},
+		{
+			name:  "category",
+			value: Category{Name: "casual", Path: "/w/casual", State: "hasOutfits", OutfitCount: 3, Label: "Casual", Tags: []string{"weekend"}},
+			want:  `{"name":"casual","path":"/w/casual","state":"hasOutfits","outfitCount":3,"label":"Casual","tags":["weekend"]}`,
+		},
+		{
+			name:  "outfit",
+			value: Outfit{FileName: "tee.avatar", Category: "casual", Path: "/w/casual/tee.avatar"},
+			want:  `{"fileName":"tee.avatar","category":"casual","path":"/w/casual/tee.avatar"}`,
+		},
+		{
+			name:  "progress",
+			value: Progress{Category: "casual", Worn: 1, Total: 3},
+			want:  `{"category":"casual","worn":1,"total":3}`,
+		},
+		{
+			name:  "event",
+			value: Event{Kind: "pick", Category: "casual", FileName: "tee.avatar", At: at},
+			want:  `{"kind":"pick","category":"casual","fileName":"tee.avatar","at":"2024-03-01T09:30:00Z"}`,
+		},
+		{
+			name:  "pick page",
+			value: Page[Pick]{Items: []Pick{{Category: "casual", FileName: "tee.avatar", SelectedAt: at, OutfitID: "abc"}}, Page: 1, Limit: 20, Total: 1},
+			want:  `{"items":[{"category":"casual","fileName":"tee.avatar","selectedAt":"2024-03-01T09:30:00Z","outfitId":"abc"}],"page":1,"limit":20,"total":1}`,
+		},
+		{
+			name:  "empty wear page",
+			value: Page[Wear]{Items: []Wear{}, Page: 1, Limit: 20},
+			want:  `{"items":[],"page":1,"limit":20,"total":0}`,
+		},
+		{
+			name:  "wear",
+			value: Wear{Category: "casual", FileName: "tee.avatar", WornAt: at, Feedback: []string{"compliment"}, Note: "lunch", CompletedRotation: true},
+			want:  `{"category":"casual","fileName":"tee.avatar","wornAt":"2024-03-01T09:30:00Z","feedback":["compliment"],"note":"lunch","completedRotation":true}`,
+		},
+		{
+			name: "history export",
+			value: HistoryExport{
+				ExportedAt: at,
+				Picks:      []Pick{},
+				Wears:      []Wear{},
+				Metadata: []OutfitMetadata{{
+					Category: "casual",
+					FileName: "tee.avatar",
+					Metadata: Metadata{Materials: []Material{{Fiber: "cotton", Percent: 100}}, Care: []string{"cold wash"}, Price: 20, Tags: []string{"summer"}, Rating: 4, Colors: []string{"blue"}},
+				}},
+			},
+			want: `{"exportedAt":"2024-03-01T09:30:00Z","picks":[],"wears":[],"metadata":[{"category":"casual","fileName":"tee.avatar",` +
+				`"metadata":{"materials":[{"fiber":"cotton","percent":100}],"care":["cold wash"],"price":20,"tags":["summer"],"rating":4,"colors":["blue"]}}]}`,
+		},
+		{
+			name:  "empty metadata",
+			value: OutfitMetadata{Category: "casual", FileName: "tee.avatar"},
+			want:  `{"category":"casual","fileName":"tee.avatar","metadata":{}}`,
+		},
+		{
+			name: "cache export",
+			value: CacheExport{Version: 1, CreatedAt: at, Categories: []CategoryCache{
+				{Path: "/w/casual", TotalOutfits: 3, WornOutfits: []string{"tee.avatar"}, LastUpdated: at},
+			}},
+			want: `{"version":1,"createdAt":"2024-03-01T09:30:00Z","categories":[{"path":"/w/casual","totalOutfits":3,"wornOutfits":["tee.avatar"],"lastUpdated":"2024-03-01T09:30:00Z"}]}`,
+		},
+		{
+			name:  "dry run",
+			value: DryRun[RotationReset]{DryRun: true, Result: RotationReset{Category: "casual", Worn: []string{"tee.avatar"}, Total: 3}, Changes: []StateChange{{Store: "cache", Change: "reset casual"}}},
+			want:  `{"dryRun":true,"result":{"category":"casual","worn":["tee.avatar"],"total":3},"changes":[{"store":"cache","change":"reset casual"}]}`,
+		},
+		{
+			name:  "error",
+			value: Error{Error: "no outfits", Code: 3},
+			want:  `{"error":"no outfits","code":3}`,
+		},
+		{
+			name:  "watch sync event",
+			value: WatchEvent{Event: "sync", Change: &OutfitCountChange{Category: "casual", Before: 3, After: 4}},
+			want:  `{"event":"sync","change":{"category":"casual","before":3,"after":4}}`,
+		},
+		{
+			name:  "roulette",
+			value: Roulette{Outfit: OutfitRef{FileName: "tee.avatar", Category: CategoryRef{Name: "casual", Path: "/w/casual"}}, Vetoes: []RouletteVeto{{FileName: "shirt.avatar"}}},
+			want:  `{"outfit":{"fileName":"tee.avatar","category":{"name":"casual","path":"/w/casual"}},"vetoes":[{"fileName":"shirt.avatar"}]}`,
+		},
+		{
+			name:  "outfit details",
+			value: OutfitDetails{Metadata: Metadata{Rating: 4}, Sidecar: &Sidecar{Tags: []string{"summer"}}},
+			want:  `{"rating":4,"sidecar":{"tags":["summer"]}}`,
+		},
+		{
+			name:  "weights",
+			value: Weights{"casual": {"tee.avatar": 2}},
+			want:  `{"casual":{"tee.avatar":2}}`,
+		},
+		{
+			name: "category removal",
+			value: CategoryRemoval{
+				CategoryRemovalProposal: CategoryRemovalProposal{Category: CategoryRef{Name: "gym", Path: "/w/gym"}, Files: "leave", Outfits: 2, References: CategoryReferences{Picks: 1}},
+				FilesPath:               "/w/gym",
+				Previous:                Backup{ID: "b1", CreatedAt: at, Files: []string{"config.json"}},
+			},
+			want: `{"category":{"name":"gym","path":"/w/gym"},"files":"leave","outfits":2,` +
+				`"references":{"picks":1,"wears":0,"metadata":0,"weights":0,"favorites":0,"skipped":0,"laundry":0,"arrivals":0,"planned":0,"capsule":0,"lastPicked":false,"latestPick":false},` +
+				`"filesPath":"/w/gym","previous":{"id":"b1","createdAt":"2024-03-01T09:30:00Z","files":["config.json"]}}`,
+		},
+		{
+			name:  "doctor",
+			value: Doctor{Health: map[string]CategoryHealth{"casual": {Score: 90, Status: "healthy", Components: HealthComponents{Progress: 1}}}, Discrepancies: []WearDiscrepancy{}},
+			want:  `{"health":{"casual":{"score":90,"status":"healthy","components":{"progress":1,"staleness":0,"wearRate":0,"laundry":0}}},"discrepancies":[]}`,
+		},
+		{
+			name:  "schedule",
+			value: Schedule{Scheduled: true, Pick: &ScheduledPick{Hour: 7, Minute: 30, Command: []string{"outfitpicker", "pick"}}},
+			want:  `{"scheduled":true,"pick":{"hour":7,"minute":30,"command":["outfitpicker","pick"]}}`,
+		},
+		{
+			name:  "progress event",
+			value: ProgressEvent{Operation: "history export", Phase: "step", Current: 2, Total: 10},
+			want:  `{"operation":"history export","phase":"step","current":2,"total":10}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("json.Marshal() =\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package v1
+
+import "time"
+
+// OutfitLocation is an outfit file by category and file name.
+type OutfitLocation struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+}
+
+// SkippedEntry is an entry of an archive or directory that was not taken,
+// with the reason.
+type SkippedEntry struct {
+	Entry  string `json:"entry"`
+	Reason string `json:"reason"`
+}
+
+// CategoryCreated is a category directory created in the wardrobe.
+type CategoryCreated struct {
+	Category string `json:"category"`
+	Path     string `json:"path"`
+	// Seeded lists the outfits copied or imported into the category.
+	Seeded []string `json:"seeded,omitempty"`
+	// Skipped lists the entries of the pack that were not outfits.
+	Skipped []SkippedEntry `json:"skipped,omitempty"`
+}
+
+// CategoryReferences counts what the state files record about a category.
+type CategoryReferences struct {
+	Picks      int  `json:"picks"`
+	Wears      int  `json:"wears"`
+	Metadata   int  `json:"metadata"`
+	Weights    int  `json:"weights"`
+	Favorites  int  `json:"favorites"`
+	Skipped    int  `json:"skipped"`
+	Laundry    int  `json:"laundry"`
+	Arrivals   int  `json:"arrivals"`
+	Planned    int  `json:"planned"`
+	Capsule    int  `json:"capsule"`
+	LastPicked bool `json:"lastPicked"`
+	LatestPick bool `json:"latestPick"`
+}
+
+// CategoryRemovalProposal is a category removal worked out but not yet
+// made, as category remove --dry-run reports it.
+type CategoryRemovalProposal struct {
+	Category CategoryRef `json:"category"`
+	// Files is what happens to the category's directory: "leave",
+	// "archive" or "trash".
+	Files   string `json:"files"`
+	Outfits int    `json:"outfits"`
+	// Settings names the configuration settings dropped with the category.
+	Settings   []string           `json:"settings,omitempty"`
+	References CategoryReferences `json:"references"`
+}
+
+// CategoryRemoval is a category taken out of the wardrobe.
+type CategoryRemoval struct {
+	CategoryRemovalProposal
+	// FilesPath is where the category's files are now: its directory when
+	// they were left, or the directory they were archived or trashed to.
+	FilesPath string `json:"filesPath"`
+	// Previous is the backup of the configuration and cache taken before
+	// the removal.
+	Previous Backup `json:"previous"`
+}
+
+// CategoryExclusion is a category left out of picks, until a time or for
+// good.
+type CategoryExclusion struct {
+	Category string    `json:"category"`
+	Until    time.Time `json:"until,omitzero"`
+}
+
+// Exclusion is a category just excluded.
+type Exclusion struct {
+	Excluded CategoryExclusion `json:"excluded"`
+}
+
+// CategoryOrder is how categories are ordered in listings.
+type CategoryOrder struct {
+	Sort   string   `json:"sort,omitempty"`
+	Manual []string `json:"manual,omitempty"`
+}
+
+// SeasonAssignments are the seasons categories and tags are worn in.
+type SeasonAssignments struct {
+	Categories map[string][]string `json:"categories,omitempty"`
+	Tags       map[string][]string `json:"tags,omitempty"`
+	Southern   bool                `json:"southern,omitempty"`
+}
+
+// Seasons is the current season and the season assignments.
+type Seasons struct {
+	Current string            `json:"current"`
+	Seasons SeasonAssignments `json:"seasons"`
+}
+
+// Occasion is a named set of categories and tags to pick from.
+type Occasion struct {
+	Categories []string `json:"categories,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// EnsembleRules are the slots of an ensemble and the rules its pieces
+// follow.
+type EnsembleRules struct {
+	Slots        map[string]string `json:"slots,omitempty"`
+	TagConflicts [][2]string       `json:"tagConflicts,omitempty"`
+	ColorClashes [][2]string       `json:"colorClashes,omitempty"`
+	MaxColors    int               `json:"maxColors,omitempty"`
+	ColorRules   []string          `json:"colorRules,omitempty"`
+}
+
+// Ensemble is the categories of each slot and the ensemble rules.
+type Ensemble struct {
+	Slots    map[string][]string `json:"slots"`
+	Ensemble EnsembleRules       `json:"ensemble"`
+}
+
+// Favorites lists the favorite outfits of each category by file name.
+type Favorites map[string][]string
+
+// Weights holds the pick weight of outfits by category and file name.
+type Weights map[string]map[string]float64
+
+// OutfitTags is the tags of one outfit.
+type OutfitTags struct {
+	Category string   `json:"category"`
+	FileName string   `json:"fileName"`
+	Tags     []string `json:"tags"`
+}
+
+// Sidecar is what an outfit's sidecar file says about it.
+type Sidecar struct {
+	Tags    []string `json:"tags,omitempty"`
+	Colors  []string `json:"colors,omitempty"`
+	Seasons []string `json:"seasons,omitempty"`
+	Weight  *float64 `json:"weight,omitempty"`
+	Notes   string   `json:"notes,omitempty"`
+}
+
+// OutfitDetails is an outfit's metadata, with its sidecar file when it has
+// one.
+type OutfitDetails struct {
+	Metadata
+	Sidecar *Sidecar `json:"sidecar,omitempty"`
+}
+
+// SkippedOutfit is an outfit left out of picks, until a time or for good.
+type SkippedOutfit struct {
+	Category string    `json:"category"`
+	FileName string    `json:"fileName"`
+	Until    time.Time `json:"until,omitzero"`
+}
+
+// Skip is an outfit just skipped.
+type Skip struct {
+	Skipped SkippedOutfit `json:"skipped"`
+}
+
+// LaundryOutfit is an outfit in the laundry, with its state such as
+// "dirty".
+type LaundryOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	State    string `json:"state"`
+}
+
+// LaundryStatus is whether laundry tracking is on and the outfits in the
+// laundry.
+type LaundryStatus struct {
+	Enabled bool            `json:"enabled"`
+	Outfits []LaundryOutfit `json:"outfits"`
+}
+
+// WashProgram is the set of care settings shared by one load.
+type WashProgram struct {
+	Method      string `json:"method"`
+	Temperature int    `json:"temperature,omitempty"`
+	TumbleDry   bool   `json:"tumbleDry"`
+}
+
+// WashLoad is a group of outfits that can be washed together.
+type WashLoad struct {
+	Program WashProgram `json:"program"`
+	Outfits []OutfitRef `json:"outfits"`
+}
+
+// LaundryReport is the dirty outfits sorted into loads, and those without
+// care symbols to sort them by.
+type LaundryReport struct {
+	Loads    []WashLoad  `json:"loads"`
+	Unsorted []OutfitRef `json:"unsorted"`
+}
+
+// OutfitSighting is when an outfit was first and last seen in the
+// wardrobe.
+type OutfitSighting struct {
+	Category  string    `json:"category"`
+	FileName  string    `json:"fileName"`
+	FirstSeen time.Time `json:"firstSeen,omitzero"`
+	LastSeen  time.Time `json:"lastSeen,omitzero"`
+	Present   bool      `json:"present"`
+}
+
+// NewOutfit is an outfit that arrived since the last triage.
+type NewOutfit struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+}
+
+// OutfitWorn is an outfit marked worn.
+type OutfitWorn struct {
+	Category string `json:"category"`
+	FileName string `json:"fileName"`
+	// RotationCompleted is set when the outfit was the last unworn one of
+	// its category.
+	RotationCompleted bool `json:"rotationCompleted,omitempty"`
+}
+
+// OutfitFeedback is the feedback given for an outfit's wears.
+type OutfitFeedback struct {
+	Outfit         OutfitRef  `json:"outfit"`
+	Events         []Wear     `json:"events"`
+	Score          int        `json:"score"`
+	LastCompliment *time.Time `json:"lastCompliment,omitempty"`
+}
+
+// OutfitIDResolution is the outfit a stable outfit ID stands for.
+type OutfitIDResolution struct {
+	ID       string         `json:"id"`
+	Location OutfitLocation `json:"location"`
+}
+
+// OutfitMove is an outfit found under a new location by its ID.
+type OutfitMove struct {
+	ID   string         `json:"id"`
+	From OutfitLocation `json:"from"`
+	To   OutfitLocation `json:"to"`
+}
+
+// OutfitIDSync is what syncing the outfit IDs with the wardrobe did.
+type OutfitIDSync struct {
+	Scheme   string       `json:"scheme"`
+	Outfits  int          `json:"outfits"`
+	Assigned int          `json:"assigned"`
+	Moves    []OutfitMove `json:"moves,omitempty"`
+	Linked   int          `json:"linked"`
+}
+
+// WeatherLocation is where forecasts are fetched for.
+type WeatherLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Forecast is the weather forecast for a day.
+type Forecast struct {
+	Location            WeatherLocation `json:"location"`
+	Date                time.Time       `json:"date"`
+	MinTemperature      float64         `json:"minTemperature"`
+	MaxTemperature      float64         `json:"maxTemperature"`
+	PrecipitationChance int             `json:"precipitationChance"`
+	FetchedAt           time.Time       `json:"fetchedAt"`
+}
+
+// WeatherReport is today's forecast with the conditions it matches, such
+// as "cold" or "rain".
+type WeatherReport struct {
+	Forecast   Forecast `json:"forecast"`
+	Conditions []string `json:"conditions"`
+}
+
+// WeatherSettings are the location forecasts are fetched for and the
+// categories and tags worn in each condition.
+type WeatherSettings struct {
+	Location   *WeatherLocation    `json:"location,omitempty"`
+	Categories map[string][]string `json:"categories,omitempty"`
+	Tags       map[string][]string `json:"tags,omitempty"`
+}
+
+// Weather is today's weather report, when a location is set, and the
+// weather settings.
+type Weather struct {
+	Today   *WeatherReport  `json:"today,omitempty"`
+	Weather WeatherSettings `json:"weather"`
+}
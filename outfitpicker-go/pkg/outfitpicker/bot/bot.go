@@ -0,0 +1,116 @@
+// Package bot answers chat slash commands such as "/outfit pick casual"
+// from Slack and Discord, and serves an Atom feed of the picks. Picks are
+// made through outfitpicker clients, so they share one rotation with the
+// command and other programs.
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dh85/outfitpicker/pkg/outfitpicker"
+)
+
+// Wardrobe is what the bot uses of an outfitpicker.Client.
+type Wardrobe interface {
+	PickContext(ctx context.Context, category string, opts ...outfitpicker.PickOption) (outfitpicker.Outfit, error)
+	ProgressContext(ctx context.Context) ([]outfitpicker.Progress, error)
+	EventsContext(ctx context.Context, limit int) ([]outfitpicker.Event, error)
+}
+
+// OpenFunc opens the wardrobe of the named profile; an empty name means the
+// active profile.
+type OpenFunc func(profile string) (Wardrobe, error)
+
+// usage is the reply to a command the bot does not understand.
+const usage = "Try: pick <category>, or progress"
+
+// Bot answers slash commands with the wardrobe of the profile mapped to the
+// channel each command came from. It is safe for concurrent use.
+type Bot struct {
+	open     OpenFunc
+	channels map[string]string
+
+	mu        sync.Mutex
+	wardrobes map[string]Wardrobe
+}
+
+// New creates a bot. channels maps channel IDs to profile names; commands
+// from other channels use the active profile.
+func New(open OpenFunc, channels map[string]string) *Bot {
+	return &Bot{open: open, channels: channels, wardrobes: make(map[string]Wardrobe)}
+}
+
+// Respond answers the text typed after the slash command in channel, such
+// as "pick casual". Failures are answered too, as the bot has nowhere else
+// to report them.
+func (b *Bot) Respond(ctx context.Context, channel, text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return usage
+	}
+	wardrobe, err := b.wardrobe(channel)
+	if err != nil {
+		return fmt.Sprintf("Could not open the wardrobe: %v", err)
+	}
+	switch {
+	case fields[0] == "pick" && len(fields) == 2:
+		return pick(ctx, wardrobe, fields[1])
+	case fields[0] == "progress" && len(fields) == 1:
+		return progress(ctx, wardrobe)
+	default:
+		return usage
+	}
+}
+
+// wardrobe returns the wardrobe of the profile mapped to channel, opening
+// it on first use.
+func (b *Bot) wardrobe(channel string) (Wardrobe, error) {
+	profile := b.channels[channel]
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if wardrobe, ok := b.wardrobes[profile]; ok {
+		return wardrobe, nil
+	}
+	wardrobe, err := b.open(profile)
+	if err != nil {
+		return nil, err
+	}
+	b.wardrobes[profile] = wardrobe
+	return wardrobe, nil
+}
+
+func pick(ctx context.Context, wardrobe Wardrobe, category string) string {
+	outfit, err := wardrobe.PickContext(ctx, category)
+	if err != nil {
+		return fmt.Sprintf("Could not pick from %s: %v", category, err)
+	}
+	reply := fmt.Sprintf("Picked %s/%s", outfit.Category, outfit.FileName)
+	rotations, err := wardrobe.ProgressContext(ctx)
+	if err != nil {
+		return reply
+	}
+	for _, rotation := range rotations {
+		if rotation.Category == outfit.Category {
+			return fmt.Sprintf("%s (%d of %d worn this rotation)", reply, rotation.Worn, rotation.Total)
+		}
+	}
+	return reply
+}
+
+func progress(ctx context.Context, wardrobe Wardrobe) string {
+	rotations, err := wardrobe.ProgressContext(ctx)
+	if err != nil {
+		return fmt.Sprintf("Could not read the rotations: %v", err)
+	}
+	if len(rotations) == 0 {
+		return "No categories have outfits yet."
+	}
+	lines := make([]string, len(rotations))
+	for i, rotation := range rotations {
+		lines[i] = fmt.Sprintf("%s: %d of %d worn", rotation.Category, rotation.Worn, rotation.Total)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/pkg/outfitpicker"
+)
+
+// fakeWardrobe picks the first outfit of a category and reports its worn
+// count.
+type fakeWardrobe struct {
+	outfits map[string][]string
+	worn    map[string]int
+	events  []outfitpicker.Event
+}
+
+func (w *fakeWardrobe) PickContext(ctx context.Context, category string, opts ...outfitpicker.PickOption) (outfitpicker.Outfit, error) {
+	files := w.outfits[category]
+	if len(files) == 0 {
+		return outfitpicker.Outfit{}, errors.New("category not found")
+	}
+	w.worn[category]++
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC).AddDate(0, 0, len(w.events))
+	w.events = append([]outfitpicker.Event{{Kind: outfitpicker.EventPick, Category: category, FileName: files[0], At: at}}, w.events...)
+	return outfitpicker.Outfit{Category: category, FileName: files[0]}, nil
+}
+
+func (w *fakeWardrobe) ProgressContext(ctx context.Context) ([]outfitpicker.Progress, error) {
+	var progress []outfitpicker.Progress
+	for category, files := range w.outfits {
+		progress = append(progress, outfitpicker.Progress{Category: category, Worn: w.worn[category], Total: len(files)})
+	}
+	return progress, nil
+}
+
+func (w *fakeWardrobe) EventsContext(ctx context.Context, limit int) ([]outfitpicker.Event, error) {
+	if limit < 0 {
+		return nil, errors.New("limit must be between 1 and 500")
+	}
+	return w.events, nil
+}
+
+// newTestBot returns a bot whose every profile has the same wardrobe, and
+// the profiles it opened.
+func newTestBot(channels map[string]string) (*Bot, *[]string) {
+	var opened []string
+	wardrobe := &fakeWardrobe{outfits: map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}}, worn: make(map[string]int)}
+	return New(func(profile string) (Wardrobe, error) {
+		opened = append(opened, profile)
+		return wardrobe, nil
+	}, channels), &opened
+}
+
+func TestBot_Respond(t *testing.T) {
+	b, _ := newTestBot(nil)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"pick casual", "Picked casual/tee.avatar (1 of 2 worn this rotation)"},
+		{"progress", "casual: 1 of 2 worn"},
+		{"pick formal", "Could not pick from formal: category not found"},
+		{"", usage},
+		{"dance", usage},
+		{"pick", usage},
+	}
+	for _, tt := range tests {
+		if got := b.Respond(context.Background(), "C1", tt.text); got != tt.want {
+			t.Errorf("Respond(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestBot_MapsChannelsToProfiles(t *testing.T) {
+	b, opened := newTestBot(map[string]string{"C-work": "work"})
+	for _, channel := range []string{"C-work", "C-work", "C-home"} {
+		if got := b.Respond(context.Background(), channel, "pick casual"); !strings.HasPrefix(got, "Picked") {
+			t.Fatalf("Respond() in %s = %q", channel, got)
+		}
+	}
+	if got := strings.Join(*opened, ","); got != "work," {
+		t.Errorf("opened profiles %q, want work once and then the active profile", got)
+	}
+}
+
+func TestBot_OpenError(t *testing.T) {
+	b := New(func(string) (Wardrobe, error) { return nil, errors.New("profile travel does not exist") }, nil)
+	if got := b.Respond(context.Background(), "C1", "pick casual"); !strings.Contains(got, "profile travel does not exist") {
+		t.Errorf("Respond() = %q, want the open error", got)
+	}
+}
@@ -0,0 +1,136 @@
+package bot
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Discord interaction and interaction response types.
+const (
+	discordPing               = 1
+	discordApplicationCommand = 2
+
+	discordPong                     = 1
+	discordChannelMessageWithSource = 4
+)
+
+// Discord application command option types that hold further options
+// rather than a value.
+const (
+	discordSubcommand      = 1
+	discordSubcommandGroup = 2
+)
+
+// discordInteraction is the part of a Discord interaction the bot reads.
+type discordInteraction struct {
+	Type      int    `json:"type"`
+	ChannelID string `json:"channel_id"`
+	Data      struct {
+		Options []discordOption `json:"options"`
+	} `json:"data"`
+}
+
+// discordOption is an option of an application command: a subcommand
+// with options of its own, or a value such as a category name.
+type discordOption struct {
+	Name    string          `json:"name"`
+	Type    int             `json:"type"`
+	Value   any             `json:"value"`
+	Options []discordOption `json:"options"`
+}
+
+type discordResponse struct {
+	Type int                  `json:"type"`
+	Data *discordResponseData `json:"data,omitempty"`
+}
+
+type discordResponseData struct {
+	Content string `json:"content"`
+}
+
+// DiscordHandler answers Discord slash commands sent to it as the
+// application's interactions endpoint. Requests not signed with the key
+// whose public half is publicKey, or signed more than five minutes ago,
+// are rejected.
+func DiscordHandler(b *Bot, publicKey ed25519.PublicKey) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, "unreadable request", http.StatusBadRequest)
+			return
+		}
+		if !validDiscordSignature(publicKey, r.Header, body, time.Now()) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var interaction discordInteraction
+		if err := json.Unmarshal(body, &interaction); err != nil {
+			http.Error(w, "malformed request", http.StatusBadRequest)
+			return
+		}
+
+		response := discordResponse{Type: discordPong}
+		switch interaction.Type {
+		case discordPing:
+		case discordApplicationCommand:
+			reply := b.Respond(r.Context(), interaction.ChannelID, commandText(interaction.Data.Options))
+			response = discordResponse{Type: discordChannelMessageWithSource, Data: &discordResponseData{Content: reply}}
+		default:
+			http.Error(w, "unsupported interaction", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+}
+
+// commandText turns the options of a command back into the text a user
+// would type: /outfit pick category:casual becomes "pick casual".
+func commandText(options []discordOption) string {
+	var words []string
+	for _, option := range options {
+		switch option.Type {
+		case discordSubcommand, discordSubcommandGroup:
+			words = append(words, option.Name, commandText(option.Options))
+		default:
+			words = append(words, fmt.Sprint(option.Value))
+		}
+	}
+	return strings.TrimSpace(strings.Join(words, " "))
+}
+
+// validDiscordSignature checks a request's Ed25519 signature of its
+// timestamp and body.
+func validDiscordSignature(publicKey ed25519.PublicKey, header http.Header, body []byte, now time.Time) bool {
+	timestamp := header.Get("X-Signature-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(seconds, 0)).Abs() > maxRequestAge {
+		return false
+	}
+	signature, err := hex.DecodeString(header.Get("X-Signature-Ed25519"))
+	if err != nil || len(signature) != ed25519.SignatureSize || len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, append([]byte(timestamp), body...), signature)
+}
+
+// ParseDiscordPublicKey parses the hex public key shown on a Discord
+// application's General Information page.
+func ParseDiscordPublicKey(value string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("a Discord public key is %d hex-encoded bytes", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func discordRequest(t *testing.T, key ed25519.PrivateKey, signedAt time.Time, body string) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	signature := ed25519.Sign(key, []byte(timestamp+body))
+	r := httptest.NewRequest(http.MethodPost, "/discord", strings.NewReader(body))
+	r.Header.Set("X-Signature-Timestamp", timestamp)
+	r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+	return r
+}
+
+func TestDiscordHandler(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, opened := newTestBot(map[string]string{"D1": "work"})
+	handler := DiscordHandler(b, publicKey)
+	tests := []struct {
+		name string
+		body string
+		want discordResponse
+	}{
+		{"ping", `{"type":1}`, discordResponse{Type: discordPong}},
+		{
+			"pick",
+			`{"type":2,"channel_id":"D1","data":{"name":"outfit","options":[{"name":"pick","type":1,"options":[{"name":"category","type":3,"value":"casual"}]}]}}`,
+			discordResponse{Type: discordChannelMessageWithSource, Data: &discordResponseData{Content: "Picked casual/tee.avatar (1 of 2 worn this rotation)"}},
+		},
+		{
+			"progress",
+			`{"type":2,"channel_id":"D1","data":{"name":"outfit","options":[{"name":"progress","type":1}]}}`,
+			discordResponse{Type: discordChannelMessageWithSource, Data: &discordResponseData{Content: "casual: 1 of 2 worn"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, discordRequest(t, privateKey, time.Now(), tt.body))
+			var got discordResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("response = %d %q, %v", w.Code, w.Body, err)
+			}
+			if got.Type != tt.want.Type || (tt.want.Data != nil && (got.Data == nil || *got.Data != *tt.want.Data)) {
+				t.Errorf("response = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if strings.Join(*opened, ",") != "work" {
+		t.Errorf("opened profiles %q, want the profile mapped to D1", *opened)
+	}
+}
+
+func TestDiscordHandler_RejectsBadSignatures(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := newTestBot(nil)
+	w := httptest.NewRecorder()
+	DiscordHandler(b, publicKey).ServeHTTP(w, discordRequest(t, otherKey, time.Now(), `{"type":1}`))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestParseDiscordPublicKey(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ParseDiscordPublicKey(hex.EncodeToString(publicKey) + "\n"); err != nil || !got.Equal(publicKey) {
+		t.Errorf("ParseDiscordPublicKey() = %x, %v", got, err)
+	}
+	if _, err := ParseDiscordPublicKey("abc"); err == nil {
+		t.Error("ParseDiscordPublicKey() of a short key succeeded")
+	}
+}
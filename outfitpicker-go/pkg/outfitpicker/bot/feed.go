@@ -0,0 +1,133 @@
+package bot
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/dh85/outfitpicker/pkg/outfitpicker"
+)
+
+// atomNamespace is the XML namespace of Atom feeds.
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// atomFeed is an Atom feed (RFC 4287) of wardrobe events.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Updated  string       `xml:"updated"`
+	Category atomCategory `xml:"category"`
+	Content  string       `xml:"content"`
+}
+
+// FeedHandler serves an Atom feed of the latest picks and rotation
+// completions of the active profile, newest first, for feed readers and
+// automation to follow. The limit query parameter sets how many events the
+// feed holds, 20 unless given.
+func FeedHandler(b *Bot) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		limit := 0
+		if value := r.URL.Query().Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "limit must be a number", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		wardrobe, err := b.wardrobe("")
+		if err != nil {
+			http.Error(w, "could not open the wardrobe", http.StatusInternalServerError)
+			return
+		}
+		events, err := wardrobe.EventsContext(r.Context(), limit)
+		if err != nil {
+			if outfitpicker.ErrorCode(err) == outfitpicker.CodeInvalidInput {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, "could not read the events", http.StatusInternalServerError)
+			return
+		}
+		self := requestURL(r)
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		fmt.Fprint(w, xml.Header)
+		xml.NewEncoder(w).Encode(newAtomFeed(self, events, time.Now()))
+	})
+}
+
+// newAtomFeed builds the feed at self of events, newest first. An empty
+// feed is dated now, as Atom requires every feed to have an update time.
+func newAtomFeed(self string, events []outfitpicker.Event, now time.Time) atomFeed {
+	feed := atomFeed{
+		XMLNS:   atomNamespace,
+		ID:      self,
+		Title:   "Outfit picks",
+		Updated: now.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: "outfitpicker"},
+		Link:    atomLink{Rel: "self", Href: self},
+		Entries: make([]atomEntry, len(events)),
+	}
+	if len(events) > 0 {
+		feed.Updated = events[0].At.UTC().Format(time.RFC3339)
+	}
+	for i, event := range events {
+		outfit := event.Category + "/" + event.FileName
+		entry := atomEntry{
+			ID:       fmt.Sprintf("urn:outfitpicker:%s:%s:%s", event.Kind, url.PathEscape(outfit), event.At.UTC().Format(time.RFC3339Nano)),
+			Updated:  event.At.UTC().Format(time.RFC3339),
+			Category: atomCategory{Term: event.Kind},
+		}
+		switch event.Kind {
+		case outfitpicker.EventRotationCompleted:
+			entry.Title = fmt.Sprintf("Completed the %s rotation", event.Category)
+			entry.Content = fmt.Sprintf("Wearing %s completed a rotation of %s; its next pick starts a new one.", outfit, event.Category)
+		default:
+			entry.Title = "Picked " + outfit
+			entry.Content = fmt.Sprintf("Picked %s from %s.", event.FileName, event.Category)
+		}
+		feed.Entries[i] = entry
+	}
+	return feed
+}
+
+// requestURL returns the URL the request was made to, as the feed's ID and
+// self link.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/pkg/outfitpicker"
+)
+
+func TestFeedHandler(t *testing.T) {
+	b, _ := newTestBot(nil)
+	b.Respond(context.Background(), "C1", "pick casual")
+	handler := FeedHandler(b)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://localhost:8080/feed.atom", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/atom+xml; charset=utf-8" {
+		t.Fatalf("response = %d %q", w.Code, w.Header())
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("feed: %v\n%s", err, w.Body)
+	}
+	if feed.ID != "http://localhost:8080/feed.atom" || feed.Link.Href != feed.ID || feed.Updated != "2024-06-01T09:00:00Z" {
+		t.Errorf("feed = %+v", feed)
+	}
+	if len(feed.Entries) != 1 || feed.Entries[0].Title != "Picked casual/tee.avatar" || feed.Entries[0].Category.Term != outfitpicker.EventPick {
+		t.Errorf("entries = %+v", feed.Entries)
+	}
+
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/feed.atom", nil),
+		httptest.NewRequest(http.MethodGet, "/feed.atom?limit=many", nil),
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code == http.StatusOK {
+			t.Errorf("%s %s answered %d", r.Method, r.URL, w.Code)
+		}
+	}
+}
+
+func TestNewAtomFeed(t *testing.T) {
+	now := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+	if feed := newAtomFeed("http://localhost/feed.atom", nil, now); feed.Updated != "2024-06-03T12:00:00Z" || len(feed.Entries) != 0 {
+		t.Errorf("empty feed = %+v, want it dated now", feed)
+	}
+
+	feed := newAtomFeed("http://localhost/feed.atom", []outfitpicker.Event{
+		{Kind: outfitpicker.EventRotationCompleted, Category: "casual", FileName: "my tee.avatar", At: now},
+	}, now)
+	entry := feed.Entries[0]
+	if entry.Title != "Completed the casual rotation" || entry.ID != "urn:outfitpicker:rotation-completed:casual%2Fmy%20tee.avatar:2024-06-03T12:00:00Z" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if !strings.Contains(entry.Content, "casual/my tee.avatar") {
+		t.Errorf("content = %q", entry.Content)
+	}
+}
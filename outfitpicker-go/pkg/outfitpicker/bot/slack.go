@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// maxRequestAge is how old a signed request may be before it is rejected
+// as a possible replay.
+const maxRequestAge = 5 * time.Minute
+
+// maxRequestBytes bounds the size of a slash command request.
+const maxRequestBytes = 64 << 10
+
+// slackResponse is the reply to a Slack slash command, posted in the
+// channel for everyone to see.
+type slackResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// SlackHandler answers Slack slash commands sent to it as the command's
+// request URL. Requests not signed with the Slack app's signing secret, or
+// signed more than five minutes ago, are rejected.
+func SlackHandler(b *Bot, signingSecret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, "unreadable request", http.StatusBadRequest)
+			return
+		}
+		if !validSlackSignature(signingSecret, r.Header, body, time.Now()) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			http.Error(w, "malformed request", http.StatusBadRequest)
+			return
+		}
+		reply := b.Respond(r.Context(), form.Get("channel_id"), form.Get("text"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(slackResponse{ResponseType: "in_channel", Text: reply})
+	})
+}
+
+// validSlackSignature checks a request's v0 signature: an HMAC-SHA256 of
+// its timestamp and body keyed with the signing secret.
+func validSlackSignature(secret string, header http.Header, body []byte, now time.Time) bool {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || now.Sub(time.Unix(seconds, 0)).Abs() > maxRequestAge {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	want := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(header.Get("X-Slack-Signature")), []byte(want))
+}
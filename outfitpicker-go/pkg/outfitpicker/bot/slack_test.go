@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+func slackRequest(t *testing.T, secret string, signedAt time.Time, form url.Values) *http.Request {
+	t.Helper()
+	body := form.Encode()
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	r := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	r.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return r
+}
+
+func TestSlackHandler(t *testing.T) {
+	b, _ := newTestBot(nil)
+	handler := SlackHandler(b, testSigningSecret)
+	form := url.Values{"command": {"/outfit"}, "text": {"pick casual"}, "channel_id": {"C1"}}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, slackRequest(t, testSigningSecret, time.Now(), form))
+	var response slackResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("response = %d %q, %v", w.Code, w.Body, err)
+	}
+	if response.ResponseType != "in_channel" || !strings.HasPrefix(response.Text, "Picked casual/tee.avatar") {
+		t.Errorf("response = %+v", response)
+	}
+}
+
+func TestSlackHandler_RejectsBadRequests(t *testing.T) {
+	b, _ := newTestBot(nil)
+	handler := SlackHandler(b, testSigningSecret)
+	form := url.Values{"text": {"pick casual"}}
+	tests := []struct {
+		name    string
+		request *http.Request
+		want    int
+	}{
+		{"wrong secret", slackRequest(t, "other secret", time.Now(), form), http.StatusUnauthorized},
+		{"replayed", slackRequest(t, testSigningSecret, time.Now().Add(-time.Hour), form), http.StatusUnauthorized},
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(form.Encode())), http.StatusUnauthorized},
+		{"get", httptest.NewRequest(http.MethodGet, "/slack", nil), http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.request)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package outfitpicker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/logging"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+)
+
+// Client picks outfits from the wardrobe of one profile.
+type Client struct {
+	// services wires the ports for one call, whose file reads, writes and
+	// lock waits stop once ctx is done.
+	services func(ctx context.Context) usecases.Services
+}
+
+type clientOptions struct {
+	directoryProvider system.DirectoryProvider
+	stateDir          string
+	profile           string
+	now               func() time.Time
+	logger            *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*clientOptions)
+
+// WithConfigDir sets the directory holding the outfitpicker directory of
+// configuration and state files. It defaults to XDG_CONFIG_HOME, or else
+// the user's configuration directory, as for the command.
+func WithConfigDir(dir string) Option {
+	return func(o *clientOptions) {
+		o.directoryProvider = system.NewStaticDirectoryProvider(dir)
+	}
+}
+
+// WithAppDir uses dir itself, rather than an outfitpicker directory in
+// it, for the configuration and state files, as the command's --config
+// does.
+func WithAppDir(dir string) Option {
+	return func(o *clientOptions) {
+		o.directoryProvider = system.NewAppDirectoryProvider(dir)
+	}
+}
+
+// WithStateDir keeps state files, backups and the log in dir instead of
+// next to the configuration. dir is laid out like the outfitpicker
+// directory, as with the command's --state-dir.
+func WithStateDir(dir string) Option {
+	return func(o *clientOptions) {
+		o.stateDir = dir
+	}
+}
+
+// WithProfile uses the named profile, which must exist, instead of the
+// active one.
+func WithProfile(name string) Option {
+	return func(o *clientOptions) {
+		o.profile = name
+	}
+}
+
+// WithClock sets the function returning the current time, which dates
+// picks and wears. It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(o *clientOptions) {
+		o.now = now
+	}
+}
+
+// WithLogger logs what the client does to logger: picks and rotation
+// resets at info level, scans and state file reads and writes at debug
+// level. By default nothing is logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// New creates a Client for the configured profile, the active one unless
+// WithProfile names another. State files are signed when integrity checks
+// are on, as they are by the command.
+func New(opts ...Option) (*Client, error) {
+	options := clientOptions{directoryProvider: system.NewDefaultDirectoryProvider(), logger: logging.Discard()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.logger == nil {
+		options.logger = logging.Discard()
+	}
+	if options.stateDir != "" {
+		options.directoryProvider = system.NewStateDirectoryProvider(options.directoryProvider, options.stateDir)
+	}
+
+	profiles := usecases.NewProfilesUseCase(newServices(context.Background(), options.directoryProvider, entities.DefaultProfile, nil, options.logger))
+	profile := options.profile
+	if profile != "" {
+		if err := profiles.EnsureExists(profile); err != nil {
+			return nil, err
+		}
+	} else {
+		active, err := profiles.Active()
+		if err != nil {
+			return nil, err
+		}
+		profile = active
+	}
+	signer, err := system.NewIntegrityStore(options.directoryProvider, system.NewOSKeychain()).Signer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{services: func(ctx context.Context) usecases.Services {
+		services := newServices(ctx, options.directoryProvider, profile, signer, options.logger)
+		services.Now = options.now
+		return services
+	}}, nil
+}
+
+type pickOptions struct {
+	options []usecases.PickOption
+}
+
+// PickOption narrows the outfits a pick may choose from.
+type PickOption func(*pickOptions)
+
+// WithSeed makes the pick reproducible: the same seed picks the same
+// outfit while the category's unworn outfits stay the same.
+func WithSeed(seed uint64) PickOption {
+	return func(o *pickOptions) {
+		o.options = append(o.options, usecases.WithPickSeed(seed))
+	}
+}
+
+// WithFavoritesOnly picks only among favorite outfits.
+func WithFavoritesOnly() PickOption {
+	return func(o *pickOptions) {
+		o.options = append(o.options, usecases.WithFavoritesOnly())
+	}
+}
+
+// WithTag picks only among outfits with tag.
+func WithTag(tag string) PickOption {
+	return func(o *pickOptions) {
+		o.options = append(o.options, usecases.WithTag(tag))
+	}
+}
+
+// WithSeason picks only from an in-season category and its in-season
+// outfits. The season "auto" uses the season of the current date.
+func WithSeason(season string) PickOption {
+	return func(o *pickOptions) {
+		o.options = append(o.options, usecases.WithSeason(season))
+	}
+}
+
+// Pick picks an outfit of the named category by its rotation policy and
+// records the pick, as the pick command does.
+func (c *Client) Pick(category string, opts ...PickOption) (Outfit, error) {
+	return c.PickContext(context.Background(), category, opts...)
+}
+
+// PickContext is like Pick, giving up with ctx.Err() once ctx is done.
+func (c *Client) PickContext(ctx context.Context, category string, opts ...PickOption) (Outfit, error) {
+	var options pickOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	outfit, err := usecases.NewPickOutfitUseCase(c.services(ctx)).Execute(category, options.options...)
+	if err != nil {
+		return Outfit{}, err
+	}
+	return newOutfit(*outfit), nil
+}
+
+// ListCategories returns every category of the wardrobe in the configured
+// order, including those with nothing to pick from.
+func (c *Client) ListCategories() ([]Category, error) {
+	return c.ListCategoriesContext(context.Background())
+}
+
+// ListCategoriesContext is like ListCategories, giving up with ctx.Err()
+// once ctx is done.
+func (c *Client) ListCategoriesContext(ctx context.Context) ([]Category, error) {
+	infos, err := usecases.NewGetCategoriesUseCase(c.services(ctx)).Execute()
+	if err != nil {
+		return nil, err
+	}
+	categories := make([]Category, len(infos))
+	for i, info := range infos {
+		categories[i] = newCategory(info)
+	}
+	return categories, nil
+}
+
+// Progress returns how far each category with outfits is through its
+// rotation.
+func (c *Client) Progress() ([]Progress, error) {
+	return c.ProgressContext(context.Background())
+}
+
+// ProgressContext is like Progress, giving up with ctx.Err() once ctx is
+// done.
+func (c *Client) ProgressContext(ctx context.Context) ([]Progress, error) {
+	rotations, err := usecases.NewGetCategoriesUseCase(c.services(ctx)).Progress()
+	if err != nil {
+		return nil, err
+	}
+	progress := make([]Progress, len(rotations))
+	for i, rotation := range rotations {
+		progress[i] = newProgress(rotation)
+	}
+	return progress, nil
+}
+
+// Events returns up to limit of the latest picks and rotation completions,
+// newest first. The limit is between 1 and 500; zero means 20.
+func (c *Client) Events(limit int) ([]Event, error) {
+	return c.EventsContext(context.Background(), limit)
+}
+
+// EventsContext is like Events, failing with ctx.Err() if ctx is already
+// done. Reading the events is quick, so it is not given up part way.
+func (c *Client) EventsContext(ctx context.Context, limit int) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	recent, err := usecases.NewHistoryUseCase(c.services(ctx)).Events(limit)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, len(recent))
+	for i, event := range recent {
+		events[i] = newEvent(event)
+	}
+	return events, nil
+}
+
+// Reset starts a new rotation of the named category with every outfit
+// unworn.
+func (c *Client) Reset(category string) error {
+	return c.ResetContext(context.Background(), category)
+}
+
+// ResetContext is like Reset, giving up with ctx.Err() once ctx is done.
+func (c *Client) ResetContext(ctx context.Context, category string) error {
+	return usecases.NewRotationLockUseCase(c.services(ctx)).Reset(category)
+}
@@ -0,0 +1,183 @@
+package outfitpicker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/logging"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/pkg/testhelpers"
+)
+
+// newTestClient sets up a wardrobe and its configuration and returns a
+// client using them.
+func newTestClient(t *testing.T, wardrobe map[string][]string, opts ...Option) *Client {
+	t.Helper()
+	root, configDir := t.TempDir(), t.TempDir()
+	for category, files := range wardrobe {
+		if err := os.MkdirAll(filepath.Join(root, category), 0755); err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			if err := os.WriteFile(filepath.Join(root, category, file), []byte(file), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	service := configuration.NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewStaticDirectoryProvider(configDir)))
+	if err := service.Save(testhelpers.NewConfig(root)); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := New(append([]Option{WithConfigDir(configDir)}, opts...)...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return client
+}
+
+func TestClient(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	client := newTestClient(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}, "empty": nil},
+		WithClock(func() time.Time { return now }))
+
+	categories, err := client.ListCategories()
+	if err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+	states := make(map[string]string)
+	for _, category := range categories {
+		states[category.Name] = category.State
+	}
+	if len(categories) != 2 || states["casual"] != CategoryHasOutfits || states["empty"] != CategoryEmpty {
+		t.Errorf("ListCategories() = %+v", categories)
+	}
+
+	outfit, err := client.Pick("casual", WithSeed(1))
+	if err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if outfit.Category != "casual" || filepath.Base(outfit.Path) != outfit.FileName {
+		t.Errorf("Pick() = %+v", outfit)
+	}
+
+	progress, err := client.Progress()
+	if err != nil || len(progress) != 1 || progress[0] != (Progress{Category: "casual", Worn: 0, Total: 2}) {
+		t.Errorf("Progress() = %+v, %v", progress, err)
+	}
+
+	events, err := client.Events(0)
+	if err != nil || len(events) != 1 || events[0] != (Event{Kind: EventPick, Category: "casual", FileName: outfit.FileName, At: now}) {
+		t.Errorf("Events() = %+v, %v", events, err)
+	}
+
+	if err := client.Reset("casual"); err != nil {
+		t.Errorf("Reset() error = %v", err)
+	}
+	if _, err := client.Pick("formal"); ErrorCode(err) != CodeCategoryNotFound {
+		t.Errorf("Pick() of an unknown category: code = %d, want 21 (%v)", ErrorCode(err), err)
+	}
+}
+
+func TestClient_Context(t *testing.T) {
+	client := newTestClient(t, map[string][]string{"casual": {"tee.avatar"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.PickContext(ctx, "casual"); !errors.Is(err, context.Canceled) {
+		t.Errorf("PickContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := client.ListCategoriesContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListCategoriesContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := client.ProgressContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ProgressContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := client.EventsContext(ctx, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("EventsContext() error = %v, want context.Canceled", err)
+	}
+	if err := client.ResetContext(ctx, "casual"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ResetContext() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClient_ContextStopsAPickWaitingForALock(t *testing.T) {
+	stateDir := t.TempDir()
+	client := newTestClient(t, map[string][]string{"casual": {"tee.avatar"}}, WithStateDir(stateDir))
+	unlock, err := system.NewLockFileLocker().Lock(context.Background(), filepath.Join(stateDir, "history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if _, err := client.PickContext(ctx, "casual"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PickContext() error = %v, want context.DeadlineExceeded", err)
+	}
+	if waited := time.Since(started); waited > time.Second {
+		t.Errorf("PickContext() waited %s for the lock after ctx was done", waited)
+	}
+}
+
+func TestClient_StateDir(t *testing.T) {
+	stateDir := t.TempDir()
+	client := newTestClient(t, map[string][]string{"casual": {"tee.avatar", "jeans.avatar"}}, WithStateDir(stateDir))
+	if _, err := client.Pick("casual", WithSeed(1)); err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, "history.json")); err != nil {
+		t.Errorf("Pick() did not record history in the state directory: %v", err)
+	}
+}
+
+func TestClient_AppDir(t *testing.T) {
+	configDir := t.TempDir()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "casual"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "casual", "tee.avatar"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	service := configuration.NewConfigService(system.WithDirectoryProvider[entities.Config](system.NewAppDirectoryProvider(configDir)))
+	if err := service.Save(testhelpers.NewConfig(root)); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := New(WithAppDir(configDir))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if outfit, err := client.Pick("casual"); err != nil || outfit.FileName != "tee.avatar" {
+		t.Errorf("Pick() = %+v, %v", outfit, err)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "config.json")); err != nil {
+		t.Errorf("config.json is not directly in the app directory: %v", err)
+	}
+}
+
+func TestNew_UnknownProfile(t *testing.T) {
+	_, err := New(WithConfigDir(t.TempDir()), WithProfile("travel"))
+	if err == nil || ErrorCode(err) == CodeUnknown {
+		t.Errorf("New() with an unknown profile error = %v, want a coded error", err)
+	}
+}
+
+func TestNewServices_WiresEveryPort(t *testing.T) {
+	services := reflect.ValueOf(newServices(context.Background(), system.NewStaticDirectoryProvider(t.TempDir()), entities.DefaultProfile, nil, logging.Discard()))
+	for i := range services.NumField() {
+		field := services.Type().Field(i)
+		if field.Type.Kind() == reflect.Interface && services.Field(i).IsNil() {
+			t.Errorf("Services.%s is not wired", field.Name)
+		}
+	}
+}
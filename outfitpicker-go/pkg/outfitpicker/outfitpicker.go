@@ -0,0 +1,98 @@
+// Package outfitpicker lets other Go programs pick outfits from a wardrobe
+// set up with the outfitpicker command. A Client reads and writes the same
+// configuration and state files as the command, so picks made either way
+// share one rotation.
+package outfitpicker
+
+import (
+	"slices"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	domainerrors "github.com/dh85/outfitpicker/internal/domain/errors"
+	v1 "github.com/dh85/outfitpicker/pkg/api/v1"
+)
+
+// Category states, as reported in Category.State.
+const (
+	CategoryHasOutfits    = string(entities.CategoryStateHasOutfits)
+	CategoryEmpty         = string(entities.CategoryStateEmpty)
+	CategoryNoAvatarFiles = string(entities.CategoryStateNoAvatarFiles)
+	CategoryExcluded      = string(entities.CategoryStateUserExcluded)
+)
+
+// Category is a category directory of the wardrobe. Its State is one of
+// the category states.
+type Category = v1.Category
+
+// Outfit is an outfit file in a category.
+type Outfit = v1.Outfit
+
+// Progress is how far a category is through its current rotation.
+type Progress = v1.Progress
+
+// Event kinds, as reported in Event.Kind.
+const (
+	EventPick              = entities.WardrobeEventPick
+	EventRotationCompleted = entities.WardrobeEventRotationCompleted
+)
+
+// Event is a pick, or a wear that completed a category's rotation. Its
+// Kind is one of the event kinds.
+type Event = v1.Event
+
+// Error codes, as returned by ErrorCode. They are the codes the
+// outfitpicker command exits with, and never change meaning.
+const (
+	CodeUnknown                = int(domainerrors.CodeUnknown)
+	CodeConfigurationNotFound  = int(domainerrors.CodeConfigurationNotFound)
+	CodeInvalidConfiguration   = int(domainerrors.CodeInvalidConfiguration)
+	CodeAmbiguousConfiguration = int(domainerrors.CodeAmbiguousConfiguration)
+	CodeNoOutfitsAvailable     = int(domainerrors.CodeNoOutfitsAvailable)
+	CodeCategoryNotFound       = int(domainerrors.CodeCategoryNotFound)
+	CodeEmptyCategories        = int(domainerrors.CodeEmptyCategories)
+	CodeInvalidInput           = int(domainerrors.CodeInvalidInput)
+	CodeMaintenanceMode        = int(domainerrors.CodeMaintenanceMode)
+	CodeConflict               = int(domainerrors.CodeConflict)
+	CodeNothingToUndo          = int(domainerrors.CodeNothingToUndo)
+	CodeRateLimited            = int(domainerrors.CodeRateLimited)
+	CodeFileSystem             = int(domainerrors.CodeFileSystem)
+	CodeCache                  = int(domainerrors.CodeCache)
+	CodeIntegrity              = int(domainerrors.CodeIntegrity)
+)
+
+// ErrorCode returns the code of an error returned by a Client, the same
+// code the outfitpicker command exits with for it: CodeCategoryNotFound for
+// an unknown category, CodeInvalidInput for invalid input and so on, as
+// listed in the README. Errors without a code of their own return
+// CodeUnknown.
+func ErrorCode(err error) int {
+	return int(domainerrors.CodeOf(err))
+}
+
+func newCategory(info entities.CategoryInfo) Category {
+	category := Category{
+		Name:        info.Category.Name,
+		Path:        info.Category.Path,
+		State:       string(info.State),
+		OutfitCount: info.OutfitCount,
+	}
+	if description := info.Description; description != nil {
+		category.Label = description.Label
+		category.Description = description.Description
+		category.Tags = slices.Clone(description.Tags)
+		category.Slot = description.Slot
+	}
+	return category
+}
+
+func newOutfit(outfit entities.OutfitReference) Outfit {
+	return Outfit{FileName: outfit.FileName, Category: outfit.Category.Name, Path: outfit.FilePath()}
+}
+
+func newProgress(progress entities.RotationProgress) Progress {
+	return Progress{Category: progress.Category.Name, Worn: progress.WornCount, Total: progress.TotalOutfitCount}
+}
+
+func newEvent(event entities.WardrobeEvent) Event {
+	return Event{Kind: event.Kind, Category: event.Category, FileName: event.FileName, At: event.At}
+}
@@ -0,0 +1,72 @@
+package outfitpicker
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/dh85/outfitpicker/internal/application/usecases"
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/infrastructure/configuration"
+	"github.com/dh85/outfitpicker/internal/infrastructure/mail"
+	"github.com/dh85/outfitpicker/internal/infrastructure/persistence"
+	"github.com/dh85/outfitpicker/internal/infrastructure/system"
+	"github.com/dh85/outfitpicker/internal/infrastructure/weather"
+)
+
+// newServices wires the production implementations of every port for the
+// named profile, as the command does, so a Client shares its files. Once
+// ctx is done, scans, forecast lookups and state file reads, writes and
+// lock waits stop with ctx.Err().
+func newServices(ctx context.Context, dp system.DirectoryProvider, profile string, signer *system.Signer, logger *slog.Logger) usecases.Services {
+	if profile == entities.DefaultProfile {
+		profile = ""
+	}
+	return usecases.Services{
+		Config:      configuration.NewConfigService(storeOptions[entities.Config](ctx, dp, profile, signer, logger)...),
+		Cache:       persistence.NewCacheService(storeOptions[entities.OutfitCache](ctx, dp, profile, signer, logger)...),
+		Scanner:     system.NewCategoryScanner(system.WithScanLogger(logger)),
+		Maintenance: persistence.NewMaintenanceStore(storeOptions[entities.MaintenanceState](ctx, dp, profile, signer, logger)...),
+		Metadata:    persistence.NewMetadataStore(storeOptions[entities.MetadataIndex](ctx, dp, profile, signer, logger)...),
+		Weights:     persistence.NewWeightStore(storeOptions[entities.OutfitWeights](ctx, dp, profile, signer, logger)...),
+		Favorites:   persistence.NewFavoritesStore(storeOptions[entities.Favorites](ctx, dp, profile, signer, logger)...),
+		Skipped:     persistence.NewSkippedOutfitsStore(storeOptions[entities.SkippedOutfits](ctx, dp, profile, signer, logger)...),
+		Laundry:     persistence.NewLaundryStore(storeOptions[entities.Laundry](ctx, dp, profile, signer, logger)...),
+		Arrivals:    persistence.NewArrivalStore(storeOptions[entities.OutfitArrivals](ctx, dp, profile, signer, logger)...),
+		LastPicked:  persistence.NewCategoryPickStore(storeOptions[entities.CategoryPicks](ctx, dp, profile, signer, logger)...),
+		LatestPicks: persistence.NewLatestPickStore(storeOptions[entities.LatestPicks](ctx, dp, profile, signer, logger)...),
+		Challenge:   persistence.NewChallengeStore(storeOptions[entities.Challenge](ctx, dp, profile, signer, logger)...),
+		Plan:        persistence.NewPlanStore(storeOptions[entities.OutfitPlan](ctx, dp, profile, signer, logger)...),
+		Forecasts:   persistence.NewForecastStore(storeOptions[entities.ForecastCache](ctx, dp, profile, signer, logger)...),
+		Weather:     weather.NewOpenMeteoProvider(),
+		WatchState:  persistence.NewWatchStateStore(storeOptions[entities.WatchState](ctx, dp, profile, signer, logger)...),
+		Commands:    persistence.NewCommandHistoryStore(storeOptions[entities.CommandHistory](ctx, dp, profile, signer, logger)...),
+		WearLog:     persistence.NewWearLogStore(storeOptions[entities.WearLog](ctx, dp, profile, signer, logger)...),
+		History:     persistence.NewHistoryStore(storeOptions[entities.SelectionHistory](ctx, dp, profile, signer, logger)...),
+		Mailer:      mail.NewSMTPMailer(),
+		Scheduler:   system.NewOSScheduler(),
+		Archiver:    system.NewFileArchiver(),
+		Importer:    system.NewArchiveImporter(),
+		Bundles:     system.NewStateBundleArchive(),
+		Creator:     system.NewFileCategoryCreator(),
+		Trash:       system.NewOSTrash(),
+		Backups:     system.NewProfileBackupStore(dp, profile),
+		Profiles:    system.NewProfileStore(dp),
+		Integrity:   system.NewIntegrityStore(dp, system.NewOSKeychain()),
+		Hasher:      system.NewFileHasher(),
+		Context:     ctx,
+		Logger:      logger,
+	}
+}
+
+// storeOptions places a store's file under dp, in the directory of the
+// named profile, signed by signer when it is not nil, logging to logger and
+// stopped by ctx.
+func storeOptions[T any](ctx context.Context, dp system.DirectoryProvider, profile string, signer *system.Signer, logger *slog.Logger) []system.FileServiceOption[T] {
+	return []system.FileServiceOption[T]{
+		system.WithContext[T](ctx),
+		system.WithDirectoryProvider[T](dp),
+		system.WithProfile[T](profile),
+		system.WithSigner[T](signer),
+		system.WithLogger[T](logger),
+	}
+}
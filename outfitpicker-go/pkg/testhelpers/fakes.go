@@ -0,0 +1,798 @@
+package testhelpers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/dh85/outfitpicker/internal/domain/entities"
+	"github.com/dh85/outfitpicker/internal/domain/errors"
+)
+
+// NewConfig returns a configuration rooted at root. Unlike entities.NewConfig
+// it skips path validation, so temporary directories can be used as roots.
+func NewConfig(root string) *entities.Config {
+	return &entities.Config{
+		Roots:              []string{root},
+		Language:           entities.DefaultLanguage,
+		ExcludedCategories: make(map[string]bool),
+		KnownCategories:    make(map[string]bool),
+		KnownCategoryFiles: make(map[string]map[string]bool),
+	}
+}
+
+// FakeConfigService is an in-memory ConfigService.
+type FakeConfigService struct {
+	Config  *entities.Config
+	LoadErr error
+	SaveErr error
+	Saves   int
+	// Conflicts is the number of upcoming saves that fail with a
+	// ConflictError, as if another writer had saved first.
+	Conflicts int
+}
+
+// NewFakeConfigService creates a fake holding config, which may be nil.
+func NewFakeConfigService(config *entities.Config) *FakeConfigService {
+	return &FakeConfigService{Config: config}
+}
+
+func (f *FakeConfigService) Load() (*entities.Config, error) {
+	if f.LoadErr != nil {
+		return nil, f.LoadErr
+	}
+	if f.Config == nil {
+		return nil, errors.ErrConfigurationNotFound
+	}
+	copied := *f.Config
+	return &copied, nil
+}
+
+func (f *FakeConfigService) Save(config *entities.Config) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	if f.Conflicts > 0 {
+		f.Conflicts--
+		return errors.NewConflictError("config.json", config.Revision, config.Revision+1)
+	}
+	config.Revision++
+	copied := *config
+	f.Config = &copied
+	f.Saves++
+	return nil
+}
+
+func (f *FakeConfigService) Delete() error {
+	f.Config = nil
+	return nil
+}
+
+func (f *FakeConfigService) Path() (string, error) {
+	return "/fake/outfitpicker/config.json", nil
+}
+
+// FakeCacheService is an in-memory CacheService.
+type FakeCacheService struct {
+	Cache   entities.OutfitCache
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeCacheService creates a fake holding an empty cache.
+func NewFakeCacheService() *FakeCacheService {
+	return &FakeCacheService{Cache: entities.NewOutfitCache()}
+}
+
+func (f *FakeCacheService) Load() (entities.OutfitCache, error) {
+	if f.LoadErr != nil {
+		return entities.OutfitCache{}, f.LoadErr
+	}
+	return f.Cache, nil
+}
+
+func (f *FakeCacheService) Save(cache entities.OutfitCache) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	f.Cache = cache
+	f.Saves++
+	return nil
+}
+
+func (f *FakeCacheService) UpdateCategory(name string, update func(entities.CategoryCache, bool) (entities.CategoryCache, error)) error {
+	if f.LoadErr != nil {
+		return f.LoadErr
+	}
+	base, exists := f.Cache.Categories[name]
+	updated, err := update(base, exists)
+	if err != nil {
+		return err
+	}
+	updated.Revision = base.Revision + 1
+	return f.Save(f.Cache.Updating(name, updated))
+}
+
+func (f *FakeCacheService) Delete() error {
+	f.Cache = entities.NewOutfitCache()
+	return nil
+}
+
+func (f *FakeCacheService) Path() (string, error) {
+	return "/fake/outfitpicker/cache.json", nil
+}
+
+// FakeMaintenanceStore is an in-memory MaintenanceStore.
+type FakeMaintenanceStore struct {
+	State   entities.MaintenanceState
+	LoadErr error
+	SaveErr error
+}
+
+func (f *FakeMaintenanceStore) Load() (entities.MaintenanceState, error) {
+	if f.LoadErr != nil {
+		return entities.MaintenanceState{}, f.LoadErr
+	}
+	return f.State, nil
+}
+
+func (f *FakeMaintenanceStore) Save(state entities.MaintenanceState) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	f.State = state
+	return nil
+}
+
+// FakeMetadataStore is an in-memory MetadataStore.
+type FakeMetadataStore struct {
+	Index   entities.MetadataIndex
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeMetadataStore creates a fake holding an empty index.
+func NewFakeMetadataStore() *FakeMetadataStore {
+	return &FakeMetadataStore{Index: entities.NewMetadataIndex()}
+}
+
+func (f *FakeMetadataStore) Load() (entities.MetadataIndex, error) {
+	if f.LoadErr != nil {
+		return entities.MetadataIndex{}, f.LoadErr
+	}
+	return f.Index, nil
+}
+
+func (f *FakeMetadataStore) Save(index entities.MetadataIndex) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	index.Revision++
+	f.Index = index
+	f.Saves++
+	return nil
+}
+
+// FakeWeightStore is an in-memory WeightStore.
+type FakeWeightStore struct {
+	Weights entities.OutfitWeights
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeWeightStore creates a fake holding no weights.
+func NewFakeWeightStore() *FakeWeightStore {
+	return &FakeWeightStore{Weights: entities.NewOutfitWeights()}
+}
+
+func (f *FakeWeightStore) Load() (entities.OutfitWeights, error) {
+	if f.LoadErr != nil {
+		return entities.OutfitWeights{}, f.LoadErr
+	}
+	return f.Weights, nil
+}
+
+func (f *FakeWeightStore) Save(weights entities.OutfitWeights) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	weights.Revision++
+	f.Weights = weights
+	f.Saves++
+	return nil
+}
+
+// FakeFavoritesStore is an in-memory FavoritesStore.
+type FakeFavoritesStore struct {
+	Favorites entities.Favorites
+	LoadErr   error
+	SaveErr   error
+	Saves     int
+}
+
+// NewFakeFavoritesStore creates a fake holding no favorites.
+func NewFakeFavoritesStore() *FakeFavoritesStore {
+	return &FakeFavoritesStore{Favorites: entities.NewFavorites()}
+}
+
+func (f *FakeFavoritesStore) Load() (entities.Favorites, error) {
+	if f.LoadErr != nil {
+		return entities.Favorites{}, f.LoadErr
+	}
+	return f.Favorites, nil
+}
+
+func (f *FakeFavoritesStore) Save(favorites entities.Favorites) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	favorites.Revision++
+	f.Favorites = favorites
+	f.Saves++
+	return nil
+}
+
+// FakeSkippedOutfitsStore is an in-memory SkippedOutfitsStore.
+type FakeSkippedOutfitsStore struct {
+	Skipped entities.SkippedOutfits
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeSkippedOutfitsStore creates a fake holding no skipped outfits.
+func NewFakeSkippedOutfitsStore() *FakeSkippedOutfitsStore {
+	return &FakeSkippedOutfitsStore{Skipped: entities.NewSkippedOutfits()}
+}
+
+func (f *FakeSkippedOutfitsStore) Load() (entities.SkippedOutfits, error) {
+	if f.LoadErr != nil {
+		return entities.SkippedOutfits{}, f.LoadErr
+	}
+	return f.Skipped, nil
+}
+
+func (f *FakeSkippedOutfitsStore) Save(skipped entities.SkippedOutfits) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	skipped.Revision++
+	f.Skipped = skipped
+	f.Saves++
+	return nil
+}
+
+// FakeLaundryStore is an in-memory LaundryStore.
+type FakeLaundryStore struct {
+	Laundry entities.Laundry
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeLaundryStore creates a fake with laundry tracking off.
+func NewFakeLaundryStore() *FakeLaundryStore {
+	return &FakeLaundryStore{Laundry: entities.NewLaundry()}
+}
+
+func (f *FakeLaundryStore) Load() (entities.Laundry, error) {
+	if f.LoadErr != nil {
+		return entities.Laundry{}, f.LoadErr
+	}
+	return f.Laundry, nil
+}
+
+func (f *FakeLaundryStore) Save(laundry entities.Laundry) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	laundry.Revision++
+	f.Laundry = laundry
+	f.Saves++
+	return nil
+}
+
+// FakeArrivalStore is an in-memory ArrivalStore.
+type FakeArrivalStore struct {
+	Arrivals entities.OutfitArrivals
+	LoadErr  error
+	SaveErr  error
+	Saves    int
+}
+
+// NewFakeArrivalStore creates a fake with no arrivals recorded.
+func NewFakeArrivalStore() *FakeArrivalStore {
+	return &FakeArrivalStore{Arrivals: entities.NewOutfitArrivals()}
+}
+
+func (f *FakeArrivalStore) Load() (entities.OutfitArrivals, error) {
+	if f.LoadErr != nil {
+		return entities.OutfitArrivals{}, f.LoadErr
+	}
+	return f.Arrivals, nil
+}
+
+func (f *FakeArrivalStore) Save(arrivals entities.OutfitArrivals) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	arrivals.Revision++
+	f.Arrivals = arrivals
+	f.Saves++
+	return nil
+}
+
+// FakeCategoryPickStore is an in-memory CategoryPickStore.
+type FakeCategoryPickStore struct {
+	Picks   entities.CategoryPicks
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeCategoryPickStore creates a fake with no picks recorded.
+func NewFakeCategoryPickStore() *FakeCategoryPickStore {
+	return &FakeCategoryPickStore{Picks: entities.NewCategoryPicks()}
+}
+
+func (f *FakeCategoryPickStore) Load() (entities.CategoryPicks, error) {
+	if f.LoadErr != nil {
+		return entities.CategoryPicks{}, f.LoadErr
+	}
+	return f.Picks, nil
+}
+
+func (f *FakeCategoryPickStore) Save(picks entities.CategoryPicks) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	picks.Revision++
+	f.Picks = picks
+	f.Saves++
+	return nil
+}
+
+// FakeLatestPickStore is an in-memory LatestPickStore.
+type FakeLatestPickStore struct {
+	Picks   entities.LatestPicks
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+// NewFakeLatestPickStore creates a fake with no picks recorded.
+func NewFakeLatestPickStore() *FakeLatestPickStore {
+	return &FakeLatestPickStore{Picks: entities.NewLatestPicks()}
+}
+
+func (f *FakeLatestPickStore) Load() (entities.LatestPicks, error) {
+	if f.LoadErr != nil {
+		return entities.LatestPicks{}, f.LoadErr
+	}
+	return f.Picks, nil
+}
+
+func (f *FakeLatestPickStore) Save(picks entities.LatestPicks) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	picks.Revision++
+	f.Picks = picks
+	f.Saves++
+	return nil
+}
+
+// FakeChallengeStore is an in-memory ChallengeStore.
+type FakeChallengeStore struct {
+	Challenge entities.Challenge
+	LoadErr   error
+	SaveErr   error
+	Saves     int
+}
+
+func (f *FakeChallengeStore) Load() (entities.Challenge, error) {
+	if f.LoadErr != nil {
+		return entities.Challenge{}, f.LoadErr
+	}
+	return f.Challenge, nil
+}
+
+func (f *FakeChallengeStore) Save(challenge entities.Challenge) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	challenge.Revision++
+	f.Challenge = challenge
+	f.Saves++
+	return nil
+}
+
+// FakePlanStore is an in-memory PlanStore.
+type FakePlanStore struct {
+	Plan    entities.OutfitPlan
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakePlanStore) Load() (entities.OutfitPlan, error) {
+	if f.LoadErr != nil {
+		return entities.OutfitPlan{}, f.LoadErr
+	}
+	return f.Plan, nil
+}
+
+func (f *FakePlanStore) Save(plan entities.OutfitPlan) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	plan.Revision++
+	f.Plan = plan
+	f.Saves++
+	return nil
+}
+
+// FakeForecastStore is an in-memory ForecastStore.
+type FakeForecastStore struct {
+	Cache   entities.ForecastCache
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakeForecastStore) Load() (entities.ForecastCache, error) {
+	if f.LoadErr != nil {
+		return entities.ForecastCache{}, f.LoadErr
+	}
+	return f.Cache, nil
+}
+
+func (f *FakeForecastStore) Save(cache entities.ForecastCache) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	cache.Revision++
+	f.Cache = cache
+	f.Saves++
+	return nil
+}
+
+// FakeWatchStateStore is an in-memory WatchStateStore.
+type FakeWatchStateStore struct {
+	State   entities.WatchState
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakeWatchStateStore) Load() (entities.WatchState, error) {
+	if f.LoadErr != nil {
+		return entities.WatchState{}, f.LoadErr
+	}
+	return f.State, nil
+}
+
+func (f *FakeWatchStateStore) Save(state entities.WatchState) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	state.Revision++
+	f.State = state
+	f.Saves++
+	return nil
+}
+
+// FakeCommandHistoryStore is an in-memory CommandHistoryStore.
+type FakeCommandHistoryStore struct {
+	History entities.CommandHistory
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakeCommandHistoryStore) Load() (entities.CommandHistory, error) {
+	if f.LoadErr != nil {
+		return entities.CommandHistory{}, f.LoadErr
+	}
+	return f.History, nil
+}
+
+func (f *FakeCommandHistoryStore) Save(history entities.CommandHistory) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	history.Revision++
+	f.History = history
+	f.Saves++
+	return nil
+}
+
+// FakeWeatherProvider is a WeatherProvider that returns Result for every
+// request, dated and located as asked, and counts the requests.
+type FakeWeatherProvider struct {
+	Result entities.Forecast
+	Err    error
+	Calls  int
+}
+
+func (f *FakeWeatherProvider) Forecast(_ context.Context, location entities.WeatherLocation, date time.Time) (entities.Forecast, error) {
+	f.Calls++
+	if f.Err != nil {
+		return entities.Forecast{}, f.Err
+	}
+	forecast := f.Result
+	forecast.Location = location
+	forecast.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return forecast, nil
+}
+
+// FakeWearLogStore is an in-memory WearLogStore.
+type FakeWearLogStore struct {
+	Log     entities.WearLog
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakeWearLogStore) Load() (entities.WearLog, error) {
+	if f.LoadErr != nil {
+		return entities.WearLog{}, f.LoadErr
+	}
+	return f.Log, nil
+}
+
+func (f *FakeWearLogStore) Query(query entities.HistoryQuery) (entities.Page[entities.WearEvent], error) {
+	if f.LoadErr != nil {
+		return entities.Page[entities.WearEvent]{}, f.LoadErr
+	}
+	return f.Log.Query(query), nil
+}
+
+func (f *FakeWearLogStore) Save(log entities.WearLog) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	log.Revision++
+	f.Log = log
+	f.Saves++
+	return nil
+}
+
+// FakeHistoryStore is an in-memory SelectionHistoryStore.
+type FakeHistoryStore struct {
+	History entities.SelectionHistory
+	LoadErr error
+	SaveErr error
+	Saves   int
+}
+
+func (f *FakeHistoryStore) Load() (entities.SelectionHistory, error) {
+	if f.LoadErr != nil {
+		return entities.SelectionHistory{}, f.LoadErr
+	}
+	return f.History, nil
+}
+
+func (f *FakeHistoryStore) Query(query entities.HistoryQuery) (entities.Page[entities.SelectionRecord], error) {
+	if f.LoadErr != nil {
+		return entities.Page[entities.SelectionRecord]{}, f.LoadErr
+	}
+	return f.History.Query(query), nil
+}
+
+func (f *FakeHistoryStore) Save(history entities.SelectionHistory) error {
+	if f.SaveErr != nil {
+		return f.SaveErr
+	}
+	history.Revision++
+	f.History = history
+	f.Saves++
+	return nil
+}
+
+// FakeScheduler is an in-memory Scheduler.
+type FakeScheduler struct {
+	Pick *entities.ScheduledPick
+	Err  error
+}
+
+func (f *FakeScheduler) Install(pick entities.ScheduledPick) error {
+	if f.Err != nil {
+		return f.Err
+	}
+	f.Pick = &pick
+	return nil
+}
+
+func (f *FakeScheduler) Remove() (bool, error) {
+	if f.Err != nil {
+		return false, f.Err
+	}
+	removed := f.Pick != nil
+	f.Pick = nil
+	return removed, nil
+}
+
+func (f *FakeScheduler) Installed() (*entities.ScheduledPick, error) {
+	return f.Pick, f.Err
+}
+
+// FakeTrash is a Trash that records the paths it is asked to trash and
+// leaves them on disk.
+type FakeTrash struct {
+	Trashed []string
+	Err     error
+}
+
+func (f *FakeTrash) Trash(path string) (string, error) {
+	if f.Err != nil {
+		return "", f.Err
+	}
+	f.Trashed = append(f.Trashed, path)
+	return filepath.Join("trash", filepath.Base(path)), nil
+}
+
+// FakeMailer is a Mailer that records the messages it is asked to send.
+type FakeMailer struct {
+	Server  entities.SMTPSettings
+	Sent    []entities.MailMessage
+	SendErr error
+}
+
+func (f *FakeMailer) Send(server entities.SMTPSettings, message entities.MailMessage) error {
+	if f.SendErr != nil {
+		return f.SendErr
+	}
+	f.Server = server
+	f.Sent = append(f.Sent, message)
+	return nil
+}
+
+// FakeChatService is a ChatService for one chat. Each message sent is
+// answered with the next of Replies, as if the user typed it; Sent records
+// the messages sent and Received holds the messages the bot has been sent.
+type FakeChatService struct {
+	Replies  []string
+	Sent     []string
+	Received []entities.ChatMessage
+	SendErr  error
+}
+
+func (f *FakeChatService) Send(_ context.Context, _, chatID, text string) error {
+	if f.SendErr != nil {
+		return f.SendErr
+	}
+	f.Sent = append(f.Sent, text)
+	if len(f.Replies) > 0 {
+		f.Received = append(f.Received, entities.ChatMessage{UpdateID: int64(len(f.Received) + 1), ChatID: chatID, Text: f.Replies[0]})
+		f.Replies = f.Replies[1:]
+	}
+	return nil
+}
+
+func (f *FakeChatService) Messages(_ context.Context, _ string, offset int64) ([]entities.ChatMessage, error) {
+	var messages []entities.ChatMessage
+	for _, message := range f.Received {
+		if message.UpdateID >= offset {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// FakeBackupStore is an in-memory BackupStore. Backups hold the base names
+// of the paths they were created from, and Restored records restored IDs.
+type FakeBackupStore struct {
+	Backups   []entities.Backup
+	Restored  []string
+	CreateErr error
+}
+
+func (f *FakeBackupStore) Create(at time.Time, paths []string) (entities.Backup, error) {
+	if f.CreateErr != nil {
+		return entities.Backup{}, f.CreateErr
+	}
+	backup := entities.Backup{ID: fmt.Sprintf("%s-%d", at.UTC().Format("20060102-150405"), len(f.Backups)+1), CreatedAt: at}
+	for _, path := range paths {
+		backup.Files = append(backup.Files, filepath.Base(path))
+	}
+	f.Backups = append(f.Backups, backup)
+	return backup, nil
+}
+
+func (f *FakeBackupStore) List() ([]entities.Backup, error) {
+	return slices.Clone(f.Backups), nil
+}
+
+func (f *FakeBackupStore) Restore(id string, paths []string) (entities.Backup, error) {
+	for _, backup := range f.Backups {
+		if backup.ID == id {
+			f.Restored = append(f.Restored, id)
+			return backup, nil
+		}
+	}
+	return entities.Backup{}, errors.NewInvalidInputError(fmt.Sprintf("no backup %q", id))
+}
+
+func (f *FakeBackupStore) Delete(id string) error {
+	for i, backup := range f.Backups {
+		if backup.ID == id {
+			f.Backups = slices.Delete(f.Backups, i, i+1)
+			return nil
+		}
+	}
+	return errors.NewInvalidInputError(fmt.Sprintf("no backup %q", id))
+}
+
+// FakeProfileStore is an in-memory ProfileStore. Names lists the profiles
+// that exist.
+type FakeProfileStore struct {
+	Names     []string
+	Selection entities.ProfileSelection
+	DeleteErr error
+}
+
+func (f *FakeProfileStore) List() ([]string, error) {
+	names := slices.Clone(f.Names)
+	slices.Sort(names)
+	return names, nil
+}
+
+func (f *FakeProfileStore) Exists(name string) (bool, error) {
+	return slices.Contains(f.Names, name), nil
+}
+
+func (f *FakeProfileStore) Delete(name string) error {
+	if f.DeleteErr != nil {
+		return f.DeleteErr
+	}
+	f.Names = slices.DeleteFunc(f.Names, func(n string) bool { return n == name })
+	return nil
+}
+
+func (f *FakeProfileStore) LoadSelection() (entities.ProfileSelection, error) {
+	return f.Selection, nil
+}
+
+func (f *FakeProfileStore) SaveSelection(selection entities.ProfileSelection) error {
+	f.Selection = selection
+	return nil
+}
+
+// FakeIntegrityStore is an in-memory IntegrityStore. Failed lists the state
+// files that fail verification until they are signed again.
+type FakeIntegrityStore struct {
+	On     bool
+	Failed []string
+	Signs  int
+}
+
+func (f *FakeIntegrityStore) Enabled() (bool, error) {
+	return f.On, nil
+}
+
+func (f *FakeIntegrityStore) Enable() error {
+	f.On = true
+	return f.Sign()
+}
+
+func (f *FakeIntegrityStore) Disable() error {
+	f.On = false
+	f.Failed = nil
+	return nil
+}
+
+func (f *FakeIntegrityStore) Verify() ([]string, error) {
+	return slices.Clone(f.Failed), nil
+}
+
+func (f *FakeIntegrityStore) Sign() error {
+	f.Signs++
+	f.Failed = nil
+	return nil
+}
@@ -0,0 +1,38 @@
+package testhelpers
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/domain/validation"
+)
+
+// unrestrictedTempBases are tried in order, after OUTFITPICKER_TEST_TMPDIR,
+// when a test needs a directory that passes path validation; the usual
+// temporary directories are restricted.
+var unrestrictedTempBases = []string{"/dev/shm"}
+
+// UnrestrictedTempDir creates a temporary directory accepted by
+// validation.ValidatePath, removed when the test ends. The test is skipped if
+// no such directory can be created.
+func UnrestrictedTempDir(tb testing.TB) string {
+	tb.Helper()
+	bases := unrestrictedTempBases
+	if dir := os.Getenv("OUTFITPICKER_TEST_TMPDIR"); dir != "" {
+		bases = append([]string{dir}, bases...)
+	}
+	for _, base := range bases {
+		dir, err := os.MkdirTemp(base, "outfitpicker-test-")
+		if err != nil {
+			continue
+		}
+		if validation.ValidatePath(dir) != nil {
+			os.RemoveAll(dir)
+			continue
+		}
+		tb.Cleanup(func() { os.RemoveAll(dir) })
+		return dir
+	}
+	tb.Skip("no temporary directory outside the restricted paths is available")
+	return ""
+}
@@ -0,0 +1,21 @@
+// Package testhelpers provides shared utilities for integration tests and
+// benchmarks: fakes of the ports, temporary directories and synthetic
+// wardrobe trees.
+package testhelpers
+
+import (
+	"testing"
+
+	"github.com/dh85/outfitpicker/internal/devtools"
+)
+
+// MustGenerateWardrobe generates a wardrobe in a fresh temporary directory and
+// fails the test or benchmark on error.
+func MustGenerateWardrobe(tb testing.TB, spec devtools.WardrobeSpec) *devtools.Wardrobe {
+	tb.Helper()
+	wardrobe, err := devtools.GenerateWardrobe(tb.TempDir(), spec)
+	if err != nil {
+		tb.Fatalf("failed to generate wardrobe: %v", err)
+	}
+	return wardrobe
+}